@@ -1,15 +1,32 @@
 package main
 
 import (
+	"boonkosang/internal/adapters/archive"
+	"boonkosang/internal/adapters/etax"
+	"boonkosang/internal/adapters/exchangerate"
+	"boonkosang/internal/adapters/files"
+	"boonkosang/internal/adapters/google"
+	"boonkosang/internal/adapters/ldap"
+	"boonkosang/internal/adapters/line"
+	"boonkosang/internal/adapters/mail"
 	"boonkosang/internal/adapters/postgres"
 	"boonkosang/internal/adapters/rest"
+	"boonkosang/internal/adapters/warehouse"
+	"boonkosang/internal/adapters/weather"
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/infrastructure/database"
+	"boonkosang/internal/infrastructure/scheduler"
 	"boonkosang/internal/infrastructure/server"
+	"boonkosang/internal/infrastructure/watermark"
+	"boonkosang/internal/repositories"
 	"boonkosang/internal/usecase"
+	"context"
 	"fmt"
+	"image"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -48,66 +65,638 @@ func main() {
 
 	userRepo := postgres.NewUserRepository(db)
 	jwtSecret := getEnv("JWT_SECRET", "your_default_secret")
+	// To rotate, move the old secret to JWT_PREVIOUS_SECRETS; sessions it
+	// signed keep working until they expire.
+	jwtKeys := models.NewJWTKeySet(jwtSecret, getEnvAsList("JWT_PREVIOUS_SECRETS"))
 	jwtExpiration := getEnvAsDuration("JWT_EXPIRATION", 15*time.Minute)
-	userUseCase := usecase.NewUserUsecase(userRepo, jwtSecret, jwtExpiration)
+	// Google sign-in is only offered when a client ID is configured.
+	var googleVerifier repositories.IdentityVerifier
+	if clientID := getEnv("GOOGLE_CLIENT_ID", ""); clientID != "" {
+		googleVerifier = google.NewIDTokenVerifier(clientID)
+	}
+	// With LDAP_URL set passwords are checked against the directory, apart
+	// from the LDAP_LOCAL_USERS kept for when it is down.
+	var directory repositories.PasswordAuthenticator
+	if ldapURL := getEnv("LDAP_URL", ""); ldapURL != "" {
+		directory, err = ldap.NewBindAuthenticator(ldapURL, getEnv("LDAP_BIND_DN", ""), getEnvAsDuration("LDAP_TIMEOUT", 10*time.Second))
+		if err != nil {
+			log.Fatalf("Failed to configure LDAP: %v", err)
+		}
+	}
+	defaultPolicy := models.DefaultPasswordPolicy()
+	passwordPolicy := models.PasswordPolicy{
+		MinLength:       getEnvAsInt("PASSWORD_MIN_LENGTH", defaultPolicy.MinLength),
+		RequireLower:    getEnvAsBool("PASSWORD_REQUIRE_LOWER", defaultPolicy.RequireLower),
+		RequireUpper:    getEnvAsBool("PASSWORD_REQUIRE_UPPER", defaultPolicy.RequireUpper),
+		RequireDigit:    getEnvAsBool("PASSWORD_REQUIRE_DIGIT", defaultPolicy.RequireDigit),
+		RequireSymbol:   getEnvAsBool("PASSWORD_REQUIRE_SYMBOL", defaultPolicy.RequireSymbol),
+		BannedPasswords: getEnvAsList("PASSWORD_BANNED"),
+	}
+	// Uploads such as avatars need FILE_STORE; without it they are
+	// refused.
+	var fileStore repositories.FileStore
+	switch getEnv("FILE_STORE", "") {
+	case "dir":
+		dir := getEnv("FILE_STORE_DIR", "./uploads")
+		fileStore = files.NewDirStore(dir, getEnv("FILE_PUBLIC_URL", "/uploads"))
+		app.Static("/uploads", dir)
+	case "s3":
+		fileStore = files.NewS3Store(
+			getEnv("FILE_S3_ENDPOINT", "https://s3.ap-southeast-1.amazonaws.com"),
+			getEnv("FILE_S3_BUCKET", ""),
+			getEnv("FILE_S3_REGION", "ap-southeast-1"),
+			getEnv("FILE_S3_ACCESS_KEY", ""),
+			getEnv("FILE_S3_SECRET_KEY", ""),
+			getEnv("FILE_PUBLIC_URL", ""),
+		)
+	}
+	activityRepo := postgres.NewActivityRepository(db)
+	userUseCase := usecase.NewUserUsecase(userRepo, jwtKeys, jwtExpiration, getEnvAsDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour), getEnvAsDuration("IMPERSONATION_TTL", 30*time.Minute),
+		getEnvAsInt("LOGIN_MAX_ATTEMPTS", 5), getEnvAsDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		googleVerifier, getEnvAsList("GOOGLE_ALLOWED_DOMAINS"), directory, getEnvAsList("LDAP_LOCAL_USERS"), fileStore, passwordPolicy, activityRepo)
+
+	// The read-only switch has to wrap every route, so it goes first.
+	maintenanceUseCase := usecase.NewMaintenanceUsecase(userRepo, getEnvAsBool("MAINTENANCE_MODE", false), getEnv("MAINTENANCE_MESSAGE", ""))
+	MaintenanceHandler := rest.NewMaintenanceHandler(maintenanceUseCase, userUseCase)
+	app.Use(MaintenanceHandler.ReadOnly())
+	app.Use(rest.NormalizeNumbers())
+	// ?fields= has to see every handler's response, so it goes before the
+	// routes too.
+	app.Use(rest.SelectFields("/projects", "/quotations", "/materials"))
+	MaintenanceHandler.MaintenanceRoutes(app)
+
+	apiUsageRepo := postgres.NewAPIUsageRepository(db)
+	apiUsageUseCase := usecase.NewAPIUsageUsecase(apiUsageRepo, userRepo)
+	APIUsageHandler := rest.NewAPIUsageHandler(apiUsageUseCase, userUseCase)
+	app.Use(APIUsageHandler.Track())
+	APIUsageHandler.APIUsageRoutes(app)
+	scheduler.Every(context.Background(), "api-usage-flush", getEnvAsDuration("API_USAGE_FLUSH_INTERVAL", time.Minute), apiUsageUseCase.Flush)
+
 	UserHandler := rest.NewUserHandler(userUseCase)
 	UserHandler.UserRoutes(app)
 
+	// Invitations, email changes and notifications are emailed when
+	// SMTP_HOST is set. Without it invitation links are returned to the
+	// inviting admin and emails can't be changed.
+	var mailer repositories.Mailer
+	if host := getEnv("SMTP_HOST", ""); host != "" {
+		mailer = mail.NewSMTPMailer(host, getEnvAsInt("SMTP_PORT", 587),
+			getEnv("SMTP_USERNAME", ""), getEnv("SMTP_PASSWORD", ""), getEnv("SMTP_FROM", "no-reply@boonkosang.local"))
+	}
+	invitationRepo := postgres.NewInvitationRepository(db)
+	invitationUseCase := usecase.NewInvitationUsecase(invitationRepo, userRepo, mailer, passwordPolicy,
+		getEnv("INVITE_LINK_SECRET", jwtSecret),
+		getEnv("INVITE_LINK_BASE_URL", "http://localhost:3000/accept-invite"),
+		getEnvAsDuration("INVITE_LINK_TTL", 7*24*time.Hour),
+	)
+	InvitationHandler := rest.NewInvitationHandler(invitationUseCase, userUseCase)
+	InvitationHandler.InvitationRoutes(app)
+
+	emailChangeUseCase := usecase.NewEmailChangeUsecase(userRepo, mailer,
+		getEnv("EMAIL_VERIFY_LINK_SECRET", jwtSecret),
+		getEnv("EMAIL_VERIFY_LINK_BASE_URL", "http://localhost:3000/verify-email"),
+		getEnvAsDuration("EMAIL_VERIFY_LINK_TTL", 24*time.Hour),
+	)
+	EmailChangeHandler := rest.NewEmailChangeHandler(emailChangeUseCase, userUseCase)
+	EmailChangeHandler.EmailChangeRoutes(app)
+
+	periodRepo := postgres.NewAccountingPeriodRepository(db)
+	periodUseCase := usecase.NewAccountingPeriodUsecase(periodRepo, userRepo)
+	AccountingPeriodHandler := rest.NewAccountingPeriodHandler(periodUseCase, userUseCase)
+	AccountingPeriodHandler.AccountingPeriodRoutes(app)
+
+	permissionRepo := postgres.NewPermissionRepository(db)
+	permissionUseCase := usecase.NewPermissionUsecase(permissionRepo, userRepo, getEnvAsDuration("PERMISSION_CACHE_TTL", time.Minute))
+	PermissionHandler := rest.NewPermissionHandler(permissionUseCase, userUseCase)
+	PermissionHandler.PermissionRoutes(app)
+	permissionGuard := rest.NewPermissionGuard(userUseCase, permissionUseCase)
+
+	// Route policies decide which permission the project, BOQ, quotation,
+	// material and supplier routes need, so the middleware has to be mounted
+	// before them. Other instances pick up edits on the next reload.
+	routePolicyRepo := postgres.NewRoutePolicyRepository(db)
+	routePolicyUseCase := usecase.NewRoutePolicyUsecase(routePolicyRepo, userRepo)
+	if err := routePolicyUseCase.Load(context.Background()); err != nil {
+		log.Fatalf("Failed to load route policies: %v", err)
+	}
+	RoutePolicyHandler := rest.NewRoutePolicyHandler(routePolicyUseCase, userUseCase)
+	RoutePolicyHandler.RoutePolicyRoutes(app)
+	app.Use(rest.RoutePolicies(userUseCase, permissionUseCase, routePolicyUseCase))
+	scheduler.Every(context.Background(), "route-policy-reload", getEnvAsDuration("ROUTE_POLICY_RELOAD_INTERVAL", time.Minute), routePolicyUseCase.Load)
+
 	clientRepo := postgres.NewClientRepository(db)
-	clientUseCase := usecase.NewClientUsecase(clientRepo)
-	ClientHandler := rest.NewClientHandler(clientUseCase)
+	clientUseCase := usecase.NewClientUsecase(clientRepo, activityRepo, getEnvAsInt("CLIENT_CREDIT_HOLD_OVERDUE_DAYS", 90))
+	ClientHandler := rest.NewClientHandler(clientUseCase, permissionGuard)
 	ClientHandler.ClientRoutes(app)
 
+	// Client documents such as ID card copies are kept apart from public
+	// uploads and need CLIENT_DOCUMENT_STORE; without it uploads are
+	// refused.
+	var documentStore repositories.DocumentStore
+	switch getEnv("CLIENT_DOCUMENT_STORE", "") {
+	case "dir":
+		documentStore = files.NewDocumentDirStore(getEnv("CLIENT_DOCUMENT_DIR", "./client-documents"))
+	case "s3":
+		documentStore = files.NewDocumentS3Store(
+			getEnv("CLIENT_DOCUMENT_S3_ENDPOINT", "https://s3.ap-southeast-1.amazonaws.com"),
+			getEnv("CLIENT_DOCUMENT_S3_BUCKET", ""),
+			getEnv("CLIENT_DOCUMENT_S3_REGION", "ap-southeast-1"),
+			getEnv("CLIENT_DOCUMENT_S3_ACCESS_KEY", ""),
+			getEnv("CLIENT_DOCUMENT_S3_SECRET_KEY", ""),
+		)
+	}
+	clientDocumentUseCase := usecase.NewClientDocumentUsecase(
+		clientRepo,
+		activityRepo,
+		documentStore,
+		getEnv("CLIENT_DOCUMENT_LINK_SECRET", jwtSecret),
+		getEnv("CLIENT_DOCUMENT_LINK_BASE_URL", "http://localhost:8004/client-documents"),
+		getEnvAsDuration("CLIENT_DOCUMENT_LINK_TTL", 15*time.Minute),
+	)
+	ClientDocumentHandler := rest.NewClientDocumentHandler(clientDocumentUseCase, permissionGuard)
+	ClientDocumentHandler.ClientDocumentRoutes(app)
+
 	supplierRepo := postgres.NewSupplierRepository(db)
 	supplierUseCase := usecase.NewSupplierUsecase(supplierRepo)
 	SupplierHandler := rest.NewSupplierHandler(supplierUseCase)
 	SupplierHandler.SupplierRoutes(app)
 
+	riskRepo := postgres.NewRiskRepository(db)
+
 	projectRepo := postgres.NewProjectRepository(db)
-	projectUseCase := usecase.NewProjectUsecase(projectRepo, clientRepo)
+	estimationRepo := postgres.NewEstimationRepository(db)
+	projectMemberRepo := postgres.NewProjectMemberRepository(db)
+	projectUseCase := usecase.NewProjectUsecase(projectRepo, clientRepo, activityRepo, riskRepo, estimationRepo, userRepo, projectMemberRepo)
 	ProjectHandler := rest.NewProjectHandler(projectUseCase)
 	ProjectHandler.ProjectRoutes(app)
 
+	projectMemberUseCase := usecase.NewProjectMemberUsecase(projectMemberRepo, projectRepo, userRepo)
+	ProjectMemberHandler := rest.NewProjectMemberHandler(projectMemberUseCase)
+	ProjectMemberHandler.ProjectMemberRoutes(app)
+
+	// LINE notifications need LINE_CHANNEL_ACCESS_TOKEN from a LINE
+	// Official Account; email ones need SMTP_HOST.
+	var lineMessenger repositories.LineMessenger
+	if token := getEnv("LINE_CHANNEL_ACCESS_TOKEN", ""); token != "" {
+		lineMessenger = line.NewMessagingAPI(token)
+	}
+	notificationRepo := postgres.NewNotificationRepository(db)
+	notificationUseCase := usecase.NewNotificationUsecase(notificationRepo, projectRepo, userRepo, projectMemberRepo,
+		mailer, lineMessenger, getEnvAsInt("INVOICE_PAYMENT_TERMS_DAYS", 30))
+	NotificationHandler := rest.NewNotificationHandler(notificationUseCase, userUseCase)
+	NotificationHandler.NotificationRoutes(app)
+
+	supplierPayableRepo := postgres.NewSupplierPayableRepository(db)
+	supplierPayableUseCase := usecase.NewSupplierPayableUsecase(supplierPayableRepo, supplierRepo, projectRepo)
+	SupplierPayableHandler := rest.NewSupplierPayableHandler(supplierPayableUseCase, permissionGuard)
+	SupplierPayableHandler.SupplierPayableRoutes(app)
+
+	riskUseCase := usecase.NewRiskUsecase(riskRepo, projectRepo)
+	RiskHandler := rest.NewRiskHandler(riskUseCase, permissionGuard)
+	RiskHandler.RiskRoutes(app)
+
+	// Draft BOQs are re-priced shortly after a material is bought at a new
+	// price rather than when someone next opens them.
+	boqPriceUpdateRepo := postgres.NewBOQPriceUpdateRepository(db)
+	boqPriceUpdateUseCase := usecase.NewBOQPriceUpdateUsecase(boqPriceUpdateRepo)
+	BOQPriceUpdateHandler := rest.NewBOQPriceUpdateHandler(boqPriceUpdateUseCase, permissionGuard)
+	BOQPriceUpdateHandler.BOQPriceUpdateRoutes(app)
+	scheduler.Every(context.Background(), "boq-price-recalc", getEnvAsDuration("BOQ_PRICE_RECALC_INTERVAL", 30*time.Second), boqPriceUpdateUseCase.Recalculate)
+
 	materialRepo := postgres.NewMaterialRepository(db)
-	materialUseCase := usecase.NewMaterialUsecase(materialRepo, supplierRepo)
+	materialUseCase := usecase.NewMaterialUsecase(materialRepo, supplierRepo, boqPriceUpdateUseCase, userRepo, projectMemberRepo)
 	MaterialHandler := rest.NewMaterialHandler(materialUseCase)
 	MaterialHandler.MaterialRoutes(app)
 
+	listPriceRepo := postgres.NewMaterialListPriceRepository(db)
+	listPriceUseCase := usecase.NewMaterialListPriceUsecase(listPriceRepo, materialRepo)
+	MaterialListPriceHandler := rest.NewMaterialListPriceHandler(listPriceUseCase, permissionGuard)
+	MaterialListPriceHandler.MaterialListPriceRoutes(app)
+
+	materialAliasRepo := postgres.NewMaterialAliasRepository(db)
+	materialAliasUseCase := usecase.NewMaterialAliasUsecase(materialAliasRepo, materialRepo, supplierRepo)
+	MaterialAliasHandler := rest.NewMaterialAliasHandler(materialAliasUseCase, permissionGuard)
+	MaterialAliasHandler.MaterialAliasRoutes(app)
+
+	purchaseOrderRepo := postgres.NewPurchaseOrderRepository(db)
+	blanketAgreementRepo := postgres.NewBlanketAgreementRepository(db)
+	purchaseOrderUseCase := usecase.NewPurchaseOrderUsecase(purchaseOrderRepo, blanketAgreementRepo, supplierRepo, projectRepo, materialRepo, periodRepo, boqPriceUpdateUseCase, userRepo, projectMemberRepo, notificationUseCase)
+	PurchaseOrderHandler := rest.NewPurchaseOrderHandler(purchaseOrderUseCase, permissionGuard)
+	PurchaseOrderHandler.PurchaseOrderRoutes(app)
+
+	blanketAgreementUseCase := usecase.NewBlanketAgreementUsecase(blanketAgreementRepo, purchaseOrderRepo, supplierRepo, materialRepo, getEnvAsInt("BLANKET_AGREEMENT_ALERT_DAYS", 30))
+	BlanketAgreementHandler := rest.NewBlanketAgreementHandler(blanketAgreementUseCase, permissionGuard)
+	BlanketAgreementHandler.BlanketAgreementRoutes(app)
+
 	jobRepo := postgres.NewJobRepository(db)
 	jobUseCase := usecase.NewJobUseCase(jobRepo)
-	JobHandler := rest.NewJobHandler(jobUseCase)
+	JobHandler := rest.NewJobHandler(jobUseCase, permissionGuard)
 	JobHandler.JobRoutes(app)
 
+	phaseRepo := postgres.NewProjectPhaseRepository(db)
+	inspectionRepo := postgres.NewInspectionRepository(db)
+	regionRepo := postgres.NewRegionRepository(db)
+
 	boqRepo := postgres.NewBOQRepository(db)
-	boqUseCase := usecase.NewBOQUsecase(boqRepo, projectRepo)
+	boqUseCase := usecase.NewBOQUsecase(boqRepo, projectRepo, activityRepo, phaseRepo, inspectionRepo, regionRepo, boqPriceUpdateUseCase, userRepo, projectMemberRepo)
 	BOQHandler := rest.NewBOQHandler(boqUseCase)
 	BOQHandler.BOQRoutes(app)
 
+	regionUseCase := usecase.NewRegionUsecase(regionRepo, projectRepo)
+	RegionHandler := rest.NewRegionHandler(regionUseCase, permissionGuard)
+	RegionHandler.RegionRoutes(app)
+
+	inspectionUseCase := usecase.NewInspectionUsecase(inspectionRepo)
+	InspectionHandler := rest.NewInspectionHandler(inspectionUseCase, permissionGuard)
+	InspectionHandler.InspectionRoutes(app)
+
+	phaseUseCase := usecase.NewProjectPhaseUsecase(phaseRepo, projectRepo, boqRepo)
+	ProjectPhaseHandler := rest.NewProjectPhaseHandler(phaseUseCase, permissionGuard)
+	ProjectPhaseHandler.ProjectPhaseRoutes(app)
+
+	crewRepo := postgres.NewCrewRepository(db)
+	crewUseCase := usecase.NewCrewUsecase(crewRepo)
+	CrewHandler := rest.NewCrewHandler(crewUseCase, permissionGuard)
+	CrewHandler.CrewRoutes(app)
+
+	scheduleTaskRepo := postgres.NewScheduleTaskRepository(db)
+	scheduleTaskUseCase := usecase.NewScheduleTaskUsecase(scheduleTaskRepo, projectRepo, phaseRepo, crewRepo, regionRepo)
+	ScheduleTaskHandler := rest.NewScheduleTaskHandler(scheduleTaskUseCase, userUseCase, permissionGuard)
+	ScheduleTaskHandler.ScheduleTaskRoutes(app)
+
 	generalCostRepo := postgres.NewGeneralCostRepository(db)
-	generalCostUseCase := usecase.NewGeneralCostUsecase(generalCostRepo, boqRepo)
-	GeneralCostHandler := rest.NewGeneralCostHandler(generalCostUseCase)
+	generalCostUseCase := usecase.NewGeneralCostUsecase(generalCostRepo, boqRepo, periodRepo)
+	GeneralCostHandler := rest.NewGeneralCostHandler(generalCostUseCase, permissionGuard)
 	GeneralCostHandler.GeneralCostRoutes(app)
 
-	quotationRepo := postgres.NewQuotationRepository(db)
-	quotationUseCase := usecase.NewQuotationUsecase(quotationRepo)
-	QuotationHandler := rest.NewQuotationHandler(quotationUseCase)
-	QuotationHandler.QuotationRoutes(app)
+	recurringGeneralCostRepo := postgres.NewRecurringGeneralCostRepository(db)
+	recurringGeneralCostUseCase := usecase.NewRecurringGeneralCostUsecase(recurringGeneralCostRepo, generalCostRepo, projectRepo, periodRepo)
+	RecurringGeneralCostHandler := rest.NewRecurringGeneralCostHandler(recurringGeneralCostUseCase, permissionGuard)
+	RecurringGeneralCostHandler.RecurringGeneralCostRoutes(app)
+
+	roundingRepo := postgres.NewRoundingRepository(db)
+	roundingUseCase := usecase.NewRoundingUsecase(roundingRepo, userRepo)
+	RoundingHandler := rest.NewRoundingHandler(roundingUseCase, userUseCase)
+	RoundingHandler.RoundingRoutes(app)
 
 	companyRepo := postgres.NewCompanyRepository(db)
 	companyUseCase := usecase.NewCompanyUsecase(companyRepo)
-	CompanyHandler := rest.NewCompanyHandler(companyUseCase)
+	CompanyHandler := rest.NewCompanyHandler(companyUseCase, permissionGuard)
 	CompanyHandler.CompanyRoutes(app)
 
+	documentLabelRepo := postgres.NewDocumentLabelRepository(db)
+	documentLabelUseCase := usecase.NewDocumentLabelUsecase(documentLabelRepo, companyRepo)
+	DocumentLabelHandler := rest.NewDocumentLabelHandler(documentLabelUseCase, permissionGuard)
+	DocumentLabelHandler.DocumentLabelRoutes(app)
+
+	quotationRepo := postgres.NewQuotationRepository(db)
+	quotationSectionRepo := postgres.NewQuotationSectionRepository(db)
+	quotationUseCase := usecase.NewQuotationUsecase(quotationRepo, projectRepo, clientRepo, userRepo, activityRepo, roundingRepo, quotationSectionRepo, documentLabelUseCase, listPriceRepo, projectMemberRepo, notificationUseCase)
+	QuotationHandler := rest.NewQuotationHandler(quotationUseCase)
+	QuotationHandler.QuotationRoutes(app)
+
+	quotationSectionUseCase := usecase.NewQuotationSectionUsecase(quotationSectionRepo, quotationRepo)
+	QuotationSectionHandler := rest.NewQuotationSectionHandler(quotationSectionUseCase, permissionGuard)
+	QuotationSectionHandler.QuotationSectionRoutes(app)
+
+	quotationNegotiationRepo := postgres.NewQuotationNegotiationRepository(db)
+	quotationNegotiationUseCase := usecase.NewQuotationNegotiationUsecase(quotationNegotiationRepo, quotationRepo, activityRepo)
+	QuotationNegotiationHandler := rest.NewQuotationNegotiationHandler(quotationNegotiationUseCase, permissionGuard)
+	QuotationNegotiationHandler.QuotationNegotiationRoutes(app)
+
+	tenderRepo := postgres.NewTenderRepository(db)
+	tenderUseCase := usecase.NewTenderUsecase(tenderRepo, projectRepo)
+	TenderHandler := rest.NewTenderHandler(tenderUseCase, permissionGuard)
+	TenderHandler.TenderRoutes(app)
+
+	deliveryRepo := postgres.NewDeliveryRepository(db)
+	deliveryUseCase := usecase.NewDeliveryUsecase(deliveryRepo, quotationRepo, projectRepo, userRepo, projectMemberRepo)
+	DeliveryHandler := rest.NewDeliveryHandler(deliveryUseCase, userUseCase)
+	DeliveryHandler.DeliveryRoutes(app)
+
 	contractRepo := postgres.NewContractRepository(db)
-	contractUseCase := usecase.NewContractUsecase(contractRepo, projectRepo)
-	ContractHandler := rest.NewContractHandler(contractUseCase)
+	contractUseCase := usecase.NewContractUsecase(contractRepo, projectRepo, riskRepo)
+	ContractHandler := rest.NewContractHandler(contractUseCase, permissionGuard)
 	ContractHandler.ContractRoutes(app)
 
 	invoiceRepo := postgres.NewInvoiceRepository(db)
-	invoiceUseCase := usecase.NewInvoiceUsecase(invoiceRepo, projectRepo)
-	InvoiceHandler := rest.NewInvoiceHandler(invoiceUseCase)
+	paymentRepo := postgres.NewPaymentRepository(db)
+	lateInterestRepo := postgres.NewLateInterestRepository(db)
+	invoiceUseCase := usecase.NewInvoiceUsecase(invoiceRepo, projectRepo, companyRepo, paymentRepo, phaseRepo, roundingRepo, periodRepo, lateInterestRepo)
+	InvoiceHandler := rest.NewInvoiceHandler(invoiceUseCase, permissionGuard)
 	InvoiceHandler.InvoiceRoutes(app)
 
+	lateInterestUseCase := usecase.NewLateInterestUsecase(lateInterestRepo, projectRepo, paymentRepo, periodRepo)
+	LateInterestHandler := rest.NewLateInterestHandler(lateInterestUseCase, permissionGuard)
+	LateInterestHandler.LateInterestRoutes(app)
+
+	reconciliationRepo := postgres.NewReconciliationRepository(db)
+	reconciliationUseCase := usecase.NewReconciliationUsecase(reconciliationRepo, contractRepo, userRepo, projectMemberRepo)
+	ReconciliationHandler := rest.NewReconciliationHandler(reconciliationUseCase, permissionGuard)
+	ReconciliationHandler.ReconciliationRoutes(app)
+
+	documentExportUseCase := usecase.NewDocumentExportUsecase(quotationRepo, invoiceRepo, projectRepo, clientRepo, companyRepo, phaseRepo, roundingRepo, documentLabelUseCase, userRepo, projectMemberRepo)
+	DocumentExportHandler := rest.NewDocumentExportHandler(documentExportUseCase, permissionGuard)
+	DocumentExportHandler.DocumentExportRoutes(app)
+
+	// Without a signing service or provider, e-tax documents can be
+	// previewed but not submitted.
+	var etaxSigner repositories.ETaxSigner
+	if signURL := getEnv("ETAX_SIGNER_URL", ""); signURL != "" {
+		etaxSigner = etax.NewHTTPSigner(signURL, getEnv("ETAX_SIGNER_API_KEY", ""))
+	}
+	var etaxProvider repositories.ETaxProvider
+	if providerURL := getEnv("ETAX_PROVIDER_URL", ""); providerURL != "" {
+		etaxProvider = etax.NewHTTPProvider(providerURL, getEnv("ETAX_PROVIDER_API_KEY", ""))
+	}
+	etaxRepo := postgres.NewETaxRepository(db)
+	etaxUseCase := usecase.NewETaxUsecase(etaxRepo, invoiceRepo, paymentRepo, roundingRepo, documentExportUseCase, etaxSigner, etaxProvider)
+	ETaxHandler := rest.NewETaxHandler(etaxUseCase, permissionGuard)
+	ETaxHandler.ETaxRoutes(app)
+
+	paymentUseCase := usecase.NewPaymentUsecase(paymentRepo, invoiceRepo, periodRepo, documentLabelUseCase)
+	PaymentHandler := rest.NewPaymentHandler(paymentUseCase, permissionGuard)
+	PaymentHandler.PaymentRoutes(app)
+
+	projectPartnerRepo := postgres.NewProjectPartnerRepository(db)
+	projectPartnerUseCase := usecase.NewProjectPartnerUsecase(projectPartnerRepo, projectRepo, invoiceRepo, paymentRepo)
+	ProjectPartnerHandler := rest.NewProjectPartnerHandler(projectPartnerUseCase, permissionGuard)
+	ProjectPartnerHandler.ProjectPartnerRoutes(app)
+
+	inventoryRepo := postgres.NewInventoryRepository(db)
+	inventoryUseCase := usecase.NewInventoryUsecase(inventoryRepo, materialRepo, projectRepo)
+	InventoryHandler := rest.NewInventoryHandler(inventoryUseCase, permissionGuard)
+	InventoryHandler.InventoryRoutes(app)
+
+	materialReturnRepo := postgres.NewMaterialReturnRepository(db)
+	materialReturnUseCase := usecase.NewMaterialReturnUsecase(materialReturnRepo, purchaseOrderRepo, inventoryRepo, periodRepo, boqPriceUpdateUseCase, userRepo, projectMemberRepo)
+	MaterialReturnHandler := rest.NewMaterialReturnHandler(materialReturnUseCase, permissionGuard)
+	MaterialReturnHandler.MaterialReturnRoutes(app)
+
+	wasteRepo := postgres.NewWasteRepository(db)
+	wasteUseCase := usecase.NewWasteUsecase(wasteRepo, inventoryRepo, projectRepo, materialRepo,
+		float64(getEnvAsInt("WASTAGE_ALLOWANCE_PERCENT", 5)), userRepo, projectMemberRepo)
+	WasteHandler := rest.NewWasteHandler(wasteUseCase, permissionGuard)
+	WasteHandler.WasteRoutes(app)
+
+	concretePourRepo := postgres.NewConcretePourRepository(db)
+	concretePourUseCase := usecase.NewConcretePourUsecase(concretePourRepo, projectRepo, boqRepo, supplierRepo, materialRepo, userRepo, projectMemberRepo)
+	ConcretePourHandler := rest.NewConcretePourHandler(concretePourUseCase, permissionGuard)
+	ConcretePourHandler.ConcretePourRoutes(app)
+
+	cubeTestRepo := postgres.NewCubeTestRepository(db)
+	cubeTestUseCase := usecase.NewCubeTestUsecase(cubeTestRepo, concretePourRepo, projectRepo, userRepo, projectMemberRepo)
+	CubeTestHandler := rest.NewCubeTestHandler(cubeTestUseCase, permissionGuard)
+	CubeTestHandler.CubeTestRoutes(app)
+
+	asBuiltRepo := postgres.NewAsBuiltRepository(db)
+	asBuiltUseCase := usecase.NewAsBuiltUsecase(asBuiltRepo, boqRepo, contractUseCase, userRepo, projectMemberRepo)
+	AsBuiltHandler := rest.NewAsBuiltHandler(asBuiltUseCase, permissionGuard)
+	AsBuiltHandler.AsBuiltRoutes(app)
+
+	finalAccountRepo := postgres.NewFinalAccountRepository(db)
+	finalAccountUseCase := usecase.NewFinalAccountUsecase(finalAccountRepo, projectRepo, contractRepo, boqRepo, asBuiltRepo,
+		float64(getEnvAsInt("FINAL_ACCOUNT_RETENTION_PERCENT", 5)), userRepo, projectMemberRepo)
+	FinalAccountHandler := rest.NewFinalAccountHandler(finalAccountUseCase, permissionGuard)
+	FinalAccountHandler.FinalAccountRoutes(app)
+
+	claimRepo := postgres.NewClaimRepository(db)
+	claimUseCase := usecase.NewClaimUsecase(claimRepo, contractRepo, userRepo, projectMemberRepo)
+	ClaimHandler := rest.NewClaimHandler(claimUseCase, permissionGuard)
+	ClaimHandler.ClaimRoutes(app)
+
+	fixedAssetRepo := postgres.NewFixedAssetRepository(db)
+	fixedAssetUseCase := usecase.NewFixedAssetUsecase(fixedAssetRepo, materialRepo, projectRepo, periodRepo)
+	FixedAssetHandler := rest.NewFixedAssetHandler(fixedAssetUseCase, permissionGuard)
+	FixedAssetHandler.FixedAssetRoutes(app)
+
+	subcontractRepo := postgres.NewSubcontractRepository(db)
+	backChargeRepo := postgres.NewBackChargeRepository(db)
+	subcontractUseCase := usecase.NewSubcontractUsecase(subcontractRepo, projectRepo, supplierRepo, userRepo, periodRepo, backChargeRepo)
+	SubcontractHandler := rest.NewSubcontractHandler(subcontractUseCase, userUseCase, permissionGuard)
+	SubcontractHandler.SubcontractRoutes(app)
+
+	backChargeUseCase := usecase.NewBackChargeUsecase(backChargeRepo, supplierRepo, projectRepo, subcontractRepo)
+	BackChargeHandler := rest.NewBackChargeHandler(backChargeUseCase, userUseCase, permissionGuard)
+	BackChargeHandler.BackChargeRoutes(app)
+
+	paymentWebhookUseCase := usecase.NewPaymentWebhookUsecase(
+		paymentRepo,
+		invoiceRepo,
+		periodRepo,
+		notificationUseCase,
+		getEnv("OMISE_WEBHOOK_SECRET", ""),
+		getEnv("TWOC2P_SECRET_KEY", ""),
+	)
+	PaymentWebhookHandler := rest.NewPaymentWebhookHandler(paymentWebhookUseCase)
+	PaymentWebhookHandler.PaymentWebhookRoutes(app)
+
+	exchangeRateRepo := postgres.NewExchangeRateRepository(db)
+	exchangeRateUseCase := usecase.NewExchangeRateUsecase(
+		exchangeRateRepo,
+		[]repositories.ExchangeRateProvider{
+			exchangerate.NewBOTProvider(getEnv("BOT_API_TOKEN", "")),
+			exchangerate.NewOpenERAPIProvider(),
+		},
+		strings.Split(getEnv("EXCHANGE_RATE_CURRENCIES", "USD,EUR,JPY,CNY,SGD"), ","),
+	)
+	ExchangeRateHandler := rest.NewExchangeRateHandler(exchangeRateUseCase, permissionGuard)
+	ExchangeRateHandler.ExchangeRateRoutes(app)
+
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		bangkok = time.FixedZone("ICT", 7*60*60)
+	}
+	// BOT publishes the daily average rates around 18:00 Bangkok time.
+	scheduler.Daily(context.Background(), "exchange-rate-fetch",
+		getEnvAsInt("EXCHANGE_RATE_FETCH_HOUR", 19), 0, bangkok, exchangeRateUseCase.RefreshRates)
+
+	priceIndexRepo := postgres.NewPriceIndexRepository(db)
+	priceIndexUseCase := usecase.NewPriceIndexUsecase(
+		priceIndexRepo,
+		materialRepo,
+		float64(getEnvAsInt("PRICE_INDEX_ALERT_PERCENT", 5)),
+	)
+	PriceIndexHandler := rest.NewPriceIndexHandler(priceIndexUseCase, permissionGuard)
+	PriceIndexHandler.PriceIndexRoutes(app)
+	scheduler.Daily(context.Background(), "price-index-alerts", 8, 0, bangkok, priceIndexUseCase.CheckAlerts)
+	scheduler.Daily(context.Background(), "client-credit-hold", 1, 0, bangkok, clientUseCase.ApplyOverdueCreditHolds)
+	// Each invoice is only notified once, so missed days are caught up on the next run.
+	scheduler.Daily(context.Background(), "invoice-overdue-notifications", getEnvAsInt("INVOICE_OVERDUE_NOTIFY_HOUR", 9), 0, bangkok, notificationUseCase.CheckOverdueInvoices)
+	// Posts the previous month's depreciation once it closes; repeat runs skip posted months.
+	scheduler.Daily(context.Background(), "asset-depreciation", getEnvAsInt("DEPRECIATION_RUN_HOUR", 2), 0, bangkok, fixedAssetUseCase.RunDepreciation)
+	// Posts the current month's recurring general costs; repeat runs skip posted months.
+	scheduler.Daily(context.Background(), "recurring-general-costs", getEnvAsInt("RECURRING_COST_RUN_HOUR", 2), 15, bangkok, recurringGeneralCostUseCase.RunPostings)
+	// Accrues through yesterday; missed days are caught up on the next run.
+	scheduler.Daily(context.Background(), "late-interest", getEnvAsInt("LATE_INTEREST_RUN_HOUR", 3), 0, bangkok, lateInterestUseCase.RunAccruals)
+
+	weatherRepo := postgres.NewWeatherRepository(db)
+	weatherUseCase := usecase.NewWeatherUsecase(
+		weatherRepo,
+		projectRepo,
+		weather.NewOpenMeteoProvider(),
+		float64(getEnvAsInt("WEATHER_HEAVY_RAIN_MM", 35)),
+		userRepo,
+		projectMemberRepo,
+	)
+	WeatherHandler := rest.NewWeatherHandler(weatherUseCase, permissionGuard)
+	WeatherHandler.WeatherRoutes(app)
+	scheduler.Daily(context.Background(), "weather-forecast", getEnvAsInt("WEATHER_FETCH_HOUR", 5), 0, bangkok, weatherUseCase.RefreshForecasts)
+
+	safetyRepo := postgres.NewSafetyRepository(db)
+	safetyUseCase := usecase.NewSafetyUsecase(safetyRepo, projectRepo, float64(getEnvAsInt("SAFETY_HOURS_PER_DAY", 8)))
+	SafetyHandler := rest.NewSafetyHandler(safetyUseCase, permissionGuard)
+	SafetyHandler.SafetyRoutes(app)
+
+	handoverRepo := postgres.NewHandoverRepository(db)
+	surveyRepo := postgres.NewSurveyRepository(db)
+	surveyUseCase := usecase.NewSurveyUsecase(
+		surveyRepo,
+		handoverRepo,
+		projectRepo,
+		userRepo,
+		getEnv("SURVEY_LINK_SECRET", jwtSecret),
+		getEnv("SURVEY_LINK_BASE_URL", "http://localhost:3000/survey"),
+		getEnvAsDuration("SURVEY_LINK_TTL", 60*24*time.Hour),
+	)
+	SurveyHandler := rest.NewSurveyHandler(surveyUseCase, permissionGuard)
+	SurveyHandler.SurveyRoutes(app)
+
+	// Photos shared with clients are only watermarked when enabled; the
+	// logo is optional and without it only the date is stamped.
+	var photoWatermark *watermark.Watermark
+	if getEnvAsBool("PHOTO_WATERMARK_ENABLED", false) {
+		var logo image.Image
+		if logoPath := getEnv("PHOTO_WATERMARK_LOGO", ""); logoPath != "" {
+			if logo, err = watermark.LoadLogo(logoPath); err != nil {
+				log.Fatalf("Failed to load watermark logo: %v", err)
+			}
+		}
+		photoWatermark = watermark.New(logo)
+	}
+
+	handoverUseCase := usecase.NewHandoverUsecase(
+		handoverRepo,
+		projectRepo,
+		boqRepo,
+		inspectionRepo,
+		cubeTestRepo,
+		surveyUseCase,
+		files.NewHTTPFetcher(),
+		photoWatermark,
+		getEnv("HANDOVER_LINK_SECRET", jwtSecret),
+		getEnv("HANDOVER_LINK_BASE_URL", "http://localhost:3000/handover"),
+		getEnvAsDuration("HANDOVER_LINK_TTL", 30*24*time.Hour),
+	)
+	HandoverHandler := rest.NewHandoverHandler(handoverUseCase, permissionGuard)
+	HandoverHandler.HandoverRoutes(app)
+
+	dashboardRepo := postgres.NewDashboardRepository(db)
+	dashboardUseCase := usecase.NewDashboardUsecase(dashboardRepo, userRepo)
+	DashboardHandler := rest.NewDashboardHandler(dashboardUseCase, userUseCase)
+	DashboardHandler.DashboardRoutes(app)
+
+	forecastUseCase := usecase.NewForecastUsecase(dashboardRepo, contractRepo, recurringGeneralCostRepo, float64(getEnvAsInt("FORECAST_ALERT_PERCENT", 0)), userRepo, projectMemberRepo)
+	ForecastHandler := rest.NewForecastHandler(forecastUseCase, permissionGuard)
+	ForecastHandler.ForecastRoutes(app)
+	scheduler.Daily(context.Background(), "forecast-alerts", 8, 0, bangkok, forecastUseCase.CheckAlerts)
+	scheduler.Daily(context.Background(), "blanket-agreement-alerts", 8, 0, bangkok, blanketAgreementUseCase.CheckAlerts)
+
+	kpiRepo := postgres.NewKPIRepository(db)
+	kpiUseCase := usecase.NewKPIUsecase(kpiRepo, userRepo)
+	KPIHandler := rest.NewKPIHandler(kpiUseCase, userUseCase)
+	KPIHandler.KPIRoutes(app)
+
+	// The warehouse export is off unless DW_EXPORT_SINK names a sink.
+	var dwSink repositories.DWSink
+	switch getEnv("DW_EXPORT_SINK", "") {
+	case "csv":
+		dwSink = warehouse.NewCSVFileSink(getEnv("DW_EXPORT_DIR", "./exports"))
+	case "clickhouse":
+		dwSink = warehouse.NewClickHouseSink(
+			getEnv("CLICKHOUSE_URL", "http://localhost:8123"),
+			getEnv("CLICKHOUSE_DATABASE", "boonkosang"),
+			getEnv("CLICKHOUSE_USER", ""),
+			getEnv("CLICKHOUSE_PASSWORD", ""),
+		)
+	}
+	if dwSink != nil {
+		dwExportRepo := postgres.NewDWExportRepository(db)
+		dwExportUseCase := usecase.NewDWExportUsecase(dwExportRepo, dwSink)
+		DWExportHandler := rest.NewDWExportHandler(dwExportUseCase, permissionGuard)
+		DWExportHandler.DWExportRoutes(app)
+		scheduler.Every(context.Background(), "dw-export", getEnvAsDuration("DW_EXPORT_INTERVAL", time.Hour), dwExportUseCase.Run)
+	}
+
+	// Sealing the audit log is off unless AUDIT_ARCHIVE names where the
+	// sealed segments go.
+	var auditArchive repositories.AuditArchive
+	switch getEnv("AUDIT_ARCHIVE", "") {
+	case "file":
+		auditArchive = archive.NewFileArchive(getEnv("AUDIT_ARCHIVE_DIR", "./audit"))
+	case "s3":
+		auditArchive = archive.NewS3Archive(
+			getEnv("AUDIT_S3_ENDPOINT", "https://s3.ap-southeast-1.amazonaws.com"),
+			getEnv("AUDIT_S3_BUCKET", ""),
+			getEnv("AUDIT_S3_REGION", "ap-southeast-1"),
+			getEnv("AUDIT_S3_ACCESS_KEY", ""),
+			getEnv("AUDIT_S3_SECRET_KEY", ""),
+			getEnvAsDuration("AUDIT_RETENTION", 10*365*24*time.Hour),
+		)
+	}
+	if auditArchive != nil {
+		auditRepo := postgres.NewAuditRepository(db)
+		auditUseCase := usecase.NewAuditUsecase(auditRepo, userRepo, auditArchive, bangkok)
+		AuditHandler := rest.NewAuditHandler(auditUseCase, userUseCase)
+		AuditHandler.AuditRoutes(app)
+		scheduler.Daily(context.Background(), "audit-seal", getEnvAsInt("AUDIT_SEAL_HOUR", 1), 30, bangkok, auditUseCase.Seal)
+	}
+
+	// Archiving projects is off unless PROJECT_ARCHIVE names where the
+	// archived data goes.
+	var coldStorage repositories.ColdStorage
+	switch getEnv("PROJECT_ARCHIVE", "") {
+	case "file":
+		// Every archive has its own key, so the write-once file archive
+		// serves here too.
+		coldStorage = archive.NewFileArchive(getEnv("PROJECT_ARCHIVE_DIR", "./project-archive"))
+	case "s3":
+		coldStorage = archive.NewS3ColdStorage(
+			getEnv("PROJECT_ARCHIVE_S3_ENDPOINT", "https://s3.ap-southeast-1.amazonaws.com"),
+			getEnv("PROJECT_ARCHIVE_S3_BUCKET", ""),
+			getEnv("PROJECT_ARCHIVE_S3_REGION", "ap-southeast-1"),
+			getEnv("PROJECT_ARCHIVE_S3_ACCESS_KEY", ""),
+			getEnv("PROJECT_ARCHIVE_S3_SECRET_KEY", ""),
+			getEnv("PROJECT_ARCHIVE_S3_STORAGE_CLASS", "STANDARD_IA"),
+		)
+	}
+	if coldStorage != nil {
+		projectArchiveRepo := postgres.NewProjectArchiveRepository(db)
+		projectArchiveUseCase := usecase.NewProjectArchiveUsecase(projectArchiveRepo, projectRepo, activityRepo, coldStorage)
+		ProjectArchiveHandler := rest.NewProjectArchiveHandler(projectArchiveUseCase, permissionGuard)
+		ProjectArchiveHandler.ProjectArchiveRoutes(app)
+	}
+
+	scheduler.Every(context.Background(), "revoked-token-purge", getEnvAsDuration("REVOKED_TOKEN_PURGE_INTERVAL", time.Hour), userUseCase.PurgeRevokedTokens)
+	scheduler.Every(context.Background(), "etax-status", getEnvAsDuration("ETAX_STATUS_INTERVAL", 15*time.Minute), etaxUseCase.RefreshPending)
+
+	publicStatsRepo := postgres.NewPublicStatsRepository(db)
+	publicStatsUseCase := usecase.NewPublicStatsUsecase(
+		publicStatsRepo,
+		getEnvAsList("STATS_WIDGET_TOKENS"),
+		getEnvAsDuration("STATS_WIDGET_CACHE_TTL", 10*time.Minute),
+	)
+	PublicStatsHandler := rest.NewPublicStatsHandler(publicStatsUseCase)
+	PublicStatsHandler.PublicStatsRoutes(app)
+
+	estimationUseCase := usecase.NewEstimationUsecase(estimationRepo, projectRepo)
+	EstimationHandler := rest.NewEstimationHandler(estimationUseCase, permissionGuard)
+	EstimationHandler.EstimationRoutes(app)
+
+	activityUseCase := usecase.NewActivityUsecase(activityRepo, projectRepo, clientRepo, quotationRepo, userRepo, projectMemberRepo)
+	TimelineHandler := rest.NewTimelineHandler(activityUseCase, userUseCase, permissionGuard)
+	TimelineHandler.TimelineRoutes(app)
+
 	port := getEnv("PORT", "8004")
 	if err := app.Listen(":" + port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
@@ -136,3 +725,22 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsList splits a comma-separated variable, dropping empty entries.
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
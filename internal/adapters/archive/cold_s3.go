@@ -0,0 +1,50 @@
+package archive
+
+import (
+	"boonkosang/internal/infrastructure/s3"
+	"boonkosang/internal/repositories"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+type s3ColdStorage struct {
+	client       *s3.Client
+	storageClass string
+}
+
+// NewS3ColdStorage stores objects in an S3-compatible bucket under
+// storageClass, such as STANDARD_IA or GLACIER_IR. Classes that must be
+// restored before they can be read, like GLACIER, won't work.
+func NewS3ColdStorage(endpoint, bucket, region, accessKey, secretKey, storageClass string) repositories.ColdStorage {
+	return &s3ColdStorage{
+		client:       s3.New(endpoint, bucket, region, accessKey, secretKey),
+		storageClass: storageClass,
+	}
+}
+
+func (s *s3ColdStorage) Put(ctx context.Context, key string, data []byte) error {
+	sum := md5.Sum(data)
+	headers := map[string]string{
+		"content-type":        "application/gzip",
+		"content-md5":         base64.StdEncoding.EncodeToString(sum[:]),
+		"x-amz-storage-class": s.storageClass,
+	}
+
+	resp, err := s.client.Do(ctx, http.MethodPut, key, data, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, s3.ReadError(resp.Body))
+	}
+	return nil
+}
+
+func (s *s3ColdStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	return getObject(ctx, s.client, key)
+}
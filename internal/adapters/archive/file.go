@@ -0,0 +1,64 @@
+package archive
+
+import (
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type fileArchive struct {
+	dir string
+}
+
+// NewFileArchive stores objects under dir as read-only files that are
+// never overwritten. It only keeps this process honest, so it's meant for
+// development or a directory on a WORM-mounted volume.
+func NewFileArchive(dir string) repositories.AuditArchive {
+	return &fileArchive{
+		dir: dir,
+	}
+}
+
+func (a *fileArchive) Put(ctx context.Context, key string, data []byte) error {
+	path := filepath.Join(a.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o444)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return errors.New("object already exists")
+		}
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to write archive file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to sync archive file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close archive file: %w", err)
+	}
+	return nil
+}
+
+func (a *fileArchive) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(a.dir, filepath.FromSlash(key)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errors.New("object not found")
+		}
+		return nil, fmt.Errorf("failed to read archive file: %w", err)
+	}
+	return data, nil
+}
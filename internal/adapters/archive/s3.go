@@ -0,0 +1,81 @@
+package archive
+
+import (
+	"boonkosang/internal/infrastructure/s3"
+	"boonkosang/internal/repositories"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type s3Archive struct {
+	client    *s3.Client
+	retention time.Duration
+}
+
+// NewS3Archive stores objects in an S3-compatible bucket that has Object
+// Lock enabled. Each object is written in compliance mode, which nobody,
+// including the account root, can shorten or remove until retention has
+// passed, and with If-None-Match so an existing key is never replaced.
+func NewS3Archive(endpoint, bucket, region, accessKey, secretKey string, retention time.Duration) repositories.AuditArchive {
+	return &s3Archive{
+		client:    s3.New(endpoint, bucket, region, accessKey, secretKey),
+		retention: retention,
+	}
+}
+
+func (a *s3Archive) Put(ctx context.Context, key string, data []byte) error {
+	sum := md5.Sum(data)
+	headers := map[string]string{
+		"content-type":                        "application/json",
+		"content-md5":                         base64.StdEncoding.EncodeToString(sum[:]),
+		"if-none-match":                       "*",
+		"x-amz-object-lock-mode":              "COMPLIANCE",
+		"x-amz-object-lock-retain-until-date": time.Now().Add(a.retention).UTC().Format(time.RFC3339),
+	}
+
+	resp, err := a.client.Do(ctx, http.MethodPut, key, data, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusPreconditionFailed:
+		return errors.New("object already exists")
+	}
+	return fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, s3.ReadError(resp.Body))
+}
+
+func (a *s3Archive) Get(ctx context.Context, key string) ([]byte, error) {
+	return getObject(ctx, a.client, key)
+}
+
+func getObject(ctx context.Context, client *s3.Client, key string) ([]byte, error) {
+	resp, err := client.Do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errors.New("object not found")
+	default:
+		return nil, fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, s3.ReadError(resp.Body))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return data, nil
+}
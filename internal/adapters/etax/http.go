@@ -0,0 +1,160 @@
+// Package etax talks to the external services behind e-tax filing: a
+// signing service holding the company's certificate and the provider that
+// forwards documents to the Revenue Department.
+package etax
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type httpSigner struct {
+	client *http.Client
+	url    string
+	apiKey string
+}
+
+// NewHTTPSigner posts unsigned XML to url and expects the signed XML back
+// in the response body. The API key, if set, is sent as a bearer token.
+func NewHTTPSigner(url, apiKey string) repositories.ETaxSigner {
+	return &httpSigner{
+		client: &http.Client{Timeout: 30 * time.Second},
+		url:    url,
+		apiKey: apiKey,
+	}
+}
+
+func (s *httpSigner) Sign(ctx context.Context, document []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	setBearer(req, s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach signing service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signed document: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signing service returned %d: %s", resp.StatusCode, truncate(body))
+	}
+
+	return body, nil
+}
+
+type httpProvider struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+// NewHTTPProvider files documents with POST <baseURL>/documents, which
+// answers {"reference": "..."}, and polls GET <baseURL>/documents/<ref>,
+// which answers {"status": "processing|accepted|rejected", "message": "..."}.
+// Service providers differ, so anything else needs a small proxy in front.
+func NewHTTPProvider(baseURL, apiKey string) repositories.ETaxProvider {
+	return &httpProvider{
+		client:  &http.Client{Timeout: 60 * time.Second},
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+	}
+}
+
+func (p *httpProvider) Submit(ctx context.Context, documentNumber string, document []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/documents", bytes.NewReader(document))
+	if err != nil {
+		return "", fmt.Errorf("failed to create submission request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("X-Document-Number", documentNumber)
+	setBearer(req, p.apiKey)
+
+	var result struct {
+		Reference string `json:"reference"`
+	}
+	if err := p.do(req, &result); err != nil {
+		return "", err
+	}
+	if result.Reference == "" {
+		return "", fmt.Errorf("e-tax provider returned no reference")
+	}
+
+	return result.Reference, nil
+}
+
+func (p *httpProvider) Status(ctx context.Context, reference string) (models.ETaxStatus, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/documents/"+url.PathEscape(reference), nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create status request: %w", err)
+	}
+	setBearer(req, p.apiKey)
+
+	var result struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := p.do(req, &result); err != nil {
+		return "", "", err
+	}
+
+	switch result.Status {
+	case "accepted":
+		return models.ETaxStatusAccepted, result.Message, nil
+	case "rejected":
+		return models.ETaxStatusRejected, result.Message, nil
+	default:
+		return models.ETaxStatusSubmitted, result.Message, nil
+	}
+}
+
+func (p *httpProvider) do(req *http.Request, out interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach e-tax provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read e-tax provider response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("e-tax provider returned %d: %s", resp.StatusCode, truncate(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode e-tax provider response: %w", err)
+	}
+
+	return nil
+}
+
+func setBearer(req *http.Request, apiKey string) {
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+}
+
+func truncate(body []byte) string {
+	message := strings.TrimSpace(string(body))
+	if len(message) > 512 {
+		message = message[:512]
+	}
+	return message
+}
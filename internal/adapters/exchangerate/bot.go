@@ -0,0 +1,122 @@
+package exchangerate
+
+import (
+	"boonkosang/internal/repositories"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const botBaseURL = "https://gateway.api.bot.or.th/Stat-ExchangeRate/v2/DAILY_AVG_EXG_RATE/"
+
+type botProvider struct {
+	token  string
+	client *http.Client
+}
+
+// NewBOTProvider returns a provider for the Bank of Thailand daily weighted
+// average interbank exchange rates.
+func NewBOTProvider(token string) repositories.ExchangeRateProvider {
+	return &botProvider{
+		token:  token,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *botProvider) Name() string {
+	return "bot"
+}
+
+type botResponse struct {
+	Result struct {
+		Data struct {
+			DataDetail []struct {
+				Period     string `json:"period"`
+				CurrencyID string `json:"currency_id"`
+				MidRate    string `json:"mid_rate"`
+			} `json:"data_detail"`
+		} `json:"data"`
+	} `json:"result"`
+}
+
+func (p *botProvider) FetchRates(ctx context.Context, currencies []string) (map[string]float64, time.Time, error) {
+	if p.token == "" {
+		return nil, time.Time{}, errors.New("BOT API token is not configured")
+	}
+
+	// BOT does not publish on weekends and holidays, so ask for the last
+	// week and keep the most recent period for each currency.
+	end := time.Now()
+	start := end.AddDate(0, 0, -7)
+
+	query := url.Values{}
+	query.Set("start_period", start.Format("2006-01-02"))
+	query.Set("end_period", end.Format("2006-01-02"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, botBaseURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("Authorization", p.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to call BOT API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("BOT API returned status %d", resp.StatusCode)
+	}
+
+	var body botResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode BOT response: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(currencies))
+	for _, c := range currencies {
+		wanted[strings.ToUpper(c)] = true
+	}
+
+	rates := make(map[string]float64)
+	latestPeriod := make(map[string]string)
+	var rateDate time.Time
+
+	for _, d := range body.Result.Data.DataDetail {
+		code := strings.ToUpper(d.CurrencyID)
+		if !wanted[code] || d.MidRate == "" || d.Period < latestPeriod[code] {
+			continue
+		}
+
+		rate, err := strconv.ParseFloat(d.MidRate, 64)
+		if err != nil {
+			continue
+		}
+
+		// JPY, IDR and a few others are quoted per 100 units.
+		if code == "JPY" || code == "IDR" || code == "KRW" || code == "VND" {
+			rate = rate / 100
+		}
+
+		rates[code] = rate
+		latestPeriod[code] = d.Period
+
+		if period, err := time.Parse("2006-01-02", d.Period); err == nil && period.After(rateDate) {
+			rateDate = period
+		}
+	}
+
+	if len(rates) == 0 {
+		return nil, time.Time{}, errors.New("BOT API returned no rates")
+	}
+
+	return rates, rateDate, nil
+}
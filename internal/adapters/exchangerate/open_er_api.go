@@ -0,0 +1,76 @@
+package exchangerate
+
+import (
+	"boonkosang/internal/repositories"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const openERAPIURL = "https://open.er-api.com/v6/latest/THB"
+
+type openERAPIProvider struct {
+	client *http.Client
+}
+
+// NewOpenERAPIProvider returns a keyless fallback provider used when the BOT
+// API is unavailable.
+func NewOpenERAPIProvider() repositories.ExchangeRateProvider {
+	return &openERAPIProvider{
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *openERAPIProvider) Name() string {
+	return "open-er-api"
+}
+
+type openERAPIResponse struct {
+	Result             string             `json:"result"`
+	TimeLastUpdateUnix int64              `json:"time_last_update_unix"`
+	Rates              map[string]float64 `json:"rates"`
+}
+
+func (p *openERAPIProvider) FetchRates(ctx context.Context, currencies []string) (map[string]float64, time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, openERAPIURL, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to call open.er-api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("open.er-api returned status %d", resp.StatusCode)
+	}
+
+	var body openERAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode open.er-api response: %w", err)
+	}
+	if body.Result != "success" {
+		return nil, time.Time{}, errors.New("open.er-api returned an error result")
+	}
+
+	// The API quotes foreign units per baht; we store baht per foreign unit.
+	rates := make(map[string]float64)
+	for _, c := range currencies {
+		code := strings.ToUpper(c)
+		if perBaht, ok := body.Rates[code]; ok && perBaht > 0 {
+			rates[code] = 1 / perBaht
+		}
+	}
+
+	if len(rates) == 0 {
+		return nil, time.Time{}, errors.New("open.er-api returned no rates")
+	}
+
+	return rates, time.Unix(body.TimeLastUpdateUnix, 0), nil
+}
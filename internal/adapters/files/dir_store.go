@@ -0,0 +1,36 @@
+package files
+
+import (
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type dirStore struct {
+	dir       string
+	publicURL string
+}
+
+// NewDirStore keeps files under dir, for development or a single instance
+// with a persistent volume. The files must be served at publicURL, e.g.
+// with Fiber's Static.
+func NewDirStore(dir, publicURL string) repositories.FileStore {
+	return &dirStore{
+		dir:       dir,
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}
+}
+
+func (s *dirStore) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return s.publicURL + "/" + key, nil
+}
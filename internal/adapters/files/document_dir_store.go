@@ -0,0 +1,54 @@
+package files
+
+import (
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type documentDirStore struct {
+	dir string
+}
+
+// NewDocumentDirStore keeps private documents under dir, for development
+// or a single instance with a persistent volume. Unlike NewDirStore the
+// directory must not be served.
+func NewDocumentDirStore(dir string) repositories.DocumentStore {
+	return &documentDirStore{
+		dir: dir,
+	}
+}
+
+func (s *documentDirStore) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create document directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write document: %w", err)
+	}
+	return nil
+}
+
+func (s *documentDirStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errors.New("object not found")
+		}
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+	return data, nil
+}
+
+func (s *documentDirStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	return nil
+}
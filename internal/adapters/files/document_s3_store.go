@@ -0,0 +1,75 @@
+package files
+
+import (
+	"boonkosang/internal/infrastructure/s3"
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type documentS3Store struct {
+	client *s3.Client
+}
+
+// NewDocumentS3Store keeps private documents in an S3-compatible bucket.
+// The bucket should not allow public reads; documents are downloaded
+// through the API.
+func NewDocumentS3Store(endpoint, bucket, region, accessKey, secretKey string) repositories.DocumentStore {
+	return &documentS3Store{
+		client: s3.New(endpoint, bucket, region, accessKey, secretKey),
+	}
+}
+
+func (s *documentS3Store) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	resp, err := s.client.Do(ctx, http.MethodPut, key, data, map[string]string{
+		"content-type": contentType,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, s3.ReadError(resp.Body))
+	}
+	return nil
+}
+
+func (s *documentS3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.client.Do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errors.New("object not found")
+	default:
+		return nil, fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, s3.ReadError(resp.Body))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return data, nil
+}
+
+// Delete succeeds for a missing key, as S3 itself does.
+func (s *documentS3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.client.Do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, s3.ReadError(resp.Body))
+	}
+	return nil
+}
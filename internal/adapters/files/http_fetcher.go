@@ -0,0 +1,52 @@
+package files
+
+import (
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxFileSize bounds how much of a shared file is read into memory.
+const maxFileSize = 25 << 20
+
+type httpFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher returns a fetcher for files uploaded to object storage and
+// referenced by URL.
+func NewHTTPFetcher() repositories.FileFetcher {
+	return &httpFetcher{
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (f *httpFetcher) Fetch(ctx context.Context, url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("file storage returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(body) > maxFileSize {
+		return nil, "", fmt.Errorf("file is larger than %d bytes", maxFileSize)
+	}
+
+	return body, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,40 @@
+package files
+
+import (
+	"boonkosang/internal/infrastructure/s3"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type s3Store struct {
+	client    *s3.Client
+	publicURL string
+}
+
+// NewS3Store uploads to an S3-compatible bucket. publicURL is where the
+// bucket's objects can be read from, such as a CDN in front of it or the
+// bucket's own URL when its objects are public.
+func NewS3Store(endpoint, bucket, region, accessKey, secretKey, publicURL string) repositories.FileStore {
+	return &s3Store{
+		client:    s3.New(endpoint, bucket, region, accessKey, secretKey),
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	resp, err := s.client.Do(ctx, http.MethodPut, key, data, map[string]string{
+		"content-type": contentType,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, s3.ReadError(resp.Body))
+	}
+	return s.publicURL + "/" + key, nil
+}
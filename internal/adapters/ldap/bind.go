@@ -0,0 +1,294 @@
+// Package ldap checks passwords against an LDAP or Active Directory server
+// with a simple bind. Only the bind and unbind operations are needed, so
+// they are encoded here rather than pulling in a full LDAP client.
+package ldap
+
+import (
+	"boonkosang/internal/repositories"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// resultInvalidCredentials is the bind result code for a wrong DN or
+// password.
+const resultInvalidCredentials = 49
+
+type bindAuthenticator struct {
+	address   string
+	useTLS    bool
+	bindDN    string
+	timeout   time.Duration
+	tlsConfig *tls.Config
+}
+
+// NewBindAuthenticator checks passwords by binding to the server at
+// rawURL (ldap://host:389 or ldaps://host:636) as bindDN with the
+// username substituted for %s, e.g. "uid=%s,ou=people,dc=example,dc=com"
+// or, for Active Directory, "%s@corp.example.com". Plain ldap:// sends
+// passwords unencrypted and should only be used on a trusted network.
+func NewBindAuthenticator(rawURL, bindDN string, timeout time.Duration) (repositories.PasswordAuthenticator, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ldap url: %w", err)
+	}
+	if !strings.Contains(bindDN, "%s") {
+		return nil, errors.New("ldap bind dn must contain %s for the username")
+	}
+
+	a := &bindAuthenticator{bindDN: bindDN, timeout: timeout}
+	host, port := u.Hostname(), u.Port()
+	switch u.Scheme {
+	case "ldap":
+		if port == "" {
+			port = "389"
+		}
+	case "ldaps":
+		if port == "" {
+			port = "636"
+		}
+		a.useTLS = true
+		a.tlsConfig = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	default:
+		return nil, fmt.Errorf("unsupported ldap url scheme %q", u.Scheme)
+	}
+	if host == "" {
+		return nil, errors.New("ldap url has no host")
+	}
+	a.address = net.JoinHostPort(host, port)
+	return a, nil
+}
+
+func (a *bindAuthenticator) Authenticate(ctx context.Context, username, password string) error {
+	// An empty password is an unauthenticated bind, which most servers
+	// accept for any DN.
+	if username == "" || password == "" {
+		return errors.New("invalid credentials")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{}
+	if a.useTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: a.tlsConfig}).DialContext(ctx, "tcp", a.address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", a.address)
+	}
+	if err != nil {
+		log.Printf("ldap: failed to connect to %s: %v", a.address, err)
+		return errors.New("directory unavailable")
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	dn := fmt.Sprintf(a.bindDN, escapeDN(username))
+	if _, err := conn.Write(bindRequest(1, dn, password)); err != nil {
+		log.Printf("ldap: failed to send bind request: %v", err)
+		return errors.New("directory unavailable")
+	}
+
+	code, message, err := readBindResponse(bufio.NewReader(conn))
+	if err != nil {
+		log.Printf("ldap: failed to read bind response: %v", err)
+		return errors.New("directory unavailable")
+	}
+	switch code {
+	case 0:
+		conn.Write(unbindRequest(2))
+		return nil
+	case resultInvalidCredentials:
+		return errors.New("invalid credentials")
+	default:
+		log.Printf("ldap: bind for %q failed with result %d: %s", username, code, message)
+		return errors.New("directory unavailable")
+	}
+}
+
+// escapeDN escapes the characters RFC 4514 gives a meaning in a DN so a
+// username can't change which entry is bound.
+func escapeDN(value string) string {
+	var b strings.Builder
+	for i, r := range value {
+		switch {
+		case strings.ContainsRune(`,+"\<>;=`, r),
+			i == 0 && (r == '#' || r == ' '),
+			i == len(value)-1 && r == ' ':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// BER tags used by the bind and unbind operations.
+const (
+	tagInteger       = 0x02
+	tagOctetString   = 0x04
+	tagEnumerated    = 0x0a
+	tagSequence      = 0x30
+	tagBindRequest   = 0x60
+	tagBindResponse  = 0x61
+	tagUnbindRequest = 0x42
+	tagSimpleAuth    = 0x80
+)
+
+func bindRequest(messageID int, dn, password string) []byte {
+	op := tlv(tagBindRequest,
+		tlv(tagInteger, []byte{3}),
+		tlv(tagOctetString, []byte(dn)),
+		tlv(tagSimpleAuth, []byte(password)),
+	)
+	return tlv(tagSequence, tlv(tagInteger, []byte{byte(messageID)}), op)
+}
+
+func unbindRequest(messageID int) []byte {
+	return tlv(tagSequence, tlv(tagInteger, []byte{byte(messageID)}), tlv(tagUnbindRequest))
+}
+
+func tlv(tag byte, contents ...[]byte) []byte {
+	length := 0
+	for _, c := range contents {
+		length += len(c)
+	}
+	out := []byte{tag}
+	if length < 0x80 {
+		out = append(out, byte(length))
+	} else {
+		// Long form: 0x80 plus the number of length bytes, then the length
+		// big-endian in as few bytes as it needs.
+		var encoded []byte
+		for n := length; n > 0; n >>= 8 {
+			encoded = append([]byte{byte(n)}, encoded...)
+		}
+		out = append(out, 0x80|byte(len(encoded)))
+		out = append(out, encoded...)
+	}
+	for _, c := range contents {
+		out = append(out, c...)
+	}
+	return out
+}
+
+// maxResponseLength bounds what a misbehaving server can make us read.
+const maxResponseLength = 64 << 10
+
+// readBindResponse reads one LDAPMessage and returns its bind result code
+// and diagnostic message.
+func readBindResponse(r *bufio.Reader) (int, string, error) {
+	tag, body, err := readTLV(r)
+	if err != nil {
+		return 0, "", err
+	}
+	if tag != tagSequence {
+		return 0, "", fmt.Errorf("unexpected message tag 0x%02x", tag)
+	}
+
+	// messageID, then the operation.
+	rest := body
+	if _, _, rest, err = splitTLV(rest); err != nil {
+		return 0, "", err
+	}
+	tag, op, _, err := splitTLV(rest)
+	if err != nil {
+		return 0, "", err
+	}
+	if tag != tagBindResponse {
+		return 0, "", fmt.Errorf("unexpected operation tag 0x%02x", tag)
+	}
+
+	tag, code, op, err := splitTLV(op)
+	if err != nil {
+		return 0, "", err
+	}
+	if tag != tagEnumerated || len(code) == 0 {
+		return 0, "", errors.New("malformed result code")
+	}
+	result := 0
+	for _, b := range code {
+		result = result<<8 | int(b)
+	}
+
+	// matchedDN, then diagnosticMessage.
+	var message []byte
+	if _, _, op, err = splitTLV(op); err == nil {
+		_, message, _, _ = splitTLV(op)
+	}
+	return result, string(message), nil
+}
+
+func readTLV(r *bufio.Reader) (byte, []byte, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, err := readLength(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if length > maxResponseLength {
+		return 0, nil, errors.New("response too large")
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return tag, body, nil
+}
+
+func readLength(r io.ByteReader) (int, error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if first < 0x80 {
+		return int(first), nil
+	}
+	n := int(first & 0x7f)
+	if n == 0 || n > 3 {
+		return 0, errors.New("unsupported length encoding")
+	}
+	length := 0
+	for i := 0; i < n; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		length = length<<8 | int(b)
+	}
+	return length, nil
+}
+
+// splitTLV returns the first element of data and what follows it.
+func splitTLV(data []byte) (byte, []byte, []byte, error) {
+	if len(data) < 2 {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+	r := bytes.NewReader(data[1:])
+	length, err := readLength(r)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	header := len(data) - r.Len()
+	if header+length > len(data) {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+	return data[0], data[header : header+length], data[header+length:], nil
+}
@@ -0,0 +1,69 @@
+// Package line sends messages through the LINE Messaging API.
+package line
+
+import (
+	"boonkosang/internal/repositories"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const pushURL = "https://api.line.me/v2/bot/message/push"
+
+// maxTextLength is the longest text message LINE accepts.
+const maxTextLength = 5000
+
+type messagingAPI struct {
+	client      *http.Client
+	accessToken string
+}
+
+// NewMessagingAPI pushes messages from the LINE Official Account whose
+// channel access token is given. Users only receive them once they have
+// added the account as a friend.
+func NewMessagingAPI(accessToken string) repositories.LineMessenger {
+	return &messagingAPI{
+		client:      &http.Client{Timeout: 15 * time.Second},
+		accessToken: accessToken,
+	}
+}
+
+func (m *messagingAPI) Push(ctx context.Context, to, text string) error {
+	if runes := []rune(text); len(runes) > maxTextLength {
+		text = string(runes[:maxTextLength])
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"to": to,
+		"messages": []map[string]string{
+			{"type": "text", "text": text},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode LINE message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pushURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create LINE request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.accessToken)
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach LINE: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("LINE returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
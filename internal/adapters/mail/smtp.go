@@ -0,0 +1,61 @@
+// Package mail sends email through an SMTP relay.
+package mail
+
+import (
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer sends mail through host:port, authenticating with
+// username and password when a username is given. net/smtp upgrades to
+// TLS with STARTTLS when the server offers it.
+func NewSMTPMailer(host string, port int, username, password, from string) repositories.Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") {
+		return errors.New("invalid recipient address")
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	// smtp.SendMail can't be cancelled, so ctx is only checked up front.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
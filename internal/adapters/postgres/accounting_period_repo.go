@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type accountingPeriodRepository struct {
+	db *sqlx.DB
+}
+
+func NewAccountingPeriodRepository(db *sqlx.DB) repositories.AccountingPeriodRepository {
+	return &accountingPeriodRepository{
+		db: db,
+	}
+}
+
+func (r *accountingPeriodRepository) ListLocks(ctx context.Context) ([]models.AccountingPeriodLock, error) {
+	var locks []models.AccountingPeriodLock
+	query := `SELECT * FROM accounting_period_lock ORDER BY period DESC`
+	if err := r.db.SelectContext(ctx, &locks, query); err != nil {
+		return nil, fmt.Errorf("failed to list accounting period locks: %w", err)
+	}
+	return locks, nil
+}
+
+func (r *accountingPeriodRepository) IsLocked(ctx context.Context, period string) (bool, error) {
+	var locked bool
+	query := `SELECT EXISTS (SELECT 1 FROM accounting_period_lock WHERE period = $1)`
+	if err := r.db.GetContext(ctx, &locked, query, period); err != nil {
+		return false, fmt.Errorf("failed to check accounting period lock: %w", err)
+	}
+	return locked, nil
+}
+
+func (r *accountingPeriodRepository) Lock(ctx context.Context, lock models.AccountingPeriodLock, event models.AccountingPeriodEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO accounting_period_lock (period, locked_at, locked_by)
+        VALUES (:period, :locked_at, :locked_by)
+        ON CONFLICT (period) DO NOTHING`
+	result, err := tx.NamedExecContext(ctx, query, lock)
+	if err != nil {
+		return fmt.Errorf("failed to lock accounting period: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("accounting period is already locked")
+	}
+
+	if err := insertAccountingPeriodEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *accountingPeriodRepository) Unlock(ctx context.Context, period string, event models.AccountingPeriodEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM accounting_period_lock WHERE period = $1`, period)
+	if err != nil {
+		return fmt.Errorf("failed to unlock accounting period: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("accounting period is not locked")
+	}
+
+	if err := insertAccountingPeriodEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *accountingPeriodRepository) ListEvents(ctx context.Context) ([]models.AccountingPeriodEvent, error) {
+	var events []models.AccountingPeriodEvent
+	query := `SELECT * FROM accounting_period_event ORDER BY occurred_at DESC`
+	if err := r.db.SelectContext(ctx, &events, query); err != nil {
+		return nil, fmt.Errorf("failed to list accounting period events: %w", err)
+	}
+	return events, nil
+}
+
+func insertAccountingPeriodEvent(ctx context.Context, tx *sqlx.Tx, event models.AccountingPeriodEvent) error {
+	query := `
+        INSERT INTO accounting_period_event (
+            event_id, period, action, user_id, reason, occurred_at
+        ) VALUES (
+            :event_id, :period, :action, :user_id, :reason, :occurred_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, event); err != nil {
+		return fmt.Errorf("failed to record accounting period event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type activityRepository struct {
+	db *sqlx.DB
+}
+
+func NewActivityRepository(db *sqlx.DB) repositories.ActivityRepository {
+	return &activityRepository{
+		db: db,
+	}
+}
+
+func (r *activityRepository) Record(ctx context.Context, event models.ActivityEvent) error {
+	if event.EventID == uuid.Nil {
+		event.EventID = uuid.New()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	query := `
+        INSERT INTO activity_event (
+            event_id, entity_type, entity_id, project_id, client_id, 
+            event_type, description, occurred_at, actor_id, impersonator_id
+        ) VALUES (
+            :event_id, :entity_type, :entity_id, :project_id, :client_id, 
+            :event_type, :description, :occurred_at, :actor_id, :impersonator_id
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, event); err != nil {
+		return fmt.Errorf("failed to record activity event: %w", err)
+	}
+	return nil
+}
+
+// projectDocumentEvents derives timeline rows from documents that carry
+// their own timestamps, for the projects selected by the given condition.
+// The recorded project_created events, kept for user activity, are left
+// out of timelines in favour of the row derived here.
+const projectDocumentEvents = `
+        SELECT 'project' AS entity_type, p.project_id AS entity_id, p.project_id, 
+            'project_created' AS event_type, 'Project ' || p.name || ' created' AS description, 
+            p.created_at AS occurred_at
+        FROM project p WHERE %[1]s
+        UNION ALL
+        SELECT 'contract', ct.contract_id, ct.project_id, 
+            'contract_uploaded', 'Contract uploaded', ct.created_at
+        FROM contract ct JOIN project p ON p.project_id = ct.project_id WHERE %[1]s
+        UNION ALL
+        SELECT 'invoice', i.invoice_id, i.project_id, 
+            'invoice_issued', 
+            'Invoice issued' || COALESCE(' for ' || to_char(i.amount, 'FM999,999,999,990.00') || ' THB', ''), 
+            i.created_at
+        FROM invoice i JOIN project p ON p.project_id = i.project_id WHERE %[1]s
+        UNION ALL
+        SELECT 'payment', pay.payment_id, i.project_id, 
+            'payment_received', 
+            'Payment of ' || to_char(pay.amount, 'FM999,999,999,990.00') || ' THB received by ' || pay.method, 
+            pay.paid_at
+        FROM payment pay 
+        JOIN invoice i ON i.invoice_id = pay.invoice_id 
+        JOIN project p ON p.project_id = i.project_id WHERE %[1]s`
+
+func (r *activityRepository) GetProjectTimeline(ctx context.Context, projectID uuid.UUID) ([]models.TimelineEvent, error) {
+	query := `
+        SELECT * FROM (
+            ` + fmt.Sprintf(projectDocumentEvents, "p.project_id = $1") + `
+            UNION ALL
+            SELECT entity_type, entity_id, project_id, event_type, description, occurred_at
+            FROM activity_event WHERE project_id = $1 AND event_type <> 'project_created'
+        ) timeline
+        ORDER BY occurred_at DESC`
+
+	var events []models.TimelineEvent
+	if err := r.db.SelectContext(ctx, &events, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get project timeline: %w", err)
+	}
+	return events, nil
+}
+
+func (r *activityRepository) GetClientTimeline(ctx context.Context, clientID uuid.UUID) ([]models.TimelineEvent, error) {
+	query := `
+        SELECT * FROM (
+            ` + fmt.Sprintf(projectDocumentEvents, "p.client_id = $1") + `
+            UNION ALL
+            SELECT ae.entity_type, ae.entity_id, ae.project_id, ae.event_type, ae.description, ae.occurred_at
+            FROM activity_event ae
+            WHERE (ae.client_id = $1
+            OR ae.client_id IN (SELECT source_client_id FROM client_merge WHERE target_client_id = $1)
+            OR ae.project_id IN (SELECT project_id FROM project WHERE client_id = $1)) 
+            AND ae.event_type <> 'project_created'
+        ) timeline
+        ORDER BY occurred_at DESC`
+
+	var events []models.TimelineEvent
+	if err := r.db.SelectContext(ctx, &events, query, clientID); err != nil {
+		return nil, fmt.Errorf("failed to get client timeline: %w", err)
+	}
+	return events, nil
+}
+
+func (r *activityRepository) GetQuotationTimeline(ctx context.Context, projectID uuid.UUID) ([]models.TimelineEvent, error) {
+	query := `
+        SELECT entity_type, entity_id, project_id, event_type, description, occurred_at
+        FROM activity_event 
+        WHERE project_id = $1 AND entity_type IN ('quotation', 'boq')
+        ORDER BY occurred_at DESC`
+
+	var events []models.TimelineEvent
+	if err := r.db.SelectContext(ctx, &events, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get quotation timeline: %w", err)
+	}
+	return events, nil
+}
+
+func (r *activityRepository) ListByActor(ctx context.Context, actorID uuid.UUID, limit, offset int) ([]models.ActivityEvent, int64, error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM activity_event WHERE actor_id = $1`, actorID); err != nil {
+		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	var events []models.ActivityEvent
+	query := `
+        SELECT * FROM activity_event 
+        WHERE actor_id = $1 
+        ORDER BY occurred_at DESC, event_id 
+        LIMIT $2 OFFSET $3`
+
+	if err := r.db.SelectContext(ctx, &events, query, actorID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list user activity: %w", err)
+	}
+	return events, total, nil
+}
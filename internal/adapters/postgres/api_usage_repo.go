@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type apiUsageRepository struct {
+	db *sqlx.DB
+}
+
+func NewAPIUsageRepository(db *sqlx.DB) repositories.APIUsageRepository {
+	return &apiUsageRepository{
+		db: db,
+	}
+}
+
+// AddUsage relies on a unique (usage_date, user_id, key_id, route)
+// constraint declared NULLS NOT DISTINCT, so anonymous and token callers
+// share one row per day and route.
+func (r *apiUsageRepository) AddUsage(ctx context.Context, usage []models.APIUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO api_usage_daily (
+            usage_date, user_id, key_id, route, request_count,
+            client_errors, server_errors, bytes_in, bytes_out
+        ) VALUES (
+            :usage_date, :user_id, :key_id, :route, :request_count,
+            :client_errors, :server_errors, :bytes_in, :bytes_out
+        )
+        ON CONFLICT (usage_date, user_id, key_id, route) DO UPDATE SET
+            request_count = api_usage_daily.request_count + EXCLUDED.request_count,
+            client_errors = api_usage_daily.client_errors + EXCLUDED.client_errors,
+            server_errors = api_usage_daily.server_errors + EXCLUDED.server_errors,
+            bytes_in = api_usage_daily.bytes_in + EXCLUDED.bytes_in,
+            bytes_out = api_usage_daily.bytes_out + EXCLUDED.bytes_out`
+
+	for _, u := range usage {
+		if _, err := tx.NamedExecContext(ctx, query, u); err != nil {
+			return fmt.Errorf("failed to record api usage: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *apiUsageRepository) ListUsage(ctx context.Context, from, to time.Time, grouping models.APIUsageGrouping) ([]models.APIUsage, error) {
+	// Callers and routes are ranked busiest first; days are in order.
+	var columns, order string
+	switch grouping {
+	case models.APIUsageByCaller:
+		columns, order = "user_id, key_id", "request_count DESC, user_id, key_id"
+	case models.APIUsageByRoute:
+		columns, order = "route", "request_count DESC, route"
+	default:
+		columns, order = "usage_date", "usage_date"
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s,
+            SUM(request_count) AS request_count,
+            SUM(client_errors) AS client_errors,
+            SUM(server_errors) AS server_errors,
+            SUM(bytes_in) AS bytes_in,
+            SUM(bytes_out) AS bytes_out
+        FROM api_usage_daily
+        WHERE usage_date BETWEEN $1 AND $2
+        GROUP BY %s
+        ORDER BY %s`, columns, columns, order)
+
+	var usage []models.APIUsage
+	if err := r.db.SelectContext(ctx, &usage, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to list api usage: %w", err)
+	}
+	return usage, nil
+}
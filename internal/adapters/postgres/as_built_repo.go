@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type asBuiltRepository struct {
+	db *sqlx.DB
+}
+
+func NewAsBuiltRepository(db *sqlx.DB) repositories.AsBuiltRepository {
+	return &asBuiltRepository{
+		db: db,
+	}
+}
+
+const asBuiltLineSelect = `
+        SELECT 
+            bj.boq_id, bj.job_id, j.name AS job_name, j.unit,
+            bj.quantity AS boq_quantity,
+            COALESCE(bj.selling_price, 0) AS unit_price,
+            bj.completed_at,
+            ab.quantity AS as_built_quantity,
+            ab.disposition, ab.change_order_id, ab.note, ab.recorded_at
+        FROM boq_job bj
+        JOIN job j ON j.job_id = bj.job_id
+        LEFT JOIN as_built_quantity ab ON ab.boq_id = bj.boq_id AND ab.job_id = bj.job_id`
+
+func (r *asBuiltRepository) ListLines(ctx context.Context, boqID uuid.UUID) ([]models.AsBuiltLine, error) {
+	var lines []models.AsBuiltLine
+	query := asBuiltLineSelect + `
+        WHERE bj.boq_id = $1
+        ORDER BY j.name`
+
+	if err := r.db.SelectContext(ctx, &lines, query, boqID); err != nil {
+		return nil, fmt.Errorf("failed to list as-built quantities: %w", err)
+	}
+	return lines, nil
+}
+
+func (r *asBuiltRepository) GetLine(ctx context.Context, boqID, jobID uuid.UUID) (*models.AsBuiltLine, error) {
+	var line models.AsBuiltLine
+	query := asBuiltLineSelect + ` WHERE bj.boq_id = $1 AND bj.job_id = $2`
+
+	if err := r.db.GetContext(ctx, &line, query, boqID, jobID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("job not found in BOQ")
+		}
+		return nil, fmt.Errorf("failed to get as-built quantity: %w", err)
+	}
+	return &line, nil
+}
+
+func (r *asBuiltRepository) Save(ctx context.Context, record models.AsBuiltQuantity) error {
+	query := `
+        INSERT INTO as_built_quantity (
+            boq_id, job_id, quantity, variation_value, disposition,
+            change_order_id, note, recorded_by, recorded_at
+        ) VALUES (
+            :boq_id, :job_id, :quantity, :variation_value, :disposition,
+            :change_order_id, :note, :recorded_by, :recorded_at
+        )
+        ON CONFLICT (boq_id, job_id) DO UPDATE SET 
+            quantity = EXCLUDED.quantity,
+            variation_value = EXCLUDED.variation_value,
+            disposition = EXCLUDED.disposition,
+            change_order_id = EXCLUDED.change_order_id,
+            note = EXCLUDED.note,
+            recorded_by = EXCLUDED.recorded_by,
+            recorded_at = EXCLUDED.recorded_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, record); err != nil {
+		return fmt.Errorf("failed to save as-built quantity: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type auditRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) repositories.AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+func (r *auditRepository) GetLastSegment(ctx context.Context) (*models.AuditSegment, error) {
+	var segment models.AuditSegment
+	query := `SELECT * FROM audit_segment ORDER BY segment_date DESC LIMIT 1`
+	if err := r.db.GetContext(ctx, &segment, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get audit segment: %w", err)
+	}
+	return &segment, nil
+}
+
+func (r *auditRepository) ListSegments(ctx context.Context, from, to time.Time) ([]models.AuditSegment, error) {
+	query := `
+        SELECT * FROM audit_segment
+        WHERE segment_date BETWEEN $1 AND $2
+        ORDER BY segment_date`
+
+	var segments []models.AuditSegment
+	if err := r.db.SelectContext(ctx, &segments, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to list audit segments: %w", err)
+	}
+	return segments, nil
+}
+
+func (r *auditRepository) GetSegmentBefore(ctx context.Context, date time.Time) (*models.AuditSegment, error) {
+	var segment models.AuditSegment
+	query := `SELECT * FROM audit_segment WHERE segment_date < $1 ORDER BY segment_date DESC LIMIT 1`
+	if err := r.db.GetContext(ctx, &segment, query, date); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get audit segment: %w", err)
+	}
+	return &segment, nil
+}
+
+func (r *auditRepository) CreateSegment(ctx context.Context, segment models.AuditSegment) error {
+	query := `
+        INSERT INTO audit_segment (
+            segment_date, event_count, events_hash, previous_hash, hash,
+            object_key, sealed_at
+        ) VALUES (
+            :segment_date, :event_count, :events_hash, :previous_hash, :hash,
+            :object_key, :sealed_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, segment); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("audit segment already sealed")
+		}
+		return fmt.Errorf("failed to create audit segment: %w", err)
+	}
+	return nil
+}
+
+func (r *auditRepository) ListEvents(ctx context.Context, from, to time.Time) ([]models.ActivityEvent, error) {
+	query := `
+        SELECT event_id, entity_type, entity_id, project_id, client_id,
+            event_type, description, occurred_at, impersonator_id
+        FROM activity_event
+        WHERE occurred_at >= $1 AND occurred_at < $2
+        ORDER BY occurred_at, event_id`
+
+	var events []models.ActivityEvent
+	if err := r.db.SelectContext(ctx, &events, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to list activity events: %w", err)
+	}
+	return events, nil
+}
+
+func (r *auditRepository) GetFirstEventTime(ctx context.Context) (time.Time, error) {
+	var first sql.NullTime
+	if err := r.db.GetContext(ctx, &first, `SELECT MIN(occurred_at) FROM activity_event`); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get first activity event: %w", err)
+	}
+	return first.Time, nil
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type backChargeRepository struct {
+	db *sqlx.DB
+}
+
+func NewBackChargeRepository(db *sqlx.DB) repositories.BackChargeRepository {
+	return &backChargeRepository{
+		db: db,
+	}
+}
+
+func (r *backChargeRepository) Create(ctx context.Context, backCharge models.BackCharge) error {
+	query := `
+        INSERT INTO back_charge (
+            back_charge_id, supplier_id, project_id, subcontract_id, category, 
+            description, amount, offset_amount, status, created_by, created_at, updated_at
+        ) VALUES (
+            :back_charge_id, :supplier_id, :project_id, :subcontract_id, :category, 
+            :description, :amount, :offset_amount, :status, :created_by, :created_at, :updated_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, backCharge); err != nil {
+		return fmt.Errorf("failed to create back-charge: %w", err)
+	}
+	return nil
+}
+
+func (r *backChargeRepository) GetByID(ctx context.Context, backChargeID uuid.UUID) (*models.BackCharge, error) {
+	var backCharge models.BackCharge
+	query := `SELECT * FROM back_charge WHERE back_charge_id = $1`
+
+	if err := r.db.GetContext(ctx, &backCharge, query, backChargeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("back-charge not found")
+		}
+		return nil, fmt.Errorf("failed to get back-charge: %w", err)
+	}
+	return &backCharge, nil
+}
+
+func (r *backChargeRepository) List(ctx context.Context, supplierID uuid.NullUUID, projectID uuid.NullUUID) ([]models.BackCharge, error) {
+	var backCharges []models.BackCharge
+	query := `
+        SELECT * FROM back_charge 
+        WHERE ($1::uuid IS NULL OR supplier_id = $1) 
+            AND ($2::uuid IS NULL OR project_id = $2)
+        ORDER BY created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &backCharges, query, supplierID, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list back-charges: %w", err)
+	}
+	return backCharges, nil
+}
+
+func (r *backChargeRepository) ListDeductible(ctx context.Context, supplierID uuid.UUID, subcontractID uuid.UUID) ([]models.BackCharge, error) {
+	var backCharges []models.BackCharge
+	query := `
+        SELECT * FROM back_charge 
+        WHERE supplier_id = $1 
+            AND status = 'open' 
+            AND offset_amount < amount
+            AND (subcontract_id IS NULL OR subcontract_id = $2)
+        ORDER BY created_at`
+
+	if err := r.db.SelectContext(ctx, &backCharges, query, supplierID, subcontractID); err != nil {
+		return nil, fmt.Errorf("failed to list deductible back-charges: %w", err)
+	}
+	return backCharges, nil
+}
+
+func (r *backChargeRepository) UpdateStatus(ctx context.Context, backCharge models.BackCharge) error {
+	// The offset guard keeps a concurrent deduction from being undercut by
+	// a reduced amount.
+	query := `
+        UPDATE back_charge SET 
+            status = :status,
+            amount = :amount,
+            dispute_reason = :dispute_reason,
+            disputed_by = :disputed_by,
+            resolution = :resolution,
+            updated_at = :updated_at
+        WHERE back_charge_id = :back_charge_id AND offset_amount <= :amount`
+
+	result, err := r.db.NamedExecContext(ctx, query, backCharge)
+	if err != nil {
+		return fmt.Errorf("failed to update back-charge: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("amount is less than already deducted")
+	}
+	return nil
+}
+
+func (r *backChargeRepository) ListOffsets(ctx context.Context, backChargeID uuid.UUID) ([]models.BackChargeOffset, error) {
+	var offsets []models.BackChargeOffset
+	query := `SELECT * FROM back_charge_offset WHERE back_charge_id = $1 ORDER BY created_at`
+
+	if err := r.db.SelectContext(ctx, &offsets, query, backChargeID); err != nil {
+		return nil, fmt.Errorf("failed to list back-charge offsets: %w", err)
+	}
+	return offsets, nil
+}
+
+func (r *backChargeRepository) AddAttachment(ctx context.Context, attachment models.BackChargeAttachment) error {
+	query := `
+        INSERT INTO back_charge_attachment (
+            attachment_id, back_charge_id, title, file_url, uploaded_by, created_at
+        ) VALUES (
+            :attachment_id, :back_charge_id, :title, :file_url, :uploaded_by, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, attachment); err != nil {
+		return fmt.Errorf("failed to add attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *backChargeRepository) ListAttachments(ctx context.Context, backChargeID uuid.UUID) ([]models.BackChargeAttachment, error) {
+	var attachments []models.BackChargeAttachment
+	query := `SELECT * FROM back_charge_attachment WHERE back_charge_id = $1 ORDER BY created_at`
+
+	if err := r.db.SelectContext(ctx, &attachments, query, backChargeID); err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *backChargeRepository) DeleteAttachment(ctx context.Context, backChargeID uuid.UUID, attachmentID uuid.UUID) error {
+	query := `DELETE FROM back_charge_attachment WHERE back_charge_id = $1 AND attachment_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, backChargeID, attachmentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("attachment not found")
+	}
+	return nil
+}
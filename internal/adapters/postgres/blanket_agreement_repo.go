@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type blanketAgreementRepository struct {
+	db *sqlx.DB
+}
+
+func NewBlanketAgreementRepository(db *sqlx.DB) repositories.BlanketAgreementRepository {
+	return &blanketAgreementRepository{
+		db: db,
+	}
+}
+
+const blanketAgreementSummaryQuery = `
+        SELECT ba.*, s.name AS supplier_name
+        FROM blanket_agreement ba
+        JOIN supplier s ON s.supplier_id = ba.supplier_id`
+
+func (r *blanketAgreementRepository) Create(ctx context.Context, agreement models.BlanketAgreement, items []models.BlanketAgreementItem) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO blanket_agreement (
+            agreement_id, supplier_id, reference, valid_from, valid_to, note, created_by, created_at
+        ) VALUES (
+            :agreement_id, :supplier_id, :reference, :valid_from, :valid_to, :note, :created_by, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, agreement); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("agreement reference already exists")
+		}
+		return fmt.Errorf("failed to create blanket agreement: %w", err)
+	}
+
+	query = `
+        INSERT INTO blanket_agreement_item (
+            item_id, agreement_id, material_id, unit_price, committed_quantity
+        ) VALUES (
+            :item_id, :agreement_id, :material_id, :unit_price, :committed_quantity
+        )`
+	for _, item := range items {
+		if _, err := tx.NamedExecContext(ctx, query, item); err != nil {
+			return fmt.Errorf("failed to create blanket agreement item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *blanketAgreementRepository) GetByID(ctx context.Context, agreementID uuid.UUID) (*models.BlanketAgreementSummary, error) {
+	var agreement models.BlanketAgreementSummary
+	query := blanketAgreementSummaryQuery + ` WHERE ba.agreement_id = $1`
+
+	if err := r.db.GetContext(ctx, &agreement, query, agreementID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("blanket agreement not found")
+		}
+		return nil, fmt.Errorf("failed to get blanket agreement: %w", err)
+	}
+	return &agreement, nil
+}
+
+func (r *blanketAgreementRepository) List(ctx context.Context, supplierID uuid.NullUUID, validOn sql.NullTime) ([]models.BlanketAgreementSummary, error) {
+	var agreements []models.BlanketAgreementSummary
+	query := blanketAgreementSummaryQuery + `
+        WHERE ($1::uuid IS NULL OR ba.supplier_id = $1)
+            AND ($2::date IS NULL OR $2::date BETWEEN ba.valid_from AND ba.valid_to)
+        ORDER BY ba.valid_from DESC, ba.created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &agreements, query, supplierID, validOn); err != nil {
+		return nil, fmt.Errorf("failed to list blanket agreements: %w", err)
+	}
+	return agreements, nil
+}
+
+func (r *blanketAgreementRepository) ListBalances(ctx context.Context, agreementID uuid.UUID) ([]models.BlanketAgreementBalance, error) {
+	var balances []models.BlanketAgreementBalance
+	query := `
+        SELECT i.*, COALESCE((
+            SELECT SUM(a.quantity)
+            FROM purchase_order po
+            JOIN purchase_order_line l ON l.po_id = po.po_id
+            JOIN purchase_order_allocation a ON a.line_id = l.line_id
+            WHERE po.agreement_id = i.agreement_id 
+                AND l.material_id = i.material_id
+                AND po.status <> 'cancelled'
+        ), 0) AS released_quantity
+        FROM blanket_agreement_item i
+        WHERE i.agreement_id = $1
+        ORDER BY i.material_id`
+
+	if err := r.db.SelectContext(ctx, &balances, query, agreementID); err != nil {
+		return nil, fmt.Errorf("failed to list blanket agreement balances: %w", err)
+	}
+	return balances, nil
+}
+
+func (r *blanketAgreementRepository) ListExpiring(ctx context.Context, from, to time.Time) ([]models.BlanketAgreementSummary, error) {
+	var agreements []models.BlanketAgreementSummary
+	query := blanketAgreementSummaryQuery + `
+        WHERE ba.valid_to BETWEEN $1 AND $2
+        ORDER BY ba.valid_to, s.name`
+
+	if err := r.db.SelectContext(ctx, &agreements, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to list expiring blanket agreements: %w", err)
+	}
+	return agreements, nil
+}
@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type boqPriceUpdateRepository struct {
+	db *sqlx.DB
+}
+
+func NewBOQPriceUpdateRepository(db *sqlx.DB) repositories.BOQPriceUpdateRepository {
+	return &boqPriceUpdateRepository{
+		db: db,
+	}
+}
+
+// openBOQCondition matches BOQs whose estimated total can still change
+// what the client is quoted.
+const openBOQCondition = `(b.status = 'draft' OR q.status = 'draft')`
+
+func (r *boqPriceUpdateRepository) ListOpenBOQsUsingMaterial(ctx context.Context, materialID string) ([]uuid.UUID, error) {
+	query := `
+        SELECT DISTINCT b.boq_id
+        FROM material_price_log mpl
+        JOIN boq b ON b.boq_id = mpl.boq_id
+        LEFT JOIN quotation q ON q.project_id = b.project_id
+        WHERE mpl.material_id = $1
+        AND ` + openBOQCondition
+
+	var boqIDs []uuid.UUID
+	err := r.db.SelectContext(ctx, &boqIDs, query, materialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BOQs using material: %w", err)
+	}
+
+	return boqIDs, nil
+}
+
+func (r *boqPriceUpdateRepository) IsBOQOpen(ctx context.Context, boqID uuid.UUID) (bool, error) {
+	query := `
+        SELECT ` + openBOQCondition + `
+        FROM boq b
+        LEFT JOIN quotation q ON q.project_id = b.project_id
+        WHERE b.boq_id = $1`
+
+	var open sql.NullBool
+	err := r.db.GetContext(ctx, &open, query, boqID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, errors.New("BOQ not found")
+		}
+		return false, fmt.Errorf("failed to get BOQ status: %w", err)
+	}
+
+	return open.Bool, nil
+}
+
+func (r *boqPriceUpdateRepository) GetEstimatedTotal(ctx context.Context, boqID uuid.UUID) (float64, error) {
+	query := `
+        WITH MaterialTotals AS (
+            SELECT 
+                job_id, 
+                SUM(COALESCE(estimated_price, 0) * COALESCE(quantity, 0)) AS total_material_price
+            FROM material_price_log
+            WHERE boq_id = $1
+            GROUP BY job_id
+        )
+        SELECT COALESCE(SUM((COALESCE(mt.total_material_price, 0) + COALESCE(bj.labor_cost, 0)) * bj.quantity), 0)
+        FROM boq_job bj
+        LEFT JOIN MaterialTotals mt ON mt.job_id = bj.job_id
+        WHERE bj.boq_id = $1`
+
+	var total float64
+	err := r.db.GetContext(ctx, &total, query, boqID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get BOQ estimated total: %w", err)
+	}
+
+	return total, nil
+}
+
+func (r *boqPriceUpdateRepository) GetPriceChanges(ctx context.Context, boqID uuid.UUID) ([]models.BOQPriceChange, error) {
+	// A material only counts as changed if it was bought after it was
+	// estimated, so re-estimating it at the new price clears the change.
+	query := `
+        WITH boq_material AS (
+            SELECT 
+                mpl.material_id,
+                SUM(COALESCE(mpl.quantity, 0) * bj.quantity) AS quantity,
+                SUM(COALESCE(mpl.estimated_price, 0) * COALESCE(mpl.quantity, 0) * bj.quantity) AS estimated_amount,
+                MAX(mpl.estimated_at) AS estimated_at
+            FROM material_price_log mpl
+            JOIN boq_job bj ON bj.boq_id = mpl.boq_id AND bj.job_id = mpl.job_id
+            WHERE mpl.boq_id = $1
+            GROUP BY mpl.material_id
+        ),
+        latest AS (
+            SELECT DISTINCT ON (material_id) material_id, actual_price, supplier_id, updated_at
+            FROM material_price_log
+            WHERE actual_price IS NOT NULL 
+            AND updated_at IS NOT NULL
+            AND material_id IN (SELECT material_id FROM boq_material)
+            ORDER BY material_id, updated_at DESC
+        )
+        SELECT 
+            bm.material_id,
+            m.name AS material_name,
+            bm.quantity,
+            bm.estimated_amount / bm.quantity AS estimated_price,
+            l.actual_price AS latest_price,
+            l.supplier_id,
+            COALESCE(s.name, '') AS supplier_name,
+            l.updated_at AS priced_at,
+            l.actual_price * bm.quantity - bm.estimated_amount AS delta
+        FROM boq_material bm
+        JOIN latest l ON l.material_id = bm.material_id
+        JOIN material m ON m.material_id = bm.material_id
+        LEFT JOIN supplier s ON s.supplier_id = l.supplier_id
+        WHERE bm.quantity > 0
+        AND (bm.estimated_at IS NULL OR l.updated_at > bm.estimated_at)
+        AND ROUND((l.actual_price * bm.quantity - bm.estimated_amount)::numeric, 2) <> 0
+        ORDER BY m.name`
+
+	var changes []models.BOQPriceChange
+	err := r.db.SelectContext(ctx, &changes, query, boqID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BOQ price changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (r *boqPriceUpdateRepository) Save(ctx context.Context, update *models.BOQPriceUpdate) error {
+	query := `
+        INSERT INTO boq_price_update (
+            boq_id, estimated_total, recalculated_total, delta, changes, flagged_at
+        ) VALUES (
+            :boq_id, :estimated_total, :recalculated_total, :delta, :changes, :flagged_at
+        )
+        ON CONFLICT (boq_id) DO UPDATE SET
+            estimated_total = EXCLUDED.estimated_total,
+            recalculated_total = EXCLUDED.recalculated_total,
+            delta = EXCLUDED.delta,
+            flagged_at = CASE WHEN boq_price_update.changes = EXCLUDED.changes 
+                THEN boq_price_update.flagged_at ELSE EXCLUDED.flagged_at END,
+            acknowledged_at = CASE WHEN boq_price_update.changes = EXCLUDED.changes 
+                THEN boq_price_update.acknowledged_at END,
+            acknowledged_by = CASE WHEN boq_price_update.changes = EXCLUDED.changes 
+                THEN boq_price_update.acknowledged_by END,
+            changes = EXCLUDED.changes`
+
+	_, err := r.db.NamedExecContext(ctx, query, update)
+	if err != nil {
+		return fmt.Errorf("failed to save BOQ price update: %w", err)
+	}
+
+	return nil
+}
+
+func (r *boqPriceUpdateRepository) Delete(ctx context.Context, boqID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM boq_price_update WHERE boq_id = $1`, boqID)
+	if err != nil {
+		return fmt.Errorf("failed to delete BOQ price update: %w", err)
+	}
+
+	return nil
+}
+
+const boqPriceUpdateSelect = `
+        SELECT 
+            u.boq_id,
+            b.project_id,
+            p.name AS project_name,
+            q.quotation_id,
+            u.estimated_total,
+            u.recalculated_total,
+            u.delta,
+            u.changes,
+            u.flagged_at,
+            u.acknowledged_at,
+            u.acknowledged_by
+        FROM boq_price_update u
+        JOIN boq b ON b.boq_id = u.boq_id
+        JOIN project p ON p.project_id = b.project_id
+        LEFT JOIN quotation q ON q.project_id = b.project_id`
+
+func (r *boqPriceUpdateRepository) GetByBOQID(ctx context.Context, boqID uuid.UUID) (*models.BOQPriceUpdate, error) {
+	query := boqPriceUpdateSelect + `
+        WHERE u.boq_id = $1`
+
+	update := &models.BOQPriceUpdate{}
+	err := r.db.GetContext(ctx, update, query, boqID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("price update not found")
+		}
+		return nil, fmt.Errorf("failed to get BOQ price update: %w", err)
+	}
+
+	return update, nil
+}
+
+func (r *boqPriceUpdateRepository) List(ctx context.Context, includeAcknowledged bool) ([]models.BOQPriceUpdate, error) {
+	query := boqPriceUpdateSelect + `
+        WHERE ($1 OR u.acknowledged_at IS NULL)
+        AND ` + openBOQCondition + `
+        ORDER BY u.flagged_at DESC`
+
+	var updates []models.BOQPriceUpdate
+	err := r.db.SelectContext(ctx, &updates, query, includeAcknowledged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BOQ price updates: %w", err)
+	}
+
+	return updates, nil
+}
+
+func (r *boqPriceUpdateRepository) Acknowledge(ctx context.Context, boqID uuid.UUID, userID uuid.UUID) error {
+	query := `
+        UPDATE boq_price_update 
+        SET acknowledged_at = CURRENT_TIMESTAMP, acknowledged_by = $2
+        WHERE boq_id = $1 AND acknowledged_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, boqID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge BOQ price update: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("price update not found")
+	}
+
+	return nil
+}
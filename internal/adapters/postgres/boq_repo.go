@@ -42,6 +42,7 @@ func (r *boqRepository) Approve(ctx context.Context, boqID uuid.UUID) error {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 
 	}
+	defer tx.Rollback()
 
 	// Check BOQ status
 	var status string
@@ -66,6 +67,10 @@ func (r *boqRepository) Approve(ctx context.Context, boqID uuid.UUID) error {
 		return fmt.Errorf("failed to update BOQ status: %w", err)
 	}
 
+	if err := snapshotSupplierNames(ctx, tx, boqID); err != nil {
+		return err
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -74,6 +79,23 @@ func (r *boqRepository) Approve(ctx context.Context, boqID uuid.UUID) error {
 	return nil
 }
 
+// snapshotSupplierNames copies the current name of each material's
+// supplier onto the BOQ's price log, so the approved BOQ and its quotation
+// keep showing it after the supplier is renamed, blacklisted or deleted.
+func snapshotSupplierNames(ctx context.Context, tx *sqlx.Tx, boqID uuid.UUID) error {
+	query := `
+        UPDATE material_price_log mpl
+        SET supplier_name_snapshot = s.name
+        FROM supplier s
+        WHERE s.supplier_id = mpl.supplier_id
+            AND mpl.boq_id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, boqID); err != nil {
+		return fmt.Errorf("failed to snapshot supplier names: %w", err)
+	}
+	return nil
+}
+
 func (r *boqRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.BOQ, error) {
 	var boq models.BOQ
 	query := `SELECT * FROM boq WHERE project_id = $1`
@@ -141,10 +163,12 @@ func (r *boqRepository) GetBoqWithProject(ctx context.Context, projectID uuid.UU
 
 	jobsQuery := `
    SELECT DISTINCT
-	j.*, bj.quantity, bj.labor_cost
+	j.*, bj.quantity, bj.labor_cost, bj.completed_at, bj.phase_id, ph.name as phase_name, ph.sort_order
 FROM job j
 JOIN boq_job bj ON j.job_id = bj.job_id
+LEFT JOIN project_phase ph ON ph.phase_id = bj.phase_id
 WHERE bj.boq_id = $1
+ORDER BY ph.sort_order NULLS LAST
 `
 
 	type BoqJobData struct {
@@ -154,6 +178,10 @@ WHERE bj.boq_id = $1
 		Unit        string         `db:"unit"`
 		Quantity    float64        `db:"quantity"`
 		LaborCost   float64        `db:"labor_cost"`
+		CompletedAt sql.NullTime   `db:"completed_at"`
+		PhaseID     uuid.NullUUID  `db:"phase_id"`
+		PhaseName   sql.NullString `db:"phase_name"`
+		SortOrder   sql.NullInt64  `db:"sort_order"`
 	}
 
 	var jobs []BoqJobData
@@ -163,16 +191,35 @@ WHERE bj.boq_id = $1
 		return nil, fmt.Errorf("failed to get jobs: %w", err)
 	}
 
+	var drawings []models.BOQJobDrawing
+	drawingsQuery := `SELECT * FROM boq_job_drawing WHERE boq_id = $1 ORDER BY created_at`
+	if err := tx.SelectContext(ctx, &drawings, drawingsQuery, data.BOQID); err != nil {
+		return nil, fmt.Errorf("failed to get job drawings: %w", err)
+	}
+	drawingsByJob := make(map[uuid.UUID][]responses.BOQJobDrawingResponse)
+	for _, d := range drawings {
+		drawingsByJob[d.JobID] = append(drawingsByJob[d.JobID], responses.NewBOQJobDrawingResponse(d))
+	}
+
 	var jobForResponse []responses.JobResponse
 	for _, job := range jobs {
-		jobForResponse = append(jobForResponse, responses.JobResponse{
+		jobResponse := responses.JobResponse{
 			JobID:       job.JobID,
 			Name:        job.Name,
 			Description: job.Description.String,
 			Unit:        job.Unit,
 			Quantity:    job.Quantity,
 			LaborCost:   job.LaborCost,
-		})
+			PhaseName:   job.PhaseName.String,
+			Drawings:    drawingsByJob[job.JobID],
+		}
+		if job.PhaseID.Valid {
+			jobResponse.PhaseID = &job.PhaseID.UUID
+		}
+		if job.CompletedAt.Valid {
+			jobResponse.CompletedAt = &job.CompletedAt.Time
+		}
+		jobForResponse = append(jobForResponse, jobResponse)
 	}
 
 	response.Jobs = jobForResponse
@@ -226,9 +273,9 @@ func (r *boqRepository) AddBOQJob(ctx context.Context, boqID uuid.UUID, req requ
 	// Insert into boq_job
 	insertBOQJobQuery := `
         INSERT INTO boq_job (
-            boq_id, job_id, quantity, labor_cost
+            boq_id, job_id, quantity, labor_cost, phase_id
         ) VALUES (
-            $1, $2, $3, $4
+            $1, $2, $3, $4, $5
         )`
 
 	_, err = tx.ExecContext(ctx, insertBOQJobQuery,
@@ -236,6 +283,7 @@ func (r *boqRepository) AddBOQJob(ctx context.Context, boqID uuid.UUID, req requ
 		req.JobID,
 		req.Quantity,
 		req.LaborCost,
+		req.PhaseID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to add job to BOQ: %w", err)
@@ -397,6 +445,11 @@ func (r *boqRepository) DeleteBOQJob(ctx context.Context, boqID uuid.UUID, jobID
 		return fmt.Errorf("failed to delete material price logs: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `DELETE FROM boq_job_drawing WHERE boq_id = $1 AND job_id = $2`, boqID, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to delete job drawings: %w", err)
+	}
+
 	// Then delete the BOQ job
 	deleteBOQJobQuery := `
         DELETE FROM boq_job 
@@ -457,6 +510,9 @@ func (r *boqRepository) GetBOQDetails(ctx context.Context, projectID uuid.UUID)
             p.address, 
 			j.job_id,
             j.name as job_name, 
+            bj.phase_id,
+            ph.name as phase_name,
+            ph.sort_order as phase_sort_order,
             j.description, 
             bj.quantity, 
             j.unit, 
@@ -471,10 +527,13 @@ func (r *boqRepository) GetBOQDetails(ctx context.Context, projectID uuid.UUID)
         JOIN boq_job bj ON bj.boq_id = b.boq_id 
         JOIN job j ON j.job_id = bj.job_id 
         LEFT JOIN MaterialTotals mt ON mt.job_id = bj.job_id AND mt.boq_id = bj.boq_id 
+        LEFT JOIN project_phase ph ON ph.phase_id = bj.phase_id
         WHERE p.project_id = $1 
         GROUP BY 
             p.name, p.address, j.job_id, j.name, j.description, 
-            bj.quantity, j.unit, bj.labor_cost, mt.total_material_price`
+            bj.quantity, j.unit, bj.labor_cost, mt.total_material_price,
+            bj.phase_id, ph.name, ph.sort_order
+        ORDER BY ph.sort_order NULLS LAST, j.name`
 
 	var details []models.BOQDetails
 	err := r.db.SelectContext(ctx, &details, query, projectID)
@@ -494,7 +553,10 @@ func (r *boqRepository) GetBOQMaterialDetails(ctx context.Context, projectID uui
             mpl.quantity, 
             m.unit, 
             mpl.estimated_price, 
-            COALESCE(mpl.quantity, 0) * COALESCE(mpl.estimated_price, 0) as total
+            COALESCE(mpl.quantity, 0) * COALESCE(mpl.estimated_price, 0) as total,
+            th.name as thai_name,
+            en.name as english_name,
+            COALESCE(mpl.supplier_name_snapshot, s.name) as supplier_name
         FROM project p 
         JOIN boq b ON b.project_id = p.project_id 
         LEFT JOIN client c ON c.client_id = p.project_id 
@@ -502,6 +564,9 @@ func (r *boqRepository) GetBOQMaterialDetails(ctx context.Context, projectID uui
         JOIN job j ON j.job_id = bj.job_id 
         LEFT JOIN material_price_log mpl ON mpl.job_id = bj.job_id AND mpl.boq_id = bj.boq_id 
         JOIN material m ON m.material_id = mpl.material_id 
+        LEFT JOIN material_alias th ON th.material_id = m.material_id AND th.language = 'th' AND th.display 
+        LEFT JOIN material_alias en ON en.material_id = m.material_id AND en.language = 'en' AND en.display 
+        LEFT JOIN supplier s ON s.supplier_id = mpl.supplier_id
         WHERE p.project_id = $1`
 
 	var details []models.BOQMaterialDetails
@@ -512,3 +577,92 @@ func (r *boqRepository) GetBOQMaterialDetails(ctx context.Context, projectID uui
 
 	return details, nil
 }
+
+// CompleteBOQJob marks a job in an approved BOQ as done.
+func (r *boqRepository) CompleteBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) error {
+	var status string
+	err := r.db.GetContext(ctx, &status, `SELECT status FROM boq WHERE boq_id = $1`, boqID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("boq not found")
+		}
+		return fmt.Errorf("failed to get BOQ status: %w", err)
+	}
+
+	if status != "approved" {
+		return errors.New("can only complete jobs in an approved BOQ")
+	}
+
+	query := `
+        UPDATE boq_job SET completed_at = CURRENT_TIMESTAMP 
+        WHERE boq_id = $1 AND job_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, boqID, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to complete BOQ job: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("job not found in BOQ")
+	}
+
+	return nil
+}
+
+func (r *boqRepository) AddJobDrawing(ctx context.Context, drawing *models.BOQJobDrawing) error {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM boq_job WHERE boq_id = $1 AND job_id = $2)`
+	if err := r.db.GetContext(ctx, &exists, query, drawing.BOQID, drawing.JobID); err != nil {
+		return fmt.Errorf("failed to check BOQ job: %w", err)
+	}
+	if !exists {
+		return errors.New("job not found in BOQ")
+	}
+
+	query = `
+        INSERT INTO boq_job_drawing (
+            drawing_id, boq_id, job_id, title, drawing_number, revision, source, url, created_at
+        ) VALUES (
+            :drawing_id, :boq_id, :job_id, :title, :drawing_number, :revision, :source, :url, :created_at
+        )`
+	if _, err := r.db.NamedExecContext(ctx, query, drawing); err != nil {
+		return fmt.Errorf("failed to add job drawing: %w", err)
+	}
+
+	return nil
+}
+
+func (r *boqRepository) ListJobDrawings(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) ([]models.BOQJobDrawing, error) {
+	var drawings []models.BOQJobDrawing
+	query := `SELECT * FROM boq_job_drawing WHERE boq_id = $1 AND job_id = $2 ORDER BY created_at`
+
+	if err := r.db.SelectContext(ctx, &drawings, query, boqID, jobID); err != nil {
+		return nil, fmt.Errorf("failed to get job drawings: %w", err)
+	}
+
+	return drawings, nil
+}
+
+func (r *boqRepository) DeleteJobDrawing(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, drawingID uuid.UUID) error {
+	query := `DELETE FROM boq_job_drawing WHERE drawing_id = $1 AND boq_id = $2 AND job_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, drawingID, boqID, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to delete job drawing: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("drawing not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type claimRepository struct {
+	db *sqlx.DB
+}
+
+func NewClaimRepository(db *sqlx.DB) repositories.ClaimRepository {
+	return &claimRepository{
+		db: db,
+	}
+}
+
+func (r *claimRepository) Create(ctx context.Context, claim models.Claim) error {
+	query := `
+        INSERT INTO claim (
+            claim_id, project_id, contract_id, document_id, claim_type, title, 
+            narrative, claimed_amount, claimed_days, status, created_by, created_at, updated_at
+        ) VALUES (
+            :claim_id, :project_id, :contract_id, :document_id, :claim_type, :title, 
+            :narrative, :claimed_amount, :claimed_days, :status, :created_by, :created_at, :updated_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, claim); err != nil {
+		return fmt.Errorf("failed to create claim: %w", err)
+	}
+	return nil
+}
+
+func (r *claimRepository) GetByID(ctx context.Context, claimID uuid.UUID) (*models.Claim, error) {
+	var claim models.Claim
+	query := `SELECT * FROM claim WHERE claim_id = $1`
+
+	if err := r.db.GetContext(ctx, &claim, query, claimID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("claim not found")
+		}
+		return nil, fmt.Errorf("failed to get claim: %w", err)
+	}
+	return &claim, nil
+}
+
+func (r *claimRepository) ListByProject(ctx context.Context, projectID uuid.UUID, status string) ([]models.Claim, error) {
+	var claims []models.Claim
+	query := `
+        SELECT * FROM claim 
+        WHERE project_id = $1 
+            AND ($2 = '' OR status = $2)
+        ORDER BY created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &claims, query, projectID, status); err != nil {
+		return nil, fmt.Errorf("failed to list claims: %w", err)
+	}
+	return claims, nil
+}
+
+func (r *claimRepository) Update(ctx context.Context, claim models.Claim) error {
+	query := `
+        UPDATE claim SET 
+            document_id = :document_id,
+            claim_type = :claim_type,
+            title = :title,
+            narrative = :narrative,
+            claimed_amount = :claimed_amount,
+            claimed_days = :claimed_days,
+            status = :status,
+            settlement_amount = :settlement_amount,
+            settlement_days = :settlement_days,
+            resolution = :resolution,
+            submitted_at = :submitted_at,
+            closed_at = :closed_at,
+            updated_at = :updated_at
+        WHERE claim_id = :claim_id 
+            AND status NOT IN ('settled', 'rejected', 'withdrawn')`
+
+	result, err := r.db.NamedExecContext(ctx, query, claim)
+	if err != nil {
+		return fmt.Errorf("failed to update claim: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("claim is already closed")
+	}
+	return nil
+}
+
+func (r *claimRepository) Delete(ctx context.Context, claimID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM claim_document WHERE claim_id = $1`, claimID); err != nil {
+		return fmt.Errorf("failed to delete claim documents: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM claim WHERE claim_id = $1 AND status = 'draft'`, claimID)
+	if err != nil {
+		return fmt.Errorf("failed to delete claim: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("only draft claims can be deleted")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *claimRepository) AddDocument(ctx context.Context, document models.ClaimDocument) error {
+	query := `
+        INSERT INTO claim_document (
+            document_id, claim_id, title, file_url, uploaded_by, created_at
+        ) VALUES (
+            :document_id, :claim_id, :title, :file_url, :uploaded_by, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, document); err != nil {
+		return fmt.Errorf("failed to add claim document: %w", err)
+	}
+	return nil
+}
+
+func (r *claimRepository) ListDocuments(ctx context.Context, claimID uuid.UUID) ([]models.ClaimDocument, error) {
+	var documents []models.ClaimDocument
+	query := `SELECT * FROM claim_document WHERE claim_id = $1 ORDER BY created_at`
+
+	if err := r.db.SelectContext(ctx, &documents, query, claimID); err != nil {
+		return nil, fmt.Errorf("failed to list claim documents: %w", err)
+	}
+	return documents, nil
+}
+
+func (r *claimRepository) DeleteDocument(ctx context.Context, claimID uuid.UUID, documentID uuid.UUID) error {
+	query := `DELETE FROM claim_document WHERE claim_id = $1 AND document_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, claimID, documentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete claim document: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("claim document not found")
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -68,16 +69,20 @@ func (r *clientRepository) Update(ctx context.Context, id uuid.UUID, req request
             email = :email,
             tel = :tel,
             address = :address,
-            tax_id = :tax_id
+            tax_id = :tax_id,
+            credit_limit = :credit_limit,
+            payment_terms_days = :payment_terms_days
         WHERE client_id = :client_id`
 
 	params := map[string]interface{}{
-		"client_id": id,
-		"name":      req.Name,
-		"email":     req.Email,
-		"tel":       req.Tel,
-		"address":   req.Address,
-		"tax_id":    req.TaxID,
+		"client_id":          id,
+		"name":               req.Name,
+		"email":              req.Email,
+		"tel":                req.Tel,
+		"address":            req.Address,
+		"tax_id":             req.TaxID,
+		"credit_limit":       req.CreditLimit,
+		"payment_terms_days": req.PaymentTermsDays,
 	}
 
 	result, err := r.db.NamedExecContext(ctx, query, params)
@@ -101,49 +106,46 @@ func (r *clientRepository) Update(ctx context.Context, id uuid.UUID, req request
 }
 
 func (r *clientRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.setArchived(ctx, id, true)
+}
 
-	checkUsageQuery := `
-		SELECT p.name as project_name
-		FROM Client c 
-		JOIN Project p ON c.client_id = p.client_id 
-		WHERE c.client_id = $1`
-
-	type ProjectUsage struct {
-		ProjectName string `db:"project_name"`
-	}
-	var projects []ProjectUsage
-
-	err := r.db.SelectContext(ctx, &projects, checkUsageQuery, id)
-	if err != nil {
-		return fmt.Errorf("failed to check client usage: %w", err)
-	}
-
-	if len(projects) > 0 {
-		var projectNames []string
-		for _, project := range projects {
-			projectNames = append(projectNames, project.ProjectName)
-		}
-		return fmt.Errorf("client is currently used in following projects: %s. Please remove client from these projects before deletion",
-			strings.Join(projectNames, ", "))
-	}
+func (r *clientRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.setArchived(ctx, id, false)
+}
 
-	query := `DELETE FROM Client WHERE client_id = $1`
+// setArchived archives or restores a client. Its projects, contacts and
+// history are left alone either way.
+func (r *clientRepository) setArchived(ctx context.Context, id uuid.UUID, archived bool) error {
+	query := `
+        UPDATE Client SET 
+            archived_at = CASE WHEN $2 THEN CURRENT_TIMESTAMP END
+        WHERE client_id = $1 
+            AND (archived_at IS NULL) = $2`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, id, archived)
 	if err != nil {
-		return fmt.Errorf("failed to delete client: %w", err)
+		return fmt.Errorf("failed to update client archive: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("failed to get affected rows: %w", err)
 	}
+	if rows > 0 {
+		return nil
+	}
 
-	if rows == 0 {
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM Client WHERE client_id = $1)`, id); err != nil {
+		return fmt.Errorf("failed to check client: %w", err)
+	}
+	if !exists {
 		return errors.New("client not found")
 	}
-
-	return nil
+	if archived {
+		return errors.New("client is already archived")
+	}
+	return errors.New("client is not archived")
 }
 
 func (r *clientRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Client, error) {
@@ -176,24 +178,440 @@ func (r *clientRepository) GetByEmail(ctx context.Context, email string) (*model
 	return client, nil
 }
 
-func (r *clientRepository) List(ctx context.Context, limit, offset int) ([]models.Client, int64, error) {
+func (r *clientRepository) List(ctx context.Context, filter models.ClientFilter, limit, offset int) ([]models.Client, int64, error) {
 	var clients []models.Client
 	var total int64
 
-	countQuery := `SELECT COUNT(*) FROM Client`
-	err := r.db.GetContext(ctx, &total, countQuery)
-	if err != nil {
+	where := `
+        WHERE ($1 = '' OR name ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%'
+                OR tel ILIKE '%' || $1 || '%')
+            AND ($2 = '' OR tax_id = $2)
+            AND ($3 OR archived_at IS NULL)`
+	args := []interface{}{filter.Search, filter.TaxID, filter.IncludeArchived}
+
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM Client`+where, args...); err != nil {
 		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
 	}
 
-	query := `
-        SELECT * FROM Client 
-        LIMIT $1 OFFSET $2`
+	// The sort column comes from ClientSortColumns, never from the caller,
+	// so it is safe to format into the query. client_id breaks ties so
+	// pages don't overlap.
+	column, ok := models.ClientSortColumns[filter.SortBy]
+	if !ok {
+		column = "name"
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
 
-	err = r.db.SelectContext(ctx, &clients, query, limit, offset)
-	if err != nil {
+	query := `SELECT * FROM Client` + where + fmt.Sprintf(`
+        ORDER BY %s %s, client_id
+        LIMIT $4 OFFSET $5`, column, direction)
+	if err := r.db.SelectContext(ctx, &clients, query, append(args, limit, offset)...); err != nil {
 		return nil, 0, fmt.Errorf("failed to list clients: %w", err)
 	}
 
 	return clients, total, nil
 }
+
+func (r *clientRepository) SetCreditHold(ctx context.Context, id uuid.UUID, reason string) error {
+	query := `
+        UPDATE Client SET 
+            credit_hold = true,
+            credit_hold_auto = false,
+            credit_hold_reason = $1,
+            credit_hold_at = CURRENT_TIMESTAMP
+        WHERE client_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, reason, id)
+	if err != nil {
+		return fmt.Errorf("failed to set credit hold: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("client not found")
+	}
+
+	return nil
+}
+
+func (r *clientRepository) ReleaseCreditHold(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE Client SET 
+            credit_hold = false,
+            credit_hold_auto = false,
+            credit_hold_reason = NULL,
+            credit_hold_at = NULL,
+            credit_hold_released_at = CURRENT_TIMESTAMP
+        WHERE client_id = $1 AND credit_hold = true`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to release credit hold: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("client is not on credit hold")
+	}
+
+	return nil
+}
+
+// ApplyOverdueCreditHolds puts every client with an invoice unpaid for more
+// than overdueDays on hold. An invoice that was already overdue when a hold
+// was last released doesn't trigger a new hold.
+func (r *clientRepository) ApplyOverdueCreditHolds(ctx context.Context, overdueDays int) ([]models.Client, error) {
+	query := `
+        WITH overdue AS (
+            SELECT 
+                p.client_id,
+                MAX(i.created_at + make_interval(days => $1)) AS overdue_since
+            FROM invoice i
+            JOIN project p ON p.project_id = i.project_id
+            LEFT JOIN (
+                SELECT invoice_id, SUM(amount) AS paid
+                FROM payment
+                GROUP BY invoice_id
+            ) pay ON pay.invoice_id = i.invoice_id
+            WHERE i.amount IS NOT NULL
+            AND i.amount > COALESCE(pay.paid, 0)
+            AND i.created_at + make_interval(days => $1) < CURRENT_TIMESTAMP
+            GROUP BY p.client_id
+        )
+        UPDATE Client c SET 
+            credit_hold = true,
+            credit_hold_auto = true,
+            credit_hold_reason = 'invoice overdue more than ' || $1 || ' days',
+            credit_hold_at = CURRENT_TIMESTAMP
+        FROM overdue o
+        WHERE c.client_id = o.client_id
+        AND c.credit_hold = false
+        AND (c.credit_hold_released_at IS NULL OR c.credit_hold_released_at < o.overdue_since)
+        RETURNING c.*`
+
+	var clients []models.Client
+	err := r.db.SelectContext(ctx, &clients, query, overdueDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply overdue credit holds: %w", err)
+	}
+
+	return clients, nil
+}
+
+func (r *clientRepository) OutstandingBalance(ctx context.Context, id uuid.UUID) (float64, error) {
+	var balance float64
+	query := `
+        SELECT COALESCE(SUM(i.amount - COALESCE(pay.paid, 0)), 0)
+        FROM invoice i
+        JOIN project p ON p.project_id = i.project_id
+        LEFT JOIN (
+            SELECT invoice_id, SUM(amount) AS paid
+            FROM payment
+            GROUP BY invoice_id
+        ) pay ON pay.invoice_id = i.invoice_id
+        WHERE p.client_id = $1
+        AND i.amount IS NOT NULL
+        AND i.amount > COALESCE(pay.paid, 0)`
+
+	if err := r.db.GetContext(ctx, &balance, query, id); err != nil {
+		return 0, fmt.Errorf("failed to get client outstanding balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+func (r *clientRepository) CountProjects(ctx context.Context, id uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM Project WHERE client_id = $1`
+
+	if err := r.db.GetContext(ctx, &count, query, id); err != nil {
+		return 0, fmt.Errorf("failed to count client projects: %w", err)
+	}
+
+	return count, nil
+}
+
+// Merge re-parents the source client's projects (and with them their
+// invoices) onto merged.ClientID, records the merge so the source's
+// activity shows on the surviving client, deletes the source and saves
+// the merged field values on the surviving client.
+func (r *clientRepository) Merge(ctx context.Context, sourceID uuid.UUID, merged models.Client) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked []uuid.UUID
+	lockQuery := `SELECT client_id FROM Client WHERE client_id IN ($1, $2) FOR UPDATE`
+	if err := tx.SelectContext(ctx, &locked, lockQuery, sourceID, merged.ClientID); err != nil {
+		return fmt.Errorf("failed to lock clients: %w", err)
+	}
+	if len(locked) != 2 {
+		return errors.New("client not found")
+	}
+
+	// Quotations and invoices belong to projects, so they move with them.
+	moveProjectsQuery := `UPDATE Project SET client_id = $1 WHERE client_id = $2`
+	if _, err := tx.ExecContext(ctx, moveProjectsQuery, merged.ClientID, sourceID); err != nil {
+		return fmt.Errorf("failed to move client projects: %w", err)
+	}
+
+	// Activity events are sealed into the audit chain, so they keep the
+	// source's ID and the merge is recorded alongside them instead. Clients
+	// merged into the source earlier now lead to the target too.
+	moveMergesQuery := `UPDATE client_merge SET target_client_id = $1 WHERE target_client_id = $2`
+	if _, err := tx.ExecContext(ctx, moveMergesQuery, merged.ClientID, sourceID); err != nil {
+		return fmt.Errorf("failed to move earlier client merges: %w", err)
+	}
+
+	mergeQuery := `
+        INSERT INTO client_merge (source_client_id, target_client_id, merged_at)
+        VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, mergeQuery, sourceID, merged.ClientID, time.Now()); err != nil {
+		return fmt.Errorf("failed to record client merge: %w", err)
+	}
+
+	// The source's contacts join the target's, which keeps its own primary
+	// contact.
+	moveContactsQuery := `UPDATE client_contacts SET client_id = $1, is_primary = false WHERE client_id = $2`
+	if _, err := tx.ExecContext(ctx, moveContactsQuery, merged.ClientID, sourceID); err != nil {
+		return fmt.Errorf("failed to move client contacts: %w", err)
+	}
+
+	moveDocumentsQuery := `UPDATE client_documents SET client_id = $1 WHERE client_id = $2`
+	if _, err := tx.ExecContext(ctx, moveDocumentsQuery, merged.ClientID, sourceID); err != nil {
+		return fmt.Errorf("failed to move client documents: %w", err)
+	}
+
+	// The source goes first so the target can take over its email.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Client WHERE client_id = $1`, sourceID); err != nil {
+		return fmt.Errorf("failed to delete merged client: %w", err)
+	}
+
+	updateQuery := `
+        UPDATE Client SET 
+            name = :name,
+            email = :email,
+            tel = :tel,
+            address = :address,
+            tax_id = :tax_id,
+            credit_limit = :credit_limit,
+            payment_terms_days = :payment_terms_days,
+            credit_hold = :credit_hold,
+            credit_hold_auto = :credit_hold_auto,
+            credit_hold_reason = :credit_hold_reason,
+            credit_hold_at = :credit_hold_at,
+            credit_hold_released_at = :credit_hold_released_at
+        WHERE client_id = :client_id`
+
+	if _, err := tx.NamedExecContext(ctx, updateQuery, merged); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("client with this email already exists")
+		}
+		return fmt.Errorf("failed to update merged client: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *clientRepository) ListContacts(ctx context.Context, clientID uuid.UUID) ([]models.ClientContact, error) {
+	var contacts []models.ClientContact
+	query := `
+        SELECT * FROM client_contacts 
+        WHERE client_id = $1 
+        ORDER BY is_primary DESC, name`
+
+	if err := r.db.SelectContext(ctx, &contacts, query, clientID); err != nil {
+		return nil, fmt.Errorf("failed to list client contacts: %w", err)
+	}
+	return contacts, nil
+}
+
+func (r *clientRepository) GetContact(ctx context.Context, clientID, contactID uuid.UUID) (*models.ClientContact, error) {
+	var contact models.ClientContact
+	query := `SELECT * FROM client_contacts WHERE client_id = $1 AND contact_id = $2`
+	if err := r.db.GetContext(ctx, &contact, query, clientID, contactID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("contact not found")
+		}
+		return nil, fmt.Errorf("failed to get client contact: %w", err)
+	}
+	return &contact, nil
+}
+
+func (r *clientRepository) CreateContact(ctx context.Context, contact models.ClientContact) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if contact.IsPrimary {
+		if err := clearPrimaryContact(ctx, tx, contact.ClientID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+        INSERT INTO client_contacts (
+            contact_id, client_id, name, role, email, tel, is_primary, created_at
+        ) VALUES (
+            :contact_id, :client_id, :name, :role, :email, :tel, :is_primary, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, contact); err != nil {
+		return fmt.Errorf("failed to create client contact: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *clientRepository) UpdateContact(ctx context.Context, contact models.ClientContact) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if contact.IsPrimary {
+		if err := clearPrimaryContact(ctx, tx, contact.ClientID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+        UPDATE client_contacts SET 
+            name = :name,
+            role = :role,
+            email = :email,
+            tel = :tel,
+            is_primary = :is_primary,
+            updated_at = :updated_at
+        WHERE client_id = :client_id AND contact_id = :contact_id`
+	result, err := tx.NamedExecContext(ctx, query, contact)
+	if err != nil {
+		return fmt.Errorf("failed to update client contact: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("contact not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *clientRepository) DeleteContact(ctx context.Context, clientID, contactID uuid.UUID) error {
+	query := `DELETE FROM client_contacts WHERE client_id = $1 AND contact_id = $2`
+	result, err := r.db.ExecContext(ctx, query, clientID, contactID)
+	if err != nil {
+		return fmt.Errorf("failed to delete client contact: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("contact not found")
+	}
+	return nil
+}
+
+func (r *clientRepository) ListDocuments(ctx context.Context, clientID uuid.UUID) ([]models.ClientDocument, error) {
+	var documents []models.ClientDocument
+	query := `
+        SELECT * FROM client_documents 
+        WHERE client_id = $1 
+        ORDER BY created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &documents, query, clientID); err != nil {
+		return nil, fmt.Errorf("failed to list client documents: %w", err)
+	}
+	return documents, nil
+}
+
+func (r *clientRepository) GetDocumentByID(ctx context.Context, documentID uuid.UUID) (*models.ClientDocument, error) {
+	var document models.ClientDocument
+	query := `SELECT * FROM client_documents WHERE document_id = $1`
+	if err := r.db.GetContext(ctx, &document, query, documentID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("document not found")
+		}
+		return nil, fmt.Errorf("failed to get client document: %w", err)
+	}
+	return &document, nil
+}
+
+func (r *clientRepository) GetDocument(ctx context.Context, clientID, documentID uuid.UUID) (*models.ClientDocument, error) {
+	var document models.ClientDocument
+	query := `SELECT * FROM client_documents WHERE client_id = $1 AND document_id = $2`
+	if err := r.db.GetContext(ctx, &document, query, clientID, documentID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("document not found")
+		}
+		return nil, fmt.Errorf("failed to get client document: %w", err)
+	}
+	return &document, nil
+}
+
+func (r *clientRepository) CreateDocument(ctx context.Context, document models.ClientDocument) error {
+	query := `
+        INSERT INTO client_documents (
+            document_id, client_id, document_type, file_name, content_type,
+            size, storage_key, note, uploaded_by, created_at
+        ) VALUES (
+            :document_id, :client_id, :document_type, :file_name, :content_type,
+            :size, :storage_key, :note, :uploaded_by, :created_at
+        )`
+	if _, err := r.db.NamedExecContext(ctx, query, document); err != nil {
+		return fmt.Errorf("failed to create client document: %w", err)
+	}
+	return nil
+}
+
+func (r *clientRepository) DeleteDocument(ctx context.Context, clientID, documentID uuid.UUID) error {
+	query := `DELETE FROM client_documents WHERE client_id = $1 AND document_id = $2`
+	result, err := r.db.ExecContext(ctx, query, clientID, documentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete client document: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("document not found")
+	}
+	return nil
+}
+
+func clearPrimaryContact(ctx context.Context, tx *sqlx.Tx, clientID uuid.UUID) error {
+	query := `UPDATE client_contacts SET is_primary = false WHERE client_id = $1 AND is_primary`
+	if _, err := tx.ExecContext(ctx, query, clientID); err != nil {
+		return fmt.Errorf("failed to clear primary contact: %w", err)
+	}
+	return nil
+}
@@ -93,12 +93,13 @@ func (r *companyRepository) GetOrCreateCompanyByUserID(ctx context.Context, user
 		Tel:       "",
 		Address:   addressJSON,
 		TaxID:     "",
+		DateEra:   models.DateEraGregorian,
 	}
 
 	// Insert new company
 	insertCompanyQuery := `
-        INSERT INTO company (company_id, name, email, tel, address, tax_id)
-        VALUES (:company_id, :name, :email, :tel, :address, :tax_id)
+        INSERT INTO company (company_id, name, email, tel, address, tax_id, date_era)
+        VALUES (:company_id, :name, :email, :tel, :address, :tax_id, :date_era)
         RETURNING *`
 
 	rows, err := r.db.NamedQueryContext(ctx, insertCompanyQuery, newCompany)
@@ -136,7 +137,9 @@ func (r *companyRepository) UpdateCompany(ctx context.Context, company *models.C
             email = :email,
             tel = :tel,
             address = :address,
-            tax_id = :tax_id
+            tax_id = :tax_id,
+            promptpay_id = :promptpay_id,
+            date_era = :date_era
         WHERE company_id = :company_id`
 
 	result, err := r.db.NamedExecContext(ctx, query, company)
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type cubeTestRepository struct {
+	db *sqlx.DB
+}
+
+func NewCubeTestRepository(db *sqlx.DB) repositories.CubeTestRepository {
+	return &cubeTestRepository{
+		db: db,
+	}
+}
+
+func (r *cubeTestRepository) Create(ctx context.Context, test models.CubeTest) error {
+	query := `
+        INSERT INTO cube_test (
+            test_id, pour_id, project_id, job_id, sample_reference, sample_date,
+            specified_strength, strength_7_day, strength_28_day, lab, note, created_at
+        ) VALUES (
+            :test_id, :pour_id, :project_id, :job_id, :sample_reference, :sample_date,
+            :specified_strength, :strength_7_day, :strength_28_day, :lab, :note, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, test); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("sample reference already recorded for this pour")
+		}
+		return fmt.Errorf("failed to create cube test: %w", err)
+	}
+	return nil
+}
+
+const cubeTestDetailSelect = `
+        SELECT ct.*, cp.ticket_number, j.name AS job_name, p.name AS project_name
+        FROM cube_test ct
+        JOIN concrete_pour cp ON cp.pour_id = ct.pour_id
+        JOIN job j ON j.job_id = ct.job_id
+        JOIN project p ON p.project_id = ct.project_id`
+
+func (r *cubeTestRepository) GetByID(ctx context.Context, testID uuid.UUID) (*models.CubeTestDetail, error) {
+	var test models.CubeTestDetail
+	if err := r.db.GetContext(ctx, &test, cubeTestDetailSelect+` WHERE ct.test_id = $1`, testID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("cube test not found")
+		}
+		return nil, fmt.Errorf("failed to get cube test: %w", err)
+	}
+	return &test, nil
+}
+
+func (r *cubeTestRepository) List(ctx context.Context, filter models.CubeTestFilter) ([]models.CubeTestDetail, error) {
+	var tests []models.CubeTestDetail
+	query := cubeTestDetailSelect + `
+        WHERE (($1::uuid IS NULL AND p.status NOT IN ('completed', 'cancelled')) OR ct.project_id = $1)
+            AND ($2::uuid IS NULL OR ct.pour_id = $2)
+            AND ($3::uuid IS NULL OR ct.job_id = $3)
+        ORDER BY ct.sample_date DESC, ct.sample_reference`
+
+	if err := r.db.SelectContext(ctx, &tests, query, filter.ProjectID, filter.PourID, filter.JobID); err != nil {
+		return nil, fmt.Errorf("failed to list cube tests: %w", err)
+	}
+	return tests, nil
+}
+
+func (r *cubeTestRepository) UpdateResults(ctx context.Context, test models.CubeTest) error {
+	query := `
+        UPDATE cube_test SET 
+            strength_7_day = :strength_7_day,
+            strength_28_day = :strength_28_day,
+            lab = :lab,
+            note = :note,
+            updated_at = :updated_at
+        WHERE test_id = :test_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, test)
+	if err != nil {
+		return fmt.Errorf("failed to update cube test: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("cube test not found")
+	}
+	return nil
+}
+
+func (r *cubeTestRepository) Delete(ctx context.Context, testID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM cube_test WHERE test_id = $1`, testID)
+	if err != nil {
+		return fmt.Errorf("failed to delete cube test: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("cube test not found")
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type concretePourRepository struct {
+	db *sqlx.DB
+}
+
+func NewConcretePourRepository(db *sqlx.DB) repositories.ConcretePourRepository {
+	return &concretePourRepository{
+		db: db,
+	}
+}
+
+func (r *concretePourRepository) Create(ctx context.Context, pour models.ConcretePour) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var onBOQ bool
+	err = tx.GetContext(ctx, &onBOQ, `
+        SELECT EXISTS (
+            SELECT 1 FROM boq_job WHERE boq_id = $1 AND job_id = $2
+        )`, pour.BOQID, pour.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to check BOQ job: %w", err)
+	}
+	if !onBOQ {
+		return errors.New("job is not on the project's BOQ")
+	}
+
+	query := `
+        INSERT INTO concrete_pour (
+            pour_id, project_id, boq_id, job_id, supplier_id, material_id,
+            ticket_number, pour_date, volume, slump_mm, location, note,
+            created_by, created_at
+        ) VALUES (
+            :pour_id, :project_id, :boq_id, :job_id, :supplier_id, :material_id,
+            :ticket_number, :pour_date, :volume, :slump_mm, :location, :note,
+            :created_by, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, pour); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("ticket number already recorded for this supplier")
+		}
+		return fmt.Errorf("failed to create concrete pour: %w", err)
+	}
+
+	for _, reference := range pour.CubeReferences {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO concrete_pour_cube (pour_id, reference) VALUES ($1, $2)`,
+			pour.PourID, reference)
+		if err != nil {
+			return fmt.Errorf("failed to add cube reference: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+const concretePourDetailSelect = `
+        SELECT cp.*, j.name AS job_name, s.name AS supplier_name
+        FROM concrete_pour cp
+        JOIN job j ON j.job_id = cp.job_id
+        JOIN supplier s ON s.supplier_id = cp.supplier_id`
+
+func (r *concretePourRepository) GetByID(ctx context.Context, pourID uuid.UUID) (*models.ConcretePourDetail, error) {
+	var pour models.ConcretePourDetail
+	err := r.db.GetContext(ctx, &pour, concretePourDetailSelect+` WHERE cp.pour_id = $1`, pourID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("concrete pour not found")
+		}
+		return nil, fmt.Errorf("failed to get concrete pour: %w", err)
+	}
+
+	err = r.db.SelectContext(ctx, &pour.CubeReferences, `
+        SELECT reference FROM concrete_pour_cube WHERE pour_id = $1 ORDER BY reference`, pourID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cube references: %w", err)
+	}
+	return &pour, nil
+}
+
+func (r *concretePourRepository) ListByProject(ctx context.Context, projectID uuid.UUID, jobID uuid.NullUUID) ([]models.ConcretePourDetail, error) {
+	var pours []models.ConcretePourDetail
+	query := concretePourDetailSelect + `
+        WHERE cp.project_id = $1 AND ($2::uuid IS NULL OR cp.job_id = $2)
+        ORDER BY cp.pour_date DESC, cp.created_at DESC`
+	if err := r.db.SelectContext(ctx, &pours, query, projectID, jobID); err != nil {
+		return nil, fmt.Errorf("failed to list concrete pours: %w", err)
+	}
+
+	var cubes []struct {
+		PourID    uuid.UUID `db:"pour_id"`
+		Reference string    `db:"reference"`
+	}
+	err := r.db.SelectContext(ctx, &cubes, `
+        SELECT c.pour_id, c.reference
+        FROM concrete_pour_cube c
+        JOIN concrete_pour cp ON cp.pour_id = c.pour_id
+        WHERE cp.project_id = $1
+        ORDER BY c.reference`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cube references: %w", err)
+	}
+	byPour := make(map[uuid.UUID][]string)
+	for _, c := range cubes {
+		byPour[c.PourID] = append(byPour[c.PourID], c.Reference)
+	}
+	for i := range pours {
+		pours[i].CubeReferences = byPour[pours[i].PourID]
+	}
+
+	return pours, nil
+}
+
+func (r *concretePourRepository) Delete(ctx context.Context, pourID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var tested bool
+	err = tx.GetContext(ctx, &tested, `SELECT EXISTS (SELECT 1 FROM cube_test WHERE pour_id = $1)`, pourID)
+	if err != nil {
+		return fmt.Errorf("failed to check cube tests: %w", err)
+	}
+	if tested {
+		return errors.New("concrete pour has cube tests")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM concrete_pour_cube WHERE pour_id = $1`, pourID); err != nil {
+		return fmt.Errorf("failed to delete cube references: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM concrete_pour WHERE pour_id = $1`, pourID)
+	if err != nil {
+		return fmt.Errorf("failed to delete concrete pour: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("concrete pour not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *concretePourRepository) Summarize(ctx context.Context, projectID uuid.UUID) ([]models.ConcretePourSummary, error) {
+	var summaries []models.ConcretePourSummary
+	// The planned volume of a job is its BOQ quantity of each concrete
+	// material that was poured into it: the quantity per job times the
+	// job's quantity.
+	query := `
+        WITH Poured AS (
+            SELECT 
+                cp.boq_id, cp.job_id,
+                COUNT(*) AS pours,
+                SUM(cp.volume) AS poured_volume,
+                COUNT(*) FILTER (WHERE NOT EXISTS (
+                    SELECT 1 FROM concrete_pour_cube c WHERE c.pour_id = cp.pour_id
+                )) AS pours_without_cubes
+            FROM concrete_pour cp
+            WHERE cp.project_id = $1
+            GROUP BY cp.boq_id, cp.job_id
+        ), Planned AS (
+            SELECT mpl.boq_id, mpl.job_id, SUM(mpl.quantity * bj.quantity) AS planned_volume
+            FROM material_price_log mpl
+            JOIN boq_job bj ON bj.boq_id = mpl.boq_id AND bj.job_id = mpl.job_id
+            WHERE mpl.material_id IN (
+                SELECT DISTINCT material_id FROM concrete_pour
+                WHERE project_id = $1 AND material_id IS NOT NULL
+            )
+            GROUP BY mpl.boq_id, mpl.job_id
+        )
+        SELECT 
+            p.boq_id, p.job_id, j.name AS job_name,
+            p.pours, p.poured_volume, p.pours_without_cubes,
+            COALESCE(pl.planned_volume, 0) AS planned_volume
+        FROM Poured p
+        JOIN job j ON j.job_id = p.job_id
+        LEFT JOIN Planned pl ON pl.boq_id = p.boq_id AND pl.job_id = p.job_id
+        ORDER BY j.name`
+
+	if err := r.db.SelectContext(ctx, &summaries, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to summarize concrete pours: %w", err)
+	}
+	return summaries, nil
+}
@@ -117,3 +117,85 @@ func (r *contractRepository) GetByProjectID(ctx context.Context, projectID uuid.
 	}
 	return &contract, nil
 }
+
+func (r *contractRepository) CreateDocument(ctx context.Context, document *models.ContractDocument) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serialise uploads per project so versions stay gapless.
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('contract_document:' || $1))`,
+		document.ProjectID.String()); err != nil {
+		return fmt.Errorf("failed to lock contract documents: %w", err)
+	}
+
+	err = tx.GetContext(ctx, &document.Version, `
+		SELECT COALESCE(MAX(version), 0) + 1
+		FROM contract_document
+		WHERE project_id = $1 AND document_type = $2`,
+		document.ProjectID, document.DocumentType)
+	if err != nil {
+		return fmt.Errorf("failed to get next document version: %w", err)
+	}
+
+	if document.SupersedesID.Valid {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE contract_document SET superseded_by = $1
+			WHERE document_id = $2 AND project_id = $3 AND superseded_by IS NULL`,
+			document.DocumentID, document.SupersedesID.UUID, document.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to supersede contract document: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rows == 0 {
+			return errors.New("document already superseded")
+		}
+	}
+
+	query := `
+		INSERT INTO contract_document (
+			document_id, project_id, document_type, version, title, file_url,
+			effective_date, contract_value, value_adjustment, supersedes_id,
+			superseded_by, created_at
+		) VALUES (
+			:document_id, :project_id, :document_type, :version, :title, :file_url,
+			:effective_date, :contract_value, :value_adjustment, :supersedes_id,
+			:superseded_by, :created_at
+		)`
+	if _, err := tx.NamedExecContext(ctx, query, document); err != nil {
+		return fmt.Errorf("failed to create contract document: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *contractRepository) GetDocument(ctx context.Context, projectID uuid.UUID, documentID uuid.UUID) (*models.ContractDocument, error) {
+	var document models.ContractDocument
+	query := `SELECT * FROM contract_document WHERE project_id = $1 AND document_id = $2`
+	err := r.db.GetContext(ctx, &document, query, projectID, documentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("contract document not found")
+		}
+		return nil, fmt.Errorf("failed to get contract document: %w", err)
+	}
+	return &document, nil
+}
+
+func (r *contractRepository) ListDocuments(ctx context.Context, projectID uuid.UUID) ([]models.ContractDocument, error) {
+	var documents []models.ContractDocument
+	query := `
+		SELECT * FROM contract_document
+		WHERE project_id = $1
+		ORDER BY effective_date, document_type, version`
+	if err := r.db.SelectContext(ctx, &documents, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list contract documents: %w", err)
+	}
+	return documents, nil
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type crewRepository struct {
+	db *sqlx.DB
+}
+
+func NewCrewRepository(db *sqlx.DB) repositories.CrewRepository {
+	return &crewRepository{
+		db: db,
+	}
+}
+
+func (r *crewRepository) Create(ctx context.Context, req requests.CreateCrewRequest) (*models.Crew, error) {
+	crew := &models.Crew{
+		CrewID:    uuid.New(),
+		Name:      req.Name,
+		Capacity:  req.Capacity,
+		CreatedAt: time.Now(),
+	}
+
+	query := `
+        INSERT INTO crew (
+            crew_id, name, capacity, created_at
+        ) VALUES (
+            :crew_id, :name, :capacity, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, crew); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return nil, errors.New("crew with this name already exists")
+		}
+		return nil, fmt.Errorf("failed to create crew: %w", err)
+	}
+
+	return crew, nil
+}
+
+func (r *crewRepository) GetByID(ctx context.Context, crewID uuid.UUID) (*models.Crew, error) {
+	var crew models.Crew
+	query := `SELECT * FROM crew WHERE crew_id = $1`
+
+	err := r.db.GetContext(ctx, &crew, query, crewID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("crew not found")
+		}
+		return nil, fmt.Errorf("failed to get crew: %w", err)
+	}
+
+	return &crew, nil
+}
+
+func (r *crewRepository) List(ctx context.Context) ([]models.Crew, error) {
+	var crews []models.Crew
+	query := `SELECT * FROM crew ORDER BY name`
+
+	if err := r.db.SelectContext(ctx, &crews, query); err != nil {
+		return nil, fmt.Errorf("failed to list crews: %w", err)
+	}
+
+	return crews, nil
+}
+
+func (r *crewRepository) Update(ctx context.Context, crewID uuid.UUID, req requests.UpdateCrewRequest) error {
+	query := `
+        UPDATE crew SET 
+            name = $1,
+            capacity = $2,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE crew_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, req.Name, req.Capacity, crewID)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("crew with this name already exists")
+		}
+		return fmt.Errorf("failed to update crew: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("crew not found")
+	}
+
+	return nil
+}
+
+// Delete removes a crew and unassigns it from its tasks.
+func (r *crewRepository) Delete(ctx context.Context, crewID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE schedule_task SET crew_id = NULL WHERE crew_id = $1`, crewID); err != nil {
+		return fmt.Errorf("failed to unassign crew tasks: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM crew WHERE crew_id = $1`, crewID)
+	if err != nil {
+		return fmt.Errorf("failed to delete crew: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("crew not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *crewRepository) GetOverAllocations(ctx context.Context, from, to time.Time) ([]models.CrewAllocation, error) {
+	query := `
+        WITH daily AS (
+            SELECT 
+                t.crew_id,
+                d::date AS work_date,
+                t.task_id,
+                t.name AS task_name,
+                t.workers,
+                t.project_id
+            FROM schedule_task t
+            CROSS JOIN LATERAL generate_series(
+                GREATEST(t.start_date, $1::date),
+                LEAST(t.end_date, $2::date),
+                interval '1 day'
+            ) d
+            WHERE t.crew_id IS NOT NULL
+                AND t.start_date <= $2::date
+                AND t.end_date >= $1::date
+        ),
+        totals AS (
+            SELECT 
+                daily.*,
+                SUM(daily.workers) OVER (PARTITION BY daily.crew_id, daily.work_date) AS allocated
+            FROM daily
+        )
+        SELECT 
+            c.crew_id,
+            c.name AS crew_name,
+            c.capacity,
+            t.work_date,
+            t.allocated,
+            t.task_id,
+            t.task_name,
+            t.workers,
+            t.project_id,
+            p.name AS project_name
+        FROM totals t
+        JOIN crew c ON c.crew_id = t.crew_id
+        JOIN project p ON p.project_id = t.project_id
+        WHERE t.allocated > c.capacity
+        ORDER BY t.work_date, c.name, p.name, t.task_name`
+
+	var allocations []models.CrewAllocation
+	if err := r.db.SelectContext(ctx, &allocations, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get crew over-allocations: %w", err)
+	}
+
+	return allocations, nil
+}
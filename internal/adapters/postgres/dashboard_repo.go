@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type dashboardRepository struct {
+	db *sqlx.DB
+}
+
+func NewDashboardRepository(db *sqlx.DB) repositories.DashboardRepository {
+	return &dashboardRepository{
+		db: db,
+	}
+}
+
+func (r *dashboardRepository) GetPortfolioProjects(ctx context.Context, asOf time.Time) ([]models.PortfolioProject, error) {
+	return r.selectPortfolioProjects(ctx, asOf, uuid.NullUUID{})
+}
+
+func (r *dashboardRepository) GetPortfolioProject(ctx context.Context, projectID uuid.UUID, asOf time.Time) (*models.PortfolioProject, error) {
+	projects, err := r.selectPortfolioProjects(ctx, asOf, uuid.NullUUID{UUID: projectID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return nil, errors.New("project not found")
+	}
+	return &projects[0], nil
+}
+
+// Actual cost only counts jobs whose actual material prices have been
+// recorded, so it grows as purchasing is entered.
+func (r *dashboardRepository) selectPortfolioProjects(ctx context.Context, asOf time.Time, projectID uuid.NullUUID) ([]models.PortfolioProject, error) {
+	query := `
+        WITH MaterialTotals AS (
+            SELECT 
+                boq_id,
+                job_id,
+                SUM(estimated_price * quantity) AS estimated,
+                SUM(actual_price * quantity) AS actual
+            FROM material_price_log 
+            GROUP BY boq_id, job_id
+        ), JobTotals AS (
+            SELECT 
+                bj.boq_id,
+                SUM(bj.selling_price * bj.quantity) AS selling,
+                SUM(bj.selling_price * bj.quantity) FILTER (WHERE bj.completed_at IS NOT NULL) AS earned,
+                SUM((COALESCE(mt.estimated, 0) + bj.labor_cost) * bj.quantity) AS estimated_cost,
+                SUM((mt.actual + bj.labor_cost) * bj.quantity) FILTER (WHERE mt.actual IS NOT NULL) AS actual_cost
+            FROM boq_job bj
+            LEFT JOIN MaterialTotals mt ON mt.boq_id = bj.boq_id AND mt.job_id = bj.job_id
+            GROUP BY bj.boq_id
+        ), GeneralCost AS (
+            SELECT 
+                boq_id,
+                SUM(estimated_cost) AS estimated,
+                SUM(actual_cost) AS actual
+            FROM general_cost 
+            GROUP BY boq_id
+        ), Invoiced AS (
+            SELECT project_id, SUM(amount) AS amount 
+            FROM invoice 
+            GROUP BY project_id
+        ), Received AS (
+            SELECT i.project_id, SUM(pm.amount) AS amount 
+            FROM payment pm
+            JOIN invoice i ON i.invoice_id = pm.invoice_id
+            GROUP BY i.project_id
+        ), Schedule AS (
+            SELECT 
+                project_id,
+                SUM(workers * (end_date - start_date + 1)) AS planned,
+                SUM(workers * (LEAST(end_date, $1::date) - start_date + 1)) FILTER (WHERE start_date <= $1::date) AS elapsed
+            FROM schedule_task 
+            GROUP BY project_id
+        ), OpenClaims AS (
+            SELECT 
+                project_id,
+                COUNT(*) AS claims,
+                SUM(claimed_amount) AS amount,
+                SUM(claimed_days) AS days
+            FROM claim 
+            WHERE status = 'submitted'
+            GROUP BY project_id
+        )
+        SELECT 
+            p.project_id,
+            p.name,
+            p.status,
+            p.created_at,
+            EXISTS (SELECT 1 FROM contract c WHERE c.project_id = p.project_id) AS contracted,
+            COALESCE(jt.selling, 0) + COALESCE(b.selling_general_cost, 0) AS selling_price,
+            COALESCE(jt.earned, 0) AS earned_value,
+            COALESCE(jt.estimated_cost, 0) + COALESCE(gc.estimated, 0) AS estimated_cost,
+            COALESCE(jt.actual_cost, 0) + COALESCE(gc.actual, 0) AS actual_cost,
+            COALESCE(inv.amount, 0) AS invoiced,
+            COALESCE(rc.amount, 0) AS received,
+            COALESCE(s.planned, 0) AS planned_worker_days,
+            COALESCE(s.elapsed, 0) AS elapsed_worker_days,
+            COALESCE(oc.claims, 0) AS open_claims,
+            COALESCE(oc.amount, 0) AS open_claim_amount,
+            COALESCE(oc.days, 0) AS open_claim_days
+        FROM project p
+        LEFT JOIN boq b ON b.project_id = p.project_id
+        LEFT JOIN JobTotals jt ON jt.boq_id = b.boq_id
+        LEFT JOIN GeneralCost gc ON gc.boq_id = b.boq_id
+        LEFT JOIN Invoiced inv ON inv.project_id = p.project_id
+        LEFT JOIN Received rc ON rc.project_id = p.project_id
+        LEFT JOIN Schedule s ON s.project_id = p.project_id
+        LEFT JOIN OpenClaims oc ON oc.project_id = p.project_id
+        WHERE p.status <> 'cancelled'
+            AND ($2::uuid IS NULL OR p.project_id = $2)
+        ORDER BY p.created_at`
+
+	var projects []models.PortfolioProject
+	if err := r.db.SelectContext(ctx, &projects, query, asOf, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get portfolio projects: %w", err)
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type deliveryRepository struct {
+	db *sqlx.DB
+}
+
+func NewDeliveryRepository(db *sqlx.DB) repositories.DeliveryRepository {
+	return &deliveryRepository{
+		db: db,
+	}
+}
+
+func (r *deliveryRepository) CreateRate(ctx context.Context, rate *models.DeliveryRate) error {
+	query := `
+        INSERT INTO delivery_rate (
+            rate_id, name, postal_prefix, province, percent_of_material,
+            minimum_per_line, created_at
+        ) VALUES (
+            :rate_id, :name, :postal_prefix, :province, :percent_of_material,
+            :minimum_per_line, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, rate); err != nil {
+		return fmt.Errorf("failed to create delivery rate: %w", err)
+	}
+
+	return nil
+}
+
+func (r *deliveryRepository) GetRateByID(ctx context.Context, rateID uuid.UUID) (*models.DeliveryRate, error) {
+	var rate models.DeliveryRate
+	err := r.db.GetContext(ctx, &rate, `SELECT * FROM delivery_rate WHERE rate_id = $1`, rateID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("delivery rate not found")
+		}
+		return nil, fmt.Errorf("failed to get delivery rate: %w", err)
+	}
+
+	return &rate, nil
+}
+
+func (r *deliveryRepository) ListRates(ctx context.Context) ([]models.DeliveryRate, error) {
+	query := `
+        SELECT * FROM delivery_rate 
+        ORDER BY postal_prefix NULLS LAST, province NULLS LAST, name`
+
+	var rates []models.DeliveryRate
+	if err := r.db.SelectContext(ctx, &rates, query); err != nil {
+		return nil, fmt.Errorf("failed to list delivery rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (r *deliveryRepository) UpdateRate(ctx context.Context, rate *models.DeliveryRate) error {
+	query := `
+        UPDATE delivery_rate SET 
+            name = :name,
+            postal_prefix = :postal_prefix,
+            province = :province,
+            percent_of_material = :percent_of_material,
+            minimum_per_line = :minimum_per_line,
+            updated_at = :updated_at
+        WHERE rate_id = :rate_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, rate)
+	if err != nil {
+		return fmt.Errorf("failed to update delivery rate: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("delivery rate not found")
+	}
+
+	return nil
+}
+
+func (r *deliveryRepository) DeleteRate(ctx context.Context, rateID uuid.UUID) error {
+	var inUse bool
+	if err := r.db.GetContext(ctx, &inUse, `SELECT EXISTS (SELECT 1 FROM quotation_transport WHERE rate_id = $1)`, rateID); err != nil {
+		return fmt.Errorf("failed to check delivery rate usage: %w", err)
+	}
+	if inUse {
+		return errors.New("delivery rate is in use by a quotation")
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM delivery_rate WHERE rate_id = $1`, rateID)
+	if err != nil {
+		return fmt.Errorf("failed to delete delivery rate: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("delivery rate not found")
+	}
+
+	return nil
+}
+
+func (r *deliveryRepository) SetQuotationTransport(ctx context.Context, projectID, quotationID uuid.UUID, lines []models.QuotationTransport) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quotation_transport WHERE quotation_id = $1`, quotationID); err != nil {
+		return fmt.Errorf("failed to clear quotation transport: %w", err)
+	}
+
+	query := `
+        INSERT INTO quotation_transport (quotation_id, job_id, rate_id, amount)
+        VALUES (:quotation_id, :job_id, :rate_id, :amount)`
+	for _, line := range lines {
+		if _, err := tx.NamedExecContext(ctx, query, line); err != nil {
+			return fmt.Errorf("failed to save quotation transport: %w", err)
+		}
+	}
+
+	if err := updateQuotationFinalAmount(ctx, tx, projectID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *deliveryRepository) GetQuotationTransport(ctx context.Context, quotationID uuid.UUID) ([]models.QuotationTransport, error) {
+	var lines []models.QuotationTransport
+	query := `SELECT * FROM quotation_transport WHERE quotation_id = $1`
+
+	if err := r.db.SelectContext(ctx, &lines, query, quotationID); err != nil {
+		return nil, fmt.Errorf("failed to get quotation transport: %w", err)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type documentLabelRepository struct {
+	db *sqlx.DB
+}
+
+func NewDocumentLabelRepository(db *sqlx.DB) repositories.DocumentLabelRepository {
+	return &documentLabelRepository{
+		db: db,
+	}
+}
+
+func (r *documentLabelRepository) ListLabels(ctx context.Context, companyID uuid.UUID) ([]models.DocumentLabel, error) {
+	var labels []models.DocumentLabel
+	query := `SELECT * FROM document_label WHERE company_id = $1 ORDER BY language, label_key`
+
+	if err := r.db.SelectContext(ctx, &labels, query, companyID); err != nil {
+		return nil, fmt.Errorf("failed to list document labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (r *documentLabelRepository) SetLabels(ctx context.Context, labels []models.DocumentLabel) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	upsert := `
+        INSERT INTO document_label (company_id, language, label_key, text, updated_at)
+        VALUES (:company_id, :language, :label_key, :text, :updated_at)
+        ON CONFLICT (company_id, language, label_key) DO UPDATE 
+        SET text = EXCLUDED.text, 
+            updated_at = EXCLUDED.updated_at`
+	remove := `
+        DELETE FROM document_label
+        WHERE company_id = :company_id AND language = :language AND label_key = :label_key`
+	for _, l := range labels {
+		query := upsert
+		if l.Text == "" {
+			query = remove
+		}
+		if _, err := tx.NamedExecContext(ctx, query, l); err != nil {
+			return fmt.Errorf("failed to save document label: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
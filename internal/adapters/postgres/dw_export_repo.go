@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type dwExportRepository struct {
+	db *sqlx.DB
+}
+
+func NewDWExportRepository(db *sqlx.DB) repositories.DWExportRepository {
+	return &dwExportRepository{
+		db: db,
+	}
+}
+
+// dwEntityQueries select the exported columns of each entity. Incremental
+// queries take the (since, until] range as $1 and $2. Changing a query's
+// columns means bumping the entity's version in Entities.
+var dwEntityQueries = map[string]string{
+	"projects": `
+        SELECT project_id, name, description, status, client_id, created_at, updated_at
+        FROM project
+        WHERE COALESCE(updated_at, created_at) > $1 
+            AND COALESCE(updated_at, created_at) <= $2
+        ORDER BY COALESCE(updated_at, created_at)`,
+	"quotations": `
+        SELECT quotation_id, project_id, status, valid_date, final_amount, tax_percentage
+        FROM quotation
+        ORDER BY quotation_id`,
+	"invoices": `
+        SELECT invoice_id, project_id, phase_id, amount, created_at, updated_at
+        FROM invoice
+        WHERE COALESCE(updated_at, created_at) > $1 
+            AND COALESCE(updated_at, created_at) <= $2
+        ORDER BY COALESCE(updated_at, created_at)`,
+	"general_costs": `
+        SELECT gc.g_id, b.project_id, gc.boq_id, gc.type_name, gc.estimated_cost, gc.actual_cost
+        FROM general_cost gc
+        JOIN boq b ON b.boq_id = gc.boq_id
+        ORDER BY gc.g_id`,
+	"material_costs": `
+        SELECT 
+            b.project_id, mpl.boq_id, mpl.job_id, mpl.material_id, mpl.supplier_id,
+            COALESCE(mpl.supplier_name_snapshot, s.name) AS supplier_name,
+            mpl.quantity, mpl.estimated_price, mpl.actual_price, mpl.updated_at
+        FROM material_price_log mpl
+        JOIN boq b ON b.boq_id = mpl.boq_id
+        LEFT JOIN supplier s ON s.supplier_id = mpl.supplier_id
+        WHERE mpl.updated_at > $1 
+            AND mpl.updated_at <= $2
+        ORDER BY mpl.updated_at`,
+}
+
+func (r *dwExportRepository) Entities() []models.DWEntity {
+	return []models.DWEntity{
+		{Name: "projects", SchemaVersion: 1, Incremental: true},
+		{Name: "quotations", SchemaVersion: 1},
+		{Name: "invoices", SchemaVersion: 1, Incremental: true},
+		{Name: "general_costs", SchemaVersion: 1},
+		{Name: "material_costs", SchemaVersion: 2, Incremental: true},
+	}
+}
+
+func (r *dwExportRepository) GetWatermark(ctx context.Context, entity string) (*models.DWWatermark, error) {
+	var watermark models.DWWatermark
+	err := r.db.GetContext(ctx, &watermark, `SELECT * FROM dw_export_watermark WHERE entity = $1`, entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get export watermark: %w", err)
+	}
+
+	return &watermark, nil
+}
+
+func (r *dwExportRepository) ListWatermarks(ctx context.Context) ([]models.DWWatermark, error) {
+	var watermarks []models.DWWatermark
+	if err := r.db.SelectContext(ctx, &watermarks, `SELECT * FROM dw_export_watermark ORDER BY entity`); err != nil {
+		return nil, fmt.Errorf("failed to list export watermarks: %w", err)
+	}
+
+	return watermarks, nil
+}
+
+func (r *dwExportRepository) SaveWatermark(ctx context.Context, watermark *models.DWWatermark) error {
+	query := `
+        INSERT INTO dw_export_watermark (
+            entity, schema_version, exported_until, last_row_count, last_run_at
+        ) VALUES (
+            :entity, :schema_version, :exported_until, :last_row_count, :last_run_at
+        )
+        ON CONFLICT (entity) DO UPDATE SET 
+            schema_version = EXCLUDED.schema_version,
+            exported_until = EXCLUDED.exported_until,
+            last_row_count = EXCLUDED.last_row_count,
+            last_run_at = EXCLUDED.last_run_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, watermark); err != nil {
+		return fmt.Errorf("failed to save export watermark: %w", err)
+	}
+
+	return nil
+}
+
+// Extract renders every value as text so sinks don't need to know column
+// types; NULL becomes an empty string.
+func (r *dwExportRepository) Extract(ctx context.Context, entity models.DWEntity, since, until time.Time) ([]string, [][]string, error) {
+	query, ok := dwEntityQueries[entity.Name]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown export entity %q", entity.Name)
+	}
+
+	var args []interface{}
+	if entity.Incremental {
+		args = []interface{}{since, until}
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to extract %s: %w", entity.Name, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s columns: %w", entity.Name, err)
+	}
+
+	var result [][]string
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan %s row: %w", entity.Name, err)
+		}
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = v.String
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s rows: %w", entity.Name, err)
+	}
+
+	return columns, result, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type estimationRepository struct {
+	db *sqlx.DB
+}
+
+func NewEstimationRepository(db *sqlx.DB) repositories.EstimationRepository {
+	return &estimationRepository{
+		db: db,
+	}
+}
+
+// RecordProjectVariances only counts jobs whose materials all have actual
+// prices, so a partly purchased job doesn't look cheaper than estimated.
+// Labor is taken as estimated on both sides since actual labor isn't
+// tracked per job.
+func (r *estimationRepository) RecordProjectVariances(ctx context.Context, projectID uuid.UUID) ([]models.EstimationVariance, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM estimation_variance WHERE project_id = $1`, projectID); err != nil {
+		return nil, fmt.Errorf("failed to clear estimation variances: %w", err)
+	}
+
+	jobQuery := `
+        INSERT INTO estimation_variance (
+            project_id, kind, item_id, item_name, estimated_cost, actual_cost, recorded_at
+        )
+        SELECT 
+            b.project_id,
+            'job',
+            bj.job_id::text,
+            j.name,
+            (COALESCE(SUM(mpl.estimated_price * mpl.quantity), 0) + COALESCE(bj.labor_cost, 0)) * bj.quantity,
+            (COALESCE(SUM(mpl.actual_price * mpl.quantity), 0) + COALESCE(bj.labor_cost, 0)) * bj.quantity,
+            CURRENT_TIMESTAMP
+        FROM boq b
+        JOIN boq_job bj ON bj.boq_id = b.boq_id
+        JOIN job j ON j.job_id = bj.job_id
+        LEFT JOIN material_price_log mpl ON mpl.boq_id = bj.boq_id AND mpl.job_id = bj.job_id
+        WHERE b.project_id = $1
+        GROUP BY b.project_id, bj.job_id, j.name, bj.labor_cost, bj.quantity
+        HAVING COUNT(mpl.material_id) = COUNT(mpl.actual_price)`
+	if _, err := tx.ExecContext(ctx, jobQuery, projectID); err != nil {
+		return nil, fmt.Errorf("failed to record job variances: %w", err)
+	}
+
+	materialQuery := `
+        INSERT INTO estimation_variance (
+            project_id, kind, item_id, item_name, estimated_cost, actual_cost, recorded_at
+        )
+        SELECT 
+            b.project_id,
+            'material',
+            mpl.material_id,
+            m.name,
+            SUM(mpl.estimated_price * mpl.quantity * bj.quantity),
+            SUM(mpl.actual_price * mpl.quantity * bj.quantity),
+            CURRENT_TIMESTAMP
+        FROM boq b
+        JOIN material_price_log mpl ON mpl.boq_id = b.boq_id
+        JOIN boq_job bj ON bj.boq_id = mpl.boq_id AND bj.job_id = mpl.job_id
+        JOIN material m ON m.material_id = mpl.material_id
+        WHERE b.project_id = $1
+            AND mpl.estimated_price IS NOT NULL
+            AND mpl.actual_price IS NOT NULL
+        GROUP BY b.project_id, mpl.material_id, m.name`
+	if _, err := tx.ExecContext(ctx, materialQuery, projectID); err != nil {
+		return nil, fmt.Errorf("failed to record material variances: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return r.ListProjectVariances(ctx, projectID)
+}
+
+func (r *estimationRepository) ListProjectVariances(ctx context.Context, projectID uuid.UUID) ([]models.EstimationVariance, error) {
+	query := `
+        SELECT * FROM estimation_variance 
+        WHERE project_id = $1 
+        ORDER BY kind, item_name`
+
+	var variances []models.EstimationVariance
+	if err := r.db.SelectContext(ctx, &variances, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list estimation variances: %w", err)
+	}
+
+	return variances, nil
+}
+
+func (r *estimationRepository) ListAdjustments(ctx context.Context, kind models.EstimationItemKind, minProjects int) ([]models.EstimationAdjustment, error) {
+	query := `
+        SELECT 
+            kind,
+            item_id,
+            MAX(item_name) AS item_name,
+            COUNT(DISTINCT project_id) AS projects,
+            SUM(estimated_cost) AS estimated_cost,
+            SUM(actual_cost) AS actual_cost
+        FROM estimation_variance
+        WHERE ($1 = '' OR kind = $1)
+        GROUP BY kind, item_id
+        HAVING COUNT(DISTINCT project_id) >= $2
+        ORDER BY kind, item_name`
+
+	var adjustments []models.EstimationAdjustment
+	if err := r.db.SelectContext(ctx, &adjustments, query, string(kind), minProjects); err != nil {
+		return nil, fmt.Errorf("failed to list estimation adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type etaxRepository struct {
+	db *sqlx.DB
+}
+
+func NewETaxRepository(db *sqlx.DB) repositories.ETaxRepository {
+	return &etaxRepository{
+		db: db,
+	}
+}
+
+func (r *etaxRepository) Create(ctx context.Context, submission *models.ETaxSubmission) error {
+	query := `
+        INSERT INTO etax_submission (
+            submission_id, invoice_id, payment_id, document_type, document_number,
+            status, xml, provider_ref, message, submitted_at, created_at, updated_at
+        ) VALUES (
+            :submission_id, :invoice_id, :payment_id, :document_type, :document_number,
+            :status, :xml, :provider_ref, :message, :submitted_at, :created_at, :updated_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, submission); err != nil {
+		return fmt.Errorf("failed to create e-tax submission: %w", err)
+	}
+
+	return nil
+}
+
+func (r *etaxRepository) Update(ctx context.Context, submission *models.ETaxSubmission) error {
+	query := `
+        UPDATE etax_submission SET
+            status = :status,
+            xml = :xml,
+            provider_ref = :provider_ref,
+            message = :message,
+            submitted_at = :submitted_at,
+            updated_at = :updated_at
+        WHERE submission_id = :submission_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, submission)
+	if err != nil {
+		return fmt.Errorf("failed to update e-tax submission: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("e-tax submission not found")
+	}
+
+	return nil
+}
+
+func (r *etaxRepository) GetByID(ctx context.Context, submissionID uuid.UUID) (*models.ETaxSubmission, error) {
+	var submission models.ETaxSubmission
+	query := `SELECT * FROM etax_submission WHERE submission_id = $1`
+
+	err := r.db.GetContext(ctx, &submission, query, submissionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("e-tax submission not found")
+		}
+		return nil, fmt.Errorf("failed to get e-tax submission: %w", err)
+	}
+
+	return &submission, nil
+}
+
+func (r *etaxRepository) ListByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]models.ETaxSubmission, error) {
+	var submissions []models.ETaxSubmission
+	query := `
+        SELECT * FROM etax_submission 
+        WHERE invoice_id = $1 
+        ORDER BY created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &submissions, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("failed to list e-tax submissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+func (r *etaxRepository) ListByStatus(ctx context.Context, status models.ETaxStatus) ([]models.ETaxSubmission, error) {
+	var submissions []models.ETaxSubmission
+	query := `
+        SELECT * FROM etax_submission 
+        WHERE status = $1 
+        ORDER BY created_at`
+
+	if err := r.db.SelectContext(ctx, &submissions, query, status); err != nil {
+		return nil, fmt.Errorf("failed to list e-tax submissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+func (r *etaxRepository) GetActive(ctx context.Context, invoiceID uuid.UUID, documentType models.ETaxDocumentType, paymentID *uuid.UUID) (*models.ETaxSubmission, error) {
+	var submission models.ETaxSubmission
+	query := `
+        SELECT * FROM etax_submission 
+        WHERE invoice_id = $1 
+            AND document_type = $2 
+            AND payment_id IS NOT DISTINCT FROM $3
+            AND status IN ('submitted', 'accepted')
+        ORDER BY created_at DESC
+        LIMIT 1`
+
+	err := r.db.GetContext(ctx, &submission, query, invoiceID, documentType, paymentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get e-tax submission: %w", err)
+	}
+
+	return &submission, nil
+}
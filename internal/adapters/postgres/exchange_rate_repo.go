@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type exchangeRateRepository struct {
+	db *sqlx.DB
+}
+
+func NewExchangeRateRepository(db *sqlx.DB) repositories.ExchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+func (r *exchangeRateRepository) SaveFetched(ctx context.Context, rates []models.ExchangeRate) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Fetched rates never replace a manual override for the same day.
+	query := `
+        INSERT INTO exchange_rate (
+            currency_code, rate_date, rate, source, is_manual, updated_at
+        ) VALUES (
+            :currency_code, :rate_date, :rate, :source, false, CURRENT_TIMESTAMP
+        )
+        ON CONFLICT (currency_code, rate_date) DO UPDATE SET
+            rate = EXCLUDED.rate,
+            source = EXCLUDED.source,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE exchange_rate.is_manual = false`
+
+	for _, rate := range rates {
+		if _, err := tx.NamedExecContext(ctx, query, rate); err != nil {
+			return fmt.Errorf("failed to save exchange rate: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *exchangeRateRepository) SaveManual(ctx context.Context, rate models.ExchangeRate) error {
+	query := `
+        INSERT INTO exchange_rate (
+            currency_code, rate_date, rate, source, is_manual, updated_at
+        ) VALUES (
+            :currency_code, :rate_date, :rate, 'manual', true, CURRENT_TIMESTAMP
+        )
+        ON CONFLICT (currency_code, rate_date) DO UPDATE SET
+            rate = EXCLUDED.rate,
+            source = 'manual',
+            is_manual = true,
+            updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.NamedExecContext(ctx, query, rate); err != nil {
+		return fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+	return nil
+}
+
+func (r *exchangeRateRepository) DeleteManual(ctx context.Context, currencyCode string, rateDate time.Time) error {
+	query := `
+        DELETE FROM exchange_rate 
+        WHERE currency_code = $1 AND rate_date = $2 AND is_manual = true`
+
+	result, err := r.db.ExecContext(ctx, query, currencyCode, rateDate)
+	if err != nil {
+		return fmt.Errorf("failed to delete exchange rate override: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("exchange rate override not found")
+	}
+
+	return nil
+}
+
+func (r *exchangeRateRepository) GetLatest(ctx context.Context, currencyCode string) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	query := `
+        SELECT * FROM exchange_rate 
+        WHERE currency_code = $1 AND rate_date <= CURRENT_DATE
+        ORDER BY rate_date DESC 
+        LIMIT 1`
+
+	err := r.db.GetContext(ctx, &rate, query, currencyCode)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("exchange rate not found")
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	return &rate, nil
+}
+
+func (r *exchangeRateRepository) ListLatest(ctx context.Context) ([]models.ExchangeRate, error) {
+	var rates []models.ExchangeRate
+	query := `
+        SELECT DISTINCT ON (currency_code) * 
+        FROM exchange_rate 
+        WHERE rate_date <= CURRENT_DATE
+        ORDER BY currency_code, rate_date DESC`
+
+	err := r.db.SelectContext(ctx, &rates, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
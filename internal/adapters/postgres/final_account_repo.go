@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type finalAccountRepository struct {
+	db *sqlx.DB
+}
+
+func NewFinalAccountRepository(db *sqlx.DB) repositories.FinalAccountRepository {
+	return &finalAccountRepository{
+		db: db,
+	}
+}
+
+func (r *finalAccountRepository) Create(ctx context.Context, account *models.FinalAccount) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM final_account WHERE project_id = $1)`, account.ProjectID); err != nil {
+		return fmt.Errorf("failed to check existing final account: %w", err)
+	}
+	if exists {
+		return errors.New("final account already exists for this project")
+	}
+
+	query := `
+        INSERT INTO final_account (
+            final_account_id, project_id, retention_percent, note,
+            created_by, created_at
+        ) VALUES (
+            :final_account_id, :project_id, :retention_percent, :note,
+            :created_by, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, account); err != nil {
+		return fmt.Errorf("failed to create final account: %w", err)
+	}
+
+	if err := insertFinalAccountBackCharges(ctx, tx, account); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *finalAccountRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.FinalAccount, error) {
+	var account models.FinalAccount
+	if err := r.db.GetContext(ctx, &account, `SELECT * FROM final_account WHERE project_id = $1`, projectID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("final account not found")
+		}
+		return nil, fmt.Errorf("failed to get final account: %w", err)
+	}
+
+	chargeQuery := `
+        SELECT * FROM final_account_back_charge 
+        WHERE final_account_id = $1 
+        ORDER BY sort_order`
+	if err := r.db.SelectContext(ctx, &account.BackCharges, chargeQuery, account.FinalAccountID); err != nil {
+		return nil, fmt.Errorf("failed to get final account back-charges: %w", err)
+	}
+
+	return &account, nil
+}
+
+func (r *finalAccountRepository) Update(ctx context.Context, account *models.FinalAccount) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE final_account SET 
+            retention_percent = :retention_percent,
+            note = :note,
+            updated_at = :updated_at
+        WHERE final_account_id = :final_account_id 
+            AND agreed_at IS NULL`
+	result, err := tx.NamedExecContext(ctx, query, account)
+	if err != nil {
+		return fmt.Errorf("failed to update final account: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("final account has already been agreed")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM final_account_back_charge WHERE final_account_id = $1`, account.FinalAccountID); err != nil {
+		return fmt.Errorf("failed to clear final account back-charges: %w", err)
+	}
+
+	if err := insertFinalAccountBackCharges(ctx, tx, account); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func insertFinalAccountBackCharges(ctx context.Context, tx *sqlx.Tx, account *models.FinalAccount) error {
+	query := `
+        INSERT INTO final_account_back_charge (
+            charge_id, final_account_id, description, amount, sort_order
+        ) VALUES (
+            :charge_id, :final_account_id, :description, :amount, :sort_order
+        )`
+	for _, charge := range account.BackCharges {
+		if _, err := tx.NamedExecContext(ctx, query, charge); err != nil {
+			return fmt.Errorf("failed to save final account back-charge: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *finalAccountRepository) Agree(ctx context.Context, finalAccountID uuid.UUID, name string, amount float64, at time.Time) error {
+	query := `
+        UPDATE final_account SET 
+            agreed_by = $2,
+            agreed_amount = $3,
+            agreed_at = $4
+        WHERE final_account_id = $1 
+            AND agreed_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, finalAccountID, name, amount, at)
+	if err != nil {
+		return fmt.Errorf("failed to agree final account: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("final account has already been agreed")
+	}
+
+	return nil
+}
+
+func (r *finalAccountRepository) PaidToDate(ctx context.Context, projectID uuid.UUID) (float64, error) {
+	var paid float64
+	query := `
+        SELECT COALESCE(SUM(pay.amount), 0)
+        FROM payment pay
+        JOIN invoice i ON i.invoice_id = pay.invoice_id
+        WHERE i.project_id = $1`
+
+	if err := r.db.GetContext(ctx, &paid, query, projectID); err != nil {
+		return 0, fmt.Errorf("failed to get project payments: %w", err)
+	}
+
+	return paid, nil
+}
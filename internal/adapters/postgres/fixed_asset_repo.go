@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type fixedAssetRepository struct {
+	db *sqlx.DB
+}
+
+func NewFixedAssetRepository(db *sqlx.DB) repositories.FixedAssetRepository {
+	return &fixedAssetRepository{
+		db: db,
+	}
+}
+
+func (r *fixedAssetRepository) Create(ctx context.Context, asset models.FixedAsset) error {
+	query := `
+        INSERT INTO fixed_asset (
+            asset_id, name, material_id, serial_number, project_id, 
+            purchase_date, purchase_cost, salvage_value, useful_life_months, 
+            status, created_at, updated_at
+        ) VALUES (
+            :asset_id, :name, :material_id, :serial_number, :project_id, 
+            :purchase_date, :purchase_cost, :salvage_value, :useful_life_months, 
+            :status, :created_at, :updated_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, asset); err != nil {
+		return fmt.Errorf("failed to create asset: %w", err)
+	}
+	return nil
+}
+
+func (r *fixedAssetRepository) Update(ctx context.Context, asset models.FixedAsset) error {
+	query := `
+        UPDATE fixed_asset SET 
+            name = :name,
+            material_id = :material_id,
+            serial_number = :serial_number,
+            project_id = :project_id,
+            purchase_date = :purchase_date,
+            purchase_cost = :purchase_cost,
+            salvage_value = :salvage_value,
+            useful_life_months = :useful_life_months,
+            updated_at = :updated_at
+        WHERE asset_id = :asset_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, asset)
+	if err != nil {
+		return fmt.Errorf("failed to update asset: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("asset not found")
+	}
+	return nil
+}
+
+func (r *fixedAssetRepository) GetByID(ctx context.Context, assetID uuid.UUID) (*models.FixedAsset, error) {
+	var asset models.FixedAsset
+	query := `SELECT * FROM fixed_asset WHERE asset_id = $1`
+
+	if err := r.db.GetContext(ctx, &asset, query, assetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("asset not found")
+		}
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+	return &asset, nil
+}
+
+func (r *fixedAssetRepository) List(ctx context.Context, purchasedBy time.Time, includeDisposed bool) ([]models.FixedAsset, error) {
+	var assets []models.FixedAsset
+	query := `
+        SELECT * FROM fixed_asset 
+        WHERE purchase_date <= $1 AND ($2 OR status = 'active') 
+        ORDER BY purchase_date, name`
+
+	if err := r.db.SelectContext(ctx, &assets, query, purchasedBy, includeDisposed); err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	return assets, nil
+}
+
+func (r *fixedAssetRepository) Dispose(ctx context.Context, assetID uuid.UUID, disposedAt time.Time) error {
+	query := `
+        UPDATE fixed_asset SET status = 'disposed', disposed_at = $2, updated_at = CURRENT_TIMESTAMP 
+        WHERE asset_id = $1 AND status = 'active'`
+
+	result, err := r.db.ExecContext(ctx, query, assetID, disposedAt)
+	if err != nil {
+		return fmt.Errorf("failed to dispose asset: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("asset already disposed")
+	}
+	return nil
+}
+
+func (r *fixedAssetRepository) CreatePostings(ctx context.Context, postings []models.DepreciationPosting) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO depreciation_posting (
+            posting_id, asset_id, period, amount, accumulated_depreciation, 
+            book_value, project_id, posted_at
+        ) VALUES (
+            :posting_id, :asset_id, :period, :amount, :accumulated_depreciation, 
+            :book_value, :project_id, :posted_at
+        ) ON CONFLICT (asset_id, period) DO NOTHING`
+
+	for _, posting := range postings {
+		if _, err := tx.NamedExecContext(ctx, query, posting); err != nil {
+			return fmt.Errorf("failed to create depreciation posting: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *fixedAssetRepository) ListPostings(ctx context.Context, assetID uuid.UUID) ([]models.DepreciationPosting, error) {
+	var postings []models.DepreciationPosting
+	query := `SELECT * FROM depreciation_posting WHERE asset_id = $1 ORDER BY period`
+
+	if err := r.db.SelectContext(ctx, &postings, query, assetID); err != nil {
+		return nil, fmt.Errorf("failed to list depreciation postings: %w", err)
+	}
+	return postings, nil
+}
+
+func (r *fixedAssetRepository) GetAllocation(ctx context.Context, period string) ([]models.DepreciationAllocation, error) {
+	var allocations []models.DepreciationAllocation
+	query := `
+        SELECT dp.project_id, p.name AS project_name, SUM(dp.amount) AS amount
+        FROM depreciation_posting dp
+        LEFT JOIN project p ON p.project_id = dp.project_id
+        WHERE dp.period = $1
+        GROUP BY dp.project_id, p.name
+        ORDER BY p.name NULLS LAST`
+
+	if err := r.db.SelectContext(ctx, &allocations, query, period); err != nil {
+		return nil, fmt.Errorf("failed to get depreciation allocation: %w", err)
+	}
+	return allocations, nil
+}
@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type handoverRepository struct {
+	db *sqlx.DB
+}
+
+func NewHandoverRepository(db *sqlx.DB) repositories.HandoverRepository {
+	return &handoverRepository{
+		db: db,
+	}
+}
+
+func (r *handoverRepository) Create(ctx context.Context, handover *models.Handover) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM project_handover WHERE project_id = $1)`, handover.ProjectID); err != nil {
+		return fmt.Errorf("failed to check existing handover: %w", err)
+	}
+	if exists {
+		return errors.New("handover already exists for this project")
+	}
+
+	query := `
+        INSERT INTO project_handover (
+            handover_id, project_id, completion_date, warranty_months,
+            warranty_terms, created_at
+        ) VALUES (
+            :handover_id, :project_id, :completion_date, :warranty_months,
+            :warranty_terms, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, handover); err != nil {
+		return fmt.Errorf("failed to create handover: %w", err)
+	}
+
+	if err := r.insertChildren(ctx, tx, handover); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *handoverRepository) GetByID(ctx context.Context, handoverID uuid.UUID) (*models.Handover, error) {
+	return r.get(ctx, `SELECT * FROM project_handover WHERE handover_id = $1`, handoverID)
+}
+
+func (r *handoverRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.Handover, error) {
+	return r.get(ctx, `SELECT * FROM project_handover WHERE project_id = $1`, projectID)
+}
+
+func (r *handoverRepository) get(ctx context.Context, query string, id uuid.UUID) (*models.Handover, error) {
+	var handover models.Handover
+	if err := r.db.GetContext(ctx, &handover, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("handover not found")
+		}
+		return nil, fmt.Errorf("failed to get handover: %w", err)
+	}
+
+	defectQuery := `
+        SELECT * FROM project_handover_defect 
+        WHERE handover_id = $1 
+        ORDER BY sort_order`
+	if err := r.db.SelectContext(ctx, &handover.Defects, defectQuery, handover.HandoverID); err != nil {
+		return nil, fmt.Errorf("failed to get handover defects: %w", err)
+	}
+
+	attachmentQuery := `
+        SELECT * FROM project_handover_attachment 
+        WHERE handover_id = $1 
+        ORDER BY created_at, name`
+	if err := r.db.SelectContext(ctx, &handover.Attachments, attachmentQuery, handover.HandoverID); err != nil {
+		return nil, fmt.Errorf("failed to get handover attachments: %w", err)
+	}
+
+	return &handover, nil
+}
+
+func (r *handoverRepository) Update(ctx context.Context, handover *models.Handover) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE project_handover SET 
+            completion_date = :completion_date,
+            warranty_months = :warranty_months,
+            warranty_terms = :warranty_terms,
+            updated_at = :updated_at
+        WHERE handover_id = :handover_id 
+            AND acknowledged_at IS NULL`
+	result, err := tx.NamedExecContext(ctx, query, handover)
+	if err != nil {
+		return fmt.Errorf("failed to update handover: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("handover has already been acknowledged")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM project_handover_defect WHERE handover_id = $1`, handover.HandoverID); err != nil {
+		return fmt.Errorf("failed to clear handover defects: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM project_handover_attachment WHERE handover_id = $1`, handover.HandoverID); err != nil {
+		return fmt.Errorf("failed to clear handover attachments: %w", err)
+	}
+
+	if err := r.insertChildren(ctx, tx, handover); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *handoverRepository) insertChildren(ctx context.Context, tx *sqlx.Tx, handover *models.Handover) error {
+	defectQuery := `
+        INSERT INTO project_handover_defect (
+            defect_id, handover_id, inspection_id, location,
+            description, note, sort_order
+        ) VALUES (
+            :defect_id, :handover_id, :inspection_id, :location,
+            :description, :note, :sort_order
+        )`
+	for _, defect := range handover.Defects {
+		if _, err := tx.NamedExecContext(ctx, defectQuery, defect); err != nil {
+			return fmt.Errorf("failed to save handover defect: %w", err)
+		}
+	}
+
+	attachmentQuery := `
+        INSERT INTO project_handover_attachment (
+            attachment_id, handover_id, name, file_url, created_at
+        ) VALUES (
+            :attachment_id, :handover_id, :name, :file_url, :created_at
+        )`
+	for _, attachment := range handover.Attachments {
+		if _, err := tx.NamedExecContext(ctx, attachmentQuery, attachment); err != nil {
+			return fmt.Errorf("failed to save handover attachment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *handoverRepository) Acknowledge(ctx context.Context, handoverID uuid.UUID, name string, ip string, at time.Time) error {
+	query := `
+        UPDATE project_handover SET 
+            acknowledged_by = $2,
+            acknowledged_ip = $3,
+            acknowledged_at = $4
+        WHERE handover_id = $1 
+            AND acknowledged_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, handoverID, name, toNullString(ip), at)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge handover: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("handover has already been acknowledged")
+	}
+
+	return nil
+}
@@ -0,0 +1,421 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type inspectionRepository struct {
+	db *sqlx.DB
+}
+
+func NewInspectionRepository(db *sqlx.DB) repositories.InspectionRepository {
+	return &inspectionRepository{
+		db: db,
+	}
+}
+
+func (r *inspectionRepository) CreateTemplate(ctx context.Context, req requests.CreateInspectionTemplateRequest) (*models.InspectionTemplate, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var jobExists bool
+	if err := tx.GetContext(ctx, &jobExists, `SELECT EXISTS (SELECT 1 FROM job WHERE job_id = $1)`, req.JobID); err != nil {
+		return nil, fmt.Errorf("failed to check job existence: %w", err)
+	}
+	if !jobExists {
+		return nil, errors.New("job not found")
+	}
+
+	template := &models.InspectionTemplate{
+		TemplateID: uuid.New(),
+		JobID:      req.JobID,
+		Name:       req.Name,
+		Mandatory:  req.Mandatory,
+		CreatedAt:  time.Now(),
+	}
+
+	templateQuery := `
+        INSERT INTO inspection_template (
+            template_id, job_id, name, mandatory, created_at
+        ) VALUES (
+            :template_id, :job_id, :name, :mandatory, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, templateQuery, template); err != nil {
+		return nil, fmt.Errorf("failed to create inspection template: %w", err)
+	}
+
+	itemQuery := `
+        INSERT INTO inspection_template_item (
+            item_id, template_id, description, sort_order
+        ) VALUES (
+            :item_id, :template_id, :description, :sort_order
+        )`
+	for i, description := range req.Items {
+		item := models.InspectionTemplateItem{
+			ItemID:      uuid.New(),
+			TemplateID:  template.TemplateID,
+			Description: description,
+			SortOrder:   i + 1,
+		}
+		if _, err := tx.NamedExecContext(ctx, itemQuery, item); err != nil {
+			return nil, fmt.Errorf("failed to create inspection item: %w", err)
+		}
+		template.Items = append(template.Items, item)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return template, nil
+}
+
+func (r *inspectionRepository) GetTemplate(ctx context.Context, templateID uuid.UUID) (*models.InspectionTemplate, error) {
+	var template models.InspectionTemplate
+	err := r.db.GetContext(ctx, &template, `SELECT * FROM inspection_template WHERE template_id = $1`, templateID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("inspection template not found")
+		}
+		return nil, fmt.Errorf("failed to get inspection template: %w", err)
+	}
+
+	if err := r.loadTemplateItems(ctx, &template); err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *inspectionRepository) ListTemplates(ctx context.Context, jobID *uuid.UUID) ([]models.InspectionTemplate, error) {
+	query := `
+        SELECT * FROM inspection_template 
+        WHERE ($1::uuid IS NULL OR job_id = $1)
+        ORDER BY name`
+
+	var templates []models.InspectionTemplate
+	if err := r.db.SelectContext(ctx, &templates, query, jobID); err != nil {
+		return nil, fmt.Errorf("failed to list inspection templates: %w", err)
+	}
+
+	for i := range templates {
+		if err := r.loadTemplateItems(ctx, &templates[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+func (r *inspectionRepository) loadTemplateItems(ctx context.Context, template *models.InspectionTemplate) error {
+	query := `
+        SELECT * FROM inspection_template_item 
+        WHERE template_id = $1 
+        ORDER BY sort_order`
+
+	if err := r.db.SelectContext(ctx, &template.Items, query, template.TemplateID); err != nil {
+		return fmt.Errorf("failed to get inspection items: %w", err)
+	}
+	return nil
+}
+
+func (r *inspectionRepository) UpdateTemplate(ctx context.Context, templateID uuid.UUID, req requests.UpdateInspectionTemplateRequest) error {
+	query := `
+        UPDATE inspection_template SET 
+            name = $1,
+            mandatory = $2,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE template_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, req.Name, req.Mandatory, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to update inspection template: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("inspection template not found")
+	}
+
+	return nil
+}
+
+func (r *inspectionRepository) DeleteTemplate(ctx context.Context, templateID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var inUse bool
+	if err := tx.GetContext(ctx, &inUse, `SELECT EXISTS (SELECT 1 FROM inspection WHERE template_id = $1)`, templateID); err != nil {
+		return fmt.Errorf("failed to check template usage: %w", err)
+	}
+	if inUse {
+		return errors.New("inspection template is in use")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM inspection_template_item WHERE template_id = $1`, templateID); err != nil {
+		return fmt.Errorf("failed to delete inspection items: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM inspection_template WHERE template_id = $1`, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to delete inspection template: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("inspection template not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Create starts a pending inspection with an empty result for every item of
+// the template.
+func (r *inspectionRepository) Create(ctx context.Context, template *models.InspectionTemplate, boqID uuid.UUID) (*models.Inspection, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var jobInBOQ bool
+	checkQuery := `SELECT EXISTS (SELECT 1 FROM boq_job WHERE boq_id = $1 AND job_id = $2)`
+	if err := tx.GetContext(ctx, &jobInBOQ, checkQuery, boqID, template.JobID); err != nil {
+		return nil, fmt.Errorf("failed to check BOQ job: %w", err)
+	}
+	if !jobInBOQ {
+		return nil, errors.New("job not found in this BOQ")
+	}
+
+	inspection := &models.Inspection{
+		InspectionID: uuid.New(),
+		TemplateID:   template.TemplateID,
+		BOQID:        boqID,
+		JobID:        template.JobID,
+		Status:       models.InspectionStatusPending,
+		CreatedAt:    time.Now(),
+	}
+
+	inspectionQuery := `
+        INSERT INTO inspection (
+            inspection_id, template_id, boq_id, job_id, status, created_at
+        ) VALUES (
+            :inspection_id, :template_id, :boq_id, :job_id, :status, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, inspectionQuery, inspection); err != nil {
+		return nil, fmt.Errorf("failed to create inspection: %w", err)
+	}
+
+	resultQuery := `INSERT INTO inspection_result (inspection_id, item_id) VALUES ($1, $2)`
+	for _, item := range template.Items {
+		if _, err := tx.ExecContext(ctx, resultQuery, inspection.InspectionID, item.ItemID); err != nil {
+			return nil, fmt.Errorf("failed to create inspection result: %w", err)
+		}
+		inspection.Results = append(inspection.Results, models.InspectionResult{
+			InspectionID: inspection.InspectionID,
+			ItemID:       item.ItemID,
+			Description:  item.Description,
+			SortOrder:    item.SortOrder,
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return inspection, nil
+}
+
+func (r *inspectionRepository) GetByID(ctx context.Context, inspectionID uuid.UUID) (*models.Inspection, error) {
+	var inspection models.Inspection
+	err := r.db.GetContext(ctx, &inspection, `SELECT * FROM inspection WHERE inspection_id = $1`, inspectionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("inspection not found")
+		}
+		return nil, fmt.Errorf("failed to get inspection: %w", err)
+	}
+
+	if err := r.loadInspectionDetails(ctx, &inspection); err != nil {
+		return nil, err
+	}
+
+	return &inspection, nil
+}
+
+func (r *inspectionRepository) ListByBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) ([]models.Inspection, error) {
+	query := `
+        SELECT * FROM inspection 
+        WHERE boq_id = $1 AND job_id = $2 
+        ORDER BY created_at`
+
+	var inspections []models.Inspection
+	if err := r.db.SelectContext(ctx, &inspections, query, boqID, jobID); err != nil {
+		return nil, fmt.Errorf("failed to list inspections: %w", err)
+	}
+
+	for i := range inspections {
+		if err := r.loadInspectionDetails(ctx, &inspections[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return inspections, nil
+}
+
+func (r *inspectionRepository) loadInspectionDetails(ctx context.Context, inspection *models.Inspection) error {
+	resultQuery := `
+        SELECT 
+            ir.inspection_id,
+            ir.item_id,
+            iti.description,
+            iti.sort_order,
+            ir.passed,
+            ir.note
+        FROM inspection_result ir
+        JOIN inspection_template_item iti ON iti.item_id = ir.item_id
+        WHERE ir.inspection_id = $1
+        ORDER BY iti.sort_order`
+	if err := r.db.SelectContext(ctx, &inspection.Results, resultQuery, inspection.InspectionID); err != nil {
+		return fmt.Errorf("failed to get inspection results: %w", err)
+	}
+
+	photoQuery := `
+        SELECT * FROM inspection_photo 
+        WHERE inspection_id = $1 
+        ORDER BY created_at`
+	if err := r.db.SelectContext(ctx, &inspection.Photos, photoQuery, inspection.InspectionID); err != nil {
+		return fmt.Errorf("failed to get inspection photos: %w", err)
+	}
+
+	return nil
+}
+
+func (r *inspectionRepository) SaveResults(ctx context.Context, inspection *models.Inspection, photos []models.InspectionPhoto) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	inspectionQuery := `
+        UPDATE inspection SET 
+            status = :status,
+            inspected_by = :inspected_by,
+            inspected_at = :inspected_at,
+            notes = :notes
+        WHERE inspection_id = :inspection_id`
+	if _, err := tx.NamedExecContext(ctx, inspectionQuery, inspection); err != nil {
+		return fmt.Errorf("failed to update inspection: %w", err)
+	}
+
+	resultQuery := `
+        UPDATE inspection_result SET 
+            passed = :passed,
+            note = :note
+        WHERE inspection_id = :inspection_id AND item_id = :item_id`
+	for _, result := range inspection.Results {
+		if _, err := tx.NamedExecContext(ctx, resultQuery, result); err != nil {
+			return fmt.Errorf("failed to update inspection result: %w", err)
+		}
+	}
+
+	photoQuery := `
+        INSERT INTO inspection_photo (
+            photo_id, inspection_id, item_id, file_url, created_at
+        ) VALUES (
+            :photo_id, :inspection_id, :item_id, :file_url, :created_at
+        )`
+	for _, photo := range photos {
+		if _, err := tx.NamedExecContext(ctx, photoQuery, photo); err != nil {
+			return fmt.Errorf("failed to save inspection photo: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *inspectionRepository) CountPendingMandatory(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) (int, error) {
+	query := `
+        SELECT COUNT(*) 
+        FROM inspection_template it
+        WHERE it.job_id = $2
+            AND it.mandatory
+            AND NOT EXISTS (
+                SELECT 1 FROM inspection i
+                WHERE i.template_id = it.template_id
+                    AND i.boq_id = $1
+                    AND i.job_id = $2
+                    AND i.status = 'passed'
+            )`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, boqID, jobID); err != nil {
+		return 0, fmt.Errorf("failed to count pending inspections: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *inspectionRepository) ListOpenFindings(ctx context.Context, boqID uuid.UUID) ([]models.InspectionFinding, error) {
+	query := `
+        SELECT 
+            i.inspection_id,
+            j.name AS job_name,
+            iti.description,
+            ir.note
+        FROM inspection i
+        JOIN job j ON j.job_id = i.job_id
+        JOIN inspection_result ir ON ir.inspection_id = i.inspection_id
+        JOIN inspection_template_item iti ON iti.item_id = ir.item_id
+        WHERE i.boq_id = $1
+            AND i.status = 'failed'
+            AND ir.passed = false
+            AND NOT EXISTS (
+                SELECT 1 FROM inspection p
+                WHERE p.template_id = i.template_id
+                    AND p.boq_id = i.boq_id
+                    AND p.job_id = i.job_id
+                    AND p.status = 'passed'
+            )
+        ORDER BY j.name, i.created_at, iti.sort_order`
+
+	var findings []models.InspectionFinding
+	if err := r.db.SelectContext(ctx, &findings, query, boqID); err != nil {
+		return nil, fmt.Errorf("failed to list inspection findings: %w", err)
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type inventoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewInventoryRepository(db *sqlx.DB) repositories.InventoryRepository {
+	return &inventoryRepository{
+		db: db,
+	}
+}
+
+func (r *inventoryRepository) CreateWarehouse(ctx context.Context, warehouse models.Warehouse) error {
+	query := `
+        INSERT INTO warehouse (
+            warehouse_id, name, kind, project_id, status, created_at
+        ) VALUES (
+            :warehouse_id, :name, :kind, :project_id, :status, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, warehouse); err != nil {
+		return fmt.Errorf("failed to create warehouse: %w", err)
+	}
+	return nil
+}
+
+func (r *inventoryRepository) GetWarehouse(ctx context.Context, warehouseID uuid.UUID) (*models.Warehouse, error) {
+	var warehouse models.Warehouse
+	query := `SELECT * FROM warehouse WHERE warehouse_id = $1`
+
+	if err := r.db.GetContext(ctx, &warehouse, query, warehouseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("warehouse not found")
+		}
+		return nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	return &warehouse, nil
+}
+
+func (r *inventoryRepository) ListWarehouses(ctx context.Context, includeClosed bool) ([]models.Warehouse, error) {
+	var warehouses []models.Warehouse
+	query := `
+        SELECT * FROM warehouse 
+        WHERE $1 OR status = 'active' 
+        ORDER BY kind, name`
+
+	if err := r.db.SelectContext(ctx, &warehouses, query, includeClosed); err != nil {
+		return nil, fmt.Errorf("failed to list warehouses: %w", err)
+	}
+	return warehouses, nil
+}
+
+const stockLevelsQuery = `
+        SELECT w.warehouse_id, w.name AS warehouse_name, m.material_id, 
+            m.name AS material_name, m.unit, SUM(sm.quantity) AS quantity
+        FROM stock_movement sm
+        JOIN warehouse w ON w.warehouse_id = sm.warehouse_id
+        JOIN material m ON m.material_id = sm.material_id
+        WHERE %s
+        GROUP BY w.warehouse_id, w.name, m.material_id, m.name, m.unit
+        HAVING SUM(sm.quantity) <> 0
+        ORDER BY %s`
+
+func (r *inventoryRepository) GetStock(ctx context.Context, warehouseID uuid.UUID) ([]models.StockLevel, error) {
+	var levels []models.StockLevel
+	query := fmt.Sprintf(stockLevelsQuery, "sm.warehouse_id = $1", "m.name")
+
+	if err := r.db.SelectContext(ctx, &levels, query, warehouseID); err != nil {
+		return nil, fmt.Errorf("failed to get warehouse stock: %w", err)
+	}
+	return levels, nil
+}
+
+func (r *inventoryRepository) GetMaterialStock(ctx context.Context, materialID string) ([]models.StockLevel, error) {
+	var levels []models.StockLevel
+	query := fmt.Sprintf(stockLevelsQuery, "sm.material_id = $1", "w.name")
+
+	if err := r.db.SelectContext(ctx, &levels, query, materialID); err != nil {
+		return nil, fmt.Errorf("failed to get material stock: %w", err)
+	}
+	return levels, nil
+}
+
+func (r *inventoryRepository) ListMovements(ctx context.Context, warehouseID uuid.UUID) ([]models.StockMovement, error) {
+	var movements []models.StockMovement
+	query := `SELECT * FROM stock_movement WHERE warehouse_id = $1 ORDER BY created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &movements, query, warehouseID); err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	return movements, nil
+}
+
+func (r *inventoryRepository) RecordMovements(ctx context.Context, movements []models.StockMovement) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertStockMovements(ctx, tx, movements); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *inventoryRepository) CloseWarehouse(ctx context.Context, warehouseID uuid.UUID, movements []models.StockMovement) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertStockMovements(ctx, tx, movements); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE warehouse SET status = 'closed', closed_at = CURRENT_TIMESTAMP 
+        WHERE warehouse_id = $1 AND status = 'active'`, warehouseID)
+	if err != nil {
+		return fmt.Errorf("failed to close warehouse: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("warehouse is closed")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// insertStockMovements serializes writers per warehouse and material with
+// an advisory lock, so the stock check on outgoing movements can't race.
+func insertStockMovements(ctx context.Context, tx *sqlx.Tx, movements []models.StockMovement) error {
+	query := `
+        INSERT INTO stock_movement (
+            movement_id, warehouse_id, material_id, quantity, type, 
+            transfer_id, project_id, reference, note, created_at
+        ) VALUES (
+            :movement_id, :warehouse_id, :material_id, :quantity, :type, 
+            :transfer_id, :project_id, :reference, :note, :created_at
+        )`
+
+	for _, movement := range movements {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`,
+			movement.WarehouseID.String(), movement.MaterialID); err != nil {
+			return fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		if movement.Quantity < 0 {
+			var onHand float64
+			if err := tx.GetContext(ctx, &onHand, `
+                SELECT COALESCE(SUM(quantity), 0) FROM stock_movement 
+                WHERE warehouse_id = $1 AND material_id = $2`,
+				movement.WarehouseID, movement.MaterialID); err != nil {
+				return fmt.Errorf("failed to get stock on hand: %w", err)
+			}
+			if onHand+movement.Quantity < -1e-9 {
+				return errors.New("insufficient stock")
+			}
+		}
+
+		if _, err := tx.NamedExecContext(ctx, query, movement); err != nil {
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+	}
+	return nil
+}
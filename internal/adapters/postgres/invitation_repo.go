@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type invitationRepository struct {
+	db *sqlx.DB
+}
+
+func NewInvitationRepository(db *sqlx.DB) repositories.InvitationRepository {
+	return &invitationRepository{
+		db: db,
+	}
+}
+
+func (r *invitationRepository) Create(ctx context.Context, user *models.User, invitation models.UserInvitation) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userQuery := `
+        INSERT INTO "User" (
+            user_id, username, password, first_name, last_name, email, tel, role
+        ) VALUES (
+            :user_id, :username, :password, :first_name, :last_name, :email, :tel, :role
+        )`
+	if _, err := tx.NamedExecContext(ctx, userQuery, user); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("username already exists")
+		}
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	invitationQuery := `
+        INSERT INTO user_invitation (
+            invitation_id, user_id, invited_by, expires_at, created_at
+        ) VALUES (
+            :invitation_id, :user_id, :invited_by, :expires_at, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, invitationQuery, invitation); err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *invitationRepository) GetByID(ctx context.Context, invitationID uuid.UUID) (*models.UserInvitation, error) {
+	var invitation models.UserInvitation
+	query := `SELECT * FROM user_invitation WHERE invitation_id = $1`
+
+	err := r.db.GetContext(ctx, &invitation, query, invitationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("invitation not found")
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	return &invitation, nil
+}
+
+func (r *invitationRepository) Accept(ctx context.Context, invitationID uuid.UUID, hashedPassword string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userID uuid.UUID
+	err = tx.GetContext(ctx, &userID, `
+        UPDATE user_invitation SET accepted_at = CURRENT_TIMESTAMP 
+        WHERE invitation_id = $1 AND accepted_at IS NULL 
+        RETURNING user_id`, invitationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("invitation already accepted")
+		}
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE "User" SET password = $2 WHERE user_id = $1`, userID, hashedPassword); err != nil {
+		return fmt.Errorf("failed to set user password: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
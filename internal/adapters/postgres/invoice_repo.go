@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -58,33 +59,59 @@ func (r *invoiceRepository) ValidateProjectStatus(ctx context.Context, projectID
 	return nil
 }
 
-func (r *invoiceRepository) Create(ctx context.Context, projectID uuid.UUID, fileURL string) error {
+func (r *invoiceRepository) Create(ctx context.Context, projectID uuid.UUID, phaseID *uuid.UUID, fileURL string, amount float64, lateInterestIDs []uuid.UUID) error {
 	if err := r.ValidateProjectStatus(ctx, projectID); err != nil {
 		return err
 	}
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
         INSERT INTO invoice (
             invoice_id,
             project_id,
+            phase_id,
             file_url,
+            amount,
             created_at,
             updated_at
         ) VALUES (
-            $1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+            $1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
         )`
 
-	_, err := r.db.ExecContext(ctx, query, uuid.New(), projectID, fileURL)
+	invoiceID := uuid.New()
+	invoiceAmount := sql.NullFloat64{Float64: amount, Valid: amount > 0}
+	_, err = tx.ExecContext(ctx, query, invoiceID, projectID, phaseID, fileURL, invoiceAmount)
 	if err != nil {
 		return fmt.Errorf("failed to create invoice: %w", err)
 	}
 
-	return nil
+	if err := billLateInterest(ctx, tx, "billed_invoice_id", invoiceID, lateInterestIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *invoiceRepository) Delete(ctx context.Context, invoiceID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Late interest billed on this invoice goes back to unbilled.
+	_, err = tx.ExecContext(ctx, `UPDATE late_interest_accrual SET billed_invoice_id = NULL WHERE billed_invoice_id = $1`, invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to release late interest: %w", err)
+	}
+
 	query := `DELETE FROM invoice WHERE invoice_id = $1`
-	result, err := r.db.ExecContext(ctx, query, invoiceID)
+	result, err := tx.ExecContext(ctx, query, invoiceID)
 	if err != nil {
 		return fmt.Errorf("failed to delete invoice: %w", err)
 	}
@@ -98,7 +125,7 @@ func (r *invoiceRepository) Delete(ctx context.Context, invoiceID uuid.UUID) err
 		return errors.New("invoice not found")
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (r *invoiceRepository) GetByID(ctx context.Context, invoiceID uuid.UUID) (*models.Invoice, error) {
@@ -123,3 +150,45 @@ func (r *invoiceRepository) GetByProjectID(ctx context.Context, projectID uuid.U
 	}
 	return invoices, nil
 }
+
+func (r *invoiceRepository) GetTaxRows(ctx context.Context, from, to time.Time) ([]models.InvoiceTaxRow, error) {
+	query := `
+        SELECT 
+            i.invoice_id,
+            i.project_id,
+            p.name as project_name,
+            c.name as client_name,
+            COALESCE(c.tax_id, '') as client_tax_id,
+            i.amount,
+            q.tax_percentage,
+            COALESCE(tb.standard, 0) as standard,
+            COALESCE(tb.zero_rated, 0) as zero_rated,
+            COALESCE(tb.exempt, 0) as exempt,
+            i.created_at
+        FROM invoice i
+        JOIN project p ON p.project_id = i.project_id
+        JOIN client c ON c.client_id = p.client_id
+        LEFT JOIN quotation q ON q.project_id = i.project_id
+        LEFT JOIN LATERAL (
+            SELECT 
+                COALESCE(SUM(bj.selling_price * bj.quantity) FILTER (WHERE bj.tax_treatment = 'standard'), 0)
+                    + COALESCE(b.selling_general_cost, 0)
+                    + (SELECT COALESCE(SUM(qt.amount), 0) 
+                       FROM quotation_transport qt 
+                       WHERE qt.quotation_id = q.quotation_id) as standard,
+                COALESCE(SUM(bj.selling_price * bj.quantity) FILTER (WHERE bj.tax_treatment = 'zero_rated'), 0) as zero_rated,
+                COALESCE(SUM(bj.selling_price * bj.quantity) FILTER (WHERE bj.tax_treatment = 'exempt'), 0) as exempt
+            FROM boq b
+            LEFT JOIN boq_job bj ON bj.boq_id = b.boq_id
+            WHERE b.project_id = i.project_id
+            GROUP BY b.boq_id, b.selling_general_cost
+        ) tb ON true
+        WHERE i.created_at >= $1 AND i.created_at < $2
+        ORDER BY i.created_at`
+
+	var rows []models.InvoiceTaxRow
+	if err := r.db.SelectContext(ctx, &rows, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get invoice tax rows: %w", err)
+	}
+	return rows, nil
+}
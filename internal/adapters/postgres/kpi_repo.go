@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type kpiRepository struct {
+	db *sqlx.DB
+}
+
+func NewKPIRepository(db *sqlx.DB) repositories.KPIRepository {
+	return &kpiRepository{
+		db: db,
+	}
+}
+
+// UpsertTarget relies on the unique (metric, month) constraint on
+// kpi_target; an existing target keeps its id.
+func (r *kpiRepository) UpsertTarget(ctx context.Context, target *models.KPITarget) error {
+	query := `
+        INSERT INTO kpi_target (
+            target_id, metric, month, value, created_at
+        ) VALUES (
+            $1, $2, $3, $4, $5
+        )
+        ON CONFLICT (metric, month) DO UPDATE SET 
+            value = EXCLUDED.value,
+            updated_at = CURRENT_TIMESTAMP
+        RETURNING target_id`
+
+	err := r.db.GetContext(ctx, &target.TargetID, query,
+		target.TargetID, target.Metric, target.Month, target.Value, target.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save KPI target: %w", err)
+	}
+
+	return nil
+}
+
+func (r *kpiRepository) ListTargets(ctx context.Context, from, to time.Time) ([]models.KPITarget, error) {
+	query := `
+        SELECT * FROM kpi_target 
+        WHERE month >= $1 AND month < $2 
+        ORDER BY month, metric`
+
+	var targets []models.KPITarget
+	if err := r.db.SelectContext(ctx, &targets, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to list KPI targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+func (r *kpiRepository) DeleteTarget(ctx context.Context, targetID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM kpi_target WHERE target_id = $1`, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to delete KPI target: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("KPI target not found")
+	}
+
+	return nil
+}
+
+// GetMonthlyActuals books sales in the month a project's contract was
+// created, valued at its BOQ selling price. Crew days skip Sundays, and a
+// crew's scheduled days are capped at its capacity so over-allocation
+// doesn't push utilization above 100%.
+func (r *kpiRepository) GetMonthlyActuals(ctx context.Context, from, to time.Time) ([]models.KPIActual, error) {
+	query := `
+        WITH months AS (
+            SELECT generate_series($1::date, ($2::date - interval '1 day'), interval '1 month')::date AS month
+        ), MaterialTotals AS (
+            SELECT boq_id, job_id, SUM(estimated_price * quantity) AS estimated
+            FROM material_price_log 
+            GROUP BY boq_id, job_id
+        ), JobTotals AS (
+            SELECT 
+                bj.boq_id,
+                SUM(bj.selling_price * bj.quantity) AS selling,
+                SUM((COALESCE(mt.estimated, 0) + bj.labor_cost) * bj.quantity) AS estimated
+            FROM boq_job bj
+            LEFT JOIN MaterialTotals mt ON mt.boq_id = bj.boq_id AND mt.job_id = bj.job_id
+            GROUP BY bj.boq_id
+        ), GeneralCost AS (
+            SELECT boq_id, SUM(estimated_cost) AS estimated
+            FROM general_cost 
+            GROUP BY boq_id
+        ), Contracts AS (
+            SELECT 
+                date_trunc('month', c.created_at)::date AS month,
+                COUNT(*) AS contracts,
+                SUM(COALESCE(jt.selling, 0) + COALESCE(b.selling_general_cost, 0)) AS sales,
+                SUM(COALESCE(jt.estimated, 0) + COALESCE(gc.estimated, 0)) AS estimated_cost
+            FROM contract c
+            JOIN boq b ON b.project_id = c.project_id
+            LEFT JOIN JobTotals jt ON jt.boq_id = b.boq_id
+            LEFT JOIN GeneralCost gc ON gc.boq_id = b.boq_id
+            WHERE c.created_at >= $1 AND c.created_at < $2
+            GROUP BY 1
+        ), CrewDays AS (
+            SELECT t.crew_id, d::date AS work_date, SUM(t.workers) AS allocated
+            FROM schedule_task t
+            CROSS JOIN LATERAL generate_series(
+                GREATEST(t.start_date, $1::date),
+                LEAST(t.end_date, $2::date - 1),
+                interval '1 day'
+            ) d
+            WHERE t.crew_id IS NOT NULL
+                AND t.start_date < $2::date
+                AND t.end_date >= $1::date
+                AND EXTRACT(DOW FROM d) <> 0
+            GROUP BY t.crew_id, d::date
+        ), Scheduled AS (
+            SELECT 
+                date_trunc('month', cd.work_date)::date AS month,
+                SUM(LEAST(cd.allocated, c.capacity)) AS days
+            FROM CrewDays cd
+            JOIN crew c ON c.crew_id = cd.crew_id
+            GROUP BY 1
+        )
+        SELECT 
+            m.month,
+            COALESCE(ct.contracts, 0) AS contracts,
+            COALESCE(ct.sales, 0) AS sales,
+            COALESCE(ct.estimated_cost, 0) AS estimated_cost,
+            COALESCE(s.days, 0) AS scheduled_crew_days,
+            (
+                SELECT COALESCE(SUM(capacity), 0) FROM crew 
+                WHERE created_at < m.month + interval '1 month'
+            ) * (
+                SELECT COUNT(*) FROM generate_series(m.month, m.month + interval '1 month' - interval '1 day', interval '1 day') d
+                WHERE EXTRACT(DOW FROM d) <> 0
+            ) AS crew_capacity_days
+        FROM months m
+        LEFT JOIN Contracts ct ON ct.month = m.month
+        LEFT JOIN Scheduled s ON s.month = m.month
+        ORDER BY m.month`
+
+	var actuals []models.KPIActual
+	if err := r.db.SelectContext(ctx, &actuals, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get KPI actuals: %w", err)
+	}
+
+	return actuals, nil
+}
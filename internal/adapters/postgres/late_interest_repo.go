@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type lateInterestRepository struct {
+	db *sqlx.DB
+}
+
+func NewLateInterestRepository(db *sqlx.DB) repositories.LateInterestRepository {
+	return &lateInterestRepository{db: db}
+}
+
+func (r *lateInterestRepository) GetTerms(ctx context.Context, projectID uuid.UUID) (*models.LatePaymentTerms, error) {
+	var terms models.LatePaymentTerms
+	query := `SELECT * FROM late_payment_terms WHERE project_id = $1`
+	if err := r.db.GetContext(ctx, &terms, query, projectID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("late payment terms not found")
+		}
+		return nil, fmt.Errorf("failed to get late payment terms: %w", err)
+	}
+	return &terms, nil
+}
+
+func (r *lateInterestRepository) UpsertTerms(ctx context.Context, terms models.LatePaymentTerms) error {
+	query := `
+        INSERT INTO late_payment_terms (
+            project_id, annual_rate_percent, payment_days, grace_days, updated_at
+        ) VALUES (
+            :project_id, :annual_rate_percent, :payment_days, :grace_days, :updated_at
+        )
+        ON CONFLICT (project_id) DO UPDATE SET
+            annual_rate_percent = EXCLUDED.annual_rate_percent,
+            payment_days = EXCLUDED.payment_days,
+            grace_days = EXCLUDED.grace_days,
+            updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, terms); err != nil {
+		return fmt.Errorf("failed to save late payment terms: %w", err)
+	}
+	return nil
+}
+
+func (r *lateInterestRepository) ListAccruableInvoices(ctx context.Context) ([]models.AccruableInvoice, error) {
+	query := `
+        SELECT 
+            i.invoice_id,
+            i.project_id,
+            i.amount,
+            i.created_at,
+            a.last_accrual_date,
+            t.annual_rate_percent,
+            t.payment_days,
+            t.grace_days,
+            t.updated_at
+        FROM invoice i
+        JOIN late_payment_terms t ON t.project_id = i.project_id
+        LEFT JOIN (
+            SELECT invoice_id, MAX(accrual_date) AS last_accrual_date
+            FROM late_interest_accrual
+            GROUP BY invoice_id
+        ) a ON a.invoice_id = i.invoice_id
+        LEFT JOIN (
+            SELECT invoice_id, SUM(amount) AS paid, MAX(paid_at) AS last_paid_at
+            FROM payment
+            GROUP BY invoice_id
+        ) p ON p.invoice_id = i.invoice_id
+        WHERE i.amount > 0
+        AND t.annual_rate_percent > 0
+        AND (
+            COALESCE(p.paid, 0) < i.amount
+            OR p.last_paid_at > COALESCE(a.last_accrual_date, i.created_at)
+        )
+        ORDER BY i.created_at`
+
+	var invoices []models.AccruableInvoice
+	if err := r.db.SelectContext(ctx, &invoices, query); err != nil {
+		return nil, fmt.Errorf("failed to list accruable invoices: %w", err)
+	}
+	return invoices, nil
+}
+
+func (r *lateInterestRepository) CreateAccruals(ctx context.Context, accruals []models.LateInterestAccrual) error {
+	if len(accruals) == 0 {
+		return nil
+	}
+
+	query := `
+        INSERT INTO late_interest_accrual (
+            accrual_id, invoice_id, project_id, accrual_date, outstanding,
+            annual_rate_percent, amount, created_at
+        ) VALUES (
+            :accrual_id, :invoice_id, :project_id, :accrual_date, :outstanding,
+            :annual_rate_percent, :amount, :created_at
+        )
+        ON CONFLICT (invoice_id, accrual_date) DO NOTHING`
+
+	if _, err := r.db.NamedExecContext(ctx, query, accruals); err != nil {
+		return fmt.Errorf("failed to create late interest accruals: %w", err)
+	}
+	return nil
+}
+
+func (r *lateInterestRepository) ListAccruals(ctx context.Context, projectID uuid.UUID, unbilledOnly bool) ([]models.LateInterestAccrual, error) {
+	query := `
+        SELECT * FROM late_interest_accrual
+        WHERE project_id = $1
+        AND (NOT $2 OR (billed_invoice_id IS NULL AND debit_note_id IS NULL))
+        ORDER BY accrual_date, invoice_id`
+
+	var accruals []models.LateInterestAccrual
+	if err := r.db.SelectContext(ctx, &accruals, query, projectID, unbilledOnly); err != nil {
+		return nil, fmt.Errorf("failed to list late interest accruals: %w", err)
+	}
+	return accruals, nil
+}
+
+func (r *lateInterestRepository) CreateDebitNote(ctx context.Context, note models.LateInterestDebitNote, accrualIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO late_interest_debit_note (
+            debit_note_id, project_id, amount, created_by, created_at
+        ) VALUES (
+            :debit_note_id, :project_id, :amount, :created_by, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, note); err != nil {
+		return fmt.Errorf("failed to create debit note: %w", err)
+	}
+
+	if err := billLateInterest(ctx, tx, "debit_note_id", note.DebitNoteID, accrualIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *lateInterestRepository) ListDebitNotes(ctx context.Context, projectID uuid.UUID) ([]models.LateInterestDebitNote, error) {
+	query := `SELECT * FROM late_interest_debit_note WHERE project_id = $1 ORDER BY created_at DESC`
+
+	var notes []models.LateInterestDebitNote
+	if err := r.db.SelectContext(ctx, &notes, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list debit notes: %w", err)
+	}
+	return notes, nil
+}
+
+// billLateInterest marks accruals as billed on the document in column
+// (billed_invoice_id or debit_note_id) within tx.
+func billLateInterest(ctx context.Context, tx *sqlx.Tx, column string, documentID uuid.UUID, accrualIDs []uuid.UUID) error {
+	query := `
+        UPDATE late_interest_accrual SET ` + column + ` = $1
+        WHERE accrual_id = $2 AND billed_invoice_id IS NULL AND debit_note_id IS NULL`
+
+	for _, accrualID := range accrualIDs {
+		result, err := tx.ExecContext(ctx, query, documentID, accrualID)
+		if err != nil {
+			return fmt.Errorf("failed to bill late interest: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rows == 0 {
+			return errors.New("late interest changed, please retry")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type materialAliasRepository struct {
+	db *sqlx.DB
+}
+
+func NewMaterialAliasRepository(db *sqlx.DB) repositories.MaterialAliasRepository {
+	return &materialAliasRepository{
+		db: db,
+	}
+}
+
+func (r *materialAliasRepository) Create(ctx context.Context, alias *models.MaterialAlias) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if alias.Display {
+		if err := clearDisplayAlias(ctx, tx, alias.MaterialID, alias.Language.String); err != nil {
+			return err
+		}
+	}
+
+	query := `
+        INSERT INTO material_alias (
+            alias_id, material_id, kind, name, language, supplier_id, display, created_at
+        ) VALUES (
+            :alias_id, :material_id, :kind, :name, :language, :supplier_id, :display, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, alias); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("alias already exists")
+		}
+		return fmt.Errorf("failed to create material alias: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *materialAliasRepository) GetByID(ctx context.Context, aliasID uuid.UUID) (*models.MaterialAlias, error) {
+	var alias models.MaterialAlias
+	query := `SELECT * FROM material_alias WHERE alias_id = $1`
+
+	err := r.db.GetContext(ctx, &alias, query, aliasID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("alias not found")
+		}
+		return nil, fmt.Errorf("failed to get material alias: %w", err)
+	}
+
+	return &alias, nil
+}
+
+func (r *materialAliasRepository) ListByMaterial(ctx context.Context, materialID string) ([]models.MaterialAlias, error) {
+	aliases := []models.MaterialAlias{}
+	query := `
+        SELECT * FROM material_alias 
+        WHERE material_id = $1 
+        ORDER BY kind, language NULLS LAST, name`
+
+	if err := r.db.SelectContext(ctx, &aliases, query, materialID); err != nil {
+		return nil, fmt.Errorf("failed to list material aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+func (r *materialAliasRepository) SetDisplay(ctx context.Context, aliasID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var alias models.MaterialAlias
+	if err := tx.GetContext(ctx, &alias, `SELECT * FROM material_alias WHERE alias_id = $1 FOR UPDATE`, aliasID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("alias not found")
+		}
+		return fmt.Errorf("failed to get material alias: %w", err)
+	}
+
+	if err := clearDisplayAlias(ctx, tx, alias.MaterialID, alias.Language.String); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE material_alias SET display = true WHERE alias_id = $1`, aliasID); err != nil {
+		return fmt.Errorf("failed to set display alias: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *materialAliasRepository) Delete(ctx context.Context, aliasID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM material_alias WHERE alias_id = $1`, aliasID)
+	if err != nil {
+		return fmt.Errorf("failed to delete material alias: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("alias not found")
+	}
+	return nil
+}
+
+func clearDisplayAlias(ctx context.Context, tx *sqlx.Tx, materialID, language string) error {
+	query := `UPDATE material_alias SET display = false WHERE material_id = $1 AND language = $2 AND display`
+	if _, err := tx.ExecContext(ctx, query, materialID, language); err != nil {
+		return fmt.Errorf("failed to clear display alias: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type materialListPriceRepository struct {
+	db *sqlx.DB
+}
+
+func NewMaterialListPriceRepository(db *sqlx.DB) repositories.MaterialListPriceRepository {
+	return &materialListPriceRepository{
+		db: db,
+	}
+}
+
+func (r *materialListPriceRepository) Create(ctx context.Context, price *models.MaterialListPrice) error {
+	query := `
+        INSERT INTO material_list_price (
+            price_id, material_id, price, effective_from, note, created_at
+        ) VALUES (
+            :price_id, :material_id, :price, :effective_from, :note, :created_at
+        )`
+
+	_, err := r.db.NamedExecContext(ctx, query, price)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("a price already takes effect on that date")
+		}
+		return fmt.Errorf("failed to create material list price: %w", err)
+	}
+
+	return nil
+}
+
+func (r *materialListPriceRepository) GetByID(ctx context.Context, priceID uuid.UUID) (*models.MaterialListPrice, error) {
+	var price models.MaterialListPrice
+	query := `SELECT * FROM material_list_price WHERE price_id = $1`
+
+	err := r.db.GetContext(ctx, &price, query, priceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("list price not found")
+		}
+		return nil, fmt.Errorf("failed to get material list price: %w", err)
+	}
+
+	return &price, nil
+}
+
+func (r *materialListPriceRepository) ListByMaterial(ctx context.Context, materialID string) ([]models.MaterialListPrice, error) {
+	var prices []models.MaterialListPrice
+	query := `
+        SELECT * FROM material_list_price 
+        WHERE material_id = $1 
+        ORDER BY effective_from DESC`
+
+	err := r.db.SelectContext(ctx, &prices, query, materialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list material list prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+func (r *materialListPriceRepository) Delete(ctx context.Context, priceID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM material_list_price WHERE price_id = $1`, priceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete material list price: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("list price not found")
+	}
+
+	return nil
+}
+
+func (r *materialListPriceRepository) ApplyToProject(ctx context.Context, projectID uuid.UUID, date time.Time) (int64, error) {
+	query := `
+        WITH effective AS (
+            SELECT DISTINCT ON (material_id) material_id, price, effective_from
+            FROM material_list_price
+            WHERE effective_from <= $2::date
+            ORDER BY material_id, effective_from DESC
+        )
+        UPDATE material_price_log mpl
+        SET estimated_price = e.price,
+            estimated_at = CURRENT_TIMESTAMP
+        FROM effective e, boq b
+        WHERE e.material_id = mpl.material_id
+        AND b.boq_id = mpl.boq_id
+        AND b.project_id = $1
+        AND (mpl.estimated_price IS NULL 
+            OR mpl.estimated_at IS NULL 
+            OR mpl.estimated_at < e.effective_from)`
+
+	result, err := r.db.ExecContext(ctx, query, projectID, date)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply list prices: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows, nil
+}
+
+func (r *materialListPriceRepository) ListUpcomingImpacts(ctx context.Context, from, to time.Time) ([]models.UpcomingPriceImpact, error) {
+	query := `
+        WITH upcoming AS (
+            SELECT DISTINCT ON (material_id) material_id, price, effective_from
+            FROM material_list_price
+            WHERE effective_from > $1::date AND effective_from <= $2::date
+            ORDER BY material_id, effective_from DESC
+        ),
+        boq_material AS (
+            SELECT 
+                mpl.boq_id,
+                mpl.material_id,
+                SUM(COALESCE(mpl.quantity, 0) * bj.quantity) AS quantity,
+                SUM(COALESCE(mpl.estimated_price, 0) * COALESCE(mpl.quantity, 0) * bj.quantity) AS estimated_amount
+            FROM material_price_log mpl
+            JOIN boq_job bj ON bj.boq_id = mpl.boq_id AND bj.job_id = mpl.job_id
+            WHERE mpl.material_id IN (SELECT material_id FROM upcoming)
+            GROUP BY mpl.boq_id, mpl.material_id
+        )
+        SELECT 
+            p.project_id,
+            p.name AS project_name,
+            b.boq_id,
+            q.quotation_id,
+            m.material_id,
+            m.name AS material_name,
+            bm.quantity,
+            bm.estimated_amount / bm.quantity AS estimated_price,
+            u.price AS upcoming_price,
+            u.effective_from,
+            u.price * bm.quantity - bm.estimated_amount AS delta
+        FROM boq_material bm
+        JOIN upcoming u ON u.material_id = bm.material_id
+        JOIN boq b ON b.boq_id = bm.boq_id
+        JOIN project p ON p.project_id = b.project_id
+        JOIN material m ON m.material_id = bm.material_id
+        LEFT JOIN quotation q ON q.project_id = b.project_id
+        WHERE ` + openBOQCondition + `
+        AND bm.quantity > 0
+        AND u.price * bm.quantity > bm.estimated_amount
+        ORDER BY p.name, b.boq_id, u.price * bm.quantity - bm.estimated_amount DESC`
+
+	var impacts []models.UpcomingPriceImpact
+	err := r.db.SelectContext(ctx, &impacts, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming price impacts: %w", err)
+	}
+
+	return impacts, nil
+}
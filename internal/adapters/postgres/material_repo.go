@@ -26,16 +26,17 @@ func NewMaterialRepository(db *sqlx.DB) repositories.MaterialRepository {
 
 func (r *materialRepository) Create(ctx context.Context, req requests.CreateMaterialRequest) (*models.Material, error) {
 	material := &models.Material{
-		MaterialID: uuid.New().String(),
-		Name:       req.Name,
-		Unit:       req.Unit,
+		MaterialID:     uuid.New().String(),
+		Name:           req.Name,
+		Unit:           req.Unit,
+		Classification: models.MaterialClassification(req.Classification),
 	}
 
 	query := `
         INSERT INTO Material (
-            material_id, name, unit
+            material_id, name, unit, classification
         ) VALUES (
-            :material_id, :name, :unit
+            :material_id, :name, :unit, :classification
         ) RETURNING *`
 
 	rows, err := r.db.NamedQueryContext(ctx, query, material)
@@ -61,13 +62,15 @@ func (r *materialRepository) Update(ctx context.Context, materialID string, req
 	query := `
         UPDATE Material SET 
             name = :name,
-            unit = :unit
+            unit = :unit,
+            classification = :classification
         WHERE material_id = :material_id`
 
 	params := map[string]interface{}{
-		"material_id": materialID,
-		"name":        req.Name,
-		"unit":        req.Unit,
+		"material_id":    materialID,
+		"name":           req.Name,
+		"unit":           req.Unit,
+		"classification": req.Classification,
 	}
 
 	result, err := r.db.NamedExecContext(ctx, query, params)
@@ -190,6 +193,44 @@ func (r *materialRepository) GetByID(ctx context.Context, materialID string) (*m
 	return material, nil
 }
 
+func (r *materialRepository) Search(ctx context.Context, query string, limit int) ([]models.MaterialSearchResult, error) {
+	// Escape LIKE wildcards so the query is matched literally.
+	pattern := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query)
+
+	searchQuery := `
+        WITH Matches AS (
+            SELECT DISTINCT ON (m.material_id)
+                m.*,
+                n.name AS matched_name,
+                n.kind AS matched_kind,
+                CASE 
+                    WHEN lower(n.name) = lower($1) THEN 0 
+                    WHEN n.name ILIKE $2 || '%' THEN 1 
+                    ELSE 2 
+                END AS rank
+            FROM Material m
+            JOIN LATERAL (
+                SELECT m.name, 'name' AS kind
+                UNION ALL
+                SELECT m.material_id, 'material_id'
+                UNION ALL
+                SELECT a.name, a.kind FROM material_alias a WHERE a.material_id = m.material_id
+            ) n ON n.name ILIKE '%' || $2 || '%'
+            ORDER BY m.material_id, rank, length(n.name)
+        )
+        SELECT material_id, name, unit, classification, matched_name, matched_kind
+        FROM Matches
+        ORDER BY rank, length(matched_name), name
+        LIMIT $3`
+
+	results := []models.MaterialSearchResult{}
+	if err := r.db.SelectContext(ctx, &results, searchQuery, query, pattern, limit); err != nil {
+		return nil, fmt.Errorf("failed to search materials: %w", err)
+	}
+
+	return results, nil
+}
+
 func (r *materialRepository) List(ctx context.Context) ([]models.Material, error) {
 	var materials []models.Material
 	var args []interface{}
@@ -241,8 +282,8 @@ func (r *materialRepository) GetMaterialPricesByProjectID(ctx context.Context, p
             mpl.estimated_price,
             fa.avg_actual_price,
             mpl.actual_price,
-			s.supplier_id,
-            s.name as supplier_name
+			mpl.supplier_id,
+            COALESCE(mpl.supplier_name_snapshot, s.name) as supplier_name
         FROM project p 
         JOIN boq b ON b.project_id = p.project_id 
         JOIN material_price_log mpl ON mpl.boq_id = b.boq_id
@@ -259,7 +300,8 @@ func (r *materialRepository) GetMaterialPricesByProjectID(ctx context.Context, p
             mpl.estimated_price,
             mpl.actual_price, 
             fa.avg_actual_price, 
-			s.supplier_id,
+			mpl.supplier_id,
+            mpl.supplier_name_snapshot,
             s.name`
 
 	var materials []models.MaterialPriceInfo
@@ -274,7 +316,8 @@ func (r *materialRepository) GetMaterialPricesByProjectID(ctx context.Context, p
 func (r *materialRepository) UpdateEstimatedPrices(ctx context.Context, boqID uuid.UUID, materialID string, estimatedPrice float64) error {
 	query := `
         UPDATE material_price_log 
-        SET estimated_price = $1
+        SET estimated_price = $1,
+            estimated_at = CURRENT_TIMESTAMP
         WHERE material_id = $2 AND boq_id = $3`
 
 	result, err := r.db.ExecContext(ctx, query, estimatedPrice, materialID, boqID)
@@ -314,6 +357,10 @@ func (r *materialRepository) UpdateActualPrice(ctx context.Context, boqID uuid.U
         UPDATE material_price_log 
         SET actual_price = :actual_price, 
             supplier_id = :supplier_id,
+            supplier_name_snapshot = CASE
+                WHEN (SELECT status FROM boq WHERE boq_id = :boq_id) = 'draft' THEN NULL
+                ELSE (SELECT name FROM supplier WHERE supplier_id = :supplier_id)
+            END,
             updated_at = CURRENT_TIMESTAMP
         WHERE material_id = :material_id 
         AND boq_id = :boq_id`
@@ -397,3 +444,120 @@ func (r *materialRepository) GetQuotationStatus(ctx context.Context, BOQId uuid.
 
 	return status, nil
 }
+
+// Merge moves every reference from the source material to the target and
+// deletes the source. Where a job or price log already has the target, the
+// quantities are added together and the target's prices are kept.
+func (r *materialRepository) Merge(ctx context.Context, sourceID string, targetID string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked []string
+	lockQuery := `SELECT material_id FROM Material WHERE material_id IN ($1, $2) FOR UPDATE`
+	if err := tx.SelectContext(ctx, &locked, lockQuery, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to lock materials: %w", err)
+	}
+	if len(locked) != 2 {
+		return errors.New("material not found")
+	}
+
+	// Job materials
+	mergeJobMaterialQuery := `
+       UPDATE job_material t 
+       SET quantity = t.quantity + s.quantity
+       FROM job_material s 
+       WHERE s.material_id = $1 AND t.material_id = $2 AND t.job_id = s.job_id`
+	if _, err := tx.ExecContext(ctx, mergeJobMaterialQuery, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to merge job materials: %w", err)
+	}
+
+	deleteJobMaterialQuery := `
+       DELETE FROM job_material s 
+       USING job_material t 
+       WHERE s.material_id = $1 AND t.material_id = $2 AND t.job_id = s.job_id`
+	if _, err := tx.ExecContext(ctx, deleteJobMaterialQuery, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to delete merged job materials: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE job_material SET material_id = $2 WHERE material_id = $1`, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move job materials: %w", err)
+	}
+
+	// Price logs
+	mergePriceLogQuery := `
+       UPDATE material_price_log t 
+       SET quantity = t.quantity + s.quantity,
+           estimated_price = COALESCE(t.estimated_price, s.estimated_price),
+           actual_price = COALESCE(t.actual_price, s.actual_price),
+           supplier_id = COALESCE(t.supplier_id, s.supplier_id)
+       FROM material_price_log s 
+       WHERE s.material_id = $1 AND t.material_id = $2 
+       AND t.boq_id = s.boq_id AND t.job_id = s.job_id`
+	if _, err := tx.ExecContext(ctx, mergePriceLogQuery, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to merge material price logs: %w", err)
+	}
+
+	deletePriceLogQuery := `
+       DELETE FROM material_price_log s 
+       USING material_price_log t 
+       WHERE s.material_id = $1 AND t.material_id = $2 
+       AND t.boq_id = s.boq_id AND t.job_id = s.job_id`
+	if _, err := tx.ExecContext(ctx, deletePriceLogQuery, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to delete merged material price logs: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE material_price_log SET material_id = $2 WHERE material_id = $1`, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move material price logs: %w", err)
+	}
+
+	// Price index link, kept from the target if both are linked
+	moveIndexQuery := `
+       UPDATE material_price_index SET material_id = $2 
+       WHERE material_id = $1 
+       AND NOT EXISTS (SELECT 1 FROM material_price_index WHERE material_id = $2)`
+	if _, err := tx.ExecContext(ctx, moveIndexQuery, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move price index link: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM material_price_index WHERE material_id = $1`, sourceID); err != nil {
+		return fmt.Errorf("failed to delete price index link: %w", err)
+	}
+
+	// Aliases, keeping the target's display names, plus the source's own
+	// name so it can still be found by it
+	moveAliasQuery := `
+       UPDATE material_alias s SET 
+           material_id = $2,
+           display = s.display AND NOT EXISTS (
+               SELECT 1 FROM material_alias t 
+               WHERE t.material_id = $2 AND t.language = s.language AND t.display)
+       WHERE s.material_id = $1`
+	if _, err := tx.ExecContext(ctx, moveAliasQuery, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move material aliases: %w", err)
+	}
+
+	sourceNameQuery := `
+       INSERT INTO material_alias (alias_id, material_id, kind, name, display, created_at)
+       SELECT $3, $2, 'name', s.name, false, CURRENT_TIMESTAMP 
+       FROM Material s, Material t 
+       WHERE s.material_id = $1 AND t.material_id = $2 AND lower(s.name) <> lower(t.name)
+       AND NOT EXISTS (
+           SELECT 1 FROM material_alias a 
+           WHERE a.material_id = $2 AND a.kind = 'name' AND lower(a.name) = lower(s.name))`
+	if _, err := tx.ExecContext(ctx, sourceNameQuery, sourceID, targetID, uuid.New()); err != nil {
+		return fmt.Errorf("failed to keep merged material name: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Material WHERE material_id = $1`, sourceID); err != nil {
+		return fmt.Errorf("failed to delete merged material: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
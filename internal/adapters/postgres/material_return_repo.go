@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type materialReturnRepository struct {
+	db *sqlx.DB
+}
+
+func NewMaterialReturnRepository(db *sqlx.DB) repositories.MaterialReturnRepository {
+	return &materialReturnRepository{
+		db: db,
+	}
+}
+
+const materialReturnSummaryQuery = `
+        SELECT mr.*, po.po_number, s.name AS supplier_name
+        FROM material_return mr
+        JOIN purchase_order po ON po.po_id = mr.po_id
+        JOIN supplier s ON s.supplier_id = mr.supplier_id`
+
+func (r *materialReturnRepository) Create(ctx context.Context, ret models.MaterialReturn, lines []models.MaterialReturnLine, movements []models.StockMovement) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO material_return (
+            return_id, return_number, po_id, supplier_id, warehouse_id, reason, return_date,
+            note, expected_credit, credit_status, created_by, created_at
+        ) VALUES (
+            :return_id, :return_number, :po_id, :supplier_id, :warehouse_id, :reason, :return_date,
+            :note, :expected_credit, :credit_status, :created_by, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, ret); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("return number already exists")
+		}
+		return fmt.Errorf("failed to create material return: %w", err)
+	}
+
+	type costTarget struct {
+		ProjectID  uuid.UUID
+		MaterialID string
+	}
+	targets := map[costTarget]bool{}
+
+	for _, line := range lines {
+		// Updating the allocation also locks it, so concurrent returns of
+		// the same receipt are checked one after the other below.
+		result, err := tx.ExecContext(ctx, `
+            UPDATE purchase_order_allocation
+            SET returned_quantity = returned_quantity + $2
+            WHERE allocation_id = $1 AND returned_quantity + $2 <= received_quantity + 0.00005`,
+			line.AllocationID, line.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to update returned quantity: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rows == 0 {
+			return errors.New("quantity exceeds returnable quantity")
+		}
+
+		if line.ReceiptID.Valid {
+			var remaining float64
+			if err := tx.GetContext(ctx, &remaining, `
+                SELECT r.quantity - COALESCE((
+                    SELECT SUM(rl.quantity) FROM material_return_line rl WHERE rl.receipt_id = r.receipt_id
+                ), 0)
+                FROM purchase_order_receipt r WHERE r.receipt_id = $1`, line.ReceiptID); err != nil {
+				return fmt.Errorf("failed to get receipt quantity: %w", err)
+			}
+			if line.Quantity > remaining+0.00005 {
+				return errors.New("quantity exceeds returnable quantity")
+			}
+		}
+
+		query := `
+            INSERT INTO material_return_line (
+                line_id, return_id, allocation_id, receipt_id, project_id, material_id,
+                quantity, unit_price, amount
+            ) VALUES (
+                :line_id, :return_id, :allocation_id, :receipt_id, :project_id, :material_id,
+                :quantity, :unit_price, :amount
+            )`
+		if _, err := tx.NamedExecContext(ctx, query, line); err != nil {
+			return fmt.Errorf("failed to create material return line: %w", err)
+		}
+		targets[costTarget{line.ProjectID, line.MaterialID}] = true
+	}
+
+	if err := insertStockMovements(ctx, tx, movements); err != nil {
+		return err
+	}
+
+	for target := range targets {
+		if err := updateReceivedActualPrice(ctx, tx, target.ProjectID, target.MaterialID, ret.POID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *materialReturnRepository) GetByID(ctx context.Context, returnID uuid.UUID) (*models.MaterialReturnSummary, error) {
+	var ret models.MaterialReturnSummary
+	query := materialReturnSummaryQuery + ` WHERE mr.return_id = $1`
+
+	if err := r.db.GetContext(ctx, &ret, query, returnID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("material return not found")
+		}
+		return nil, fmt.Errorf("failed to get material return: %w", err)
+	}
+	return &ret, nil
+}
+
+func (r *materialReturnRepository) List(ctx context.Context, filter models.MaterialReturnFilter) ([]models.MaterialReturnSummary, error) {
+	var returns []models.MaterialReturnSummary
+	query := materialReturnSummaryQuery + `
+        WHERE ($1::uuid IS NULL OR mr.supplier_id = $1)
+            AND ($2::uuid IS NULL OR mr.po_id = $2)
+            AND ($3 = '' OR mr.credit_status = $3)
+        ORDER BY mr.return_date DESC, mr.created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &returns, query, filter.SupplierID, filter.POID, filter.CreditStatus); err != nil {
+		return nil, fmt.Errorf("failed to list material returns: %w", err)
+	}
+	return returns, nil
+}
+
+func (r *materialReturnRepository) ListLines(ctx context.Context, returnID uuid.UUID) ([]models.MaterialReturnLine, error) {
+	var lines []models.MaterialReturnLine
+	query := `SELECT * FROM material_return_line WHERE return_id = $1 ORDER BY material_id, project_id`
+
+	if err := r.db.SelectContext(ctx, &lines, query, returnID); err != nil {
+		return nil, fmt.Errorf("failed to list material return lines: %w", err)
+	}
+	return lines, nil
+}
+
+func (r *materialReturnRepository) RecordCredit(ctx context.Context, returnID uuid.UUID, number string, amount float64, date time.Time) error {
+	query := `
+        UPDATE material_return
+        SET credit_status = 'received', credit_note_number = $2, credit_amount = $3, credit_date = $4
+        WHERE return_id = $1 AND credit_status = 'pending'`
+
+	result, err := r.db.ExecContext(ctx, query, returnID, number, amount, date)
+	if err != nil {
+		return fmt.Errorf("failed to record credit note: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("credit note already received")
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type notificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) repositories.NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRepository) ListRules(ctx context.Context, projectID uuid.NullUUID) ([]models.NotificationRule, error) {
+	var rules []models.NotificationRule
+	query := `
+        SELECT * FROM notification_rule 
+        WHERE project_id IS NOT DISTINCT FROM $1 
+        ORDER BY event, channel, role`
+
+	if err := r.db.SelectContext(ctx, &rules, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list notification rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *notificationRepository) SetRules(ctx context.Context, projectID uuid.NullUUID, rules []models.NotificationRule) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The defaults have a null project, which a unique constraint can't
+	// conflict on, so rules are replaced rather than upserted.
+	deleteQuery := `
+        DELETE FROM notification_rule 
+        WHERE project_id IS NOT DISTINCT FROM $1 
+        AND event = $2 AND channel = $3 AND role = $4`
+	insertQuery := `
+        INSERT INTO notification_rule (project_id, event, channel, role, enabled, updated_at)
+        VALUES (:project_id, :event, :channel, :role, :enabled, :updated_at)`
+	for _, rule := range rules {
+		if _, err := tx.ExecContext(ctx, deleteQuery, projectID, rule.Event, rule.Channel, rule.Role); err != nil {
+			return fmt.Errorf("failed to replace notification rule: %w", err)
+		}
+		if _, err := tx.NamedExecContext(ctx, insertQuery, rule); err != nil {
+			return fmt.Errorf("failed to save notification rule: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) ResetRules(ctx context.Context, projectID uuid.UUID) error {
+	query := `DELETE FROM notification_rule WHERE project_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, projectID); err != nil {
+		return fmt.Errorf("failed to reset notification rules: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	query := `
+        INSERT INTO notification (
+            notification_id, user_id, project_id, event, title, body, created_at
+        ) VALUES (
+            :notification_id, :user_id, :project_id, :event, :title, :body, :created_at
+        )`
+	if _, err := r.db.NamedExecContext(ctx, query, notifications); err != nil {
+		return fmt.Errorf("failed to create notifications: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) ListByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := `
+        SELECT * FROM notification 
+        WHERE user_id = $1 
+        AND (NOT $2 OR read_at IS NULL) 
+        ORDER BY created_at DESC 
+        LIMIT $3`
+
+	if err := r.db.SelectContext(ctx, &notifications, query, userID, unreadOnly, limit); err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM notification WHERE user_id = $1 AND read_at IS NULL`
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	query := `
+        UPDATE notification SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP) 
+        WHERE user_id = $1 AND notification_id = $2`
+	result, err := r.db.ExecContext(ctx, query, userID, notificationID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("notification not found")
+	}
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE notification SET read_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND read_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) ClaimOverdueInvoices(ctx context.Context, defaultTermsDays int) ([]models.OverdueInvoice, error) {
+	query := `
+        WITH overdue AS (
+            SELECT 
+                i.invoice_id,
+                i.project_id,
+                p.name AS project_name,
+                c.name AS client_name,
+                i.amount,
+                i.amount - COALESCE(pay.paid, 0) AS outstanding,
+                (i.created_at + make_interval(days => COALESCE(c.payment_terms_days, $1)::int))::date AS due_date
+            FROM invoice i
+            JOIN project p ON p.project_id = i.project_id
+            JOIN client c ON c.client_id = p.client_id
+            LEFT JOIN (
+                SELECT invoice_id, SUM(amount) AS paid
+                FROM payment
+                GROUP BY invoice_id
+            ) pay ON pay.invoice_id = i.invoice_id
+            WHERE i.amount IS NOT NULL
+            AND i.amount > COALESCE(pay.paid, 0)
+            AND i.created_at + make_interval(days => COALESCE(c.payment_terms_days, $1)::int) < CURRENT_TIMESTAMP
+            AND NOT EXISTS (
+                SELECT 1 FROM invoice_overdue_notification n WHERE n.invoice_id = i.invoice_id
+            )
+        ), claimed AS (
+            INSERT INTO invoice_overdue_notification (invoice_id, notified_at)
+            SELECT invoice_id, CURRENT_TIMESTAMP FROM overdue
+            ON CONFLICT (invoice_id) DO NOTHING
+            RETURNING invoice_id
+        )
+        SELECT o.* FROM overdue o
+        JOIN claimed USING (invoice_id)
+        ORDER BY o.due_date, o.project_name`
+
+	var invoices []models.OverdueInvoice
+	if err := r.db.SelectContext(ctx, &invoices, query, defaultTermsDays); err != nil {
+		return nil, fmt.Errorf("failed to claim overdue invoices: %w", err)
+	}
+	return invoices, nil
+}
@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type paymentRepository struct {
+	db *sqlx.DB
+}
+
+func NewPaymentRepository(db *sqlx.DB) repositories.PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+func (r *paymentRepository) Create(ctx context.Context, invoiceID uuid.UUID, req requests.RecordPaymentRequest) (*models.Payment, *models.Receipt, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	payment, receipt, err := r.create(ctx, tx, invoiceID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return payment, receipt, nil
+}
+
+// CreateOnce holds a lock on the reference while it checks for an earlier
+// payment, so two deliveries of the same gateway notification can't both
+// record it. It returns nil when the reference is already recorded.
+func (r *paymentRepository) CreateOnce(ctx context.Context, invoiceID uuid.UUID, req requests.RecordPaymentRequest) (*models.Payment, *models.Receipt, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, req.Reference); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock payment reference: %w", err)
+	}
+
+	var recorded bool
+	if err := tx.GetContext(ctx, &recorded, `SELECT EXISTS (SELECT 1 FROM payment WHERE reference = $1)`, req.Reference); err != nil {
+		return nil, nil, fmt.Errorf("failed to check payment reference: %w", err)
+	}
+	if recorded {
+		return nil, nil, nil
+	}
+
+	payment, receipt, err := r.create(ctx, tx, invoiceID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return payment, receipt, nil
+}
+
+func (r *paymentRepository) create(ctx context.Context, tx *sqlx.Tx, invoiceID uuid.UUID, req requests.RecordPaymentRequest) (*models.Payment, *models.Receipt, error) {
+	now := time.Now()
+	paidAt := now
+	if req.PaidAt != nil {
+		paidAt = *req.PaidAt
+	}
+
+	payment := &models.Payment{
+		PaymentID: uuid.New(),
+		InvoiceID: invoiceID,
+		Amount:    req.Amount,
+		Method:    req.Method,
+		Reference: sql.NullString{String: req.Reference, Valid: req.Reference != ""},
+		Note:      sql.NullString{String: req.Note, Valid: req.Note != ""},
+		PaidAt:    paidAt,
+		CreatedAt: now,
+	}
+
+	paymentQuery := `
+        INSERT INTO payment (
+            payment_id, invoice_id, amount, method, reference, note, paid_at, created_at
+        ) VALUES (
+            :payment_id, :invoice_id, :amount, :method, :reference, :note, :paid_at, :created_at
+        )`
+
+	if _, err := tx.NamedExecContext(ctx, paymentQuery, payment); err != nil {
+		return nil, nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	// Serialize receipt numbering so two payments recorded at the same time
+	// can never be issued the same running number.
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE receipt IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock receipt table: %w", err)
+	}
+
+	var issuedThisYear int
+	countQuery := `
+        SELECT COUNT(*) 
+        FROM receipt 
+        WHERE EXTRACT(YEAR FROM created_at) = $1`
+
+	if err := tx.GetContext(ctx, &issuedThisYear, countQuery, now.Year()); err != nil {
+		return nil, nil, fmt.Errorf("failed to count receipts: %w", err)
+	}
+
+	receipt := &models.Receipt{
+		ReceiptID:     uuid.New(),
+		ReceiptNumber: fmt.Sprintf("RC%d-%05d", now.Year(), issuedThisYear+1),
+		PaymentID:     payment.PaymentID,
+		CreatedAt:     now,
+	}
+
+	receiptQuery := `
+        INSERT INTO receipt (
+            receipt_id, receipt_number, payment_id, created_at
+        ) VALUES (
+            :receipt_id, :receipt_number, :payment_id, :created_at
+        )`
+
+	if _, err := tx.NamedExecContext(ctx, receiptQuery, receipt); err != nil {
+		return nil, nil, fmt.Errorf("failed to create receipt: %w", err)
+	}
+
+	return payment, receipt, nil
+}
+
+func (r *paymentRepository) GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]models.Payment, error) {
+	var payments []models.Payment
+	query := `SELECT * FROM payment WHERE invoice_id = $1 ORDER BY paid_at`
+	err := r.db.SelectContext(ctx, &payments, query, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice payments: %w", err)
+	}
+	return payments, nil
+}
+
+func (r *paymentRepository) GetTotalPaidByInvoiceID(ctx context.Context, invoiceID uuid.UUID) (float64, error) {
+	var total float64
+	query := `SELECT COALESCE(SUM(amount), 0) FROM payment WHERE invoice_id = $1`
+	err := r.db.GetContext(ctx, &total, query, invoiceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get total paid: %w", err)
+	}
+	return total, nil
+}
+
+func (r *paymentRepository) GetReceiptByPaymentID(ctx context.Context, paymentID uuid.UUID) (*models.Receipt, error) {
+	var receipt models.Receipt
+	query := `SELECT * FROM receipt WHERE payment_id = $1`
+	err := r.db.GetContext(ctx, &receipt, query, paymentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	return &receipt, nil
+}
+
+func (r *paymentRepository) GetReceiptExportData(ctx context.Context, receiptID uuid.UUID) (*responses.ReceiptExportData, error) {
+	query := `
+        SELECT 
+            r.receipt_id,
+            r.receipt_number,
+            r.created_at as issued_at,
+            pm.payment_id,
+            pm.amount,
+            pm.method,
+            COALESCE(pm.reference, '') as reference,
+            COALESCE(pm.note, '') as note,
+            pm.paid_at,
+            i.invoice_id,
+            COALESCE(i.file_url, '') as invoice_file_url,
+            p.project_id,
+            p.name as project_name,
+            p.address as project_address,
+            c.name as client_name,
+            c.address as client_address,
+            c.email as client_email,
+            c.tel as client_tel,
+            c.tax_id as client_tax_id
+        FROM receipt r
+        JOIN payment pm ON pm.payment_id = r.payment_id
+        JOIN invoice i ON i.invoice_id = pm.invoice_id
+        JOIN project p ON p.project_id = i.project_id
+        LEFT JOIN client c ON c.client_id = p.client_id
+        WHERE r.receipt_id = $1`
+
+	var data responses.ReceiptExportData
+	err := r.db.GetContext(ctx, &data, query, receiptID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("receipt not found")
+		}
+		return nil, fmt.Errorf("failed to get receipt data: %w", err)
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type permissionRepository struct {
+	db *sqlx.DB
+}
+
+func NewPermissionRepository(db *sqlx.DB) repositories.PermissionRepository {
+	return &permissionRepository{
+		db: db,
+	}
+}
+
+func (r *permissionRepository) ListRolePermissions(ctx context.Context) ([]models.RolePermission, error) {
+	var permissions []models.RolePermission
+	query := `SELECT * FROM role_permission ORDER BY role, resource, action`
+
+	if err := r.db.SelectContext(ctx, &permissions, query); err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+func (r *permissionRepository) SetRolePermissions(ctx context.Context, permissions []models.RolePermission) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO role_permission (role, resource, action, allowed, updated_at)
+        VALUES (:role, :resource, :action, :allowed, :updated_at)
+        ON CONFLICT (role, resource, action) DO UPDATE 
+        SET allowed = EXCLUDED.allowed, 
+            updated_at = EXCLUDED.updated_at`
+	for _, p := range permissions {
+		if _, err := tx.NamedExecContext(ctx, query, p); err != nil {
+			return fmt.Errorf("failed to save role permission: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type priceIndexRepository struct {
+	db *sqlx.DB
+}
+
+func NewPriceIndexRepository(db *sqlx.DB) repositories.PriceIndexRepository {
+	return &priceIndexRepository{
+		db: db,
+	}
+}
+
+func (r *priceIndexRepository) Create(ctx context.Context, req requests.CreatePriceIndexRequest) (*models.PriceIndex, error) {
+	index := &models.PriceIndex{
+		IndexID:     uuid.New(),
+		Code:        req.Code,
+		Name:        req.Name,
+		Unit:        sql.NullString{String: req.Unit, Valid: req.Unit != ""},
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+		CreatedAt:   time.Now(),
+	}
+
+	query := `
+        INSERT INTO price_index (
+            index_id, code, name, unit, description, created_at
+        ) VALUES (
+            :index_id, :code, :name, :unit, :description, :created_at
+        ) RETURNING *`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, index)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return nil, errors.New("price index code already exists")
+		}
+		return nil, fmt.Errorf("failed to create price index: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.StructScan(index); err != nil {
+			return nil, fmt.Errorf("failed to scan price index: %w", err)
+		}
+		return index, nil
+	}
+	return nil, errors.New("failed to create price index: no rows returned")
+}
+
+func (r *priceIndexRepository) GetByID(ctx context.Context, indexID uuid.UUID) (*models.PriceIndex, error) {
+	var index models.PriceIndex
+	query := `SELECT * FROM price_index WHERE index_id = $1`
+
+	err := r.db.GetContext(ctx, &index, query, indexID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("price index not found")
+		}
+		return nil, fmt.Errorf("failed to get price index: %w", err)
+	}
+
+	return &index, nil
+}
+
+func (r *priceIndexRepository) List(ctx context.Context) ([]models.PriceIndex, error) {
+	var indices []models.PriceIndex
+	query := `SELECT * FROM price_index ORDER BY code`
+
+	err := r.db.SelectContext(ctx, &indices, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price indices: %w", err)
+	}
+
+	return indices, nil
+}
+
+func (r *priceIndexRepository) Delete(ctx context.Context, indexID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM material_price_index WHERE index_id = $1`, indexID); err != nil {
+		return fmt.Errorf("failed to unlink materials: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM price_index_value WHERE index_id = $1`, indexID); err != nil {
+		return fmt.Errorf("failed to delete price index values: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM price_index WHERE index_id = $1`, indexID)
+	if err != nil {
+		return fmt.Errorf("failed to delete price index: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("price index not found")
+	}
+
+	return tx.Commit()
+}
+
+func (r *priceIndexRepository) SaveValues(ctx context.Context, values []models.PriceIndexValue) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO price_index_value (
+            index_id, period_date, value, source, created_at
+        ) VALUES (
+            :index_id, :period_date, :value, :source, CURRENT_TIMESTAMP
+        )
+        ON CONFLICT (index_id, period_date) DO UPDATE SET
+            value = EXCLUDED.value,
+            source = EXCLUDED.source,
+            created_at = CURRENT_TIMESTAMP`
+
+	for _, value := range values {
+		if _, err := tx.NamedExecContext(ctx, query, value); err != nil {
+			return fmt.Errorf("failed to save price index value: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *priceIndexRepository) ListValues(ctx context.Context, indexID uuid.UUID) ([]models.PriceIndexValue, error) {
+	var values []models.PriceIndexValue
+	query := `
+        SELECT * FROM price_index_value 
+        WHERE index_id = $1 
+        ORDER BY period_date DESC`
+
+	err := r.db.SelectContext(ctx, &values, query, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price index values: %w", err)
+	}
+
+	return values, nil
+}
+
+func (r *priceIndexRepository) LinkMaterial(ctx context.Context, materialID string, indexID uuid.UUID) error {
+	query := `
+        INSERT INTO material_price_index (material_id, index_id) 
+        VALUES ($1, $2)
+        ON CONFLICT (material_id) DO UPDATE SET index_id = EXCLUDED.index_id`
+
+	if _, err := r.db.ExecContext(ctx, query, materialID, indexID); err != nil {
+		return fmt.Errorf("failed to link material to price index: %w", err)
+	}
+	return nil
+}
+
+func (r *priceIndexRepository) UnlinkMaterial(ctx context.Context, materialID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM material_price_index WHERE material_id = $1`, materialID)
+	if err != nil {
+		return fmt.Errorf("failed to unlink material from price index: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("material is not linked to a price index")
+	}
+
+	return nil
+}
+
+func (r *priceIndexRepository) GetDraftQuotationAlerts(ctx context.Context, thresholdPercent float64) ([]models.PriceIndexAlert, error) {
+	// The base value is the last index reading on or before the day the
+	// material's estimated price was set.
+	query := `
+        WITH priced AS (
+            SELECT boq_id, material_id, MAX(estimated_at) AS estimated_at
+            FROM material_price_log
+            WHERE estimated_price IS NOT NULL AND estimated_at IS NOT NULL
+            GROUP BY boq_id, material_id
+        ),
+        latest AS (
+            SELECT DISTINCT ON (index_id) index_id, period_date, value
+            FROM price_index_value
+            ORDER BY index_id, period_date DESC
+        )
+        SELECT 
+            p.project_id,
+            p.name AS project_name,
+            q.quotation_id,
+            b.boq_id,
+            m.material_id,
+            m.name AS material_name,
+            pi.index_id,
+            pi.code AS index_code,
+            priced.estimated_at,
+            base.period_date AS base_date,
+            base.value AS base_value,
+            latest.period_date AS latest_date,
+            latest.value AS latest_value,
+            (latest.value - base.value) / base.value * 100 AS change_percent
+        FROM quotation q
+        JOIN project p ON p.project_id = q.project_id
+        JOIN boq b ON b.project_id = q.project_id
+        JOIN priced ON priced.boq_id = b.boq_id
+        JOIN material m ON m.material_id = priced.material_id
+        JOIN material_price_index mpi ON mpi.material_id = priced.material_id
+        JOIN price_index pi ON pi.index_id = mpi.index_id
+        JOIN latest ON latest.index_id = pi.index_id
+        JOIN LATERAL (
+            SELECT v.period_date, v.value
+            FROM price_index_value v
+            WHERE v.index_id = pi.index_id 
+            AND v.period_date <= priced.estimated_at::date
+            ORDER BY v.period_date DESC
+            LIMIT 1
+        ) base ON true
+        WHERE q.status = 'draft'
+        AND base.value > 0
+        AND ABS(latest.value - base.value) / base.value * 100 >= $1
+        ORDER BY ABS(latest.value - base.value) / base.value DESC`
+
+	var alerts []models.PriceIndexAlert
+	err := r.db.SelectContext(ctx, &alerts, query, thresholdPercent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price index alerts: %w", err)
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// archivedTables are the child tables moved to cold storage, with the
+// condition selecting a project's rows ($1 is the project ID). They are
+// leaves, so nothing else references their rows, and restored in this
+// order.
+var archivedTables = []struct {
+	name  string
+	scope string
+}{
+	{"inspection_result", `inspection_id IN (
+            SELECT i.inspection_id FROM inspection i JOIN boq b ON b.boq_id = i.boq_id WHERE b.project_id = $1)`},
+	{"inspection_photo", `inspection_id IN (
+            SELECT i.inspection_id FROM inspection i JOIN boq b ON b.boq_id = i.boq_id WHERE b.project_id = $1)`},
+	{"project_handover_attachment", `handover_id IN (
+            SELECT handover_id FROM project_handover WHERE project_id = $1)`},
+	{"boq_job_drawing", `boq_id IN (SELECT boq_id FROM boq WHERE project_id = $1)`},
+}
+
+type projectArchiveRepository struct {
+	db *sqlx.DB
+}
+
+func NewProjectArchiveRepository(db *sqlx.DB) repositories.ProjectArchiveRepository {
+	return &projectArchiveRepository{
+		db: db,
+	}
+}
+
+func (r *projectArchiveRepository) ExportChildData(ctx context.Context, projectID uuid.UUID) (map[string]models.ArchivedRows, error) {
+	data := make(map[string]models.ArchivedRows, len(archivedTables))
+	for _, table := range archivedTables {
+		query := fmt.Sprintf(`
+            SELECT COALESCE(json_agg(t), '[]') AS rows, COUNT(*) AS count
+            FROM %s t
+            WHERE %s`, table.name, table.scope)
+
+		var rows models.ArchivedRows
+		if err := r.db.GetContext(ctx, &rows, query, projectID); err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", table.name, err)
+		}
+		data[table.name] = rows
+	}
+	return data, nil
+}
+
+func (r *projectArchiveRepository) Archive(ctx context.Context, archive *models.ProjectArchive, counts map[string]int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range archivedTables {
+		result, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s`, table.name, table.scope), archive.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to delete archived %s: %w", table.name, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if int(rows) != counts[table.name] {
+			return errors.New("project changed while archiving, please retry")
+		}
+	}
+
+	query := `
+        INSERT INTO project_archive (
+            archive_id, project_id, object_key, size_bytes, row_counts, archived_by, archived_at
+        ) VALUES (
+            :archive_id, :project_id, :object_key, :size_bytes, :row_counts, :archived_by, :archived_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, archive); err != nil {
+		return fmt.Errorf("failed to create project archive: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *projectArchiveRepository) Restore(ctx context.Context, archiveID, restoredBy uuid.UUID, tables map[string]json.RawMessage) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE project_archive SET 
+            restored_by = $2,
+            restored_at = CURRENT_TIMESTAMP
+        WHERE archive_id = $1 AND restored_at IS NULL`, archiveID, restoredBy)
+	if err != nil {
+		return fmt.Errorf("failed to mark project archive restored: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("project is not archived")
+	}
+
+	for _, table := range archivedTables {
+		data, ok := tables[table.name]
+		if !ok {
+			continue
+		}
+		query := fmt.Sprintf(`INSERT INTO %[1]s SELECT * FROM json_populate_recordset(NULL::%[1]s, $1)`, table.name)
+		if _, err := tx.ExecContext(ctx, query, string(data)); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", table.name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *projectArchiveRepository) GetLatest(ctx context.Context, projectID uuid.UUID) (*models.ProjectArchive, error) {
+	query := `
+        SELECT * FROM project_archive 
+        WHERE project_id = $1 
+        ORDER BY archived_at DESC 
+        LIMIT 1`
+
+	var archive models.ProjectArchive
+	err := r.db.GetContext(ctx, &archive, query, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("archive not found")
+		}
+		return nil, fmt.Errorf("failed to get project archive: %w", err)
+	}
+
+	return &archive, nil
+}
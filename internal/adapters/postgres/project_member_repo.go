@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type projectMemberRepository struct {
+	db *sqlx.DB
+}
+
+func NewProjectMemberRepository(db *sqlx.DB) repositories.ProjectMemberRepository {
+	return &projectMemberRepository{
+		db: db,
+	}
+}
+
+func (r *projectMemberRepository) Add(ctx context.Context, member models.ProjectMember) error {
+	query := `
+        INSERT INTO project_member (
+            project_id, user_id, added_by, added_at
+        ) VALUES (
+            :project_id, :user_id, :added_by, :added_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, member); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("user is already a member of this project")
+		}
+		return fmt.Errorf("failed to add project member: %w", err)
+	}
+	return nil
+}
+
+func (r *projectMemberRepository) Remove(ctx context.Context, projectID, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM project_member WHERE project_id = $1 AND user_id = $2`, projectID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove project member: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("project member not found")
+	}
+	return nil
+}
+
+func (r *projectMemberRepository) ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.ProjectMemberDetail, error) {
+	var members []models.ProjectMemberDetail
+	query := `
+        SELECT pm.project_id, pm.user_id, pm.added_by, pm.added_at, 
+            u.username, u.first_name, u.last_name, u.role, u.is_active
+        FROM project_member pm
+        JOIN "User" u ON u.user_id = pm.user_id
+        WHERE pm.project_id = $1
+        ORDER BY u.first_name, u.last_name, u.username`
+
+	if err := r.db.SelectContext(ctx, &members, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list project members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *projectMemberRepository) IsMember(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM project_member WHERE project_id = $1 AND user_id = $2)`
+
+	if err := r.db.GetContext(ctx, &exists, query, projectID, userID); err != nil {
+		return false, fmt.Errorf("failed to check project membership: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *projectMemberRepository) ListProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var projectIDs []uuid.UUID
+	query := `SELECT project_id FROM project_member WHERE user_id = $1`
+
+	if err := r.db.SelectContext(ctx, &projectIDs, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list member projects: %w", err)
+	}
+	return projectIDs, nil
+}
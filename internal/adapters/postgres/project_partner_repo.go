@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type projectPartnerRepository struct {
+	db *sqlx.DB
+}
+
+func NewProjectPartnerRepository(db *sqlx.DB) repositories.ProjectPartnerRepository {
+	return &projectPartnerRepository{
+		db: db,
+	}
+}
+
+func (r *projectPartnerRepository) ListPartners(ctx context.Context, projectID uuid.UUID) ([]models.ProjectPartner, error) {
+	var partners []models.ProjectPartner
+	query := `
+        SELECT * FROM project_partner 
+        WHERE project_id = $1 
+        ORDER BY is_self DESC, share_percent DESC, name`
+
+	if err := r.db.SelectContext(ctx, &partners, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get project partners: %w", err)
+	}
+	return partners, nil
+}
+
+func (r *projectPartnerRepository) ReplacePartners(ctx context.Context, projectID uuid.UUID, partners []models.ProjectPartner) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM project_partner WHERE project_id = $1`, projectID); err != nil {
+		return fmt.Errorf("failed to clear project partners: %w", err)
+	}
+
+	query := `
+        INSERT INTO project_partner (
+            partner_id, project_id, name, tax_id, share_percent, is_self, created_at
+        ) VALUES (
+            :partner_id, :project_id, :name, :tax_id, :share_percent, :is_self, :created_at
+        )`
+	for _, partner := range partners {
+		if _, err := tx.NamedExecContext(ctx, query, partner); err != nil {
+			return fmt.Errorf("failed to save project partner: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
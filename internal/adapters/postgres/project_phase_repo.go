@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type projectPhaseRepository struct {
+	db *sqlx.DB
+}
+
+func NewProjectPhaseRepository(db *sqlx.DB) repositories.ProjectPhaseRepository {
+	return &projectPhaseRepository{
+		db: db,
+	}
+}
+
+const insertProjectPhaseQuery = `
+        INSERT INTO project_phase (
+            phase_id, project_id, name, sort_order, created_at
+        ) VALUES (
+            :phase_id, :project_id, :name, :sort_order, :created_at
+        )`
+
+func (r *projectPhaseRepository) Create(ctx context.Context, projectID uuid.UUID, req requests.CreateProjectPhaseRequest) (*models.ProjectPhase, error) {
+	phase := &models.ProjectPhase{
+		PhaseID:   uuid.New(),
+		ProjectID: projectID,
+		Name:      req.Name,
+		SortOrder: req.SortOrder,
+		CreatedAt: time.Now(),
+	}
+
+	if _, err := r.db.NamedExecContext(ctx, insertProjectPhaseQuery, phase); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return nil, errors.New("phase with this name already exists")
+		}
+		return nil, fmt.Errorf("failed to create phase: %w", err)
+	}
+
+	return phase, nil
+}
+
+// CreateDefaults adds the named phases in order, skipping any the project
+// already has.
+func (r *projectPhaseRepository) CreateDefaults(ctx context.Context, projectID uuid.UUID, names []string) ([]models.ProjectPhase, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var existing []string
+	if err := tx.SelectContext(ctx, &existing, `SELECT LOWER(name) FROM project_phase WHERE project_id = $1`, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get existing phases: %w", err)
+	}
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	var created []models.ProjectPhase
+	for i, name := range names {
+		if exists[strings.ToLower(name)] {
+			continue
+		}
+
+		phase := models.ProjectPhase{
+			PhaseID:   uuid.New(),
+			ProjectID: projectID,
+			Name:      name,
+			SortOrder: (i + 1) * 10,
+			CreatedAt: time.Now(),
+		}
+		if _, err := tx.NamedExecContext(ctx, insertProjectPhaseQuery, phase); err != nil {
+			return nil, fmt.Errorf("failed to create phase: %w", err)
+		}
+		created = append(created, phase)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *projectPhaseRepository) GetByID(ctx context.Context, phaseID uuid.UUID) (*models.ProjectPhase, error) {
+	var phase models.ProjectPhase
+	query := `SELECT * FROM project_phase WHERE phase_id = $1`
+
+	err := r.db.GetContext(ctx, &phase, query, phaseID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("phase not found")
+		}
+		return nil, fmt.Errorf("failed to get phase: %w", err)
+	}
+
+	return &phase, nil
+}
+
+func (r *projectPhaseRepository) ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.ProjectPhase, error) {
+	var phases []models.ProjectPhase
+	query := `
+        SELECT * FROM project_phase 
+        WHERE project_id = $1 
+        ORDER BY sort_order, name`
+
+	if err := r.db.SelectContext(ctx, &phases, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list phases: %w", err)
+	}
+
+	return phases, nil
+}
+
+func (r *projectPhaseRepository) Update(ctx context.Context, phaseID uuid.UUID, req requests.UpdateProjectPhaseRequest) error {
+	query := `
+        UPDATE project_phase SET 
+            name = $1,
+            sort_order = $2
+        WHERE phase_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, req.Name, req.SortOrder, phaseID)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("phase with this name already exists")
+		}
+		return fmt.Errorf("failed to update phase: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("phase not found")
+	}
+
+	return nil
+}
+
+// Delete removes a phase; its jobs, invoices and tasks become unassigned.
+func (r *projectPhaseRepository) Delete(ctx context.Context, phaseID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE boq_job SET phase_id = NULL WHERE phase_id = $1`, phaseID); err != nil {
+		return fmt.Errorf("failed to unassign BOQ jobs: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE invoice SET phase_id = NULL WHERE phase_id = $1`, phaseID); err != nil {
+		return fmt.Errorf("failed to unassign invoices: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE schedule_task SET phase_id = NULL WHERE phase_id = $1`, phaseID); err != nil {
+		return fmt.Errorf("failed to unassign schedule tasks: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM project_phase WHERE phase_id = $1`, phaseID)
+	if err != nil {
+		return fmt.Errorf("failed to delete phase: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("phase not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *projectPhaseRepository) AssignBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, phaseID *uuid.UUID) error {
+	query := `
+        UPDATE boq_job SET phase_id = $1 
+        WHERE boq_id = $2 AND job_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, phaseID, boqID, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to assign job phase: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("job not found in this BOQ")
+	}
+
+	return nil
+}
+
+func (r *projectPhaseRepository) GetSummary(ctx context.Context, projectID uuid.UUID) ([]models.PhaseSummary, error) {
+	query := `
+        WITH material_totals AS (
+            SELECT job_id, boq_id, 
+                SUM(COALESCE(estimated_price, 0) * COALESCE(quantity, 0)) AS total_material_price
+            FROM material_price_log
+            GROUP BY job_id, boq_id
+        ),
+        job_totals AS (
+            SELECT 
+                bj.phase_id,
+                SUM((COALESCE(mt.total_material_price, 0) + COALESCE(bj.labor_cost, 0)) * bj.quantity) AS estimated_total,
+                SUM(COALESCE(bj.selling_price, 0) * bj.quantity) AS quoted_total
+            FROM boq b
+            JOIN boq_job bj ON bj.boq_id = b.boq_id
+            LEFT JOIN material_totals mt ON mt.job_id = bj.job_id AND mt.boq_id = bj.boq_id
+            WHERE b.project_id = $1
+            GROUP BY bj.phase_id
+        ),
+        invoice_totals AS (
+            SELECT 
+                i.phase_id,
+                SUM(COALESCE(i.amount, 0)) AS invoiced_total,
+                SUM(COALESCE(pay.paid, 0)) AS paid_total
+            FROM invoice i
+            LEFT JOIN (
+                SELECT invoice_id, SUM(amount) AS paid 
+                FROM payment 
+                GROUP BY invoice_id
+            ) pay ON pay.invoice_id = i.invoice_id
+            WHERE i.project_id = $1
+            GROUP BY i.phase_id
+        ),
+        phases AS (
+            SELECT phase_id, name, sort_order FROM project_phase WHERE project_id = $1
+            UNION ALL
+            SELECT NULL::uuid, NULL::text, NULL::int
+        )
+        SELECT 
+            ph.phase_id,
+            ph.name AS phase_name,
+            ph.sort_order,
+            COALESCE(jt.estimated_total, 0) AS estimated_total,
+            COALESCE(jt.quoted_total, 0) AS quoted_total,
+            COALESCE(it.invoiced_total, 0) AS invoiced_total,
+            COALESCE(it.paid_total, 0) AS paid_total
+        FROM phases ph
+        LEFT JOIN job_totals jt ON jt.phase_id IS NOT DISTINCT FROM ph.phase_id
+        LEFT JOIN invoice_totals it ON it.phase_id IS NOT DISTINCT FROM ph.phase_id
+        ORDER BY ph.sort_order NULLS LAST, ph.name`
+
+	var summary []models.PhaseSummary
+	if err := r.db.SelectContext(ctx, &summary, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get phase summary: %w", err)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type publicStatsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPublicStatsRepository(db *sqlx.DB) repositories.PublicStatsRepository {
+	return &publicStatsRepository{
+		db: db,
+	}
+}
+
+// GetPublicStats measures area built as the quantity of BOQ jobs priced per
+// square metre on completed projects; projects have no floor area of their
+// own.
+func (r *publicStatsRepository) GetPublicStats(ctx context.Context) (*models.PublicStats, error) {
+	query := `
+        SELECT 
+            COUNT(*) FILTER (WHERE p.status = 'completed') AS projects_completed,
+            COUNT(*) FILTER (WHERE p.status = 'in_progress') AS projects_in_progress,
+            COUNT(DISTINCT p.client_id) FILTER (WHERE p.status = 'completed') AS clients_served,
+            COALESCE((
+                SELECT SUM(bj.quantity)
+                FROM boq_job bj
+                JOIN boq b ON b.boq_id = bj.boq_id
+                JOIN project cp ON cp.project_id = b.project_id
+                JOIN job j ON j.job_id = bj.job_id
+                WHERE cp.status = 'completed'
+                    AND LOWER(REPLACE(j.unit, ' ', '')) IN ('m2', 'm²', 'sqm', 'sq.m.', 'ตร.ม.', 'ตรม.', 'ตารางเมตร')
+            ), 0) AS area_built_m2
+        FROM project p`
+
+	var stats models.PublicStats
+	if err := r.db.GetContext(ctx, &stats, query); err != nil {
+		return nil, fmt.Errorf("failed to get public stats: %w", err)
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,309 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type purchaseOrderRepository struct {
+	db *sqlx.DB
+}
+
+func NewPurchaseOrderRepository(db *sqlx.DB) repositories.PurchaseOrderRepository {
+	return &purchaseOrderRepository{
+		db: db,
+	}
+}
+
+const purchaseOrderSummaryQuery = `
+        SELECT po.*, s.name AS supplier_name,
+            COALESCE(t.total_amount, 0) AS total_amount,
+            COALESCE(t.received_amount, 0) AS received_amount
+        FROM purchase_order po
+        JOIN supplier s ON s.supplier_id = po.supplier_id
+        LEFT JOIN (
+            SELECT l.po_id,
+                SUM(a.quantity * l.unit_price) AS total_amount,
+                SUM(a.received_quantity * l.unit_price) AS received_amount
+            FROM purchase_order_line l
+            JOIN purchase_order_allocation a ON a.line_id = l.line_id
+            GROUP BY l.po_id
+        ) t ON t.po_id = po.po_id`
+
+func (r *purchaseOrderRepository) Create(ctx context.Context, po models.PurchaseOrder, lines []models.PurchaseOrderLine, allocations []models.PurchaseOrderAllocation) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO purchase_order (
+            po_id, po_number, supplier_id, agreement_id, status, order_date, note, created_by, created_at
+        ) VALUES (
+            :po_id, :po_number, :supplier_id, :agreement_id, :status, :order_date, :note, :created_by, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, po); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("purchase order number already exists")
+		}
+		return fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	query = `
+        INSERT INTO purchase_order_line (
+            line_id, po_id, material_id, unit_price, sort_order
+        ) VALUES (
+            :line_id, :po_id, :material_id, :unit_price, :sort_order
+        )`
+	for _, line := range lines {
+		if _, err := tx.NamedExecContext(ctx, query, line); err != nil {
+			return fmt.Errorf("failed to create purchase order line: %w", err)
+		}
+	}
+
+	query = `
+        INSERT INTO purchase_order_allocation (
+            allocation_id, line_id, project_id, quantity, received_quantity, delivery_address
+        ) VALUES (
+            :allocation_id, :line_id, :project_id, :quantity, :received_quantity, :delivery_address
+        )`
+	for _, allocation := range allocations {
+		if _, err := tx.NamedExecContext(ctx, query, allocation); err != nil {
+			return fmt.Errorf("failed to create purchase order allocation: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *purchaseOrderRepository) GetByID(ctx context.Context, poID uuid.UUID) (*models.PurchaseOrderSummary, error) {
+	var po models.PurchaseOrderSummary
+	query := purchaseOrderSummaryQuery + ` WHERE po.po_id = $1`
+
+	if err := r.db.GetContext(ctx, &po, query, poID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("purchase order not found")
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+	return &po, nil
+}
+
+func (r *purchaseOrderRepository) List(ctx context.Context, supplierID, projectID uuid.NullUUID) ([]models.PurchaseOrderSummary, error) {
+	var orders []models.PurchaseOrderSummary
+	query := purchaseOrderSummaryQuery + `
+        WHERE ($1::uuid IS NULL OR po.supplier_id = $1)
+            AND ($2::uuid IS NULL OR EXISTS (
+                SELECT 1 FROM purchase_order_line l
+                JOIN purchase_order_allocation a ON a.line_id = l.line_id
+                WHERE l.po_id = po.po_id AND a.project_id = $2
+            ))
+        ORDER BY po.order_date DESC, po.created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &orders, query, supplierID, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list purchase orders: %w", err)
+	}
+	return orders, nil
+}
+
+func (r *purchaseOrderRepository) ListByAgreement(ctx context.Context, agreementID uuid.UUID) ([]models.PurchaseOrderSummary, error) {
+	var orders []models.PurchaseOrderSummary
+	query := purchaseOrderSummaryQuery + `
+        WHERE po.agreement_id = $1
+        ORDER BY po.order_date DESC, po.created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &orders, query, agreementID); err != nil {
+		return nil, fmt.Errorf("failed to list release orders: %w", err)
+	}
+	return orders, nil
+}
+
+func (r *purchaseOrderRepository) ListLines(ctx context.Context, poID uuid.UUID) ([]models.PurchaseOrderLine, error) {
+	var lines []models.PurchaseOrderLine
+	query := `SELECT * FROM purchase_order_line WHERE po_id = $1 ORDER BY sort_order`
+
+	if err := r.db.SelectContext(ctx, &lines, query, poID); err != nil {
+		return nil, fmt.Errorf("failed to list purchase order lines: %w", err)
+	}
+	return lines, nil
+}
+
+func (r *purchaseOrderRepository) ListAllocations(ctx context.Context, poID uuid.UUID) ([]models.PurchaseOrderAllocation, error) {
+	var allocations []models.PurchaseOrderAllocation
+	query := `
+        SELECT a.* FROM purchase_order_allocation a
+        JOIN purchase_order_line l ON l.line_id = a.line_id
+        WHERE l.po_id = $1
+        ORDER BY l.sort_order, a.project_id`
+
+	if err := r.db.SelectContext(ctx, &allocations, query, poID); err != nil {
+		return nil, fmt.Errorf("failed to list purchase order allocations: %w", err)
+	}
+	return allocations, nil
+}
+
+func (r *purchaseOrderRepository) ListReceipts(ctx context.Context, poID uuid.UUID) ([]models.PurchaseOrderReceipt, error) {
+	var receipts []models.PurchaseOrderReceipt
+	query := `
+        SELECT r.* FROM purchase_order_receipt r
+        JOIN purchase_order_allocation a ON a.allocation_id = r.allocation_id
+        JOIN purchase_order_line l ON l.line_id = a.line_id
+        WHERE l.po_id = $1
+        ORDER BY r.received_date, r.created_at`
+
+	if err := r.db.SelectContext(ctx, &receipts, query, poID); err != nil {
+		return nil, fmt.Errorf("failed to list purchase order receipts: %w", err)
+	}
+	return receipts, nil
+}
+
+func (r *purchaseOrderRepository) Cancel(ctx context.Context, poID uuid.UUID) error {
+	query := `UPDATE purchase_order SET status = 'cancelled' WHERE po_id = $1 AND status = 'open'`
+
+	result, err := r.db.ExecContext(ctx, query, poID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel purchase order: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("only open purchase orders with nothing received can be cancelled")
+	}
+	return nil
+}
+
+func (r *purchaseOrderRepository) ProjectUsesMaterial(ctx context.Context, projectID uuid.UUID, materialID string) (bool, error) {
+	var uses bool
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM material_price_log mpl
+            JOIN boq b ON b.boq_id = mpl.boq_id
+            WHERE b.project_id = $1 AND mpl.material_id = $2
+        )`
+
+	if err := r.db.GetContext(ctx, &uses, query, projectID, materialID); err != nil {
+		return false, fmt.Errorf("failed to check project material: %w", err)
+	}
+	return uses, nil
+}
+
+func (r *purchaseOrderRepository) Receive(ctx context.Context, poID uuid.UUID, status models.PurchaseOrderStatus, receipts []models.PurchaseOrderReceipt) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	type costTarget struct {
+		ProjectID  uuid.UUID `db:"project_id"`
+		MaterialID string    `db:"material_id"`
+	}
+	targets := map[costTarget]bool{}
+
+	for _, receipt := range receipts {
+		query := `
+            UPDATE purchase_order_allocation 
+            SET received_quantity = received_quantity + $2
+            WHERE allocation_id = $1 AND received_quantity + $2 <= quantity + 0.00005`
+		result, err := tx.ExecContext(ctx, query, receipt.AllocationID, receipt.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to update received quantity: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rows == 0 {
+			return errors.New("quantity exceeds outstanding")
+		}
+
+		query = `
+            INSERT INTO purchase_order_receipt (
+                receipt_id, allocation_id, quantity, amount, received_date, received_by, created_at
+            ) VALUES (
+                :receipt_id, :allocation_id, :quantity, :amount, :received_date, :received_by, :created_at
+            )`
+		if _, err := tx.NamedExecContext(ctx, query, receipt); err != nil {
+			return fmt.Errorf("failed to create purchase order receipt: %w", err)
+		}
+
+		var target costTarget
+		query = `
+            SELECT a.project_id, l.material_id 
+            FROM purchase_order_allocation a
+            JOIN purchase_order_line l ON l.line_id = a.line_id
+            WHERE a.allocation_id = $1`
+		if err := tx.GetContext(ctx, &target, query, receipt.AllocationID); err != nil {
+			return fmt.Errorf("failed to get allocation: %w", err)
+		}
+		targets[target] = true
+	}
+
+	for target := range targets {
+		if err := updateReceivedActualPrice(ctx, tx, target.ProjectID, target.MaterialID, poID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE purchase_order SET status = $2 WHERE po_id = $1`, poID, status); err != nil {
+		return fmt.Errorf("failed to update purchase order status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// updateReceivedActualPrice sets a project's actual price for a material
+// to the average of what has been received for it across every order, net
+// of what was returned, and records poID's supplier against it. The price
+// is left alone once everything received has been returned.
+func updateReceivedActualPrice(ctx context.Context, tx *sqlx.Tx, projectID uuid.UUID, materialID string, poID uuid.UUID) error {
+	query := `
+        UPDATE material_price_log mpl
+        SET actual_price = net.price,
+            supplier_id = po.supplier_id,
+            supplier_name_snapshot = CASE
+                WHEN b.status = 'draft' THEN NULL
+                ELSE (SELECT name FROM supplier WHERE supplier_id = po.supplier_id)
+            END,
+            updated_at = CURRENT_TIMESTAMP
+        FROM boq b, purchase_order po, (
+            SELECT (received.amount - returned.amount) / NULLIF(received.quantity - returned.quantity, 0) AS price
+            FROM (
+                SELECT COALESCE(SUM(r.amount), 0) AS amount, COALESCE(SUM(r.quantity), 0) AS quantity
+                FROM purchase_order_receipt r
+                JOIN purchase_order_allocation a ON a.allocation_id = r.allocation_id
+                JOIN purchase_order_line l ON l.line_id = a.line_id
+                WHERE a.project_id = $1 AND l.material_id = $2
+            ) received, (
+                SELECT COALESCE(SUM(rl.amount), 0) AS amount, COALESCE(SUM(rl.quantity), 0) AS quantity
+                FROM material_return_line rl
+                WHERE rl.project_id = $1 AND rl.material_id = $2
+            ) returned
+        ) net
+        WHERE b.boq_id = mpl.boq_id 
+            AND b.project_id = $1 
+            AND mpl.material_id = $2
+            AND po.po_id = $3
+            AND net.price IS NOT NULL`
+	if _, err := tx.ExecContext(ctx, query, projectID, materialID, poID); err != nil {
+		return fmt.Errorf("failed to update actual price: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type quotationNegotiationRepository struct {
+	db *sqlx.DB
+}
+
+func NewQuotationNegotiationRepository(db *sqlx.DB) repositories.QuotationNegotiationRepository {
+	return &quotationNegotiationRepository{
+		db: db,
+	}
+}
+
+func (r *quotationNegotiationRepository) CreateRound(ctx context.Context, round *models.QuotationNegotiationRound) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the quotation so concurrent rounds don't get the same number.
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM quotation WHERE quotation_id = $1 FOR UPDATE`, round.QuotationID); err != nil {
+		return fmt.Errorf("failed to lock quotation: %w", err)
+	}
+	if err := tx.GetContext(ctx, &round.RoundNumber,
+		`SELECT COALESCE(MAX(round_number), 0) + 1 FROM quotation_negotiation_round WHERE quotation_id = $1`,
+		round.QuotationID); err != nil {
+		return fmt.Errorf("failed to number negotiation round: %w", err)
+	}
+
+	query := `
+        INSERT INTO quotation_negotiation_round (
+            round_id, quotation_id, round_number, counter_offer, offered_on, 
+            client_notes, internal_response, created_at
+        ) VALUES (
+            :round_id, :quotation_id, :round_number, :counter_offer, :offered_on, 
+            :client_notes, :internal_response, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, round); err != nil {
+		return fmt.Errorf("failed to create negotiation round: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *quotationNegotiationRepository) ListRounds(ctx context.Context, quotationID uuid.UUID) ([]models.QuotationNegotiationRound, error) {
+	var rounds []models.QuotationNegotiationRound
+	query := `SELECT * FROM quotation_negotiation_round WHERE quotation_id = $1 ORDER BY round_number`
+
+	if err := r.db.SelectContext(ctx, &rounds, query, quotationID); err != nil {
+		return nil, fmt.Errorf("failed to get negotiation rounds: %w", err)
+	}
+	return rounds, nil
+}
+
+func (r *quotationNegotiationRepository) GetRound(ctx context.Context, roundID uuid.UUID) (*models.QuotationNegotiationRound, error) {
+	var round models.QuotationNegotiationRound
+	query := `SELECT * FROM quotation_negotiation_round WHERE round_id = $1`
+
+	if err := r.db.GetContext(ctx, &round, query, roundID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("negotiation round not found")
+		}
+		return nil, fmt.Errorf("failed to get negotiation round: %w", err)
+	}
+	return &round, nil
+}
+
+func (r *quotationNegotiationRepository) UpdateRound(ctx context.Context, round models.QuotationNegotiationRound) error {
+	query := `
+        UPDATE quotation_negotiation_round SET 
+            counter_offer = :counter_offer, 
+            offered_on = :offered_on, 
+            client_notes = :client_notes, 
+            internal_response = :internal_response, 
+            updated_at = :updated_at
+        WHERE round_id = :round_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, round)
+	if err != nil {
+		return fmt.Errorf("failed to update negotiation round: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("negotiation round not found")
+	}
+	return nil
+}
+
+func (r *quotationNegotiationRepository) GetOutcomes(ctx context.Context, from, to time.Time) ([]models.QuotationOutcomeRow, error) {
+	query := `
+        SELECT q.quotation_id, p.project_id, p.name AS project_name, c.name AS client_name, 
+            CASE 
+                WHEN q.status = 'approved' THEN 'won' 
+                WHEN p.status = 'cancelled' THEN 'lost' 
+                ELSE 'open' 
+            END AS outcome, 
+            COALESCE(q.final_amount, 0) AS quoted_amount, 
+            COUNT(n.round_id) AS rounds, 
+            (SELECT n2.counter_offer FROM quotation_negotiation_round n2 
+             WHERE n2.quotation_id = q.quotation_id 
+             ORDER BY n2.round_number DESC LIMIT 1) AS last_counter_offer, 
+            p.created_at AS project_created_at
+        FROM quotation q
+        JOIN project p ON p.project_id = q.project_id
+        JOIN client c ON c.client_id = p.client_id
+        LEFT JOIN quotation_negotiation_round n ON n.quotation_id = q.quotation_id
+        WHERE p.created_at >= $1 AND p.created_at < $2
+        GROUP BY q.quotation_id, p.project_id, c.name
+        ORDER BY p.created_at`
+
+	var rows []models.QuotationOutcomeRow
+	if err := r.db.SelectContext(ctx, &rows, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get quotation outcomes: %w", err)
+	}
+	return rows, nil
+}
@@ -121,13 +121,16 @@ SELECT
     (mt.total_material_price + bj.labor_cost) as overall_cost,
     bj.selling_price,
     (mt.total_material_price + bj.labor_cost) * bj.quantity as total,
-    (bj.selling_price * bj.quantity) as total_selling_price
+    (bj.selling_price * bj.quantity) as total_selling_price,
+    COALESCE(qt.amount, 0) as transport_cost,
+    bj.tax_treatment
 FROM project p
 LEFT JOIN quotation q ON q.project_id = p.project_id
 JOIN boq b ON b.project_id = p.project_id
 JOIN boq_job bj ON bj.boq_id = b.boq_id
 JOIN job j ON j.job_id = bj.job_id
 LEFT JOIN MaterialTotals mt ON mt.job_id = j.job_id AND mt.boq_id = b.boq_id
+LEFT JOIN quotation_transport qt ON qt.quotation_id = q.quotation_id AND qt.job_id = j.job_id
 WHERE p.project_id = $1
 GROUP BY 
     q.quotation_id, 
@@ -141,7 +144,9 @@ GROUP BY
     bj.quantity, 
     bj.labor_cost, 
     bj.selling_price, 
-    mt.total_material_price`
+    mt.total_material_price,
+    qt.amount,
+    bj.tax_treatment`
 
 	var jobs []models.QuotationJob
 	err := r.db.SelectContext(ctx, &jobs, query, projectID)
@@ -234,6 +239,16 @@ func (r *quotationRepository) ApproveQuotation(ctx context.Context, projectID uu
 		return fmt.Errorf("failed to approve quotation: %w", err)
 	}
 
+	// Suppliers chosen since the BOQ was approved are snapshotted now.
+	var boqID uuid.UUID
+	if err := tx.GetContext(ctx, &boqID, `SELECT boq_id FROM boq WHERE project_id = $1`, projectID); err != nil {
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("failed to get BOQ: %w", err)
+		}
+	} else if err := snapshotSupplierNames(ctx, tx, boqID); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -252,6 +267,33 @@ func (r *quotationRepository) GetQuotationStatus(ctx context.Context, projectID
 	return status, nil
 }
 
+func (r *quotationRepository) GetTaxBases(ctx context.Context, projectID uuid.UUID) (*models.QuotationTaxBases, error) {
+	query := `
+        SELECT 
+            COALESCE(SUM(bj.selling_price * bj.quantity) FILTER (WHERE bj.tax_treatment = 'standard'), 0)
+                + COALESCE(b.selling_general_cost, 0)
+                + (SELECT COALESCE(SUM(qt.amount), 0) 
+                   FROM quotation_transport qt 
+                   JOIN quotation q ON q.quotation_id = qt.quotation_id 
+                   WHERE q.project_id = b.project_id) as standard,
+            COALESCE(SUM(bj.selling_price * bj.quantity) FILTER (WHERE bj.tax_treatment = 'zero_rated'), 0) as zero_rated,
+            COALESCE(SUM(bj.selling_price * bj.quantity) FILTER (WHERE bj.tax_treatment = 'exempt'), 0) as exempt
+        FROM boq b
+        LEFT JOIN boq_job bj ON bj.boq_id = b.boq_id
+        WHERE b.project_id = $1
+        GROUP BY b.boq_id, b.project_id, b.selling_general_cost`
+
+	var bases models.QuotationTaxBases
+	if err := r.db.GetContext(ctx, &bases, query, projectID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("BOQ not found")
+		}
+		return nil, fmt.Errorf("failed to get quotation tax bases: %w", err)
+	}
+
+	return &bases, nil
+}
+
 func (r *quotationRepository) GetExportData(ctx context.Context, projectID uuid.UUID) (*responses.QuotationExportData, error) {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -301,28 +343,38 @@ func (r *quotationRepository) GetExportData(ctx context.Context, projectID uuid.
         )
         SELECT 
             b.selling_general_cost,
+            COALESCE(ph.name, '') as phase_name,
             j.name,
             j.description,
             j.unit,
             bj.quantity,
             bj.selling_price,
-            (bj.selling_price * bj.quantity) as amount
+            (bj.selling_price * bj.quantity) as amount,
+            COALESCE(qt.amount, 0) as transport_cost,
+            bj.tax_treatment
         FROM project p
         JOIN boq b ON b.project_id = p.project_id
         JOIN boq_job bj ON bj.boq_id = b.boq_id
         JOIN job j ON j.job_id = bj.job_id
         LEFT JOIN MaterialTotals mt ON mt.job_id = j.job_id AND mt.boq_id = b.boq_id
+        LEFT JOIN project_phase ph ON ph.phase_id = bj.phase_id
+        LEFT JOIN quotation q ON q.project_id = p.project_id
+        LEFT JOIN quotation_transport qt ON qt.quotation_id = q.quotation_id AND qt.job_id = j.job_id
         WHERE p.project_id = $1
-        GROUP BY b.selling_general_cost, j.name, j.description, j.unit, bj.quantity, bj.selling_price`
+        GROUP BY b.selling_general_cost, ph.name, ph.sort_order, j.name, j.description, j.unit, bj.quantity, bj.selling_price, qt.amount, bj.tax_treatment
+        ORDER BY ph.sort_order NULLS LAST, j.name`
 
 	type jobDetailResult struct {
 		SellingGeneralCost float64         `db:"selling_general_cost"`
+		PhaseName          string          `db:"phase_name"`
 		Name               string          `db:"name"`
 		Description        string          `db:"description"`
 		Unit               string          `db:"unit"`
 		Quantity           float64         `db:"quantity"`
 		SellingPrice       sql.NullFloat64 `db:"selling_price"`
 		Amount             sql.NullFloat64 `db:"amount"`
+		TransportCost      float64         `db:"transport_cost"`
+		TaxTreatment       string          `db:"tax_treatment"`
 	}
 
 	var detailResults []jobDetailResult
@@ -331,44 +383,72 @@ func (r *quotationRepository) GetExportData(ctx context.Context, projectID uuid.
 		return nil, fmt.Errorf("failed to get job details: %w", err)
 	}
 
-	// Process job details and calculate totals
+	policy, err := getRoundingPolicy(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Process job details and calculate totals. Line amounts are rounded
+	// under the rounding policy so the totals add up from what's printed.
 	data.JobDetails = make([]responses.JobDetail, len(detailResults))
-	var totalSellingPrice float64
+	data.PhaseSubtotals = []responses.QuotationPhaseSubtotal{}
+	var lines []models.TaxedLine
 
 	// Set selling general cost from the first result
 	if len(detailResults) > 0 {
-		data.SellingGeneralCost = detailResults[0].SellingGeneralCost
+		data.SellingGeneralCost = policy.Line(detailResults[0].SellingGeneralCost)
+		lines = append(lines, models.TaxedLine{Amount: data.SellingGeneralCost, Treatment: models.TaxTreatmentStandard})
 	}
 
 	for i, result := range detailResults {
+		if result.Amount.Valid {
+			result.Amount.Float64 = policy.Line(result.Amount.Float64)
+		}
+		result.TransportCost = policy.Line(result.TransportCost)
+
 		data.JobDetails[i] = responses.JobDetail{
-			Name:         result.Name,
-			Description:  result.Description,
-			Unit:         result.Unit,
-			Quantity:     result.Quantity,
-			SellingPrice: result.SellingPrice,
-			Amount:       result.Amount,
+			PhaseName:     result.PhaseName,
+			Name:          result.Name,
+			Description:   result.Description,
+			Unit:          result.Unit,
+			Quantity:      result.Quantity,
+			SellingPrice:  result.SellingPrice,
+			Amount:        result.Amount,
+			TransportCost: result.TransportCost,
+			TaxTreatment:  result.TaxTreatment,
+		}
+		data.TransportCost += result.TransportCost
+		if result.TransportCost > 0 {
+			lines = append(lines, models.TaxedLine{Amount: result.TransportCost, Treatment: models.TaxTreatmentStandard})
+		}
+
+		// Results are ordered by phase, so a new subtotal starts whenever
+		// the phase changes.
+		last := len(data.PhaseSubtotals) - 1
+		if last < 0 || data.PhaseSubtotals[last].PhaseName != result.PhaseName {
+			data.PhaseSubtotals = append(data.PhaseSubtotals, responses.QuotationPhaseSubtotal{PhaseName: result.PhaseName})
+			last++
 		}
 
 		if result.Amount.Valid {
-			totalSellingPrice += result.Amount.Float64
+			lines = append(lines, models.TaxedLine{Amount: result.Amount.Float64, Treatment: models.TaxTreatment(result.TaxTreatment)})
+			data.PhaseSubtotals[last].Amount += result.Amount.Float64
 		}
 	}
+	data.TransportCost = policy.Line(data.TransportCost)
 
-	// Calculate subtotal including selling general cost and tax
-	data.SubTotal = data.SellingGeneralCost + totalSellingPrice
-
-	// Calculate tax amount if tax percentage exists
-	if data.TaxPercentage > 0 {
-		data.TaxAmount = data.SubTotal * data.TaxPercentage / 100
+	// General costs and transport are standard-rated
+	totals := policy.Totals(lines, data.TaxPercentage)
+	data.SubTotal = totals.SubTotal
+	data.TaxableAmount = totals.Bases.Standard
+	data.ZeroRatedAmount = totals.Bases.ZeroRated
+	data.ExemptAmount = totals.Bases.Exempt
+	data.TaxAmount = totals.TaxAmount
+	data.RoundingAdjustment = totals.RoundingAdjustment
 
-		// Update final amount if not already set
-		if !data.FinalAmount.Valid {
-			data.FinalAmount = sql.NullFloat64{
-				Float64: data.SubTotal + data.TaxAmount,
-				Valid:   true,
-			}
-		}
+	// Update final amount if not already set
+	if !data.FinalAmount.Valid && data.TaxPercentage > 0 {
+		data.FinalAmount = sql.NullFloat64{Float64: totals.GrandTotal, Valid: true}
 	}
 
 	// Format all nullable fields
@@ -421,37 +501,79 @@ func (r *quotationRepository) UpdateProjectSellingPrice(ctx context.Context, req
 
 	// Update job selling prices
 	for _, job := range req.JobSellingPrices {
-		query = `UPDATE boq_job SET selling_price = $1 WHERE boq_id = $2 AND job_id = $3`
-		_, err = tx.ExecContext(ctx, query, job.SellingPrice, boqID, job.JobID)
+		query = `
+            UPDATE boq_job 
+            SET selling_price = $1, tax_treatment = COALESCE(NULLIF($4, ''), tax_treatment) 
+            WHERE boq_id = $2 AND job_id = $3`
+		_, err = tx.ExecContext(ctx, query, job.SellingPrice, boqID, job.JobID, job.TaxTreatment)
 		if err != nil {
 			return fmt.Errorf("failed to update job selling price: %w", err)
 		}
 	}
 
-	// Update final amount
-	query = `
-        WITH ProjectCostData AS (
-            SELECT 
-                q.tax_percentage, 
-                SUM(bj.selling_price * bj.quantity) + b.selling_general_cost as total_selling_price 
-            FROM project p 
-            JOIN boq b ON b.project_id = p.project_id 
-            JOIN quotation q ON q.project_id = p.project_id 
-            LEFT JOIN boq_job bj ON bj.boq_id = b.boq_id 
-            WHERE p.project_id = $1 
-            GROUP BY bj.boq_id, q.tax_percentage , b.selling_general_cost
-        )
-        UPDATE quotation 
-        SET final_amount = (
-            SELECT (ProjectCostData.tax_percentage * ProjectCostData.total_selling_price / 100) + ProjectCostData.total_selling_price 
-            FROM ProjectCostData
-        ) 
-        WHERE project_id = $1`
+	if err := updateQuotationFinalAmount(ctx, tx, req.ProjectID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
 
-	_, err = tx.ExecContext(ctx, query, req.ProjectID)
+// updateQuotationFinalAmount recalculates a project's quotation total from
+// job selling prices, selling general cost and transport under the rounding
+// policy. Tax is only charged on standard-rated lines; general cost and
+// transport always are.
+func updateQuotationFinalAmount(ctx context.Context, tx *sqlx.Tx, projectID uuid.UUID) error {
+	policy, err := getRoundingPolicy(ctx, tx)
 	if err != nil {
+		return err
+	}
+
+	var quotation struct {
+		QuotationID   uuid.UUID       `db:"quotation_id"`
+		TaxPercentage sql.NullFloat64 `db:"tax_percentage"`
+	}
+	query := `SELECT quotation_id, tax_percentage FROM quotation WHERE project_id = $1`
+	if err := tx.GetContext(ctx, &quotation, query, projectID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to get quotation: %w", err)
+	}
+
+	// One row per priced line: jobs, the selling general cost and each
+	// transport line.
+	query = `
+        SELECT bj.selling_price * bj.quantity as amount, bj.tax_treatment as treatment
+        FROM boq b
+        JOIN boq_job bj ON bj.boq_id = b.boq_id
+        WHERE b.project_id = $1 AND bj.selling_price IS NOT NULL
+        UNION ALL
+        SELECT b.selling_general_cost, 'standard'
+        FROM boq b
+        WHERE b.project_id = $1 AND b.selling_general_cost IS NOT NULL
+        UNION ALL
+        SELECT qt.amount, 'standard'
+        FROM quotation_transport qt
+        WHERE qt.quotation_id = $2`
+
+	var lines []struct {
+		Amount    float64             `db:"amount"`
+		Treatment models.TaxTreatment `db:"treatment"`
+	}
+	if err := tx.SelectContext(ctx, &lines, query, projectID, quotation.QuotationID); err != nil {
+		return fmt.Errorf("failed to get quotation lines: %w", err)
+	}
+
+	taxed := make([]models.TaxedLine, len(lines))
+	for i, line := range lines {
+		taxed[i] = models.TaxedLine{Amount: line.Amount, Treatment: line.Treatment}
+	}
+	totals := policy.Totals(taxed, quotation.TaxPercentage.Float64)
+
+	query = `UPDATE quotation SET final_amount = $1 WHERE quotation_id = $2`
+	if _, err := tx.ExecContext(ctx, query, totals.GrandTotal, quotation.QuotationID); err != nil {
 		return fmt.Errorf("failed to update final amount: %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type quotationSectionRepository struct {
+	db *sqlx.DB
+}
+
+func NewQuotationSectionRepository(db *sqlx.DB) repositories.QuotationSectionRepository {
+	return &quotationSectionRepository{
+		db: db,
+	}
+}
+
+func (r *quotationSectionRepository) ListSections(ctx context.Context, quotationID uuid.UUID) ([]models.QuotationSection, error) {
+	var sections []models.QuotationSection
+	query := `SELECT * FROM quotation_section WHERE quotation_id = $1`
+
+	if err := r.db.SelectContext(ctx, &sections, query, quotationID); err != nil {
+		return nil, fmt.Errorf("failed to get quotation sections: %w", err)
+	}
+
+	return sections, nil
+}
+
+func (r *quotationSectionRepository) SaveSections(ctx context.Context, quotationID uuid.UUID, sections []models.QuotationSection, remove []models.QuotationSectionKind) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO quotation_section (quotation_id, kind, content, updated_at)
+        VALUES (:quotation_id, :kind, :content, :updated_at)
+        ON CONFLICT (quotation_id, kind) DO UPDATE 
+        SET content = EXCLUDED.content, 
+            updated_at = EXCLUDED.updated_at`
+	for _, section := range sections {
+		if _, err := tx.NamedExecContext(ctx, query, section); err != nil {
+			return fmt.Errorf("failed to save quotation section: %w", err)
+		}
+	}
+
+	for _, kind := range remove {
+		query := `DELETE FROM quotation_section WHERE quotation_id = $1 AND kind = $2`
+		if _, err := tx.ExecContext(ctx, query, quotationID, kind); err != nil {
+			return fmt.Errorf("failed to delete quotation section: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *quotationSectionRepository) CreateSnippet(ctx context.Context, snippet *models.QuotationSnippet) error {
+	query := `
+        INSERT INTO quotation_snippet (snippet_id, kind, title, content, created_at)
+        VALUES (:snippet_id, :kind, :title, :content, :created_at)`
+
+	if _, err := r.db.NamedExecContext(ctx, query, snippet); err != nil {
+		return fmt.Errorf("failed to create quotation snippet: %w", err)
+	}
+
+	return nil
+}
+
+func (r *quotationSectionRepository) GetSnippetByID(ctx context.Context, snippetID uuid.UUID) (*models.QuotationSnippet, error) {
+	var snippet models.QuotationSnippet
+	query := `SELECT * FROM quotation_snippet WHERE snippet_id = $1`
+
+	if err := r.db.GetContext(ctx, &snippet, query, snippetID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("snippet not found")
+		}
+		return nil, fmt.Errorf("failed to get quotation snippet: %w", err)
+	}
+
+	return &snippet, nil
+}
+
+func (r *quotationSectionRepository) ListSnippets(ctx context.Context, kind models.QuotationSectionKind) ([]models.QuotationSnippet, error) {
+	var snippets []models.QuotationSnippet
+	query := `
+        SELECT * FROM quotation_snippet 
+        WHERE $1 = '' OR kind = $1 
+        ORDER BY kind, title`
+
+	if err := r.db.SelectContext(ctx, &snippets, query, kind); err != nil {
+		return nil, fmt.Errorf("failed to list quotation snippets: %w", err)
+	}
+
+	return snippets, nil
+}
+
+func (r *quotationSectionRepository) UpdateSnippet(ctx context.Context, snippet *models.QuotationSnippet) error {
+	query := `
+        UPDATE quotation_snippet 
+        SET kind = :kind, title = :title, content = :content, updated_at = :updated_at 
+        WHERE snippet_id = :snippet_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, snippet)
+	if err != nil {
+		return fmt.Errorf("failed to update quotation snippet: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("snippet not found")
+	}
+
+	return nil
+}
+
+func (r *quotationSectionRepository) DeleteSnippet(ctx context.Context, snippetID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM quotation_snippet WHERE snippet_id = $1`, snippetID)
+	if err != nil {
+		return fmt.Errorf("failed to delete quotation snippet: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("snippet not found")
+	}
+
+	return nil
+}
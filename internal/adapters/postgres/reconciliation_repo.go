@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type reconciliationRepository struct {
+	db *sqlx.DB
+}
+
+func NewReconciliationRepository(db *sqlx.DB) repositories.ReconciliationRepository {
+	return &reconciliationRepository{
+		db: db,
+	}
+}
+
+// Unlike the project overview, missing estimated prices don't fail the
+// query; they are counted so the reconciliation can point them out.
+func (r *reconciliationRepository) GetTotals(ctx context.Context, projectID uuid.UUID) (*models.ReconciliationTotals, error) {
+	query := `
+        WITH MaterialTotals AS (
+            SELECT 
+                mpl.boq_id,
+                mpl.job_id,
+                SUM(COALESCE(mpl.estimated_price, 0) * mpl.quantity) AS material_cost,
+                COUNT(*) FILTER (WHERE mpl.estimated_price IS NULL) AS unpriced
+            FROM material_price_log mpl
+            JOIN boq b ON b.boq_id = mpl.boq_id
+            WHERE b.project_id = $1
+            GROUP BY mpl.boq_id, mpl.job_id
+        ), JobTotals AS (
+            SELECT 
+                bj.boq_id,
+                SUM((COALESCE(mt.material_cost, 0) + bj.labor_cost) * bj.quantity) AS job_cost,
+                SUM(bj.selling_price * bj.quantity) AS job_selling_price,
+                SUM(COALESCE(mt.unpriced, 0)) AS unpriced
+            FROM boq_job bj
+            JOIN boq b ON b.boq_id = bj.boq_id
+            LEFT JOIN MaterialTotals mt ON mt.boq_id = bj.boq_id AND mt.job_id = bj.job_id
+            WHERE b.project_id = $1
+            GROUP BY bj.boq_id
+        ), GeneralCost AS (
+            SELECT 
+                gc.boq_id,
+                SUM(gc.estimated_cost) AS estimated_cost
+            FROM general_cost gc
+            JOIN boq b ON b.boq_id = gc.boq_id
+            WHERE b.project_id = $1
+            GROUP BY gc.boq_id
+        )
+        SELECT 
+            b.boq_id,
+            b.status AS boq_status,
+            COALESCE(jt.job_cost, 0) + COALESCE(gc.estimated_cost, 0) AS estimated_cost,
+            COALESCE(jt.unpriced, 0) AS unpriced_materials,
+            q.quotation_id,
+            q.status AS quotation_status,
+            COALESCE(jt.job_selling_price, 0) + COALESCE(b.selling_general_cost, 0) AS selling_price,
+            COALESCE(q.tax_percentage, 0) AS tax_percentage,
+            q.final_amount
+        FROM project p
+        LEFT JOIN boq b ON b.project_id = p.project_id
+        LEFT JOIN quotation q ON q.project_id = p.project_id
+        LEFT JOIN JobTotals jt ON jt.boq_id = b.boq_id
+        LEFT JOIN GeneralCost gc ON gc.boq_id = b.boq_id
+        WHERE p.project_id = $1`
+
+	var totals models.ReconciliationTotals
+	err := r.db.GetContext(ctx, &totals, query, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("project not found")
+		}
+		return nil, fmt.Errorf("failed to get reconciliation totals: %w", err)
+	}
+
+	return &totals, nil
+}
+
+func (r *reconciliationRepository) ListInvoices(ctx context.Context, projectID uuid.UUID) ([]models.ReconciliationInvoice, error) {
+	query := `
+        SELECT 
+            i.invoice_id,
+            i.phase_id,
+            i.amount,
+            COALESCE(li.late_interest, 0) AS late_interest,
+            COALESCE(pm.paid, 0) AS paid,
+            i.created_at
+        FROM invoice i
+        LEFT JOIN (
+            SELECT invoice_id, SUM(amount) AS paid
+            FROM payment
+            GROUP BY invoice_id
+        ) pm ON pm.invoice_id = i.invoice_id
+        LEFT JOIN (
+            SELECT billed_invoice_id, SUM(amount) AS late_interest
+            FROM late_interest_accrual
+            WHERE billed_invoice_id IS NOT NULL
+            GROUP BY billed_invoice_id
+        ) li ON li.billed_invoice_id = i.invoice_id
+        WHERE i.project_id = $1
+        ORDER BY i.created_at`
+
+	invoices := []models.ReconciliationInvoice{}
+	err := r.db.SelectContext(ctx, &invoices, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reconciliation invoices: %w", err)
+	}
+
+	return invoices, nil
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type recurringGeneralCostRepository struct {
+	db *sqlx.DB
+}
+
+func NewRecurringGeneralCostRepository(db *sqlx.DB) repositories.RecurringGeneralCostRepository {
+	return &recurringGeneralCostRepository{
+		db: db,
+	}
+}
+
+func (r *recurringGeneralCostRepository) Create(ctx context.Context, cost models.RecurringGeneralCost) error {
+	query := `
+        INSERT INTO recurring_general_cost (
+            recurring_id, project_id, type_name, description, monthly_amount, 
+            start_period, end_period, created_at, updated_at
+        ) VALUES (
+            :recurring_id, :project_id, :type_name, :description, :monthly_amount, 
+            :start_period, :end_period, :created_at, :updated_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, cost); err != nil {
+		return fmt.Errorf("failed to create recurring general cost: %w", err)
+	}
+	return nil
+}
+
+func (r *recurringGeneralCostRepository) Update(ctx context.Context, cost models.RecurringGeneralCost) error {
+	query := `
+        UPDATE recurring_general_cost SET 
+            type_name = :type_name,
+            description = :description,
+            monthly_amount = :monthly_amount,
+            start_period = :start_period,
+            end_period = :end_period,
+            updated_at = :updated_at
+        WHERE recurring_id = :recurring_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, cost)
+	if err != nil {
+		return fmt.Errorf("failed to update recurring general cost: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("recurring general cost not found")
+	}
+	return nil
+}
+
+func (r *recurringGeneralCostRepository) GetByID(ctx context.Context, recurringID uuid.UUID) (*models.RecurringGeneralCost, error) {
+	var cost models.RecurringGeneralCost
+	query := `SELECT * FROM recurring_general_cost WHERE recurring_id = $1`
+
+	if err := r.db.GetContext(ctx, &cost, query, recurringID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("recurring general cost not found")
+		}
+		return nil, fmt.Errorf("failed to get recurring general cost: %w", err)
+	}
+	return &cost, nil
+}
+
+func (r *recurringGeneralCostRepository) ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.RecurringGeneralCost, error) {
+	var costs []models.RecurringGeneralCost
+	query := `
+        SELECT * FROM recurring_general_cost 
+        WHERE project_id = $1 
+        ORDER BY start_period, type_name, description`
+
+	if err := r.db.SelectContext(ctx, &costs, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list recurring general costs: %w", err)
+	}
+	return costs, nil
+}
+
+func (r *recurringGeneralCostRepository) Delete(ctx context.Context, recurringID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM recurring_general_cost WHERE recurring_id = $1`, recurringID)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring general cost: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("recurring general cost not found")
+	}
+	return nil
+}
+
+func (r *recurringGeneralCostRepository) ListPostable(ctx context.Context) ([]models.RecurringGeneralCost, error) {
+	var costs []models.RecurringGeneralCost
+	query := `
+        SELECT rc.* 
+        FROM recurring_general_cost rc
+        JOIN project p ON p.project_id = rc.project_id
+        JOIN boq b ON b.project_id = p.project_id
+        WHERE p.status NOT IN ('completed', 'cancelled') AND b.status = 'approved'
+        ORDER BY rc.project_id, rc.start_period`
+
+	if err := r.db.SelectContext(ctx, &costs, query); err != nil {
+		return nil, fmt.Errorf("failed to list postable recurring general costs: %w", err)
+	}
+	return costs, nil
+}
+
+func (r *recurringGeneralCostRepository) ListPostingsByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.RecurringCostPosting, error) {
+	var postings []models.RecurringCostPosting
+	query := `
+        SELECT rp.* 
+        FROM recurring_cost_posting rp
+        JOIN recurring_general_cost rc ON rc.recurring_id = rp.recurring_id
+        WHERE rc.project_id = $1
+        ORDER BY rp.period`
+
+	if err := r.db.SelectContext(ctx, &postings, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list recurring cost postings: %w", err)
+	}
+	return postings, nil
+}
+
+func (r *recurringGeneralCostRepository) CreatePosting(ctx context.Context, posting models.RecurringCostPosting) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO recurring_cost_posting (
+            posting_id, recurring_id, period, amount, posted_at
+        ) VALUES (
+            :posting_id, :recurring_id, :period, :amount, :posted_at
+        ) ON CONFLICT (recurring_id, period) DO NOTHING`
+
+	result, err := tx.NamedExecContext(ctx, query, posting)
+	if err != nil {
+		return fmt.Errorf("failed to create recurring cost posting: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return nil
+	}
+
+	var target struct {
+		BOQID    uuid.UUID `db:"boq_id"`
+		TypeName string    `db:"type_name"`
+	}
+	query = `
+        SELECT b.boq_id, rc.type_name 
+        FROM recurring_general_cost rc
+        JOIN boq b ON b.project_id = rc.project_id
+        WHERE rc.recurring_id = $1`
+	if err := tx.GetContext(ctx, &target, query, posting.RecurringID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("boq not found")
+		}
+		return fmt.Errorf("failed to get general cost for posting: %w", err)
+	}
+
+	// The project's general cost row for the type may not exist yet; rows
+	// are otherwise only created when the project's costs are first read.
+	query = `
+        UPDATE general_cost SET actual_cost = COALESCE(actual_cost, 0) + $3 
+        WHERE boq_id = $1 AND type_name = $2`
+	result, err = tx.ExecContext(ctx, query, target.BOQID, target.TypeName, posting.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to add recurring cost to general cost: %w", err)
+	}
+	if rows, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		query = `
+            INSERT INTO general_cost (
+                g_id, boq_id, type_name, actual_cost, estimated_cost
+            ) VALUES (
+                $1, $2, $3, $4, 0
+            )`
+		if _, err := tx.ExecContext(ctx, query, uuid.New(), target.BOQID, target.TypeName, posting.Amount); err != nil {
+			return fmt.Errorf("failed to create general cost for type %s: %w", target.TypeName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
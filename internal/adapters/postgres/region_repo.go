@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type regionRepository struct {
+	db *sqlx.DB
+}
+
+func NewRegionRepository(db *sqlx.DB) repositories.RegionRepository {
+	return &regionRepository{
+		db: db,
+	}
+}
+
+func (r *regionRepository) Create(ctx context.Context, preset *models.RegionPreset) error {
+	query := `
+        INSERT INTO region_preset (
+            region_id, code, name, daily_labor_rate, labor_rate_factor,
+            transport_cost_percent, created_at
+        ) VALUES (
+            :region_id, :code, :name, :daily_labor_rate, :labor_rate_factor,
+            :transport_cost_percent, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, preset); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("region code already exists")
+		}
+		return fmt.Errorf("failed to create region preset: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDefaults adds the presets whose code doesn't exist yet.
+func (r *regionRepository) CreateDefaults(ctx context.Context, presets []models.RegionPreset) error {
+	query := `
+        INSERT INTO region_preset (
+            region_id, code, name, daily_labor_rate, labor_rate_factor,
+            transport_cost_percent, created_at
+        ) VALUES (
+            :region_id, :code, :name, :daily_labor_rate, :labor_rate_factor,
+            :transport_cost_percent, :created_at
+        )
+        ON CONFLICT (code) DO NOTHING`
+
+	for _, preset := range presets {
+		preset.RegionID = uuid.New()
+		preset.CreatedAt = time.Now()
+		if _, err := r.db.NamedExecContext(ctx, query, preset); err != nil {
+			return fmt.Errorf("failed to create region preset: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *regionRepository) GetByID(ctx context.Context, regionID uuid.UUID) (*models.RegionPreset, error) {
+	var preset models.RegionPreset
+	err := r.db.GetContext(ctx, &preset, `SELECT * FROM region_preset WHERE region_id = $1`, regionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("region preset not found")
+		}
+		return nil, fmt.Errorf("failed to get region preset: %w", err)
+	}
+
+	return &preset, nil
+}
+
+func (r *regionRepository) List(ctx context.Context) ([]models.RegionPreset, error) {
+	var presets []models.RegionPreset
+	if err := r.db.SelectContext(ctx, &presets, `SELECT * FROM region_preset ORDER BY name`); err != nil {
+		return nil, fmt.Errorf("failed to list region presets: %w", err)
+	}
+
+	return presets, nil
+}
+
+func (r *regionRepository) Update(ctx context.Context, preset *models.RegionPreset) error {
+	query := `
+        UPDATE region_preset SET 
+            code = :code,
+            name = :name,
+            daily_labor_rate = :daily_labor_rate,
+            labor_rate_factor = :labor_rate_factor,
+            transport_cost_percent = :transport_cost_percent,
+            updated_at = :updated_at
+        WHERE region_id = :region_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, preset)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("region code already exists")
+		}
+		return fmt.Errorf("failed to update region preset: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("region preset not found")
+	}
+
+	return nil
+}
+
+func (r *regionRepository) Delete(ctx context.Context, regionID uuid.UUID) error {
+	var inUse bool
+	if err := r.db.GetContext(ctx, &inUse, `SELECT EXISTS (SELECT 1 FROM project_region WHERE region_id = $1)`, regionID); err != nil {
+		return fmt.Errorf("failed to check region usage: %w", err)
+	}
+	if inUse {
+		return errors.New("region preset is in use by a project")
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM region_preset WHERE region_id = $1`, regionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete region preset: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("region preset not found")
+	}
+
+	return nil
+}
+
+func (r *regionRepository) GetProjectRegion(ctx context.Context, projectID uuid.UUID) (*models.RegionPreset, error) {
+	query := `
+        SELECT rp.* 
+        FROM project_region pr
+        JOIN region_preset rp ON rp.region_id = pr.region_id
+        WHERE pr.project_id = $1`
+
+	var preset models.RegionPreset
+	if err := r.db.GetContext(ctx, &preset, query, projectID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get project region: %w", err)
+	}
+
+	return &preset, nil
+}
+
+// SetProjectRegion keeps the selection in its own table rather than on
+// project, whose row is scanned positionally elsewhere.
+func (r *regionRepository) SetProjectRegion(ctx context.Context, projectID uuid.UUID, regionID *uuid.UUID) error {
+	if regionID == nil {
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM project_region WHERE project_id = $1`, projectID); err != nil {
+			return fmt.Errorf("failed to clear project region: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+        INSERT INTO project_region (project_id, region_id)
+        VALUES ($1, $2)
+        ON CONFLICT (project_id) DO UPDATE SET region_id = EXCLUDED.region_id`
+
+	if _, err := r.db.ExecContext(ctx, query, projectID, *regionID); err != nil {
+		return fmt.Errorf("failed to set project region: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type riskRepository struct {
+	db *sqlx.DB
+}
+
+func NewRiskRepository(db *sqlx.DB) repositories.RiskRepository {
+	return &riskRepository{
+		db: db,
+	}
+}
+
+func (r *riskRepository) Create(ctx context.Context, projectID uuid.UUID, req requests.CreateRiskRequest) (*models.Risk, error) {
+	risk := &models.Risk{
+		RiskID:      uuid.New(),
+		ProjectID:   projectID,
+		Description: req.Description,
+		Probability: req.Probability,
+		Impact:      req.Impact,
+		Mitigation:  toNullString(req.Mitigation),
+		Owner:       toNullString(req.Owner),
+		Status:      models.RiskStatusOpen,
+		CreatedAt:   time.Now(),
+	}
+
+	query := `
+        INSERT INTO project_risk (
+            risk_id, project_id, description, probability, impact,
+            mitigation, owner, status, created_at
+        ) VALUES (
+            :risk_id, :project_id, :description, :probability, :impact,
+            :mitigation, :owner, :status, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, risk); err != nil {
+		return nil, fmt.Errorf("failed to create risk: %w", err)
+	}
+
+	return risk, nil
+}
+
+func (r *riskRepository) GetByID(ctx context.Context, riskID uuid.UUID) (*models.Risk, error) {
+	var risk models.Risk
+	query := `SELECT * FROM project_risk WHERE risk_id = $1`
+
+	err := r.db.GetContext(ctx, &risk, query, riskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("risk not found")
+		}
+		return nil, fmt.Errorf("failed to get risk: %w", err)
+	}
+
+	return &risk, nil
+}
+
+func (r *riskRepository) ListByProjectID(ctx context.Context, projectID uuid.UUID, status string) ([]models.Risk, error) {
+	query := `
+        SELECT * FROM project_risk 
+        WHERE project_id = $1 
+            AND ($2 = '' OR status = $2)
+        ORDER BY probability * impact DESC, created_at`
+
+	var risks []models.Risk
+	if err := r.db.SelectContext(ctx, &risks, query, projectID, status); err != nil {
+		return nil, fmt.Errorf("failed to list risks: %w", err)
+	}
+
+	return risks, nil
+}
+
+func (r *riskRepository) Update(ctx context.Context, riskID uuid.UUID, req requests.UpdateRiskRequest) error {
+	query := `
+        UPDATE project_risk SET 
+            description = $1,
+            probability = $2,
+            impact = $3,
+            mitigation = $4,
+            owner = $5,
+            status = $6,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE risk_id = $7`
+
+	result, err := r.db.ExecContext(ctx, query,
+		req.Description,
+		req.Probability,
+		req.Impact,
+		toNullString(req.Mitigation),
+		toNullString(req.Owner),
+		req.Status,
+		riskID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update risk: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("risk not found")
+	}
+
+	return nil
+}
+
+func (r *riskRepository) Delete(ctx context.Context, riskID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM project_risk WHERE risk_id = $1`, riskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete risk: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("risk not found")
+	}
+
+	return nil
+}
+
+func (r *riskRepository) CountUnmitigated(ctx context.Context, projectID uuid.UUID, minScore int) (int, error) {
+	query := `
+        SELECT COUNT(*) FROM project_risk 
+        WHERE project_id = $1 
+            AND status <> 'closed'
+            AND probability * impact >= $2
+            AND COALESCE(TRIM(mitigation), '') = ''`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, projectID, minScore); err != nil {
+		return 0, fmt.Errorf("failed to count unmitigated risks: %w", err)
+	}
+
+	return count, nil
+}
+
+func toNullString(s string) sql.NullString {
+	s = strings.TrimSpace(s)
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type roundingRepository struct {
+	db *sqlx.DB
+}
+
+func NewRoundingRepository(db *sqlx.DB) repositories.RoundingRepository {
+	return &roundingRepository{
+		db: db,
+	}
+}
+
+func (r *roundingRepository) GetPolicy(ctx context.Context) (*models.RoundingPolicy, error) {
+	return getRoundingPolicy(ctx, r.db)
+}
+
+func (r *roundingRepository) UpdatePolicy(ctx context.Context, policy *models.RoundingPolicy) error {
+	// rounding_policy holds a single row.
+	query := `
+        INSERT INTO rounding_policy (policy_id, mode, increment, updated_at)
+        VALUES (1, :mode, :increment, :updated_at)
+        ON CONFLICT (policy_id) DO UPDATE 
+        SET mode = EXCLUDED.mode, 
+            increment = EXCLUDED.increment, 
+            updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, policy); err != nil {
+		return fmt.Errorf("failed to update rounding policy: %w", err)
+	}
+
+	return nil
+}
+
+// getRoundingPolicy lets other repositories apply the policy inside their
+// own transactions.
+func getRoundingPolicy(ctx context.Context, q sqlx.QueryerContext) (*models.RoundingPolicy, error) {
+	var policy models.RoundingPolicy
+	query := `SELECT mode, increment, updated_at FROM rounding_policy WHERE policy_id = 1`
+
+	if err := sqlx.GetContext(ctx, q, &policy, query); err != nil {
+		if err == sql.ErrNoRows {
+			policy = models.DefaultRoundingPolicy()
+			return &policy, nil
+		}
+		return nil, fmt.Errorf("failed to get rounding policy: %w", err)
+	}
+
+	return &policy, nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type routePolicyRepository struct {
+	db *sqlx.DB
+}
+
+func NewRoutePolicyRepository(db *sqlx.DB) repositories.RoutePolicyRepository {
+	return &routePolicyRepository{
+		db: db,
+	}
+}
+
+func (r *routePolicyRepository) List(ctx context.Context) ([]models.RoutePolicy, error) {
+	var policies []models.RoutePolicy
+	query := `SELECT * FROM route_policy ORDER BY path, method`
+
+	if err := r.db.SelectContext(ctx, &policies, query); err != nil {
+		return nil, fmt.Errorf("failed to list route policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+func (r *routePolicyRepository) Save(ctx context.Context, policy models.RoutePolicy) error {
+	query := `
+        INSERT INTO route_policy (method, path, resource, action, enabled, updated_at)
+        VALUES (:method, :path, :resource, :action, :enabled, :updated_at)
+        ON CONFLICT (method, path) DO UPDATE
+        SET resource = EXCLUDED.resource,
+            action = EXCLUDED.action,
+            enabled = EXCLUDED.enabled,
+            updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, policy); err != nil {
+		return fmt.Errorf("failed to save route policy: %w", err)
+	}
+
+	return nil
+}
+
+func (r *routePolicyRepository) Delete(ctx context.Context, method, path string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM route_policy WHERE method = $1 AND path = $2`, method, path)
+	if err != nil {
+		return fmt.Errorf("failed to delete route policy: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("route policy not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type safetyRepository struct {
+	db *sqlx.DB
+}
+
+func NewSafetyRepository(db *sqlx.DB) repositories.SafetyRepository {
+	return &safetyRepository{
+		db: db,
+	}
+}
+
+func (r *safetyRepository) Create(ctx context.Context, incident *models.SafetyIncident) error {
+	query := `
+        INSERT INTO safety_incident (
+            incident_id, project_id, type, severity, occurred_at, location,
+            description, injured_name, injured_role, injury_details,
+            lost_days, reported_by, created_at
+        ) VALUES (
+            :incident_id, :project_id, :type, :severity, :occurred_at, :location,
+            :description, :injured_name, :injured_role, :injury_details,
+            :lost_days, :reported_by, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, incident); err != nil {
+		return fmt.Errorf("failed to create safety incident: %w", err)
+	}
+
+	return nil
+}
+
+func (r *safetyRepository) GetByID(ctx context.Context, incidentID uuid.UUID) (*models.SafetyIncident, error) {
+	var incident models.SafetyIncident
+	err := r.db.GetContext(ctx, &incident, `SELECT * FROM safety_incident WHERE incident_id = $1`, incidentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("safety incident not found")
+		}
+		return nil, fmt.Errorf("failed to get safety incident: %w", err)
+	}
+
+	if err := r.loadActions(ctx, &incident); err != nil {
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+func (r *safetyRepository) ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.SafetyIncident, error) {
+	query := `
+        SELECT * FROM safety_incident 
+        WHERE project_id = $1 
+        ORDER BY occurred_at DESC`
+
+	var incidents []models.SafetyIncident
+	if err := r.db.SelectContext(ctx, &incidents, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list safety incidents: %w", err)
+	}
+
+	for i := range incidents {
+		if err := r.loadActions(ctx, &incidents[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return incidents, nil
+}
+
+func (r *safetyRepository) loadActions(ctx context.Context, incident *models.SafetyIncident) error {
+	query := `
+        SELECT * FROM safety_corrective_action 
+        WHERE incident_id = $1 
+        ORDER BY created_at`
+
+	if err := r.db.SelectContext(ctx, &incident.Actions, query, incident.IncidentID); err != nil {
+		return fmt.Errorf("failed to get corrective actions: %w", err)
+	}
+	return nil
+}
+
+func (r *safetyRepository) Update(ctx context.Context, incident *models.SafetyIncident) error {
+	query := `
+        UPDATE safety_incident SET 
+            type = :type,
+            severity = :severity,
+            occurred_at = :occurred_at,
+            location = :location,
+            description = :description,
+            injured_name = :injured_name,
+            injured_role = :injured_role,
+            injury_details = :injury_details,
+            lost_days = :lost_days,
+            reported_by = :reported_by,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE incident_id = :incident_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, incident)
+	if err != nil {
+		return fmt.Errorf("failed to update safety incident: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("safety incident not found")
+	}
+
+	return nil
+}
+
+func (r *safetyRepository) Delete(ctx context.Context, incidentID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM safety_corrective_action WHERE incident_id = $1`, incidentID); err != nil {
+		return fmt.Errorf("failed to delete corrective actions: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM safety_incident WHERE incident_id = $1`, incidentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete safety incident: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("safety incident not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *safetyRepository) AddAction(ctx context.Context, action *models.CorrectiveAction) error {
+	query := `
+        INSERT INTO safety_corrective_action (
+            action_id, incident_id, description, owner, due_date, created_at
+        ) VALUES (
+            :action_id, :incident_id, :description, :owner, :due_date, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, action); err != nil {
+		return fmt.Errorf("failed to create corrective action: %w", err)
+	}
+
+	return nil
+}
+
+func (r *safetyRepository) CompleteAction(ctx context.Context, incidentID uuid.UUID, actionID uuid.UUID) error {
+	query := `
+        UPDATE safety_corrective_action 
+        SET completed_at = CURRENT_TIMESTAMP 
+        WHERE incident_id = $1 AND action_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, incidentID, actionID)
+	if err != nil {
+		return fmt.Errorf("failed to complete corrective action: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("corrective action not found")
+	}
+
+	return nil
+}
+
+func (r *safetyRepository) GetMonthlyStats(ctx context.Context, from, to time.Time, projectID *uuid.UUID, hoursPerDay float64) ([]models.SafetyMonthlyStat, error) {
+	query := `
+        WITH months AS (
+            SELECT generate_series($1::date, ($2::date - interval '1 day'), interval '1 month')::date AS month
+        ),
+        incidents AS (
+            SELECT 
+                date_trunc('month', occurred_at)::date AS month,
+                COUNT(*) FILTER (WHERE type = 'incident') AS incidents,
+                COUNT(*) FILTER (WHERE type = 'near_miss') AS near_misses,
+                COUNT(*) FILTER (WHERE type = 'incident' AND severity = 'minor') AS minor,
+                COUNT(*) FILTER (WHERE type = 'incident' AND severity = 'moderate') AS moderate,
+                COUNT(*) FILTER (WHERE type = 'incident' AND severity = 'serious') AS serious,
+                COUNT(*) FILTER (WHERE type = 'incident' AND severity = 'fatal') AS fatal,
+                COALESCE(SUM(lost_days), 0) AS lost_days
+            FROM safety_incident
+            WHERE occurred_at >= $1 AND occurred_at < $2
+                AND ($3::uuid IS NULL OR project_id = $3)
+            GROUP BY 1
+        ),
+        man_hours AS (
+            SELECT 
+                date_trunc('month', d)::date AS month,
+                SUM(t.workers) * $4::numeric AS man_hours
+            FROM schedule_task t
+            CROSS JOIN LATERAL generate_series(
+                GREATEST(t.start_date, $1::date),
+                LEAST(t.end_date, $2::date - 1),
+                interval '1 day'
+            ) d
+            WHERE t.start_date < $2::date AND t.end_date >= $1::date
+                AND ($3::uuid IS NULL OR t.project_id = $3)
+            GROUP BY 1
+        )
+        SELECT 
+            m.month,
+            COALESCE(i.incidents, 0) AS incidents,
+            COALESCE(i.near_misses, 0) AS near_misses,
+            COALESCE(i.minor, 0) AS minor,
+            COALESCE(i.moderate, 0) AS moderate,
+            COALESCE(i.serious, 0) AS serious,
+            COALESCE(i.fatal, 0) AS fatal,
+            COALESCE(i.lost_days, 0) AS lost_days,
+            COALESCE(mh.man_hours, 0) AS man_hours
+        FROM months m
+        LEFT JOIN incidents i ON i.month = m.month
+        LEFT JOIN man_hours mh ON mh.month = m.month
+        ORDER BY m.month`
+
+	var stats []models.SafetyMonthlyStat
+	if err := r.db.SelectContext(ctx, &stats, query, from, to, projectID, hoursPerDay); err != nil {
+		return nil, fmt.Errorf("failed to get safety statistics: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type scheduleTaskRepository struct {
+	db *sqlx.DB
+}
+
+func NewScheduleTaskRepository(db *sqlx.DB) repositories.ScheduleTaskRepository {
+	return &scheduleTaskRepository{
+		db: db,
+	}
+}
+
+func (r *scheduleTaskRepository) Create(ctx context.Context, task *models.ScheduleTask) error {
+	query := `
+        INSERT INTO schedule_task (
+            task_id, project_id, phase_id, crew_id, name,
+            start_date, end_date, workers, outdoor, created_at
+        ) VALUES (
+            :task_id, :project_id, :phase_id, :crew_id, :name,
+            :start_date, :end_date, :workers, :outdoor, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, task); err != nil {
+		return fmt.Errorf("failed to create schedule task: %w", err)
+	}
+
+	return nil
+}
+
+func (r *scheduleTaskRepository) GetByID(ctx context.Context, taskID uuid.UUID) (*models.ScheduleTask, error) {
+	var task models.ScheduleTask
+	query := `SELECT * FROM schedule_task WHERE task_id = $1`
+
+	err := r.db.GetContext(ctx, &task, query, taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("schedule task not found")
+		}
+		return nil, fmt.Errorf("failed to get schedule task: %w", err)
+	}
+
+	return &task, nil
+}
+
+func (r *scheduleTaskRepository) ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.ScheduleTask, error) {
+	var tasks []models.ScheduleTask
+	query := `
+        SELECT * FROM schedule_task 
+        WHERE project_id = $1 
+        ORDER BY start_date, name`
+
+	if err := r.db.SelectContext(ctx, &tasks, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list schedule tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *scheduleTaskRepository) Update(ctx context.Context, task *models.ScheduleTask) error {
+	query := `
+        UPDATE schedule_task SET 
+            phase_id = :phase_id,
+            crew_id = :crew_id,
+            name = :name,
+            start_date = :start_date,
+            end_date = :end_date,
+            workers = :workers,
+            outdoor = :outdoor,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE task_id = :task_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, task)
+	if err != nil {
+		return fmt.Errorf("failed to update schedule task: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("schedule task not found")
+	}
+
+	return nil
+}
+
+func (r *scheduleTaskRepository) Delete(ctx context.Context, taskID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM schedule_task WHERE task_id = $1`, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule task: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("schedule task not found")
+	}
+
+	return nil
+}
+
+func (r *scheduleTaskRepository) AssignCrew(ctx context.Context, taskID uuid.UUID, crewID *uuid.UUID) error {
+	query := `
+        UPDATE schedule_task SET 
+            crew_id = $1,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE task_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, crewID, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to assign crew: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("schedule task not found")
+	}
+
+	return nil
+}
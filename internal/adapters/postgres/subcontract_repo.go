@@ -0,0 +1,458 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type subcontractRepository struct {
+	db *sqlx.DB
+}
+
+func NewSubcontractRepository(db *sqlx.DB) repositories.SubcontractRepository {
+	return &subcontractRepository{
+		db: db,
+	}
+}
+
+func (r *subcontractRepository) Create(ctx context.Context, subcontract models.Subcontract) error {
+	query := `
+        INSERT INTO subcontract (
+            subcontract_id, project_id, supplier_id, scope, contract_value, 
+            retention_percent, advance_amount, advance_recovery_percent, 
+            dlp_months, created_at
+        ) VALUES (
+            :subcontract_id, :project_id, :supplier_id, :scope, :contract_value, 
+            :retention_percent, :advance_amount, :advance_recovery_percent, 
+            :dlp_months, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, subcontract); err != nil {
+		return fmt.Errorf("failed to create subcontract: %w", err)
+	}
+	return nil
+}
+
+func (r *subcontractRepository) GetByID(ctx context.Context, subcontractID uuid.UUID) (*models.Subcontract, error) {
+	var subcontract models.Subcontract
+	query := `SELECT * FROM subcontract WHERE subcontract_id = $1`
+
+	if err := r.db.GetContext(ctx, &subcontract, query, subcontractID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("subcontract not found")
+		}
+		return nil, fmt.Errorf("failed to get subcontract: %w", err)
+	}
+	return &subcontract, nil
+}
+
+func (r *subcontractRepository) ListByProject(ctx context.Context, projectID uuid.UUID) ([]models.Subcontract, error) {
+	var subcontracts []models.Subcontract
+	query := `SELECT * FROM subcontract WHERE project_id = $1 ORDER BY created_at`
+
+	if err := r.db.SelectContext(ctx, &subcontracts, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list subcontracts: %w", err)
+	}
+	return subcontracts, nil
+}
+
+func (r *subcontractRepository) Complete(ctx context.Context, subcontractID uuid.UUID, completedAt time.Time) error {
+	query := `
+        UPDATE subcontract SET completed_at = $2 
+        WHERE subcontract_id = $1 AND completed_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, subcontractID, completedAt)
+	if err != nil {
+		return fmt.Errorf("failed to complete subcontract: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("subcontract already completed")
+	}
+	return nil
+}
+
+func (r *subcontractRepository) CreateCertificate(ctx context.Context, certificate *models.SubcontractCertificate, offsets []models.BackChargeOffset) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertSubcontractCertificate(ctx, tx, certificate, offsets); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *subcontractRepository) ListCertificates(ctx context.Context, subcontractID uuid.UUID) ([]models.SubcontractCertificate, error) {
+	var certificates []models.SubcontractCertificate
+	query := `SELECT * FROM subcontract_certificate WHERE subcontract_id = $1 ORDER BY certificate_no`
+
+	if err := r.db.SelectContext(ctx, &certificates, query, subcontractID); err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	return certificates, nil
+}
+
+func (r *subcontractRepository) GetRetentionBalance(ctx context.Context, subcontractID uuid.UUID) (*models.RetentionBalance, error) {
+	var balance models.RetentionBalance
+	query := `
+        SELECT 
+            (SELECT COALESCE(SUM(retention_amount), 0) FROM subcontract_certificate 
+                WHERE subcontract_id = $1) AS retained,
+            (SELECT COALESCE(SUM(amount), 0) FROM retention_release 
+                WHERE subcontract_id = $1 AND status = 'approved') AS released,
+            (SELECT COALESCE(SUM(amount), 0) FROM retention_release 
+                WHERE subcontract_id = $1 AND status = 'pending') AS pending`
+
+	if err := r.db.GetContext(ctx, &balance, query, subcontractID); err != nil {
+		return nil, fmt.Errorf("failed to get retention balance: %w", err)
+	}
+	return &balance, nil
+}
+
+func (r *subcontractRepository) CreateRelease(ctx context.Context, release models.RetentionRelease) error {
+	query := `
+        INSERT INTO retention_release (
+            release_id, subcontract_id, amount, status, note, requested_by, requested_at
+        ) VALUES (
+            :release_id, :subcontract_id, :amount, :status, :note, :requested_by, :requested_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, release); err != nil {
+		return fmt.Errorf("failed to create retention release: %w", err)
+	}
+	return nil
+}
+
+func (r *subcontractRepository) GetRelease(ctx context.Context, releaseID uuid.UUID) (*models.RetentionRelease, error) {
+	var release models.RetentionRelease
+	query := `SELECT * FROM retention_release WHERE release_id = $1`
+
+	if err := r.db.GetContext(ctx, &release, query, releaseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("retention release not found")
+		}
+		return nil, fmt.Errorf("failed to get retention release: %w", err)
+	}
+	return &release, nil
+}
+
+func (r *subcontractRepository) ListReleases(ctx context.Context, subcontractID uuid.UUID) ([]models.RetentionRelease, error) {
+	var releases []models.RetentionRelease
+	query := `SELECT * FROM retention_release WHERE subcontract_id = $1 ORDER BY requested_at`
+
+	if err := r.db.SelectContext(ctx, &releases, query, subcontractID); err != nil {
+		return nil, fmt.Errorf("failed to list retention releases: %w", err)
+	}
+	return releases, nil
+}
+
+func (r *subcontractRepository) DecideRelease(ctx context.Context, releaseID uuid.UUID, status models.RetentionReleaseStatus, decidedBy uuid.UUID) error {
+	query := `
+        UPDATE retention_release SET status = $2, decided_by = $3, decided_at = CURRENT_TIMESTAMP 
+        WHERE release_id = $1 AND status = 'pending'`
+
+	result, err := r.db.ExecContext(ctx, query, releaseID, status, decidedBy)
+	if err != nil {
+		return fmt.Errorf("failed to update retention release: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("retention release already decided")
+	}
+	return nil
+}
+
+// insertSubcontractCertificate locks the subcontract so concurrent
+// certificates get distinct numbers. A back-charge that was disputed or
+// deducted elsewhere since the offsets were worked out fails the insert.
+func insertSubcontractCertificate(ctx context.Context, tx *sqlx.Tx, certificate *models.SubcontractCertificate, offsets []models.BackChargeOffset) error {
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM subcontract WHERE subcontract_id = $1 FOR UPDATE`, certificate.SubcontractID); err != nil {
+		return fmt.Errorf("failed to lock subcontract: %w", err)
+	}
+	if err := tx.GetContext(ctx, &certificate.CertificateNo, `
+        SELECT COALESCE(MAX(certificate_no), 0) + 1 FROM subcontract_certificate 
+        WHERE subcontract_id = $1`, certificate.SubcontractID); err != nil {
+		return fmt.Errorf("failed to number certificate: %w", err)
+	}
+
+	query := `
+        INSERT INTO subcontract_certificate (
+            certificate_id, subcontract_id, claim_id, certificate_no, gross_amount, 
+            retention_amount, advance_recovery, back_charges, net_amount, note, 
+            certified_by, certified_at
+        ) VALUES (
+            :certificate_id, :subcontract_id, :claim_id, :certificate_no, :gross_amount, 
+            :retention_amount, :advance_recovery, :back_charges, :net_amount, :note, 
+            :certified_by, :certified_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, certificate); err != nil {
+		return fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	for _, offset := range offsets {
+		result, err := tx.ExecContext(ctx, `
+            UPDATE back_charge SET offset_amount = offset_amount + $2, updated_at = CURRENT_TIMESTAMP 
+            WHERE back_charge_id = $1 AND status = 'open' AND offset_amount + $2 <= amount`,
+			offset.BackChargeID, offset.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to deduct back-charge: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rows == 0 {
+			return errors.New("back-charges changed, please retry")
+		}
+
+		if _, err := tx.NamedExecContext(ctx, `
+            INSERT INTO back_charge_offset (
+                back_charge_id, certificate_id, amount, created_at
+            ) VALUES (
+                :back_charge_id, :certificate_id, :amount, :created_at
+            )`, offset); err != nil {
+			return fmt.Errorf("failed to record back-charge offset: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *subcontractRepository) ListItems(ctx context.Context, subcontractID uuid.UUID) ([]models.SubcontractItem, error) {
+	var items []models.SubcontractItem
+	query := `SELECT * FROM subcontract_item WHERE subcontract_id = $1 ORDER BY sort_order`
+
+	if err := r.db.SelectContext(ctx, &items, query, subcontractID); err != nil {
+		return nil, fmt.Errorf("failed to list subcontract items: %w", err)
+	}
+	return items, nil
+}
+
+func (r *subcontractRepository) ReplaceItems(ctx context.Context, subcontractID uuid.UUID, items []models.SubcontractItem) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var claims int
+	if err := tx.GetContext(ctx, &claims, `
+        SELECT COUNT(*) FROM subcontract_claim 
+        WHERE subcontract_id = $1 AND status <> 'rejected'`, subcontractID); err != nil {
+		return fmt.Errorf("failed to check claims: %w", err)
+	}
+	if claims > 0 {
+		return errors.New("subcontract items have claims")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM subcontract_item WHERE subcontract_id = $1`, subcontractID); err != nil {
+		return fmt.Errorf("failed to delete subcontract items: %w", err)
+	}
+
+	query := `
+        INSERT INTO subcontract_item (
+            item_id, subcontract_id, description, unit, quantity, rate, sort_order
+        ) VALUES (
+            :item_id, :subcontract_id, :description, :unit, :quantity, :rate, :sort_order
+        )`
+	for _, item := range items {
+		if _, err := tx.NamedExecContext(ctx, query, item); err != nil {
+			return fmt.Errorf("failed to create subcontract item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *subcontractRepository) GetItemProgress(ctx context.Context, subcontractID uuid.UUID) ([]models.SubcontractItemProgress, error) {
+	var progress []models.SubcontractItemProgress
+	query := `
+        SELECT 
+            l.item_id,
+            COALESCE(SUM(l.certified_quantity) FILTER (WHERE c.status = 'certified'), 0) AS certified,
+            COALESCE(SUM(l.claimed_quantity) FILTER (WHERE c.status = 'submitted'), 0) AS pending
+        FROM subcontract_claim_line l
+        JOIN subcontract_claim c ON c.claim_id = l.claim_id
+        WHERE c.subcontract_id = $1
+        GROUP BY l.item_id`
+
+	if err := r.db.SelectContext(ctx, &progress, query, subcontractID); err != nil {
+		return nil, fmt.Errorf("failed to get item progress: %w", err)
+	}
+	return progress, nil
+}
+
+func (r *subcontractRepository) CreateClaim(ctx context.Context, claim *models.SubcontractClaim, lines []models.SubcontractClaimLine) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM subcontract WHERE subcontract_id = $1 FOR UPDATE`, claim.SubcontractID); err != nil {
+		return fmt.Errorf("failed to lock subcontract: %w", err)
+	}
+	if err := tx.GetContext(ctx, &claim.ClaimNo, `
+        SELECT COALESCE(MAX(claim_no), 0) + 1 FROM subcontract_claim 
+        WHERE subcontract_id = $1`, claim.SubcontractID); err != nil {
+		return fmt.Errorf("failed to number claim: %w", err)
+	}
+
+	query := `
+        INSERT INTO subcontract_claim (
+            claim_id, subcontract_id, claim_no, status, claimant_name, note, 
+            submitted_by, submitted_at
+        ) VALUES (
+            :claim_id, :subcontract_id, :claim_no, :status, :claimant_name, :note, 
+            :submitted_by, :submitted_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, claim); err != nil {
+		return fmt.Errorf("failed to create claim: %w", err)
+	}
+
+	lineQuery := `
+        INSERT INTO subcontract_claim_line (
+            claim_id, item_id, claimed_quantity
+        ) VALUES (
+            :claim_id, :item_id, :claimed_quantity
+        )`
+	for _, line := range lines {
+		if _, err := tx.NamedExecContext(ctx, lineQuery, line); err != nil {
+			return fmt.Errorf("failed to create claim line: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *subcontractRepository) GetClaim(ctx context.Context, claimID uuid.UUID) (*models.SubcontractClaim, error) {
+	var claim models.SubcontractClaim
+	query := `SELECT * FROM subcontract_claim WHERE claim_id = $1`
+
+	if err := r.db.GetContext(ctx, &claim, query, claimID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("claim not found")
+		}
+		return nil, fmt.Errorf("failed to get claim: %w", err)
+	}
+	return &claim, nil
+}
+
+func (r *subcontractRepository) ListClaims(ctx context.Context, subcontractID uuid.UUID) ([]models.SubcontractClaim, error) {
+	var claims []models.SubcontractClaim
+	query := `SELECT * FROM subcontract_claim WHERE subcontract_id = $1 ORDER BY claim_no`
+
+	if err := r.db.SelectContext(ctx, &claims, query, subcontractID); err != nil {
+		return nil, fmt.Errorf("failed to list claims: %w", err)
+	}
+	return claims, nil
+}
+
+func (r *subcontractRepository) ListClaimLines(ctx context.Context, claimID uuid.UUID) ([]models.SubcontractClaimLine, error) {
+	var lines []models.SubcontractClaimLine
+	query := `
+        SELECT l.* FROM subcontract_claim_line l
+        JOIN subcontract_item i ON i.item_id = l.item_id
+        WHERE l.claim_id = $1 
+        ORDER BY i.sort_order`
+
+	if err := r.db.SelectContext(ctx, &lines, query, claimID); err != nil {
+		return nil, fmt.Errorf("failed to list claim lines: %w", err)
+	}
+	return lines, nil
+}
+
+func (r *subcontractRepository) CertifyClaim(ctx context.Context, claim models.SubcontractClaim, lines []models.SubcontractClaimLine, certificate *models.SubcontractCertificate, offsets []models.BackChargeOffset) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertSubcontractCertificate(ctx, tx, certificate, offsets); err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, `
+            UPDATE subcontract_claim_line SET certified_quantity = $3 
+            WHERE claim_id = $1 AND item_id = $2`,
+			line.ClaimID, line.ItemID, line.CertifiedQuantity); err != nil {
+			return fmt.Errorf("failed to certify claim line: %w", err)
+		}
+	}
+
+	result, err := tx.NamedExecContext(ctx, `
+        UPDATE subcontract_claim SET 
+            status = :status,
+            decided_by = :decided_by,
+            decided_at = :decided_at,
+            decision_note = :decision_note,
+            certificate_id = :certificate_id
+        WHERE claim_id = :claim_id AND status = 'submitted'`, claim)
+	if err != nil {
+		return fmt.Errorf("failed to certify claim: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("claim already decided")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *subcontractRepository) RejectClaim(ctx context.Context, claim models.SubcontractClaim) error {
+	result, err := r.db.NamedExecContext(ctx, `
+        UPDATE subcontract_claim SET 
+            status = :status,
+            decided_by = :decided_by,
+            decided_at = :decided_at,
+            decision_note = :decision_note
+        WHERE claim_id = :claim_id AND status = 'submitted'`, claim)
+	if err != nil {
+		return fmt.Errorf("failed to reject claim: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("claim already decided")
+	}
+	return nil
+}
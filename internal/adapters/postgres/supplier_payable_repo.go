@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type supplierPayableRepository struct {
+	db *sqlx.DB
+}
+
+func NewSupplierPayableRepository(db *sqlx.DB) repositories.SupplierPayableRepository {
+	return &supplierPayableRepository{
+		db: db,
+	}
+}
+
+func (r *supplierPayableRepository) GetCreditTerms(ctx context.Context, supplierID uuid.UUID) (*models.SupplierCreditTerms, error) {
+	var terms models.SupplierCreditTerms
+	query := `SELECT * FROM supplier_credit_terms WHERE supplier_id = $1`
+
+	if err := r.db.GetContext(ctx, &terms, query, supplierID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("credit terms not found")
+		}
+		return nil, fmt.Errorf("failed to get credit terms: %w", err)
+	}
+	return &terms, nil
+}
+
+func (r *supplierPayableRepository) SetCreditTerms(ctx context.Context, terms models.SupplierCreditTerms) error {
+	query := `
+        INSERT INTO supplier_credit_terms (
+            supplier_id, credit_days, basis, end_of_month, updated_at
+        ) VALUES (
+            :supplier_id, :credit_days, :basis, :end_of_month, :updated_at
+        )
+        ON CONFLICT (supplier_id) DO UPDATE SET
+            credit_days = EXCLUDED.credit_days,
+            basis = EXCLUDED.basis,
+            end_of_month = EXCLUDED.end_of_month,
+            updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, terms); err != nil {
+		return fmt.Errorf("failed to set credit terms: %w", err)
+	}
+	return nil
+}
+
+func (r *supplierPayableRepository) Create(ctx context.Context, payable models.SupplierPayable) error {
+	query := `
+        INSERT INTO supplier_payable (
+            payable_id, supplier_id, project_id, invoice_number, invoice_date,
+            received_date, amount, due_date, created_by, created_at
+        ) VALUES (
+            :payable_id, :supplier_id, :project_id, :invoice_number, :invoice_date,
+            :received_date, :amount, :due_date, :created_by, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, payable); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("invoice number already recorded for this supplier")
+		}
+		return fmt.Errorf("failed to create supplier payable: %w", err)
+	}
+	return nil
+}
+
+func (r *supplierPayableRepository) GetByID(ctx context.Context, payableID uuid.UUID) (*models.SupplierPayable, error) {
+	var payable models.SupplierPayable
+	query := `SELECT * FROM supplier_payable WHERE payable_id = $1`
+
+	if err := r.db.GetContext(ctx, &payable, query, payableID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("supplier payable not found")
+		}
+		return nil, fmt.Errorf("failed to get supplier payable: %w", err)
+	}
+	return &payable, nil
+}
+
+func (r *supplierPayableRepository) ListBySupplierID(ctx context.Context, supplierID uuid.UUID) ([]models.SupplierPayable, error) {
+	var payables []models.SupplierPayable
+	query := `
+        SELECT * FROM supplier_payable
+        WHERE supplier_id = $1
+        ORDER BY invoice_date DESC, created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &payables, query, supplierID); err != nil {
+		return nil, fmt.Errorf("failed to list supplier payables: %w", err)
+	}
+	return payables, nil
+}
+
+func (r *supplierPayableRepository) ListUnpaidDueBy(ctx context.Context, date time.Time) ([]models.SupplierPayableDetail, error) {
+	var payables []models.SupplierPayableDetail
+	query := `
+        SELECT sp.*, s.name AS supplier_name
+        FROM supplier_payable sp
+        JOIN supplier s ON s.supplier_id = sp.supplier_id
+        WHERE sp.paid_at IS NULL AND sp.due_date <= $1
+        ORDER BY sp.due_date, s.name`
+
+	if err := r.db.SelectContext(ctx, &payables, query, date); err != nil {
+		return nil, fmt.Errorf("failed to list unpaid supplier payables: %w", err)
+	}
+	return payables, nil
+}
+
+func (r *supplierPayableRepository) MarkPaid(ctx context.Context, payableID uuid.UUID, paidAt time.Time) error {
+	query := `UPDATE supplier_payable SET paid_at = $2 WHERE payable_id = $1 AND paid_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, payableID, paidAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark supplier payable paid: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("supplier payable already paid")
+	}
+	return nil
+}
@@ -31,13 +31,14 @@ func (r *supplierRepository) Create(ctx context.Context, req requests.CreateSupp
 		Email:      req.Email,
 		Tel:        req.Tel,
 		Address:    req.Address,
+		Status:     models.SupplierStatusPendingReview,
 	}
 
 	query := `
 	INSERT INTO Supplier (
-	supplier_id, name, email,tel, address
+	supplier_id, name, email,tel, address, status
 	) VALUES (
-	 :supplier_id, :name , :email, :tel, :address
+	 :supplier_id, :name , :email, :tel, :address, :status
 	 ) RETURNING *
 	`
 
@@ -190,24 +191,52 @@ func (r *supplierRepository) GetByEmail(ctx context.Context, email string) (*mod
 	return supplier, nil
 }
 
-func (r *supplierRepository) List(ctx context.Context, limit, offset int) ([]models.Supplier, int64, error) {
+func (r *supplierRepository) List(ctx context.Context, limit, offset int, status string) ([]models.Supplier, int64, error) {
 	var suppliers []models.Supplier
 	var total int64
 
-	countQuery := `SELECT COUNT(*) FROM Supplier`
-	err := r.db.GetContext(ctx, &total, countQuery)
+	// An empty status matches every supplier.
+	countQuery := `SELECT COUNT(*) FROM Supplier WHERE ($1 = '' OR status = $1)`
+	err := r.db.GetContext(ctx, &total, countQuery, status)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
 	}
 
 	query := `
         SELECT * FROM Supplier 
-        LIMIT $1 OFFSET $2`
+        WHERE ($1 = '' OR status = $1)
+        ORDER BY name
+        LIMIT $2 OFFSET $3`
 
-	err = r.db.SelectContext(ctx, &suppliers, query, limit, offset)
+	err = r.db.SelectContext(ctx, &suppliers, query, status, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list suppliers: %w", err)
 	}
 
 	return suppliers, total, nil
 }
+
+func (r *supplierRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.SupplierStatus, reason string) error {
+	query := `
+        UPDATE Supplier SET 
+            status = $1,
+            status_reason = NULLIF($2, ''),
+            status_updated_at = CURRENT_TIMESTAMP
+        WHERE supplier_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, status, reason, id)
+	if err != nil {
+		return fmt.Errorf("failed to update supplier status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errors.New("supplier not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type surveyRepository struct {
+	db *sqlx.DB
+}
+
+func NewSurveyRepository(db *sqlx.DB) repositories.SurveyRepository {
+	return &surveyRepository{db: db}
+}
+
+func (r *surveyRepository) CreateTemplate(ctx context.Context, template models.SurveyTemplate) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if template.IsDefault {
+		if _, err := tx.ExecContext(ctx, `UPDATE survey_template SET is_default = false WHERE is_default`); err != nil {
+			return fmt.Errorf("failed to clear default survey template: %w", err)
+		}
+	}
+
+	query := `
+        INSERT INTO survey_template (
+            template_id, name, questions, is_default, created_at
+        ) VALUES (
+            :template_id, :name, :questions, :is_default, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, template); err != nil {
+		return fmt.Errorf("failed to create survey template: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *surveyRepository) GetTemplate(ctx context.Context, templateID uuid.UUID) (*models.SurveyTemplate, error) {
+	var template models.SurveyTemplate
+	query := `SELECT * FROM survey_template WHERE template_id = $1`
+	if err := r.db.GetContext(ctx, &template, query, templateID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("survey template not found")
+		}
+		return nil, fmt.Errorf("failed to get survey template: %w", err)
+	}
+	return &template, nil
+}
+
+func (r *surveyRepository) GetDefaultTemplate(ctx context.Context) (*models.SurveyTemplate, error) {
+	var template models.SurveyTemplate
+	query := `SELECT * FROM survey_template WHERE is_default`
+	if err := r.db.GetContext(ctx, &template, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("no default survey template")
+		}
+		return nil, fmt.Errorf("failed to get default survey template: %w", err)
+	}
+	return &template, nil
+}
+
+func (r *surveyRepository) ListTemplates(ctx context.Context) ([]models.SurveyTemplate, error) {
+	var templates []models.SurveyTemplate
+	query := `SELECT * FROM survey_template ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &templates, query); err != nil {
+		return nil, fmt.Errorf("failed to list survey templates: %w", err)
+	}
+	return templates, nil
+}
+
+func (r *surveyRepository) SetDefaultTemplate(ctx context.Context, templateID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE survey_template SET is_default = false WHERE is_default`); err != nil {
+		return fmt.Errorf("failed to clear default survey template: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE survey_template SET is_default = true WHERE template_id = $1`, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to set default survey template: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("survey template not found")
+	}
+
+	return tx.Commit()
+}
+
+func (r *surveyRepository) Create(ctx context.Context, survey models.Survey) error {
+	query := `
+        INSERT INTO survey (
+            survey_id, template_id, project_id, handover_id, manager_id, sent_at, expires_at
+        ) VALUES (
+            :survey_id, :template_id, :project_id, :handover_id, :manager_id, :sent_at, :expires_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, survey); err != nil {
+		return fmt.Errorf("failed to create survey: %w", err)
+	}
+	return nil
+}
+
+func (r *surveyRepository) GetByID(ctx context.Context, surveyID uuid.UUID) (*models.Survey, error) {
+	var survey models.Survey
+	query := `SELECT * FROM survey WHERE survey_id = $1`
+	if err := r.db.GetContext(ctx, &survey, query, surveyID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("survey not found")
+		}
+		return nil, fmt.Errorf("failed to get survey: %w", err)
+	}
+	return &survey, nil
+}
+
+func (r *surveyRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.Survey, error) {
+	var survey models.Survey
+	query := `SELECT * FROM survey WHERE project_id = $1`
+	if err := r.db.GetContext(ctx, &survey, query, projectID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("survey not found")
+		}
+		return nil, fmt.Errorf("failed to get survey: %w", err)
+	}
+	return &survey, nil
+}
+
+func (r *surveyRepository) Submit(ctx context.Context, survey models.Survey) error {
+	query := `
+        UPDATE survey SET 
+            answers = :answers,
+            nps_score = :nps_score,
+            average_rating = :average_rating,
+            responded_at = :responded_at
+        WHERE survey_id = :survey_id AND responded_at IS NULL`
+
+	result, err := r.db.NamedExecContext(ctx, query, survey)
+	if err != nil {
+		return fmt.Errorf("failed to submit survey: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("survey has already been completed")
+	}
+	return nil
+}
+
+func (r *surveyRepository) ListResponded(ctx context.Context, year int) ([]models.Survey, error) {
+	query := `
+        SELECT * FROM survey
+        WHERE responded_at IS NOT NULL
+        AND ($1 = 0 OR EXTRACT(YEAR FROM responded_at AT TIME ZONE 'Asia/Bangkok') = $1)
+        ORDER BY responded_at`
+
+	var surveys []models.Survey
+	if err := r.db.SelectContext(ctx, &surveys, query, year); err != nil {
+		return nil, fmt.Errorf("failed to list survey responses: %w", err)
+	}
+	return surveys, nil
+}
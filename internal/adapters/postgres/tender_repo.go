@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type tenderRepository struct {
+	db *sqlx.DB
+}
+
+func NewTenderRepository(db *sqlx.DB) repositories.TenderRepository {
+	return &tenderRepository{
+		db: db,
+	}
+}
+
+func (r *tenderRepository) Create(ctx context.Context, tender models.Tender) error {
+	query := `
+        INSERT INTO tender (
+            tender_id, project_id, title, agency, reference_number, 
+            submission_deadline, bond_amount, status, created_at
+        ) VALUES (
+            :tender_id, :project_id, :title, :agency, :reference_number, 
+            :submission_deadline, :bond_amount, :status, :created_at
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, tender); err != nil {
+		return fmt.Errorf("failed to create tender: %w", err)
+	}
+	return nil
+}
+
+func (r *tenderRepository) GetByID(ctx context.Context, tenderID uuid.UUID) (*models.Tender, error) {
+	var tender models.Tender
+	query := `SELECT * FROM tender WHERE tender_id = $1`
+
+	if err := r.db.GetContext(ctx, &tender, query, tenderID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("tender not found")
+		}
+		return nil, fmt.Errorf("failed to get tender: %w", err)
+	}
+	return &tender, nil
+}
+
+func (r *tenderRepository) List(ctx context.Context, status string) ([]models.Tender, error) {
+	var tenders []models.Tender
+	query := `
+        SELECT * FROM tender 
+        WHERE $1 = '' OR status = $1 
+        ORDER BY submission_deadline`
+
+	if err := r.db.SelectContext(ctx, &tenders, query, status); err != nil {
+		return nil, fmt.Errorf("failed to list tenders: %w", err)
+	}
+	return tenders, nil
+}
+
+func (r *tenderRepository) Update(ctx context.Context, tender models.Tender) error {
+	query := `
+        UPDATE tender SET 
+            project_id = :project_id, 
+            title = :title, 
+            agency = :agency, 
+            reference_number = :reference_number, 
+            submission_deadline = :submission_deadline, 
+            bond_amount = :bond_amount, 
+            status = :status, 
+            submitted_at = :submitted_at, 
+            winning_bidder = :winning_bidder, 
+            winning_amount = :winning_amount, 
+            outcome_notes = :outcome_notes, 
+            outcome_at = :outcome_at, 
+            updated_at = :updated_at
+        WHERE tender_id = :tender_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, tender)
+	if err != nil {
+		return fmt.Errorf("failed to update tender: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("tender not found")
+	}
+	return nil
+}
+
+func (r *tenderRepository) Delete(ctx context.Context, tenderID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tender_document WHERE tender_id = $1`, tenderID); err != nil {
+		return fmt.Errorf("failed to delete tender documents: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tender_bid_line WHERE tender_id = $1`, tenderID); err != nil {
+		return fmt.Errorf("failed to delete tender bid lines: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM tender WHERE tender_id = $1`, tenderID)
+	if err != nil {
+		return fmt.Errorf("failed to delete tender: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("tender not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *tenderRepository) ListDocuments(ctx context.Context, tenderID uuid.UUID) ([]models.TenderDocument, error) {
+	var documents []models.TenderDocument
+	query := `SELECT * FROM tender_document WHERE tender_id = $1 ORDER BY sort_order, name`
+
+	if err := r.db.SelectContext(ctx, &documents, query, tenderID); err != nil {
+		return nil, fmt.Errorf("failed to get tender documents: %w", err)
+	}
+	return documents, nil
+}
+
+func (r *tenderRepository) GetDocument(ctx context.Context, documentID uuid.UUID) (*models.TenderDocument, error) {
+	var document models.TenderDocument
+	query := `SELECT * FROM tender_document WHERE document_id = $1`
+
+	if err := r.db.GetContext(ctx, &document, query, documentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("tender document not found")
+		}
+		return nil, fmt.Errorf("failed to get tender document: %w", err)
+	}
+	return &document, nil
+}
+
+func (r *tenderRepository) CreateDocument(ctx context.Context, document models.TenderDocument) error {
+	query := `
+        INSERT INTO tender_document (
+            document_id, tender_id, name, required, completed, file_url, sort_order
+        ) VALUES (
+            :document_id, :tender_id, :name, :required, :completed, :file_url, :sort_order
+        )`
+
+	if _, err := r.db.NamedExecContext(ctx, query, document); err != nil {
+		return fmt.Errorf("failed to create tender document: %w", err)
+	}
+	return nil
+}
+
+func (r *tenderRepository) UpdateDocument(ctx context.Context, document models.TenderDocument) error {
+	query := `
+        UPDATE tender_document SET 
+            name = :name, 
+            required = :required, 
+            completed = :completed, 
+            file_url = :file_url, 
+            sort_order = :sort_order
+        WHERE document_id = :document_id`
+
+	result, err := r.db.NamedExecContext(ctx, query, document)
+	if err != nil {
+		return fmt.Errorf("failed to update tender document: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("tender document not found")
+	}
+	return nil
+}
+
+func (r *tenderRepository) DeleteDocument(ctx context.Context, documentID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tender_document WHERE document_id = $1`, documentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete tender document: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("tender document not found")
+	}
+	return nil
+}
+
+func (r *tenderRepository) ListBidLines(ctx context.Context, tenderID uuid.UUID) ([]models.TenderBidLine, error) {
+	var lines []models.TenderBidLine
+	query := `SELECT * FROM tender_bid_line WHERE tender_id = $1 ORDER BY sort_order`
+
+	if err := r.db.SelectContext(ctx, &lines, query, tenderID); err != nil {
+		return nil, fmt.Errorf("failed to get tender bid lines: %w", err)
+	}
+	return lines, nil
+}
+
+func (r *tenderRepository) ReplaceBidLines(ctx context.Context, tenderID uuid.UUID, lines []models.TenderBidLine) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tender_bid_line WHERE tender_id = $1`, tenderID); err != nil {
+		return fmt.Errorf("failed to clear tender bid lines: %w", err)
+	}
+
+	query := `
+        INSERT INTO tender_bid_line (
+            line_id, tender_id, description, unit, quantity, unit_price, sort_order
+        ) VALUES (
+            :line_id, :tender_id, :description, :unit, :quantity, :unit_price, :sort_order
+        )`
+	for _, line := range lines {
+		if _, err := tx.NamedExecContext(ctx, query, line); err != nil {
+			return fmt.Errorf("failed to save tender bid line: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -37,6 +38,32 @@ func (ur *userRepository) GetByUsername(ctx context.Context, username string) (*
 	return user, nil
 }
 
+func (ur *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	user := &models.User{}
+	query := `SELECT * FROM "User" WHERE user_id = $1`
+	err := ur.db.GetContext(ctx, user, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+func (ur *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	user := &models.User{}
+	query := `SELECT * FROM "User" WHERE LOWER(email) = LOWER($1)`
+	err := ur.db.GetContext(ctx, user, query, email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
 func (ur *userRepository) CreateUser(ctx context.Context, req requests.RegisterRequest) error {
 	user := &models.User{
 		UserID:    uuid.New(),
@@ -46,15 +73,16 @@ func (ur *userRepository) CreateUser(ctx context.Context, req requests.RegisterR
 		LastName:  req.LastName,
 		Email:     sql.NullString{String: req.Email, Valid: req.Email != ""},
 		Tel:       sql.NullString{String: req.Tel, Valid: req.Tel != ""},
+		Role:      models.UserRoleViewer,
 	}
 
 	query := `
         INSERT INTO "User" (
             user_id, username, password, first_name, last_name, 
-            email, tel
+            email, tel, role
         ) VALUES (
             :user_id, :username, :password, :first_name, :last_name, 
-            :email, :tel
+            :email, :tel, :role
         )`
 
 	_, err := ur.db.NamedExecContext(ctx, query, user)
@@ -66,3 +94,600 @@ func (ur *userRepository) CreateUser(ctx context.Context, req requests.RegisterR
 	}
 	return nil
 }
+
+func (ur *userRepository) List(ctx context.Context, filter models.UserFilter, limit, offset int) ([]models.User, int64, error) {
+	var users []models.User
+	var total int64
+
+	where := `
+        WHERE ($1 = '' OR username ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%'
+                OR (first_name || ' ' || last_name) ILIKE '%' || $1 || '%')
+            AND ($2 = '' OR role = $2)
+            AND ($3::boolean IS NULL OR is_active = $3)`
+	active := sql.NullBool{}
+	if filter.Active != nil {
+		active = sql.NullBool{Bool: *filter.Active, Valid: true}
+	}
+	args := []interface{}{filter.Search, string(filter.Role), active}
+
+	if err := ur.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM "User"`+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	query := `SELECT * FROM "User"` + where + `
+        ORDER BY username
+        LIMIT $4 OFFSET $5`
+	if err := ur.db.SelectContext(ctx, &users, query, append(args, limit, offset)...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, total, nil
+}
+
+func (ur *userRepository) UpdateRole(ctx context.Context, id uuid.UUID, role models.UserRole) error {
+	query := `UPDATE "User" SET role = $2 WHERE user_id = $1`
+	result, err := ur.db.ExecContext(ctx, query, id, role)
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) UpdateProfile(ctx context.Context, id uuid.UUID, req requests.UpdateProfileRequest) error {
+	query := `UPDATE "User" SET first_name = $2, last_name = $3, tel = NULLIF($4, '') WHERE user_id = $1`
+	result, err := ur.db.ExecContext(ctx, query, id, req.FirstName, req.LastName, req.Tel)
+	if err != nil {
+		return fmt.Errorf("failed to update user profile: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) UpdateAvatarURL(ctx context.Context, id uuid.UUID, avatarURL string) error {
+	query := `UPDATE "User" SET avatar_url = $2 WHERE user_id = $1`
+	result, err := ur.db.ExecContext(ctx, query, id, avatarURL)
+	if err != nil {
+		return fmt.Errorf("failed to update user avatar: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+	query := `UPDATE "User" SET password = $2, must_change_password = FALSE WHERE user_id = $1`
+	result, err := ur.db.ExecContext(ctx, query, id, hashedPassword)
+	if err != nil {
+		return fmt.Errorf("failed to update user password: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) RequirePasswordChange(ctx context.Context, id uuid.UUID) error {
+	tx, err := ur.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE "User" SET must_change_password = TRUE WHERE user_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to require password change: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE refresh_token SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, id); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	tx, err := ur.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE "User" 
+        SET is_active = $2,
+            deactivated_at = CASE WHEN $2 THEN NULL ELSE COALESCE(deactivated_at, NOW()) END
+        WHERE user_id = $1`
+	result, err := tx.ExecContext(ctx, query, id, active)
+	if err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+
+	// Signed-out sessions stay signed out if the user is reactivated.
+	if !active {
+		if _, err := tx.ExecContext(ctx, `UPDATE refresh_token SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, id); err != nil {
+			return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) SetPendingEmail(ctx context.Context, id uuid.UUID, email string) error {
+	query := `UPDATE "User" SET pending_email = $2 WHERE user_id = $1`
+	result, err := ur.db.ExecContext(ctx, query, id, email)
+	if err != nil {
+		return fmt.Errorf("failed to set pending email: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) ConfirmPendingEmail(ctx context.Context, id uuid.UUID, email string) error {
+	query := `
+        UPDATE "User" SET email = pending_email, pending_email = NULL
+        WHERE user_id = $1 AND LOWER(pending_email) = LOWER($2)`
+	result, err := ur.db.ExecContext(ctx, query, id, email)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("email already in use")
+		}
+		return fmt.Errorf("failed to confirm pending email: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("email change not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) RecordFailedLogin(ctx context.Context, id uuid.UUID, maxAttempts int, lockUntil time.Time) (bool, error) {
+	query := `
+        UPDATE "User" SET
+            failed_login_attempts = CASE
+                WHEN failed_login_attempts + 1 >= $2 THEN 0
+                ELSE failed_login_attempts + 1
+            END,
+            locked_until = CASE
+                WHEN failed_login_attempts + 1 >= $2 THEN $3
+                ELSE locked_until
+            END
+        WHERE user_id = $1
+        RETURNING failed_login_attempts = 0`
+
+	var locked bool
+	if err := ur.db.GetContext(ctx, &locked, query, id, maxAttempts, lockUntil); err != nil {
+		if err == sql.ErrNoRows {
+			return false, errors.New("user not found")
+		}
+		return false, fmt.Errorf("failed to record failed login: %w", err)
+	}
+	return locked, nil
+}
+
+func (ur *userRepository) ResetFailedLogins(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE "User" SET failed_login_attempts = 0, locked_until = NULL WHERE user_id = $1`
+	result, err := ur.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to reset failed logins: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) GetPreferences(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	var preferences []byte
+	query := `SELECT preferences FROM user_preference WHERE user_id = $1`
+	err := ur.db.GetContext(ctx, &preferences, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return preferences, nil
+}
+
+func (ur *userRepository) SavePreferences(ctx context.Context, userID uuid.UUID, preferences []byte) error {
+	query := `
+        INSERT INTO user_preference (user_id, preferences, updated_at)
+        VALUES ($1, $2, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id) DO UPDATE SET 
+            preferences = EXCLUDED.preferences,
+            updated_at = EXCLUDED.updated_at`
+
+	if _, err := ur.db.ExecContext(ctx, query, userID, preferences); err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) RecordLogin(ctx context.Context, audit models.LoginAudit) error {
+	query := `
+        INSERT INTO login_audit (
+            audit_id, user_id, username, method, success, failure_reason,
+            ip_address, user_agent, fingerprint, attempted_at
+        ) VALUES (
+            :audit_id, :user_id, :username, :method, :success, :failure_reason,
+            :ip_address, :user_agent, :fingerprint, :attempted_at
+        )`
+
+	if _, err := ur.db.NamedExecContext(ctx, query, audit); err != nil {
+		return fmt.Errorf("failed to record login: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) ListLogins(ctx context.Context, userID uuid.UUID, limit int) ([]models.LoginAudit, error) {
+	var logins []models.LoginAudit
+	query := `SELECT * FROM login_audit WHERE user_id = $1 ORDER BY attempted_at DESC LIMIT $2`
+	if err := ur.db.SelectContext(ctx, &logins, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list logins: %w", err)
+	}
+	return logins, nil
+}
+
+func (ur *userRepository) CreateImpersonationSession(ctx context.Context, session models.ImpersonationSession) error {
+	query := `
+        INSERT INTO impersonation_session (
+            session_id, impersonator_id, user_id, reason, started_at, expires_at
+        ) VALUES (
+            :session_id, :impersonator_id, :user_id, :reason, :started_at, :expires_at
+        )`
+
+	if _, err := ur.db.NamedExecContext(ctx, query, session); err != nil {
+		return fmt.Errorf("failed to create impersonation session: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) ListImpersonationSessions(ctx context.Context) ([]models.ImpersonationSession, error) {
+	var sessions []models.ImpersonationSession
+	query := `SELECT * FROM impersonation_session ORDER BY started_at DESC`
+	if err := ur.db.SelectContext(ctx, &sessions, query); err != nil {
+		return nil, fmt.Errorf("failed to list impersonation sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+const insertRefreshTokenQuery = `
+        INSERT INTO refresh_token (
+            token_id, user_id, family_id, token_hash, expires_at, created_at
+        ) VALUES (
+            :token_id, :user_id, :family_id, :token_hash, :expires_at, :created_at
+        )`
+
+func (ur *userRepository) CreateRefreshToken(ctx context.Context, token models.RefreshToken) error {
+	if _, err := ur.db.NamedExecContext(ctx, insertRefreshTokenQuery, token); err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	query := `SELECT * FROM refresh_token WHERE token_hash = $1`
+	if err := ur.db.GetContext(ctx, &token, query, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("refresh token not found")
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+func (ur *userRepository) RotateRefreshToken(ctx context.Context, oldID uuid.UUID, next models.RefreshToken) error {
+	tx, err := ur.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NamedExecContext(ctx, insertRefreshTokenQuery, next); err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE refresh_token SET revoked_at = $1, replaced_by = $2
+        WHERE token_id = $3 AND revoked_at IS NULL`,
+		next.CreatedAt, next.TokenID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("refresh token already used")
+	}
+
+	return tx.Commit()
+}
+
+func (ur *userRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `UPDATE refresh_token SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`
+	if _, err := ur.db.ExecContext(ctx, query, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) CreateSession(ctx context.Context, session models.UserSession) error {
+	query := `
+        INSERT INTO user_session (
+            session_id, user_id, method, fingerprint, user_agent, ip_address,
+            created_at, last_seen_at
+        ) VALUES (
+            :session_id, :user_id, :method, :fingerprint, :user_agent, :ip_address,
+            :created_at, :last_seen_at
+        )`
+
+	if _, err := ur.db.NamedExecContext(ctx, query, session); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) TouchSession(ctx context.Context, sessionID uuid.UUID, ipAddress string, seenAt time.Time) error {
+	query := `UPDATE user_session SET ip_address = $1, last_seen_at = $2 WHERE session_id = $3`
+	if _, err := ur.db.ExecContext(ctx, query, ipAddress, seenAt, sessionID); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) ListActiveSessions(ctx context.Context, userID uuid.UUID, now time.Time) ([]models.UserSession, error) {
+	sessions := []models.UserSession{}
+	query := `
+        SELECT s.*, t.expires_at
+        FROM user_session s
+        JOIN refresh_token t ON t.family_id = s.session_id
+        WHERE s.user_id = $1 AND t.revoked_at IS NULL AND t.expires_at > $2
+        ORDER BY s.last_seen_at DESC`
+	if err := ur.db.SelectContext(ctx, &sessions, query, userID, now); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (ur *userRepository) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	query := `
+        UPDATE refresh_token SET revoked_at = NOW()
+        WHERE family_id = $1 AND user_id = $2 AND revoked_at IS NULL`
+	result, err := ur.db.ExecContext(ctx, query, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("session not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) GetByIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	user := &models.User{}
+	query := `
+        SELECT u.* FROM "User" u
+        JOIN user_identity i ON i.user_id = u.user_id
+        WHERE i.provider = $1 AND i.subject = $2`
+	err := ur.db.GetContext(ctx, user, query, provider, subject)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+func (ur *userRepository) LinkIdentity(ctx context.Context, identity models.UserIdentity) error {
+	query := `
+        INSERT INTO user_identity (provider, subject, user_id, email, created_at)
+        VALUES (:provider, :subject, :user_id, :email, :created_at)`
+
+	if _, err := ur.db.NamedExecContext(ctx, query, identity); err != nil {
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) CreateUserWithIdentity(ctx context.Context, user *models.User, identity models.UserIdentity) error {
+	tx, err := ur.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userQuery := `
+        INSERT INTO "User" (
+            user_id, username, password, first_name, last_name, email, role
+        ) VALUES (
+            :user_id, :username, :password, :first_name, :last_name, :email, :role
+        )`
+	if _, err := tx.NamedExecContext(ctx, userQuery, user); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return errors.New("username already exists")
+		}
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	identityQuery := `
+        INSERT INTO user_identity (provider, subject, user_id, email, created_at)
+        VALUES (:provider, :subject, :user_id, :email, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, identityQuery, identity); err != nil {
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) RevokeAccessToken(ctx context.Context, token models.RevokedAccessToken) error {
+	query := `
+        INSERT INTO revoked_access_token (token_id, user_id, expires_at, revoked_at)
+        VALUES (:token_id, :user_id, :expires_at, :revoked_at)
+        ON CONFLICT (token_id) DO NOTHING`
+
+	if _, err := ur.db.NamedExecContext(ctx, query, token); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) IsAccessTokenRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	var revoked bool
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_access_token WHERE token_id = $1)`
+	if err := ur.db.GetContext(ctx, &revoked, query, tokenID); err != nil {
+		return false, fmt.Errorf("failed to check access token: %w", err)
+	}
+	return revoked, nil
+}
+
+func (ur *userRepository) IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	var revoked bool
+	query := `
+        SELECT NOT EXISTS (
+            SELECT 1 FROM refresh_token WHERE family_id = $1 AND revoked_at IS NULL
+        )`
+	if err := ur.db.GetContext(ctx, &revoked, query, sessionID); err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+	return revoked, nil
+}
+
+func (ur *userRepository) DeleteExpiredRevokedAccessTokens(ctx context.Context, before time.Time) (int64, error) {
+	result, err := ur.db.ExecContext(ctx, `DELETE FROM revoked_access_token WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete revoked access tokens: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows, nil
+}
+
+func (ur *userRepository) CreateAPIKey(ctx context.Context, key models.APIKey) error {
+	query := `
+        INSERT INTO api_keys (
+            key_id, user_id, name, prefix, key_hash, expires_at, created_at
+        ) VALUES (
+            :key_id, :user_id, :name, :prefix, :key_hash, :expires_at, :created_at
+        )`
+
+	if _, err := ur.db.NamedExecContext(ctx, query, key); err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+func (ur *userRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	query := `SELECT * FROM api_keys WHERE key_hash = $1`
+	if err := ur.db.GetContext(ctx, &key, query, keyHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("api key not found")
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+func (ur *userRepository) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]models.APIKey, error) {
+	keys := []models.APIKey{}
+	query := `SELECT * FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC`
+	if err := ur.db.SelectContext(ctx, &keys, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (ur *userRepository) RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	query := `
+        UPDATE api_keys SET revoked_at = NOW()
+        WHERE key_id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := ur.db.ExecContext(ctx, query, keyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("api key not found")
+	}
+	return nil
+}
+
+func (ur *userRepository) TouchAPIKey(ctx context.Context, keyID uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2 WHERE key_id = $1`
+	if _, err := ur.db.ExecContext(ctx, query, keyID, usedAt); err != nil {
+		return fmt.Errorf("failed to update api key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type wasteRepository struct {
+	db *sqlx.DB
+}
+
+func NewWasteRepository(db *sqlx.DB) repositories.WasteRepository {
+	return &wasteRepository{
+		db: db,
+	}
+}
+
+func (r *wasteRepository) Create(ctx context.Context, record models.WasteRecord, movement models.StockMovement) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertStockMovements(ctx, tx, []models.StockMovement{movement}); err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO waste_record (
+            waste_id, project_id, material_id, warehouse_id, quantity, reason, note,
+            recorded_date, created_by, created_at
+        ) VALUES (
+            :waste_id, :project_id, :material_id, :warehouse_id, :quantity, :reason, :note,
+            :recorded_date, :created_by, :created_at
+        )`
+	if _, err := tx.NamedExecContext(ctx, query, record); err != nil {
+		return fmt.Errorf("failed to create waste record: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *wasteRepository) ListByProject(ctx context.Context, projectID uuid.UUID) ([]models.WasteRecordDetail, error) {
+	var records []models.WasteRecordDetail
+	query := `
+        SELECT wr.*, m.name AS material_name, m.unit, w.name AS warehouse_name
+        FROM waste_record wr
+        JOIN material m ON m.material_id = wr.material_id
+        JOIN warehouse w ON w.warehouse_id = wr.warehouse_id
+        WHERE wr.project_id = $1
+        ORDER BY wr.recorded_date DESC, wr.created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &records, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list waste records: %w", err)
+	}
+	return records, nil
+}
+
+func (r *wasteRepository) ListSummaries(ctx context.Context, projectID uuid.NullUUID) ([]models.WasteSummary, error) {
+	var summaries []models.WasteSummary
+	// A project's BOQ quantity of a material is its quantity per job times
+	// the job's quantity.
+	query := `
+        WITH Wasted AS (
+            SELECT project_id, material_id, SUM(quantity) AS quantity
+            FROM waste_record
+            GROUP BY project_id, material_id
+        ), Planned AS (
+            SELECT b.project_id, mpl.material_id, SUM(mpl.quantity * bj.quantity) AS quantity
+            FROM boq b
+            JOIN material_price_log mpl ON mpl.boq_id = b.boq_id
+            JOIN boq_job bj ON bj.boq_id = mpl.boq_id AND bj.job_id = mpl.job_id
+            GROUP BY b.project_id, mpl.material_id
+        )
+        SELECT 
+            p.project_id, p.name AS project_name,
+            m.material_id, m.name AS material_name, m.unit,
+            COALESCE(pl.quantity, 0) AS boq_quantity,
+            w.quantity AS wasted_quantity
+        FROM Wasted w
+        JOIN project p ON p.project_id = w.project_id
+        JOIN material m ON m.material_id = w.material_id
+        LEFT JOIN Planned pl ON pl.project_id = w.project_id AND pl.material_id = w.material_id
+        WHERE ($1::uuid IS NULL AND p.status NOT IN ('completed', 'cancelled')) OR p.project_id = $1
+        ORDER BY p.name, m.name`
+
+	if err := r.db.SelectContext(ctx, &summaries, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list waste summaries: %w", err)
+	}
+	return summaries, nil
+}
+
+func (r *wasteRepository) ListAllowances(ctx context.Context) ([]models.WastageAllowance, error) {
+	var allowances []models.WastageAllowance
+	query := `SELECT * FROM wastage_allowance ORDER BY material_id`
+
+	if err := r.db.SelectContext(ctx, &allowances, query); err != nil {
+		return nil, fmt.Errorf("failed to list wastage allowances: %w", err)
+	}
+	return allowances, nil
+}
+
+func (r *wasteRepository) SetAllowance(ctx context.Context, allowance models.WastageAllowance) error {
+	query := `
+        INSERT INTO wastage_allowance (material_id, percent, updated_at)
+        VALUES (:material_id, :percent, :updated_at)
+        ON CONFLICT (material_id) DO UPDATE 
+        SET percent = EXCLUDED.percent, updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.NamedExecContext(ctx, query, allowance); err != nil {
+		return fmt.Errorf("failed to set wastage allowance: %w", err)
+	}
+	return nil
+}
+
+func (r *wasteRepository) DeleteAllowance(ctx context.Context, materialID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM wastage_allowance WHERE material_id = $1`, materialID); err != nil {
+		return fmt.Errorf("failed to delete wastage allowance: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type weatherRepository struct {
+	db *sqlx.DB
+}
+
+func NewWeatherRepository(db *sqlx.DB) repositories.WeatherRepository {
+	return &weatherRepository{
+		db: db,
+	}
+}
+
+func (r *weatherRepository) ListForecastTargets(ctx context.Context) ([]models.WeatherForecastTarget, error) {
+	query := `
+        SELECT p.project_id, p.name, p.address
+        FROM project p
+        WHERE p.status NOT IN ('completed', 'cancelled')
+            AND EXISTS (
+                SELECT 1 FROM schedule_task t
+                WHERE t.project_id = p.project_id
+                    AND t.outdoor
+                    AND t.end_date >= CURRENT_DATE
+            )`
+
+	var targets []models.WeatherForecastTarget
+	if err := r.db.SelectContext(ctx, &targets, query); err != nil {
+		return nil, fmt.Errorf("failed to list forecast targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+// SaveForecasts replaces the stored forecast for each day returned by the
+// provider.
+func (r *weatherRepository) SaveForecasts(ctx context.Context, projectID uuid.UUID, forecasts []models.WeatherForecast) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO weather_forecast (
+            project_id, forecast_date, precipitation_mm,
+            precipitation_probability, source, fetched_at
+        ) VALUES (
+            :project_id, :forecast_date, :precipitation_mm,
+            :precipitation_probability, :source, :fetched_at
+        )
+        ON CONFLICT (project_id, forecast_date) DO UPDATE SET
+            precipitation_mm = EXCLUDED.precipitation_mm,
+            precipitation_probability = EXCLUDED.precipitation_probability,
+            source = EXCLUDED.source,
+            fetched_at = EXCLUDED.fetched_at`
+
+	for _, forecast := range forecasts {
+		forecast.ProjectID = projectID
+		if _, err := tx.NamedExecContext(ctx, query, forecast); err != nil {
+			return fmt.Errorf("failed to save forecast: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM weather_forecast WHERE project_id = $1 AND forecast_date < CURRENT_DATE`, projectID); err != nil {
+		return fmt.Errorf("failed to prune old forecasts: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *weatherRepository) GetRisks(ctx context.Context, projectID *uuid.UUID, heavyRainMM float64) ([]models.WeatherRisk, error) {
+	query := `
+        SELECT 
+            t.task_id,
+            t.name AS task_name,
+            p.project_id,
+            p.name AS project_name,
+            wf.forecast_date,
+            wf.precipitation_mm,
+            wf.precipitation_probability,
+            wf.fetched_at
+        FROM schedule_task t
+        JOIN project p ON p.project_id = t.project_id
+        JOIN weather_forecast wf ON wf.project_id = t.project_id
+            AND wf.forecast_date BETWEEN t.start_date AND t.end_date
+        WHERE t.outdoor
+            AND wf.forecast_date >= CURRENT_DATE
+            AND wf.precipitation_mm >= $1
+            AND ($2::uuid IS NULL OR t.project_id = $2)
+        ORDER BY wf.forecast_date, p.name, t.name`
+
+	var risks []models.WeatherRisk
+	if err := r.db.SelectContext(ctx, &risks, query, heavyRainMM, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get weather risks: %w", err)
+	}
+
+	return risks, nil
+}
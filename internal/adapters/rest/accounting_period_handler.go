@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccountingPeriodHandler struct {
+	periodUseCase usecase.AccountingPeriodUseCase
+	userUsecase   usecase.UserUsecase
+}
+
+func NewAccountingPeriodHandler(periodUseCase usecase.AccountingPeriodUseCase, userUsecase usecase.UserUsecase) *AccountingPeriodHandler {
+	return &AccountingPeriodHandler{
+		periodUseCase: periodUseCase,
+		userUsecase:   userUsecase,
+	}
+}
+
+func (h *AccountingPeriodHandler) AccountingPeriodRoutes(app *fiber.App) {
+	periods := app.Group("/accounting-periods")
+	periods.Get("/", h.ListLocks)
+	periods.Get("/events", RequireAuth(h.userUsecase), h.ListEvents)
+	periods.Post("/:period/lock", RequireAuth(h.userUsecase), h.Lock)
+	periods.Post("/:period/unlock", RequireAuth(h.userUsecase), h.Unlock)
+}
+
+func (h *AccountingPeriodHandler) ListLocks(c *fiber.Ctx) error {
+	locks, err := h.periodUseCase.ListLocks(c.Context())
+	if err != nil {
+		return accountingPeriodError(c, err, "Failed to retrieve locked periods")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Locked periods retrieved successfully",
+		"data":    locks,
+	})
+}
+
+func (h *AccountingPeriodHandler) ListEvents(c *fiber.Ctx) error {
+	events, err := h.periodUseCase.ListEvents(c.Context(), currentUserID(c))
+	if err != nil {
+		return accountingPeriodError(c, err, "Failed to retrieve period history")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Period history retrieved successfully",
+		"data":    events,
+	})
+}
+
+func (h *AccountingPeriodHandler) Lock(c *fiber.Ctx) error {
+	lock, err := h.periodUseCase.Lock(c.Context(), currentUserID(c), c.Params("period"))
+	if err != nil {
+		return accountingPeriodError(c, err, "Failed to lock period")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Period locked successfully",
+		"data":    lock,
+	})
+}
+
+func (h *AccountingPeriodHandler) Unlock(c *fiber.Ctx) error {
+	var req requests.UnlockAccountingPeriodRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.periodUseCase.Unlock(c.Context(), currentUserID(c), c.Params("period"), req); err != nil {
+		return accountingPeriodError(c, err, "Failed to unlock period")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Period unlocked successfully",
+	})
+}
+
+func accountingPeriodError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "accounting period is already locked", "accounting period is not locked":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid period", "cannot lock a future period", "reason is required":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
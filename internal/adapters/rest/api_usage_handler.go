@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type APIUsageHandler struct {
+	usageUseCase usecase.APIUsageUseCase
+	userUsecase  usecase.UserUsecase
+}
+
+func NewAPIUsageHandler(usageUseCase usecase.APIUsageUseCase, userUsecase usecase.UserUsecase) *APIUsageHandler {
+	return &APIUsageHandler{
+		usageUseCase: usageUseCase,
+		userUsecase:  userUsecase,
+	}
+}
+
+func (h *APIUsageHandler) APIUsageRoutes(app *fiber.App) {
+	app.Get("/admin/api-usage", RequireAuth(h.userUsecase), h.GetReport)
+}
+
+// Track counts every request by caller and route. It must be registered
+// before any routes; the caller is read after the route's own auth
+// middleware has run.
+func (h *APIUsageHandler) Track() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		route := c.Method() + " " + c.Route().Path
+		if err != nil {
+			// The error handler hasn't written the response yet.
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+			// Handlers answer with JSON rather than errors, so a 404 error
+			// means no route matched and c.Route() is this middleware.
+			if status == fiber.StatusNotFound {
+				route = "unmatched"
+			}
+		}
+
+		req := models.APIRequest{
+			At:       time.Now(),
+			Route:    route,
+			Status:   status,
+			BytesIn:  int64(len(c.Request().Body())),
+			BytesOut: int64(len(c.Response().Body())),
+		}
+		if userID, ok := c.Locals(userIDLocal).(uuid.UUID); ok {
+			req.UserID = uuid.NullUUID{UUID: userID, Valid: true}
+		}
+		if claims, ok := c.Locals(apiKeyClaimsLocal).(*models.AccessClaims); ok {
+			req.UserID = uuid.NullUUID{UUID: claims.UserID, Valid: true}
+			req.KeyID = claims.APIKeyID
+		}
+		h.usageUseCase.Record(req)
+
+		return err
+	}
+}
+
+// GetReport accepts ?from=&to= (YYYY-MM-DD) and ?group_by=day|caller|route.
+func (h *APIUsageHandler) GetReport(c *fiber.Ctx) error {
+	report, err := h.usageUseCase.GetReport(c.Context(), currentUserID(c), c.Query("from"), c.Query("to"), c.Query("group_by"))
+	if err != nil {
+		switch err.Error() {
+		case "only owners can access this resource":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "invalid from date", "invalid to date", "to date must not be before from date",
+			"group_by must be day, caller or route":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve API usage",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "API usage retrieved successfully",
+		"data":    report,
+	})
+}
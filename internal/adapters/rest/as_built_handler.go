@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AsBuiltHandler struct {
+	asBuiltUseCase usecase.AsBuiltUseCase
+	guard          PermissionGuard
+}
+
+func NewAsBuiltHandler(asBuiltUseCase usecase.AsBuiltUseCase, guard PermissionGuard) *AsBuiltHandler {
+	return &AsBuiltHandler{
+		asBuiltUseCase: asBuiltUseCase,
+		guard:          guard,
+	}
+}
+
+func (h *AsBuiltHandler) AsBuiltRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceBOQs, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit)
+
+	asBuilt := app.Group("/projects/:projectId/as-built")
+	asBuilt.Get("/", view, h.Reconcile)
+	asBuilt.Put("/:jobId", edit, h.Record)
+}
+
+func (h *AsBuiltHandler) Reconcile(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	reconciliation, err := h.asBuiltUseCase.Reconcile(c.Context(), projectID)
+	if err != nil {
+		return asBuiltError(c, err, "Failed to retrieve as-built reconciliation")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "As-built reconciliation retrieved successfully",
+		"data":    reconciliation,
+	})
+}
+
+func (h *AsBuiltHandler) Record(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	jobID, err := uuid.Parse(c.Params("jobId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid job ID",
+		})
+	}
+
+	var req requests.RecordAsBuiltRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	line, err := h.asBuiltUseCase.Record(c.Context(), projectID, jobID, req)
+	if err != nil {
+		return asBuiltError(c, err, "Failed to record as-built quantity")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "As-built quantity recorded successfully",
+		"data":    line,
+	})
+}
+
+func asBuiltError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "boq not found", "job not found in BOQ", "contract not found", "project not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "quantity cannot be negative", "invalid disposition", "no variation to raise as a change order",
+		"title and file url are required", "invalid date format, expected YYYY-MM-DD":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "job must be completed before recording as-built quantities",
+		"as-built quantity already raised as a change order":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditHandler struct {
+	auditUseCase usecase.AuditUseCase
+	userUsecase  usecase.UserUsecase
+}
+
+func NewAuditHandler(auditUseCase usecase.AuditUseCase, userUsecase usecase.UserUsecase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+		userUsecase:  userUsecase,
+	}
+}
+
+func (h *AuditHandler) AuditRoutes(app *fiber.App) {
+	segments := app.Group("/admin/audit-segments", RequireAuth(h.userUsecase))
+	segments.Get("/", h.ListSegments)
+	segments.Get("/verify", h.Verify)
+}
+
+// ListSegments accepts ?from=&to= (YYYY-MM-DD).
+func (h *AuditHandler) ListSegments(c *fiber.Ctx) error {
+	segments, err := h.auditUseCase.ListSegments(c.Context(), currentUserID(c), c.Query("from"), c.Query("to"))
+	if err != nil {
+		return auditError(c, err, "Failed to retrieve audit segments")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Audit segments retrieved successfully",
+		"data":    segments,
+	})
+}
+
+// Verify accepts ?from=&to= (YYYY-MM-DD).
+func (h *AuditHandler) Verify(c *fiber.Ctx) error {
+	result, err := h.auditUseCase.Verify(c.Context(), currentUserID(c), c.Query("from"), c.Query("to"))
+	if err != nil {
+		return auditError(c, err, "Failed to verify audit segments")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Audit segments verified successfully",
+		"data":    result,
+	})
+}
+
+func auditError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "only owners can access this resource":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid from date", "invalid to date", "to date must not be before from date":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -0,0 +1,255 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type BackChargeHandler struct {
+	backChargeUseCase usecase.BackChargeUseCase
+	userUsecase       usecase.UserUsecase
+	guard             PermissionGuard
+}
+
+func NewBackChargeHandler(backChargeUseCase usecase.BackChargeUseCase, userUsecase usecase.UserUsecase, guard PermissionGuard) *BackChargeHandler {
+	return &BackChargeHandler{
+		backChargeUseCase: backChargeUseCase,
+		userUsecase:       userUsecase,
+		guard:             guard,
+	}
+}
+
+func (h *BackChargeHandler) BackChargeRoutes(app *fiber.App) {
+	backCharges := app.Group("/back-charges")
+	backCharges.Get("/", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.List)
+	backCharges.Post("/", RequireAuth(h.userUsecase), h.Create)
+	backCharges.Get("/:id", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.GetByID)
+	backCharges.Post("/:id/dispute", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionEdit), h.Dispute)
+	backCharges.Post("/:id/resolve", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionApprove), h.Resolve)
+	backCharges.Post("/:id/withdraw", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionApprove), h.Withdraw)
+
+	backCharges.Post("/:id/attachments", RequireAuth(h.userUsecase), h.AddAttachment)
+	backCharges.Delete("/:id/attachments/:attachmentId", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionEdit), h.DeleteAttachment)
+}
+
+func (h *BackChargeHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreateBackChargeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	backCharge, err := h.backChargeUseCase.Create(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return backChargeError(c, err, "Failed to create back-charge")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Back-charge created successfully",
+		"data":    backCharge,
+	})
+}
+
+func (h *BackChargeHandler) List(c *fiber.Ctx) error {
+	var supplierID, projectID uuid.NullUUID
+	if v := c.Query("supplier_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid supplier ID",
+			})
+		}
+		supplierID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	if v := c.Query("project_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid project ID",
+			})
+		}
+		projectID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	backCharges, err := h.backChargeUseCase.List(c.Context(), supplierID, projectID)
+	if err != nil {
+		return backChargeError(c, err, "Failed to retrieve back-charges")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Back-charges retrieved successfully",
+		"data":    backCharges,
+	})
+}
+
+func (h *BackChargeHandler) GetByID(c *fiber.Ctx) error {
+	backChargeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid back-charge ID",
+		})
+	}
+
+	backCharge, err := h.backChargeUseCase.GetByID(c.Context(), backChargeID)
+	if err != nil {
+		return backChargeError(c, err, "Failed to retrieve back-charge")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Back-charge retrieved successfully",
+		"data":    backCharge,
+	})
+}
+
+func (h *BackChargeHandler) Dispute(c *fiber.Ctx) error {
+	backChargeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid back-charge ID",
+		})
+	}
+
+	var req requests.DisputeBackChargeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	backCharge, err := h.backChargeUseCase.Dispute(c.Context(), currentUserID(c), backChargeID, req)
+	if err != nil {
+		return backChargeError(c, err, "Failed to dispute back-charge")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Back-charge disputed successfully",
+		"data":    backCharge,
+	})
+}
+
+func (h *BackChargeHandler) Resolve(c *fiber.Ctx) error {
+	backChargeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid back-charge ID",
+		})
+	}
+
+	var req requests.ResolveBackChargeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	backCharge, err := h.backChargeUseCase.Resolve(c.Context(), backChargeID, req)
+	if err != nil {
+		return backChargeError(c, err, "Failed to resolve back-charge")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Back-charge resolved successfully",
+		"data":    backCharge,
+	})
+}
+
+func (h *BackChargeHandler) Withdraw(c *fiber.Ctx) error {
+	backChargeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid back-charge ID",
+		})
+	}
+
+	backCharge, err := h.backChargeUseCase.Withdraw(c.Context(), backChargeID)
+	if err != nil {
+		return backChargeError(c, err, "Failed to withdraw back-charge")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Back-charge withdrawn successfully",
+		"data":    backCharge,
+	})
+}
+
+func (h *BackChargeHandler) AddAttachment(c *fiber.Ctx) error {
+	backChargeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid back-charge ID",
+		})
+	}
+
+	var req requests.BackChargeAttachmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	attachment, err := h.backChargeUseCase.AddAttachment(c.Context(), currentUserID(c), backChargeID, req)
+	if err != nil {
+		return backChargeError(c, err, "Failed to add attachment")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Attachment added successfully",
+		"data":    attachment,
+	})
+}
+
+func (h *BackChargeHandler) DeleteAttachment(c *fiber.Ctx) error {
+	backChargeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid back-charge ID",
+		})
+	}
+
+	attachmentID, err := uuid.Parse(c.Params("attachmentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid attachment ID",
+		})
+	}
+
+	if err := h.backChargeUseCase.DeleteAttachment(c.Context(), backChargeID, attachmentID); err != nil {
+		return backChargeError(c, err, "Failed to delete attachment")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Attachment deleted successfully",
+	})
+}
+
+func backChargeError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "back-charge not found", "attachment not found", "supplier not found", "project not found",
+		"subcontract not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only open back-charges can be disputed", "back-charge is fully deducted", "back-charge is not disputed",
+		"back-charge already withdrawn", "amount is less than already deducted":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid back-charge category", "description is required", "amount must be greater than 0",
+		"subcontract belongs to another supplier", "subcontract belongs to another project", "reason is required",
+		"resolution is required", "invalid outcome",
+		"reduced amount must be greater than 0 and less than the current amount",
+		"title and file url are required":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
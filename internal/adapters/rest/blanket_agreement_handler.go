@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type BlanketAgreementHandler struct {
+	agreementUseCase usecase.BlanketAgreementUseCase
+	guard            PermissionGuard
+}
+
+func NewBlanketAgreementHandler(agreementUseCase usecase.BlanketAgreementUseCase, guard PermissionGuard) *BlanketAgreementHandler {
+	return &BlanketAgreementHandler{
+		agreementUseCase: agreementUseCase,
+		guard:            guard,
+	}
+}
+
+func (h *BlanketAgreementHandler) BlanketAgreementRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceMaterials, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit)
+
+	agreements := app.Group("/blanket-agreements")
+	agreements.Get("/", view, h.List)
+	agreements.Post("/", edit, h.Create)
+	agreements.Get("/alerts", view, h.GetAlerts)
+	agreements.Get("/:id", view, h.GetByID)
+}
+
+func (h *BlanketAgreementHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreateBlanketAgreementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	agreement, err := h.agreementUseCase.Create(c.Context(), req)
+	if err != nil {
+		return blanketAgreementError(c, err, "Failed to create blanket agreement")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Blanket agreement created successfully",
+		"data":    agreement,
+	})
+}
+
+// List accepts ?supplier_id= and ?active=true for agreements valid today.
+func (h *BlanketAgreementHandler) List(c *fiber.Ctx) error {
+	var supplierID uuid.NullUUID
+	if raw := c.Query("supplier_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid supplier ID",
+			})
+		}
+		supplierID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	agreements, err := h.agreementUseCase.List(c.Context(), supplierID, c.QueryBool("active"))
+	if err != nil {
+		return blanketAgreementError(c, err, "Failed to retrieve blanket agreements")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Blanket agreements retrieved successfully",
+		"data":    agreements,
+	})
+}
+
+func (h *BlanketAgreementHandler) GetByID(c *fiber.Ctx) error {
+	agreementID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid blanket agreement ID",
+		})
+	}
+
+	agreement, err := h.agreementUseCase.GetByID(c.Context(), agreementID)
+	if err != nil {
+		return blanketAgreementError(c, err, "Failed to retrieve blanket agreement")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Blanket agreement retrieved successfully",
+		"data":    agreement,
+	})
+}
+
+// GetAlerts accepts ?days= to override how far ahead expiries are shown.
+func (h *BlanketAgreementHandler) GetAlerts(c *fiber.Ctx) error {
+	alerts, err := h.agreementUseCase.GetAlerts(c.Context(), c.QueryInt("days", -1))
+	if err != nil {
+		return blanketAgreementError(c, err, "Failed to retrieve blanket agreement alerts")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Blanket agreement alerts retrieved successfully",
+		"data":    alerts,
+	})
+}
+
+func blanketAgreementError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "blanket agreement not found", "supplier not found", "material not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "reference is required", "at least one item is required",
+		"invalid valid from date format, expected YYYY-MM-DD", "invalid valid to date format, expected YYYY-MM-DD",
+		"valid to cannot be before valid from", "cannot agree terms with a blacklisted supplier",
+		"unit price must be greater than 0", "committed quantity must be greater than 0",
+		"each material can only be on an agreement once":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "agreement reference already exists":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -28,6 +28,11 @@ func (h *BOQHandler) BOQRoutes(app *fiber.App) {
 	boq.Post("/:id/jobs", h.AddBOQJob)
 	boq.Put("/:id/jobs", h.UpdateBOQJob)
 	boq.Delete("/:id/jobs/:jobId", h.DeleteBOQJob)
+	boq.Post("/:id/jobs/:jobId/complete", h.CompleteBOQJob)
+
+	boq.Get("/:id/jobs/:jobId/drawings", h.ListJobDrawings)
+	boq.Post("/:id/jobs/:jobId/drawings", h.AddJobDrawing)
+	boq.Delete("/:id/jobs/:jobId/drawings/:drawingId", h.DeleteJobDrawing)
 }
 
 func (h *BOQHandler) Approve(c *fiber.Ctx) error {
@@ -40,7 +45,11 @@ func (h *BOQHandler) Approve(c *fiber.Ctx) error {
 
 	err = h.boqUsecase.Approve(c.Context(), boqID)
 	if err != nil {
-
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -68,7 +77,11 @@ func (h *BOQHandler) GetBoqWithProject(c *fiber.Ctx) error {
 
 	boq, err := h.boqUsecase.GetBoqWithProject(c.Context(), uuid)
 	if err != nil {
-
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -97,7 +110,11 @@ func (h *BOQHandler) AddBOQJob(c *fiber.Ctx) error {
 
 	err = h.boqUsecase.AddBOQJob(c.Context(), boqID, req)
 	if err != nil {
-
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -125,7 +142,11 @@ func (h *BOQHandler) UpdateBOQJob(c *fiber.Ctx) error {
 
 	err = h.boqUsecase.UpdateBOQJob(c.Context(), boqID, req)
 	if err != nil {
-
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -153,7 +174,11 @@ func (h *BOQHandler) DeleteBOQJob(c *fiber.Ctx) error {
 
 	err = h.boqUsecase.DeleteBOQJob(c.Context(), boqID, jobID)
 	if err != nil {
-
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -164,6 +189,47 @@ func (h *BOQHandler) DeleteBOQJob(c *fiber.Ctx) error {
 	})
 }
 
+func (h *BOQHandler) CompleteBOQJob(c *fiber.Ctx) error {
+	boqID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid BOQ ID",
+		})
+	}
+
+	jobID, err := uuid.Parse(c.Params("jobId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid job ID",
+		})
+	}
+
+	if err := h.boqUsecase.CompleteBOQJob(c.Context(), boqID, jobID); err != nil {
+		switch err.Error() {
+		case "project access denied":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "boq not found", "job not found in BOQ":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "mandatory inspections have not passed", "can only complete jobs in an approved BOQ":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to complete BOQ job",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "BOQ job completed successfully",
+	})
+}
+
 func (h *BOQHandler) ExportBOQ(c *fiber.Ctx) error {
 	projectID, err := uuid.Parse(c.Params("projectId"))
 	if err != nil {
@@ -173,8 +239,18 @@ func (h *BOQHandler) ExportBOQ(c *fiber.Ctx) error {
 	}
 
 	// Get BOQ summary data
-	summary, err := h.boqUsecase.GetBOQSummary(c.Context(), projectID)
+	summary, err := h.boqUsecase.GetBOQSummary(c.Context(), projectID, c.Query("language"))
 	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err.Error() == "language must be th, en or bilingual" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -186,3 +262,108 @@ func (h *BOQHandler) ExportBOQ(c *fiber.Ctx) error {
 	})
 
 }
+
+// boqJobParams parses the BOQ and job IDs shared by the drawing routes.
+func boqJobParams(c *fiber.Ctx) (uuid.UUID, uuid.UUID, string) {
+	boqID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "Invalid BOQ ID"
+	}
+
+	jobID, err := uuid.Parse(c.Params("jobId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "Invalid job ID"
+	}
+
+	return boqID, jobID, ""
+}
+
+func (h *BOQHandler) AddJobDrawing(c *fiber.Ctx) error {
+	boqID, jobID, msg := boqJobParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	var req requests.BOQJobDrawingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	drawing, err := h.boqUsecase.AddJobDrawing(c.Context(), boqID, jobID, req)
+	if err != nil {
+		return boqDrawingError(c, err, "Failed to add drawing")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Drawing added successfully",
+		"data":    drawing,
+	})
+}
+
+func (h *BOQHandler) ListJobDrawings(c *fiber.Ctx) error {
+	boqID, jobID, msg := boqJobParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	drawings, err := h.boqUsecase.ListJobDrawings(c.Context(), boqID, jobID)
+	if err != nil {
+		return boqDrawingError(c, err, "Failed to retrieve drawings")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Drawings retrieved successfully",
+		"data":    drawings,
+	})
+}
+
+func (h *BOQHandler) DeleteJobDrawing(c *fiber.Ctx) error {
+	boqID, jobID, msg := boqJobParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	drawingID, err := uuid.Parse(c.Params("drawingId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid drawing ID",
+		})
+	}
+
+	if err := h.boqUsecase.DeleteJobDrawing(c.Context(), boqID, jobID, drawingID); err != nil {
+		return boqDrawingError(c, err, "Failed to delete drawing")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Drawing deleted successfully",
+	})
+}
+
+func boqDrawingError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "job not found in BOQ", "drawing not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "drawing title is required", "either file_url or url is required", "invalid drawing URL":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type BOQPriceUpdateHandler struct {
+	priceUpdateUseCase usecase.BOQPriceUpdateUseCase
+	guard              PermissionGuard
+}
+
+func NewBOQPriceUpdateHandler(priceUpdateUseCase usecase.BOQPriceUpdateUseCase, guard PermissionGuard) *BOQPriceUpdateHandler {
+	return &BOQPriceUpdateHandler{
+		priceUpdateUseCase: priceUpdateUseCase,
+		guard:              guard,
+	}
+}
+
+func (h *BOQPriceUpdateHandler) BOQPriceUpdateRoutes(app *fiber.App) {
+	boq := app.Group("/boqs")
+
+	boq.Get("/price-updates", h.List)
+	boq.Get("/:id/price-update", h.GetByBOQID)
+	boq.Post("/:id/price-update/acknowledge", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.Acknowledge)
+}
+
+// List returns drafts flagged as "prices updated" that nobody has
+// acknowledged yet, or every flag with ?all=true.
+func (h *BOQPriceUpdateHandler) List(c *fiber.Ctx) error {
+	updates, err := h.priceUpdateUseCase.List(c.Context(), c.QueryBool("all", false))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve price updates",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Price updates retrieved successfully",
+		"data":    updates,
+	})
+}
+
+func (h *BOQPriceUpdateHandler) GetByBOQID(c *fiber.Ctx) error {
+	boqID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid BOQ ID",
+		})
+	}
+
+	update, err := h.priceUpdateUseCase.GetByBOQID(c.Context(), boqID)
+	if err != nil {
+		return boqPriceUpdateError(c, err, "Failed to retrieve price update")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Price update retrieved successfully",
+		"data":    update,
+	})
+}
+
+func (h *BOQPriceUpdateHandler) Acknowledge(c *fiber.Ctx) error {
+	boqID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid BOQ ID",
+		})
+	}
+
+	if err := h.priceUpdateUseCase.Acknowledge(c.Context(), boqID, currentUserID(c)); err != nil {
+		return boqPriceUpdateError(c, err, "Failed to acknowledge price update")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Price update acknowledged successfully",
+	})
+}
+
+func boqPriceUpdateError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "price update not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "price update already acknowledged":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -0,0 +1,320 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ClaimHandler struct {
+	claimUseCase usecase.ClaimUseCase
+	guard        PermissionGuard
+}
+
+func NewClaimHandler(claimUseCase usecase.ClaimUseCase, guard PermissionGuard) *ClaimHandler {
+	return &ClaimHandler{
+		claimUseCase: claimUseCase,
+		guard:        guard,
+	}
+}
+
+func (h *ClaimHandler) ClaimRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceProjects, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceProjects, models.PermissionActionEdit)
+	approve := h.guard(models.PermissionResourceProjects, models.PermissionActionApprove)
+
+	projectClaims := app.Group("/projects/:projectId/claims")
+	projectClaims.Get("/", view, h.List)
+	projectClaims.Post("/", edit, h.Create)
+
+	claims := app.Group("/claims")
+	claims.Get("/:id", view, h.GetByID)
+	claims.Put("/:id", edit, h.Update)
+	claims.Delete("/:id", edit, h.Delete)
+	claims.Post("/:id/submit", edit, h.Submit)
+	claims.Post("/:id/settle", approve, h.Settle)
+	claims.Post("/:id/reject", approve, h.Reject)
+	claims.Post("/:id/withdraw", edit, h.Withdraw)
+
+	claims.Post("/:id/documents", edit, h.AddDocument)
+	claims.Delete("/:id/documents/:documentId", edit, h.DeleteDocument)
+}
+
+func (h *ClaimHandler) Create(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.ClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	claim, err := h.claimUseCase.Create(c.Context(), projectID, req)
+	if err != nil {
+		return claimError(c, err, "Failed to create claim")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Claim created successfully",
+		"data":    claim,
+	})
+}
+
+// List accepts ?status= to show only claims in that status.
+func (h *ClaimHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	claims, err := h.claimUseCase.List(c.Context(), projectID, c.Query("status"))
+	if err != nil {
+		return claimError(c, err, "Failed to retrieve claims")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claims retrieved successfully",
+		"data":    claims,
+	})
+}
+
+func (h *ClaimHandler) GetByID(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+
+	claim, err := h.claimUseCase.GetByID(c.Context(), claimID)
+	if err != nil {
+		return claimError(c, err, "Failed to retrieve claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim retrieved successfully",
+		"data":    claim,
+	})
+}
+
+func (h *ClaimHandler) Update(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+
+	var req requests.ClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	claim, err := h.claimUseCase.Update(c.Context(), claimID, req)
+	if err != nil {
+		return claimError(c, err, "Failed to update claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim updated successfully",
+		"data":    claim,
+	})
+}
+
+func (h *ClaimHandler) Delete(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+
+	if err := h.claimUseCase.Delete(c.Context(), claimID); err != nil {
+		return claimError(c, err, "Failed to delete claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim deleted successfully",
+	})
+}
+
+func (h *ClaimHandler) Submit(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+
+	claim, err := h.claimUseCase.Submit(c.Context(), claimID)
+	if err != nil {
+		return claimError(c, err, "Failed to submit claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim submitted successfully",
+		"data":    claim,
+	})
+}
+
+func (h *ClaimHandler) Settle(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+
+	var req requests.SettleClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	claim, err := h.claimUseCase.Settle(c.Context(), claimID, req)
+	if err != nil {
+		return claimError(c, err, "Failed to settle claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim settled successfully",
+		"data":    claim,
+	})
+}
+
+func (h *ClaimHandler) Reject(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+
+	var req requests.RejectClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	claim, err := h.claimUseCase.Reject(c.Context(), claimID, req)
+	if err != nil {
+		return claimError(c, err, "Failed to reject claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim rejected successfully",
+		"data":    claim,
+	})
+}
+
+func (h *ClaimHandler) Withdraw(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+
+	claim, err := h.claimUseCase.Withdraw(c.Context(), claimID)
+	if err != nil {
+		return claimError(c, err, "Failed to withdraw claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim withdrawn successfully",
+		"data":    claim,
+	})
+}
+
+func (h *ClaimHandler) AddDocument(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+
+	var req requests.ClaimDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	document, err := h.claimUseCase.AddDocument(c.Context(), claimID, req)
+	if err != nil {
+		return claimError(c, err, "Failed to add claim document")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Claim document added successfully",
+		"data":    document,
+	})
+}
+
+func (h *ClaimHandler) DeleteDocument(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid claim ID",
+		})
+	}
+	documentID, err := uuid.Parse(c.Params("documentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	if err := h.claimUseCase.DeleteDocument(c.Context(), claimID, documentID); err != nil {
+		return claimError(c, err, "Failed to delete claim document")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim document deleted successfully",
+	})
+}
+
+func claimError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "claim not found", "claim document not found", "contract not found", "contract document not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "claim is already closed", "only draft claims can be deleted", "only draft claims can be submitted",
+		"only submitted claims can be settled", "only submitted claims can be rejected":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid claim status", "invalid claim type", "title and narrative are required",
+		"claimed amount and days cannot be negative", "a time-extension claim needs claimed days",
+		"a cost claim needs a claimed amount", "settlement cannot be negative", "resolution is required",
+		"title and file url are required":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
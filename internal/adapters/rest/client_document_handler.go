@@ -0,0 +1,186 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ClientDocumentHandler struct {
+	clientDocumentUsecase usecase.ClientDocumentUsecase
+	guard                 PermissionGuard
+}
+
+func NewClientDocumentHandler(clientDocumentUsecase usecase.ClientDocumentUsecase, guard PermissionGuard) *ClientDocumentHandler {
+	return &ClientDocumentHandler{
+		clientDocumentUsecase: clientDocumentUsecase,
+		guard:                 guard,
+	}
+}
+
+func (h *ClientDocumentHandler) ClientDocumentRoutes(app *fiber.App) {
+	documents := app.Group("/clients/:id/documents")
+	documents.Get("/", h.guard(models.PermissionResourceClients, models.PermissionActionView), h.List)
+	documents.Post("/", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.Upload)
+	documents.Get("/:documentId/url", h.guard(models.PermissionResourceClients, models.PermissionActionView), h.Link)
+	documents.Delete("/:documentId", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.Delete)
+
+	// Reached through the signed link.
+	app.Get("/client-documents/:token", h.Download)
+}
+
+func (h *ClientDocumentHandler) List(c *fiber.Ctx) error {
+	clientID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+
+	documents, err := h.clientDocumentUsecase.List(c.Context(), clientID)
+	if err != nil {
+		return clientDocumentError(c, err, "Failed to retrieve client documents")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client documents retrieved successfully",
+		"data":    documents,
+	})
+}
+
+// Upload takes the file as the multipart field "file" along with the form
+// fields document_type (company_registration, id_card or other) and note.
+func (h *ClientDocumentHandler) Upload(c *fiber.Ctx) error {
+	clientID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+
+	var req requests.ClientDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Document file is required",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to read document file",
+		})
+	}
+	defer file.Close()
+
+	document, err := h.clientDocumentUsecase.Upload(c.Context(), clientID, req, fileHeader.Filename, file)
+	if err != nil {
+		return clientDocumentError(c, err, "Failed to upload client document")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Client document uploaded successfully",
+		"data":    document,
+	})
+}
+
+func (h *ClientDocumentHandler) Link(c *fiber.Ctx) error {
+	clientID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+	documentID, err := uuid.Parse(c.Params("documentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	link, err := h.clientDocumentUsecase.Link(c.Context(), clientID, documentID)
+	if err != nil {
+		return clientDocumentError(c, err, "Failed to create document link")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Document link created successfully",
+		"data":    link,
+	})
+}
+
+func (h *ClientDocumentHandler) Delete(c *fiber.Ctx) error {
+	clientID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+	documentID, err := uuid.Parse(c.Params("documentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	if err := h.clientDocumentUsecase.Delete(c.Context(), clientID, documentID); err != nil {
+		return clientDocumentError(c, err, "Failed to delete client document")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client document deleted successfully",
+	})
+}
+
+func (h *ClientDocumentHandler) Download(c *fiber.Ctx) error {
+	document, content, err := h.clientDocumentUsecase.Download(c.Context(), c.Params("token"))
+	if err != nil {
+		return clientDocumentError(c, err, "Failed to retrieve client document")
+	}
+
+	c.Set(fiber.HeaderContentType, document.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, document.FileName))
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	return c.Send(content)
+}
+
+func clientDocumentError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "client not found", "document not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid or expired link":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "client is archived":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid document type", "document is empty", "document must not exceed 3 MB",
+		"document must be a PDF, JPEG or PNG file":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "document storage is not configured":
+		return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
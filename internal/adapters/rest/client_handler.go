@@ -2,9 +2,11 @@
 package rest
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/usecase"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -12,22 +14,35 @@ import (
 
 type ClientHandler struct {
 	clientUsecase usecase.ClientUsecase
+	guard         PermissionGuard
 }
 
-func NewClientHandler(clientUsecase usecase.ClientUsecase) *ClientHandler {
+func NewClientHandler(clientUsecase usecase.ClientUsecase, guard PermissionGuard) *ClientHandler {
 	return &ClientHandler{
 		clientUsecase: clientUsecase,
+		guard:         guard,
 	}
 }
 
 func (h *ClientHandler) ClientRoutes(app *fiber.App) {
 	client := app.Group("/clients")
 
-	client.Post("/", h.Create)
+	client.Post("/", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.Create)
 	client.Get("/", h.List)
+	client.Get("/merge/preview", h.PreviewMerge)
+	client.Post("/merge", h.guard(models.PermissionResourceClients, models.PermissionActionDelete), h.Merge)
 	client.Get("/:id", h.GetByID)
-	client.Put("/:id", h.Update)
-	client.Delete("/:id", h.Delete)
+	client.Put("/:id", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.Update)
+	client.Delete("/:id", h.guard(models.PermissionResourceClients, models.PermissionActionDelete), h.Delete)
+	client.Post("/:id/restore", h.guard(models.PermissionResourceClients, models.PermissionActionDelete), h.Restore)
+
+	client.Put("/:id/credit-hold", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.SetCreditHold)
+	client.Delete("/:id/credit-hold", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.ReleaseCreditHold)
+
+	client.Get("/:id/contacts", h.ListContacts)
+	client.Post("/:id/contacts", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.AddContact)
+	client.Put("/:id/contacts/:contactId", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.UpdateContact)
+	client.Delete("/:id/contacts/:contactId", h.guard(models.PermissionResourceClients, models.PermissionActionEdit), h.RemoveContact)
 }
 
 func (h *ClientHandler) Create(c *fiber.Ctx) error {
@@ -65,6 +80,9 @@ func (h *ClientHandler) Create(c *fiber.Ctx) error {
 	})
 }
 
+// List accepts ?search= (name, email or phone), ?tax_id=,
+// ?sort=name|email|tel|tax_id, ?order=asc|desc, ?page= and ?page_size=
+// (default 10, at most 100).
 func (h *ClientHandler) List(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
@@ -76,8 +94,23 @@ func (h *ClientHandler) List(c *fiber.Ctx) error {
 		pageSize = 10
 	}
 
-	response, err := h.clientUsecase.List(c.Context(), page, pageSize)
+	response, err := h.clientUsecase.List(c.Context(), requests.ListClientsRequest{
+		Search:   c.Query("search"),
+		TaxID:    c.Query("tax_id"),
+		Sort:     c.Query("sort"),
+		Order:    c.Query("order"),
+		Page:     page,
+		PageSize: pageSize,
+
+		IncludeArchived: c.QueryBool("include_archived"),
+	})
 	if err != nil {
+		switch err.Error() {
+		case "invalid sort column", "order must be asc or desc":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to retrieve clients",
 		})
@@ -147,6 +180,10 @@ func (h *ClientHandler) Update(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"error": "Client with this email already exists",
 			})
+		case "credit limit cannot be negative", "payment terms cannot be negative":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": "Failed to update client",
@@ -169,13 +206,318 @@ func (h *ClientHandler) Delete(c *fiber.Ctx) error {
 
 	err = h.clientUsecase.Delete(c.Context(), id)
 	if err != nil {
+		switch err.Error() {
+		case "client not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Client not found",
+			})
+		case "client is already archived":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to delete client",
+			})
+		}
+	}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Client deleted successfully",
+	})
+}
+
+func (h *ClientHandler) Restore(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+
+	err = h.clientUsecase.Restore(c.Context(), id)
+	if err != nil {
+		switch err.Error() {
+		case "client not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Client not found",
+			})
+		case "client is not archived":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to restore client",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client restored successfully",
+	})
+}
+
+func (h *ClientHandler) SetCreditHold(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+
+	var req requests.SetCreditHoldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	err = h.clientUsecase.SetCreditHold(c.Context(), id, req)
+	if err != nil {
+		switch err.Error() {
+		case "client not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Client not found",
+			})
+		case "reason is required":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to set credit hold",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client put on credit hold successfully",
+	})
+}
+
+func (h *ClientHandler) ReleaseCreditHold(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+
+	err = h.clientUsecase.ReleaseCreditHold(c.Context(), id)
+	if err != nil {
+		switch err.Error() {
+		case "client not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Client not found",
+			})
+		case "client is not on credit hold":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to release credit hold",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client credit hold released successfully",
+	})
+}
+
+func (h *ClientHandler) ListContacts(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+
+	contacts, err := h.clientUsecase.ListContacts(c.Context(), id)
+	if err != nil {
+		return clientContactError(c, err, "Failed to retrieve client contacts")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client contacts retrieved successfully",
+		"data":    contacts,
+	})
+}
+
+func (h *ClientHandler) AddContact(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+
+	var req requests.ClientContactRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	contact, err := h.clientUsecase.AddContact(c.Context(), id, req)
+	if err != nil {
+		return clientContactError(c, err, "Failed to add client contact")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Client contact added successfully",
+		"data":    contact,
+	})
+}
+
+func (h *ClientHandler) UpdateContact(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+	contactID, err := uuid.Parse(c.Params("contactId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contact ID",
+		})
+	}
+
+	var req requests.ClientContactRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	contact, err := h.clientUsecase.UpdateContact(c.Context(), id, contactID, req)
+	if err != nil {
+		return clientContactError(c, err, "Failed to update client contact")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client contact updated successfully",
+		"data":    contact,
+	})
+}
+
+func (h *ClientHandler) RemoveContact(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+	contactID, err := uuid.Parse(c.Params("contactId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contact ID",
+		})
+	}
+
+	if err := h.clientUsecase.RemoveContact(c.Context(), id, contactID); err != nil {
+		return clientContactError(c, err, "Failed to remove client contact")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client contact removed successfully",
+	})
+}
+
+func clientContactError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "client not found", "contact not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "contact name is required", "invalid contact email":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
 	}
+}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Client deleted successfully",
+func (h *ClientHandler) PreviewMerge(c *fiber.Ctx) error {
+	sourceID, err := uuid.Parse(c.Query("source"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid source client ID",
+		})
+	}
+
+	targetID, err := uuid.Parse(c.Query("target"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid target client ID",
+		})
+	}
+
+	preview, err := h.clientUsecase.PreviewMerge(c.Context(), sourceID, targetID)
+	if err != nil {
+		switch err.Error() {
+		case "client not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Client not found",
+			})
+		case "cannot merge a client into itself", "cannot merge into an archived client":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to preview client merge",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client merge preview retrieved successfully",
+		"data":    preview,
+	})
+}
+
+func (h *ClientHandler) Merge(c *fiber.Ctx) error {
+	var req requests.MergeClientRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	client, err := h.clientUsecase.Merge(c.Context(), req)
+	if err != nil {
+		switch {
+		case err.Error() == "client not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Client not found",
+			})
+		case err.Error() == "client with this email already exists":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Client with this email already exists",
+			})
+		case err.Error() == "cannot merge a client into itself",
+			err.Error() == "cannot merge into an archived client",
+			err.Error() == "keep values must be source or target",
+			strings.HasPrefix(err.Error(), "unknown merge field"):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to merge clients",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Clients merged successfully",
+		"data":    client,
 	})
 }
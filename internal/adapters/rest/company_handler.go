@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/usecase"
 
@@ -10,19 +11,21 @@ import (
 
 type CompanyHandler struct {
 	companyUseCase usecase.CompanyUseCase
+	guard          PermissionGuard
 }
 
-func NewCompanyHandler(companyUseCase usecase.CompanyUseCase) *CompanyHandler {
+func NewCompanyHandler(companyUseCase usecase.CompanyUseCase, guard PermissionGuard) *CompanyHandler {
 	return &CompanyHandler{
 		companyUseCase: companyUseCase,
+		guard:          guard,
 	}
 }
 
 func (h *CompanyHandler) CompanyRoutes(app *fiber.App) {
 	company := app.Group("/company")
 
-	company.Get("/:userId", h.GetCompanyByUserID)
-	company.Put("/:userId", h.UpdateCompany)
+	company.Get("/:userId", h.guard(models.PermissionResourceSettings, models.PermissionActionView), h.GetCompanyByUserID)
+	company.Put("/:userId", h.guard(models.PermissionResourceSettings, models.PermissionActionEdit), h.UpdateCompany)
 }
 
 // GetCompanyByUserID retrieves company for a specific user
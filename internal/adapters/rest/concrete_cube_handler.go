@@ -0,0 +1,216 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CubeTestHandler struct {
+	cubeUseCase usecase.CubeTestUseCase
+	guard       PermissionGuard
+}
+
+func NewCubeTestHandler(cubeUseCase usecase.CubeTestUseCase, guard PermissionGuard) *CubeTestHandler {
+	return &CubeTestHandler{
+		cubeUseCase: cubeUseCase,
+		guard:       guard,
+	}
+}
+
+func (h *CubeTestHandler) CubeTestRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceBOQs, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit)
+
+	app.Get("/projects/:projectId/cube-tests", view, h.List)
+	app.Get("/projects/:projectId/cube-tests/summary", view, h.Summary)
+
+	tests := app.Group("/cube-tests")
+	tests.Post("/", edit, h.Create)
+	tests.Get("/alerts", view, h.Alerts)
+	tests.Get("/:id", view, h.GetByID)
+	tests.Put("/:id/results", edit, h.RecordResults)
+	tests.Delete("/:id", edit, h.Delete)
+}
+
+func (h *CubeTestHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreateCubeTestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	test, err := h.cubeUseCase.Create(c.Context(), req)
+	if err != nil {
+		return cubeTestError(c, err, "Failed to record cube test")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Cube test recorded successfully",
+		"data":    test,
+	})
+}
+
+// List accepts ?pour_id= and ?job_id=.
+func (h *CubeTestHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var pourID, jobID uuid.NullUUID
+	if raw := c.Query("pour_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid concrete pour ID",
+			})
+		}
+		pourID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	if raw := c.Query("job_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid job ID",
+			})
+		}
+		jobID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	tests, err := h.cubeUseCase.List(c.Context(), projectID, pourID, jobID)
+	if err != nil {
+		return cubeTestError(c, err, "Failed to retrieve cube tests")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cube tests retrieved successfully",
+		"data":    tests,
+	})
+}
+
+func (h *CubeTestHandler) Summary(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	summary, err := h.cubeUseCase.Summary(c.Context(), projectID)
+	if err != nil {
+		return cubeTestError(c, err, "Failed to retrieve cube test summary")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cube test summary retrieved successfully",
+		"data":    summary,
+	})
+}
+
+func (h *CubeTestHandler) Alerts(c *fiber.Ctx) error {
+	alerts, err := h.cubeUseCase.Alerts(c.Context())
+	if err != nil {
+		return cubeTestError(c, err, "Failed to retrieve cube test alerts")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cube test alerts retrieved successfully",
+		"data":    alerts,
+	})
+}
+
+func (h *CubeTestHandler) GetByID(c *fiber.Ctx) error {
+	testID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cube test ID",
+		})
+	}
+
+	test, err := h.cubeUseCase.GetByID(c.Context(), testID)
+	if err != nil {
+		return cubeTestError(c, err, "Failed to retrieve cube test")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cube test retrieved successfully",
+		"data":    test,
+	})
+}
+
+func (h *CubeTestHandler) RecordResults(c *fiber.Ctx) error {
+	testID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cube test ID",
+		})
+	}
+
+	var req requests.RecordCubeResultsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	test, err := h.cubeUseCase.RecordResults(c.Context(), testID, req)
+	if err != nil {
+		return cubeTestError(c, err, "Failed to record cube test results")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cube test results recorded successfully",
+		"data":    test,
+	})
+}
+
+func (h *CubeTestHandler) Delete(c *fiber.Ctx) error {
+	testID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cube test ID",
+		})
+	}
+
+	if err := h.cubeUseCase.Delete(c.Context(), testID); err != nil {
+		return cubeTestError(c, err, "Failed to delete cube test")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cube test deleted successfully",
+	})
+}
+
+func cubeTestError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "cube test not found", "concrete pour not found", "project not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "sample reference is required", "specified strength must be greater than 0",
+		"strength must be greater than 0", "invalid date format, expected YYYY-MM-DD",
+		"sample date cannot be before the pour date":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "sample reference already recorded for this pour":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
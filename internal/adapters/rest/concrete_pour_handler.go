@@ -0,0 +1,174 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ConcretePourHandler struct {
+	pourUseCase usecase.ConcretePourUseCase
+	guard       PermissionGuard
+}
+
+func NewConcretePourHandler(pourUseCase usecase.ConcretePourUseCase, guard PermissionGuard) *ConcretePourHandler {
+	return &ConcretePourHandler{
+		pourUseCase: pourUseCase,
+		guard:       guard,
+	}
+}
+
+func (h *ConcretePourHandler) ConcretePourRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceBOQs, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit)
+
+	projectPours := app.Group("/projects/:projectId/concrete-pours")
+	projectPours.Get("/", view, h.List)
+	projectPours.Post("/", edit, h.Record)
+	projectPours.Get("/summary", view, h.Summary)
+
+	pours := app.Group("/concrete-pours")
+	pours.Get("/:id", view, h.GetByID)
+	pours.Delete("/:id", edit, h.Delete)
+}
+
+func (h *ConcretePourHandler) Record(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.RecordConcretePourRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	pour, err := h.pourUseCase.Record(c.Context(), projectID, req)
+	if err != nil {
+		return concretePourError(c, err, "Failed to record concrete pour")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Concrete pour recorded successfully",
+		"data":    pour,
+	})
+}
+
+// List accepts ?job_id= to show the pours into one job.
+func (h *ConcretePourHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var jobID uuid.NullUUID
+	if raw := c.Query("job_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid job ID",
+			})
+		}
+		jobID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	pours, err := h.pourUseCase.List(c.Context(), projectID, jobID)
+	if err != nil {
+		return concretePourError(c, err, "Failed to retrieve concrete pours")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Concrete pours retrieved successfully",
+		"data":    pours,
+	})
+}
+
+func (h *ConcretePourHandler) Summary(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	summary, err := h.pourUseCase.Summary(c.Context(), projectID)
+	if err != nil {
+		return concretePourError(c, err, "Failed to retrieve concrete pour summary")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Concrete pour summary retrieved successfully",
+		"data":    summary,
+	})
+}
+
+func (h *ConcretePourHandler) GetByID(c *fiber.Ctx) error {
+	pourID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid concrete pour ID",
+		})
+	}
+
+	pour, err := h.pourUseCase.GetByID(c.Context(), pourID)
+	if err != nil {
+		return concretePourError(c, err, "Failed to retrieve concrete pour")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Concrete pour retrieved successfully",
+		"data":    pour,
+	})
+}
+
+func (h *ConcretePourHandler) Delete(c *fiber.Ctx) error {
+	pourID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid concrete pour ID",
+		})
+	}
+
+	if err := h.pourUseCase.Delete(c.Context(), pourID); err != nil {
+		return concretePourError(c, err, "Failed to delete concrete pour")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Concrete pour deleted successfully",
+	})
+}
+
+func concretePourError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "concrete pour not found", "project not found", "supplier not found", "material not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "ticket number is required", "volume must be greater than 0", "slump cannot be negative",
+		"invalid date format, expected YYYY-MM-DD", "job is not on the project's BOQ":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "ticket number already recorded for this supplier", "concrete pour has cube tests":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/usecase"
 
@@ -10,11 +11,13 @@ import (
 
 type ContractHandler struct {
 	contractUseCase usecase.ContractUseCase
+	guard           PermissionGuard
 }
 
-func NewContractHandler(contractUseCase usecase.ContractUseCase) *ContractHandler {
+func NewContractHandler(contractUseCase usecase.ContractUseCase, guard PermissionGuard) *ContractHandler {
 	return &ContractHandler{
 		contractUseCase: contractUseCase,
+		guard:           guard,
 	}
 }
 
@@ -22,8 +25,13 @@ func (h *ContractHandler) ContractRoutes(app *fiber.App) {
 	contract := app.Group("/contracts/:projectId")
 
 	contract.Get("/", h.GetContract)
-	contract.Post("/", h.CreateContract)
-	contract.Delete("/", h.DeleteContract)
+	contract.Post("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.CreateContract)
+	contract.Delete("/", h.guard(models.PermissionResourceProjects, models.PermissionActionDelete), h.DeleteContract)
+
+	contract.Get("/value", h.GetContractValue)
+	contract.Get("/documents", h.ListDocuments)
+	contract.Post("/documents", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.CreateDocument)
+	contract.Get("/documents/:documentId", h.GetDocument)
 }
 
 func (h *ContractHandler) GetContract(c *fiber.Ctx) error {
@@ -64,6 +72,11 @@ func (h *ContractHandler) CreateContract(c *fiber.Ctx) error {
 	}
 
 	if err := h.contractUseCase.CreateContract(c.Context(), projectID, req); err != nil {
+		if err.Error() == "high risks must have mitigation before contracting" {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -92,3 +105,118 @@ func (h *ContractHandler) DeleteContract(c *fiber.Ctx) error {
 		"message": "Contract deleted successfully",
 	})
 }
+
+func (h *ContractHandler) CreateDocument(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.CreateContractDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	document, err := h.contractUseCase.CreateDocument(c.Context(), projectID, req)
+	if err != nil {
+		return contractDocumentError(c, err, "Failed to create contract document")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Contract document created successfully",
+		"data":    document,
+	})
+}
+
+func (h *ContractHandler) ListDocuments(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	documents, err := h.contractUseCase.ListDocuments(c.Context(), projectID)
+	if err != nil {
+		return contractDocumentError(c, err, "Failed to retrieve contract documents")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Contract documents retrieved successfully",
+		"data":    documents,
+	})
+}
+
+func (h *ContractHandler) GetDocument(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	documentID, err := uuid.Parse(c.Params("documentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	document, err := h.contractUseCase.GetDocument(c.Context(), projectID, documentID)
+	if err != nil {
+		return contractDocumentError(c, err, "Failed to retrieve contract document")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Contract document retrieved successfully",
+		"data":    document,
+	})
+}
+
+func (h *ContractHandler) GetContractValue(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	value, err := h.contractUseCase.GetContractValue(c.Context(), projectID, c.Query("as_of"))
+	if err != nil {
+		return contractDocumentError(c, err, "Failed to retrieve contract value")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Contract value retrieved successfully",
+		"data":    value,
+	})
+}
+
+func contractDocumentError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "contract document not found", "no contract in force":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "document already superseded":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid document type", "title and file url are required", "invalid date format, expected YYYY-MM-DD",
+		"contract value must be greater than 0", "value adjustment is only allowed on addenda",
+		"contract value is only allowed on loi and main contract",
+		"document type cannot supersede the given document",
+		"effective date must not be before the superseded document":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CrewHandler struct {
+	crewUseCase usecase.CrewUseCase
+	guard       PermissionGuard
+}
+
+func NewCrewHandler(crewUseCase usecase.CrewUseCase, guard PermissionGuard) *CrewHandler {
+	return &CrewHandler{
+		crewUseCase: crewUseCase,
+		guard:       guard,
+	}
+}
+
+func (h *CrewHandler) CrewRoutes(app *fiber.App) {
+	crew := app.Group("/crews")
+	crew.Get("/", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.List)
+	crew.Post("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Create)
+	crew.Get("/over-allocations", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetOverAllocations)
+	crew.Put("/:id", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Update)
+	crew.Delete("/:id", h.guard(models.PermissionResourceProjects, models.PermissionActionDelete), h.Delete)
+}
+
+func (h *CrewHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreateCrewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	crew, err := h.crewUseCase.Create(c.Context(), req)
+	if err != nil {
+		switch err.Error() {
+		case "crew name is required", "crew capacity must be positive":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "crew with this name already exists":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to create crew",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Crew created successfully",
+		"data":    crew,
+	})
+}
+
+func (h *CrewHandler) List(c *fiber.Ctx) error {
+	crews, err := h.crewUseCase.List(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve crews",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Crews retrieved successfully",
+		"data":    crews,
+	})
+}
+
+func (h *CrewHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid crew ID",
+		})
+	}
+
+	var req requests.UpdateCrewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.crewUseCase.Update(c.Context(), id, req); err != nil {
+		switch err.Error() {
+		case "crew not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Crew not found",
+			})
+		case "crew name is required", "crew capacity must be positive":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "crew with this name already exists":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update crew",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Crew updated successfully",
+	})
+}
+
+func (h *CrewHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid crew ID",
+		})
+	}
+
+	if err := h.crewUseCase.Delete(c.Context(), id); err != nil {
+		if err.Error() == "crew not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Crew not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete crew",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Crew deleted successfully",
+	})
+}
+
+// GetOverAllocations accepts optional ?from= and ?to= dates (YYYY-MM-DD).
+func (h *CrewHandler) GetOverAllocations(c *fiber.Ctx) error {
+	allocations, err := h.crewUseCase.GetOverAllocations(c.Context(), c.Query("from"), c.Query("to"))
+	if err != nil {
+		switch err.Error() {
+		case "invalid date format, expected YYYY-MM-DD", "end date must not be before start date":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to retrieve crew over-allocations",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Crew over-allocations retrieved successfully",
+		"data":    allocations,
+	})
+}
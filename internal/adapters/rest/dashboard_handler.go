@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DashboardHandler struct {
+	dashboardUseCase usecase.DashboardUseCase
+	userUsecase      usecase.UserUsecase
+}
+
+func NewDashboardHandler(dashboardUseCase usecase.DashboardUseCase, userUsecase usecase.UserUsecase) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardUseCase: dashboardUseCase,
+		userUsecase:      userUsecase,
+	}
+}
+
+func (h *DashboardHandler) DashboardRoutes(app *fiber.App) {
+	dashboard := app.Group("/dashboard", RequireAuth(h.userUsecase))
+	dashboard.Get("/portfolio", h.GetPortfolio)
+}
+
+// GetPortfolio is limited to owners.
+func (h *DashboardHandler) GetPortfolio(c *fiber.Ctx) error {
+	portfolio, err := h.dashboardUseCase.GetPortfolio(c.Context(), currentUserID(c))
+	if err != nil {
+		return dashboardError(c, err, "Failed to retrieve portfolio dashboard")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Portfolio dashboard retrieved successfully",
+		"data":    portfolio,
+	})
+}
+
+func dashboardError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
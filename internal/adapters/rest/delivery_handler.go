@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DeliveryHandler struct {
+	deliveryUseCase usecase.DeliveryUseCase
+	userUsecase     usecase.UserUsecase
+}
+
+func NewDeliveryHandler(deliveryUseCase usecase.DeliveryUseCase, userUsecase usecase.UserUsecase) *DeliveryHandler {
+	return &DeliveryHandler{
+		deliveryUseCase: deliveryUseCase,
+		userUsecase:     userUsecase,
+	}
+}
+
+func (h *DeliveryHandler) DeliveryRoutes(app *fiber.App) {
+	rates := app.Group("/delivery-rates", RequireAuth(h.userUsecase))
+	rates.Get("/", h.ListRates)
+	rates.Post("/", h.CreateRate)
+	rates.Put("/:rateId", h.UpdateRate)
+	rates.Delete("/:rateId", h.DeleteRate)
+
+	transport := app.Group("/quotations/projects/:projectId/transport", RequireAuth(h.userUsecase))
+	transport.Get("/", h.GetQuotationTransport)
+	transport.Post("/", h.ApplyToQuotation)
+}
+
+func (h *DeliveryHandler) CreateRate(c *fiber.Ctx) error {
+	var req requests.DeliveryRateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	rate, err := h.deliveryUseCase.CreateRate(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return deliveryError(c, err, "Failed to create delivery rate")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Delivery rate created successfully",
+		"data":    rate,
+	})
+}
+
+func (h *DeliveryHandler) ListRates(c *fiber.Ctx) error {
+	rates, err := h.deliveryUseCase.ListRates(c.Context())
+	if err != nil {
+		return deliveryError(c, err, "Failed to retrieve delivery rates")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Delivery rates retrieved successfully",
+		"data":    rates,
+	})
+}
+
+func (h *DeliveryHandler) UpdateRate(c *fiber.Ctx) error {
+	rateID, err := uuid.Parse(c.Params("rateId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid rate ID",
+		})
+	}
+
+	var req requests.DeliveryRateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.deliveryUseCase.UpdateRate(c.Context(), rateID, currentUserID(c), req); err != nil {
+		return deliveryError(c, err, "Failed to update delivery rate")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Delivery rate updated successfully",
+	})
+}
+
+func (h *DeliveryHandler) DeleteRate(c *fiber.Ctx) error {
+	rateID, err := uuid.Parse(c.Params("rateId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid rate ID",
+		})
+	}
+
+	if err := h.deliveryUseCase.DeleteRate(c.Context(), rateID, currentUserID(c)); err != nil {
+		return deliveryError(c, err, "Failed to delete delivery rate")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Delivery rate deleted successfully",
+	})
+}
+
+func (h *DeliveryHandler) ApplyToQuotation(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	transport, err := h.deliveryUseCase.ApplyToQuotation(c.Context(), projectID)
+	if err != nil {
+		return deliveryError(c, err, "Failed to apply transport cost")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Transport cost applied successfully",
+		"data":    transport,
+	})
+}
+
+func (h *DeliveryHandler) GetQuotationTransport(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	transport, err := h.deliveryUseCase.GetQuotationTransport(c.Context(), projectID)
+	if err != nil {
+		return deliveryError(c, err, "Failed to retrieve transport cost")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Transport cost retrieved successfully",
+		"data":    transport,
+	})
+}
+
+func deliveryError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found", "delivery rate not found", "project not found", "quotation not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource", "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "delivery rate is in use by a quotation", "transport can only be applied to draft quotations":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "delivery rate name is required", "postal prefix or province is required", "postal prefix must be digits",
+		"percent of material must be between 0 and 100", "minimum per line must not be negative",
+		"invalid project address":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
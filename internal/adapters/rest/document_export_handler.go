@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/infrastructure/tradedoc"
+	"boonkosang/internal/usecase"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DocumentExportHandler struct {
+	documentExportUseCase usecase.DocumentExportUseCase
+	guard                 PermissionGuard
+}
+
+func NewDocumentExportHandler(documentExportUseCase usecase.DocumentExportUseCase, guard PermissionGuard) *DocumentExportHandler {
+	return &DocumentExportHandler{
+		documentExportUseCase: documentExportUseCase,
+		guard:                 guard,
+	}
+}
+
+// DocumentExportRoutes serve quotations and invoices as ?format=json (the
+// default, see /trade-documents/schema) or ?format=ubl for UBL 2.1 XML.
+// With ?lang=th, en or bilingual the JSON also carries printed labels.
+func (h *DocumentExportHandler) DocumentExportRoutes(app *fiber.App) {
+	app.Get("/trade-documents/schema", h.GetSchema)
+	app.Get("/quotations/projects/:projectId/document", h.guard(models.PermissionResourceQuotations, models.PermissionActionView), h.ExportQuotation)
+	app.Get("/invoices/:projectId/:invoiceId/document", h.guard(models.PermissionResourceInvoices, models.PermissionActionView), h.ExportInvoice)
+}
+
+func (h *DocumentExportHandler) GetSchema(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "application/schema+json")
+	return c.Send(tradedoc.Schema())
+}
+
+func (h *DocumentExportHandler) ExportQuotation(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	userID, err := uuid.Parse(c.Query("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	doc, err := h.documentExportUseCase.ExportQuotation(c.Context(), projectID, userID)
+	if err != nil {
+		return documentExportError(c, err, "Failed to export quotation")
+	}
+	if err := h.documentExportUseCase.Localize(c.Context(), doc, c.Query("lang"), userID); err != nil {
+		return documentExportError(c, err, "Failed to export quotation")
+	}
+
+	return sendTradeDocument(c, doc)
+}
+
+func (h *DocumentExportHandler) ExportInvoice(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	invoiceID, err := uuid.Parse(c.Params("invoiceId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid invoice ID",
+		})
+	}
+
+	userID, err := uuid.Parse(c.Query("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	doc, err := h.documentExportUseCase.ExportInvoice(c.Context(), projectID, invoiceID, userID)
+	if err != nil {
+		return documentExportError(c, err, "Failed to export invoice")
+	}
+	if err := h.documentExportUseCase.Localize(c.Context(), doc, c.Query("lang"), userID); err != nil {
+		return documentExportError(c, err, "Failed to export invoice")
+	}
+
+	return sendTradeDocument(c, doc)
+}
+
+// sendTradeDocument returns the document itself rather than the usual
+// message/data envelope so ERPs can consume it directly.
+func sendTradeDocument(c *fiber.Ctx, doc *tradedoc.Document) error {
+	switch c.Query("format", "json") {
+	case "json":
+		return c.JSON(doc)
+	case "ubl":
+		body, err := tradedoc.MarshalUBL(doc)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to render UBL document",
+			})
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s-%s.xml", doc.Type, doc.ID)))
+		return c.Send(body)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "format must be json or ubl",
+		})
+	}
+}
+
+func documentExportError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "invoice not found", "quotation not found", "approved quotation not found",
+		"client not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only approved quotations can be exported", "invoice does not belong to the specified project",
+		"invoice amount is not set", "language must be th, en or bilingual":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
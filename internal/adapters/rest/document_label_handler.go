@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DocumentLabelHandler struct {
+	labelUseCase usecase.DocumentLabelUseCase
+	guard        PermissionGuard
+}
+
+func NewDocumentLabelHandler(labelUseCase usecase.DocumentLabelUseCase, guard PermissionGuard) *DocumentLabelHandler {
+	return &DocumentLabelHandler{
+		labelUseCase: labelUseCase,
+		guard:        guard,
+	}
+}
+
+// DocumentLabelRoutes manage the wording used on generated documents,
+// per company like the rest of /company.
+func (h *DocumentLabelHandler) DocumentLabelRoutes(app *fiber.App) {
+	labels := app.Group("/company/:userId/document-labels")
+	labels.Get("/", h.guard(models.PermissionResourceSettings, models.PermissionActionView), h.GetLabels)
+	labels.Put("/:language", h.guard(models.PermissionResourceSettings, models.PermissionActionEdit), h.UpdateLabels)
+}
+
+func (h *DocumentLabelHandler) GetLabels(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	labels, err := h.labelUseCase.GetLabels(c.Context(), userID)
+	if err != nil {
+		return documentLabelError(c, err, "Failed to retrieve document labels")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Document labels retrieved successfully",
+		"data":    labels,
+	})
+}
+
+func (h *DocumentLabelHandler) UpdateLabels(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req requests.UpdateDocumentLabelsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	labels, err := h.labelUseCase.UpdateLabels(c.Context(), userID, c.Params("language"), req)
+	if err != nil {
+		return documentLabelError(c, err, "Failed to update document labels")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Document labels updated successfully",
+		"data":    labels,
+	})
+}
+
+// documentTenant reads the optional ?user_id whose company's labels a
+// document is rendered with.
+func documentTenant(c *fiber.Ctx) (uuid.UUID, bool) {
+	raw := c.Query("user_id")
+	if raw == "" {
+		return uuid.Nil, true
+	}
+	userID, err := uuid.Parse(raw)
+	return userID, err == nil
+}
+
+func documentLabelError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case err.Error() == "labels can only be set for th or en",
+		err.Error() == "at least one label is required",
+		strings.HasPrefix(err.Error(), "unknown label: "):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
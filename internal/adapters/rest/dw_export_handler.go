@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DWExportHandler struct {
+	dwExportUseCase usecase.DWExportUseCase
+	guard           PermissionGuard
+}
+
+func NewDWExportHandler(dwExportUseCase usecase.DWExportUseCase, guard PermissionGuard) *DWExportHandler {
+	return &DWExportHandler{
+		dwExportUseCase: dwExportUseCase,
+		guard:           guard,
+	}
+}
+
+func (h *DWExportHandler) DWExportRoutes(app *fiber.App) {
+	export := app.Group("/dw-export")
+	export.Get("/status", h.guard(models.PermissionResourceSettings, models.PermissionActionView), h.Status)
+	export.Post("/run", h.guard(models.PermissionResourceSettings, models.PermissionActionEdit), h.Run)
+}
+
+func (h *DWExportHandler) Status(c *fiber.Ctx) error {
+	status, err := h.dwExportUseCase.Status(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve export status",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Export status retrieved successfully",
+		"data":    status,
+	})
+}
+
+// Run triggers an export outside the schedule.
+func (h *DWExportHandler) Run(c *fiber.Ctx) error {
+	if err := h.dwExportUseCase.Run(c.Context()); err != nil {
+		if err.Error() == "export is already running" {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to run export",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Export finished successfully",
+	})
+}
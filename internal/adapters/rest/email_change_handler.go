@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmailChangeHandler struct {
+	emailChangeUsecase usecase.EmailChangeUsecase
+	userUsecase        usecase.UserUsecase
+}
+
+func NewEmailChangeHandler(emailChangeUsecase usecase.EmailChangeUsecase, userUsecase usecase.UserUsecase) *EmailChangeHandler {
+	return &EmailChangeHandler{
+		emailChangeUsecase: emailChangeUsecase,
+		userUsecase:        userUsecase,
+	}
+}
+
+func (h *EmailChangeHandler) EmailChangeRoutes(app *fiber.App) {
+	app.Post("/users/me/email", RequireAuth(h.userUsecase), h.RequestChange)
+	app.Post("/users/verify-email", h.Verify)
+}
+
+func (h *EmailChangeHandler) RequestChange(c *fiber.Ctx) error {
+	var req requests.ChangeEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	change, err := h.emailChangeUsecase.RequestChange(c.Context(), currentUserID(c), req)
+	if err != nil {
+		switch err.Error() {
+		case "current password is incorrect", "invalid email address", "new email must differ from the current one":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "email already in use":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "email delivery is not configured":
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to request email change",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Verification email sent",
+		"data":    change,
+	})
+}
+
+func (h *EmailChangeHandler) Verify(c *fiber.Ctx) error {
+	var req requests.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.emailChangeUsecase.Verify(c.Context(), req); err != nil {
+		switch err.Error() {
+		case "invalid or expired verification link":
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "email already in use":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to verify email",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Email changed successfully",
+	})
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type EstimationHandler struct {
+	estimationUseCase usecase.EstimationUseCase
+	guard             PermissionGuard
+}
+
+func NewEstimationHandler(estimationUseCase usecase.EstimationUseCase, guard PermissionGuard) *EstimationHandler {
+	return &EstimationHandler{
+		estimationUseCase: estimationUseCase,
+		guard:             guard,
+	}
+}
+
+func (h *EstimationHandler) EstimationRoutes(app *fiber.App) {
+	variance := app.Group("/projects/:projectId/estimation-variance")
+	variance.Get("/", h.GetProjectVariances)
+	variance.Post("/", h.RecordProjectVariances)
+
+	app.Get("/estimation/adjustments", h.guard(models.PermissionResourcePrices, models.PermissionActionView), h.SuggestAdjustments)
+}
+
+func (h *EstimationHandler) RecordProjectVariances(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	variances, err := h.estimationUseCase.RecordProjectVariances(c.Context(), projectID)
+	if err != nil {
+		return estimationError(c, err, "Failed to record estimation variances")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Estimation variances recorded successfully",
+		"data":    variances,
+	})
+}
+
+func (h *EstimationHandler) GetProjectVariances(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	variances, err := h.estimationUseCase.GetProjectVariances(c.Context(), projectID)
+	if err != nil {
+		return estimationError(c, err, "Failed to retrieve estimation variances")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Estimation variances retrieved successfully",
+		"data":    variances,
+	})
+}
+
+// SuggestAdjustments accepts ?kind=job|material and ?min_projects=N.
+func (h *EstimationHandler) SuggestAdjustments(c *fiber.Ctx) error {
+	adjustments, err := h.estimationUseCase.SuggestAdjustments(c.Context(), c.Query("kind"), c.QueryInt("min_projects", 1))
+	if err != nil {
+		return estimationError(c, err, "Failed to retrieve estimation adjustments")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Estimation adjustments retrieved successfully",
+		"data":    adjustments,
+	})
+}
+
+func estimationError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project is not completed":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "kind must be job or material":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ETaxHandler struct {
+	etaxUseCase usecase.ETaxUseCase
+	guard       PermissionGuard
+}
+
+func NewETaxHandler(etaxUseCase usecase.ETaxUseCase, guard PermissionGuard) *ETaxHandler {
+	return &ETaxHandler{
+		etaxUseCase: etaxUseCase,
+		guard:       guard,
+	}
+}
+
+// ETaxRoutes file an invoice's tax invoice, or with ?payment_id= the
+// receipt of one of its payments. The seller is the company of ?user_id=.
+func (h *ETaxHandler) ETaxRoutes(app *fiber.App) {
+	invoice := app.Group("/invoices/:projectId/:invoiceId/etax")
+	invoice.Get("/", h.guard(models.PermissionResourceInvoices, models.PermissionActionView), h.ListSubmissions)
+	invoice.Get("/preview", h.guard(models.PermissionResourceInvoices, models.PermissionActionView), h.Preview)
+	invoice.Post("/", h.guard(models.PermissionResourceInvoices, models.PermissionActionEdit), h.Submit)
+
+	submission := app.Group("/etax-submissions")
+	submission.Get("/:submissionId", h.guard(models.PermissionResourceInvoices, models.PermissionActionView), h.GetSubmission)
+	submission.Get("/:submissionId/xml", h.guard(models.PermissionResourceInvoices, models.PermissionActionView), h.GetSubmissionXML)
+	submission.Post("/:submissionId/refresh", h.guard(models.PermissionResourceInvoices, models.PermissionActionEdit), h.RefreshStatus)
+}
+
+type etaxTarget struct {
+	projectID uuid.UUID
+	invoiceID uuid.UUID
+	paymentID *uuid.UUID
+	userID    uuid.UUID
+}
+
+// parseETaxTarget reads the IDs shared by preview and submit. It returns
+// an error message for the first invalid one.
+func parseETaxTarget(c *fiber.Ctx) (etaxTarget, string) {
+	var target etaxTarget
+	var err error
+
+	if target.projectID, err = uuid.Parse(c.Params("projectId")); err != nil {
+		return target, "Invalid project ID"
+	}
+	if target.invoiceID, err = uuid.Parse(c.Params("invoiceId")); err != nil {
+		return target, "Invalid invoice ID"
+	}
+	if raw := c.Query("payment_id"); raw != "" {
+		paymentID, err := uuid.Parse(raw)
+		if err != nil {
+			return target, "Invalid payment ID"
+		}
+		target.paymentID = &paymentID
+	}
+	if target.userID, err = uuid.Parse(c.Query("user_id")); err != nil {
+		return target, "Invalid user ID"
+	}
+
+	return target, ""
+}
+
+func (h *ETaxHandler) Preview(c *fiber.Ctx) error {
+	target, invalid := parseETaxTarget(c)
+	if invalid != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": invalid,
+		})
+	}
+
+	document, err := h.etaxUseCase.Preview(c.Context(), target.projectID, target.invoiceID, target.paymentID, target.userID)
+	if err != nil {
+		return etaxError(c, err, "Failed to generate e-tax document")
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+	return c.Send(document)
+}
+
+func (h *ETaxHandler) Submit(c *fiber.Ctx) error {
+	target, invalid := parseETaxTarget(c)
+	if invalid != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": invalid,
+		})
+	}
+
+	submission, err := h.etaxUseCase.Submit(c.Context(), target.projectID, target.invoiceID, target.paymentID, target.userID)
+	if err != nil {
+		return etaxError(c, err, "Failed to submit e-tax document")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "E-tax document processed successfully",
+		"data":    submission,
+	})
+}
+
+func (h *ETaxHandler) ListSubmissions(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	invoiceID, err := uuid.Parse(c.Params("invoiceId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid invoice ID",
+		})
+	}
+
+	submissions, err := h.etaxUseCase.ListSubmissions(c.Context(), projectID, invoiceID)
+	if err != nil {
+		return etaxError(c, err, "Failed to retrieve e-tax submissions")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "E-tax submissions retrieved successfully",
+		"data":    submissions,
+	})
+}
+
+func (h *ETaxHandler) GetSubmission(c *fiber.Ctx) error {
+	submissionID, err := uuid.Parse(c.Params("submissionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid submission ID",
+		})
+	}
+
+	submission, err := h.etaxUseCase.GetSubmission(c.Context(), submissionID)
+	if err != nil {
+		return etaxError(c, err, "Failed to retrieve e-tax submission")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "E-tax submission retrieved successfully",
+		"data":    submission,
+	})
+}
+
+func (h *ETaxHandler) GetSubmissionXML(c *fiber.Ctx) error {
+	submissionID, err := uuid.Parse(c.Params("submissionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid submission ID",
+		})
+	}
+
+	document, err := h.etaxUseCase.GetSubmissionXML(c.Context(), submissionID)
+	if err != nil {
+		return etaxError(c, err, "Failed to retrieve e-tax document")
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+	return c.Send(document)
+}
+
+func (h *ETaxHandler) RefreshStatus(c *fiber.Ctx) error {
+	submissionID, err := uuid.Parse(c.Params("submissionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid submission ID",
+		})
+	}
+
+	submission, err := h.etaxUseCase.RefreshStatus(c.Context(), submissionID)
+	if err != nil {
+		return etaxError(c, err, "Failed to refresh e-tax submission")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "E-tax submission refreshed successfully",
+		"data":    submission,
+	})
+}
+
+func etaxError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "invoice not found", "payment not found", "receipt not found",
+		"client not found", "e-tax submission not found", "submission has no signed document":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "document has already been submitted", "only submitted documents can be refreshed":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invoice does not belong to the specified project", "invoice amount is not set",
+		"company tax ID is required for e-tax documents":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "e-tax signing service is not configured", "e-tax provider is not configured":
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExchangeRateHandler struct {
+	exchangeRateUseCase usecase.ExchangeRateUseCase
+	guard               PermissionGuard
+}
+
+func NewExchangeRateHandler(exchangeRateUseCase usecase.ExchangeRateUseCase, guard PermissionGuard) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		exchangeRateUseCase: exchangeRateUseCase,
+		guard:               guard,
+	}
+}
+
+func (h *ExchangeRateHandler) ExchangeRateRoutes(app *fiber.App) {
+	rate := app.Group("/exchange-rates")
+
+	rate.Get("/", h.guard(models.PermissionResourcePrices, models.PermissionActionView), h.List)
+	rate.Get("/convert", h.guard(models.PermissionResourcePrices, models.PermissionActionView), h.Convert)
+	rate.Post("/refresh", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.Refresh)
+	rate.Get("/:currency", h.guard(models.PermissionResourcePrices, models.PermissionActionView), h.GetByCurrency)
+	rate.Put("/:currency", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.SetManualRate)
+	rate.Delete("/:currency/override", h.guard(models.PermissionResourcePrices, models.PermissionActionDelete), h.ClearManualRate)
+}
+
+func (h *ExchangeRateHandler) List(c *fiber.Ctx) error {
+	rates, err := h.exchangeRateUseCase.List(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve exchange rates",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Exchange rates retrieved successfully",
+		"data":    rates,
+	})
+}
+
+func (h *ExchangeRateHandler) GetByCurrency(c *fiber.Ctx) error {
+	rate, err := h.exchangeRateUseCase.GetByCurrency(c.Context(), c.Params("currency"))
+	if err != nil {
+		if err.Error() == "exchange rate not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Exchange rate not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve exchange rate",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Exchange rate retrieved successfully",
+		"data":    rate,
+	})
+}
+
+func (h *ExchangeRateHandler) Refresh(c *fiber.Ctx) error {
+	if err := h.exchangeRateUseCase.RefreshRates(c.Context()); err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Exchange rates refreshed successfully",
+	})
+}
+
+func (h *ExchangeRateHandler) SetManualRate(c *fiber.Ctx) error {
+	var req requests.SetExchangeRateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.exchangeRateUseCase.SetManualRate(c.Context(), c.Params("currency"), req); err != nil {
+		switch err.Error() {
+		case "invalid currency code",
+			"cannot set a rate for the base currency",
+			"rate must be greater than 0",
+			"invalid rate date format, expected YYYY-MM-DD":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to set exchange rate",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Exchange rate override saved successfully",
+	})
+}
+
+func (h *ExchangeRateHandler) ClearManualRate(c *fiber.Ctx) error {
+	err := h.exchangeRateUseCase.ClearManualRate(c.Context(), c.Params("currency"), c.Query("date"))
+	if err != nil {
+		switch err.Error() {
+		case "exchange rate override not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Exchange rate override not found",
+			})
+		case "invalid currency code", "invalid rate date format, expected YYYY-MM-DD":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to clear exchange rate override",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Exchange rate override cleared successfully",
+	})
+}
+
+func (h *ExchangeRateHandler) Convert(c *fiber.Ctx) error {
+	amount, err := strconv.ParseFloat(c.Query("amount"), 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid amount",
+		})
+	}
+
+	result, err := h.exchangeRateUseCase.Convert(c.Context(), amount, c.Query("from", "THB"), c.Query("to", "THB"))
+	if err != nil {
+		switch err.Error() {
+		case "invalid currency code":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "exchange rate not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Exchange rate not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to convert amount",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Amount converted successfully",
+		"data":    result,
+	})
+}
@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fieldSet is the tree of fields a client asked for. A nil subtree keeps
+// the whole value.
+type fieldSet map[string]fieldSet
+
+// SelectFields trims successful GET responses under the given path
+// prefixes to the fields listed in ?fields=, so the mobile app can fetch
+// only what it shows. Fields are comma-separated JSON keys of the
+// envelope's data, with dots for nested keys; arrays are looked through,
+// so "projects.name,total" keeps each project's name and the total of a
+// project list. Unknown fields are ignored, and the message is always
+// kept.
+func SelectFields(prefixes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodGet || !hasPathPrefix(c.Path(), prefixes) {
+			return c.Next()
+		}
+		fields := parseFieldSet(c.Query("fields"))
+		if fields == nil {
+			return c.Next()
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		resp := c.Response()
+		if resp.StatusCode() != fiber.StatusOK ||
+			!strings.HasPrefix(string(resp.Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return nil
+		}
+
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(resp.Body(), &envelope); err != nil {
+			return nil
+		}
+		raw, ok := envelope["data"]
+		if !ok {
+			return nil
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var data interface{}
+		if err := decoder.Decode(&data); err != nil {
+			return nil
+		}
+
+		selected, err := json.Marshal(selectFields(data, fields))
+		if err != nil {
+			return nil
+		}
+		envelope["data"] = selected
+		body, err := json.Marshal(envelope)
+		if err != nil {
+			return nil
+		}
+		resp.SetBody(body)
+		return nil
+	}
+}
+
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFieldSet returns nil when no fields are given. Asking for a field
+// and one of its subfields keeps the whole field.
+func parseFieldSet(query string) fieldSet {
+	var set fieldSet
+	for _, field := range strings.Split(query, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if set == nil {
+			set = fieldSet{}
+		}
+
+		node := set
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			child, seen := node[part]
+			if seen && child == nil {
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if child == nil {
+				child = fieldSet{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return set
+}
+
+func selectFields(value interface{}, fields fieldSet) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		selected := make(map[string]interface{}, len(fields))
+		for key, sub := range fields {
+			field, ok := v[key]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				selected[key] = field
+			} else {
+				selected[key] = selectFields(field, sub)
+			}
+		}
+		return selected
+	case []interface{}:
+		selected := make([]interface{}, len(v))
+		for i, item := range v {
+			selected[i] = selectFields(item, fields)
+		}
+		return selected
+	default:
+		return value
+	}
+}
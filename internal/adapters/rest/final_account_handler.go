@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type FinalAccountHandler struct {
+	finalAccountUseCase usecase.FinalAccountUseCase
+	guard               PermissionGuard
+}
+
+func NewFinalAccountHandler(finalAccountUseCase usecase.FinalAccountUseCase, guard PermissionGuard) *FinalAccountHandler {
+	return &FinalAccountHandler{
+		finalAccountUseCase: finalAccountUseCase,
+		guard:               guard,
+	}
+}
+
+func (h *FinalAccountHandler) FinalAccountRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceInvoices, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceInvoices, models.PermissionActionEdit)
+
+	finalAccount := app.Group("/projects/:projectId/final-account")
+	finalAccount.Get("/", view, h.Get)
+	finalAccount.Post("/", edit, h.Create)
+	finalAccount.Put("/", edit, h.Update)
+	finalAccount.Post("/agree", edit, h.Agree)
+}
+
+func (h *FinalAccountHandler) Create(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.FinalAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	account, err := h.finalAccountUseCase.Create(c.Context(), projectID, req)
+	if err != nil {
+		return finalAccountError(c, err, "Failed to create final account")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Final account created successfully",
+		"data":    account,
+	})
+}
+
+func (h *FinalAccountHandler) Get(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	account, err := h.finalAccountUseCase.Get(c.Context(), projectID)
+	if err != nil {
+		return finalAccountError(c, err, "Failed to retrieve final account")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Final account retrieved successfully",
+		"data":    account,
+	})
+}
+
+func (h *FinalAccountHandler) Update(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.FinalAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	account, err := h.finalAccountUseCase.Update(c.Context(), projectID, req)
+	if err != nil {
+		return finalAccountError(c, err, "Failed to update final account")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Final account updated successfully",
+		"data":    account,
+	})
+}
+
+func (h *FinalAccountHandler) Agree(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.AgreeFinalAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	account, err := h.finalAccountUseCase.Agree(c.Context(), projectID, req)
+	if err != nil {
+		return finalAccountError(c, err, "Failed to agree final account")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Final account agreed successfully",
+		"data":    account,
+	})
+}
+
+func finalAccountError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "final account not found", "project not found", "no contract in force":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "retention percent must be between 0 and 100", "back-charge description is required",
+		"back-charge amount must be greater than 0", "agreeing name is required":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project must be completed before the final account", "final account already exists for this project",
+		"final account has already been agreed":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type FixedAssetHandler struct {
+	assetUseCase usecase.FixedAssetUseCase
+	guard        PermissionGuard
+}
+
+func NewFixedAssetHandler(assetUseCase usecase.FixedAssetUseCase, guard PermissionGuard) *FixedAssetHandler {
+	return &FixedAssetHandler{
+		assetUseCase: assetUseCase,
+		guard:        guard,
+	}
+}
+
+func (h *FixedAssetHandler) FixedAssetRoutes(app *fiber.App) {
+	assets := app.Group("/assets")
+	assets.Get("/", h.guard(models.PermissionResourceAssets, models.PermissionActionView), h.List)
+	assets.Post("/", h.guard(models.PermissionResourceAssets, models.PermissionActionEdit), h.Create)
+	assets.Get("/reports/book-value", h.guard(models.PermissionResourceAssets, models.PermissionActionView), h.GetBookValueReport)
+	assets.Get("/reports/depreciation-allocation", h.guard(models.PermissionResourceAssets, models.PermissionActionView), h.GetDepreciationAllocation)
+	assets.Post("/depreciation/run", h.guard(models.PermissionResourceAssets, models.PermissionActionEdit), h.RunDepreciation)
+	assets.Get("/:id", h.guard(models.PermissionResourceAssets, models.PermissionActionView), h.GetByID)
+	assets.Put("/:id", h.guard(models.PermissionResourceAssets, models.PermissionActionEdit), h.Update)
+	assets.Post("/:id/dispose", h.guard(models.PermissionResourceAssets, models.PermissionActionEdit), h.Dispose)
+	assets.Get("/:id/depreciation", h.guard(models.PermissionResourceAssets, models.PermissionActionView), h.GetDepreciationSchedule)
+}
+
+func (h *FixedAssetHandler) List(c *fiber.Ctx) error {
+	assets, err := h.assetUseCase.List(c.Context(), c.QueryBool("include_disposed"))
+	if err != nil {
+		return fixedAssetError(c, err, "Failed to retrieve assets")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Assets retrieved successfully",
+		"data":    assets,
+	})
+}
+
+func (h *FixedAssetHandler) Create(c *fiber.Ctx) error {
+	var req requests.FixedAssetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	asset, err := h.assetUseCase.Create(c.Context(), req)
+	if err != nil {
+		return fixedAssetError(c, err, "Failed to create asset")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Asset created successfully",
+		"data":    asset,
+	})
+}
+
+func (h *FixedAssetHandler) GetByID(c *fiber.Ctx) error {
+	assetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid asset ID",
+		})
+	}
+
+	asset, err := h.assetUseCase.GetByID(c.Context(), assetID)
+	if err != nil {
+		return fixedAssetError(c, err, "Failed to retrieve asset")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Asset retrieved successfully",
+		"data":    asset,
+	})
+}
+
+func (h *FixedAssetHandler) Update(c *fiber.Ctx) error {
+	assetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid asset ID",
+		})
+	}
+
+	var req requests.FixedAssetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	asset, err := h.assetUseCase.Update(c.Context(), assetID, req)
+	if err != nil {
+		return fixedAssetError(c, err, "Failed to update asset")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Asset updated successfully",
+		"data":    asset,
+	})
+}
+
+func (h *FixedAssetHandler) Dispose(c *fiber.Ctx) error {
+	assetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid asset ID",
+		})
+	}
+
+	var req requests.DisposeFixedAssetRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	asset, err := h.assetUseCase.Dispose(c.Context(), assetID, req)
+	if err != nil {
+		return fixedAssetError(c, err, "Failed to dispose asset")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Asset disposed successfully",
+		"data":    asset,
+	})
+}
+
+func (h *FixedAssetHandler) GetBookValueReport(c *fiber.Ctx) error {
+	report, err := h.assetUseCase.GetBookValueReport(c.Context(), c.Query("as_of"))
+	if err != nil {
+		return fixedAssetError(c, err, "Failed to retrieve book value report")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Book value report retrieved successfully",
+		"data":    report,
+	})
+}
+
+func (h *FixedAssetHandler) RunDepreciation(c *fiber.Ctx) error {
+	if err := h.assetUseCase.RunDepreciation(c.Context()); err != nil {
+		return fixedAssetError(c, err, "Failed to post depreciation")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Depreciation posted successfully",
+	})
+}
+
+func (h *FixedAssetHandler) GetDepreciationSchedule(c *fiber.Ctx) error {
+	assetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid asset ID",
+		})
+	}
+
+	schedule, err := h.assetUseCase.GetDepreciationSchedule(c.Context(), assetID)
+	if err != nil {
+		return fixedAssetError(c, err, "Failed to retrieve depreciation schedule")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Depreciation schedule retrieved successfully",
+		"data":    schedule,
+	})
+}
+
+func (h *FixedAssetHandler) GetDepreciationAllocation(c *fiber.Ctx) error {
+	allocation, err := h.assetUseCase.GetDepreciationAllocation(c.Context(), c.Query("period"))
+	if err != nil {
+		return fixedAssetError(c, err, "Failed to retrieve depreciation allocation")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Depreciation allocation retrieved successfully",
+		"data":    allocation,
+	})
+}
+
+func fixedAssetError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "asset not found", "material not found", "project not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "asset already disposed", "asset has depreciation postings":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "asset name is required", "invalid date format, expected YYYY-MM-DD",
+		"purchase cost must be greater than 0", "salvage value must be at least 0 and less than purchase cost",
+		"useful life must be greater than 0 months", "material is not classified as an asset",
+		"disposal date must not be before purchase date", "invalid period format, expected YYYY-MM":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ForecastHandler struct {
+	forecastUsecase usecase.ForecastUsecase
+	guard           PermissionGuard
+}
+
+func NewForecastHandler(forecastUsecase usecase.ForecastUsecase, guard PermissionGuard) *ForecastHandler {
+	return &ForecastHandler{
+		forecastUsecase: forecastUsecase,
+		guard:           guard,
+	}
+}
+
+func (h *ForecastHandler) ForecastRoutes(app *fiber.App) {
+	app.Get("/projects/:id/forecast", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetProjectForecast)
+	app.Get("/forecasts/alerts", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetAlerts)
+}
+
+func (h *ForecastHandler) GetProjectForecast(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	forecast, err := h.forecastUsecase.GetProjectForecast(c.Context(), projectID)
+	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err.Error() == "project not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Project not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get project forecast",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project forecast retrieved successfully",
+		"data":    forecast,
+	})
+}
+
+// GetAlerts takes an optional ?threshold= percentage over the contract
+// value, defaulting to the configured one.
+func (h *ForecastHandler) GetAlerts(c *fiber.Ctx) error {
+	alerts, err := h.forecastUsecase.GetAlerts(c.Context(), c.QueryFloat("threshold", -1))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get forecast alerts",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Forecast alerts retrieved successfully",
+		"data":    alerts,
+	})
+}
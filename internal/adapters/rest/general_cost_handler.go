@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/usecase"
 
@@ -10,11 +11,13 @@ import (
 
 type GeneralCostHandler struct {
 	generalCostUseCase usecase.GeneralCostUseCase
+	guard              PermissionGuard
 }
 
-func NewGeneralCostHandler(generalCostUseCase usecase.GeneralCostUseCase) *GeneralCostHandler {
+func NewGeneralCostHandler(generalCostUseCase usecase.GeneralCostUseCase, guard PermissionGuard) *GeneralCostHandler {
 	return &GeneralCostHandler{
 		generalCostUseCase: generalCostUseCase,
+		guard:              guard,
 	}
 }
 
@@ -24,8 +27,8 @@ func (h *GeneralCostHandler) GeneralCostRoutes(app *fiber.App) {
 	generalCost.Get("/project/:projectId", h.GetByProjectID)
 	generalCost.Get("/types", h.GetTypes)
 	generalCost.Get("/:id", h.GetByID)
-	generalCost.Put("/:id/actual-cost", h.UpdateActualCost)
-	generalCost.Put("/:id", h.Update)
+	generalCost.Put("/:id/actual-cost", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.UpdateActualCost)
+	generalCost.Put("/:id", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.Update)
 }
 
 func (h *GeneralCostHandler) GetByProjectID(c *fiber.Ctx) error {
@@ -170,6 +173,10 @@ func (h *GeneralCostHandler) UpdateActualCost(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": "BOQ must be approved to update actual cost",
 			})
+		case "accounting period is locked":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
 		case "Quotation must be approved to update actual cost":
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": "Quotation must be approved to update actual cost",
@@ -0,0 +1,216 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type HandoverHandler struct {
+	handoverUseCase usecase.HandoverUseCase
+	guard           PermissionGuard
+}
+
+func NewHandoverHandler(handoverUseCase usecase.HandoverUseCase, guard PermissionGuard) *HandoverHandler {
+	return &HandoverHandler{
+		handoverUseCase: handoverUseCase,
+		guard:           guard,
+	}
+}
+
+func (h *HandoverHandler) HandoverRoutes(app *fiber.App) {
+	handover := app.Group("/projects/:projectId/handover")
+	handover.Get("/", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.Get)
+	handover.Post("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Create)
+	handover.Put("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Update)
+	handover.Get("/certificate", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetCertificate)
+
+	// Reached by the client through the signed link.
+	acknowledgement := app.Group("/handover-acknowledgements")
+	acknowledgement.Get("/:token", h.GetByToken)
+	acknowledgement.Get("/:token/certificate", h.GetCertificateByToken)
+	acknowledgement.Get("/:token/attachments/:attachmentId", h.GetAttachmentByToken)
+	acknowledgement.Post("/:token", h.Acknowledge)
+}
+
+func (h *HandoverHandler) Create(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.HandoverRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	handover, err := h.handoverUseCase.Create(c.Context(), projectID, req)
+	if err != nil {
+		return handoverError(c, err, "Failed to create handover")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Handover created successfully",
+		"data":    handover,
+	})
+}
+
+func (h *HandoverHandler) Get(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	handover, err := h.handoverUseCase.Get(c.Context(), projectID)
+	if err != nil {
+		return handoverError(c, err, "Failed to retrieve handover")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Handover retrieved successfully",
+		"data":    handover,
+	})
+}
+
+func (h *HandoverHandler) Update(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.HandoverRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	handover, err := h.handoverUseCase.Update(c.Context(), projectID, req)
+	if err != nil {
+		return handoverError(c, err, "Failed to update handover")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Handover updated successfully",
+		"data":    handover,
+	})
+}
+
+func (h *HandoverHandler) GetCertificate(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	certificate, err := h.handoverUseCase.GetCertificate(c.Context(), projectID)
+	if err != nil {
+		return handoverError(c, err, "Failed to generate handover certificate")
+	}
+
+	return sendCertificate(c, certificate, projectID.String())
+}
+
+func (h *HandoverHandler) GetByToken(c *fiber.Ctx) error {
+	handover, err := h.handoverUseCase.GetByToken(c.Context(), c.Params("token"))
+	if err != nil {
+		return handoverError(c, err, "Failed to retrieve handover")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Handover retrieved successfully",
+		"data":    handover,
+	})
+}
+
+func (h *HandoverHandler) GetCertificateByToken(c *fiber.Ctx) error {
+	certificate, err := h.handoverUseCase.GetCertificateByToken(c.Context(), c.Params("token"))
+	if err != nil {
+		return handoverError(c, err, "Failed to generate handover certificate")
+	}
+
+	return sendCertificate(c, certificate, "certificate")
+}
+
+func (h *HandoverHandler) GetAttachmentByToken(c *fiber.Ctx) error {
+	attachmentID, err := uuid.Parse(c.Params("attachmentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid attachment ID",
+		})
+	}
+
+	content, contentType, err := h.handoverUseCase.GetAttachmentByToken(c.Context(), c.Params("token"), attachmentID)
+	if err != nil {
+		return handoverError(c, err, "Failed to retrieve attachment")
+	}
+
+	if contentType != "" {
+		c.Set(fiber.HeaderContentType, contentType)
+	}
+	return c.Send(content)
+}
+
+func (h *HandoverHandler) Acknowledge(c *fiber.Ctx) error {
+	var req requests.AcknowledgeHandoverRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.handoverUseCase.Acknowledge(c.Context(), c.Params("token"), req, c.IP()); err != nil {
+		return handoverError(c, err, "Failed to acknowledge handover")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Handover acknowledged successfully",
+	})
+}
+
+func sendCertificate(c *fiber.Ctx, certificate []byte, name string) error {
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="handover-%s.pdf"`, name))
+	return c.Send(certificate)
+}
+
+func handoverError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "handover not found", "attachment not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid or expired link":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "handover already exists for this project", "handover has already been acknowledged",
+		"project must be completed before handover":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid date format, expected YYYY-MM-DD", "completion date must not be in the future",
+		"warranty months must not be negative", "defect description is required",
+		"attachment name and file_url are required", "acknowledging name is required":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
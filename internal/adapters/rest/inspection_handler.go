@@ -0,0 +1,247 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type InspectionHandler struct {
+	inspectionUseCase usecase.InspectionUseCase
+	guard             PermissionGuard
+}
+
+func NewInspectionHandler(inspectionUseCase usecase.InspectionUseCase, guard PermissionGuard) *InspectionHandler {
+	return &InspectionHandler{
+		inspectionUseCase: inspectionUseCase,
+		guard:             guard,
+	}
+}
+
+func (h *InspectionHandler) InspectionRoutes(app *fiber.App) {
+	templates := app.Group("/inspection-templates")
+	templates.Get("/", h.guard(models.PermissionResourceBOQs, models.PermissionActionView), h.ListTemplates)
+	templates.Post("/", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.CreateTemplate)
+	templates.Put("/:id", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.UpdateTemplate)
+	templates.Delete("/:id", h.guard(models.PermissionResourceBOQs, models.PermissionActionDelete), h.DeleteTemplate)
+
+	app.Get("/boqs/:id/jobs/:jobId/inspections", h.guard(models.PermissionResourceBOQs, models.PermissionActionView), h.ListByBOQJob)
+	app.Post("/boqs/:id/jobs/:jobId/inspections", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.Create)
+
+	inspections := app.Group("/inspections")
+	inspections.Get("/:id", h.guard(models.PermissionResourceBOQs, models.PermissionActionView), h.GetByID)
+	inspections.Put("/:id/results", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.Record)
+}
+
+func (h *InspectionHandler) CreateTemplate(c *fiber.Ctx) error {
+	var req requests.CreateInspectionTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	template, err := h.inspectionUseCase.CreateTemplate(c.Context(), req)
+	if err != nil {
+		return inspectionError(c, err, "Failed to create inspection template")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Inspection template created successfully",
+		"data":    template,
+	})
+}
+
+// ListTemplates accepts an optional ?job_id= filter.
+func (h *InspectionHandler) ListTemplates(c *fiber.Ctx) error {
+	var jobID *uuid.UUID
+	if raw := c.Query("job_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid job ID",
+			})
+		}
+		jobID = &id
+	}
+
+	templates, err := h.inspectionUseCase.ListTemplates(c.Context(), jobID)
+	if err != nil {
+		return inspectionError(c, err, "Failed to retrieve inspection templates")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Inspection templates retrieved successfully",
+		"data":    templates,
+	})
+}
+
+func (h *InspectionHandler) UpdateTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid template ID",
+		})
+	}
+
+	var req requests.UpdateInspectionTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.inspectionUseCase.UpdateTemplate(c.Context(), id, req); err != nil {
+		return inspectionError(c, err, "Failed to update inspection template")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Inspection template updated successfully",
+	})
+}
+
+func (h *InspectionHandler) DeleteTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid template ID",
+		})
+	}
+
+	if err := h.inspectionUseCase.DeleteTemplate(c.Context(), id); err != nil {
+		return inspectionError(c, err, "Failed to delete inspection template")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Inspection template deleted successfully",
+	})
+}
+
+func (h *InspectionHandler) Create(c *fiber.Ctx) error {
+	boqID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid BOQ ID",
+		})
+	}
+
+	jobID, err := uuid.Parse(c.Params("jobId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid job ID",
+		})
+	}
+
+	var req requests.CreateInspectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	inspection, err := h.inspectionUseCase.Create(c.Context(), boqID, jobID, req)
+	if err != nil {
+		return inspectionError(c, err, "Failed to create inspection")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Inspection created successfully",
+		"data":    inspection,
+	})
+}
+
+func (h *InspectionHandler) ListByBOQJob(c *fiber.Ctx) error {
+	boqID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid BOQ ID",
+		})
+	}
+
+	jobID, err := uuid.Parse(c.Params("jobId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid job ID",
+		})
+	}
+
+	inspections, err := h.inspectionUseCase.ListByBOQJob(c.Context(), boqID, jobID)
+	if err != nil {
+		return inspectionError(c, err, "Failed to retrieve inspections")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Inspections retrieved successfully",
+		"data":    inspections,
+	})
+}
+
+func (h *InspectionHandler) GetByID(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid inspection ID",
+		})
+	}
+
+	inspection, err := h.inspectionUseCase.GetByID(c.Context(), id)
+	if err != nil {
+		return inspectionError(c, err, "Failed to retrieve inspection")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Inspection retrieved successfully",
+		"data":    inspection,
+	})
+}
+
+func (h *InspectionHandler) Record(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid inspection ID",
+		})
+	}
+
+	var req requests.RecordInspectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	inspection, err := h.inspectionUseCase.Record(c.Context(), id, req)
+	if err != nil {
+		return inspectionError(c, err, "Failed to record inspection")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Inspection recorded successfully",
+		"data":    inspection,
+	})
+}
+
+func inspectionError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "job not found", "inspection template not found", "inspection not found", "job not found in this BOQ":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "template name is required", "template must have at least one item", "inspector name is required",
+		"inspection template is not for this job", "item does not belong to this inspection":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "inspection template is in use":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type InventoryHandler struct {
+	inventoryUseCase usecase.InventoryUseCase
+	guard            PermissionGuard
+}
+
+func NewInventoryHandler(inventoryUseCase usecase.InventoryUseCase, guard PermissionGuard) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryUseCase: inventoryUseCase,
+		guard:            guard,
+	}
+}
+
+func (h *InventoryHandler) InventoryRoutes(app *fiber.App) {
+	warehouses := app.Group("/warehouses")
+	warehouses.Get("/", h.guard(models.PermissionResourceMaterials, models.PermissionActionView), h.ListWarehouses)
+	warehouses.Post("/", h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit), h.CreateWarehouse)
+	warehouses.Post("/transfers", h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit), h.Transfer)
+	warehouses.Get("/stock/materials/:materialId", h.guard(models.PermissionResourceMaterials, models.PermissionActionView), h.GetMaterialStock)
+	warehouses.Get("/:id/stock", h.guard(models.PermissionResourceMaterials, models.PermissionActionView), h.GetWarehouseStock)
+	warehouses.Get("/:id/movements", h.guard(models.PermissionResourceMaterials, models.PermissionActionView), h.ListMovements)
+	warehouses.Post("/:id/receipts", h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit), h.ReceiveStock)
+	warehouses.Post("/:id/issues", h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit), h.IssueStock)
+	warehouses.Post("/:id/close", h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit), h.CloseSiteStore)
+}
+
+func (h *InventoryHandler) ListWarehouses(c *fiber.Ctx) error {
+	warehouses, err := h.inventoryUseCase.ListWarehouses(c.Context(), c.QueryBool("include_closed"))
+	if err != nil {
+		return inventoryError(c, err, "Failed to retrieve warehouses")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Warehouses retrieved successfully",
+		"data":    warehouses,
+	})
+}
+
+func (h *InventoryHandler) CreateWarehouse(c *fiber.Ctx) error {
+	var req requests.CreateWarehouseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	warehouse, err := h.inventoryUseCase.CreateWarehouse(c.Context(), req)
+	if err != nil {
+		return inventoryError(c, err, "Failed to create warehouse")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Warehouse created successfully",
+		"data":    warehouse,
+	})
+}
+
+func (h *InventoryHandler) GetWarehouseStock(c *fiber.Ctx) error {
+	warehouseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid warehouse ID",
+		})
+	}
+
+	stock, err := h.inventoryUseCase.GetWarehouseStock(c.Context(), warehouseID)
+	if err != nil {
+		return inventoryError(c, err, "Failed to retrieve warehouse stock")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Warehouse stock retrieved successfully",
+		"data":    stock,
+	})
+}
+
+func (h *InventoryHandler) GetMaterialStock(c *fiber.Ctx) error {
+	stock, err := h.inventoryUseCase.GetMaterialStock(c.Context(), c.Params("materialId"))
+	if err != nil {
+		return inventoryError(c, err, "Failed to retrieve material stock")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Material stock retrieved successfully",
+		"data":    stock,
+	})
+}
+
+func (h *InventoryHandler) ListMovements(c *fiber.Ctx) error {
+	warehouseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid warehouse ID",
+		})
+	}
+
+	movements, err := h.inventoryUseCase.ListMovements(c.Context(), warehouseID)
+	if err != nil {
+		return inventoryError(c, err, "Failed to retrieve stock movements")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Stock movements retrieved successfully",
+		"data":    movements,
+	})
+}
+
+func (h *InventoryHandler) ReceiveStock(c *fiber.Ctx) error {
+	warehouseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid warehouse ID",
+		})
+	}
+
+	var req requests.StockMovementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	movements, err := h.inventoryUseCase.ReceiveStock(c.Context(), warehouseID, req)
+	if err != nil {
+		return inventoryError(c, err, "Failed to receive stock")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Stock received successfully",
+		"data":    movements,
+	})
+}
+
+func (h *InventoryHandler) IssueStock(c *fiber.Ctx) error {
+	warehouseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid warehouse ID",
+		})
+	}
+
+	var req requests.StockMovementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	movements, err := h.inventoryUseCase.IssueStock(c.Context(), warehouseID, req)
+	if err != nil {
+		return inventoryError(c, err, "Failed to issue stock")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Stock issued successfully",
+		"data":    movements,
+	})
+}
+
+func (h *InventoryHandler) Transfer(c *fiber.Ctx) error {
+	var req requests.StockTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	movements, err := h.inventoryUseCase.Transfer(c.Context(), req)
+	if err != nil {
+		return inventoryError(c, err, "Failed to transfer stock")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Stock transferred successfully",
+		"data":    movements,
+	})
+}
+
+func (h *InventoryHandler) CloseSiteStore(c *fiber.Ctx) error {
+	warehouseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid warehouse ID",
+		})
+	}
+
+	var req requests.CloseWarehouseRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	result, err := h.inventoryUseCase.CloseSiteStore(c.Context(), warehouseID, req)
+	if err != nil {
+		return inventoryError(c, err, "Failed to close site store")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Site store closed successfully",
+		"data":    result,
+	})
+}
+
+func inventoryError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "warehouse not found", "material not found", "project not found", "no active main warehouse":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "warehouse is closed", "insufficient stock":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "warehouse name is required", "invalid warehouse kind", "site stores require a project",
+		"only site stores belong to a project", "at least one stock line is required",
+		"quantity must be greater than 0", "cannot transfer stock to the same warehouse",
+		"only site stores can be closed", "site store stock must be returned to a main warehouse",
+		"target warehouse is required when there are several main warehouses":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
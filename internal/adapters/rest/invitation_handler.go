@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type InvitationHandler struct {
+	invitationUsecase usecase.InvitationUsecase
+	userUsecase       usecase.UserUsecase
+}
+
+func NewInvitationHandler(invitationUsecase usecase.InvitationUsecase, userUsecase usecase.UserUsecase) *InvitationHandler {
+	return &InvitationHandler{
+		invitationUsecase: invitationUsecase,
+		userUsecase:       userUsecase,
+	}
+}
+
+func (h *InvitationHandler) InvitationRoutes(app *fiber.App) {
+	app.Post("/users/invite", RequireAuth(h.userUsecase), h.Invite)
+	app.Post("/users/accept-invite", h.Accept)
+}
+
+func (h *InvitationHandler) Invite(c *fiber.Ctx) error {
+	var req requests.InviteUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	invitation, err := h.invitationUsecase.Invite(c.Context(), currentUserID(c), req)
+	if err != nil {
+		switch err.Error() {
+		case "only owners can access this resource":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "username, first name and last name are required", "invalid email address", "invalid role":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "username already exists":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to invite user",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "User invited successfully",
+		"data":    invitation,
+	})
+}
+
+func (h *InvitationHandler) Accept(c *fiber.Ctx) error {
+	var req requests.AcceptInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.invitationUsecase.Accept(c.Context(), req); err != nil {
+		switch {
+		case isPasswordPolicyError(err):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case err.Error() == "invalid or expired invitation":
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case err.Error() == "invitation already accepted":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to accept invitation",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Invitation accepted successfully",
+	})
+}
@@ -1,8 +1,11 @@
 package rest
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/usecase"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -10,19 +13,24 @@ import (
 
 type InvoiceHandler struct {
 	invoiceUseCase usecase.InvoiceUseCase
+	guard          PermissionGuard
 }
 
-func NewInvoiceHandler(invoiceUseCase usecase.InvoiceUseCase) *InvoiceHandler {
+func NewInvoiceHandler(invoiceUseCase usecase.InvoiceUseCase, guard PermissionGuard) *InvoiceHandler {
 	return &InvoiceHandler{
 		invoiceUseCase: invoiceUseCase,
+		guard:          guard,
 	}
 }
 
 func (h *InvoiceHandler) InvoiceRoutes(app *fiber.App) {
 	invoice := app.Group("/invoices/:projectId")
-	invoice.Post("/", h.CreateInvoice)
-	invoice.Delete("/:invoiceId", h.DeleteInvoice)
+	invoice.Post("/", h.guard(models.PermissionResourceInvoices, models.PermissionActionEdit), h.CreateInvoice)
+	invoice.Delete("/:invoiceId", h.guard(models.PermissionResourceInvoices, models.PermissionActionDelete), h.DeleteInvoice)
 	invoice.Get("/", h.GetProjectInvoices)
+	invoice.Get("/:invoiceId/promptpay", h.GetPromptPayQR)
+
+	app.Get("/reports/vat", h.GetVATReport)
 }
 
 func (h *InvoiceHandler) CreateInvoice(c *fiber.Ctx) error {
@@ -41,6 +49,11 @@ func (h *InvoiceHandler) CreateInvoice(c *fiber.Ctx) error {
 	}
 
 	if err := h.invoiceUseCase.CreateInvoice(c.Context(), projectID, req); err != nil {
+		if err.Error() == "accounting period is locked" || err.Error() == "late interest changed, please retry" {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -71,6 +84,11 @@ func (h *InvoiceHandler) DeleteInvoice(c *fiber.Ctx) error {
 	}
 
 	if err := h.invoiceUseCase.DeleteInvoice(c.Context(), projectID, req); err != nil {
+		if err.Error() == "accounting period is locked" {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -101,3 +119,75 @@ func (h *InvoiceHandler) GetProjectInvoices(c *fiber.Ctx) error {
 		"data":    invoices,
 	})
 }
+
+func (h *InvoiceHandler) GetPromptPayQR(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	invoiceID, err := uuid.Parse(c.Params("invoiceId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid invoice ID",
+		})
+	}
+
+	userID, err := uuid.Parse(c.Query("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	amount, _ := strconv.ParseFloat(c.Query("amount", "0"), 64)
+
+	qr, err := h.invoiceUseCase.GetPromptPayQR(c.Context(), projectID, invoiceID, userID, amount)
+	if err != nil {
+		switch err.Error() {
+		case "invoice not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Invoice not found",
+			})
+		case "invoice does not belong to the specified project",
+			"company promptpay ID is not configured",
+			"invoice amount is not set",
+			"invoice is already fully paid":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "PromptPay QR generated successfully",
+		"data":    qr,
+	})
+}
+
+func (h *InvoiceHandler) GetVATReport(c *fiber.Ctx) error {
+	month := c.Query("month", time.Now().Format("2006-01"))
+
+	report, err := h.invoiceUseCase.GetVATReport(c.Context(), month)
+	if err != nil {
+		if err.Error() == "invalid month" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid month, expected YYYY-MM",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "VAT report retrieved successfully",
+		"data":    report,
+	})
+}
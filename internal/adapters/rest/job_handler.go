@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/usecase"
 
@@ -10,11 +11,13 @@ import (
 
 type JobHandler struct {
 	jobUsecase usecase.JobUseCase
+	guard      PermissionGuard
 }
 
-func NewJobHandler(jobUsecase usecase.JobUseCase) *JobHandler {
+func NewJobHandler(jobUsecase usecase.JobUseCase, guard PermissionGuard) *JobHandler {
 	return &JobHandler{
 		jobUsecase: jobUsecase,
+		guard:      guard,
 	}
 }
 
@@ -22,15 +25,15 @@ func (h *JobHandler) JobRoutes(app *fiber.App) {
 	job := app.Group("/jobs")
 
 	job.Get("/", h.List)
-	job.Post("/", h.Create)
+	job.Post("/", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.Create)
 	job.Get("/:id", h.GetByID)
-	job.Put("/:id", h.Update)
-	job.Delete("/:id", h.Delete)
+	job.Put("/:id", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.Update)
+	job.Delete("/:id", h.guard(models.PermissionResourceBOQs, models.PermissionActionDelete), h.Delete)
 
 	// Material management routes
-	job.Post("/:id/materials", h.AddMaterial)
-	job.Delete("/:id/materials/:materialId", h.DeleteMaterial)
-	job.Put("/:id/materials/:materialId/quantity", h.UpdateMaterialQuantity)
+	job.Post("/:id/materials", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.AddMaterial)
+	job.Delete("/:id/materials/:materialId", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.DeleteMaterial)
+	job.Put("/:id/materials/:materialId/quantity", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.UpdateMaterialQuantity)
 
 }
 
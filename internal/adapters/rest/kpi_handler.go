@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type KPIHandler struct {
+	kpiUseCase  usecase.KPIUseCase
+	userUsecase usecase.UserUsecase
+}
+
+func NewKPIHandler(kpiUseCase usecase.KPIUseCase, userUsecase usecase.UserUsecase) *KPIHandler {
+	return &KPIHandler{
+		kpiUseCase:  kpiUseCase,
+		userUsecase: userUsecase,
+	}
+}
+
+func (h *KPIHandler) KPIRoutes(app *fiber.App) {
+	kpi := app.Group("/kpi", RequireAuth(h.userUsecase))
+	kpi.Get("/targets", h.ListTargets)
+	kpi.Put("/targets", h.SetTarget)
+	kpi.Delete("/targets/:targetId", h.DeleteTarget)
+	kpi.Get("/report", h.GetReport)
+}
+
+func (h *KPIHandler) SetTarget(c *fiber.Ctx) error {
+	var req requests.SetKPITargetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	target, err := h.kpiUseCase.SetTarget(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return kpiError(c, err, "Failed to set KPI target")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "KPI target saved successfully",
+		"data":    target,
+	})
+}
+
+// ListTargets accepts ?year=, defaulting to the current year.
+func (h *KPIHandler) ListTargets(c *fiber.Ctx) error {
+	targets, err := h.kpiUseCase.ListTargets(c.Context(), c.QueryInt("year", 0))
+	if err != nil {
+		return kpiError(c, err, "Failed to retrieve KPI targets")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "KPI targets retrieved successfully",
+		"data":    targets,
+	})
+}
+
+// DeleteTarget is limited to owners.
+func (h *KPIHandler) DeleteTarget(c *fiber.Ctx) error {
+	targetID, err := uuid.Parse(c.Params("targetId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid target ID",
+		})
+	}
+
+	if err := h.kpiUseCase.DeleteTarget(c.Context(), targetID, currentUserID(c)); err != nil {
+		return kpiError(c, err, "Failed to delete KPI target")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "KPI target deleted successfully",
+	})
+}
+
+// GetReport accepts ?from=YYYY-MM&to=YYYY-MM.
+func (h *KPIHandler) GetReport(c *fiber.Ctx) error {
+	report, err := h.kpiUseCase.GetReport(c.Context(), c.Query("from"), c.Query("to"))
+	if err != nil {
+		return kpiError(c, err, "Failed to retrieve KPI report")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "KPI report retrieved successfully",
+		"data":    report,
+	})
+}
+
+func kpiError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found", "KPI target not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid KPI metric", "percentage targets must not exceed 100", "target value must not be negative",
+		"invalid month format, expected YYYY-MM", "from must not be after to", "report range must not exceed 24 months":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type LateInterestHandler struct {
+	lateInterestUseCase usecase.LateInterestUseCase
+	guard               PermissionGuard
+}
+
+func NewLateInterestHandler(lateInterestUseCase usecase.LateInterestUseCase, guard PermissionGuard) *LateInterestHandler {
+	return &LateInterestHandler{
+		lateInterestUseCase: lateInterestUseCase,
+		guard:               guard,
+	}
+}
+
+func (h *LateInterestHandler) LateInterestRoutes(app *fiber.App) {
+	interest := app.Group("/late-interest/:projectId")
+	interest.Get("/", h.GetProjectInterest)
+	interest.Get("/terms", h.GetTerms)
+	interest.Put("/terms", h.guard(models.PermissionResourceInvoices, models.PermissionActionEdit), h.UpdateTerms)
+	interest.Get("/debit-notes", h.ListDebitNotes)
+	interest.Post("/debit-notes", h.guard(models.PermissionResourceInvoices, models.PermissionActionEdit), h.CreateDebitNote)
+}
+
+func (h *LateInterestHandler) GetProjectInterest(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	interest, err := h.lateInterestUseCase.GetProjectInterest(c.Context(), projectID)
+	if err != nil {
+		return lateInterestError(c, err, "Failed to retrieve late interest")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Late interest retrieved successfully",
+		"data":    interest,
+	})
+}
+
+func (h *LateInterestHandler) GetTerms(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	terms, err := h.lateInterestUseCase.GetTerms(c.Context(), projectID)
+	if err != nil {
+		return lateInterestError(c, err, "Failed to retrieve late payment terms")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Late payment terms retrieved successfully",
+		"data":    terms,
+	})
+}
+
+func (h *LateInterestHandler) UpdateTerms(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.UpdateLatePaymentTermsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	terms, err := h.lateInterestUseCase.UpdateTerms(c.Context(), projectID, req)
+	if err != nil {
+		return lateInterestError(c, err, "Failed to update late payment terms")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Late payment terms updated successfully",
+		"data":    terms,
+	})
+}
+
+func (h *LateInterestHandler) ListDebitNotes(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	notes, err := h.lateInterestUseCase.ListDebitNotes(c.Context(), projectID)
+	if err != nil {
+		return lateInterestError(c, err, "Failed to retrieve debit notes")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Debit notes retrieved successfully",
+		"data":    notes,
+	})
+}
+
+func (h *LateInterestHandler) CreateDebitNote(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	note, err := h.lateInterestUseCase.CreateDebitNote(c.Context(), currentUserID(c), projectID)
+	if err != nil {
+		return lateInterestError(c, err, "Failed to create debit note")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Debit note created successfully",
+		"data":    note,
+	})
+}
+
+func lateInterestError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "late payment terms not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "accounting period is locked", "late interest changed, please retry", "no unbilled late interest":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "annual rate percent must be between 0 and 100", "payment and grace days must not be negative":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
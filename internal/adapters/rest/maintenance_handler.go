@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MaintenanceHandler struct {
+	maintenanceUseCase usecase.MaintenanceUseCase
+	userUsecase        usecase.UserUsecase
+}
+
+func NewMaintenanceHandler(maintenanceUseCase usecase.MaintenanceUseCase, userUsecase usecase.UserUsecase) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenanceUseCase: maintenanceUseCase,
+		userUsecase:        userUsecase,
+	}
+}
+
+func (h *MaintenanceHandler) MaintenanceRoutes(app *fiber.App) {
+	maintenance := app.Group("/admin/maintenance")
+	maintenance.Get("/", h.GetStatus)
+	maintenance.Put("/", RequireAuth(h.userUsecase), h.Update)
+}
+
+// ReadOnly rejects requests that change data with 503 while maintenance
+// mode is on. It must be registered before any routes. Logging in,
+// refreshing tokens and switching maintenance mode off stay available.
+func (h *MaintenanceHandler) ReadOnly() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+		switch c.Path() {
+		case "/login", "/users/oauth/google", "/users/refresh", "/admin/maintenance", "/admin/maintenance/":
+			return c.Next()
+		}
+
+		mode := h.maintenanceUseCase.Status()
+		if !mode.Enabled {
+			return c.Next()
+		}
+		c.Set(fiber.HeaderRetryAfter, "300")
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error":     mode.Message,
+			"read_only": true,
+		})
+	}
+}
+
+func (h *MaintenanceHandler) GetStatus(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"message": "Maintenance mode retrieved successfully",
+		"data":    h.maintenanceUseCase.Status(),
+	})
+}
+
+func (h *MaintenanceHandler) Update(c *fiber.Ctx) error {
+	var req requests.MaintenanceModeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	mode, err := h.maintenanceUseCase.Update(c.Context(), currentUserID(c), req)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "only owners can access this resource":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update maintenance mode",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Maintenance mode updated successfully",
+		"data":    mode,
+	})
+}
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type MaterialAliasHandler struct {
+	aliasUseCase usecase.MaterialAliasUseCase
+	guard        PermissionGuard
+}
+
+func NewMaterialAliasHandler(aliasUseCase usecase.MaterialAliasUseCase, guard PermissionGuard) *MaterialAliasHandler {
+	return &MaterialAliasHandler{
+		aliasUseCase: aliasUseCase,
+		guard:        guard,
+	}
+}
+
+func (h *MaterialAliasHandler) MaterialAliasRoutes(app *fiber.App) {
+	material := app.Group("/materials")
+
+	material.Get("/:id/aliases", h.List)
+	material.Post("/:id/aliases", h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit), h.Create)
+	material.Put("/:id/aliases/:aliasId/display", h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit), h.SetDisplay)
+	material.Delete("/:id/aliases/:aliasId", h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit), h.Delete)
+}
+
+func (h *MaterialAliasHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreateMaterialAliasRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	alias, err := h.aliasUseCase.Create(c.Context(), c.Params("id"), req)
+	if err != nil {
+		return materialAliasError(c, err, "Failed to create alias")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Alias created successfully",
+		"data":    alias,
+	})
+}
+
+func (h *MaterialAliasHandler) List(c *fiber.Ctx) error {
+	aliases, err := h.aliasUseCase.List(c.Context(), c.Params("id"))
+	if err != nil {
+		return materialAliasError(c, err, "Failed to retrieve aliases")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Aliases retrieved successfully",
+		"data":    aliases,
+	})
+}
+
+func (h *MaterialAliasHandler) SetDisplay(c *fiber.Ctx) error {
+	aliasID, err := uuid.Parse(c.Params("aliasId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid alias ID",
+		})
+	}
+
+	alias, err := h.aliasUseCase.SetDisplay(c.Context(), c.Params("id"), aliasID)
+	if err != nil {
+		return materialAliasError(c, err, "Failed to update alias")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Display name updated successfully",
+		"data":    alias,
+	})
+}
+
+func (h *MaterialAliasHandler) Delete(c *fiber.Ctx) error {
+	aliasID, err := uuid.Parse(c.Params("aliasId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid alias ID",
+		})
+	}
+
+	if err := h.aliasUseCase.Delete(c.Context(), c.Params("id"), aliasID); err != nil {
+		return materialAliasError(c, err, "Failed to delete alias")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Alias deleted successfully",
+	})
+}
+
+func materialAliasError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "material not found", "alias not found", "supplier not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "kind must be name or supplier_code", "alias name is required", "language must be th or en",
+		"only supplier codes belong to a supplier", "supplier codes need a supplier",
+		"supplier codes have no language", "only names in th or en can be displayed":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "alias already exists":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -24,6 +24,9 @@ func (h *MaterialHandler) MaterialRoutes(app *fiber.App) {
 
 	material.Post("/", h.Create)
 	material.Get("/", h.List)
+	material.Get("/duplicates", h.FindDuplicates)
+	material.Get("/search", h.Search)
+	material.Post("/merge", h.Merge)
 
 	material.Get("/:projectId/prices", h.GetMaterialPrices)
 	material.Put("/:boqId/estimated-price", h.UpdateEstimatedPrice)
@@ -50,6 +53,10 @@ func (h *MaterialHandler) Create(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"error": err.Error(),
 			})
+		case "invalid material classification":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": "Failed to create material",
@@ -78,6 +85,26 @@ func (h *MaterialHandler) List(c *fiber.Ctx) error {
 	})
 }
 
+func (h *MaterialHandler) Search(c *fiber.Ctx) error {
+	results, err := h.materialUsecase.Search(c.Context(), c.Query("q"), c.QueryInt("limit", 0))
+	if err != nil {
+		switch err.Error() {
+		case "search query is required", "limit must not exceed 100":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to search materials",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Materials retrieved successfully",
+		"data":    results,
+	})
+}
+
 func (h *MaterialHandler) GetByID(c *fiber.Ctx) error {
 	materialID := c.Params("id")
 	if materialID == "" {
@@ -134,6 +161,10 @@ func (h *MaterialHandler) Update(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Material not found",
 			})
+		case "invalid material classification":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": "Failed to update material",
@@ -187,6 +218,11 @@ func (h *MaterialHandler) GetMaterialPrices(c *fiber.Ctx) error {
 
 	response, err := h.materialUsecase.GetMaterialPrices(c.Context(), projectID)
 	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -268,6 +304,14 @@ func (h *MaterialHandler) UpdateActualPrice(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": err.Error(),
 			})
+		case "supplier not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "cannot order from a blacklisted supplier", "cannot order from a suspended supplier":
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error": err.Error(),
+			})
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": err.Error(),
@@ -279,3 +323,49 @@ func (h *MaterialHandler) UpdateActualPrice(c *fiber.Ctx) error {
 		"message": "Actual price updated successfully",
 	})
 }
+
+func (h *MaterialHandler) FindDuplicates(c *fiber.Ctx) error {
+	response, err := h.materialUsecase.FindDuplicates(c.Context(), c.QueryFloat("threshold", 0.8))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to find duplicate materials",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Duplicate materials retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *MaterialHandler) Merge(c *fiber.Ctx) error {
+	var req requests.MergeMaterialRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.materialUsecase.Merge(c.Context(), req); err != nil {
+		switch err.Error() {
+		case "material not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Material not found",
+			})
+		case "source and target material are required",
+			"cannot merge a material into itself",
+			"cannot merge materials with different units":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to merge materials",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Materials merged successfully",
+	})
+}
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type MaterialListPriceHandler struct {
+	listPriceUseCase usecase.MaterialListPriceUseCase
+	guard            PermissionGuard
+}
+
+func NewMaterialListPriceHandler(listPriceUseCase usecase.MaterialListPriceUseCase, guard PermissionGuard) *MaterialListPriceHandler {
+	return &MaterialListPriceHandler{
+		listPriceUseCase: listPriceUseCase,
+		guard:            guard,
+	}
+}
+
+func (h *MaterialListPriceHandler) MaterialListPriceRoutes(app *fiber.App) {
+	material := app.Group("/materials")
+
+	material.Get("/list-prices/upcoming-impact", h.GetUpcomingImpacts)
+
+	material.Get("/:id/list-prices", h.List)
+	material.Post("/:id/list-prices", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.Create)
+	material.Delete("/:id/list-prices/:priceId", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.Delete)
+}
+
+func (h *MaterialListPriceHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreateMaterialListPriceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	price, err := h.listPriceUseCase.Create(c.Context(), c.Params("id"), req)
+	if err != nil {
+		return materialListPriceError(c, err, "Failed to create list price")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "List price created successfully",
+		"data":    price,
+	})
+}
+
+func (h *MaterialListPriceHandler) List(c *fiber.Ctx) error {
+	prices, err := h.listPriceUseCase.List(c.Context(), c.Params("id"))
+	if err != nil {
+		return materialListPriceError(c, err, "Failed to retrieve list prices")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "List prices retrieved successfully",
+		"data":    prices,
+	})
+}
+
+func (h *MaterialListPriceHandler) Delete(c *fiber.Ctx) error {
+	priceID, err := uuid.Parse(c.Params("priceId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid price ID",
+		})
+	}
+
+	if err := h.listPriceUseCase.Delete(c.Context(), c.Params("id"), priceID); err != nil {
+		return materialListPriceError(c, err, "Failed to delete list price")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "List price deleted successfully",
+	})
+}
+
+// GetUpcomingImpacts accepts ?days= (default 30).
+func (h *MaterialListPriceHandler) GetUpcomingImpacts(c *fiber.Ctx) error {
+	impacts, err := h.listPriceUseCase.GetUpcomingImpacts(c.Context(), c.QueryInt("days", 30))
+	if err != nil {
+		return materialListPriceError(c, err, "Failed to retrieve upcoming price impacts")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Upcoming price impacts retrieved successfully",
+		"data":    impacts,
+	})
+}
+
+func materialListPriceError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "material not found", "list price not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "price must be greater than 0", "invalid effective date format, expected YYYY-MM-DD",
+		"only future prices can be deleted", "days must not exceed 366":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "a price already takes effect on that date":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
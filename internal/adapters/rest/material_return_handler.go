@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type MaterialReturnHandler struct {
+	returnUseCase usecase.MaterialReturnUseCase
+	guard         PermissionGuard
+}
+
+func NewMaterialReturnHandler(returnUseCase usecase.MaterialReturnUseCase, guard PermissionGuard) *MaterialReturnHandler {
+	return &MaterialReturnHandler{
+		returnUseCase: returnUseCase,
+		guard:         guard,
+	}
+}
+
+func (h *MaterialReturnHandler) MaterialReturnRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceMaterials, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit)
+
+	returns := app.Group("/material-returns")
+	returns.Get("/", view, h.List)
+	returns.Post("/", edit, h.Create)
+	returns.Get("/:id", view, h.GetByID)
+	returns.Post("/:id/credit-note", edit, h.RecordCreditNote)
+}
+
+func (h *MaterialReturnHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreateMaterialReturnRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	ret, err := h.returnUseCase.Create(c.Context(), req)
+	if err != nil {
+		return materialReturnError(c, err, "Failed to create material return")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Material return created successfully",
+		"data":    ret,
+	})
+}
+
+// List accepts ?supplier_id=, ?po_id= and ?credit_status=pending|received.
+func (h *MaterialReturnHandler) List(c *fiber.Ctx) error {
+	filter := models.MaterialReturnFilter{
+		CreditStatus: models.CreditNoteStatus(c.Query("credit_status")),
+	}
+	if raw := c.Query("supplier_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid supplier ID",
+			})
+		}
+		filter.SupplierID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	if raw := c.Query("po_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid purchase order ID",
+			})
+		}
+		filter.POID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	returns, err := h.returnUseCase.List(c.Context(), filter)
+	if err != nil {
+		return materialReturnError(c, err, "Failed to retrieve material returns")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Material returns retrieved successfully",
+		"data":    returns,
+	})
+}
+
+func (h *MaterialReturnHandler) GetByID(c *fiber.Ctx) error {
+	returnID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid material return ID",
+		})
+	}
+
+	ret, err := h.returnUseCase.GetByID(c.Context(), returnID)
+	if err != nil {
+		return materialReturnError(c, err, "Failed to retrieve material return")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Material return retrieved successfully",
+		"data":    ret,
+	})
+}
+
+func (h *MaterialReturnHandler) RecordCreditNote(c *fiber.Ctx) error {
+	returnID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid material return ID",
+		})
+	}
+
+	var req requests.RecordCreditNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	ret, err := h.returnUseCase.RecordCreditNote(c.Context(), returnID, req)
+	if err != nil {
+		return materialReturnError(c, err, "Failed to record credit note")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Credit note recorded successfully",
+		"data":    ret,
+	})
+}
+
+func materialReturnError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "material return not found", "purchase order not found", "warehouse not found",
+		"allocation not found on this purchase order", "receipt not found on this allocation":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "return number is required", "invalid return reason", "at least one line is required",
+		"invalid return date format, expected YYYY-MM-DD", "return date cannot be before the order date",
+		"quantity must be greater than 0", "invalid credit status", "credit note number is required",
+		"amount must be greater than 0", "invalid date format, expected YYYY-MM-DD",
+		"credit note date cannot be before the return date":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "return number already exists", "quantity exceeds returnable quantity", "insufficient stock",
+		"warehouse is closed", "credit note already received", "accounting period is locked":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type NotificationHandler struct {
+	notificationUseCase usecase.NotificationUseCase
+	userUsecase         usecase.UserUsecase
+}
+
+func NewNotificationHandler(notificationUseCase usecase.NotificationUseCase, userUsecase usecase.UserUsecase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+		userUsecase:         userUsecase,
+	}
+}
+
+func (h *NotificationHandler) NotificationRoutes(app *fiber.App) {
+	matrix := app.Group("/admin/notifications", RequireAuth(h.userUsecase))
+	matrix.Get("/", h.GetDefaultMatrix)
+	matrix.Put("/", h.UpdateDefaultMatrix)
+	matrix.Get("/projects/:projectId", h.GetProjectMatrix)
+	matrix.Put("/projects/:projectId", h.UpdateProjectMatrix)
+	matrix.Delete("/projects/:projectId", h.ResetProjectMatrix)
+
+	notifications := app.Group("/notifications", RequireAuth(h.userUsecase))
+	notifications.Get("/", h.List)
+	notifications.Post("/read", h.MarkAllRead)
+	notifications.Post("/:id/read", h.MarkRead)
+}
+
+func (h *NotificationHandler) GetDefaultMatrix(c *fiber.Ctx) error {
+	matrix, err := h.notificationUseCase.GetDefaultMatrix(c.Context(), currentUserID(c))
+	if err != nil {
+		return notificationError(c, err, "Failed to retrieve notification matrix")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notification matrix retrieved successfully",
+		"data":    matrix,
+	})
+}
+
+func (h *NotificationHandler) UpdateDefaultMatrix(c *fiber.Ctx) error {
+	var req requests.UpdateNotificationMatrixRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	matrix, err := h.notificationUseCase.UpdateDefaultMatrix(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return notificationError(c, err, "Failed to update notification matrix")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notification matrix updated successfully",
+		"data":    matrix,
+	})
+}
+
+func (h *NotificationHandler) GetProjectMatrix(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	matrix, err := h.notificationUseCase.GetProjectMatrix(c.Context(), currentUserID(c), projectID)
+	if err != nil {
+		return notificationError(c, err, "Failed to retrieve notification matrix")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notification matrix retrieved successfully",
+		"data":    matrix,
+	})
+}
+
+func (h *NotificationHandler) UpdateProjectMatrix(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.UpdateNotificationMatrixRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	matrix, err := h.notificationUseCase.UpdateProjectMatrix(c.Context(), currentUserID(c), projectID, req)
+	if err != nil {
+		return notificationError(c, err, "Failed to update notification matrix")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notification matrix updated successfully",
+		"data":    matrix,
+	})
+}
+
+func (h *NotificationHandler) ResetProjectMatrix(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	matrix, err := h.notificationUseCase.ResetProjectMatrix(c.Context(), currentUserID(c), projectID)
+	if err != nil {
+		return notificationError(c, err, "Failed to reset notification matrix")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notification matrix reset successfully",
+		"data":    matrix,
+	})
+}
+
+// List accepts ?unread=true to list only unread notifications.
+func (h *NotificationHandler) List(c *fiber.Ctx) error {
+	notifications, err := h.notificationUseCase.List(c.Context(), currentUserID(c), c.QueryBool("unread"))
+	if err != nil {
+		return notificationError(c, err, "Failed to retrieve notifications")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notifications retrieved successfully",
+		"data":    notifications,
+	})
+}
+
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	notificationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid notification ID",
+		})
+	}
+
+	if err := h.notificationUseCase.MarkRead(c.Context(), currentUserID(c), notificationID); err != nil {
+		return notificationError(c, err, "Failed to mark notification read")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notification marked read successfully",
+	})
+}
+
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	if err := h.notificationUseCase.MarkAllRead(c.Context(), currentUserID(c)); err != nil {
+		return notificationError(c, err, "Failed to mark notifications read")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notifications marked read successfully",
+	})
+}
+
+func notificationError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found", "project not found", "notification not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid notification event", "invalid notification channel", "invalid role":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
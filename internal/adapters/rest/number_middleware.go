@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// numericFieldSuffixes are the JSON keys treated as quantities or money.
+// A key matches when it is one of these or ends in "_" plus one of these,
+// so "estimated_price" and "tax_percentage" are covered wherever they
+// appear in the body.
+var numericFieldSuffixes = []string{
+	"quantity", "price", "amount", "cost", "rate", "percent", "percentage", "factor",
+}
+
+var groupedNumber = regexp.MustCompile(`^-?\d{1,3}(,\d{3})+(\.\d+)?$`)
+
+// NormalizeNumbers lets clients send quantities and prices the way Thai
+// users type them: Thai digits ("๑๒๕"), thousands separators ("1,250.50")
+// and a baht sign, as JSON strings. Such values are rewritten to plain JSON
+// numbers before handlers parse the body. A string that isn't a valid
+// number is rejected with 400 naming the field, rather than reaching
+// the handler as a generic bad body or a zero.
+func NormalizeNumbers() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch:
+		default:
+			return c.Next()
+		}
+		if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+			return c.Next()
+		}
+		// Webhook bodies are signed by the sender and must reach the
+		// handler byte for byte.
+		if strings.HasPrefix(c.Path(), "/webhooks/") {
+			return c.Next()
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+		decoder.UseNumber()
+		var body interface{}
+		if err := decoder.Decode(&body); err != nil {
+			// Leave malformed JSON to the handler's own error.
+			return c.Next()
+		}
+
+		changed, err := normalizeNumericFields(body)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if changed {
+			normalized, err := json.Marshal(body)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "Failed to read request body",
+				})
+			}
+			c.Request().SetBody(normalized)
+		}
+
+		return c.Next()
+	}
+}
+
+func normalizeNumericFields(value interface{}) (bool, error) {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if s, ok := field.(string); ok && isNumericField(key) {
+				number, err := parseLocaleNumber(s)
+				if err != nil {
+					return false, errors.New("invalid number in field " + key + ": " + err.Error())
+				}
+				v[key] = number
+				changed = true
+				continue
+			}
+			fieldChanged, err := normalizeNumericFields(field)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || fieldChanged
+		}
+	case []interface{}:
+		for _, item := range v {
+			itemChanged, err := normalizeNumericFields(item)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || itemChanged
+		}
+	}
+	return changed, nil
+}
+
+func isNumericField(key string) bool {
+	for _, suffix := range numericFieldSuffixes {
+		if key == suffix || strings.HasSuffix(key, "_"+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseLocaleNumber converts Thai digits, drops a baht sign and spaces, and
+// accepts commas only as thousands separators, so "1,5" is an error rather
+// than 15.
+func parseLocaleNumber(s string) (json.Number, error) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= '๐' && r <= '๙':
+			b.WriteRune('0' + (r - '๐'))
+		case r == '฿' || r == ' ' || r == ' ':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	normalized := b.String()
+	if normalized == "" {
+		return "", errors.New("value is empty")
+	}
+
+	if strings.Contains(normalized, ",") {
+		if !groupedNumber.MatchString(normalized) {
+			return "", errors.New("commas are only allowed as thousands separators")
+		}
+		normalized = strings.ReplaceAll(normalized, ",", "")
+	}
+
+	f, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return "", errors.New("not a number")
+	}
+	return json.Number(strconv.FormatFloat(f, 'f', -1, 64)), nil
+}
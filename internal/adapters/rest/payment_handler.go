@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PaymentHandler struct {
+	paymentUseCase usecase.PaymentUseCase
+	guard          PermissionGuard
+}
+
+func NewPaymentHandler(paymentUseCase usecase.PaymentUseCase, guard PermissionGuard) *PaymentHandler {
+	return &PaymentHandler{
+		paymentUseCase: paymentUseCase,
+		guard:          guard,
+	}
+}
+
+func (h *PaymentHandler) PaymentRoutes(app *fiber.App) {
+	payment := app.Group("/payments/:projectId")
+	payment.Post("/invoices/:invoiceId", h.guard(models.PermissionResourceInvoices, models.PermissionActionEdit), h.RecordPayment)
+	payment.Get("/invoices/:invoiceId", h.GetInvoicePayments)
+
+	receipt := app.Group("/receipts")
+	receipt.Get("/:receiptId/export", h.ExportReceipt)
+}
+
+func (h *PaymentHandler) RecordPayment(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	invoiceID, err := uuid.Parse(c.Params("invoiceId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid invoice ID",
+		})
+	}
+
+	var req requests.RecordPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	receipt, err := h.paymentUseCase.RecordPayment(c.Context(), projectID, invoiceID, req)
+	if err != nil {
+		switch err.Error() {
+		case "invoice not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Invoice not found",
+			})
+		case "accounting period is locked":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "invoice does not belong to the specified project",
+			"payment amount must be greater than 0",
+			"invalid payment method":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Payment recorded successfully",
+		"data":    receipt,
+	})
+}
+
+func (h *PaymentHandler) GetInvoicePayments(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	invoiceID, err := uuid.Parse(c.Params("invoiceId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid invoice ID",
+		})
+	}
+
+	payments, err := h.paymentUseCase.GetInvoicePayments(c.Context(), projectID, invoiceID)
+	if err != nil {
+		switch err.Error() {
+		case "invoice not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Invoice not found",
+			})
+		case "invoice does not belong to the specified project":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Payments retrieved successfully",
+		"data":    payments,
+	})
+}
+
+func (h *PaymentHandler) ExportReceipt(c *fiber.Ctx) error {
+	receiptID, err := uuid.Parse(c.Params("receiptId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid receipt ID",
+		})
+	}
+
+	userID, ok := documentTenant(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	exportData, err := h.paymentUseCase.ExportReceipt(c.Context(), receiptID, c.Query("lang"), userID)
+	if err != nil {
+		switch err.Error() {
+		case "receipt not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Receipt not found",
+			})
+		case "language must be th, en or bilingual":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to export receipt",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Receipt exported successfully",
+		"data":    exportData,
+	})
+}
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaymentWebhookHandler struct {
+	paymentWebhookUseCase usecase.PaymentWebhookUseCase
+}
+
+func NewPaymentWebhookHandler(paymentWebhookUseCase usecase.PaymentWebhookUseCase) *PaymentWebhookHandler {
+	return &PaymentWebhookHandler{
+		paymentWebhookUseCase: paymentWebhookUseCase,
+	}
+}
+
+func (h *PaymentWebhookHandler) PaymentWebhookRoutes(app *fiber.App) {
+	webhook := app.Group("/webhooks/payments")
+	webhook.Post("/omise", h.HandleOmise)
+	webhook.Post("/2c2p", h.Handle2C2P)
+}
+
+func (h *PaymentWebhookHandler) HandleOmise(c *fiber.Ctx) error {
+	err := h.paymentWebhookUseCase.HandleOmise(
+		c.Context(),
+		c.Body(),
+		c.Get("Omise-Signature"),
+		c.Get("Omise-Signature-Timestamp"),
+	)
+	return h.respond(c, err)
+}
+
+func (h *PaymentWebhookHandler) Handle2C2P(c *fiber.Ctx) error {
+	var req requests.TwoC2PWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	err := h.paymentWebhookUseCase.Handle2C2P(c.Context(), req)
+	return h.respond(c, err)
+}
+
+func (h *PaymentWebhookHandler) respond(c *fiber.Ctx, err error) error {
+	if err != nil {
+		switch err.Error() {
+		case "invalid webhook signature", "webhook timestamp is too old":
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "invalid webhook payload",
+			"payment reference does not match any invoice",
+			"payment amount must be greater than 0",
+			"payment currency must be THB":
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Webhook processed successfully",
+	})
+}
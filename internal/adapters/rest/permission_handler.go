@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PermissionHandler struct {
+	permissionUseCase usecase.PermissionUseCase
+	userUsecase       usecase.UserUsecase
+}
+
+func NewPermissionHandler(permissionUseCase usecase.PermissionUseCase, userUsecase usecase.UserUsecase) *PermissionHandler {
+	return &PermissionHandler{
+		permissionUseCase: permissionUseCase,
+		userUsecase:       userUsecase,
+	}
+}
+
+func (h *PermissionHandler) PermissionRoutes(app *fiber.App) {
+	permissions := app.Group("/admin/permissions", RequireAuth(h.userUsecase))
+	permissions.Get("/", h.GetMatrix)
+	permissions.Put("/:role", h.UpdateRolePermissions)
+}
+
+func (h *PermissionHandler) GetMatrix(c *fiber.Ctx) error {
+	matrix, err := h.permissionUseCase.GetMatrix(c.Context(), currentUserID(c))
+	if err != nil {
+		return permissionError(c, err, "Failed to retrieve permissions")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Permissions retrieved successfully",
+		"data":    matrix,
+	})
+}
+
+func (h *PermissionHandler) UpdateRolePermissions(c *fiber.Ctx) error {
+	var req requests.UpdateRolePermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	permissions, err := h.permissionUseCase.UpdateRolePermissions(c.Context(), currentUserID(c), c.Params("role"), req)
+	if err != nil {
+		return permissionError(c, err, "Failed to update permissions")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Permissions updated successfully",
+		"data":    permissions,
+	})
+}
+
+func permissionError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource", "owner permissions cannot be changed":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid role", "invalid permission resource", "invalid permission action":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PriceIndexHandler struct {
+	priceIndexUseCase usecase.PriceIndexUseCase
+	guard             PermissionGuard
+}
+
+func NewPriceIndexHandler(priceIndexUseCase usecase.PriceIndexUseCase, guard PermissionGuard) *PriceIndexHandler {
+	return &PriceIndexHandler{
+		priceIndexUseCase: priceIndexUseCase,
+		guard:             guard,
+	}
+}
+
+func (h *PriceIndexHandler) PriceIndexRoutes(app *fiber.App) {
+	index := app.Group("/price-indices")
+
+	index.Post("/", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.Create)
+	index.Get("/", h.guard(models.PermissionResourcePrices, models.PermissionActionView), h.List)
+	index.Get("/alerts", h.guard(models.PermissionResourcePrices, models.PermissionActionView), h.GetAlerts)
+
+	index.Put("/materials/:materialId", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.LinkMaterial)
+	index.Delete("/materials/:materialId", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.UnlinkMaterial)
+
+	index.Get("/:id", h.guard(models.PermissionResourcePrices, models.PermissionActionView), h.GetByID)
+	index.Delete("/:id", h.guard(models.PermissionResourcePrices, models.PermissionActionDelete), h.Delete)
+	index.Post("/:id/values", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.AddValue)
+	index.Post("/:id/values/import", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.ImportValues)
+}
+
+func (h *PriceIndexHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreatePriceIndexRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	index, err := h.priceIndexUseCase.Create(c.Context(), req)
+	if err != nil {
+		switch err.Error() {
+		case "code and name are required":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "price index code already exists":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to create price index",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Price index created successfully",
+		"data":    index,
+	})
+}
+
+func (h *PriceIndexHandler) List(c *fiber.Ctx) error {
+	indices, err := h.priceIndexUseCase.List(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve price indices",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Price indices retrieved successfully",
+		"data":    indices,
+	})
+}
+
+func (h *PriceIndexHandler) GetByID(c *fiber.Ctx) error {
+	indexID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid price index ID",
+		})
+	}
+
+	index, err := h.priceIndexUseCase.GetByID(c.Context(), indexID)
+	if err != nil {
+		if err.Error() == "price index not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Price index not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve price index",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Price index retrieved successfully",
+		"data":    index,
+	})
+}
+
+func (h *PriceIndexHandler) Delete(c *fiber.Ctx) error {
+	indexID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid price index ID",
+		})
+	}
+
+	if err := h.priceIndexUseCase.Delete(c.Context(), indexID); err != nil {
+		if err.Error() == "price index not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Price index not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete price index",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Price index deleted successfully",
+	})
+}
+
+func (h *PriceIndexHandler) AddValue(c *fiber.Ctx) error {
+	indexID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid price index ID",
+		})
+	}
+
+	var req requests.AddPriceIndexValueRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.priceIndexUseCase.AddValue(c.Context(), indexID, req); err != nil {
+		switch err.Error() {
+		case "price index not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Price index not found",
+			})
+		case "invalid period date format, expected YYYY-MM-DD", "value must be greater than 0":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to add price index value",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Price index value added successfully",
+	})
+}
+
+func (h *PriceIndexHandler) ImportValues(c *fiber.Ctx) error {
+	indexID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid price index ID",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "CSV file is required",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to read CSV file",
+		})
+	}
+	defer file.Close()
+
+	result, err := h.priceIndexUseCase.ImportValues(c.Context(), indexID, file)
+	if err != nil {
+		switch {
+		case err.Error() == "price index not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Price index not found",
+			})
+		case strings.HasPrefix(err.Error(), "invalid csv file"):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to import price index values",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Price index values imported successfully",
+		"data":    result,
+	})
+}
+
+func (h *PriceIndexHandler) LinkMaterial(c *fiber.Ctx) error {
+	var req requests.LinkMaterialPriceIndexRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.priceIndexUseCase.LinkMaterial(c.Context(), c.Params("materialId"), req); err != nil {
+		switch err.Error() {
+		case "material not found", "price index not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to link material to price index",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Material linked to price index successfully",
+	})
+}
+
+func (h *PriceIndexHandler) UnlinkMaterial(c *fiber.Ctx) error {
+	if err := h.priceIndexUseCase.UnlinkMaterial(c.Context(), c.Params("materialId")); err != nil {
+		if err.Error() == "material is not linked to a price index" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to unlink material from price index",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Material unlinked from price index successfully",
+	})
+}
+
+func (h *PriceIndexHandler) GetAlerts(c *fiber.Ctx) error {
+	alerts, err := h.priceIndexUseCase.GetAlerts(c.Context(), c.QueryFloat("threshold", 0))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve price index alerts",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Price index alerts retrieved successfully",
+		"data":    alerts,
+	})
+}
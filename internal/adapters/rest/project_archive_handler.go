@@ -0,0 +1,105 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ProjectArchiveHandler struct {
+	archiveUsecase usecase.ProjectArchiveUsecase
+	guard          PermissionGuard
+}
+
+func NewProjectArchiveHandler(archiveUsecase usecase.ProjectArchiveUsecase, guard PermissionGuard) *ProjectArchiveHandler {
+	return &ProjectArchiveHandler{
+		archiveUsecase: archiveUsecase,
+		guard:          guard,
+	}
+}
+
+func (h *ProjectArchiveHandler) ProjectArchiveRoutes(app *fiber.App) {
+	archive := app.Group("/projects/:id/archive")
+	archive.Get("/", h.Get)
+	archive.Post("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Archive)
+	archive.Post("/restore", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Restore)
+}
+
+func (h *ProjectArchiveHandler) Archive(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	archive, err := h.archiveUsecase.Archive(c.Context(), currentUserID(c), projectID)
+	if err != nil {
+		return projectArchiveError(c, err, "Failed to archive project")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Project archived successfully",
+		"data":    archive,
+	})
+}
+
+func (h *ProjectArchiveHandler) Restore(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	archive, err := h.archiveUsecase.Restore(c.Context(), currentUserID(c), projectID)
+	if err != nil {
+		return projectArchiveError(c, err, "Failed to restore project")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project restored successfully",
+		"data":    archive,
+	})
+}
+
+func (h *ProjectArchiveHandler) Get(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	archive, err := h.archiveUsecase.Get(c.Context(), projectID)
+	if err != nil {
+		return projectArchiveError(c, err, "Failed to get project archive")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project archive retrieved successfully",
+		"data":    archive,
+	})
+}
+
+func projectArchiveError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "archive not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only completed projects can be archived":
+		return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project is already archived", "project is not archived", "project changed while archiving, please retry":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -44,6 +44,11 @@ func (h *ProjectHandler) Create(c *fiber.Ctx) error {
 
 	project, err := h.projectUsecase.Create(c.Context(), req)
 	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -80,6 +85,11 @@ func (h *ProjectHandler) Update(c *fiber.Ctx) error {
 
 	err = h.projectUsecase.Update(c.Context(), uuid, req)
 	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -107,6 +117,11 @@ func (h *ProjectHandler) GetByID(c *fiber.Ctx) error {
 
 	project, err := h.projectUsecase.GetByID(c.Context(), uuid)
 	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		if err.Error() == "project not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Project not found",
@@ -127,6 +142,11 @@ func (h *ProjectHandler) List(c *fiber.Ctx) error {
 
 	project, err := h.projectUsecase.List(c.Context())
 	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to retrieve projects",
 		})
@@ -155,6 +175,11 @@ func (h *ProjectHandler) Cancel(c *fiber.Ctx) error {
 
 	err = h.projectUsecase.Cancel(c.Context(), uuid)
 	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -184,7 +209,12 @@ func (h *ProjectHandler) UpdateStatus(c *fiber.Ctx) error {
 
 	if err := h.projectUsecase.UpdateProjectStatus(c.Context(), req); err != nil {
 		switch err.Error() {
-		case "BOQ must be approved", "quotation must be approved":
+		case "project access denied":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "BOQ must be approved", "quotation must be approved",
+			"high risks must have mitigation before contracting":
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"error": err.Error(),
 			})
@@ -215,6 +245,11 @@ func (h *ProjectHandler) GetProjectOverview(c *fiber.Ctx) error {
 
 	overview, err := h.projectUsecase.GetProjectOverview(c.Context(), projectID)
 	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		if err.Error() == "some materials are missing price information" {
 			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
 				"error": err.Error(),
@@ -243,6 +278,10 @@ func (h *ProjectHandler) GetProjectSummary(c *fiber.Ctx) error {
 	summary, err := h.projectUsecase.GetProjectSummary(c.Context(), projectID)
 	if err != nil {
 		switch err.Error() {
+		case "project access denied":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
 		case "project must be completed to view summary":
 			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
 				"error": err.Error(),
@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ProjectMemberHandler serves project membership. Its routes fall under
+// the /projects route policies.
+type ProjectMemberHandler struct {
+	memberUseCase usecase.ProjectMemberUseCase
+}
+
+func NewProjectMemberHandler(memberUseCase usecase.ProjectMemberUseCase) *ProjectMemberHandler {
+	return &ProjectMemberHandler{
+		memberUseCase: memberUseCase,
+	}
+}
+
+func (h *ProjectMemberHandler) ProjectMemberRoutes(app *fiber.App) {
+	app.Get("/projects/:projectId/members", h.List)
+	app.Post("/projects/:projectId/members", h.Add)
+	app.Delete("/projects/:projectId/members/:userId", h.Remove)
+}
+
+func (h *ProjectMemberHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	members, err := h.memberUseCase.List(c.Context(), projectID)
+	if err != nil {
+		return projectMemberError(c, err, "Failed to retrieve project members")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project members retrieved successfully",
+		"data":    members,
+	})
+}
+
+func (h *ProjectMemberHandler) Add(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.AddProjectMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.memberUseCase.Add(c.Context(), projectID, req); err != nil {
+		return projectMemberError(c, err, "Failed to add project member")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Project member added successfully",
+	})
+}
+
+func (h *ProjectMemberHandler) Remove(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	if err := h.memberUseCase.Remove(c.Context(), projectID, userID); err != nil {
+		return projectMemberError(c, err, "Failed to remove project member")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project member removed successfully",
+	})
+}
+
+func projectMemberError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "user not found", "project member not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "user_id is required", "user is deactivated":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "user is already a member of this project":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
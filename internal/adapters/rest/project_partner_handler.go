@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ProjectPartnerHandler struct {
+	partnerUseCase usecase.ProjectPartnerUseCase
+	guard          PermissionGuard
+}
+
+func NewProjectPartnerHandler(partnerUseCase usecase.ProjectPartnerUseCase, guard PermissionGuard) *ProjectPartnerHandler {
+	return &ProjectPartnerHandler{
+		partnerUseCase: partnerUseCase,
+		guard:          guard,
+	}
+}
+
+func (h *ProjectPartnerHandler) ProjectPartnerRoutes(app *fiber.App) {
+	partners := app.Group("/projects/:projectId/partners")
+	partners.Get("/", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetPartners)
+	partners.Put("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.UpdatePartners)
+	partners.Get("/shares", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetShareReport)
+	partners.Get("/:partnerId/statement", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.ExportPartnerStatement)
+}
+
+func (h *ProjectPartnerHandler) GetPartners(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	partners, err := h.partnerUseCase.GetPartners(c.Context(), projectID)
+	if err != nil {
+		return projectPartnerError(c, err, "Failed to retrieve project partners")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project partners retrieved successfully",
+		"data":    partners,
+	})
+}
+
+func (h *ProjectPartnerHandler) UpdatePartners(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.UpdateProjectPartnersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	partners, err := h.partnerUseCase.UpdatePartners(c.Context(), projectID, req)
+	if err != nil {
+		return projectPartnerError(c, err, "Failed to update project partners")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project partners updated successfully",
+		"data":    partners,
+	})
+}
+
+func (h *ProjectPartnerHandler) GetShareReport(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	report, err := h.partnerUseCase.GetShareReport(c.Context(), projectID)
+	if err != nil {
+		return projectPartnerError(c, err, "Failed to build partner share report")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Partner share report retrieved successfully",
+		"data":    report,
+	})
+}
+
+func (h *ProjectPartnerHandler) ExportPartnerStatement(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+	partnerID, err := uuid.Parse(c.Params("partnerId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid partner ID",
+		})
+	}
+
+	document, err := h.partnerUseCase.ExportPartnerStatement(c.Context(), projectID, partnerID)
+	if err != nil {
+		return projectPartnerError(c, err, "Failed to export partner statement")
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="partner-statement-%s.pdf"`, partnerID))
+	return c.Send(document)
+}
+
+func projectPartnerError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "partner not found", "project has no partners":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "partner name is required", "partner names must be unique",
+		"share percent must be greater than 0 and at most 100",
+		"exactly one partner must be our company", "partner shares must add up to 100 percent":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
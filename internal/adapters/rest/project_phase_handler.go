@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ProjectPhaseHandler struct {
+	phaseUseCase usecase.ProjectPhaseUseCase
+	guard        PermissionGuard
+}
+
+func NewProjectPhaseHandler(phaseUseCase usecase.ProjectPhaseUseCase, guard PermissionGuard) *ProjectPhaseHandler {
+	return &ProjectPhaseHandler{
+		phaseUseCase: phaseUseCase,
+		guard:        guard,
+	}
+}
+
+func (h *ProjectPhaseHandler) ProjectPhaseRoutes(app *fiber.App) {
+	projectPhases := app.Group("/projects/:projectId/phases")
+	projectPhases.Get("/", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.List)
+	projectPhases.Post("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Create)
+	projectPhases.Post("/defaults", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.CreateDefaults)
+	projectPhases.Get("/summary", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetSummary)
+
+	phases := app.Group("/phases")
+	phases.Put("/:phaseId", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Update)
+	phases.Delete("/:phaseId", h.guard(models.PermissionResourceProjects, models.PermissionActionDelete), h.Delete)
+
+	app.Put("/boqs/:id/jobs/:jobId/phase", h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit), h.AssignBOQJob)
+}
+
+func (h *ProjectPhaseHandler) Create(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.CreateProjectPhaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	phase, err := h.phaseUseCase.Create(c.Context(), projectID, req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create phase")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Phase created successfully",
+		"data":    phase,
+	})
+}
+
+func (h *ProjectPhaseHandler) CreateDefaults(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	phases, err := h.phaseUseCase.CreateDefaults(c.Context(), projectID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create default phases")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Default phases created successfully",
+		"data":    phases,
+	})
+}
+
+func (h *ProjectPhaseHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	phases, err := h.phaseUseCase.List(c.Context(), projectID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to retrieve phases")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Phases retrieved successfully",
+		"data":    phases,
+	})
+}
+
+func (h *ProjectPhaseHandler) GetSummary(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	summary, err := h.phaseUseCase.GetSummary(c.Context(), projectID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to retrieve phase summary")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Phase summary retrieved successfully",
+		"data":    summary,
+	})
+}
+
+func (h *ProjectPhaseHandler) Update(c *fiber.Ctx) error {
+	phaseID, err := uuid.Parse(c.Params("phaseId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid phase ID",
+		})
+	}
+
+	var req requests.UpdateProjectPhaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.phaseUseCase.Update(c.Context(), phaseID, req); err != nil {
+		return h.handleError(c, err, "Failed to update phase")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Phase updated successfully",
+	})
+}
+
+func (h *ProjectPhaseHandler) Delete(c *fiber.Ctx) error {
+	phaseID, err := uuid.Parse(c.Params("phaseId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid phase ID",
+		})
+	}
+
+	if err := h.phaseUseCase.Delete(c.Context(), phaseID); err != nil {
+		return h.handleError(c, err, "Failed to delete phase")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Phase deleted successfully",
+	})
+}
+
+func (h *ProjectPhaseHandler) AssignBOQJob(c *fiber.Ctx) error {
+	boqID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid BOQ ID",
+		})
+	}
+
+	jobID, err := uuid.Parse(c.Params("jobId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid job ID",
+		})
+	}
+
+	var req requests.AssignJobPhaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.phaseUseCase.AssignBOQJob(c.Context(), boqID, jobID, req); err != nil {
+		return h.handleError(c, err, "Failed to assign job phase")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Job phase assigned successfully",
+	})
+}
+
+func (h *ProjectPhaseHandler) handleError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "phase not found", "boq not found", "job not found in this BOQ":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "phase name is required", "phase does not belong to the BOQ project":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "phase with this name already exists":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
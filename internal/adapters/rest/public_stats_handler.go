@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"boonkosang/internal/usecase"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+type PublicStatsHandler struct {
+	publicStatsUseCase usecase.PublicStatsUseCase
+}
+
+func NewPublicStatsHandler(publicStatsUseCase usecase.PublicStatsUseCase) *PublicStatsHandler {
+	return &PublicStatsHandler{
+		publicStatsUseCase: publicStatsUseCase,
+	}
+}
+
+// PublicStatsRoutes may be called from any origin since the widget is
+// embedded on other sites; it is read-only and sends no credentials.
+func (h *PublicStatsHandler) PublicStatsRoutes(app *fiber.App) {
+	public := app.Group("/public", cors.New(cors.Config{
+		AllowOrigins: "*",
+		AllowMethods: "GET",
+	}))
+	public.Get("/stats", h.GetStats)
+}
+
+// GetStats takes ?token= and an optional comma-separated ?fields=.
+func (h *PublicStatsHandler) GetStats(c *fiber.Ctx) error {
+	var fields []string
+	for _, field := range strings.Split(c.Query("fields"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	stats, err := h.publicStatsUseCase.Get(c.Context(), c.Query("token"), fields)
+	if err != nil {
+		switch err.Error() {
+		case "invalid widget token":
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "unknown stats field":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to retrieve stats",
+			})
+		}
+	}
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(h.publicStatsUseCase.CacheTTL().Seconds())))
+	return c.JSON(fiber.Map{
+		"data": stats,
+	})
+}
@@ -0,0 +1,201 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PurchaseOrderHandler struct {
+	poUseCase usecase.PurchaseOrderUseCase
+	guard     PermissionGuard
+}
+
+func NewPurchaseOrderHandler(poUseCase usecase.PurchaseOrderUseCase, guard PermissionGuard) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		poUseCase: poUseCase,
+		guard:     guard,
+	}
+}
+
+func (h *PurchaseOrderHandler) PurchaseOrderRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceMaterials, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit)
+
+	app.Get("/projects/:projectId/purchase-orders", view, h.ListByProject)
+
+	orders := app.Group("/purchase-orders")
+	orders.Get("/", view, h.List)
+	orders.Post("/", edit, h.Create)
+	orders.Get("/:id", view, h.GetByID)
+	orders.Post("/:id/receipts", edit, h.Receive)
+	orders.Post("/:id/cancel", edit, h.Cancel)
+}
+
+func (h *PurchaseOrderHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreatePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	po, err := h.poUseCase.Create(c.Context(), req)
+	if err != nil {
+		return purchaseOrderError(c, err, "Failed to create purchase order")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Purchase order created successfully",
+		"data":    po,
+	})
+}
+
+// List accepts ?supplier_id=.
+func (h *PurchaseOrderHandler) List(c *fiber.Ctx) error {
+	var supplierID uuid.NullUUID
+	if raw := c.Query("supplier_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid supplier ID",
+			})
+		}
+		supplierID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	orders, err := h.poUseCase.List(c.Context(), supplierID)
+	if err != nil {
+		return purchaseOrderError(c, err, "Failed to retrieve purchase orders")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Purchase orders retrieved successfully",
+		"data":    orders,
+	})
+}
+
+func (h *PurchaseOrderHandler) ListByProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	orders, err := h.poUseCase.ListByProject(c.Context(), projectID)
+	if err != nil {
+		return purchaseOrderError(c, err, "Failed to retrieve purchase orders")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Purchase orders retrieved successfully",
+		"data":    orders,
+	})
+}
+
+func (h *PurchaseOrderHandler) GetByID(c *fiber.Ctx) error {
+	poID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid purchase order ID",
+		})
+	}
+
+	po, err := h.poUseCase.GetByID(c.Context(), poID)
+	if err != nil {
+		return purchaseOrderError(c, err, "Failed to retrieve purchase order")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Purchase order retrieved successfully",
+		"data":    po,
+	})
+}
+
+func (h *PurchaseOrderHandler) Receive(c *fiber.Ctx) error {
+	poID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid purchase order ID",
+		})
+	}
+
+	var req requests.ReceivePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	po, err := h.poUseCase.Receive(c.Context(), poID, req)
+	if err != nil {
+		return purchaseOrderError(c, err, "Failed to receive purchase order")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Purchase order received successfully",
+		"data":    po,
+	})
+}
+
+func (h *PurchaseOrderHandler) Cancel(c *fiber.Ctx) error {
+	poID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid purchase order ID",
+		})
+	}
+
+	if err := h.poUseCase.Cancel(c.Context(), poID); err != nil {
+		return purchaseOrderError(c, err, "Failed to cancel purchase order")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Purchase order cancelled successfully",
+	})
+}
+
+func purchaseOrderError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case err.Error() == "purchase order not found", err.Error() == "supplier not found",
+		err.Error() == "blanket agreement not found",
+		err.Error() == "project not found", err.Error() == "material not found",
+		err.Error() == "allocation not found on this purchase order":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case err.Error() == "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case err.Error() == "po number is required", err.Error() == "at least one line is required",
+		err.Error() == "unit price must be greater than 0", err.Error() == "each line needs at least one project allocation",
+		err.Error() == "quantity must be greater than 0", err.Error() == "a line can only be allocated to each project once",
+		err.Error() == "invalid order date format, expected YYYY-MM-DD", err.Error() == "invalid received date format, expected YYYY-MM-DD",
+		err.Error() == "received date cannot be before the order date", err.Error() == "at least one item is required",
+		err.Error() == "cannot order from a blacklisted supplier", err.Error() == "cannot order from a suspended supplier",
+		err.Error() == "supplier does not match the agreement", err.Error() == "agreement is not valid on the order date",
+		err.Error() == "material is not on the agreement", err.Error() == "unit price must match the agreement price",
+		strings.HasPrefix(err.Error(), "material is not in the BOQ of project"):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case err.Error() == "purchase order number already exists", err.Error() == "purchase order is cancelled",
+		err.Error() == "purchase order is fully received", err.Error() == "quantity exceeds outstanding",
+		err.Error() == "only open purchase orders with nothing received can be cancelled",
+		err.Error() == "quantity exceeds the agreement's remaining balance",
+		err.Error() == "accounting period is locked":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -4,6 +4,7 @@ package rest
 import (
 	"boonkosang/internal/requests"
 	"boonkosang/internal/usecase"
+	"fmt"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -24,6 +25,7 @@ func (h *QuotationHandler) QuotationRoutes(app *fiber.App) {
 
 	// Create or Get Quotation
 	quotation.Get("/projects/:projectId/export", h.ExportQuotation)
+	quotation.Get("/projects/:projectId/pdf", h.ExportQuotationPDF)
 
 	quotation.Put("/projects/:projectId/selling-price", h.UpdateProjectSellingPrice)
 
@@ -44,6 +46,10 @@ func (h *QuotationHandler) CreateOrGetQuotation(c *fiber.Ctx) error {
 	response, err := h.quotationUsecase.CreateOrGetQuotation(c.Context(), projectID)
 	if err != nil {
 		switch err.Error() {
+		case "project access denied":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
 		case "BOQ must be approved before creating quotation":
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": err.Error(),
@@ -78,9 +84,34 @@ func (h *QuotationHandler) ApproveQuotation(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.quotationUsecase.ApproveQuotation(c.Context(), projectID)
+	var req requests.ApproveQuotationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	approval, err := h.quotationUsecase.ApproveQuotation(c.Context(), projectID, req)
 	if err != nil {
 		switch err.Error() {
+		case "project access denied":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "client is on credit hold":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Client is on credit hold",
+			})
+		case "only owners can override a credit hold":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
 		case "BOQ not found":
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "BOQ not found",
@@ -111,6 +142,7 @@ func (h *QuotationHandler) ApproveQuotation(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Quotation approved successfully",
+		"data":    approval,
 	})
 }
 
@@ -122,10 +154,21 @@ func (h *QuotationHandler) ExportQuotation(c *fiber.Ctx) error {
 		})
 	}
 
-	exportData, err := h.quotationUsecase.ExportQuotation(c.Context(), projectID)
+	userID, ok := documentTenant(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	exportData, err := h.quotationUsecase.ExportQuotation(c.Context(), projectID, c.Query("lang"), userID)
 	if err != nil {
 		switch err.Error() {
-		case "BOQ must be approved before exporting quotation":
+		case "project access denied":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "BOQ must be approved before exporting quotation", "language must be th, en or bilingual":
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": err.Error(),
 			})
@@ -155,6 +198,49 @@ func (h *QuotationHandler) ExportQuotation(c *fiber.Ctx) error {
 	})
 }
 
+func (h *QuotationHandler) ExportQuotationPDF(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID format",
+		})
+	}
+
+	userID, ok := documentTenant(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	document, err := h.quotationUsecase.ExportQuotationPDF(c.Context(), projectID, c.Query("lang"), userID)
+	if err != nil {
+		switch err.Error() {
+		case "project access denied":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "BOQ must be approved before exporting quotation", "only approved quotations can be exported",
+			"language must be th, en or bilingual", "pdf documents can only be rendered in English":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "BOQ not found", "approved quotation not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to export quotation",
+			})
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="quotation-%s.pdf"`, projectID))
+	return c.Send(document)
+}
+
 func (h *QuotationHandler) UpdateProjectSellingPrice(c *fiber.Ctx) error {
 	var req requests.UpdateProjectSellingPriceRequest
 
@@ -175,7 +261,11 @@ func (h *QuotationHandler) UpdateProjectSellingPrice(c *fiber.Ctx) error {
 
 	err = h.quotationUsecase.UpdateProjectSellingPrice(c.Context(), req)
 	if err != nil {
-
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
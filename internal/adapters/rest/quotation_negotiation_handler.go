@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuotationNegotiationHandler struct {
+	negotiationUseCase usecase.QuotationNegotiationUseCase
+	guard              PermissionGuard
+}
+
+func NewQuotationNegotiationHandler(negotiationUseCase usecase.QuotationNegotiationUseCase, guard PermissionGuard) *QuotationNegotiationHandler {
+	return &QuotationNegotiationHandler{
+		negotiationUseCase: negotiationUseCase,
+		guard:              guard,
+	}
+}
+
+func (h *QuotationNegotiationHandler) QuotationNegotiationRoutes(app *fiber.App) {
+	negotiation := app.Group("/quotations/projects/:projectId/negotiation")
+	negotiation.Get("/", h.GetNegotiation)
+	negotiation.Post("/rounds", h.guard(models.PermissionResourceQuotations, models.PermissionActionEdit), h.RecordRound)
+	negotiation.Put("/rounds/:roundId", h.guard(models.PermissionResourceQuotations, models.PermissionActionEdit), h.UpdateRound)
+
+	app.Get("/reports/win-loss", h.GetWinLossReport)
+}
+
+func (h *QuotationNegotiationHandler) GetNegotiation(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	negotiation, err := h.negotiationUseCase.GetNegotiation(c.Context(), projectID)
+	if err != nil {
+		return negotiationError(c, err, "Failed to retrieve negotiation")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Negotiation retrieved successfully",
+		"data":    negotiation,
+	})
+}
+
+func (h *QuotationNegotiationHandler) RecordRound(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.QuotationNegotiationRoundRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	round, err := h.negotiationUseCase.RecordRound(c.Context(), projectID, req)
+	if err != nil {
+		return negotiationError(c, err, "Failed to record negotiation round")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Negotiation round recorded successfully",
+		"data":    round,
+	})
+}
+
+func (h *QuotationNegotiationHandler) UpdateRound(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+	roundID, err := uuid.Parse(c.Params("roundId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid round ID",
+		})
+	}
+
+	var req requests.QuotationNegotiationRoundRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	round, err := h.negotiationUseCase.UpdateRound(c.Context(), projectID, roundID, req)
+	if err != nil {
+		return negotiationError(c, err, "Failed to update negotiation round")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Negotiation round updated successfully",
+		"data":    round,
+	})
+}
+
+func (h *QuotationNegotiationHandler) GetWinLossReport(c *fiber.Ctx) error {
+	report, err := h.negotiationUseCase.GetWinLossReport(c.Context(), c.Query("from"), c.Query("to"))
+	if err != nil {
+		return negotiationError(c, err, "Failed to build win/loss report")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Win/loss report retrieved successfully",
+		"data":    report,
+	})
+}
+
+func negotiationError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "quotation not found", "negotiation round not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "can only negotiate quotation in draft status":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "counter offer must be greater than 0", "invalid offered_on, expected YYYY-MM-DD",
+		"offered_on must not be in the future", "invalid from date", "invalid to date",
+		"from date must not be after to date":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
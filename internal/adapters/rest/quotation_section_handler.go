@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuotationSectionHandler struct {
+	sectionUseCase usecase.QuotationSectionUseCase
+	guard          PermissionGuard
+}
+
+func NewQuotationSectionHandler(sectionUseCase usecase.QuotationSectionUseCase, guard PermissionGuard) *QuotationSectionHandler {
+	return &QuotationSectionHandler{
+		sectionUseCase: sectionUseCase,
+		guard:          guard,
+	}
+}
+
+func (h *QuotationSectionHandler) QuotationSectionRoutes(app *fiber.App) {
+	sections := app.Group("/quotations/projects/:projectId/sections")
+	sections.Get("/", h.GetSections)
+	sections.Put("/", h.guard(models.PermissionResourceQuotations, models.PermissionActionEdit), h.UpdateSections)
+
+	snippets := app.Group("/quotation-snippets")
+	snippets.Get("/", h.ListSnippets)
+	snippets.Post("/", h.guard(models.PermissionResourceQuotations, models.PermissionActionEdit), h.CreateSnippet)
+	snippets.Put("/:snippetId", h.guard(models.PermissionResourceQuotations, models.PermissionActionEdit), h.UpdateSnippet)
+	snippets.Delete("/:snippetId", h.guard(models.PermissionResourceQuotations, models.PermissionActionDelete), h.DeleteSnippet)
+}
+
+func (h *QuotationSectionHandler) GetSections(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	sections, err := h.sectionUseCase.GetSections(c.Context(), projectID)
+	if err != nil {
+		return quotationSectionError(c, err, "Failed to retrieve quotation sections")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Quotation sections retrieved successfully",
+		"data":    sections,
+	})
+}
+
+func (h *QuotationSectionHandler) UpdateSections(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.UpdateQuotationSectionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	sections, err := h.sectionUseCase.UpdateSections(c.Context(), projectID, req)
+	if err != nil {
+		return quotationSectionError(c, err, "Failed to update quotation sections")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Quotation sections updated successfully",
+		"data":    sections,
+	})
+}
+
+func (h *QuotationSectionHandler) ListSnippets(c *fiber.Ctx) error {
+	snippets, err := h.sectionUseCase.ListSnippets(c.Context(), c.Query("kind"))
+	if err != nil {
+		return quotationSectionError(c, err, "Failed to retrieve snippets")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Snippets retrieved successfully",
+		"data":    snippets,
+	})
+}
+
+func (h *QuotationSectionHandler) CreateSnippet(c *fiber.Ctx) error {
+	var req requests.QuotationSnippetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	snippet, err := h.sectionUseCase.CreateSnippet(c.Context(), req)
+	if err != nil {
+		return quotationSectionError(c, err, "Failed to create snippet")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Snippet created successfully",
+		"data":    snippet,
+	})
+}
+
+func (h *QuotationSectionHandler) UpdateSnippet(c *fiber.Ctx) error {
+	snippetID, err := uuid.Parse(c.Params("snippetId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid snippet ID",
+		})
+	}
+
+	var req requests.QuotationSnippetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.sectionUseCase.UpdateSnippet(c.Context(), snippetID, req); err != nil {
+		return quotationSectionError(c, err, "Failed to update snippet")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Snippet updated successfully",
+	})
+}
+
+func (h *QuotationSectionHandler) DeleteSnippet(c *fiber.Ctx) error {
+	snippetID, err := uuid.Parse(c.Params("snippetId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid snippet ID",
+		})
+	}
+
+	if err := h.sectionUseCase.DeleteSnippet(c.Context(), snippetID); err != nil {
+		return quotationSectionError(c, err, "Failed to delete snippet")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Snippet deleted successfully",
+	})
+}
+
+func quotationSectionError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "quotation not found", "snippet not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "can only update sections for quotation in draft status":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid section kind", "snippet is for a different section",
+		"snippet title is required", "snippet content is required":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReconciliationHandler struct {
+	reconciliationUsecase usecase.ReconciliationUsecase
+	guard                 PermissionGuard
+}
+
+func NewReconciliationHandler(reconciliationUsecase usecase.ReconciliationUsecase, guard PermissionGuard) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationUsecase: reconciliationUsecase,
+		guard:                 guard,
+	}
+}
+
+func (h *ReconciliationHandler) ReconciliationRoutes(app *fiber.App) {
+	app.Get("/projects/:id/reconciliation", h.guard(models.PermissionResourceInvoices, models.PermissionActionView), h.GetProjectReconciliation)
+}
+
+func (h *ReconciliationHandler) GetProjectReconciliation(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	reconciliation, err := h.reconciliationUsecase.GetProjectReconciliation(c.Context(), projectID)
+	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err.Error() == "project not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Project not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get project reconciliation",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project reconciliation retrieved successfully",
+		"data":    reconciliation,
+	})
+}
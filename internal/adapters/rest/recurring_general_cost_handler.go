@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RecurringGeneralCostHandler struct {
+	recurringUseCase usecase.RecurringGeneralCostUseCase
+	guard            PermissionGuard
+}
+
+func NewRecurringGeneralCostHandler(recurringUseCase usecase.RecurringGeneralCostUseCase, guard PermissionGuard) *RecurringGeneralCostHandler {
+	return &RecurringGeneralCostHandler{
+		recurringUseCase: recurringUseCase,
+		guard:            guard,
+	}
+}
+
+func (h *RecurringGeneralCostHandler) RecurringGeneralCostRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceBOQs, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceBOQs, models.PermissionActionEdit)
+	remove := h.guard(models.PermissionResourceBOQs, models.PermissionActionDelete)
+
+	app.Get("/projects/:projectId/recurring-costs", view, h.List)
+	app.Post("/projects/:projectId/recurring-costs", edit, h.Create)
+
+	recurring := app.Group("/recurring-costs")
+	recurring.Post("/run", edit, h.RunPostings)
+	recurring.Put("/:id", edit, h.Update)
+	recurring.Delete("/:id", remove, h.Delete)
+}
+
+func (h *RecurringGeneralCostHandler) Create(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.RecurringGeneralCostRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	cost, err := h.recurringUseCase.Create(c.Context(), projectID, req)
+	if err != nil {
+		return recurringGeneralCostError(c, err, "Failed to create recurring general cost")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Recurring general cost created successfully",
+		"data":    cost,
+	})
+}
+
+func (h *RecurringGeneralCostHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	costs, err := h.recurringUseCase.List(c.Context(), projectID)
+	if err != nil {
+		return recurringGeneralCostError(c, err, "Failed to retrieve recurring general costs")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Recurring general costs retrieved successfully",
+		"data":    costs,
+	})
+}
+
+func (h *RecurringGeneralCostHandler) Update(c *fiber.Ctx) error {
+	recurringID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid recurring general cost ID",
+		})
+	}
+
+	var req requests.RecurringGeneralCostRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	cost, err := h.recurringUseCase.Update(c.Context(), recurringID, req)
+	if err != nil {
+		return recurringGeneralCostError(c, err, "Failed to update recurring general cost")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Recurring general cost updated successfully",
+		"data":    cost,
+	})
+}
+
+func (h *RecurringGeneralCostHandler) Delete(c *fiber.Ctx) error {
+	recurringID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid recurring general cost ID",
+		})
+	}
+
+	if err := h.recurringUseCase.Delete(c.Context(), recurringID); err != nil {
+		return recurringGeneralCostError(c, err, "Failed to delete recurring general cost")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Recurring general cost deleted successfully",
+	})
+}
+
+// RunPostings posts due months now rather than waiting for the daily run.
+func (h *RecurringGeneralCostHandler) RunPostings(c *fiber.Ctx) error {
+	if err := h.recurringUseCase.RunPostings(c.Context()); err != nil {
+		return recurringGeneralCostError(c, err, "Failed to post recurring general costs")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Recurring general costs posted successfully",
+	})
+}
+
+func recurringGeneralCostError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "recurring general cost not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "type name is required", "unknown general cost type", "monthly amount must be positive",
+		"invalid period format, expected YYYY-MM", "end period must not be before start period":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project is closed", "cannot change the type of a posted recurring cost",
+		"periods already posted must stay in range", "recurring general cost already posted":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RegionHandler struct {
+	regionUseCase usecase.RegionUseCase
+	guard         PermissionGuard
+}
+
+func NewRegionHandler(regionUseCase usecase.RegionUseCase, guard PermissionGuard) *RegionHandler {
+	return &RegionHandler{
+		regionUseCase: regionUseCase,
+		guard:         guard,
+	}
+}
+
+func (h *RegionHandler) RegionRoutes(app *fiber.App) {
+	presets := app.Group("/region-presets")
+	presets.Get("/", h.guard(models.PermissionResourcePrices, models.PermissionActionView), h.List)
+	presets.Post("/", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.Create)
+	presets.Post("/defaults", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.CreateDefaults)
+	presets.Put("/:regionId", h.guard(models.PermissionResourcePrices, models.PermissionActionEdit), h.Update)
+	presets.Delete("/:regionId", h.guard(models.PermissionResourcePrices, models.PermissionActionDelete), h.Delete)
+
+	projectRegion := app.Group("/projects/:projectId/region")
+	projectRegion.Get("/", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetProjectRegion)
+	projectRegion.Put("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.SetProjectRegion)
+}
+
+func (h *RegionHandler) Create(c *fiber.Ctx) error {
+	var req requests.RegionPresetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	preset, err := h.regionUseCase.Create(c.Context(), req)
+	if err != nil {
+		return regionError(c, err, "Failed to create region preset")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Region preset created successfully",
+		"data":    preset,
+	})
+}
+
+// CreateDefaults adds the Bangkok, Isaan and South presets if missing.
+func (h *RegionHandler) CreateDefaults(c *fiber.Ctx) error {
+	presets, err := h.regionUseCase.CreateDefaults(c.Context())
+	if err != nil {
+		return regionError(c, err, "Failed to create default region presets")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Default region presets created successfully",
+		"data":    presets,
+	})
+}
+
+func (h *RegionHandler) List(c *fiber.Ctx) error {
+	presets, err := h.regionUseCase.List(c.Context())
+	if err != nil {
+		return regionError(c, err, "Failed to retrieve region presets")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Region presets retrieved successfully",
+		"data":    presets,
+	})
+}
+
+func (h *RegionHandler) Update(c *fiber.Ctx) error {
+	regionID, err := uuid.Parse(c.Params("regionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid region ID",
+		})
+	}
+
+	var req requests.RegionPresetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.regionUseCase.Update(c.Context(), regionID, req); err != nil {
+		return regionError(c, err, "Failed to update region preset")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Region preset updated successfully",
+	})
+}
+
+func (h *RegionHandler) Delete(c *fiber.Ctx) error {
+	regionID, err := uuid.Parse(c.Params("regionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid region ID",
+		})
+	}
+
+	if err := h.regionUseCase.Delete(c.Context(), regionID); err != nil {
+		return regionError(c, err, "Failed to delete region preset")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Region preset deleted successfully",
+	})
+}
+
+func (h *RegionHandler) GetProjectRegion(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	preset, err := h.regionUseCase.GetProjectRegion(c.Context(), projectID)
+	if err != nil {
+		return regionError(c, err, "Failed to retrieve project region")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project region retrieved successfully",
+		"data":    preset,
+	})
+}
+
+func (h *RegionHandler) SetProjectRegion(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.SetProjectRegionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.regionUseCase.SetProjectRegion(c.Context(), projectID, req); err != nil {
+		return regionError(c, err, "Failed to set project region")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project region updated successfully",
+	})
+}
+
+func regionError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "region preset not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "region code already exists", "region preset is in use by a project":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "region code and name are required", "labor rate and factor must be positive",
+		"transport cost percent must be between 0 and 100":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RiskHandler struct {
+	riskUseCase usecase.RiskUseCase
+	guard       PermissionGuard
+}
+
+func NewRiskHandler(riskUseCase usecase.RiskUseCase, guard PermissionGuard) *RiskHandler {
+	return &RiskHandler{
+		riskUseCase: riskUseCase,
+		guard:       guard,
+	}
+}
+
+func (h *RiskHandler) RiskRoutes(app *fiber.App) {
+	projectRisks := app.Group("/projects/:projectId/risks")
+	projectRisks.Get("/", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.List)
+	projectRisks.Post("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Create)
+	projectRisks.Get("/heat-map", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetHeatMap)
+
+	risks := app.Group("/risks")
+	risks.Put("/:riskId", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Update)
+	risks.Delete("/:riskId", h.guard(models.PermissionResourceProjects, models.PermissionActionDelete), h.Delete)
+}
+
+func (h *RiskHandler) Create(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.CreateRiskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	risk, err := h.riskUseCase.Create(c.Context(), projectID, req)
+	if err != nil {
+		return riskError(c, err, "Failed to create risk")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Risk created successfully",
+		"data":    risk,
+	})
+}
+
+// List accepts an optional ?status= filter.
+func (h *RiskHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	risks, err := h.riskUseCase.List(c.Context(), projectID, c.Query("status"))
+	if err != nil {
+		return riskError(c, err, "Failed to retrieve risks")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Risks retrieved successfully",
+		"data":    risks,
+	})
+}
+
+func (h *RiskHandler) GetHeatMap(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	heatMap, err := h.riskUseCase.GetHeatMap(c.Context(), projectID)
+	if err != nil {
+		return riskError(c, err, "Failed to retrieve risk heat map")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Risk heat map retrieved successfully",
+		"data":    heatMap,
+	})
+}
+
+func (h *RiskHandler) Update(c *fiber.Ctx) error {
+	riskID, err := uuid.Parse(c.Params("riskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid risk ID",
+		})
+	}
+
+	var req requests.UpdateRiskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.riskUseCase.Update(c.Context(), riskID, req); err != nil {
+		return riskError(c, err, "Failed to update risk")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Risk updated successfully",
+	})
+}
+
+func (h *RiskHandler) Delete(c *fiber.Ctx) error {
+	riskID, err := uuid.Parse(c.Params("riskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid risk ID",
+		})
+	}
+
+	if err := h.riskUseCase.Delete(c.Context(), riskID); err != nil {
+		return riskError(c, err, "Failed to delete risk")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Risk deleted successfully",
+	})
+}
+
+func riskError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "risk not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "risk description is required", "probability and impact must be between 1 and 5", "invalid risk status":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
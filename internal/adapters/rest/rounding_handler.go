@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RoundingHandler struct {
+	roundingUseCase usecase.RoundingUseCase
+	userUsecase     usecase.UserUsecase
+}
+
+func NewRoundingHandler(roundingUseCase usecase.RoundingUseCase, userUsecase usecase.UserUsecase) *RoundingHandler {
+	return &RoundingHandler{
+		roundingUseCase: roundingUseCase,
+		userUsecase:     userUsecase,
+	}
+}
+
+func (h *RoundingHandler) RoundingRoutes(app *fiber.App) {
+	policy := app.Group("/rounding-policy", RequireAuth(h.userUsecase))
+	policy.Get("/", h.GetPolicy)
+	policy.Put("/", h.UpdatePolicy)
+}
+
+func (h *RoundingHandler) GetPolicy(c *fiber.Ctx) error {
+	policy, err := h.roundingUseCase.GetPolicy(c.Context())
+	if err != nil {
+		return roundingError(c, err, "Failed to retrieve rounding policy")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Rounding policy retrieved successfully",
+		"data":    policy,
+	})
+}
+
+func (h *RoundingHandler) UpdatePolicy(c *fiber.Ctx) error {
+	var req requests.RoundingPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	policy, err := h.roundingUseCase.UpdatePolicy(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return roundingError(c, err, "Failed to update rounding policy")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Rounding policy updated successfully",
+		"data":    policy,
+	})
+}
+
+func roundingError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid rounding mode", "unsupported rounding increment":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
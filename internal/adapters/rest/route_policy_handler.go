@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RoutePolicyHandler struct {
+	routePolicyUseCase usecase.RoutePolicyUseCase
+	userUsecase        usecase.UserUsecase
+}
+
+func NewRoutePolicyHandler(routePolicyUseCase usecase.RoutePolicyUseCase, userUsecase usecase.UserUsecase) *RoutePolicyHandler {
+	return &RoutePolicyHandler{
+		routePolicyUseCase: routePolicyUseCase,
+		userUsecase:        userUsecase,
+	}
+}
+
+func (h *RoutePolicyHandler) RoutePolicyRoutes(app *fiber.App) {
+	policies := app.Group("/admin/route-policies", RequireAuth(h.userUsecase))
+	policies.Get("/", h.List)
+	policies.Put("/", h.Save)
+	// The route is given in the query because its path contains slashes.
+	policies.Delete("/", h.Reset)
+}
+
+func (h *RoutePolicyHandler) List(c *fiber.Ctx) error {
+	policies, err := h.routePolicyUseCase.List(c.Context(), currentUserID(c))
+	if err != nil {
+		return routePolicyError(c, err, "Failed to retrieve route policies")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Route policies retrieved successfully",
+		"data":    policies,
+	})
+}
+
+func (h *RoutePolicyHandler) Save(c *fiber.Ctx) error {
+	var req requests.SaveRoutePolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	policy, err := h.routePolicyUseCase.Save(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return routePolicyError(c, err, "Failed to save route policy")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Route policy saved successfully",
+		"data":    policy,
+	})
+}
+
+func (h *RoutePolicyHandler) Reset(c *fiber.Ctx) error {
+	if err := h.routePolicyUseCase.Reset(c.Context(), currentUserID(c), c.Query("method"), c.Query("path")); err != nil {
+		return routePolicyError(c, err, "Failed to reset route policy")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Route policy reset successfully",
+	})
+}
+
+func routePolicyError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found", "route policy not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid route method", "route is not covered by route policies",
+		"invalid permission resource", "invalid permission action":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
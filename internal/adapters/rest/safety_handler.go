@@ -0,0 +1,239 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SafetyHandler struct {
+	safetyUseCase usecase.SafetyUseCase
+	guard         PermissionGuard
+}
+
+func NewSafetyHandler(safetyUseCase usecase.SafetyUseCase, guard PermissionGuard) *SafetyHandler {
+	return &SafetyHandler{
+		safetyUseCase: safetyUseCase,
+		guard:         guard,
+	}
+}
+
+func (h *SafetyHandler) SafetyRoutes(app *fiber.App) {
+	projectIncidents := app.Group("/projects/:projectId/safety-incidents")
+	projectIncidents.Get("/", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.List)
+	projectIncidents.Post("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Create)
+
+	incidents := app.Group("/safety-incidents")
+	incidents.Get("/:id", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetByID)
+	incidents.Put("/:id", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Update)
+	incidents.Delete("/:id", h.guard(models.PermissionResourceProjects, models.PermissionActionDelete), h.Delete)
+	incidents.Post("/:id/actions", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.AddAction)
+	incidents.Put("/:id/actions/:actionId/complete", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.CompleteAction)
+
+	app.Get("/safety/statistics", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetStatistics)
+}
+
+func (h *SafetyHandler) Create(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.CreateSafetyIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	incident, err := h.safetyUseCase.Create(c.Context(), projectID, req)
+	if err != nil {
+		return safetyError(c, err, "Failed to report safety incident")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Safety incident reported successfully",
+		"data":    incident,
+	})
+}
+
+func (h *SafetyHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	incidents, err := h.safetyUseCase.List(c.Context(), projectID)
+	if err != nil {
+		return safetyError(c, err, "Failed to retrieve safety incidents")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Safety incidents retrieved successfully",
+		"data":    incidents,
+	})
+}
+
+func (h *SafetyHandler) GetByID(c *fiber.Ctx) error {
+	incidentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid incident ID",
+		})
+	}
+
+	incident, err := h.safetyUseCase.GetByID(c.Context(), incidentID)
+	if err != nil {
+		return safetyError(c, err, "Failed to retrieve safety incident")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Safety incident retrieved successfully",
+		"data":    incident,
+	})
+}
+
+func (h *SafetyHandler) Update(c *fiber.Ctx) error {
+	incidentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid incident ID",
+		})
+	}
+
+	var req requests.UpdateSafetyIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.safetyUseCase.Update(c.Context(), incidentID, req); err != nil {
+		return safetyError(c, err, "Failed to update safety incident")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Safety incident updated successfully",
+	})
+}
+
+func (h *SafetyHandler) Delete(c *fiber.Ctx) error {
+	incidentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid incident ID",
+		})
+	}
+
+	if err := h.safetyUseCase.Delete(c.Context(), incidentID); err != nil {
+		return safetyError(c, err, "Failed to delete safety incident")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Safety incident deleted successfully",
+	})
+}
+
+func (h *SafetyHandler) AddAction(c *fiber.Ctx) error {
+	incidentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid incident ID",
+		})
+	}
+
+	var req requests.CreateCorrectiveActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	action, err := h.safetyUseCase.AddAction(c.Context(), incidentID, req)
+	if err != nil {
+		return safetyError(c, err, "Failed to add corrective action")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Corrective action added successfully",
+		"data":    action,
+	})
+}
+
+func (h *SafetyHandler) CompleteAction(c *fiber.Ctx) error {
+	incidentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid incident ID",
+		})
+	}
+
+	actionID, err := uuid.Parse(c.Params("actionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid action ID",
+		})
+	}
+
+	if err := h.safetyUseCase.CompleteAction(c.Context(), incidentID, actionID); err != nil {
+		return safetyError(c, err, "Failed to complete corrective action")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Corrective action completed successfully",
+	})
+}
+
+// GetStatistics accepts ?year= (defaults to the current year) and an
+// optional ?project_id= to narrow the figures to one project.
+func (h *SafetyHandler) GetStatistics(c *fiber.Ctx) error {
+	year := c.QueryInt("year", 0)
+
+	var projectID *uuid.UUID
+	if raw := c.Query("project_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid project ID",
+			})
+		}
+		projectID = &id
+	}
+
+	stats, err := h.safetyUseCase.GetStatistics(c.Context(), year, projectID)
+	if err != nil {
+		return safetyError(c, err, "Failed to retrieve safety statistics")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Safety statistics retrieved successfully",
+		"data":    stats,
+	})
+}
+
+func safetyError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "safety incident not found", "corrective action not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invalid incident type", "invalid incident severity", "incident description is required",
+		"lost days must not be negative", "invalid occurred_at, expected RFC 3339 or YYYY-MM-DD",
+		"occurred_at must not be in the future", "action description is required",
+		"invalid date format, expected YYYY-MM-DD", "invalid year":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ScheduleTaskHandler struct {
+	taskUseCase usecase.ScheduleTaskUseCase
+	userUsecase usecase.UserUsecase
+	guard       PermissionGuard
+}
+
+func NewScheduleTaskHandler(taskUseCase usecase.ScheduleTaskUseCase, userUsecase usecase.UserUsecase, guard PermissionGuard) *ScheduleTaskHandler {
+	return &ScheduleTaskHandler{
+		taskUseCase: taskUseCase,
+		userUsecase: userUsecase,
+		guard:       guard,
+	}
+}
+
+// ScheduleTaskRoutes accept API keys as well as access tokens so an
+// external scheduling service can keep the programme in sync.
+func (h *ScheduleTaskHandler) ScheduleTaskRoutes(app *fiber.App) {
+	apiKey := AllowAPIKey(h.userUsecase)
+	view := h.guard(models.PermissionResourceProjects, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceProjects, models.PermissionActionEdit)
+	remove := h.guard(models.PermissionResourceProjects, models.PermissionActionDelete)
+
+	app.Get("/projects/:projectId/schedule-tasks", apiKey, view, h.List)
+	app.Post("/projects/:projectId/schedule-tasks", apiKey, edit, h.Create)
+	app.Post("/projects/:projectId/schedule-tasks/what-if", apiKey, view, h.WhatIf)
+
+	task := app.Group("/schedule-tasks", apiKey)
+	task.Put("/:taskId", edit, h.Update)
+	task.Delete("/:taskId", remove, h.Delete)
+	task.Put("/:taskId/crew", edit, h.AssignCrew)
+}
+
+func (h *ScheduleTaskHandler) Create(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.CreateScheduleTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	task, err := h.taskUseCase.Create(c.Context(), projectID, req)
+	if err != nil {
+		return scheduleTaskError(c, err, "Failed to create schedule task")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Schedule task created successfully",
+		"data":    task,
+	})
+}
+
+func (h *ScheduleTaskHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	tasks, err := h.taskUseCase.List(c.Context(), projectID)
+	if err != nil {
+		return scheduleTaskError(c, err, "Failed to retrieve schedule tasks")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Schedule tasks retrieved successfully",
+		"data":    tasks,
+	})
+}
+
+// WhatIf only reads the schedule, so viewing the project is enough.
+func (h *ScheduleTaskHandler) WhatIf(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.ScheduleWhatIfRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	result, err := h.taskUseCase.WhatIf(c.Context(), projectID, req)
+	if err != nil {
+		return scheduleTaskError(c, err, "Failed to compute schedule scenario")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Schedule scenario computed successfully",
+		"data":    result,
+	})
+}
+
+func (h *ScheduleTaskHandler) Update(c *fiber.Ctx) error {
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	var req requests.UpdateScheduleTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.taskUseCase.Update(c.Context(), taskID, req); err != nil {
+		return scheduleTaskError(c, err, "Failed to update schedule task")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Schedule task updated successfully",
+	})
+}
+
+func (h *ScheduleTaskHandler) Delete(c *fiber.Ctx) error {
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	if err := h.taskUseCase.Delete(c.Context(), taskID); err != nil {
+		return scheduleTaskError(c, err, "Failed to delete schedule task")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Schedule task deleted successfully",
+	})
+}
+
+func (h *ScheduleTaskHandler) AssignCrew(c *fiber.Ctx) error {
+	taskID, err := uuid.Parse(c.Params("taskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	var req requests.AssignCrewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.taskUseCase.AssignCrew(c.Context(), taskID, req); err != nil {
+		return scheduleTaskError(c, err, "Failed to assign crew")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Crew assigned successfully",
+	})
+}
+
+func scheduleTaskError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "schedule task not found", "phase not found", "crew not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "task name is required", "workers must be positive",
+		"invalid date format, expected YYYY-MM-DD", "end date must not be before start date",
+		"phase does not belong to the specified project",
+		"adjustments must be above -100 percent", "daily labor rate must be positive",
+		"daily labor rate is required when the project has no region", "project has no schedule tasks":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,496 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SubcontractHandler struct {
+	subcontractUseCase usecase.SubcontractUseCase
+	userUsecase        usecase.UserUsecase
+	guard              PermissionGuard
+}
+
+func NewSubcontractHandler(subcontractUseCase usecase.SubcontractUseCase, userUsecase usecase.UserUsecase, guard PermissionGuard) *SubcontractHandler {
+	return &SubcontractHandler{
+		subcontractUseCase: subcontractUseCase,
+		userUsecase:        userUsecase,
+		guard:              guard,
+	}
+}
+
+func (h *SubcontractHandler) SubcontractRoutes(app *fiber.App) {
+	subcontracts := app.Group("/subcontracts")
+	subcontracts.Post("/", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionEdit), h.Create)
+	subcontracts.Get("/projects/:projectId", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.ListByProject)
+	subcontracts.Get("/:id", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.GetByID)
+	subcontracts.Post("/:id/complete", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionEdit), h.Complete)
+
+	subcontracts.Get("/:id/certificates", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.ListCertificates)
+	subcontracts.Post("/:id/certificates", RequireAuth(h.userUsecase), h.CreateCertificate)
+
+	subcontracts.Get("/:id/items", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.ListItems)
+	subcontracts.Put("/:id/items", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionEdit), h.UpdateItems)
+
+	subcontracts.Get("/:id/claims", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.ListClaims)
+	subcontracts.Post("/:id/claims", RequireAuth(h.userUsecase), h.SubmitClaim)
+	subcontracts.Get("/:id/claims/:claimId", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.GetClaim)
+	subcontracts.Post("/:id/claims/:claimId/certify", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionApprove), h.CertifyClaim)
+	subcontracts.Post("/:id/claims/:claimId/reject", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionApprove), h.RejectClaim)
+
+	subcontracts.Get("/:id/retention-releases", h.guard(models.PermissionResourceSubcontracts, models.PermissionActionView), h.ListReleases)
+	subcontracts.Post("/:id/retention-releases", RequireAuth(h.userUsecase), h.RequestRelease)
+	subcontracts.Post("/:id/retention-releases/:releaseId/approve", RequireAuth(h.userUsecase), h.ApproveRelease)
+	subcontracts.Post("/:id/retention-releases/:releaseId/reject", RequireAuth(h.userUsecase), h.RejectRelease)
+}
+
+func (h *SubcontractHandler) Create(c *fiber.Ctx) error {
+	var req requests.CreateSubcontractRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	subcontract, err := h.subcontractUseCase.Create(c.Context(), req)
+	if err != nil {
+		return subcontractError(c, err, "Failed to create subcontract")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Subcontract created successfully",
+		"data":    subcontract,
+	})
+}
+
+func (h *SubcontractHandler) ListByProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	subcontracts, err := h.subcontractUseCase.ListByProject(c.Context(), projectID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to retrieve subcontracts")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Subcontracts retrieved successfully",
+		"data":    subcontracts,
+	})
+}
+
+func (h *SubcontractHandler) GetByID(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	subcontract, err := h.subcontractUseCase.GetByID(c.Context(), subcontractID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to retrieve subcontract")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Subcontract retrieved successfully",
+		"data":    subcontract,
+	})
+}
+
+func (h *SubcontractHandler) Complete(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	var req requests.CompleteSubcontractRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	subcontract, err := h.subcontractUseCase.Complete(c.Context(), subcontractID, req)
+	if err != nil {
+		return subcontractError(c, err, "Failed to complete subcontract")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Subcontract completed successfully",
+		"data":    subcontract,
+	})
+}
+
+func (h *SubcontractHandler) ListCertificates(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	certificates, err := h.subcontractUseCase.ListCertificates(c.Context(), subcontractID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to retrieve certificates")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Certificates retrieved successfully",
+		"data":    certificates,
+	})
+}
+
+func (h *SubcontractHandler) CreateCertificate(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	var req requests.CreateSubcontractCertificateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	certificate, err := h.subcontractUseCase.CreateCertificate(c.Context(), currentUserID(c), subcontractID, req)
+	if err != nil {
+		return subcontractError(c, err, "Failed to create certificate")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Certificate created successfully",
+		"data":    certificate,
+	})
+}
+
+func (h *SubcontractHandler) ListReleases(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	releases, err := h.subcontractUseCase.ListReleases(c.Context(), subcontractID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to retrieve retention releases")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Retention releases retrieved successfully",
+		"data":    releases,
+	})
+}
+
+func (h *SubcontractHandler) RequestRelease(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	var req requests.RequestRetentionReleaseRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	release, err := h.subcontractUseCase.RequestRelease(c.Context(), currentUserID(c), subcontractID, req)
+	if err != nil {
+		return subcontractError(c, err, "Failed to request retention release")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Retention release requested successfully",
+		"data":    release,
+	})
+}
+
+func (h *SubcontractHandler) ApproveRelease(c *fiber.Ctx) error {
+	subcontractID, releaseID, msg := retentionReleaseParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	release, err := h.subcontractUseCase.ApproveRelease(c.Context(), currentUserID(c), subcontractID, releaseID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to approve retention release")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Retention release approved successfully",
+		"data":    release,
+	})
+}
+
+func (h *SubcontractHandler) RejectRelease(c *fiber.Ctx) error {
+	subcontractID, releaseID, msg := retentionReleaseParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	release, err := h.subcontractUseCase.RejectRelease(c.Context(), currentUserID(c), subcontractID, releaseID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to reject retention release")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Retention release rejected successfully",
+		"data":    release,
+	})
+}
+
+func (h *SubcontractHandler) ListItems(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	items, err := h.subcontractUseCase.ListItems(c.Context(), subcontractID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to retrieve subcontract items")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Subcontract items retrieved successfully",
+		"data":    items,
+	})
+}
+
+func (h *SubcontractHandler) UpdateItems(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	var req requests.UpdateSubcontractItemsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	items, err := h.subcontractUseCase.UpdateItems(c.Context(), subcontractID, req)
+	if err != nil {
+		return subcontractError(c, err, "Failed to update subcontract items")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Subcontract items updated successfully",
+		"data":    items,
+	})
+}
+
+func (h *SubcontractHandler) ListClaims(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	claims, err := h.subcontractUseCase.ListClaims(c.Context(), subcontractID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to retrieve claims")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claims retrieved successfully",
+		"data":    claims,
+	})
+}
+
+func (h *SubcontractHandler) SubmitClaim(c *fiber.Ctx) error {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid subcontract ID",
+		})
+	}
+
+	var req requests.SubmitSubcontractClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	claim, err := h.subcontractUseCase.SubmitClaim(c.Context(), currentUserID(c), subcontractID, req)
+	if err != nil {
+		return subcontractError(c, err, "Failed to submit claim")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Claim submitted successfully",
+		"data":    claim,
+	})
+}
+
+func (h *SubcontractHandler) GetClaim(c *fiber.Ctx) error {
+	subcontractID, claimID, msg := subcontractClaimParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	claim, err := h.subcontractUseCase.GetClaim(c.Context(), subcontractID, claimID)
+	if err != nil {
+		return subcontractError(c, err, "Failed to retrieve claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim retrieved successfully",
+		"data":    claim,
+	})
+}
+
+func (h *SubcontractHandler) CertifyClaim(c *fiber.Ctx) error {
+	subcontractID, claimID, msg := subcontractClaimParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	var req requests.CertifySubcontractClaimRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	claim, err := h.subcontractUseCase.CertifyClaim(c.Context(), currentUserID(c), subcontractID, claimID, req)
+	if err != nil {
+		return subcontractError(c, err, "Failed to certify claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim certified successfully",
+		"data":    claim,
+	})
+}
+
+func (h *SubcontractHandler) RejectClaim(c *fiber.Ctx) error {
+	subcontractID, claimID, msg := subcontractClaimParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	var req requests.RejectSubcontractClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	claim, err := h.subcontractUseCase.RejectClaim(c.Context(), currentUserID(c), subcontractID, claimID, req)
+	if err != nil {
+		return subcontractError(c, err, "Failed to reject claim")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Claim rejected successfully",
+		"data":    claim,
+	})
+}
+
+func subcontractClaimParams(c *fiber.Ctx) (uuid.UUID, uuid.UUID, string) {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "Invalid subcontract ID"
+	}
+
+	claimID, err := uuid.Parse(c.Params("claimId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "Invalid claim ID"
+	}
+
+	return subcontractID, claimID, ""
+}
+
+func retentionReleaseParams(c *fiber.Ctx) (uuid.UUID, uuid.UUID, string) {
+	subcontractID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "Invalid subcontract ID"
+	}
+
+	releaseID, err := uuid.Parse(c.Params("releaseId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "Invalid release ID"
+	}
+
+	return subcontractID, releaseID, ""
+}
+
+func subcontractError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "subcontract not found", "project not found", "supplier not found", "retention release not found",
+		"claim not found", "subcontract item not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "subcontract already completed", "retention release already decided", "accounting period is locked",
+		"subcontract is not completed", "defects liability period has not ended", "no retention available for release",
+		"certified amount exceeds contract value", "cannot subcontract to a blacklisted supplier",
+		"cannot subcontract to a suspended supplier", "subcontract items have claims", "claim already decided",
+		"back-charges changed, please retry":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "scope is required", "contract value must be greater than 0",
+		"retention percent must be at least 0 and less than 100", "dlp months must not be negative",
+		"invalid date format, expected YYYY-MM-DD", "completion date must not be in the future",
+		"gross amount must be greater than 0", "release amount exceeds retention held",
+		"advance amount must be at least 0 and at most the contract value",
+		"advance recovery percent must be between 0 and 100",
+		"advance recovery percent is required when an advance is paid",
+		"item description is required", "item quantity must be greater than 0", "item rate must not be negative",
+		"at least one claim line is required", "each item can only be claimed once per claim",
+		"claimed quantity must be greater than 0", "claimed quantity exceeds remaining quantity",
+		"certified quantity must not be negative", "certified quantity exceeds remaining quantity",
+		"certified lines must be on the claim", "nothing certified, reject the claim instead", "reason is required":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -27,6 +27,7 @@ func (h *SupplierHandler) SupplierRoutes(app *fiber.App) {
 	supplier.Get("/:id", h.GetByID)
 	supplier.Put("/:id", h.Update)
 	supplier.Delete("/:id", h.Delete)
+	supplier.Put("/:id/status", h.UpdateStatus)
 }
 
 func (h *SupplierHandler) Create(c *fiber.Ctx) error {
@@ -62,8 +63,13 @@ func (h *SupplierHandler) List(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
 
-	response, err := h.supplierUsecase.List(c.Context(), page, pageSize)
+	response, err := h.supplierUsecase.List(c.Context(), page, pageSize, c.Query("status"))
 	if err != nil {
+		if err.Error() == "invalid supplier status" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to retrieve suppliers",
 		})
@@ -163,3 +169,41 @@ func (h *SupplierHandler) Delete(c *fiber.Ctx) error {
 		"message": "Supplier deleted successfully",
 	})
 }
+
+func (h *SupplierHandler) UpdateStatus(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid supplier ID",
+		})
+	}
+
+	var req requests.UpdateSupplierStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	err = h.supplierUsecase.UpdateStatus(c.Context(), id, req)
+	if err != nil {
+		switch err.Error() {
+		case "supplier not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Supplier not found",
+			})
+		case "invalid supplier status", "reason is required to suspend or blacklist a supplier":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update supplier status",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Supplier status updated successfully",
+	})
+}
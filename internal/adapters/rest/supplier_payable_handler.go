@@ -0,0 +1,188 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// SupplierPayableHandler serves supplier credit terms, their bills and the
+// payment calendar. The /suppliers routes fall under the route policies.
+type SupplierPayableHandler struct {
+	payableUseCase usecase.SupplierPayableUseCase
+	guard          PermissionGuard
+}
+
+func NewSupplierPayableHandler(payableUseCase usecase.SupplierPayableUseCase, guard PermissionGuard) *SupplierPayableHandler {
+	return &SupplierPayableHandler{
+		payableUseCase: payableUseCase,
+		guard:          guard,
+	}
+}
+
+func (h *SupplierPayableHandler) SupplierPayableRoutes(app *fiber.App) {
+	app.Get("/suppliers/:id/credit-terms", h.GetCreditTerms)
+	app.Put("/suppliers/:id/credit-terms", h.SetCreditTerms)
+	app.Get("/suppliers/:id/payables", h.ListBySupplier)
+	app.Post("/suppliers/:id/payables", h.Create)
+
+	payables := app.Group("/supplier-payables")
+	payables.Get("/calendar", h.guard(models.PermissionResourceInvoices, models.PermissionActionView), h.PaymentCalendar)
+	payables.Post("/:id/paid", h.guard(models.PermissionResourceInvoices, models.PermissionActionEdit), h.MarkPaid)
+}
+
+func (h *SupplierPayableHandler) GetCreditTerms(c *fiber.Ctx) error {
+	supplierID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid supplier ID",
+		})
+	}
+
+	terms, err := h.payableUseCase.GetCreditTerms(c.Context(), supplierID)
+	if err != nil {
+		return supplierPayableError(c, err, "Failed to retrieve credit terms")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Credit terms retrieved successfully",
+		"data":    terms,
+	})
+}
+
+func (h *SupplierPayableHandler) SetCreditTerms(c *fiber.Ctx) error {
+	supplierID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid supplier ID",
+		})
+	}
+
+	var req requests.SupplierCreditTermsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	terms, err := h.payableUseCase.SetCreditTerms(c.Context(), supplierID, req)
+	if err != nil {
+		return supplierPayableError(c, err, "Failed to update credit terms")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Credit terms updated successfully",
+		"data":    terms,
+	})
+}
+
+func (h *SupplierPayableHandler) Create(c *fiber.Ctx) error {
+	supplierID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid supplier ID",
+		})
+	}
+
+	var req requests.CreateSupplierPayableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	payable, err := h.payableUseCase.Create(c.Context(), supplierID, req)
+	if err != nil {
+		return supplierPayableError(c, err, "Failed to record supplier bill")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Supplier bill recorded successfully",
+		"data":    payable,
+	})
+}
+
+func (h *SupplierPayableHandler) ListBySupplier(c *fiber.Ctx) error {
+	supplierID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid supplier ID",
+		})
+	}
+
+	payables, err := h.payableUseCase.ListBySupplier(c.Context(), supplierID)
+	if err != nil {
+		return supplierPayableError(c, err, "Failed to retrieve supplier bills")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Supplier bills retrieved successfully",
+		"data":    payables,
+	})
+}
+
+func (h *SupplierPayableHandler) MarkPaid(c *fiber.Ctx) error {
+	payableID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid payable ID",
+		})
+	}
+
+	var req requests.MarkSupplierPayablePaidRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	if err := h.payableUseCase.MarkPaid(c.Context(), payableID, req); err != nil {
+		return supplierPayableError(c, err, "Failed to mark supplier bill paid")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Supplier bill marked paid successfully",
+	})
+}
+
+func (h *SupplierPayableHandler) PaymentCalendar(c *fiber.Ctx) error {
+	calendar, err := h.payableUseCase.PaymentCalendar(c.Context(), c.Query("week"))
+	if err != nil {
+		return supplierPayableError(c, err, "Failed to retrieve payment calendar")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Payment calendar retrieved successfully",
+		"data":    calendar,
+	})
+}
+
+func supplierPayableError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "supplier not found", "project not found", "supplier payable not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "basis must be invoice or receipt", "credit days cannot be negative",
+		"invoice number is required", "amount must be greater than 0",
+		"invalid invoice date format, expected YYYY-MM-DD", "invalid received date format, expected YYYY-MM-DD",
+		"invalid paid date format, expected YYYY-MM-DD", "paid date cannot be before the invoice date",
+		"invalid week format, expected YYYY-MM-DD":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "invoice number already recorded for this supplier", "supplier payable already paid":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SurveyHandler struct {
+	surveyUseCase usecase.SurveyUseCase
+	guard         PermissionGuard
+}
+
+func NewSurveyHandler(surveyUseCase usecase.SurveyUseCase, guard PermissionGuard) *SurveyHandler {
+	return &SurveyHandler{
+		surveyUseCase: surveyUseCase,
+		guard:         guard,
+	}
+}
+
+func (h *SurveyHandler) SurveyRoutes(app *fiber.App) {
+	surveys := app.Group("/surveys")
+	surveys.Get("/templates", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.ListTemplates)
+	surveys.Post("/templates", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.CreateTemplate)
+	surveys.Put("/templates/:id/default", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.SetDefaultTemplate)
+	surveys.Get("/reports", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetReport)
+	surveys.Get("/projects/:projectId", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetByProject)
+	surveys.Post("/projects/:projectId", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Send)
+
+	// Reached by the client through the signed link.
+	public := app.Group("/survey-responses")
+	public.Get("/:token", h.GetByToken)
+	public.Post("/:token", h.Submit)
+}
+
+func (h *SurveyHandler) CreateTemplate(c *fiber.Ctx) error {
+	var req requests.CreateSurveyTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	template, err := h.surveyUseCase.CreateTemplate(c.Context(), req)
+	if err != nil {
+		return surveyError(c, err, "Failed to create survey template")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Survey template created successfully",
+		"data":    template,
+	})
+}
+
+func (h *SurveyHandler) ListTemplates(c *fiber.Ctx) error {
+	templates, err := h.surveyUseCase.ListTemplates(c.Context())
+	if err != nil {
+		return surveyError(c, err, "Failed to retrieve survey templates")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Survey templates retrieved successfully",
+		"data":    templates,
+	})
+}
+
+func (h *SurveyHandler) SetDefaultTemplate(c *fiber.Ctx) error {
+	templateID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid template ID",
+		})
+	}
+
+	if err := h.surveyUseCase.SetDefaultTemplate(c.Context(), templateID); err != nil {
+		return surveyError(c, err, "Failed to set default survey template")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Default survey template set successfully",
+	})
+}
+
+func (h *SurveyHandler) GetReport(c *fiber.Ctx) error {
+	year := 0
+	if v := c.Query("year"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid year",
+			})
+		}
+		year = parsed
+	}
+
+	report, err := h.surveyUseCase.GetReport(c.Context(), year)
+	if err != nil {
+		return surveyError(c, err, "Failed to retrieve survey report")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Survey report retrieved successfully",
+		"data":    report,
+	})
+}
+
+func (h *SurveyHandler) GetByProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	survey, err := h.surveyUseCase.GetByProject(c.Context(), projectID)
+	if err != nil {
+		return surveyError(c, err, "Failed to retrieve survey")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Survey retrieved successfully",
+		"data":    survey,
+	})
+}
+
+func (h *SurveyHandler) Send(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.SendSurveyRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	survey, err := h.surveyUseCase.Send(c.Context(), projectID, req)
+	if err != nil {
+		return surveyError(c, err, "Failed to send survey")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Survey sent successfully",
+		"data":    survey,
+	})
+}
+
+func (h *SurveyHandler) GetByToken(c *fiber.Ctx) error {
+	survey, err := h.surveyUseCase.GetByToken(c.Context(), c.Params("token"))
+	if err != nil {
+		return surveyError(c, err, "Failed to retrieve survey")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Survey retrieved successfully",
+		"data":    survey,
+	})
+}
+
+func (h *SurveyHandler) Submit(c *fiber.Ctx) error {
+	var req requests.SubmitSurveyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.surveyUseCase.Submit(c.Context(), c.Params("token"), req); err != nil {
+		return surveyError(c, err, "Failed to submit survey")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Survey submitted successfully",
+	})
+}
+
+func surveyError(c *fiber.Ctx, err error, fallback string) error {
+	switch msg := err.Error(); {
+	case msg == "project not found", msg == "handover not found", msg == "survey not found",
+		msg == "survey template not found", msg == "no default survey template", msg == "user not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": msg,
+		})
+	case msg == "invalid or expired link":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": msg,
+		})
+	case msg == "survey already sent", msg == "survey has already been completed":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": msg,
+		})
+	case msg == "template name is required", msg == "at least one question is required",
+		msg == "question key and text are required", msg == "invalid question type",
+		msg == "question keys must be unique", msg == "a template can only have one nps question",
+		msg == "each question can only be answered once", msg == "answers must match the survey questions",
+		// Answer checks name the question they are about.
+		strings.HasPrefix(msg, "question "), strings.HasPrefix(msg, "score for "):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TenderHandler struct {
+	tenderUseCase usecase.TenderUseCase
+	guard         PermissionGuard
+}
+
+func NewTenderHandler(tenderUseCase usecase.TenderUseCase, guard PermissionGuard) *TenderHandler {
+	return &TenderHandler{
+		tenderUseCase: tenderUseCase,
+		guard:         guard,
+	}
+}
+
+func (h *TenderHandler) TenderRoutes(app *fiber.App) {
+	tender := app.Group("/tenders")
+	tender.Get("/", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.List)
+	tender.Post("/", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Create)
+	tender.Get("/:id", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetByID)
+	tender.Put("/:id", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Update)
+	tender.Delete("/:id", h.guard(models.PermissionResourceProjects, models.PermissionActionDelete), h.Delete)
+
+	tender.Post("/:id/checklist", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.AddDocument)
+	tender.Put("/:id/checklist/:documentId", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.UpdateDocument)
+	tender.Delete("/:id/checklist/:documentId", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.DeleteDocument)
+
+	tender.Put("/:id/worksheet", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.UpdateWorksheet)
+
+	tender.Post("/:id/submit", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.Submit)
+	tender.Put("/:id/outcome", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.RecordOutcome)
+}
+
+func (h *TenderHandler) Create(c *fiber.Ctx) error {
+	var req requests.TenderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	tender, err := h.tenderUseCase.Create(c.Context(), req)
+	if err != nil {
+		return tenderError(c, err, "Failed to create tender")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Tender created successfully",
+		"data":    tender,
+	})
+}
+
+func (h *TenderHandler) List(c *fiber.Ctx) error {
+	tenders, err := h.tenderUseCase.List(c.Context(), c.Query("status"))
+	if err != nil {
+		return tenderError(c, err, "Failed to retrieve tenders")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tenders retrieved successfully",
+		"data":    tenders,
+	})
+}
+
+func (h *TenderHandler) GetByID(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tender ID",
+		})
+	}
+
+	tender, err := h.tenderUseCase.GetByID(c.Context(), id)
+	if err != nil {
+		return tenderError(c, err, "Failed to retrieve tender")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tender retrieved successfully",
+		"data":    tender,
+	})
+}
+
+func (h *TenderHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tender ID",
+		})
+	}
+
+	var req requests.TenderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	tender, err := h.tenderUseCase.Update(c.Context(), id, req)
+	if err != nil {
+		return tenderError(c, err, "Failed to update tender")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tender updated successfully",
+		"data":    tender,
+	})
+}
+
+func (h *TenderHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tender ID",
+		})
+	}
+
+	if err := h.tenderUseCase.Delete(c.Context(), id); err != nil {
+		return tenderError(c, err, "Failed to delete tender")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tender deleted successfully",
+	})
+}
+
+func (h *TenderHandler) AddDocument(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tender ID",
+		})
+	}
+
+	var req requests.TenderDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	document, err := h.tenderUseCase.AddDocument(c.Context(), id, req)
+	if err != nil {
+		return tenderError(c, err, "Failed to add checklist document")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Checklist document added successfully",
+		"data":    document,
+	})
+}
+
+func (h *TenderHandler) UpdateDocument(c *fiber.Ctx) error {
+	id, documentID, msg := tenderDocumentParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	var req requests.TenderDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	document, err := h.tenderUseCase.UpdateDocument(c.Context(), id, documentID, req)
+	if err != nil {
+		return tenderError(c, err, "Failed to update checklist document")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Checklist document updated successfully",
+		"data":    document,
+	})
+}
+
+func (h *TenderHandler) DeleteDocument(c *fiber.Ctx) error {
+	id, documentID, msg := tenderDocumentParams(c)
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	if err := h.tenderUseCase.DeleteDocument(c.Context(), id, documentID); err != nil {
+		return tenderError(c, err, "Failed to delete checklist document")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Checklist document deleted successfully",
+	})
+}
+
+func (h *TenderHandler) UpdateWorksheet(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tender ID",
+		})
+	}
+
+	var req requests.TenderWorksheetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	tender, err := h.tenderUseCase.UpdateWorksheet(c.Context(), id, req)
+	if err != nil {
+		return tenderError(c, err, "Failed to update bid worksheet")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Bid worksheet updated successfully",
+		"data":    tender,
+	})
+}
+
+func (h *TenderHandler) Submit(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tender ID",
+		})
+	}
+
+	tender, err := h.tenderUseCase.Submit(c.Context(), id)
+	if err != nil {
+		return tenderError(c, err, "Failed to submit tender")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tender submitted successfully",
+		"data":    tender,
+	})
+}
+
+func (h *TenderHandler) RecordOutcome(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tender ID",
+		})
+	}
+
+	var req requests.TenderOutcomeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	tender, err := h.tenderUseCase.RecordOutcome(c.Context(), id, req)
+	if err != nil {
+		return tenderError(c, err, "Failed to record tender outcome")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tender outcome recorded successfully",
+		"data":    tender,
+	})
+}
+
+func tenderDocumentParams(c *fiber.Ctx) (uuid.UUID, uuid.UUID, string) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "Invalid tender ID"
+	}
+
+	documentID, err := uuid.Parse(c.Params("documentId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, "Invalid document ID"
+	}
+
+	return id, documentID, ""
+}
+
+func tenderError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "tender not found", "tender document not found", "project not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "can only modify tender in preparing status", "tender outcome is already recorded",
+		"tender must be submitted before recording a result", "submission deadline has passed":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "title and agency are required", "submission deadline is required", "bond amount must not be negative",
+		"document name is required", "invalid file URL", "bid line description is required",
+		"bid line quantity must be greater than 0", "bid line unit price must not be negative",
+		"bid worksheet is empty", "required documents are not complete", "invalid tender outcome",
+		"winning amount must not be negative":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
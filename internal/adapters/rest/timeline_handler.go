@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TimelineHandler struct {
+	activityUseCase usecase.ActivityUseCase
+	userUsecase     usecase.UserUsecase
+	guard           PermissionGuard
+}
+
+func NewTimelineHandler(activityUseCase usecase.ActivityUseCase, userUsecase usecase.UserUsecase, guard PermissionGuard) *TimelineHandler {
+	return &TimelineHandler{
+		activityUseCase: activityUseCase,
+		userUsecase:     userUsecase,
+		guard:           guard,
+	}
+}
+
+func (h *TimelineHandler) TimelineRoutes(app *fiber.App) {
+	app.Get("/projects/:projectId/timeline", h.GetProjectTimeline)
+	app.Get("/clients/:id/timeline", h.guard(models.PermissionResourceClients, models.PermissionActionView), h.GetClientTimeline)
+	app.Get("/quotations/projects/:projectId/timeline", h.GetQuotationTimeline)
+	app.Get("/users/:id/activity", RequireAuth(h.userUsecase), h.GetUserActivity)
+}
+
+func (h *TimelineHandler) GetProjectTimeline(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	timeline, err := h.activityUseCase.GetProjectTimeline(c.Context(), projectID)
+	if err != nil {
+		if err.Error() == "project not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Project not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve project timeline",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project timeline retrieved successfully",
+		"data":    timeline,
+	})
+}
+
+func (h *TimelineHandler) GetClientTimeline(c *fiber.Ctx) error {
+	clientID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid client ID",
+		})
+	}
+
+	timeline, err := h.activityUseCase.GetClientTimeline(c.Context(), clientID)
+	if err != nil {
+		if err.Error() == "client not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Client not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve client timeline",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Client timeline retrieved successfully",
+		"data":    timeline,
+	})
+}
+
+func (h *TimelineHandler) GetQuotationTimeline(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	timeline, err := h.activityUseCase.GetQuotationTimeline(c.Context(), projectID)
+	if err != nil {
+		if err.Error() == "quotation not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Quotation not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve quotation timeline",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Quotation timeline retrieved successfully",
+		"data":    timeline,
+	})
+}
+
+// GetUserActivity accepts ?page= and ?page_size= (default 20, at most 100).
+func (h *TimelineHandler) GetUserActivity(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "20"))
+	if pageSize > 100 {
+		pageSize = 20
+	}
+
+	activity, err := h.activityUseCase.GetUserActivity(c.Context(), currentUserID(c), userID, page, pageSize)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		case "only managers can review other users' activity":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve user activity",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User activity retrieved successfully",
+		"data":    activity,
+	})
+}
@@ -1,10 +1,13 @@
 package rest
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/usecase"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type UserHandler struct {
@@ -20,6 +23,38 @@ func NewUserHandler(userUsecase usecase.UserUsecase) *UserHandler {
 func (h *UserHandler) UserRoutes(app *fiber.App) {
 	app.Post("/login", h.Login)
 	app.Post("/register", h.Register)
+	app.Post("/users/refresh", h.Refresh)
+	app.Post("/users/oauth/google", h.LoginWithGoogle)
+	app.Post("/users/logout", RequireAuth(h.userUsecase), h.Logout)
+
+	me := app.Group("/users/me", RequireAuth(h.userUsecase))
+	me.Get("/", h.GetProfile)
+	me.Put("/", h.UpdateProfile)
+	me.Post("/avatar", h.UploadAvatar)
+	me.Put("/password", h.ChangePassword)
+	me.Get("/preferences", h.GetPreferences)
+	me.Put("/preferences", h.UpdatePreferences)
+	me.Get("/sessions", h.ListSessions)
+	me.Delete("/sessions/:sessionId", h.RevokeSession)
+
+	apiKeys := app.Group("/users/api-keys", RequireAuth(h.userUsecase))
+	apiKeys.Get("/", h.ListAPIKeys)
+	apiKeys.Post("/", h.CreateAPIKey)
+	apiKeys.Delete("/:keyId", h.RevokeAPIKey)
+
+	impersonations := app.Group("/admin/impersonations", RequireAuth(h.userUsecase))
+	impersonations.Get("/", h.ListImpersonations)
+	impersonations.Post("/", h.Impersonate)
+	app.Post("/admin/impersonate/:user_id", RequireAuth(h.userUsecase), h.ImpersonateUser)
+
+	app.Get("/admin/users", RequireAuth(h.userUsecase), h.ListUsers)
+	app.Put("/admin/users/:id/role", RequireAuth(h.userUsecase), h.UpdateRole)
+	app.Post("/admin/users/:id/unlock", RequireAuth(h.userUsecase), h.UnlockUser)
+	app.Post("/admin/users/:id/deactivate", RequireAuth(h.userUsecase), h.DeactivateUser)
+	app.Post("/admin/users/:id/reactivate", RequireAuth(h.userUsecase), h.ReactivateUser)
+	app.Post("/admin/users/:id/reset-password", RequireAuth(h.userUsecase), h.ResetPassword)
+	app.Post("/admin/users/:id/force-password-reset", RequireAuth(h.userUsecase), h.ForcePasswordReset)
+	app.Get("/users/:id/logins", RequireAuth(h.userUsecase), h.ListLogins)
 }
 
 func (uh *UserHandler) Login(c *fiber.Ctx) error {
@@ -31,10 +66,29 @@ func (uh *UserHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	loginResponse, err := uh.userUsecase.Login(c.Context(), loginRequest)
+	loginResponse, err := uh.userUsecase.Login(c.Context(), loginRequest, loginClient(c))
 	if err != nil {
+		if err.Error() == "account locked" {
+			return c.Status(fiber.StatusLocked).JSON(fiber.Map{
+				"error": "Account is locked after too many failed login attempts",
+				"code":  "account_locked",
+			})
+		}
+		if err.Error() == "account deactivated" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Account has been deactivated",
+				"code":  "account_deactivated",
+			})
+		}
+		if err.Error() == "directory unavailable" {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "The login directory is unavailable, try again later",
+				"code":  "directory_unavailable",
+			})
+		}
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": "Invalid credentials",
+			"code":  "invalid_credentials",
 		})
 	}
 
@@ -46,6 +100,93 @@ func (uh *UserHandler) Login(c *fiber.Ctx) error {
 	)
 }
 
+// loginClient fingerprints the device from its headers and the optional
+// X-Device-ID the client keeps for itself.
+func loginClient(c *fiber.Ctx) models.LoginClient {
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	return models.LoginClient{
+		IPAddress:   c.IP(),
+		UserAgent:   userAgent,
+		Fingerprint: models.DeviceFingerprint(userAgent, c.Get(fiber.HeaderAcceptLanguage), c.Get("X-Device-ID")),
+	}
+}
+
+func (uh *UserHandler) LoginWithGoogle(c *fiber.Ctx) error {
+	var req requests.GoogleLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	loginResponse, err := uh.userUsecase.LoginWithGoogle(c.Context(), req, loginClient(c))
+	if err != nil {
+		switch err.Error() {
+		case "invalid id token", "google account email is not verified":
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "google account is not allowed":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "username already exists":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "A user with this email as username already exists",
+			})
+		case "account locked":
+			return c.Status(fiber.StatusLocked).JSON(fiber.Map{
+				"error": "Account is locked after too many failed login attempts",
+				"code":  "account_locked",
+			})
+		case "account deactivated":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Account has been deactivated",
+				"code":  "account_deactivated",
+			})
+		case "google sign-in is not configured":
+			return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to sign in with Google",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Login successful",
+		"data":    loginResponse,
+	})
+}
+
+func (uh *UserHandler) Refresh(c *fiber.Ctx) error {
+	var req requests.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	loginResponse, err := uh.userUsecase.Refresh(c.Context(), req, loginClient(c))
+	if err != nil {
+		switch err.Error() {
+		case "invalid refresh token", "refresh token expired", "user not found", "account deactivated":
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to refresh token",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Token refreshed successfully",
+		"data":    loginResponse,
+	})
+}
+
 func (uh *UserHandler) Register(c *fiber.Ctx) error {
 	var registerRequest requests.RegisterRequest
 
@@ -57,6 +198,11 @@ func (uh *UserHandler) Register(c *fiber.Ctx) error {
 
 	err := uh.userUsecase.Register(c.Context(), registerRequest)
 	if err != nil {
+		if isPasswordPolicyError(err) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to create user",
 		})
@@ -66,3 +212,623 @@ func (uh *UserHandler) Register(c *fiber.Ctx) error {
 		"message": "User created successfully",
 	})
 }
+
+// isPasswordPolicyError reports whether err is a password the policy
+// refused, which is the caller's to fix.
+func isPasswordPolicyError(err error) bool {
+	return strings.HasPrefix(err.Error(), "password ")
+}
+
+func (uh *UserHandler) ChangePassword(c *fiber.Ctx) error {
+	var req requests.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := uh.userUsecase.ChangePassword(c.Context(), currentUserID(c), req); err != nil {
+		if isPasswordPolicyError(err) || err.Error() == "current password is incorrect" ||
+			err.Error() == "new password must differ from the current one" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to change password",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Password changed successfully",
+	})
+}
+
+func (uh *UserHandler) ResetPassword(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req requests.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := uh.userUsecase.ResetPassword(c.Context(), currentUserID(c), userID, req); err != nil {
+		switch {
+		case isPasswordPolicyError(err):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case err.Error() == "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case err.Error() == "only owners can access this resource":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to reset password",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Password reset successfully",
+	})
+}
+
+func (uh *UserHandler) GetProfile(c *fiber.Ctx) error {
+	profile, err := uh.userUsecase.GetProfile(c.Context(), currentUserID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve profile",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Profile retrieved successfully",
+		"data":    profile,
+	})
+}
+
+func (uh *UserHandler) UpdateProfile(c *fiber.Ctx) error {
+	var req requests.UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	profile, err := uh.userUsecase.UpdateProfile(c.Context(), currentUserID(c), req)
+	if err != nil {
+		switch err.Error() {
+		case "first name and last name are required", "tel must be 10 digits":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update profile",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Profile updated successfully",
+		"data":    profile,
+	})
+}
+
+// UploadAvatar takes the image as the multipart field "avatar".
+func (uh *UserHandler) UploadAvatar(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Avatar file is required",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to read avatar file",
+		})
+	}
+	defer file.Close()
+
+	profile, err := uh.userUsecase.UploadAvatar(c.Context(), currentUserID(c), file)
+	if err != nil {
+		switch err.Error() {
+		case "avatar must not exceed 2 MB", "avatar must be a JPEG or PNG image":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "file storage is not configured":
+			return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to upload avatar",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Avatar uploaded successfully",
+		"data":    profile,
+	})
+}
+
+func (uh *UserHandler) GetPreferences(c *fiber.Ctx) error {
+	preferences, err := uh.userUsecase.GetPreferences(c.Context(), currentUserID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve preferences",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Preferences retrieved successfully",
+		"data":    preferences,
+	})
+}
+
+// UpdatePreferences replaces the whole preferences document.
+func (uh *UserHandler) UpdatePreferences(c *fiber.Ctx) error {
+	preferences, err := uh.userUsecase.UpdatePreferences(c.Context(), currentUserID(c), c.Body())
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid preferences") || err.Error() == "preferences must not exceed 64 KB" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update preferences",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Preferences updated successfully",
+		"data":    preferences,
+	})
+}
+
+func (uh *UserHandler) Impersonate(c *fiber.Ctx) error {
+	var req requests.ImpersonateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	response, err := uh.userUsecase.Impersonate(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return impersonationError(c, err, "Failed to start impersonation")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Impersonation started successfully",
+		"data":    response,
+	})
+}
+
+// ImpersonateUser is Impersonate with the user in the path; the body only
+// carries the reason.
+func (uh *UserHandler) ImpersonateUser(c *fiber.Ctx) error {
+	var req requests.ImpersonateRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+	req.UserID = c.Params("user_id")
+
+	response, err := uh.userUsecase.Impersonate(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return impersonationError(c, err, "Failed to start impersonation")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Impersonation started successfully",
+		"data":    response,
+	})
+}
+
+func (uh *UserHandler) ListImpersonations(c *fiber.Ctx) error {
+	sessions, err := uh.userUsecase.ListImpersonations(c.Context(), currentUserID(c))
+	if err != nil {
+		return impersonationError(c, err, "Failed to retrieve impersonations")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Impersonations retrieved successfully",
+		"data":    sessions,
+	})
+}
+
+func (uh *UserHandler) UpdateRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req requests.UpdateUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	user, err := uh.userUsecase.UpdateRole(c.Context(), currentUserID(c), userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "only owners can access this resource", "only owners can manage owners":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "invalid role", "cannot change your own role":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update user role",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User role updated successfully",
+		"data":    user,
+	})
+}
+
+func (uh *UserHandler) Logout(c *fiber.Ctx) error {
+	var req requests.LogoutRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	if err := uh.userUsecase.Logout(c.Context(), bearerToken(c), req); err != nil {
+		switch err.Error() {
+		case "invalid token":
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid access token",
+			})
+		case "invalid refresh token":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to log out",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Logged out successfully",
+	})
+}
+
+func (uh *UserHandler) ListSessions(c *fiber.Ctx) error {
+	var current uuid.NullUUID
+	if sessionID, ok := c.Locals(sessionIDLocal).(uuid.UUID); ok {
+		current = uuid.NullUUID{UUID: sessionID, Valid: true}
+	}
+
+	sessions, err := uh.userUsecase.ListSessions(c.Context(), currentUserID(c), current)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve sessions",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Sessions retrieved successfully",
+		"data":    sessions,
+	})
+}
+
+func (uh *UserHandler) RevokeSession(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid session ID",
+		})
+	}
+
+	if err := uh.userUsecase.RevokeSession(c.Context(), currentUserID(c), sessionID); err != nil {
+		if err.Error() == "session not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to revoke session",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Session revoked successfully",
+	})
+}
+
+// ListUsers accepts ?search=, ?role=, ?status=active|inactive, ?page= and
+// ?page_size= (default 20, at most 100).
+func (uh *UserHandler) ListUsers(c *fiber.Ctx) error {
+	users, err := uh.userUsecase.ListUsers(c.Context(), currentUserID(c), requests.ListUsersRequest{
+		Search:   c.Query("search"),
+		Role:     c.Query("role"),
+		Status:   c.Query("status"),
+		Page:     c.QueryInt("page", 1),
+		PageSize: c.QueryInt("page_size", 20),
+	})
+	if err != nil {
+		switch err.Error() {
+		case "only owners can access this resource":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "invalid role", "status must be active or inactive":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve users",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Users retrieved successfully",
+		"data":    users,
+	})
+}
+
+// ForcePasswordReset makes the user choose a new password the next time
+// they sign in.
+func (uh *UserHandler) ForcePasswordReset(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	if err := uh.userUsecase.ForcePasswordReset(c.Context(), currentUserID(c), userID); err != nil {
+		return userStatusError(c, err, "Failed to force password reset")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User must change their password at next sign-in",
+	})
+}
+
+func (uh *UserHandler) UnlockUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	if err := uh.userUsecase.UnlockUser(c.Context(), currentUserID(c), userID); err != nil {
+		switch err.Error() {
+		case "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "only owners can access this resource":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to unlock user",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User unlocked successfully",
+	})
+}
+
+func (uh *UserHandler) DeactivateUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	user, err := uh.userUsecase.DeactivateUser(c.Context(), currentUserID(c), userID)
+	if err != nil {
+		return userStatusError(c, err, "Failed to deactivate user")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User deactivated successfully",
+		"data":    user,
+	})
+}
+
+func (uh *UserHandler) ReactivateUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	user, err := uh.userUsecase.ReactivateUser(c.Context(), currentUserID(c), userID)
+	if err != nil {
+		return userStatusError(c, err, "Failed to reactivate user")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User reactivated successfully",
+		"data":    user,
+	})
+}
+
+func userStatusError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource", "only owners can manage owners":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "cannot deactivate yourself", "password is managed by the directory":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
+
+// ListLogins accepts ?limit= (default 100, at most 500).
+func (uh *UserHandler) ListLogins(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	logins, err := uh.userUsecase.ListLogins(c.Context(), currentUserID(c), userID, c.QueryInt("limit", 100))
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case "only owners can access this resource":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve logins",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Logins retrieved successfully",
+		"data":    logins,
+	})
+}
+
+func (uh *UserHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req requests.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	key, err := uh.userUsecase.CreateAPIKey(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return apiKeyError(c, err, "Failed to create API key")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "API key created successfully",
+		"data":    key,
+	})
+}
+
+func (uh *UserHandler) ListAPIKeys(c *fiber.Ctx) error {
+	keys, err := uh.userUsecase.ListAPIKeys(c.Context(), currentUserID(c))
+	if err != nil {
+		return apiKeyError(c, err, "Failed to retrieve API keys")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "API keys retrieved successfully",
+		"data":    keys,
+	})
+}
+
+func (uh *UserHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	keyID, err := uuid.Parse(c.Params("keyId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+
+	if err := uh.userUsecase.RevokeAPIKey(c.Context(), currentUserID(c), keyID); err != nil {
+		return apiKeyError(c, err, "Failed to revoke API key")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "API key revoked successfully",
+	})
+}
+
+func apiKeyError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "api key not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "name is required", "expiry must be in the future":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "api keys cannot be created while impersonating":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
+
+func impersonationError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "user not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "only owners can access this resource", "owners cannot be impersonated":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "reason is required", "invalid user ID", "cannot impersonate yourself", "account deactivated":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
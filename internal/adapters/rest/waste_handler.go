@@ -0,0 +1,180 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WasteHandler struct {
+	wasteUseCase usecase.WasteUseCase
+	guard        PermissionGuard
+}
+
+func NewWasteHandler(wasteUseCase usecase.WasteUseCase, guard PermissionGuard) *WasteHandler {
+	return &WasteHandler{
+		wasteUseCase: wasteUseCase,
+		guard:        guard,
+	}
+}
+
+func (h *WasteHandler) WasteRoutes(app *fiber.App) {
+	view := h.guard(models.PermissionResourceMaterials, models.PermissionActionView)
+	edit := h.guard(models.PermissionResourceMaterials, models.PermissionActionEdit)
+
+	app.Get("/projects/:projectId/waste", view, h.List)
+	app.Post("/projects/:projectId/waste", edit, h.Record)
+	app.Get("/projects/:projectId/waste/summary", view, h.Summary)
+	app.Get("/waste/alerts", view, h.Alerts)
+
+	allowances := app.Group("/wastage-allowances")
+	allowances.Get("/", view, h.ListAllowances)
+	allowances.Put("/:materialId", edit, h.SetAllowance)
+	allowances.Delete("/:materialId", edit, h.DeleteAllowance)
+}
+
+func (h *WasteHandler) Record(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req requests.RecordWasteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	record, err := h.wasteUseCase.Record(c.Context(), projectID, req)
+	if err != nil {
+		return wasteError(c, err, "Failed to record waste")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Waste recorded successfully",
+		"data":    record,
+	})
+}
+
+func (h *WasteHandler) List(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	records, err := h.wasteUseCase.List(c.Context(), projectID)
+	if err != nil {
+		return wasteError(c, err, "Failed to retrieve waste records")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Waste records retrieved successfully",
+		"data":    records,
+	})
+}
+
+func (h *WasteHandler) Summary(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	summary, err := h.wasteUseCase.Summary(c.Context(), projectID)
+	if err != nil {
+		return wasteError(c, err, "Failed to retrieve waste summary")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Waste summary retrieved successfully",
+		"data":    summary,
+	})
+}
+
+func (h *WasteHandler) Alerts(c *fiber.Ctx) error {
+	alerts, err := h.wasteUseCase.Alerts(c.Context())
+	if err != nil {
+		return wasteError(c, err, "Failed to retrieve waste alerts")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Waste alerts retrieved successfully",
+		"data":    alerts,
+	})
+}
+
+func (h *WasteHandler) ListAllowances(c *fiber.Ctx) error {
+	allowances, err := h.wasteUseCase.ListAllowances(c.Context())
+	if err != nil {
+		return wasteError(c, err, "Failed to retrieve wastage allowances")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Wastage allowances retrieved successfully",
+		"data":    allowances,
+	})
+}
+
+func (h *WasteHandler) SetAllowance(c *fiber.Ctx) error {
+	var req requests.SetWastageAllowanceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.wasteUseCase.SetAllowance(c.Context(), c.Params("materialId"), req); err != nil {
+		return wasteError(c, err, "Failed to set wastage allowance")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Wastage allowance set successfully",
+	})
+}
+
+func (h *WasteHandler) DeleteAllowance(c *fiber.Ctx) error {
+	if err := h.wasteUseCase.DeleteAllowance(c.Context(), c.Params("materialId")); err != nil {
+		return wasteError(c, err, "Failed to remove wastage allowance")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Wastage allowance removed successfully",
+	})
+}
+
+func wasteError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "project not found", "material not found", "warehouse not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "project access denied":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "quantity must be greater than 0", "waste reason is required",
+		"invalid date format, expected YYYY-MM-DD", "allowance must be between 0 and 100 percent",
+		"project has no site store, warehouse is required",
+		"project has several site stores, warehouse is required":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case "insufficient stock", "warehouse is closed", "project is closed":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
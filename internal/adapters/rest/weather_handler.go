@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WeatherHandler struct {
+	weatherUseCase usecase.WeatherUseCase
+	guard          PermissionGuard
+}
+
+func NewWeatherHandler(weatherUseCase usecase.WeatherUseCase, guard PermissionGuard) *WeatherHandler {
+	return &WeatherHandler{
+		weatherUseCase: weatherUseCase,
+		guard:          guard,
+	}
+}
+
+func (h *WeatherHandler) WeatherRoutes(app *fiber.App) {
+	weather := app.Group("/weather")
+	weather.Get("/risks", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetRisks)
+	weather.Post("/refresh", h.guard(models.PermissionResourceProjects, models.PermissionActionEdit), h.RefreshForecasts)
+
+	app.Get("/projects/:projectId/weather-risks", h.guard(models.PermissionResourceProjects, models.PermissionActionView), h.GetProjectRisks)
+}
+
+func (h *WeatherHandler) GetRisks(c *fiber.Ctx) error {
+	risks, err := h.weatherUseCase.GetRisks(c.Context(), nil)
+	if err != nil {
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve weather risks",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Weather risks retrieved successfully",
+		"data":    risks,
+	})
+}
+
+func (h *WeatherHandler) GetProjectRisks(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	risks, err := h.weatherUseCase.GetRisks(c.Context(), &projectID)
+	if err != nil {
+		if err.Error() == "project not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Project not found",
+			})
+		}
+		if err.Error() == "project access denied" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve weather risks",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Weather risks retrieved successfully",
+		"data":    risks,
+	})
+}
+
+func (h *WeatherHandler) RefreshForecasts(c *fiber.Ctx) error {
+	if err := h.weatherUseCase.RefreshForecasts(c.Context()); err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Weather forecasts refreshed successfully",
+	})
+}
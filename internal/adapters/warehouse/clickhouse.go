@@ -0,0 +1,86 @@
+package warehouse
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type clickHouseSink struct {
+	client   *http.Client
+	baseURL  string
+	database string
+	user     string
+	password string
+}
+
+// NewClickHouseSink inserts batches over ClickHouse's HTTP interface into
+// <database>.<entity>_v<version>. The tables are expected to exist with the
+// exported columns plus an _exported_at DateTime column.
+func NewClickHouseSink(baseURL, database, user, password string) repositories.DWSink {
+	return &clickHouseSink{
+		client:   &http.Client{Timeout: 60 * time.Second},
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		database: database,
+		user:     user,
+		password: password,
+	}
+}
+
+func (s *clickHouseSink) Name() string {
+	return "clickhouse"
+}
+
+func (s *clickHouseSink) Write(ctx context.Context, batch models.DWBatch) error {
+	var body bytes.Buffer
+	w := csv.NewWriter(&body)
+
+	exportedAt := batch.ExportedAt.UTC().Format("2006-01-02 15:04:05")
+	if err := w.Write(append(append([]string{}, batch.Columns...), "_exported_at")); err != nil {
+		return fmt.Errorf("failed to encode batch header: %w", err)
+	}
+	for _, row := range batch.Rows {
+		if err := w.Write(append(append([]string{}, row...), exportedAt)); err != nil {
+			return fmt.Errorf("failed to encode batch row: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to encode batch: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("query", fmt.Sprintf("INSERT INTO %s.%s_v%d FORMAT CSVWithNames", s.database, batch.Entity, batch.SchemaVersion))
+	// Empty CSV fields are NULLs, not empty strings, for nullable columns.
+	params.Set("input_format_csv_empty_as_default", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/?"+params.Encode(), &body)
+	if err != nil {
+		return fmt.Errorf("failed to create clickhouse request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/csv")
+	if s.user != "" {
+		req.SetBasicAuth(s.user, s.password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send batch to clickhouse: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("clickhouse returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
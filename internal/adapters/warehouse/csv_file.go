@@ -0,0 +1,74 @@
+package warehouse
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type csvFileSink struct {
+	dir string
+}
+
+// NewCSVFileSink writes each batch to
+// <dir>/<entity>/v<version>/<entity>_<timestamp>[_full].csv, a layout that
+// can be synced to object storage as-is and loaded by the BI tools.
+func NewCSVFileSink(dir string) repositories.DWSink {
+	return &csvFileSink{
+		dir: dir,
+	}
+}
+
+func (s *csvFileSink) Name() string {
+	return "csv"
+}
+
+func (s *csvFileSink) Write(ctx context.Context, batch models.DWBatch) error {
+	dir := filepath.Join(s.dir, batch.Entity, fmt.Sprintf("v%d", batch.SchemaVersion))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s_%s", batch.Entity, batch.ExportedAt.UTC().Format("20060102T150405Z"))
+	if batch.Full {
+		name += "_full"
+	}
+	path := filepath.Join(dir, name+".csv")
+
+	// Write to a temporary file first so loaders never pick up a partial
+	// export.
+	tmp, err := os.CreateTemp(dir, ".export-*")
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := writeCSV(tmp, batch); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close export file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move export file: %w", err)
+	}
+
+	return nil
+}
+
+func writeCSV(f *os.File, batch models.DWBatch) error {
+	w := csv.NewWriter(f)
+	if err := w.Write(batch.Columns); err != nil {
+		return fmt.Errorf("failed to write export header: %w", err)
+	}
+	if err := w.WriteAll(batch.Rows); err != nil {
+		return fmt.Errorf("failed to write export rows: %w", err)
+	}
+	return nil
+}
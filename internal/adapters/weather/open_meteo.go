@@ -0,0 +1,139 @@
+package weather
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	openMeteoForecastURL  = "https://api.open-meteo.com/v1/forecast"
+	openMeteoGeocodingURL = "https://geocoding-api.open-meteo.com/v1/search"
+	openMeteoForecastDays = 16
+)
+
+type openMeteoProvider struct {
+	client *http.Client
+}
+
+// NewOpenMeteoProvider returns a keyless provider backed by Open-Meteo,
+// which forecasts up to 16 days ahead.
+func NewOpenMeteoProvider() repositories.WeatherProvider {
+	return &openMeteoProvider{
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *openMeteoProvider) Name() string {
+	return "open-meteo"
+}
+
+type openMeteoGeocodingResponse struct {
+	Results []struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+	} `json:"results"`
+}
+
+func (p *openMeteoProvider) Geocode(ctx context.Context, query string) (*models.GeoLocation, error) {
+	params := url.Values{}
+	params.Set("name", query)
+	params.Set("count", "1")
+	params.Set("language", "th")
+	params.Set("countryCode", "TH")
+
+	var body openMeteoGeocodingResponse
+	if err := p.get(ctx, openMeteoGeocodingURL+"?"+params.Encode(), &body); err != nil {
+		return nil, err
+	}
+
+	if len(body.Results) == 0 {
+		return nil, errors.New("location not found")
+	}
+
+	return &models.GeoLocation{
+		Latitude:  body.Results[0].Latitude,
+		Longitude: body.Results[0].Longitude,
+	}, nil
+}
+
+type openMeteoForecastResponse struct {
+	Daily struct {
+		Time                        []string   `json:"time"`
+		PrecipitationSum            []*float64 `json:"precipitation_sum"`
+		PrecipitationProbabilityMax []*float64 `json:"precipitation_probability_max"`
+	} `json:"daily"`
+}
+
+func (p *openMeteoProvider) DailyForecast(ctx context.Context, location models.GeoLocation) ([]models.WeatherForecast, error) {
+	params := url.Values{}
+	params.Set("latitude", fmt.Sprintf("%.4f", location.Latitude))
+	params.Set("longitude", fmt.Sprintf("%.4f", location.Longitude))
+	params.Set("daily", "precipitation_sum,precipitation_probability_max")
+	params.Set("timezone", "Asia/Bangkok")
+	params.Set("forecast_days", fmt.Sprint(openMeteoForecastDays))
+
+	var body openMeteoForecastResponse
+	if err := p.get(ctx, openMeteoForecastURL+"?"+params.Encode(), &body); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var forecasts []models.WeatherForecast
+	for i, day := range body.Daily.Time {
+		date, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		if i >= len(body.Daily.PrecipitationSum) || body.Daily.PrecipitationSum[i] == nil {
+			continue
+		}
+
+		forecast := models.WeatherForecast{
+			ForecastDate:    date,
+			PrecipitationMM: *body.Daily.PrecipitationSum[i],
+			Source:          p.Name(),
+			FetchedAt:       now,
+		}
+		if i < len(body.Daily.PrecipitationProbabilityMax) && body.Daily.PrecipitationProbabilityMax[i] != nil {
+			forecast.PrecipitationProbability = sql.NullFloat64{Float64: *body.Daily.PrecipitationProbabilityMax[i], Valid: true}
+		}
+		forecasts = append(forecasts, forecast)
+	}
+
+	if len(forecasts) == 0 {
+		return nil, errors.New("open-meteo returned no forecast")
+	}
+
+	return forecasts, nil
+}
+
+func (p *openMeteoProvider) get(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call open-meteo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("open-meteo returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode open-meteo response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountingPeriodLayout formats a period as its month, e.g. "2024-03".
+const AccountingPeriodLayout = "2006-01"
+
+// AccountingPeriodLocation is where month boundaries fall for period
+// locking, matching the VAT report.
+var AccountingPeriodLocation = time.FixedZone("ICT", 7*60*60)
+
+// AccountingPeriodOf returns the period t falls in.
+func AccountingPeriodOf(t time.Time) string {
+	return t.In(AccountingPeriodLocation).Format(AccountingPeriodLayout)
+}
+
+// AccountingPeriodLock closes a month to postings dated in it.
+type AccountingPeriodLock struct {
+	Period   string    `db:"period"`
+	LockedAt time.Time `db:"locked_at"`
+	LockedBy uuid.UUID `db:"locked_by"`
+}
+
+type AccountingPeriodAction string
+
+const (
+	AccountingPeriodActionLock   AccountingPeriodAction = "lock"
+	AccountingPeriodActionUnlock AccountingPeriodAction = "unlock"
+)
+
+// AccountingPeriodEvent is the audit trail of locks and unlocks.
+type AccountingPeriodEvent struct {
+	EventID    uuid.UUID              `db:"event_id"`
+	Period     string                 `db:"period"`
+	Action     AccountingPeriodAction `db:"action"`
+	UserID     uuid.UUID              `db:"user_id"`
+	Reason     string                 `db:"reason"`
+	OccurredAt time.Time              `db:"occurred_at"`
+}
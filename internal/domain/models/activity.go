@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ActivityEntityType string
+
+const (
+	ActivityEntityProject   ActivityEntityType = "project"
+	ActivityEntityClient    ActivityEntityType = "client"
+	ActivityEntityQuotation ActivityEntityType = "quotation"
+	ActivityEntityBOQ       ActivityEntityType = "boq"
+	ActivityEntityContract  ActivityEntityType = "contract"
+	ActivityEntityInvoice   ActivityEntityType = "invoice"
+	ActivityEntityPayment   ActivityEntityType = "payment"
+	ActivityEntityUser      ActivityEntityType = "user"
+)
+
+// ActivityEvent is a recorded domain event for things that leave no
+// timestamp of their own, such as status changes and approvals.
+type ActivityEvent struct {
+	EventID     uuid.UUID          `db:"event_id"`
+	EntityType  ActivityEntityType `db:"entity_type"`
+	EntityID    uuid.UUID          `db:"entity_id"`
+	ProjectID   uuid.NullUUID      `db:"project_id"`
+	ClientID    uuid.NullUUID      `db:"client_id"`
+	EventType   string             `db:"event_type"`
+	Description string             `db:"description"`
+	OccurredAt  time.Time          `db:"occurred_at"`
+	// ActorID is the user who caused the event; it is null for events
+	// from scheduled jobs.
+	ActorID uuid.NullUUID `db:"actor_id"`
+	// ImpersonatorID is set when the event happened during an
+	// impersonation session.
+	ImpersonatorID uuid.NullUUID `db:"impersonator_id"`
+}
+
+// TimelineEvent is one row of an entity's timeline, either a recorded
+// ActivityEvent or derived from a document's own timestamps.
+type TimelineEvent struct {
+	EntityType  ActivityEntityType `db:"entity_type"`
+	EntityID    uuid.UUID          `db:"entity_id"`
+	ProjectID   uuid.NullUUID      `db:"project_id"`
+	EventType   string             `db:"event_type"`
+	Description string             `db:"description"`
+	OccurredAt  time.Time          `db:"occurred_at"`
+}
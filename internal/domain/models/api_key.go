@@ -0,0 +1,31 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey lets a machine client call the API as the user who created it,
+// with that user's permissions. Like refresh tokens only the SHA-256 of
+// the key is stored; Prefix is kept so users can tell their keys apart.
+type APIKey struct {
+	KeyID      uuid.UUID    `db:"key_id"`
+	UserID     uuid.UUID    `db:"user_id"`
+	Name       string       `db:"name"`
+	Prefix     string       `db:"prefix"`
+	KeyHash    string       `db:"key_hash"`
+	ExpiresAt  sql.NullTime `db:"expires_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at"`
+}
+
+// Active reports whether the key may still be used at now.
+func (k APIKey) Active(now time.Time) bool {
+	if k.RevokedAt.Valid {
+		return false
+	}
+	return !k.ExpiresAt.Valid || now.Before(k.ExpiresAt.Time)
+}
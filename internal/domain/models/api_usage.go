@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIUsage is the daily rollup of requests to one route by one caller.
+// UserID is null for requests that weren't authenticated and KeyID for
+// requests made with a bearer token rather than an API key.
+type APIUsage struct {
+	UsageDate    time.Time     `db:"usage_date"`
+	UserID       uuid.NullUUID `db:"user_id"`
+	KeyID        uuid.NullUUID `db:"key_id"`
+	Route        string        `db:"route"`
+	RequestCount int64         `db:"request_count"`
+	ClientErrors int64         `db:"client_errors"`
+	ServerErrors int64         `db:"server_errors"`
+	BytesIn      int64         `db:"bytes_in"`
+	BytesOut     int64         `db:"bytes_out"`
+}
+
+// APIUsageGrouping is what the usage report totals by.
+type APIUsageGrouping string
+
+const (
+	APIUsageByDay    APIUsageGrouping = "day"
+	APIUsageByCaller APIUsageGrouping = "caller"
+	APIUsageByRoute  APIUsageGrouping = "route"
+)
+
+func (g APIUsageGrouping) Valid() bool {
+	switch g {
+	case APIUsageByDay, APIUsageByCaller, APIUsageByRoute:
+		return true
+	}
+	return false
+}
+
+// APIRequest is one served request, as counted into APIUsage.
+type APIRequest struct {
+	At       time.Time
+	UserID   uuid.NullUUID
+	KeyID    uuid.NullUUID
+	Route    string
+	Status   int
+	BytesIn  int64
+	BytesOut int64
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AsBuiltDisposition says what is done with the difference between a
+// job's as-built and BOQ quantities.
+type AsBuiltDisposition string
+
+const (
+	// AsBuiltDispositionNone records the difference without charging it.
+	AsBuiltDispositionNone AsBuiltDisposition = "none"
+	// AsBuiltDispositionChangeOrder raised a contract addendum for it.
+	AsBuiltDispositionChangeOrder AsBuiltDisposition = "change_order"
+	// AsBuiltDispositionFinalAccount leaves it to be settled in the final
+	// account.
+	AsBuiltDispositionFinalAccount AsBuiltDisposition = "final_account"
+)
+
+func (d AsBuiltDisposition) Valid() bool {
+	switch d {
+	case AsBuiltDispositionNone, AsBuiltDispositionChangeOrder, AsBuiltDispositionFinalAccount:
+		return true
+	}
+	return false
+}
+
+// AsBuiltQuantity is the quantity of a BOQ job actually built, recorded
+// once the job is complete. VariationValue is the difference from the BOQ
+// quantity at the job's selling price, negative for work omitted.
+type AsBuiltQuantity struct {
+	BOQID          uuid.UUID          `db:"boq_id"`
+	JobID          uuid.UUID          `db:"job_id"`
+	Quantity       float64            `db:"quantity"`
+	VariationValue float64            `db:"variation_value"`
+	Disposition    AsBuiltDisposition `db:"disposition"`
+	ChangeOrderID  uuid.NullUUID      `db:"change_order_id"`
+	Note           sql.NullString     `db:"note"`
+	RecordedBy     uuid.NullUUID      `db:"recorded_by"`
+	RecordedAt     time.Time          `db:"recorded_at"`
+}
+
+// AsBuiltLine is a BOQ job with its as-built quantity, if recorded.
+type AsBuiltLine struct {
+	BOQID         uuid.UUID       `db:"boq_id"`
+	JobID         uuid.UUID       `db:"job_id"`
+	JobName       string          `db:"job_name"`
+	Unit          string          `db:"unit"`
+	BOQQuantity   float64         `db:"boq_quantity"`
+	UnitPrice     float64         `db:"unit_price"`
+	CompletedAt   sql.NullTime    `db:"completed_at"`
+	AsBuilt       sql.NullFloat64 `db:"as_built_quantity"`
+	Disposition   sql.NullString  `db:"disposition"`
+	ChangeOrderID uuid.NullUUID   `db:"change_order_id"`
+	Note          sql.NullString  `db:"note"`
+	RecordedAt    sql.NullTime    `db:"recorded_at"`
+}
+
+// Variation is the as-built quantity less the BOQ quantity and its value
+// at the job's selling price.
+func (l AsBuiltLine) Variation(asBuilt float64) (quantity, value float64) {
+	quantity = asBuilt - l.BOQQuantity
+	return quantity, math.Round(quantity*l.UnitPrice*100) / 100
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// AuditGenesisHash is the previous hash of the first sealed segment.
+var AuditGenesisHash = strings.Repeat("0", 64)
+
+// AuditSegment seals one day of activity events. Hash covers the day, the
+// events and the previous segment's hash, so the segments form a chain and
+// changing, removing or reordering any sealed event breaks every later
+// link. A copy of each segment is archived to write-once storage under
+// ObjectKey.
+type AuditSegment struct {
+	SegmentDate  time.Time `db:"segment_date"`
+	EventCount   int       `db:"event_count"`
+	EventsHash   string    `db:"events_hash"`
+	PreviousHash string    `db:"previous_hash"`
+	Hash         string    `db:"hash"`
+	ObjectKey    string    `db:"object_key"`
+	SealedAt     time.Time `db:"sealed_at"`
+}
+
+// AuditSegmentFile is the archived form of a segment: its seal together
+// with the events, so it can be verified without the database.
+type AuditSegmentFile struct {
+	SegmentDate  string            `json:"segment_date"`
+	PreviousHash string            `json:"previous_hash"`
+	EventsHash   string            `json:"events_hash"`
+	Hash         string            `json:"hash"`
+	Events       []AuditEventEntry `json:"events"`
+}
+
+// AuditEventEntry is an ActivityEvent as hashed and archived. Times are
+// UTC so the encoding doesn't depend on the server's zone.
+type AuditEventEntry struct {
+	EventID        string `json:"event_id"`
+	EntityType     string `json:"entity_type"`
+	EntityID       string `json:"entity_id"`
+	ProjectID      string `json:"project_id,omitempty"`
+	ClientID       string `json:"client_id,omitempty"`
+	EventType      string `json:"event_type"`
+	Description    string `json:"description"`
+	OccurredAt     string `json:"occurred_at"`
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
+}
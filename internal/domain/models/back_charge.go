@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BackChargeCategory string
+
+const (
+	BackChargeCategoryRework BackChargeCategory = "rework"
+	BackChargeCategoryDamage BackChargeCategory = "damage"
+	BackChargeCategoryOther  BackChargeCategory = "other"
+)
+
+func (c BackChargeCategory) Valid() bool {
+	switch c {
+	case BackChargeCategoryRework, BackChargeCategoryDamage, BackChargeCategoryOther:
+		return true
+	}
+	return false
+}
+
+// BackChargeStatus: open charges are deducted from the supplier's next
+// payment certificates, disputed ones are held until the dispute is
+// resolved, and withdrawn ones are never deducted.
+type BackChargeStatus string
+
+const (
+	BackChargeStatusOpen      BackChargeStatus = "open"
+	BackChargeStatusDisputed  BackChargeStatus = "disputed"
+	BackChargeStatusWithdrawn BackChargeStatus = "withdrawn"
+)
+
+// BackCharge is a cost we incurred because of a subcontractor or supplier,
+// such as rework or damage, recovered from what we owe them. When
+// SubcontractID is set it is only deducted from that subcontract.
+type BackCharge struct {
+	BackChargeID  uuid.UUID          `db:"back_charge_id"`
+	SupplierID    uuid.UUID          `db:"supplier_id"`
+	ProjectID     uuid.NullUUID      `db:"project_id"`
+	SubcontractID uuid.NullUUID      `db:"subcontract_id"`
+	Category      BackChargeCategory `db:"category"`
+	Description   string             `db:"description"`
+	Amount        float64            `db:"amount"`
+	OffsetAmount  float64            `db:"offset_amount"`
+	Status        BackChargeStatus   `db:"status"`
+	DisputeReason sql.NullString     `db:"dispute_reason"`
+	DisputedBy    uuid.NullUUID      `db:"disputed_by"`
+	Resolution    sql.NullString     `db:"resolution"`
+	CreatedBy     uuid.UUID          `db:"created_by"`
+	CreatedAt     time.Time          `db:"created_at"`
+	UpdatedAt     time.Time          `db:"updated_at"`
+}
+
+// Remaining is what is still to be deducted.
+func (b BackCharge) Remaining() float64 {
+	return roundToIncrement(b.Amount-b.OffsetAmount, 0.01)
+}
+
+// BackChargeOffset is the part of a back-charge deducted on one payment
+// certificate.
+type BackChargeOffset struct {
+	BackChargeID  uuid.UUID `db:"back_charge_id"`
+	CertificateID uuid.UUID `db:"certificate_id"`
+	Amount        float64   `db:"amount"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// BackChargeAttachment is supporting evidence such as photos or invoices,
+// uploaded beforehand and referenced by URL.
+type BackChargeAttachment struct {
+	AttachmentID uuid.UUID `db:"attachment_id"`
+	BackChargeID uuid.UUID `db:"back_charge_id"`
+	Title        string    `db:"title"`
+	FileURL      string    `db:"file_url"`
+	UploadedBy   uuid.UUID `db:"uploaded_by"`
+	CreatedAt    time.Time `db:"created_at"`
+}
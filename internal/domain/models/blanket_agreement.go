@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BlanketAgreement fixes a supplier's unit prices for a period in return
+// for committed quantities. Release orders are purchase orders drawn
+// against it.
+type BlanketAgreement struct {
+	AgreementID uuid.UUID      `db:"agreement_id"`
+	SupplierID  uuid.UUID      `db:"supplier_id"`
+	Reference   string         `db:"reference"`
+	ValidFrom   time.Time      `db:"valid_from"`
+	ValidTo     time.Time      `db:"valid_to"`
+	Note        sql.NullString `db:"note"`
+	CreatedBy   uuid.NullUUID  `db:"created_by"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// ValidOn reports whether orders dated date may be released against a.
+func (a BlanketAgreement) ValidOn(date time.Time) bool {
+	return !date.Before(a.ValidFrom) && !date.After(a.ValidTo)
+}
+
+// BlanketAgreementSummary is an agreement with its supplier's name for
+// listing.
+type BlanketAgreementSummary struct {
+	BlanketAgreement
+	SupplierName string `db:"supplier_name"`
+}
+
+type BlanketAgreementItem struct {
+	ItemID            uuid.UUID `db:"item_id"`
+	AgreementID       uuid.UUID `db:"agreement_id"`
+	MaterialID        string    `db:"material_id"`
+	UnitPrice         float64   `db:"unit_price"`
+	CommittedQuantity float64   `db:"committed_quantity"`
+}
+
+// BlanketAgreementBalance is an item with the quantity ordered against it
+// on release orders that haven't been cancelled.
+type BlanketAgreementBalance struct {
+	BlanketAgreementItem
+	ReleasedQuantity float64 `db:"released_quantity"`
+}
+
+func (b BlanketAgreementBalance) Remaining() float64 {
+	return math.Round((b.CommittedQuantity-b.ReleasedQuantity)*10000) / 10000
+}
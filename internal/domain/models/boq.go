@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -25,6 +26,9 @@ type BOQDetails struct {
 	ProjectAddress      sql.NullString  `db:"address"`
 	JobID               uuid.UUID       `db:"job_id"`
 	JobName             string          `db:"job_name"`
+	PhaseID             uuid.NullUUID   `db:"phase_id"`
+	PhaseName           sql.NullString  `db:"phase_name"`
+	PhaseSortOrder      sql.NullInt64   `db:"phase_sort_order"`
 	Description         sql.NullString  `db:"description"`
 	Quantity            int             `db:"quantity"`
 	Unit                string          `db:"unit"`
@@ -43,6 +47,12 @@ type BOQMaterialDetails struct {
 	Unit           string          `db:"unit"`
 	EstimatedPrice sql.NullFloat64 `db:"estimated_price"` // Changed to handle NULL
 	Total          sql.NullFloat64 `db:"total"`           // Changed to handle NULL
+	// ThaiName and EnglishName are the material's display aliases.
+	ThaiName    sql.NullString `db:"thai_name"`
+	EnglishName sql.NullString `db:"english_name"`
+	// SupplierName is the name snapshotted when the BOQ was approved, or
+	// the supplier's current name before that.
+	SupplierName sql.NullString `db:"supplier_name"`
 }
 
 type BOQGeneralCost struct {
@@ -50,3 +60,26 @@ type BOQGeneralCost struct {
 	TypeName      string    `db:"type_name"`
 	EstimatedCost float64   `db:"estimated_cost"`
 }
+
+// DrawingSource says whether a drawing is a file uploaded with the BOQ or a
+// link to a drawing kept elsewhere, e.g. in the designer's document system.
+type DrawingSource string
+
+const (
+	DrawingSourceFile DrawingSource = "file"
+	DrawingSourceURL  DrawingSource = "url"
+)
+
+// BOQJobDrawing is a reference drawing for one BOQ line. DrawingNumber
+// points at the sheet or detail the line refers to.
+type BOQJobDrawing struct {
+	DrawingID     uuid.UUID      `db:"drawing_id"`
+	BOQID         uuid.UUID      `db:"boq_id"`
+	JobID         uuid.UUID      `db:"job_id"`
+	Title         string         `db:"title"`
+	DrawingNumber sql.NullString `db:"drawing_number"`
+	Revision      sql.NullString `db:"revision"`
+	Source        DrawingSource  `db:"source"`
+	URL           string         `db:"url"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
@@ -1,13 +1,17 @@
 package models
 
 import (
+	"database/sql"
+
 	"github.com/google/uuid"
 )
 
 type BOQJob struct {
-	BOQID        uuid.UUID `db:"boq_id"`
-	JobID        uuid.UUID `db:"job_id"`
-	Quantity     int       `db:"quantity"`
-	LaborCost    float64   `db:"labor_cost"`
-	SellingPrice float64   `db:"selling_price"`
+	BOQID        uuid.UUID     `db:"boq_id"`
+	JobID        uuid.UUID     `db:"job_id"`
+	Quantity     int           `db:"quantity"`
+	LaborCost    float64       `db:"labor_cost"`
+	SellingPrice float64       `db:"selling_price"`
+	PhaseID      uuid.NullUUID `db:"phase_id"`
+	CompletedAt  sql.NullTime  `db:"completed_at"`
 }
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BOQPriceUpdate flags a draft BOQ (or the draft quotation built on it)
+// whose materials have been bought at different prices since they were
+// estimated. Totals are what the BOQ comes to at its estimated prices and
+// at the latest supplier prices; Changes holds the []BOQPriceChange that
+// make up the difference.
+type BOQPriceUpdate struct {
+	BOQID             uuid.UUID       `db:"boq_id"`
+	ProjectID         uuid.UUID       `db:"project_id"`
+	ProjectName       string          `db:"project_name"`
+	QuotationID       uuid.NullUUID   `db:"quotation_id"`
+	EstimatedTotal    float64         `db:"estimated_total"`
+	RecalculatedTotal float64         `db:"recalculated_total"`
+	Delta             float64         `db:"delta"`
+	Changes           json.RawMessage `db:"changes"`
+	FlaggedAt         time.Time       `db:"flagged_at"`
+	AcknowledgedAt    sql.NullTime    `db:"acknowledged_at"`
+	AcknowledgedBy    uuid.NullUUID   `db:"acknowledged_by"`
+}
+
+// BOQPriceChange is one material of a BOQ whose latest supplier price
+// differs from the price it was estimated at. Quantity is the total over
+// every job in the BOQ and Delta is what the change adds to the BOQ.
+type BOQPriceChange struct {
+	MaterialID     string        `db:"material_id" json:"material_id"`
+	MaterialName   string        `db:"material_name" json:"material_name"`
+	Quantity       float64       `db:"quantity" json:"quantity"`
+	EstimatedPrice float64       `db:"estimated_price" json:"estimated_price"`
+	LatestPrice    float64       `db:"latest_price" json:"latest_price"`
+	SupplierID     uuid.NullUUID `db:"supplier_id" json:"supplier_id"`
+	SupplierName   string        `db:"supplier_name" json:"supplier_name"`
+	PricedAt       time.Time     `db:"priced_at" json:"priced_at"`
+	Delta          float64       `db:"delta" json:"delta"`
+}
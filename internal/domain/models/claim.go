@@ -0,0 +1,85 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClaimType: a time-extension claim asks for more days to complete, a cost
+// claim for more money. A claim may ask for both.
+type ClaimType string
+
+const (
+	ClaimTypeTimeExtension ClaimType = "time_extension"
+	ClaimTypeCost          ClaimType = "cost"
+)
+
+func (t ClaimType) Valid() bool {
+	switch t {
+	case ClaimTypeTimeExtension, ClaimTypeCost:
+		return true
+	}
+	return false
+}
+
+// ClaimStatus: a claim is drafted, submitted to the client and then
+// settled, rejected or withdrawn. Submitted claims are the open ones.
+type ClaimStatus string
+
+const (
+	ClaimStatusDraft     ClaimStatus = "draft"
+	ClaimStatusSubmitted ClaimStatus = "submitted"
+	ClaimStatusSettled   ClaimStatus = "settled"
+	ClaimStatusRejected  ClaimStatus = "rejected"
+	ClaimStatusWithdrawn ClaimStatus = "withdrawn"
+)
+
+func (s ClaimStatus) Valid() bool {
+	switch s {
+	case ClaimStatusDraft, ClaimStatusSubmitted, ClaimStatusSettled, ClaimStatusRejected, ClaimStatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// Closed reports whether the claim has been decided or dropped.
+func (s ClaimStatus) Closed() bool {
+	return s == ClaimStatusSettled || s == ClaimStatusRejected || s == ClaimStatusWithdrawn
+}
+
+// Claim is a time-extension or cost claim made to the client under the
+// project's contract. DocumentID points at the contract document, such as
+// an addendum, the claim relies on.
+type Claim struct {
+	ClaimID          uuid.UUID       `db:"claim_id"`
+	ProjectID        uuid.UUID       `db:"project_id"`
+	ContractID       uuid.UUID       `db:"contract_id"`
+	DocumentID       uuid.NullUUID   `db:"document_id"`
+	ClaimType        ClaimType       `db:"claim_type"`
+	Title            string          `db:"title"`
+	Narrative        string          `db:"narrative"`
+	ClaimedAmount    float64         `db:"claimed_amount"`
+	ClaimedDays      int             `db:"claimed_days"`
+	Status           ClaimStatus     `db:"status"`
+	SettlementAmount sql.NullFloat64 `db:"settlement_amount"`
+	SettlementDays   sql.NullInt64   `db:"settlement_days"`
+	Resolution       sql.NullString  `db:"resolution"`
+	SubmittedAt      sql.NullTime    `db:"submitted_at"`
+	ClosedAt         sql.NullTime    `db:"closed_at"`
+	CreatedBy        uuid.NullUUID   `db:"created_by"`
+	CreatedAt        time.Time       `db:"created_at"`
+	UpdatedAt        time.Time       `db:"updated_at"`
+}
+
+// ClaimDocument is supporting evidence such as site diaries, letters or
+// photos, uploaded beforehand and referenced by URL.
+type ClaimDocument struct {
+	DocumentID uuid.UUID     `db:"document_id"`
+	ClaimID    uuid.UUID     `db:"claim_id"`
+	Title      string        `db:"title"`
+	FileURL    string        `db:"file_url"`
+	UploadedBy uuid.NullUUID `db:"uploaded_by"`
+	CreatedAt  time.Time     `db:"created_at"`
+}
@@ -1,7 +1,9 @@
 package models
 
 import (
+	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,4 +15,92 @@ type Client struct {
 	Tel      string          `db:"tel"`
 	Address  json.RawMessage `db:"address"`
 	TaxID    string          `db:"tax_id"`
+
+	// CreditLimit caps what the client may owe on unpaid invoices plus
+	// newly approved quotations; null means no limit. PaymentTermsDays is
+	// how long the client has to pay an invoice.
+	CreditLimit      sql.NullFloat64 `db:"credit_limit"`
+	PaymentTermsDays sql.NullInt64   `db:"payment_terms_days"`
+
+	// A client on credit hold can't have new quotations approved until the
+	// hold is released or an owner overrides it.
+	CreditHold           bool           `db:"credit_hold"`
+	CreditHoldAuto       bool           `db:"credit_hold_auto"`
+	CreditHoldReason     sql.NullString `db:"credit_hold_reason"`
+	CreditHoldAt         sql.NullTime   `db:"credit_hold_at"`
+	CreditHoldReleasedAt sql.NullTime   `db:"credit_hold_released_at"`
+
+	// ArchivedAt is set when the client is deleted. Archived clients keep
+	// their projects and history but are left out of the client list and
+	// can't be given new projects.
+	ArchivedAt sql.NullTime `db:"archived_at"`
+}
+
+// ClientFilter narrows and orders the client list. Search matches the
+// name, email or phone number; TaxID must match exactly. An empty SortBy
+// sorts by name. Archived clients are only listed with IncludeArchived.
+type ClientFilter struct {
+	Search          string
+	TaxID           string
+	SortBy          string
+	Descending      bool
+	IncludeArchived bool
+}
+
+// ClientSortColumns maps the sort keys the client list accepts to their
+// columns.
+var ClientSortColumns = map[string]string{
+	"name":   "name",
+	"email":  "email",
+	"tel":    "tel",
+	"tax_id": "tax_id",
+}
+
+// ClientContact is a person to deal with at a client company, such as its
+// owner, site engineer or accountant. At most one contact per client is
+// primary.
+type ClientContact struct {
+	ContactID uuid.UUID      `db:"contact_id"`
+	ClientID  uuid.UUID      `db:"client_id"`
+	Name      string         `db:"name"`
+	Role      sql.NullString `db:"role"`
+	Email     sql.NullString `db:"email"`
+	Tel       sql.NullString `db:"tel"`
+	IsPrimary bool           `db:"is_primary"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt sql.NullTime   `db:"updated_at"`
+}
+
+// ClientDocumentType: the documents kept on file for a client, such as its
+// company registration certificate or a copy of an owner's ID card.
+type ClientDocumentType string
+
+const (
+	ClientDocumentTypeCompanyRegistration ClientDocumentType = "company_registration"
+	ClientDocumentTypeIDCard              ClientDocumentType = "id_card"
+	ClientDocumentTypeOther               ClientDocumentType = "other"
+)
+
+func (t ClientDocumentType) Valid() bool {
+	switch t {
+	case ClientDocumentTypeCompanyRegistration, ClientDocumentTypeIDCard, ClientDocumentTypeOther:
+		return true
+	}
+	return false
+}
+
+// ClientDocument is a file uploaded against a client. The file itself is
+// kept in private object storage under StorageKey and is only handed out
+// through short-lived signed links.
+type ClientDocument struct {
+	DocumentID   uuid.UUID          `db:"document_id"`
+	ClientID     uuid.UUID          `db:"client_id"`
+	DocumentType ClientDocumentType `db:"document_type"`
+	FileName     string             `db:"file_name"`
+	ContentType  string             `db:"content_type"`
+	Size         int64              `db:"size"`
+	StorageKey   string             `db:"storage_key"`
+	Note         sql.NullString     `db:"note"`
+	UploadedBy   uuid.NullUUID      `db:"uploaded_by"`
+	CreatedAt    time.Time          `db:"created_at"`
 }
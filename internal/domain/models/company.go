@@ -1,16 +1,31 @@
 package models
 
 import (
+	"database/sql"
 	"encoding/json"
 
 	"github.com/google/uuid"
 )
 
 type Company struct {
-	CompanyID uuid.UUID       `db:"company_id" json:"company_id"`
-	Name      string          `db:"name" json:"name" validate:"required"`
-	Email     string          `db:"email" json:"email" validate:"required,email"`
-	Tel       string          `db:"tel" json:"tel" validate:"required,len=10"`
-	Address   json.RawMessage `db:"address" json:"address"`
-	TaxID     string          `db:"tax_id" json:"tax_id" validate:"required,len=13,numeric"`
+	CompanyID   uuid.UUID       `db:"company_id" json:"company_id"`
+	Name        string          `db:"name" json:"name" validate:"required"`
+	Email       string          `db:"email" json:"email" validate:"required,email"`
+	Tel         string          `db:"tel" json:"tel" validate:"required,len=10"`
+	Address     json.RawMessage `db:"address" json:"address"`
+	TaxID       string          `db:"tax_id" json:"tax_id" validate:"required,len=13,numeric"`
+	PromptPayID sql.NullString  `db:"promptpay_id" json:"promptpay_id"`
+	DateEra     DateEra         `db:"date_era" json:"date_era"`
+}
+
+// DateEra is how years are numbered on a company's documents.
+type DateEra string
+
+const (
+	DateEraGregorian DateEra = "gregorian"
+	DateEraBuddhist  DateEra = "buddhist"
+)
+
+func (e DateEra) Valid() bool {
+	return e == DateEraGregorian || e == DateEraBuddhist
 }
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CubeTestStatus string
+
+const (
+	CubeTestStatusPending CubeTestStatus = "pending"
+	CubeTestStatusPassed  CubeTestStatus = "passed"
+	CubeTestStatusFailed  CubeTestStatus = "failed"
+)
+
+// CubeTestEarlyRatio is the share of the specified strength a cube is
+// expected to reach at 7 days. A weaker 7-day result is a warning that the
+// 28-day result may fail.
+const CubeTestEarlyRatio = 0.65
+
+// CubeTest is a set of concrete cubes cast from a pour and crushed at 7
+// and 28 days. Strengths are in MPa; the test passes when the 28-day
+// result reaches SpecifiedStrength.
+type CubeTest struct {
+	TestID            uuid.UUID       `db:"test_id"`
+	PourID            uuid.UUID       `db:"pour_id"`
+	ProjectID         uuid.UUID       `db:"project_id"`
+	JobID             uuid.UUID       `db:"job_id"`
+	SampleReference   string          `db:"sample_reference"`
+	SampleDate        time.Time       `db:"sample_date"`
+	SpecifiedStrength float64         `db:"specified_strength"`
+	Strength7Day      sql.NullFloat64 `db:"strength_7_day"`
+	Strength28Day     sql.NullFloat64 `db:"strength_28_day"`
+	Lab               sql.NullString  `db:"lab"`
+	Note              sql.NullString  `db:"note"`
+	CreatedAt         time.Time       `db:"created_at"`
+	UpdatedAt         sql.NullTime    `db:"updated_at"`
+}
+
+// Status is pending until the 28-day result is in.
+func (t CubeTest) Status() CubeTestStatus {
+	if !t.Strength28Day.Valid {
+		return CubeTestStatusPending
+	}
+	if t.Strength28Day.Float64 >= t.SpecifiedStrength {
+		return CubeTestStatusPassed
+	}
+	return CubeTestStatusFailed
+}
+
+// EarlyWarning reports a 7-day result below CubeTestEarlyRatio of the
+// specified strength while the 28-day result is still to come.
+func (t CubeTest) EarlyWarning() bool {
+	return !t.Strength28Day.Valid && t.Strength7Day.Valid &&
+		t.Strength7Day.Float64 < t.SpecifiedStrength*CubeTestEarlyRatio
+}
+
+// CubeTestDetail is a cube test with its pour's ticket and job for
+// listing.
+type CubeTestDetail struct {
+	CubeTest
+	TicketNumber string `db:"ticket_number"`
+	JobName      string `db:"job_name"`
+	ProjectName  string `db:"project_name"`
+}
+
+// CubeTestFilter narrows a cube test list. A null ProjectID lists tests
+// on every project that isn't completed or cancelled.
+type CubeTestFilter struct {
+	ProjectID uuid.NullUUID
+	PourID    uuid.NullUUID
+	JobID     uuid.NullUUID
+}
+
+// CubeTestSummary counts a project's cube tests by status for its QA
+// record.
+type CubeTestSummary struct {
+	Total         int
+	Pending       int
+	Passed        int
+	Failed        int
+	EarlyWarnings int
+}
+
+func SummarizeCubeTests(tests []CubeTestDetail) CubeTestSummary {
+	summary := CubeTestSummary{Total: len(tests)}
+	for _, t := range tests {
+		switch t.Status() {
+		case CubeTestStatusPending:
+			summary.Pending++
+		case CubeTestStatusPassed:
+			summary.Passed++
+		case CubeTestStatusFailed:
+			summary.Failed++
+		}
+		if t.EarlyWarning() {
+			summary.EarlyWarnings++
+		}
+	}
+	return summary
+}
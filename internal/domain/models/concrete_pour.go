@@ -0,0 +1,52 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConcretePour is one ready-mix delivery poured into a BOQ job, recorded
+// from the supplier's delivery ticket. Volume is in cubic metres and
+// SlumpMM is the slump measured on site. CubeReferences are the labels of
+// the test cubes cast from the load.
+type ConcretePour struct {
+	PourID         uuid.UUID       `db:"pour_id"`
+	ProjectID      uuid.UUID       `db:"project_id"`
+	BOQID          uuid.UUID       `db:"boq_id"`
+	JobID          uuid.UUID       `db:"job_id"`
+	SupplierID     uuid.UUID       `db:"supplier_id"`
+	MaterialID     sql.NullString  `db:"material_id"`
+	TicketNumber   string          `db:"ticket_number"`
+	PourDate       time.Time       `db:"pour_date"`
+	Volume         float64         `db:"volume"`
+	SlumpMM        sql.NullFloat64 `db:"slump_mm"`
+	Location       sql.NullString  `db:"location"`
+	Note           sql.NullString  `db:"note"`
+	CreatedBy      uuid.NullUUID   `db:"created_by"`
+	CreatedAt      time.Time       `db:"created_at"`
+	CubeReferences []string        `db:"-"`
+}
+
+// ConcretePourDetail is a pour with the names of its job and supplier for
+// listing.
+type ConcretePourDetail struct {
+	ConcretePour
+	JobName      string `db:"job_name"`
+	SupplierName string `db:"supplier_name"`
+}
+
+// ConcretePourSummary totals the concrete poured into a BOQ job. Planned
+// is the quantity the BOQ gives for the concrete materials that were
+// poured, so it is zero when pours don't name a material.
+type ConcretePourSummary struct {
+	BOQID         uuid.UUID `db:"boq_id"`
+	JobID         uuid.UUID `db:"job_id"`
+	JobName       string    `db:"job_name"`
+	Pours         int       `db:"pours"`
+	PouredVolume  float64   `db:"poured_volume"`
+	PlannedVolume float64   `db:"planned_volume"`
+	// PoursWithoutCubes counts loads no test cubes were taken from.
+	PoursWithoutCubes int `db:"pours_without_cubes"`
+}
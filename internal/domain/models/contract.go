@@ -14,3 +14,118 @@ type Contract struct {
 	CreatedAt  time.Time      `db:"created_at"`
 	UpdatedAt  sql.NullTime   `db:"updated_at"`
 }
+
+// ContractDocumentType distinguishes the documents that make up a project's
+// contract over time.
+type ContractDocumentType string
+
+const (
+	ContractDocumentTypeLOI          ContractDocumentType = "loi"
+	ContractDocumentTypeMainContract ContractDocumentType = "main_contract"
+	ContractDocumentTypeAddendum     ContractDocumentType = "addendum"
+)
+
+func (t ContractDocumentType) Valid() bool {
+	switch t {
+	case ContractDocumentTypeLOI, ContractDocumentTypeMainContract, ContractDocumentTypeAddendum:
+		return true
+	}
+	return false
+}
+
+// IsBase reports whether the document carries the full contract value
+// rather than an adjustment to it.
+func (t ContractDocumentType) IsBase() bool {
+	return t == ContractDocumentTypeLOI || t == ContractDocumentTypeMainContract
+}
+
+// ContractDocument is one version of a letter of intent, main contract or
+// addendum. LOIs and main contracts carry the full ContractValue; addenda
+// carry a ValueAdjustment on top of it.
+type ContractDocument struct {
+	DocumentID      uuid.UUID            `db:"document_id"`
+	ProjectID       uuid.UUID            `db:"project_id"`
+	DocumentType    ContractDocumentType `db:"document_type"`
+	Version         int                  `db:"version"`
+	Title           string               `db:"title"`
+	FileURL         string               `db:"file_url"`
+	EffectiveDate   time.Time            `db:"effective_date"`
+	ContractValue   sql.NullFloat64      `db:"contract_value"`
+	ValueAdjustment float64              `db:"value_adjustment"`
+	SupersedesID    uuid.NullUUID        `db:"supersedes_id"`
+	SupersededBy    uuid.NullUUID        `db:"superseded_by"`
+	CreatedAt       time.Time            `db:"created_at"`
+}
+
+// CanSupersede reports whether d may replace previous. Addenda only replace
+// addenda; a main contract may replace an LOI.
+func (d ContractDocument) CanSupersede(previous ContractDocument) bool {
+	switch d.DocumentType {
+	case ContractDocumentTypeAddendum:
+		return previous.DocumentType == ContractDocumentTypeAddendum
+	case ContractDocumentTypeMainContract:
+		return previous.DocumentType.IsBase()
+	default:
+		return previous.DocumentType == ContractDocumentTypeLOI
+	}
+}
+
+// ContractPosition is the contract in force on a given date.
+type ContractPosition struct {
+	Base    *ContractDocument
+	Addenda []ContractDocument
+	Value   float64
+}
+
+// CurrentContract works out which documents are in force on asOf. A document
+// only counts as superseded once its replacement is effective. The base is
+// the latest main contract in force, falling back to the latest LOI, and
+// only addenda effective on or after the base count towards the value.
+// It returns nil when no base document is in force yet.
+func CurrentContract(documents []ContractDocument, asOf time.Time) *ContractPosition {
+	effective := make(map[uuid.UUID]bool, len(documents))
+	for _, d := range documents {
+		effective[d.DocumentID] = !d.EffectiveDate.After(asOf)
+	}
+	inForce := func(d ContractDocument) bool {
+		if !effective[d.DocumentID] {
+			return false
+		}
+		return !d.SupersededBy.Valid || !effective[d.SupersededBy.UUID]
+	}
+
+	var base *ContractDocument
+	for i := range documents {
+		d := documents[i]
+		if !d.DocumentType.IsBase() || !inForce(d) {
+			continue
+		}
+		if base == nil || laterBase(d, *base) {
+			base = &documents[i]
+		}
+	}
+	if base == nil {
+		return nil
+	}
+
+	position := &ContractPosition{Base: base, Value: base.ContractValue.Float64}
+	for _, d := range documents {
+		if d.DocumentType != ContractDocumentTypeAddendum || !inForce(d) || d.EffectiveDate.Before(base.EffectiveDate) {
+			continue
+		}
+		position.Addenda = append(position.Addenda, d)
+		position.Value += d.ValueAdjustment
+	}
+	position.Value = roundToIncrement(position.Value, 0.01)
+	return position
+}
+
+func laterBase(d, current ContractDocument) bool {
+	if d.DocumentType != current.DocumentType {
+		return d.DocumentType == ContractDocumentTypeMainContract
+	}
+	if !d.EffectiveDate.Equal(current.EffectiveDate) {
+		return d.EffectiveDate.After(current.EffectiveDate)
+	}
+	return d.Version > current.Version
+}
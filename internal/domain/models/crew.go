@@ -0,0 +1,33 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Crew is a team of workers that can be assigned to schedule tasks.
+// Capacity is the number of workers it can field per day.
+type Crew struct {
+	CrewID    uuid.UUID    `db:"crew_id"`
+	Name      string       `db:"name"`
+	Capacity  int          `db:"capacity"`
+	CreatedAt time.Time    `db:"created_at"`
+	UpdatedAt sql.NullTime `db:"updated_at"`
+}
+
+// CrewAllocation is one task's share of a crew on a day where the crew's
+// total allocation exceeds its capacity.
+type CrewAllocation struct {
+	CrewID      uuid.UUID `db:"crew_id"`
+	CrewName    string    `db:"crew_name"`
+	Capacity    int       `db:"capacity"`
+	WorkDate    time.Time `db:"work_date"`
+	Allocated   int       `db:"allocated"`
+	TaskID      uuid.UUID `db:"task_id"`
+	TaskName    string    `db:"task_name"`
+	Workers     int       `db:"workers"`
+	ProjectID   uuid.UUID `db:"project_id"`
+	ProjectName string    `db:"project_name"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PortfolioProject holds the money and progress figures of one project for
+// the owner dashboard. Amounts exclude VAT.
+type PortfolioProject struct {
+	ProjectID uuid.UUID     `db:"project_id"`
+	Name      string        `db:"name"`
+	Status    ProjectStatus `db:"status"`
+	CreatedAt time.Time     `db:"created_at"`
+
+	Contracted    bool    `db:"contracted"`
+	SellingPrice  float64 `db:"selling_price"`
+	EarnedValue   float64 `db:"earned_value"`
+	EstimatedCost float64 `db:"estimated_cost"`
+	ActualCost    float64 `db:"actual_cost"`
+	Invoiced      float64 `db:"invoiced"`
+	Received      float64 `db:"received"`
+
+	// Worker-days across the project's schedule tasks, in total and up to
+	// the reporting date.
+	PlannedWorkerDays float64 `db:"planned_worker_days"`
+	ElapsedWorkerDays float64 `db:"elapsed_worker_days"`
+
+	// Submitted claims still waiting on the client, with the money and
+	// days they ask for.
+	OpenClaims      int     `db:"open_claims"`
+	OpenClaimAmount float64 `db:"open_claim_amount"`
+	OpenClaimDays   int     `db:"open_claim_days"`
+}
+
+// PlannedProgress is the share of scheduled work that should be done by now.
+func (p *PortfolioProject) PlannedProgress() float64 {
+	if p.PlannedWorkerDays <= 0 {
+		return 0
+	}
+	return p.ElapsedWorkerDays / p.PlannedWorkerDays
+}
+
+// ActualProgress is the share of the BOQ value whose jobs are complete.
+func (p *PortfolioProject) ActualProgress() float64 {
+	if p.SellingPrice <= 0 {
+		return 0
+	}
+	return p.EarnedValue / p.SellingPrice
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryRate prices transport to a zone given by a postal code prefix
+// or, failing that, a province. Each quotation line is charged
+// PercentOfMaterial of its material value, but at least MinimumPerLine
+// when it has any material.
+type DeliveryRate struct {
+	RateID            uuid.UUID      `db:"rate_id"`
+	Name              string         `db:"name"`
+	PostalPrefix      sql.NullString `db:"postal_prefix"`
+	Province          sql.NullString `db:"province"`
+	PercentOfMaterial float64        `db:"percent_of_material"`
+	MinimumPerLine    float64        `db:"minimum_per_line"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         sql.NullTime   `db:"updated_at"`
+}
+
+// QuotationTransport is the transport charged on one quotation line.
+type QuotationTransport struct {
+	QuotationID uuid.UUID `db:"quotation_id"`
+	JobID       uuid.UUID `db:"job_id"`
+	RateID      uuid.UUID `db:"rate_id"`
+	Amount      float64   `db:"amount"`
+}
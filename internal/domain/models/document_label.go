@@ -0,0 +1,140 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DocumentLanguage is the language documents are rendered in. Bilingual
+// documents show each label in Thai followed by English.
+type DocumentLanguage string
+
+const (
+	DocumentLanguageThai      DocumentLanguage = "th"
+	DocumentLanguageEnglish   DocumentLanguage = "en"
+	DocumentLanguageBilingual DocumentLanguage = "bilingual"
+)
+
+func (l DocumentLanguage) Valid() bool {
+	switch l {
+	case DocumentLanguageThai, DocumentLanguageEnglish, DocumentLanguageBilingual:
+		return true
+	}
+	return false
+}
+
+// Translatable reports whether labels can be maintained for l; bilingual
+// labels are built from the Thai and English ones.
+func (l DocumentLanguage) Translatable() bool {
+	return l == DocumentLanguageThai || l == DocumentLanguageEnglish
+}
+
+// DocumentLabel is a company's own wording for a label in one language,
+// replacing the default.
+type DocumentLabel struct {
+	CompanyID uuid.UUID        `db:"company_id"`
+	Language  DocumentLanguage `db:"language"`
+	LabelKey  string           `db:"label_key"`
+	Text      string           `db:"text"`
+	UpdatedAt time.Time        `db:"updated_at"`
+}
+
+// DocumentFormat is how a document is printed: its language, the labels
+// in that language and the era its dates are shown in.
+type DocumentFormat struct {
+	Language DocumentLanguage
+	Labels   map[string]string
+	DateEra  DateEra
+}
+
+type documentLabelText struct {
+	Thai    string
+	English string
+}
+
+// defaultDocumentLabels are the labels used on quotations, invoices and
+// receipts unless a company has its own wording.
+var defaultDocumentLabels = map[string]documentLabelText{
+	"quotation":      {"ใบเสนอราคา", "Quotation"},
+	"invoice":        {"ใบแจ้งหนี้", "Invoice"},
+	"receipt":        {"ใบเสร็จรับเงิน", "Receipt"},
+	"project":        {"โครงการ", "Project"},
+	"client":         {"ลูกค้า", "Client"},
+	"seller":         {"ผู้ขาย", "Seller"},
+	"buyer":          {"ผู้ซื้อ", "Buyer"},
+	"tax_id":         {"เลขประจำตัวผู้เสียภาษี", "Tax ID"},
+	"issue_date":     {"วันที่", "Date"},
+	"valid_until":    {"ยืนราคาถึง", "Valid until"},
+	"scope_of_work":  {"ขอบเขตงาน", "Scope of work"},
+	"exclusions":     {"รายการที่ไม่รวม", "Exclusions"},
+	"assumptions":    {"สมมติฐาน", "Assumptions"},
+	"prices":         {"รายการราคา", "Prices"},
+	"general_costs":  {"ค่าใช้จ่ายทั่วไป", "General costs"},
+	"transport":      {"ค่าขนส่ง", "Transport"},
+	"subtotal":       {"รวมเป็นเงิน", "Subtotal"},
+	"zero_rated":     {"ภาษีอัตราศูนย์", "Zero-rated"},
+	"vat_exempt":     {"ยกเว้นภาษีมูลค่าเพิ่ม", "VAT exempt"},
+	"vat":            {"ภาษีมูลค่าเพิ่ม", "VAT"},
+	"rounding":       {"ปัดเศษ", "Rounding"},
+	"total":          {"จำนวนเงินรวมทั้งสิ้น", "Total"},
+	"amount":         {"จำนวนเงิน", "Amount"},
+	"payment_method": {"วิธีการชำระเงิน", "Payment method"},
+	"reference":      {"เลขที่อ้างอิง", "Reference"},
+	"paid_at":        {"วันที่ชำระ", "Paid on"},
+	"currency":       {"บาท", "THB"},
+}
+
+// DocumentLabelKeys returns every label key, sorted.
+func DocumentLabelKeys() []string {
+	keys := make([]string, 0, len(defaultDocumentLabels))
+	for key := range defaultDocumentLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func IsDocumentLabelKey(key string) bool {
+	_, ok := defaultDocumentLabels[key]
+	return ok
+}
+
+// ResolveDocumentLabels returns every label in language, using the
+// company's overrides where it has them.
+func ResolveDocumentLabels(language DocumentLanguage, overrides []DocumentLabel) map[string]string {
+	custom := make(map[DocumentLanguage]map[string]string)
+	for _, o := range overrides {
+		if custom[o.Language] == nil {
+			custom[o.Language] = make(map[string]string)
+		}
+		custom[o.Language][o.LabelKey] = o.Text
+	}
+
+	text := func(lang DocumentLanguage, key string) string {
+		if t, ok := custom[lang][key]; ok {
+			return t
+		}
+		if lang == DocumentLanguageThai {
+			return defaultDocumentLabels[key].Thai
+		}
+		return defaultDocumentLabels[key].English
+	}
+
+	labels := make(map[string]string, len(defaultDocumentLabels))
+	for key := range defaultDocumentLabels {
+		switch language {
+		case DocumentLanguageBilingual:
+			thai, english := text(DocumentLanguageThai, key), text(DocumentLanguageEnglish, key)
+			if thai == english {
+				labels[key] = thai
+			} else {
+				labels[key] = thai + " / " + english
+			}
+		default:
+			labels[key] = text(language, key)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// DWEntity is a table exported to the data warehouse. Incremental entities
+// only send rows changed since the last run; the others are re-sent in
+// full every run. Bumping SchemaVersion starts the entity over from an
+// empty watermark so the warehouse can build the new table from scratch.
+type DWEntity struct {
+	Name          string
+	SchemaVersion int
+	Incremental   bool
+}
+
+// DWWatermark records how far an entity has been exported.
+type DWWatermark struct {
+	Entity        string    `db:"entity"`
+	SchemaVersion int       `db:"schema_version"`
+	ExportedUntil time.Time `db:"exported_until"`
+	LastRowCount  int       `db:"last_row_count"`
+	LastRunAt     time.Time `db:"last_run_at"`
+}
+
+// DWBatch is one run's rows for an entity, already rendered as text.
+type DWBatch struct {
+	Entity        string
+	SchemaVersion int
+	Full          bool
+	ExportedAt    time.Time
+	Columns       []string
+	Rows          [][]string
+}
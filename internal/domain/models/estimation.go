@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EstimationItemKind string
+
+const (
+	EstimationItemJob      EstimationItemKind = "job"
+	EstimationItemMaterial EstimationItemKind = "material"
+)
+
+// EstimationVariance is the estimated and actual cost of one job type or
+// material on a completed project. ItemID is a job ID or a material ID.
+type EstimationVariance struct {
+	ProjectID     uuid.UUID          `db:"project_id"`
+	Kind          EstimationItemKind `db:"kind"`
+	ItemID        string             `db:"item_id"`
+	ItemName      string             `db:"item_name"`
+	EstimatedCost float64            `db:"estimated_cost"`
+	ActualCost    float64            `db:"actual_cost"`
+	RecordedAt    time.Time          `db:"recorded_at"`
+}
+
+// EstimationAdjustment aggregates the variances of one item over every
+// completed project that used it.
+type EstimationAdjustment struct {
+	Kind          EstimationItemKind `db:"kind"`
+	ItemID        string             `db:"item_id"`
+	ItemName      string             `db:"item_name"`
+	Projects      int                `db:"projects"`
+	EstimatedCost float64            `db:"estimated_cost"`
+	ActualCost    float64            `db:"actual_cost"`
+}
+
+// Factor is what estimates should be multiplied by to match actuals.
+func (a *EstimationAdjustment) Factor() float64 {
+	if a.EstimatedCost <= 0 {
+		return 1
+	}
+	return a.ActualCost / a.EstimatedCost
+}
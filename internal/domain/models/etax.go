@@ -0,0 +1,45 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ETaxDocumentType string
+
+const (
+	ETaxDocumentTaxInvoice ETaxDocumentType = "tax_invoice"
+	ETaxDocumentReceipt    ETaxDocumentType = "receipt"
+)
+
+// ETaxStatus tracks a document from generation to the e-tax provider's
+// verdict. Failed means it never reached the provider (signing or sending
+// failed); rejected means the provider refused it. Both can be resubmitted.
+type ETaxStatus string
+
+const (
+	ETaxStatusSigned    ETaxStatus = "signed"
+	ETaxStatusSubmitted ETaxStatus = "submitted"
+	ETaxStatusAccepted  ETaxStatus = "accepted"
+	ETaxStatusRejected  ETaxStatus = "rejected"
+	ETaxStatusFailed    ETaxStatus = "failed"
+)
+
+// ETaxSubmission is one attempt to file an e-tax invoice or receipt. XML
+// is the signed document once signing succeeded.
+type ETaxSubmission struct {
+	SubmissionID   uuid.UUID        `db:"submission_id"`
+	InvoiceID      uuid.UUID        `db:"invoice_id"`
+	PaymentID      uuid.NullUUID    `db:"payment_id"`
+	DocumentType   ETaxDocumentType `db:"document_type"`
+	DocumentNumber string           `db:"document_number"`
+	Status         ETaxStatus       `db:"status"`
+	XML            sql.NullString   `db:"xml"`
+	ProviderRef    sql.NullString   `db:"provider_ref"`
+	Message        sql.NullString   `db:"message"`
+	SubmittedAt    sql.NullTime     `db:"submitted_at"`
+	CreatedAt      time.Time        `db:"created_at"`
+	UpdatedAt      time.Time        `db:"updated_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ExchangeRate is the value of one unit of CurrencyCode in Thai baht.
+type ExchangeRate struct {
+	CurrencyCode string    `db:"currency_code"`
+	RateDate     time.Time `db:"rate_date"`
+	Rate         float64   `db:"rate"`
+	Source       string    `db:"source"`
+	IsManual     bool      `db:"is_manual"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
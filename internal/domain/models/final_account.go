@@ -0,0 +1,69 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FinalAccount settles a completed project with its client. It is editable
+// until the client's representative agrees it, at which point the agreed
+// final contract sum is kept.
+type FinalAccount struct {
+	FinalAccountID   uuid.UUID       `db:"final_account_id"`
+	ProjectID        uuid.UUID       `db:"project_id"`
+	RetentionPercent float64         `db:"retention_percent"`
+	Note             sql.NullString  `db:"note"`
+	AgreedBy         sql.NullString  `db:"agreed_by"`
+	AgreedAmount     sql.NullFloat64 `db:"agreed_amount"`
+	AgreedAt         sql.NullTime    `db:"agreed_at"`
+	CreatedBy        uuid.NullUUID   `db:"created_by"`
+	CreatedAt        time.Time       `db:"created_at"`
+	UpdatedAt        sql.NullTime    `db:"updated_at"`
+
+	BackCharges []FinalAccountBackCharge `db:"-"`
+}
+
+// FinalAccountBackCharge is a deduction the client makes from the final
+// account, such as the cost of rectifying a defect it fixed itself.
+type FinalAccountBackCharge struct {
+	ChargeID       uuid.UUID `db:"charge_id"`
+	FinalAccountID uuid.UUID `db:"final_account_id"`
+	Description    string    `db:"description"`
+	Amount         float64   `db:"amount"`
+	SortOrder      int       `db:"sort_order"`
+}
+
+// FinalSettlement is the final account statement: the original contract
+// sum adjusted for change orders, remeasurement and back-charges, less what
+// the client has paid and the retention it still holds.
+type FinalSettlement struct {
+	OriginalContract float64
+	ChangeOrders     float64
+	Remeasurement    float64
+	BackCharges      float64
+	FinalContractSum float64
+	PaidToDate       float64
+	RetentionHeld    float64
+	BalanceDue       float64
+}
+
+// Settle works out the statement from the compiled totals.
+func (a *FinalAccount) Settle(originalContract, changeOrders, remeasurement, paidToDate float64) FinalSettlement {
+	settlement := FinalSettlement{
+		OriginalContract: roundToIncrement(originalContract, 0.01),
+		ChangeOrders:     roundToIncrement(changeOrders, 0.01),
+		Remeasurement:    roundToIncrement(remeasurement, 0.01),
+		PaidToDate:       roundToIncrement(paidToDate, 0.01),
+	}
+	for _, charge := range a.BackCharges {
+		settlement.BackCharges += charge.Amount
+	}
+	settlement.BackCharges = roundToIncrement(settlement.BackCharges, 0.01)
+	settlement.FinalContractSum = roundToIncrement(
+		settlement.OriginalContract+settlement.ChangeOrders+settlement.Remeasurement-settlement.BackCharges, 0.01)
+	settlement.RetentionHeld = roundToIncrement(settlement.FinalContractSum*a.RetentionPercent/100, 0.01)
+	settlement.BalanceDue = roundToIncrement(settlement.FinalContractSum-settlement.PaidToDate-settlement.RetentionHeld, 0.01)
+	return settlement
+}
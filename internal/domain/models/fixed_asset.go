@@ -0,0 +1,109 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FixedAssetStatus string
+
+const (
+	FixedAssetStatusActive   FixedAssetStatus = "active"
+	FixedAssetStatusDisposed FixedAssetStatus = "disposed"
+)
+
+// FixedAsset is an entry on the asset register: equipment and materials
+// classified as assets that are depreciated instead of being costed to a
+// project when bought. Depreciation is straight-line over UsefulLifeMonths,
+// from the month of purchase, down to SalvageValue.
+type FixedAsset struct {
+	AssetID          uuid.UUID        `db:"asset_id"`
+	Name             string           `db:"name"`
+	MaterialID       sql.NullString   `db:"material_id"`
+	SerialNumber     sql.NullString   `db:"serial_number"`
+	ProjectID        uuid.NullUUID    `db:"project_id"`
+	PurchaseDate     time.Time        `db:"purchase_date"`
+	PurchaseCost     float64          `db:"purchase_cost"`
+	SalvageValue     float64          `db:"salvage_value"`
+	UsefulLifeMonths int              `db:"useful_life_months"`
+	Status           FixedAssetStatus `db:"status"`
+	DisposedAt       sql.NullTime     `db:"disposed_at"`
+	CreatedAt        time.Time        `db:"created_at"`
+	UpdatedAt        time.Time        `db:"updated_at"`
+}
+
+// DepreciableAmount is what is written off over the asset's life.
+func (a FixedAsset) DepreciableAmount() float64 {
+	return roundToIncrement(a.PurchaseCost-a.SalvageValue, 0.01)
+}
+
+// MonthsDepreciated is how many months of depreciation have been charged by
+// the end of asOf's month. Nothing is charged after disposal.
+func (a FixedAsset) MonthsDepreciated(asOf time.Time) int {
+	if a.DisposedAt.Valid && a.DisposedAt.Time.Before(asOf) {
+		asOf = a.DisposedAt.Time
+	}
+	months := (asOf.Year()-a.PurchaseDate.Year())*12 + int(asOf.Month()) - int(a.PurchaseDate.Month()) + 1
+	if months < 0 {
+		return 0
+	}
+	if months > a.UsefulLifeMonths {
+		return a.UsefulLifeMonths
+	}
+	return months
+}
+
+// AccumulatedDepreciation is the depreciation charged after the given
+// number of months, rounded to the satang. The final month takes up any
+// rounding so the asset ends exactly at its salvage value.
+func (a FixedAsset) AccumulatedDepreciation(months int) float64 {
+	if a.UsefulLifeMonths <= 0 || months <= 0 {
+		return 0
+	}
+	if months >= a.UsefulLifeMonths {
+		return a.DepreciableAmount()
+	}
+	return roundToIncrement(a.DepreciableAmount()*float64(months)/float64(a.UsefulLifeMonths), 0.01)
+}
+
+// BookValue is cost less the depreciation charged by the end of asOf's
+// month.
+func (a FixedAsset) BookValue(asOf time.Time) float64 {
+	return roundToIncrement(a.PurchaseCost-a.AccumulatedDepreciation(a.MonthsDepreciated(asOf)), 0.01)
+}
+
+// DepreciationPeriod is the accounting period of the asset's nth month of
+// depreciation, counting the purchase month as 1.
+func (a FixedAsset) DepreciationPeriod(n int) string {
+	start := time.Date(a.PurchaseDate.Year(), a.PurchaseDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start.AddDate(0, n-1, 0).Format(AccountingPeriodLayout)
+}
+
+// DepreciationForMonth is the charge for the nth month of the asset's life.
+func (a FixedAsset) DepreciationForMonth(n int) float64 {
+	return roundToIncrement(a.AccumulatedDepreciation(n)-a.AccumulatedDepreciation(n-1), 0.01)
+}
+
+// DepreciationPosting is one month's depreciation charge for an asset.
+// ProjectID is the project the asset was assigned to when it was posted;
+// charges without one are company overhead.
+type DepreciationPosting struct {
+	PostingID               uuid.UUID     `db:"posting_id"`
+	AssetID                 uuid.UUID     `db:"asset_id"`
+	Period                  string        `db:"period"`
+	Amount                  float64       `db:"amount"`
+	AccumulatedDepreciation float64       `db:"accumulated_depreciation"`
+	BookValue               float64       `db:"book_value"`
+	ProjectID               uuid.NullUUID `db:"project_id"`
+	PostedAt                time.Time     `db:"posted_at"`
+}
+
+// DepreciationAllocation is a period's depreciation charged to one project,
+// or to company overhead when ProjectID is null.
+type DepreciationAllocation struct {
+	ProjectID   uuid.NullUUID  `db:"project_id"`
+	ProjectName sql.NullString `db:"project_name"`
+	Amount      float64        `db:"amount"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// EarnedValue is a project's cost forecast from its progress and the costs
+// recorded so far. The budget is the estimated cost, earned value is the
+// budget times the share of BOQ value completed and planned value is the
+// budget times the share of scheduled work due by now.
+type EarnedValue struct {
+	Budget      float64
+	PlannedCost float64
+	EarnedCost  float64
+	ActualCost  float64
+	// CPI and SPI are 0 when there is nothing to divide by yet.
+	CPI float64
+	SPI float64
+	// EstimateAtCompletion assumes the remaining work runs at the cost
+	// efficiency achieved so far, or to budget before any is measured.
+	EstimateAtCompletion float64
+	EstimateToComplete   float64
+}
+
+func NewEarnedValue(p *PortfolioProject) EarnedValue {
+	ev := EarnedValue{
+		Budget:     p.EstimatedCost,
+		ActualCost: p.ActualCost,
+	}
+	ev.PlannedCost = ev.Budget * p.PlannedProgress()
+	ev.EarnedCost = ev.Budget * p.ActualProgress()
+
+	if ev.ActualCost > 0 {
+		ev.CPI = ev.EarnedCost / ev.ActualCost
+	}
+	if ev.PlannedCost > 0 {
+		ev.SPI = ev.EarnedCost / ev.PlannedCost
+	}
+
+	remaining := max(ev.Budget-ev.EarnedCost, 0)
+	if ev.CPI > 0 {
+		remaining /= ev.CPI
+	}
+	ev.EstimateAtCompletion = ev.ActualCost + remaining
+	ev.EstimateToComplete = remaining
+	return ev
+}
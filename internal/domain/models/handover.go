@@ -0,0 +1,55 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Handover records a completed project being handed to the client. It is
+// editable until the client acknowledges it through the signed link.
+type Handover struct {
+	HandoverID     uuid.UUID      `db:"handover_id"`
+	ProjectID      uuid.UUID      `db:"project_id"`
+	CompletionDate time.Time      `db:"completion_date"`
+	WarrantyMonths int            `db:"warranty_months"`
+	WarrantyTerms  string         `db:"warranty_terms"`
+	AcknowledgedBy sql.NullString `db:"acknowledged_by"`
+	AcknowledgedIP sql.NullString `db:"acknowledged_ip"`
+	AcknowledgedAt sql.NullTime   `db:"acknowledged_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      sql.NullTime   `db:"updated_at"`
+
+	Defects     []HandoverDefect     `db:"-"`
+	Attachments []HandoverAttachment `db:"-"`
+	// CubeTests are the project's concrete tests, handed over as its QA
+	// record.
+	CubeTests []CubeTestDetail `db:"-"`
+}
+
+func (h *Handover) WarrantyEndsAt() time.Time {
+	return h.CompletionDate.AddDate(0, h.WarrantyMonths, 0)
+}
+
+// HandoverDefect is an outstanding defect listed on the certificate. Defects
+// compiled from failed inspections keep a reference to the inspection.
+type HandoverDefect struct {
+	DefectID     uuid.UUID      `db:"defect_id"`
+	HandoverID   uuid.UUID      `db:"handover_id"`
+	InspectionID uuid.NullUUID  `db:"inspection_id"`
+	Location     sql.NullString `db:"location"`
+	Description  string         `db:"description"`
+	Note         sql.NullString `db:"note"`
+	SortOrder    int            `db:"sort_order"`
+}
+
+// HandoverAttachment is an as-built drawing or document handed over with
+// the project.
+type HandoverAttachment struct {
+	AttachmentID uuid.UUID `db:"attachment_id"`
+	HandoverID   uuid.UUID `db:"handover_id"`
+	Name         string    `db:"name"`
+	FileURL      string    `db:"file_url"`
+	CreatedAt    time.Time `db:"created_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccessClaims are what an access token vouches for. ImpersonatorID is set
+// when an owner is acting as UserID for support.
+type AccessClaims struct {
+	UserID         uuid.UUID
+	ImpersonatorID uuid.NullUUID
+	// TokenID and ExpiresAt identify the access token so it can be
+	// revoked; API keys and tokens issued before revocation have no ID.
+	TokenID   uuid.NullUUID
+	ExpiresAt time.Time
+	// SessionID is the login session the access token was issued to.
+	SessionID uuid.NullUUID
+	// APIKeyID is set when the caller authenticated with an API key.
+	APIKeyID uuid.NullUUID
+}
+
+// ImpersonationSession is the audit record of one impersonation token.
+type ImpersonationSession struct {
+	SessionID      uuid.UUID `db:"session_id"`
+	ImpersonatorID uuid.UUID `db:"impersonator_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	Reason         string    `db:"reason"`
+	StartedAt      time.Time `db:"started_at"`
+	ExpiresAt      time.Time `db:"expires_at"`
+}
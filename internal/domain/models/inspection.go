@@ -0,0 +1,77 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InspectionStatus string
+
+const (
+	InspectionStatusPending InspectionStatus = "pending"
+	InspectionStatusPassed  InspectionStatus = "passed"
+	InspectionStatusFailed  InspectionStatus = "failed"
+)
+
+// InspectionTemplate is a checklist attached to a job type. Mandatory
+// templates must have a passed inspection before a BOQ job using that job
+// can be marked complete.
+type InspectionTemplate struct {
+	TemplateID uuid.UUID                `db:"template_id"`
+	JobID      uuid.UUID                `db:"job_id"`
+	Name       string                   `db:"name"`
+	Mandatory  bool                     `db:"mandatory"`
+	CreatedAt  time.Time                `db:"created_at"`
+	UpdatedAt  sql.NullTime             `db:"updated_at"`
+	Items      []InspectionTemplateItem `db:"-"`
+}
+
+type InspectionTemplateItem struct {
+	ItemID      uuid.UUID `db:"item_id"`
+	TemplateID  uuid.UUID `db:"template_id"`
+	Description string    `db:"description"`
+	SortOrder   int       `db:"sort_order"`
+}
+
+// Inspection is one filled-in checklist for a job in a BOQ.
+type Inspection struct {
+	InspectionID uuid.UUID          `db:"inspection_id"`
+	TemplateID   uuid.UUID          `db:"template_id"`
+	BOQID        uuid.UUID          `db:"boq_id"`
+	JobID        uuid.UUID          `db:"job_id"`
+	Status       InspectionStatus   `db:"status"`
+	InspectedBy  sql.NullString     `db:"inspected_by"`
+	InspectedAt  sql.NullTime       `db:"inspected_at"`
+	Notes        sql.NullString     `db:"notes"`
+	CreatedAt    time.Time          `db:"created_at"`
+	Results      []InspectionResult `db:"-"`
+	Photos       []InspectionPhoto  `db:"-"`
+}
+
+type InspectionResult struct {
+	InspectionID uuid.UUID      `db:"inspection_id"`
+	ItemID       uuid.UUID      `db:"item_id"`
+	Description  string         `db:"description"`
+	SortOrder    int            `db:"sort_order"`
+	Passed       sql.NullBool   `db:"passed"`
+	Note         sql.NullString `db:"note"`
+}
+
+type InspectionPhoto struct {
+	PhotoID      uuid.UUID     `db:"photo_id"`
+	InspectionID uuid.UUID     `db:"inspection_id"`
+	ItemID       uuid.NullUUID `db:"item_id"`
+	FileURL      string        `db:"file_url"`
+	CreatedAt    time.Time     `db:"created_at"`
+}
+
+// InspectionFinding is a failed checklist item on an inspection that has
+// not since been superseded by a passed one.
+type InspectionFinding struct {
+	InspectionID uuid.UUID      `db:"inspection_id"`
+	JobName      string         `db:"job_name"`
+	Description  string         `db:"description"`
+	Note         sql.NullString `db:"note"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WarehouseKind separates permanent warehouses from temporary stores set up
+// on a project site.
+type WarehouseKind string
+
+const (
+	WarehouseKindMain WarehouseKind = "main"
+	WarehouseKindSite WarehouseKind = "site"
+)
+
+type WarehouseStatus string
+
+const (
+	WarehouseStatusActive WarehouseStatus = "active"
+	WarehouseStatusClosed WarehouseStatus = "closed"
+)
+
+// Warehouse is a place stock is held. Site stores belong to a project and
+// are closed back into a main warehouse when the project ends.
+type Warehouse struct {
+	WarehouseID uuid.UUID       `db:"warehouse_id"`
+	Name        string          `db:"name"`
+	Kind        WarehouseKind   `db:"kind"`
+	ProjectID   uuid.NullUUID   `db:"project_id"`
+	Status      WarehouseStatus `db:"status"`
+	CreatedAt   time.Time       `db:"created_at"`
+	ClosedAt    sql.NullTime    `db:"closed_at"`
+}
+
+type StockMovementType string
+
+const (
+	StockMovementReceipt     StockMovementType = "receipt"
+	StockMovementIssue       StockMovementType = "issue"
+	StockMovementTransferOut StockMovementType = "transfer_out"
+	StockMovementTransferIn  StockMovementType = "transfer_in"
+	// StockMovementSupplierReturn is stock sent back to the supplier it
+	// was bought from.
+	StockMovementSupplierReturn StockMovementType = "supplier_return"
+	// StockMovementWaste is stock scrapped or wasted on a project.
+	StockMovementWaste StockMovementType = "waste"
+)
+
+// StockMovement is one entry of the stock ledger. Quantity is positive for
+// stock coming in and negative for stock going out; a warehouse's stock of
+// a material is the sum of its movements. Both legs of a transfer share a
+// TransferID.
+type StockMovement struct {
+	MovementID  uuid.UUID         `db:"movement_id"`
+	WarehouseID uuid.UUID         `db:"warehouse_id"`
+	MaterialID  string            `db:"material_id"`
+	Quantity    float64           `db:"quantity"`
+	Type        StockMovementType `db:"type"`
+	TransferID  uuid.NullUUID     `db:"transfer_id"`
+	ProjectID   uuid.NullUUID     `db:"project_id"`
+	Reference   sql.NullString    `db:"reference"`
+	Note        sql.NullString    `db:"note"`
+	CreatedAt   time.Time         `db:"created_at"`
+}
+
+// StockLevel is the quantity of a material on hand in a warehouse.
+type StockLevel struct {
+	WarehouseID   uuid.UUID `db:"warehouse_id"`
+	WarehouseName string    `db:"warehouse_name"`
+	MaterialID    string    `db:"material_id"`
+	MaterialName  string    `db:"material_name"`
+	Unit          string    `db:"unit"`
+	Quantity      float64   `db:"quantity"`
+}
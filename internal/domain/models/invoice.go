@@ -8,9 +8,28 @@ import (
 )
 
 type Invoice struct {
-	InvoiceID uuid.UUID      `db:"invoice_id"`
-	ProjectID uuid.UUID      `db:"project_id"`
-	FileURL   sql.NullString `db:"file_url"`
-	CreatedAt time.Time      `db:"created_at"`
-	UpdatedAt sql.NullTime   `db:"updated_at"`
+	InvoiceID uuid.UUID       `db:"invoice_id"`
+	ProjectID uuid.UUID       `db:"project_id"`
+	PhaseID   uuid.NullUUID   `db:"phase_id"`
+	FileURL   sql.NullString  `db:"file_url"`
+	Amount    sql.NullFloat64 `db:"amount"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt sql.NullTime    `db:"updated_at"`
+}
+
+// InvoiceTaxRow is an invoice with what a VAT report needs from its project,
+// client and quotation. Standard, ZeroRated and Exempt are the quotation's
+// pre-tax bases the invoice amount is apportioned by.
+type InvoiceTaxRow struct {
+	InvoiceID     uuid.UUID       `db:"invoice_id"`
+	ProjectID     uuid.UUID       `db:"project_id"`
+	ProjectName   string          `db:"project_name"`
+	ClientName    string          `db:"client_name"`
+	ClientTaxID   string          `db:"client_tax_id"`
+	Amount        sql.NullFloat64 `db:"amount"`
+	TaxPercentage sql.NullFloat64 `db:"tax_percentage"`
+	Standard      float64         `db:"standard"`
+	ZeroRated     float64         `db:"zero_rated"`
+	Exempt        float64         `db:"exempt"`
+	CreatedAt     time.Time       `db:"created_at"`
 }
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// JWTKey is a secret access tokens are signed with. ID goes in the token's
+// "kid" header so the key can be found again when the token comes back.
+type JWTKey struct {
+	ID     string
+	Secret []byte
+}
+
+// JWTKeySet is the key new tokens are signed with plus the keys it
+// replaced. Tokens signed with a previous key stay valid until they
+// expire, so JWT_SECRET can be rotated without logging everyone out.
+type JWTKeySet struct {
+	Current  JWTKey
+	Previous []JWTKey
+}
+
+// NewJWTKeySet derives each key's ID from its secret, so a secret keeps
+// its ID when it moves from current to previous.
+func NewJWTKeySet(current string, previous []string) JWTKeySet {
+	set := JWTKeySet{Current: newJWTKey(current)}
+	for _, secret := range previous {
+		if secret == current {
+			continue
+		}
+		set.Previous = append(set.Previous, newJWTKey(secret))
+	}
+	return set
+}
+
+// Verifying returns the keys a token with the given kid may have been
+// signed with: the key with that ID, or every key for tokens issued before
+// kids were added.
+func (s JWTKeySet) Verifying(kid string) []JWTKey {
+	keys := append([]JWTKey{s.Current}, s.Previous...)
+	if kid == "" {
+		return keys
+	}
+	for _, key := range keys {
+		if key.ID == kid {
+			return []JWTKey{key}
+		}
+	}
+	return nil
+}
+
+func newJWTKey(secret string) JWTKey {
+	sum := sha256.Sum256([]byte(secret))
+	return JWTKey{ID: hex.EncodeToString(sum[:8]), Secret: []byte(secret)}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type KPIMetric string
+
+const (
+	// KPIMetricSales is the value of contracts signed in the month.
+	KPIMetricSales KPIMetric = "sales"
+	// KPIMetricMargin is the estimated margin percent of those contracts.
+	KPIMetricMargin KPIMetric = "margin"
+	// KPIMetricUtilization is the percent of crew capacity scheduled.
+	KPIMetricUtilization KPIMetric = "utilization"
+)
+
+// KPITarget is the target for one metric in one month. Month is always the
+// first day of the month.
+type KPITarget struct {
+	TargetID  uuid.UUID    `db:"target_id"`
+	Metric    KPIMetric    `db:"metric"`
+	Month     time.Time    `db:"month"`
+	Value     float64      `db:"value"`
+	CreatedAt time.Time    `db:"created_at"`
+	UpdatedAt sql.NullTime `db:"updated_at"`
+}
+
+// KPIActual holds the raw figures behind each metric for one month.
+type KPIActual struct {
+	Month             time.Time `db:"month"`
+	Contracts         int       `db:"contracts"`
+	Sales             float64   `db:"sales"`
+	EstimatedCost     float64   `db:"estimated_cost"`
+	ScheduledCrewDays float64   `db:"scheduled_crew_days"`
+	CrewCapacityDays  float64   `db:"crew_capacity_days"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LatePaymentTerms are a project's contractual terms for interest on late
+// payment. An invoice is due PaymentDays after it is issued and interest
+// accrues daily on the unpaid balance from the day after the due date plus
+// GraceDays.
+type LatePaymentTerms struct {
+	ProjectID         uuid.UUID `db:"project_id"`
+	AnnualRatePercent float64   `db:"annual_rate_percent"`
+	PaymentDays       int       `db:"payment_days"`
+	GraceDays         int       `db:"grace_days"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+// DueDate is the calendar date an invoice issued at issuedAt falls due.
+func (t LatePaymentTerms) DueDate(issuedAt time.Time) time.Time {
+	return CalendarDate(issuedAt).AddDate(0, 0, t.PaymentDays)
+}
+
+// FirstInterestDate is the first day interest accrues for an invoice
+// issued at issuedAt.
+func (t LatePaymentTerms) FirstInterestDate(issuedAt time.Time) time.Time {
+	return t.DueDate(issuedAt).AddDate(0, 0, t.GraceDays+1)
+}
+
+// DailyInterest is one day's simple interest on outstanding at the
+// annual rate, on a 365-day year.
+func (t LatePaymentTerms) DailyInterest(outstanding float64) float64 {
+	return roundToIncrement(outstanding*t.AnnualRatePercent/100/365, 0.01)
+}
+
+// CalendarDate is the Thai calendar date of t as midnight UTC, the form
+// date-only columns are stored in.
+func CalendarDate(t time.Time) time.Time {
+	local := t.In(AccountingPeriodLocation)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// AccruableInvoice is an invoice under late payment terms that may still
+// need interest accrued, with its last accrual date if any.
+type AccruableInvoice struct {
+	InvoiceID       uuid.UUID    `db:"invoice_id"`
+	ProjectID       uuid.UUID    `db:"project_id"`
+	Amount          float64      `db:"amount"`
+	CreatedAt       time.Time    `db:"created_at"`
+	LastAccrualDate sql.NullTime `db:"last_accrual_date"`
+	LatePaymentTerms
+}
+
+// LateInterestAccrual is one day's interest on one overdue invoice. It is
+// billed once, either on a later invoice or on a debit note.
+type LateInterestAccrual struct {
+	AccrualID         uuid.UUID     `db:"accrual_id"`
+	InvoiceID         uuid.UUID     `db:"invoice_id"`
+	ProjectID         uuid.UUID     `db:"project_id"`
+	AccrualDate       time.Time     `db:"accrual_date"`
+	Outstanding       float64       `db:"outstanding"`
+	AnnualRatePercent float64       `db:"annual_rate_percent"`
+	Amount            float64       `db:"amount"`
+	BilledInvoiceID   uuid.NullUUID `db:"billed_invoice_id"`
+	DebitNoteID       uuid.NullUUID `db:"debit_note_id"`
+	CreatedAt         time.Time     `db:"created_at"`
+}
+
+func (a LateInterestAccrual) Billed() bool {
+	return a.BilledInvoiceID.Valid || a.DebitNoteID.Valid
+}
+
+// LateInterestDebitNote bills accrued interest on its own document rather
+// than on the next invoice.
+type LateInterestDebitNote struct {
+	DebitNoteID uuid.UUID `db:"debit_note_id"`
+	ProjectID   uuid.UUID `db:"project_id"`
+	Amount      float64   `db:"amount"`
+	CreatedBy   uuid.UUID `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+}
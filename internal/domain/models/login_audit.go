@@ -0,0 +1,49 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LoginMethod string
+
+const (
+	LoginMethodPassword LoginMethod = "password"
+	LoginMethodGoogle   LoginMethod = "google"
+	LoginMethodLDAP     LoginMethod = "ldap"
+)
+
+// Why a login attempt failed, as recorded in the login audit.
+const (
+	LoginFailureUnknownUser        = "unknown_user"
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureAccountLocked      = "account_locked"
+	LoginFailureNotAllowed         = "not_allowed"
+	LoginFailureError              = "error"
+)
+
+// LoginClient is where a login attempt came from. Fingerprint is the
+// DeviceFingerprint of the client.
+type LoginClient struct {
+	IPAddress   string
+	UserAgent   string
+	Fingerprint string
+}
+
+// LoginAudit records one login attempt, successful or not. UserID is
+// unset when the username or external account matched no user; Username
+// is what was tried, or the external account's email.
+type LoginAudit struct {
+	AuditID       uuid.UUID      `db:"audit_id"`
+	UserID        uuid.NullUUID  `db:"user_id"`
+	Username      string         `db:"username"`
+	Method        LoginMethod    `db:"method"`
+	Success       bool           `db:"success"`
+	FailureReason sql.NullString `db:"failure_reason"`
+	IPAddress     string         `db:"ip_address"`
+	UserAgent     string         `db:"user_agent"`
+	Fingerprint   string         `db:"fingerprint"`
+	AttemptedAt   time.Time      `db:"attempted_at"`
+}
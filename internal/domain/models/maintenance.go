@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceMode is the API's read-only switch. While Enabled, requests
+// that would change data are refused with Message.
+type MaintenanceMode struct {
+	Enabled   bool          `json:"enabled"`
+	Message   string        `json:"message"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	UpdatedBy uuid.NullUUID `json:"updated_by"`
+}
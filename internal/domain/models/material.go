@@ -2,10 +2,25 @@ package models
 
 import "database/sql"
 
+// MaterialClassification separates consumables, which are costed to
+// projects as they are used, from assets, which go on the asset register
+// and are depreciated.
+type MaterialClassification string
+
+const (
+	MaterialClassificationConsumable MaterialClassification = "consumable"
+	MaterialClassificationAsset      MaterialClassification = "asset"
+)
+
+func (c MaterialClassification) Valid() bool {
+	return c == MaterialClassificationConsumable || c == MaterialClassificationAsset
+}
+
 type Material struct {
-	MaterialID string `db:"material_id"`
-	Name       string `db:"name"`
-	Unit       string `db:"unit"`
+	MaterialID     string                 `db:"material_id"`
+	Name           string                 `db:"name"`
+	Unit           string                 `db:"unit"`
+	Classification MaterialClassification `db:"classification"`
 }
 
 type MaterialPriceInfo struct {
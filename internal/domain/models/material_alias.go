@@ -0,0 +1,69 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaterialAliasKind says what an alias is: another name for the material
+// in Thai or English, or the code a particular supplier uses for it.
+type MaterialAliasKind string
+
+const (
+	MaterialAliasKindName         MaterialAliasKind = "name"
+	MaterialAliasKindSupplierCode MaterialAliasKind = "supplier_code"
+)
+
+func (k MaterialAliasKind) Valid() bool {
+	return k == MaterialAliasKindName || k == MaterialAliasKindSupplierCode
+}
+
+// MaterialAlias is another name a material goes by. Names may have a
+// Language; the Display name for a language replaces the material's own
+// name on documents in that language. Supplier codes belong to SupplierID.
+type MaterialAlias struct {
+	AliasID    uuid.UUID         `db:"alias_id"`
+	MaterialID string            `db:"material_id"`
+	Kind       MaterialAliasKind `db:"kind"`
+	Name       string            `db:"name"`
+	Language   sql.NullString    `db:"language"`
+	SupplierID uuid.NullUUID     `db:"supplier_id"`
+	Display    bool              `db:"display"`
+	CreatedAt  time.Time         `db:"created_at"`
+}
+
+// MaterialSearchResult is a material found by search, with the name or
+// alias that matched.
+type MaterialSearchResult struct {
+	Material
+	MatchedName string `db:"matched_name"`
+	MatchedKind string `db:"matched_kind"`
+}
+
+// MaterialDisplayName is the name to print for a material on a document
+// in language, given its Thai and English display names. Bilingual
+// documents show both when they differ.
+func MaterialDisplayName(name string, thai, english sql.NullString, language DocumentLanguage) string {
+	pick := func(alias sql.NullString) string {
+		if alias.Valid {
+			return alias.String
+		}
+		return name
+	}
+
+	switch language {
+	case DocumentLanguageThai:
+		return pick(thai)
+	case DocumentLanguageEnglish:
+		return pick(english)
+	case DocumentLanguageBilingual:
+		th, en := pick(thai), pick(english)
+		if th == en {
+			return th
+		}
+		return th + " / " + en
+	}
+	return name
+}
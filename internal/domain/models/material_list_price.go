@@ -0,0 +1,37 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaterialListPrice is a material's price from EffectiveFrom until the next
+// list price for it takes effect. Prices can be entered ahead of time,
+// e.g. for an increase a supplier has announced.
+type MaterialListPrice struct {
+	PriceID       uuid.UUID      `db:"price_id"`
+	MaterialID    string         `db:"material_id"`
+	Price         float64        `db:"price"`
+	EffectiveFrom time.Time      `db:"effective_from"`
+	Note          sql.NullString `db:"note"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
+// UpcomingPriceImpact is a material in a draft BOQ or quotation whose list
+// price goes up after the draft was priced. UpcomingPrice is the last list
+// price taking effect within the period looked at.
+type UpcomingPriceImpact struct {
+	ProjectID      uuid.UUID     `db:"project_id"`
+	ProjectName    string        `db:"project_name"`
+	BOQID          uuid.UUID     `db:"boq_id"`
+	QuotationID    uuid.NullUUID `db:"quotation_id"`
+	MaterialID     string        `db:"material_id"`
+	MaterialName   string        `db:"material_name"`
+	Quantity       float64       `db:"quantity"`
+	EstimatedPrice float64       `db:"estimated_price"`
+	UpcomingPrice  float64       `db:"upcoming_price"`
+	EffectiveFrom  time.Time     `db:"effective_from"`
+	Delta          float64       `db:"delta"`
+}
@@ -16,4 +16,5 @@ type MaterialPriceLog struct {
 	JobID          uuid.UUID       `db:"job_id"`
 	Quantity       float64         `db:"quantity"`
 	UpdatedAt      sql.NullTime    `db:"updated_at"`
+	EstimatedAt    sql.NullTime    `db:"estimated_at"`
 }
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MaterialReturnReason string
+
+const (
+	MaterialReturnReasonRejected MaterialReturnReason = "rejected"
+	MaterialReturnReasonSurplus  MaterialReturnReason = "surplus"
+)
+
+func (r MaterialReturnReason) Valid() bool {
+	return r == MaterialReturnReasonRejected || r == MaterialReturnReasonSurplus
+}
+
+// CreditNoteStatus tracks the credit note a supplier owes for a return.
+type CreditNoteStatus string
+
+const (
+	CreditNoteStatusPending  CreditNoteStatus = "pending"
+	CreditNoteStatusReceived CreditNoteStatus = "received"
+)
+
+// MaterialReturn sends received materials back to the supplier of a
+// purchase order. ExpectedCredit is what the returned lines cost; the
+// return stays pending until the supplier's credit note arrives. When
+// WarehouseID is set the materials are taken out of that warehouse's
+// stock.
+type MaterialReturn struct {
+	ReturnID         uuid.UUID            `db:"return_id"`
+	ReturnNumber     string               `db:"return_number"`
+	POID             uuid.UUID            `db:"po_id"`
+	SupplierID       uuid.UUID            `db:"supplier_id"`
+	WarehouseID      uuid.NullUUID        `db:"warehouse_id"`
+	Reason           MaterialReturnReason `db:"reason"`
+	ReturnDate       time.Time            `db:"return_date"`
+	Note             sql.NullString       `db:"note"`
+	ExpectedCredit   float64              `db:"expected_credit"`
+	CreditStatus     CreditNoteStatus     `db:"credit_status"`
+	CreditNoteNumber sql.NullString       `db:"credit_note_number"`
+	CreditAmount     sql.NullFloat64      `db:"credit_amount"`
+	CreditDate       sql.NullTime         `db:"credit_date"`
+	CreatedBy        uuid.NullUUID        `db:"created_by"`
+	CreatedAt        time.Time            `db:"created_at"`
+}
+
+// MaterialReturnSummary is a return with its order and supplier for
+// listing.
+type MaterialReturnSummary struct {
+	MaterialReturn
+	PONumber     string `db:"po_number"`
+	SupplierName string `db:"supplier_name"`
+}
+
+// MaterialReturnLine is a quantity of one allocation sent back, at the
+// order line's unit price. ReceiptID is the delivery it came from, when
+// known.
+type MaterialReturnLine struct {
+	LineID       uuid.UUID     `db:"line_id"`
+	ReturnID     uuid.UUID     `db:"return_id"`
+	AllocationID uuid.UUID     `db:"allocation_id"`
+	ReceiptID    uuid.NullUUID `db:"receipt_id"`
+	ProjectID    uuid.UUID     `db:"project_id"`
+	MaterialID   string        `db:"material_id"`
+	Quantity     float64       `db:"quantity"`
+	UnitPrice    float64       `db:"unit_price"`
+	Amount       float64       `db:"amount"`
+}
+
+type MaterialReturnFilter struct {
+	SupplierID   uuid.NullUUID
+	POID         uuid.NullUUID
+	CreditStatus CreditNoteStatus
+}
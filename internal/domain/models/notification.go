@@ -0,0 +1,150 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationEvent is something in a project's workflow people may be
+// told about.
+type NotificationEvent string
+
+const (
+	NotificationEventQuotationApproved     NotificationEvent = "quotation_approved"
+	NotificationEventPurchaseOrderReceived NotificationEvent = "purchase_order_received"
+	// NotificationEventInvoiceOverdue fires once per invoice, on the first
+	// day it is past its client's payment terms and not fully paid.
+	NotificationEventInvoiceOverdue NotificationEvent = "invoice_overdue"
+	// NotificationEventPaymentReceived fires when a payment gateway reports
+	// a payment, which is recorded without anyone entering it.
+	NotificationEventPaymentReceived NotificationEvent = "payment_received"
+)
+
+var NotificationEvents = []NotificationEvent{
+	NotificationEventQuotationApproved,
+	NotificationEventPurchaseOrderReceived,
+	NotificationEventInvoiceOverdue,
+	NotificationEventPaymentReceived,
+}
+
+// NotificationChannel is how a notification reaches someone. LINE needs
+// the user's LINE user ID in their preferences; in-app notifications are
+// listed at /notifications.
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelLine  NotificationChannel = "line"
+	NotificationChannelInApp NotificationChannel = "in_app"
+)
+
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelEmail,
+	NotificationChannelLine,
+	NotificationChannelInApp,
+}
+
+// NotificationRule overrides whether users with a role are told about an
+// event through a channel. Rules without a project are the company's
+// defaults; a project's own rules win over them.
+type NotificationRule struct {
+	ProjectID uuid.NullUUID       `db:"project_id"`
+	Event     NotificationEvent   `db:"event"`
+	Channel   NotificationChannel `db:"channel"`
+	Role      UserRole            `db:"role"`
+	Enabled   bool                `db:"enabled"`
+	UpdatedAt time.Time           `db:"updated_at"`
+}
+
+// NotificationMatrix holds the saved overrides by event, channel and role.
+type NotificationMatrix map[NotificationEvent]map[NotificationChannel]map[UserRole]bool
+
+// NewNotificationMatrix applies each set of rules in turn, so later sets,
+// such as a project's, win over earlier ones.
+func NewNotificationMatrix(ruleSets ...[]NotificationRule) NotificationMatrix {
+	m := NotificationMatrix{}
+	for _, rules := range ruleSets {
+		for _, r := range rules {
+			if m[r.Event] == nil {
+				m[r.Event] = map[NotificationChannel]map[UserRole]bool{}
+			}
+			if m[r.Event][r.Channel] == nil {
+				m[r.Event][r.Channel] = map[UserRole]bool{}
+			}
+			m[r.Event][r.Channel][r.Role] = r.Enabled
+		}
+	}
+	return m
+}
+
+// Notifies reports whether users with role are told about event through
+// channel.
+func (m NotificationMatrix) Notifies(event NotificationEvent, channel NotificationChannel, role UserRole) bool {
+	if enabled, ok := m[event][channel][role]; ok {
+		return enabled
+	}
+	return defaultNotifies(event, channel, role)
+}
+
+// defaultNotifies is who was told about what before the matrix could be
+// configured: project managers hear about their projects' approved
+// quotations and deliveries, and owners and admins about overdue invoices,
+// gateway payments and approvals. LINE is off until it is set up.
+func defaultNotifies(event NotificationEvent, channel NotificationChannel, role UserRole) bool {
+	if channel == NotificationChannelLine {
+		return false
+	}
+	switch event {
+	case NotificationEventQuotationApproved:
+		return role == UserRoleProjectManager || (role.IsAdmin() && channel == NotificationChannelInApp)
+	case NotificationEventPurchaseOrderReceived:
+		return role == UserRoleProjectManager && channel == NotificationChannelInApp
+	case NotificationEventInvoiceOverdue, NotificationEventPaymentReceived:
+		return role.IsAdmin() || (role == UserRoleProjectManager && channel == NotificationChannelInApp)
+	}
+	return false
+}
+
+func (e NotificationEvent) Valid() bool {
+	for _, event := range NotificationEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (c NotificationChannel) Valid() bool {
+	for _, channel := range NotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification is an in-app notification for one user.
+type Notification struct {
+	NotificationID uuid.UUID         `db:"notification_id"`
+	UserID         uuid.UUID         `db:"user_id"`
+	ProjectID      uuid.NullUUID     `db:"project_id"`
+	Event          NotificationEvent `db:"event"`
+	Title          string            `db:"title"`
+	Body           string            `db:"body"`
+	ReadAt         sql.NullTime      `db:"read_at"`
+	CreatedAt      time.Time         `db:"created_at"`
+}
+
+// OverdueInvoice is an invoice that has just gone past its client's
+// payment terms with money still owed.
+type OverdueInvoice struct {
+	InvoiceID   uuid.UUID `db:"invoice_id"`
+	ProjectID   uuid.UUID `db:"project_id"`
+	ProjectName string    `db:"project_name"`
+	ClientName  string    `db:"client_name"`
+	Amount      float64   `db:"amount"`
+	Outstanding float64   `db:"outstanding"`
+	DueDate     time.Time `db:"due_date"`
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy is what a new password must satisfy when registering,
+// changing or resetting it. BannedPasswords is checked on top of the
+// built-in list of common passwords, ignoring case.
+type PasswordPolicy struct {
+	MinLength       int
+	RequireLower    bool
+	RequireUpper    bool
+	RequireDigit    bool
+	RequireSymbol   bool
+	BannedPasswords []string
+}
+
+// DefaultPasswordPolicy asks for eight characters mixing upper and lower
+// case letters and digits.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    8,
+		RequireLower: true,
+		RequireUpper: true,
+		RequireDigit: true,
+	}
+}
+
+// Validate returns the first rule password breaks. username is refused as
+// a password too.
+func (p PasswordPolicy) Validate(password, username string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	switch {
+	case p.RequireLower && !lower:
+		return errors.New("password must contain a lowercase letter")
+	case p.RequireUpper && !upper:
+		return errors.New("password must contain an uppercase letter")
+	case p.RequireDigit && !digit:
+		return errors.New("password must contain a digit")
+	case p.RequireSymbol && !symbol:
+		return errors.New("password must contain a symbol")
+	}
+
+	folded := strings.ToLower(password)
+	if username != "" && folded == strings.ToLower(username) {
+		return errors.New("password must not be the username")
+	}
+	if _, ok := commonPasswords[folded]; ok {
+		return errors.New("password is too common")
+	}
+	for _, banned := range p.BannedPasswords {
+		if strings.EqualFold(password, banned) {
+			return errors.New("password is too common")
+		}
+	}
+	return nil
+}
+
+// commonPasswords are among the most used passwords in public breach
+// corpora, lower-cased, plus a few local favourites.
+var commonPasswords = passwordSet([]string{
+	"123456", "123456789", "12345678", "1234567890", "12345", "1234567",
+	"password", "password1", "password12", "password123", "passw0rd",
+	"p@ssw0rd", "p@ssword", "qwerty", "qwerty123", "qwertyuiop", "qwerty1",
+	"1q2w3e4r", "1q2w3e4r5t", "1qaz2wsx", "zaq12wsx", "asdfghjkl", "asdf1234",
+	"abc123", "abcd1234", "abc12345", "a1b2c3d4", "iloveyou", "iloveyou1",
+	"111111", "11111111", "000000", "00000000", "123123", "123123123",
+	"666666", "654321", "987654321", "112233", "121212", "88888888",
+	"admin", "admin123", "admin1234", "administrator", "root", "toor",
+	"welcome", "welcome1", "welcome123", "letmein", "letmein1", "login",
+	"monkey", "dragon", "football", "baseball", "sunshine", "princess",
+	"master", "shadow", "superman", "batman", "trustno1", "starwars",
+	"secret", "changeme", "default", "guest", "test", "test123", "testing",
+	"computer", "internet", "whatever", "freedom", "hello123", "helloworld",
+	"michael", "charlie", "jennifer", "jordan23", "liverpool", "chelsea",
+	"manchester", "arsenal", "thailand", "bangkok", "sawasdee", "boonkosang",
+	"construction", "company", "qwer1234", "aa123456", "1234qwer",
+})
+
+func passwordSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentMethod string
+
+const (
+	PaymentMethodCash         PaymentMethod = "cash"
+	PaymentMethodBankTransfer PaymentMethod = "bank_transfer"
+	PaymentMethodCheque       PaymentMethod = "cheque"
+	PaymentMethodPromptPay    PaymentMethod = "promptpay"
+	PaymentMethodCreditCard   PaymentMethod = "credit_card"
+)
+
+type Payment struct {
+	PaymentID uuid.UUID      `db:"payment_id"`
+	InvoiceID uuid.UUID      `db:"invoice_id"`
+	Amount    float64        `db:"amount"`
+	Method    PaymentMethod  `db:"method"`
+	Reference sql.NullString `db:"reference"`
+	Note      sql.NullString `db:"note"`
+	PaidAt    time.Time      `db:"paid_at"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+type Receipt struct {
+	ReceiptID     uuid.UUID `db:"receipt_id"`
+	ReceiptNumber string    `db:"receipt_number"`
+	PaymentID     uuid.UUID `db:"payment_id"`
+	CreatedAt     time.Time `db:"created_at"`
+}
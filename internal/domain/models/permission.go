@@ -0,0 +1,139 @@
+package models
+
+import "time"
+
+// PermissionResource is an area of the application a permission covers.
+type PermissionResource string
+
+const (
+	PermissionResourceProjects   PermissionResource = "projects"
+	PermissionResourceClients    PermissionResource = "clients"
+	PermissionResourceBOQs       PermissionResource = "boqs"
+	PermissionResourceQuotations PermissionResource = "quotations"
+	// PermissionResourcePrices covers selling, estimated and actual prices,
+	// so staff can be allowed to view prices without editing them.
+	PermissionResourcePrices    PermissionResource = "prices"
+	PermissionResourceMaterials PermissionResource = "materials"
+	PermissionResourceInvoices  PermissionResource = "invoices"
+	PermissionResourceSuppliers PermissionResource = "suppliers"
+	// PermissionResourceSubcontracts covers subcontracts and certifying
+	// their progress claims.
+	PermissionResourceSubcontracts PermissionResource = "subcontracts"
+	// PermissionResourceAssets covers fixed assets and their depreciation.
+	PermissionResourceAssets PermissionResource = "assets"
+	// PermissionResourceSettings covers company-wide settings: the company
+	// profile, document labels and the data warehouse export.
+	PermissionResourceSettings PermissionResource = "settings"
+)
+
+var PermissionResources = []PermissionResource{
+	PermissionResourceProjects,
+	PermissionResourceClients,
+	PermissionResourceBOQs,
+	PermissionResourceQuotations,
+	PermissionResourcePrices,
+	PermissionResourceMaterials,
+	PermissionResourceInvoices,
+	PermissionResourceSuppliers,
+	PermissionResourceSubcontracts,
+	PermissionResourceAssets,
+	PermissionResourceSettings,
+}
+
+type PermissionAction string
+
+const (
+	PermissionActionView    PermissionAction = "view"
+	PermissionActionEdit    PermissionAction = "edit"
+	PermissionActionApprove PermissionAction = "approve"
+	PermissionActionDelete  PermissionAction = "delete"
+)
+
+var PermissionActions = []PermissionAction{
+	PermissionActionView,
+	PermissionActionEdit,
+	PermissionActionApprove,
+	PermissionActionDelete,
+}
+
+// RolePermission overrides whether a role may perform an action on a
+// resource.
+type RolePermission struct {
+	Role      UserRole           `db:"role"`
+	Resource  PermissionResource `db:"resource"`
+	Action    PermissionAction   `db:"action"`
+	Allowed   bool               `db:"allowed"`
+	UpdatedAt time.Time          `db:"updated_at"`
+}
+
+// PermissionMatrix holds the saved overrides by role, resource and action.
+type PermissionMatrix map[UserRole]map[PermissionResource]map[PermissionAction]bool
+
+func NewPermissionMatrix(permissions []RolePermission) PermissionMatrix {
+	m := PermissionMatrix{}
+	for _, p := range permissions {
+		if m[p.Role] == nil {
+			m[p.Role] = map[PermissionResource]map[PermissionAction]bool{}
+		}
+		if m[p.Role][p.Resource] == nil {
+			m[p.Role][p.Resource] = map[PermissionAction]bool{}
+		}
+		m[p.Role][p.Resource][p.Action] = p.Allowed
+	}
+	return m
+}
+
+// Allows reports whether role may perform action on resource. Owners and
+// admins may always do everything so they can't lock themselves out; for
+// other roles a saved override wins over the role's default.
+func (m PermissionMatrix) Allows(role UserRole, resource PermissionResource, action PermissionAction) bool {
+	if role.IsAdmin() {
+		return true
+	}
+	if allowed, ok := m[role][resource][action]; ok {
+		return allowed
+	}
+	return defaultAllows(role, resource, action)
+}
+
+// defaultAllows is what a role may do before any override is saved. Only
+// estimators approve BOQs and quotations, project managers approve project
+// and subcontract work, and viewers only view. Settings are view only for
+// everyone but owners and admins. Staff keep everything else they could do
+// before roles existed.
+func defaultAllows(role UserRole, resource PermissionResource, action PermissionAction) bool {
+	if resource == PermissionResourceSettings {
+		return action == PermissionActionView
+	}
+	switch role {
+	case UserRoleViewer:
+		return action == PermissionActionView
+	case UserRoleEstimator:
+		return action != PermissionActionApprove ||
+			resource == PermissionResourceBOQs || resource == PermissionResourceQuotations
+	case UserRoleProjectManager:
+		return action != PermissionActionApprove ||
+			resource == PermissionResourceProjects || resource == PermissionResourceSubcontracts
+	default:
+		return action != PermissionActionApprove ||
+			(resource != PermissionResourceBOQs && resource != PermissionResourceQuotations)
+	}
+}
+
+func (r PermissionResource) Valid() bool {
+	for _, resource := range PermissionResources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+func (a PermissionAction) Valid() bool {
+	for _, action := range PermissionActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceIndex is a benchmark series (e.g. steel, cement) that materials can be
+// tracked against.
+type PriceIndex struct {
+	IndexID     uuid.UUID      `db:"index_id"`
+	Code        string         `db:"code"`
+	Name        string         `db:"name"`
+	Unit        sql.NullString `db:"unit"`
+	Description sql.NullString `db:"description"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+type PriceIndexValue struct {
+	IndexID    uuid.UUID `db:"index_id"`
+	PeriodDate time.Time `db:"period_date"`
+	Value      float64   `db:"value"`
+	Source     string    `db:"source"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// PriceIndexAlert is a material in a draft quotation whose linked index has
+// moved since the material's estimated price was set.
+type PriceIndexAlert struct {
+	ProjectID     uuid.UUID `db:"project_id"`
+	ProjectName   string    `db:"project_name"`
+	QuotationID   uuid.UUID `db:"quotation_id"`
+	BOQID         uuid.UUID `db:"boq_id"`
+	MaterialID    string    `db:"material_id"`
+	MaterialName  string    `db:"material_name"`
+	IndexID       uuid.UUID `db:"index_id"`
+	IndexCode     string    `db:"index_code"`
+	EstimatedAt   time.Time `db:"estimated_at"`
+	BaseDate      time.Time `db:"base_date"`
+	BaseValue     float64   `db:"base_value"`
+	LatestDate    time.Time `db:"latest_date"`
+	LatestValue   float64   `db:"latest_value"`
+	ChangePercent float64   `db:"change_percent"`
+}
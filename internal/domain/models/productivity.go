@@ -0,0 +1,37 @@
+package models
+
+import "math"
+
+// ProductivityScenario adjusts a schedule's labor assumptions. Both are
+// percentage changes, so CrewSizePercent -20 puts a fifth fewer workers on
+// every task and ProductivityPercent 10 has each worker get a tenth more
+// done per day.
+type ProductivityScenario struct {
+	CrewSizePercent     float64
+	ProductivityPercent float64
+}
+
+func (s ProductivityScenario) Valid() bool {
+	return s.CrewSizePercent > -100 && s.ProductivityPercent > -100
+}
+
+// Workers is a task's crew under the scenario, never fewer than one.
+func (s ProductivityScenario) Workers(workers int) int {
+	return max(int(math.Round(float64(workers)*(1+s.CrewSizePercent/100))), 1)
+}
+
+// Days is how long a task that took days with workers takes under the
+// scenario. The task's work, in baseline worker-days, stays the same and
+// partial days round up.
+func (s ProductivityScenario) Days(workers, days int) int {
+	work := float64(workers * days)
+	rate := float64(s.Workers(workers)) * (1 + s.ProductivityPercent/100)
+	return max(int(math.Ceil(work/rate-1e-9)), 1)
+}
+
+// Stretch is how much the scenario lengthens (above 1) or shortens the
+// schedule as a whole, used to move task start dates so tasks keep their
+// order and overlap.
+func (s ProductivityScenario) Stretch() float64 {
+	return 1 / ((1 + s.CrewSizePercent/100) * (1 + s.ProductivityPercent/100))
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectArchive records a completed project's heavy child data being moved
+// to cold storage. The project and its financial records stay hot, and
+// RowCounts says how many rows of each table were moved. An archive stays
+// active until it is restored.
+type ProjectArchive struct {
+	ArchiveID  uuid.UUID       `db:"archive_id"`
+	ProjectID  uuid.UUID       `db:"project_id"`
+	ObjectKey  string          `db:"object_key"`
+	SizeBytes  int64           `db:"size_bytes"`
+	RowCounts  json.RawMessage `db:"row_counts"`
+	ArchivedBy uuid.UUID       `db:"archived_by"`
+	ArchivedAt time.Time       `db:"archived_at"`
+	RestoredBy uuid.NullUUID   `db:"restored_by"`
+	RestoredAt sql.NullTime    `db:"restored_at"`
+}
+
+func (a ProjectArchive) Active() bool {
+	return !a.RestoredAt.Valid
+}
+
+// ArchivedRows are one table's rows for a project as a JSON array.
+type ArchivedRows struct {
+	Rows  json.RawMessage `db:"rows"`
+	Count int             `db:"count"`
+}
+
+// ProjectArchiveFile is what is written, gzipped, to cold storage.
+type ProjectArchiveFile struct {
+	ArchiveID  uuid.UUID                  `json:"archive_id"`
+	ProjectID  uuid.UUID                  `json:"project_id"`
+	ArchivedAt time.Time                  `json:"archived_at"`
+	Tables     map[string]json.RawMessage `json:"tables"`
+}
+
+// ArchiveCategory groups archived tables for the summary shown while a
+// project is archived.
+type ArchiveCategory string
+
+const (
+	ArchiveCategoryPhotos    ArchiveCategory = "photos"
+	ArchiveCategoryLogs      ArchiveCategory = "logs"
+	ArchiveCategoryRevisions ArchiveCategory = "revisions"
+)
+
+var archivedTableCategories = map[string]ArchiveCategory{
+	"inspection_photo":            ArchiveCategoryPhotos,
+	"project_handover_attachment": ArchiveCategoryPhotos,
+	"inspection_result":           ArchiveCategoryLogs,
+	"boq_job_drawing":             ArchiveCategoryRevisions,
+}
+
+// ArchivedTableCategory says which summary category rows of table count
+// towards.
+func ArchivedTableCategory(table string) ArchiveCategory {
+	return archivedTableCategories[table]
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectMember assigns a user to a project. Users without an admin role
+// can only see and change the projects they are members of.
+type ProjectMember struct {
+	ProjectID uuid.UUID     `db:"project_id"`
+	UserID    uuid.UUID     `db:"user_id"`
+	AddedBy   uuid.NullUUID `db:"added_by"`
+	AddedAt   time.Time     `db:"added_at"`
+}
+
+// ProjectMemberDetail is a member with the user's details for listing.
+type ProjectMemberDetail struct {
+	ProjectMember
+	Username  string   `db:"username"`
+	FirstName string   `db:"first_name"`
+	LastName  string   `db:"last_name"`
+	Role      UserRole `db:"role"`
+	IsActive  bool     `db:"is_active"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectPartner is a company sharing a joint venture project. A project
+// with partners lists every party, including our own company (IsSelf), and
+// their shares add up to 100%. Projects without partners are ours alone.
+type ProjectPartner struct {
+	PartnerID    uuid.UUID      `db:"partner_id"`
+	ProjectID    uuid.UUID      `db:"project_id"`
+	Name         string         `db:"name"`
+	TaxID        sql.NullString `db:"tax_id"`
+	SharePercent float64        `db:"share_percent"`
+	IsSelf       bool           `db:"is_self"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultProjectPhases are created by the phase defaults endpoint for a
+// typical building project.
+var DefaultProjectPhases = []string{"Foundation", "Structure", "Roofing", "Finishing"}
+
+// ProjectPhase groups BOQ jobs and invoices of a project into a work
+// package such as foundation or roofing.
+type ProjectPhase struct {
+	PhaseID   uuid.UUID `db:"phase_id"`
+	ProjectID uuid.UUID `db:"project_id"`
+	Name      string    `db:"name"`
+	SortOrder int       `db:"sort_order"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// PhaseSummary holds a phase's subtotals. A row with no PhaseID covers
+// jobs and invoices that aren't assigned to a phase.
+type PhaseSummary struct {
+	PhaseID        uuid.NullUUID  `db:"phase_id"`
+	PhaseName      sql.NullString `db:"phase_name"`
+	SortOrder      sql.NullInt64  `db:"sort_order"`
+	EstimatedTotal float64        `db:"estimated_total"`
+	QuotedTotal    float64        `db:"quoted_total"`
+	InvoicedTotal  float64        `db:"invoiced_total"`
+	PaidTotal      float64        `db:"paid_total"`
+}
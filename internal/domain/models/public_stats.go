@@ -0,0 +1,10 @@
+package models
+
+// PublicStats are company-wide aggregates safe to show on the public
+// website. Nothing here identifies a client or a price.
+type PublicStats struct {
+	ProjectsCompleted  int     `db:"projects_completed"`
+	ProjectsInProgress int     `db:"projects_in_progress"`
+	ClientsServed      int     `db:"clients_served"`
+	AreaBuiltM2        float64 `db:"area_built_m2"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusOpen              PurchaseOrderStatus = "open"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "cancelled"
+)
+
+// PurchaseOrder buys materials from one supplier for one or more projects.
+// Each line's quantity is split between projects by its allocations, and
+// each allocation is delivered to its own address. An order with an
+// AgreementID is a release against that blanket agreement.
+type PurchaseOrder struct {
+	POID        uuid.UUID           `db:"po_id"`
+	PONumber    string              `db:"po_number"`
+	SupplierID  uuid.UUID           `db:"supplier_id"`
+	AgreementID uuid.NullUUID       `db:"agreement_id"`
+	Status      PurchaseOrderStatus `db:"status"`
+	OrderDate   time.Time           `db:"order_date"`
+	Note        sql.NullString      `db:"note"`
+	CreatedBy   uuid.NullUUID       `db:"created_by"`
+	CreatedAt   time.Time           `db:"created_at"`
+}
+
+// PurchaseOrderSummary is an order with its supplier's name and totals for
+// listing.
+type PurchaseOrderSummary struct {
+	PurchaseOrder
+	SupplierName   string  `db:"supplier_name"`
+	TotalAmount    float64 `db:"total_amount"`
+	ReceivedAmount float64 `db:"received_amount"`
+}
+
+type PurchaseOrderLine struct {
+	LineID     uuid.UUID `db:"line_id"`
+	POID       uuid.UUID `db:"po_id"`
+	MaterialID string    `db:"material_id"`
+	UnitPrice  float64   `db:"unit_price"`
+	SortOrder  int       `db:"sort_order"`
+}
+
+// PurchaseOrderAllocation is the part of a line bought for one project.
+type PurchaseOrderAllocation struct {
+	AllocationID     uuid.UUID       `db:"allocation_id"`
+	LineID           uuid.UUID       `db:"line_id"`
+	ProjectID        uuid.UUID       `db:"project_id"`
+	Quantity         float64         `db:"quantity"`
+	ReceivedQuantity float64         `db:"received_quantity"`
+	ReturnedQuantity float64         `db:"returned_quantity"`
+	DeliveryAddress  json.RawMessage `db:"delivery_address"`
+}
+
+// Outstanding is the quantity still to be delivered.
+func (a PurchaseOrderAllocation) Outstanding() float64 {
+	return math.Round((a.Quantity-a.ReceivedQuantity)*10000) / 10000
+}
+
+// Returnable is the quantity received that hasn't been sent back.
+func (a PurchaseOrderAllocation) Returnable() float64 {
+	return math.Round((a.ReceivedQuantity-a.ReturnedQuantity)*10000) / 10000
+}
+
+// PurchaseOrderReceipt is goods delivered against an allocation. Amount is
+// their cost at the line's unit price, which is charged to the
+// allocation's project.
+type PurchaseOrderReceipt struct {
+	ReceiptID    uuid.UUID     `db:"receipt_id"`
+	AllocationID uuid.UUID     `db:"allocation_id"`
+	Quantity     float64       `db:"quantity"`
+	Amount       float64       `db:"amount"`
+	ReceivedDate time.Time     `db:"received_date"`
+	ReceivedBy   uuid.NullUUID `db:"received_by"`
+	CreatedAt    time.Time     `db:"created_at"`
+}
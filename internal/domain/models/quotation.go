@@ -15,6 +15,30 @@ const (
 	QuotationStatusApproved QuotationStatus = "approved"
 )
 
+// TaxTreatment is the VAT treatment of a quotation line. Zero-rated lines
+// are taxable at 0% and can reclaim input VAT; exempt lines are outside
+// VAT altogether. Both appear separately on VAT reports.
+type TaxTreatment string
+
+const (
+	TaxTreatmentStandard  TaxTreatment = "standard"
+	TaxTreatmentZeroRated TaxTreatment = "zero_rated"
+	TaxTreatmentExempt    TaxTreatment = "exempt"
+)
+
+// QuotationTaxBases splits a quotation's pre-tax total by treatment.
+// General costs and transport are always standard-rated.
+type QuotationTaxBases struct {
+	Standard  float64 `db:"standard"`
+	ZeroRated float64 `db:"zero_rated"`
+	Exempt    float64 `db:"exempt"`
+}
+
+// Total is the quotation's pre-tax amount.
+func (b QuotationTaxBases) Total() float64 {
+	return b.Standard + b.ZeroRated + b.Exempt
+}
+
 type Quotation struct {
 	QuotationID   uuid.UUID       `db:"quotation_id"`
 	ProjectID     uuid.UUID       `db:"project_id"`
@@ -40,6 +64,8 @@ type QuotationJob struct {
 	SellingPrice       sql.NullFloat64 `db:"selling_price"`
 	Total              sql.NullFloat64 `db:"total"`
 	TotalSellingPrice  sql.NullFloat64 `db:"total_selling_price"`
+	TransportCost      float64         `db:"transport_cost"`
+	TaxTreatment       TaxTreatment    `db:"tax_treatment"`
 }
 
 type QuotationGeneralCost struct {
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotationNegotiationRound is one client counter-offer on a quotation and
+// how we answered it. Rounds are numbered from 1 in the order recorded.
+type QuotationNegotiationRound struct {
+	RoundID          uuid.UUID      `db:"round_id"`
+	QuotationID      uuid.UUID      `db:"quotation_id"`
+	RoundNumber      int            `db:"round_number"`
+	CounterOffer     float64        `db:"counter_offer"`
+	OfferedOn        time.Time      `db:"offered_on"`
+	ClientNotes      sql.NullString `db:"client_notes"`
+	InternalResponse sql.NullString `db:"internal_response"`
+	CreatedAt        time.Time      `db:"created_at"`
+	UpdatedAt        sql.NullTime   `db:"updated_at"`
+}
+
+type QuotationOutcome string
+
+const (
+	QuotationOutcomeWon  QuotationOutcome = "won"
+	QuotationOutcomeLost QuotationOutcome = "lost"
+	QuotationOutcomeOpen QuotationOutcome = "open"
+)
+
+// QuotationOutcomeRow is one quotation in the win/loss report with its
+// negotiation summary. A quotation is won once approved, lost when its
+// project was cancelled before approval, and open otherwise.
+type QuotationOutcomeRow struct {
+	QuotationID      uuid.UUID        `db:"quotation_id"`
+	ProjectID        uuid.UUID        `db:"project_id"`
+	ProjectName      string           `db:"project_name"`
+	ClientName       string           `db:"client_name"`
+	Outcome          QuotationOutcome `db:"outcome"`
+	QuotedAmount     float64          `db:"quoted_amount"`
+	Rounds           int              `db:"rounds"`
+	LastCounterOffer sql.NullFloat64  `db:"last_counter_offer"`
+	ProjectCreatedAt time.Time        `db:"project_created_at"`
+}
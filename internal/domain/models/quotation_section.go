@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotationSectionKind is one of the free-text parts of a quotation printed
+// around the priced lines.
+type QuotationSectionKind string
+
+const (
+	QuotationSectionCoverLetter QuotationSectionKind = "cover_letter"
+	QuotationSectionScopeOfWork QuotationSectionKind = "scope_of_work"
+	QuotationSectionExclusions  QuotationSectionKind = "exclusions"
+	QuotationSectionAssumptions QuotationSectionKind = "assumptions"
+)
+
+// QuotationSectionKinds lists the kinds in the order they're printed.
+var QuotationSectionKinds = []QuotationSectionKind{
+	QuotationSectionCoverLetter,
+	QuotationSectionScopeOfWork,
+	QuotationSectionExclusions,
+	QuotationSectionAssumptions,
+}
+
+func (k QuotationSectionKind) Valid() bool {
+	for _, kind := range QuotationSectionKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Title is the heading the section is printed under.
+func (k QuotationSectionKind) Title() string {
+	switch k {
+	case QuotationSectionCoverLetter:
+		return "Cover letter"
+	case QuotationSectionScopeOfWork:
+		return "Scope of work"
+	case QuotationSectionExclusions:
+		return "Exclusions"
+	case QuotationSectionAssumptions:
+		return "Assumptions"
+	default:
+		return string(k)
+	}
+}
+
+// QuotationSection is a quotation's text for one section kind. Exclusions
+// and assumptions hold one item per line.
+type QuotationSection struct {
+	QuotationID uuid.UUID            `db:"quotation_id"`
+	Kind        QuotationSectionKind `db:"kind"`
+	Content     string               `db:"content"`
+	UpdatedAt   time.Time            `db:"updated_at"`
+}
+
+// QuotationSnippet is reusable section text, e.g. a standard exclusions
+// list, copied into quotations.
+type QuotationSnippet struct {
+	SnippetID uuid.UUID            `db:"snippet_id"`
+	Kind      QuotationSectionKind `db:"kind"`
+	Title     string               `db:"title"`
+	Content   string               `db:"content"`
+	CreatedAt time.Time            `db:"created_at"`
+	UpdatedAt sql.NullTime         `db:"updated_at"`
+}
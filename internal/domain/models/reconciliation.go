@@ -0,0 +1,34 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReconciliationTotals are a project's estimate and quotation figures.
+// EstimatedCost counts materials without an estimated price as zero;
+// UnpricedMaterials says how many there are.
+type ReconciliationTotals struct {
+	BOQID             uuid.NullUUID   `db:"boq_id"`
+	BOQStatus         sql.NullString  `db:"boq_status"`
+	EstimatedCost     float64         `db:"estimated_cost"`
+	UnpricedMaterials int             `db:"unpriced_materials"`
+	QuotationID       uuid.NullUUID   `db:"quotation_id"`
+	QuotationStatus   sql.NullString  `db:"quotation_status"`
+	SellingPrice      float64         `db:"selling_price"`
+	TaxPercentage     float64         `db:"tax_percentage"`
+	FinalAmount       sql.NullFloat64 `db:"final_amount"`
+}
+
+// ReconciliationInvoice is an invoice with what has been paid against it
+// and the late interest billed on it.
+type ReconciliationInvoice struct {
+	InvoiceID    uuid.UUID       `db:"invoice_id"`
+	PhaseID      uuid.NullUUID   `db:"phase_id"`
+	Amount       sql.NullFloat64 `db:"amount"`
+	LateInterest float64         `db:"late_interest"`
+	Paid         float64         `db:"paid"`
+	CreatedAt    time.Time       `db:"created_at"`
+}
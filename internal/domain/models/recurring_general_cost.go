@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurringGeneralCost is a project general cost charged every month, such
+// as site rental, security or utilities. It is posted to the project's
+// general cost of the same type for each month from StartPeriod through
+// EndPeriod, or until the project closes when EndPeriod is null.
+type RecurringGeneralCost struct {
+	RecurringID   uuid.UUID      `db:"recurring_id"`
+	ProjectID     uuid.UUID      `db:"project_id"`
+	TypeName      string         `db:"type_name"`
+	Description   string         `db:"description"`
+	MonthlyAmount float64        `db:"monthly_amount"`
+	StartPeriod   string         `db:"start_period"`
+	EndPeriod     sql.NullString `db:"end_period"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+// Periods lists the months the cost is charged for up to and including
+// through, in order.
+func (r RecurringGeneralCost) Periods(through string) []string {
+	if r.EndPeriod.Valid && r.EndPeriod.String < through {
+		through = r.EndPeriod.String
+	}
+	start, err := time.Parse(AccountingPeriodLayout, r.StartPeriod)
+	if err != nil {
+		return nil
+	}
+
+	var periods []string
+	for month := start; ; month = month.AddDate(0, 1, 0) {
+		period := month.Format(AccountingPeriodLayout)
+		if period > through {
+			return periods
+		}
+		periods = append(periods, period)
+	}
+}
+
+// RecurringCostPosting is one month of a recurring cost added to the
+// project's actual general cost.
+type RecurringCostPosting struct {
+	PostingID   uuid.UUID `db:"posting_id"`
+	RecurringID uuid.UUID `db:"recurring_id"`
+	Period      string    `db:"period"`
+	Amount      float64   `db:"amount"`
+	PostedAt    time.Time `db:"posted_at"`
+}
+
+// Remaining is the amount still to be posted given the periods already
+// posted. It is only known when the cost has an end period.
+func (r RecurringGeneralCost) Remaining(posted map[string]bool) (float64, bool) {
+	if !r.EndPeriod.Valid {
+		return 0, false
+	}
+	remaining := 0.0
+	for _, period := range r.Periods(r.EndPeriod.String) {
+		if !posted[period] {
+			remaining += r.MonthlyAmount
+		}
+	}
+	return roundToIncrement(remaining, 0.01), true
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is a long-lived token exchanged for a new access token.
+// Only the SHA-256 of the token is stored. Each refresh replaces the token
+// with a new one in the same family; presenting a replaced token again
+// means it leaked, so the whole family is revoked.
+type RefreshToken struct {
+	TokenID    uuid.UUID     `db:"token_id"`
+	UserID     uuid.UUID     `db:"user_id"`
+	FamilyID   uuid.UUID     `db:"family_id"`
+	TokenHash  string        `db:"token_hash"`
+	ExpiresAt  time.Time     `db:"expires_at"`
+	CreatedAt  time.Time     `db:"created_at"`
+	RevokedAt  sql.NullTime  `db:"revoked_at"`
+	ReplacedBy uuid.NullUUID `db:"replaced_by"`
+}
+
+// RevokedAccessToken blocks an access token, by its jti claim, until it
+// would have expired anyway.
+type RevokedAccessToken struct {
+	TokenID   uuid.UUID `db:"token_id"`
+	UserID    uuid.UUID `db:"user_id"`
+	ExpiresAt time.Time `db:"expires_at"`
+	RevokedAt time.Time `db:"revoked_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RegionPreset carries regional cost defaults. BOQ labor costs are entered
+// at base (Bangkok) rates and scaled by LaborRateFactor in the estimate;
+// transport is added as a percentage of material cost.
+type RegionPreset struct {
+	RegionID             uuid.UUID    `db:"region_id"`
+	Code                 string       `db:"code"`
+	Name                 string       `db:"name"`
+	DailyLaborRate       float64      `db:"daily_labor_rate"`
+	LaborRateFactor      float64      `db:"labor_rate_factor"`
+	TransportCostPercent float64      `db:"transport_cost_percent"`
+	CreatedAt            time.Time    `db:"created_at"`
+	UpdatedAt            sql.NullTime `db:"updated_at"`
+}
+
+// DefaultRegionPresets seed a new installation.
+var DefaultRegionPresets = []RegionPreset{
+	{Code: "bangkok", Name: "Bangkok", DailyLaborRate: 450, LaborRateFactor: 1.00, TransportCostPercent: 3},
+	{Code: "isaan", Name: "Isaan", DailyLaborRate: 380, LaborRateFactor: 0.85, TransportCostPercent: 6},
+	{Code: "south", Name: "South", DailyLaborRate: 420, LaborRateFactor: 0.95, TransportCostPercent: 7},
+}
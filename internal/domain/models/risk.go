@@ -0,0 +1,59 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RiskStatus string
+
+const (
+	RiskStatusOpen       RiskStatus = "open"
+	RiskStatusMitigating RiskStatus = "mitigating"
+	RiskStatusClosed     RiskStatus = "closed"
+)
+
+type RiskLevel string
+
+const (
+	RiskLevelLow    RiskLevel = "low"
+	RiskLevelMedium RiskLevel = "medium"
+	RiskLevelHigh   RiskLevel = "high"
+)
+
+// Risk scores run from 1 to 5 for both probability and impact.
+const (
+	RiskScaleMax       = 5
+	RiskHighScoreMin   = 15
+	RiskMediumScoreMin = 6
+)
+
+type Risk struct {
+	RiskID      uuid.UUID      `db:"risk_id"`
+	ProjectID   uuid.UUID      `db:"project_id"`
+	Description string         `db:"description"`
+	Probability int            `db:"probability"`
+	Impact      int            `db:"impact"`
+	Mitigation  sql.NullString `db:"mitigation"`
+	Owner       sql.NullString `db:"owner"`
+	Status      RiskStatus     `db:"status"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   sql.NullTime   `db:"updated_at"`
+}
+
+func (r *Risk) Score() int {
+	return r.Probability * r.Impact
+}
+
+func (r *Risk) Level() RiskLevel {
+	switch score := r.Score(); {
+	case score >= RiskHighScoreMin:
+		return RiskLevelHigh
+	case score >= RiskMediumScoreMin:
+		return RiskLevelMedium
+	default:
+		return RiskLevelLow
+	}
+}
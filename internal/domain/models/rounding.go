@@ -0,0 +1,105 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// RoundingMode decides where money amounts are rounded to the policy's
+// increment: on every line, or only on the grand total.
+type RoundingMode string
+
+const (
+	RoundingModeLine  RoundingMode = "line"
+	RoundingModeTotal RoundingMode = "total"
+)
+
+// RoundingIncrements are the supported granularities in THB. 0.01 rounds to
+// the satang.
+var RoundingIncrements = []float64{0.01, 0.25, 0.50, 1}
+
+// RoundingPolicy is the company-wide rounding rule for quotation and invoice
+// math. In line mode each line is rounded to Increment and the total is
+// their exact sum. In total mode lines are rounded to the satang and only
+// the grand total is rounded to Increment, with the difference shown as a
+// rounding adjustment. Tax is always rounded to the satang.
+type RoundingPolicy struct {
+	Mode      RoundingMode `db:"mode" json:"mode"`
+	Increment float64      `db:"increment" json:"increment"`
+	UpdatedAt time.Time    `db:"updated_at" json:"updated_at"`
+}
+
+// DefaultRoundingPolicy rounds everything to the satang.
+func DefaultRoundingPolicy() RoundingPolicy {
+	return RoundingPolicy{Mode: RoundingModeTotal, Increment: 0.01}
+}
+
+// Line rounds a line amount.
+func (p RoundingPolicy) Line(v float64) float64 {
+	if p.Mode == RoundingModeLine {
+		return roundToIncrement(v, p.Increment)
+	}
+	return roundToIncrement(v, 0.01)
+}
+
+// Total rounds a grand total.
+func (p RoundingPolicy) Total(v float64) float64 {
+	if p.Mode == RoundingModeTotal {
+		return roundToIncrement(v, p.Increment)
+	}
+	return roundToIncrement(v, 0.01)
+}
+
+// TaxedLine is a pre-tax amount and its VAT treatment.
+type TaxedLine struct {
+	Amount    float64
+	Treatment TaxTreatment
+}
+
+// DocumentTotals are the rounded totals of a quotation or invoice.
+// GrandTotal is SubTotal + TaxAmount + RoundingAdjustment.
+type DocumentTotals struct {
+	SubTotal           float64
+	Bases              QuotationTaxBases
+	TaxAmount          float64
+	RoundingAdjustment float64
+	GrandTotal         float64
+}
+
+// Totals rounds lines and sums them by treatment, taxing the standard-rated
+// base at taxPercent.
+func (p RoundingPolicy) Totals(lines []TaxedLine, taxPercent float64) DocumentTotals {
+	var totals DocumentTotals
+	for _, line := range lines {
+		amount := p.Line(line.Amount)
+		switch line.Treatment {
+		case TaxTreatmentZeroRated:
+			totals.Bases.ZeroRated += amount
+		case TaxTreatmentExempt:
+			totals.Bases.Exempt += amount
+		default:
+			totals.Bases.Standard += amount
+		}
+	}
+
+	totals.Bases.Standard = roundToIncrement(totals.Bases.Standard, 0.01)
+	totals.Bases.ZeroRated = roundToIncrement(totals.Bases.ZeroRated, 0.01)
+	totals.Bases.Exempt = roundToIncrement(totals.Bases.Exempt, 0.01)
+	totals.SubTotal = roundToIncrement(totals.Bases.Total(), 0.01)
+	totals.TaxAmount = roundToIncrement(totals.Bases.Standard*taxPercent/100, 0.01)
+
+	unrounded := roundToIncrement(totals.SubTotal+totals.TaxAmount, 0.01)
+	totals.GrandTotal = p.Total(unrounded)
+	totals.RoundingAdjustment = roundToIncrement(totals.GrandTotal-unrounded, 0.01)
+	return totals
+}
+
+// roundToIncrement rounds half away from zero to a multiple of increment,
+// then to the satang to drop floating point noise.
+func roundToIncrement(v, increment float64) float64 {
+	if increment <= 0 {
+		increment = 0.01
+	}
+	v = math.Round(v/increment) * increment
+	return math.Round(v*100) / 100
+}
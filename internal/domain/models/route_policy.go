@@ -0,0 +1,291 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// RoutePolicy says which permission a route needs. Path uses Fiber's
+// syntax, so ":id" matches any one segment. A disabled policy leaves the
+// route open, which is how a default check is switched off.
+type RoutePolicy struct {
+	Method    string             `db:"method"`
+	Path      string             `db:"path"`
+	Resource  PermissionResource `db:"resource"`
+	Action    PermissionAction   `db:"action"`
+	Enabled   bool               `db:"enabled"`
+	UpdatedAt time.Time          `db:"updated_at"`
+}
+
+// RoutePolicyPrefixes are the route groups whose permissions are read from
+// the policy table rather than fixed in their handlers.
+var RoutePolicyPrefixes = []string{"/projects", "/boqs", "/quotations", "/materials", "/suppliers"}
+
+// routePolicyResources are the resources checked for routes under each of
+// the RoutePolicyPrefixes that have no policy of their own.
+var routePolicyResources = map[string]PermissionResource{
+	"/projects":   PermissionResourceProjects,
+	"/boqs":       PermissionResourceBOQs,
+	"/quotations": PermissionResourceQuotations,
+	"/materials":  PermissionResourceMaterials,
+	"/suppliers":  PermissionResourceSuppliers,
+}
+
+var RoutePolicyMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+
+// DefaultRoutePolicies apply until a saved policy for the same method and
+// path replaces them. Routes without a policy need their prefix's
+// resource, with the action their method implies; see Match. Project, BOQ
+// and quotation reads need a view policy so the caller is known when
+// checking project membership.
+var DefaultRoutePolicies = []RoutePolicy{
+	{Method: "GET", Path: "/projects", Resource: PermissionResourceProjects, Action: PermissionActionView},
+	{Method: "GET", Path: "/projects/:id", Resource: PermissionResourceProjects, Action: PermissionActionView},
+	{Method: "GET", Path: "/projects/:id/summary", Resource: PermissionResourceProjects, Action: PermissionActionView},
+	{Method: "GET", Path: "/projects/:id/overview", Resource: PermissionResourceProjects, Action: PermissionActionView},
+	{Method: "GET", Path: "/projects/:id/members", Resource: PermissionResourceProjects, Action: PermissionActionView},
+	{Method: "GET", Path: "/projects/:id/timeline", Resource: PermissionResourceProjects, Action: PermissionActionView},
+	{Method: "GET", Path: "/projects/:id/weather-risks", Resource: PermissionResourceProjects, Action: PermissionActionView},
+	{Method: "POST", Path: "/projects/:id/members", Resource: PermissionResourceProjects, Action: PermissionActionEdit},
+	{Method: "DELETE", Path: "/projects/:id/members/:userId", Resource: PermissionResourceProjects, Action: PermissionActionEdit},
+	{Method: "POST", Path: "/projects", Resource: PermissionResourceProjects, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/projects/:id", Resource: PermissionResourceProjects, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/projects/:id/status", Resource: PermissionResourceProjects, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/projects/:id/cancel", Resource: PermissionResourceProjects, Action: PermissionActionDelete},
+
+	{Method: "GET", Path: "/boqs/project/:id", Resource: PermissionResourceBOQs, Action: PermissionActionView},
+	{Method: "GET", Path: "/boqs/project/:id/export", Resource: PermissionResourceBOQs, Action: PermissionActionView},
+	{Method: "GET", Path: "/boqs/:id/jobs/:jobId/drawings", Resource: PermissionResourceBOQs, Action: PermissionActionView},
+	{Method: "POST", Path: "/boqs/:id/approve", Resource: PermissionResourceBOQs, Action: PermissionActionApprove},
+	{Method: "POST", Path: "/boqs/:id/jobs", Resource: PermissionResourceBOQs, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/boqs/:id/jobs", Resource: PermissionResourceBOQs, Action: PermissionActionEdit},
+	{Method: "DELETE", Path: "/boqs/:id/jobs/:jobId", Resource: PermissionResourceBOQs, Action: PermissionActionDelete},
+	{Method: "POST", Path: "/boqs/:id/jobs/:jobId/complete", Resource: PermissionResourceBOQs, Action: PermissionActionEdit},
+	{Method: "POST", Path: "/boqs/:id/jobs/:jobId/drawings", Resource: PermissionResourceBOQs, Action: PermissionActionEdit},
+	{Method: "DELETE", Path: "/boqs/:id/jobs/:jobId/drawings/:drawingId", Resource: PermissionResourceBOQs, Action: PermissionActionDelete},
+	{Method: "GET", Path: "/boqs/:id/jobs/:jobId/inspections", Resource: PermissionResourceBOQs, Action: PermissionActionView},
+	{Method: "POST", Path: "/boqs/:id/jobs/:jobId/inspections", Resource: PermissionResourceBOQs, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/boqs/:id/jobs/:jobId/phase", Resource: PermissionResourceBOQs, Action: PermissionActionEdit},
+
+	{Method: "POST", Path: "/quotations/projects/:projectId", Resource: PermissionResourceQuotations, Action: PermissionActionEdit},
+	{Method: "GET", Path: "/quotations/projects/:projectId/document", Resource: PermissionResourceQuotations, Action: PermissionActionView},
+	{Method: "GET", Path: "/quotations/projects/:projectId/timeline", Resource: PermissionResourceQuotations, Action: PermissionActionView},
+	{Method: "GET", Path: "/quotations/projects/:projectId/transport", Resource: PermissionResourcePrices, Action: PermissionActionView},
+	{Method: "POST", Path: "/quotations/projects/:projectId/transport", Resource: PermissionResourcePrices, Action: PermissionActionEdit},
+	{Method: "GET", Path: "/quotations/projects/:projectId/export", Resource: PermissionResourceQuotations, Action: PermissionActionView},
+	{Method: "GET", Path: "/quotations/projects/:projectId/pdf", Resource: PermissionResourceQuotations, Action: PermissionActionView},
+	{Method: "PUT", Path: "/quotations/projects/:projectId/selling-price", Resource: PermissionResourcePrices, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/quotations/projects/:projectId/approve", Resource: PermissionResourceQuotations, Action: PermissionActionApprove},
+
+	{Method: "POST", Path: "/materials", Resource: PermissionResourceMaterials, Action: PermissionActionEdit},
+	{Method: "POST", Path: "/materials/merge", Resource: PermissionResourceMaterials, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/materials/:id", Resource: PermissionResourceMaterials, Action: PermissionActionEdit},
+	{Method: "DELETE", Path: "/materials/:id", Resource: PermissionResourceMaterials, Action: PermissionActionDelete},
+	{Method: "GET", Path: "/materials/:projectId/prices", Resource: PermissionResourcePrices, Action: PermissionActionView},
+	{Method: "PUT", Path: "/materials/:boqId/estimated-price", Resource: PermissionResourcePrices, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/materials/:boqId/actual-price", Resource: PermissionResourcePrices, Action: PermissionActionEdit},
+
+	{Method: "POST", Path: "/suppliers", Resource: PermissionResourceSuppliers, Action: PermissionActionEdit},
+	{Method: "PUT", Path: "/suppliers/:id", Resource: PermissionResourceSuppliers, Action: PermissionActionEdit},
+	{Method: "DELETE", Path: "/suppliers/:id", Resource: PermissionResourceSuppliers, Action: PermissionActionDelete},
+	{Method: "PUT", Path: "/suppliers/:id/status", Resource: PermissionResourceSuppliers, Action: PermissionActionEdit},
+	{Method: "GET", Path: "/suppliers/:id/credit-terms", Resource: PermissionResourceSuppliers, Action: PermissionActionView},
+	{Method: "PUT", Path: "/suppliers/:id/credit-terms", Resource: PermissionResourceSuppliers, Action: PermissionActionEdit},
+	{Method: "GET", Path: "/suppliers/:id/payables", Resource: PermissionResourceInvoices, Action: PermissionActionView},
+	{Method: "POST", Path: "/suppliers/:id/payables", Resource: PermissionResourceInvoices, Action: PermissionActionEdit},
+}
+
+// NormalizeRoutePath gives path a leading and no trailing slash, as the
+// policies are stored.
+func NormalizeRoutePath(path string) string {
+	return "/" + strings.Join(routeSegments(path), "/")
+}
+
+// InRoutePolicyScope reports whether path belongs to one of the
+// RoutePolicyPrefixes.
+func InRoutePolicyScope(path string) bool {
+	_, ok := routePolicyPrefix(path)
+	return ok
+}
+
+func routePolicyPrefix(path string) (string, bool) {
+	segments := routeSegments(path)
+	if len(segments) == 0 {
+		return "", false
+	}
+	for _, prefix := range RoutePolicyPrefixes {
+		if strings.EqualFold("/"+segments[0], prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// fallbackRoutePolicy is the policy for a route under the
+// RoutePolicyPrefixes that has none configured, so new routes there are
+// checked until they are given one.
+func fallbackRoutePolicy(method, path string) (RoutePolicy, bool) {
+	prefix, ok := routePolicyPrefix(path)
+	if !ok || !ValidRoutePolicyMethod(method) {
+		return RoutePolicy{}, false
+	}
+
+	action := PermissionActionEdit
+	switch method {
+	case "GET", "HEAD":
+		action = PermissionActionView
+	case "DELETE":
+		action = PermissionActionDelete
+	}
+	return RoutePolicy{
+		Method:   method,
+		Path:     NormalizeRoutePath(path),
+		Resource: routePolicyResources[prefix],
+		Action:   action,
+		Enabled:  true,
+	}, true
+}
+
+func ValidRoutePolicyMethod(method string) bool {
+	for _, m := range RoutePolicyMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// RoutePolicySet is the effective policy for every configured route.
+type RoutePolicySet struct {
+	policies []RoutePolicy
+	custom   map[string]bool
+}
+
+// NewRoutePolicySet lays the saved policies over the defaults.
+func NewRoutePolicySet(saved []RoutePolicy) RoutePolicySet {
+	byKey := map[string]RoutePolicy{}
+	for _, p := range DefaultRoutePolicies {
+		p.Enabled = true
+		byKey[routePolicyKey(p.Method, p.Path)] = p
+	}
+	custom := map[string]bool{}
+	for _, p := range saved {
+		key := routePolicyKey(p.Method, p.Path)
+		byKey[key] = p
+		custom[key] = true
+	}
+
+	policies := make([]RoutePolicy, 0, len(byKey))
+	for _, p := range byKey {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Path != policies[j].Path {
+			return policies[i].Path < policies[j].Path
+		}
+		return policies[i].Method < policies[j].Method
+	})
+	return RoutePolicySet{policies: policies, custom: custom}
+}
+
+func (s RoutePolicySet) Policies() []RoutePolicy {
+	return s.policies
+}
+
+// Customized reports whether p comes from the table rather than the
+// defaults.
+func (s RoutePolicySet) Customized(p RoutePolicy) bool {
+	return s.custom[routePolicyKey(p.Method, p.Path)]
+}
+
+// Find returns the policy configured for the same route as method and
+// path, which may spell its parameters differently.
+func (s RoutePolicySet) Find(method, path string) (RoutePolicy, bool) {
+	key := routePolicyKey(method, path)
+	for _, p := range s.policies {
+		if routePolicyKey(p.Method, p.Path) == key {
+			return p, true
+		}
+	}
+	return RoutePolicy{}, false
+}
+
+// Match returns the enabled policy for a request. When several patterns
+// fit, the one with the most literal segments wins, as Fiber would route
+// "/materials/search" ahead of "/materials/:id". A request under the
+// RoutePolicyPrefixes that no policy fits gets its prefix's resource, with
+// view for GET and HEAD, delete for DELETE and edit otherwise; only a
+// disabled policy leaves such a route open. Fiber answers HEAD with the GET
+// handler, so HEAD requests without a policy of their own take GET's.
+func (s RoutePolicySet) Match(method, path string) (RoutePolicy, bool) {
+	segments := routeSegments(path)
+
+	best, bestScore := s.best(method, segments)
+	if bestScore < 0 && method == "HEAD" {
+		best, bestScore = s.best("GET", segments)
+	}
+	if bestScore < 0 {
+		return fallbackRoutePolicy(method, path)
+	}
+	if !best.Enabled {
+		return RoutePolicy{}, false
+	}
+	return best, true
+}
+
+// best returns the policy for method whose pattern fits segments with the
+// most literal segments, and its score, which is -1 when none fits.
+func (s RoutePolicySet) best(method string, segments []string) (RoutePolicy, int) {
+	var best RoutePolicy
+	bestScore := -1
+	for _, p := range s.policies {
+		if p.Method != method {
+			continue
+		}
+		if score, ok := matchRoute(routeSegments(p.Path), segments); ok && score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+	return best, bestScore
+}
+
+func matchRoute(pattern, segments []string) (int, bool) {
+	if len(pattern) != len(segments) {
+		return 0, false
+	}
+	score := 0
+	for i, s := range pattern {
+		if strings.HasPrefix(s, ":") {
+			if segments[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if !strings.EqualFold(s, segments[i]) {
+			return 0, false
+		}
+		score++
+	}
+	return score, true
+}
+
+func routeSegments(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// routePolicyKey identifies a route regardless of how its parameters are
+// named, so "/projects/:projectId" and "/projects/:id" are the same route.
+func routePolicyKey(method, path string) string {
+	segments := routeSegments(strings.ToLower(path))
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			segments[i] = ":"
+		}
+	}
+	return method + " /" + strings.Join(segments, "/")
+}
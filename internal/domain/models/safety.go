@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SafetyIncidentType string
+
+const (
+	SafetyIncidentTypeIncident SafetyIncidentType = "incident"
+	SafetyIncidentTypeNearMiss SafetyIncidentType = "near_miss"
+)
+
+type SafetySeverity string
+
+const (
+	SafetySeverityMinor    SafetySeverity = "minor"
+	SafetySeverityModerate SafetySeverity = "moderate"
+	SafetySeveritySerious  SafetySeverity = "serious"
+	SafetySeverityFatal    SafetySeverity = "fatal"
+)
+
+type SafetyIncident struct {
+	IncidentID    uuid.UUID          `db:"incident_id"`
+	ProjectID     uuid.UUID          `db:"project_id"`
+	Type          SafetyIncidentType `db:"type"`
+	Severity      SafetySeverity     `db:"severity"`
+	OccurredAt    time.Time          `db:"occurred_at"`
+	Location      sql.NullString     `db:"location"`
+	Description   string             `db:"description"`
+	InjuredName   sql.NullString     `db:"injured_name"`
+	InjuredRole   sql.NullString     `db:"injured_role"`
+	InjuryDetails sql.NullString     `db:"injury_details"`
+	LostDays      int                `db:"lost_days"`
+	ReportedBy    sql.NullString     `db:"reported_by"`
+	CreatedAt     time.Time          `db:"created_at"`
+	UpdatedAt     sql.NullTime       `db:"updated_at"`
+	Actions       []CorrectiveAction `db:"-"`
+}
+
+type CorrectiveAction struct {
+	ActionID    uuid.UUID      `db:"action_id"`
+	IncidentID  uuid.UUID      `db:"incident_id"`
+	Description string         `db:"description"`
+	Owner       sql.NullString `db:"owner"`
+	DueDate     sql.NullTime   `db:"due_date"`
+	CompletedAt sql.NullTime   `db:"completed_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// SafetyMonthlyStat holds one month of incident counts and the man-hours
+// worked that month.
+type SafetyMonthlyStat struct {
+	Month     time.Time `db:"month"`
+	Incidents int       `db:"incidents"`
+	NearMiss  int       `db:"near_misses"`
+	Minor     int       `db:"minor"`
+	Moderate  int       `db:"moderate"`
+	Serious   int       `db:"serious"`
+	Fatal     int       `db:"fatal"`
+	LostDays  int       `db:"lost_days"`
+	ManHours  float64   `db:"man_hours"`
+}
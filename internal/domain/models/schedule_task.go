@@ -0,0 +1,25 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleTask is a dated piece of project work. Workers is the number of
+// crew members it needs on each day between StartDate and EndDate. Outdoor
+// tasks are checked against the weather forecast.
+type ScheduleTask struct {
+	TaskID    uuid.UUID     `db:"task_id"`
+	ProjectID uuid.UUID     `db:"project_id"`
+	PhaseID   uuid.NullUUID `db:"phase_id"`
+	CrewID    uuid.NullUUID `db:"crew_id"`
+	Name      string        `db:"name"`
+	StartDate time.Time     `db:"start_date"`
+	EndDate   time.Time     `db:"end_date"`
+	Workers   int           `db:"workers"`
+	Outdoor   bool          `db:"outdoor"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt sql.NullTime  `db:"updated_at"`
+}
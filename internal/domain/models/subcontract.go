@@ -0,0 +1,144 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subcontract is work on a project let to a subcontractor, who is paid
+// like any other supplier. RetentionPercent is withheld from every payment
+// certificate and held until DLPMonths after the work is completed. An
+// advance paid up front is recovered at AdvanceRecoveryPercent of each
+// certificate's gross amount.
+type Subcontract struct {
+	SubcontractID          uuid.UUID    `db:"subcontract_id"`
+	ProjectID              uuid.UUID    `db:"project_id"`
+	SupplierID             uuid.UUID    `db:"supplier_id"`
+	Scope                  string       `db:"scope"`
+	ContractValue          float64      `db:"contract_value"`
+	RetentionPercent       float64      `db:"retention_percent"`
+	AdvanceAmount          float64      `db:"advance_amount"`
+	AdvanceRecoveryPercent float64      `db:"advance_recovery_percent"`
+	DLPMonths              int          `db:"dlp_months"`
+	CompletedAt            sql.NullTime `db:"completed_at"`
+	CreatedAt              time.Time    `db:"created_at"`
+}
+
+// DLPEndsAt is when the defects liability period ends, if the work is
+// complete.
+func (s Subcontract) DLPEndsAt() (time.Time, bool) {
+	if !s.CompletedAt.Valid {
+		return time.Time{}, false
+	}
+	return s.CompletedAt.Time.AddDate(0, s.DLPMonths, 0), true
+}
+
+// SubcontractItem is a line of the subcontract's bill of quantities that
+// progress is claimed against.
+type SubcontractItem struct {
+	ItemID        uuid.UUID `db:"item_id"`
+	SubcontractID uuid.UUID `db:"subcontract_id"`
+	Description   string    `db:"description"`
+	Unit          string    `db:"unit"`
+	Quantity      float64   `db:"quantity"`
+	Rate          float64   `db:"rate"`
+	SortOrder     int       `db:"sort_order"`
+}
+
+// SubcontractItemProgress is the quantity of an item certified so far and
+// the quantity in claims still awaiting certification.
+type SubcontractItemProgress struct {
+	ItemID    uuid.UUID `db:"item_id"`
+	Certified float64   `db:"certified"`
+	Pending   float64   `db:"pending"`
+}
+
+type SubcontractClaimStatus string
+
+const (
+	SubcontractClaimSubmitted SubcontractClaimStatus = "submitted"
+	SubcontractClaimCertified SubcontractClaimStatus = "certified"
+	SubcontractClaimRejected  SubcontractClaimStatus = "rejected"
+)
+
+// SubcontractClaim is a progress claim. It is entered by a user, either
+// from the subcontractor or on their behalf, named in ClaimantName.
+// Certifying it creates the payment certificate.
+type SubcontractClaim struct {
+	ClaimID       uuid.UUID              `db:"claim_id"`
+	SubcontractID uuid.UUID              `db:"subcontract_id"`
+	ClaimNo       int                    `db:"claim_no"`
+	Status        SubcontractClaimStatus `db:"status"`
+	ClaimantName  sql.NullString         `db:"claimant_name"`
+	Note          sql.NullString         `db:"note"`
+	SubmittedBy   uuid.UUID              `db:"submitted_by"`
+	SubmittedAt   time.Time              `db:"submitted_at"`
+	DecidedBy     uuid.NullUUID          `db:"decided_by"`
+	DecidedAt     sql.NullTime           `db:"decided_at"`
+	DecisionNote  sql.NullString         `db:"decision_note"`
+	CertificateID uuid.NullUUID          `db:"certificate_id"`
+}
+
+// SubcontractClaimLine is the quantity claimed for an item in this claim
+// and, once certified, the quantity the certifier accepted.
+type SubcontractClaimLine struct {
+	ClaimID           uuid.UUID       `db:"claim_id"`
+	ItemID            uuid.UUID       `db:"item_id"`
+	ClaimedQuantity   float64         `db:"claimed_quantity"`
+	CertifiedQuantity sql.NullFloat64 `db:"certified_quantity"`
+}
+
+// SubcontractCertificate is a payment certificate to a subcontractor, and
+// the payable voucher for it. Net is what is payable now: gross less
+// retention, which is held until release, advance recovered and
+// back-charges deducted.
+type SubcontractCertificate struct {
+	CertificateID   uuid.UUID      `db:"certificate_id"`
+	SubcontractID   uuid.UUID      `db:"subcontract_id"`
+	ClaimID         uuid.NullUUID  `db:"claim_id"`
+	CertificateNo   int            `db:"certificate_no"`
+	GrossAmount     float64        `db:"gross_amount"`
+	RetentionAmount float64        `db:"retention_amount"`
+	AdvanceRecovery float64        `db:"advance_recovery"`
+	BackCharges     float64        `db:"back_charges"`
+	NetAmount       float64        `db:"net_amount"`
+	Note            sql.NullString `db:"note"`
+	CertifiedBy     uuid.UUID      `db:"certified_by"`
+	CertifiedAt     time.Time      `db:"certified_at"`
+}
+
+type RetentionReleaseStatus string
+
+const (
+	RetentionReleasePending  RetentionReleaseStatus = "pending"
+	RetentionReleaseApproved RetentionReleaseStatus = "approved"
+	RetentionReleaseRejected RetentionReleaseStatus = "rejected"
+)
+
+// RetentionRelease pays back retention once the DLP is over. It is
+// requested, then approved or rejected by an owner.
+type RetentionRelease struct {
+	ReleaseID     uuid.UUID              `db:"release_id"`
+	SubcontractID uuid.UUID              `db:"subcontract_id"`
+	Amount        float64                `db:"amount"`
+	Status        RetentionReleaseStatus `db:"status"`
+	Note          sql.NullString         `db:"note"`
+	RequestedBy   uuid.UUID              `db:"requested_by"`
+	RequestedAt   time.Time              `db:"requested_at"`
+	DecidedBy     uuid.NullUUID          `db:"decided_by"`
+	DecidedAt     sql.NullTime           `db:"decided_at"`
+}
+
+// RetentionBalance sums a subcontract's retention. Held is retained less
+// approved releases; Pending is what is awaiting approval.
+type RetentionBalance struct {
+	Retained float64 `db:"retained"`
+	Released float64 `db:"released"`
+	Pending  float64 `db:"pending"`
+}
+
+func (b RetentionBalance) Held() float64 {
+	return roundToIncrement(b.Retained-b.Released, 0.01)
+}
@@ -1,15 +1,28 @@
 package models
 
 import (
+	"database/sql"
 	"encoding/json"
 
 	"github.com/google/uuid"
 )
 
+type SupplierStatus string
+
+const (
+	SupplierStatusPendingReview SupplierStatus = "pending_review"
+	SupplierStatusApproved      SupplierStatus = "approved"
+	SupplierStatusSuspended     SupplierStatus = "suspended"
+	SupplierStatusBlacklisted   SupplierStatus = "blacklisted"
+)
+
 type Supplier struct {
-	SupplierID uuid.UUID       `db:"supplier_id"`
-	Name       string          `db:"name"`
-	Email      string          `db:"email"`
-	Tel        string          `db:"tel"`
-	Address    json.RawMessage `db:"address"`
+	SupplierID      uuid.UUID       `db:"supplier_id"`
+	Name            string          `db:"name"`
+	Email           string          `db:"email"`
+	Tel             string          `db:"tel"`
+	Address         json.RawMessage `db:"address"`
+	Status          SupplierStatus  `db:"status"`
+	StatusReason    sql.NullString  `db:"status_reason"`
+	StatusUpdatedAt sql.NullTime    `db:"status_updated_at"`
 }
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreditTermsBasis is the date a supplier's credit period counts from.
+type CreditTermsBasis string
+
+const (
+	CreditTermsBasisInvoice CreditTermsBasis = "invoice"
+	CreditTermsBasisReceipt CreditTermsBasis = "receipt"
+)
+
+func (b CreditTermsBasis) Valid() bool {
+	return b == CreditTermsBasisInvoice || b == CreditTermsBasisReceipt
+}
+
+// SupplierCreditTerms is how long we have to pay a supplier. With
+// EndOfMonth the period starts at the end of the month of the basis date,
+// as in "30 days end of month". Suppliers without terms are paid on the
+// invoice date.
+type SupplierCreditTerms struct {
+	SupplierID uuid.UUID        `db:"supplier_id"`
+	CreditDays int              `db:"credit_days"`
+	Basis      CreditTermsBasis `db:"basis"`
+	EndOfMonth bool             `db:"end_of_month"`
+	UpdatedAt  time.Time        `db:"updated_at"`
+}
+
+// DueDate is when a bill falls due under t. Bills counted from the goods
+// receipt fall back to the invoice date until the goods are received.
+func (t SupplierCreditTerms) DueDate(invoiceDate time.Time, receivedDate sql.NullTime) time.Time {
+	from := invoiceDate
+	if t.Basis == CreditTermsBasisReceipt && receivedDate.Valid {
+		from = receivedDate.Time
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	if t.EndOfMonth {
+		from = from.AddDate(0, 1, -from.Day())
+	}
+	return from.AddDate(0, 0, t.CreditDays)
+}
+
+// SupplierPayable is a supplier's bill for goods or services. DueDate is
+// worked out from the supplier's terms when the bill is recorded, so later
+// changes to the terms don't move it.
+type SupplierPayable struct {
+	PayableID     uuid.UUID     `db:"payable_id"`
+	SupplierID    uuid.UUID     `db:"supplier_id"`
+	ProjectID     uuid.NullUUID `db:"project_id"`
+	InvoiceNumber string        `db:"invoice_number"`
+	InvoiceDate   time.Time     `db:"invoice_date"`
+	ReceivedDate  sql.NullTime  `db:"received_date"`
+	Amount        float64       `db:"amount"`
+	DueDate       time.Time     `db:"due_date"`
+	PaidAt        sql.NullTime  `db:"paid_at"`
+	CreatedBy     uuid.NullUUID `db:"created_by"`
+	CreatedAt     time.Time     `db:"created_at"`
+}
+
+// SupplierPayableDetail is a payable with its supplier's name for listing.
+type SupplierPayableDetail struct {
+	SupplierPayable
+	SupplierName string `db:"supplier_name"`
+}
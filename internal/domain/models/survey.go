@@ -0,0 +1,95 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SurveyQuestionType string
+
+const (
+	// SurveyQuestionNPS asks how likely the client is to recommend us, 0-10.
+	SurveyQuestionNPS    SurveyQuestionType = "nps"
+	SurveyQuestionRating SurveyQuestionType = "rating" // 1-5
+	SurveyQuestionText   SurveyQuestionType = "text"
+)
+
+// ScoreRange is the inclusive range of valid scores, ok is false for
+// free-text questions.
+func (t SurveyQuestionType) ScoreRange() (low, high int, ok bool) {
+	switch t {
+	case SurveyQuestionNPS:
+		return 0, 10, true
+	case SurveyQuestionRating:
+		return 1, 5, true
+	}
+	return 0, 0, false
+}
+
+func (t SurveyQuestionType) Valid() bool {
+	return t == SurveyQuestionNPS || t == SurveyQuestionRating || t == SurveyQuestionText
+}
+
+// SurveyQuestion is stored as part of its template's questions JSON.
+type SurveyQuestion struct {
+	Key      string             `json:"key"`
+	Text     string             `json:"text"`
+	Type     SurveyQuestionType `json:"type"`
+	Required bool               `json:"required"`
+}
+
+// SurveyTemplate is a reusable questionnaire. The default template is sent
+// automatically when a handover is created.
+type SurveyTemplate struct {
+	TemplateID uuid.UUID       `db:"template_id"`
+	Name       string          `db:"name"`
+	Questions  json.RawMessage `db:"questions"`
+	IsDefault  bool            `db:"is_default"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
+
+// SurveyAnswer is one answer in a survey's answers JSON. Score is set for
+// NPS and rating questions, Text for free-text ones.
+type SurveyAnswer struct {
+	Key   string `json:"key"`
+	Score *int   `json:"score,omitempty"`
+	Text  string `json:"text,omitempty"`
+}
+
+// Survey is one client's satisfaction survey for a handed-over project.
+// NPSScore and AverageRating are taken from the answers on submission so
+// reports don't have to decode them.
+type Survey struct {
+	SurveyID      uuid.UUID       `db:"survey_id"`
+	TemplateID    uuid.UUID       `db:"template_id"`
+	ProjectID     uuid.UUID       `db:"project_id"`
+	HandoverID    uuid.UUID       `db:"handover_id"`
+	ManagerID     uuid.NullUUID   `db:"manager_id"`
+	SentAt        time.Time       `db:"sent_at"`
+	ExpiresAt     time.Time       `db:"expires_at"`
+	Answers       json.RawMessage `db:"answers"`
+	NPSScore      sql.NullInt64   `db:"nps_score"`
+	AverageRating sql.NullFloat64 `db:"average_rating"`
+	RespondedAt   sql.NullTime    `db:"responded_at"`
+}
+
+// NetPromoterScore is the percentage of promoters (9-10) minus the
+// percentage of detractors (0-6).
+func NetPromoterScore(scores []int) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	promoters, detractors := 0, 0
+	for _, score := range scores {
+		switch {
+		case score >= 9:
+			promoters++
+		case score <= 6:
+			detractors++
+		}
+	}
+	return roundToIncrement(float64(promoters-detractors)*100/float64(len(scores)), 0.1)
+}
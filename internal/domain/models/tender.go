@@ -0,0 +1,68 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenderStatus tracks a bid from preparation to its result. Only preparing
+// tenders can be edited; won, lost and cancelled are outcomes.
+type TenderStatus string
+
+const (
+	TenderStatusPreparing TenderStatus = "preparing"
+	TenderStatusSubmitted TenderStatus = "submitted"
+	TenderStatusWon       TenderStatus = "won"
+	TenderStatusLost      TenderStatus = "lost"
+	TenderStatusCancelled TenderStatus = "cancelled"
+)
+
+// IsOutcome reports whether s is a final result of a tender.
+func (s TenderStatus) IsOutcome() bool {
+	return s == TenderStatusWon || s == TenderStatusLost || s == TenderStatusCancelled
+}
+
+// Tender is a government tender notice we are bidding on. ProjectID links
+// it to the project created for the work, if any.
+type Tender struct {
+	TenderID           uuid.UUID       `db:"tender_id"`
+	ProjectID          uuid.NullUUID   `db:"project_id"`
+	Title              string          `db:"title"`
+	Agency             string          `db:"agency"`
+	ReferenceNumber    sql.NullString  `db:"reference_number"`
+	SubmissionDeadline time.Time       `db:"submission_deadline"`
+	BondAmount         float64         `db:"bond_amount"`
+	Status             TenderStatus    `db:"status"`
+	SubmittedAt        sql.NullTime    `db:"submitted_at"`
+	WinningBidder      sql.NullString  `db:"winning_bidder"`
+	WinningAmount      sql.NullFloat64 `db:"winning_amount"`
+	OutcomeNotes       sql.NullString  `db:"outcome_notes"`
+	OutcomeAt          sql.NullTime    `db:"outcome_at"`
+	CreatedAt          time.Time       `db:"created_at"`
+	UpdatedAt          sql.NullTime    `db:"updated_at"`
+}
+
+// TenderDocument is one entry of a tender's submission checklist.
+type TenderDocument struct {
+	DocumentID uuid.UUID      `db:"document_id"`
+	TenderID   uuid.UUID      `db:"tender_id"`
+	Name       string         `db:"name"`
+	Required   bool           `db:"required"`
+	Completed  bool           `db:"completed"`
+	FileURL    sql.NullString `db:"file_url"`
+	SortOrder  int            `db:"sort_order"`
+}
+
+// TenderBidLine is a line of the bid price worksheet. Its prices are what
+// we offer the agency and are kept apart from the internal BOQ costs.
+type TenderBidLine struct {
+	LineID      uuid.UUID `db:"line_id"`
+	TenderID    uuid.UUID `db:"tender_id"`
+	Description string    `db:"description"`
+	Unit        string    `db:"unit"`
+	Quantity    float64   `db:"quantity"`
+	UnitPrice   float64   `db:"unit_price"`
+	SortOrder   int       `db:"sort_order"`
+}
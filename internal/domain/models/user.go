@@ -2,10 +2,54 @@ package models
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type UserRole string
+
+const (
+	UserRoleOwner UserRole = "owner"
+	// UserRoleAdmin has the same unrestricted access as an owner.
+	UserRoleAdmin          UserRole = "admin"
+	UserRoleEstimator      UserRole = "estimator"
+	UserRoleProjectManager UserRole = "project_manager"
+	UserRoleViewer         UserRole = "viewer"
+	// UserRoleStaff is the role users registered with before the others
+	// existed.
+	UserRoleStaff UserRole = "staff"
+)
+
+var UserRoles = []UserRole{
+	UserRoleOwner,
+	UserRoleAdmin,
+	UserRoleEstimator,
+	UserRoleProjectManager,
+	UserRoleViewer,
+	UserRoleStaff,
+}
+
+func (r UserRole) Valid() bool {
+	for _, role := range UserRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the role has unrestricted access, which the
+// permission matrix can't take away.
+func (r UserRole) IsAdmin() bool {
+	return r == UserRoleOwner || r == UserRoleAdmin
+}
+
+// IsManager reports whether the role oversees other users' work.
+func (r UserRole) IsManager() bool {
+	return r.IsAdmin() || r == UserRoleProjectManager
+}
+
 type User struct {
 	UserID    uuid.UUID      `db:"user_id"`
 	Username  string         `db:"username"`
@@ -15,4 +59,37 @@ type User struct {
 	Email     sql.NullString `db:"email"`
 	Tel       sql.NullString `db:"tel"`
 	CompanyID *uuid.UUID     `db:"company_id"`
+	Role      UserRole       `db:"role"`
+	AvatarURL sql.NullString `db:"avatar_url"`
+
+	// IsActive is cleared instead of deleting a user, so their history on
+	// projects stays intact. Deactivated users can't sign in.
+	IsActive      bool         `db:"is_active"`
+	DeactivatedAt sql.NullTime `db:"deactivated_at"`
+
+	// PendingEmail is an address the user asked to change to; it replaces
+	// Email once they follow the link sent to it.
+	PendingEmail sql.NullString `db:"pending_email"`
+
+	// FailedLoginAttempts counts wrong passwords since the last successful
+	// login or lockout; LockedUntil is set once too many are made.
+	FailedLoginAttempts int          `db:"failed_login_attempts"`
+	LockedUntil         sql.NullTime `db:"locked_until"`
+
+	// MustChangePassword is set by an admin to make the user choose a new
+	// password; until they do, permission-checked routes are refused.
+	MustChangePassword bool `db:"must_change_password"`
+}
+
+// UserFilter narrows the admin user list. Search matches the username,
+// name or email; an empty Role and a nil Active match every user.
+type UserFilter struct {
+	Search string
+	Role   UserRole
+	Active *bool
+}
+
+// IsLocked reports whether the account is locked out at now.
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil.Valid && now.Before(u.LockedUntil.Time)
 }
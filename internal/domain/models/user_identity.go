@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const IdentityProviderGoogle = "google"
+
+// UserIdentity links a user to an account at an external identity
+// provider, identified by the provider's stable subject ID rather than the
+// email address, which can change.
+type UserIdentity struct {
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"`
+	UserID    uuid.UUID `db:"user_id"`
+	Email     string    `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ExternalIdentity is what a verified ID token says about its holder.
+// HostedDomain is the Google Workspace domain, empty for consumer
+// accounts.
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	HostedDomain  string
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserInvitation lets an invited user set their own password. The user is
+// created pending with a random password nobody knows, so they can't sign
+// in until the invitation is accepted.
+type UserInvitation struct {
+	InvitationID uuid.UUID    `db:"invitation_id"`
+	UserID       uuid.UUID    `db:"user_id"`
+	InvitedBy    uuid.UUID    `db:"invited_by"`
+	ExpiresAt    time.Time    `db:"expires_at"`
+	AcceptedAt   sql.NullTime `db:"accepted_at"`
+	CreatedAt    time.Time    `db:"created_at"`
+}
+
+func (i UserInvitation) Pending(now time.Time) bool {
+	return !i.AcceptedAt.Valid && now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,24 @@
+package models
+
+// UserPreferences are UI settings stored per user so they follow the user
+// across devices. Every field is optional; unknown keys are rejected.
+// NotificationChannels, when set, limits the channels the user is notified
+// through; LineUserID is where LINE notifications go.
+type UserPreferences struct {
+	DefaultTaxPercentage *float64               `json:"default_tax_percentage,omitempty"`
+	Language             string                 `json:"language,omitempty"`
+	PreferredUnits       map[string]string      `json:"preferred_units,omitempty"`
+	NotificationChannels []string               `json:"notification_channels,omitempty"`
+	LineUserID           string                 `json:"line_user_id,omitempty"`
+	TableLayouts         map[string]TableLayout `json:"table_layouts,omitempty"`
+}
+
+// TableLayout is the saved state of one table in the UI, keyed by the
+// table's name in the frontend.
+type TableLayout struct {
+	Columns  []string `json:"columns,omitempty"`
+	Hidden   []string `json:"hidden,omitempty"`
+	SortBy   string   `json:"sort_by,omitempty"`
+	SortDesc bool     `json:"sort_desc,omitempty"`
+	PageSize int      `json:"page_size,omitempty"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserSession is one signed-in device. Its ID is the family of the refresh
+// tokens issued to it, so revoking the family ends the session.
+type UserSession struct {
+	SessionID   uuid.UUID   `db:"session_id"`
+	UserID      uuid.UUID   `db:"user_id"`
+	Method      LoginMethod `db:"method"`
+	Fingerprint string      `db:"fingerprint"`
+	UserAgent   string      `db:"user_agent"`
+	IPAddress   string      `db:"ip_address"`
+	CreatedAt   time.Time   `db:"created_at"`
+	LastSeenAt  time.Time   `db:"last_seen_at"`
+	// ExpiresAt is when the session's current refresh token expires; it
+	// isn't stored.
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+// DeviceFingerprint identifies a browser or app install across logins
+// without storing anything that identifies the person. deviceID is an
+// optional ID the client keeps for itself, which tells apart devices that
+// report the same user agent.
+func DeviceFingerprint(userAgent, acceptLanguage, deviceID string) string {
+	sum := sha256.Sum256([]byte(userAgent + "\n" + acceptLanguage + "\n" + deviceID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// DescribeDevice names the browser and operating system in a user agent,
+// e.g. "Chrome on Windows", for showing a session to its user.
+func DescribeDevice(userAgent string) string {
+	browser := "Unknown browser"
+	// Order matters: Edge and Opera also claim to be Chrome, and Chrome
+	// claims to be Safari.
+	for _, b := range []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"okhttp/", "Android app"},
+		{"Dart/", "Mobile app"},
+	} {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	system := ""
+	for _, s := range []struct{ token, name string }{
+		{"Windows", "Windows"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Mac OS X", "macOS"},
+		{"Android", "Android"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, s.token) {
+			system = s.name
+			break
+		}
+	}
+	if system == "" {
+		return browser
+	}
+	return browser + " on " + system
+}
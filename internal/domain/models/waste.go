@@ -0,0 +1,68 @@
+package models
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WasteRecord is material scrapped or wasted on a project. It is taken
+// out of the warehouse it was held in by the stock movement of the same
+// ID.
+type WasteRecord struct {
+	WasteID      uuid.UUID      `db:"waste_id"`
+	ProjectID    uuid.UUID      `db:"project_id"`
+	MaterialID   string         `db:"material_id"`
+	WarehouseID  uuid.UUID      `db:"warehouse_id"`
+	Quantity     float64        `db:"quantity"`
+	Reason       string         `db:"reason"`
+	Note         sql.NullString `db:"note"`
+	RecordedDate time.Time      `db:"recorded_date"`
+	CreatedBy    uuid.NullUUID  `db:"created_by"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+// WasteRecordDetail is a waste record with its material and warehouse
+// names for listing.
+type WasteRecordDetail struct {
+	WasteRecord
+	MaterialName  string `db:"material_name"`
+	Unit          string `db:"unit"`
+	WarehouseName string `db:"warehouse_name"`
+}
+
+// WastageAllowance is the waste allowed for a material as a percentage of
+// the quantity in a project's BOQ. Materials without one use the default.
+type WastageAllowance struct {
+	MaterialID string    `db:"material_id"`
+	Percent    float64   `db:"percent"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// WasteSummary is a project's total waste of a material against the
+// quantity its BOQ calls for.
+type WasteSummary struct {
+	ProjectID      uuid.UUID `db:"project_id"`
+	ProjectName    string    `db:"project_name"`
+	MaterialID     string    `db:"material_id"`
+	MaterialName   string    `db:"material_name"`
+	Unit           string    `db:"unit"`
+	BOQQuantity    float64   `db:"boq_quantity"`
+	WastedQuantity float64   `db:"wasted_quantity"`
+}
+
+// AllowedQuantity is how much may be wasted at allowancePercent.
+func (s WasteSummary) AllowedQuantity(allowancePercent float64) float64 {
+	return math.Round(s.BOQQuantity*allowancePercent/100*10000) / 10000
+}
+
+// WastePercent is the waste as a percentage of the BOQ quantity, or zero
+// when the material isn't in the BOQ.
+func (s WasteSummary) WastePercent() float64 {
+	if s.BOQQuantity <= 0 {
+		return 0
+	}
+	return math.Round(s.WastedQuantity/s.BOQQuantity*100*100) / 100
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GeoLocation struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// WeatherForecast is the forecast daily rainfall at a project's location.
+type WeatherForecast struct {
+	ProjectID                uuid.UUID       `db:"project_id"`
+	ForecastDate             time.Time       `db:"forecast_date"`
+	PrecipitationMM          float64         `db:"precipitation_mm"`
+	PrecipitationProbability sql.NullFloat64 `db:"precipitation_probability"`
+	Source                   string          `db:"source"`
+	FetchedAt                time.Time       `db:"fetched_at"`
+}
+
+// WeatherForecastTarget is a project with upcoming outdoor work whose
+// forecast should be kept fresh.
+type WeatherForecastTarget struct {
+	ProjectID uuid.UUID       `db:"project_id"`
+	Name      string          `db:"name"`
+	Address   json.RawMessage `db:"address"`
+}
+
+// WeatherRisk is an outdoor task day with heavy rain in the forecast.
+type WeatherRisk struct {
+	TaskID                   uuid.UUID       `db:"task_id"`
+	TaskName                 string          `db:"task_name"`
+	ProjectID                uuid.UUID       `db:"project_id"`
+	ProjectName              string          `db:"project_name"`
+	ForecastDate             time.Time       `db:"forecast_date"`
+	PrecipitationMM          float64         `db:"precipitation_mm"`
+	PrecipitationProbability sql.NullFloat64 `db:"precipitation_probability"`
+	FetchedAt                time.Time       `db:"fetched_at"`
+}
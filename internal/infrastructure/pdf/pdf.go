@@ -0,0 +1,170 @@
+// Package pdf writes plain text documents as PDF using the base-14
+// Helvetica fonts, so no font files have to ship with the binary. Text is
+// encoded as WinAnsi; characters outside Latin-1 are printed as '?'.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595.0 // A4 in points
+	pageHeight = 842.0
+	margin     = 50.0
+
+	bodySize    = 11.0
+	headingSize = 16.0
+
+	// Helvetica averages about half an em per character, close enough to
+	// wrap lines without carrying the font's width table.
+	avgCharWidth = 0.5
+)
+
+// Document collects lines of text and lays them out top to bottom, starting
+// a new page when the current one is full.
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+// Heading writes a bold line in a larger size.
+func (d *Document) Heading(text string) {
+	d.line("F2", headingSize, 0, text)
+	d.Gap()
+}
+
+// Field writes "label: value", wrapping the value under the label.
+func (d *Document) Field(label, value string) {
+	d.Text(label + ": " + value)
+}
+
+// Bold writes a bold body-sized paragraph.
+func (d *Document) Bold(text string) {
+	d.paragraph("F2", 0, text)
+}
+
+// Text writes a paragraph, wrapped to the page width.
+func (d *Document) Text(text string) {
+	d.paragraph("F1", 0, text)
+}
+
+// Item writes an indented paragraph, for list entries.
+func (d *Document) Item(text string) {
+	d.paragraph("F1", 15, text)
+}
+
+// Gap leaves a blank line.
+func (d *Document) Gap() {
+	d.y -= bodySize * 0.8
+}
+
+func (d *Document) paragraph(font string, indent float64, text string) {
+	for _, p := range strings.Split(text, "\n") {
+		for _, l := range wrap(p, int((pageWidth-2*margin-indent)/(bodySize*avgCharWidth))) {
+			d.line(font, bodySize, indent, l)
+		}
+	}
+}
+
+func (d *Document) line(font string, size, indent float64, text string) {
+	leading := size * 1.4
+	if d.y-leading < margin {
+		d.newPage()
+	}
+	d.y -= leading
+
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+		font, size, margin+indent, d.y, escape(text))
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1-4 are fixed; each page then takes a page object followed
+	// by its content stream.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+i*2,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+func wrap(text string, width int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	current := words[0]
+	for _, word := range words[1:] {
+		if len([]rune(current))+1+len([]rune(word)) > width {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current += " " + word
+	}
+
+	return append(lines, current)
+}
+
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Daily runs fn every day at hour:minute in loc until ctx is cancelled.
+// Errors are logged and the job is retried at the next scheduled time.
+func Daily(ctx context.Context, name string, hour, minute int, loc *time.Location, fn func(context.Context) error) {
+	go func() {
+		for {
+			next := nextRun(time.Now().In(loc), hour, minute)
+			timer := time.NewTimer(time.Until(next))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				run(ctx, name, fn)
+			}
+		}
+	}()
+}
+
+// Every runs fn at a fixed interval until ctx is cancelled.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(context.Context) error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run(ctx, name, fn)
+			}
+		}
+	}()
+}
+
+func run(ctx context.Context, name string, fn func(context.Context) error) {
+	start := time.Now()
+	if err := fn(ctx); err != nil {
+		log.Printf("scheduler: %s failed: %v", name, err)
+		return
+	}
+	log.Printf("scheduler: %s finished in %s", name, time.Since(start))
+}
+
+func nextRun(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
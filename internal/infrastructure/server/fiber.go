@@ -11,7 +11,7 @@ func NewFiberServer() *fiber.App {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:3000, https://construction-planner.teerut.com",
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Device-ID",
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
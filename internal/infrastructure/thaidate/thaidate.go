@@ -0,0 +1,67 @@
+// Package thaidate converts between the Gregorian calendar (CE) and the
+// Thai solar calendar's Buddhist era (BE). The two only differ in how years
+// are numbered, so conversions touch the year and never the day or month;
+// in particular a date is never shifted by adding years, which would move
+// 29 February to 1 March.
+package thaidate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BuddhistEraOffset is how many years the Buddhist era is ahead of the
+// Gregorian one: 2024 CE is 2567 BE.
+const BuddhistEraOffset = 543
+
+// DateLayout is the layout dates are exchanged in.
+const DateLayout = "2006-01-02"
+
+func ToBuddhistYear(year int) int {
+	return year + BuddhistEraOffset
+}
+
+func ToGregorianYear(year int) int {
+	return year - BuddhistEraOffset
+}
+
+// Format is time.Format that, when buddhist is set, numbers the year in
+// the Buddhist era. Only the four-digit year element "2006" is converted.
+func Format(t time.Time, layout string, buddhist bool) string {
+	if !buddhist {
+		return t.Format(layout)
+	}
+
+	parts := strings.Split(layout, "2006")
+	formatted := make([]string, len(parts))
+	for i, part := range parts {
+		formatted[i] = t.Format(part)
+	}
+	return strings.Join(formatted, fmt.Sprintf("%04d", ToBuddhistYear(t.Year())))
+}
+
+// FormatDate formats t as YYYY-MM-DD in the given era.
+func FormatDate(t time.Time, buddhist bool) string {
+	return Format(t, DateLayout, buddhist)
+}
+
+// ParseDate parses a YYYY-MM-DD date whose year is in the Buddhist era
+// when buddhist is set, returning the date in the Gregorian calendar.
+func ParseDate(value string, buddhist bool) (time.Time, error) {
+	if !buddhist {
+		return time.Parse(DateLayout, value)
+	}
+
+	var year, month, day int
+	if _, err := fmt.Sscanf(value, "%4d-%2d-%2d", &year, &month, &day); err != nil || len(value) != len(DateLayout) {
+		return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", value)
+	}
+	t := time.Date(ToGregorianYear(year), time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// time.Date normalises out-of-range days, so a date that doesn't
+	// exist comes back different.
+	if t.Month() != time.Month(month) || t.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,187 @@
+// Package tradedoc describes quotations and invoices in a structured form
+// for exchange with client ERPs. Document is the JSON format, described by
+// the JSON Schema returned by Schema; MarshalUBL renders the same data as
+// UBL 2.1 XML.
+package tradedoc
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+)
+
+// SchemaVersion changes whenever a field is renamed or removed. Added
+// optional fields don't bump it.
+const SchemaVersion = "1.0"
+
+type DocumentType string
+
+// Tax categories follow UNCL5305, as used by both UBL and ETDA.
+const (
+	TaxCategoryStandard  = "S"
+	TaxCategoryZeroRated = "Z"
+	TaxCategoryExempt    = "E"
+)
+
+const (
+	DocumentTypeQuotation DocumentType = "quotation"
+	DocumentTypeInvoice   DocumentType = "invoice"
+)
+
+//go:embed schema.json
+var schema []byte
+
+// Schema returns the JSON Schema (draft 2020-12) of Document.
+func Schema() []byte {
+	return schema
+}
+
+// Document is a quotation or invoice. Dates are YYYY-MM-DD and amounts are
+// in Currency, rounded to two decimals.
+type Document struct {
+	SchemaVersion string       `json:"schema_version"`
+	Type          DocumentType `json:"type"`
+	ID            string       `json:"id"`
+	IssueDate     string       `json:"issue_date"`
+	ValidUntil    string       `json:"valid_until,omitempty"`
+	Currency      string       `json:"currency"`
+
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	// QuotationRef is the quotation an invoice bills against.
+	QuotationRef string `json:"quotation_ref,omitempty"`
+
+	Seller Party  `json:"seller"`
+	Buyer  Party  `json:"buyer"`
+	Lines  []Line `json:"lines"`
+
+	TaxPercent        float64       `json:"tax_percent"`
+	TaxSubtotals      []TaxSubtotal `json:"tax_subtotals"`
+	LineTotal         float64       `json:"line_total"`
+	TaxExclusiveTotal float64       `json:"tax_exclusive_total"`
+	TaxAmount         float64       `json:"tax_amount"`
+	TaxInclusiveTotal float64       `json:"tax_inclusive_total"`
+	// RoundingAmount is added to TaxInclusiveTotal to reach PayableAmount
+	// when totals are rounded to a coarser increment than the satang.
+	RoundingAmount float64 `json:"rounding_amount,omitempty"`
+	PayableAmount  float64 `json:"payable_amount"`
+
+	// Language and Labels are only set when a language is requested, for
+	// clients that print the document. UBL leaves them out.
+	Language string            `json:"language,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	// DateEra and Dates go with them: the dates above as printed, keyed by
+	// field name. The dates above stay Gregorian.
+	DateEra string            `json:"date_era,omitempty"`
+	Dates   map[string]string `json:"dates,omitempty"`
+}
+
+type Party struct {
+	Name    string  `json:"name"`
+	TaxID   string  `json:"tax_id,omitempty"`
+	Email   string  `json:"email,omitempty"`
+	Tel     string  `json:"tel,omitempty"`
+	Address Address `json:"address"`
+}
+
+type Address struct {
+	HouseNumber string `json:"house_number,omitempty"`
+	Moo         string `json:"moo,omitempty"`
+	Soi         string `json:"soi,omitempty"`
+	Road        string `json:"road,omitempty"`
+	SubDistrict string `json:"sub_district,omitempty"`
+	District    string `json:"district,omitempty"`
+	Province    string `json:"province,omitempty"`
+	PostalCode  string `json:"postal_code,omitempty"`
+	Country     string `json:"country"`
+}
+
+// Line is one billed item. Unit is the unit as entered in the BOQ and
+// UnitCode its UN/ECE Recommendation 20 code.
+type Line struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Group       string  `json:"group,omitempty"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit"`
+	UnitCode    string  `json:"unit_code"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+	TaxCategory string  `json:"tax_category"`
+}
+
+// TaxSubtotal is the taxable amount and tax of one tax category. Only the
+// standard category has a non-zero Percent.
+type TaxSubtotal struct {
+	Category      string  `json:"category"`
+	Percent       float64 `json:"percent"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
+// AddressFromJSON reads the address object stored on clients, companies
+// and projects. Unknown or malformed input gives an address with only the
+// country set.
+func AddressFromJSON(raw json.RawMessage) Address {
+	var fields map[string]interface{}
+	_ = json.Unmarshal(raw, &fields)
+
+	get := func(key string) string {
+		value, _ := fields[key].(string)
+		return strings.TrimSpace(value)
+	}
+
+	return Address{
+		HouseNumber: get("house_number"),
+		Moo:         get("moo"),
+		Soi:         get("soi"),
+		Road:        get("road"),
+		SubDistrict: get("sub_district"),
+		District:    get("district"),
+		Province:    get("province"),
+		PostalCode:  get("postal_code"),
+		Country:     "TH",
+	}
+}
+
+// unitCodes maps units used in BOQs to UN/ECE Recommendation 20 codes.
+var unitCodes = map[string]string{
+	"m":         "MTR",
+	"เมตร":      "MTR",
+	"ม.":        "MTR",
+	"m2":        "MTK",
+	"sqm":       "MTK",
+	"ตร.ม.":     "MTK",
+	"ตารางเมตร": "MTK",
+	"m3":        "MTQ",
+	"ลบ.ม.":     "MTQ",
+	"คิว":       "MTQ",
+	"kg":        "KGM",
+	"กก.":       "KGM",
+	"กิโลกรัม":  "KGM",
+	"ton":       "TNE",
+	"ตัน":       "TNE",
+	"l":         "LTR",
+	"ลิตร":      "LTR",
+	"day":       "DAY",
+	"วัน":       "DAY",
+	"set":       "SET",
+	"ชุด":       "SET",
+	"lot":       "LS",
+	"ls":        "LS",
+	"เหมา":      "LS",
+	"งาน":       "LS",
+}
+
+// UnitCode returns the Recommendation 20 code of unit, or C62 ("one") when
+// the unit isn't recognised.
+func UnitCode(unit string) string {
+	key := strings.ToLower(strings.TrimSpace(unit))
+	key = strings.ReplaceAll(key, "²", "2")
+	key = strings.ReplaceAll(key, "³", "3")
+	if code, ok := unitCodes[key]; ok {
+		return code
+	}
+	return "C62"
+}
@@ -0,0 +1,251 @@
+package tradedoc
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ETDAKind is an e-Tax document type from the ETDA standard ขมธอ. 3-2560.
+type ETDAKind struct {
+	TypeCode string
+	Name     string
+	// root is the document's root element without the "rsm:" prefix; its
+	// namespaces are derived from it.
+	root string
+}
+
+var (
+	ETDATaxInvoice = ETDAKind{TypeCode: "388", Name: "ใบกำกับภาษี", root: "TaxInvoice"}
+	ETDAReceipt    = ETDAKind{TypeCode: "T01", Name: "ใบรับ", root: "Receipt"}
+)
+
+type etdaID struct {
+	SchemeID        string `xml:"schemeID,attr,omitempty"`
+	SchemeAgencyID  string `xml:"schemeAgencyID,attr,omitempty"`
+	SchemeVersionID string `xml:"schemeVersionID,attr,omitempty"`
+	Value           string `xml:",chardata"`
+}
+
+type etdaAddress struct {
+	PostcodeCode        string `xml:"ram:PostcodeCode,omitempty"`
+	LineOne             string `xml:"ram:LineOne,omitempty"`
+	CityName            string `xml:"ram:CityName,omitempty"`
+	CitySubDivisionName string `xml:"ram:CitySubDivisionName,omitempty"`
+	CountryID           etdaID `xml:"ram:CountryID"`
+	CountrySubDivision  string `xml:"ram:CountrySubDivisionName,omitempty"`
+}
+
+type etdaParty struct {
+	Name                     string               `xml:"ram:Name"`
+	SpecifiedTaxRegistration *etdaTaxRegistration `xml:"ram:SpecifiedTaxRegistration,omitempty"`
+	DefinedTradeContact      *etdaContact         `xml:"ram:DefinedTradeContact,omitempty"`
+	PostalTradeAddress       etdaAddress          `xml:"ram:PostalTradeAddress"`
+}
+
+type etdaTaxRegistration struct {
+	ID etdaID `xml:"ram:ID"`
+}
+
+type etdaContact struct {
+	Email     *etdaEmail     `xml:"ram:EmailURIUniversalCommunication,omitempty"`
+	Telephone *etdaTelephone `xml:"ram:TelephoneUniversalCommunication,omitempty"`
+}
+
+type etdaEmail struct {
+	URIID string `xml:"ram:URIID"`
+}
+
+type etdaTelephone struct {
+	CompleteNumber string `xml:"ram:CompleteNumber"`
+}
+
+type etdaReferencedDocument struct {
+	IssuerAssignedID string `xml:"ram:IssuerAssignedID"`
+}
+
+type etdaTradeTax struct {
+	TypeCode         string `xml:"ram:TypeCode"`
+	CalculatedRate   string `xml:"ram:CalculatedRate"`
+	BasisAmount      string `xml:"ram:BasisAmount,omitempty"`
+	CalculatedAmount string `xml:"ram:CalculatedAmount,omitempty"`
+}
+
+type etdaLineItem struct {
+	LineID             string `xml:"ram:AssociatedDocumentLineDocument>ram:LineID"`
+	ProductName        string `xml:"ram:SpecifiedTradeProduct>ram:Name"`
+	ProductDescription string `xml:"ram:SpecifiedTradeProduct>ram:Description,omitempty"`
+	ChargeAmount       string `xml:"ram:SpecifiedLineTradeAgreement>ram:GrossPriceProductTradePrice>ram:ChargeAmount"`
+	BilledQuantity     etdaQuantity
+	ApplicableTradeTax etdaTradeTax `xml:"ram:SpecifiedLineTradeSettlement>ram:ApplicableTradeTax"`
+	NetLineTotalAmount string       `xml:"ram:SpecifiedLineTradeSettlement>ram:SpecifiedTradeSettlementLineMonetarySummation>ram:NetLineTotalAmount"`
+}
+
+type etdaQuantity struct {
+	XMLName  xml.Name `xml:"ram:SpecifiedLineTradeDelivery"`
+	Quantity struct {
+		UnitCode string `xml:"unitCode,attr"`
+		Value    string `xml:",chardata"`
+	} `xml:"ram:BilledQuantity"`
+}
+
+type etdaDocument struct {
+	XMLName xml.Name
+	RSM     string `xml:"xmlns:rsm,attr"`
+	RAM     string `xml:"xmlns:ram,attr"`
+
+	GuidelineID etdaID `xml:"rsm:ExchangedDocumentContext>ram:GuidelineSpecifiedDocumentContextParameter>ram:ID"`
+
+	Exchanged struct {
+		ID               string `xml:"ram:ID"`
+		Name             string `xml:"ram:Name"`
+		TypeCode         string `xml:"ram:TypeCode"`
+		IssueDateTime    string `xml:"ram:IssueDateTime"`
+		Purpose          string `xml:"ram:Purpose,omitempty"`
+		CreationDateTime string `xml:"ram:CreationDateTime"`
+	} `xml:"rsm:ExchangedDocument"`
+
+	Transaction struct {
+		Agreement struct {
+			Seller        etdaParty               `xml:"ram:SellerTradeParty"`
+			Buyer         etdaParty               `xml:"ram:BuyerTradeParty"`
+			BuyerOrderRef *etdaReferencedDocument `xml:"ram:BuyerOrderReferencedDocument,omitempty"`
+		} `xml:"ram:ApplicableHeaderTradeAgreement"`
+		Delivery   struct{} `xml:"ram:ApplicableHeaderTradeDelivery"`
+		Settlement struct {
+			Currency  etdaID         `xml:"ram:InvoiceCurrencyCode"`
+			Taxes     []etdaTradeTax `xml:"ram:ApplicableTradeTax"`
+			Summation struct {
+				LineTotalAmount     string `xml:"ram:LineTotalAmount"`
+				TaxBasisTotalAmount string `xml:"ram:TaxBasisTotalAmount"`
+				TaxTotalAmount      string `xml:"ram:TaxTotalAmount"`
+				GrandTotalAmount    string `xml:"ram:GrandTotalAmount"`
+			} `xml:"ram:SpecifiedTradeSettlementHeaderMonetarySummation"`
+		} `xml:"ram:ApplicableHeaderTradeSettlement"`
+		Lines []etdaLineItem `xml:"ram:IncludedSupplyChainTradeLineItem"`
+	} `xml:"rsm:SupplyChainTradeTransaction"`
+}
+
+// MarshalETDA renders doc as an unsigned ETDA e-Tax XML document of the
+// given kind, numbered documentNumber and issued at issuedAt.
+func MarshalETDA(doc *Document, kind ETDAKind, documentNumber string, issuedAt time.Time) ([]byte, error) {
+	if kind.root == "" {
+		return nil, fmt.Errorf("unsupported e-tax document kind %q", kind.TypeCode)
+	}
+	if doc.Seller.TaxID == "" {
+		return nil, fmt.Errorf("seller tax ID is required for e-tax documents")
+	}
+
+	var out etdaDocument
+	out.XMLName = xml.Name{Local: "rsm:" + kind.root + "_CrossIndustryInvoice"}
+	out.RSM = "urn:etda:uncefact:data:standard:" + kind.root + "_CrossIndustryInvoice:2"
+	out.RAM = "urn:etda:uncefact:data:standard:" + kind.root + "_ReusableAggregateBusinessInformationEntity:2"
+	out.GuidelineID = etdaID{SchemeAgencyID: "ETDA", SchemeVersionID: "v2.0", Value: "ER3-2560"}
+
+	// ETDA timestamps are local time without an offset.
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	out.Exchanged.ID = documentNumber
+	out.Exchanged.Name = kind.Name
+	out.Exchanged.TypeCode = kind.TypeCode
+	out.Exchanged.IssueDateTime = issuedAt.In(bangkok).Format("2006-01-02T15:04:05")
+	out.Exchanged.CreationDateTime = time.Now().In(bangkok).Format("2006-01-02T15:04:05")
+
+	out.Transaction.Agreement.Seller = toETDAParty(doc.Seller)
+	out.Transaction.Agreement.Buyer = toETDAParty(doc.Buyer)
+	if doc.QuotationRef != "" {
+		out.Transaction.Agreement.BuyerOrderRef = &etdaReferencedDocument{IssuerAssignedID: doc.QuotationRef}
+	}
+
+	settlement := &out.Transaction.Settlement
+	settlement.Currency = etdaID{Value: doc.Currency}
+	if len(doc.TaxSubtotals) == 0 {
+		settlement.Taxes = []etdaTradeTax{{
+			TypeCode:         "VAT",
+			CalculatedRate:   formatQuantity(doc.TaxPercent),
+			BasisAmount:      fmt.Sprintf("%.2f", doc.TaxExclusiveTotal),
+			CalculatedAmount: fmt.Sprintf("%.2f", doc.TaxAmount),
+		}}
+	}
+	// Zero-rated and exempt supplies are reported as separate 0% entries.
+	for _, sub := range doc.TaxSubtotals {
+		settlement.Taxes = append(settlement.Taxes, etdaTradeTax{
+			TypeCode:         "VAT",
+			CalculatedRate:   formatQuantity(sub.Percent),
+			BasisAmount:      fmt.Sprintf("%.2f", sub.TaxableAmount),
+			CalculatedAmount: fmt.Sprintf("%.2f", sub.TaxAmount),
+		})
+	}
+	settlement.Summation.LineTotalAmount = fmt.Sprintf("%.2f", doc.LineTotal)
+	settlement.Summation.TaxBasisTotalAmount = fmt.Sprintf("%.2f", doc.TaxExclusiveTotal)
+	settlement.Summation.TaxTotalAmount = fmt.Sprintf("%.2f", doc.TaxAmount)
+	settlement.Summation.GrandTotalAmount = fmt.Sprintf("%.2f", doc.PayableAmount)
+
+	for _, line := range doc.Lines {
+		rate := doc.TaxPercent
+		if line.TaxCategory == TaxCategoryZeroRated || line.TaxCategory == TaxCategoryExempt {
+			rate = 0
+		}
+		item := etdaLineItem{
+			LineID:             line.ID,
+			ProductName:        line.Name,
+			ProductDescription: line.Description,
+			ChargeAmount:       fmt.Sprintf("%.2f", line.UnitPrice),
+			ApplicableTradeTax: etdaTradeTax{
+				TypeCode:       "VAT",
+				CalculatedRate: formatQuantity(rate),
+			},
+			NetLineTotalAmount: fmt.Sprintf("%.2f", line.Amount),
+		}
+		item.BilledQuantity.Quantity.UnitCode = line.UnitCode
+		item.BilledQuantity.Quantity.Value = formatQuantity(line.Quantity)
+		out.Transaction.Lines = append(out.Transaction.Lines, item)
+	}
+
+	body, err := xml.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal e-tax document: %w", err)
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func toETDAParty(p Party) etdaParty {
+	party := etdaParty{Name: p.Name}
+
+	// Tax IDs carry a five digit branch number; 00000 is the head office.
+	if taxID := strings.TrimSpace(p.TaxID); taxID != "" {
+		if len(taxID) == 13 {
+			taxID += "00000"
+		}
+		party.SpecifiedTaxRegistration = &etdaTaxRegistration{ID: etdaID{SchemeID: "TXID", Value: taxID}}
+	}
+
+	if p.Email != "" || p.Tel != "" {
+		party.DefinedTradeContact = &etdaContact{}
+		if p.Email != "" {
+			party.DefinedTradeContact.Email = &etdaEmail{URIID: p.Email}
+		}
+		if p.Tel != "" {
+			party.DefinedTradeContact.Telephone = &etdaTelephone{CompleteNumber: p.Tel}
+		}
+	}
+
+	a := p.Address
+	var line []string
+	for _, part := range []string{a.HouseNumber, prefixed("หมู่ ", a.Moo), prefixed("ซอย ", a.Soi), prefixed("ถนน ", a.Road)} {
+		if part != "" {
+			line = append(line, part)
+		}
+	}
+	party.PostalTradeAddress = etdaAddress{
+		PostcodeCode:        a.PostalCode,
+		LineOne:             strings.Join(line, " "),
+		CityName:            a.District,
+		CitySubDivisionName: a.SubDistrict,
+		CountryID:           etdaID{SchemeID: "3166-1 alpha-2", Value: a.Country},
+		CountrySubDivision:  a.Province,
+	}
+
+	return party
+}
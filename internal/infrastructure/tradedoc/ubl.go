@@ -0,0 +1,358 @@
+package tradedoc
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const (
+	ublInvoiceNS   = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	ublQuotationNS = "urn:oasis:names:specification:ubl:schema:xsd:Quotation-2"
+	ublCACNS       = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	ublCBCNS       = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+)
+
+// encoding/xml can't emit namespace prefixes, so elements are named with
+// their prefix and the declarations are written as plain attributes.
+
+type ublAmount struct {
+	CurrencyID string `xml:"currencyID,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type ublQuantity struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type ublTaxScheme struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ublAddress struct {
+	StreetName           string `xml:"cbc:StreetName,omitempty"`
+	AdditionalStreetName string `xml:"cbc:AdditionalStreetName,omitempty"`
+	BuildingNumber       string `xml:"cbc:BuildingNumber,omitempty"`
+	CitySubdivisionName  string `xml:"cbc:CitySubdivisionName,omitempty"`
+	CityName             string `xml:"cbc:CityName,omitempty"`
+	PostalZone           string `xml:"cbc:PostalZone,omitempty"`
+	CountrySubentity     string `xml:"cbc:CountrySubentity,omitempty"`
+	Country              struct {
+		IdentificationCode string `xml:"cbc:IdentificationCode"`
+	} `xml:"cac:Country"`
+}
+
+type ublPartyTaxScheme struct {
+	CompanyID string       `xml:"cbc:CompanyID"`
+	TaxScheme ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublContact struct {
+	Telephone      string `xml:"cbc:Telephone,omitempty"`
+	ElectronicMail string `xml:"cbc:ElectronicMail,omitempty"`
+}
+
+type ublParty struct {
+	PartyName struct {
+		Name string `xml:"cbc:Name"`
+	} `xml:"cac:PartyName"`
+	PostalAddress  ublAddress         `xml:"cac:PostalAddress"`
+	PartyTaxScheme *ublPartyTaxScheme `xml:"cac:PartyTaxScheme,omitempty"`
+	Contact        *ublContact        `xml:"cac:Contact,omitempty"`
+}
+
+type ublPartyWrapper struct {
+	Party ublParty `xml:"cac:Party"`
+}
+
+type ublTaxCategory struct {
+	ID                 string       `xml:"cbc:ID"`
+	Percent            string       `xml:"cbc:Percent"`
+	TaxExemptionReason string       `xml:"cbc:TaxExemptionReason,omitempty"`
+	TaxScheme          ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublTaxSubtotal struct {
+	TaxableAmount ublAmount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     ublAmount      `xml:"cbc:TaxAmount"`
+	TaxCategory   ublTaxCategory `xml:"cac:TaxCategory"`
+}
+
+type ublTaxTotal struct {
+	TaxAmount    ublAmount        `xml:"cbc:TaxAmount"`
+	TaxSubtotals []ublTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type ublMonetaryTotal struct {
+	LineExtensionAmount ublAmount  `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  ublAmount  `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  ublAmount  `xml:"cbc:TaxInclusiveAmount"`
+	RoundingAmount      *ublAmount `xml:"cbc:PayableRoundingAmount,omitempty"`
+	PayableAmount       ublAmount  `xml:"cbc:PayableAmount"`
+}
+
+type ublItem struct {
+	Description           string          `xml:"cbc:Description,omitempty"`
+	Name                  string          `xml:"cbc:Name"`
+	ClassifiedTaxCategory *ublTaxCategory `xml:"cac:ClassifiedTaxCategory,omitempty"`
+}
+
+type ublPrice struct {
+	PriceAmount ublAmount `xml:"cbc:PriceAmount"`
+}
+
+type ublInvoiceLine struct {
+	ID                  string      `xml:"cbc:ID"`
+	InvoicedQuantity    ublQuantity `xml:"cbc:InvoicedQuantity"`
+	LineExtensionAmount ublAmount   `xml:"cbc:LineExtensionAmount"`
+	Item                ublItem     `xml:"cac:Item"`
+	Price               ublPrice    `xml:"cac:Price"`
+}
+
+type ublLineItem struct {
+	ID                  string      `xml:"cbc:ID"`
+	Quantity            ublQuantity `xml:"cbc:Quantity"`
+	LineExtensionAmount ublAmount   `xml:"cbc:LineExtensionAmount"`
+	Price               ublPrice    `xml:"cac:Price"`
+	Item                ublItem     `xml:"cac:Item"`
+}
+
+type ublQuotationLine struct {
+	LineItem ublLineItem `xml:"cac:LineItem"`
+}
+
+type ublInvoice struct {
+	XMLName              xml.Name         `xml:"Invoice"`
+	XMLNS                string           `xml:"xmlns,attr"`
+	CAC                  string           `xml:"xmlns:cac,attr"`
+	CBC                  string           `xml:"xmlns:cbc,attr"`
+	UBLVersionID         string           `xml:"cbc:UBLVersionID"`
+	ID                   string           `xml:"cbc:ID"`
+	IssueDate            string           `xml:"cbc:IssueDate"`
+	InvoiceTypeCode      string           `xml:"cbc:InvoiceTypeCode"`
+	Note                 string           `xml:"cbc:Note,omitempty"`
+	DocumentCurrencyCode string           `xml:"cbc:DocumentCurrencyCode"`
+	OrderReference       *ublReference    `xml:"cac:OrderReference,omitempty"`
+	Supplier             ublPartyWrapper  `xml:"cac:AccountingSupplierParty"`
+	Customer             ublPartyWrapper  `xml:"cac:AccountingCustomerParty"`
+	TaxTotal             ublTaxTotal      `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal   ublMonetaryTotal `xml:"cac:LegalMonetaryTotal"`
+	Lines                []ublInvoiceLine `xml:"cac:InvoiceLine"`
+}
+
+type ublReference struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ublQuotation struct {
+	XMLName             xml.Name `xml:"Quotation"`
+	XMLNS               string   `xml:"xmlns,attr"`
+	CAC                 string   `xml:"xmlns:cac,attr"`
+	CBC                 string   `xml:"xmlns:cbc,attr"`
+	UBLVersionID        string   `xml:"cbc:UBLVersionID"`
+	ID                  string   `xml:"cbc:ID"`
+	IssueDate           string   `xml:"cbc:IssueDate"`
+	PricingCurrencyCode string   `xml:"cbc:PricingCurrencyCode,omitempty"`
+	Note                string   `xml:"cbc:Note,omitempty"`
+	ValidityPeriod      *struct {
+		EndDate string `xml:"cbc:EndDate"`
+	} `xml:"cac:ValidityPeriod,omitempty"`
+	Seller              ublPartyWrapper    `xml:"cac:SellerSupplierParty"`
+	Buyer               ublPartyWrapper    `xml:"cac:BuyerCustomerParty"`
+	TaxTotal            ublTaxTotal        `xml:"cac:TaxTotal"`
+	QuotedMonetaryTotal ublMonetaryTotal   `xml:"cac:QuotedMonetaryTotal"`
+	Lines               []ublQuotationLine `xml:"cac:QuotationLine"`
+}
+
+// MarshalUBL renders doc as a UBL 2.1 Invoice or Quotation document.
+func MarshalUBL(doc *Document) ([]byte, error) {
+	var root interface{}
+
+	switch doc.Type {
+	case DocumentTypeInvoice:
+		invoice := ublInvoice{
+			XMLNS:                ublInvoiceNS,
+			CAC:                  ublCACNS,
+			CBC:                  ublCBCNS,
+			UBLVersionID:         "2.1",
+			ID:                   doc.ID,
+			IssueDate:            doc.IssueDate,
+			InvoiceTypeCode:      "380",
+			Note:                 doc.ProjectName,
+			DocumentCurrencyCode: doc.Currency,
+			Supplier:             ublPartyWrapper{Party: toUBLParty(doc.Seller)},
+			Customer:             ublPartyWrapper{Party: toUBLParty(doc.Buyer)},
+			TaxTotal:             toUBLTaxTotal(doc),
+			LegalMonetaryTotal:   toUBLMonetaryTotal(doc),
+		}
+		if doc.QuotationRef != "" {
+			invoice.OrderReference = &ublReference{ID: doc.QuotationRef}
+		}
+		for _, line := range doc.Lines {
+			invoice.Lines = append(invoice.Lines, ublInvoiceLine{
+				ID:                  line.ID,
+				InvoicedQuantity:    ublQuantity{UnitCode: line.UnitCode, Value: formatQuantity(line.Quantity)},
+				LineExtensionAmount: amount(doc.Currency, line.Amount),
+				Item:                toUBLItem(doc, line),
+				Price:               ublPrice{PriceAmount: amount(doc.Currency, line.UnitPrice)},
+			})
+		}
+		root = invoice
+
+	case DocumentTypeQuotation:
+		quotation := ublQuotation{
+			XMLNS:               ublQuotationNS,
+			CAC:                 ublCACNS,
+			CBC:                 ublCBCNS,
+			UBLVersionID:        "2.1",
+			ID:                  doc.ID,
+			IssueDate:           doc.IssueDate,
+			Note:                doc.ProjectName,
+			PricingCurrencyCode: doc.Currency,
+			Seller:              ublPartyWrapper{Party: toUBLParty(doc.Seller)},
+			Buyer:               ublPartyWrapper{Party: toUBLParty(doc.Buyer)},
+			TaxTotal:            toUBLTaxTotal(doc),
+			QuotedMonetaryTotal: toUBLMonetaryTotal(doc),
+		}
+		if doc.ValidUntil != "" {
+			quotation.ValidityPeriod = &struct {
+				EndDate string `xml:"cbc:EndDate"`
+			}{EndDate: doc.ValidUntil}
+		}
+		for _, line := range doc.Lines {
+			quotation.Lines = append(quotation.Lines, ublQuotationLine{LineItem: ublLineItem{
+				ID:                  line.ID,
+				Quantity:            ublQuantity{UnitCode: line.UnitCode, Value: formatQuantity(line.Quantity)},
+				LineExtensionAmount: amount(doc.Currency, line.Amount),
+				Price:               ublPrice{PriceAmount: amount(doc.Currency, line.UnitPrice)},
+				Item:                toUBLItem(doc, line),
+			}})
+		}
+		root = quotation
+
+	default:
+		return nil, fmt.Errorf("unsupported document type %q", doc.Type)
+	}
+
+	body, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal UBL document: %w", err)
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func toUBLParty(p Party) ublParty {
+	var party ublParty
+	party.PartyName.Name = p.Name
+
+	a := p.Address
+	var street []string
+	for _, part := range []string{a.HouseNumber, prefixed("หมู่ ", a.Moo), prefixed("ซอย ", a.Soi)} {
+		if part != "" {
+			street = append(street, part)
+		}
+	}
+	party.PostalAddress = ublAddress{
+		StreetName:           a.Road,
+		AdditionalStreetName: strings.Join(street, " "),
+		CitySubdivisionName:  a.SubDistrict,
+		CityName:             a.District,
+		PostalZone:           a.PostalCode,
+		CountrySubentity:     a.Province,
+	}
+	party.PostalAddress.Country.IdentificationCode = a.Country
+
+	if p.TaxID != "" {
+		party.PartyTaxScheme = &ublPartyTaxScheme{
+			CompanyID: p.TaxID,
+			TaxScheme: ublTaxScheme{ID: "VAT"},
+		}
+	}
+	if p.Tel != "" || p.Email != "" {
+		party.Contact = &ublContact{Telephone: p.Tel, ElectronicMail: p.Email}
+	}
+
+	return party
+}
+
+func toUBLTaxTotal(doc *Document) ublTaxTotal {
+	total := ublTaxTotal{TaxAmount: amount(doc.Currency, doc.TaxAmount)}
+
+	subtotals := doc.TaxSubtotals
+	if len(subtotals) == 0 {
+		// Documents built without per-category totals are a single category:
+		// S is the standard rate, Z a zero-rated supply.
+		category := TaxCategoryStandard
+		if doc.TaxPercent == 0 {
+			category = TaxCategoryZeroRated
+		}
+		subtotals = []TaxSubtotal{{
+			Category:      category,
+			Percent:       doc.TaxPercent,
+			TaxableAmount: doc.TaxExclusiveTotal,
+			TaxAmount:     doc.TaxAmount,
+		}}
+	}
+
+	for _, sub := range subtotals {
+		total.TaxSubtotals = append(total.TaxSubtotals, ublTaxSubtotal{
+			TaxableAmount: amount(doc.Currency, sub.TaxableAmount),
+			TaxAmount:     amount(doc.Currency, sub.TaxAmount),
+			TaxCategory:   toUBLTaxCategory(sub.Category, sub.Percent),
+		})
+	}
+	return total
+}
+
+func toUBLTaxCategory(category string, percent float64) ublTaxCategory {
+	tc := ublTaxCategory{ID: category, TaxScheme: ublTaxScheme{ID: "VAT"}}
+	if category == TaxCategoryStandard {
+		tc.Percent = formatQuantity(percent)
+	} else {
+		tc.Percent = "0"
+	}
+	if category == TaxCategoryExempt {
+		tc.TaxExemptionReason = "Exempt from VAT"
+	}
+	return tc
+}
+
+func toUBLItem(doc *Document, line Line) ublItem {
+	item := ublItem{Description: line.Description, Name: line.Name}
+	if line.TaxCategory != "" {
+		tc := toUBLTaxCategory(line.TaxCategory, doc.TaxPercent)
+		item.ClassifiedTaxCategory = &tc
+	}
+	return item
+}
+
+func toUBLMonetaryTotal(doc *Document) ublMonetaryTotal {
+	total := ublMonetaryTotal{
+		LineExtensionAmount: amount(doc.Currency, doc.LineTotal),
+		TaxExclusiveAmount:  amount(doc.Currency, doc.TaxExclusiveTotal),
+		TaxInclusiveAmount:  amount(doc.Currency, doc.TaxInclusiveTotal),
+		PayableAmount:       amount(doc.Currency, doc.PayableAmount),
+	}
+	if doc.RoundingAmount != 0 {
+		rounding := amount(doc.Currency, doc.RoundingAmount)
+		total.RoundingAmount = &rounding
+	}
+	return total
+}
+
+func amount(currency string, value float64) ublAmount {
+	return ublAmount{CurrencyID: currency, Value: fmt.Sprintf("%.2f", value)}
+}
+
+func formatQuantity(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.4f", value), "0"), ".")
+}
+
+func prefixed(prefix, value string) string {
+	if value == "" {
+		return ""
+	}
+	return prefix + value
+}
@@ -0,0 +1,152 @@
+// Package watermark stamps photos shared outside the company with the
+// company logo and a date. It only uses the standard library, so the logo
+// is scaled with nearest-neighbour sampling and the date is drawn with a
+// small built-in bitmap font covering digits and date separators.
+package watermark
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"time"
+)
+
+const (
+	// The logo is scaled to this fraction of the photo's width and drawn
+	// with this opacity so it doesn't hide the subject.
+	logoWidthRatio = 0.18
+	logoOpacity    = 0.6
+
+	jpegQuality = 90
+)
+
+// ErrUnsupported means the file isn't a JPEG or PNG image; callers serve
+// such files unchanged.
+var ErrUnsupported = errors.New("unsupported image format")
+
+type Watermark struct {
+	logo image.Image
+}
+
+// New returns a watermark that draws logo in the bottom-right corner. A
+// nil logo stamps only the date.
+func New(logo image.Image) *Watermark {
+	return &Watermark{logo: logo}
+}
+
+// LoadLogo reads a JPEG or PNG logo from path.
+func LoadLogo(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open logo: %w", err)
+	}
+	defer f.Close()
+
+	logo, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode logo: %w", err)
+	}
+	return logo, nil
+}
+
+// Apply stamps src with the logo and date and re-encodes it in its
+// original format, returning the new image and its content type.
+func (w *Watermark) Apply(src []byte, date time.Time) ([]byte, string, error) {
+	photo, format, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, "", ErrUnsupported
+	}
+
+	bounds := photo.Bounds()
+	canvas := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(canvas, canvas.Bounds(), photo, bounds.Min, draw.Src)
+
+	// Size everything off the shorter side so portrait and landscape
+	// photos get the same-looking stamp.
+	short := min(bounds.Dx(), bounds.Dy())
+	pad := max(short/40, 4)
+
+	if w.logo != nil {
+		w.drawLogo(canvas, pad)
+	}
+	drawDate(canvas, date.Format("2006-01-02"), max(short/150, 1), pad)
+
+	var buf bytes.Buffer
+	switch format {
+	case "png":
+		if err := png.Encode(&buf, canvas); err != nil {
+			return nil, "", fmt.Errorf("failed to encode image: %w", err)
+		}
+		return buf.Bytes(), "image/png", nil
+	default:
+		if err := jpeg.Encode(&buf, canvas, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", fmt.Errorf("failed to encode image: %w", err)
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+}
+
+func (w *Watermark) drawLogo(canvas *image.RGBA, pad int) {
+	lb := w.logo.Bounds()
+	width := int(float64(canvas.Bounds().Dx()) * logoWidthRatio)
+	if width < 1 || lb.Dx() == 0 {
+		return
+	}
+	height := width * lb.Dy() / lb.Dx()
+	if height < 1 {
+		return
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		sy := lb.Min.Y + y*lb.Dy()/height
+		for x := 0; x < width; x++ {
+			sx := lb.Min.X + x*lb.Dx()/width
+			scaled.Set(x, y, w.logo.At(sx, sy))
+		}
+	}
+
+	cb := canvas.Bounds()
+	at := image.Rect(cb.Max.X-pad-width, cb.Max.Y-pad-height, cb.Max.X-pad, cb.Max.Y-pad)
+	mask := image.NewUniform(color.Alpha{A: uint8(logoOpacity * 255)})
+	draw.DrawMask(canvas, at, scaled, image.Point{}, mask, image.Point{}, draw.Over)
+}
+
+// drawDate writes text in the bottom-left corner in white on a translucent
+// dark box, scaling each font pixel to scale×scale.
+func drawDate(canvas *image.RGBA, text string, scale, pad int) {
+	const advance = glyphWidth + 1
+
+	width := (len(text)*advance - 1) * scale
+	height := glyphHeight * scale
+	margin := 2 * scale
+
+	cb := canvas.Bounds()
+	box := image.Rect(cb.Min.X+pad, cb.Max.Y-pad-height-2*margin, cb.Min.X+pad+width+2*margin, cb.Max.Y-pad)
+	draw.Draw(canvas, box, image.NewUniform(color.NRGBA{A: 128}), image.Point{}, draw.Over)
+
+	x0 := box.Min.X + margin
+	y0 := box.Min.Y + margin
+	for i, r := range text {
+		glyph, ok := glyphs[r]
+		if !ok {
+			continue
+		}
+		for row, bits := range glyph {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				px := x0 + (i*advance+col)*scale
+				py := y0 + row*scale
+				draw.Draw(canvas, image.Rect(px, py, px+scale, py+scale), image.White, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
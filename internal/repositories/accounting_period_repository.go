@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+)
+
+type AccountingPeriodRepository interface {
+	ListLocks(ctx context.Context) ([]models.AccountingPeriodLock, error)
+	IsLocked(ctx context.Context, period string) (bool, error)
+	// Lock and Unlock change the lock and append event in one transaction.
+	Lock(ctx context.Context, lock models.AccountingPeriodLock, event models.AccountingPeriodEvent) error
+	Unlock(ctx context.Context, period string, event models.AccountingPeriodEvent) error
+	ListEvents(ctx context.Context) ([]models.AccountingPeriodEvent, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ActivityRepository interface {
+	Record(ctx context.Context, event models.ActivityEvent) error
+
+	GetProjectTimeline(ctx context.Context, projectID uuid.UUID) ([]models.TimelineEvent, error)
+	GetClientTimeline(ctx context.Context, clientID uuid.UUID) ([]models.TimelineEvent, error)
+	GetQuotationTimeline(ctx context.Context, projectID uuid.UUID) ([]models.TimelineEvent, error)
+	// ListByActor returns a page of the events a user caused, newest
+	// first, and how many there are in all.
+	ListByActor(ctx context.Context, actorID uuid.UUID, limit, offset int) ([]models.ActivityEvent, int64, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+)
+
+type APIUsageRepository interface {
+	// AddUsage adds the counts to the daily rollups, creating rows as
+	// needed.
+	AddUsage(ctx context.Context, usage []models.APIUsage) error
+	// ListUsage totals usage between from and to (inclusive dates) by
+	// grouping. Fields that aren't part of the grouping are left zero.
+	ListUsage(ctx context.Context, from, to time.Time, grouping models.APIUsageGrouping) ([]models.APIUsage, error)
+}
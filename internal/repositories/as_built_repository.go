@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AsBuiltRepository interface {
+	// ListLines lists every job in the BOQ with its as-built quantity.
+	ListLines(ctx context.Context, boqID uuid.UUID) ([]models.AsBuiltLine, error)
+	// GetLine fails with "job not found in BOQ".
+	GetLine(ctx context.Context, boqID, jobID uuid.UUID) (*models.AsBuiltLine, error)
+	// Save records or replaces the job's as-built quantity.
+	Save(ctx context.Context, record models.AsBuiltQuantity) error
+}
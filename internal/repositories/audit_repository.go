@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+)
+
+type AuditRepository interface {
+	// GetLastSegment returns nil when nothing has been sealed yet.
+	GetLastSegment(ctx context.Context) (*models.AuditSegment, error)
+	ListSegments(ctx context.Context, from, to time.Time) ([]models.AuditSegment, error)
+	// GetSegmentBefore returns the latest segment before date, or nil.
+	GetSegmentBefore(ctx context.Context, date time.Time) (*models.AuditSegment, error)
+	CreateSegment(ctx context.Context, segment models.AuditSegment) error
+
+	// ListEvents returns activity events in [from, to) ordered by time and
+	// ID.
+	ListEvents(ctx context.Context, from, to time.Time) ([]models.ActivityEvent, error)
+	// GetFirstEventTime returns the zero time when there are no events.
+	GetFirstEventTime(ctx context.Context) (time.Time, error)
+}
+
+// AuditArchive is write-once storage for sealed segments. Put fails with
+// "object already exists" rather than replace an object.
+type AuditArchive interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get fails with "object not found" for a missing key.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
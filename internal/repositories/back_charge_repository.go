@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type BackChargeRepository interface {
+	Create(ctx context.Context, backCharge models.BackCharge) error
+	GetByID(ctx context.Context, backChargeID uuid.UUID) (*models.BackCharge, error)
+	// List filters by supplier and project when they are set.
+	List(ctx context.Context, supplierID uuid.NullUUID, projectID uuid.NullUUID) ([]models.BackCharge, error)
+	// ListDeductible returns the supplier's open back-charges with an
+	// amount left that may be deducted on the subcontract, oldest first.
+	ListDeductible(ctx context.Context, supplierID uuid.UUID, subcontractID uuid.UUID) ([]models.BackCharge, error)
+	// UpdateStatus also saves the amount, dispute reason and resolution.
+	UpdateStatus(ctx context.Context, backCharge models.BackCharge) error
+	ListOffsets(ctx context.Context, backChargeID uuid.UUID) ([]models.BackChargeOffset, error)
+
+	AddAttachment(ctx context.Context, attachment models.BackChargeAttachment) error
+	ListAttachments(ctx context.Context, backChargeID uuid.UUID) ([]models.BackChargeAttachment, error)
+	DeleteAttachment(ctx context.Context, backChargeID uuid.UUID, attachmentID uuid.UUID) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BlanketAgreementRepository interface {
+	Create(ctx context.Context, agreement models.BlanketAgreement, items []models.BlanketAgreementItem) error
+	GetByID(ctx context.Context, agreementID uuid.UUID) (*models.BlanketAgreementSummary, error)
+	// List returns agreements newest first. A null supplier matches every
+	// supplier; a valid validOn only returns agreements valid that day.
+	List(ctx context.Context, supplierID uuid.NullUUID, validOn sql.NullTime) ([]models.BlanketAgreementSummary, error)
+	ListBalances(ctx context.Context, agreementID uuid.UUID) ([]models.BlanketAgreementBalance, error)
+	// ListExpiring returns agreements whose validity ends between from and
+	// to, soonest first.
+	ListExpiring(ctx context.Context, from, to time.Time) ([]models.BlanketAgreementSummary, error)
+}
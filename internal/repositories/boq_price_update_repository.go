@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type BOQPriceUpdateRepository interface {
+	// ListOpenBOQsUsingMaterial returns the BOQs that use materialID and
+	// are still draft, or whose quotation is.
+	ListOpenBOQsUsingMaterial(ctx context.Context, materialID string) ([]uuid.UUID, error)
+	IsBOQOpen(ctx context.Context, boqID uuid.UUID) (bool, error)
+
+	// GetEstimatedTotal is the BOQ's materials at their estimated prices
+	// plus labour.
+	GetEstimatedTotal(ctx context.Context, boqID uuid.UUID) (float64, error)
+	// GetPriceChanges returns the BOQ's materials that have been bought
+	// since they were estimated, at the most recent supplier price.
+	GetPriceChanges(ctx context.Context, boqID uuid.UUID) ([]models.BOQPriceChange, error)
+
+	// Save creates or replaces the BOQ's flag. A flag whose changes
+	// differ from the saved ones is raised again even if it had been
+	// acknowledged.
+	Save(ctx context.Context, update *models.BOQPriceUpdate) error
+	Delete(ctx context.Context, boqID uuid.UUID) error
+	GetByBOQID(ctx context.Context, boqID uuid.UUID) (*models.BOQPriceUpdate, error)
+	List(ctx context.Context, includeAcknowledged bool) ([]models.BOQPriceUpdate, error)
+	Acknowledge(ctx context.Context, boqID uuid.UUID, userID uuid.UUID) error
+}
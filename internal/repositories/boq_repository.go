@@ -17,6 +17,11 @@ type BOQRepository interface {
 	AddBOQJob(ctx context.Context, boqID uuid.UUID, req requests.BOQJobRequest) error
 	UpdateBOQJob(ctx context.Context, boqID uuid.UUID, req requests.BOQJobRequest) error
 	DeleteBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) error
+	CompleteBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) error
+
+	AddJobDrawing(ctx context.Context, drawing *models.BOQJobDrawing) error
+	ListJobDrawings(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) ([]models.BOQJobDrawing, error)
+	DeleteJobDrawing(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, drawingID uuid.UUID) error
 
 	GetBOQGeneralCosts(ctx context.Context, boqID uuid.UUID) ([]models.BOQGeneralCost, error)
 	GetBOQDetails(ctx context.Context, projectID uuid.UUID) ([]models.BOQDetails, error)
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ClaimRepository interface {
+	Create(ctx context.Context, claim models.Claim) error
+	GetByID(ctx context.Context, claimID uuid.UUID) (*models.Claim, error)
+	// ListByProject filters by status when it is not empty.
+	ListByProject(ctx context.Context, projectID uuid.UUID, status string) ([]models.Claim, error)
+	// Update saves the claim's details and status. It fails when the claim
+	// was closed in the meantime.
+	Update(ctx context.Context, claim models.Claim) error
+	// Delete only removes draft claims.
+	Delete(ctx context.Context, claimID uuid.UUID) error
+
+	AddDocument(ctx context.Context, document models.ClaimDocument) error
+	ListDocuments(ctx context.Context, claimID uuid.UUID) ([]models.ClaimDocument, error)
+	DeleteDocument(ctx context.Context, claimID uuid.UUID, documentID uuid.UUID) error
+}
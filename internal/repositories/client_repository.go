@@ -11,8 +11,46 @@ import (
 type ClientRepository interface {
 	Create(ctx context.Context, req requests.CreateClientRequest) (*models.Client, error)
 	Update(ctx context.Context, id uuid.UUID, req requests.UpdateClientRequest) error
+	// Delete archives the client; Restore brings it back.
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Client, error)
-	List(ctx context.Context, limit, offset int) ([]models.Client, int64, error)
+	List(ctx context.Context, filter models.ClientFilter, limit, offset int) ([]models.Client, int64, error)
 	GetByEmail(ctx context.Context, email string) (*models.Client, error)
+
+	SetCreditHold(ctx context.Context, id uuid.UUID, reason string) error
+	ReleaseCreditHold(ctx context.Context, id uuid.UUID) error
+	ApplyOverdueCreditHolds(ctx context.Context, overdueDays int) ([]models.Client, error)
+	// OutstandingBalance is what the client still owes on invoices across
+	// all of its projects.
+	OutstandingBalance(ctx context.Context, id uuid.UUID) (float64, error)
+
+	ListContacts(ctx context.Context, clientID uuid.UUID) ([]models.ClientContact, error)
+	GetContact(ctx context.Context, clientID, contactID uuid.UUID) (*models.ClientContact, error)
+	// CreateContact and UpdateContact clear the client's other primary
+	// contact when the contact is primary.
+	CreateContact(ctx context.Context, contact models.ClientContact) error
+	UpdateContact(ctx context.Context, contact models.ClientContact) error
+	DeleteContact(ctx context.Context, clientID, contactID uuid.UUID) error
+
+	ListDocuments(ctx context.Context, clientID uuid.UUID) ([]models.ClientDocument, error)
+	// GetDocumentByID looks the document up without its client, for
+	// downloads through a signed link.
+	GetDocumentByID(ctx context.Context, documentID uuid.UUID) (*models.ClientDocument, error)
+	GetDocument(ctx context.Context, clientID, documentID uuid.UUID) (*models.ClientDocument, error)
+	CreateDocument(ctx context.Context, document models.ClientDocument) error
+	DeleteDocument(ctx context.Context, clientID, documentID uuid.UUID) error
+
+	CountProjects(ctx context.Context, id uuid.UUID) (int, error)
+	Merge(ctx context.Context, sourceID uuid.UUID, merged models.Client) error
+}
+
+// DocumentStore keeps files that must not be public, such as copies of ID
+// cards. Unlike FileStore it returns no URL; files are read back through
+// the API.
+type DocumentStore interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	// Get fails with "object not found" for a missing key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
 }
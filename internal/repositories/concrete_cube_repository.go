@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CubeTestRepository interface {
+	// Create fails with "sample reference already recorded for this pour"
+	// for a reference used before on the same pour.
+	Create(ctx context.Context, test models.CubeTest) error
+	GetByID(ctx context.Context, testID uuid.UUID) (*models.CubeTestDetail, error)
+	List(ctx context.Context, filter models.CubeTestFilter) ([]models.CubeTestDetail, error)
+	// UpdateResults saves the test's strengths, lab and note.
+	UpdateResults(ctx context.Context, test models.CubeTest) error
+	Delete(ctx context.Context, testID uuid.UUID) error
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ConcretePourRepository interface {
+	// Create fails with "job is not on the project's BOQ" unless the pour's
+	// job is in its BOQ, and with "ticket number already recorded for this
+	// supplier" for a ticket logged before.
+	Create(ctx context.Context, pour models.ConcretePour) error
+	GetByID(ctx context.Context, pourID uuid.UUID) (*models.ConcretePourDetail, error)
+	// ListByProject lists a project's pours, newest first, optionally for
+	// one job.
+	ListByProject(ctx context.Context, projectID uuid.UUID, jobID uuid.NullUUID) ([]models.ConcretePourDetail, error)
+	// Delete fails with "concrete pour has cube tests" once tests have
+	// been recorded against the pour.
+	Delete(ctx context.Context, pourID uuid.UUID) error
+	// Summarize totals the project's pours per BOQ job.
+	Summarize(ctx context.Context, projectID uuid.UUID) ([]models.ConcretePourSummary, error)
+}
@@ -12,4 +12,10 @@ type ContractRepository interface {
 	Delete(ctx context.Context, projectID uuid.UUID) error
 	GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.Contract, error)
 	ValidateProjectStatus(ctx context.Context, projectID uuid.UUID) error
+
+	// CreateDocument assigns the next version for the document's type and,
+	// when it supersedes another document, marks that one superseded.
+	CreateDocument(ctx context.Context, document *models.ContractDocument) error
+	GetDocument(ctx context.Context, projectID uuid.UUID, documentID uuid.UUID) (*models.ContractDocument, error)
+	ListDocuments(ctx context.Context, projectID uuid.UUID) ([]models.ContractDocument, error)
 }
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CrewRepository interface {
+	Create(ctx context.Context, req requests.CreateCrewRequest) (*models.Crew, error)
+	GetByID(ctx context.Context, crewID uuid.UUID) (*models.Crew, error)
+	List(ctx context.Context) ([]models.Crew, error)
+	Update(ctx context.Context, crewID uuid.UUID, req requests.UpdateCrewRequest) error
+	Delete(ctx context.Context, crewID uuid.UUID) error
+
+	// GetOverAllocations returns, for every day in [from, to] on which a
+	// crew's assigned workers exceed its capacity, one row per task.
+	GetOverAllocations(ctx context.Context, from, to time.Time) ([]models.CrewAllocation, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DashboardRepository interface {
+	// GetPortfolioProjects returns every project that isn't cancelled, with
+	// schedule progress measured up to asOf.
+	GetPortfolioProjects(ctx context.Context, asOf time.Time) ([]models.PortfolioProject, error)
+	// GetPortfolioProject returns the same figures for one project, or
+	// "project not found" if it doesn't exist or is cancelled.
+	GetPortfolioProject(ctx context.Context, projectID uuid.UUID, asOf time.Time) (*models.PortfolioProject, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryRepository interface {
+	CreateRate(ctx context.Context, rate *models.DeliveryRate) error
+	GetRateByID(ctx context.Context, rateID uuid.UUID) (*models.DeliveryRate, error)
+	ListRates(ctx context.Context) ([]models.DeliveryRate, error)
+	UpdateRate(ctx context.Context, rate *models.DeliveryRate) error
+	DeleteRate(ctx context.Context, rateID uuid.UUID) error
+
+	// SetQuotationTransport replaces the quotation's transport lines and
+	// recalculates its final amount.
+	SetQuotationTransport(ctx context.Context, projectID, quotationID uuid.UUID, lines []models.QuotationTransport) error
+	GetQuotationTransport(ctx context.Context, quotationID uuid.UUID) ([]models.QuotationTransport, error)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type DocumentLabelRepository interface {
+	ListLabels(ctx context.Context, companyID uuid.UUID) ([]models.DocumentLabel, error)
+	// SetLabels upserts the given overrides. A label with empty text is
+	// removed so the default applies again.
+	SetLabels(ctx context.Context, labels []models.DocumentLabel) error
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+)
+
+type DWExportRepository interface {
+	Entities() []models.DWEntity
+
+	// GetWatermark returns nil when the entity has never been exported.
+	GetWatermark(ctx context.Context, entity string) (*models.DWWatermark, error)
+	ListWatermarks(ctx context.Context) ([]models.DWWatermark, error)
+	SaveWatermark(ctx context.Context, watermark *models.DWWatermark) error
+
+	// Extract returns the entity's rows changed in (since, until]. Entities
+	// that aren't incremental ignore the range and return every row.
+	Extract(ctx context.Context, entity models.DWEntity, since, until time.Time) (columns []string, rows [][]string, err error)
+}
+
+// DWSink delivers exported batches to the warehouse.
+type DWSink interface {
+	Name() string
+	Write(ctx context.Context, batch models.DWBatch) error
+}
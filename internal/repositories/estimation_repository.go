@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type EstimationRepository interface {
+	// RecordProjectVariances replaces the project's stored variances with
+	// freshly computed ones and returns them.
+	RecordProjectVariances(ctx context.Context, projectID uuid.UUID) ([]models.EstimationVariance, error)
+	ListProjectVariances(ctx context.Context, projectID uuid.UUID) ([]models.EstimationVariance, error)
+
+	// ListAdjustments aggregates variances per item, keeping items seen on
+	// at least minProjects projects. An empty kind returns both kinds.
+	ListAdjustments(ctx context.Context, kind models.EstimationItemKind, minProjects int) ([]models.EstimationAdjustment, error)
+}
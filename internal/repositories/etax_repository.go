@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ETaxRepository interface {
+	Create(ctx context.Context, submission *models.ETaxSubmission) error
+	Update(ctx context.Context, submission *models.ETaxSubmission) error
+	GetByID(ctx context.Context, submissionID uuid.UUID) (*models.ETaxSubmission, error)
+	ListByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]models.ETaxSubmission, error)
+	ListByStatus(ctx context.Context, status models.ETaxStatus) ([]models.ETaxSubmission, error)
+
+	// GetActive returns the submitted or accepted submission of a document,
+	// or nil when there is none. paymentID is nil for tax invoices.
+	GetActive(ctx context.Context, invoiceID uuid.UUID, documentType models.ETaxDocumentType, paymentID *uuid.UUID) (*models.ETaxSubmission, error)
+}
+
+// ETaxSigner applies the company's digital signature (XAdES) to an e-tax
+// XML document and returns the signed document.
+type ETaxSigner interface {
+	Sign(ctx context.Context, document []byte) ([]byte, error)
+}
+
+// ETaxProvider files signed documents with the Revenue Department, directly
+// or through a certified service provider.
+type ETaxProvider interface {
+	Submit(ctx context.Context, documentNumber string, document []byte) (reference string, err error)
+	// Status reports the provider's current verdict. It returns
+	// ETaxStatusSubmitted while the document is still being processed.
+	Status(ctx context.Context, reference string) (status models.ETaxStatus, message string, err error)
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+)
+
+type ExchangeRateRepository interface {
+	SaveFetched(ctx context.Context, rates []models.ExchangeRate) error
+	SaveManual(ctx context.Context, rate models.ExchangeRate) error
+	DeleteManual(ctx context.Context, currencyCode string, rateDate time.Time) error
+	GetLatest(ctx context.Context, currencyCode string) (*models.ExchangeRate, error)
+	ListLatest(ctx context.Context) ([]models.ExchangeRate, error)
+}
+
+// ExchangeRateProvider fetches THB rates from an external source.
+// The returned map is keyed by ISO 4217 code and holds THB per unit.
+type ExchangeRateProvider interface {
+	Name() string
+	FetchRates(ctx context.Context, currencies []string) (map[string]float64, time.Time, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FinalAccountRepository interface {
+	Create(ctx context.Context, account *models.FinalAccount) error
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.FinalAccount, error)
+
+	// Update replaces the final account's fields and back-charges. It fails
+	// once the final account has been agreed.
+	Update(ctx context.Context, account *models.FinalAccount) error
+	Agree(ctx context.Context, finalAccountID uuid.UUID, name string, amount float64, at time.Time) error
+
+	// PaidToDate is what the client has paid against the project's
+	// invoices.
+	PaidToDate(ctx context.Context, projectID uuid.UUID) (float64, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FixedAssetRepository interface {
+	Create(ctx context.Context, asset models.FixedAsset) error
+	Update(ctx context.Context, asset models.FixedAsset) error
+	GetByID(ctx context.Context, assetID uuid.UUID) (*models.FixedAsset, error)
+	// List returns assets bought on or before purchasedBy, including
+	// disposed ones when includeDisposed is set.
+	List(ctx context.Context, purchasedBy time.Time, includeDisposed bool) ([]models.FixedAsset, error)
+	Dispose(ctx context.Context, assetID uuid.UUID, disposedAt time.Time) error
+
+	// CreatePostings skips postings for periods already posted, so
+	// depreciation runs can be repeated safely.
+	CreatePostings(ctx context.Context, postings []models.DepreciationPosting) error
+	ListPostings(ctx context.Context, assetID uuid.UUID) ([]models.DepreciationPosting, error)
+	GetAllocation(ctx context.Context, period string) ([]models.DepreciationAllocation, error)
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HandoverRepository interface {
+	Create(ctx context.Context, handover *models.Handover) error
+	GetByID(ctx context.Context, handoverID uuid.UUID) (*models.Handover, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.Handover, error)
+
+	// Update replaces the handover's fields, defects and attachments. It
+	// fails once the handover has been acknowledged.
+	Update(ctx context.Context, handover *models.Handover) error
+	Acknowledge(ctx context.Context, handoverID uuid.UUID, name string, ip string, at time.Time) error
+}
+
+// FileFetcher downloads a file that was uploaded elsewhere and stored by
+// URL, returning its content and content type.
+type FileFetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, string, error)
+}
+
+// FileStore uploads files to object storage and returns the URL they are
+// served from. Put replaces any file already stored under key.
+type FileStore interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) (string, error)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type InspectionRepository interface {
+	CreateTemplate(ctx context.Context, req requests.CreateInspectionTemplateRequest) (*models.InspectionTemplate, error)
+	GetTemplate(ctx context.Context, templateID uuid.UUID) (*models.InspectionTemplate, error)
+	ListTemplates(ctx context.Context, jobID *uuid.UUID) ([]models.InspectionTemplate, error)
+	UpdateTemplate(ctx context.Context, templateID uuid.UUID, req requests.UpdateInspectionTemplateRequest) error
+	DeleteTemplate(ctx context.Context, templateID uuid.UUID) error
+
+	Create(ctx context.Context, template *models.InspectionTemplate, boqID uuid.UUID) (*models.Inspection, error)
+	GetByID(ctx context.Context, inspectionID uuid.UUID) (*models.Inspection, error)
+	ListByBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) ([]models.Inspection, error)
+	SaveResults(ctx context.Context, inspection *models.Inspection, photos []models.InspectionPhoto) error
+
+	// CountPendingMandatory counts the job's mandatory templates that have
+	// no passed inspection in the BOQ.
+	CountPendingMandatory(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) (int, error)
+
+	// ListOpenFindings lists failed items on the BOQ's inspections whose
+	// template has no passed inspection for the same job.
+	ListOpenFindings(ctx context.Context, boqID uuid.UUID) ([]models.InspectionFinding, error)
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type InventoryRepository interface {
+	CreateWarehouse(ctx context.Context, warehouse models.Warehouse) error
+	GetWarehouse(ctx context.Context, warehouseID uuid.UUID) (*models.Warehouse, error)
+	ListWarehouses(ctx context.Context, includeClosed bool) ([]models.Warehouse, error)
+
+	// GetStock lists non-zero stock in a warehouse.
+	GetStock(ctx context.Context, warehouseID uuid.UUID) ([]models.StockLevel, error)
+	// GetMaterialStock lists where a material is held.
+	GetMaterialStock(ctx context.Context, materialID string) ([]models.StockLevel, error)
+	ListMovements(ctx context.Context, warehouseID uuid.UUID) ([]models.StockMovement, error)
+
+	// RecordMovements writes the movements in one transaction and fails
+	// with "insufficient stock" if any would take a warehouse's stock of a
+	// material below zero.
+	RecordMovements(ctx context.Context, movements []models.StockMovement) error
+	// CloseWarehouse records the movements emptying the warehouse and marks
+	// it closed in the same transaction.
+	CloseWarehouse(ctx context.Context, warehouseID uuid.UUID, movements []models.StockMovement) error
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type InvitationRepository interface {
+	// Create adds the pending user and their invitation together. It fails
+	// with "username already exists" when the username is taken.
+	Create(ctx context.Context, user *models.User, invitation models.UserInvitation) error
+	GetByID(ctx context.Context, invitationID uuid.UUID) (*models.UserInvitation, error)
+	// Accept sets the user's password and marks the invitation accepted.
+	// It fails with "invitation already accepted" when it was.
+	Accept(ctx context.Context, invitationID uuid.UUID, hashedPassword string) error
+}
+
+// Mailer sends plain-text email.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
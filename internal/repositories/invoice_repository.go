@@ -3,14 +3,18 @@ package repositories
 import (
 	"boonkosang/internal/domain/models"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type InvoiceRepository interface {
-	Create(ctx context.Context, projectID uuid.UUID, fileURL string) error
+	// Create adds the invoice and marks lateInterestIDs as billed on it.
+	Create(ctx context.Context, projectID uuid.UUID, phaseID *uuid.UUID, fileURL string, amount float64, lateInterestIDs []uuid.UUID) error
 	Delete(ctx context.Context, invoiceID uuid.UUID) error
 	GetByID(ctx context.Context, invoiceID uuid.UUID) (*models.Invoice, error)
 	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.Invoice, error)
 	ValidateProjectStatus(ctx context.Context, projectID uuid.UUID) error
+	// GetTaxRows returns invoices created in [from, to), oldest first.
+	GetTaxRows(ctx context.Context, from, to time.Time) ([]models.InvoiceTaxRow, error)
 }
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type KPIRepository interface {
+	UpsertTarget(ctx context.Context, target *models.KPITarget) error
+	ListTargets(ctx context.Context, from, to time.Time) ([]models.KPITarget, error)
+	DeleteTarget(ctx context.Context, targetID uuid.UUID) error
+
+	// GetMonthlyActuals returns one row per month in [from, to).
+	GetMonthlyActuals(ctx context.Context, from, to time.Time) ([]models.KPIActual, error)
+}
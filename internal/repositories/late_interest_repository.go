@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type LateInterestRepository interface {
+	GetTerms(ctx context.Context, projectID uuid.UUID) (*models.LatePaymentTerms, error)
+	UpsertTerms(ctx context.Context, terms models.LatePaymentTerms) error
+
+	// ListAccruableInvoices returns invoices under late payment terms that
+	// are unpaid, or were paid after their last accrual.
+	ListAccruableInvoices(ctx context.Context) ([]models.AccruableInvoice, error)
+	// CreateAccruals skips days already accrued for an invoice.
+	CreateAccruals(ctx context.Context, accruals []models.LateInterestAccrual) error
+	ListAccruals(ctx context.Context, projectID uuid.UUID, unbilledOnly bool) ([]models.LateInterestAccrual, error)
+
+	// CreateDebitNote bills the given unbilled accruals on note. It fails
+	// with "late interest changed, please retry" if any was billed since.
+	CreateDebitNote(ctx context.Context, note models.LateInterestDebitNote, accrualIDs []uuid.UUID) error
+	ListDebitNotes(ctx context.Context, projectID uuid.UUID) ([]models.LateInterestDebitNote, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type MaterialAliasRepository interface {
+	// Create fails with "alias already exists" for a duplicate name or
+	// supplier code. A display alias replaces the material's previous
+	// display name in its language.
+	Create(ctx context.Context, alias *models.MaterialAlias) error
+	GetByID(ctx context.Context, aliasID uuid.UUID) (*models.MaterialAlias, error)
+	ListByMaterial(ctx context.Context, materialID string) ([]models.MaterialAlias, error)
+	// SetDisplay makes the alias the display name in its language.
+	SetDisplay(ctx context.Context, aliasID uuid.UUID) error
+	Delete(ctx context.Context, aliasID uuid.UUID) error
+}
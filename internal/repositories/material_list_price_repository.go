@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MaterialListPriceRepository interface {
+	Create(ctx context.Context, price *models.MaterialListPrice) error
+	GetByID(ctx context.Context, priceID uuid.UUID) (*models.MaterialListPrice, error)
+	// ListByMaterial returns every price of the material, latest
+	// effective date first.
+	ListByMaterial(ctx context.Context, materialID string) ([]models.MaterialListPrice, error)
+	Delete(ctx context.Context, priceID uuid.UUID) error
+
+	// ApplyToProject prices the project's BOQ materials at the list price
+	// effective on date. Estimates set since that price took effect are
+	// kept. It returns how many BOQ material lines were re-priced.
+	ApplyToProject(ctx context.Context, projectID uuid.UUID, date time.Time) (int64, error)
+	// ListUpcomingImpacts finds draft BOQ and quotation materials whose
+	// list price rises after from and on or before to.
+	ListUpcomingImpacts(ctx context.Context, from, to time.Time) ([]models.UpcomingPriceImpact, error)
+}
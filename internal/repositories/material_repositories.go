@@ -14,6 +14,9 @@ type MaterialRepository interface {
 	Delete(ctx context.Context, materialID string) error
 	GetByID(ctx context.Context, materialID string) (*models.Material, error)
 	List(ctx context.Context) ([]models.Material, error)
+	// Search finds materials whose ID, name or any alias contains query,
+	// exact and prefix matches first.
+	Search(ctx context.Context, query string, limit int) ([]models.MaterialSearchResult, error)
 
 	GetMaterialPricesByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.MaterialPriceInfo, error)
 	UpdateEstimatedPrices(ctx context.Context, boqID uuid.UUID, materialID string, estimatedPrice float64) error
@@ -21,4 +24,6 @@ type MaterialRepository interface {
 	UpdateActualPrice(ctx context.Context, boqID uuid.UUID, req requests.UpdateMaterialActualPriceRequest) error
 	GetProjectStatus(ctx context.Context, projectID uuid.UUID) (string, error)
 	GetQuotationStatus(ctx context.Context, projectID uuid.UUID) (string, error)
+
+	Merge(ctx context.Context, sourceID string, targetID string) error
 }
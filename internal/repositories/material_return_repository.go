@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MaterialReturnRepository interface {
+	// Create records the return, its lines and any stock movements in one
+	// transaction. It fails with "quantity exceeds returnable quantity" if
+	// a line would return more than was received, or more than its
+	// receipt, and with "insufficient stock" if the warehouse doesn't hold
+	// it. The actual price of each returned material in its project's BOQ
+	// is recalculated net of returns.
+	Create(ctx context.Context, ret models.MaterialReturn, lines []models.MaterialReturnLine, movements []models.StockMovement) error
+	GetByID(ctx context.Context, returnID uuid.UUID) (*models.MaterialReturnSummary, error)
+	// List returns returns newest first.
+	List(ctx context.Context, filter models.MaterialReturnFilter) ([]models.MaterialReturnSummary, error)
+	ListLines(ctx context.Context, returnID uuid.UUID) ([]models.MaterialReturnLine, error)
+	// RecordCredit marks the credit note received. It fails with "credit
+	// note already received" if it was.
+	RecordCredit(ctx context.Context, returnID uuid.UUID, number string, amount float64, date time.Time) error
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	// ListRules returns the company's default rules for a null projectID,
+	// or the project's own rules.
+	ListRules(ctx context.Context, projectID uuid.NullUUID) ([]models.NotificationRule, error)
+	// SetRules upserts the given rules, all of which belong to projectID.
+	SetRules(ctx context.Context, projectID uuid.NullUUID, rules []models.NotificationRule) error
+	// ResetRules drops a project's own rules so the defaults apply again.
+	ResetRules(ctx context.Context, projectID uuid.UUID) error
+
+	Create(ctx context.Context, notifications []models.Notification) error
+	// ListByUser returns the user's notifications, newest first.
+	ListByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	// MarkRead fails with "notification not found" unless the notification
+	// is the user's.
+	MarkRead(ctx context.Context, userID, notificationID uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+
+	// ClaimOverdueInvoices returns invoices that have gone overdue since
+	// they were last claimed and records them, so each is only returned
+	// once. Clients without payment terms get defaultTermsDays.
+	ClaimOverdueInvoices(ctx context.Context, defaultTermsDays int) ([]models.OverdueInvoice, error)
+}
+
+// LineMessenger pushes a text message to a LINE user.
+type LineMessenger interface {
+	Push(ctx context.Context, to, text string) error
+}
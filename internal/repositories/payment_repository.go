@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, invoiceID uuid.UUID, req requests.RecordPaymentRequest) (*models.Payment, *models.Receipt, error)
+	// CreateOnce records the payment unless one with the same reference
+	// exists, in which case it returns nil.
+	CreateOnce(ctx context.Context, invoiceID uuid.UUID, req requests.RecordPaymentRequest) (*models.Payment, *models.Receipt, error)
+	GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]models.Payment, error)
+	GetTotalPaidByInvoiceID(ctx context.Context, invoiceID uuid.UUID) (float64, error)
+	GetReceiptByPaymentID(ctx context.Context, paymentID uuid.UUID) (*models.Receipt, error)
+	GetReceiptExportData(ctx context.Context, receiptID uuid.UUID) (*responses.ReceiptExportData, error)
+}
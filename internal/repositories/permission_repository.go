@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+)
+
+type PermissionRepository interface {
+	ListRolePermissions(ctx context.Context) ([]models.RolePermission, error)
+	// SetRolePermissions upserts the given overrides for one role.
+	SetRolePermissions(ctx context.Context, permissions []models.RolePermission) error
+}
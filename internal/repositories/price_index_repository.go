@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type PriceIndexRepository interface {
+	Create(ctx context.Context, req requests.CreatePriceIndexRequest) (*models.PriceIndex, error)
+	GetByID(ctx context.Context, indexID uuid.UUID) (*models.PriceIndex, error)
+	List(ctx context.Context) ([]models.PriceIndex, error)
+	Delete(ctx context.Context, indexID uuid.UUID) error
+
+	SaveValues(ctx context.Context, values []models.PriceIndexValue) error
+	ListValues(ctx context.Context, indexID uuid.UUID) ([]models.PriceIndexValue, error)
+
+	LinkMaterial(ctx context.Context, materialID string, indexID uuid.UUID) error
+	UnlinkMaterial(ctx context.Context, materialID string) error
+
+	GetDraftQuotationAlerts(ctx context.Context, thresholdPercent float64) ([]models.PriceIndexAlert, error)
+}
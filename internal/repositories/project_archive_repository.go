@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+type ProjectArchiveRepository interface {
+	// ExportChildData returns the project's rows in every archived table,
+	// keyed by table name.
+	ExportChildData(ctx context.Context, projectID uuid.UUID) (map[string]models.ArchivedRows, error)
+	// Archive deletes the exported rows and records the archive. It fails
+	// with "project changed while archiving, please retry" when the rows
+	// no longer match counts.
+	Archive(ctx context.Context, archive *models.ProjectArchive, counts map[string]int) error
+	// Restore puts the archived rows back and marks the archive restored.
+	Restore(ctx context.Context, archiveID, restoredBy uuid.UUID, tables map[string]json.RawMessage) error
+	// GetLatest fails with "archive not found" when the project has never
+	// been archived.
+	GetLatest(ctx context.Context, projectID uuid.UUID) (*models.ProjectArchive, error)
+}
+
+// ColdStorage keeps archived project data. Keys are never reused, so a
+// write-once store works.
+type ColdStorage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get fails with "object not found" for a missing key.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ProjectMemberRepository interface {
+	Add(ctx context.Context, member models.ProjectMember) error
+	Remove(ctx context.Context, projectID, userID uuid.UUID) error
+	ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.ProjectMemberDetail, error)
+	IsMember(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+	// ListProjectIDs returns the projects userID is a member of.
+	ListProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+}
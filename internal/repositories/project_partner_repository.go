@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ProjectPartnerRepository interface {
+	ListPartners(ctx context.Context, projectID uuid.UUID) ([]models.ProjectPartner, error)
+	// ReplacePartners swaps the project's partner list in one transaction;
+	// an empty list makes it a sole project again.
+	ReplacePartners(ctx context.Context, projectID uuid.UUID, partners []models.ProjectPartner) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ProjectPhaseRepository interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.CreateProjectPhaseRequest) (*models.ProjectPhase, error)
+	CreateDefaults(ctx context.Context, projectID uuid.UUID, names []string) ([]models.ProjectPhase, error)
+	GetByID(ctx context.Context, phaseID uuid.UUID) (*models.ProjectPhase, error)
+	ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.ProjectPhase, error)
+	Update(ctx context.Context, phaseID uuid.UUID, req requests.UpdateProjectPhaseRequest) error
+	Delete(ctx context.Context, phaseID uuid.UUID) error
+
+	AssignBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, phaseID *uuid.UUID) error
+	GetSummary(ctx context.Context, projectID uuid.UUID) ([]models.PhaseSummary, error)
+}
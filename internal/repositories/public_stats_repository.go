@@ -0,0 +1,10 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+)
+
+type PublicStatsRepository interface {
+	GetPublicStats(ctx context.Context) (*models.PublicStats, error)
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, po models.PurchaseOrder, lines []models.PurchaseOrderLine, allocations []models.PurchaseOrderAllocation) error
+	GetByID(ctx context.Context, poID uuid.UUID) (*models.PurchaseOrderSummary, error)
+	// List returns orders newest first; a null supplier or project matches
+	// every order.
+	List(ctx context.Context, supplierID, projectID uuid.NullUUID) ([]models.PurchaseOrderSummary, error)
+	// ListByAgreement returns the release orders against a blanket
+	// agreement, newest first.
+	ListByAgreement(ctx context.Context, agreementID uuid.UUID) ([]models.PurchaseOrderSummary, error)
+	ListLines(ctx context.Context, poID uuid.UUID) ([]models.PurchaseOrderLine, error)
+	ListAllocations(ctx context.Context, poID uuid.UUID) ([]models.PurchaseOrderAllocation, error)
+	ListReceipts(ctx context.Context, poID uuid.UUID) ([]models.PurchaseOrderReceipt, error)
+	Cancel(ctx context.Context, poID uuid.UUID) error
+
+	// ProjectUsesMaterial reports whether the material is in the project's
+	// BOQ, which is where received costs are charged.
+	ProjectUsesMaterial(ctx context.Context, projectID uuid.UUID, materialID string) (bool, error)
+	// Receive records the receipts and the order's new status, and sets
+	// the actual price of each received material in its project's BOQ to
+	// the average price received for it so far, net of returns. It fails with "quantity
+	// exceeds outstanding" if another receipt got there first.
+	Receive(ctx context.Context, poID uuid.UUID, status models.PurchaseOrderStatus, receipts []models.PurchaseOrderReceipt) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuotationNegotiationRepository interface {
+	// CreateRound assigns the next round number for the quotation.
+	CreateRound(ctx context.Context, round *models.QuotationNegotiationRound) error
+	ListRounds(ctx context.Context, quotationID uuid.UUID) ([]models.QuotationNegotiationRound, error)
+	GetRound(ctx context.Context, roundID uuid.UUID) (*models.QuotationNegotiationRound, error)
+	UpdateRound(ctx context.Context, round models.QuotationNegotiationRound) error
+
+	// GetOutcomes lists quotations whose projects were created in
+	// [from, to).
+	GetOutcomes(ctx context.Context, from, to time.Time) ([]models.QuotationOutcomeRow, error)
+}
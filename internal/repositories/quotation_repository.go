@@ -20,6 +20,7 @@ type QuotationRepository interface {
 	GetQuotationStatus(ctx context.Context, projectID uuid.UUID) (string, error)
 	ValidateApproval(ctx context.Context, projectID uuid.UUID) error
 
+	GetTaxBases(ctx context.Context, projectID uuid.UUID) (*models.QuotationTaxBases, error)
 	GetExportData(ctx context.Context, projectID uuid.UUID) (*responses.QuotationExportData, error)
 
 	UpdateProjectSellingPrice(ctx context.Context, req requests.UpdateProjectSellingPriceRequest) error
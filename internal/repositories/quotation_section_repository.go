@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type QuotationSectionRepository interface {
+	ListSections(ctx context.Context, quotationID uuid.UUID) ([]models.QuotationSection, error)
+	// SaveSections upserts sections with content and deletes the kinds in
+	// remove, in one transaction.
+	SaveSections(ctx context.Context, quotationID uuid.UUID, sections []models.QuotationSection, remove []models.QuotationSectionKind) error
+
+	CreateSnippet(ctx context.Context, snippet *models.QuotationSnippet) error
+	GetSnippetByID(ctx context.Context, snippetID uuid.UUID) (*models.QuotationSnippet, error)
+	// ListSnippets returns every snippet when kind is empty.
+	ListSnippets(ctx context.Context, kind models.QuotationSectionKind) ([]models.QuotationSnippet, error)
+	UpdateSnippet(ctx context.Context, snippet *models.QuotationSnippet) error
+	DeleteSnippet(ctx context.Context, snippetID uuid.UUID) error
+}
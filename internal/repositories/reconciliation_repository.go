@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ReconciliationRepository interface {
+	GetTotals(ctx context.Context, projectID uuid.UUID) (*models.ReconciliationTotals, error)
+	// ListInvoices returns the project's invoices, oldest first.
+	ListInvoices(ctx context.Context, projectID uuid.UUID) ([]models.ReconciliationInvoice, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RecurringGeneralCostRepository interface {
+	Create(ctx context.Context, cost models.RecurringGeneralCost) error
+	Update(ctx context.Context, cost models.RecurringGeneralCost) error
+	GetByID(ctx context.Context, recurringID uuid.UUID) (*models.RecurringGeneralCost, error)
+	ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.RecurringGeneralCost, error)
+	Delete(ctx context.Context, recurringID uuid.UUID) error
+
+	// ListPostable returns the recurring costs of projects that are still
+	// open and have an approved BOQ to post them to.
+	ListPostable(ctx context.Context) ([]models.RecurringGeneralCost, error)
+	ListPostingsByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.RecurringCostPosting, error)
+	// CreatePosting records the posting and adds its amount to the actual
+	// cost of the project's general cost of the same type. A period that
+	// was already posted is skipped, so runs can be repeated safely.
+	CreatePosting(ctx context.Context, posting models.RecurringCostPosting) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RegionRepository interface {
+	Create(ctx context.Context, preset *models.RegionPreset) error
+	CreateDefaults(ctx context.Context, presets []models.RegionPreset) error
+	GetByID(ctx context.Context, regionID uuid.UUID) (*models.RegionPreset, error)
+	List(ctx context.Context) ([]models.RegionPreset, error)
+	Update(ctx context.Context, preset *models.RegionPreset) error
+	Delete(ctx context.Context, regionID uuid.UUID) error
+
+	// GetProjectRegion returns nil when the project has no region.
+	GetProjectRegion(ctx context.Context, projectID uuid.UUID) (*models.RegionPreset, error)
+	SetProjectRegion(ctx context.Context, projectID uuid.UUID, regionID *uuid.UUID) error
+}
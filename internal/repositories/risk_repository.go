@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/requests"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RiskRepository interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.CreateRiskRequest) (*models.Risk, error)
+	GetByID(ctx context.Context, riskID uuid.UUID) (*models.Risk, error)
+	ListByProjectID(ctx context.Context, projectID uuid.UUID, status string) ([]models.Risk, error)
+	Update(ctx context.Context, riskID uuid.UUID, req requests.UpdateRiskRequest) error
+	Delete(ctx context.Context, riskID uuid.UUID) error
+
+	// CountUnmitigated counts risks that are not closed, score at least
+	// minScore and have no mitigation recorded.
+	CountUnmitigated(ctx context.Context, projectID uuid.UUID, minScore int) (int, error)
+}
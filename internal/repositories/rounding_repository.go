@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+)
+
+type RoundingRepository interface {
+	// GetPolicy returns the default policy when none has been saved.
+	GetPolicy(ctx context.Context) (*models.RoundingPolicy, error)
+	UpdatePolicy(ctx context.Context, policy *models.RoundingPolicy) error
+}
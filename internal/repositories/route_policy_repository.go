@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+)
+
+type RoutePolicyRepository interface {
+	List(ctx context.Context) ([]models.RoutePolicy, error)
+	// Save upserts the policy for its method and path.
+	Save(ctx context.Context, policy models.RoutePolicy) error
+	// Delete returns "route policy not found" when no policy is saved for
+	// method and path.
+	Delete(ctx context.Context, method, path string) error
+}
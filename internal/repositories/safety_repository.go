@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SafetyRepository interface {
+	Create(ctx context.Context, incident *models.SafetyIncident) error
+	GetByID(ctx context.Context, incidentID uuid.UUID) (*models.SafetyIncident, error)
+	ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.SafetyIncident, error)
+	Update(ctx context.Context, incident *models.SafetyIncident) error
+	Delete(ctx context.Context, incidentID uuid.UUID) error
+
+	AddAction(ctx context.Context, action *models.CorrectiveAction) error
+	CompleteAction(ctx context.Context, incidentID uuid.UUID, actionID uuid.UUID) error
+
+	// GetMonthlyStats returns one row per month in [from, to). Man-hours are
+	// derived from the workers on schedule tasks times hoursPerDay.
+	GetMonthlyStats(ctx context.Context, from, to time.Time, projectID *uuid.UUID, hoursPerDay float64) ([]models.SafetyMonthlyStat, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleTaskRepository interface {
+	Create(ctx context.Context, task *models.ScheduleTask) error
+	GetByID(ctx context.Context, taskID uuid.UUID) (*models.ScheduleTask, error)
+	ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]models.ScheduleTask, error)
+	Update(ctx context.Context, task *models.ScheduleTask) error
+	Delete(ctx context.Context, taskID uuid.UUID) error
+	AssignCrew(ctx context.Context, taskID uuid.UUID, crewID *uuid.UUID) error
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SubcontractRepository interface {
+	Create(ctx context.Context, subcontract models.Subcontract) error
+	GetByID(ctx context.Context, subcontractID uuid.UUID) (*models.Subcontract, error)
+	ListByProject(ctx context.Context, projectID uuid.UUID) ([]models.Subcontract, error)
+	Complete(ctx context.Context, subcontractID uuid.UUID, completedAt time.Time) error
+
+	// CreateCertificate numbers the certificate within its subcontract and
+	// records the back-charges deducted on it.
+	CreateCertificate(ctx context.Context, certificate *models.SubcontractCertificate, offsets []models.BackChargeOffset) error
+	ListCertificates(ctx context.Context, subcontractID uuid.UUID) ([]models.SubcontractCertificate, error)
+
+	ListItems(ctx context.Context, subcontractID uuid.UUID) ([]models.SubcontractItem, error)
+	ReplaceItems(ctx context.Context, subcontractID uuid.UUID, items []models.SubcontractItem) error
+	GetItemProgress(ctx context.Context, subcontractID uuid.UUID) ([]models.SubcontractItemProgress, error)
+
+	// CreateClaim numbers the claim within its subcontract.
+	CreateClaim(ctx context.Context, claim *models.SubcontractClaim, lines []models.SubcontractClaimLine) error
+	GetClaim(ctx context.Context, claimID uuid.UUID) (*models.SubcontractClaim, error)
+	ListClaims(ctx context.Context, subcontractID uuid.UUID) ([]models.SubcontractClaim, error)
+	ListClaimLines(ctx context.Context, claimID uuid.UUID) ([]models.SubcontractClaimLine, error)
+	// CertifyClaim saves the certified quantities, creates the certificate
+	// and marks the claim certified in one transaction.
+	CertifyClaim(ctx context.Context, claim models.SubcontractClaim, lines []models.SubcontractClaimLine, certificate *models.SubcontractCertificate, offsets []models.BackChargeOffset) error
+	RejectClaim(ctx context.Context, claim models.SubcontractClaim) error
+
+	GetRetentionBalance(ctx context.Context, subcontractID uuid.UUID) (*models.RetentionBalance, error)
+	CreateRelease(ctx context.Context, release models.RetentionRelease) error
+	GetRelease(ctx context.Context, releaseID uuid.UUID) (*models.RetentionRelease, error)
+	ListReleases(ctx context.Context, subcontractID uuid.UUID) ([]models.RetentionRelease, error)
+	// DecideRelease approves or rejects a pending release.
+	DecideRelease(ctx context.Context, releaseID uuid.UUID, status models.RetentionReleaseStatus, decidedBy uuid.UUID) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SupplierPayableRepository interface {
+	GetCreditTerms(ctx context.Context, supplierID uuid.UUID) (*models.SupplierCreditTerms, error)
+	SetCreditTerms(ctx context.Context, terms models.SupplierCreditTerms) error
+
+	Create(ctx context.Context, payable models.SupplierPayable) error
+	GetByID(ctx context.Context, payableID uuid.UUID) (*models.SupplierPayable, error)
+	ListBySupplierID(ctx context.Context, supplierID uuid.UUID) ([]models.SupplierPayable, error)
+	// ListUnpaidDueBy returns unpaid payables due on or before date,
+	// earliest first.
+	ListUnpaidDueBy(ctx context.Context, date time.Time) ([]models.SupplierPayableDetail, error)
+	MarkPaid(ctx context.Context, payableID uuid.UUID, paidAt time.Time) error
+}
@@ -13,6 +13,7 @@ type SupplierRepository interface {
 	Update(ctx context.Context, id uuid.UUID, req requests.UpdateSupplierRequest) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Supplier, error)
-	List(ctx context.Context, limit, offset int) ([]models.Supplier, int64, error)
+	List(ctx context.Context, limit, offset int, status string) ([]models.Supplier, int64, error)
 	GetByEmail(ctx context.Context, email string) (*models.Supplier, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status models.SupplierStatus, reason string) error
 }
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type SurveyRepository interface {
+	// CreateTemplate makes the template the only default when IsDefault is
+	// set.
+	CreateTemplate(ctx context.Context, template models.SurveyTemplate) error
+	GetTemplate(ctx context.Context, templateID uuid.UUID) (*models.SurveyTemplate, error)
+	GetDefaultTemplate(ctx context.Context) (*models.SurveyTemplate, error)
+	ListTemplates(ctx context.Context) ([]models.SurveyTemplate, error)
+	SetDefaultTemplate(ctx context.Context, templateID uuid.UUID) error
+
+	Create(ctx context.Context, survey models.Survey) error
+	GetByID(ctx context.Context, surveyID uuid.UUID) (*models.Survey, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.Survey, error)
+	// Submit stores the answers unless the survey was already answered.
+	Submit(ctx context.Context, survey models.Survey) error
+	// ListResponded returns answered surveys, for year only when it is
+	// non-zero, oldest first.
+	ListResponded(ctx context.Context, year int) ([]models.Survey, error)
+}
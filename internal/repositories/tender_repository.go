@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type TenderRepository interface {
+	Create(ctx context.Context, tender models.Tender) error
+	GetByID(ctx context.Context, tenderID uuid.UUID) (*models.Tender, error)
+	// List returns tenders by submission deadline, optionally filtered by
+	// status.
+	List(ctx context.Context, status string) ([]models.Tender, error)
+	Update(ctx context.Context, tender models.Tender) error
+	Delete(ctx context.Context, tenderID uuid.UUID) error
+
+	ListDocuments(ctx context.Context, tenderID uuid.UUID) ([]models.TenderDocument, error)
+	GetDocument(ctx context.Context, documentID uuid.UUID) (*models.TenderDocument, error)
+	CreateDocument(ctx context.Context, document models.TenderDocument) error
+	UpdateDocument(ctx context.Context, document models.TenderDocument) error
+	DeleteDocument(ctx context.Context, documentID uuid.UUID) error
+
+	ListBidLines(ctx context.Context, tenderID uuid.UUID) ([]models.TenderBidLine, error)
+	ReplaceBidLines(ctx context.Context, tenderID uuid.UUID, lines []models.TenderBidLine) error
+}
@@ -4,9 +4,113 @@ import (
 	"boonkosang/internal/domain/models"
 	"boonkosang/internal/requests"
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type UserRepository interface {
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	// GetByEmail matches the address case-insensitively.
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	// CreateUser adds a self-registered user as a viewer; an admin grants
+	// anything more.
 	CreateUser(ctx context.Context, user requests.RegisterRequest) error
+	// List returns users matching filter ordered by username, and how many
+	// match in total.
+	List(ctx context.Context, filter models.UserFilter, limit, offset int) ([]models.User, int64, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, role models.UserRole) error
+	UpdateProfile(ctx context.Context, id uuid.UUID, req requests.UpdateProfileRequest) error
+	UpdateAvatarURL(ctx context.Context, id uuid.UUID, avatarURL string) error
+	// UpdatePassword also clears any requirement to change the password.
+	UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error
+	// RequirePasswordChange makes the user choose a new password and
+	// revokes their refresh tokens so they must sign in again.
+	RequirePasswordChange(ctx context.Context, id uuid.UUID) error
+	// SetActive deactivates or reactivates a user. Deactivating also
+	// revokes their refresh tokens.
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+	// SetPendingEmail records an address awaiting verification, replacing
+	// any earlier one.
+	SetPendingEmail(ctx context.Context, id uuid.UUID, email string) error
+	// ConfirmPendingEmail makes the pending address the user's email. It
+	// fails with "email change not found" unless email is still the
+	// pending one, and "email already in use" if another user took it.
+	ConfirmPendingEmail(ctx context.Context, id uuid.UUID, email string) error
+
+	// RecordFailedLogin counts a wrong password and, on the maxAttempts-th,
+	// locks the account until lockUntil and starts counting again. It
+	// reports whether this attempt locked the account.
+	RecordFailedLogin(ctx context.Context, id uuid.UUID, maxAttempts int, lockUntil time.Time) (bool, error)
+	// ResetFailedLogins clears the count and any lock.
+	ResetFailedLogins(ctx context.Context, id uuid.UUID) error
+
+	// GetPreferences returns the stored JSON, or nil if the user has never
+	// saved any.
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]byte, error)
+	SavePreferences(ctx context.Context, userID uuid.UUID, preferences []byte) error
+
+	RecordLogin(ctx context.Context, audit models.LoginAudit) error
+	// ListLogins returns the user's most recent login attempts first.
+	ListLogins(ctx context.Context, userID uuid.UUID, limit int) ([]models.LoginAudit, error)
+
+	CreateImpersonationSession(ctx context.Context, session models.ImpersonationSession) error
+	ListImpersonationSessions(ctx context.Context) ([]models.ImpersonationSession, error)
+
+	CreateRefreshToken(ctx context.Context, token models.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	// RotateRefreshToken revokes oldID in favour of next. It fails with
+	// "refresh token already used" if oldID was revoked concurrently.
+	RotateRefreshToken(ctx context.Context, oldID uuid.UUID, next models.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+
+	CreateSession(ctx context.Context, session models.UserSession) error
+	// TouchSession records that the session refreshed its token from
+	// ipAddress.
+	TouchSession(ctx context.Context, sessionID uuid.UUID, ipAddress string, seenAt time.Time) error
+	// ListActiveSessions returns the user's sessions that still hold an
+	// unrevoked refresh token, most recently seen first.
+	ListActiveSessions(ctx context.Context, userID uuid.UUID, now time.Time) ([]models.UserSession, error)
+	// RevokeSession revokes the session's refresh tokens. It fails with
+	// "session not found" unless the session is the user's and active.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	// IsSessionRevoked reports whether the session has no unrevoked
+	// refresh token left.
+	IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error)
+
+	// GetByIdentity returns the user linked to an external account.
+	GetByIdentity(ctx context.Context, provider, subject string) (*models.User, error)
+	LinkIdentity(ctx context.Context, identity models.UserIdentity) error
+	// CreateUserWithIdentity provisions a user for an external account and
+	// links them in one transaction.
+	CreateUserWithIdentity(ctx context.Context, user *models.User, identity models.UserIdentity) error
+
+	RevokeAccessToken(ctx context.Context, token models.RevokedAccessToken) error
+	IsAccessTokenRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error)
+	// DeleteExpiredRevokedAccessTokens forgets revocations of tokens that
+	// expired before the given time, as they are rejected anyway.
+	DeleteExpiredRevokedAccessTokens(ctx context.Context, before time.Time) (int64, error)
+
+	CreateAPIKey(ctx context.Context, key models.APIKey) error
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]models.APIKey, error)
+	// RevokeAPIKey fails with "api key not found" unless keyID is an
+	// unrevoked key belonging to userID.
+	RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error
+	TouchAPIKey(ctx context.Context, keyID uuid.UUID, usedAt time.Time) error
+}
+
+// IdentityVerifier checks ID tokens from an external identity provider. It
+// fails with "invalid id token" for tokens that aren't genuine, current and
+// issued to this application.
+type IdentityVerifier interface {
+	VerifyIDToken(ctx context.Context, idToken string) (*models.ExternalIdentity, error)
+}
+
+// PasswordAuthenticator checks a username and password against an
+// external directory. It fails with "invalid credentials" for a wrong
+// password and "directory unavailable" when the directory can't answer.
+type PasswordAuthenticator interface {
+	Authenticate(ctx context.Context, username, password string) error
 }
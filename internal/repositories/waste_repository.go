@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WasteRepository interface {
+	// Create records the waste and takes it out of stock in one
+	// transaction, failing with "insufficient stock" if the warehouse
+	// doesn't hold it.
+	Create(ctx context.Context, record models.WasteRecord, movement models.StockMovement) error
+	ListByProject(ctx context.Context, projectID uuid.UUID) ([]models.WasteRecordDetail, error)
+	// ListSummaries totals waste per project and material, for one project
+	// or, with a null projectID, every project that isn't completed or
+	// cancelled.
+	ListSummaries(ctx context.Context, projectID uuid.NullUUID) ([]models.WasteSummary, error)
+
+	ListAllowances(ctx context.Context) ([]models.WastageAllowance, error)
+	SetAllowance(ctx context.Context, allowance models.WastageAllowance) error
+	DeleteAllowance(ctx context.Context, materialID string) error
+}
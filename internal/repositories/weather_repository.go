@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"boonkosang/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WeatherRepository interface {
+	// ListForecastTargets returns projects with outdoor tasks that haven't
+	// finished yet.
+	ListForecastTargets(ctx context.Context) ([]models.WeatherForecastTarget, error)
+	SaveForecasts(ctx context.Context, projectID uuid.UUID, forecasts []models.WeatherForecast) error
+	GetRisks(ctx context.Context, projectID *uuid.UUID, heavyRainMM float64) ([]models.WeatherRisk, error)
+}
+
+// WeatherProvider fetches daily forecasts from an external source.
+type WeatherProvider interface {
+	Name() string
+	Geocode(ctx context.Context, query string) (*models.GeoLocation, error)
+	DailyForecast(ctx context.Context, location models.GeoLocation) ([]models.WeatherForecast, error)
+}
@@ -0,0 +1,5 @@
+package requests
+
+type UnlockAccountingPeriodRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
@@ -0,0 +1,15 @@
+package requests
+
+// RecordAsBuiltRequest records a completed job's as-built quantity.
+// Disposition is "none" (the default), "change_order" or "final_account".
+// A change order is raised as a contract addendum and needs the signed
+// document's FileURL; Title defaults to the job name and EffectiveDate,
+// YYYY-MM-DD, to today.
+type RecordAsBuiltRequest struct {
+	Quantity      float64 `json:"quantity" validate:"gte=0"`
+	Disposition   string  `json:"disposition"`
+	Note          string  `json:"note"`
+	Title         string  `json:"title"`
+	FileURL       string  `json:"file_url"`
+	EffectiveDate string  `json:"effective_date"`
+}
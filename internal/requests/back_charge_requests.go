@@ -0,0 +1,31 @@
+package requests
+
+import "github.com/google/uuid"
+
+type CreateBackChargeRequest struct {
+	SupplierID uuid.UUID `json:"supplier_id" validate:"required"`
+	// SubcontractID limits the deduction to one subcontract; its project is
+	// used when ProjectID is empty.
+	SubcontractID *uuid.UUID `json:"subcontract_id"`
+	ProjectID     *uuid.UUID `json:"project_id"`
+	Category      string     `json:"category" validate:"required,oneof=rework damage other"`
+	Description   string     `json:"description" validate:"required"`
+	Amount        float64    `json:"amount" validate:"gt=0"`
+}
+
+type DisputeBackChargeRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// ResolveBackChargeRequest settles a dispute. Outcome is "upheld", "reduced"
+// (with the new Amount) or "withdrawn".
+type ResolveBackChargeRequest struct {
+	Outcome    string  `json:"outcome" validate:"required,oneof=upheld reduced withdrawn"`
+	Amount     float64 `json:"amount"`
+	Resolution string  `json:"resolution" validate:"required"`
+}
+
+type BackChargeAttachmentRequest struct {
+	Title   string `json:"title" validate:"required"`
+	FileURL string `json:"file_url" validate:"required"`
+}
@@ -0,0 +1,20 @@
+package requests
+
+import "github.com/google/uuid"
+
+// CreateBlanketAgreementRequest fixes a supplier's prices for a period.
+// ValidFrom and ValidTo are YYYY-MM-DD and inclusive.
+type CreateBlanketAgreementRequest struct {
+	SupplierID uuid.UUID                     `json:"supplier_id" validate:"required"`
+	Reference  string                        `json:"reference" validate:"required"`
+	ValidFrom  string                        `json:"valid_from" validate:"required"`
+	ValidTo    string                        `json:"valid_to" validate:"required"`
+	Note       string                        `json:"note"`
+	Items      []BlanketAgreementItemRequest `json:"items" validate:"required,min=1"`
+}
+
+type BlanketAgreementItemRequest struct {
+	MaterialID        string  `json:"material_id" validate:"required"`
+	UnitPrice         float64 `json:"unit_price" validate:"gt=0"`
+	CommittedQuantity float64 `json:"committed_quantity" validate:"gt=0"`
+}
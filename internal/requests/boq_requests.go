@@ -13,7 +13,18 @@ type UpdateBOQRequest struct {
 	SellingGeneralCost float64 `json:"selling_general_cost" validate:"required"`
 }
 type BOQJobRequest struct {
-	JobID     uuid.UUID `json:"job_id" validate:"required"`
-	Quantity  float64   `json:"quantity" validate:"required,gt=0"`
-	LaborCost float64   `json:"labor_cost" validate:"required,gt=0"`
+	JobID     uuid.UUID  `json:"job_id" validate:"required"`
+	Quantity  float64    `json:"quantity" validate:"required,gt=0"`
+	LaborCost float64    `json:"labor_cost" validate:"required,gt=0"`
+	PhaseID   *uuid.UUID `json:"phase_id"`
+}
+
+// BOQJobDrawingRequest attaches either an uploaded file (FileURL) or an
+// external drawing link (URL), not both.
+type BOQJobDrawingRequest struct {
+	Title         string `json:"title" validate:"required"`
+	DrawingNumber string `json:"drawing_number"`
+	Revision      string `json:"revision"`
+	FileURL       string `json:"file_url"`
+	URL           string `json:"url"`
 }
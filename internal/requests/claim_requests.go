@@ -0,0 +1,32 @@
+package requests
+
+import "github.com/google/uuid"
+
+// ClaimRequest drafts or edits a claim. A time-extension claim needs
+// ClaimedDays and a cost claim ClaimedAmount. DocumentID is the contract
+// document the claim relies on.
+type ClaimRequest struct {
+	ClaimType     string     `json:"claim_type" validate:"required,oneof=time_extension cost"`
+	Title         string     `json:"title" validate:"required"`
+	Narrative     string     `json:"narrative" validate:"required"`
+	ClaimedAmount float64    `json:"claimed_amount" validate:"gte=0"`
+	ClaimedDays   int        `json:"claimed_days" validate:"gte=0"`
+	DocumentID    *uuid.UUID `json:"document_id"`
+}
+
+// SettleClaimRequest records what the client agreed to. SettlementAmount
+// and SettlementDays may be less than claimed, or zero.
+type SettleClaimRequest struct {
+	SettlementAmount float64 `json:"settlement_amount" validate:"gte=0"`
+	SettlementDays   int     `json:"settlement_days" validate:"gte=0"`
+	Resolution       string  `json:"resolution"`
+}
+
+type RejectClaimRequest struct {
+	Resolution string `json:"resolution" validate:"required"`
+}
+
+type ClaimDocumentRequest struct {
+	Title   string `json:"title" validate:"required"`
+	FileURL string `json:"file_url" validate:"required"`
+}
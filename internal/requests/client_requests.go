@@ -1,6 +1,10 @@
 package requests
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
 
 type CreateClientRequest struct {
 	Name    string          `json:"name" validate:"required"`
@@ -10,10 +14,59 @@ type CreateClientRequest struct {
 	TaxID   string          `json:"tax_id" validate:"required,len=13"`
 }
 
+// UpdateClientRequest replaces a client's details. Leaving CreditLimit or
+// PaymentTermsDays out clears it.
 type UpdateClientRequest struct {
-	Name    string          `json:"name" validate:"required"`
-	Email   string          `json:"email" validate:"required,email"`
-	Tel     string          `json:"tel" validate:"required,len=10"`
-	Address json.RawMessage `json:"address" validate:"required"`
-	TaxID   string          `json:"tax_id" validate:"required,len=13"`
+	Name             string          `json:"name" validate:"required"`
+	Email            string          `json:"email" validate:"required,email"`
+	Tel              string          `json:"tel" validate:"required,len=10"`
+	Address          json.RawMessage `json:"address" validate:"required"`
+	TaxID            string          `json:"tax_id" validate:"required,len=13"`
+	CreditLimit      *float64        `json:"credit_limit" validate:"omitempty,gte=0"`
+	PaymentTermsDays *int            `json:"payment_terms_days" validate:"omitempty,gte=0"`
+}
+
+// ListClientsRequest filters and sorts the client list. Sort is one of
+// name, email, tel or tax_id and Order is "asc" or "desc"; empty fields
+// match every client. Archived clients are left out unless
+// IncludeArchived is set.
+type ListClientsRequest struct {
+	Search          string
+	TaxID           string
+	Sort            string
+	Order           string
+	IncludeArchived bool
+	Page            int
+	PageSize        int
+}
+
+// ClientContactRequest adds or replaces a contact person. Role is free
+// text such as "owner", "site engineer" or "accountant".
+type ClientContactRequest struct {
+	Name      string `json:"name" validate:"required"`
+	Role      string `json:"role"`
+	Email     string `json:"email" validate:"omitempty,email"`
+	Tel       string `json:"tel"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+type SetCreditHoldRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// MergeClientRequest merges the source client into the target. Keep says,
+// per field (name, email, tel, address, tax_id, credit_limit,
+// payment_terms_days), whether to keep the "source" or "target" value;
+// fields not listed keep the target's value.
+type MergeClientRequest struct {
+	SourceClientID uuid.UUID         `json:"source_client_id" validate:"required"`
+	TargetClientID uuid.UUID         `json:"target_client_id" validate:"required"`
+	Keep           map[string]string `json:"keep"`
+}
+
+// ClientDocumentRequest describes a document uploaded against a client. It
+// comes as form fields alongside the multipart file "file".
+type ClientDocumentRequest struct {
+	DocumentType string `form:"document_type" validate:"required"`
+	Note         string `form:"note"`
 }
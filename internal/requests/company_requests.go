@@ -11,9 +11,18 @@ type CreateCompanyRequest struct {
 }
 
 type UpdateCompanyRequest struct {
-	Name    string          `json:"name"`
-	Email   string          `json:"email"`
-	Tel     string          `json:"tel"`
-	Address json.RawMessage `json:"address"`
-	TaxID   string          `json:"tax_id"`
+	Name        string          `json:"name"`
+	Email       string          `json:"email"`
+	Tel         string          `json:"tel"`
+	Address     json.RawMessage `json:"address"`
+	TaxID       string          `json:"tax_id"`
+	PromptPayID string          `json:"promptpay_id"`
+	// DateEra is gregorian or buddhist; empty keeps the current setting.
+	DateEra string `json:"date_era"`
+}
+
+// UpdateDocumentLabelsRequest maps label keys to the company's wording; an
+// empty string restores the default.
+type UpdateDocumentLabelsRequest struct {
+	Labels map[string]string `json:"labels" validate:"required"`
 }
@@ -0,0 +1,26 @@
+package requests
+
+import "github.com/google/uuid"
+
+// CreateCubeTestRequest records cubes cast from a pour. SampleDate is
+// YYYY-MM-DD and defaults to the pour date; strengths are in MPa and may
+// be given now or recorded later.
+type CreateCubeTestRequest struct {
+	PourID            uuid.UUID `json:"pour_id" validate:"required"`
+	SampleReference   string    `json:"sample_reference" validate:"required"`
+	SampleDate        string    `json:"sample_date"`
+	SpecifiedStrength float64   `json:"specified_strength" validate:"gt=0"`
+	Strength7Day      *float64  `json:"strength_7_day"`
+	Strength28Day     *float64  `json:"strength_28_day"`
+	Lab               string    `json:"lab"`
+	Note              string    `json:"note"`
+}
+
+// RecordCubeResultsRequest records the lab's results. Fields left out keep
+// their current values.
+type RecordCubeResultsRequest struct {
+	Strength7Day  *float64 `json:"strength_7_day"`
+	Strength28Day *float64 `json:"strength_28_day"`
+	Lab           *string  `json:"lab"`
+	Note          *string  `json:"note"`
+}
@@ -0,0 +1,20 @@
+package requests
+
+import "github.com/google/uuid"
+
+// RecordConcretePourRequest logs a ready-mix delivery from its ticket.
+// Volume is in cubic metres and SlumpMM in millimetres; PourDate is
+// YYYY-MM-DD and defaults to today. MaterialID is the concrete grade in
+// the material catalogue, needed to compare the pour with the BOQ.
+type RecordConcretePourRequest struct {
+	JobID          uuid.UUID `json:"job_id" validate:"required"`
+	SupplierID     uuid.UUID `json:"supplier_id" validate:"required"`
+	MaterialID     string    `json:"material_id"`
+	TicketNumber   string    `json:"ticket_number" validate:"required"`
+	PourDate       string    `json:"pour_date"`
+	Volume         float64   `json:"volume" validate:"gt=0"`
+	SlumpMM        *float64  `json:"slump_mm"`
+	Location       string    `json:"location"`
+	Note           string    `json:"note"`
+	CubeReferences []string  `json:"cube_references"`
+}
@@ -11,3 +11,16 @@ type UploadContractRequest struct {
 type DeleteContractRequest struct {
 	ProjectID uuid.UUID `json:"project_id" validate:"required"`
 }
+
+// CreateContractDocumentRequest uploads a new LOI, main contract or addendum.
+// ContractValue is required for LOIs and main contracts; addenda use
+// ValueAdjustment, which may be negative for omissions.
+type CreateContractDocumentRequest struct {
+	DocumentType    string     `json:"document_type" validate:"required,oneof=loi main_contract addendum"`
+	Title           string     `json:"title" validate:"required"`
+	FileURL         string     `json:"file_url" validate:"required,url"`
+	EffectiveDate   string     `json:"effective_date" validate:"required"`
+	ContractValue   *float64   `json:"contract_value"`
+	ValueAdjustment float64    `json:"value_adjustment"`
+	SupersedesID    *uuid.UUID `json:"supersedes_id"`
+}
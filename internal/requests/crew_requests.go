@@ -0,0 +1,11 @@
+package requests
+
+type CreateCrewRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Capacity int    `json:"capacity" validate:"required,gt=0"`
+}
+
+type UpdateCrewRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Capacity int    `json:"capacity" validate:"required,gt=0"`
+}
@@ -0,0 +1,11 @@
+package requests
+
+// DeliveryRateRequest needs a postal code prefix, a province, or both;
+// the prefix takes precedence when matching.
+type DeliveryRateRequest struct {
+	Name              string  `json:"name" validate:"required"`
+	PostalPrefix      string  `json:"postal_prefix"`
+	Province          string  `json:"province"`
+	PercentOfMaterial float64 `json:"percent_of_material" validate:"min=0,max=100"`
+	MinimumPerLine    float64 `json:"minimum_per_line" validate:"min=0"`
+}
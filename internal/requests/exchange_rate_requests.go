@@ -0,0 +1,6 @@
+package requests
+
+type SetExchangeRateRequest struct {
+	Rate     float64 `json:"rate" validate:"required,gt=0"`
+	RateDate string  `json:"rate_date"` // YYYY-MM-DD, defaults to today
+}
@@ -0,0 +1,21 @@
+package requests
+
+// FinalAccountRequest prepares a project's final account. RetentionPercent
+// defaults to the configured retention when left out. BackCharges replace
+// the previous list on update.
+type FinalAccountRequest struct {
+	RetentionPercent *float64                        `json:"retention_percent" validate:"omitempty,gte=0,lte=100"`
+	Note             string                          `json:"note"`
+	BackCharges      []FinalAccountBackChargeRequest `json:"back_charges" validate:"dive"`
+}
+
+type FinalAccountBackChargeRequest struct {
+	Description string  `json:"description" validate:"required"`
+	Amount      float64 `json:"amount" validate:"gt=0"`
+}
+
+// AgreeFinalAccountRequest records the client's representative agreeing
+// the final account.
+type AgreeFinalAccountRequest struct {
+	AgreedBy string `json:"agreed_by" validate:"required"`
+}
@@ -0,0 +1,19 @@
+package requests
+
+import "github.com/google/uuid"
+
+type FixedAssetRequest struct {
+	Name string `json:"name" validate:"required"`
+	// MaterialID links the asset to a material classified as an asset.
+	MaterialID       string     `json:"material_id"`
+	SerialNumber     string     `json:"serial_number"`
+	ProjectID        *uuid.UUID `json:"project_id"`
+	PurchaseDate     string     `json:"purchase_date" validate:"required"` // YYYY-MM-DD
+	PurchaseCost     float64    `json:"purchase_cost" validate:"gt=0"`
+	SalvageValue     float64    `json:"salvage_value" validate:"gte=0"`
+	UsefulLifeMonths int        `json:"useful_life_months" validate:"gt=0"`
+}
+
+type DisposeFixedAssetRequest struct {
+	DisposedAt string `json:"disposed_at"` // YYYY-MM-DD, defaults to today
+}
@@ -0,0 +1,32 @@
+package requests
+
+import "github.com/google/uuid"
+
+// HandoverRequest prepares a project handover. Defects from failed
+// inspections are compiled automatically; Defects adds any found during
+// the walkthrough. Attachments replace the previous list on update.
+type HandoverRequest struct {
+	CompletionDate string                      `json:"completion_date" validate:"required"`
+	WarrantyMonths int                         `json:"warranty_months" validate:"min=0"`
+	WarrantyTerms  string                      `json:"warranty_terms"`
+	Defects        []HandoverDefectRequest     `json:"defects" validate:"dive"`
+	Attachments    []HandoverAttachmentRequest `json:"attachments" validate:"dive"`
+	// ProjectManagerID is recorded on the satisfaction survey sent when the
+	// handover is created, for the per-manager survey report.
+	ProjectManagerID *uuid.UUID `json:"project_manager_id"`
+}
+
+type HandoverDefectRequest struct {
+	Location    string `json:"location"`
+	Description string `json:"description" validate:"required"`
+	Note        string `json:"note"`
+}
+
+type HandoverAttachmentRequest struct {
+	Name    string `json:"name" validate:"required"`
+	FileURL string `json:"file_url" validate:"required,url"`
+}
+
+type AcknowledgeHandoverRequest struct {
+	Name string `json:"name" validate:"required"`
+}
@@ -0,0 +1,35 @@
+package requests
+
+import "github.com/google/uuid"
+
+type CreateInspectionTemplateRequest struct {
+	JobID     uuid.UUID `json:"job_id" validate:"required"`
+	Name      string    `json:"name" validate:"required"`
+	Mandatory bool      `json:"mandatory"`
+	Items     []string  `json:"items" validate:"required,min=1"`
+}
+
+type UpdateInspectionTemplateRequest struct {
+	Name      string `json:"name" validate:"required"`
+	Mandatory bool   `json:"mandatory"`
+}
+
+type CreateInspectionRequest struct {
+	TemplateID uuid.UUID `json:"template_id" validate:"required"`
+}
+
+// RecordInspectionRequest fills in a checklist. Items left out keep their
+// previous result; photos are added, never replaced.
+type RecordInspectionRequest struct {
+	InspectedBy string                        `json:"inspected_by" validate:"required"`
+	Notes       string                        `json:"notes"`
+	Items       []InspectionItemResultRequest `json:"items" validate:"required,dive"`
+	PhotoURLs   []string                      `json:"photo_urls"`
+}
+
+type InspectionItemResultRequest struct {
+	ItemID    uuid.UUID `json:"item_id" validate:"required"`
+	Passed    *bool     `json:"passed"`
+	Note      string    `json:"note"`
+	PhotoURLs []string  `json:"photo_urls"`
+}
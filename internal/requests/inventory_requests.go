@@ -0,0 +1,35 @@
+package requests
+
+import "github.com/google/uuid"
+
+type CreateWarehouseRequest struct {
+	Name string `json:"name" validate:"required"`
+	// Kind is "main" or "site"; site stores need a project.
+	Kind      string     `json:"kind" validate:"required,oneof=main site"`
+	ProjectID *uuid.UUID `json:"project_id"`
+}
+
+type StockLineRequest struct {
+	MaterialID string  `json:"material_id" validate:"required"`
+	Quantity   float64 `json:"quantity" validate:"gt=0"`
+}
+
+type StockMovementRequest struct {
+	Lines     []StockLineRequest `json:"lines" validate:"required,dive"`
+	ProjectID *uuid.UUID         `json:"project_id"`
+	Reference string             `json:"reference"`
+	Note      string             `json:"note"`
+}
+
+type StockTransferRequest struct {
+	FromWarehouseID uuid.UUID          `json:"from_warehouse_id" validate:"required"`
+	ToWarehouseID   uuid.UUID          `json:"to_warehouse_id" validate:"required"`
+	Lines           []StockLineRequest `json:"lines" validate:"required,dive"`
+	Reference       string             `json:"reference"`
+	Note            string             `json:"note"`
+}
+
+type CloseWarehouseRequest struct {
+	// TargetWarehouseID defaults to the only active main warehouse.
+	TargetWarehouseID *uuid.UUID `json:"target_warehouse_id"`
+}
@@ -5,7 +5,12 @@ import (
 )
 
 type CreateInvoiceRequest struct {
-	FileURL string `json:"file_url" validate:"required,url"`
+	FileURL string     `json:"file_url" validate:"required,url"`
+	Amount  float64    `json:"amount" validate:"gte=0"`
+	PhaseID *uuid.UUID `json:"phase_id"`
+	// IncludeLateInterest adds the project's unbilled late payment interest
+	// to Amount.
+	IncludeLateInterest bool `json:"include_late_interest"`
 }
 
 type DeleteInvoiceRequest struct {
@@ -0,0 +1,9 @@
+package requests
+
+// SetKPITargetRequest creates or replaces the target for a metric in a
+// month ("YYYY-MM"). Margin and utilization are percentages.
+type SetKPITargetRequest struct {
+	Metric string  `json:"metric" validate:"required,oneof=sales margin utilization"`
+	Month  string  `json:"month" validate:"required"`
+	Value  float64 `json:"value" validate:"min=0"`
+}
@@ -0,0 +1,7 @@
+package requests
+
+type UpdateLatePaymentTermsRequest struct {
+	AnnualRatePercent float64 `json:"annual_rate_percent" validate:"gte=0,lte=100"`
+	PaymentDays       int     `json:"payment_days" validate:"gte=0"`
+	GraceDays         int     `json:"grace_days" validate:"gte=0"`
+}
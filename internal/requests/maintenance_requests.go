@@ -0,0 +1,6 @@
+package requests
+
+type MaintenanceModeRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
@@ -5,11 +5,15 @@ import "github.com/google/uuid"
 type CreateMaterialRequest struct {
 	Name string `json:"name" validate:"required"`
 	Unit string `json:"unit" validate:"required"`
+	// Classification is "consumable" (the default) or "asset".
+	Classification string `json:"classification" validate:"omitempty,oneof=consumable asset"`
 }
 
 type UpdateMaterialRequest struct {
 	Name string `json:"name" validate:"required"`
 	Unit string `json:"unit" validate:"required"`
+	// Classification keeps the current value when empty.
+	Classification string `json:"classification" validate:"omitempty,oneof=consumable asset"`
 }
 
 type UpdateMaterialEstimatedPriceRequest struct {
@@ -22,3 +26,27 @@ type UpdateMaterialActualPriceRequest struct {
 	ActualPrice float64   `json:"actual_price" validate:"required,gt=0"`
 	SupplierID  uuid.UUID `json:"supplier_id" validate:"required"`
 }
+
+type MergeMaterialRequest struct {
+	SourceMaterialID string `json:"source_material_id" validate:"required"`
+	TargetMaterialID string `json:"target_material_id" validate:"required"`
+}
+
+// CreateMaterialListPriceRequest sets the material's price from
+// EffectiveFrom (YYYY-MM-DD), which may be in the future.
+type CreateMaterialListPriceRequest struct {
+	Price         float64 `json:"price" validate:"required,gt=0"`
+	EffectiveFrom string  `json:"effective_from" validate:"required"`
+	Note          string  `json:"note"`
+}
+
+// CreateMaterialAliasRequest adds another name for a material. Names take
+// an optional language (th or en); supplier codes need the supplier.
+// Display makes a name the one documents in its language print.
+type CreateMaterialAliasRequest struct {
+	Kind       string     `json:"kind" validate:"required"`
+	Name       string     `json:"name" validate:"required"`
+	Language   string     `json:"language"`
+	SupplierID *uuid.UUID `json:"supplier_id"`
+	Display    bool       `json:"display"`
+}
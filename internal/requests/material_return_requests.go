@@ -0,0 +1,33 @@
+package requests
+
+import "github.com/google/uuid"
+
+// CreateMaterialReturnRequest sends received materials on a purchase order
+// back to its supplier. Reason is "rejected" or "surplus"; ReturnDate is
+// YYYY-MM-DD and defaults to today. With a WarehouseID the materials are
+// taken out of that warehouse's stock.
+type CreateMaterialReturnRequest struct {
+	ReturnNumber string                      `json:"return_number" validate:"required"`
+	POID         uuid.UUID                   `json:"po_id" validate:"required"`
+	Reason       string                      `json:"reason" validate:"required"`
+	WarehouseID  *uuid.UUID                  `json:"warehouse_id"`
+	ReturnDate   string                      `json:"return_date"`
+	Note         string                      `json:"note"`
+	Lines        []MaterialReturnLineRequest `json:"lines" validate:"required,min=1"`
+}
+
+// MaterialReturnLineRequest returns part of an allocation, optionally
+// naming the delivery it came from.
+type MaterialReturnLineRequest struct {
+	AllocationID uuid.UUID  `json:"allocation_id" validate:"required"`
+	ReceiptID    *uuid.UUID `json:"receipt_id"`
+	Quantity     float64    `json:"quantity" validate:"gt=0"`
+}
+
+// RecordCreditNoteRequest records the supplier's credit note for a return.
+// Amount defaults to the expected credit and Date to today.
+type RecordCreditNoteRequest struct {
+	CreditNoteNumber string   `json:"credit_note_number" validate:"required"`
+	Amount           *float64 `json:"amount"`
+	Date             string   `json:"date"`
+}
@@ -0,0 +1,14 @@
+package requests
+
+type NotificationRuleEntry struct {
+	Event   string `json:"event" validate:"required"`
+	Channel string `json:"channel" validate:"required"`
+	Role    string `json:"role" validate:"required"`
+	Enabled bool   `json:"enabled"`
+}
+
+// UpdateNotificationMatrixRequest saves the given rules; rules not listed
+// are left as they are.
+type UpdateNotificationMatrixRequest struct {
+	Rules []NotificationRuleEntry `json:"rules" validate:"required,dive"`
+}
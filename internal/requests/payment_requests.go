@@ -0,0 +1,14 @@
+package requests
+
+import (
+	"boonkosang/internal/domain/models"
+	"time"
+)
+
+type RecordPaymentRequest struct {
+	Amount    float64              `json:"amount" validate:"required,gt=0"`
+	Method    models.PaymentMethod `json:"method" validate:"required,oneof=cash bank_transfer cheque promptpay credit_card"`
+	Reference string               `json:"reference"`
+	Note      string               `json:"note"`
+	PaidAt    *time.Time           `json:"paid_at"`
+}
@@ -0,0 +1,26 @@
+package requests
+
+// OmiseWebhookEvent is the subset of an Omise event we act on.
+// See https://docs.opn.ooo/api-webhooks
+type OmiseWebhookEvent struct {
+	Key  string      `json:"key"`
+	Data OmiseCharge `json:"data"`
+}
+
+type OmiseCharge struct {
+	Object   string            `json:"object"`
+	ID       string            `json:"id"`
+	Amount   int64             `json:"amount"`
+	Currency string            `json:"currency"`
+	Status   string            `json:"status"`
+	Metadata map[string]string `json:"metadata"`
+	Source   *struct {
+		Type string `json:"type"`
+	} `json:"source"`
+}
+
+// TwoC2PWebhookRequest wraps the signed JWT sent by the 2C2P backend
+// notification.
+type TwoC2PWebhookRequest struct {
+	Payload string `json:"payload"`
+}
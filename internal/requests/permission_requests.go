@@ -0,0 +1,19 @@
+package requests
+
+type PermissionEntry struct {
+	Resource string `json:"resource" validate:"required"`
+	Action   string `json:"action" validate:"required"`
+	Allowed  bool   `json:"allowed"`
+}
+
+type UpdateRolePermissionsRequest struct {
+	Permissions []PermissionEntry `json:"permissions" validate:"required,dive"`
+}
+
+type SaveRoutePolicyRequest struct {
+	Method   string `json:"method" validate:"required"`
+	Path     string `json:"path" validate:"required"`
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	Enabled  bool   `json:"enabled"`
+}
@@ -0,0 +1,19 @@
+package requests
+
+import "github.com/google/uuid"
+
+type CreatePriceIndexRequest struct {
+	Code        string `json:"code" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	Unit        string `json:"unit"`
+	Description string `json:"description"`
+}
+
+type AddPriceIndexValueRequest struct {
+	PeriodDate string  `json:"period_date" validate:"required"`
+	Value      float64 `json:"value" validate:"required,gt=0"`
+}
+
+type LinkMaterialPriceIndexRequest struct {
+	IndexID uuid.UUID `json:"index_id" validate:"required"`
+}
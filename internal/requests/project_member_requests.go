@@ -0,0 +1,7 @@
+package requests
+
+import "github.com/google/uuid"
+
+type AddProjectMemberRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
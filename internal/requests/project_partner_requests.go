@@ -0,0 +1,12 @@
+package requests
+
+type ProjectPartnerRequest struct {
+	Name         string  `json:"name" validate:"required"`
+	TaxID        string  `json:"tax_id"`
+	SharePercent float64 `json:"share_percent" validate:"gt=0,lte=100"`
+	IsSelf       bool    `json:"is_self"`
+}
+
+type UpdateProjectPartnersRequest struct {
+	Partners []ProjectPartnerRequest `json:"partners" validate:"dive"`
+}
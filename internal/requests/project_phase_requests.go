@@ -0,0 +1,19 @@
+package requests
+
+import "github.com/google/uuid"
+
+type CreateProjectPhaseRequest struct {
+	Name      string `json:"name" validate:"required"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type UpdateProjectPhaseRequest struct {
+	Name      string `json:"name" validate:"required"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// AssignJobPhaseRequest moves a BOQ job into a phase; a null phase_id
+// clears the assignment.
+type AssignJobPhaseRequest struct {
+	PhaseID *uuid.UUID `json:"phase_id"`
+}
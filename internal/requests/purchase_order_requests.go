@@ -0,0 +1,46 @@
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// CreatePurchaseOrderRequest orders materials from one supplier for one or
+// more projects. OrderDate is YYYY-MM-DD and defaults to today. With an
+// AgreementID the order is released against that blanket agreement: the
+// supplier and unit prices default to the agreement's.
+type CreatePurchaseOrderRequest struct {
+	PONumber    string                     `json:"po_number" validate:"required"`
+	SupplierID  uuid.UUID                  `json:"supplier_id"`
+	AgreementID *uuid.UUID                 `json:"agreement_id"`
+	OrderDate   string                     `json:"order_date"`
+	Note        string                     `json:"note"`
+	Lines       []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1"`
+}
+
+type PurchaseOrderLineRequest struct {
+	MaterialID  string                           `json:"material_id" validate:"required"`
+	UnitPrice   float64                          `json:"unit_price" validate:"gte=0"`
+	Allocations []PurchaseOrderAllocationRequest `json:"allocations" validate:"required,min=1"`
+}
+
+// PurchaseOrderAllocationRequest is the quantity of a line bought for a
+// project. DeliveryAddress defaults to the project's address.
+type PurchaseOrderAllocationRequest struct {
+	ProjectID       uuid.UUID       `json:"project_id" validate:"required"`
+	Quantity        float64         `json:"quantity" validate:"gt=0"`
+	DeliveryAddress json.RawMessage `json:"delivery_address"`
+}
+
+// ReceivePurchaseOrderRequest records goods delivered. ReceivedDate is
+// YYYY-MM-DD and defaults to today.
+type ReceivePurchaseOrderRequest struct {
+	ReceivedDate string                     `json:"received_date"`
+	Items        []ReceivePurchaseOrderItem `json:"items" validate:"required,min=1"`
+}
+
+type ReceivePurchaseOrderItem struct {
+	AllocationID uuid.UUID `json:"allocation_id" validate:"required"`
+	Quantity     float64   `json:"quantity" validate:"gt=0"`
+}
@@ -9,7 +9,17 @@ type UpdateProjectSellingPriceRequest struct {
 	JobSellingPrices   []JobSellingPrice `json:"job_selling_prices" validate:"required,dive"`
 }
 
+// JobSellingPrice prices one quotation line. TaxTreatment is standard,
+// zero_rated or exempt; when empty the line keeps its current treatment.
 type JobSellingPrice struct {
 	JobID        uuid.UUID `json:"job_id" validate:"required"`
 	SellingPrice float64   `json:"selling_price" validate:"required,gt=0"`
+	TaxTreatment string    `json:"tax_treatment" validate:"omitempty,oneof=standard zero_rated exempt"`
+}
+
+// ApproveQuotationRequest is optional; it's only needed when an owner
+// approves a quotation for a client on credit hold.
+type ApproveQuotationRequest struct {
+	UserID             *uuid.UUID `json:"user_id"`
+	OverrideCreditHold bool       `json:"override_credit_hold"`
 }
@@ -0,0 +1,8 @@
+package requests
+
+type QuotationNegotiationRoundRequest struct {
+	CounterOffer     float64 `json:"counter_offer" validate:"required,gt=0"`
+	OfferedOn        string  `json:"offered_on" validate:"required"` // YYYY-MM-DD
+	ClientNotes      string  `json:"client_notes"`
+	InternalResponse string  `json:"internal_response"`
+}
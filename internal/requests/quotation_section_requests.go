@@ -0,0 +1,22 @@
+package requests
+
+import "github.com/google/uuid"
+
+// QuotationSectionRequest sets one section. When SnippetID is set and
+// Content is empty the snippet's text is used; empty content with no
+// snippet removes the section.
+type QuotationSectionRequest struct {
+	Kind      string     `json:"kind" validate:"required,oneof=cover_letter scope_of_work exclusions assumptions"`
+	Content   string     `json:"content"`
+	SnippetID *uuid.UUID `json:"snippet_id"`
+}
+
+type UpdateQuotationSectionsRequest struct {
+	Sections []QuotationSectionRequest `json:"sections" validate:"required,dive"`
+}
+
+type QuotationSnippetRequest struct {
+	Kind    string `json:"kind" validate:"required,oneof=cover_letter scope_of_work exclusions assumptions"`
+	Title   string `json:"title" validate:"required"`
+	Content string `json:"content" validate:"required"`
+}
@@ -0,0 +1,12 @@
+package requests
+
+// RecurringGeneralCostRequest sets up a monthly general cost. Periods are
+// months as YYYY-MM; without an end period the cost runs until the project
+// closes.
+type RecurringGeneralCostRequest struct {
+	TypeName      string  `json:"type_name" validate:"required"`
+	Description   string  `json:"description"`
+	MonthlyAmount float64 `json:"monthly_amount" validate:"required,gt=0"`
+	StartPeriod   string  `json:"start_period" validate:"required"`
+	EndPeriod     *string `json:"end_period"`
+}
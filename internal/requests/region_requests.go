@@ -0,0 +1,17 @@
+package requests
+
+import "github.com/google/uuid"
+
+type RegionPresetRequest struct {
+	Code                 string  `json:"code" validate:"required"`
+	Name                 string  `json:"name" validate:"required"`
+	DailyLaborRate       float64 `json:"daily_labor_rate" validate:"gt=0"`
+	LaborRateFactor      float64 `json:"labor_rate_factor" validate:"gt=0"`
+	TransportCostPercent float64 `json:"transport_cost_percent" validate:"min=0,max=100"`
+}
+
+// SetProjectRegionRequest selects a preset for a project; a nil RegionID
+// clears it.
+type SetProjectRegionRequest struct {
+	RegionID *uuid.UUID `json:"region_id"`
+}
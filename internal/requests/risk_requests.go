@@ -0,0 +1,18 @@
+package requests
+
+type CreateRiskRequest struct {
+	Description string `json:"description" validate:"required"`
+	Probability int    `json:"probability" validate:"required,min=1,max=5"`
+	Impact      int    `json:"impact" validate:"required,min=1,max=5"`
+	Mitigation  string `json:"mitigation"`
+	Owner       string `json:"owner"`
+}
+
+type UpdateRiskRequest struct {
+	Description string `json:"description" validate:"required"`
+	Probability int    `json:"probability" validate:"required,min=1,max=5"`
+	Impact      int    `json:"impact" validate:"required,min=1,max=5"`
+	Mitigation  string `json:"mitigation"`
+	Owner       string `json:"owner"`
+	Status      string `json:"status" validate:"required,oneof=open mitigating closed"`
+}
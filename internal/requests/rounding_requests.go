@@ -0,0 +1,6 @@
+package requests
+
+type RoundingPolicyRequest struct {
+	Mode      string  `json:"mode" validate:"required,oneof=line total"`
+	Increment float64 `json:"increment" validate:"required"`
+}
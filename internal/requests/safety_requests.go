@@ -0,0 +1,22 @@
+package requests
+
+type CreateSafetyIncidentRequest struct {
+	Type          string `json:"type" validate:"required,oneof=incident near_miss"`
+	Severity      string `json:"severity" validate:"required,oneof=minor moderate serious fatal"`
+	OccurredAt    string `json:"occurred_at" validate:"required"` // RFC 3339 or YYYY-MM-DD
+	Location      string `json:"location"`
+	Description   string `json:"description" validate:"required"`
+	InjuredName   string `json:"injured_name"`
+	InjuredRole   string `json:"injured_role"`
+	InjuryDetails string `json:"injury_details"`
+	LostDays      int    `json:"lost_days" validate:"gte=0"`
+	ReportedBy    string `json:"reported_by"`
+}
+
+type UpdateSafetyIncidentRequest CreateSafetyIncidentRequest
+
+type CreateCorrectiveActionRequest struct {
+	Description string `json:"description" validate:"required"`
+	Owner       string `json:"owner"`
+	DueDate     string `json:"due_date"` // YYYY-MM-DD
+}
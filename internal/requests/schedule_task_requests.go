@@ -0,0 +1,36 @@
+package requests
+
+import "github.com/google/uuid"
+
+type CreateScheduleTaskRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	PhaseID   *uuid.UUID `json:"phase_id"`
+	CrewID    *uuid.UUID `json:"crew_id"`
+	StartDate string     `json:"start_date" validate:"required"` // YYYY-MM-DD
+	EndDate   string     `json:"end_date" validate:"required"`   // YYYY-MM-DD
+	Workers   int        `json:"workers" validate:"required,gt=0"`
+	Outdoor   *bool      `json:"outdoor"` // inferred from the name when omitted
+}
+
+type UpdateScheduleTaskRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	PhaseID   *uuid.UUID `json:"phase_id"`
+	CrewID    *uuid.UUID `json:"crew_id"`
+	StartDate string     `json:"start_date" validate:"required"`
+	EndDate   string     `json:"end_date" validate:"required"`
+	Workers   int        `json:"workers" validate:"required,gt=0"`
+	Outdoor   *bool      `json:"outdoor"` // inferred from the name when omitted
+}
+
+// AssignCrewRequest assigns a crew to a task; a null crew_id unassigns it.
+type AssignCrewRequest struct {
+	CrewID *uuid.UUID `json:"crew_id"`
+}
+
+// ScheduleWhatIfRequest describes a productivity scenario as percentage
+// changes, e.g. crew_size_percent -20 for a fifth fewer workers.
+type ScheduleWhatIfRequest struct {
+	CrewSizePercent     float64  `json:"crew_size_percent"`
+	ProductivityPercent float64  `json:"productivity_percent"`
+	DailyLaborRate      *float64 `json:"daily_labor_rate"` // defaults to the project region's rate
+}
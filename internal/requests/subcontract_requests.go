@@ -0,0 +1,71 @@
+package requests
+
+import "github.com/google/uuid"
+
+type CreateSubcontractRequest struct {
+	ProjectID        uuid.UUID `json:"project_id" validate:"required"`
+	SupplierID       uuid.UUID `json:"supplier_id" validate:"required"`
+	Scope            string    `json:"scope" validate:"required"`
+	ContractValue    float64   `json:"contract_value" validate:"gt=0"`
+	RetentionPercent float64   `json:"retention_percent" validate:"gte=0,lt=100"`
+	DLPMonths        int       `json:"dlp_months" validate:"gte=0"`
+	// AdvanceAmount is paid up front and recovered at
+	// AdvanceRecoveryPercent of each certificate.
+	AdvanceAmount          float64 `json:"advance_amount" validate:"gte=0"`
+	AdvanceRecoveryPercent float64 `json:"advance_recovery_percent" validate:"gte=0,lte=100"`
+}
+
+type CompleteSubcontractRequest struct {
+	CompletedAt string `json:"completed_at"` // YYYY-MM-DD, defaults to today
+}
+
+type CreateSubcontractCertificateRequest struct {
+	GrossAmount float64 `json:"gross_amount" validate:"gt=0"`
+	Note        string  `json:"note"`
+}
+
+type RequestRetentionReleaseRequest struct {
+	// Amount defaults to all retention still held.
+	Amount float64 `json:"amount" validate:"gte=0"`
+	Note   string  `json:"note"`
+}
+
+type SubcontractItemRequest struct {
+	Description string  `json:"description" validate:"required"`
+	Unit        string  `json:"unit" validate:"required"`
+	Quantity    float64 `json:"quantity" validate:"gt=0"`
+	Rate        float64 `json:"rate" validate:"gte=0"`
+}
+
+type UpdateSubcontractItemsRequest struct {
+	Items []SubcontractItemRequest `json:"items" validate:"dive"`
+}
+
+type SubcontractClaimLineRequest struct {
+	ItemID   uuid.UUID `json:"item_id" validate:"required"`
+	Quantity float64   `json:"quantity" validate:"gt=0"`
+}
+
+type SubmitSubcontractClaimRequest struct {
+	// ClaimantName is who at the subcontractor made the claim, when it is
+	// entered on their behalf.
+	ClaimantName string                        `json:"claimant_name"`
+	Note         string                        `json:"note"`
+	Lines        []SubcontractClaimLineRequest `json:"lines" validate:"required,dive"`
+}
+
+type CertifyClaimLineRequest struct {
+	ItemID            uuid.UUID `json:"item_id" validate:"required"`
+	CertifiedQuantity float64   `json:"certified_quantity" validate:"gte=0"`
+}
+
+type CertifySubcontractClaimRequest struct {
+	// Lines adjust claimed quantities; lines left out are certified as
+	// claimed.
+	Lines []CertifyClaimLineRequest `json:"lines" validate:"dive"`
+	Note  string                    `json:"note"`
+}
+
+type RejectSubcontractClaimRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
@@ -0,0 +1,27 @@
+package requests
+
+import "github.com/google/uuid"
+
+// SupplierCreditTermsRequest sets how long we have to pay a supplier.
+// Basis is "invoice" or "receipt" (the goods receipt date).
+type SupplierCreditTermsRequest struct {
+	CreditDays int    `json:"credit_days"`
+	Basis      string `json:"basis" validate:"required"`
+	EndOfMonth bool   `json:"end_of_month"`
+}
+
+// CreateSupplierPayableRequest records a supplier's bill. Dates are
+// YYYY-MM-DD; ReceivedDate is when the goods were received.
+type CreateSupplierPayableRequest struct {
+	ProjectID     *uuid.UUID `json:"project_id"`
+	InvoiceNumber string     `json:"invoice_number" validate:"required"`
+	InvoiceDate   string     `json:"invoice_date" validate:"required"`
+	ReceivedDate  *string    `json:"received_date"`
+	Amount        float64    `json:"amount" validate:"required,gt=0"`
+}
+
+// MarkSupplierPayablePaidRequest records a payment; PaidDate defaults to
+// today.
+type MarkSupplierPayablePaidRequest struct {
+	PaidDate *string `json:"paid_date"`
+}
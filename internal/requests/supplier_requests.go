@@ -15,3 +15,8 @@ type UpdateSupplierRequest struct {
 	Tel     string          `json:"tel" validate:"required"`
 	Address json.RawMessage `json:"address" validate:"required"`
 }
+
+type UpdateSupplierStatusRequest struct {
+	Status string `json:"status" validate:"required"`
+	Reason string `json:"reason"`
+}
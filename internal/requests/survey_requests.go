@@ -0,0 +1,35 @@
+package requests
+
+import "github.com/google/uuid"
+
+type SurveyQuestionRequest struct {
+	Key      string `json:"key" validate:"required"`
+	Text     string `json:"text" validate:"required"`
+	Type     string `json:"type" validate:"required,oneof=nps rating text"`
+	Required bool   `json:"required"`
+}
+
+// CreateSurveyTemplateRequest adds a questionnaire. IsDefault makes it the
+// template sent automatically at handover.
+type CreateSurveyTemplateRequest struct {
+	Name      string                  `json:"name" validate:"required"`
+	Questions []SurveyQuestionRequest `json:"questions" validate:"required,min=1,dive"`
+	IsDefault bool                    `json:"is_default"`
+}
+
+// SendSurveyRequest sends a survey for a handed-over project. TemplateID
+// defaults to the default template.
+type SendSurveyRequest struct {
+	TemplateID       *uuid.UUID `json:"template_id"`
+	ProjectManagerID *uuid.UUID `json:"project_manager_id"`
+}
+
+type SurveyAnswerRequest struct {
+	Key   string `json:"key" validate:"required"`
+	Score *int   `json:"score"`
+	Text  string `json:"text"`
+}
+
+type SubmitSurveyRequest struct {
+	Answers []SurveyAnswerRequest `json:"answers" validate:"dive"`
+}
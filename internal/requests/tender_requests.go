@@ -0,0 +1,42 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TenderRequest struct {
+	ProjectID          *uuid.UUID `json:"project_id"`
+	Title              string     `json:"title" validate:"required"`
+	Agency             string     `json:"agency" validate:"required"`
+	ReferenceNumber    string     `json:"reference_number"`
+	SubmissionDeadline time.Time  `json:"submission_deadline" validate:"required"`
+	BondAmount         float64    `json:"bond_amount" validate:"gte=0"`
+}
+
+type TenderDocumentRequest struct {
+	Name      string `json:"name" validate:"required"`
+	Required  bool   `json:"required"`
+	Completed bool   `json:"completed"`
+	FileURL   string `json:"file_url"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type TenderBidLineRequest struct {
+	Description string  `json:"description" validate:"required"`
+	Unit        string  `json:"unit" validate:"required"`
+	Quantity    float64 `json:"quantity" validate:"gt=0"`
+	UnitPrice   float64 `json:"unit_price" validate:"gte=0"`
+}
+
+type TenderWorksheetRequest struct {
+	Lines []TenderBidLineRequest `json:"lines" validate:"dive"`
+}
+
+type TenderOutcomeRequest struct {
+	Status        string   `json:"status" validate:"required,oneof=won lost cancelled"`
+	WinningBidder string   `json:"winning_bidder"`
+	WinningAmount *float64 `json:"winning_amount"`
+	Notes         string   `json:"notes"`
+}
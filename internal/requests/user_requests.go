@@ -1,10 +1,18 @@
 package requests
 
+import "time"
+
 type LoginRequest struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
 
+// GoogleLoginRequest carries the ID token the client got from Google
+// Sign-In.
+type GoogleLoginRequest struct {
+	IDToken string `json:"id_token" validate:"required"`
+}
+
 type RegisterRequest struct {
 	Username  string `json:"username" validate:"required"`
 	Password  string `json:"password" validate:"required,min=6"`
@@ -13,3 +21,84 @@ type RegisterRequest struct {
 	Email     string `json:"email" validate:"required,email"`
 	Tel       string `json:"tel" validate:"required,len=10"`
 }
+
+// UpdateProfileRequest replaces the caller's own profile details. The
+// username, email and role can't be changed here; an empty Tel clears it.
+type UpdateProfileRequest struct {
+	FirstName string `json:"first_name" validate:"required"`
+	LastName  string `json:"last_name" validate:"required"`
+	Tel       string `json:"tel"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// ListUsersRequest filters the admin user list. Status is "active" or
+// "inactive"; empty fields match every user.
+type ListUsersRequest struct {
+	Search   string
+	Role     string
+	Status   string
+	Page     int
+	PageSize int
+}
+
+// ResetPasswordRequest is an admin setting a new password for a user who
+// can't sign in.
+type ResetPasswordRequest struct {
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// InviteUserRequest creates a pending user who sets their own password
+// from the emailed link. Role defaults to staff.
+type InviteUserRequest struct {
+	Username  string `json:"username" validate:"required"`
+	FirstName string `json:"first_name" validate:"required"`
+	LastName  string `json:"last_name" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Tel       string `json:"tel"`
+	Role      string `json:"role"`
+}
+
+// ChangeEmailRequest asks to move the caller's account to NewEmail. The
+// current password is required so a borrowed session can't take over the
+// account's address.
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"new_email" validate:"required"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type ImpersonateRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// LogoutRequest optionally names the session's refresh token so it is
+// revoked along with the access token.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,19 @@
+package requests
+
+import "github.com/google/uuid"
+
+// RecordWasteRequest scraps material on a project. WarehouseID defaults to
+// the project's site store; RecordedDate is YYYY-MM-DD and defaults to
+// today.
+type RecordWasteRequest struct {
+	MaterialID   string     `json:"material_id" validate:"required"`
+	Quantity     float64    `json:"quantity" validate:"gt=0"`
+	Reason       string     `json:"reason" validate:"required"`
+	WarehouseID  *uuid.UUID `json:"warehouse_id"`
+	RecordedDate string     `json:"recorded_date"`
+	Note         string     `json:"note"`
+}
+
+type SetWastageAllowanceRequest struct {
+	Percent float64 `json:"percent" validate:"gte=0,lte=100"`
+}
@@ -0,0 +1,22 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountingPeriodLockResponse struct {
+	Period   string    `json:"period"`
+	LockedAt time.Time `json:"locked_at"`
+	LockedBy uuid.UUID `json:"locked_by"`
+}
+
+type AccountingPeriodEventResponse struct {
+	EventID    uuid.UUID `json:"event_id"`
+	Period     string    `json:"period"`
+	Action     string    `json:"action"`
+	UserID     uuid.UUID `json:"user_id"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
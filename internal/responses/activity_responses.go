@@ -0,0 +1,42 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimelineEventResponse struct {
+	EntityType  string     `json:"entity_type"`
+	EntityID    uuid.UUID  `json:"entity_id"`
+	ProjectID   *uuid.UUID `json:"project_id,omitempty"`
+	EventType   string     `json:"event_type"`
+	Description string     `json:"description"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+}
+
+type TimelineResponse struct {
+	Events []TimelineEventResponse `json:"events"`
+}
+
+// UserActivityResponse is one page of the events a user caused, newest
+// first.
+type UserActivityResponse struct {
+	UserID   uuid.UUID               `json:"user_id"`
+	Events   []UserActivityEventItem `json:"events"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
+type UserActivityEventItem struct {
+	EventID        uuid.UUID  `json:"event_id"`
+	EntityType     string     `json:"entity_type"`
+	EntityID       uuid.UUID  `json:"entity_id"`
+	ProjectID      *uuid.UUID `json:"project_id,omitempty"`
+	ClientID       *uuid.UUID `json:"client_id,omitempty"`
+	EventType      string     `json:"event_type"`
+	Description    string     `json:"description"`
+	OccurredAt     time.Time  `json:"occurred_at"`
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
+}
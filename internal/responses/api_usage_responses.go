@@ -0,0 +1,25 @@
+package responses
+
+import "github.com/google/uuid"
+
+// APIUsageResponse is one row of the usage report. Only the fields the
+// report is grouped by are set.
+type APIUsageResponse struct {
+	Date         string     `json:"date,omitempty"`
+	UserID       *uuid.UUID `json:"user_id,omitempty"`
+	KeyID        *uuid.UUID `json:"key_id,omitempty"`
+	Route        string     `json:"route,omitempty"`
+	Requests     int64      `json:"requests"`
+	ClientErrors int64      `json:"client_errors"`
+	ServerErrors int64      `json:"server_errors"`
+	ErrorRate    float64    `json:"error_rate"`
+	BytesIn      int64      `json:"bytes_in"`
+	BytesOut     int64      `json:"bytes_out"`
+}
+
+type APIUsageReportResponse struct {
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	GroupBy string             `json:"group_by"`
+	Rows    []APIUsageResponse `json:"rows"`
+}
@@ -0,0 +1,39 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AsBuiltLineResponse compares a BOQ job's as-built quantity with its BOQ
+// quantity. The as-built fields are empty until the quantity is recorded.
+type AsBuiltLineResponse struct {
+	BOQID             uuid.UUID  `json:"boq_id"`
+	JobID             uuid.UUID  `json:"job_id"`
+	JobName           string     `json:"job_name"`
+	Unit              string     `json:"unit"`
+	Completed         bool       `json:"completed"`
+	BOQQuantity       float64    `json:"boq_quantity"`
+	UnitPrice         float64    `json:"unit_price"`
+	AsBuiltQuantity   *float64   `json:"as_built_quantity"`
+	VariationQuantity float64    `json:"variation_quantity"`
+	VariationValue    float64    `json:"variation_value"`
+	Disposition       string     `json:"disposition,omitempty"`
+	ChangeOrderID     *uuid.UUID `json:"change_order_id,omitempty"`
+	Note              string     `json:"note,omitempty"`
+	RecordedAt        *time.Time `json:"recorded_at,omitempty"`
+}
+
+// AsBuiltReconciliationResponse totals a project's variations by what is
+// done with them.
+type AsBuiltReconciliationResponse struct {
+	ProjectID         uuid.UUID             `json:"project_id"`
+	BOQID             uuid.UUID             `json:"boq_id"`
+	Lines             []AsBuiltLineResponse `json:"lines"`
+	Recorded          int                   `json:"recorded"`
+	Outstanding       int                   `json:"outstanding"`
+	VariationTotal    float64               `json:"variation_total"`
+	ChangeOrderTotal  float64               `json:"change_order_total"`
+	FinalAccountTotal float64               `json:"final_account_total"`
+}
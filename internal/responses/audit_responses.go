@@ -0,0 +1,30 @@
+package responses
+
+import "time"
+
+type AuditSegmentResponse struct {
+	SegmentDate  string    `json:"segment_date"`
+	EventCount   int       `json:"event_count"`
+	EventsHash   string    `json:"events_hash"`
+	PreviousHash string    `json:"previous_hash"`
+	Hash         string    `json:"hash"`
+	ObjectKey    string    `json:"object_key"`
+	SealedAt     time.Time `json:"sealed_at"`
+}
+
+// AuditSegmentCheckResponse is the verification of one segment; Problems
+// says what didn't match when Valid is false.
+type AuditSegmentCheckResponse struct {
+	SegmentDate string   `json:"segment_date"`
+	EventCount  int      `json:"event_count"`
+	Hash        string   `json:"hash"`
+	Valid       bool     `json:"valid"`
+	Problems    []string `json:"problems"`
+}
+
+type AuditVerificationResponse struct {
+	From     string                      `json:"from"`
+	To       string                      `json:"to"`
+	Valid    bool                        `json:"valid"`
+	Segments []AuditSegmentCheckResponse `json:"segments"`
+}
@@ -0,0 +1,42 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BackChargeAttachmentResponse struct {
+	AttachmentID uuid.UUID `json:"attachment_id"`
+	Title        string    `json:"title"`
+	FileURL      string    `json:"file_url"`
+	UploadedBy   uuid.UUID `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type BackChargeOffsetResponse struct {
+	CertificateID uuid.UUID `json:"certificate_id"`
+	Amount        float64   `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type BackChargeResponse struct {
+	BackChargeID  uuid.UUID                      `json:"back_charge_id"`
+	SupplierID    uuid.UUID                      `json:"supplier_id"`
+	ProjectID     *uuid.UUID                     `json:"project_id,omitempty"`
+	SubcontractID *uuid.UUID                     `json:"subcontract_id,omitempty"`
+	Category      string                         `json:"category"`
+	Description   string                         `json:"description"`
+	Amount        float64                        `json:"amount"`
+	Deducted      float64                        `json:"deducted"`
+	Remaining     float64                        `json:"remaining"`
+	Status        string                         `json:"status"`
+	DisputeReason string                         `json:"dispute_reason,omitempty"`
+	DisputedBy    *uuid.UUID                     `json:"disputed_by,omitempty"`
+	Resolution    string                         `json:"resolution,omitempty"`
+	CreatedBy     uuid.UUID                      `json:"created_by"`
+	CreatedAt     time.Time                      `json:"created_at"`
+	UpdatedAt     time.Time                      `json:"updated_at"`
+	Attachments   []BackChargeAttachmentResponse `json:"attachments,omitempty"`
+	Offsets       []BackChargeOffsetResponse     `json:"offsets,omitempty"`
+}
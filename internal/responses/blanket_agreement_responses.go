@@ -0,0 +1,41 @@
+package responses
+
+import "github.com/google/uuid"
+
+// BlanketAgreementResponse is an agreement with its totals; Items and
+// ReleaseOrders are only included when a single agreement is fetched.
+type BlanketAgreementResponse struct {
+	AgreementID     uuid.UUID                      `json:"agreement_id"`
+	SupplierID      uuid.UUID                      `json:"supplier_id"`
+	SupplierName    string                         `json:"supplier_name"`
+	Reference       string                         `json:"reference"`
+	ValidFrom       string                         `json:"valid_from"`
+	ValidTo         string                         `json:"valid_to"`
+	Note            string                         `json:"note,omitempty"`
+	CommittedAmount float64                        `json:"committed_amount"`
+	ReleasedAmount  float64                        `json:"released_amount"`
+	RemainingAmount float64                        `json:"remaining_amount"`
+	Items           []BlanketAgreementItemResponse `json:"items,omitempty"`
+	ReleaseOrders   []PurchaseOrderResponse        `json:"release_orders,omitempty"`
+}
+
+type BlanketAgreementItemResponse struct {
+	ItemID            uuid.UUID `json:"item_id"`
+	MaterialID        string    `json:"material_id"`
+	UnitPrice         float64   `json:"unit_price"`
+	CommittedQuantity float64   `json:"committed_quantity"`
+	ReleasedQuantity  float64   `json:"released_quantity"`
+	RemainingQuantity float64   `json:"remaining_quantity"`
+}
+
+// BlanketAgreementAlertResponse is an agreement about to expire with
+// quantity still to be released.
+type BlanketAgreementAlertResponse struct {
+	BlanketAgreementResponse
+	DaysToExpiry int `json:"days_to_expiry"`
+}
+
+type BlanketAgreementAlertListResponse struct {
+	WithinDays int                             `json:"within_days"`
+	Alerts     []BlanketAgreementAlertResponse `json:"alerts"`
+}
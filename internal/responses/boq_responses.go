@@ -3,6 +3,7 @@ package responses
 import (
 	"boonkosang/internal/domain/models"
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -24,7 +25,11 @@ type BOQSummaryResponse struct {
 	ProjectInfo    ProjectInfo      `json:"project_info"`
 	GeneralCosts   []GeneralCostDTO `json:"general_costs"`
 	Details        []BOQDetailDTO   `json:"jobs"`
+	Phases         []BOQPhaseTotal  `json:"phases"`
 	SummaryMetrics SummaryMetrics   `json:"summary_metrics"`
+
+	// Region is the project's regional preset, nil when none is selected.
+	Region *RegionPresetResponse `json:"region"`
 }
 
 type ProjectInfo struct {
@@ -40,6 +45,8 @@ type GeneralCostDTO struct {
 type BOQDetailDTO struct {
 	JobID               uuid.UUID     `json:"job_id"`
 	JobName             string        `json:"job_name"`
+	PhaseID             *uuid.UUID    `json:"phase_id,omitempty"`
+	PhaseName           string        `json:"phase_name,omitempty"`
 	Description         string        `json:"description"`
 	Quantity            int           `json:"quantity"`
 	Unit                string        `json:"unit"`
@@ -51,6 +58,17 @@ type BOQDetailDTO struct {
 	Materials           []MaterialDTO `json:"materials"`
 }
 
+// BOQPhaseTotal subtotals the jobs of one phase. Jobs without a phase are
+// grouped under a nil PhaseID.
+type BOQPhaseTotal struct {
+	PhaseID             *uuid.UUID `json:"phase_id"`
+	PhaseName           string     `json:"phase_name"`
+	TotalMaterialCost   float64    `json:"total_material_cost"`
+	TotalLaborCost      float64    `json:"total_labor_cost"`
+	TotalEstimatedPrice float64    `json:"total_estimated_price"`
+	TotalAmount         float64    `json:"total_amount"`
+}
+
 type MaterialDTO struct {
 	JobID          uuid.UUID `json:"job_id"`
 	JobName        string    `json:"job_name"`
@@ -59,6 +77,7 @@ type MaterialDTO struct {
 	Unit           string    `json:"unit"`
 	EstimatedPrice float64   `json:"estimated_price"`
 	Total          float64   `json:"total"`
+	SupplierName   string    `json:"supplier_name,omitempty"`
 }
 
 type SummaryMetrics struct {
@@ -67,5 +86,22 @@ type SummaryMetrics struct {
 	TotalLaborCost      float64 `json:"total_labor_cost"`
 	TotalEstimatedPrice float64 `json:"total_estimated_price"`
 	TotalAmount         float64 `json:"total_amount"`
+	LaborAdjustment     float64 `json:"labor_adjustment"`
+	TransportCost       float64 `json:"transport_cost"`
 	GrandTotal          float64 `json:"grand_total"`
 }
+
+type BOQPriceUpdateResponse struct {
+	BOQID             uuid.UUID               `json:"boq_id"`
+	ProjectID         uuid.UUID               `json:"project_id"`
+	ProjectName       string                  `json:"project_name"`
+	QuotationID       *uuid.UUID              `json:"quotation_id,omitempty"`
+	EstimatedTotal    float64                 `json:"estimated_total"`
+	RecalculatedTotal float64                 `json:"recalculated_total"`
+	Delta             float64                 `json:"delta"`
+	DeltaPercent      float64                 `json:"delta_percent"`
+	Changes           []models.BOQPriceChange `json:"changes"`
+	FlaggedAt         time.Time               `json:"flagged_at"`
+	AcknowledgedAt    *time.Time              `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy    *uuid.UUID              `json:"acknowledged_by,omitempty"`
+}
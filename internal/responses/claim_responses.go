@@ -0,0 +1,37 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ClaimResponse struct {
+	ClaimID          uuid.UUID               `json:"claim_id"`
+	ProjectID        uuid.UUID               `json:"project_id"`
+	ContractID       uuid.UUID               `json:"contract_id"`
+	DocumentID       *uuid.UUID              `json:"document_id,omitempty"`
+	ClaimType        string                  `json:"claim_type"`
+	Title            string                  `json:"title"`
+	Narrative        string                  `json:"narrative"`
+	ClaimedAmount    float64                 `json:"claimed_amount"`
+	ClaimedDays      int                     `json:"claimed_days"`
+	Status           string                  `json:"status"`
+	SettlementAmount *float64                `json:"settlement_amount,omitempty"`
+	SettlementDays   *int64                  `json:"settlement_days,omitempty"`
+	Resolution       string                  `json:"resolution,omitempty"`
+	SubmittedAt      *time.Time              `json:"submitted_at,omitempty"`
+	ClosedAt         *time.Time              `json:"closed_at,omitempty"`
+	CreatedBy        *uuid.UUID              `json:"created_by,omitempty"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+	Documents        []ClaimDocumentResponse `json:"documents,omitempty"`
+}
+
+type ClaimDocumentResponse struct {
+	DocumentID uuid.UUID  `json:"document_id"`
+	Title      string     `json:"title"`
+	FileURL    string     `json:"file_url"`
+	UploadedBy *uuid.UUID `json:"uploaded_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
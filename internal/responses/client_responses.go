@@ -16,9 +16,63 @@ type ClientResponse struct {
 	TaxID     string          `json:"tax_id"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
+
+	CreditLimit      *float64 `json:"credit_limit"`
+	PaymentTermsDays *int64   `json:"payment_terms_days"`
+
+	CreditHold       bool       `json:"credit_hold"`
+	CreditHoldAuto   bool       `json:"credit_hold_auto,omitempty"`
+	CreditHoldReason string     `json:"credit_hold_reason,omitempty"`
+	CreditHoldAt     *time.Time `json:"credit_hold_at,omitempty"`
+
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
+	// Contacts are only included when a single client is fetched.
+	Contacts []ClientContactResponse `json:"contacts,omitempty"`
+}
+
+type ClientContactResponse struct {
+	ContactID uuid.UUID `json:"contact_id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Tel       string    `json:"tel,omitempty"`
+	IsPrimary bool      `json:"is_primary"`
 }
 
 type ClientListResponse struct {
 	Clients []ClientResponse `json:"clients"`
 	Total   int64            `json:"total"`
 }
+
+type ClientFieldConflict struct {
+	Field       string      `json:"field"`
+	SourceValue interface{} `json:"source_value"`
+	TargetValue interface{} `json:"target_value"`
+}
+
+type ClientMergePreviewResponse struct {
+	Source             ClientResponse        `json:"source"`
+	Target             ClientResponse        `json:"target"`
+	Conflicts          []ClientFieldConflict `json:"conflicts"`
+	SourceProjectCount int                   `json:"source_project_count"`
+}
+
+type ClientDocumentResponse struct {
+	DocumentID   uuid.UUID  `json:"document_id"`
+	ClientID     uuid.UUID  `json:"client_id"`
+	DocumentType string     `json:"document_type"`
+	FileName     string     `json:"file_name"`
+	ContentType  string     `json:"content_type"`
+	Size         int64      `json:"size"`
+	Note         string     `json:"note,omitempty"`
+	UploadedBy   *uuid.UUID `json:"uploaded_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ClientDocumentLinkResponse is a signed download link for a document,
+// usable without signing in until it expires.
+type ClientDocumentLinkResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
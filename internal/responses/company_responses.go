@@ -7,13 +7,15 @@ import (
 )
 
 type CompanyResponse struct {
-	CompanyID uuid.UUID       `json:"company_id"`
-	Name      string          `json:"name"`
-	Email     string          `json:"email"`
-	Tel       string          `json:"tel"`
-	Address   json.RawMessage `json:"address"`
-	TaxID     string          `json:"tax_id"`
-	IsNew     bool            `json:"-"`
+	CompanyID   uuid.UUID       `json:"company_id"`
+	Name        string          `json:"name"`
+	Email       string          `json:"email"`
+	Tel         string          `json:"tel"`
+	Address     json.RawMessage `json:"address"`
+	TaxID       string          `json:"tax_id"`
+	PromptPayID string          `json:"promptpay_id"`
+	DateEra     string          `json:"date_era"`
+	IsNew       bool            `json:"-"`
 }
 
 // Example API response structures
@@ -36,3 +38,11 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 	Code    string `json:"code,omitempty"`
 }
+
+// DocumentLabelsResponse is the effective wording of every label in one
+// language. Customized lists the keys the company has overridden.
+type DocumentLabelsResponse struct {
+	Language   string            `json:"language"`
+	Labels     map[string]string `json:"labels"`
+	Customized []string          `json:"customized"`
+}
@@ -0,0 +1,36 @@
+package responses
+
+import "github.com/google/uuid"
+
+type CubeTestResponse struct {
+	TestID            uuid.UUID `json:"test_id"`
+	PourID            uuid.UUID `json:"pour_id"`
+	TicketNumber      string    `json:"ticket_number,omitempty"`
+	ProjectID         uuid.UUID `json:"project_id"`
+	ProjectName       string    `json:"project_name,omitempty"`
+	JobID             uuid.UUID `json:"job_id"`
+	JobName           string    `json:"job_name,omitempty"`
+	SampleReference   string    `json:"sample_reference"`
+	SampleDate        string    `json:"sample_date"`
+	SpecifiedStrength float64   `json:"specified_strength"`
+	Strength7Day      *float64  `json:"strength_7_day"`
+	Strength28Day     *float64  `json:"strength_28_day"`
+	Lab               string    `json:"lab,omitempty"`
+	Note              string    `json:"note,omitempty"`
+	// Status is pending, passed or failed on the 28-day result.
+	Status string `json:"status"`
+	// EarlyWarning is set when the 7-day result is weak enough that the
+	// 28-day result may fail.
+	EarlyWarning bool `json:"early_warning"`
+}
+
+// CubeTestSummaryResponse is a project's QA record of cube tests; Failed
+// lists the failing tests.
+type CubeTestSummaryResponse struct {
+	Total         int                `json:"total"`
+	Pending       int                `json:"pending"`
+	Passed        int                `json:"passed"`
+	Failed        int                `json:"failed"`
+	EarlyWarnings int                `json:"early_warnings"`
+	Failures      []CubeTestResponse `json:"failures"`
+}
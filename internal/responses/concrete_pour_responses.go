@@ -0,0 +1,35 @@
+package responses
+
+import "github.com/google/uuid"
+
+type ConcretePourResponse struct {
+	PourID         uuid.UUID `json:"pour_id"`
+	ProjectID      uuid.UUID `json:"project_id"`
+	BOQID          uuid.UUID `json:"boq_id"`
+	JobID          uuid.UUID `json:"job_id"`
+	JobName        string    `json:"job_name,omitempty"`
+	SupplierID     uuid.UUID `json:"supplier_id"`
+	SupplierName   string    `json:"supplier_name,omitempty"`
+	MaterialID     string    `json:"material_id,omitempty"`
+	TicketNumber   string    `json:"ticket_number"`
+	PourDate       string    `json:"pour_date"`
+	Volume         float64   `json:"volume"`
+	SlumpMM        *float64  `json:"slump_mm,omitempty"`
+	Location       string    `json:"location,omitempty"`
+	Note           string    `json:"note,omitempty"`
+	CubeReferences []string  `json:"cube_references"`
+}
+
+// ConcretePourSummaryResponse is the concrete poured into a BOQ job so far
+// against its BOQ quantity. Variance is positive when more was poured than
+// planned.
+type ConcretePourSummaryResponse struct {
+	BOQID             uuid.UUID `json:"boq_id"`
+	JobID             uuid.UUID `json:"job_id"`
+	JobName           string    `json:"job_name"`
+	Pours             int       `json:"pours"`
+	PouredVolume      float64   `json:"poured_volume"`
+	PlannedVolume     float64   `json:"planned_volume"`
+	Variance          float64   `json:"variance"`
+	PoursWithoutCubes int       `json:"pours_without_cubes"`
+}
@@ -13,3 +13,26 @@ type ContractResponse struct {
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
+
+type ContractDocumentResponse struct {
+	DocumentID      uuid.UUID  `json:"document_id"`
+	ProjectID       uuid.UUID  `json:"project_id"`
+	DocumentType    string     `json:"document_type"`
+	Version         int        `json:"version"`
+	Title           string     `json:"title"`
+	FileURL         string     `json:"file_url"`
+	EffectiveDate   string     `json:"effective_date"`
+	ContractValue   *float64   `json:"contract_value,omitempty"`
+	ValueAdjustment float64    `json:"value_adjustment"`
+	SupersedesID    *uuid.UUID `json:"supersedes_id,omitempty"`
+	SupersededBy    *uuid.UUID `json:"superseded_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type ContractValueResponse struct {
+	ProjectID            uuid.UUID                  `json:"project_id"`
+	AsOf                 string                     `json:"as_of"`
+	CurrentContractValue float64                    `json:"current_contract_value"`
+	Base                 ContractDocumentResponse   `json:"base"`
+	Addenda              []ContractDocumentResponse `json:"addenda"`
+}
@@ -0,0 +1,32 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CrewResponse struct {
+	CrewID    uuid.UUID `json:"crew_id"`
+	Name      string    `json:"name"`
+	Capacity  int       `json:"capacity"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CrewOverAllocation struct {
+	CrewID    uuid.UUID           `json:"crew_id"`
+	CrewName  string              `json:"crew_name"`
+	Date      string              `json:"date"`
+	Capacity  int                 `json:"capacity"`
+	Allocated int                 `json:"allocated"`
+	Excess    int                 `json:"excess"`
+	Tasks     []OverAllocatedTask `json:"tasks"`
+}
+
+type OverAllocatedTask struct {
+	TaskID      uuid.UUID `json:"task_id"`
+	TaskName    string    `json:"task_name"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	Workers     int       `json:"workers"`
+}
@@ -0,0 +1,66 @@
+package responses
+
+import (
+	"boonkosang/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// PortfolioDashboardResponse summarises every live project for owners.
+// Amounts exclude VAT.
+type PortfolioDashboardResponse struct {
+	AsOf string `json:"as_of"`
+
+	ContractedValue    float64 `json:"contracted_value"`
+	ContractedProjects int     `json:"contracted_projects"`
+
+	// WorkInProgressValue is completed work on in-progress projects that
+	// hasn't been invoiced yet.
+	WorkInProgressValue float64 `json:"work_in_progress_value"`
+	ActiveProjects      int     `json:"active_projects"`
+
+	Cash           CashPositionResponse    `json:"cash"`
+	Claims         ClaimExposureResponse   `json:"claims"`
+	MarginTrend    []MarginTrendPoint      `json:"margin_trend"`
+	AtRiskProjects []AtRiskProjectResponse `json:"at_risk_projects"`
+}
+
+// ClaimExposureResponse totals the claims submitted to clients that are
+// still undecided.
+type ClaimExposureResponse struct {
+	Open          int     `json:"open"`
+	ClaimedAmount float64 `json:"claimed_amount"`
+	ClaimedDays   int     `json:"claimed_days"`
+}
+
+type CashPositionResponse struct {
+	Received    float64 `json:"received"`
+	Spent       float64 `json:"spent"`
+	Position    float64 `json:"position"`
+	Receivables float64 `json:"receivables"`
+}
+
+// MarginTrendPoint groups contracted projects by the month they were
+// created. ActualMarginPercent is nil until actual costs are recorded.
+type MarginTrendPoint struct {
+	Month                  string   `json:"month"`
+	Projects               int      `json:"projects"`
+	SellingPrice           float64  `json:"selling_price"`
+	EstimatedMarginPercent float64  `json:"estimated_margin_percent"`
+	ActualMarginPercent    *float64 `json:"actual_margin_percent"`
+}
+
+type AtRiskProjectResponse struct {
+	ProjectID           uuid.UUID            `json:"project_id"`
+	Name                string               `json:"name"`
+	Status              models.ProjectStatus `json:"status"`
+	EstimatedCost       float64              `json:"estimated_cost"`
+	ActualCost          float64              `json:"actual_cost"`
+	CostOverrunPercent  float64              `json:"cost_overrun_percent"`
+	PlannedProgress     float64              `json:"planned_progress_percent"`
+	ActualProgress      float64              `json:"actual_progress_percent"`
+	ScheduleSlipPercent float64              `json:"schedule_slip_percent"`
+	OpenClaims          int                  `json:"open_claims"`
+	OpenClaimAmount     float64              `json:"open_claim_amount"`
+	Reasons             []string             `json:"reasons"`
+}
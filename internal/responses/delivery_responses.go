@@ -0,0 +1,29 @@
+package responses
+
+import "github.com/google/uuid"
+
+type DeliveryRateResponse struct {
+	RateID            uuid.UUID `json:"rate_id"`
+	Name              string    `json:"name"`
+	PostalPrefix      string    `json:"postal_prefix,omitempty"`
+	Province          string    `json:"province,omitempty"`
+	PercentOfMaterial float64   `json:"percent_of_material"`
+	MinimumPerLine    float64   `json:"minimum_per_line"`
+}
+
+type QuotationTransportLine struct {
+	JobID          uuid.UUID `json:"job_id"`
+	JobName        string    `json:"job_name"`
+	MaterialAmount float64   `json:"material_amount"`
+	TransportCost  float64   `json:"transport_cost"`
+}
+
+// QuotationTransportResponse is the transport applied to a quotation. Rate
+// is nil when no rate matches the project address.
+type QuotationTransportResponse struct {
+	PostalCode string                   `json:"postal_code"`
+	Province   string                   `json:"province"`
+	Rate       *DeliveryRateResponse    `json:"rate"`
+	Lines      []QuotationTransportLine `json:"lines"`
+	Total      float64                  `json:"total"`
+}
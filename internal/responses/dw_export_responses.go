@@ -0,0 +1,12 @@
+package responses
+
+import "time"
+
+type DWExportStatusResponse struct {
+	Entity        string     `json:"entity"`
+	SchemaVersion int        `json:"schema_version"`
+	Incremental   bool       `json:"incremental"`
+	ExportedUntil *time.Time `json:"exported_until"`
+	LastRowCount  int        `json:"last_row_count"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+}
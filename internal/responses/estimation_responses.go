@@ -0,0 +1,30 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EstimationVarianceResponse struct {
+	ProjectID       uuid.UUID `json:"project_id"`
+	Kind            string    `json:"kind"`
+	ItemID          string    `json:"item_id"`
+	ItemName        string    `json:"item_name"`
+	EstimatedCost   float64   `json:"estimated_cost"`
+	ActualCost      float64   `json:"actual_cost"`
+	Variance        float64   `json:"variance"`
+	VariancePercent float64   `json:"variance_percent"`
+	RecordedAt      time.Time `json:"recorded_at"`
+}
+
+type EstimationAdjustmentResponse struct {
+	Kind            string  `json:"kind"`
+	ItemID          string  `json:"item_id"`
+	ItemName        string  `json:"item_name"`
+	Projects        int     `json:"projects"`
+	EstimatedCost   float64 `json:"estimated_cost"`
+	ActualCost      float64 `json:"actual_cost"`
+	VariancePercent float64 `json:"variance_percent"`
+	SuggestedFactor float64 `json:"suggested_factor"`
+}
@@ -0,0 +1,22 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ETaxSubmissionResponse struct {
+	SubmissionID   uuid.UUID  `json:"submission_id"`
+	InvoiceID      uuid.UUID  `json:"invoice_id"`
+	PaymentID      *uuid.UUID `json:"payment_id,omitempty"`
+	DocumentType   string     `json:"document_type"`
+	DocumentNumber string     `json:"document_number"`
+	Status         string     `json:"status"`
+	Signed         bool       `json:"signed"`
+	ProviderRef    string     `json:"provider_ref,omitempty"`
+	Message        string     `json:"message,omitempty"`
+	SubmittedAt    *time.Time `json:"submitted_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
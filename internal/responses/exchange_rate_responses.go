@@ -0,0 +1,25 @@
+package responses
+
+import "time"
+
+type ExchangeRateResponse struct {
+	CurrencyCode string    `json:"currency_code"`
+	RateDate     string    `json:"rate_date"`
+	Rate         float64   `json:"rate"`
+	Source       string    `json:"source"`
+	IsManual     bool      `json:"is_manual"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ExchangeRateListResponse struct {
+	BaseCurrency string                 `json:"base_currency"`
+	Rates        []ExchangeRateResponse `json:"rates"`
+}
+
+type CurrencyConversionResponse struct {
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Amount    float64 `json:"amount"`
+	Converted float64 `json:"converted"`
+	Rate      float64 `json:"rate"`
+}
@@ -0,0 +1,44 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FinalAccountResponse is the final settlement statement. The contract,
+// change orders and remeasurement are compiled afresh on every read; once
+// the account is agreed AgreedAmount keeps the sum the client agreed to.
+type FinalAccountResponse struct {
+	FinalAccountID uuid.UUID `json:"final_account_id"`
+	ProjectID      uuid.UUID `json:"project_id"`
+	Status         string    `json:"status"`
+	Note           string    `json:"note,omitempty"`
+
+	OriginalContract ContractDocumentResponse         `json:"original_contract"`
+	ChangeOrders     []ContractDocumentResponse       `json:"change_orders"`
+	Remeasurement    []AsBuiltLineResponse            `json:"remeasurement"`
+	BackCharges      []FinalAccountBackChargeResponse `json:"back_charges"`
+
+	OriginalContractSum float64 `json:"original_contract_sum"`
+	ChangeOrderTotal    float64 `json:"change_order_total"`
+	RemeasurementTotal  float64 `json:"remeasurement_total"`
+	BackChargeTotal     float64 `json:"back_charge_total"`
+	FinalContractSum    float64 `json:"final_contract_sum"`
+	PaidToDate          float64 `json:"paid_to_date"`
+	RetentionPercent    float64 `json:"retention_percent"`
+	RetentionHeld       float64 `json:"retention_held"`
+	BalanceDue          float64 `json:"balance_due"`
+
+	AgreedBy     string     `json:"agreed_by,omitempty"`
+	AgreedAmount *float64   `json:"agreed_amount,omitempty"`
+	AgreedAt     *time.Time `json:"agreed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+type FinalAccountBackChargeResponse struct {
+	ChargeID    uuid.UUID `json:"charge_id"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+}
@@ -0,0 +1,80 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FixedAssetResponse struct {
+	AssetID             uuid.UUID  `json:"asset_id"`
+	Name                string     `json:"name"`
+	MaterialID          string     `json:"material_id,omitempty"`
+	SerialNumber        string     `json:"serial_number,omitempty"`
+	ProjectID           *uuid.UUID `json:"project_id,omitempty"`
+	PurchaseDate        string     `json:"purchase_date"`
+	PurchaseCost        float64    `json:"purchase_cost"`
+	SalvageValue        float64    `json:"salvage_value"`
+	UsefulLifeMonths    int        `json:"useful_life_months"`
+	MonthlyDepreciation float64    `json:"monthly_depreciation"`
+	Status              string     `json:"status"`
+	DisposedAt          string     `json:"disposed_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type BookValueLine struct {
+	AssetID                 uuid.UUID `json:"asset_id"`
+	Name                    string    `json:"name"`
+	Status                  string    `json:"status"`
+	PurchaseDate            string    `json:"purchase_date"`
+	PurchaseCost            float64   `json:"purchase_cost"`
+	MonthsDepreciated       int       `json:"months_depreciated"`
+	AccumulatedDepreciation float64   `json:"accumulated_depreciation"`
+	BookValue               float64   `json:"book_value"`
+}
+
+// BookValueReportResponse values the asset register at the end of AsOf's
+// month. Assets disposed before then are left out.
+type BookValueReportResponse struct {
+	AsOf              string          `json:"as_of"`
+	Assets            []BookValueLine `json:"assets"`
+	TotalCost         float64         `json:"total_cost"`
+	TotalDepreciation float64         `json:"total_depreciation"`
+	TotalBookValue    float64         `json:"total_book_value"`
+}
+
+type DepreciationScheduleLine struct {
+	Month                   int        `json:"month"`
+	Period                  string     `json:"period"`
+	Depreciation            float64    `json:"depreciation"`
+	AccumulatedDepreciation float64    `json:"accumulated_depreciation"`
+	BookValue               float64    `json:"book_value"`
+	Posted                  bool       `json:"posted"`
+	PostedAt                *time.Time `json:"posted_at,omitempty"`
+	ProjectID               *uuid.UUID `json:"project_id,omitempty"`
+}
+
+// DepreciationScheduleResponse is an asset's full straight-line schedule,
+// with the months already posted marked.
+type DepreciationScheduleResponse struct {
+	Asset             FixedAssetResponse         `json:"asset"`
+	Schedule          []DepreciationScheduleLine `json:"schedule"`
+	TotalPosted       float64                    `json:"total_posted"`
+	RemainingToCharge float64                    `json:"remaining_to_charge"`
+}
+
+type DepreciationAllocationLine struct {
+	ProjectID   *uuid.UUID `json:"project_id,omitempty"`
+	ProjectName string     `json:"project_name,omitempty"`
+	Amount      float64    `json:"amount"`
+}
+
+// DepreciationAllocationResponse splits a period's depreciation between the
+// projects the assets were assigned to and unallocated company overhead.
+type DepreciationAllocationResponse struct {
+	Period          string                       `json:"period"`
+	Projects        []DepreciationAllocationLine `json:"projects"`
+	CompanyOverhead float64                      `json:"company_overhead"`
+	Total           float64                      `json:"total"`
+}
@@ -0,0 +1,47 @@
+package responses
+
+import (
+	"boonkosang/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ProjectForecastResponse is a project's earned-value forecast. Costs
+// exclude VAT; progress figures are percentages. CPI and SPI are nil until
+// there are actual costs or scheduled work to compare against.
+type ProjectForecastResponse struct {
+	ProjectID uuid.UUID            `json:"project_id"`
+	Name      string               `json:"name"`
+	Status    models.ProjectStatus `json:"status"`
+	AsOf      string               `json:"as_of"`
+
+	PlannedProgress float64 `json:"planned_progress"`
+	ActualProgress  float64 `json:"actual_progress"`
+
+	BudgetAtCompletion   float64  `json:"budget_at_completion"`
+	PlannedValue         float64  `json:"planned_value"`
+	EarnedValue          float64  `json:"earned_value"`
+	ActualCost           float64  `json:"actual_cost"`
+	CPI                  *float64 `json:"cpi"`
+	SPI                  *float64 `json:"spi"`
+	EstimateAtCompletion float64  `json:"estimate_at_completion"`
+	EstimateToComplete   float64  `json:"estimate_to_complete"`
+	VarianceAtCompletion float64  `json:"variance_at_completion"`
+	// CommittedRecurringCost is what recurring general costs with an end
+	// period have still to post; the estimate at completion is at least
+	// the actual cost plus this.
+	CommittedRecurringCost float64 `json:"committed_recurring_cost"`
+
+	// ContractValue is the contract in force, or the BOQ selling price
+	// when there is none yet, as ContractSource says.
+	ContractValue   float64 `json:"contract_value"`
+	ContractSource  string  `json:"contract_source"`
+	ProjectedMargin float64 `json:"projected_margin"`
+	ExceedsContract bool    `json:"exceeds_contract"`
+	OverrunPercent  float64 `json:"overrun_percent,omitempty"`
+}
+
+type ForecastAlertListResponse struct {
+	ThresholdPercent float64                   `json:"threshold_percent"`
+	Alerts           []ProjectForecastResponse `json:"alerts"`
+}
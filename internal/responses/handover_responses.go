@@ -0,0 +1,47 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HandoverResponse struct {
+	HandoverID     uuid.UUID                    `json:"handover_id"`
+	ProjectID      uuid.UUID                    `json:"project_id"`
+	CompletionDate string                       `json:"completion_date"`
+	WarrantyMonths int                          `json:"warranty_months"`
+	WarrantyEndsAt string                       `json:"warranty_ends_at"`
+	WarrantyTerms  string                       `json:"warranty_terms"`
+	Defects        []HandoverDefectResponse     `json:"defects"`
+	Attachments    []HandoverAttachmentResponse `json:"attachments"`
+	QASummary      *CubeTestSummaryResponse     `json:"qa_summary"`
+	Acknowledged   bool                         `json:"acknowledged"`
+	AcknowledgedBy string                       `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time                   `json:"acknowledged_at,omitempty"`
+
+	// AcknowledgementURL is the signed link sent to the client. It is only
+	// present until the handover is acknowledged.
+	AcknowledgementURL string     `json:"acknowledgement_url,omitempty"`
+	LinkExpiresAt      *time.Time `json:"link_expires_at,omitempty"`
+
+	// SurveyURL is the satisfaction survey link, returned when the handover
+	// is created and a default survey template exists.
+	SurveyURL string `json:"survey_url,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type HandoverDefectResponse struct {
+	DefectID     uuid.UUID  `json:"defect_id"`
+	InspectionID *uuid.UUID `json:"inspection_id"`
+	Location     string     `json:"location"`
+	Description  string     `json:"description"`
+	Note         string     `json:"note"`
+}
+
+type HandoverAttachmentResponse struct {
+	AttachmentID uuid.UUID `json:"attachment_id"`
+	Name         string    `json:"name"`
+	FileURL      string    `json:"file_url"`
+}
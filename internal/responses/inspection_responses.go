@@ -0,0 +1,45 @@
+package responses
+
+import (
+	"boonkosang/internal/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InspectionTemplateResponse struct {
+	TemplateID uuid.UUID                    `json:"template_id"`
+	JobID      uuid.UUID                    `json:"job_id"`
+	Name       string                       `json:"name"`
+	Mandatory  bool                         `json:"mandatory"`
+	Items      []InspectionTemplateItemResp `json:"items"`
+	CreatedAt  time.Time                    `json:"created_at"`
+}
+
+type InspectionTemplateItemResp struct {
+	ItemID      uuid.UUID `json:"item_id"`
+	Description string    `json:"description"`
+	SortOrder   int       `json:"sort_order"`
+}
+
+type InspectionResponse struct {
+	InspectionID uuid.UUID                `json:"inspection_id"`
+	TemplateID   uuid.UUID                `json:"template_id"`
+	BOQID        uuid.UUID                `json:"boq_id"`
+	JobID        uuid.UUID                `json:"job_id"`
+	Status       models.InspectionStatus  `json:"status"`
+	InspectedBy  string                   `json:"inspected_by"`
+	InspectedAt  *time.Time               `json:"inspected_at"`
+	Notes        string                   `json:"notes"`
+	Items        []InspectionItemResponse `json:"items"`
+	PhotoURLs    []string                 `json:"photo_urls"`
+	CreatedAt    time.Time                `json:"created_at"`
+}
+
+type InspectionItemResponse struct {
+	ItemID      uuid.UUID `json:"item_id"`
+	Description string    `json:"description"`
+	Passed      *bool     `json:"passed"`
+	Note        string    `json:"note"`
+	PhotoURLs   []string  `json:"photo_urls"`
+}
@@ -0,0 +1,47 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WarehouseResponse struct {
+	WarehouseID uuid.UUID  `json:"warehouse_id"`
+	Name        string     `json:"name"`
+	Kind        string     `json:"kind"`
+	ProjectID   *uuid.UUID `json:"project_id,omitempty"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+}
+
+type StockLevelResponse struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	MaterialID    string    `json:"material_id"`
+	MaterialName  string    `json:"material_name"`
+	Unit          string    `json:"unit"`
+	Quantity      float64   `json:"quantity"`
+}
+
+type StockMovementResponse struct {
+	MovementID  uuid.UUID  `json:"movement_id"`
+	WarehouseID uuid.UUID  `json:"warehouse_id"`
+	MaterialID  string     `json:"material_id"`
+	Quantity    float64    `json:"quantity"`
+	Type        string     `json:"type"`
+	TransferID  *uuid.UUID `json:"transfer_id,omitempty"`
+	ProjectID   *uuid.UUID `json:"project_id,omitempty"`
+	Reference   string     `json:"reference,omitempty"`
+	Note        string     `json:"note,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// WarehouseCloseResponse lists the stock moved out of a closed site store.
+type WarehouseCloseResponse struct {
+	Warehouse         WarehouseResponse       `json:"warehouse"`
+	TargetWarehouseID uuid.UUID               `json:"target_warehouse_id"`
+	TransferID        *uuid.UUID              `json:"transfer_id,omitempty"`
+	Movements         []StockMovementResponse `json:"movements"`
+}
@@ -7,13 +7,48 @@ import (
 )
 
 type InvoiceResponse struct {
-	InvoiceID uuid.UUID `json:"invoice_id"`
-	ProjectID uuid.UUID `json:"project_id"`
-	FileURL   string    `json:"file_url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	InvoiceID uuid.UUID  `json:"invoice_id"`
+	ProjectID uuid.UUID  `json:"project_id"`
+	PhaseID   *uuid.UUID `json:"phase_id,omitempty"`
+	FileURL   string     `json:"file_url"`
+	Amount    float64    `json:"amount"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
 }
 
 type InvoiceListResponse struct {
 	Invoices []InvoiceResponse `json:"invoices"`
 }
+
+type PromptPayQRResponse struct {
+	InvoiceID   uuid.UUID `json:"invoice_id"`
+	PromptPayID string    `json:"promptpay_id"`
+	Amount      float64   `json:"amount"`
+	Payload     string    `json:"payload"`
+}
+
+// VATReportLine is one invoice on the output VAT report. The invoice amount
+// is split by the tax treatments of its quotation.
+type VATReportLine struct {
+	InvoiceID   uuid.UUID `json:"invoice_id"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	ClientName  string    `json:"client_name"`
+	ClientTaxID string    `json:"client_tax_id"`
+	IssuedAt    time.Time `json:"issued_at"`
+	Standard    float64   `json:"standard_amount"`
+	ZeroRated   float64   `json:"zero_rated_amount"`
+	Exempt      float64   `json:"exempt_amount"`
+	VAT         float64   `json:"vat_amount"`
+	Total       float64   `json:"total_amount"`
+}
+
+type VATReportResponse struct {
+	Month     string          `json:"month"`
+	Lines     []VATReportLine `json:"lines"`
+	Standard  float64         `json:"standard_amount"`
+	ZeroRated float64         `json:"zero_rated_amount"`
+	Exempt    float64         `json:"exempt_amount"`
+	VAT       float64         `json:"vat_amount"`
+	Total     float64         `json:"total_amount"`
+}
@@ -1,16 +1,36 @@
 package responses
 
 import (
+	"boonkosang/internal/domain/models"
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type JobResponse struct {
-	JobID       uuid.UUID `json:"job_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Unit        string    `json:"unit"`
-	Quantity    float64   `json:"quantity"`
-	LaborCost   float64   `json:"labor_cost"`
+	JobID       uuid.UUID  `json:"job_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Unit        string     `json:"unit"`
+	Quantity    float64    `json:"quantity"`
+	LaborCost   float64    `json:"labor_cost"`
+	PhaseID     *uuid.UUID `json:"phase_id,omitempty"`
+	PhaseName   string     `json:"phase_name,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// Drawings is only filled in on BOQ responses.
+	Drawings []BOQJobDrawingResponse `json:"drawings,omitempty"`
+}
+
+type BOQJobDrawingResponse struct {
+	DrawingID     uuid.UUID `json:"drawing_id"`
+	JobID         uuid.UUID `json:"job_id"`
+	Title         string    `json:"title"`
+	DrawingNumber string    `json:"drawing_number,omitempty"`
+	Revision      string    `json:"revision,omitempty"`
+	Source        string    `json:"source"`
+	URL           string    `json:"url"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 type JobMaterialResponse struct {
@@ -56,3 +76,16 @@ type ApiResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
 }
+
+func NewBOQJobDrawingResponse(d models.BOQJobDrawing) BOQJobDrawingResponse {
+	return BOQJobDrawingResponse{
+		DrawingID:     d.DrawingID,
+		JobID:         d.JobID,
+		Title:         d.Title,
+		DrawingNumber: d.DrawingNumber.String,
+		Revision:      d.Revision.String,
+		Source:        string(d.Source),
+		URL:           d.URL,
+		CreatedAt:     d.CreatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package responses
+
+import (
+	"boonkosang/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type KPITargetResponse struct {
+	TargetID uuid.UUID        `json:"target_id"`
+	Metric   models.KPIMetric `json:"metric"`
+	Month    string           `json:"month"`
+	Value    float64          `json:"value"`
+}
+
+type KPIReportResponse struct {
+	From   string           `json:"from"`
+	To     string           `json:"to"`
+	Months []KPIMonthReport `json:"months"`
+}
+
+type KPIMonthReport struct {
+	Month       string        `json:"month"`
+	Contracts   int           `json:"contracts"`
+	Sales       KPIAttainment `json:"sales"`
+	Margin      KPIAttainment `json:"margin"`
+	Utilization KPIAttainment `json:"utilization"`
+}
+
+// KPIAttainment compares an actual against its target. Target and
+// attainment are nil when no target was set for the month.
+type KPIAttainment struct {
+	Actual            float64  `json:"actual"`
+	Target            *float64 `json:"target"`
+	AttainmentPercent *float64 `json:"attainment_percent"`
+}
@@ -0,0 +1,40 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LatePaymentTermsResponse struct {
+	ProjectID         uuid.UUID `json:"project_id"`
+	AnnualRatePercent float64   `json:"annual_rate_percent"`
+	PaymentDays       int       `json:"payment_days"`
+	GraceDays         int       `json:"grace_days"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type InvoiceLateInterestResponse struct {
+	InvoiceID    uuid.UUID `json:"invoice_id"`
+	FirstAccrued string    `json:"first_accrued"`
+	LastAccrued  string    `json:"last_accrued"`
+	Days         int       `json:"days"`
+	Accrued      float64   `json:"accrued"`
+	Unbilled     float64   `json:"unbilled"`
+}
+
+type ProjectLateInterestResponse struct {
+	ProjectID uuid.UUID                     `json:"project_id"`
+	Terms     *LatePaymentTermsResponse     `json:"terms,omitempty"`
+	Invoices  []InvoiceLateInterestResponse `json:"invoices"`
+	Accrued   float64                       `json:"accrued"`
+	Unbilled  float64                       `json:"unbilled"`
+}
+
+type LateInterestDebitNoteResponse struct {
+	DebitNoteID uuid.UUID `json:"debit_note_id"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	Amount      float64   `json:"amount"`
+	CreatedBy   uuid.UUID `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
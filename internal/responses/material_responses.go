@@ -1,11 +1,24 @@
 package responses
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type MaterialResponse struct {
-	MaterialID string `json:"material_id"`
-	Name       string `json:"name"`
-	Unit       string `json:"unit"`
+	MaterialID     string `json:"material_id"`
+	Name           string `json:"name"`
+	Unit           string `json:"unit"`
+	Classification string `json:"classification,omitempty"`
+}
+
+// MaterialSearchResponse is a material found by search. MatchedKind is
+// name, material_id or the kind of alias that matched.
+type MaterialSearchResponse struct {
+	MaterialResponse
+	MatchedName string `json:"matched_name"`
+	MatchedKind string `json:"matched_kind"`
 }
 
 type MaterialListResponse struct {
@@ -33,3 +46,63 @@ type MaterialActualPriceResponse struct {
 	ActualPrice float64   `json:"actual_price"`
 	SupplierID  uuid.UUID `json:"supplier_id"`
 }
+
+type DuplicateMaterialPair struct {
+	Material   MaterialResponse `json:"material"`
+	Duplicate  MaterialResponse `json:"duplicate"`
+	Similarity float64          `json:"similarity"`
+}
+
+type DuplicateMaterialListResponse struct {
+	Threshold  float64                 `json:"threshold"`
+	Duplicates []DuplicateMaterialPair `json:"duplicates"`
+}
+
+type MaterialListPriceResponse struct {
+	PriceID       uuid.UUID `json:"price_id"`
+	MaterialID    string    `json:"material_id"`
+	Price         float64   `json:"price"`
+	EffectiveFrom string    `json:"effective_from"`
+	Note          string    `json:"note,omitempty"`
+	// Current is set on the price in effect today.
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UpcomingPriceImpactItem struct {
+	MaterialID     string  `json:"material_id"`
+	MaterialName   string  `json:"material_name"`
+	Quantity       float64 `json:"quantity"`
+	EstimatedPrice float64 `json:"estimated_price"`
+	UpcomingPrice  float64 `json:"upcoming_price"`
+	EffectiveFrom  string  `json:"effective_from"`
+	Delta          float64 `json:"delta"`
+}
+
+// UpcomingPriceImpactResponse is one draft and how much its materials go
+// up by.
+type UpcomingPriceImpactResponse struct {
+	ProjectID   uuid.UUID                 `json:"project_id"`
+	ProjectName string                    `json:"project_name"`
+	BOQID       uuid.UUID                 `json:"boq_id"`
+	QuotationID *uuid.UUID                `json:"quotation_id,omitempty"`
+	TotalDelta  float64                   `json:"total_delta"`
+	Materials   []UpcomingPriceImpactItem `json:"materials"`
+}
+
+type UpcomingPriceImpactListResponse struct {
+	From   string                        `json:"from"`
+	To     string                        `json:"to"`
+	Drafts []UpcomingPriceImpactResponse `json:"drafts"`
+}
+
+type MaterialAliasResponse struct {
+	AliasID    uuid.UUID  `json:"alias_id"`
+	MaterialID string     `json:"material_id"`
+	Kind       string     `json:"kind"`
+	Name       string     `json:"name"`
+	Language   string     `json:"language,omitempty"`
+	SupplierID *uuid.UUID `json:"supplier_id,omitempty"`
+	Display    bool       `json:"display"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
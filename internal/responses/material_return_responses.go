@@ -0,0 +1,35 @@
+package responses
+
+import "github.com/google/uuid"
+
+// MaterialReturnResponse is a return with its credit note; Lines are only
+// included when a single return is fetched.
+type MaterialReturnResponse struct {
+	ReturnID         uuid.UUID                    `json:"return_id"`
+	ReturnNumber     string                       `json:"return_number"`
+	POID             uuid.UUID                    `json:"po_id"`
+	PONumber         string                       `json:"po_number"`
+	SupplierID       uuid.UUID                    `json:"supplier_id"`
+	SupplierName     string                       `json:"supplier_name"`
+	WarehouseID      *uuid.UUID                   `json:"warehouse_id,omitempty"`
+	Reason           string                       `json:"reason"`
+	ReturnDate       string                       `json:"return_date"`
+	Note             string                       `json:"note,omitempty"`
+	ExpectedCredit   float64                      `json:"expected_credit"`
+	CreditStatus     string                       `json:"credit_status"`
+	CreditNoteNumber string                       `json:"credit_note_number,omitempty"`
+	CreditAmount     *float64                     `json:"credit_amount,omitempty"`
+	CreditDate       string                       `json:"credit_date,omitempty"`
+	Lines            []MaterialReturnLineResponse `json:"lines,omitempty"`
+}
+
+type MaterialReturnLineResponse struct {
+	LineID       uuid.UUID  `json:"line_id"`
+	AllocationID uuid.UUID  `json:"allocation_id"`
+	ReceiptID    *uuid.UUID `json:"receipt_id,omitempty"`
+	ProjectID    uuid.UUID  `json:"project_id"`
+	MaterialID   string     `json:"material_id"`
+	Quantity     float64    `json:"quantity"`
+	UnitPrice    float64    `json:"unit_price"`
+	Amount       float64    `json:"amount"`
+}
@@ -0,0 +1,31 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationMatrixResponse is the effective matrix, by event, channel
+// and then role. For a project, Customized says whether it has rules of
+// its own or follows the company's defaults.
+type NotificationMatrixResponse struct {
+	ProjectID  *uuid.UUID                            `json:"project_id,omitempty"`
+	Customized bool                                  `json:"customized"`
+	Matrix     map[string]map[string]map[string]bool `json:"matrix"`
+}
+
+type NotificationResponse struct {
+	NotificationID uuid.UUID  `json:"notification_id"`
+	ProjectID      *uuid.UUID `json:"project_id,omitempty"`
+	Event          string     `json:"event"`
+	Title          string     `json:"title"`
+	Body           string     `json:"body"`
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Unread        int                    `json:"unread"`
+}
@@ -0,0 +1,61 @@
+package responses
+
+import (
+	"boonkosang/internal/domain/models"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentResponse struct {
+	PaymentID     uuid.UUID            `json:"payment_id"`
+	InvoiceID     uuid.UUID            `json:"invoice_id"`
+	Amount        float64              `json:"amount"`
+	Method        models.PaymentMethod `json:"method"`
+	Reference     string               `json:"reference"`
+	Note          string               `json:"note"`
+	PaidAt        time.Time            `json:"paid_at"`
+	ReceiptID     *uuid.UUID           `json:"receipt_id,omitempty"`
+	ReceiptNumber string               `json:"receipt_number,omitempty"`
+}
+
+type ReceiptResponse struct {
+	ReceiptID     uuid.UUID       `json:"receipt_id"`
+	ReceiptNumber string          `json:"receipt_number"`
+	Payment       PaymentResponse `json:"payment"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type ReceiptExportData struct {
+	ReceiptID     uuid.UUID `json:"receipt_id" db:"receipt_id"`
+	ReceiptNumber string    `json:"receipt_number" db:"receipt_number"`
+	IssuedAt      time.Time `json:"issued_at" db:"issued_at"`
+
+	PaymentID     uuid.UUID            `json:"payment_id" db:"payment_id"`
+	Amount        float64              `json:"amount" db:"amount"`
+	PaymentMethod models.PaymentMethod `json:"payment_method" db:"method"`
+	Reference     string               `json:"reference" db:"reference"`
+	Note          string               `json:"note" db:"note"`
+	PaidAt        time.Time            `json:"paid_at" db:"paid_at"`
+
+	InvoiceID      uuid.UUID `json:"invoice_id" db:"invoice_id"`
+	InvoiceFileURL string    `json:"invoice_file_url" db:"invoice_file_url"`
+
+	ProjectID      uuid.UUID       `json:"project_id" db:"project_id"`
+	ProjectName    string          `json:"project_name" db:"project_name"`
+	ProjectAddress json.RawMessage `json:"project_address" db:"project_address"`
+
+	ClientName    string          `json:"client_name" db:"client_name"`
+	ClientAddress json.RawMessage `json:"client_address" db:"client_address"`
+	ClientEmail   string          `json:"client_email" db:"client_email"`
+	ClientTel     string          `json:"client_tel" db:"client_tel"`
+	ClientTaxID   string          `json:"client_tax_id" db:"client_tax_id"`
+
+	// Language and Labels are what the receipt is printed with.
+	Language string            `json:"language" db:"-"`
+	Labels   map[string]string `json:"labels" db:"-"`
+	// Dates are the receipt's dates as printed, in the company's era.
+	DateEra string            `json:"date_era" db:"-"`
+	Dates   map[string]string `json:"dates" db:"-"`
+}
@@ -0,0 +1,21 @@
+package responses
+
+// RolePermissionsResponse is the effective matrix of one role, by resource
+// then action.
+type RolePermissionsResponse struct {
+	Role        string                     `json:"role"`
+	Editable    bool                       `json:"editable"`
+	Permissions map[string]map[string]bool `json:"permissions"`
+}
+
+// RoutePolicyResponse is the permission a route needs. Customized routes
+// come from the policy table and fall back to their default, if any, when
+// reset.
+type RoutePolicyResponse struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Resource   string `json:"resource"`
+	Action     string `json:"action"`
+	Enabled    bool   `json:"enabled"`
+	Customized bool   `json:"customized"`
+}
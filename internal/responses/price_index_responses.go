@@ -0,0 +1,53 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PriceIndexResponse struct {
+	IndexID     uuid.UUID `json:"index_id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Unit        string    `json:"unit"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PriceIndexValueResponse struct {
+	PeriodDate string  `json:"period_date"`
+	Value      float64 `json:"value"`
+	Source     string  `json:"source"`
+}
+
+type PriceIndexDetailResponse struct {
+	PriceIndexResponse
+	Values []PriceIndexValueResponse `json:"values"`
+}
+
+type PriceIndexImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+type PriceIndexAlertResponse struct {
+	ProjectID     uuid.UUID `json:"project_id"`
+	ProjectName   string    `json:"project_name"`
+	QuotationID   uuid.UUID `json:"quotation_id"`
+	BOQID         uuid.UUID `json:"boq_id"`
+	MaterialID    string    `json:"material_id"`
+	MaterialName  string    `json:"material_name"`
+	IndexID       uuid.UUID `json:"index_id"`
+	IndexCode     string    `json:"index_code"`
+	EstimatedAt   time.Time `json:"estimated_at"`
+	BaseDate      string    `json:"base_date"`
+	BaseValue     float64   `json:"base_value"`
+	LatestDate    string    `json:"latest_date"`
+	LatestValue   float64   `json:"latest_value"`
+	ChangePercent float64   `json:"change_percent"`
+}
+
+type PriceIndexAlertListResponse struct {
+	ThresholdPercent float64                   `json:"threshold_percent"`
+	Alerts           []PriceIndexAlertResponse `json:"alerts"`
+}
@@ -0,0 +1,27 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectArchiveResponse struct {
+	ArchiveID uuid.UUID `json:"archive_id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	// Archived is false once the archive has been restored.
+	Archived   bool                  `json:"archived"`
+	SizeBytes  int64                 `json:"size_bytes"`
+	Summary    ProjectArchiveSummary `json:"summary"`
+	Tables     map[string]int        `json:"tables"`
+	ArchivedBy uuid.UUID             `json:"archived_by"`
+	ArchivedAt time.Time             `json:"archived_at"`
+	RestoredBy *uuid.UUID            `json:"restored_by,omitempty"`
+	RestoredAt *time.Time            `json:"restored_at,omitempty"`
+}
+
+type ProjectArchiveSummary struct {
+	Photos    int `json:"photos"`
+	Logs      int `json:"logs"`
+	Revisions int `json:"revisions"`
+}
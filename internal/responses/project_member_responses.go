@@ -0,0 +1,18 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectMemberResponse struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	Username  string     `json:"username"`
+	FirstName string     `json:"first_name"`
+	LastName  string     `json:"last_name"`
+	Role      string     `json:"role"`
+	IsActive  bool       `json:"is_active"`
+	AddedBy   *uuid.UUID `json:"added_by"`
+	AddedAt   time.Time  `json:"added_at"`
+}
@@ -0,0 +1,41 @@
+package responses
+
+import "github.com/google/uuid"
+
+type ProjectPartnerResponse struct {
+	PartnerID    uuid.UUID `json:"partner_id"`
+	Name         string    `json:"name"`
+	TaxID        string    `json:"tax_id,omitempty"`
+	SharePercent float64   `json:"share_percent"`
+	IsSelf       bool      `json:"is_self"`
+}
+
+// ProjectFinancials are a project's pre-tax figures, in total or for one
+// partner's share.
+type ProjectFinancials struct {
+	Revenue         float64 `json:"revenue"`
+	EstimatedCost   float64 `json:"estimated_cost"`
+	ActualCost      float64 `json:"actual_cost"`
+	EstimatedProfit float64 `json:"estimated_profit"`
+	ActualProfit    float64 `json:"actual_profit"`
+	Invoiced        float64 `json:"invoiced"`
+	Collected       float64 `json:"collected"`
+}
+
+type PartnerShareResponse struct {
+	ProjectPartnerResponse
+	ProjectFinancials
+}
+
+// ProjectShareReportResponse splits a project's figures by partner share.
+// Margins are the same for every partner; each partner's amounts are
+// rounded to the satang with any remainder on the last partner, so the
+// shares add up to the totals.
+type ProjectShareReportResponse struct {
+	ProjectID       uuid.UUID              `json:"project_id"`
+	ProjectName     string                 `json:"project_name"`
+	Totals          ProjectFinancials      `json:"totals"`
+	EstimatedMargin float64                `json:"estimated_margin"`
+	ActualMargin    float64                `json:"actual_margin"`
+	Partners        []PartnerShareResponse `json:"partners"`
+}
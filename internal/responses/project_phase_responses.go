@@ -0,0 +1,29 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectPhaseResponse struct {
+	PhaseID   uuid.UUID `json:"phase_id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Name      string    `json:"name"`
+	SortOrder int       `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PhaseSubtotal struct {
+	PhaseID        *uuid.UUID `json:"phase_id"`
+	PhaseName      string     `json:"phase_name"`
+	EstimatedTotal float64    `json:"estimated_total"`
+	QuotedTotal    float64    `json:"quoted_total"`
+	InvoicedTotal  float64    `json:"invoiced_total"`
+	PaidTotal      float64    `json:"paid_total"`
+}
+
+type PhaseSummaryResponse struct {
+	ProjectID uuid.UUID       `json:"project_id"`
+	Phases    []PhaseSubtotal `json:"phases"`
+}
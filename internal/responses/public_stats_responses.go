@@ -0,0 +1,4 @@
+package responses
+
+// PublicStatsResponse maps whitelisted field names to their values.
+type PublicStatsResponse map[string]float64
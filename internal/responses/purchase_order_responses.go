@@ -0,0 +1,44 @@
+package responses
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderResponse is an order with its totals; Lines are only
+// included when a single order is fetched.
+type PurchaseOrderResponse struct {
+	POID           uuid.UUID                   `json:"po_id"`
+	PONumber       string                      `json:"po_number"`
+	SupplierID     uuid.UUID                   `json:"supplier_id"`
+	SupplierName   string                      `json:"supplier_name"`
+	AgreementID    *uuid.UUID                  `json:"agreement_id,omitempty"`
+	Status         string                      `json:"status"`
+	OrderDate      string                      `json:"order_date"`
+	Note           string                      `json:"note,omitempty"`
+	TotalAmount    float64                     `json:"total_amount"`
+	ReceivedAmount float64                     `json:"received_amount"`
+	Lines          []PurchaseOrderLineResponse `json:"lines,omitempty"`
+}
+
+type PurchaseOrderLineResponse struct {
+	LineID           uuid.UUID                         `json:"line_id"`
+	MaterialID       string                            `json:"material_id"`
+	UnitPrice        float64                           `json:"unit_price"`
+	Quantity         float64                           `json:"quantity"`
+	ReceivedQuantity float64                           `json:"received_quantity"`
+	Amount           float64                           `json:"amount"`
+	Allocations      []PurchaseOrderAllocationResponse `json:"allocations"`
+}
+
+type PurchaseOrderAllocationResponse struct {
+	AllocationID     uuid.UUID       `json:"allocation_id"`
+	ProjectID        uuid.UUID       `json:"project_id"`
+	Quantity         float64         `json:"quantity"`
+	ReceivedQuantity float64         `json:"received_quantity"`
+	ReturnedQuantity float64         `json:"returned_quantity"`
+	Outstanding      float64         `json:"outstanding"`
+	Amount           float64         `json:"amount"`
+	DeliveryAddress  json.RawMessage `json:"delivery_address"`
+}
@@ -0,0 +1,58 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuotationNegotiationRoundResponse struct {
+	RoundID          uuid.UUID `json:"round_id"`
+	RoundNumber      int       `json:"round_number"`
+	CounterOffer     float64   `json:"counter_offer"`
+	OfferedOn        string    `json:"offered_on"`
+	ClientNotes      string    `json:"client_notes,omitempty"`
+	InternalResponse string    `json:"internal_response,omitempty"`
+	// GapPercent is how far the counter-offer is below the quoted amount.
+	GapPercent float64    `json:"gap_percent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+type QuotationNegotiationResponse struct {
+	QuotationID  uuid.UUID                           `json:"quotation_id"`
+	QuotedAmount float64                             `json:"quoted_amount"`
+	Rounds       []QuotationNegotiationRoundResponse `json:"rounds"`
+}
+
+type WinLossQuotation struct {
+	QuotationID      uuid.UUID `json:"quotation_id"`
+	ProjectID        uuid.UUID `json:"project_id"`
+	ProjectName      string    `json:"project_name"`
+	ClientName       string    `json:"client_name"`
+	Outcome          string    `json:"outcome"`
+	QuotedAmount     float64   `json:"quoted_amount"`
+	Rounds           int       `json:"rounds"`
+	LastCounterOffer *float64  `json:"last_counter_offer,omitempty"`
+	GapPercent       *float64  `json:"gap_percent,omitempty"`
+}
+
+// WinLossSummary aggregates one outcome. AverageGapPercent only counts
+// quotations that were negotiated.
+type WinLossSummary struct {
+	Outcome           string  `json:"outcome"`
+	Count             int     `json:"count"`
+	QuotedAmount      float64 `json:"quoted_amount"`
+	NegotiatedCount   int     `json:"negotiated_count"`
+	AverageRounds     float64 `json:"average_rounds"`
+	AverageGapPercent float64 `json:"average_gap_percent"`
+}
+
+type WinLossReportResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// WinRate is won / (won + lost) as a percentage.
+	WinRate    float64            `json:"win_rate"`
+	Summary    []WinLossSummary   `json:"summary"`
+	Quotations []WinLossQuotation `json:"quotations"`
+}
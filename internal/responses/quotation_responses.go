@@ -9,6 +9,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// QuotationApprovalResponse lists anything about the approved quotation
+// worth a second look, such as a client going over its credit limit.
+type QuotationApprovalResponse struct {
+	QuotationID uuid.UUID `json:"quotation_id"`
+	GrandTotal  float64   `json:"grand_total"`
+	Warnings    []string  `json:"warnings"`
+}
+
 type QuotationResponse struct {
 	QuotationID        uuid.UUID            `json:"quotation_id"`
 	Status             string               `json:"status"`
@@ -17,6 +25,16 @@ type QuotationResponse struct {
 	SellingGeneralCost float64              `json:"selling_general_cost"`
 	Jobs               []QuotationJobDetail `json:"jobs"`
 	Costs              []GeneralCostDetail  `json:"general_costs"`
+	TransportCost      float64              `json:"transport_cost"`
+
+	// Pre-tax totals by VAT treatment, and the tax on TaxableAmount.
+	TaxableAmount   float64 `json:"taxable_amount"`
+	ZeroRatedAmount float64 `json:"zero_rated_amount"`
+	ExemptAmount    float64 `json:"exempt_amount"`
+	TaxAmount       float64 `json:"tax_amount"`
+
+	RoundingAdjustment float64 `json:"rounding_adjustment"`
+	GrandTotal         float64 `json:"grand_total"`
 }
 
 type QuotationJobDetail struct {
@@ -31,6 +49,8 @@ type QuotationJobDetail struct {
 	Total              float64 `json:"total"`
 	OverallCost        float64 `json:"overall_cost"`
 	TotalSellingPrice  float64 `json:"total_selling_price"`
+	TransportCost      float64 `json:"transport_cost"`
+	TaxTreatment       string  `json:"tax_treatment"`
 }
 
 type GeneralCostDetail struct {
@@ -58,11 +78,29 @@ type QuotationExportData struct {
 
 	SubTotal  float64 `json:"sub_total"`
 	TaxAmount float64 `json:"tax_amount"`
+	// RoundingAdjustment brings SubTotal + TaxAmount to the final amount
+	// when the rounding policy rounds totals.
+	RoundingAdjustment float64 `json:"rounding_adjustment"`
 
-	JobDetails []JobDetail `json:"jobs"`
+	// SubTotal split by VAT treatment; tax is charged on TaxableAmount.
+	TaxableAmount   float64 `json:"taxable_amount"`
+	ZeroRatedAmount float64 `json:"zero_rated_amount"`
+	ExemptAmount    float64 `json:"exempt_amount"`
+
+	JobDetails     []JobDetail                `json:"jobs"`
+	PhaseSubtotals []QuotationPhaseSubtotal   `json:"phase_subtotals"`
+	Sections       []QuotationSectionResponse `json:"sections"`
 
 	SellingGeneralCost   float64  `json:"selling_general_cost"`
+	TransportCost        float64  `json:"transport_cost"`
 	FormattedFinalAmount *float64 `json:"final_amount"`
+
+	// Language and Labels are what the document is printed with.
+	Language string            `json:"language"`
+	Labels   map[string]string `json:"labels"`
+	// Dates are the document's dates as printed, in the company's era.
+	DateEra string            `json:"date_era"`
+	Dates   map[string]string `json:"dates"`
 }
 
 func (q *QuotationExportData) FormatFinalAmount() {
@@ -73,13 +111,24 @@ func (q *QuotationExportData) FormatFinalAmount() {
 	}
 }
 
+// QuotationPhaseSubtotal is the quoted amount of one phase. Jobs without a
+// phase are grouped under an empty PhaseName.
+type QuotationPhaseSubtotal struct {
+	PhaseName string  `json:"phase_name"`
+	Amount    float64 `json:"amount"`
+}
+
 type JobDetail struct {
+	PhaseName    string          `json:"phase_name" db:"phase_name"`
 	Name         string          `json:"name" db:"name"`
 	Description  string          `json:"description" db:"description"`
 	Unit         string          `json:"unit" db:"unit"`
 	Quantity     float64         `json:"quantity" db:"quantity"`
 	SellingPrice sql.NullFloat64 `json:"-" db:"selling_price"`
 	Amount       sql.NullFloat64 `json:"-" db:"amount"`
+	// TransportCost is charged on top of Amount.
+	TransportCost float64 `json:"transport_cost" db:"transport_cost"`
+	TaxTreatment  string  `json:"tax_treatment" db:"tax_treatment"`
 
 	FormattedSellingPrice *float64 `json:"selling_price"`
 	FormattedAmount       *float64 `json:"amount"`
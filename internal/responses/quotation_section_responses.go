@@ -0,0 +1,23 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuotationSectionResponse struct {
+	Kind      string    `json:"kind"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type QuotationSnippetResponse struct {
+	SnippetID uuid.UUID  `json:"snippet_id"`
+	Kind      string     `json:"kind"`
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,83 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectReconciliationResponse follows a project's money from estimate to
+// cash: what the BOQ costs, what was quoted, what the contract and its
+// change orders are worth, and what has been invoiced and paid. Issues
+// lists anything that doesn't add up.
+type ProjectReconciliationResponse struct {
+	ProjectID uuid.UUID               `json:"project_id"`
+	AsOf      string                  `json:"as_of"`
+	Estimate  ReconciliationEstimate  `json:"estimate"`
+	Quotation ReconciliationQuotation `json:"quotation"`
+	Contract  ReconciliationContract  `json:"contract"`
+	Billing   ReconciliationBilling   `json:"billing"`
+	Issues    []ReconciliationIssue   `json:"issues"`
+}
+
+type ReconciliationEstimate struct {
+	BOQID             *uuid.UUID `json:"boq_id,omitempty"`
+	Status            string     `json:"status,omitempty"`
+	EstimatedCost     float64    `json:"estimated_cost"`
+	UnpricedMaterials int        `json:"unpriced_materials"`
+}
+
+type ReconciliationQuotation struct {
+	QuotationID   *uuid.UUID `json:"quotation_id,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	SellingPrice  float64    `json:"selling_price"`
+	TaxPercentage float64    `json:"tax_percentage"`
+	QuotedAmount  float64    `json:"quoted_amount"`
+	// EstimatedMargin is the selling price's margin over the estimated
+	// cost, in percent.
+	EstimatedMargin float64 `json:"estimated_margin"`
+}
+
+type ReconciliationContract struct {
+	InForce          bool                        `json:"in_force"`
+	BaseValue        float64                     `json:"base_value"`
+	ChangeOrders     []ReconciliationChangeOrder `json:"change_orders"`
+	ChangeOrderTotal float64                     `json:"change_order_total"`
+	ContractValue    float64                     `json:"contract_value"`
+}
+
+type ReconciliationChangeOrder struct {
+	DocumentID    uuid.UUID `json:"document_id"`
+	Title         string    `json:"title"`
+	EffectiveDate string    `json:"effective_date"`
+	Amount        float64   `json:"amount"`
+}
+
+// ReconciliationBilling compares invoices with the agreed value, which is
+// the contract value once a contract is in force and the quoted amount
+// before. Late interest is billed on top of the agreed value, so it is
+// left out of RemainingToInvoice.
+type ReconciliationBilling struct {
+	AgreedValue          float64                 `json:"agreed_value"`
+	InvoicedToDate       float64                 `json:"invoiced_to_date"`
+	LateInterestInvoiced float64                 `json:"late_interest_invoiced"`
+	PaidToDate           float64                 `json:"paid_to_date"`
+	Outstanding          float64                 `json:"outstanding"`
+	RemainingToInvoice   float64                 `json:"remaining_to_invoice"`
+	Invoices             []ReconciliationInvoice `json:"invoices"`
+}
+
+type ReconciliationInvoice struct {
+	InvoiceID    uuid.UUID  `json:"invoice_id"`
+	PhaseID      *uuid.UUID `json:"phase_id,omitempty"`
+	Amount       *float64   `json:"amount"`
+	LateInterest float64    `json:"late_interest"`
+	Paid         float64    `json:"paid"`
+	Outstanding  float64    `json:"outstanding"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type ReconciliationIssue struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
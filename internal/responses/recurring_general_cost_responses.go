@@ -0,0 +1,23 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurringGeneralCostResponse includes what has been posted so far.
+// RemainingAmount is nil for costs that run until the project closes.
+type RecurringGeneralCostResponse struct {
+	RecurringID     uuid.UUID `json:"recurring_id"`
+	ProjectID       uuid.UUID `json:"project_id"`
+	TypeName        string    `json:"type_name"`
+	Description     string    `json:"description"`
+	MonthlyAmount   float64   `json:"monthly_amount"`
+	StartPeriod     string    `json:"start_period"`
+	EndPeriod       *string   `json:"end_period"`
+	PostedPeriods   []string  `json:"posted_periods"`
+	PostedAmount    float64   `json:"posted_amount"`
+	RemainingAmount *float64  `json:"remaining_amount"`
+	CreatedAt       time.Time `json:"created_at"`
+}
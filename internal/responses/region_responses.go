@@ -0,0 +1,12 @@
+package responses
+
+import "github.com/google/uuid"
+
+type RegionPresetResponse struct {
+	RegionID             uuid.UUID `json:"region_id"`
+	Code                 string    `json:"code"`
+	Name                 string    `json:"name"`
+	DailyLaborRate       float64   `json:"daily_labor_rate"`
+	LaborRateFactor      float64   `json:"labor_rate_factor"`
+	TransportCostPercent float64   `json:"transport_cost_percent"`
+}
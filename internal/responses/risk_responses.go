@@ -0,0 +1,40 @@
+package responses
+
+import (
+	"boonkosang/internal/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RiskResponse struct {
+	RiskID      uuid.UUID         `json:"risk_id"`
+	ProjectID   uuid.UUID         `json:"project_id"`
+	Description string            `json:"description"`
+	Probability int               `json:"probability"`
+	Impact      int               `json:"impact"`
+	Score       int               `json:"score"`
+	Level       models.RiskLevel  `json:"level"`
+	Mitigation  string            `json:"mitigation"`
+	Owner       string            `json:"owner"`
+	Status      models.RiskStatus `json:"status"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   *time.Time        `json:"updated_at"`
+}
+
+type RiskHeatMapCell struct {
+	Probability int              `json:"probability"`
+	Impact      int              `json:"impact"`
+	Level       models.RiskLevel `json:"level"`
+	Count       int              `json:"count"`
+}
+
+// RiskHeatMapResponse summarises the project's risks that are not closed.
+type RiskHeatMapResponse struct {
+	ProjectID       uuid.UUID         `json:"project_id"`
+	Cells           []RiskHeatMapCell `json:"cells"`
+	High            int               `json:"high"`
+	Medium          int               `json:"medium"`
+	Low             int               `json:"low"`
+	UnmitigatedHigh int               `json:"unmitigated_high"`
+}
@@ -0,0 +1,56 @@
+package responses
+
+import (
+	"boonkosang/internal/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SafetyIncidentResponse struct {
+	IncidentID    uuid.UUID                  `json:"incident_id"`
+	ProjectID     uuid.UUID                  `json:"project_id"`
+	Type          models.SafetyIncidentType  `json:"type"`
+	Severity      models.SafetySeverity      `json:"severity"`
+	OccurredAt    time.Time                  `json:"occurred_at"`
+	Location      string                     `json:"location"`
+	Description   string                     `json:"description"`
+	InjuredName   string                     `json:"injured_name"`
+	InjuredRole   string                     `json:"injured_role"`
+	InjuryDetails string                     `json:"injury_details"`
+	LostDays      int                        `json:"lost_days"`
+	ReportedBy    string                     `json:"reported_by"`
+	Actions       []CorrectiveActionResponse `json:"corrective_actions"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+type CorrectiveActionResponse struct {
+	ActionID    uuid.UUID  `json:"action_id"`
+	Description string     `json:"description"`
+	Owner       string     `json:"owner"`
+	DueDate     string     `json:"due_date,omitempty"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type SafetyMonthlyStatResponse struct {
+	Month        string  `json:"month"` // YYYY-MM
+	Incidents    int     `json:"incidents"`
+	NearMisses   int     `json:"near_misses"`
+	Minor        int     `json:"minor"`
+	Moderate     int     `json:"moderate"`
+	Serious      int     `json:"serious"`
+	Fatal        int     `json:"fatal"`
+	LostDays     int     `json:"lost_days"`
+	ManHours     float64 `json:"man_hours"`
+	IncidentRate float64 `json:"incident_rate"` // incidents per 200,000 man-hours
+}
+
+type SafetyStatisticsResponse struct {
+	Year         int                         `json:"year"`
+	ProjectID    *uuid.UUID                  `json:"project_id,omitempty"`
+	Months       []SafetyMonthlyStatResponse `json:"months"`
+	Incidents    int                         `json:"incidents"`
+	NearMisses   int                         `json:"near_misses"`
+	ManHours     float64                     `json:"man_hours"`
+	IncidentRate float64                     `json:"incident_rate"`
+}
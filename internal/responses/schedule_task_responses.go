@@ -0,0 +1,58 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleTaskResponse struct {
+	TaskID    uuid.UUID  `json:"task_id"`
+	ProjectID uuid.UUID  `json:"project_id"`
+	PhaseID   *uuid.UUID `json:"phase_id"`
+	CrewID    *uuid.UUID `json:"crew_id"`
+	Name      string     `json:"name"`
+	StartDate string     `json:"start_date"`
+	EndDate   string     `json:"end_date"`
+	Workers   int        `json:"workers"`
+	Outdoor   bool       `json:"outdoor"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ScheduleWhatIfResponse compares the schedule as planned with the same
+// tasks under an adjusted crew size and productivity. Labor cost is worker
+// days at DailyLaborRate.
+type ScheduleWhatIfResponse struct {
+	ProjectID           uuid.UUID                `json:"project_id"`
+	CrewSizePercent     float64                  `json:"crew_size_percent"`
+	ProductivityPercent float64                  `json:"productivity_percent"`
+	DailyLaborRate      float64                  `json:"daily_labor_rate"`
+	Baseline            ScheduleScenarioResponse `json:"baseline"`
+	Scenario            ScheduleScenarioResponse `json:"scenario"`
+	DaysChange          int                      `json:"days_change"`
+	LaborCostChange     float64                  `json:"labor_cost_change"`
+	Tasks               []WhatIfTaskResponse     `json:"tasks"`
+}
+
+type ScheduleScenarioResponse struct {
+	StartDate  string  `json:"start_date"`
+	EndDate    string  `json:"end_date"`
+	Days       int     `json:"days"`
+	WorkerDays int     `json:"worker_days"`
+	LaborCost  float64 `json:"labor_cost"`
+}
+
+// WhatIfTaskResponse is one task before and after the adjustment.
+// OverCapacity flags tasks that would need more workers than their
+// assigned crew can field.
+type WhatIfTaskResponse struct {
+	TaskID            uuid.UUID `json:"task_id"`
+	Name              string    `json:"name"`
+	Workers           int       `json:"workers"`
+	StartDate         string    `json:"start_date"`
+	EndDate           string    `json:"end_date"`
+	AdjustedWorkers   int       `json:"adjusted_workers"`
+	AdjustedStartDate string    `json:"adjusted_start_date"`
+	AdjustedEndDate   string    `json:"adjusted_end_date"`
+	OverCapacity      bool      `json:"over_capacity"`
+}
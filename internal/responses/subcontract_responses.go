@@ -0,0 +1,100 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RetentionBalanceResponse struct {
+	Retained  float64 `json:"retained"`
+	Released  float64 `json:"released"`
+	Held      float64 `json:"held"`
+	Pending   float64 `json:"pending"`
+	Available float64 `json:"available"`
+}
+
+type SubcontractResponse struct {
+	SubcontractID          uuid.UUID                `json:"subcontract_id"`
+	ProjectID              uuid.UUID                `json:"project_id"`
+	SupplierID             uuid.UUID                `json:"supplier_id"`
+	Scope                  string                   `json:"scope"`
+	ContractValue          float64                  `json:"contract_value"`
+	RetentionPercent       float64                  `json:"retention_percent"`
+	AdvanceAmount          float64                  `json:"advance_amount"`
+	AdvanceRecoveryPercent float64                  `json:"advance_recovery_percent"`
+	AdvanceRecovered       float64                  `json:"advance_recovered"`
+	DLPMonths              int                      `json:"dlp_months"`
+	CompletedAt            string                   `json:"completed_at,omitempty"`
+	DLPEndsAt              string                   `json:"dlp_ends_at,omitempty"`
+	Certified              float64                  `json:"certified"`
+	Retention              RetentionBalanceResponse `json:"retention"`
+	CreatedAt              time.Time                `json:"created_at"`
+}
+
+type SubcontractCertificateResponse struct {
+	CertificateID   uuid.UUID  `json:"certificate_id"`
+	SubcontractID   uuid.UUID  `json:"subcontract_id"`
+	ClaimID         *uuid.UUID `json:"claim_id,omitempty"`
+	CertificateNo   int        `json:"certificate_no"`
+	GrossAmount     float64    `json:"gross_amount"`
+	RetentionAmount float64    `json:"retention_amount"`
+	AdvanceRecovery float64    `json:"advance_recovery"`
+	BackCharges     float64    `json:"back_charges"`
+	NetAmount       float64    `json:"net_amount"`
+	Note            string     `json:"note,omitempty"`
+	CertifiedBy     uuid.UUID  `json:"certified_by"`
+	CertifiedAt     time.Time  `json:"certified_at"`
+}
+
+type RetentionReleaseResponse struct {
+	ReleaseID     uuid.UUID  `json:"release_id"`
+	SubcontractID uuid.UUID  `json:"subcontract_id"`
+	Amount        float64    `json:"amount"`
+	Status        string     `json:"status"`
+	Note          string     `json:"note,omitempty"`
+	RequestedBy   uuid.UUID  `json:"requested_by"`
+	RequestedAt   time.Time  `json:"requested_at"`
+	DecidedBy     *uuid.UUID `json:"decided_by,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+}
+
+type SubcontractItemResponse struct {
+	ItemID            uuid.UUID `json:"item_id"`
+	Description       string    `json:"description"`
+	Unit              string    `json:"unit"`
+	Quantity          float64   `json:"quantity"`
+	Rate              float64   `json:"rate"`
+	Amount            float64   `json:"amount"`
+	CertifiedQuantity float64   `json:"certified_quantity"`
+	PendingQuantity   float64   `json:"pending_quantity"`
+	CertifiedAmount   float64   `json:"certified_amount"`
+}
+
+type SubcontractClaimLineResponse struct {
+	ItemID            uuid.UUID `json:"item_id"`
+	Description       string    `json:"description"`
+	Unit              string    `json:"unit"`
+	Rate              float64   `json:"rate"`
+	ClaimedQuantity   float64   `json:"claimed_quantity"`
+	ClaimedAmount     float64   `json:"claimed_amount"`
+	CertifiedQuantity *float64  `json:"certified_quantity,omitempty"`
+	CertifiedAmount   *float64  `json:"certified_amount,omitempty"`
+}
+
+type SubcontractClaimResponse struct {
+	ClaimID       uuid.UUID                       `json:"claim_id"`
+	SubcontractID uuid.UUID                       `json:"subcontract_id"`
+	ClaimNo       int                             `json:"claim_no"`
+	Status        string                          `json:"status"`
+	ClaimantName  string                          `json:"claimant_name,omitempty"`
+	Note          string                          `json:"note,omitempty"`
+	SubmittedBy   uuid.UUID                       `json:"submitted_by"`
+	SubmittedAt   time.Time                       `json:"submitted_at"`
+	DecidedBy     *uuid.UUID                      `json:"decided_by,omitempty"`
+	DecidedAt     *time.Time                      `json:"decided_at,omitempty"`
+	DecisionNote  string                          `json:"decision_note,omitempty"`
+	ClaimedAmount float64                         `json:"claimed_amount"`
+	Lines         []SubcontractClaimLineResponse  `json:"lines"`
+	Certificate   *SubcontractCertificateResponse `json:"certificate,omitempty"`
+}
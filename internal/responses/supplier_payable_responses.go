@@ -0,0 +1,44 @@
+package responses
+
+import "github.com/google/uuid"
+
+// SupplierCreditTermsResponse is a supplier's credit terms. Default is set
+// when none have been recorded and bills fall due on the invoice date.
+type SupplierCreditTermsResponse struct {
+	SupplierID uuid.UUID `json:"supplier_id"`
+	CreditDays int       `json:"credit_days"`
+	Basis      string    `json:"basis"`
+	EndOfMonth bool      `json:"end_of_month"`
+	Default    bool      `json:"default"`
+}
+
+type SupplierPayableResponse struct {
+	PayableID     uuid.UUID  `json:"payable_id"`
+	SupplierID    uuid.UUID  `json:"supplier_id"`
+	SupplierName  string     `json:"supplier_name,omitempty"`
+	ProjectID     *uuid.UUID `json:"project_id"`
+	InvoiceNumber string     `json:"invoice_number"`
+	InvoiceDate   string     `json:"invoice_date"`
+	ReceivedDate  *string    `json:"received_date"`
+	Amount        float64    `json:"amount"`
+	DueDate       string     `json:"due_date"`
+	PaidDate      *string    `json:"paid_date"`
+	Overdue       bool       `json:"overdue"`
+}
+
+// PaymentCalendarResponse lists the unpaid supplier bills falling due in
+// a week, Monday to Sunday, and those already overdue before it.
+type PaymentCalendarResponse struct {
+	WeekStart    string                    `json:"week_start"`
+	WeekEnd      string                    `json:"week_end"`
+	Overdue      []SupplierPayableResponse `json:"overdue"`
+	OverdueTotal float64                   `json:"overdue_total"`
+	Days         []PaymentCalendarDay      `json:"days"`
+	WeekTotal    float64                   `json:"week_total"`
+}
+
+type PaymentCalendarDay struct {
+	Date     string                    `json:"date"`
+	Payables []SupplierPayableResponse `json:"payables"`
+	Total    float64                   `json:"total"`
+}
@@ -2,16 +2,20 @@ package responses
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type SupplierResponse struct {
-	ID      uuid.UUID       `json:"id"`
-	Name    string          `json:"name"`
-	Email   string          `json:"email"`
-	Tel     string          `json:"tel"`
-	Address json.RawMessage `json:"address"`
+	ID              uuid.UUID       `json:"id"`
+	Name            string          `json:"name"`
+	Email           string          `json:"email"`
+	Tel             string          `json:"tel"`
+	Address         json.RawMessage `json:"address"`
+	Status          string          `json:"status"`
+	StatusReason    string          `json:"status_reason,omitempty"`
+	StatusUpdatedAt *time.Time      `json:"status_updated_at,omitempty"`
 }
 
 type SupplierListResponse struct {
@@ -0,0 +1,70 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SurveyQuestionResponse struct {
+	Key      string `json:"key"`
+	Text     string `json:"text"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+type SurveyTemplateResponse struct {
+	TemplateID uuid.UUID                `json:"template_id"`
+	Name       string                   `json:"name"`
+	Questions  []SurveyQuestionResponse `json:"questions"`
+	IsDefault  bool                     `json:"is_default"`
+	CreatedAt  time.Time                `json:"created_at"`
+}
+
+type SurveyAnswerResponse struct {
+	Key   string `json:"key"`
+	Score *int   `json:"score,omitempty"`
+	Text  string `json:"text,omitempty"`
+}
+
+type SurveyResponse struct {
+	SurveyID   uuid.UUID  `json:"survey_id"`
+	ProjectID  uuid.UUID  `json:"project_id"`
+	TemplateID uuid.UUID  `json:"template_id"`
+	ManagerID  *uuid.UUID `json:"project_manager_id,omitempty"`
+	SentAt     time.Time  `json:"sent_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+
+	// SurveyURL is the public link sent to the client. It is only present
+	// until the survey is completed.
+	SurveyURL string `json:"survey_url,omitempty"`
+
+	Completed     bool                   `json:"completed"`
+	RespondedAt   *time.Time             `json:"responded_at,omitempty"`
+	NPSScore      *int                   `json:"nps_score,omitempty"`
+	AverageRating *float64               `json:"average_rating,omitempty"`
+	Answers       []SurveyAnswerResponse `json:"answers,omitempty"`
+}
+
+// PublicSurveyResponse is what the client sees through the survey link.
+type PublicSurveyResponse struct {
+	ProjectName string                   `json:"project_name"`
+	Questions   []SurveyQuestionResponse `json:"questions"`
+	Completed   bool                     `json:"completed"`
+}
+
+type SurveyReportLine struct {
+	ManagerID     *uuid.UUID `json:"project_manager_id,omitempty"`
+	ManagerName   string     `json:"project_manager_name,omitempty"`
+	Year          int        `json:"year"`
+	Responses     int        `json:"responses"`
+	NPSResponses  int        `json:"nps_responses"`
+	NPS           *float64   `json:"nps,omitempty"`
+	AverageRating *float64   `json:"average_rating,omitempty"`
+}
+
+type SurveyReportResponse struct {
+	Year    int                `json:"year,omitempty"`
+	Overall []SurveyReportLine `json:"overall"`
+	Lines   []SurveyReportLine `json:"by_project_manager"`
+}
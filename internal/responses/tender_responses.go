@@ -0,0 +1,54 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TenderResponse struct {
+	TenderID           uuid.UUID  `json:"tender_id"`
+	ProjectID          *uuid.UUID `json:"project_id,omitempty"`
+	Title              string     `json:"title"`
+	Agency             string     `json:"agency"`
+	ReferenceNumber    string     `json:"reference_number,omitempty"`
+	SubmissionDeadline time.Time  `json:"submission_deadline"`
+	// DaysUntilDeadline is negative once the deadline has passed.
+	DaysUntilDeadline int        `json:"days_until_deadline"`
+	BondAmount        float64    `json:"bond_amount"`
+	Status            string     `json:"status"`
+	BidTotal          float64    `json:"bid_total"`
+	ChecklistDone     int        `json:"checklist_done"`
+	ChecklistTotal    int        `json:"checklist_total"`
+	SubmittedAt       *time.Time `json:"submitted_at,omitempty"`
+	WinningBidder     string     `json:"winning_bidder,omitempty"`
+	WinningAmount     *float64   `json:"winning_amount,omitempty"`
+	OutcomeNotes      string     `json:"outcome_notes,omitempty"`
+	OutcomeAt         *time.Time `json:"outcome_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+type TenderDocumentResponse struct {
+	DocumentID uuid.UUID `json:"document_id"`
+	Name       string    `json:"name"`
+	Required   bool      `json:"required"`
+	Completed  bool      `json:"completed"`
+	FileURL    string    `json:"file_url,omitempty"`
+	SortOrder  int       `json:"sort_order"`
+}
+
+type TenderBidLineResponse struct {
+	LineID      uuid.UUID `json:"line_id"`
+	Description string    `json:"description"`
+	Unit        string    `json:"unit"`
+	Quantity    float64   `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	Amount      float64   `json:"amount"`
+}
+
+type TenderDetailResponse struct {
+	TenderResponse
+	Checklist []TenderDocumentResponse `json:"checklist"`
+	Worksheet []TenderBidLineResponse  `json:"worksheet"`
+}
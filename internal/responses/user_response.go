@@ -1,6 +1,8 @@
 package responses
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -11,9 +13,104 @@ type UserResponse struct {
 	LastName  string    `json:"last_name"`
 	Email     string    `json:"email"`
 	Tel       string    `json:"tel"`
+	Role      string    `json:"role"`
+	AvatarURL string    `json:"avatar_url,omitempty"`
+	IsActive  bool      `json:"is_active"`
+
+	// PendingEmail is set while an email change awaits verification.
+	PendingEmail string `json:"pending_email,omitempty"`
+
+	// PasswordChangeRequired means an admin has asked the user to choose
+	// a new password before doing anything else.
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
+}
+
+type UserListResponse struct {
+	Users    []UserResponse `json:"users"`
+	Total    int64          `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+// EmailChangeResponse is the address awaiting verification and when the
+// link sent to it expires.
+type EmailChangeResponse struct {
+	PendingEmail string    `json:"pending_email"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// InvitationResponse is the invited user. InviteURL is only returned when
+// the invitation couldn't be emailed, for the admin to pass on.
+type InvitationResponse struct {
+	InvitationID uuid.UUID    `json:"invitation_id"`
+	User         UserResponse `json:"user"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+	EmailSent    bool         `json:"email_sent"`
+	InviteURL    string       `json:"invite_url,omitempty"`
 }
 
 type LoginResponse struct {
-	AccessToken string       `json:"access_token"`
-	User        UserResponse `json:"user"` // Changed from lowercase to uppercase for export
+	AccessToken           string       `json:"access_token"`
+	RefreshToken          string       `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time    `json:"refresh_token_expires_at"`
+	User                  UserResponse `json:"user"` // Changed from lowercase to uppercase for export
+}
+
+type ImpersonationResponse struct {
+	AccessToken    string       `json:"access_token"`
+	ExpiresAt      time.Time    `json:"expires_at"`
+	ImpersonatorID uuid.UUID    `json:"impersonator_id"`
+	User           UserResponse `json:"user"`
+}
+
+type ImpersonationSessionResponse struct {
+	SessionID      uuid.UUID `json:"session_id"`
+	ImpersonatorID uuid.UUID `json:"impersonator_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Reason         string    `json:"reason"`
+	StartedAt      time.Time `json:"started_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+type LoginAuditResponse struct {
+	AuditID       uuid.UUID `json:"audit_id"`
+	Method        string    `json:"method"`
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	Device        string    `json:"device"`
+	Fingerprint   string    `json:"fingerprint,omitempty"`
+	AttemptedAt   time.Time `json:"attempted_at"`
+}
+
+// UserSessionResponse is a device the user is signed in on. Current marks
+// the session making the request.
+type UserSessionResponse struct {
+	SessionID   uuid.UUID `json:"session_id"`
+	Device      string    `json:"device"`
+	Method      string    `json:"method"`
+	Fingerprint string    `json:"fingerprint"`
+	IPAddress   string    `json:"ip_address"`
+	UserAgent   string    `json:"user_agent"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Current     bool      `json:"current"`
+}
+
+type APIKeyResponse struct {
+	KeyID      uuid.UUID  `json:"key_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// CreatedAPIKeyResponse is the only time the key itself is returned.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
 }
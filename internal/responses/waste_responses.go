@@ -0,0 +1,50 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WasteRecordResponse struct {
+	WasteID       uuid.UUID `json:"waste_id"`
+	ProjectID     uuid.UUID `json:"project_id"`
+	MaterialID    string    `json:"material_id"`
+	MaterialName  string    `json:"material_name,omitempty"`
+	Unit          string    `json:"unit,omitempty"`
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name,omitempty"`
+	Quantity      float64   `json:"quantity"`
+	Reason        string    `json:"reason"`
+	Note          string    `json:"note,omitempty"`
+	RecordedDate  string    `json:"recorded_date"`
+}
+
+// WasteSummaryResponse compares a project's waste of a material with its
+// allowance. Exceeded is set once the waste is over the allowed quantity.
+type WasteSummaryResponse struct {
+	ProjectID        uuid.UUID `json:"project_id"`
+	ProjectName      string    `json:"project_name"`
+	MaterialID       string    `json:"material_id"`
+	MaterialName     string    `json:"material_name"`
+	Unit             string    `json:"unit"`
+	BOQQuantity      float64   `json:"boq_quantity"`
+	WastedQuantity   float64   `json:"wasted_quantity"`
+	WastePercent     float64   `json:"waste_percent"`
+	AllowancePercent float64   `json:"allowance_percent"`
+	AllowedQuantity  float64   `json:"allowed_quantity"`
+	Exceeded         bool      `json:"exceeded"`
+}
+
+// WastageAllowanceResponse lists materials with their own allowance along
+// with the default that applies to the rest.
+type WastageAllowanceResponse struct {
+	DefaultPercent float64                  `json:"default_percent"`
+	Materials      []MaterialAllowanceEntry `json:"materials"`
+}
+
+type MaterialAllowanceEntry struct {
+	MaterialID string    `json:"material_id"`
+	Percent    float64   `json:"percent"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
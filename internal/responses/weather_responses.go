@@ -0,0 +1,18 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WeatherRiskResponse struct {
+	TaskID                   uuid.UUID `json:"task_id"`
+	TaskName                 string    `json:"task_name"`
+	ProjectID                uuid.UUID `json:"project_id"`
+	ProjectName              string    `json:"project_name"`
+	Date                     string    `json:"date"`
+	PrecipitationMM          float64   `json:"precipitation_mm"`
+	PrecipitationProbability *float64  `json:"precipitation_probability"`
+	ForecastFetchedAt        time.Time `json:"forecast_fetched_at"`
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountingPeriodUseCase interface {
+	ListLocks(ctx context.Context) ([]responses.AccountingPeriodLockResponse, error)
+	// Lock closes period (YYYY-MM) to payments, invoices and expenses dated
+	// in it. Only owners may lock or unlock periods.
+	Lock(ctx context.Context, userID uuid.UUID, period string) (*responses.AccountingPeriodLockResponse, error)
+	Unlock(ctx context.Context, userID uuid.UUID, period string, req requests.UnlockAccountingPeriodRequest) error
+	ListEvents(ctx context.Context, userID uuid.UUID) ([]responses.AccountingPeriodEventResponse, error)
+}
+
+type accountingPeriodUseCase struct {
+	periodRepo repositories.AccountingPeriodRepository
+	userRepo   repositories.UserRepository
+}
+
+func NewAccountingPeriodUsecase(
+	periodRepo repositories.AccountingPeriodRepository,
+	userRepo repositories.UserRepository,
+) AccountingPeriodUseCase {
+	return &accountingPeriodUseCase{
+		periodRepo: periodRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (u *accountingPeriodUseCase) ListLocks(ctx context.Context) ([]responses.AccountingPeriodLockResponse, error) {
+	locks, err := u.periodRepo.ListLocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.AccountingPeriodLockResponse, len(locks))
+	for i, lock := range locks {
+		result[i] = responses.AccountingPeriodLockResponse{
+			Period:   lock.Period,
+			LockedAt: lock.LockedAt,
+			LockedBy: lock.LockedBy,
+		}
+	}
+	return result, nil
+}
+
+func (u *accountingPeriodUseCase) Lock(ctx context.Context, userID uuid.UUID, period string) (*responses.AccountingPeriodLockResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	start, err := time.ParseInLocation(models.AccountingPeriodLayout, period, models.AccountingPeriodLocation)
+	if err != nil {
+		return nil, errors.New("invalid period")
+	}
+	now := time.Now()
+	if start.After(now) {
+		return nil, errors.New("cannot lock a future period")
+	}
+
+	lock := models.AccountingPeriodLock{
+		Period:   period,
+		LockedAt: now,
+		LockedBy: userID,
+	}
+	event := models.AccountingPeriodEvent{
+		EventID:    uuid.New(),
+		Period:     period,
+		Action:     models.AccountingPeriodActionLock,
+		UserID:     userID,
+		OccurredAt: now,
+	}
+	if err := u.periodRepo.Lock(ctx, lock, event); err != nil {
+		return nil, err
+	}
+
+	return &responses.AccountingPeriodLockResponse{
+		Period:   lock.Period,
+		LockedAt: lock.LockedAt,
+		LockedBy: lock.LockedBy,
+	}, nil
+}
+
+func (u *accountingPeriodUseCase) Unlock(ctx context.Context, userID uuid.UUID, period string, req requests.UnlockAccountingPeriodRequest) error {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return err
+	}
+
+	if _, err := time.ParseInLocation(models.AccountingPeriodLayout, period, models.AccountingPeriodLocation); err != nil {
+		return errors.New("invalid period")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return errors.New("reason is required")
+	}
+
+	return u.periodRepo.Unlock(ctx, period, models.AccountingPeriodEvent{
+		EventID:    uuid.New(),
+		Period:     period,
+		Action:     models.AccountingPeriodActionUnlock,
+		UserID:     userID,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+}
+
+func (u *accountingPeriodUseCase) ListEvents(ctx context.Context, userID uuid.UUID) ([]responses.AccountingPeriodEventResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	events, err := u.periodRepo.ListEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.AccountingPeriodEventResponse, len(events))
+	for i, event := range events {
+		result[i] = responses.AccountingPeriodEventResponse{
+			EventID:    event.EventID,
+			Period:     event.Period,
+			Action:     string(event.Action),
+			UserID:     event.UserID,
+			Reason:     event.Reason,
+			OccurredAt: event.OccurredAt,
+		}
+	}
+	return result, nil
+}
+
+// checkPostingDate rejects a posting dated in a locked period.
+func checkPostingDate(ctx context.Context, periodRepo repositories.AccountingPeriodRepository, date time.Time) error {
+	locked, err := periodRepo.IsLocked(ctx, models.AccountingPeriodOf(date))
+	if err != nil {
+		return err
+	}
+	if locked {
+		return errors.New("accounting period is locked")
+	}
+	return nil
+}
+
+// openPostingDate returns date if its period is open, otherwise the start
+// of the first open period after it. It is for postings that can't be
+// refused, such as payment gateway notifications.
+func openPostingDate(ctx context.Context, periodRepo repositories.AccountingPeriodRepository, date time.Time) (time.Time, error) {
+	posting := date
+	for i := 0; i < 120; i++ {
+		locked, err := periodRepo.IsLocked(ctx, models.AccountingPeriodOf(posting))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !locked {
+			return posting, nil
+		}
+		local := posting.In(models.AccountingPeriodLocation)
+		posting = time.Date(local.Year(), local.Month()+1, 1, 0, 0, 0, 0, models.AccountingPeriodLocation)
+	}
+	return time.Time{}, errors.New("no open accounting period")
+}
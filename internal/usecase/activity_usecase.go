@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+type ActivityUseCase interface {
+	GetProjectTimeline(ctx context.Context, projectID uuid.UUID) (*responses.TimelineResponse, error)
+	// GetClientTimeline leaves out events of projects the caller can't
+	// access.
+	GetClientTimeline(ctx context.Context, clientID uuid.UUID) (*responses.TimelineResponse, error)
+	GetQuotationTimeline(ctx context.Context, projectID uuid.UUID) (*responses.TimelineResponse, error)
+	// GetUserActivity lists what userID has done. Users can review their
+	// own activity; managers can review anyone's.
+	GetUserActivity(ctx context.Context, actorID, userID uuid.UUID, page, pageSize int) (*responses.UserActivityResponse, error)
+}
+
+type activityUseCase struct {
+	activityRepo  repositories.ActivityRepository
+	projectRepo   repositories.ProjectRepository
+	clientRepo    repositories.ClientRepository
+	quotationRepo repositories.QuotationRepository
+	userRepo      repositories.UserRepository
+	access        projectAccess
+}
+
+func NewActivityUsecase(
+	activityRepo repositories.ActivityRepository,
+	projectRepo repositories.ProjectRepository,
+	clientRepo repositories.ClientRepository,
+	quotationRepo repositories.QuotationRepository,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) ActivityUseCase {
+	return &activityUseCase{
+		activityRepo:  activityRepo,
+		projectRepo:   projectRepo,
+		clientRepo:    clientRepo,
+		quotationRepo: quotationRepo,
+		userRepo:      userRepo,
+		access:        projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *activityUseCase) GetProjectTimeline(ctx context.Context, projectID uuid.UUID) (*responses.TimelineResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	events, err := u.activityRepo.GetProjectTimeline(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTimelineResponse(events), nil
+}
+
+func (u *activityUseCase) GetClientTimeline(ctx context.Context, clientID uuid.UUID) (*responses.TimelineResponse, error) {
+	if _, err := u.clientRepo.GetByID(ctx, clientID); err != nil {
+		return nil, err
+	}
+
+	visible, err := u.access.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := u.activityRepo.GetClientTimeline(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	shown := events[:0]
+	for _, event := range events {
+		if !event.ProjectID.Valid || visible(event.ProjectID.UUID) {
+			shown = append(shown, event)
+		}
+	}
+	return toTimelineResponse(shown), nil
+}
+
+func (u *activityUseCase) GetQuotationTimeline(ctx context.Context, projectID uuid.UUID) (*responses.TimelineResponse, error) {
+	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quotation == nil {
+		return nil, errors.New("quotation not found")
+	}
+
+	events, err := u.activityRepo.GetQuotationTimeline(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTimelineResponse(events), nil
+}
+
+func (u *activityUseCase) GetUserActivity(ctx context.Context, actorID, userID uuid.UUID, page, pageSize int) (*responses.UserActivityResponse, error) {
+	if actorID != userID {
+		actor, err := u.userRepo.GetByID(ctx, actorID)
+		if err != nil {
+			return nil, err
+		}
+		if !actor.Role.IsManager() {
+			return nil, errors.New("only managers can review other users' activity")
+		}
+	}
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	events, total, err := u.activityRepo.ListByActor(ctx, userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.UserActivityResponse{
+		UserID:   userID,
+		Events:   make([]responses.UserActivityEventItem, len(events)),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for i, event := range events {
+		response.Events[i] = responses.UserActivityEventItem{
+			EventID:        event.EventID,
+			EntityType:     string(event.EntityType),
+			EntityID:       event.EntityID,
+			ProjectID:      nullUUIDPtr(event.ProjectID),
+			ClientID:       nullUUIDPtr(event.ClientID),
+			EventType:      event.EventType,
+			Description:    event.Description,
+			OccurredAt:     event.OccurredAt,
+			ImpersonatorID: nullUUIDPtr(event.ImpersonatorID),
+		}
+	}
+	return response, nil
+}
+
+func toTimelineResponse(events []models.TimelineEvent) *responses.TimelineResponse {
+	response := &responses.TimelineResponse{
+		Events: make([]responses.TimelineEventResponse, len(events)),
+	}
+	for i, event := range events {
+		response.Events[i] = responses.TimelineEventResponse{
+			EntityType:  string(event.EntityType),
+			EntityID:    event.EntityID,
+			EventType:   event.EventType,
+			Description: event.Description,
+			OccurredAt:  event.OccurredAt,
+		}
+		if event.ProjectID.Valid {
+			projectID := event.ProjectID.UUID
+			response.Events[i].ProjectID = &projectID
+		}
+	}
+	return response
+}
+
+// recordActivity saves a timeline event. A failure is logged rather than
+// returned so it never undoes the change being recorded.
+func recordActivity(ctx context.Context, repo repositories.ActivityRepository, event models.ActivityEvent) {
+	if repo == nil {
+		return
+	}
+	if !event.ActorID.Valid {
+		event.ActorID = actorFromContext(ctx)
+	}
+	if !event.ImpersonatorID.Valid {
+		event.ImpersonatorID = impersonatorFromContext(ctx)
+	}
+	if err := repo.Record(ctx, event); err != nil {
+		log.Printf("failed to record %s activity for %s %s: %v", event.EventType, event.EntityType, event.EntityID, err)
+	}
+}
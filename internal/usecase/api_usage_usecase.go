@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIUsageUseCase interface {
+	// Record counts a served request. It only touches memory; Flush
+	// writes the counts to the daily rollups.
+	Record(req models.APIRequest)
+	Flush(ctx context.Context) error
+
+	// GetReport totals usage between from and to (YYYY-MM-DD, inclusive;
+	// the last 30 days by default) by day, caller or route. Only owners
+	// and admins may see it.
+	GetReport(ctx context.Context, userID uuid.UUID, from, to, groupBy string) (*responses.APIUsageReportResponse, error)
+}
+
+type apiUsageKey struct {
+	date   string
+	userID uuid.NullUUID
+	keyID  uuid.NullUUID
+	route  string
+}
+
+// apiUsageUseCase buffers counts in memory so requests never wait on the
+// database; counts not yet flushed are lost if the instance stops.
+type apiUsageUseCase struct {
+	usageRepo repositories.APIUsageRepository
+	userRepo  repositories.UserRepository
+
+	mu      sync.Mutex
+	pending map[apiUsageKey]*models.APIUsage
+}
+
+func NewAPIUsageUsecase(usageRepo repositories.APIUsageRepository, userRepo repositories.UserRepository) APIUsageUseCase {
+	return &apiUsageUseCase{
+		usageRepo: usageRepo,
+		userRepo:  userRepo,
+		pending:   make(map[apiUsageKey]*models.APIUsage),
+	}
+}
+
+func (u *apiUsageUseCase) Record(req models.APIRequest) {
+	date := time.Date(req.At.Year(), req.At.Month(), req.At.Day(), 0, 0, 0, 0, time.UTC)
+	key := apiUsageKey{
+		date:   date.Format("2006-01-02"),
+		userID: req.UserID,
+		keyID:  req.KeyID,
+		route:  req.Route,
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage, ok := u.pending[key]
+	if !ok {
+		usage = &models.APIUsage{
+			UsageDate: date,
+			UserID:    req.UserID,
+			KeyID:     req.KeyID,
+			Route:     req.Route,
+		}
+		u.pending[key] = usage
+	}
+	addAPIRequest(usage, req)
+}
+
+func addAPIRequest(usage *models.APIUsage, req models.APIRequest) {
+	usage.RequestCount++
+	switch {
+	case req.Status >= 500:
+		usage.ServerErrors++
+	case req.Status >= 400:
+		usage.ClientErrors++
+	}
+	usage.BytesIn += req.BytesIn
+	usage.BytesOut += req.BytesOut
+}
+
+func (u *apiUsageUseCase) Flush(ctx context.Context) error {
+	u.mu.Lock()
+	pending := u.pending
+	u.pending = make(map[apiUsageKey]*models.APIUsage)
+	u.mu.Unlock()
+
+	usage := make([]models.APIUsage, 0, len(pending))
+	for _, p := range pending {
+		usage = append(usage, *p)
+	}
+	if err := u.usageRepo.AddUsage(ctx, usage); err != nil {
+		// Put the counts back so the next flush retries them.
+		u.mu.Lock()
+		for key, p := range pending {
+			if current, ok := u.pending[key]; ok {
+				current.RequestCount += p.RequestCount
+				current.ClientErrors += p.ClientErrors
+				current.ServerErrors += p.ServerErrors
+				current.BytesIn += p.BytesIn
+				current.BytesOut += p.BytesOut
+			} else {
+				u.pending[key] = p
+			}
+		}
+		u.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (u *apiUsageUseCase) GetReport(ctx context.Context, userID uuid.UUID, from, to, groupBy string) (*responses.APIUsageReportResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	grouping := models.APIUsageByDay
+	if groupBy != "" {
+		grouping = models.APIUsageGrouping(groupBy)
+	}
+	if !grouping.Valid() {
+		return nil, errors.New("group_by must be day, caller or route")
+	}
+
+	today := time.Now()
+	end := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		parsed, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return nil, errors.New("invalid to date")
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, -29)
+	if from != "" {
+		parsed, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return nil, errors.New("invalid from date")
+		}
+		start = parsed
+	}
+	if end.Before(start) {
+		return nil, errors.New("to date must not be before from date")
+	}
+
+	usage, err := u.usageRepo.ListUsage(ctx, start, end, grouping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]responses.APIUsageResponse, len(usage))
+	for i, row := range usage {
+		rows[i] = toAPIUsageResponse(row, grouping)
+	}
+	return &responses.APIUsageReportResponse{
+		From:    start.Format("2006-01-02"),
+		To:      end.Format("2006-01-02"),
+		GroupBy: string(grouping),
+		Rows:    rows,
+	}, nil
+}
+
+func toAPIUsageResponse(usage models.APIUsage, grouping models.APIUsageGrouping) responses.APIUsageResponse {
+	response := responses.APIUsageResponse{
+		Requests:     usage.RequestCount,
+		ClientErrors: usage.ClientErrors,
+		ServerErrors: usage.ServerErrors,
+		BytesIn:      usage.BytesIn,
+		BytesOut:     usage.BytesOut,
+	}
+	if usage.RequestCount > 0 {
+		rate := float64(usage.ClientErrors+usage.ServerErrors) / float64(usage.RequestCount)
+		response.ErrorRate = math.Round(rate*10000) / 10000
+	}
+
+	switch grouping {
+	case models.APIUsageByDay:
+		response.Date = usage.UsageDate.Format("2006-01-02")
+	case models.APIUsageByCaller:
+		if usage.UserID.Valid {
+			response.UserID = &usage.UserID.UUID
+		}
+		if usage.KeyID.Valid {
+			response.KeyID = &usage.KeyID.UUID
+		}
+	case models.APIUsageByRoute:
+		response.Route = usage.Route
+	}
+	return response
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AsBuiltUseCase interface {
+	// Reconcile compares every job in the project's BOQ with its as-built
+	// quantity.
+	Reconcile(ctx context.Context, projectID uuid.UUID) (*responses.AsBuiltReconciliationResponse, error)
+	// Record saves a completed job's as-built quantity and, for a change
+	// order, raises a contract addendum for the variation.
+	Record(ctx context.Context, projectID, jobID uuid.UUID, req requests.RecordAsBuiltRequest) (*responses.AsBuiltLineResponse, error)
+}
+
+type asBuiltUseCase struct {
+	asBuiltRepo repositories.AsBuiltRepository
+	boqRepo     repositories.BOQRepository
+	contracts   ContractUseCase
+	access      projectAccess
+}
+
+func NewAsBuiltUsecase(
+	asBuiltRepo repositories.AsBuiltRepository,
+	boqRepo repositories.BOQRepository,
+	contracts ContractUseCase,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) AsBuiltUseCase {
+	return &asBuiltUseCase{
+		asBuiltRepo: asBuiltRepo,
+		boqRepo:     boqRepo,
+		contracts:   contracts,
+		access:      projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *asBuiltUseCase) Reconcile(ctx context.Context, projectID uuid.UUID) (*responses.AsBuiltReconciliationResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	boq, err := u.projectBOQ(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := u.asBuiltRepo.ListLines(ctx, boq.BOQID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.AsBuiltReconciliationResponse{
+		ProjectID: projectID,
+		BOQID:     boq.BOQID,
+		Lines:     make([]responses.AsBuiltLineResponse, len(lines)),
+	}
+	for i := range lines {
+		line := toAsBuiltLineResponse(&lines[i])
+		response.Lines[i] = line
+		if line.AsBuiltQuantity == nil {
+			if line.Completed {
+				response.Outstanding++
+			}
+			continue
+		}
+		response.Recorded++
+		response.VariationTotal += line.VariationValue
+		switch models.AsBuiltDisposition(line.Disposition) {
+		case models.AsBuiltDispositionChangeOrder:
+			response.ChangeOrderTotal += line.VariationValue
+		case models.AsBuiltDispositionFinalAccount:
+			response.FinalAccountTotal += line.VariationValue
+		}
+	}
+	response.VariationTotal = roundTo(response.VariationTotal, 2)
+	response.ChangeOrderTotal = roundTo(response.ChangeOrderTotal, 2)
+	response.FinalAccountTotal = roundTo(response.FinalAccountTotal, 2)
+	return response, nil
+}
+
+func (u *asBuiltUseCase) Record(ctx context.Context, projectID, jobID uuid.UUID, req requests.RecordAsBuiltRequest) (*responses.AsBuiltLineResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if req.Quantity < 0 {
+		return nil, errors.New("quantity cannot be negative")
+	}
+	disposition := models.AsBuiltDisposition(req.Disposition)
+	if disposition == "" {
+		disposition = models.AsBuiltDispositionNone
+	}
+	if !disposition.Valid() {
+		return nil, errors.New("invalid disposition")
+	}
+
+	boq, err := u.projectBOQ(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	line, err := u.asBuiltRepo.GetLine(ctx, boq.BOQID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if !line.CompletedAt.Valid {
+		return nil, errors.New("job must be completed before recording as-built quantities")
+	}
+	// The addendum is part of the contract now, so the quantity it was
+	// priced on can't change under it.
+	if line.ChangeOrderID.Valid {
+		return nil, errors.New("as-built quantity already raised as a change order")
+	}
+
+	_, value := line.Variation(req.Quantity)
+	record := models.AsBuiltQuantity{
+		BOQID:          boq.BOQID,
+		JobID:          jobID,
+		Quantity:       req.Quantity,
+		VariationValue: value,
+		Disposition:    disposition,
+		Note:           optionalString(req.Note),
+		RecordedBy:     actorFromContext(ctx),
+		RecordedAt:     time.Now(),
+	}
+
+	if disposition == models.AsBuiltDispositionChangeOrder {
+		if value == 0 {
+			return nil, errors.New("no variation to raise as a change order")
+		}
+		title := strings.TrimSpace(req.Title)
+		if title == "" {
+			title = "As-built variation: " + line.JobName
+		}
+		effectiveDate := strings.TrimSpace(req.EffectiveDate)
+		if effectiveDate == "" {
+			effectiveDate = currentDate().Format("2006-01-02")
+		}
+		addendum, err := u.contracts.CreateDocument(ctx, projectID, requests.CreateContractDocumentRequest{
+			DocumentType:    string(models.ContractDocumentTypeAddendum),
+			Title:           title,
+			FileURL:         req.FileURL,
+			EffectiveDate:   effectiveDate,
+			ValueAdjustment: value,
+		})
+		if err != nil {
+			return nil, err
+		}
+		record.ChangeOrderID = uuid.NullUUID{UUID: addendum.DocumentID, Valid: true}
+	}
+
+	if err := u.asBuiltRepo.Save(ctx, record); err != nil {
+		return nil, err
+	}
+
+	line.AsBuilt.Float64, line.AsBuilt.Valid = record.Quantity, true
+	line.Disposition.String, line.Disposition.Valid = string(record.Disposition), true
+	line.ChangeOrderID = record.ChangeOrderID
+	line.Note = record.Note
+	line.RecordedAt.Time, line.RecordedAt.Valid = record.RecordedAt, true
+	response := toAsBuiltLineResponse(line)
+	return &response, nil
+}
+
+func (u *asBuiltUseCase) projectBOQ(ctx context.Context, projectID uuid.UUID) (*models.BOQ, error) {
+	boq, err := u.boqRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("boq not found")
+		}
+		return nil, err
+	}
+	return boq, nil
+}
+
+func toAsBuiltLineResponse(line *models.AsBuiltLine) responses.AsBuiltLineResponse {
+	response := responses.AsBuiltLineResponse{
+		BOQID:         line.BOQID,
+		JobID:         line.JobID,
+		JobName:       line.JobName,
+		Unit:          line.Unit,
+		Completed:     line.CompletedAt.Valid,
+		BOQQuantity:   line.BOQQuantity,
+		UnitPrice:     line.UnitPrice,
+		Disposition:   line.Disposition.String,
+		ChangeOrderID: nullUUIDPtr(line.ChangeOrderID),
+		Note:          line.Note.String,
+	}
+	if line.AsBuilt.Valid {
+		response.AsBuiltQuantity = &line.AsBuilt.Float64
+		response.VariationQuantity, response.VariationValue = line.Variation(line.AsBuilt.Float64)
+	}
+	if line.RecordedAt.Valid {
+		response.RecordedAt = &line.RecordedAt.Time
+	}
+	return response
+}
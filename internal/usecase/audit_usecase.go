@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditUseCase interface {
+	// Seal seals and archives every whole day of activity not sealed yet,
+	// oldest first. Running it again after a failure picks up where it
+	// stopped.
+	Seal(ctx context.Context) error
+
+	// ListSegments and Verify take from and to as YYYY-MM-DD, inclusive,
+	// defaulting to the last 30 days. Only owners and admins may use them.
+	ListSegments(ctx context.Context, userID uuid.UUID, from, to string) ([]responses.AuditSegmentResponse, error)
+	// Verify checks each segment's chain link, recomputes it from the
+	// activity events in the database and compares it with the archived
+	// copy.
+	Verify(ctx context.Context, userID uuid.UUID, from, to string) (*responses.AuditVerificationResponse, error)
+}
+
+type auditUseCase struct {
+	auditRepo repositories.AuditRepository
+	userRepo  repositories.UserRepository
+	archive   repositories.AuditArchive
+	// loc decides where a day starts.
+	loc *time.Location
+}
+
+func NewAuditUsecase(auditRepo repositories.AuditRepository, userRepo repositories.UserRepository, archive repositories.AuditArchive, loc *time.Location) AuditUseCase {
+	return &auditUseCase{
+		auditRepo: auditRepo,
+		userRepo:  userRepo,
+		archive:   archive,
+		loc:       loc,
+	}
+}
+
+func (u *auditUseCase) Seal(ctx context.Context) error {
+	now := time.Now().In(u.loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, u.loc)
+
+	previousHash := models.AuditGenesisHash
+	var day time.Time
+	last, err := u.auditRepo.GetLastSegment(ctx)
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		day = u.localDate(last.SegmentDate).AddDate(0, 0, 1)
+		previousHash = last.Hash
+	} else {
+		first, err := u.auditRepo.GetFirstEventTime(ctx)
+		if err != nil {
+			return err
+		}
+		if first.IsZero() {
+			return nil
+		}
+		first = first.In(u.loc)
+		day = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, u.loc)
+	}
+
+	sealed := 0
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		segment, err := u.sealDay(ctx, day, previousHash)
+		if err != nil {
+			return fmt.Errorf("failed to seal %s: %w", day.Format("2006-01-02"), err)
+		}
+		previousHash = segment.Hash
+		sealed++
+	}
+	if sealed > 0 {
+		log.Printf("audit: sealed %d day(s) through %s", sealed, today.AddDate(0, 0, -1).Format("2006-01-02"))
+	}
+	return nil
+}
+
+func (u *auditUseCase) sealDay(ctx context.Context, day time.Time, previousHash string) (*models.AuditSegment, error) {
+	events, err := u.auditRepo.ListEvents(ctx, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	date := day.Format("2006-01-02")
+	file, err := buildAuditSegmentFile(date, previousHash, events)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode segment: %w", err)
+	}
+
+	key := fmt.Sprintf("activity/%s/%s.json", day.Format("2006/01"), date)
+	if err := u.archive.Put(ctx, key, data); err != nil {
+		if err.Error() != "object already exists" {
+			return nil, err
+		}
+		// An earlier run archived the day but didn't record the seal.
+		// That's fine as long as it archived exactly the same thing.
+		existing, err := u.archive.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(existing, data) {
+			return nil, errors.New("archived segment differs from the activity log")
+		}
+	}
+
+	segment := models.AuditSegment{
+		SegmentDate:  time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC),
+		EventCount:   len(events),
+		EventsHash:   file.EventsHash,
+		PreviousHash: previousHash,
+		Hash:         file.Hash,
+		ObjectKey:    key,
+		SealedAt:     time.Now(),
+	}
+	if err := u.auditRepo.CreateSegment(ctx, segment); err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+func (u *auditUseCase) ListSegments(ctx context.Context, userID uuid.UUID, from, to string) ([]responses.AuditSegmentResponse, error) {
+	segments, _, _, err := u.segmentsBetween(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.AuditSegmentResponse, len(segments))
+	for i, s := range segments {
+		result[i] = responses.AuditSegmentResponse{
+			SegmentDate:  s.SegmentDate.Format("2006-01-02"),
+			EventCount:   s.EventCount,
+			EventsHash:   s.EventsHash,
+			PreviousHash: s.PreviousHash,
+			Hash:         s.Hash,
+			ObjectKey:    s.ObjectKey,
+			SealedAt:     s.SealedAt,
+		}
+	}
+	return result, nil
+}
+
+func (u *auditUseCase) Verify(ctx context.Context, userID uuid.UUID, from, to string) (*responses.AuditVerificationResponse, error) {
+	segments, start, end, err := u.segmentsBetween(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	previousHash := models.AuditGenesisHash
+	previous, err := u.auditRepo.GetSegmentBefore(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		previousHash = previous.Hash
+	}
+
+	result := &responses.AuditVerificationResponse{
+		From:     start.Format("2006-01-02"),
+		To:       end.Format("2006-01-02"),
+		Valid:    true,
+		Segments: make([]responses.AuditSegmentCheckResponse, len(segments)),
+	}
+	for i, segment := range segments {
+		problems, err := u.checkSegment(ctx, segment, previousHash)
+		if err != nil {
+			return nil, err
+		}
+		result.Segments[i] = responses.AuditSegmentCheckResponse{
+			SegmentDate: segment.SegmentDate.Format("2006-01-02"),
+			EventCount:  segment.EventCount,
+			Hash:        segment.Hash,
+			Valid:       len(problems) == 0,
+			Problems:    problems,
+		}
+		if len(problems) > 0 {
+			result.Valid = false
+		}
+		previousHash = segment.Hash
+	}
+	return result, nil
+}
+
+func (u *auditUseCase) checkSegment(ctx context.Context, segment models.AuditSegment, previousHash string) ([]string, error) {
+	problems := []string{}
+	date := segment.SegmentDate.Format("2006-01-02")
+
+	if segment.PreviousHash != previousHash {
+		problems = append(problems, "previous hash does not match the preceding segment")
+	}
+	if auditSegmentHash(segment.PreviousHash, date, segment.EventsHash) != segment.Hash {
+		problems = append(problems, "segment hash does not match its contents")
+	}
+
+	day := u.localDate(segment.SegmentDate)
+	events, err := u.auditRepo.ListEvents(ctx, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	current, err := buildAuditSegmentFile(date, segment.PreviousHash, events)
+	if err != nil {
+		return nil, err
+	}
+	if current.EventsHash != segment.EventsHash {
+		problems = append(problems, fmt.Sprintf("activity log no longer matches the seal (%d events sealed, %d now)", segment.EventCount, len(events)))
+	}
+
+	data, err := u.archive.Get(ctx, segment.ObjectKey)
+	if err != nil {
+		if err.Error() == "object not found" {
+			return append(problems, "archived copy is missing"), nil
+		}
+		return nil, err
+	}
+	var archived models.AuditSegmentFile
+	if err := json.Unmarshal(data, &archived); err != nil {
+		return append(problems, "archived copy is unreadable"), nil
+	}
+	eventsHash, err := auditEventsHash(archived.Events)
+	if err != nil {
+		return nil, err
+	}
+	if archived.Hash != segment.Hash || archived.EventsHash != eventsHash ||
+		auditSegmentHash(archived.PreviousHash, archived.SegmentDate, eventsHash) != archived.Hash {
+		problems = append(problems, "archived copy does not match the seal")
+	}
+	return problems, nil
+}
+
+// segmentsBetween checks the caller and resolves the date range.
+func (u *auditUseCase) segmentsBetween(ctx context.Context, userID uuid.UUID, from, to string) ([]models.AuditSegment, time.Time, time.Time, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+
+	now := time.Now().In(u.loc)
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		parsed, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, errors.New("invalid to date")
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, -29)
+	if from != "" {
+		parsed, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, errors.New("invalid from date")
+		}
+		start = parsed
+	}
+	if end.Before(start) {
+		return nil, time.Time{}, time.Time{}, errors.New("to date must not be before from date")
+	}
+
+	segments, err := u.auditRepo.ListSegments(ctx, start, end)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+	return segments, start, end, nil
+}
+
+// localDate turns a segment date, stored as a date, into midnight in loc.
+func (u *auditUseCase) localDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, u.loc)
+}
+
+func buildAuditSegmentFile(date, previousHash string, events []models.ActivityEvent) (*models.AuditSegmentFile, error) {
+	entries := make([]models.AuditEventEntry, len(events))
+	for i, e := range events {
+		entries[i] = models.AuditEventEntry{
+			EventID:     e.EventID.String(),
+			EntityType:  string(e.EntityType),
+			EntityID:    e.EntityID.String(),
+			EventType:   e.EventType,
+			Description: e.Description,
+			OccurredAt:  e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		}
+		if e.ProjectID.Valid {
+			entries[i].ProjectID = e.ProjectID.UUID.String()
+		}
+		if e.ClientID.Valid {
+			entries[i].ClientID = e.ClientID.UUID.String()
+		}
+		if e.ImpersonatorID.Valid {
+			entries[i].ImpersonatorID = e.ImpersonatorID.UUID.String()
+		}
+	}
+
+	eventsHash, err := auditEventsHash(entries)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuditSegmentFile{
+		SegmentDate:  date,
+		PreviousHash: previousHash,
+		EventsHash:   eventsHash,
+		Hash:         auditSegmentHash(previousHash, date, eventsHash),
+		Events:       entries,
+	}, nil
+}
+
+// auditEventsHash hashes the JSON encoding of entries, which is stable
+// because struct fields are always encoded in declaration order.
+func auditEventsHash(entries []models.AuditEventEntry) (string, error) {
+	if entries == nil {
+		entries = []models.AuditEventEntry{}
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode events: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func auditSegmentHash(previousHash, date, eventsHash string) string {
+	sum := sha256.Sum256([]byte(previousHash + "\n" + date + "\n" + eventsHash))
+	return hex.EncodeToString(sum[:])
+}
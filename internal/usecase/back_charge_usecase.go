@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BackChargeUseCase interface {
+	// Create raises a back-charge. Open back-charges are deducted from the
+	// supplier's next subcontract payment certificates.
+	Create(ctx context.Context, userID uuid.UUID, req requests.CreateBackChargeRequest) (*responses.BackChargeResponse, error)
+	List(ctx context.Context, supplierID uuid.NullUUID, projectID uuid.NullUUID) ([]responses.BackChargeResponse, error)
+	GetByID(ctx context.Context, backChargeID uuid.UUID) (*responses.BackChargeResponse, error)
+
+	// Dispute holds the remaining amount back from deductions until the
+	// dispute is resolved.
+	Dispute(ctx context.Context, userID uuid.UUID, backChargeID uuid.UUID, req requests.DisputeBackChargeRequest) (*responses.BackChargeResponse, error)
+	Resolve(ctx context.Context, backChargeID uuid.UUID, req requests.ResolveBackChargeRequest) (*responses.BackChargeResponse, error)
+	// Withdraw stops any further deduction; amounts already deducted stand.
+	Withdraw(ctx context.Context, backChargeID uuid.UUID) (*responses.BackChargeResponse, error)
+
+	AddAttachment(ctx context.Context, userID uuid.UUID, backChargeID uuid.UUID, req requests.BackChargeAttachmentRequest) (*responses.BackChargeAttachmentResponse, error)
+	DeleteAttachment(ctx context.Context, backChargeID uuid.UUID, attachmentID uuid.UUID) error
+}
+
+type backChargeUseCase struct {
+	backChargeRepo  repositories.BackChargeRepository
+	supplierRepo    repositories.SupplierRepository
+	projectRepo     repositories.ProjectRepository
+	subcontractRepo repositories.SubcontractRepository
+}
+
+func NewBackChargeUsecase(
+	backChargeRepo repositories.BackChargeRepository,
+	supplierRepo repositories.SupplierRepository,
+	projectRepo repositories.ProjectRepository,
+	subcontractRepo repositories.SubcontractRepository,
+) BackChargeUseCase {
+	return &backChargeUseCase{
+		backChargeRepo:  backChargeRepo,
+		supplierRepo:    supplierRepo,
+		projectRepo:     projectRepo,
+		subcontractRepo: subcontractRepo,
+	}
+}
+
+func (u *backChargeUseCase) Create(ctx context.Context, userID uuid.UUID, req requests.CreateBackChargeRequest) (*responses.BackChargeResponse, error) {
+	category := models.BackChargeCategory(req.Category)
+	if !category.Valid() {
+		return nil, errors.New("invalid back-charge category")
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, errors.New("description is required")
+	}
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+	if _, err := u.supplierRepo.GetByID(ctx, req.SupplierID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	backCharge := models.BackCharge{
+		BackChargeID: uuid.New(),
+		SupplierID:   req.SupplierID,
+		Category:     category,
+		Description:  description,
+		Amount:       roundTo(req.Amount, 2),
+		Status:       models.BackChargeStatusOpen,
+		CreatedBy:    userID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if req.SubcontractID != nil {
+		subcontract, err := u.subcontractRepo.GetByID(ctx, *req.SubcontractID)
+		if err != nil {
+			return nil, err
+		}
+		if subcontract.SupplierID != req.SupplierID {
+			return nil, errors.New("subcontract belongs to another supplier")
+		}
+		backCharge.SubcontractID = uuid.NullUUID{UUID: subcontract.SubcontractID, Valid: true}
+		backCharge.ProjectID = uuid.NullUUID{UUID: subcontract.ProjectID, Valid: true}
+	}
+	if req.ProjectID != nil {
+		if backCharge.ProjectID.Valid && backCharge.ProjectID.UUID != *req.ProjectID {
+			return nil, errors.New("subcontract belongs to another project")
+		}
+		if _, err := u.projectRepo.GetByID(ctx, *req.ProjectID); err != nil {
+			return nil, err
+		}
+		backCharge.ProjectID = uuid.NullUUID{UUID: *req.ProjectID, Valid: true}
+	}
+
+	if err := u.backChargeRepo.Create(ctx, backCharge); err != nil {
+		return nil, err
+	}
+	return u.response(ctx, &backCharge)
+}
+
+func (u *backChargeUseCase) List(ctx context.Context, supplierID uuid.NullUUID, projectID uuid.NullUUID) ([]responses.BackChargeResponse, error) {
+	backCharges, err := u.backChargeRepo.List(ctx, supplierID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.BackChargeResponse, len(backCharges))
+	for i := range backCharges {
+		result[i] = toBackChargeResponse(&backCharges[i])
+	}
+	return result, nil
+}
+
+func (u *backChargeUseCase) GetByID(ctx context.Context, backChargeID uuid.UUID) (*responses.BackChargeResponse, error) {
+	backCharge, err := u.backChargeRepo.GetByID(ctx, backChargeID)
+	if err != nil {
+		return nil, err
+	}
+	return u.response(ctx, backCharge)
+}
+
+func (u *backChargeUseCase) Dispute(ctx context.Context, userID uuid.UUID, backChargeID uuid.UUID, req requests.DisputeBackChargeRequest) (*responses.BackChargeResponse, error) {
+	backCharge, err := u.backChargeRepo.GetByID(ctx, backChargeID)
+	if err != nil {
+		return nil, err
+	}
+	if backCharge.Status != models.BackChargeStatusOpen {
+		return nil, errors.New("only open back-charges can be disputed")
+	}
+	if backCharge.Remaining() <= 0 {
+		return nil, errors.New("back-charge is fully deducted")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	backCharge.Status = models.BackChargeStatusDisputed
+	backCharge.DisputeReason = optionalString(reason)
+	backCharge.DisputedBy = uuid.NullUUID{UUID: userID, Valid: true}
+	backCharge.Resolution = optionalString("")
+	return u.save(ctx, backCharge)
+}
+
+func (u *backChargeUseCase) Resolve(ctx context.Context, backChargeID uuid.UUID, req requests.ResolveBackChargeRequest) (*responses.BackChargeResponse, error) {
+	backCharge, err := u.backChargeRepo.GetByID(ctx, backChargeID)
+	if err != nil {
+		return nil, err
+	}
+	if backCharge.Status != models.BackChargeStatusDisputed {
+		return nil, errors.New("back-charge is not disputed")
+	}
+	resolution := strings.TrimSpace(req.Resolution)
+	if resolution == "" {
+		return nil, errors.New("resolution is required")
+	}
+
+	switch req.Outcome {
+	case "upheld":
+		backCharge.Status = models.BackChargeStatusOpen
+	case "reduced":
+		amount := roundTo(req.Amount, 2)
+		if amount <= 0 || amount >= backCharge.Amount {
+			return nil, errors.New("reduced amount must be greater than 0 and less than the current amount")
+		}
+		backCharge.Amount = amount
+		backCharge.Status = models.BackChargeStatusOpen
+	case "withdrawn":
+		backCharge.Amount = backCharge.OffsetAmount
+		backCharge.Status = models.BackChargeStatusWithdrawn
+	default:
+		return nil, errors.New("invalid outcome")
+	}
+
+	backCharge.Resolution = optionalString(resolution)
+	return u.save(ctx, backCharge)
+}
+
+func (u *backChargeUseCase) Withdraw(ctx context.Context, backChargeID uuid.UUID) (*responses.BackChargeResponse, error) {
+	backCharge, err := u.backChargeRepo.GetByID(ctx, backChargeID)
+	if err != nil {
+		return nil, err
+	}
+	if backCharge.Status == models.BackChargeStatusWithdrawn {
+		return nil, errors.New("back-charge already withdrawn")
+	}
+
+	backCharge.Amount = backCharge.OffsetAmount
+	backCharge.Status = models.BackChargeStatusWithdrawn
+	return u.save(ctx, backCharge)
+}
+
+func (u *backChargeUseCase) AddAttachment(ctx context.Context, userID uuid.UUID, backChargeID uuid.UUID, req requests.BackChargeAttachmentRequest) (*responses.BackChargeAttachmentResponse, error) {
+	if _, err := u.backChargeRepo.GetByID(ctx, backChargeID); err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(req.Title)
+	fileURL := strings.TrimSpace(req.FileURL)
+	if title == "" || fileURL == "" {
+		return nil, errors.New("title and file url are required")
+	}
+
+	attachment := models.BackChargeAttachment{
+		AttachmentID: uuid.New(),
+		BackChargeID: backChargeID,
+		Title:        title,
+		FileURL:      fileURL,
+		UploadedBy:   userID,
+		CreatedAt:    time.Now(),
+	}
+	if err := u.backChargeRepo.AddAttachment(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	response := toBackChargeAttachmentResponse(&attachment)
+	return &response, nil
+}
+
+func (u *backChargeUseCase) DeleteAttachment(ctx context.Context, backChargeID uuid.UUID, attachmentID uuid.UUID) error {
+	if _, err := u.backChargeRepo.GetByID(ctx, backChargeID); err != nil {
+		return err
+	}
+	return u.backChargeRepo.DeleteAttachment(ctx, backChargeID, attachmentID)
+}
+
+func (u *backChargeUseCase) save(ctx context.Context, backCharge *models.BackCharge) (*responses.BackChargeResponse, error) {
+	backCharge.UpdatedAt = time.Now()
+	if err := u.backChargeRepo.UpdateStatus(ctx, *backCharge); err != nil {
+		return nil, err
+	}
+	return u.response(ctx, backCharge)
+}
+
+func (u *backChargeUseCase) response(ctx context.Context, backCharge *models.BackCharge) (*responses.BackChargeResponse, error) {
+	attachments, err := u.backChargeRepo.ListAttachments(ctx, backCharge.BackChargeID)
+	if err != nil {
+		return nil, err
+	}
+	offsets, err := u.backChargeRepo.ListOffsets(ctx, backCharge.BackChargeID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toBackChargeResponse(backCharge)
+	for i := range attachments {
+		response.Attachments = append(response.Attachments, toBackChargeAttachmentResponse(&attachments[i]))
+	}
+	for _, offset := range offsets {
+		response.Offsets = append(response.Offsets, responses.BackChargeOffsetResponse{
+			CertificateID: offset.CertificateID,
+			Amount:        offset.Amount,
+			CreatedAt:     offset.CreatedAt,
+		})
+	}
+	return &response, nil
+}
+
+func toBackChargeResponse(backCharge *models.BackCharge) responses.BackChargeResponse {
+	return responses.BackChargeResponse{
+		BackChargeID:  backCharge.BackChargeID,
+		SupplierID:    backCharge.SupplierID,
+		ProjectID:     nullUUIDPtr(backCharge.ProjectID),
+		SubcontractID: nullUUIDPtr(backCharge.SubcontractID),
+		Category:      string(backCharge.Category),
+		Description:   backCharge.Description,
+		Amount:        backCharge.Amount,
+		Deducted:      backCharge.OffsetAmount,
+		Remaining:     backCharge.Remaining(),
+		Status:        string(backCharge.Status),
+		DisputeReason: backCharge.DisputeReason.String,
+		DisputedBy:    nullUUIDPtr(backCharge.DisputedBy),
+		Resolution:    backCharge.Resolution.String,
+		CreatedBy:     backCharge.CreatedBy,
+		CreatedAt:     backCharge.CreatedAt,
+		UpdatedAt:     backCharge.UpdatedAt,
+	}
+}
+
+func toBackChargeAttachmentResponse(attachment *models.BackChargeAttachment) responses.BackChargeAttachmentResponse {
+	return responses.BackChargeAttachmentResponse{
+		AttachmentID: attachment.AttachmentID,
+		Title:        attachment.Title,
+		FileURL:      attachment.FileURL,
+		UploadedBy:   attachment.UploadedBy,
+		CreatedAt:    attachment.CreatedAt,
+	}
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BlanketAgreementUseCase interface {
+	Create(ctx context.Context, req requests.CreateBlanketAgreementRequest) (*responses.BlanketAgreementResponse, error)
+	// GetByID includes each item's remaining balance and the release
+	// orders drawn against the agreement.
+	GetByID(ctx context.Context, agreementID uuid.UUID) (*responses.BlanketAgreementResponse, error)
+	// List returns a supplier's agreements, or everyone's for a null
+	// supplier; activeOnly keeps those valid today.
+	List(ctx context.Context, supplierID uuid.NullUUID, activeOnly bool) ([]responses.BlanketAgreementResponse, error)
+	// GetAlerts lists agreements expiring within days that still have
+	// quantity left to release. A negative days uses the default.
+	GetAlerts(ctx context.Context, days int) (*responses.BlanketAgreementAlertListResponse, error)
+	CheckAlerts(ctx context.Context) error
+}
+
+type blanketAgreementUseCase struct {
+	agreementRepo repositories.BlanketAgreementRepository
+	poRepo        repositories.PurchaseOrderRepository
+	supplierRepo  repositories.SupplierRepository
+	materialRepo  repositories.MaterialRepository
+	alertDays     int
+}
+
+// NewBlanketAgreementUsecase takes how many days before expiry an
+// agreement with an unreleased balance is alerted on.
+func NewBlanketAgreementUsecase(
+	agreementRepo repositories.BlanketAgreementRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	supplierRepo repositories.SupplierRepository,
+	materialRepo repositories.MaterialRepository,
+	alertDays int,
+) BlanketAgreementUseCase {
+	return &blanketAgreementUseCase{
+		agreementRepo: agreementRepo,
+		poRepo:        poRepo,
+		supplierRepo:  supplierRepo,
+		materialRepo:  materialRepo,
+		alertDays:     alertDays,
+	}
+}
+
+func (u *blanketAgreementUseCase) Create(ctx context.Context, req requests.CreateBlanketAgreementRequest) (*responses.BlanketAgreementResponse, error) {
+	reference := strings.TrimSpace(req.Reference)
+	if reference == "" {
+		return nil, errors.New("reference is required")
+	}
+	if len(req.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+
+	validFrom, err := time.Parse("2006-01-02", strings.TrimSpace(req.ValidFrom))
+	if err != nil {
+		return nil, errors.New("invalid valid from date format, expected YYYY-MM-DD")
+	}
+	validTo, err := time.Parse("2006-01-02", strings.TrimSpace(req.ValidTo))
+	if err != nil {
+		return nil, errors.New("invalid valid to date format, expected YYYY-MM-DD")
+	}
+	if validTo.Before(validFrom) {
+		return nil, errors.New("valid to cannot be before valid from")
+	}
+
+	supplier, err := u.supplierRepo.GetByID(ctx, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	if supplier.Status == models.SupplierStatusBlacklisted {
+		return nil, errors.New("cannot agree terms with a blacklisted supplier")
+	}
+
+	note := strings.TrimSpace(req.Note)
+	agreement := models.BlanketAgreement{
+		AgreementID: uuid.New(),
+		SupplierID:  supplier.SupplierID,
+		Reference:   reference,
+		ValidFrom:   validFrom,
+		ValidTo:     validTo,
+		Note:        sql.NullString{String: note, Valid: note != ""},
+		CreatedBy:   actorFromContext(ctx),
+		CreatedAt:   time.Now(),
+	}
+
+	seen := map[string]bool{}
+	items := make([]models.BlanketAgreementItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		if item.UnitPrice <= 0 {
+			return nil, errors.New("unit price must be greater than 0")
+		}
+		if item.CommittedQuantity <= 0 {
+			return nil, errors.New("committed quantity must be greater than 0")
+		}
+		if seen[item.MaterialID] {
+			return nil, errors.New("each material can only be on an agreement once")
+		}
+		seen[item.MaterialID] = true
+		if _, err := u.materialRepo.GetByID(ctx, item.MaterialID); err != nil {
+			return nil, err
+		}
+
+		items = append(items, models.BlanketAgreementItem{
+			ItemID:            uuid.New(),
+			AgreementID:       agreement.AgreementID,
+			MaterialID:        item.MaterialID,
+			UnitPrice:         item.UnitPrice,
+			CommittedQuantity: item.CommittedQuantity,
+		})
+	}
+
+	if err := u.agreementRepo.Create(ctx, agreement, items); err != nil {
+		return nil, err
+	}
+	return u.GetByID(ctx, agreement.AgreementID)
+}
+
+func (u *blanketAgreementUseCase) GetByID(ctx context.Context, agreementID uuid.UUID) (*responses.BlanketAgreementResponse, error) {
+	agreement, err := u.agreementRepo.GetByID(ctx, agreementID)
+	if err != nil {
+		return nil, err
+	}
+	response, balances, err := u.toResponse(ctx, *agreement)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Items = make([]responses.BlanketAgreementItemResponse, 0, len(balances))
+	for _, b := range balances {
+		response.Items = append(response.Items, responses.BlanketAgreementItemResponse{
+			ItemID:            b.ItemID,
+			MaterialID:        b.MaterialID,
+			UnitPrice:         b.UnitPrice,
+			CommittedQuantity: b.CommittedQuantity,
+			ReleasedQuantity:  b.ReleasedQuantity,
+			RemainingQuantity: b.Remaining(),
+		})
+	}
+
+	orders, err := u.poRepo.ListByAgreement(ctx, agreementID)
+	if err != nil {
+		return nil, err
+	}
+	response.ReleaseOrders = toPurchaseOrderResponses(orders)
+	return response, nil
+}
+
+func (u *blanketAgreementUseCase) List(ctx context.Context, supplierID uuid.NullUUID, activeOnly bool) ([]responses.BlanketAgreementResponse, error) {
+	var validOn sql.NullTime
+	if activeOnly {
+		validOn = sql.NullTime{Time: currentDate(), Valid: true}
+	}
+	agreements, err := u.agreementRepo.List(ctx, supplierID, validOn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.BlanketAgreementResponse, 0, len(agreements))
+	for _, a := range agreements {
+		response, _, err := u.toResponse(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *response)
+	}
+	return result, nil
+}
+
+func (u *blanketAgreementUseCase) GetAlerts(ctx context.Context, days int) (*responses.BlanketAgreementAlertListResponse, error) {
+	if days < 0 {
+		days = u.alertDays
+	}
+
+	today := currentDate()
+	agreements, err := u.agreementRepo.ListExpiring(ctx, today, today.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.BlanketAgreementAlertListResponse{
+		WithinDays: days,
+		Alerts:     []responses.BlanketAgreementAlertResponse{},
+	}
+	for _, a := range agreements {
+		agreement, balances, err := u.toResponse(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		unreleased := false
+		for _, b := range balances {
+			if b.Remaining() > 0 {
+				unreleased = true
+				break
+			}
+		}
+		if !unreleased {
+			continue
+		}
+		response.Alerts = append(response.Alerts, responses.BlanketAgreementAlertResponse{
+			BlanketAgreementResponse: *agreement,
+			DaysToExpiry:             int(a.ValidTo.Sub(today).Hours() / 24),
+		})
+	}
+	return response, nil
+}
+
+// CheckAlerts runs on a schedule and logs every agreement expiring within
+// the default number of days with quantity still to be released.
+func (u *blanketAgreementUseCase) CheckAlerts(ctx context.Context) error {
+	alerts, err := u.GetAlerts(ctx, u.alertDays)
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range alerts.Alerts {
+		log.Printf("blanket agreement alert: %q with %s expires in %d days with %.2f of %.2f unreleased",
+			alert.Reference, alert.SupplierName, alert.DaysToExpiry, alert.RemainingAmount, alert.CommittedAmount)
+	}
+	return nil
+}
+
+func (u *blanketAgreementUseCase) toResponse(ctx context.Context, a models.BlanketAgreementSummary) (*responses.BlanketAgreementResponse, []models.BlanketAgreementBalance, error) {
+	balances, err := u.agreementRepo.ListBalances(ctx, a.AgreementID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := &responses.BlanketAgreementResponse{
+		AgreementID:  a.AgreementID,
+		SupplierID:   a.SupplierID,
+		SupplierName: a.SupplierName,
+		Reference:    a.Reference,
+		ValidFrom:    a.ValidFrom.Format("2006-01-02"),
+		ValidTo:      a.ValidTo.Format("2006-01-02"),
+		Note:         a.Note.String,
+	}
+	for _, b := range balances {
+		response.CommittedAmount += b.CommittedQuantity * b.UnitPrice
+		response.ReleasedAmount += b.ReleasedQuantity * b.UnitPrice
+		response.RemainingAmount += max(b.Remaining(), 0) * b.UnitPrice
+	}
+	response.CommittedAmount = roundTo(response.CommittedAmount, 2)
+	response.ReleasedAmount = roundTo(response.ReleasedAmount, 2)
+	response.RemainingAmount = roundTo(response.RemainingAmount, 2)
+	return response, balances, nil
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BOQPriceUpdateUseCase interface {
+	// MaterialPriceChanged and BOQChanged queue draft BOQs to be
+	// recalculated. They only touch memory; Recalculate does the work.
+	MaterialPriceChanged(materialID string)
+	BOQChanged(boqID uuid.UUID)
+	Recalculate(ctx context.Context) error
+
+	List(ctx context.Context, includeAcknowledged bool) ([]responses.BOQPriceUpdateResponse, error)
+	GetByBOQID(ctx context.Context, boqID uuid.UUID) (*responses.BOQPriceUpdateResponse, error)
+	Acknowledge(ctx context.Context, boqID uuid.UUID, userID uuid.UUID) error
+}
+
+// boqPriceUpdateUseCase recalculates in the background so recording a
+// purchase never waits on every draft that uses the material. Queued work
+// is lost if the instance stops; the next price change for the material
+// catches the drafts up.
+type boqPriceUpdateUseCase struct {
+	priceUpdateRepo repositories.BOQPriceUpdateRepository
+
+	mu        sync.Mutex
+	materials map[string]struct{}
+	boqs      map[uuid.UUID]struct{}
+}
+
+func NewBOQPriceUpdateUsecase(priceUpdateRepo repositories.BOQPriceUpdateRepository) BOQPriceUpdateUseCase {
+	return &boqPriceUpdateUseCase{
+		priceUpdateRepo: priceUpdateRepo,
+		materials:       make(map[string]struct{}),
+		boqs:            make(map[uuid.UUID]struct{}),
+	}
+}
+
+func (u *boqPriceUpdateUseCase) MaterialPriceChanged(materialID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.materials[materialID] = struct{}{}
+}
+
+func (u *boqPriceUpdateUseCase) BOQChanged(boqID uuid.UUID) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.boqs[boqID] = struct{}{}
+}
+
+func (u *boqPriceUpdateUseCase) Recalculate(ctx context.Context) error {
+	u.mu.Lock()
+	materials, boqs := u.materials, u.boqs
+	u.materials = make(map[string]struct{})
+	u.boqs = make(map[uuid.UUID]struct{})
+	u.mu.Unlock()
+
+	var firstErr error
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for materialID := range materials {
+		boqIDs, err := u.priceUpdateRepo.ListOpenBOQsUsingMaterial(ctx, materialID)
+		if err != nil {
+			// Retried on the next run.
+			u.MaterialPriceChanged(materialID)
+			fail(err)
+			continue
+		}
+		for _, boqID := range boqIDs {
+			boqs[boqID] = struct{}{}
+		}
+	}
+
+	for boqID := range boqs {
+		if err := u.recalculateBOQ(ctx, boqID); err != nil {
+			u.BOQChanged(boqID)
+			fail(fmt.Errorf("BOQ %s: %w", boqID, err))
+		}
+	}
+
+	return firstErr
+}
+
+// recalculateBOQ flags the BOQ if any of its materials now cost something
+// different, and clears the flag once none do or the BOQ is no longer a
+// draft.
+func (u *boqPriceUpdateUseCase) recalculateBOQ(ctx context.Context, boqID uuid.UUID) error {
+	open, err := u.priceUpdateRepo.IsBOQOpen(ctx, boqID)
+	if err != nil {
+		if err.Error() == "BOQ not found" {
+			return nil
+		}
+		return err
+	}
+	if !open {
+		return u.priceUpdateRepo.Delete(ctx, boqID)
+	}
+
+	changes, err := u.priceUpdateRepo.GetPriceChanges(ctx, boqID)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return u.priceUpdateRepo.Delete(ctx, boqID)
+	}
+
+	estimatedTotal, err := u.priceUpdateRepo.GetEstimatedTotal(ctx, boqID)
+	if err != nil {
+		return err
+	}
+
+	var delta float64
+	for _, change := range changes {
+		delta += change.Delta
+	}
+
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode price changes: %w", err)
+	}
+
+	return u.priceUpdateRepo.Save(ctx, &models.BOQPriceUpdate{
+		BOQID:             boqID,
+		EstimatedTotal:    estimatedTotal,
+		RecalculatedTotal: estimatedTotal + delta,
+		Delta:             delta,
+		Changes:           raw,
+		FlaggedAt:         time.Now(),
+	})
+}
+
+func (u *boqPriceUpdateUseCase) List(ctx context.Context, includeAcknowledged bool) ([]responses.BOQPriceUpdateResponse, error) {
+	updates, err := u.priceUpdateRepo.List(ctx, includeAcknowledged)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.BOQPriceUpdateResponse, len(updates))
+	for i := range updates {
+		response, err := toBOQPriceUpdateResponse(&updates[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *response
+	}
+	return result, nil
+}
+
+func (u *boqPriceUpdateUseCase) GetByBOQID(ctx context.Context, boqID uuid.UUID) (*responses.BOQPriceUpdateResponse, error) {
+	update, err := u.priceUpdateRepo.GetByBOQID(ctx, boqID)
+	if err != nil {
+		return nil, err
+	}
+	return toBOQPriceUpdateResponse(update)
+}
+
+func (u *boqPriceUpdateUseCase) Acknowledge(ctx context.Context, boqID uuid.UUID, userID uuid.UUID) error {
+	update, err := u.priceUpdateRepo.GetByBOQID(ctx, boqID)
+	if err != nil {
+		return err
+	}
+	if update.AcknowledgedAt.Valid {
+		return errors.New("price update already acknowledged")
+	}
+
+	return u.priceUpdateRepo.Acknowledge(ctx, boqID, userID)
+}
+
+func toBOQPriceUpdateResponse(update *models.BOQPriceUpdate) (*responses.BOQPriceUpdateResponse, error) {
+	var changes []models.BOQPriceChange
+	if err := json.Unmarshal(update.Changes, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode price changes: %w", err)
+	}
+
+	response := &responses.BOQPriceUpdateResponse{
+		BOQID:             update.BOQID,
+		ProjectID:         update.ProjectID,
+		ProjectName:       update.ProjectName,
+		QuotationID:       nullUUIDPtr(update.QuotationID),
+		EstimatedTotal:    update.EstimatedTotal,
+		RecalculatedTotal: update.RecalculatedTotal,
+		Delta:             update.Delta,
+		Changes:           changes,
+		FlaggedAt:         update.FlaggedAt,
+		AcknowledgedBy:    nullUUIDPtr(update.AcknowledgedBy),
+	}
+	if update.EstimatedTotal != 0 {
+		response.DeltaPercent = update.Delta / update.EstimatedTotal * 100
+	}
+	if update.AcknowledgedAt.Valid {
+		response.AcknowledgedAt = &update.AcknowledgedAt.Time
+	}
+	return response, nil
+}
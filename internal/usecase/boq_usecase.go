@@ -6,9 +6,13 @@ import (
 	"boonkosang/internal/requests"
 	"boonkosang/internal/responses"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -19,41 +23,176 @@ type BOQUsecase interface {
 	AddBOQJob(ctx context.Context, boqID uuid.UUID, req requests.BOQJobRequest) error
 	UpdateBOQJob(ctx context.Context, boqID uuid.UUID, req requests.BOQJobRequest) error
 	DeleteBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) error
-	GetBOQSummary(ctx context.Context, projectID uuid.UUID) (*responses.BOQSummaryResponse, error)
+	CompleteBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) error
+	// GetBOQSummary names materials by their display name in language
+	// (th, en or bilingual), or by their own name when it is empty.
+	GetBOQSummary(ctx context.Context, projectID uuid.UUID, language string) (*responses.BOQSummaryResponse, error)
+
+	// Drawings can be attached at any BOQ status, since site engineers
+	// add them during construction.
+	AddJobDrawing(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, req requests.BOQJobDrawingRequest) (*responses.BOQJobDrawingResponse, error)
+	ListJobDrawings(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) ([]responses.BOQJobDrawingResponse, error)
+	DeleteJobDrawing(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, drawingID uuid.UUID) error
 }
 
 type boqUsecase struct {
-	boqRepo     repositories.BOQRepository
-	projectRepo repositories.ProjectRepository
+	boqRepo        repositories.BOQRepository
+	projectRepo    repositories.ProjectRepository
+	activityRepo   repositories.ActivityRepository
+	phaseRepo      repositories.ProjectPhaseRepository
+	inspectionRepo repositories.InspectionRepository
+	regionRepo     repositories.RegionRepository
+	priceUpdates   BOQPriceUpdateUseCase
+	access         projectAccess
 }
 
-func NewBOQUsecase(boqRepo repositories.BOQRepository, projectRepo repositories.ProjectRepository) BOQUsecase {
+func NewBOQUsecase(
+	boqRepo repositories.BOQRepository,
+	projectRepo repositories.ProjectRepository,
+	activityRepo repositories.ActivityRepository,
+	phaseRepo repositories.ProjectPhaseRepository,
+	inspectionRepo repositories.InspectionRepository,
+	regionRepo repositories.RegionRepository,
+	priceUpdates BOQPriceUpdateUseCase,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) BOQUsecase {
 	return &boqUsecase{
-		boqRepo:     boqRepo,
-		projectRepo: projectRepo,
+		boqRepo:        boqRepo,
+		projectRepo:    projectRepo,
+		activityRepo:   activityRepo,
+		phaseRepo:      phaseRepo,
+		inspectionRepo: inspectionRepo,
+		regionRepo:     regionRepo,
+		priceUpdates:   priceUpdates,
+		access:         projectAccess{userRepo: userRepo, memberRepo: memberRepo},
 	}
 }
 
+// checkAccess checks the caller may reach the project the BOQ belongs to.
+// The BOQ is only looked up for callers whose projects are limited.
+func (u *boqUsecase) checkAccess(ctx context.Context, boqID uuid.UUID) error {
+	if _, limited, err := u.access.restricted(ctx); err != nil || !limited {
+		return err
+	}
+	boq, err := u.boqRepo.GetByID(ctx, boqID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("boq not found")
+		}
+		return err
+	}
+	return u.access.check(ctx, boq.ProjectID)
+}
+
 func (u *boqUsecase) Approve(ctx context.Context, boqID uuid.UUID) error {
-	return u.boqRepo.Approve(ctx, boqID)
+	if err := u.checkAccess(ctx, boqID); err != nil {
+		return err
+	}
+
+	if err := u.boqRepo.Approve(ctx, boqID); err != nil {
+		return err
+	}
+
+	u.recordBOQActivity(ctx, boqID, "boq_approved", "BOQ approved")
+	return nil
+}
+
+func (u *boqUsecase) recordBOQActivity(ctx context.Context, boqID uuid.UUID, eventType, description string) {
+	if boq, err := u.boqRepo.GetByID(ctx, boqID); err == nil && boq != nil {
+		recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+			EntityType:  models.ActivityEntityBOQ,
+			EntityID:    boqID,
+			ProjectID:   uuid.NullUUID{UUID: boq.ProjectID, Valid: true},
+			EventType:   eventType,
+			Description: description,
+		})
+	}
 }
 func (u *boqUsecase) GetBoqWithProject(ctx context.Context, project_id uuid.UUID) (*responses.BOQResponse, error) {
+	if err := u.access.check(ctx, project_id); err != nil {
+		return nil, err
+	}
+
 	return u.boqRepo.GetBoqWithProject(ctx, project_id)
 }
 
 func (u *boqUsecase) AddBOQJob(ctx context.Context, boqID uuid.UUID, req requests.BOQJobRequest) error {
-	return u.boqRepo.AddBOQJob(ctx, boqID, req)
+	if err := u.checkAccess(ctx, boqID); err != nil {
+		return err
+	}
+
+	if req.PhaseID != nil {
+		if err := validateBOQPhase(ctx, u.boqRepo, u.phaseRepo, boqID, *req.PhaseID); err != nil {
+			return err
+		}
+	}
+	if err := u.boqRepo.AddBOQJob(ctx, boqID, req); err != nil {
+		return err
+	}
+
+	u.priceUpdates.BOQChanged(boqID)
+	u.recordBOQActivity(ctx, boqID, "boq_job_added",
+		fmt.Sprintf("BOQ job %s added: quantity %g, labor cost %.2f", req.JobID, req.Quantity, req.LaborCost))
+	return nil
 }
 
 func (u *boqUsecase) UpdateBOQJob(ctx context.Context, boqID uuid.UUID, req requests.BOQJobRequest) error {
-	return u.boqRepo.UpdateBOQJob(ctx, boqID, req)
+	if err := u.checkAccess(ctx, boqID); err != nil {
+		return err
+	}
+
+	if err := u.boqRepo.UpdateBOQJob(ctx, boqID, req); err != nil {
+		return err
+	}
+
+	u.priceUpdates.BOQChanged(boqID)
+	u.recordBOQActivity(ctx, boqID, "boq_job_updated",
+		fmt.Sprintf("BOQ job %s updated: quantity %g, labor cost %.2f", req.JobID, req.Quantity, req.LaborCost))
+	return nil
 }
 
 func (u *boqUsecase) DeleteBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) error {
-	return u.boqRepo.DeleteBOQJob(ctx, boqID, jobID)
+	if err := u.checkAccess(ctx, boqID); err != nil {
+		return err
+	}
+
+	if err := u.boqRepo.DeleteBOQJob(ctx, boqID, jobID); err != nil {
+		return err
+	}
+
+	u.priceUpdates.BOQChanged(boqID)
+	u.recordBOQActivity(ctx, boqID, "boq_job_removed", fmt.Sprintf("BOQ job %s removed", jobID))
+	return nil
+}
+
+// CompleteBOQJob refuses to complete a job until every mandatory inspection
+// for it has passed.
+func (u *boqUsecase) CompleteBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) error {
+	if err := u.checkAccess(ctx, boqID); err != nil {
+		return err
+	}
+
+	pending, err := u.inspectionRepo.CountPendingMandatory(ctx, boqID, jobID)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return errors.New("mandatory inspections have not passed")
+	}
+
+	return u.boqRepo.CompleteBOQJob(ctx, boqID, jobID)
 }
 
-func (u *boqUsecase) GetBOQSummary(ctx context.Context, projectID uuid.UUID) (*responses.BOQSummaryResponse, error) {
+func (u *boqUsecase) GetBOQSummary(ctx context.Context, projectID uuid.UUID, language string) (*responses.BOQSummaryResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	if language != "" && !models.DocumentLanguage(language).Valid() {
+		return nil, errors.New("language must be th, en or bilingual")
+	}
+
 	boq, err := u.boqRepo.GetByProjectID(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting BOQ: %w", err)
@@ -78,9 +217,38 @@ func (u *boqUsecase) GetBOQSummary(ctx context.Context, projectID uuid.UUID) (*r
 	if err != nil {
 		return nil, fmt.Errorf("error getting material details: %w", err)
 	}
+	if language != "" {
+		for i := range materials {
+			m := &materials[i]
+			m.MaterialName = models.MaterialDisplayName(m.MaterialName, m.ThaiName, m.EnglishName, models.DocumentLanguage(language))
+		}
+	}
+
+	region, err := u.regionRepo.GetProjectRegion(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting project region: %w", err)
+	}
 
 	// Transform data to DTOs
-	return transformToResponse(details[0], generalCosts, details, materials), nil
+	response := transformToResponse(details[0], generalCosts, details, materials)
+	applyRegionPreset(response, region)
+	return response, nil
+}
+
+// applyRegionPreset scales labor, entered at base rates, by the region's
+// factor and adds transport as a share of material cost.
+func applyRegionPreset(response *responses.BOQSummaryResponse, region *models.RegionPreset) {
+	if region == nil {
+		return
+	}
+
+	preset := toRegionPresetResponse(region)
+	response.Region = &preset
+
+	metrics := &response.SummaryMetrics
+	metrics.LaborAdjustment = metrics.TotalLaborCost * (region.LaborRateFactor - 1)
+	metrics.TransportCost = metrics.TotalMaterialCost * region.TransportCostPercent / 100
+	metrics.GrandTotal += metrics.LaborAdjustment + metrics.TransportCost
 }
 
 func transformGeneralCosts(costs []models.BOQGeneralCost) []responses.GeneralCostDTO {
@@ -104,6 +272,7 @@ func transformToResponse(firstDetail models.BOQDetails, generalCosts []models.BO
 		GeneralCosts: transformGeneralCosts(generalCosts),
 		Details:      transformBOQDetailsWithMaterials(details, materials),
 	}
+	response.Phases = calculatePhaseTotals(response.Details)
 
 	response.SummaryMetrics = calculateSummaryMetrics(response.GeneralCosts, response.Details)
 
@@ -128,6 +297,8 @@ func transformBOQDetailsWithMaterials(details []models.BOQDetails, materials []m
 		dtos[i] = responses.BOQDetailDTO{
 			JobID:               detail.JobID,
 			JobName:             detail.JobName,
+			PhaseID:             nullUUIDPtr(detail.PhaseID),
+			PhaseName:           detail.PhaseName.String,
 			Description:         detail.Description.String,
 			Quantity:            detail.Quantity,
 			Unit:                detail.Unit,
@@ -160,6 +331,7 @@ func transformMaterials(materials []models.BOQMaterialDetails) []responses.Mater
 			Unit:           material.Unit,
 			EstimatedPrice: estimatedPrice,
 			Total:          material.Total.Float64,
+			SupplierName:   material.SupplierName.String,
 		}
 	}
 	return dtos
@@ -187,3 +359,104 @@ func calculateSummaryMetrics(generalCosts []responses.GeneralCostDTO, details []
 
 	return metrics
 }
+
+// calculatePhaseTotals subtotals job details by phase, keeping the order in
+// which phases first appear. Details are already sorted by phase.
+func calculatePhaseTotals(details []responses.BOQDetailDTO) []responses.BOQPhaseTotal {
+	phases := []responses.BOQPhaseTotal{}
+	index := make(map[uuid.UUID]int)
+
+	for _, detail := range details {
+		key := uuid.Nil
+		if detail.PhaseID != nil {
+			key = *detail.PhaseID
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(phases)
+			index[key] = i
+			phases = append(phases, responses.BOQPhaseTotal{
+				PhaseID:   detail.PhaseID,
+				PhaseName: detail.PhaseName,
+			})
+		}
+
+		phases[i].TotalLaborCost += detail.TotalLaborCost
+		phases[i].TotalEstimatedPrice += detail.TotalEstimatedPrice
+		phases[i].TotalAmount += detail.Total
+		for _, material := range detail.Materials {
+			phases[i].TotalMaterialCost += material.Total
+		}
+	}
+
+	return phases
+}
+
+func (u *boqUsecase) AddJobDrawing(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, req requests.BOQJobDrawingRequest) (*responses.BOQJobDrawingResponse, error) {
+	if err := u.checkAccess(ctx, boqID); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, errors.New("drawing title is required")
+	}
+
+	fileURL := strings.TrimSpace(req.FileURL)
+	linkURL := strings.TrimSpace(req.URL)
+	if (fileURL == "") == (linkURL == "") {
+		return nil, errors.New("either file_url or url is required")
+	}
+
+	source, rawURL := models.DrawingSourceFile, fileURL
+	if linkURL != "" {
+		source, rawURL = models.DrawingSourceURL, linkURL
+	}
+	if parsed, err := url.Parse(rawURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("invalid drawing URL")
+	}
+
+	drawing := &models.BOQJobDrawing{
+		DrawingID:     uuid.New(),
+		BOQID:         boqID,
+		JobID:         jobID,
+		Title:         title,
+		DrawingNumber: optionalString(req.DrawingNumber),
+		Revision:      optionalString(req.Revision),
+		Source:        source,
+		URL:           rawURL,
+		CreatedAt:     time.Now(),
+	}
+	if err := u.boqRepo.AddJobDrawing(ctx, drawing); err != nil {
+		return nil, err
+	}
+
+	response := responses.NewBOQJobDrawingResponse(*drawing)
+	return &response, nil
+}
+
+func (u *boqUsecase) ListJobDrawings(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) ([]responses.BOQJobDrawingResponse, error) {
+	if err := u.checkAccess(ctx, boqID); err != nil {
+		return nil, err
+	}
+
+	drawings, err := u.boqRepo.ListJobDrawings(ctx, boqID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.BOQJobDrawingResponse, 0, len(drawings))
+	for _, d := range drawings {
+		result = append(result, responses.NewBOQJobDrawingResponse(d))
+	}
+	return result, nil
+}
+
+func (u *boqUsecase) DeleteJobDrawing(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, drawingID uuid.UUID) error {
+	if err := u.checkAccess(ctx, boqID); err != nil {
+		return err
+	}
+
+	return u.boqRepo.DeleteJobDrawing(ctx, boqID, jobID, drawingID)
+}
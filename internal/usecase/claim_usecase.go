@@ -0,0 +1,347 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ClaimUseCase interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.ClaimRequest) (*responses.ClaimResponse, error)
+	List(ctx context.Context, projectID uuid.UUID, status string) ([]responses.ClaimResponse, error)
+	GetByID(ctx context.Context, claimID uuid.UUID) (*responses.ClaimResponse, error)
+	// Update edits a claim until it is closed.
+	Update(ctx context.Context, claimID uuid.UUID, req requests.ClaimRequest) (*responses.ClaimResponse, error)
+	Delete(ctx context.Context, claimID uuid.UUID) error
+
+	Submit(ctx context.Context, claimID uuid.UUID) (*responses.ClaimResponse, error)
+	Settle(ctx context.Context, claimID uuid.UUID, req requests.SettleClaimRequest) (*responses.ClaimResponse, error)
+	Reject(ctx context.Context, claimID uuid.UUID, req requests.RejectClaimRequest) (*responses.ClaimResponse, error)
+	Withdraw(ctx context.Context, claimID uuid.UUID) (*responses.ClaimResponse, error)
+
+	AddDocument(ctx context.Context, claimID uuid.UUID, req requests.ClaimDocumentRequest) (*responses.ClaimDocumentResponse, error)
+	DeleteDocument(ctx context.Context, claimID uuid.UUID, documentID uuid.UUID) error
+}
+
+type claimUseCase struct {
+	claimRepo    repositories.ClaimRepository
+	contractRepo repositories.ContractRepository
+	access       projectAccess
+}
+
+func NewClaimUsecase(
+	claimRepo repositories.ClaimRepository,
+	contractRepo repositories.ContractRepository,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) ClaimUseCase {
+	return &claimUseCase{
+		claimRepo:    claimRepo,
+		contractRepo: contractRepo,
+		access:       projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *claimUseCase) Create(ctx context.Context, projectID uuid.UUID, req requests.ClaimRequest) (*responses.ClaimResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	contract, err := u.contractRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract: %w", err)
+	}
+	if contract == nil {
+		return nil, errors.New("contract not found")
+	}
+
+	now := time.Now()
+	claim := &models.Claim{
+		ClaimID:    uuid.New(),
+		ProjectID:  projectID,
+		ContractID: contract.ContractID,
+		Status:     models.ClaimStatusDraft,
+		CreatedBy:  actorFromContext(ctx),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := u.applyClaimFields(ctx, claim, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.claimRepo.Create(ctx, *claim); err != nil {
+		return nil, err
+	}
+
+	return u.response(ctx, claim)
+}
+
+func (u *claimUseCase) List(ctx context.Context, projectID uuid.UUID, status string) ([]responses.ClaimResponse, error) {
+	if status != "" && !models.ClaimStatus(status).Valid() {
+		return nil, errors.New("invalid claim status")
+	}
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	claims, err := u.claimRepo.ListByProject(ctx, projectID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.ClaimResponse, len(claims))
+	for i := range claims {
+		result[i] = toClaimResponse(&claims[i])
+	}
+	return result, nil
+}
+
+func (u *claimUseCase) GetByID(ctx context.Context, claimID uuid.UUID) (*responses.ClaimResponse, error) {
+	claim, err := u.get(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	return u.response(ctx, claim)
+}
+
+func (u *claimUseCase) Update(ctx context.Context, claimID uuid.UUID, req requests.ClaimRequest) (*responses.ClaimResponse, error) {
+	claim, err := u.get(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status.Closed() {
+		return nil, errors.New("claim is already closed")
+	}
+
+	if err := u.applyClaimFields(ctx, claim, req); err != nil {
+		return nil, err
+	}
+	return u.save(ctx, claim)
+}
+
+func (u *claimUseCase) Delete(ctx context.Context, claimID uuid.UUID) error {
+	if _, err := u.get(ctx, claimID); err != nil {
+		return err
+	}
+	return u.claimRepo.Delete(ctx, claimID)
+}
+
+func (u *claimUseCase) Submit(ctx context.Context, claimID uuid.UUID) (*responses.ClaimResponse, error) {
+	claim, err := u.get(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status != models.ClaimStatusDraft {
+		return nil, errors.New("only draft claims can be submitted")
+	}
+
+	claim.Status = models.ClaimStatusSubmitted
+	claim.SubmittedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return u.save(ctx, claim)
+}
+
+func (u *claimUseCase) Settle(ctx context.Context, claimID uuid.UUID, req requests.SettleClaimRequest) (*responses.ClaimResponse, error) {
+	claim, err := u.get(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status != models.ClaimStatusSubmitted {
+		return nil, errors.New("only submitted claims can be settled")
+	}
+	if req.SettlementAmount < 0 || req.SettlementDays < 0 {
+		return nil, errors.New("settlement cannot be negative")
+	}
+
+	claim.Status = models.ClaimStatusSettled
+	claim.SettlementAmount = sql.NullFloat64{Float64: roundTo(req.SettlementAmount, 2), Valid: true}
+	claim.SettlementDays = sql.NullInt64{Int64: int64(req.SettlementDays), Valid: true}
+	claim.Resolution = optionalString(req.Resolution)
+	claim.ClosedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return u.save(ctx, claim)
+}
+
+func (u *claimUseCase) Reject(ctx context.Context, claimID uuid.UUID, req requests.RejectClaimRequest) (*responses.ClaimResponse, error) {
+	claim, err := u.get(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status != models.ClaimStatusSubmitted {
+		return nil, errors.New("only submitted claims can be rejected")
+	}
+	resolution := strings.TrimSpace(req.Resolution)
+	if resolution == "" {
+		return nil, errors.New("resolution is required")
+	}
+
+	claim.Status = models.ClaimStatusRejected
+	claim.Resolution = optionalString(resolution)
+	claim.ClosedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return u.save(ctx, claim)
+}
+
+func (u *claimUseCase) Withdraw(ctx context.Context, claimID uuid.UUID) (*responses.ClaimResponse, error) {
+	claim, err := u.get(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status.Closed() {
+		return nil, errors.New("claim is already closed")
+	}
+
+	claim.Status = models.ClaimStatusWithdrawn
+	claim.ClosedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return u.save(ctx, claim)
+}
+
+func (u *claimUseCase) AddDocument(ctx context.Context, claimID uuid.UUID, req requests.ClaimDocumentRequest) (*responses.ClaimDocumentResponse, error) {
+	if _, err := u.get(ctx, claimID); err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(req.Title)
+	fileURL := strings.TrimSpace(req.FileURL)
+	if title == "" || fileURL == "" {
+		return nil, errors.New("title and file url are required")
+	}
+
+	document := models.ClaimDocument{
+		DocumentID: uuid.New(),
+		ClaimID:    claimID,
+		Title:      title,
+		FileURL:    fileURL,
+		UploadedBy: actorFromContext(ctx),
+		CreatedAt:  time.Now(),
+	}
+	if err := u.claimRepo.AddDocument(ctx, document); err != nil {
+		return nil, err
+	}
+
+	response := toClaimDocumentResponse(&document)
+	return &response, nil
+}
+
+func (u *claimUseCase) DeleteDocument(ctx context.Context, claimID uuid.UUID, documentID uuid.UUID) error {
+	if _, err := u.get(ctx, claimID); err != nil {
+		return err
+	}
+	return u.claimRepo.DeleteDocument(ctx, claimID, documentID)
+}
+
+// get loads a claim the caller may see.
+func (u *claimUseCase) get(ctx context.Context, claimID uuid.UUID) (*models.Claim, error) {
+	claim, err := u.claimRepo.GetByID(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.access.check(ctx, claim.ProjectID); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+func (u *claimUseCase) applyClaimFields(ctx context.Context, claim *models.Claim, req requests.ClaimRequest) error {
+	claimType := models.ClaimType(req.ClaimType)
+	if !claimType.Valid() {
+		return errors.New("invalid claim type")
+	}
+	title := strings.TrimSpace(req.Title)
+	narrative := strings.TrimSpace(req.Narrative)
+	if title == "" || narrative == "" {
+		return errors.New("title and narrative are required")
+	}
+	if req.ClaimedAmount < 0 || req.ClaimedDays < 0 {
+		return errors.New("claimed amount and days cannot be negative")
+	}
+	if claimType == models.ClaimTypeTimeExtension && req.ClaimedDays == 0 {
+		return errors.New("a time-extension claim needs claimed days")
+	}
+	if claimType == models.ClaimTypeCost && req.ClaimedAmount == 0 {
+		return errors.New("a cost claim needs a claimed amount")
+	}
+
+	claim.DocumentID = uuid.NullUUID{}
+	if req.DocumentID != nil {
+		if _, err := u.contractRepo.GetDocument(ctx, claim.ProjectID, *req.DocumentID); err != nil {
+			return err
+		}
+		claim.DocumentID = uuid.NullUUID{UUID: *req.DocumentID, Valid: true}
+	}
+
+	claim.ClaimType = claimType
+	claim.Title = title
+	claim.Narrative = narrative
+	claim.ClaimedAmount = roundTo(req.ClaimedAmount, 2)
+	claim.ClaimedDays = req.ClaimedDays
+	return nil
+}
+
+func (u *claimUseCase) save(ctx context.Context, claim *models.Claim) (*responses.ClaimResponse, error) {
+	claim.UpdatedAt = time.Now()
+	if err := u.claimRepo.Update(ctx, *claim); err != nil {
+		return nil, err
+	}
+	return u.response(ctx, claim)
+}
+
+func (u *claimUseCase) response(ctx context.Context, claim *models.Claim) (*responses.ClaimResponse, error) {
+	documents, err := u.claimRepo.ListDocuments(ctx, claim.ClaimID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toClaimResponse(claim)
+	for i := range documents {
+		response.Documents = append(response.Documents, toClaimDocumentResponse(&documents[i]))
+	}
+	return &response, nil
+}
+
+func toClaimResponse(claim *models.Claim) responses.ClaimResponse {
+	response := responses.ClaimResponse{
+		ClaimID:       claim.ClaimID,
+		ProjectID:     claim.ProjectID,
+		ContractID:    claim.ContractID,
+		DocumentID:    nullUUIDPtr(claim.DocumentID),
+		ClaimType:     string(claim.ClaimType),
+		Title:         claim.Title,
+		Narrative:     claim.Narrative,
+		ClaimedAmount: claim.ClaimedAmount,
+		ClaimedDays:   claim.ClaimedDays,
+		Status:        string(claim.Status),
+		Resolution:    claim.Resolution.String,
+		CreatedBy:     nullUUIDPtr(claim.CreatedBy),
+		CreatedAt:     claim.CreatedAt,
+		UpdatedAt:     claim.UpdatedAt,
+	}
+	if claim.SettlementAmount.Valid {
+		response.SettlementAmount = &claim.SettlementAmount.Float64
+	}
+	if claim.SettlementDays.Valid {
+		response.SettlementDays = &claim.SettlementDays.Int64
+	}
+	if claim.SubmittedAt.Valid {
+		response.SubmittedAt = &claim.SubmittedAt.Time
+	}
+	if claim.ClosedAt.Valid {
+		response.ClosedAt = &claim.ClosedAt.Time
+	}
+	return response
+}
+
+func toClaimDocumentResponse(document *models.ClaimDocument) responses.ClaimDocumentResponse {
+	return responses.ClaimDocumentResponse{
+		DocumentID: document.DocumentID,
+		Title:      document.Title,
+		FileURL:    document.FileURL,
+		UploadedBy: nullUUIDPtr(document.UploadedBy),
+		CreatedAt:  document.CreatedAt,
+	}
+}
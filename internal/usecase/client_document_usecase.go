@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ClientDocumentUsecase interface {
+	List(ctx context.Context, clientID uuid.UUID) ([]responses.ClientDocumentResponse, error)
+	// Upload stores a PDF, JPEG or PNG of up to 3 MB against the client.
+	Upload(ctx context.Context, clientID uuid.UUID, req requests.ClientDocumentRequest, fileName string, file io.Reader) (*responses.ClientDocumentResponse, error)
+	Delete(ctx context.Context, clientID, documentID uuid.UUID) error
+
+	// Link returns a signed download link for the document.
+	Link(ctx context.Context, clientID, documentID uuid.UUID) (*responses.ClientDocumentLinkResponse, error)
+	// Download returns the document a signed link points to and its
+	// content.
+	Download(ctx context.Context, token string) (*models.ClientDocument, []byte, error)
+}
+
+type clientDocumentUsecase struct {
+	clientRepo    repositories.ClientRepository
+	activityRepo  repositories.ActivityRepository
+	documentStore repositories.DocumentStore
+	links         linkSigner
+	linkBaseURL   string
+	linkTTL       time.Duration
+}
+
+// NewClientDocumentUsecase takes the store documents are kept in, which
+// may be nil when none is configured, and the secret, base URL and
+// lifetime of the download links it signs.
+func NewClientDocumentUsecase(
+	clientRepo repositories.ClientRepository,
+	activityRepo repositories.ActivityRepository,
+	documentStore repositories.DocumentStore,
+	linkSecret string,
+	linkBaseURL string,
+	linkTTL time.Duration,
+) ClientDocumentUsecase {
+	return &clientDocumentUsecase{
+		clientRepo:    clientRepo,
+		activityRepo:  activityRepo,
+		documentStore: documentStore,
+		links:         newLinkSigner(linkSecret, linkPurposeClientDocument),
+		linkBaseURL:   strings.TrimRight(linkBaseURL, "/"),
+		linkTTL:       linkTTL,
+	}
+}
+
+// maxClientDocumentSize keeps uploads under Fiber's default 4 MB body
+// limit.
+const maxClientDocumentSize = 3 << 20
+
+var clientDocumentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+func (u *clientDocumentUsecase) List(ctx context.Context, clientID uuid.UUID) ([]responses.ClientDocumentResponse, error) {
+	if _, err := u.clientRepo.GetByID(ctx, clientID); err != nil {
+		return nil, err
+	}
+
+	documents, err := u.clientRepo.ListDocuments(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.ClientDocumentResponse, len(documents))
+	for i := range documents {
+		result[i] = toClientDocumentResponse(&documents[i])
+	}
+	return result, nil
+}
+
+func (u *clientDocumentUsecase) Upload(ctx context.Context, clientID uuid.UUID, req requests.ClientDocumentRequest, fileName string, file io.Reader) (*responses.ClientDocumentResponse, error) {
+	if u.documentStore == nil {
+		return nil, errors.New("document storage is not configured")
+	}
+
+	documentType := models.ClientDocumentType(req.DocumentType)
+	if !documentType.Valid() {
+		return nil, errors.New("invalid document type")
+	}
+
+	client, err := u.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client.ArchivedAt.Valid {
+		return nil, errors.New("client is archived")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxClientDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("document is empty")
+	}
+	if len(data) > maxClientDocumentSize {
+		return nil, errors.New("document must not exceed 3 MB")
+	}
+
+	// The browser's content type isn't trusted; ID copies are served back
+	// with whatever type is stored here.
+	contentType := http.DetectContentType(data)
+	if !clientDocumentContentTypes[contentType] {
+		return nil, errors.New("document must be a PDF, JPEG or PNG file")
+	}
+
+	document := models.ClientDocument{
+		DocumentID:   uuid.New(),
+		ClientID:     clientID,
+		DocumentType: documentType,
+		FileName:     clientDocumentFileName(fileName),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Note:         optionalString(req.Note),
+		UploadedBy:   actorFromContext(ctx),
+		CreatedAt:    time.Now(),
+	}
+	// Keys don't include the client, so documents stay put when clients
+	// are merged.
+	document.StorageKey = "client-documents/" + document.DocumentID.String()
+
+	if err := u.documentStore.Put(ctx, document.StorageKey, contentType, data); err != nil {
+		return nil, err
+	}
+	if err := u.clientRepo.CreateDocument(ctx, document); err != nil {
+		if deleteErr := u.documentStore.Delete(ctx, document.StorageKey); deleteErr != nil {
+			log.Printf("failed to remove unsaved client document %s: %v", document.StorageKey, deleteErr)
+		}
+		return nil, err
+	}
+
+	u.recordDocumentActivity(ctx, &document, "client_document_uploaded", "Document uploaded: ")
+
+	response := toClientDocumentResponse(&document)
+	return &response, nil
+}
+
+func (u *clientDocumentUsecase) Delete(ctx context.Context, clientID, documentID uuid.UUID) error {
+	document, err := u.clientRepo.GetDocument(ctx, clientID, documentID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.clientRepo.DeleteDocument(ctx, clientID, documentID); err != nil {
+		return err
+	}
+
+	// The record is gone, so no link can reach the file any more; a file
+	// left behind is only logged.
+	if u.documentStore != nil {
+		if err := u.documentStore.Delete(ctx, document.StorageKey); err != nil {
+			log.Printf("failed to remove client document %s: %v", document.StorageKey, err)
+		}
+	}
+
+	u.recordDocumentActivity(ctx, document, "client_document_deleted", "Document deleted: ")
+	return nil
+}
+
+func (u *clientDocumentUsecase) Link(ctx context.Context, clientID, documentID uuid.UUID) (*responses.ClientDocumentLinkResponse, error) {
+	document, err := u.clientRepo.GetDocument(ctx, clientID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(u.linkTTL).Truncate(time.Second)
+	return &responses.ClientDocumentLinkResponse{
+		URL:       u.linkBaseURL + "/" + u.links.sign(document.DocumentID, expiresAt),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (u *clientDocumentUsecase) Download(ctx context.Context, token string) (*models.ClientDocument, []byte, error) {
+	invalid := errors.New("invalid or expired link")
+
+	documentID, ok := u.links.verify(token)
+	if !ok {
+		return nil, nil, invalid
+	}
+
+	document, err := u.clientRepo.GetDocumentByID(ctx, documentID)
+	if err != nil {
+		if err.Error() == "document not found" {
+			return nil, nil, invalid
+		}
+		return nil, nil, err
+	}
+
+	if u.documentStore == nil {
+		return nil, nil, errors.New("document storage is not configured")
+	}
+	data, err := u.documentStore.Get(ctx, document.StorageKey)
+	if err != nil {
+		if err.Error() == "object not found" {
+			return nil, nil, errors.New("document not found")
+		}
+		return nil, nil, err
+	}
+
+	return document, data, nil
+}
+
+func (u *clientDocumentUsecase) recordDocumentActivity(ctx context.Context, document *models.ClientDocument, eventType, description string) {
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType:  models.ActivityEntityClient,
+		EntityID:    document.ClientID,
+		ClientID:    uuid.NullUUID{UUID: document.ClientID, Valid: true},
+		EventType:   eventType,
+		Description: description + document.FileName,
+	})
+}
+
+// clientDocumentFileName keeps only the base name of an uploaded file, as
+// it ends up in a Content-Disposition header.
+func clientDocumentFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r == '"' || r < ' ' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "document"
+	}
+	return name
+}
+
+func toClientDocumentResponse(document *models.ClientDocument) responses.ClientDocumentResponse {
+	return responses.ClientDocumentResponse{
+		DocumentID:   document.DocumentID,
+		ClientID:     document.ClientID,
+		DocumentType: string(document.DocumentType),
+		FileName:     document.FileName,
+		ContentType:  document.ContentType,
+		Size:         document.Size,
+		Note:         document.Note.String,
+		UploadedBy:   nullUUIDPtr(document.UploadedBy),
+		CreatedAt:    document.CreatedAt,
+	}
+}
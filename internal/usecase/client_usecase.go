@@ -1,11 +1,17 @@
 package usecase
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/repositories"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/responses"
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,18 +19,43 @@ import (
 type ClientUsecase interface {
 	Create(ctx context.Context, req requests.CreateClientRequest) (*responses.ClientResponse, error)
 	Update(ctx context.Context, id uuid.UUID, req requests.UpdateClientRequest) error
+	// Delete archives the client rather than removing it, so its projects
+	// keep their client.
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*responses.ClientResponse, error)
-	List(ctx context.Context, page, pageSize int) (*responses.ClientListResponse, error)
+	List(ctx context.Context, req requests.ListClientsRequest) (*responses.ClientListResponse, error)
+
+	SetCreditHold(ctx context.Context, id uuid.UUID, req requests.SetCreditHoldRequest) error
+	ReleaseCreditHold(ctx context.Context, id uuid.UUID) error
+	ApplyOverdueCreditHolds(ctx context.Context) error
+
+	ListContacts(ctx context.Context, clientID uuid.UUID) ([]responses.ClientContactResponse, error)
+	AddContact(ctx context.Context, clientID uuid.UUID, req requests.ClientContactRequest) (*responses.ClientContactResponse, error)
+	UpdateContact(ctx context.Context, clientID, contactID uuid.UUID, req requests.ClientContactRequest) (*responses.ClientContactResponse, error)
+	RemoveContact(ctx context.Context, clientID, contactID uuid.UUID) error
+
+	PreviewMerge(ctx context.Context, sourceID, targetID uuid.UUID) (*responses.ClientMergePreviewResponse, error)
+	Merge(ctx context.Context, req requests.MergeClientRequest) (*responses.ClientResponse, error)
 }
 
 type clientUsecase struct {
-	clientRepo repositories.ClientRepository
+	clientRepo   repositories.ClientRepository
+	activityRepo repositories.ActivityRepository
+	overdueDays  int
 }
 
-func NewClientUsecase(clientRepo repositories.ClientRepository) ClientUsecase {
+// NewClientUsecase takes the number of days an invoice may stay unpaid
+// before its client is put on credit hold automatically.
+func NewClientUsecase(
+	clientRepo repositories.ClientRepository,
+	activityRepo repositories.ActivityRepository,
+	overdueDays int,
+) ClientUsecase {
 	return &clientUsecase{
-		clientRepo: clientRepo,
+		clientRepo:   clientRepo,
+		activityRepo: activityRepo,
+		overdueDays:  overdueDays,
 	}
 }
 
@@ -39,14 +70,8 @@ func (u *clientUsecase) Create(ctx context.Context, req requests.CreateClientReq
 		return nil, err
 	}
 
-	return &responses.ClientResponse{
-		ID:      client.ClientID,
-		Name:    client.Name,
-		Email:   client.Email,
-		Tel:     client.Tel,
-		Address: client.Address,
-		TaxID:   client.TaxID,
-	}, nil
+	response := toClientResponse(client)
+	return &response, nil
 }
 
 func (u *clientUsecase) Update(ctx context.Context, id uuid.UUID, req requests.UpdateClientRequest) error {
@@ -55,6 +80,13 @@ func (u *clientUsecase) Update(ctx context.Context, id uuid.UUID, req requests.U
 		return err
 	}
 
+	if req.CreditLimit != nil && *req.CreditLimit < 0 {
+		return errors.New("credit limit cannot be negative")
+	}
+	if req.PaymentTermsDays != nil && *req.PaymentTermsDays < 0 {
+		return errors.New("payment terms cannot be negative")
+	}
+
 	if existing.Email != req.Email {
 		client, err := u.clientRepo.GetByEmail(ctx, req.Email)
 		if err == nil && client != nil {
@@ -66,7 +98,21 @@ func (u *clientUsecase) Update(ctx context.Context, id uuid.UUID, req requests.U
 }
 
 func (u *clientUsecase) Delete(ctx context.Context, id uuid.UUID) error {
-	return u.clientRepo.Delete(ctx, id)
+	if err := u.clientRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	u.recordClientActivity(ctx, id, "client_archived", "Client archived")
+	return nil
+}
+
+func (u *clientUsecase) Restore(ctx context.Context, id uuid.UUID) error {
+	if err := u.clientRepo.Restore(ctx, id); err != nil {
+		return err
+	}
+
+	u.recordClientActivity(ctx, id, "client_restored", "Client restored")
+	return nil
 }
 
 func (u *clientUsecase) GetByID(ctx context.Context, id uuid.UUID) (*responses.ClientResponse, error) {
@@ -75,17 +121,36 @@ func (u *clientUsecase) GetByID(ctx context.Context, id uuid.UUID) (*responses.C
 		return nil, err
 	}
 
-	return &responses.ClientResponse{
-		ID:      client.ClientID,
-		Name:    client.Name,
-		Email:   client.Email,
-		Tel:     client.Tel,
-		Address: client.Address,
-		TaxID:   client.TaxID,
-	}, nil
+	contacts, err := u.clientRepo.ListContacts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toClientResponse(client)
+	response.Contacts = toClientContactResponses(contacts)
+	return &response, nil
 }
 
-func (u *clientUsecase) List(ctx context.Context, page, pageSize int) (*responses.ClientListResponse, error) {
+func (u *clientUsecase) List(ctx context.Context, req requests.ListClientsRequest) (*responses.ClientListResponse, error) {
+	filter := models.ClientFilter{
+		Search: strings.TrimSpace(req.Search),
+		TaxID:  strings.TrimSpace(req.TaxID),
+		SortBy: req.Sort,
+
+		IncludeArchived: req.IncludeArchived,
+	}
+	if _, ok := models.ClientSortColumns[filter.SortBy]; filter.SortBy != "" && !ok {
+		return nil, errors.New("invalid sort column")
+	}
+	switch req.Order {
+	case "", "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		return nil, errors.New("order must be asc or desc")
+	}
+
+	page, pageSize := req.Page, req.PageSize
 	if page < 1 {
 		page = 1
 	}
@@ -94,21 +159,14 @@ func (u *clientUsecase) List(ctx context.Context, page, pageSize int) (*response
 	}
 
 	offset := (page - 1) * pageSize
-	clients, total, err := u.clientRepo.List(ctx, pageSize, offset)
+	clients, total, err := u.clientRepo.List(ctx, filter, pageSize, offset)
 	if err != nil {
 		return nil, err
 	}
 
 	clientResponses := make([]responses.ClientResponse, len(clients))
-	for i, client := range clients {
-		clientResponses[i] = responses.ClientResponse{
-			ID:      client.ClientID,
-			Name:    client.Name,
-			Email:   client.Email,
-			Tel:     client.Tel,
-			Address: client.Address,
-			TaxID:   client.TaxID,
-		}
+	for i := range clients {
+		clientResponses[i] = toClientResponse(&clients[i])
 	}
 
 	return &responses.ClientListResponse{
@@ -116,3 +174,316 @@ func (u *clientUsecase) List(ctx context.Context, page, pageSize int) (*response
 		Total:   total,
 	}, nil
 }
+
+func (u *clientUsecase) SetCreditHold(ctx context.Context, id uuid.UUID, req requests.SetCreditHoldRequest) error {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return errors.New("reason is required")
+	}
+
+	if err := u.clientRepo.SetCreditHold(ctx, id, reason); err != nil {
+		return err
+	}
+
+	u.recordClientActivity(ctx, id, "credit_hold_set", "Credit hold set: "+reason)
+	return nil
+}
+
+func (u *clientUsecase) ReleaseCreditHold(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.clientRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	if err := u.clientRepo.ReleaseCreditHold(ctx, id); err != nil {
+		return err
+	}
+
+	u.recordClientActivity(ctx, id, "credit_hold_released", "Credit hold released")
+	return nil
+}
+
+func (u *clientUsecase) ApplyOverdueCreditHolds(ctx context.Context) error {
+	clients, err := u.clientRepo.ApplyOverdueCreditHolds(ctx, u.overdueDays)
+	if err != nil {
+		return err
+	}
+
+	for _, client := range clients {
+		log.Printf("client %s (%s) put on credit hold: %s", client.Name, client.ClientID, client.CreditHoldReason.String)
+		u.recordClientActivity(ctx, client.ClientID, "credit_hold_set", "Credit hold set automatically: "+client.CreditHoldReason.String)
+	}
+
+	return nil
+}
+
+func (u *clientUsecase) recordClientActivity(ctx context.Context, clientID uuid.UUID, eventType, description string) {
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType:  models.ActivityEntityClient,
+		EntityID:    clientID,
+		ClientID:    uuid.NullUUID{UUID: clientID, Valid: true},
+		EventType:   eventType,
+		Description: description,
+	})
+}
+
+// clientMergeFields are the fields a merge can take from either client.
+var clientMergeFields = []string{"name", "email", "tel", "address", "tax_id", "credit_limit", "payment_terms_days"}
+
+func clientFieldValue(client *models.Client, field string) interface{} {
+	switch field {
+	case "name":
+		return client.Name
+	case "email":
+		return client.Email
+	case "tel":
+		return client.Tel
+	case "address":
+		return client.Address
+	case "tax_id":
+		return client.TaxID
+	case "credit_limit":
+		if client.CreditLimit.Valid {
+			return client.CreditLimit.Float64
+		}
+	case "payment_terms_days":
+		if client.PaymentTermsDays.Valid {
+			return client.PaymentTermsDays.Int64
+		}
+	}
+	return nil
+}
+
+func (u *clientUsecase) getMergePair(ctx context.Context, sourceID, targetID uuid.UUID) (*models.Client, *models.Client, error) {
+	if sourceID == targetID {
+		return nil, nil, errors.New("cannot merge a client into itself")
+	}
+
+	source, err := u.clientRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := u.clientRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The surviving record has to be one new work can be booked against.
+	if target.ArchivedAt.Valid {
+		return nil, nil, errors.New("cannot merge into an archived client")
+	}
+
+	return source, target, nil
+}
+
+func (u *clientUsecase) PreviewMerge(ctx context.Context, sourceID, targetID uuid.UUID) (*responses.ClientMergePreviewResponse, error) {
+	source, target, err := u.getMergePair(ctx, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectCount, err := u.clientRepo.CountProjects(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]responses.ClientFieldConflict, 0)
+	for _, field := range clientMergeFields {
+		sourceValue := clientFieldValue(source, field)
+		targetValue := clientFieldValue(target, field)
+		if fmt.Sprint(sourceValue) == fmt.Sprint(targetValue) {
+			continue
+		}
+		conflicts = append(conflicts, responses.ClientFieldConflict{
+			Field:       field,
+			SourceValue: sourceValue,
+			TargetValue: targetValue,
+		})
+	}
+
+	return &responses.ClientMergePreviewResponse{
+		Source:             toClientResponse(source),
+		Target:             toClientResponse(target),
+		Conflicts:          conflicts,
+		SourceProjectCount: projectCount,
+	}, nil
+}
+
+func (u *clientUsecase) Merge(ctx context.Context, req requests.MergeClientRequest) (*responses.ClientResponse, error) {
+	source, target, err := u.getMergePair(ctx, req.SourceClientID, req.TargetClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *target
+	for field, keep := range req.Keep {
+		switch keep {
+		case "target":
+			continue
+		case "source":
+		default:
+			return nil, errors.New("keep values must be source or target")
+		}
+
+		switch field {
+		case "name":
+			merged.Name = source.Name
+		case "email":
+			merged.Email = source.Email
+		case "tel":
+			merged.Tel = source.Tel
+		case "address":
+			merged.Address = source.Address
+		case "tax_id":
+			merged.TaxID = source.TaxID
+		case "credit_limit":
+			merged.CreditLimit = source.CreditLimit
+		case "payment_terms_days":
+			merged.PaymentTermsDays = source.PaymentTermsDays
+		default:
+			return nil, fmt.Errorf("unknown merge field: %s", field)
+		}
+	}
+
+	// A hold on either record carries over to the merged client.
+	if source.CreditHold && !target.CreditHold {
+		merged.CreditHold = true
+		merged.CreditHoldAuto = source.CreditHoldAuto
+		merged.CreditHoldReason = source.CreditHoldReason
+		merged.CreditHoldAt = source.CreditHoldAt
+	}
+
+	if err := u.clientRepo.Merge(ctx, source.ClientID, merged); err != nil {
+		return nil, err
+	}
+
+	log.Printf("client %s (%s) merged into %s", source.Name, source.ClientID, target.ClientID)
+	u.recordClientActivity(ctx, target.ClientID, "client_merged", fmt.Sprintf("Client %s merged into this client", source.Name))
+
+	response := toClientResponse(&merged)
+	return &response, nil
+}
+
+func (u *clientUsecase) ListContacts(ctx context.Context, clientID uuid.UUID) ([]responses.ClientContactResponse, error) {
+	if _, err := u.clientRepo.GetByID(ctx, clientID); err != nil {
+		return nil, err
+	}
+
+	contacts, err := u.clientRepo.ListContacts(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	result := toClientContactResponses(contacts)
+	if result == nil {
+		result = []responses.ClientContactResponse{}
+	}
+	return result, nil
+}
+
+func (u *clientUsecase) AddContact(ctx context.Context, clientID uuid.UUID, req requests.ClientContactRequest) (*responses.ClientContactResponse, error) {
+	if _, err := u.clientRepo.GetByID(ctx, clientID); err != nil {
+		return nil, err
+	}
+
+	contact := models.ClientContact{
+		ContactID: uuid.New(),
+		ClientID:  clientID,
+		CreatedAt: time.Now(),
+	}
+	if err := applyContactRequest(&contact, req); err != nil {
+		return nil, err
+	}
+	if err := u.clientRepo.CreateContact(ctx, contact); err != nil {
+		return nil, err
+	}
+
+	response := toClientContactResponse(&contact)
+	return &response, nil
+}
+
+func (u *clientUsecase) UpdateContact(ctx context.Context, clientID, contactID uuid.UUID, req requests.ClientContactRequest) (*responses.ClientContactResponse, error) {
+	contact, err := u.clientRepo.GetContact(ctx, clientID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyContactRequest(contact, req); err != nil {
+		return nil, err
+	}
+	contact.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	if err := u.clientRepo.UpdateContact(ctx, *contact); err != nil {
+		return nil, err
+	}
+
+	response := toClientContactResponse(contact)
+	return &response, nil
+}
+
+func (u *clientUsecase) RemoveContact(ctx context.Context, clientID, contactID uuid.UUID) error {
+	return u.clientRepo.DeleteContact(ctx, clientID, contactID)
+}
+
+func applyContactRequest(contact *models.ClientContact, req requests.ClientContactRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("contact name is required")
+	}
+	email := strings.TrimSpace(req.Email)
+	if email != "" && !strings.Contains(email, "@") {
+		return errors.New("invalid contact email")
+	}
+
+	contact.Name = name
+	contact.Role = optionalString(req.Role)
+	contact.Email = optionalString(email)
+	contact.Tel = optionalString(req.Tel)
+	contact.IsPrimary = req.IsPrimary
+	return nil
+}
+
+func toClientContactResponses(contacts []models.ClientContact) []responses.ClientContactResponse {
+	if len(contacts) == 0 {
+		return nil
+	}
+	result := make([]responses.ClientContactResponse, len(contacts))
+	for i := range contacts {
+		result[i] = toClientContactResponse(&contacts[i])
+	}
+	return result
+}
+
+func toClientContactResponse(contact *models.ClientContact) responses.ClientContactResponse {
+	return responses.ClientContactResponse{
+		ContactID: contact.ContactID,
+		Name:      contact.Name,
+		Role:      contact.Role.String,
+		Email:     contact.Email.String,
+		Tel:       contact.Tel.String,
+		IsPrimary: contact.IsPrimary,
+	}
+}
+
+func toClientResponse(client *models.Client) responses.ClientResponse {
+	response := responses.ClientResponse{
+		ID:               client.ClientID,
+		Name:             client.Name,
+		Email:            client.Email,
+		Tel:              client.Tel,
+		Address:          client.Address,
+		TaxID:            client.TaxID,
+		CreditHold:       client.CreditHold,
+		CreditHoldAuto:   client.CreditHoldAuto,
+		CreditHoldReason: client.CreditHoldReason.String,
+	}
+	if client.CreditLimit.Valid {
+		response.CreditLimit = &client.CreditLimit.Float64
+	}
+	if client.PaymentTermsDays.Valid {
+		response.PaymentTermsDays = &client.PaymentTermsDays.Int64
+	}
+	if client.CreditHoldAt.Valid {
+		response.CreditHoldAt = &client.CreditHoldAt.Time
+	}
+	if client.ArchivedAt.Valid {
+		response.ArchivedAt = &client.ArchivedAt.Time
+	}
+	return response
+}
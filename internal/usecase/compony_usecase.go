@@ -7,7 +7,9 @@ import (
 	"boonkosang/internal/requests"
 	"boonkosang/internal/responses"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -51,6 +53,18 @@ func (u *companyUseCase) UpdateCompany(ctx context.Context, userID uuid.UUID, re
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
 
+	if req.PromptPayID != "" && !isValidPromptPayID(req.PromptPayID) {
+		return nil, errors.New("promptpay ID must be a 10-digit phone number, 13-digit tax ID or 15-digit e-wallet ID")
+	}
+
+	dateEra := existingCompany.DateEra
+	if req.DateEra != "" {
+		dateEra = models.DateEra(req.DateEra)
+		if !dateEra.Valid() {
+			return nil, errors.New("date era must be gregorian or buddhist")
+		}
+	}
+
 	// Convert address to JSON
 	addressJSON, err := json.Marshal(req.Address)
 	if err != nil {
@@ -65,6 +79,11 @@ func (u *companyUseCase) UpdateCompany(ctx context.Context, userID uuid.UUID, re
 		Tel:       req.Tel,
 		Address:   addressJSON,
 		TaxID:     req.TaxID,
+		PromptPayID: sql.NullString{
+			String: req.PromptPayID,
+			Valid:  req.PromptPayID != "",
+		},
+		DateEra: dateEra,
 	}
 
 	// Update in repository
@@ -90,12 +109,14 @@ func (u *companyUseCase) UpdateCompany(ctx context.Context, userID uuid.UUID, re
 func (u *companyUseCase) createCompanyResponse(company *models.Company) (*responses.CompanyResponse, error) {
 
 	return &responses.CompanyResponse{
-		CompanyID: company.CompanyID,
-		Name:      company.Name,
-		Email:     company.Email,
-		Tel:       company.Tel,
-		Address:   company.Address,
-		TaxID:     company.TaxID,
-		IsNew:     company.TaxID == "",
+		CompanyID:   company.CompanyID,
+		Name:        company.Name,
+		Email:       company.Email,
+		Tel:         company.Tel,
+		Address:     company.Address,
+		TaxID:       company.TaxID,
+		PromptPayID: company.PromptPayID.String,
+		DateEra:     string(company.DateEra),
+		IsNew:       company.TaxID == "",
 	}, nil
 }
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CubeTestUseCase interface {
+	Create(ctx context.Context, req requests.CreateCubeTestRequest) (*responses.CubeTestResponse, error)
+	GetByID(ctx context.Context, testID uuid.UUID) (*responses.CubeTestResponse, error)
+	// List lists the project's tests, optionally for one pour or job.
+	List(ctx context.Context, projectID uuid.UUID, pourID, jobID uuid.NullUUID) ([]responses.CubeTestResponse, error)
+	RecordResults(ctx context.Context, testID uuid.UUID, req requests.RecordCubeResultsRequest) (*responses.CubeTestResponse, error)
+	Delete(ctx context.Context, testID uuid.UUID) error
+	Summary(ctx context.Context, projectID uuid.UUID) (*responses.CubeTestSummaryResponse, error)
+	// Alerts lists failed tests and weak 7-day results on every project
+	// that is still open.
+	Alerts(ctx context.Context) ([]responses.CubeTestResponse, error)
+}
+
+type cubeTestUseCase struct {
+	cubeRepo    repositories.CubeTestRepository
+	pourRepo    repositories.ConcretePourRepository
+	projectRepo repositories.ProjectRepository
+	access      projectAccess
+}
+
+func NewCubeTestUsecase(
+	cubeRepo repositories.CubeTestRepository,
+	pourRepo repositories.ConcretePourRepository,
+	projectRepo repositories.ProjectRepository,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) CubeTestUseCase {
+	return &cubeTestUseCase{
+		cubeRepo:    cubeRepo,
+		pourRepo:    pourRepo,
+		projectRepo: projectRepo,
+		access:      projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *cubeTestUseCase) Create(ctx context.Context, req requests.CreateCubeTestRequest) (*responses.CubeTestResponse, error) {
+	reference := strings.TrimSpace(req.SampleReference)
+	if reference == "" {
+		return nil, errors.New("sample reference is required")
+	}
+	if req.SpecifiedStrength <= 0 {
+		return nil, errors.New("specified strength must be greater than 0")
+	}
+
+	pour, err := u.pourRepo.GetByID(ctx, req.PourID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.access.check(ctx, pour.ProjectID); err != nil {
+		return nil, err
+	}
+
+	sampleDate := pour.PourDate
+	if date := strings.TrimSpace(req.SampleDate); date != "" {
+		sampleDate, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+	if sampleDate.Before(pour.PourDate) {
+		return nil, errors.New("sample date cannot be before the pour date")
+	}
+
+	test := models.CubeTest{
+		TestID:            uuid.New(),
+		PourID:            pour.PourID,
+		ProjectID:         pour.ProjectID,
+		JobID:             pour.JobID,
+		SampleReference:   reference,
+		SampleDate:        sampleDate,
+		SpecifiedStrength: req.SpecifiedStrength,
+		Lab:               optionalString(req.Lab),
+		Note:              optionalString(req.Note),
+		CreatedAt:         time.Now(),
+	}
+	if err := applyCubeStrengths(&test, req.Strength7Day, req.Strength28Day); err != nil {
+		return nil, err
+	}
+
+	if err := u.cubeRepo.Create(ctx, test); err != nil {
+		return nil, err
+	}
+
+	detail := &models.CubeTestDetail{CubeTest: test, TicketNumber: pour.TicketNumber, JobName: pour.JobName}
+	logCubeTestAlert(detail)
+	response := toCubeTestResponse(detail)
+	return &response, nil
+}
+
+func (u *cubeTestUseCase) GetByID(ctx context.Context, testID uuid.UUID) (*responses.CubeTestResponse, error) {
+	test, err := u.cubeRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.access.check(ctx, test.ProjectID); err != nil {
+		return nil, err
+	}
+
+	response := toCubeTestResponse(test)
+	return &response, nil
+}
+
+func (u *cubeTestUseCase) List(ctx context.Context, projectID uuid.UUID, pourID, jobID uuid.NullUUID) ([]responses.CubeTestResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	tests, err := u.cubeRepo.List(ctx, models.CubeTestFilter{
+		ProjectID: uuid.NullUUID{UUID: projectID, Valid: true},
+		PourID:    pourID,
+		JobID:     jobID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCubeTestResponses(tests), nil
+}
+
+func (u *cubeTestUseCase) RecordResults(ctx context.Context, testID uuid.UUID, req requests.RecordCubeResultsRequest) (*responses.CubeTestResponse, error) {
+	test, err := u.cubeRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.access.check(ctx, test.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := applyCubeStrengths(&test.CubeTest, req.Strength7Day, req.Strength28Day); err != nil {
+		return nil, err
+	}
+	if req.Lab != nil {
+		test.Lab = optionalString(*req.Lab)
+	}
+	if req.Note != nil {
+		test.Note = optionalString(*req.Note)
+	}
+	test.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := u.cubeRepo.UpdateResults(ctx, test.CubeTest); err != nil {
+		return nil, err
+	}
+
+	logCubeTestAlert(test)
+	response := toCubeTestResponse(test)
+	return &response, nil
+}
+
+func (u *cubeTestUseCase) Delete(ctx context.Context, testID uuid.UUID) error {
+	test, err := u.cubeRepo.GetByID(ctx, testID)
+	if err != nil {
+		return err
+	}
+	if err := u.access.check(ctx, test.ProjectID); err != nil {
+		return err
+	}
+	return u.cubeRepo.Delete(ctx, testID)
+}
+
+func (u *cubeTestUseCase) Summary(ctx context.Context, projectID uuid.UUID) (*responses.CubeTestSummaryResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	tests, err := u.cubeRepo.List(ctx, models.CubeTestFilter{ProjectID: uuid.NullUUID{UUID: projectID, Valid: true}})
+	if err != nil {
+		return nil, err
+	}
+	return toCubeTestSummaryResponse(tests), nil
+}
+
+func (u *cubeTestUseCase) Alerts(ctx context.Context) ([]responses.CubeTestResponse, error) {
+	tests, err := u.cubeRepo.List(ctx, models.CubeTestFilter{})
+	if err != nil {
+		return nil, err
+	}
+	visible, err := u.access.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []responses.CubeTestResponse{}
+	for i := range tests {
+		t := &tests[i]
+		if visible(t.ProjectID) && (t.Status() == models.CubeTestStatusFailed || t.EarlyWarning()) {
+			result = append(result, toCubeTestResponse(t))
+		}
+	}
+	return result, nil
+}
+
+func applyCubeStrengths(test *models.CubeTest, strength7Day, strength28Day *float64) error {
+	if strength7Day != nil {
+		if *strength7Day <= 0 {
+			return errors.New("strength must be greater than 0")
+		}
+		test.Strength7Day = sql.NullFloat64{Float64: *strength7Day, Valid: true}
+	}
+	if strength28Day != nil {
+		if *strength28Day <= 0 {
+			return errors.New("strength must be greater than 0")
+		}
+		test.Strength28Day = sql.NullFloat64{Float64: *strength28Day, Valid: true}
+	}
+	return nil
+}
+
+// logCubeTestAlert logs a failed test or a weak 7-day result as soon as it
+// is recorded.
+func logCubeTestAlert(test *models.CubeTestDetail) {
+	switch {
+	case test.Status() == models.CubeTestStatusFailed:
+		log.Printf("cube test alert: sample %s from ticket %s (%s) failed at 28 days with %.1f of %.1f MPa",
+			test.SampleReference, test.TicketNumber, test.JobName, test.Strength28Day.Float64, test.SpecifiedStrength)
+	case test.EarlyWarning():
+		log.Printf("cube test alert: sample %s from ticket %s (%s) reached %.1f of %.1f MPa at 7 days",
+			test.SampleReference, test.TicketNumber, test.JobName, test.Strength7Day.Float64, test.SpecifiedStrength)
+	}
+}
+
+func toCubeTestSummaryResponse(tests []models.CubeTestDetail) *responses.CubeTestSummaryResponse {
+	summary := models.SummarizeCubeTests(tests)
+	response := &responses.CubeTestSummaryResponse{
+		Total:         summary.Total,
+		Pending:       summary.Pending,
+		Passed:        summary.Passed,
+		Failed:        summary.Failed,
+		EarlyWarnings: summary.EarlyWarnings,
+		Failures:      []responses.CubeTestResponse{},
+	}
+	for i := range tests {
+		if tests[i].Status() == models.CubeTestStatusFailed {
+			response.Failures = append(response.Failures, toCubeTestResponse(&tests[i]))
+		}
+	}
+	return response
+}
+
+func toCubeTestResponses(tests []models.CubeTestDetail) []responses.CubeTestResponse {
+	result := make([]responses.CubeTestResponse, len(tests))
+	for i := range tests {
+		result[i] = toCubeTestResponse(&tests[i])
+	}
+	return result
+}
+
+func toCubeTestResponse(test *models.CubeTestDetail) responses.CubeTestResponse {
+	response := responses.CubeTestResponse{
+		TestID:            test.TestID,
+		PourID:            test.PourID,
+		TicketNumber:      test.TicketNumber,
+		ProjectID:         test.ProjectID,
+		ProjectName:       test.ProjectName,
+		JobID:             test.JobID,
+		JobName:           test.JobName,
+		SampleReference:   test.SampleReference,
+		SampleDate:        test.SampleDate.Format("2006-01-02"),
+		SpecifiedStrength: test.SpecifiedStrength,
+		Lab:               test.Lab.String,
+		Note:              test.Note.String,
+		Status:            string(test.Status()),
+		EarlyWarning:      test.EarlyWarning(),
+	}
+	if test.Strength7Day.Valid {
+		response.Strength7Day = &test.Strength7Day.Float64
+	}
+	if test.Strength28Day.Valid {
+		response.Strength28Day = &test.Strength28Day.Float64
+	}
+	return response
+}
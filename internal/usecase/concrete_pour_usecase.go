@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ConcretePourUseCase interface {
+	Record(ctx context.Context, projectID uuid.UUID, req requests.RecordConcretePourRequest) (*responses.ConcretePourResponse, error)
+	GetByID(ctx context.Context, pourID uuid.UUID) (*responses.ConcretePourResponse, error)
+	List(ctx context.Context, projectID uuid.UUID, jobID uuid.NullUUID) ([]responses.ConcretePourResponse, error)
+	Delete(ctx context.Context, pourID uuid.UUID) error
+	// Summary totals the concrete poured into each of the project's BOQ
+	// jobs and how many loads weren't sampled for cube tests.
+	Summary(ctx context.Context, projectID uuid.UUID) ([]responses.ConcretePourSummaryResponse, error)
+}
+
+type concretePourUseCase struct {
+	pourRepo     repositories.ConcretePourRepository
+	projectRepo  repositories.ProjectRepository
+	boqRepo      repositories.BOQRepository
+	supplierRepo repositories.SupplierRepository
+	materialRepo repositories.MaterialRepository
+	access       projectAccess
+}
+
+func NewConcretePourUsecase(
+	pourRepo repositories.ConcretePourRepository,
+	projectRepo repositories.ProjectRepository,
+	boqRepo repositories.BOQRepository,
+	supplierRepo repositories.SupplierRepository,
+	materialRepo repositories.MaterialRepository,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) ConcretePourUseCase {
+	return &concretePourUseCase{
+		pourRepo:     pourRepo,
+		projectRepo:  projectRepo,
+		boqRepo:      boqRepo,
+		supplierRepo: supplierRepo,
+		materialRepo: materialRepo,
+		access:       projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *concretePourUseCase) Record(ctx context.Context, projectID uuid.UUID, req requests.RecordConcretePourRequest) (*responses.ConcretePourResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	ticket := strings.TrimSpace(req.TicketNumber)
+	if ticket == "" {
+		return nil, errors.New("ticket number is required")
+	}
+	if req.Volume <= 0 {
+		return nil, errors.New("volume must be greater than 0")
+	}
+	if req.SlumpMM != nil && *req.SlumpMM < 0 {
+		return nil, errors.New("slump cannot be negative")
+	}
+
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	boq, err := u.boqRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.supplierRepo.GetByID(ctx, req.SupplierID); err != nil {
+		return nil, err
+	}
+	materialID := strings.TrimSpace(req.MaterialID)
+	if materialID != "" {
+		if _, err := u.materialRepo.GetByID(ctx, materialID); err != nil {
+			return nil, err
+		}
+	}
+
+	pourDate := currentDate()
+	if date := strings.TrimSpace(req.PourDate); date != "" {
+		pourDate, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+
+	var cubes []string
+	seen := map[string]bool{}
+	for _, ref := range req.CubeReferences {
+		if ref = strings.TrimSpace(ref); ref != "" && !seen[ref] {
+			seen[ref] = true
+			cubes = append(cubes, ref)
+		}
+	}
+
+	pour := models.ConcretePour{
+		PourID:         uuid.New(),
+		ProjectID:      projectID,
+		BOQID:          boq.BOQID,
+		JobID:          req.JobID,
+		SupplierID:     req.SupplierID,
+		MaterialID:     sql.NullString{String: materialID, Valid: materialID != ""},
+		TicketNumber:   ticket,
+		PourDate:       pourDate,
+		Volume:         req.Volume,
+		Location:       optionalString(req.Location),
+		Note:           optionalString(req.Note),
+		CreatedBy:      actorFromContext(ctx),
+		CreatedAt:      time.Now(),
+		CubeReferences: cubes,
+	}
+	if req.SlumpMM != nil {
+		pour.SlumpMM = sql.NullFloat64{Float64: *req.SlumpMM, Valid: true}
+	}
+
+	if err := u.pourRepo.Create(ctx, pour); err != nil {
+		return nil, err
+	}
+
+	response := toConcretePourResponse(&models.ConcretePourDetail{ConcretePour: pour})
+	return &response, nil
+}
+
+func (u *concretePourUseCase) GetByID(ctx context.Context, pourID uuid.UUID) (*responses.ConcretePourResponse, error) {
+	pour, err := u.pourRepo.GetByID(ctx, pourID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.access.check(ctx, pour.ProjectID); err != nil {
+		return nil, err
+	}
+
+	response := toConcretePourResponse(pour)
+	return &response, nil
+}
+
+func (u *concretePourUseCase) List(ctx context.Context, projectID uuid.UUID, jobID uuid.NullUUID) ([]responses.ConcretePourResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	pours, err := u.pourRepo.ListByProject(ctx, projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]responses.ConcretePourResponse, len(pours))
+	for i := range pours {
+		result[i] = toConcretePourResponse(&pours[i])
+	}
+	return result, nil
+}
+
+func (u *concretePourUseCase) Delete(ctx context.Context, pourID uuid.UUID) error {
+	pour, err := u.pourRepo.GetByID(ctx, pourID)
+	if err != nil {
+		return err
+	}
+	if err := u.access.check(ctx, pour.ProjectID); err != nil {
+		return err
+	}
+	return u.pourRepo.Delete(ctx, pourID)
+}
+
+func (u *concretePourUseCase) Summary(ctx context.Context, projectID uuid.UUID) ([]responses.ConcretePourSummaryResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	summaries, err := u.pourRepo.Summarize(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]responses.ConcretePourSummaryResponse, len(summaries))
+	for i, s := range summaries {
+		result[i] = responses.ConcretePourSummaryResponse{
+			BOQID:             s.BOQID,
+			JobID:             s.JobID,
+			JobName:           s.JobName,
+			Pours:             s.Pours,
+			PouredVolume:      roundTo(s.PouredVolume, 3),
+			PlannedVolume:     roundTo(s.PlannedVolume, 3),
+			Variance:          roundTo(s.PouredVolume-s.PlannedVolume, 3),
+			PoursWithoutCubes: s.PoursWithoutCubes,
+		}
+	}
+	return result, nil
+}
+
+func toConcretePourResponse(pour *models.ConcretePourDetail) responses.ConcretePourResponse {
+	response := responses.ConcretePourResponse{
+		PourID:         pour.PourID,
+		ProjectID:      pour.ProjectID,
+		BOQID:          pour.BOQID,
+		JobID:          pour.JobID,
+		JobName:        pour.JobName,
+		SupplierID:     pour.SupplierID,
+		SupplierName:   pour.SupplierName,
+		MaterialID:     pour.MaterialID.String,
+		TicketNumber:   pour.TicketNumber,
+		PourDate:       pour.PourDate.Format("2006-01-02"),
+		Volume:         pour.Volume,
+		Location:       pour.Location.String,
+		Note:           pour.Note.String,
+		CubeReferences: pour.CubeReferences,
+	}
+	if pour.SlumpMM.Valid {
+		response.SlumpMM = &pour.SlumpMM.Float64
+	}
+	if response.CubeReferences == nil {
+		response.CubeReferences = []string{}
+	}
+	return response
+}
@@ -1,12 +1,16 @@
 package usecase
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/repositories"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/responses"
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,20 +19,30 @@ type ContractUseCase interface {
 	GetContract(ctx context.Context, projectID uuid.UUID) (*responses.ContractResponse, error)
 	CreateContract(ctx context.Context, projectID uuid.UUID, req requests.UploadContractRequest) error
 	DeleteContract(ctx context.Context, projectID uuid.UUID) error
+
+	CreateDocument(ctx context.Context, projectID uuid.UUID, req requests.CreateContractDocumentRequest) (*responses.ContractDocumentResponse, error)
+	ListDocuments(ctx context.Context, projectID uuid.UUID) ([]responses.ContractDocumentResponse, error)
+	GetDocument(ctx context.Context, projectID uuid.UUID, documentID uuid.UUID) (*responses.ContractDocumentResponse, error)
+	// GetContractValue returns the contract in force on asOf (today when
+	// empty): the latest base document plus the addenda on top of it.
+	GetContractValue(ctx context.Context, projectID uuid.UUID, asOf string) (*responses.ContractValueResponse, error)
 }
 
 type contractUseCase struct {
 	contractRepo repositories.ContractRepository
 	projectRepo  repositories.ProjectRepository
+	riskRepo     repositories.RiskRepository
 }
 
 func NewContractUsecase(
 	contractRepo repositories.ContractRepository,
 	projectRepo repositories.ProjectRepository,
+	riskRepo repositories.RiskRepository,
 ) ContractUseCase {
 	return &contractUseCase{
 		contractRepo: contractRepo,
 		projectRepo:  projectRepo,
+		riskRepo:     riskRepo,
 	}
 }
 
@@ -73,6 +87,10 @@ func (u *contractUseCase) CreateContract(ctx context.Context, projectID uuid.UUI
 		return errors.New("contract already exists for this project")
 	}
 
+	if err := checkRiskMitigation(ctx, u.riskRepo, projectID); err != nil {
+		return err
+	}
+
 	// Create new contract
 	err = u.contractRepo.Create(ctx, projectID, req.FileURL)
 	if err != nil {
@@ -100,3 +118,149 @@ func (u *contractUseCase) DeleteContract(ctx context.Context, projectID uuid.UUI
 
 	return nil
 }
+
+func (u *contractUseCase) CreateDocument(ctx context.Context, projectID uuid.UUID, req requests.CreateContractDocumentRequest) (*responses.ContractDocumentResponse, error) {
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	documentType := models.ContractDocumentType(req.DocumentType)
+	if !documentType.Valid() {
+		return nil, errors.New("invalid document type")
+	}
+	title := strings.TrimSpace(req.Title)
+	fileURL := strings.TrimSpace(req.FileURL)
+	if title == "" || fileURL == "" {
+		return nil, errors.New("title and file url are required")
+	}
+	effectiveDate, err := time.Parse("2006-01-02", req.EffectiveDate)
+	if err != nil {
+		return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+	}
+
+	document := models.ContractDocument{
+		DocumentID:    uuid.New(),
+		ProjectID:     projectID,
+		DocumentType:  documentType,
+		Title:         title,
+		FileURL:       fileURL,
+		EffectiveDate: effectiveDate,
+		CreatedAt:     time.Now(),
+	}
+
+	if documentType.IsBase() {
+		if req.ContractValue == nil || *req.ContractValue <= 0 {
+			return nil, errors.New("contract value must be greater than 0")
+		}
+		if req.ValueAdjustment != 0 {
+			return nil, errors.New("value adjustment is only allowed on addenda")
+		}
+		document.ContractValue = sql.NullFloat64{Float64: roundTo(*req.ContractValue, 2), Valid: true}
+	} else {
+		if req.ContractValue != nil {
+			return nil, errors.New("contract value is only allowed on loi and main contract")
+		}
+		document.ValueAdjustment = roundTo(req.ValueAdjustment, 2)
+	}
+
+	if req.SupersedesID != nil {
+		previous, err := u.contractRepo.GetDocument(ctx, projectID, *req.SupersedesID)
+		if err != nil {
+			return nil, err
+		}
+		if previous.SupersededBy.Valid {
+			return nil, errors.New("document already superseded")
+		}
+		if !document.CanSupersede(*previous) {
+			return nil, errors.New("document type cannot supersede the given document")
+		}
+		if effectiveDate.Before(previous.EffectiveDate) {
+			return nil, errors.New("effective date must not be before the superseded document")
+		}
+		document.SupersedesID = uuid.NullUUID{UUID: previous.DocumentID, Valid: true}
+	}
+
+	if err := u.contractRepo.CreateDocument(ctx, &document); err != nil {
+		return nil, err
+	}
+
+	response := toContractDocumentResponse(&document)
+	return &response, nil
+}
+
+func (u *contractUseCase) ListDocuments(ctx context.Context, projectID uuid.UUID) ([]responses.ContractDocumentResponse, error) {
+	documents, err := u.contractRepo.ListDocuments(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.ContractDocumentResponse, len(documents))
+	for i := range documents {
+		result[i] = toContractDocumentResponse(&documents[i])
+	}
+	return result, nil
+}
+
+func (u *contractUseCase) GetDocument(ctx context.Context, projectID uuid.UUID, documentID uuid.UUID) (*responses.ContractDocumentResponse, error) {
+	document, err := u.contractRepo.GetDocument(ctx, projectID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toContractDocumentResponse(document)
+	return &response, nil
+}
+
+func (u *contractUseCase) GetContractValue(ctx context.Context, projectID uuid.UUID, asOf string) (*responses.ContractValueResponse, error) {
+	date := time.Now().Truncate(24 * time.Hour)
+	if asOf != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", asOf)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+
+	documents, err := u.contractRepo.ListDocuments(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	position := models.CurrentContract(documents, date)
+	if position == nil {
+		return nil, errors.New("no contract in force")
+	}
+
+	response := &responses.ContractValueResponse{
+		ProjectID:            projectID,
+		AsOf:                 date.Format("2006-01-02"),
+		CurrentContractValue: position.Value,
+		Base:                 toContractDocumentResponse(position.Base),
+		Addenda:              []responses.ContractDocumentResponse{},
+	}
+	for i := range position.Addenda {
+		response.Addenda = append(response.Addenda, toContractDocumentResponse(&position.Addenda[i]))
+	}
+	return response, nil
+}
+
+func toContractDocumentResponse(document *models.ContractDocument) responses.ContractDocumentResponse {
+	response := responses.ContractDocumentResponse{
+		DocumentID:      document.DocumentID,
+		ProjectID:       document.ProjectID,
+		DocumentType:    string(document.DocumentType),
+		Version:         document.Version,
+		Title:           document.Title,
+		FileURL:         document.FileURL,
+		EffectiveDate:   document.EffectiveDate.Format("2006-01-02"),
+		ValueAdjustment: document.ValueAdjustment,
+		SupersedesID:    nullUUIDPtr(document.SupersedesID),
+		SupersededBy:    nullUUIDPtr(document.SupersededBy),
+		CreatedAt:       document.CreatedAt,
+	}
+	if document.ContractValue.Valid {
+		value := document.ContractValue.Float64
+		response.ContractValue = &value
+	}
+	return response
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// defaultAllocationWindow is how far ahead over-allocations are reported
+// when no end date is given.
+const defaultAllocationWindow = 90 * 24 * time.Hour
+
+type CrewUseCase interface {
+	Create(ctx context.Context, req requests.CreateCrewRequest) (*responses.CrewResponse, error)
+	List(ctx context.Context) ([]responses.CrewResponse, error)
+	Update(ctx context.Context, crewID uuid.UUID, req requests.UpdateCrewRequest) error
+	Delete(ctx context.Context, crewID uuid.UUID) error
+	GetOverAllocations(ctx context.Context, from, to string) ([]responses.CrewOverAllocation, error)
+}
+
+type crewUseCase struct {
+	crewRepo repositories.CrewRepository
+}
+
+func NewCrewUsecase(crewRepo repositories.CrewRepository) CrewUseCase {
+	return &crewUseCase{
+		crewRepo: crewRepo,
+	}
+}
+
+func (u *crewUseCase) Create(ctx context.Context, req requests.CreateCrewRequest) (*responses.CrewResponse, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, errors.New("crew name is required")
+	}
+	if req.Capacity <= 0 {
+		return nil, errors.New("crew capacity must be positive")
+	}
+
+	crew, err := u.crewRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toCrewResponse(crew)
+	return &response, nil
+}
+
+func (u *crewUseCase) List(ctx context.Context) ([]responses.CrewResponse, error) {
+	crews, err := u.crewRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.CrewResponse, len(crews))
+	for i := range crews {
+		response[i] = toCrewResponse(&crews[i])
+	}
+
+	return response, nil
+}
+
+func (u *crewUseCase) Update(ctx context.Context, crewID uuid.UUID, req requests.UpdateCrewRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("crew name is required")
+	}
+	if req.Capacity <= 0 {
+		return errors.New("crew capacity must be positive")
+	}
+
+	return u.crewRepo.Update(ctx, crewID, req)
+}
+
+func (u *crewUseCase) Delete(ctx context.Context, crewID uuid.UUID) error {
+	return u.crewRepo.Delete(ctx, crewID)
+}
+
+// GetOverAllocations lists the days on which a crew is assigned more workers
+// than it has, across all projects, with the tasks competing for it. The
+// range defaults to today through the next 90 days.
+func (u *crewUseCase) GetOverAllocations(ctx context.Context, from, to string) ([]responses.CrewOverAllocation, error) {
+	start := truncateToDate(time.Now())
+	if from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+		start = date
+	}
+
+	end := start.Add(defaultAllocationWindow)
+	if to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+		end = date
+	}
+
+	if end.Before(start) {
+		return nil, errors.New("end date must not be before start date")
+	}
+
+	allocations, err := u.crewRepo.GetOverAllocations(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []responses.CrewOverAllocation{}
+	for _, a := range allocations {
+		date := a.WorkDate.Format("2006-01-02")
+
+		last := len(response) - 1
+		if last < 0 || response[last].CrewID != a.CrewID || response[last].Date != date {
+			response = append(response, responses.CrewOverAllocation{
+				CrewID:    a.CrewID,
+				CrewName:  a.CrewName,
+				Date:      date,
+				Capacity:  a.Capacity,
+				Allocated: a.Allocated,
+				Excess:    a.Allocated - a.Capacity,
+				Tasks:     []responses.OverAllocatedTask{},
+			})
+			last++
+		}
+
+		response[last].Tasks = append(response[last].Tasks, responses.OverAllocatedTask{
+			TaskID:      a.TaskID,
+			TaskName:    a.TaskName,
+			ProjectID:   a.ProjectID,
+			ProjectName: a.ProjectName,
+			Workers:     a.Workers,
+		})
+	}
+
+	return response, nil
+}
+
+func toCrewResponse(crew *models.Crew) responses.CrewResponse {
+	return responses.CrewResponse{
+		CrewID:    crew.CrewID,
+		Name:      crew.Name,
+		Capacity:  crew.Capacity,
+		CreatedAt: crew.CreatedAt,
+	}
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	marginTrendMonths = 12
+	atRiskLimit       = 5
+)
+
+type DashboardUseCase interface {
+	GetPortfolio(ctx context.Context, userID uuid.UUID) (*responses.PortfolioDashboardResponse, error)
+}
+
+type dashboardUseCase struct {
+	dashboardRepo repositories.DashboardRepository
+	userRepo      repositories.UserRepository
+}
+
+func NewDashboardUsecase(
+	dashboardRepo repositories.DashboardRepository,
+	userRepo repositories.UserRepository,
+) DashboardUseCase {
+	return &dashboardUseCase{
+		dashboardRepo: dashboardRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// GetPortfolio is limited to owners. There is no cash book, so the cash
+// position is payments received less the costs recorded against projects.
+func (u *dashboardUseCase) GetPortfolio(ctx context.Context, userID uuid.UUID) (*responses.PortfolioDashboardResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	projects, err := u.dashboardRepo.GetPortfolioProjects(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.PortfolioDashboardResponse{
+		AsOf:           now.Format("2006-01-02"),
+		MarginTrend:    marginTrend(projects, now),
+		AtRiskProjects: []responses.AtRiskProjectResponse{},
+	}
+
+	for i := range projects {
+		p := &projects[i]
+
+		if p.Contracted {
+			response.ContractedValue += p.SellingPrice
+			response.ContractedProjects++
+		}
+		if p.Status == models.ProjectStatusInProgress {
+			response.ActiveProjects++
+			if unbilled := p.EarnedValue - p.Invoiced; unbilled > 0 {
+				response.WorkInProgressValue += unbilled
+			}
+			if risk, ok := assessProjectRisk(p); ok {
+				response.AtRiskProjects = append(response.AtRiskProjects, risk)
+			}
+		}
+
+		response.Claims.Open += p.OpenClaims
+		response.Claims.ClaimedAmount += p.OpenClaimAmount
+		response.Claims.ClaimedDays += p.OpenClaimDays
+
+		response.Cash.Received += p.Received
+		response.Cash.Spent += p.ActualCost
+		response.Cash.Receivables += p.Invoiced - p.Received
+	}
+	response.Cash.Position = response.Cash.Received - response.Cash.Spent
+
+	sort.SliceStable(response.AtRiskProjects, func(i, j int) bool {
+		a, b := response.AtRiskProjects[i], response.AtRiskProjects[j]
+		return a.CostOverrunPercent+a.ScheduleSlipPercent > b.CostOverrunPercent+b.ScheduleSlipPercent
+	})
+	if len(response.AtRiskProjects) > atRiskLimit {
+		response.AtRiskProjects = response.AtRiskProjects[:atRiskLimit]
+	}
+
+	return response, nil
+}
+
+// assessProjectRisk flags a project whose recorded costs already exceed the
+// whole estimate, whose completed work trails the schedule, or that has
+// claims waiting on the client.
+func assessProjectRisk(p *models.PortfolioProject) (responses.AtRiskProjectResponse, bool) {
+	risk := responses.AtRiskProjectResponse{
+		ProjectID:       p.ProjectID,
+		Name:            p.Name,
+		Status:          p.Status,
+		EstimatedCost:   p.EstimatedCost,
+		ActualCost:      p.ActualCost,
+		PlannedProgress: p.PlannedProgress() * 100,
+		ActualProgress:  p.ActualProgress() * 100,
+		OpenClaims:      p.OpenClaims,
+		OpenClaimAmount: p.OpenClaimAmount,
+		Reasons:         []string{},
+	}
+
+	if p.EstimatedCost > 0 && p.ActualCost > p.EstimatedCost {
+		risk.CostOverrunPercent = (p.ActualCost - p.EstimatedCost) / p.EstimatedCost * 100
+		risk.Reasons = append(risk.Reasons, "cost overrun")
+	}
+	if slip := risk.PlannedProgress - risk.ActualProgress; slip > 0 {
+		risk.ScheduleSlipPercent = slip
+		risk.Reasons = append(risk.Reasons, "behind schedule")
+	}
+	if p.OpenClaims > 0 {
+		risk.Reasons = append(risk.Reasons, "open claims")
+	}
+
+	return risk, len(risk.Reasons) > 0
+}
+
+func marginTrend(projects []models.PortfolioProject, now time.Time) []responses.MarginTrendPoint {
+	type bucket struct {
+		projects           int
+		selling, estimated float64
+		actualSelling      float64
+		actual             float64
+	}
+
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -(marginTrendMonths - 1), 0)
+	buckets := make([]bucket, marginTrendMonths)
+
+	for _, p := range projects {
+		if !p.Contracted || p.CreatedAt.Before(start) {
+			continue
+		}
+		created := p.CreatedAt.In(now.Location())
+		i := (created.Year()-start.Year())*12 + int(created.Month()) - int(start.Month())
+		if i < 0 || i >= marginTrendMonths {
+			continue
+		}
+
+		buckets[i].projects++
+		buckets[i].selling += p.SellingPrice
+		buckets[i].estimated += p.EstimatedCost
+		if p.ActualCost > 0 {
+			buckets[i].actualSelling += p.SellingPrice
+			buckets[i].actual += p.ActualCost
+		}
+	}
+
+	points := make([]responses.MarginTrendPoint, marginTrendMonths)
+	for i, b := range buckets {
+		points[i] = responses.MarginTrendPoint{
+			Month:        start.AddDate(0, i, 0).Format("2006-01"),
+			Projects:     b.projects,
+			SellingPrice: b.selling,
+		}
+		if b.selling > 0 {
+			points[i].EstimatedMarginPercent = (b.selling - b.estimated) / b.selling * 100
+		}
+		if b.actualSelling > 0 {
+			margin := (b.actualSelling - b.actual) / b.actualSelling * 100
+			points[i].ActualMarginPercent = &margin
+		}
+	}
+
+	return points
+}
+
+func requireOwner(ctx context.Context, userRepo repositories.UserRepository, userID uuid.UUID) error {
+	user, err := userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.Role.IsAdmin() {
+		return errors.New("only owners can access this resource")
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryUseCase interface {
+	CreateRate(ctx context.Context, userID uuid.UUID, req requests.DeliveryRateRequest) (*responses.DeliveryRateResponse, error)
+	ListRates(ctx context.Context) ([]responses.DeliveryRateResponse, error)
+	UpdateRate(ctx context.Context, rateID uuid.UUID, userID uuid.UUID, req requests.DeliveryRateRequest) error
+	DeleteRate(ctx context.Context, rateID uuid.UUID, userID uuid.UUID) error
+
+	// ApplyToQuotation prices transport for every line of a draft
+	// quotation from the rate matching the project address.
+	ApplyToQuotation(ctx context.Context, projectID uuid.UUID) (*responses.QuotationTransportResponse, error)
+	GetQuotationTransport(ctx context.Context, projectID uuid.UUID) (*responses.QuotationTransportResponse, error)
+}
+
+type deliveryUseCase struct {
+	deliveryRepo  repositories.DeliveryRepository
+	quotationRepo repositories.QuotationRepository
+	projectRepo   repositories.ProjectRepository
+	userRepo      repositories.UserRepository
+	access        projectAccess
+}
+
+func NewDeliveryUsecase(
+	deliveryRepo repositories.DeliveryRepository,
+	quotationRepo repositories.QuotationRepository,
+	projectRepo repositories.ProjectRepository,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) DeliveryUseCase {
+	return &deliveryUseCase{
+		deliveryRepo:  deliveryRepo,
+		quotationRepo: quotationRepo,
+		projectRepo:   projectRepo,
+		userRepo:      userRepo,
+		access:        projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *deliveryUseCase) CreateRate(ctx context.Context, userID uuid.UUID, req requests.DeliveryRateRequest) (*responses.DeliveryRateResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	rate := &models.DeliveryRate{
+		RateID:    uuid.New(),
+		CreatedAt: time.Now(),
+	}
+	if err := applyDeliveryRateFields(rate, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.deliveryRepo.CreateRate(ctx, rate); err != nil {
+		return nil, err
+	}
+
+	response := toDeliveryRateResponse(rate)
+	return &response, nil
+}
+
+func (u *deliveryUseCase) ListRates(ctx context.Context) ([]responses.DeliveryRateResponse, error) {
+	rates, err := u.deliveryRepo.ListRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.DeliveryRateResponse, 0, len(rates))
+	for i := range rates {
+		result = append(result, toDeliveryRateResponse(&rates[i]))
+	}
+	return result, nil
+}
+
+func (u *deliveryUseCase) UpdateRate(ctx context.Context, rateID uuid.UUID, userID uuid.UUID, req requests.DeliveryRateRequest) error {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return err
+	}
+
+	rate, err := u.deliveryRepo.GetRateByID(ctx, rateID)
+	if err != nil {
+		return err
+	}
+	if err := applyDeliveryRateFields(rate, req); err != nil {
+		return err
+	}
+	rate.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return u.deliveryRepo.UpdateRate(ctx, rate)
+}
+
+func (u *deliveryUseCase) DeleteRate(ctx context.Context, rateID uuid.UUID, userID uuid.UUID) error {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return err
+	}
+
+	return u.deliveryRepo.DeleteRate(ctx, rateID)
+}
+
+func (u *deliveryUseCase) ApplyToQuotation(ctx context.Context, projectID uuid.UUID) (*responses.QuotationTransportResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quotation == nil {
+		return nil, errors.New("quotation not found")
+	}
+	if quotation.Status != models.QuotationStatusDraft {
+		return nil, errors.New("transport can only be applied to draft quotations")
+	}
+
+	postalCode, province, err := u.projectDestination(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := u.deliveryRepo.ListRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rate := matchDeliveryRate(rates, postalCode, province)
+
+	jobs, err := u.quotationRepo.GetQuotationJobs(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.QuotationTransportResponse{
+		PostalCode: postalCode,
+		Province:   province,
+		Lines:      []responses.QuotationTransportLine{},
+	}
+
+	var lines []models.QuotationTransport
+	for _, job := range jobs {
+		material := job.TotalMaterialPrice.Float64 * job.Quantity
+		var cost float64
+		if rate != nil && material > 0 {
+			cost = math.Max(material*rate.PercentOfMaterial/100, rate.MinimumPerLine)
+			cost = roundTo(cost, 2)
+		}
+
+		if cost > 0 {
+			lines = append(lines, models.QuotationTransport{
+				QuotationID: quotation.QuotationID,
+				JobID:       job.JobID,
+				RateID:      rate.RateID,
+				Amount:      cost,
+			})
+		}
+		response.Lines = append(response.Lines, responses.QuotationTransportLine{
+			JobID:          job.JobID,
+			JobName:        job.JobName,
+			MaterialAmount: roundTo(material, 2),
+			TransportCost:  cost,
+		})
+		response.Total += cost
+	}
+
+	if err := u.deliveryRepo.SetQuotationTransport(ctx, projectID, quotation.QuotationID, lines); err != nil {
+		return nil, err
+	}
+
+	if rate != nil {
+		rateResponse := toDeliveryRateResponse(rate)
+		response.Rate = &rateResponse
+	}
+	response.Total = roundTo(response.Total, 2)
+	return response, nil
+}
+
+func (u *deliveryUseCase) GetQuotationTransport(ctx context.Context, projectID uuid.UUID) (*responses.QuotationTransportResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quotation == nil {
+		return nil, errors.New("quotation not found")
+	}
+
+	postalCode, province, err := u.projectDestination(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := u.quotationRepo.GetQuotationJobs(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := u.deliveryRepo.GetQuotationTransport(ctx, quotation.QuotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.QuotationTransportResponse{
+		PostalCode: postalCode,
+		Province:   province,
+		Lines:      []responses.QuotationTransportLine{},
+	}
+	for _, job := range jobs {
+		response.Lines = append(response.Lines, responses.QuotationTransportLine{
+			JobID:          job.JobID,
+			JobName:        job.JobName,
+			MaterialAmount: roundTo(job.TotalMaterialPrice.Float64*job.Quantity, 2),
+			TransportCost:  job.TransportCost,
+		})
+		response.Total += job.TransportCost
+	}
+	response.Total = roundTo(response.Total, 2)
+
+	// All lines are priced from one rate, so any saved line names it.
+	if len(saved) > 0 {
+		rate, err := u.deliveryRepo.GetRateByID(ctx, saved[0].RateID)
+		if err != nil {
+			return nil, err
+		}
+		rateResponse := toDeliveryRateResponse(rate)
+		response.Rate = &rateResponse
+	}
+
+	return response, nil
+}
+
+func (u *deliveryUseCase) projectDestination(ctx context.Context, projectID uuid.UUID) (postalCode, province string, err error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return "", "", err
+	}
+	if project == nil {
+		return "", "", errors.New("project not found")
+	}
+
+	var address map[string]interface{}
+	if err := json.Unmarshal(project.Address, &address); err != nil {
+		return "", "", errors.New("invalid project address")
+	}
+	postalCode, _ = address["postal_code"].(string)
+	province, _ = address["province"].(string)
+
+	return strings.TrimSpace(postalCode), strings.TrimSpace(province), nil
+}
+
+// matchDeliveryRate prefers the longest matching postal code prefix and
+// falls back to a province-only rate.
+func matchDeliveryRate(rates []models.DeliveryRate, postalCode, province string) *models.DeliveryRate {
+	var best *models.DeliveryRate
+	for i := range rates {
+		rate := &rates[i]
+		if !rate.PostalPrefix.Valid || postalCode == "" || !strings.HasPrefix(postalCode, rate.PostalPrefix.String) {
+			continue
+		}
+		if best == nil || len(rate.PostalPrefix.String) > len(best.PostalPrefix.String) {
+			best = rate
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	for i := range rates {
+		rate := &rates[i]
+		if !rate.PostalPrefix.Valid && rate.Province.Valid && province != "" && strings.EqualFold(rate.Province.String, province) {
+			return rate
+		}
+	}
+	return nil
+}
+
+func applyDeliveryRateFields(rate *models.DeliveryRate, req requests.DeliveryRateRequest) error {
+	name := strings.TrimSpace(req.Name)
+	prefix := strings.TrimSpace(req.PostalPrefix)
+	province := strings.TrimSpace(req.Province)
+
+	if name == "" {
+		return errors.New("delivery rate name is required")
+	}
+	if prefix == "" && province == "" {
+		return errors.New("postal prefix or province is required")
+	}
+	for _, r := range prefix {
+		if r < '0' || r > '9' {
+			return errors.New("postal prefix must be digits")
+		}
+	}
+	if req.PercentOfMaterial < 0 || req.PercentOfMaterial > 100 {
+		return errors.New("percent of material must be between 0 and 100")
+	}
+	if req.MinimumPerLine < 0 {
+		return errors.New("minimum per line must not be negative")
+	}
+
+	rate.Name = name
+	rate.PostalPrefix = optionalString(prefix)
+	rate.Province = optionalString(province)
+	rate.PercentOfMaterial = req.PercentOfMaterial
+	rate.MinimumPerLine = req.MinimumPerLine
+	return nil
+}
+
+func toDeliveryRateResponse(rate *models.DeliveryRate) responses.DeliveryRateResponse {
+	return responses.DeliveryRateResponse{
+		RateID:            rate.RateID,
+		Name:              rate.Name,
+		PostalPrefix:      rate.PostalPrefix.String,
+		Province:          rate.Province.String,
+		PercentOfMaterial: rate.PercentOfMaterial,
+		MinimumPerLine:    rate.MinimumPerLine,
+	}
+}
@@ -0,0 +1,445 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/infrastructure/thaidate"
+	"boonkosang/internal/infrastructure/tradedoc"
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DocumentExportUseCase interface {
+	// ExportQuotation builds the project's approved quotation. The seller is
+	// the company of userID.
+	ExportQuotation(ctx context.Context, projectID uuid.UUID, userID uuid.UUID) (*tradedoc.Document, error)
+	ExportInvoice(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID, userID uuid.UUID) (*tradedoc.Document, error)
+	// Localize adds labels in language, worded as userID's company has set
+	// them, for printing. An empty language leaves doc unchanged.
+	Localize(ctx context.Context, doc *tradedoc.Document, language string, userID uuid.UUID) error
+}
+
+type documentExportUseCase struct {
+	quotationRepo repositories.QuotationRepository
+	invoiceRepo   repositories.InvoiceRepository
+	projectRepo   repositories.ProjectRepository
+	clientRepo    repositories.ClientRepository
+	companyRepo   repositories.CompanyRepository
+	phaseRepo     repositories.ProjectPhaseRepository
+	roundingRepo  repositories.RoundingRepository
+	labelUseCase  DocumentLabelUseCase
+	access        projectAccess
+}
+
+func NewDocumentExportUsecase(
+	quotationRepo repositories.QuotationRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	projectRepo repositories.ProjectRepository,
+	clientRepo repositories.ClientRepository,
+	companyRepo repositories.CompanyRepository,
+	phaseRepo repositories.ProjectPhaseRepository,
+	roundingRepo repositories.RoundingRepository,
+	labelUseCase DocumentLabelUseCase,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) DocumentExportUseCase {
+	return &documentExportUseCase{
+		quotationRepo: quotationRepo,
+		invoiceRepo:   invoiceRepo,
+		projectRepo:   projectRepo,
+		clientRepo:    clientRepo,
+		companyRepo:   companyRepo,
+		phaseRepo:     phaseRepo,
+		roundingRepo:  roundingRepo,
+		labelUseCase:  labelUseCase,
+		access:        projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *documentExportUseCase) ExportQuotation(ctx context.Context, projectID uuid.UUID, userID uuid.UUID) (*tradedoc.Document, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	quotationStatus, err := u.quotationRepo.GetQuotationStatus(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quotationStatus != "approved" {
+		return nil, errors.New("only approved quotations can be exported")
+	}
+
+	data, err := u.quotationRepo.GetExportData(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	seller, err := u.sellerParty(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quotations don't record when they were issued, so the export date is
+	// used.
+	doc := &tradedoc.Document{
+		SchemaVersion: tradedoc.SchemaVersion,
+		Type:          tradedoc.DocumentTypeQuotation,
+		ID:            data.QuotationID.String(),
+		IssueDate:     time.Now().Format("2006-01-02"),
+		Currency:      "THB",
+		ProjectID:     data.ProjectID.String(),
+		ProjectName:   data.ProjectName,
+		Seller:        seller,
+		Buyer: tradedoc.Party{
+			Name:    data.ClientName,
+			TaxID:   data.ClientTaxID,
+			Email:   data.ClientEmail,
+			Tel:     data.ClientTel,
+			Address: tradedoc.AddressFromJSON(data.ClientAddress),
+		},
+		TaxPercent: data.TaxPercentage,
+		Lines:      []tradedoc.Line{},
+	}
+	if !data.ValidDate.IsZero() {
+		doc.ValidUntil = data.ValidDate.Format("2006-01-02")
+	}
+
+	for _, job := range data.JobDetails {
+		doc.Lines = append(doc.Lines, tradedoc.Line{
+			ID:          strconv.Itoa(len(doc.Lines) + 1),
+			Name:        job.Name,
+			Description: job.Description,
+			Group:       job.PhaseName,
+			Quantity:    job.Quantity,
+			Unit:        job.Unit,
+			UnitCode:    tradedoc.UnitCode(job.Unit),
+			UnitPrice:   roundTo(job.SellingPrice.Float64, 2),
+			Amount:      roundTo(job.Amount.Float64, 2),
+			TaxCategory: taxCategory(models.TaxTreatment(job.TaxTreatment)),
+		})
+	}
+	if data.TransportCost > 0 {
+		doc.Lines = append(doc.Lines, tradedoc.Line{
+			ID:          strconv.Itoa(len(doc.Lines) + 1),
+			Name:        "Transport",
+			Quantity:    1,
+			Unit:        "lot",
+			UnitCode:    tradedoc.UnitCode("lot"),
+			UnitPrice:   roundTo(data.TransportCost, 2),
+			Amount:      roundTo(data.TransportCost, 2),
+			TaxCategory: tradedoc.TaxCategoryStandard,
+		})
+	}
+	if data.SellingGeneralCost > 0 {
+		doc.Lines = append(doc.Lines, tradedoc.Line{
+			ID:          strconv.Itoa(len(doc.Lines) + 1),
+			Name:        "General costs",
+			Quantity:    1,
+			Unit:        "lot",
+			UnitCode:    tradedoc.UnitCode("lot"),
+			UnitPrice:   roundTo(data.SellingGeneralCost, 2),
+			Amount:      roundTo(data.SellingGeneralCost, 2),
+			TaxCategory: tradedoc.TaxCategoryStandard,
+		})
+	}
+
+	setDocumentTotals(doc)
+	doc.RoundingAmount = data.RoundingAdjustment
+	if data.FinalAmount.Valid {
+		doc.PayableAmount = roundTo(data.FinalAmount.Float64, 2)
+	}
+	return doc, nil
+}
+
+// ExportInvoice bills the invoice amount as one line per tax treatment,
+// split in the proportions of the quotation since invoices carry only an
+// amount. The amount is what the client pays, so it's treated as
+// tax-inclusive at the quotation's tax rate.
+func (u *documentExportUseCase) ExportInvoice(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID, userID uuid.UUID) (*tradedoc.Document, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	invoice, err := u.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if invoice == nil {
+		return nil, errors.New("invoice not found")
+	}
+	if invoice.ProjectID != projectID {
+		return nil, errors.New("invoice does not belong to the specified project")
+	}
+	if !invoice.Amount.Valid {
+		return nil, errors.New("invoice amount is not set")
+	}
+
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	client, err := u.clientRepo.GetByID(ctx, project.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	seller, err := u.sellerParty(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &tradedoc.Document{
+		SchemaVersion: tradedoc.SchemaVersion,
+		Type:          tradedoc.DocumentTypeInvoice,
+		ID:            invoice.InvoiceID.String(),
+		IssueDate:     invoice.CreatedAt.Format("2006-01-02"),
+		Currency:      "THB",
+		ProjectID:     project.ProjectID.String(),
+		ProjectName:   project.Name,
+		Seller:        seller,
+		Buyer: tradedoc.Party{
+			Name:    client.Name,
+			TaxID:   client.TaxID,
+			Email:   client.Email,
+			Tel:     client.Tel,
+			Address: tradedoc.AddressFromJSON(client.Address),
+		},
+	}
+	if quotation != nil {
+		doc.QuotationRef = quotation.QuotationID.String()
+		doc.TaxPercent = quotation.TaxPercentage.Float64
+	}
+
+	lineName := fmt.Sprintf("Progress billing: %s", project.Name)
+	group := ""
+	if invoice.PhaseID.Valid {
+		phase, err := u.phaseRepo.GetByID(ctx, invoice.PhaseID.UUID)
+		if err != nil {
+			return nil, err
+		}
+		lineName = fmt.Sprintf("Progress billing: %s", phase.Name)
+		group = phase.Name
+	}
+
+	var bases models.QuotationTaxBases
+	if quotation != nil {
+		b, err := u.quotationRepo.GetTaxBases(ctx, projectID)
+		if err != nil && err.Error() != "BOQ not found" {
+			return nil, err
+		}
+		if b != nil {
+			bases = *b
+		}
+	}
+
+	policy, err := u.roundingRepo.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	setBillingLines(doc, lineName, group, invoice.Amount.Float64, bases, *policy)
+	return doc, nil
+}
+
+func (u *documentExportUseCase) Localize(ctx context.Context, doc *tradedoc.Document, language string, userID uuid.UUID) error {
+	if language == "" {
+		return nil
+	}
+
+	format, err := u.labelUseCase.Resolve(ctx, language, userID)
+	if err != nil {
+		return err
+	}
+	doc.Language = string(format.Language)
+	doc.Labels = format.Labels
+	doc.DateEra = string(format.DateEra)
+
+	dates := map[string]time.Time{}
+	for key, value := range map[string]string{"issue_date": doc.IssueDate, "valid_until": doc.ValidUntil} {
+		if value == "" {
+			continue
+		}
+		date, err := thaidate.ParseDate(value, false)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+		dates[key] = date
+	}
+	doc.Dates = documentDates(format.DateEra, dates)
+	return nil
+}
+
+func (u *documentExportUseCase) sellerParty(ctx context.Context, userID uuid.UUID) (tradedoc.Party, error) {
+	company, err := u.companyRepo.GetOrCreateCompanyByUserID(ctx, userID)
+	if err != nil {
+		return tradedoc.Party{}, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	return tradedoc.Party{
+		Name:    company.Name,
+		TaxID:   company.TaxID,
+		Email:   company.Email,
+		Tel:     company.Tel,
+		Address: tradedoc.AddressFromJSON(company.Address),
+	}, nil
+}
+
+func taxCategory(treatment models.TaxTreatment) string {
+	switch treatment {
+	case models.TaxTreatmentZeroRated:
+		return tradedoc.TaxCategoryZeroRated
+	case models.TaxTreatmentExempt:
+		return tradedoc.TaxCategoryExempt
+	default:
+		return tradedoc.TaxCategoryStandard
+	}
+}
+
+// documentTaxBases sums the document's line amounts by tax category.
+func documentTaxBases(doc *tradedoc.Document) models.QuotationTaxBases {
+	var bases models.QuotationTaxBases
+	for _, line := range doc.Lines {
+		switch line.TaxCategory {
+		case tradedoc.TaxCategoryZeroRated:
+			bases.ZeroRated += line.Amount
+		case tradedoc.TaxCategoryExempt:
+			bases.Exempt += line.Amount
+		default:
+			bases.Standard += line.Amount
+		}
+	}
+	return bases
+}
+
+// splitPayable splits a tax-inclusive amount into pre-tax amounts per
+// treatment in the proportions of bases, each rounded as a line under
+// policy. With no bases it's all standard-rated. The parts plus tax add
+// back up to payable exactly.
+func splitPayable(payable, taxPercent float64, bases models.QuotationTaxBases, policy models.RoundingPolicy) (parts models.QuotationTaxBases, tax float64) {
+	payable = roundTo(payable, 2)
+	rate := taxPercent / 100
+
+	gross := bases.Standard*(1+rate) + bases.ZeroRated + bases.Exempt
+	if gross <= 0 {
+		parts.Standard = policy.Line(payable / (1 + rate))
+		return parts, roundTo(payable-parts.Standard, 2)
+	}
+
+	factor := payable / gross
+	parts.ZeroRated = policy.Line(bases.ZeroRated * factor)
+	if bases.Standard == 0 {
+		// Nothing is taxed, so the rounding difference goes to the last
+		// untaxed part instead.
+		if bases.Exempt == 0 {
+			parts.ZeroRated = payable
+		} else {
+			parts.Exempt = roundTo(payable-parts.ZeroRated, 2)
+		}
+		return parts, 0
+	}
+	parts.Exempt = policy.Line(bases.Exempt * factor)
+	parts.Standard = policy.Line(bases.Standard * factor)
+	return parts, roundTo(payable-parts.Standard-parts.ZeroRated-parts.Exempt, 2)
+}
+
+// billingLineName is the line name setBillingLines was given, without the
+// tax treatment suffix it adds.
+func billingLineName(doc *tradedoc.Document) string {
+	name := doc.Lines[0].Name
+	for _, suffix := range []string{" (zero-rated)", " (VAT exempt)"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	return name
+}
+
+// setBillingLines replaces the document's lines with one lot per tax
+// treatment billing payable in total, and keeps the payable amount exactly
+// as billed rather than re-deriving it from the rounded net amounts.
+func setBillingLines(doc *tradedoc.Document, name, group string, payable float64, bases models.QuotationTaxBases, policy models.RoundingPolicy) {
+	parts, tax := splitPayable(payable, doc.TaxPercent, bases, policy)
+
+	doc.Lines = []tradedoc.Line{}
+	for _, part := range []struct {
+		category string
+		suffix   string
+		amount   float64
+	}{
+		{tradedoc.TaxCategoryStandard, "", parts.Standard},
+		{tradedoc.TaxCategoryZeroRated, " (zero-rated)", parts.ZeroRated},
+		{tradedoc.TaxCategoryExempt, " (VAT exempt)", parts.Exempt},
+	} {
+		if part.amount == 0 && (part.category != tradedoc.TaxCategoryStandard || parts.Total() != 0) {
+			continue
+		}
+		lineName := name
+		if parts.Standard != parts.Total() {
+			lineName += part.suffix
+		}
+		doc.Lines = append(doc.Lines, tradedoc.Line{
+			ID:          strconv.Itoa(len(doc.Lines) + 1),
+			Name:        lineName,
+			Group:       group,
+			Quantity:    1,
+			Unit:        "lot",
+			UnitCode:    tradedoc.UnitCode("lot"),
+			UnitPrice:   part.amount,
+			Amount:      part.amount,
+			TaxCategory: part.category,
+		})
+	}
+
+	setDocumentTotals(doc)
+	for i := range doc.TaxSubtotals {
+		if doc.TaxSubtotals[i].Category == tradedoc.TaxCategoryStandard {
+			doc.TaxSubtotals[i].TaxAmount = tax
+		}
+	}
+	doc.TaxAmount = tax
+	doc.TaxInclusiveTotal = roundTo(payable, 2)
+	doc.PayableAmount = doc.TaxInclusiveTotal
+}
+
+// setDocumentTotals totals the lines per tax category. Only standard-rated
+// lines are taxed.
+func setDocumentTotals(doc *tradedoc.Document) {
+	var lineTotal float64
+	for _, line := range doc.Lines {
+		lineTotal += line.Amount
+	}
+
+	doc.TaxSubtotals = []tradedoc.TaxSubtotal{}
+	bases := documentTaxBases(doc)
+	for _, sub := range []tradedoc.TaxSubtotal{
+		{Category: tradedoc.TaxCategoryStandard, Percent: doc.TaxPercent, TaxableAmount: bases.Standard},
+		{Category: tradedoc.TaxCategoryZeroRated, TaxableAmount: bases.ZeroRated},
+		{Category: tradedoc.TaxCategoryExempt, TaxableAmount: bases.Exempt},
+	} {
+		if sub.TaxableAmount == 0 {
+			continue
+		}
+		sub.TaxableAmount = roundTo(sub.TaxableAmount, 2)
+		sub.TaxAmount = roundTo(sub.TaxableAmount*sub.Percent/100, 2)
+		doc.TaxSubtotals = append(doc.TaxSubtotals, sub)
+	}
+
+	doc.LineTotal = roundTo(lineTotal, 2)
+	doc.TaxExclusiveTotal = doc.LineTotal
+	doc.TaxAmount = roundTo(bases.Standard*doc.TaxPercent/100, 2)
+	doc.TaxInclusiveTotal = roundTo(doc.TaxExclusiveTotal+doc.TaxAmount, 2)
+	doc.PayableAmount = doc.TaxInclusiveTotal
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/infrastructure/thaidate"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DocumentLabelUseCase interface {
+	// GetLabels returns the Thai and English labels of the user's company.
+	GetLabels(ctx context.Context, userID uuid.UUID) ([]responses.DocumentLabelsResponse, error)
+	UpdateLabels(ctx context.Context, userID uuid.UUID, language string, req requests.UpdateDocumentLabelsRequest) (*responses.DocumentLabelsResponse, error)
+
+	// Resolve returns the labels and date era to render a document with.
+	// An empty language means English, the language documents were always
+	// rendered in; a nil userID uses the default wording and Gregorian
+	// dates.
+	Resolve(ctx context.Context, language string, userID uuid.UUID) (*models.DocumentFormat, error)
+}
+
+type documentLabelUseCase struct {
+	labelRepo   repositories.DocumentLabelRepository
+	companyRepo repositories.CompanyRepository
+}
+
+func NewDocumentLabelUsecase(labelRepo repositories.DocumentLabelRepository, companyRepo repositories.CompanyRepository) DocumentLabelUseCase {
+	return &documentLabelUseCase{
+		labelRepo:   labelRepo,
+		companyRepo: companyRepo,
+	}
+}
+
+func (u *documentLabelUseCase) GetLabels(ctx context.Context, userID uuid.UUID) ([]responses.DocumentLabelsResponse, error) {
+	overrides, err := u.companyLabels(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []responses.DocumentLabelsResponse{
+		toDocumentLabelsResponse(models.DocumentLanguageThai, overrides),
+		toDocumentLabelsResponse(models.DocumentLanguageEnglish, overrides),
+	}, nil
+}
+
+func (u *documentLabelUseCase) UpdateLabels(ctx context.Context, userID uuid.UUID, language string, req requests.UpdateDocumentLabelsRequest) (*responses.DocumentLabelsResponse, error) {
+	lang := models.DocumentLanguage(language)
+	if !lang.Translatable() {
+		return nil, errors.New("labels can only be set for th or en")
+	}
+	if len(req.Labels) == 0 {
+		return nil, errors.New("at least one label is required")
+	}
+
+	company, err := u.companyRepo.GetOrCreateCompanyByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	now := time.Now()
+	labels := make([]models.DocumentLabel, 0, len(req.Labels))
+	for key, text := range req.Labels {
+		if !models.IsDocumentLabelKey(key) {
+			return nil, fmt.Errorf("unknown label: %s", key)
+		}
+		labels = append(labels, models.DocumentLabel{
+			CompanyID: company.CompanyID,
+			Language:  lang,
+			LabelKey:  key,
+			Text:      strings.TrimSpace(text),
+			UpdatedAt: now,
+		})
+	}
+	if err := u.labelRepo.SetLabels(ctx, labels); err != nil {
+		return nil, err
+	}
+
+	overrides, err := u.labelRepo.ListLabels(ctx, company.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	response := toDocumentLabelsResponse(lang, overrides)
+	return &response, nil
+}
+
+func (u *documentLabelUseCase) Resolve(ctx context.Context, language string, userID uuid.UUID) (*models.DocumentFormat, error) {
+	lang := models.DocumentLanguageEnglish
+	if language != "" {
+		lang = models.DocumentLanguage(language)
+	}
+	if !lang.Valid() {
+		return nil, errors.New("language must be th, en or bilingual")
+	}
+
+	era := models.DateEraGregorian
+	var overrides []models.DocumentLabel
+	if userID != uuid.Nil {
+		company, err := u.companyRepo.GetOrCreateCompanyByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get company: %w", err)
+		}
+		if company.DateEra.Valid() {
+			era = company.DateEra
+		}
+		if overrides, err = u.labelRepo.ListLabels(ctx, company.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.DocumentFormat{
+		Language: lang,
+		Labels:   models.ResolveDocumentLabels(lang, overrides),
+		DateEra:  era,
+	}, nil
+}
+
+// documentDates formats each non-zero date as YYYY-MM-DD in era, keyed
+// like the payload field it belongs to.
+func documentDates(era models.DateEra, dates map[string]time.Time) map[string]string {
+	formatted := make(map[string]string, len(dates))
+	for key, date := range dates {
+		if date.IsZero() {
+			continue
+		}
+		formatted[key] = thaidate.FormatDate(date, era == models.DateEraBuddhist)
+	}
+	return formatted
+}
+
+func (u *documentLabelUseCase) companyLabels(ctx context.Context, userID uuid.UUID) ([]models.DocumentLabel, error) {
+	company, err := u.companyRepo.GetOrCreateCompanyByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	return u.labelRepo.ListLabels(ctx, company.CompanyID)
+}
+
+func toDocumentLabelsResponse(lang models.DocumentLanguage, overrides []models.DocumentLabel) responses.DocumentLabelsResponse {
+	customized := []string{}
+	for _, o := range overrides {
+		if o.Language == lang {
+			customized = append(customized, o.LabelKey)
+		}
+	}
+	sort.Strings(customized)
+
+	return responses.DocumentLabelsResponse{
+		Language:   string(lang),
+		Labels:     models.ResolveDocumentLabels(lang, overrides),
+		Customized: customized,
+	}
+}
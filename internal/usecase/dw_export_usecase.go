@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type DWExportUseCase interface {
+	Run(ctx context.Context) error
+	Status(ctx context.Context) ([]responses.DWExportStatusResponse, error)
+}
+
+type dwExportUseCase struct {
+	exportRepo repositories.DWExportRepository
+	sink       repositories.DWSink
+	running    sync.Mutex
+}
+
+func NewDWExportUsecase(exportRepo repositories.DWExportRepository, sink repositories.DWSink) DWExportUseCase {
+	return &dwExportUseCase{
+		exportRepo: exportRepo,
+		sink:       sink,
+	}
+}
+
+// Run exports every entity. Each entity's watermark only moves once its
+// batch has been written, so a failed entity is retried from the same
+// point next run while the others carry on.
+func (u *dwExportUseCase) Run(ctx context.Context) error {
+	if !u.running.TryLock() {
+		return errors.New("export is already running")
+	}
+	defer u.running.Unlock()
+
+	var failed []string
+	for _, entity := range u.exportRepo.Entities() {
+		if err := u.exportEntity(ctx, entity); err != nil {
+			log.Printf("dw export: %s failed: %v", entity.Name, err)
+			failed = append(failed, entity.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to export %v", failed)
+	}
+	return nil
+}
+
+func (u *dwExportUseCase) exportEntity(ctx context.Context, entity models.DWEntity) error {
+	watermark, err := u.exportRepo.GetWatermark(ctx, entity.Name)
+	if err != nil {
+		return err
+	}
+
+	// A new schema version starts from scratch.
+	var since time.Time
+	if watermark != nil && watermark.SchemaVersion == entity.SchemaVersion {
+		since = watermark.ExportedUntil
+	}
+	until := time.Now()
+
+	columns, rows, err := u.exportRepo.Extract(ctx, entity, since, until)
+	if err != nil {
+		return err
+	}
+
+	// Full snapshots are always written so an empty table still replaces
+	// the previous snapshot downstream.
+	if len(rows) > 0 || !entity.Incremental {
+		batch := models.DWBatch{
+			Entity:        entity.Name,
+			SchemaVersion: entity.SchemaVersion,
+			Full:          !entity.Incremental || since.IsZero(),
+			ExportedAt:    until,
+			Columns:       columns,
+			Rows:          rows,
+		}
+		if err := u.sink.Write(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	return u.exportRepo.SaveWatermark(ctx, &models.DWWatermark{
+		Entity:        entity.Name,
+		SchemaVersion: entity.SchemaVersion,
+		ExportedUntil: until,
+		LastRowCount:  len(rows),
+		LastRunAt:     until,
+	})
+}
+
+func (u *dwExportUseCase) Status(ctx context.Context) ([]responses.DWExportStatusResponse, error) {
+	watermarks, err := u.exportRepo.ListWatermarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byEntity := make(map[string]models.DWWatermark, len(watermarks))
+	for _, w := range watermarks {
+		byEntity[w.Entity] = w
+	}
+
+	entities := u.exportRepo.Entities()
+	response := make([]responses.DWExportStatusResponse, len(entities))
+	for i, entity := range entities {
+		response[i] = responses.DWExportStatusResponse{
+			Entity:        entity.Name,
+			SchemaVersion: entity.SchemaVersion,
+			Incremental:   entity.Incremental,
+		}
+		if w, ok := byEntity[entity.Name]; ok && w.SchemaVersion == entity.SchemaVersion {
+			response[i].ExportedUntil = &w.ExportedUntil
+			response[i].LastRowCount = w.LastRowCount
+			response[i].LastRunAt = &w.LastRunAt
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type EmailChangeUsecase interface {
+	// RequestChange records the new address as pending and emails it a
+	// signed verification link. The user's email is unchanged until the
+	// link is followed.
+	RequestChange(ctx context.Context, userID uuid.UUID, req requests.ChangeEmailRequest) (*responses.EmailChangeResponse, error)
+	// Verify makes the pending address the user's email. A link only works
+	// for the address it was sent to, so requesting another change voids
+	// earlier links.
+	Verify(ctx context.Context, req requests.VerifyEmailRequest) error
+}
+
+type emailChangeUsecase struct {
+	userRepo repositories.UserRepository
+	// mailer is nil when no mail server is configured, in which case
+	// emails can't be changed: the link must only reach the new address.
+	mailer      repositories.Mailer
+	links       linkSigner
+	linkBaseURL string
+	linkTTL     time.Duration
+}
+
+// NewEmailChangeUsecase signs verification links with linkSecret. Links
+// are linkBaseURL followed by the token and stay valid for linkTTL.
+func NewEmailChangeUsecase(
+	userRepo repositories.UserRepository,
+	mailer repositories.Mailer,
+	linkSecret string,
+	linkBaseURL string,
+	linkTTL time.Duration,
+) EmailChangeUsecase {
+	return &emailChangeUsecase{
+		userRepo:    userRepo,
+		mailer:      mailer,
+		links:       newLinkSigner(linkSecret, linkPurposeEmailChange),
+		linkBaseURL: strings.TrimRight(linkBaseURL, "/"),
+		linkTTL:     linkTTL,
+	}
+}
+
+func (u *emailChangeUsecase) RequestChange(ctx context.Context, userID uuid.UUID, req requests.ChangeEmailRequest) (*responses.EmailChangeResponse, error) {
+	if u.mailer == nil {
+		return nil, errors.New("email delivery is not configured")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return nil, errors.New("current password is incorrect")
+	}
+
+	email := strings.TrimSpace(req.NewEmail)
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, errors.New("invalid email address")
+	}
+	if user.Email.Valid && strings.EqualFold(user.Email.String, email) {
+		return nil, errors.New("new email must differ from the current one")
+	}
+	if _, err := u.userRepo.GetByEmail(ctx, email); err == nil {
+		return nil, errors.New("email already in use")
+	} else if err.Error() != "user not found" {
+		return nil, err
+	}
+
+	if err := u.userRepo.SetPendingEmail(ctx, userID, email); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(u.linkTTL)
+	link := u.linkBaseURL + "/" + u.links.sign(userID, expiresAt, strings.ToLower(email))
+	body := fmt.Sprintf("Hello %s,\n\nConfirm this address for your Boonkosang account %s here:\n\n%s\n\nThe link expires on %s. If you didn't ask for this, ignore this email and your address will stay the same.\n",
+		user.FirstName, user.Username, link, expiresAt.Format("2006-01-02 15:04"))
+	if err := u.mailer.Send(ctx, email, "Confirm your new email address", body); err != nil {
+		return nil, fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return &responses.EmailChangeResponse{
+		PendingEmail: email,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (u *emailChangeUsecase) Verify(ctx context.Context, req requests.VerifyEmailRequest) error {
+	invalid := errors.New("invalid or expired verification link")
+
+	userID, expiresAt, ok := u.links.parse(req.Token)
+	if !ok {
+		return invalid
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return invalid
+		}
+		return err
+	}
+	if !user.PendingEmail.Valid {
+		return invalid
+	}
+	if !u.links.signed(req.Token, userID, expiresAt, strings.ToLower(user.PendingEmail.String)) {
+		return invalid
+	}
+
+	if err := u.userRepo.ConfirmPendingEmail(ctx, userID, user.PendingEmail.String); err != nil {
+		if err.Error() == "email change not found" {
+			return invalid
+		}
+		return err
+	}
+
+	// Let the old address know in case the change wasn't the owner's.
+	if u.mailer != nil && user.Email.Valid && user.Email.String != "" {
+		body := fmt.Sprintf("Hello %s,\n\nThe email address for your Boonkosang account %s was changed to %s. If you didn't do this, contact your administrator.\n",
+			user.FirstName, user.Username, user.PendingEmail.String)
+		if err := u.mailer.Send(ctx, user.Email.String, "Your email address was changed", body); err != nil {
+			log.Printf("failed to notify %s of email change: %v", user.UserID, err)
+		}
+	}
+	return nil
+}
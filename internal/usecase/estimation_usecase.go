@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"log"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+type EstimationUseCase interface {
+	RecordProjectVariances(ctx context.Context, projectID uuid.UUID) ([]responses.EstimationVarianceResponse, error)
+	GetProjectVariances(ctx context.Context, projectID uuid.UUID) ([]responses.EstimationVarianceResponse, error)
+	SuggestAdjustments(ctx context.Context, kind string, minProjects int) ([]responses.EstimationAdjustmentResponse, error)
+}
+
+type estimationUseCase struct {
+	estimationRepo repositories.EstimationRepository
+	projectRepo    repositories.ProjectRepository
+}
+
+func NewEstimationUsecase(
+	estimationRepo repositories.EstimationRepository,
+	projectRepo repositories.ProjectRepository,
+) EstimationUseCase {
+	return &estimationUseCase{
+		estimationRepo: estimationRepo,
+		projectRepo:    projectRepo,
+	}
+}
+
+func (u *estimationUseCase) RecordProjectVariances(ctx context.Context, projectID uuid.UUID) ([]responses.EstimationVarianceResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+	if project.Status != models.ProjectStatusCompleted {
+		return nil, errors.New("project is not completed")
+	}
+
+	variances, err := u.estimationRepo.RecordProjectVariances(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEstimationVarianceResponses(variances), nil
+}
+
+func (u *estimationUseCase) GetProjectVariances(ctx context.Context, projectID uuid.UUID) ([]responses.EstimationVarianceResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	variances, err := u.estimationRepo.ListProjectVariances(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEstimationVarianceResponses(variances), nil
+}
+
+// SuggestAdjustments returns, per job type or material, the factor that
+// would have turned past estimates into actual costs. Factors are pooled
+// over projects (total actual / total estimated) so large jobs weigh more.
+func (u *estimationUseCase) SuggestAdjustments(ctx context.Context, kind string, minProjects int) ([]responses.EstimationAdjustmentResponse, error) {
+	itemKind := models.EstimationItemKind(kind)
+	if itemKind != "" && itemKind != models.EstimationItemJob && itemKind != models.EstimationItemMaterial {
+		return nil, errors.New("kind must be job or material")
+	}
+	if minProjects < 1 {
+		minProjects = 1
+	}
+
+	adjustments, err := u.estimationRepo.ListAdjustments(ctx, itemKind, minProjects)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.EstimationAdjustmentResponse, 0, len(adjustments))
+	for _, a := range adjustments {
+		factor := a.Factor()
+		result = append(result, responses.EstimationAdjustmentResponse{
+			Kind:            string(a.Kind),
+			ItemID:          a.ItemID,
+			ItemName:        a.ItemName,
+			Projects:        a.Projects,
+			EstimatedCost:   a.EstimatedCost,
+			ActualCost:      a.ActualCost,
+			VariancePercent: roundTo(variancePercent(a.EstimatedCost, a.ActualCost), 2),
+			SuggestedFactor: roundTo(factor, 4),
+		})
+	}
+
+	return result, nil
+}
+
+// recordEstimationVariances is called when a project is completed. Like
+// recordActivity it only logs failures; the variances can be recomputed
+// through the endpoint later.
+func recordEstimationVariances(ctx context.Context, repo repositories.EstimationRepository, projectID uuid.UUID) {
+	if repo == nil {
+		return
+	}
+	if _, err := repo.RecordProjectVariances(ctx, projectID); err != nil {
+		log.Printf("failed to record estimation variances for project %s: %v", projectID, err)
+	}
+}
+
+func toEstimationVarianceResponses(variances []models.EstimationVariance) []responses.EstimationVarianceResponse {
+	result := make([]responses.EstimationVarianceResponse, 0, len(variances))
+	for _, v := range variances {
+		result = append(result, responses.EstimationVarianceResponse{
+			ProjectID:       v.ProjectID,
+			Kind:            string(v.Kind),
+			ItemID:          v.ItemID,
+			ItemName:        v.ItemName,
+			EstimatedCost:   v.EstimatedCost,
+			ActualCost:      v.ActualCost,
+			Variance:        v.ActualCost - v.EstimatedCost,
+			VariancePercent: roundTo(variancePercent(v.EstimatedCost, v.ActualCost), 2),
+			RecordedAt:      v.RecordedAt,
+		})
+	}
+	return result
+}
+
+func variancePercent(estimated, actual float64) float64 {
+	if estimated == 0 {
+		return 0
+	}
+	return (actual - estimated) / estimated * 100
+}
+
+func roundTo(value float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(value*p) / p
+}
@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/infrastructure/tradedoc"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ETaxUseCase interface {
+	// Preview returns the unsigned XML of an invoice's tax invoice, or of a
+	// payment's receipt when paymentID is set.
+	Preview(ctx context.Context, projectID, invoiceID uuid.UUID, paymentID *uuid.UUID, userID uuid.UUID) ([]byte, error)
+	Submit(ctx context.Context, projectID, invoiceID uuid.UUID, paymentID *uuid.UUID, userID uuid.UUID) (*responses.ETaxSubmissionResponse, error)
+
+	ListSubmissions(ctx context.Context, projectID, invoiceID uuid.UUID) ([]responses.ETaxSubmissionResponse, error)
+	GetSubmission(ctx context.Context, submissionID uuid.UUID) (*responses.ETaxSubmissionResponse, error)
+	GetSubmissionXML(ctx context.Context, submissionID uuid.UUID) ([]byte, error)
+	RefreshStatus(ctx context.Context, submissionID uuid.UUID) (*responses.ETaxSubmissionResponse, error)
+
+	// RefreshPending polls the provider for every submission still awaiting
+	// a verdict.
+	RefreshPending(ctx context.Context) error
+}
+
+type etaxUseCase struct {
+	etaxRepo     repositories.ETaxRepository
+	invoiceRepo  repositories.InvoiceRepository
+	paymentRepo  repositories.PaymentRepository
+	roundingRepo repositories.RoundingRepository
+	documents    DocumentExportUseCase
+	signer       repositories.ETaxSigner
+	provider     repositories.ETaxProvider
+}
+
+// NewETaxUsecase accepts a nil signer or provider when they aren't
+// configured; previews still work but submissions are refused.
+func NewETaxUsecase(
+	etaxRepo repositories.ETaxRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	paymentRepo repositories.PaymentRepository,
+	roundingRepo repositories.RoundingRepository,
+	documents DocumentExportUseCase,
+	signer repositories.ETaxSigner,
+	provider repositories.ETaxProvider,
+) ETaxUseCase {
+	return &etaxUseCase{
+		etaxRepo:     etaxRepo,
+		invoiceRepo:  invoiceRepo,
+		paymentRepo:  paymentRepo,
+		roundingRepo: roundingRepo,
+		documents:    documents,
+		signer:       signer,
+		provider:     provider,
+	}
+}
+
+// etaxDocument is an e-tax document ready to be rendered.
+type etaxDocument struct {
+	documentType models.ETaxDocumentType
+	kind         tradedoc.ETDAKind
+	number       string
+	issuedAt     time.Time
+	doc          *tradedoc.Document
+}
+
+func (u *etaxUseCase) buildDocument(ctx context.Context, projectID, invoiceID uuid.UUID, paymentID *uuid.UUID, userID uuid.UUID) (*etaxDocument, error) {
+	doc, err := u.documents.ExportInvoice(ctx, projectID, invoiceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Seller.TaxID == "" {
+		return nil, errors.New("company tax ID is required for e-tax documents")
+	}
+
+	if paymentID == nil {
+		issuedAt, err := time.ParseInLocation("2006-01-02", doc.IssueDate, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid invoice date: %w", err)
+		}
+		return &etaxDocument{
+			documentType: models.ETaxDocumentTaxInvoice,
+			kind:         tradedoc.ETDATaxInvoice,
+			number:       doc.ID,
+			issuedAt:     issuedAt,
+			doc:          doc,
+		}, nil
+	}
+
+	payments, err := u.paymentRepo.GetByInvoiceID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	var payment *models.Payment
+	for i := range payments {
+		if payments[i].PaymentID == *paymentID {
+			payment = &payments[i]
+			break
+		}
+	}
+	if payment == nil {
+		return nil, errors.New("payment not found")
+	}
+
+	receipt, err := u.paymentRepo.GetReceiptByPaymentID(ctx, payment.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, errors.New("receipt not found")
+	}
+
+	// A receipt covers what was actually paid, which for partial payments
+	// is less than the invoice. The invoice lines are re-priced to the
+	// payment, tax-inclusive like the invoice amount, keeping their mix of
+	// tax treatments.
+	doc.ID = receipt.ReceiptNumber
+	doc.IssueDate = payment.PaidAt.Format("2006-01-02")
+	policy, err := u.roundingRepo.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	setBillingLines(doc, billingLineName(doc), doc.Lines[0].Group, payment.Amount, documentTaxBases(doc), *policy)
+
+	return &etaxDocument{
+		documentType: models.ETaxDocumentReceipt,
+		kind:         tradedoc.ETDAReceipt,
+		number:       receipt.ReceiptNumber,
+		issuedAt:     payment.PaidAt,
+		doc:          doc,
+	}, nil
+}
+
+func (u *etaxUseCase) Preview(ctx context.Context, projectID, invoiceID uuid.UUID, paymentID *uuid.UUID, userID uuid.UUID) ([]byte, error) {
+	document, err := u.buildDocument(ctx, projectID, invoiceID, paymentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tradedoc.MarshalETDA(document.doc, document.kind, document.number, document.issuedAt)
+}
+
+// Submit signs and files the document. Signing or sending failures are
+// recorded on the submission rather than returned, so the attempt shows up
+// in the history and can be retried.
+func (u *etaxUseCase) Submit(ctx context.Context, projectID, invoiceID uuid.UUID, paymentID *uuid.UUID, userID uuid.UUID) (*responses.ETaxSubmissionResponse, error) {
+	if u.signer == nil {
+		return nil, errors.New("e-tax signing service is not configured")
+	}
+	if u.provider == nil {
+		return nil, errors.New("e-tax provider is not configured")
+	}
+
+	document, err := u.buildDocument(ctx, projectID, invoiceID, paymentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := u.etaxRepo.GetActive(ctx, invoiceID, document.documentType, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, errors.New("document has already been submitted")
+	}
+
+	unsigned, err := tradedoc.MarshalETDA(document.doc, document.kind, document.number, document.issuedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	submission := &models.ETaxSubmission{
+		SubmissionID:   uuid.New(),
+		InvoiceID:      invoiceID,
+		DocumentType:   document.documentType,
+		DocumentNumber: document.number,
+		Status:         models.ETaxStatusFailed,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if paymentID != nil {
+		submission.PaymentID = uuid.NullUUID{UUID: *paymentID, Valid: true}
+	}
+
+	signed, err := u.signer.Sign(ctx, unsigned)
+	if err != nil {
+		submission.Message = optionalString(err.Error())
+	} else {
+		submission.Status = models.ETaxStatusSigned
+		submission.XML = optionalString(string(signed))
+
+		reference, err := u.provider.Submit(ctx, document.number, signed)
+		if err != nil {
+			submission.Status = models.ETaxStatusFailed
+			submission.Message = optionalString(err.Error())
+		} else {
+			submission.Status = models.ETaxStatusSubmitted
+			submission.ProviderRef = optionalString(reference)
+			submission.SubmittedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+	}
+
+	if err := u.etaxRepo.Create(ctx, submission); err != nil {
+		return nil, err
+	}
+
+	response := toETaxSubmissionResponse(submission)
+	return &response, nil
+}
+
+func (u *etaxUseCase) ListSubmissions(ctx context.Context, projectID, invoiceID uuid.UUID) ([]responses.ETaxSubmissionResponse, error) {
+	invoice, err := u.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if invoice == nil {
+		return nil, errors.New("invoice not found")
+	}
+	if invoice.ProjectID != projectID {
+		return nil, errors.New("invoice does not belong to the specified project")
+	}
+
+	submissions, err := u.etaxRepo.ListByInvoiceID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.ETaxSubmissionResponse, 0, len(submissions))
+	for i := range submissions {
+		result = append(result, toETaxSubmissionResponse(&submissions[i]))
+	}
+	return result, nil
+}
+
+func (u *etaxUseCase) GetSubmission(ctx context.Context, submissionID uuid.UUID) (*responses.ETaxSubmissionResponse, error) {
+	submission, err := u.etaxRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toETaxSubmissionResponse(submission)
+	return &response, nil
+}
+
+func (u *etaxUseCase) GetSubmissionXML(ctx context.Context, submissionID uuid.UUID) ([]byte, error) {
+	submission, err := u.etaxRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if !submission.XML.Valid {
+		return nil, errors.New("submission has no signed document")
+	}
+
+	return []byte(submission.XML.String), nil
+}
+
+func (u *etaxUseCase) RefreshStatus(ctx context.Context, submissionID uuid.UUID) (*responses.ETaxSubmissionResponse, error) {
+	submission, err := u.etaxRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.Status != models.ETaxStatusSubmitted {
+		return nil, errors.New("only submitted documents can be refreshed")
+	}
+
+	if err := u.refresh(ctx, submission); err != nil {
+		return nil, err
+	}
+
+	response := toETaxSubmissionResponse(submission)
+	return &response, nil
+}
+
+func (u *etaxUseCase) RefreshPending(ctx context.Context) error {
+	if u.provider == nil {
+		return nil
+	}
+
+	submissions, err := u.etaxRepo.ListByStatus(ctx, models.ETaxStatusSubmitted)
+	if err != nil {
+		return err
+	}
+
+	for i := range submissions {
+		if err := u.refresh(ctx, &submissions[i]); err != nil {
+			log.Printf("failed to refresh e-tax submission %s: %v", submissions[i].SubmissionID, err)
+		}
+	}
+	return nil
+}
+
+func (u *etaxUseCase) refresh(ctx context.Context, submission *models.ETaxSubmission) error {
+	if u.provider == nil {
+		return errors.New("e-tax provider is not configured")
+	}
+
+	status, message, err := u.provider.Status(ctx, submission.ProviderRef.String)
+	if err != nil {
+		return err
+	}
+	if status == submission.Status && message == submission.Message.String {
+		return nil
+	}
+
+	submission.Status = status
+	submission.Message = optionalString(message)
+	submission.UpdatedAt = time.Now()
+	return u.etaxRepo.Update(ctx, submission)
+}
+
+func toETaxSubmissionResponse(s *models.ETaxSubmission) responses.ETaxSubmissionResponse {
+	response := responses.ETaxSubmissionResponse{
+		SubmissionID:   s.SubmissionID,
+		InvoiceID:      s.InvoiceID,
+		PaymentID:      nullUUIDPtr(s.PaymentID),
+		DocumentType:   string(s.DocumentType),
+		DocumentNumber: s.DocumentNumber,
+		Status:         string(s.Status),
+		Signed:         s.XML.Valid,
+		ProviderRef:    s.ProviderRef.String,
+		Message:        s.Message.String,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	if s.SubmittedAt.Valid {
+		response.SubmittedAt = &s.SubmittedAt.Time
+	}
+	return response
+}
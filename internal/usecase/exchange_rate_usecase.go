@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+const baseCurrency = "THB"
+
+type ExchangeRateUseCase interface {
+	RefreshRates(ctx context.Context) error
+	List(ctx context.Context) (*responses.ExchangeRateListResponse, error)
+	GetByCurrency(ctx context.Context, currencyCode string) (*responses.ExchangeRateResponse, error)
+	SetManualRate(ctx context.Context, currencyCode string, req requests.SetExchangeRateRequest) error
+	ClearManualRate(ctx context.Context, currencyCode string, rateDate string) error
+	Convert(ctx context.Context, amount float64, from string, to string) (*responses.CurrencyConversionResponse, error)
+}
+
+type exchangeRateUseCase struct {
+	exchangeRateRepo repositories.ExchangeRateRepository
+	providers        []repositories.ExchangeRateProvider
+	currencies       []string
+}
+
+// NewExchangeRateUsecase takes providers in priority order; the first one
+// that succeeds supplies the day's rates.
+func NewExchangeRateUsecase(
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	providers []repositories.ExchangeRateProvider,
+	currencies []string,
+) ExchangeRateUseCase {
+	return &exchangeRateUseCase{
+		exchangeRateRepo: exchangeRateRepo,
+		providers:        providers,
+		currencies:       currencies,
+	}
+}
+
+func (u *exchangeRateUseCase) RefreshRates(ctx context.Context) error {
+	var errs []string
+
+	for _, provider := range u.providers {
+		rates, rateDate, err := provider.FetchRates(ctx, u.currencies)
+		if err != nil {
+			log.Printf("exchange rate provider %s failed: %v", provider.Name(), err)
+			errs = append(errs, fmt.Sprintf("%s: %v", provider.Name(), err))
+			continue
+		}
+
+		date := truncateToDate(rateDate)
+		records := make([]models.ExchangeRate, 0, len(rates))
+		for code, rate := range rates {
+			records = append(records, models.ExchangeRate{
+				CurrencyCode: code,
+				RateDate:     date,
+				Rate:         rate,
+				Source:       provider.Name(),
+			})
+		}
+
+		return u.exchangeRateRepo.SaveFetched(ctx, records)
+	}
+
+	return fmt.Errorf("all exchange rate providers failed: %s", strings.Join(errs, "; "))
+}
+
+func (u *exchangeRateUseCase) List(ctx context.Context) (*responses.ExchangeRateListResponse, error) {
+	rates, err := u.exchangeRateRepo.ListLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.ExchangeRateListResponse{
+		BaseCurrency: baseCurrency,
+		Rates:        make([]responses.ExchangeRateResponse, len(rates)),
+	}
+	for i := range rates {
+		response.Rates[i] = toExchangeRateResponse(&rates[i])
+	}
+
+	return response, nil
+}
+
+func (u *exchangeRateUseCase) GetByCurrency(ctx context.Context, currencyCode string) (*responses.ExchangeRateResponse, error) {
+	rate, err := u.exchangeRateRepo.GetLatest(ctx, strings.ToUpper(currencyCode))
+	if err != nil {
+		return nil, err
+	}
+
+	response := toExchangeRateResponse(rate)
+	return &response, nil
+}
+
+func (u *exchangeRateUseCase) SetManualRate(ctx context.Context, currencyCode string, req requests.SetExchangeRateRequest) error {
+	code, err := normalizeCurrencyCode(currencyCode)
+	if err != nil {
+		return err
+	}
+	if code == baseCurrency {
+		return errors.New("cannot set a rate for the base currency")
+	}
+	if req.Rate <= 0 {
+		return errors.New("rate must be greater than 0")
+	}
+
+	rateDate := truncateToDate(time.Now())
+	if req.RateDate != "" {
+		rateDate, err = time.Parse("2006-01-02", req.RateDate)
+		if err != nil {
+			return errors.New("invalid rate date format, expected YYYY-MM-DD")
+		}
+	}
+
+	return u.exchangeRateRepo.SaveManual(ctx, models.ExchangeRate{
+		CurrencyCode: code,
+		RateDate:     rateDate,
+		Rate:         req.Rate,
+	})
+}
+
+func (u *exchangeRateUseCase) ClearManualRate(ctx context.Context, currencyCode string, rateDate string) error {
+	code, err := normalizeCurrencyCode(currencyCode)
+	if err != nil {
+		return err
+	}
+
+	date := truncateToDate(time.Now())
+	if rateDate != "" {
+		date, err = time.Parse("2006-01-02", rateDate)
+		if err != nil {
+			return errors.New("invalid rate date format, expected YYYY-MM-DD")
+		}
+	}
+
+	return u.exchangeRateRepo.DeleteManual(ctx, code, date)
+}
+
+// Convert converts between any two currencies through THB.
+func (u *exchangeRateUseCase) Convert(ctx context.Context, amount float64, from string, to string) (*responses.CurrencyConversionResponse, error) {
+	fromRate, err := u.rateToBaht(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	toRate, err := u.rateToBaht(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := fromRate / toRate
+
+	return &responses.CurrencyConversionResponse{
+		From:      strings.ToUpper(from),
+		To:        strings.ToUpper(to),
+		Amount:    amount,
+		Converted: amount * rate,
+		Rate:      rate,
+	}, nil
+}
+
+func (u *exchangeRateUseCase) rateToBaht(ctx context.Context, currencyCode string) (float64, error) {
+	code, err := normalizeCurrencyCode(currencyCode)
+	if err != nil {
+		return 0, err
+	}
+	if code == baseCurrency {
+		return 1, nil
+	}
+
+	rate, err := u.exchangeRateRepo.GetLatest(ctx, code)
+	if err != nil {
+		return 0, err
+	}
+	return rate.Rate, nil
+}
+
+func normalizeCurrencyCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", errors.New("invalid currency code")
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", errors.New("invalid currency code")
+		}
+	}
+	return code, nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toExchangeRateResponse(rate *models.ExchangeRate) responses.ExchangeRateResponse {
+	return responses.ExchangeRateResponse{
+		CurrencyCode: rate.CurrencyCode,
+		RateDate:     rate.RateDate.Format("2006-01-02"),
+		Rate:         rate.Rate,
+		Source:       rate.Source,
+		IsManual:     rate.IsManual,
+		UpdatedAt:    rate.UpdatedAt,
+	}
+}
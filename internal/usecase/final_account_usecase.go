@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FinalAccountUseCase interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.FinalAccountRequest) (*responses.FinalAccountResponse, error)
+	Get(ctx context.Context, projectID uuid.UUID) (*responses.FinalAccountResponse, error)
+	Update(ctx context.Context, projectID uuid.UUID, req requests.FinalAccountRequest) (*responses.FinalAccountResponse, error)
+	// Agree records the client agreeing the statement as it stands and
+	// locks the final account.
+	Agree(ctx context.Context, projectID uuid.UUID, req requests.AgreeFinalAccountRequest) (*responses.FinalAccountResponse, error)
+}
+
+type finalAccountUseCase struct {
+	finalAccountRepo repositories.FinalAccountRepository
+	projectRepo      repositories.ProjectRepository
+	contractRepo     repositories.ContractRepository
+	boqRepo          repositories.BOQRepository
+	asBuiltRepo      repositories.AsBuiltRepository
+	retentionPercent float64
+	access           projectAccess
+}
+
+// NewFinalAccountUsecase takes the retention percentage final accounts
+// default to.
+func NewFinalAccountUsecase(
+	finalAccountRepo repositories.FinalAccountRepository,
+	projectRepo repositories.ProjectRepository,
+	contractRepo repositories.ContractRepository,
+	boqRepo repositories.BOQRepository,
+	asBuiltRepo repositories.AsBuiltRepository,
+	retentionPercent float64,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) FinalAccountUseCase {
+	return &finalAccountUseCase{
+		finalAccountRepo: finalAccountRepo,
+		projectRepo:      projectRepo,
+		contractRepo:     contractRepo,
+		boqRepo:          boqRepo,
+		asBuiltRepo:      asBuiltRepo,
+		retentionPercent: retentionPercent,
+		access:           projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *finalAccountUseCase) Create(ctx context.Context, projectID uuid.UUID, req requests.FinalAccountRequest) (*responses.FinalAccountResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+	if project.Status != models.ProjectStatusCompleted {
+		return nil, errors.New("project must be completed before the final account")
+	}
+
+	account := &models.FinalAccount{
+		FinalAccountID:   uuid.New(),
+		ProjectID:        projectID,
+		RetentionPercent: u.retentionPercent,
+		CreatedBy:        actorFromContext(ctx),
+		CreatedAt:        time.Now(),
+	}
+	if err := applyFinalAccountFields(account, req); err != nil {
+		return nil, err
+	}
+
+	// The statement needs a contract to settle against, so check before
+	// saving rather than leave an account that can't be read.
+	if _, err := u.currentContract(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	if err := u.finalAccountRepo.Create(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return u.statement(ctx, account)
+}
+
+func (u *finalAccountUseCase) Get(ctx context.Context, projectID uuid.UUID) (*responses.FinalAccountResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	account, err := u.finalAccountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.statement(ctx, account)
+}
+
+func (u *finalAccountUseCase) Update(ctx context.Context, projectID uuid.UUID, req requests.FinalAccountRequest) (*responses.FinalAccountResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	account, err := u.finalAccountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if account.AgreedAt.Valid {
+		return nil, errors.New("final account has already been agreed")
+	}
+
+	if err := applyFinalAccountFields(account, req); err != nil {
+		return nil, err
+	}
+	account.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := u.finalAccountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return u.statement(ctx, account)
+}
+
+func (u *finalAccountUseCase) Agree(ctx context.Context, projectID uuid.UUID, req requests.AgreeFinalAccountRequest) (*responses.FinalAccountResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.AgreedBy)
+	if name == "" {
+		return nil, errors.New("agreeing name is required")
+	}
+
+	account, err := u.finalAccountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if account.AgreedAt.Valid {
+		return nil, errors.New("final account has already been agreed")
+	}
+
+	statement, err := u.statement(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := u.finalAccountRepo.Agree(ctx, account.FinalAccountID, name, statement.FinalContractSum, now); err != nil {
+		return nil, err
+	}
+
+	statement.Status = "agreed"
+	statement.AgreedBy = name
+	statement.AgreedAmount = &statement.FinalContractSum
+	statement.AgreedAt = &now
+	return statement, nil
+}
+
+func applyFinalAccountFields(account *models.FinalAccount, req requests.FinalAccountRequest) error {
+	if req.RetentionPercent != nil {
+		if *req.RetentionPercent < 0 || *req.RetentionPercent > 100 {
+			return errors.New("retention percent must be between 0 and 100")
+		}
+		account.RetentionPercent = *req.RetentionPercent
+	}
+	account.Note = optionalString(req.Note)
+
+	account.BackCharges = make([]models.FinalAccountBackCharge, 0, len(req.BackCharges))
+	for i, charge := range req.BackCharges {
+		description := strings.TrimSpace(charge.Description)
+		if description == "" {
+			return errors.New("back-charge description is required")
+		}
+		if charge.Amount <= 0 {
+			return errors.New("back-charge amount must be greater than 0")
+		}
+		account.BackCharges = append(account.BackCharges, models.FinalAccountBackCharge{
+			ChargeID:       uuid.New(),
+			FinalAccountID: account.FinalAccountID,
+			Description:    description,
+			Amount:         roundTo(charge.Amount, 2),
+			SortOrder:      i,
+		})
+	}
+	return nil
+}
+
+func (u *finalAccountUseCase) currentContract(ctx context.Context, projectID uuid.UUID) (*models.ContractPosition, error) {
+	documents, err := u.contractRepo.ListDocuments(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	position := models.CurrentContract(documents, currentDate())
+	if position == nil {
+		return nil, errors.New("no contract in force")
+	}
+	return position, nil
+}
+
+// remeasurement is the as-built variations carried to the final account.
+// A project without a BOQ has none.
+func (u *finalAccountUseCase) remeasurement(ctx context.Context, projectID uuid.UUID) ([]models.AsBuiltLine, error) {
+	boq, err := u.boqRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	lines, err := u.asBuiltRepo.ListLines(ctx, boq.BOQID)
+	if err != nil {
+		return nil, err
+	}
+
+	remeasured := make([]models.AsBuiltLine, 0, len(lines))
+	for _, line := range lines {
+		if line.AsBuilt.Valid && line.Disposition.String == string(models.AsBuiltDispositionFinalAccount) {
+			remeasured = append(remeasured, line)
+		}
+	}
+	return remeasured, nil
+}
+
+func (u *finalAccountUseCase) statement(ctx context.Context, account *models.FinalAccount) (*responses.FinalAccountResponse, error) {
+	position, err := u.currentContract(ctx, account.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	remeasured, err := u.remeasurement(ctx, account.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	paid, err := u.finalAccountRepo.PaidToDate(ctx, account.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.FinalAccountResponse{
+		FinalAccountID:   account.FinalAccountID,
+		ProjectID:        account.ProjectID,
+		Status:           "draft",
+		Note:             account.Note.String,
+		OriginalContract: toContractDocumentResponse(position.Base),
+		ChangeOrders:     make([]responses.ContractDocumentResponse, len(position.Addenda)),
+		Remeasurement:    make([]responses.AsBuiltLineResponse, len(remeasured)),
+		BackCharges:      make([]responses.FinalAccountBackChargeResponse, len(account.BackCharges)),
+		RetentionPercent: account.RetentionPercent,
+		AgreedBy:         account.AgreedBy.String,
+		CreatedAt:        account.CreatedAt,
+	}
+
+	var changeOrders, remeasurement float64
+	for i := range position.Addenda {
+		response.ChangeOrders[i] = toContractDocumentResponse(&position.Addenda[i])
+		changeOrders += position.Addenda[i].ValueAdjustment
+	}
+	for i := range remeasured {
+		response.Remeasurement[i] = toAsBuiltLineResponse(&remeasured[i])
+		remeasurement += response.Remeasurement[i].VariationValue
+	}
+	for i, charge := range account.BackCharges {
+		response.BackCharges[i] = responses.FinalAccountBackChargeResponse{
+			ChargeID:    charge.ChargeID,
+			Description: charge.Description,
+			Amount:      charge.Amount,
+		}
+	}
+
+	settlement := account.Settle(position.Base.ContractValue.Float64, changeOrders, remeasurement, paid)
+	response.OriginalContractSum = settlement.OriginalContract
+	response.ChangeOrderTotal = settlement.ChangeOrders
+	response.RemeasurementTotal = settlement.Remeasurement
+	response.BackChargeTotal = settlement.BackCharges
+	response.FinalContractSum = settlement.FinalContractSum
+	response.PaidToDate = settlement.PaidToDate
+	response.RetentionHeld = settlement.RetentionHeld
+	response.BalanceDue = settlement.BalanceDue
+
+	if account.AgreedAt.Valid {
+		response.Status = "agreed"
+		response.AgreedAmount = &account.AgreedAmount.Float64
+		response.AgreedAt = &account.AgreedAt.Time
+	}
+	if account.UpdatedAt.Valid {
+		response.UpdatedAt = &account.UpdatedAt.Time
+	}
+	return response, nil
+}
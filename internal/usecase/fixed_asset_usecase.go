@@ -0,0 +1,433 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FixedAssetUseCase interface {
+	Create(ctx context.Context, req requests.FixedAssetRequest) (*responses.FixedAssetResponse, error)
+	Update(ctx context.Context, assetID uuid.UUID, req requests.FixedAssetRequest) (*responses.FixedAssetResponse, error)
+	GetByID(ctx context.Context, assetID uuid.UUID) (*responses.FixedAssetResponse, error)
+	List(ctx context.Context, includeDisposed bool) ([]responses.FixedAssetResponse, error)
+	Dispose(ctx context.Context, assetID uuid.UUID, req requests.DisposeFixedAssetRequest) (*responses.FixedAssetResponse, error)
+
+	// GetBookValueReport values the register at asOf (YYYY-MM-DD, defaults
+	// to today).
+	GetBookValueReport(ctx context.Context, asOf string) (*responses.BookValueReportResponse, error)
+
+	// RunDepreciation posts every month of depreciation due up to the end
+	// of the last completed month. Months already posted are skipped, as
+	// are months in locked accounting periods.
+	RunDepreciation(ctx context.Context) error
+	GetDepreciationSchedule(ctx context.Context, assetID uuid.UUID) (*responses.DepreciationScheduleResponse, error)
+	// GetDepreciationAllocation splits a period's (YYYY-MM) posted
+	// depreciation between projects and company overhead.
+	GetDepreciationAllocation(ctx context.Context, period string) (*responses.DepreciationAllocationResponse, error)
+}
+
+type fixedAssetUseCase struct {
+	assetRepo    repositories.FixedAssetRepository
+	materialRepo repositories.MaterialRepository
+	projectRepo  repositories.ProjectRepository
+	periodRepo   repositories.AccountingPeriodRepository
+}
+
+func NewFixedAssetUsecase(
+	assetRepo repositories.FixedAssetRepository,
+	materialRepo repositories.MaterialRepository,
+	projectRepo repositories.ProjectRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+) FixedAssetUseCase {
+	return &fixedAssetUseCase{
+		assetRepo:    assetRepo,
+		materialRepo: materialRepo,
+		projectRepo:  projectRepo,
+		periodRepo:   periodRepo,
+	}
+}
+
+func (u *fixedAssetUseCase) Create(ctx context.Context, req requests.FixedAssetRequest) (*responses.FixedAssetResponse, error) {
+	now := time.Now()
+	asset := models.FixedAsset{
+		AssetID:   uuid.New(),
+		Status:    models.FixedAssetStatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.applyRequest(ctx, &asset, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.assetRepo.Create(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	response := toFixedAssetResponse(&asset)
+	return &response, nil
+}
+
+func (u *fixedAssetUseCase) Update(ctx context.Context, assetID uuid.UUID, req requests.FixedAssetRequest) (*responses.FixedAssetResponse, error) {
+	asset, err := u.assetRepo.GetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	if asset.Status == models.FixedAssetStatusDisposed {
+		return nil, errors.New("asset already disposed")
+	}
+	before := *asset
+
+	if err := u.applyRequest(ctx, asset, req); err != nil {
+		return nil, err
+	}
+	asset.UpdatedAt = time.Now()
+
+	// Posted months can't be restated, so the schedule is fixed once
+	// depreciation has been charged.
+	if !asset.PurchaseDate.Equal(before.PurchaseDate) || asset.PurchaseCost != before.PurchaseCost ||
+		asset.SalvageValue != before.SalvageValue || asset.UsefulLifeMonths != before.UsefulLifeMonths {
+		postings, err := u.assetRepo.ListPostings(ctx, assetID)
+		if err != nil {
+			return nil, err
+		}
+		if len(postings) > 0 {
+			return nil, errors.New("asset has depreciation postings")
+		}
+	}
+
+	if err := u.assetRepo.Update(ctx, *asset); err != nil {
+		return nil, err
+	}
+
+	response := toFixedAssetResponse(asset)
+	return &response, nil
+}
+
+func (u *fixedAssetUseCase) GetByID(ctx context.Context, assetID uuid.UUID) (*responses.FixedAssetResponse, error) {
+	asset, err := u.assetRepo.GetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toFixedAssetResponse(asset)
+	return &response, nil
+}
+
+func (u *fixedAssetUseCase) List(ctx context.Context, includeDisposed bool) ([]responses.FixedAssetResponse, error) {
+	assets, err := u.assetRepo.List(ctx, time.Now(), includeDisposed)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.FixedAssetResponse, len(assets))
+	for i := range assets {
+		result[i] = toFixedAssetResponse(&assets[i])
+	}
+	return result, nil
+}
+
+func (u *fixedAssetUseCase) Dispose(ctx context.Context, assetID uuid.UUID, req requests.DisposeFixedAssetRequest) (*responses.FixedAssetResponse, error) {
+	asset, err := u.assetRepo.GetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	disposedAt := time.Now().Truncate(24 * time.Hour)
+	if req.DisposedAt != "" {
+		disposedAt, err = time.Parse("2006-01-02", req.DisposedAt)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+	if disposedAt.Before(asset.PurchaseDate) {
+		return nil, errors.New("disposal date must not be before purchase date")
+	}
+
+	if err := u.assetRepo.Dispose(ctx, assetID, disposedAt); err != nil {
+		return nil, err
+	}
+
+	asset.Status = models.FixedAssetStatusDisposed
+	asset.DisposedAt = sql.NullTime{Time: disposedAt, Valid: true}
+	response := toFixedAssetResponse(asset)
+	return &response, nil
+}
+
+func (u *fixedAssetUseCase) GetBookValueReport(ctx context.Context, asOf string) (*responses.BookValueReportResponse, error) {
+	date := time.Now().Truncate(24 * time.Hour)
+	if asOf != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", asOf)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+
+	assets, err := u.assetRepo.List(ctx, date, true)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &responses.BookValueReportResponse{
+		AsOf:   date.Format("2006-01-02"),
+		Assets: []responses.BookValueLine{},
+	}
+	for _, asset := range assets {
+		if asset.DisposedAt.Valid && asset.DisposedAt.Time.Before(date) {
+			continue
+		}
+
+		months := asset.MonthsDepreciated(date)
+		accumulated := asset.AccumulatedDepreciation(months)
+		line := responses.BookValueLine{
+			AssetID:                 asset.AssetID,
+			Name:                    asset.Name,
+			Status:                  string(asset.Status),
+			PurchaseDate:            asset.PurchaseDate.Format("2006-01-02"),
+			PurchaseCost:            asset.PurchaseCost,
+			MonthsDepreciated:       months,
+			AccumulatedDepreciation: accumulated,
+			BookValue:               asset.BookValue(date),
+		}
+		report.Assets = append(report.Assets, line)
+		report.TotalCost += line.PurchaseCost
+		report.TotalDepreciation += line.AccumulatedDepreciation
+		report.TotalBookValue += line.BookValue
+	}
+
+	report.TotalCost = roundTo(report.TotalCost, 2)
+	report.TotalDepreciation = roundTo(report.TotalDepreciation, 2)
+	report.TotalBookValue = roundTo(report.TotalBookValue, 2)
+	return report, nil
+}
+
+func (u *fixedAssetUseCase) RunDepreciation(ctx context.Context) error {
+	now := time.Now().In(models.AccountingPeriodLocation)
+	through := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	assets, err := u.assetRepo.List(ctx, through, true)
+	if err != nil {
+		return err
+	}
+
+	locked := map[string]bool{}
+	for _, asset := range assets {
+		due := asset.MonthsDepreciated(through)
+		if due == 0 {
+			continue
+		}
+
+		existing, err := u.assetRepo.ListPostings(ctx, asset.AssetID)
+		if err != nil {
+			return err
+		}
+		posted := make(map[string]bool, len(existing))
+		for _, posting := range existing {
+			posted[posting.Period] = true
+		}
+
+		var postings []models.DepreciationPosting
+		for month := 1; month <= due; month++ {
+			period := asset.DepreciationPeriod(month)
+			if posted[period] {
+				continue
+			}
+			isLocked, ok := locked[period]
+			if !ok {
+				isLocked, err = u.periodRepo.IsLocked(ctx, period)
+				if err != nil {
+					return err
+				}
+				locked[period] = isLocked
+			}
+			if isLocked {
+				continue
+			}
+
+			accumulated := asset.AccumulatedDepreciation(month)
+			postings = append(postings, models.DepreciationPosting{
+				PostingID:               uuid.New(),
+				AssetID:                 asset.AssetID,
+				Period:                  period,
+				Amount:                  asset.DepreciationForMonth(month),
+				AccumulatedDepreciation: accumulated,
+				BookValue:               roundTo(asset.PurchaseCost-accumulated, 2),
+				ProjectID:               asset.ProjectID,
+				PostedAt:                time.Now(),
+			})
+		}
+
+		if len(postings) == 0 {
+			continue
+		}
+		if err := u.assetRepo.CreatePostings(ctx, postings); err != nil {
+			return fmt.Errorf("failed to post depreciation for asset %s: %w", asset.AssetID, err)
+		}
+	}
+	return nil
+}
+
+func (u *fixedAssetUseCase) GetDepreciationSchedule(ctx context.Context, assetID uuid.UUID) (*responses.DepreciationScheduleResponse, error) {
+	asset, err := u.assetRepo.GetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	postings, err := u.assetRepo.ListPostings(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	byPeriod := make(map[string]models.DepreciationPosting, len(postings))
+	for _, posting := range postings {
+		byPeriod[posting.Period] = posting
+	}
+
+	// A disposed asset's schedule stops at its disposal month.
+	months := asset.UsefulLifeMonths
+	if asset.DisposedAt.Valid {
+		months = asset.MonthsDepreciated(asset.DisposedAt.Time)
+	}
+
+	response := &responses.DepreciationScheduleResponse{
+		Asset:    toFixedAssetResponse(asset),
+		Schedule: make([]responses.DepreciationScheduleLine, 0, months),
+	}
+	for month := 1; month <= months; month++ {
+		accumulated := asset.AccumulatedDepreciation(month)
+		line := responses.DepreciationScheduleLine{
+			Month:                   month,
+			Period:                  asset.DepreciationPeriod(month),
+			Depreciation:            asset.DepreciationForMonth(month),
+			AccumulatedDepreciation: accumulated,
+			BookValue:               roundTo(asset.PurchaseCost-accumulated, 2),
+		}
+		if posting, ok := byPeriod[line.Period]; ok {
+			line.Posted = true
+			line.PostedAt = &posting.PostedAt
+			line.ProjectID = nullUUIDPtr(posting.ProjectID)
+			response.TotalPosted += posting.Amount
+		}
+		response.Schedule = append(response.Schedule, line)
+	}
+
+	response.TotalPosted = roundTo(response.TotalPosted, 2)
+	response.RemainingToCharge = roundTo(asset.AccumulatedDepreciation(months)-response.TotalPosted, 2)
+	return response, nil
+}
+
+func (u *fixedAssetUseCase) GetDepreciationAllocation(ctx context.Context, period string) (*responses.DepreciationAllocationResponse, error) {
+	if period == "" {
+		period = models.AccountingPeriodOf(time.Now().AddDate(0, -1, 0))
+	}
+	if _, err := time.Parse(models.AccountingPeriodLayout, period); err != nil {
+		return nil, errors.New("invalid period format, expected YYYY-MM")
+	}
+
+	allocations, err := u.assetRepo.GetAllocation(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.DepreciationAllocationResponse{
+		Period:   period,
+		Projects: []responses.DepreciationAllocationLine{},
+	}
+	for _, allocation := range allocations {
+		if allocation.ProjectID.Valid {
+			response.Projects = append(response.Projects, responses.DepreciationAllocationLine{
+				ProjectID:   nullUUIDPtr(allocation.ProjectID),
+				ProjectName: allocation.ProjectName.String,
+				Amount:      roundTo(allocation.Amount, 2),
+			})
+		} else {
+			response.CompanyOverhead += allocation.Amount
+		}
+		response.Total += allocation.Amount
+	}
+
+	response.CompanyOverhead = roundTo(response.CompanyOverhead, 2)
+	response.Total = roundTo(response.Total, 2)
+	return response, nil
+}
+
+func (u *fixedAssetUseCase) applyRequest(ctx context.Context, asset *models.FixedAsset, req requests.FixedAssetRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("asset name is required")
+	}
+	purchaseDate, err := time.Parse("2006-01-02", req.PurchaseDate)
+	if err != nil {
+		return errors.New("invalid date format, expected YYYY-MM-DD")
+	}
+	if req.PurchaseCost <= 0 {
+		return errors.New("purchase cost must be greater than 0")
+	}
+	if req.SalvageValue < 0 || req.SalvageValue >= req.PurchaseCost {
+		return errors.New("salvage value must be at least 0 and less than purchase cost")
+	}
+	if req.UsefulLifeMonths <= 0 {
+		return errors.New("useful life must be greater than 0 months")
+	}
+
+	// Only materials classified as assets go on the register; consumables
+	// are costed to projects as they are used.
+	materialID := strings.TrimSpace(req.MaterialID)
+	if materialID != "" {
+		material, err := u.materialRepo.GetByID(ctx, materialID)
+		if err != nil {
+			return err
+		}
+		if material.Classification != models.MaterialClassificationAsset {
+			return errors.New("material is not classified as an asset")
+		}
+	}
+
+	asset.ProjectID = uuid.NullUUID{}
+	if req.ProjectID != nil {
+		if _, err := u.projectRepo.GetByID(ctx, *req.ProjectID); err != nil {
+			return err
+		}
+		asset.ProjectID = uuid.NullUUID{UUID: *req.ProjectID, Valid: true}
+	}
+
+	asset.Name = name
+	asset.MaterialID = optionalString(materialID)
+	asset.SerialNumber = optionalString(req.SerialNumber)
+	asset.PurchaseDate = purchaseDate
+	asset.PurchaseCost = roundTo(req.PurchaseCost, 2)
+	asset.SalvageValue = roundTo(req.SalvageValue, 2)
+	asset.UsefulLifeMonths = req.UsefulLifeMonths
+	return nil
+}
+
+func toFixedAssetResponse(asset *models.FixedAsset) responses.FixedAssetResponse {
+	response := responses.FixedAssetResponse{
+		AssetID:             asset.AssetID,
+		Name:                asset.Name,
+		MaterialID:          asset.MaterialID.String,
+		SerialNumber:        asset.SerialNumber.String,
+		ProjectID:           nullUUIDPtr(asset.ProjectID),
+		PurchaseDate:        asset.PurchaseDate.Format("2006-01-02"),
+		PurchaseCost:        asset.PurchaseCost,
+		SalvageValue:        asset.SalvageValue,
+		UsefulLifeMonths:    asset.UsefulLifeMonths,
+		MonthlyDepreciation: asset.AccumulatedDepreciation(1),
+		Status:              string(asset.Status),
+		CreatedAt:           asset.CreatedAt,
+		UpdatedAt:           asset.UpdatedAt,
+	}
+	if asset.DisposedAt.Valid {
+		response.DisposedAt = asset.DisposedAt.Time.Format("2006-01-02")
+	}
+	return response
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ForecastUsecase interface {
+	GetProjectForecast(ctx context.Context, projectID uuid.UUID) (*responses.ProjectForecastResponse, error)
+	// GetAlerts lists the live projects the caller can see whose estimate
+	// at completion exceeds their contract value by more than
+	// thresholdPercent.
+	GetAlerts(ctx context.Context, thresholdPercent float64) (*responses.ForecastAlertListResponse, error)
+	CheckAlerts(ctx context.Context) error
+}
+
+type forecastUsecase struct {
+	dashboardRepo  repositories.DashboardRepository
+	contractRepo   repositories.ContractRepository
+	recurringRepo  repositories.RecurringGeneralCostRepository
+	alertThreshold float64
+	access         projectAccess
+}
+
+// NewForecastUsecase takes the default alert threshold as a percentage
+// over the contract value; 0 alerts as soon as the forecast exceeds it.
+func NewForecastUsecase(
+	dashboardRepo repositories.DashboardRepository,
+	contractRepo repositories.ContractRepository,
+	recurringRepo repositories.RecurringGeneralCostRepository,
+	alertThreshold float64,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) ForecastUsecase {
+	return &forecastUsecase{
+		dashboardRepo:  dashboardRepo,
+		contractRepo:   contractRepo,
+		recurringRepo:  recurringRepo,
+		alertThreshold: alertThreshold,
+		access:         projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *forecastUsecase) GetProjectForecast(ctx context.Context, projectID uuid.UUID) (*responses.ProjectForecastResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	asOf := currentDate()
+	project, err := u.dashboardRepo.GetPortfolioProject(ctx, projectID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return u.forecast(ctx, project, asOf)
+}
+
+func (u *forecastUsecase) GetAlerts(ctx context.Context, thresholdPercent float64) (*responses.ForecastAlertListResponse, error) {
+	if thresholdPercent < 0 {
+		thresholdPercent = u.alertThreshold
+	}
+
+	visible, err := u.access.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.alerts(ctx, thresholdPercent, visible)
+}
+
+// CheckAlerts runs on a schedule and logs every project forecast to
+// overrun its contract by more than the default threshold.
+func (u *forecastUsecase) CheckAlerts(ctx context.Context) error {
+	alerts, err := u.alerts(ctx, u.alertThreshold, func(uuid.UUID) bool { return true })
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range alerts.Alerts {
+		log.Printf("forecast alert: project %q is forecast to cost %.2f against a %s of %.2f (%.2f%% over)",
+			alert.Name, alert.EstimateAtCompletion, alert.ContractSource, alert.ContractValue, alert.OverrunPercent)
+	}
+	return nil
+}
+
+func (u *forecastUsecase) alerts(ctx context.Context, thresholdPercent float64, visible func(uuid.UUID) bool) (*responses.ForecastAlertListResponse, error) {
+	asOf := currentDate()
+	projects, err := u.dashboardRepo.GetPortfolioProjects(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.ForecastAlertListResponse{
+		ThresholdPercent: thresholdPercent,
+		Alerts:           []responses.ProjectForecastResponse{},
+	}
+	for i := range projects {
+		p := &projects[i]
+		if !visible(p.ProjectID) || p.Status == models.ProjectStatusCompleted || p.EstimatedCost <= 0 {
+			continue
+		}
+
+		forecast, err := u.forecast(ctx, p, asOf)
+		if err != nil {
+			return nil, err
+		}
+		if forecast.ExceedsContract && forecast.OverrunPercent > thresholdPercent {
+			response.Alerts = append(response.Alerts, *forecast)
+		}
+	}
+	return response, nil
+}
+
+func (u *forecastUsecase) forecast(ctx context.Context, p *models.PortfolioProject, asOf time.Time) (*responses.ProjectForecastResponse, error) {
+	documents, err := u.contractRepo.ListDocuments(ctx, p.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	committed, err := committedRecurringCost(ctx, u.recurringRepo, p.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	ev := models.NewEarnedValue(p)
+	// Recurring costs still to be posted will be spent whatever the
+	// project's efficiency, so the forecast never falls below them.
+	if floor := ev.ActualCost + committed; ev.EstimateAtCompletion < floor {
+		ev.EstimateAtCompletion = floor
+		ev.EstimateToComplete = committed
+	}
+	response := &responses.ProjectForecastResponse{
+		ProjectID:              p.ProjectID,
+		Name:                   p.Name,
+		Status:                 p.Status,
+		AsOf:                   asOf.Format("2006-01-02"),
+		PlannedProgress:        roundTo(p.PlannedProgress()*100, 2),
+		ActualProgress:         roundTo(p.ActualProgress()*100, 2),
+		BudgetAtCompletion:     roundTo(ev.Budget, 2),
+		PlannedValue:           roundTo(ev.PlannedCost, 2),
+		EarnedValue:            roundTo(ev.EarnedCost, 2),
+		ActualCost:             roundTo(ev.ActualCost, 2),
+		EstimateAtCompletion:   roundTo(ev.EstimateAtCompletion, 2),
+		EstimateToComplete:     roundTo(ev.EstimateToComplete, 2),
+		VarianceAtCompletion:   roundTo(ev.Budget-ev.EstimateAtCompletion, 2),
+		CommittedRecurringCost: committed,
+		ContractValue:          roundTo(p.SellingPrice, 2),
+		ContractSource:         "selling_price",
+	}
+	if ev.CPI > 0 {
+		cpi := roundTo(ev.CPI, 2)
+		response.CPI = &cpi
+	}
+	if ev.SPI > 0 {
+		spi := roundTo(ev.SPI, 2)
+		response.SPI = &spi
+	}
+
+	if position := models.CurrentContract(documents, asOf); position != nil {
+		response.ContractValue = roundTo(position.Value, 2)
+		response.ContractSource = "contract"
+	}
+	response.ProjectedMargin = roundTo(response.ContractValue-ev.EstimateAtCompletion, 2)
+	if response.ContractValue > 0 && ev.EstimateAtCompletion > response.ContractValue {
+		response.ExceedsContract = true
+		response.OverrunPercent = roundTo((ev.EstimateAtCompletion-response.ContractValue)/response.ContractValue*100, 2)
+	}
+	return response, nil
+}
@@ -7,6 +7,7 @@ import (
 	"boonkosang/internal/responses"
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,12 +23,14 @@ type GeneralCostUseCase interface {
 type generalCostUseCase struct {
 	generalCostRepo repositories.GeneralCostRepository
 	boqRepo         repositories.BOQRepository
+	periodRepo      repositories.AccountingPeriodRepository
 }
 
-func NewGeneralCostUsecase(generalCostRepo repositories.GeneralCostRepository, boqRepo repositories.BOQRepository) GeneralCostUseCase {
+func NewGeneralCostUsecase(generalCostRepo repositories.GeneralCostRepository, boqRepo repositories.BOQRepository, periodRepo repositories.AccountingPeriodRepository) GeneralCostUseCase {
 	return &generalCostUseCase{
 		generalCostRepo: generalCostRepo,
 		boqRepo:         boqRepo,
+		periodRepo:      periodRepo,
 	}
 }
 
@@ -94,6 +97,11 @@ func (u *generalCostUseCase) UpdateActualCost(ctx context.Context, gID uuid.UUID
 		return errors.New("general cost not found")
 	}
 
+	// Actual costs are posted as of today.
+	if err := checkPostingDate(ctx, u.periodRepo, time.Now()); err != nil {
+		return err
+	}
+
 	// Update the actual cost
 	return u.generalCostRepo.UpdateActualCost(ctx, gID, req)
 }
@@ -0,0 +1,492 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/infrastructure/pdf"
+	"boonkosang/internal/infrastructure/watermark"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HandoverUseCase interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.HandoverRequest) (*responses.HandoverResponse, error)
+	Get(ctx context.Context, projectID uuid.UUID) (*responses.HandoverResponse, error)
+	Update(ctx context.Context, projectID uuid.UUID, req requests.HandoverRequest) (*responses.HandoverResponse, error)
+	GetCertificate(ctx context.Context, projectID uuid.UUID) ([]byte, error)
+
+	// The token variants serve the client through the signed link.
+	GetByToken(ctx context.Context, token string) (*responses.HandoverResponse, error)
+	GetCertificateByToken(ctx context.Context, token string) ([]byte, error)
+	Acknowledge(ctx context.Context, token string, req requests.AcknowledgeHandoverRequest, ip string) error
+	// GetAttachmentByToken returns an attachment's content and content
+	// type, watermarked if it's a photo and watermarking is enabled.
+	GetAttachmentByToken(ctx context.Context, token string, attachmentID uuid.UUID) ([]byte, string, error)
+}
+
+type handoverUseCase struct {
+	handoverRepo   repositories.HandoverRepository
+	projectRepo    repositories.ProjectRepository
+	boqRepo        repositories.BOQRepository
+	inspectionRepo repositories.InspectionRepository
+	cubeRepo       repositories.CubeTestRepository
+	surveyUseCase  SurveyUseCase
+	fileFetcher    repositories.FileFetcher
+	watermark      *watermark.Watermark
+	links          linkSigner
+	linkBaseURL    string
+	linkTTL        time.Duration
+}
+
+// NewHandoverUsecase signs acknowledgement links with linkSecret. Links are
+// linkBaseURL followed by the token and stay valid for linkTTL. With a
+// non-nil mark, photos attached to the handover are watermarked before the
+// client sees them.
+func NewHandoverUsecase(
+	handoverRepo repositories.HandoverRepository,
+	projectRepo repositories.ProjectRepository,
+	boqRepo repositories.BOQRepository,
+	inspectionRepo repositories.InspectionRepository,
+	cubeRepo repositories.CubeTestRepository,
+	surveyUseCase SurveyUseCase,
+	fileFetcher repositories.FileFetcher,
+	mark *watermark.Watermark,
+	linkSecret string,
+	linkBaseURL string,
+	linkTTL time.Duration,
+) HandoverUseCase {
+	return &handoverUseCase{
+		handoverRepo:   handoverRepo,
+		projectRepo:    projectRepo,
+		boqRepo:        boqRepo,
+		inspectionRepo: inspectionRepo,
+		cubeRepo:       cubeRepo,
+		surveyUseCase:  surveyUseCase,
+		fileFetcher:    fileFetcher,
+		watermark:      mark,
+		links:          newLinkSigner(linkSecret, linkPurposeHandover),
+		linkBaseURL:    strings.TrimRight(linkBaseURL, "/"),
+		linkTTL:        linkTTL,
+	}
+}
+
+func (u *handoverUseCase) Create(ctx context.Context, projectID uuid.UUID, req requests.HandoverRequest) (*responses.HandoverResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+	if project.Status != models.ProjectStatusCompleted {
+		return nil, errors.New("project must be completed before handover")
+	}
+
+	handover := &models.Handover{
+		HandoverID: uuid.New(),
+		ProjectID:  projectID,
+		CreatedAt:  time.Now(),
+	}
+	if err := u.applyHandoverFields(ctx, handover, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.handoverRepo.Create(ctx, handover); err != nil {
+		return nil, err
+	}
+	if err := u.loadCubeTests(ctx, handover); err != nil {
+		return nil, err
+	}
+
+	response := u.toHandoverResponse(handover)
+	// The handover stands even if the survey can't be sent; it can be sent
+	// again from the survey endpoints.
+	survey, err := u.surveyUseCase.SendForHandover(ctx, handover, req.ProjectManagerID)
+	if err != nil {
+		log.Printf("failed to send survey for handover %s: %v", handover.HandoverID, err)
+	} else if survey != nil {
+		response.SurveyURL = survey.SurveyURL
+	}
+
+	return response, nil
+}
+
+func (u *handoverUseCase) Get(ctx context.Context, projectID uuid.UUID) (*responses.HandoverResponse, error) {
+	handover, err := u.handoverRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.loadCubeTests(ctx, handover); err != nil {
+		return nil, err
+	}
+
+	return u.toHandoverResponse(handover), nil
+}
+
+// Update recompiles the outstanding defects, so defects fixed since the
+// handover was prepared drop off the certificate.
+func (u *handoverUseCase) Update(ctx context.Context, projectID uuid.UUID, req requests.HandoverRequest) (*responses.HandoverResponse, error) {
+	handover, err := u.handoverRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if handover.AcknowledgedAt.Valid {
+		return nil, errors.New("handover has already been acknowledged")
+	}
+
+	if err := u.applyHandoverFields(ctx, handover, req); err != nil {
+		return nil, err
+	}
+	handover.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := u.handoverRepo.Update(ctx, handover); err != nil {
+		return nil, err
+	}
+	if err := u.loadCubeTests(ctx, handover); err != nil {
+		return nil, err
+	}
+
+	return u.toHandoverResponse(handover), nil
+}
+
+func (u *handoverUseCase) GetCertificate(ctx context.Context, projectID uuid.UUID) ([]byte, error) {
+	handover, err := u.handoverRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.renderCertificate(ctx, handover)
+}
+
+func (u *handoverUseCase) GetByToken(ctx context.Context, token string) (*responses.HandoverResponse, error) {
+	handover, err := u.handoverFromToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.loadCubeTests(ctx, handover); err != nil {
+		return nil, err
+	}
+
+	response := u.toHandoverResponse(handover)
+	// The client already holds the link; don't hand out a fresh one.
+	response.AcknowledgementURL = ""
+	response.LinkExpiresAt = nil
+	// Point the client at the watermarked copies instead of the originals.
+	if u.watermark != nil {
+		for i := range response.Attachments {
+			response.Attachments[i].FileURL = "/handover-acknowledgements/" + token +
+				"/attachments/" + response.Attachments[i].AttachmentID.String()
+		}
+	}
+	return response, nil
+}
+
+func (u *handoverUseCase) GetCertificateByToken(ctx context.Context, token string) ([]byte, error) {
+	handover, err := u.handoverFromToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.renderCertificate(ctx, handover)
+}
+
+func (u *handoverUseCase) GetAttachmentByToken(ctx context.Context, token string, attachmentID uuid.UUID) ([]byte, string, error) {
+	handover, err := u.handoverFromToken(ctx, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var attachment *models.HandoverAttachment
+	for i := range handover.Attachments {
+		if handover.Attachments[i].AttachmentID == attachmentID {
+			attachment = &handover.Attachments[i]
+			break
+		}
+	}
+	if attachment == nil {
+		return nil, "", errors.New("attachment not found")
+	}
+
+	content, contentType, err := u.fileFetcher.Fetch(ctx, attachment.FileURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if u.watermark == nil {
+		return content, contentType, nil
+	}
+
+	// Stamp the date the photo was shared with the client.
+	marked, markedType, err := u.watermark.Apply(content, attachment.CreatedAt.In(models.AccountingPeriodLocation))
+	if err != nil {
+		if errors.Is(err, watermark.ErrUnsupported) {
+			return content, contentType, nil
+		}
+		return nil, "", err
+	}
+	return marked, markedType, nil
+}
+
+func (u *handoverUseCase) Acknowledge(ctx context.Context, token string, req requests.AcknowledgeHandoverRequest, ip string) error {
+	handover, err := u.handoverFromToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if handover.AcknowledgedAt.Valid {
+		return errors.New("handover has already been acknowledged")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("acknowledging name is required")
+	}
+
+	return u.handoverRepo.Acknowledge(ctx, handover.HandoverID, name, ip, time.Now())
+}
+
+func (u *handoverUseCase) applyHandoverFields(ctx context.Context, handover *models.Handover, req requests.HandoverRequest) error {
+	completionDate, err := time.Parse("2006-01-02", req.CompletionDate)
+	if err != nil {
+		return errors.New("invalid date format, expected YYYY-MM-DD")
+	}
+	if completionDate.After(time.Now()) {
+		return errors.New("completion date must not be in the future")
+	}
+	if req.WarrantyMonths < 0 {
+		return errors.New("warranty months must not be negative")
+	}
+
+	defects, err := u.compileDefects(ctx, handover.ProjectID)
+	if err != nil {
+		return err
+	}
+	for _, d := range req.Defects {
+		description := strings.TrimSpace(d.Description)
+		if description == "" {
+			return errors.New("defect description is required")
+		}
+		defects = append(defects, models.HandoverDefect{
+			Location:    optionalString(d.Location),
+			Description: description,
+			Note:        optionalString(d.Note),
+		})
+	}
+	for i := range defects {
+		defects[i].DefectID = uuid.New()
+		defects[i].HandoverID = handover.HandoverID
+		defects[i].SortOrder = i + 1
+	}
+
+	attachments := make([]models.HandoverAttachment, len(req.Attachments))
+	for i, a := range req.Attachments {
+		name := strings.TrimSpace(a.Name)
+		fileURL := strings.TrimSpace(a.FileURL)
+		if name == "" || fileURL == "" {
+			return errors.New("attachment name and file_url are required")
+		}
+		attachments[i] = models.HandoverAttachment{
+			AttachmentID: uuid.New(),
+			HandoverID:   handover.HandoverID,
+			Name:         name,
+			FileURL:      fileURL,
+			CreatedAt:    time.Now(),
+		}
+	}
+
+	handover.CompletionDate = completionDate
+	handover.WarrantyMonths = req.WarrantyMonths
+	handover.WarrantyTerms = strings.TrimSpace(req.WarrantyTerms)
+	handover.Defects = defects
+	handover.Attachments = attachments
+
+	return nil
+}
+
+// compileDefects turns the open failures on the project's inspections into
+// defects. A project without a BOQ has nothing to compile.
+func (u *handoverUseCase) compileDefects(ctx context.Context, projectID uuid.UUID) ([]models.HandoverDefect, error) {
+	boq, err := u.boqRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	findings, err := u.inspectionRepo.ListOpenFindings(ctx, boq.BOQID)
+	if err != nil {
+		return nil, err
+	}
+
+	defects := make([]models.HandoverDefect, len(findings))
+	for i, f := range findings {
+		defects[i] = models.HandoverDefect{
+			InspectionID: uuid.NullUUID{UUID: f.InspectionID, Valid: true},
+			Location:     sql.NullString{String: f.JobName, Valid: true},
+			Description:  f.Description,
+			Note:         f.Note,
+		}
+	}
+
+	return defects, nil
+}
+
+// loadCubeTests attaches the project's concrete cube tests to handover.
+func (u *handoverUseCase) loadCubeTests(ctx context.Context, handover *models.Handover) error {
+	tests, err := u.cubeRepo.List(ctx, models.CubeTestFilter{
+		ProjectID: uuid.NullUUID{UUID: handover.ProjectID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	handover.CubeTests = tests
+	return nil
+}
+
+func (u *handoverUseCase) renderCertificate(ctx context.Context, handover *models.Handover) ([]byte, error) {
+	project, client, err := u.projectRepo.GetByIDWithClient(ctx, handover.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.loadCubeTests(ctx, handover); err != nil {
+		return nil, err
+	}
+
+	doc := pdf.New()
+	doc.Heading("Project Handover Certificate")
+	doc.Field("Project", project.Name)
+	doc.Field("Client", client.Name)
+	doc.Field("Completion date", handover.CompletionDate.Format("2 January 2006"))
+	doc.Gap()
+
+	doc.Bold("Warranty")
+	if handover.WarrantyMonths > 0 {
+		doc.Text(fmt.Sprintf("%d months, until %s", handover.WarrantyMonths, handover.WarrantyEndsAt().Format("2 January 2006")))
+	} else {
+		doc.Text("No warranty period")
+	}
+	if handover.WarrantyTerms != "" {
+		doc.Text(handover.WarrantyTerms)
+	}
+	doc.Gap()
+
+	doc.Bold("Outstanding defects")
+	if len(handover.Defects) == 0 {
+		doc.Text("None")
+	}
+	for i, d := range handover.Defects {
+		line := fmt.Sprintf("%d. %s", i+1, d.Description)
+		if d.Location.Valid {
+			line = fmt.Sprintf("%d. [%s] %s", i+1, d.Location.String, d.Description)
+		}
+		if d.Note.Valid {
+			line += " - " + d.Note.String
+		}
+		doc.Item(line)
+	}
+	doc.Gap()
+
+	doc.Bold("Concrete cube tests")
+	if len(handover.CubeTests) == 0 {
+		doc.Text("None")
+	} else {
+		qa := models.SummarizeCubeTests(handover.CubeTests)
+		doc.Text(fmt.Sprintf("%d tested: %d passed, %d failed, %d awaiting 28-day results", qa.Total, qa.Passed, qa.Failed, qa.Pending))
+		for _, t := range handover.CubeTests {
+			if t.Status() == models.CubeTestStatusFailed {
+				doc.Item(fmt.Sprintf("Failed: sample %s, %s, %.1f of %.1f MPa at 28 days",
+					t.SampleReference, t.JobName, t.Strength28Day.Float64, t.SpecifiedStrength))
+			}
+		}
+	}
+	doc.Gap()
+
+	doc.Bold("As-built documents")
+	if len(handover.Attachments) == 0 {
+		doc.Text("None")
+	}
+	for i, a := range handover.Attachments {
+		doc.Item(fmt.Sprintf("%d. %s: %s", i+1, a.Name, a.FileURL))
+	}
+	doc.Gap()
+
+	doc.Bold("Client acknowledgement")
+	if handover.AcknowledgedAt.Valid {
+		doc.Text(fmt.Sprintf("Acknowledged by %s on %s", handover.AcknowledgedBy.String, handover.AcknowledgedAt.Time.Format("2 January 2006 15:04")))
+	} else {
+		doc.Text("Pending")
+	}
+
+	return doc.Bytes(), nil
+}
+
+func (u *handoverUseCase) handoverFromToken(ctx context.Context, token string) (*models.Handover, error) {
+	invalid := errors.New("invalid or expired link")
+
+	handoverID, ok := u.links.verify(token)
+	if !ok {
+		return nil, invalid
+	}
+
+	handover, err := u.handoverRepo.GetByID(ctx, handoverID)
+	if err != nil {
+		if err.Error() == "handover not found" {
+			return nil, invalid
+		}
+		return nil, err
+	}
+
+	return handover, nil
+}
+
+func (u *handoverUseCase) toHandoverResponse(handover *models.Handover) *responses.HandoverResponse {
+	response := &responses.HandoverResponse{
+		HandoverID:     handover.HandoverID,
+		ProjectID:      handover.ProjectID,
+		CompletionDate: handover.CompletionDate.Format("2006-01-02"),
+		WarrantyMonths: handover.WarrantyMonths,
+		WarrantyEndsAt: handover.WarrantyEndsAt().Format("2006-01-02"),
+		WarrantyTerms:  handover.WarrantyTerms,
+		Defects:        make([]responses.HandoverDefectResponse, len(handover.Defects)),
+		Attachments:    make([]responses.HandoverAttachmentResponse, len(handover.Attachments)),
+		QASummary:      toCubeTestSummaryResponse(handover.CubeTests),
+		Acknowledged:   handover.AcknowledgedAt.Valid,
+		AcknowledgedBy: handover.AcknowledgedBy.String,
+		CreatedAt:      handover.CreatedAt,
+	}
+
+	for i, d := range handover.Defects {
+		response.Defects[i] = responses.HandoverDefectResponse{
+			DefectID:     d.DefectID,
+			InspectionID: nullUUIDPtr(d.InspectionID),
+			Location:     d.Location.String,
+			Description:  d.Description,
+			Note:         d.Note.String,
+		}
+	}
+	for i, a := range handover.Attachments {
+		response.Attachments[i] = responses.HandoverAttachmentResponse{
+			AttachmentID: a.AttachmentID,
+			Name:         a.Name,
+			FileURL:      a.FileURL,
+		}
+	}
+
+	if handover.AcknowledgedAt.Valid {
+		response.AcknowledgedAt = &handover.AcknowledgedAt.Time
+	} else {
+		expiresAt := time.Now().Add(u.linkTTL)
+		response.AcknowledgementURL = u.linkBaseURL + "/" + u.links.sign(handover.HandoverID, expiresAt)
+		response.LinkExpiresAt = &expiresAt
+	}
+
+	return response
+}
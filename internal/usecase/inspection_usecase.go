@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InspectionUseCase interface {
+	CreateTemplate(ctx context.Context, req requests.CreateInspectionTemplateRequest) (*responses.InspectionTemplateResponse, error)
+	ListTemplates(ctx context.Context, jobID *uuid.UUID) ([]responses.InspectionTemplateResponse, error)
+	UpdateTemplate(ctx context.Context, templateID uuid.UUID, req requests.UpdateInspectionTemplateRequest) error
+	DeleteTemplate(ctx context.Context, templateID uuid.UUID) error
+
+	Create(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, req requests.CreateInspectionRequest) (*responses.InspectionResponse, error)
+	GetByID(ctx context.Context, inspectionID uuid.UUID) (*responses.InspectionResponse, error)
+	ListByBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) ([]responses.InspectionResponse, error)
+	Record(ctx context.Context, inspectionID uuid.UUID, req requests.RecordInspectionRequest) (*responses.InspectionResponse, error)
+}
+
+type inspectionUseCase struct {
+	inspectionRepo repositories.InspectionRepository
+}
+
+func NewInspectionUsecase(inspectionRepo repositories.InspectionRepository) InspectionUseCase {
+	return &inspectionUseCase{
+		inspectionRepo: inspectionRepo,
+	}
+}
+
+func (u *inspectionUseCase) CreateTemplate(ctx context.Context, req requests.CreateInspectionTemplateRequest) (*responses.InspectionTemplateResponse, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, errors.New("template name is required")
+	}
+
+	var items []string
+	for _, item := range req.Items {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil, errors.New("template must have at least one item")
+	}
+	req.Items = items
+
+	template, err := u.inspectionRepo.CreateTemplate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toInspectionTemplateResponse(template)
+	return &response, nil
+}
+
+func (u *inspectionUseCase) ListTemplates(ctx context.Context, jobID *uuid.UUID) ([]responses.InspectionTemplateResponse, error) {
+	templates, err := u.inspectionRepo.ListTemplates(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.InspectionTemplateResponse, len(templates))
+	for i := range templates {
+		response[i] = toInspectionTemplateResponse(&templates[i])
+	}
+
+	return response, nil
+}
+
+func (u *inspectionUseCase) UpdateTemplate(ctx context.Context, templateID uuid.UUID, req requests.UpdateInspectionTemplateRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("template name is required")
+	}
+
+	return u.inspectionRepo.UpdateTemplate(ctx, templateID, req)
+}
+
+func (u *inspectionUseCase) DeleteTemplate(ctx context.Context, templateID uuid.UUID) error {
+	return u.inspectionRepo.DeleteTemplate(ctx, templateID)
+}
+
+func (u *inspectionUseCase) Create(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, req requests.CreateInspectionRequest) (*responses.InspectionResponse, error) {
+	template, err := u.inspectionRepo.GetTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.JobID != jobID {
+		return nil, errors.New("inspection template is not for this job")
+	}
+
+	inspection, err := u.inspectionRepo.Create(ctx, template, boqID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toInspectionResponse(inspection)
+	return &response, nil
+}
+
+func (u *inspectionUseCase) GetByID(ctx context.Context, inspectionID uuid.UUID) (*responses.InspectionResponse, error) {
+	inspection, err := u.inspectionRepo.GetByID(ctx, inspectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toInspectionResponse(inspection)
+	return &response, nil
+}
+
+func (u *inspectionUseCase) ListByBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID) ([]responses.InspectionResponse, error) {
+	inspections, err := u.inspectionRepo.ListByBOQJob(ctx, boqID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.InspectionResponse, len(inspections))
+	for i := range inspections {
+		response[i] = toInspectionResponse(&inspections[i])
+	}
+
+	return response, nil
+}
+
+// Record saves the on-site results. The inspection passes once every item
+// has passed, fails as soon as any item fails, and stays pending otherwise.
+func (u *inspectionUseCase) Record(ctx context.Context, inspectionID uuid.UUID, req requests.RecordInspectionRequest) (*responses.InspectionResponse, error) {
+	if strings.TrimSpace(req.InspectedBy) == "" {
+		return nil, errors.New("inspector name is required")
+	}
+
+	inspection, err := u.inspectionRepo.GetByID(ctx, inspectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var photos []models.InspectionPhoto
+	addPhotos := func(itemID uuid.NullUUID, urls []string) {
+		for _, url := range urls {
+			if url = strings.TrimSpace(url); url == "" {
+				continue
+			}
+			photos = append(photos, models.InspectionPhoto{
+				PhotoID:      uuid.New(),
+				InspectionID: inspectionID,
+				ItemID:       itemID,
+				FileURL:      url,
+				CreatedAt:    now,
+			})
+		}
+	}
+
+	index := make(map[uuid.UUID]int, len(inspection.Results))
+	for i, result := range inspection.Results {
+		index[result.ItemID] = i
+	}
+
+	for _, item := range req.Items {
+		i, ok := index[item.ItemID]
+		if !ok {
+			return nil, errors.New("item does not belong to this inspection")
+		}
+
+		if item.Passed != nil {
+			inspection.Results[i].Passed = sql.NullBool{Bool: *item.Passed, Valid: true}
+		}
+		if note := strings.TrimSpace(item.Note); note != "" {
+			inspection.Results[i].Note = sql.NullString{String: note, Valid: true}
+		}
+		addPhotos(uuid.NullUUID{UUID: item.ItemID, Valid: true}, item.PhotoURLs)
+	}
+	addPhotos(uuid.NullUUID{}, req.PhotoURLs)
+
+	inspection.Status = inspectionStatus(inspection.Results)
+	inspection.InspectedBy = sql.NullString{String: strings.TrimSpace(req.InspectedBy), Valid: true}
+	inspection.InspectedAt = sql.NullTime{Time: now, Valid: true}
+	if notes := strings.TrimSpace(req.Notes); notes != "" {
+		inspection.Notes = sql.NullString{String: notes, Valid: true}
+	}
+
+	if err := u.inspectionRepo.SaveResults(ctx, inspection, photos); err != nil {
+		return nil, err
+	}
+	inspection.Photos = append(inspection.Photos, photos...)
+
+	response := toInspectionResponse(inspection)
+	return &response, nil
+}
+
+func inspectionStatus(results []models.InspectionResult) models.InspectionStatus {
+	status := models.InspectionStatusPassed
+	for _, result := range results {
+		if !result.Passed.Valid {
+			status = models.InspectionStatusPending
+			continue
+		}
+		if !result.Passed.Bool {
+			return models.InspectionStatusFailed
+		}
+	}
+	return status
+}
+
+func toInspectionTemplateResponse(template *models.InspectionTemplate) responses.InspectionTemplateResponse {
+	response := responses.InspectionTemplateResponse{
+		TemplateID: template.TemplateID,
+		JobID:      template.JobID,
+		Name:       template.Name,
+		Mandatory:  template.Mandatory,
+		Items:      make([]responses.InspectionTemplateItemResp, len(template.Items)),
+		CreatedAt:  template.CreatedAt,
+	}
+	for i, item := range template.Items {
+		response.Items[i] = responses.InspectionTemplateItemResp{
+			ItemID:      item.ItemID,
+			Description: item.Description,
+			SortOrder:   item.SortOrder,
+		}
+	}
+	return response
+}
+
+func toInspectionResponse(inspection *models.Inspection) responses.InspectionResponse {
+	response := responses.InspectionResponse{
+		InspectionID: inspection.InspectionID,
+		TemplateID:   inspection.TemplateID,
+		BOQID:        inspection.BOQID,
+		JobID:        inspection.JobID,
+		Status:       inspection.Status,
+		InspectedBy:  inspection.InspectedBy.String,
+		Notes:        inspection.Notes.String,
+		Items:        make([]responses.InspectionItemResponse, len(inspection.Results)),
+		PhotoURLs:    []string{},
+		CreatedAt:    inspection.CreatedAt,
+	}
+	if inspection.InspectedAt.Valid {
+		response.InspectedAt = &inspection.InspectedAt.Time
+	}
+
+	itemPhotos := make(map[uuid.UUID][]string)
+	for _, photo := range inspection.Photos {
+		if photo.ItemID.Valid {
+			itemPhotos[photo.ItemID.UUID] = append(itemPhotos[photo.ItemID.UUID], photo.FileURL)
+		} else {
+			response.PhotoURLs = append(response.PhotoURLs, photo.FileURL)
+		}
+	}
+
+	for i, result := range inspection.Results {
+		item := responses.InspectionItemResponse{
+			ItemID:      result.ItemID,
+			Description: result.Description,
+			Note:        result.Note.String,
+			PhotoURLs:   itemPhotos[result.ItemID],
+		}
+		if result.Passed.Valid {
+			passed := result.Passed.Bool
+			item.Passed = &passed
+		}
+		if item.PhotoURLs == nil {
+			item.PhotoURLs = []string{}
+		}
+		response.Items[i] = item
+	}
+
+	return response
+}
@@ -0,0 +1,410 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InventoryUseCase interface {
+	CreateWarehouse(ctx context.Context, req requests.CreateWarehouseRequest) (*responses.WarehouseResponse, error)
+	ListWarehouses(ctx context.Context, includeClosed bool) ([]responses.WarehouseResponse, error)
+	GetWarehouseStock(ctx context.Context, warehouseID uuid.UUID) ([]responses.StockLevelResponse, error)
+	GetMaterialStock(ctx context.Context, materialID string) ([]responses.StockLevelResponse, error)
+	ListMovements(ctx context.Context, warehouseID uuid.UUID) ([]responses.StockMovementResponse, error)
+
+	ReceiveStock(ctx context.Context, warehouseID uuid.UUID, req requests.StockMovementRequest) ([]responses.StockMovementResponse, error)
+	IssueStock(ctx context.Context, warehouseID uuid.UUID, req requests.StockMovementRequest) ([]responses.StockMovementResponse, error)
+	Transfer(ctx context.Context, req requests.StockTransferRequest) ([]responses.StockMovementResponse, error)
+
+	// CloseSiteStore moves everything left in a site store to a main
+	// warehouse and closes the store.
+	CloseSiteStore(ctx context.Context, warehouseID uuid.UUID, req requests.CloseWarehouseRequest) (*responses.WarehouseCloseResponse, error)
+}
+
+type inventoryUseCase struct {
+	inventoryRepo repositories.InventoryRepository
+	materialRepo  repositories.MaterialRepository
+	projectRepo   repositories.ProjectRepository
+}
+
+func NewInventoryUsecase(
+	inventoryRepo repositories.InventoryRepository,
+	materialRepo repositories.MaterialRepository,
+	projectRepo repositories.ProjectRepository,
+) InventoryUseCase {
+	return &inventoryUseCase{
+		inventoryRepo: inventoryRepo,
+		materialRepo:  materialRepo,
+		projectRepo:   projectRepo,
+	}
+}
+
+func (u *inventoryUseCase) CreateWarehouse(ctx context.Context, req requests.CreateWarehouseRequest) (*responses.WarehouseResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("warehouse name is required")
+	}
+
+	warehouse := models.Warehouse{
+		WarehouseID: uuid.New(),
+		Name:        name,
+		Kind:        models.WarehouseKind(req.Kind),
+		Status:      models.WarehouseStatusActive,
+		CreatedAt:   time.Now(),
+	}
+
+	switch warehouse.Kind {
+	case models.WarehouseKindMain:
+		if req.ProjectID != nil {
+			return nil, errors.New("only site stores belong to a project")
+		}
+	case models.WarehouseKindSite:
+		if req.ProjectID == nil {
+			return nil, errors.New("site stores require a project")
+		}
+		if _, err := u.projectRepo.GetByID(ctx, *req.ProjectID); err != nil {
+			return nil, err
+		}
+		warehouse.ProjectID = uuid.NullUUID{UUID: *req.ProjectID, Valid: true}
+	default:
+		return nil, errors.New("invalid warehouse kind")
+	}
+
+	if err := u.inventoryRepo.CreateWarehouse(ctx, warehouse); err != nil {
+		return nil, err
+	}
+
+	response := toWarehouseResponse(&warehouse)
+	return &response, nil
+}
+
+func (u *inventoryUseCase) ListWarehouses(ctx context.Context, includeClosed bool) ([]responses.WarehouseResponse, error) {
+	warehouses, err := u.inventoryRepo.ListWarehouses(ctx, includeClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.WarehouseResponse, len(warehouses))
+	for i := range warehouses {
+		result[i] = toWarehouseResponse(&warehouses[i])
+	}
+	return result, nil
+}
+
+func (u *inventoryUseCase) GetWarehouseStock(ctx context.Context, warehouseID uuid.UUID) ([]responses.StockLevelResponse, error) {
+	if _, err := u.inventoryRepo.GetWarehouse(ctx, warehouseID); err != nil {
+		return nil, err
+	}
+
+	levels, err := u.inventoryRepo.GetStock(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	return toStockLevelResponses(levels), nil
+}
+
+func (u *inventoryUseCase) GetMaterialStock(ctx context.Context, materialID string) ([]responses.StockLevelResponse, error) {
+	if _, err := u.materialRepo.GetByID(ctx, materialID); err != nil {
+		return nil, err
+	}
+
+	levels, err := u.inventoryRepo.GetMaterialStock(ctx, materialID)
+	if err != nil {
+		return nil, err
+	}
+	return toStockLevelResponses(levels), nil
+}
+
+func (u *inventoryUseCase) ListMovements(ctx context.Context, warehouseID uuid.UUID) ([]responses.StockMovementResponse, error) {
+	if _, err := u.inventoryRepo.GetWarehouse(ctx, warehouseID); err != nil {
+		return nil, err
+	}
+
+	movements, err := u.inventoryRepo.ListMovements(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	return toStockMovementResponses(movements), nil
+}
+
+func (u *inventoryUseCase) ReceiveStock(ctx context.Context, warehouseID uuid.UUID, req requests.StockMovementRequest) ([]responses.StockMovementResponse, error) {
+	return u.recordSingleSided(ctx, warehouseID, req, models.StockMovementReceipt, 1)
+}
+
+func (u *inventoryUseCase) IssueStock(ctx context.Context, warehouseID uuid.UUID, req requests.StockMovementRequest) ([]responses.StockMovementResponse, error) {
+	return u.recordSingleSided(ctx, warehouseID, req, models.StockMovementIssue, -1)
+}
+
+func (u *inventoryUseCase) recordSingleSided(ctx context.Context, warehouseID uuid.UUID, req requests.StockMovementRequest, movementType models.StockMovementType, sign float64) ([]responses.StockMovementResponse, error) {
+	warehouse, err := u.activeWarehouse(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.validateLines(ctx, req.Lines); err != nil {
+		return nil, err
+	}
+
+	// Stock issued from a site store is charged to its project unless the
+	// request says otherwise.
+	projectID := warehouse.ProjectID
+	if req.ProjectID != nil {
+		if _, err := u.projectRepo.GetByID(ctx, *req.ProjectID); err != nil {
+			return nil, err
+		}
+		projectID = uuid.NullUUID{UUID: *req.ProjectID, Valid: true}
+	}
+
+	now := time.Now()
+	movements := make([]models.StockMovement, len(req.Lines))
+	for i, line := range req.Lines {
+		movements[i] = models.StockMovement{
+			MovementID:  uuid.New(),
+			WarehouseID: warehouseID,
+			MaterialID:  line.MaterialID,
+			Quantity:    sign * line.Quantity,
+			Type:        movementType,
+			ProjectID:   projectID,
+			Reference:   optionalString(req.Reference),
+			Note:        optionalString(req.Note),
+			CreatedAt:   now,
+		}
+	}
+
+	if err := u.inventoryRepo.RecordMovements(ctx, movements); err != nil {
+		return nil, err
+	}
+	return toStockMovementResponses(movements), nil
+}
+
+func (u *inventoryUseCase) Transfer(ctx context.Context, req requests.StockTransferRequest) ([]responses.StockMovementResponse, error) {
+	if req.FromWarehouseID == req.ToWarehouseID {
+		return nil, errors.New("cannot transfer stock to the same warehouse")
+	}
+	from, err := u.activeWarehouse(ctx, req.FromWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := u.activeWarehouse(ctx, req.ToWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.validateLines(ctx, req.Lines); err != nil {
+		return nil, err
+	}
+
+	movements := transferMovements(from, to, req.Lines, req.Reference, req.Note)
+	if err := u.inventoryRepo.RecordMovements(ctx, movements); err != nil {
+		return nil, err
+	}
+	return toStockMovementResponses(movements), nil
+}
+
+func (u *inventoryUseCase) CloseSiteStore(ctx context.Context, warehouseID uuid.UUID, req requests.CloseWarehouseRequest) (*responses.WarehouseCloseResponse, error) {
+	store, err := u.activeWarehouse(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if store.Kind != models.WarehouseKindSite {
+		return nil, errors.New("only site stores can be closed")
+	}
+
+	target, err := u.closeTarget(ctx, req.TargetWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := u.inventoryRepo.GetStock(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []requests.StockLineRequest
+	for _, level := range levels {
+		if level.Quantity > 0 {
+			lines = append(lines, requests.StockLineRequest{
+				MaterialID: level.MaterialID,
+				Quantity:   level.Quantity,
+			})
+		}
+	}
+
+	reference := "Site store closed: " + store.Name
+	movements := transferMovements(store, target, lines, reference, "")
+	if err := u.inventoryRepo.CloseWarehouse(ctx, warehouseID, movements); err != nil {
+		return nil, err
+	}
+
+	closed, err := u.inventoryRepo.GetWarehouse(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.WarehouseCloseResponse{
+		Warehouse:         toWarehouseResponse(closed),
+		TargetWarehouseID: target.WarehouseID,
+		Movements:         toStockMovementResponses(movements),
+	}
+	if len(movements) > 0 {
+		response.TransferID = nullUUIDPtr(movements[0].TransferID)
+	}
+	return response, nil
+}
+
+// closeTarget resolves where a closing site store's stock goes, defaulting
+// to the main warehouse when there is exactly one.
+func (u *inventoryUseCase) closeTarget(ctx context.Context, targetID *uuid.UUID) (*models.Warehouse, error) {
+	if targetID != nil {
+		target, err := u.activeWarehouse(ctx, *targetID)
+		if err != nil {
+			return nil, err
+		}
+		if target.Kind != models.WarehouseKindMain {
+			return nil, errors.New("site store stock must be returned to a main warehouse")
+		}
+		return target, nil
+	}
+
+	warehouses, err := u.inventoryRepo.ListWarehouses(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	var target *models.Warehouse
+	for i := range warehouses {
+		if warehouses[i].Kind != models.WarehouseKindMain {
+			continue
+		}
+		if target != nil {
+			return nil, errors.New("target warehouse is required when there are several main warehouses")
+		}
+		target = &warehouses[i]
+	}
+	if target == nil {
+		return nil, errors.New("no active main warehouse")
+	}
+	return target, nil
+}
+
+func (u *inventoryUseCase) activeWarehouse(ctx context.Context, warehouseID uuid.UUID) (*models.Warehouse, error) {
+	warehouse, err := u.inventoryRepo.GetWarehouse(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if warehouse.Status != models.WarehouseStatusActive {
+		return nil, errors.New("warehouse is closed")
+	}
+	return warehouse, nil
+}
+
+func (u *inventoryUseCase) validateLines(ctx context.Context, lines []requests.StockLineRequest) error {
+	if len(lines) == 0 {
+		return errors.New("at least one stock line is required")
+	}
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return errors.New("quantity must be greater than 0")
+		}
+		if _, err := u.materialRepo.GetByID(ctx, line.MaterialID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferMovements builds the out and in legs of a transfer. The in leg
+// carries the destination's project, so stock moved to a site store shows
+// up against that project.
+func transferMovements(from, to *models.Warehouse, lines []requests.StockLineRequest, reference, note string) []models.StockMovement {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	transferID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	now := time.Now()
+	movements := make([]models.StockMovement, 0, len(lines)*2)
+	for _, line := range lines {
+		movements = append(movements,
+			models.StockMovement{
+				MovementID:  uuid.New(),
+				WarehouseID: from.WarehouseID,
+				MaterialID:  line.MaterialID,
+				Quantity:    -line.Quantity,
+				Type:        models.StockMovementTransferOut,
+				TransferID:  transferID,
+				ProjectID:   from.ProjectID,
+				Reference:   optionalString(reference),
+				Note:        optionalString(note),
+				CreatedAt:   now,
+			},
+			models.StockMovement{
+				MovementID:  uuid.New(),
+				WarehouseID: to.WarehouseID,
+				MaterialID:  line.MaterialID,
+				Quantity:    line.Quantity,
+				Type:        models.StockMovementTransferIn,
+				TransferID:  transferID,
+				ProjectID:   to.ProjectID,
+				Reference:   optionalString(reference),
+				Note:        optionalString(note),
+				CreatedAt:   now,
+			},
+		)
+	}
+	return movements
+}
+
+func toWarehouseResponse(warehouse *models.Warehouse) responses.WarehouseResponse {
+	response := responses.WarehouseResponse{
+		WarehouseID: warehouse.WarehouseID,
+		Name:        warehouse.Name,
+		Kind:        string(warehouse.Kind),
+		ProjectID:   nullUUIDPtr(warehouse.ProjectID),
+		Status:      string(warehouse.Status),
+		CreatedAt:   warehouse.CreatedAt,
+	}
+	if warehouse.ClosedAt.Valid {
+		response.ClosedAt = &warehouse.ClosedAt.Time
+	}
+	return response
+}
+
+func toStockLevelResponses(levels []models.StockLevel) []responses.StockLevelResponse {
+	result := make([]responses.StockLevelResponse, len(levels))
+	for i, level := range levels {
+		result[i] = responses.StockLevelResponse{
+			WarehouseID:   level.WarehouseID,
+			WarehouseName: level.WarehouseName,
+			MaterialID:    level.MaterialID,
+			MaterialName:  level.MaterialName,
+			Unit:          level.Unit,
+			Quantity:      roundTo(level.Quantity, 4),
+		}
+	}
+	return result
+}
+
+func toStockMovementResponses(movements []models.StockMovement) []responses.StockMovementResponse {
+	result := make([]responses.StockMovementResponse, len(movements))
+	for i, movement := range movements {
+		result[i] = responses.StockMovementResponse{
+			MovementID:  movement.MovementID,
+			WarehouseID: movement.WarehouseID,
+			MaterialID:  movement.MaterialID,
+			Quantity:    movement.Quantity,
+			Type:        string(movement.Type),
+			TransferID:  nullUUIDPtr(movement.TransferID),
+			ProjectID:   nullUUIDPtr(movement.ProjectID),
+			Reference:   movement.Reference.String,
+			Note:        movement.Note.String,
+			CreatedAt:   movement.CreatedAt,
+		}
+	}
+	return result
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type InvitationUsecase interface {
+	// Invite creates a pending user and emails them a signed link to set
+	// their password. Only owners and admins may invite.
+	Invite(ctx context.Context, actorID uuid.UUID, req requests.InviteUserRequest) (*responses.InvitationResponse, error)
+	// Accept sets the invited user's password, which must meet the
+	// password policy. Each invitation can be accepted once.
+	Accept(ctx context.Context, req requests.AcceptInvitationRequest) error
+}
+
+type invitationUsecase struct {
+	invitationRepo repositories.InvitationRepository
+	userRepo       repositories.UserRepository
+	// mailer is nil when no mail server is configured; the link is then
+	// returned to the admin instead.
+	mailer         repositories.Mailer
+	passwordPolicy models.PasswordPolicy
+	links          linkSigner
+	linkBaseURL    string
+	linkTTL        time.Duration
+}
+
+// NewInvitationUsecase signs invitation links with linkSecret. Links are
+// linkBaseURL followed by the token and stay valid for linkTTL.
+func NewInvitationUsecase(
+	invitationRepo repositories.InvitationRepository,
+	userRepo repositories.UserRepository,
+	mailer repositories.Mailer,
+	passwordPolicy models.PasswordPolicy,
+	linkSecret string,
+	linkBaseURL string,
+	linkTTL time.Duration,
+) InvitationUsecase {
+	return &invitationUsecase{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		mailer:         mailer,
+		passwordPolicy: passwordPolicy,
+		links:          newLinkSigner(linkSecret, linkPurposeInvitation),
+		linkBaseURL:    strings.TrimRight(linkBaseURL, "/"),
+		linkTTL:        linkTTL,
+	}
+}
+
+func (u *invitationUsecase) Invite(ctx context.Context, actorID uuid.UUID, req requests.InviteUserRequest) (*responses.InvitationResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, actorID); err != nil {
+		return nil, err
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Username == "" || strings.TrimSpace(req.FirstName) == "" || strings.TrimSpace(req.LastName) == "" {
+		return nil, errors.New("username, first name and last name are required")
+	}
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		return nil, errors.New("invalid email address")
+	}
+	role := models.UserRoleStaff
+	if req.Role != "" {
+		role = models.UserRole(req.Role)
+		if !role.Valid() {
+			return nil, errors.New("invalid role")
+		}
+	}
+
+	// The invitee can't sign in with a password until they set one, so
+	// the account starts with a random one nobody is told.
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(base64.RawURLEncoding.EncodeToString(secret)), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		UserID:    uuid.New(),
+		Username:  req.Username,
+		Password:  string(hashedPassword),
+		FirstName: strings.TrimSpace(req.FirstName),
+		LastName:  strings.TrimSpace(req.LastName),
+		Email:     sql.NullString{String: req.Email, Valid: true},
+		Tel:       sql.NullString{String: req.Tel, Valid: req.Tel != ""},
+		Role:      role,
+		IsActive:  true,
+	}
+	now := time.Now()
+	invitation := models.UserInvitation{
+		InvitationID: uuid.New(),
+		UserID:       user.UserID,
+		InvitedBy:    actorID,
+		ExpiresAt:    now.Add(u.linkTTL),
+		CreatedAt:    now,
+	}
+	if err := u.invitationRepo.Create(ctx, user, invitation); err != nil {
+		return nil, err
+	}
+
+	link := u.linkBaseURL + "/" + u.links.sign(invitation.InvitationID, invitation.ExpiresAt)
+	response := &responses.InvitationResponse{
+		InvitationID: invitation.InvitationID,
+		User:         toUserResponse(user),
+		ExpiresAt:    invitation.ExpiresAt,
+	}
+	if u.mailer != nil {
+		body := fmt.Sprintf("Hello %s,\n\nYou have been invited to Boonkosang as %s. Set your password here:\n\n%s\n\nThe link expires on %s.\n",
+			user.FirstName, user.Username, link, invitation.ExpiresAt.Format("2006-01-02 15:04"))
+		if err := u.mailer.Send(ctx, req.Email, "You're invited to Boonkosang", body); err != nil {
+			// The user exists now, so hand the link to the admin rather
+			// than fail the invitation.
+			log.Printf("failed to email invitation %s: %v", invitation.InvitationID, err)
+		} else {
+			response.EmailSent = true
+		}
+	}
+	if !response.EmailSent {
+		response.InviteURL = link
+	}
+	return response, nil
+}
+
+func (u *invitationUsecase) Accept(ctx context.Context, req requests.AcceptInvitationRequest) error {
+	invitation, err := u.invitationFromToken(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+	if invitation.AcceptedAt.Valid {
+		return errors.New("invitation already accepted")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, invitation.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.passwordPolicy.Validate(req.Password, user.Username); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	return u.invitationRepo.Accept(ctx, invitation.InvitationID, string(hashedPassword))
+}
+
+func (u *invitationUsecase) invitationFromToken(ctx context.Context, token string) (*models.UserInvitation, error) {
+	invalid := errors.New("invalid or expired invitation")
+
+	invitationID, ok := u.links.verify(token)
+	if !ok {
+		return nil, invalid
+	}
+
+	invitation, err := u.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		if err.Error() == "invitation not found" {
+			return nil, invalid
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/repositories"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/responses"
@@ -8,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -16,20 +18,41 @@ type InvoiceUseCase interface {
 	CreateInvoice(ctx context.Context, projectID uuid.UUID, req requests.CreateInvoiceRequest) error
 	DeleteInvoice(ctx context.Context, projectID uuid.UUID, req requests.DeleteInvoiceRequest) error
 	GetProjectInvoices(ctx context.Context, projectID uuid.UUID) ([]responses.InvoiceResponse, error)
+	GetPromptPayQR(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID, userID uuid.UUID, amount float64) (*responses.PromptPayQRResponse, error)
+	// GetVATReport lists output VAT for invoices issued in month (YYYY-MM).
+	GetVATReport(ctx context.Context, month string) (*responses.VATReportResponse, error)
 }
 
 type invoiceUseCase struct {
-	invoiceRepo repositories.InvoiceRepository
-	projectRepo repositories.ProjectRepository
+	invoiceRepo  repositories.InvoiceRepository
+	projectRepo  repositories.ProjectRepository
+	companyRepo  repositories.CompanyRepository
+	paymentRepo  repositories.PaymentRepository
+	phaseRepo    repositories.ProjectPhaseRepository
+	roundingRepo repositories.RoundingRepository
+	periodRepo   repositories.AccountingPeriodRepository
+	interestRepo repositories.LateInterestRepository
 }
 
 func NewInvoiceUsecase(
 	invoiceRepo repositories.InvoiceRepository,
 	projectRepo repositories.ProjectRepository,
+	companyRepo repositories.CompanyRepository,
+	paymentRepo repositories.PaymentRepository,
+	phaseRepo repositories.ProjectPhaseRepository,
+	roundingRepo repositories.RoundingRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+	interestRepo repositories.LateInterestRepository,
 ) InvoiceUseCase {
 	return &invoiceUseCase{
-		invoiceRepo: invoiceRepo,
-		projectRepo: projectRepo,
+		invoiceRepo:  invoiceRepo,
+		projectRepo:  projectRepo,
+		companyRepo:  companyRepo,
+		paymentRepo:  paymentRepo,
+		phaseRepo:    phaseRepo,
+		roundingRepo: roundingRepo,
+		periodRepo:   periodRepo,
+		interestRepo: interestRepo,
 	}
 }
 
@@ -48,9 +71,45 @@ func (u *invoiceUseCase) CreateInvoice(ctx context.Context, projectID uuid.UUID,
 		return errors.New("invalid file URL")
 	}
 
+	if req.Amount < 0 {
+		return errors.New("invoice amount must not be negative")
+	}
+
+	if req.PhaseID != nil {
+		phase, err := u.phaseRepo.GetByID(ctx, *req.PhaseID)
+		if err != nil {
+			return err
+		}
+		if phase.ProjectID != projectID {
+			return errors.New("phase does not belong to the specified project")
+		}
+	}
+
+	// Invoices are dated when they are created.
+	if err := checkPostingDate(ctx, u.periodRepo, time.Now()); err != nil {
+		return err
+	}
+
+	amount := req.Amount
+	var lateInterestIDs []uuid.UUID
+	if req.IncludeLateInterest {
+		accruals, err := u.interestRepo.ListAccruals(ctx, projectID, true)
+		if err != nil {
+			return err
+		}
+		for _, accrual := range accruals {
+			amount += accrual.Amount
+			lateInterestIDs = append(lateInterestIDs, accrual.AccrualID)
+		}
+		amount = roundTo(amount, 2)
+	}
+
 	// Create invoice
-	err = u.invoiceRepo.Create(ctx, projectID, req.FileURL)
+	err = u.invoiceRepo.Create(ctx, projectID, req.PhaseID, req.FileURL, amount, lateInterestIDs)
 	if err != nil {
+		if err.Error() == "late interest changed, please retry" {
+			return err
+		}
 		return fmt.Errorf("failed to create invoice: %w", err)
 	}
 
@@ -70,6 +129,10 @@ func (u *invoiceUseCase) DeleteInvoice(ctx context.Context, projectID uuid.UUID,
 		return errors.New("invoice does not belong to the specified project")
 	}
 
+	if err := checkPostingDate(ctx, u.periodRepo, invoice.CreatedAt); err != nil {
+		return err
+	}
+
 	// Validate project status
 	err = u.invoiceRepo.ValidateProjectStatus(ctx, projectID)
 	if err != nil {
@@ -101,7 +164,9 @@ func (u *invoiceUseCase) GetProjectInvoices(ctx context.Context, projectID uuid.
 		response = append(response, responses.InvoiceResponse{
 			InvoiceID: invoice.InvoiceID,
 			ProjectID: invoice.ProjectID,
+			PhaseID:   nullUUIDPtr(invoice.PhaseID),
 			FileURL:   invoice.FileURL.String,
+			Amount:    invoice.Amount.Float64,
 			CreatedAt: invoice.CreatedAt,
 			UpdatedAt: invoice.UpdatedAt.Time,
 		})
@@ -109,3 +174,118 @@ func (u *invoiceUseCase) GetProjectInvoices(ctx context.Context, projectID uuid.
 
 	return response, nil
 }
+
+// GetPromptPayQR builds a scan-to-pay payload for the outstanding balance of
+// an invoice. A positive amount overrides the outstanding balance, e.g. for
+// partial payments.
+func (u *invoiceUseCase) GetPromptPayQR(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID, userID uuid.UUID, amount float64) (*responses.PromptPayQRResponse, error) {
+	invoice, err := u.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if invoice == nil {
+		return nil, errors.New("invoice not found")
+	}
+	if invoice.ProjectID != projectID {
+		return nil, errors.New("invoice does not belong to the specified project")
+	}
+
+	company, err := u.companyRepo.GetOrCreateCompanyByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if !company.PromptPayID.Valid || company.PromptPayID.String == "" {
+		return nil, errors.New("company promptpay ID is not configured")
+	}
+
+	if amount <= 0 {
+		if !invoice.Amount.Valid {
+			return nil, errors.New("invoice amount is not set")
+		}
+
+		paid, err := u.paymentRepo.GetTotalPaidByInvoiceID(ctx, invoiceID)
+		if err != nil {
+			return nil, err
+		}
+
+		amount = invoice.Amount.Float64 - paid
+		if amount <= 0 {
+			return nil, errors.New("invoice is already fully paid")
+		}
+	}
+
+	payload, err := buildPromptPayPayload(company.PromptPayID.String, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses.PromptPayQRResponse{
+		InvoiceID:   invoice.InvoiceID,
+		PromptPayID: company.PromptPayID.String,
+		Amount:      amount,
+		Payload:     payload,
+	}, nil
+}
+
+func (u *invoiceUseCase) GetVATReport(ctx context.Context, month string) (*responses.VATReportResponse, error) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	from, err := time.ParseInLocation("2006-01", month, bangkok)
+	if err != nil {
+		return nil, errors.New("invalid month")
+	}
+
+	rows, err := u.invoiceRepo.GetTaxRows(ctx, from, from.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := u.roundingRepo.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &responses.VATReportResponse{
+		Month: month,
+		Lines: []responses.VATReportLine{},
+	}
+	for _, row := range rows {
+		if !row.Amount.Valid {
+			continue
+		}
+
+		bases := models.QuotationTaxBases{
+			Standard:  row.Standard,
+			ZeroRated: row.ZeroRated,
+			Exempt:    row.Exempt,
+		}
+		parts, vat := splitPayable(row.Amount.Float64, row.TaxPercentage.Float64, bases, *policy)
+
+		line := responses.VATReportLine{
+			InvoiceID:   row.InvoiceID,
+			ProjectID:   row.ProjectID,
+			ProjectName: row.ProjectName,
+			ClientName:  row.ClientName,
+			ClientTaxID: row.ClientTaxID,
+			IssuedAt:    row.CreatedAt,
+			Standard:    parts.Standard,
+			ZeroRated:   parts.ZeroRated,
+			Exempt:      parts.Exempt,
+			VAT:         vat,
+			Total:       roundTo(row.Amount.Float64, 2),
+		}
+		report.Lines = append(report.Lines, line)
+
+		report.Standard += line.Standard
+		report.ZeroRated += line.ZeroRated
+		report.Exempt += line.Exempt
+		report.VAT += line.VAT
+		report.Total += line.Total
+	}
+
+	report.Standard = roundTo(report.Standard, 2)
+	report.ZeroRated = roundTo(report.ZeroRated, 2)
+	report.Exempt = roundTo(report.Exempt, 2)
+	report.VAT = roundTo(report.VAT, 2)
+	report.Total = roundTo(report.Total, 2)
+	return report, nil
+}
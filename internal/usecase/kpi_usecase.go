@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const kpiReportMaxMonths = 24
+
+type KPIUseCase interface {
+	SetTarget(ctx context.Context, userID uuid.UUID, req requests.SetKPITargetRequest) (*responses.KPITargetResponse, error)
+	ListTargets(ctx context.Context, year int) ([]responses.KPITargetResponse, error)
+	DeleteTarget(ctx context.Context, targetID uuid.UUID, userID uuid.UUID) error
+	GetReport(ctx context.Context, from, to string) (*responses.KPIReportResponse, error)
+}
+
+type kpiUseCase struct {
+	kpiRepo  repositories.KPIRepository
+	userRepo repositories.UserRepository
+}
+
+func NewKPIUsecase(
+	kpiRepo repositories.KPIRepository,
+	userRepo repositories.UserRepository,
+) KPIUseCase {
+	return &kpiUseCase{
+		kpiRepo:  kpiRepo,
+		userRepo: userRepo,
+	}
+}
+
+// SetTarget is limited to owners.
+func (u *kpiUseCase) SetTarget(ctx context.Context, userID uuid.UUID, req requests.SetKPITargetRequest) (*responses.KPITargetResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	metric := models.KPIMetric(req.Metric)
+	switch metric {
+	case models.KPIMetricSales:
+	case models.KPIMetricMargin, models.KPIMetricUtilization:
+		if req.Value > 100 {
+			return nil, errors.New("percentage targets must not exceed 100")
+		}
+	default:
+		return nil, errors.New("invalid KPI metric")
+	}
+	if req.Value < 0 {
+		return nil, errors.New("target value must not be negative")
+	}
+
+	month, err := time.Parse("2006-01", req.Month)
+	if err != nil {
+		return nil, errors.New("invalid month format, expected YYYY-MM")
+	}
+
+	target := &models.KPITarget{
+		TargetID:  uuid.New(),
+		Metric:    metric,
+		Month:     month,
+		Value:     req.Value,
+		CreatedAt: time.Now(),
+	}
+	if err := u.kpiRepo.UpsertTarget(ctx, target); err != nil {
+		return nil, err
+	}
+
+	response := toKPITargetResponse(target)
+	return &response, nil
+}
+
+func (u *kpiUseCase) ListTargets(ctx context.Context, year int) ([]responses.KPITargetResponse, error) {
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	targets, err := u.kpiRepo.ListTargets(ctx, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.KPITargetResponse, len(targets))
+	for i := range targets {
+		response[i] = toKPITargetResponse(&targets[i])
+	}
+
+	return response, nil
+}
+
+func (u *kpiUseCase) DeleteTarget(ctx context.Context, targetID uuid.UUID, userID uuid.UUID) error {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return err
+	}
+
+	return u.kpiRepo.DeleteTarget(ctx, targetID)
+}
+
+// GetReport covers the months from and to inclusive ("YYYY-MM"). It
+// defaults to the current year up to this month.
+func (u *kpiUseCase) GetReport(ctx context.Context, from, to string) (*responses.KPIReportResponse, error) {
+	now := time.Now()
+	start := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var err error
+	if from != "" {
+		if start, err = time.Parse("2006-01", from); err != nil {
+			return nil, errors.New("invalid month format, expected YYYY-MM")
+		}
+	}
+	if to != "" {
+		if end, err = time.Parse("2006-01", to); err != nil {
+			return nil, errors.New("invalid month format, expected YYYY-MM")
+		}
+	}
+	if end.Before(start) {
+		return nil, errors.New("from must not be after to")
+	}
+	if end.After(start.AddDate(0, kpiReportMaxMonths-1, 0)) {
+		return nil, errors.New("report range must not exceed 24 months")
+	}
+
+	// Both ranges are half-open, so move past the last month.
+	end = end.AddDate(0, 1, 0)
+
+	actuals, err := u.kpiRepo.GetMonthlyActuals(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := u.kpiRepo.ListTargets(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	targetFor := make(map[string]float64, len(targets))
+	for _, t := range targets {
+		targetFor[string(t.Metric)+t.Month.Format("2006-01")] = t.Value
+	}
+	attainment := func(metric models.KPIMetric, month string, actual float64) responses.KPIAttainment {
+		a := responses.KPIAttainment{Actual: actual}
+		if target, ok := targetFor[string(metric)+month]; ok {
+			a.Target = &target
+			if target > 0 {
+				percent := actual / target * 100
+				a.AttainmentPercent = &percent
+			}
+		}
+		return a
+	}
+
+	response := &responses.KPIReportResponse{
+		From:   start.Format("2006-01"),
+		To:     end.AddDate(0, -1, 0).Format("2006-01"),
+		Months: make([]responses.KPIMonthReport, len(actuals)),
+	}
+	for i, a := range actuals {
+		month := a.Month.Format("2006-01")
+
+		var margin, utilization float64
+		if a.Sales > 0 {
+			margin = (a.Sales - a.EstimatedCost) / a.Sales * 100
+		}
+		if a.CrewCapacityDays > 0 {
+			utilization = a.ScheduledCrewDays / a.CrewCapacityDays * 100
+		}
+
+		response.Months[i] = responses.KPIMonthReport{
+			Month:       month,
+			Contracts:   a.Contracts,
+			Sales:       attainment(models.KPIMetricSales, month, a.Sales),
+			Margin:      attainment(models.KPIMetricMargin, month, margin),
+			Utilization: attainment(models.KPIMetricUtilization, month, utilization),
+		}
+	}
+
+	return response, nil
+}
+
+func toKPITargetResponse(target *models.KPITarget) responses.KPITargetResponse {
+	return responses.KPITargetResponse{
+		TargetID: target.TargetID,
+		Metric:   target.Metric,
+		Month:    target.Month.Format("2006-01"),
+		Value:    target.Value,
+	}
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LateInterestUseCase interface {
+	GetTerms(ctx context.Context, projectID uuid.UUID) (*responses.LatePaymentTermsResponse, error)
+	UpdateTerms(ctx context.Context, projectID uuid.UUID, req requests.UpdateLatePaymentTermsRequest) (*responses.LatePaymentTermsResponse, error)
+
+	// RunAccruals accrues interest on overdue invoices for every day up to
+	// yesterday that hasn't been accrued yet. It is safe to run repeatedly.
+	RunAccruals(ctx context.Context) error
+	GetProjectInterest(ctx context.Context, projectID uuid.UUID) (*responses.ProjectLateInterestResponse, error)
+
+	// CreateDebitNote bills all of the project's unbilled interest on a
+	// debit note.
+	CreateDebitNote(ctx context.Context, userID uuid.UUID, projectID uuid.UUID) (*responses.LateInterestDebitNoteResponse, error)
+	ListDebitNotes(ctx context.Context, projectID uuid.UUID) ([]responses.LateInterestDebitNoteResponse, error)
+}
+
+type lateInterestUseCase struct {
+	interestRepo repositories.LateInterestRepository
+	projectRepo  repositories.ProjectRepository
+	paymentRepo  repositories.PaymentRepository
+	periodRepo   repositories.AccountingPeriodRepository
+}
+
+func NewLateInterestUsecase(
+	interestRepo repositories.LateInterestRepository,
+	projectRepo repositories.ProjectRepository,
+	paymentRepo repositories.PaymentRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+) LateInterestUseCase {
+	return &lateInterestUseCase{
+		interestRepo: interestRepo,
+		projectRepo:  projectRepo,
+		paymentRepo:  paymentRepo,
+		periodRepo:   periodRepo,
+	}
+}
+
+func (u *lateInterestUseCase) GetTerms(ctx context.Context, projectID uuid.UUID) (*responses.LatePaymentTermsResponse, error) {
+	terms, err := u.interestRepo.GetTerms(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toLatePaymentTermsResponse(terms)
+	return &response, nil
+}
+
+func (u *lateInterestUseCase) UpdateTerms(ctx context.Context, projectID uuid.UUID, req requests.UpdateLatePaymentTermsRequest) (*responses.LatePaymentTermsResponse, error) {
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if req.AnnualRatePercent < 0 || req.AnnualRatePercent > 100 {
+		return nil, errors.New("annual rate percent must be between 0 and 100")
+	}
+	if req.PaymentDays < 0 || req.GraceDays < 0 {
+		return nil, errors.New("payment and grace days must not be negative")
+	}
+
+	terms := models.LatePaymentTerms{
+		ProjectID:         projectID,
+		AnnualRatePercent: req.AnnualRatePercent,
+		PaymentDays:       req.PaymentDays,
+		GraceDays:         req.GraceDays,
+		UpdatedAt:         time.Now(),
+	}
+	if err := u.interestRepo.UpsertTerms(ctx, terms); err != nil {
+		return nil, err
+	}
+
+	response := toLatePaymentTermsResponse(&terms)
+	return &response, nil
+}
+
+func (u *lateInterestUseCase) RunAccruals(ctx context.Context) error {
+	through := models.CalendarDate(time.Now()).AddDate(0, 0, -1)
+
+	invoices, err := u.interestRepo.ListAccruableInvoices(ctx)
+	if err != nil {
+		return err
+	}
+
+	created := 0
+	for _, invoice := range invoices {
+		start := invoice.FirstInterestDate(invoice.CreatedAt)
+		if invoice.LastAccrualDate.Valid {
+			if next := invoice.LastAccrualDate.Time.AddDate(0, 0, 1); next.After(start) {
+				start = next
+			}
+		}
+		if start.After(through) {
+			continue
+		}
+
+		payments, err := u.paymentRepo.GetByInvoiceID(ctx, invoice.InvoiceID)
+		if err != nil {
+			return err
+		}
+
+		var accruals []models.LateInterestAccrual
+		now := time.Now()
+		for day := start; !day.After(through); day = day.AddDate(0, 0, 1) {
+			// A payment stops interest on what it covers from the day it
+			// is received.
+			outstanding := invoice.Amount
+			for _, payment := range payments {
+				if !models.CalendarDate(payment.PaidAt).After(day) {
+					outstanding -= payment.Amount
+				}
+			}
+			if outstanding <= 0 {
+				break
+			}
+
+			interest := invoice.DailyInterest(outstanding)
+			if interest <= 0 {
+				continue
+			}
+			accruals = append(accruals, models.LateInterestAccrual{
+				AccrualID:         uuid.New(),
+				InvoiceID:         invoice.InvoiceID,
+				ProjectID:         invoice.ProjectID,
+				AccrualDate:       day,
+				Outstanding:       roundTo(outstanding, 2),
+				AnnualRatePercent: invoice.AnnualRatePercent,
+				Amount:            interest,
+				CreatedAt:         now,
+			})
+		}
+
+		if err := u.interestRepo.CreateAccruals(ctx, accruals); err != nil {
+			return err
+		}
+		created += len(accruals)
+	}
+
+	if created > 0 {
+		log.Printf("late interest: accrued %d invoice-days through %s", created, through.Format("2006-01-02"))
+	}
+	return nil
+}
+
+func (u *lateInterestUseCase) GetProjectInterest(ctx context.Context, projectID uuid.UUID) (*responses.ProjectLateInterestResponse, error) {
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	response := &responses.ProjectLateInterestResponse{
+		ProjectID: projectID,
+		Invoices:  []responses.InvoiceLateInterestResponse{},
+	}
+
+	terms, err := u.interestRepo.GetTerms(ctx, projectID)
+	if err != nil && err.Error() != "late payment terms not found" {
+		return nil, err
+	}
+	if terms != nil {
+		termsResponse := toLatePaymentTermsResponse(terms)
+		response.Terms = &termsResponse
+	}
+
+	accruals, err := u.interestRepo.ListAccruals(ctx, projectID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	byInvoice := map[uuid.UUID]int{}
+	for _, accrual := range accruals {
+		i, ok := byInvoice[accrual.InvoiceID]
+		if !ok {
+			i = len(response.Invoices)
+			byInvoice[accrual.InvoiceID] = i
+			response.Invoices = append(response.Invoices, responses.InvoiceLateInterestResponse{
+				InvoiceID:    accrual.InvoiceID,
+				FirstAccrued: accrual.AccrualDate.Format("2006-01-02"),
+			})
+		}
+
+		line := &response.Invoices[i]
+		line.LastAccrued = accrual.AccrualDate.Format("2006-01-02")
+		line.Days++
+		line.Accrued = roundTo(line.Accrued+accrual.Amount, 2)
+		response.Accrued = roundTo(response.Accrued+accrual.Amount, 2)
+		if !accrual.Billed() {
+			line.Unbilled = roundTo(line.Unbilled+accrual.Amount, 2)
+			response.Unbilled = roundTo(response.Unbilled+accrual.Amount, 2)
+		}
+	}
+
+	return response, nil
+}
+
+func (u *lateInterestUseCase) CreateDebitNote(ctx context.Context, userID uuid.UUID, projectID uuid.UUID) (*responses.LateInterestDebitNoteResponse, error) {
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := checkPostingDate(ctx, u.periodRepo, now); err != nil {
+		return nil, err
+	}
+
+	accruals, err := u.interestRepo.ListAccruals(ctx, projectID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	note := models.LateInterestDebitNote{
+		DebitNoteID: uuid.New(),
+		ProjectID:   projectID,
+		CreatedBy:   userID,
+		CreatedAt:   now,
+	}
+	accrualIDs := make([]uuid.UUID, len(accruals))
+	for i, accrual := range accruals {
+		note.Amount += accrual.Amount
+		accrualIDs[i] = accrual.AccrualID
+	}
+	note.Amount = roundTo(note.Amount, 2)
+	if note.Amount <= 0 {
+		return nil, errors.New("no unbilled late interest")
+	}
+
+	if err := u.interestRepo.CreateDebitNote(ctx, note, accrualIDs); err != nil {
+		return nil, err
+	}
+
+	response := toLateInterestDebitNoteResponse(&note)
+	return &response, nil
+}
+
+func (u *lateInterestUseCase) ListDebitNotes(ctx context.Context, projectID uuid.UUID) ([]responses.LateInterestDebitNoteResponse, error) {
+	notes, err := u.interestRepo.ListDebitNotes(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.LateInterestDebitNoteResponse, len(notes))
+	for i := range notes {
+		result[i] = toLateInterestDebitNoteResponse(&notes[i])
+	}
+	return result, nil
+}
+
+func toLatePaymentTermsResponse(terms *models.LatePaymentTerms) responses.LatePaymentTermsResponse {
+	return responses.LatePaymentTermsResponse{
+		ProjectID:         terms.ProjectID,
+		AnnualRatePercent: terms.AnnualRatePercent,
+		PaymentDays:       terms.PaymentDays,
+		GraceDays:         terms.GraceDays,
+		UpdatedAt:         terms.UpdatedAt,
+	}
+}
+
+func toLateInterestDebitNoteResponse(note *models.LateInterestDebitNote) responses.LateInterestDebitNoteResponse {
+	return responses.LateInterestDebitNoteResponse{
+		DebitNoteID: note.DebitNoteID,
+		ProjectID:   note.ProjectID,
+		Amount:      note.Amount,
+		CreatedBy:   note.CreatedBy,
+		CreatedAt:   note.CreatedAt,
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Purposes of signed links. The purpose is part of the signature, so a
+// token issued for one kind of link is refused by every other kind even
+// though they share a secret.
+const (
+	linkPurposeHandover       = "handover-acknowledgement"
+	linkPurposeSurvey         = "survey-response"
+	linkPurposeInvitation     = "user-invitation"
+	linkPurposeEmailChange    = "email-change"
+	linkPurposeClientDocument = "client-document"
+)
+
+// linkSigner signs the tokens in links sent to people outside a session.
+// Tokens are "<id>.<expiry unix>.<signature>", where the signature is an
+// HMAC-SHA256 of the purpose, the id, the expiry and any bound values.
+// Bound values aren't in the token; the link stops working once they
+// change.
+type linkSigner struct {
+	secret  []byte
+	purpose string
+}
+
+func newLinkSigner(secret string, purpose string) linkSigner {
+	return linkSigner{secret: []byte(secret), purpose: purpose}
+}
+
+func (s linkSigner) sign(id uuid.UUID, expiresAt time.Time, bound ...string) string {
+	payload := id.String() + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(s.purpose + "\n" + payload))
+	for _, value := range bound {
+		mac.Write([]byte("\n" + value))
+	}
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parse returns the id and expiry of a well-formed, unexpired token
+// without checking its signature, for links whose bound values have to be
+// looked up by id first.
+func (s linkSigner) parse(token string) (uuid.UUID, time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, time.Time{}, false
+	}
+
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, time.Time{}, false
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expiry {
+		return uuid.Nil, time.Time{}, false
+	}
+	return id, time.Unix(expiry, 0), true
+}
+
+// signed reports whether token is the one sign gives for id, expiresAt
+// and bound.
+func (s linkSigner) signed(token string, id uuid.UUID, expiresAt time.Time, bound ...string) bool {
+	return hmac.Equal([]byte(token), []byte(s.sign(id, expiresAt, bound...)))
+}
+
+// verify returns the id of a valid, unexpired token with no bound values.
+func (s linkSigner) verify(token string) (uuid.UUID, bool) {
+	id, expiresAt, ok := s.parse(token)
+	if !ok || !s.signed(token, id, expiresAt) {
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const defaultMaintenanceMessage = "The system is temporarily read-only for maintenance. Please try again later."
+
+type MaintenanceUseCase interface {
+	Status() models.MaintenanceMode
+	Update(ctx context.Context, userID uuid.UUID, req requests.MaintenanceModeRequest) (models.MaintenanceMode, error)
+}
+
+// maintenanceUseCase keeps the switch in memory, so it applies to this
+// instance only and resets to the configured state on restart.
+type maintenanceUseCase struct {
+	userRepo repositories.UserRepository
+
+	mu   sync.RWMutex
+	mode models.MaintenanceMode
+}
+
+// NewMaintenanceUsecase starts in read-only mode when enabled is set, with
+// message shown to clients (a default is used when it is empty).
+func NewMaintenanceUsecase(userRepo repositories.UserRepository, enabled bool, message string) MaintenanceUseCase {
+	return &maintenanceUseCase{
+		userRepo: userRepo,
+		mode: models.MaintenanceMode{
+			Enabled:   enabled,
+			Message:   maintenanceMessage(message),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
+
+func (u *maintenanceUseCase) Status() models.MaintenanceMode {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.mode
+}
+
+func (u *maintenanceUseCase) Update(ctx context.Context, userID uuid.UUID, req requests.MaintenanceModeRequest) (models.MaintenanceMode, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return models.MaintenanceMode{}, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.mode = models.MaintenanceMode{
+		Enabled:   req.Enabled,
+		Message:   maintenanceMessage(req.Message),
+		UpdatedAt: time.Now(),
+		UpdatedBy: uuid.NullUUID{UUID: userID, Valid: true},
+	}
+	return u.mode, nil
+}
+
+func maintenanceMessage(message string) string {
+	if message = strings.TrimSpace(message); message != "" {
+		return message
+	}
+	return defaultMaintenanceMessage
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MaterialAliasUseCase interface {
+	Create(ctx context.Context, materialID string, req requests.CreateMaterialAliasRequest) (*responses.MaterialAliasResponse, error)
+	List(ctx context.Context, materialID string) ([]responses.MaterialAliasResponse, error)
+	// SetDisplay makes a Thai or English name the one documents in that
+	// language print instead of the material's own name.
+	SetDisplay(ctx context.Context, materialID string, aliasID uuid.UUID) (*responses.MaterialAliasResponse, error)
+	Delete(ctx context.Context, materialID string, aliasID uuid.UUID) error
+}
+
+type materialAliasUseCase struct {
+	aliasRepo    repositories.MaterialAliasRepository
+	materialRepo repositories.MaterialRepository
+	supplierRepo repositories.SupplierRepository
+}
+
+func NewMaterialAliasUsecase(
+	aliasRepo repositories.MaterialAliasRepository,
+	materialRepo repositories.MaterialRepository,
+	supplierRepo repositories.SupplierRepository,
+) MaterialAliasUseCase {
+	return &materialAliasUseCase{
+		aliasRepo:    aliasRepo,
+		materialRepo: materialRepo,
+		supplierRepo: supplierRepo,
+	}
+}
+
+func (u *materialAliasUseCase) Create(ctx context.Context, materialID string, req requests.CreateMaterialAliasRequest) (*responses.MaterialAliasResponse, error) {
+	if _, err := u.materialRepo.GetByID(ctx, materialID); err != nil {
+		return nil, err
+	}
+
+	alias := &models.MaterialAlias{
+		AliasID:    uuid.New(),
+		MaterialID: materialID,
+		Kind:       models.MaterialAliasKind(req.Kind),
+		Name:       strings.TrimSpace(req.Name),
+		Display:    req.Display,
+		CreatedAt:  time.Now(),
+	}
+	if !alias.Kind.Valid() {
+		return nil, errors.New("kind must be name or supplier_code")
+	}
+	if alias.Name == "" {
+		return nil, errors.New("alias name is required")
+	}
+
+	switch alias.Kind {
+	case models.MaterialAliasKindName:
+		if req.Language != "" {
+			if !models.DocumentLanguage(req.Language).Translatable() {
+				return nil, errors.New("language must be th or en")
+			}
+			alias.Language = sql.NullString{String: req.Language, Valid: true}
+		}
+		if req.SupplierID != nil {
+			return nil, errors.New("only supplier codes belong to a supplier")
+		}
+	case models.MaterialAliasKindSupplierCode:
+		if req.SupplierID == nil {
+			return nil, errors.New("supplier codes need a supplier")
+		}
+		if req.Language != "" {
+			return nil, errors.New("supplier codes have no language")
+		}
+		if _, err := u.supplierRepo.GetByID(ctx, *req.SupplierID); err != nil {
+			return nil, err
+		}
+		alias.SupplierID = uuid.NullUUID{UUID: *req.SupplierID, Valid: true}
+	}
+	if alias.Display && !alias.Language.Valid {
+		return nil, errors.New("only names in th or en can be displayed")
+	}
+
+	if err := u.aliasRepo.Create(ctx, alias); err != nil {
+		return nil, err
+	}
+
+	response := toMaterialAliasResponse(alias)
+	return &response, nil
+}
+
+func (u *materialAliasUseCase) List(ctx context.Context, materialID string) ([]responses.MaterialAliasResponse, error) {
+	if _, err := u.materialRepo.GetByID(ctx, materialID); err != nil {
+		return nil, err
+	}
+
+	aliases, err := u.aliasRepo.ListByMaterial(ctx, materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.MaterialAliasResponse, len(aliases))
+	for i := range aliases {
+		response[i] = toMaterialAliasResponse(&aliases[i])
+	}
+	return response, nil
+}
+
+func (u *materialAliasUseCase) SetDisplay(ctx context.Context, materialID string, aliasID uuid.UUID) (*responses.MaterialAliasResponse, error) {
+	alias, err := u.getAlias(ctx, materialID, aliasID)
+	if err != nil {
+		return nil, err
+	}
+	if alias.Kind != models.MaterialAliasKindName || !alias.Language.Valid {
+		return nil, errors.New("only names in th or en can be displayed")
+	}
+
+	if err := u.aliasRepo.SetDisplay(ctx, aliasID); err != nil {
+		return nil, err
+	}
+
+	alias.Display = true
+	response := toMaterialAliasResponse(alias)
+	return &response, nil
+}
+
+func (u *materialAliasUseCase) Delete(ctx context.Context, materialID string, aliasID uuid.UUID) error {
+	if _, err := u.getAlias(ctx, materialID, aliasID); err != nil {
+		return err
+	}
+	return u.aliasRepo.Delete(ctx, aliasID)
+}
+
+func (u *materialAliasUseCase) getAlias(ctx context.Context, materialID string, aliasID uuid.UUID) (*models.MaterialAlias, error) {
+	alias, err := u.aliasRepo.GetByID(ctx, aliasID)
+	if err != nil {
+		return nil, err
+	}
+	if alias.MaterialID != materialID {
+		return nil, errors.New("alias not found")
+	}
+	return alias, nil
+}
+
+func toMaterialAliasResponse(alias *models.MaterialAlias) responses.MaterialAliasResponse {
+	return responses.MaterialAliasResponse{
+		AliasID:    alias.AliasID,
+		MaterialID: alias.MaterialID,
+		Kind:       string(alias.Kind),
+		Name:       alias.Name,
+		Language:   alias.Language.String,
+		SupplierID: nullUUIDPtr(alias.SupplierID),
+		Display:    alias.Display,
+		CreatedAt:  alias.CreatedAt,
+	}
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MaterialListPriceUseCase interface {
+	Create(ctx context.Context, materialID string, req requests.CreateMaterialListPriceRequest) (*responses.MaterialListPriceResponse, error)
+	List(ctx context.Context, materialID string) ([]responses.MaterialListPriceResponse, error)
+	// Delete only removes prices that haven't taken effect yet, since
+	// drafts may already have been priced from the others.
+	Delete(ctx context.Context, materialID string, priceID uuid.UUID) error
+
+	// GetUpcomingImpacts lists drafts whose materials go up in price
+	// within the next days (30 by default).
+	GetUpcomingImpacts(ctx context.Context, days int) (*responses.UpcomingPriceImpactListResponse, error)
+}
+
+type materialListPriceUseCase struct {
+	listPriceRepo repositories.MaterialListPriceRepository
+	materialRepo  repositories.MaterialRepository
+}
+
+func NewMaterialListPriceUsecase(
+	listPriceRepo repositories.MaterialListPriceRepository,
+	materialRepo repositories.MaterialRepository,
+) MaterialListPriceUseCase {
+	return &materialListPriceUseCase{
+		listPriceRepo: listPriceRepo,
+		materialRepo:  materialRepo,
+	}
+}
+
+func (u *materialListPriceUseCase) Create(ctx context.Context, materialID string, req requests.CreateMaterialListPriceRequest) (*responses.MaterialListPriceResponse, error) {
+	if _, err := u.materialRepo.GetByID(ctx, materialID); err != nil {
+		return nil, err
+	}
+	if req.Price <= 0 {
+		return nil, errors.New("price must be greater than 0")
+	}
+	effectiveFrom, err := time.Parse("2006-01-02", strings.TrimSpace(req.EffectiveFrom))
+	if err != nil {
+		return nil, errors.New("invalid effective date format, expected YYYY-MM-DD")
+	}
+
+	note := strings.TrimSpace(req.Note)
+	price := &models.MaterialListPrice{
+		PriceID:       uuid.New(),
+		MaterialID:    materialID,
+		Price:         req.Price,
+		EffectiveFrom: effectiveFrom,
+		Note:          sql.NullString{String: note, Valid: note != ""},
+		CreatedAt:     time.Now(),
+	}
+	if err := u.listPriceRepo.Create(ctx, price); err != nil {
+		return nil, err
+	}
+
+	response := toMaterialListPriceResponse(price, false)
+	return &response, nil
+}
+
+func (u *materialListPriceUseCase) List(ctx context.Context, materialID string) ([]responses.MaterialListPriceResponse, error) {
+	if _, err := u.materialRepo.GetByID(ctx, materialID); err != nil {
+		return nil, err
+	}
+
+	prices, err := u.listPriceRepo.ListByMaterial(ctx, materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prices come latest first, so the first one already in effect is
+	// the current price.
+	today := currentDate()
+	current := false
+	result := make([]responses.MaterialListPriceResponse, len(prices))
+	for i := range prices {
+		isCurrent := !current && !prices[i].EffectiveFrom.After(today)
+		current = current || isCurrent
+		result[i] = toMaterialListPriceResponse(&prices[i], isCurrent)
+	}
+	return result, nil
+}
+
+func (u *materialListPriceUseCase) Delete(ctx context.Context, materialID string, priceID uuid.UUID) error {
+	price, err := u.listPriceRepo.GetByID(ctx, priceID)
+	if err != nil {
+		return err
+	}
+	if price.MaterialID != materialID {
+		return errors.New("list price not found")
+	}
+	if !price.EffectiveFrom.After(currentDate()) {
+		return errors.New("only future prices can be deleted")
+	}
+
+	return u.listPriceRepo.Delete(ctx, priceID)
+}
+
+func (u *materialListPriceUseCase) GetUpcomingImpacts(ctx context.Context, days int) (*responses.UpcomingPriceImpactListResponse, error) {
+	if days <= 0 {
+		days = 30
+	}
+	if days > 366 {
+		return nil, errors.New("days must not exceed 366")
+	}
+
+	from := currentDate()
+	to := from.AddDate(0, 0, days)
+	impacts, err := u.listPriceRepo.ListUpcomingImpacts(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows come grouped by draft.
+	response := &responses.UpcomingPriceImpactListResponse{
+		From:   from.Format("2006-01-02"),
+		To:     to.Format("2006-01-02"),
+		Drafts: []responses.UpcomingPriceImpactResponse{},
+	}
+	for _, impact := range impacts {
+		n := len(response.Drafts)
+		if n == 0 || response.Drafts[n-1].BOQID != impact.BOQID {
+			response.Drafts = append(response.Drafts, responses.UpcomingPriceImpactResponse{
+				ProjectID:   impact.ProjectID,
+				ProjectName: impact.ProjectName,
+				BOQID:       impact.BOQID,
+				QuotationID: nullUUIDPtr(impact.QuotationID),
+			})
+			n++
+		}
+
+		draft := &response.Drafts[n-1]
+		draft.TotalDelta += impact.Delta
+		draft.Materials = append(draft.Materials, responses.UpcomingPriceImpactItem{
+			MaterialID:     impact.MaterialID,
+			MaterialName:   impact.MaterialName,
+			Quantity:       impact.Quantity,
+			EstimatedPrice: impact.EstimatedPrice,
+			UpcomingPrice:  impact.UpcomingPrice,
+			EffectiveFrom:  impact.EffectiveFrom.Format("2006-01-02"),
+			Delta:          impact.Delta,
+		})
+	}
+
+	return response, nil
+}
+
+// currentDate is today at midnight UTC, matching how dates from requests
+// are parsed.
+func currentDate() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toMaterialListPriceResponse(price *models.MaterialListPrice, current bool) responses.MaterialListPriceResponse {
+	return responses.MaterialListPriceResponse{
+		PriceID:       price.PriceID,
+		MaterialID:    price.MaterialID,
+		Price:         price.Price,
+		EffectiveFrom: price.EffectiveFrom.Format("2006-01-02"),
+		Note:          price.Note.String,
+		Current:       current,
+		CreatedAt:     price.CreatedAt,
+	}
+}
@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MaterialReturnUseCase interface {
+	// Create returns rejected or surplus materials from a purchase order,
+	// takes them out of stock when a warehouse is given and expects a
+	// credit note for their cost.
+	Create(ctx context.Context, req requests.CreateMaterialReturnRequest) (*responses.MaterialReturnResponse, error)
+	GetByID(ctx context.Context, returnID uuid.UUID) (*responses.MaterialReturnResponse, error)
+	List(ctx context.Context, filter models.MaterialReturnFilter) ([]responses.MaterialReturnResponse, error)
+	// RecordCreditNote closes the return's expected credit.
+	RecordCreditNote(ctx context.Context, returnID uuid.UUID, req requests.RecordCreditNoteRequest) (*responses.MaterialReturnResponse, error)
+}
+
+type materialReturnUseCase struct {
+	returnRepo    repositories.MaterialReturnRepository
+	poRepo        repositories.PurchaseOrderRepository
+	inventoryRepo repositories.InventoryRepository
+	periodRepo    repositories.AccountingPeriodRepository
+	priceUpdates  BOQPriceUpdateUseCase
+	access        projectAccess
+}
+
+func NewMaterialReturnUsecase(
+	returnRepo repositories.MaterialReturnRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	inventoryRepo repositories.InventoryRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+	priceUpdates BOQPriceUpdateUseCase,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) MaterialReturnUseCase {
+	return &materialReturnUseCase{
+		returnRepo:    returnRepo,
+		poRepo:        poRepo,
+		inventoryRepo: inventoryRepo,
+		periodRepo:    periodRepo,
+		priceUpdates:  priceUpdates,
+		access:        projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *materialReturnUseCase) Create(ctx context.Context, req requests.CreateMaterialReturnRequest) (*responses.MaterialReturnResponse, error) {
+	returnNumber := strings.TrimSpace(req.ReturnNumber)
+	if returnNumber == "" {
+		return nil, errors.New("return number is required")
+	}
+	reason := models.MaterialReturnReason(req.Reason)
+	if !reason.Valid() {
+		return nil, errors.New("invalid return reason")
+	}
+	if len(req.Lines) == 0 {
+		return nil, errors.New("at least one line is required")
+	}
+
+	po, err := u.poRepo.GetByID(ctx, req.POID)
+	if err != nil {
+		return nil, err
+	}
+
+	returnDate := currentDate()
+	if date := strings.TrimSpace(req.ReturnDate); date != "" {
+		returnDate, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, errors.New("invalid return date format, expected YYYY-MM-DD")
+		}
+	}
+	if returnDate.Before(po.OrderDate) {
+		return nil, errors.New("return date cannot be before the order date")
+	}
+	if err := checkPostingDate(ctx, u.periodRepo, returnDate); err != nil {
+		return nil, err
+	}
+
+	var warehouse *models.Warehouse
+	if req.WarehouseID != nil {
+		if warehouse, err = u.inventoryRepo.GetWarehouse(ctx, *req.WarehouseID); err != nil {
+			return nil, err
+		}
+		if warehouse.Status != models.WarehouseStatusActive {
+			return nil, errors.New("warehouse is closed")
+		}
+	}
+
+	lines, err := u.poRepo.ListLines(ctx, po.POID)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := u.poRepo.ListAllocations(ctx, po.POID)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := u.poRepo.ListReceipts(ctx, po.POID)
+	if err != nil {
+		return nil, err
+	}
+	linesByID := map[uuid.UUID]models.PurchaseOrderLine{}
+	for _, l := range lines {
+		linesByID[l.LineID] = l
+	}
+	allocationsByID := map[uuid.UUID]*models.PurchaseOrderAllocation{}
+	for i := range allocations {
+		allocationsByID[allocations[i].AllocationID] = &allocations[i]
+	}
+	receiptsByID := map[uuid.UUID]models.PurchaseOrderReceipt{}
+	for _, r := range receipts {
+		receiptsByID[r.ReceiptID] = r
+	}
+
+	note := strings.TrimSpace(req.Note)
+	ret := models.MaterialReturn{
+		ReturnID:     uuid.New(),
+		ReturnNumber: returnNumber,
+		POID:         po.POID,
+		SupplierID:   po.SupplierID,
+		Reason:       reason,
+		ReturnDate:   returnDate,
+		Note:         sql.NullString{String: note, Valid: note != ""},
+		CreditStatus: models.CreditNoteStatusPending,
+		CreatedBy:    actorFromContext(ctx),
+		CreatedAt:    time.Now(),
+	}
+	if warehouse != nil {
+		ret.WarehouseID = uuid.NullUUID{UUID: warehouse.WarehouseID, Valid: true}
+	}
+
+	checked := map[uuid.UUID]bool{}
+	materials := map[string]bool{}
+	returnLines := make([]models.MaterialReturnLine, 0, len(req.Lines))
+	var movements []models.StockMovement
+	for _, l := range req.Lines {
+		allocation, ok := allocationsByID[l.AllocationID]
+		if !ok {
+			return nil, errors.New("allocation not found on this purchase order")
+		}
+		if l.Quantity <= 0 {
+			return nil, errors.New("quantity must be greater than 0")
+		}
+		if l.Quantity > allocation.Returnable()+0.00005 {
+			return nil, errors.New("quantity exceeds returnable quantity")
+		}
+		allocation.ReturnedQuantity += l.Quantity
+
+		line := models.MaterialReturnLine{
+			LineID:       uuid.New(),
+			ReturnID:     ret.ReturnID,
+			AllocationID: allocation.AllocationID,
+			ProjectID:    allocation.ProjectID,
+			MaterialID:   linesByID[allocation.LineID].MaterialID,
+			Quantity:     l.Quantity,
+			UnitPrice:    linesByID[allocation.LineID].UnitPrice,
+		}
+		if l.ReceiptID != nil {
+			receipt, ok := receiptsByID[*l.ReceiptID]
+			if !ok || receipt.AllocationID != allocation.AllocationID {
+				return nil, errors.New("receipt not found on this allocation")
+			}
+			line.ReceiptID = uuid.NullUUID{UUID: receipt.ReceiptID, Valid: true}
+		}
+		line.Amount = roundTo(line.Quantity*line.UnitPrice, 2)
+		ret.ExpectedCredit += line.Amount
+		returnLines = append(returnLines, line)
+		materials[line.MaterialID] = true
+
+		if !checked[allocation.ProjectID] {
+			if err := u.access.check(ctx, allocation.ProjectID); err != nil {
+				return nil, err
+			}
+			checked[allocation.ProjectID] = true
+		}
+
+		if warehouse != nil {
+			movements = append(movements, models.StockMovement{
+				MovementID:  uuid.New(),
+				WarehouseID: warehouse.WarehouseID,
+				MaterialID:  line.MaterialID,
+				Quantity:    -line.Quantity,
+				Type:        models.StockMovementSupplierReturn,
+				ProjectID:   uuid.NullUUID{UUID: line.ProjectID, Valid: true},
+				Reference:   sql.NullString{String: returnNumber, Valid: true},
+				Note:        ret.Note,
+				CreatedAt:   ret.CreatedAt,
+			})
+		}
+	}
+	ret.ExpectedCredit = roundTo(ret.ExpectedCredit, 2)
+
+	if err := u.returnRepo.Create(ctx, ret, returnLines, movements); err != nil {
+		return nil, err
+	}
+	for materialID := range materials {
+		u.priceUpdates.MaterialPriceChanged(materialID)
+	}
+	return u.GetByID(ctx, ret.ReturnID)
+}
+
+func (u *materialReturnUseCase) GetByID(ctx context.Context, returnID uuid.UUID) (*responses.MaterialReturnResponse, error) {
+	ret, err := u.returnRepo.GetByID(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := u.returnRepo.ListLines(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toMaterialReturnResponse(*ret)
+	response.Lines = make([]responses.MaterialReturnLineResponse, 0, len(lines))
+	for _, l := range lines {
+		response.Lines = append(response.Lines, responses.MaterialReturnLineResponse{
+			LineID:       l.LineID,
+			AllocationID: l.AllocationID,
+			ReceiptID:    nullUUIDPtr(l.ReceiptID),
+			ProjectID:    l.ProjectID,
+			MaterialID:   l.MaterialID,
+			Quantity:     l.Quantity,
+			UnitPrice:    l.UnitPrice,
+			Amount:       l.Amount,
+		})
+	}
+	return &response, nil
+}
+
+func (u *materialReturnUseCase) List(ctx context.Context, filter models.MaterialReturnFilter) ([]responses.MaterialReturnResponse, error) {
+	if filter.CreditStatus != "" && filter.CreditStatus != models.CreditNoteStatusPending && filter.CreditStatus != models.CreditNoteStatusReceived {
+		return nil, errors.New("invalid credit status")
+	}
+
+	returns, err := u.returnRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]responses.MaterialReturnResponse, 0, len(returns))
+	for _, r := range returns {
+		result = append(result, toMaterialReturnResponse(r))
+	}
+	return result, nil
+}
+
+func (u *materialReturnUseCase) RecordCreditNote(ctx context.Context, returnID uuid.UUID, req requests.RecordCreditNoteRequest) (*responses.MaterialReturnResponse, error) {
+	number := strings.TrimSpace(req.CreditNoteNumber)
+	if number == "" {
+		return nil, errors.New("credit note number is required")
+	}
+
+	ret, err := u.returnRepo.GetByID(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	if ret.CreditStatus == models.CreditNoteStatusReceived {
+		return nil, errors.New("credit note already received")
+	}
+
+	amount := ret.ExpectedCredit
+	if req.Amount != nil {
+		if *req.Amount <= 0 {
+			return nil, errors.New("amount must be greater than 0")
+		}
+		amount = roundTo(*req.Amount, 2)
+	}
+	date := currentDate()
+	if d := strings.TrimSpace(req.Date); d != "" {
+		date, err = time.Parse("2006-01-02", d)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+	if date.Before(ret.ReturnDate) {
+		return nil, errors.New("credit note date cannot be before the return date")
+	}
+
+	if err := u.returnRepo.RecordCredit(ctx, returnID, number, amount, date); err != nil {
+		return nil, err
+	}
+	return u.GetByID(ctx, returnID)
+}
+
+func toMaterialReturnResponse(r models.MaterialReturnSummary) responses.MaterialReturnResponse {
+	response := responses.MaterialReturnResponse{
+		ReturnID:         r.ReturnID,
+		ReturnNumber:     r.ReturnNumber,
+		POID:             r.POID,
+		PONumber:         r.PONumber,
+		SupplierID:       r.SupplierID,
+		SupplierName:     r.SupplierName,
+		WarehouseID:      nullUUIDPtr(r.WarehouseID),
+		Reason:           string(r.Reason),
+		ReturnDate:       r.ReturnDate.Format("2006-01-02"),
+		Note:             r.Note.String,
+		ExpectedCredit:   r.ExpectedCredit,
+		CreditStatus:     string(r.CreditStatus),
+		CreditNoteNumber: r.CreditNoteNumber.String,
+	}
+	if r.CreditAmount.Valid {
+		response.CreditAmount = &r.CreditAmount.Float64
+	}
+	if r.CreditDate.Valid {
+		response.CreditDate = r.CreditDate.Time.Format("2006-01-02")
+	}
+	return response
+}
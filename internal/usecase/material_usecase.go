@@ -9,6 +9,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -19,28 +21,47 @@ type MaterialUsecase interface {
 	Delete(ctx context.Context, materialID string) error
 	GetByID(ctx context.Context, materialID string) (*responses.MaterialResponse, error)
 	List(ctx context.Context) (*responses.MaterialListResponse, error)
+	// Search matches query against material IDs, names and aliases,
+	// returning at most limit results (20 by default).
+	Search(ctx context.Context, query string, limit int) ([]responses.MaterialSearchResponse, error)
 
 	GetMaterialPrices(ctx context.Context, projectID uuid.UUID) (*responses.MaterialPriceListResponse, error)
 	UpdateEstimatedPrice(ctx context.Context, boqID uuid.UUID, req requests.UpdateMaterialEstimatedPriceRequest) error
 	UpdateActualPrice(ctx context.Context, boqID uuid.UUID, req requests.UpdateMaterialActualPriceRequest) error
+
+	FindDuplicates(ctx context.Context, threshold float64) (*responses.DuplicateMaterialListResponse, error)
+	Merge(ctx context.Context, req requests.MergeMaterialRequest) error
 }
 
 type materialUsecase struct {
 	materialRepo repositories.MaterialRepository
 	supplierRepo repositories.SupplierRepository
+	priceUpdates BOQPriceUpdateUseCase
+	access       projectAccess
 }
 
 func NewMaterialUsecase(
 	materialRepo repositories.MaterialRepository,
 	supplierRepo repositories.SupplierRepository,
+	priceUpdates BOQPriceUpdateUseCase,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
 ) MaterialUsecase {
 	return &materialUsecase{
 		materialRepo: materialRepo,
 		supplierRepo: supplierRepo,
+		priceUpdates: priceUpdates,
+		access:       projectAccess{userRepo: userRepo, memberRepo: memberRepo},
 	}
 }
 
 func (u *materialUsecase) Create(ctx context.Context, req requests.CreateMaterialRequest) (*responses.MaterialResponse, error) {
+	if req.Classification == "" {
+		req.Classification = string(models.MaterialClassificationConsumable)
+	}
+	if !models.MaterialClassification(req.Classification).Valid() {
+		return nil, errors.New("invalid material classification")
+	}
 
 	material, err := u.materialRepo.Create(ctx, req)
 	if err != nil {
@@ -59,6 +80,13 @@ func (u *materialUsecase) Update(ctx context.Context, materialID string, req req
 		return errors.New("material not found")
 	}
 
+	if req.Classification == "" {
+		req.Classification = string(existing.Classification)
+	}
+	if !models.MaterialClassification(req.Classification).Valid() {
+		return errors.New("invalid material classification")
+	}
+
 	return u.materialRepo.Update(ctx, materialID, req)
 }
 
@@ -112,16 +140,50 @@ func (u *materialUsecase) List(ctx context.Context) (*responses.MaterialListResp
 
 }
 
+func (u *materialUsecase) Search(ctx context.Context, query string, limit int) ([]responses.MaterialSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query is required")
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		return nil, errors.New("limit must not exceed 100")
+	}
+
+	results, err := u.materialRepo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.MaterialSearchResponse, len(results))
+	for i, result := range results {
+		material, _ := u.createMaterialResponse(&result.Material)
+		response[i] = responses.MaterialSearchResponse{
+			MaterialResponse: *material,
+			MatchedName:      result.MatchedName,
+			MatchedKind:      result.MatchedKind,
+		}
+	}
+	return response, nil
+}
+
 func (u *materialUsecase) createMaterialResponse(material *models.Material) (*responses.MaterialResponse, error) {
 
 	return &responses.MaterialResponse{
-		MaterialID: material.MaterialID,
-		Name:       material.Name,
-		Unit:       material.Unit,
+		MaterialID:     material.MaterialID,
+		Name:           material.Name,
+		Unit:           material.Unit,
+		Classification: string(material.Classification),
 	}, nil
 }
 
 func (u *materialUsecase) GetMaterialPrices(ctx context.Context, projectID uuid.UUID) (*responses.MaterialPriceListResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
 	materials, err := u.materialRepo.GetMaterialPricesByProjectID(ctx, projectID)
 	if err != nil {
 		return nil, err
@@ -166,7 +228,12 @@ func (u *materialUsecase) UpdateEstimatedPrice(ctx context.Context, boqID uuid.U
 		return errors.New("estimated price must be greater than 0")
 	}
 
-	return u.materialRepo.UpdateEstimatedPrices(ctx, boqID, req.MaterialID, req.EstimatedPrice)
+	if err := u.materialRepo.UpdateEstimatedPrices(ctx, boqID, req.MaterialID, req.EstimatedPrice); err != nil {
+		return err
+	}
+
+	u.priceUpdates.BOQChanged(boqID)
+	return nil
 }
 
 func (u *materialUsecase) UpdateActualPrice(ctx context.Context, boqID uuid.UUID, req requests.UpdateMaterialActualPriceRequest) error {
@@ -205,5 +272,91 @@ func (u *materialUsecase) UpdateActualPrice(ctx context.Context, boqID uuid.UUID
 		return errors.New("actual price must be greater than 0")
 	}
 
-	return u.materialRepo.UpdateActualPrice(ctx, boqID, req)
+	// Materials can't be bought from suppliers that are blacklisted or suspended
+	supplier, err := u.supplierRepo.GetByID(ctx, req.SupplierID)
+	if err != nil {
+		return err
+	}
+
+	switch supplier.Status {
+	case models.SupplierStatusBlacklisted:
+		return errors.New("cannot order from a blacklisted supplier")
+	case models.SupplierStatusSuspended:
+		return errors.New("cannot order from a suspended supplier")
+	}
+
+	if err := u.materialRepo.UpdateActualPrice(ctx, boqID, req); err != nil {
+		return err
+	}
+
+	// Drafts that use the material are re-priced in the background.
+	u.priceUpdates.MaterialPriceChanged(req.MaterialID)
+	return nil
+}
+
+// FindDuplicates pairs up materials with the same unit whose names are at
+// least threshold similar (0-1).
+func (u *materialUsecase) FindDuplicates(ctx context.Context, threshold float64) (*responses.DuplicateMaterialListResponse, error) {
+	if threshold <= 0 || threshold > 1 {
+		threshold = 0.8
+	}
+
+	materials, err := u.materialRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list materials: %w", err)
+	}
+
+	duplicates := make([]responses.DuplicateMaterialPair, 0)
+	for i := 0; i < len(materials); i++ {
+		for j := i + 1; j < len(materials); j++ {
+			a, b := materials[i], materials[j]
+			if !strings.EqualFold(strings.TrimSpace(a.Unit), strings.TrimSpace(b.Unit)) {
+				continue
+			}
+
+			similarity := nameSimilarity(a.Name, b.Name)
+			if similarity < threshold {
+				continue
+			}
+
+			duplicates = append(duplicates, responses.DuplicateMaterialPair{
+				Material:   responses.MaterialResponse{MaterialID: a.MaterialID, Name: a.Name, Unit: a.Unit},
+				Duplicate:  responses.MaterialResponse{MaterialID: b.MaterialID, Name: b.Name, Unit: b.Unit},
+				Similarity: similarity,
+			})
+		}
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		return duplicates[i].Similarity > duplicates[j].Similarity
+	})
+
+	return &responses.DuplicateMaterialListResponse{
+		Threshold:  threshold,
+		Duplicates: duplicates,
+	}, nil
+}
+
+func (u *materialUsecase) Merge(ctx context.Context, req requests.MergeMaterialRequest) error {
+	if req.SourceMaterialID == "" || req.TargetMaterialID == "" {
+		return errors.New("source and target material are required")
+	}
+	if req.SourceMaterialID == req.TargetMaterialID {
+		return errors.New("cannot merge a material into itself")
+	}
+
+	source, err := u.materialRepo.GetByID(ctx, req.SourceMaterialID)
+	if err != nil {
+		return err
+	}
+	target, err := u.materialRepo.GetByID(ctx, req.TargetMaterialID)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(source.Unit), strings.TrimSpace(target.Unit)) {
+		return errors.New("cannot merge materials with different units")
+	}
+
+	return u.materialRepo.Merge(ctx, source.MaterialID, target.MaterialID)
 }
@@ -0,0 +1,415 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notifier tells people about workflow events on a project, through the
+// channels and to the roles the project's notification matrix names.
+type Notifier interface {
+	// Notify sends in the background so the action that raised the event
+	// isn't held up or failed by delivery; failures are logged.
+	Notify(projectID uuid.UUID, event models.NotificationEvent, title, body string)
+}
+
+type NotificationUseCase interface {
+	Notifier
+
+	GetDefaultMatrix(ctx context.Context, userID uuid.UUID) (*responses.NotificationMatrixResponse, error)
+	UpdateDefaultMatrix(ctx context.Context, userID uuid.UUID, req requests.UpdateNotificationMatrixRequest) (*responses.NotificationMatrixResponse, error)
+	GetProjectMatrix(ctx context.Context, userID, projectID uuid.UUID) (*responses.NotificationMatrixResponse, error)
+	UpdateProjectMatrix(ctx context.Context, userID, projectID uuid.UUID, req requests.UpdateNotificationMatrixRequest) (*responses.NotificationMatrixResponse, error)
+	// ResetProjectMatrix makes the project follow the defaults again.
+	ResetProjectMatrix(ctx context.Context, userID, projectID uuid.UUID) (*responses.NotificationMatrixResponse, error)
+
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool) (*responses.NotificationListResponse, error)
+	MarkRead(ctx context.Context, userID, notificationID uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+
+	// CheckOverdueInvoices notifies about invoices that have gone overdue
+	// since the last check.
+	CheckOverdueInvoices(ctx context.Context) error
+}
+
+type notificationUseCase struct {
+	notificationRepo repositories.NotificationRepository
+	projectRepo      repositories.ProjectRepository
+	userRepo         repositories.UserRepository
+	memberRepo       repositories.ProjectMemberRepository
+	mailer           repositories.Mailer
+	line             repositories.LineMessenger
+	invoiceTermsDays int
+}
+
+// NewNotificationUsecase takes the mailer and LINE messenger to deliver
+// through, either of which may be nil when it isn't configured, and the
+// payment terms for clients that have none of their own.
+func NewNotificationUsecase(
+	notificationRepo repositories.NotificationRepository,
+	projectRepo repositories.ProjectRepository,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+	mailer repositories.Mailer,
+	line repositories.LineMessenger,
+	invoiceTermsDays int,
+) NotificationUseCase {
+	return &notificationUseCase{
+		notificationRepo: notificationRepo,
+		projectRepo:      projectRepo,
+		userRepo:         userRepo,
+		memberRepo:       memberRepo,
+		mailer:           mailer,
+		line:             line,
+		invoiceTermsDays: invoiceTermsDays,
+	}
+}
+
+// notificationListLimit is how many notifications are listed at most.
+const notificationListLimit = 100
+
+func (u *notificationUseCase) GetDefaultMatrix(ctx context.Context, userID uuid.UUID) (*responses.NotificationMatrixResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	rules, err := u.notificationRepo.ListRules(ctx, uuid.NullUUID{})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toNotificationMatrixResponse(models.NewNotificationMatrix(rules))
+	return &response, nil
+}
+
+func (u *notificationUseCase) UpdateDefaultMatrix(ctx context.Context, userID uuid.UUID, req requests.UpdateNotificationMatrixRequest) (*responses.NotificationMatrixResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	rules, err := toNotificationRules(uuid.NullUUID{}, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.notificationRepo.SetRules(ctx, uuid.NullUUID{}, rules); err != nil {
+		return nil, err
+	}
+
+	return u.GetDefaultMatrix(ctx, userID)
+}
+
+func (u *notificationUseCase) GetProjectMatrix(ctx context.Context, userID, projectID uuid.UUID) (*responses.NotificationMatrixResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return u.projectMatrixResponse(ctx, projectID)
+}
+
+func (u *notificationUseCase) UpdateProjectMatrix(ctx context.Context, userID, projectID uuid.UUID, req requests.UpdateNotificationMatrixRequest) (*responses.NotificationMatrixResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	project := uuid.NullUUID{UUID: projectID, Valid: true}
+	rules, err := toNotificationRules(project, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.notificationRepo.SetRules(ctx, project, rules); err != nil {
+		return nil, err
+	}
+
+	return u.projectMatrixResponse(ctx, projectID)
+}
+
+func (u *notificationUseCase) ResetProjectMatrix(ctx context.Context, userID, projectID uuid.UUID) (*responses.NotificationMatrixResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	if err := u.notificationRepo.ResetRules(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return u.projectMatrixResponse(ctx, projectID)
+}
+
+func (u *notificationUseCase) List(ctx context.Context, userID uuid.UUID, unreadOnly bool) (*responses.NotificationListResponse, error) {
+	notifications, err := u.notificationRepo.ListByUser(ctx, userID, unreadOnly, notificationListLimit)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := u.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.NotificationListResponse{
+		Notifications: make([]responses.NotificationResponse, len(notifications)),
+		Unread:        unread,
+	}
+	for i := range notifications {
+		response.Notifications[i] = toNotificationResponse(&notifications[i])
+	}
+	return response, nil
+}
+
+func (u *notificationUseCase) MarkRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	return u.notificationRepo.MarkRead(ctx, userID, notificationID)
+}
+
+func (u *notificationUseCase) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return u.notificationRepo.MarkAllRead(ctx, userID)
+}
+
+func (u *notificationUseCase) Notify(projectID uuid.UUID, event models.NotificationEvent, title, body string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		if err := u.deliver(ctx, projectID, event, title, body); err != nil {
+			log.Printf("failed to send %s notification for project %s: %v", event, projectID, err)
+		}
+	}()
+}
+
+func (u *notificationUseCase) CheckOverdueInvoices(ctx context.Context) error {
+	invoices, err := u.notificationRepo.ClaimOverdueInvoices(ctx, u.invoiceTermsDays)
+	if err != nil {
+		return err
+	}
+
+	for _, invoice := range invoices {
+		body := fmt.Sprintf("An invoice to %s for %.2f was due on %s and %.2f is still unpaid.",
+			invoice.ClientName, invoice.Amount, invoice.DueDate.Format("2006-01-02"), invoice.Outstanding)
+		if err := u.deliver(ctx, invoice.ProjectID, models.NotificationEventInvoiceOverdue, "Invoice overdue", body); err != nil {
+			log.Printf("failed to send overdue notification for invoice %s: %v", invoice.InvoiceID, err)
+		}
+	}
+	return nil
+}
+
+// deliver sends to every active user on the project whose role the matrix
+// names for a channel, skipping channels the user has opted out of or has
+// no address for. Project members are included by their role; owners and
+// admins see every project, so they are included whether or not they are
+// members.
+func (u *notificationUseCase) deliver(ctx context.Context, projectID uuid.UUID, event models.NotificationEvent, title, body string) error {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	matrix, err := u.projectMatrix(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	recipients, err := u.recipients(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%s - %s", title, project.Name)
+	now := time.Now()
+	var inApp []models.Notification
+	for _, user := range recipients {
+		preferences, err := u.preferences(ctx, user.UserID)
+		if err != nil {
+			log.Printf("failed to read notification preferences of %s: %v", user.UserID, err)
+			continue
+		}
+
+		for _, channel := range models.NotificationChannels {
+			if !matrix.Notifies(event, channel, user.Role) {
+				continue
+			}
+			if len(preferences.NotificationChannels) > 0 && !slices.Contains(preferences.NotificationChannels, string(channel)) {
+				continue
+			}
+
+			switch channel {
+			case models.NotificationChannelEmail:
+				if u.mailer == nil || !user.Email.Valid {
+					continue
+				}
+				if err := u.mailer.Send(ctx, user.Email.String, subject, body); err != nil {
+					log.Printf("failed to email %s notification to %s: %v", event, user.UserID, err)
+				}
+			case models.NotificationChannelLine:
+				if u.line == nil || preferences.LineUserID == "" {
+					continue
+				}
+				if err := u.line.Push(ctx, preferences.LineUserID, subject+"\n"+body); err != nil {
+					log.Printf("failed to send %s notification to %s on LINE: %v", event, user.UserID, err)
+				}
+			case models.NotificationChannelInApp:
+				inApp = append(inApp, models.Notification{
+					NotificationID: uuid.New(),
+					UserID:         user.UserID,
+					ProjectID:      uuid.NullUUID{UUID: projectID, Valid: true},
+					Event:          event,
+					Title:          subject,
+					Body:           body,
+					CreatedAt:      now,
+				})
+			}
+		}
+	}
+
+	return u.notificationRepo.Create(ctx, inApp)
+}
+
+func (u *notificationUseCase) recipients(ctx context.Context, projectID uuid.UUID) ([]models.User, error) {
+	members, err := u.memberRepo.ListByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := true
+	seen := map[uuid.UUID]bool{}
+	var recipients []models.User
+	for _, role := range []models.UserRole{models.UserRoleOwner, models.UserRoleAdmin} {
+		admins, _, err := u.userRepo.List(ctx, models.UserFilter{Role: role, Active: &active}, 1000, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, admin := range admins {
+			seen[admin.UserID] = true
+			recipients = append(recipients, admin)
+		}
+	}
+
+	for _, member := range members {
+		if seen[member.UserID] || !member.IsActive {
+			continue
+		}
+		user, err := u.userRepo.GetByID(ctx, member.UserID)
+		if err != nil {
+			return nil, err
+		}
+		seen[user.UserID] = true
+		recipients = append(recipients, *user)
+	}
+	return recipients, nil
+}
+
+func (u *notificationUseCase) preferences(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error) {
+	preferences := &models.UserPreferences{}
+	raw, err := u.userRepo.GetPreferences(ctx, userID)
+	if err != nil || raw == nil {
+		return preferences, err
+	}
+	if err := json.Unmarshal(raw, preferences); err != nil {
+		return nil, fmt.Errorf("failed to decode preferences: %w", err)
+	}
+	return preferences, nil
+}
+
+func (u *notificationUseCase) projectMatrix(ctx context.Context, projectID uuid.UUID) (models.NotificationMatrix, error) {
+	defaults, err := u.notificationRepo.ListRules(ctx, uuid.NullUUID{})
+	if err != nil {
+		return nil, err
+	}
+	own, err := u.notificationRepo.ListRules(ctx, uuid.NullUUID{UUID: projectID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return models.NewNotificationMatrix(defaults, own), nil
+}
+
+func (u *notificationUseCase) projectMatrixResponse(ctx context.Context, projectID uuid.UUID) (*responses.NotificationMatrixResponse, error) {
+	matrix, err := u.projectMatrix(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	own, err := u.notificationRepo.ListRules(ctx, uuid.NullUUID{UUID: projectID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toNotificationMatrixResponse(matrix)
+	response.ProjectID = &projectID
+	response.Customized = len(own) > 0
+	return &response, nil
+}
+
+func toNotificationRules(projectID uuid.NullUUID, req requests.UpdateNotificationMatrixRequest) ([]models.NotificationRule, error) {
+	now := time.Now()
+	rules := make([]models.NotificationRule, 0, len(req.Rules))
+	for _, entry := range req.Rules {
+		event := models.NotificationEvent(entry.Event)
+		if !event.Valid() {
+			return nil, errors.New("invalid notification event")
+		}
+		channel := models.NotificationChannel(entry.Channel)
+		if !channel.Valid() {
+			return nil, errors.New("invalid notification channel")
+		}
+		role := models.UserRole(entry.Role)
+		if !role.Valid() {
+			return nil, errors.New("invalid role")
+		}
+		rules = append(rules, models.NotificationRule{
+			ProjectID: projectID,
+			Event:     event,
+			Channel:   channel,
+			Role:      role,
+			Enabled:   entry.Enabled,
+			UpdatedAt: now,
+		})
+	}
+	return rules, nil
+}
+
+func toNotificationMatrixResponse(matrix models.NotificationMatrix) responses.NotificationMatrixResponse {
+	response := responses.NotificationMatrixResponse{
+		Matrix: map[string]map[string]map[string]bool{},
+	}
+	for _, event := range models.NotificationEvents {
+		channels := map[string]map[string]bool{}
+		for _, channel := range models.NotificationChannels {
+			roles := map[string]bool{}
+			for _, role := range models.UserRoles {
+				roles[string(role)] = matrix.Notifies(event, channel, role)
+			}
+			channels[string(channel)] = roles
+		}
+		response.Matrix[string(event)] = channels
+	}
+	return response
+}
+
+func toNotificationResponse(notification *models.Notification) responses.NotificationResponse {
+	response := responses.NotificationResponse{
+		NotificationID: notification.NotificationID,
+		ProjectID:      nullUUIDPtr(notification.ProjectID),
+		Event:          string(notification.Event),
+		Title:          notification.Title,
+		Body:           notification.Body,
+		CreatedAt:      notification.CreatedAt,
+	}
+	if notification.ReadAt.Valid {
+		response.ReadAt = &notification.ReadAt.Time
+	}
+	return response
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentUseCase interface {
+	RecordPayment(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID, req requests.RecordPaymentRequest) (*responses.ReceiptResponse, error)
+	GetInvoicePayments(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID) ([]responses.PaymentResponse, error)
+	// ExportReceipt returns the receipt with its labels in language, worded
+	// as userID's company has set them.
+	ExportReceipt(ctx context.Context, receiptID uuid.UUID, language string, userID uuid.UUID) (*responses.ReceiptExportData, error)
+}
+
+type paymentUseCase struct {
+	paymentRepo  repositories.PaymentRepository
+	invoiceRepo  repositories.InvoiceRepository
+	periodRepo   repositories.AccountingPeriodRepository
+	labelUseCase DocumentLabelUseCase
+}
+
+func NewPaymentUsecase(
+	paymentRepo repositories.PaymentRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+	labelUseCase DocumentLabelUseCase,
+) PaymentUseCase {
+	return &paymentUseCase{
+		paymentRepo:  paymentRepo,
+		invoiceRepo:  invoiceRepo,
+		periodRepo:   periodRepo,
+		labelUseCase: labelUseCase,
+	}
+}
+
+func (u *paymentUseCase) RecordPayment(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID, req requests.RecordPaymentRequest) (*responses.ReceiptResponse, error) {
+	if err := u.validateInvoice(ctx, projectID, invoiceID); err != nil {
+		return nil, err
+	}
+
+	if req.Amount <= 0 {
+		return nil, errors.New("payment amount must be greater than 0")
+	}
+
+	if !isValidPaymentMethod(req.Method) {
+		return nil, errors.New("invalid payment method")
+	}
+
+	paidAt := time.Now()
+	if req.PaidAt != nil {
+		paidAt = *req.PaidAt
+	}
+	if err := checkPostingDate(ctx, u.periodRepo, paidAt); err != nil {
+		return nil, err
+	}
+
+	payment, receipt, err := u.paymentRepo.Create(ctx, invoiceID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	paymentResponse := toPaymentResponse(payment, receipt)
+
+	return &responses.ReceiptResponse{
+		ReceiptID:     receipt.ReceiptID,
+		ReceiptNumber: receipt.ReceiptNumber,
+		Payment:       paymentResponse,
+		CreatedAt:     receipt.CreatedAt,
+	}, nil
+}
+
+func (u *paymentUseCase) GetInvoicePayments(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID) ([]responses.PaymentResponse, error) {
+	if err := u.validateInvoice(ctx, projectID, invoiceID); err != nil {
+		return nil, err
+	}
+
+	payments, err := u.paymentRepo.GetByInvoiceID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.PaymentResponse, 0, len(payments))
+	for i := range payments {
+		receipt, err := u.paymentRepo.GetReceiptByPaymentID(ctx, payments[i].PaymentID)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, toPaymentResponse(&payments[i], receipt))
+	}
+
+	return response, nil
+}
+
+func (u *paymentUseCase) ExportReceipt(ctx context.Context, receiptID uuid.UUID, language string, userID uuid.UUID) (*responses.ReceiptExportData, error) {
+	format, err := u.labelUseCase.Resolve(ctx, language, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := u.paymentRepo.GetReceiptExportData(ctx, receiptID)
+	if err != nil {
+		return nil, err
+	}
+	data.Language = string(format.Language)
+	data.Labels = format.Labels
+	data.DateEra = string(format.DateEra)
+	data.Dates = documentDates(format.DateEra, map[string]time.Time{
+		"issued_at": data.IssuedAt,
+		"paid_at":   data.PaidAt,
+	})
+	return data, nil
+}
+
+func (u *paymentUseCase) validateInvoice(ctx context.Context, projectID uuid.UUID, invoiceID uuid.UUID) error {
+	invoice, err := u.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if invoice == nil {
+		return errors.New("invoice not found")
+	}
+	if invoice.ProjectID != projectID {
+		return errors.New("invoice does not belong to the specified project")
+	}
+	return nil
+}
+
+func isValidPaymentMethod(method models.PaymentMethod) bool {
+	switch method {
+	case models.PaymentMethodCash,
+		models.PaymentMethodBankTransfer,
+		models.PaymentMethodCheque,
+		models.PaymentMethodPromptPay,
+		models.PaymentMethodCreditCard:
+		return true
+	}
+	return false
+}
+
+func toPaymentResponse(payment *models.Payment, receipt *models.Receipt) responses.PaymentResponse {
+	response := responses.PaymentResponse{
+		PaymentID: payment.PaymentID,
+		InvoiceID: payment.InvoiceID,
+		Amount:    payment.Amount,
+		Method:    payment.Method,
+		Reference: payment.Reference.String,
+		Note:      payment.Note.String,
+		PaidAt:    payment.PaidAt,
+	}
+
+	if receipt != nil {
+		receiptID := receipt.ReceiptID
+		response.ReceiptID = &receiptID
+		response.ReceiptNumber = receipt.ReceiptNumber
+	}
+
+	return response
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+type PaymentWebhookUseCase interface {
+	HandleOmise(ctx context.Context, body []byte, signature string, timestamp string) error
+	Handle2C2P(ctx context.Context, req requests.TwoC2PWebhookRequest) error
+}
+
+type paymentWebhookUseCase struct {
+	paymentRepo  repositories.PaymentRepository
+	invoiceRepo  repositories.InvoiceRepository
+	periodRepo   repositories.AccountingPeriodRepository
+	notifier     Notifier
+	omiseSecret  string
+	twoC2PSecret []byte
+}
+
+func NewPaymentWebhookUsecase(
+	paymentRepo repositories.PaymentRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+	notifier Notifier,
+	omiseSecret string,
+	twoC2PSecret string,
+) PaymentWebhookUseCase {
+	return &paymentWebhookUseCase{
+		paymentRepo:  paymentRepo,
+		invoiceRepo:  invoiceRepo,
+		periodRepo:   periodRepo,
+		notifier:     notifier,
+		omiseSecret:  omiseSecret,
+		twoC2PSecret: []byte(twoC2PSecret),
+	}
+}
+
+// gatewayPayment is the provider-neutral result of a verified notification.
+type gatewayPayment struct {
+	InvoiceID uuid.UUID
+	Amount    float64
+	Currency  string
+	Method    models.PaymentMethod
+	Reference string
+}
+
+// gatewayCurrency is the only currency invoices are issued in.
+const gatewayCurrency = "THB"
+
+func (u *paymentWebhookUseCase) HandleOmise(ctx context.Context, body []byte, signature string, timestamp string) error {
+	if err := u.verifyOmiseSignature(body, signature, timestamp); err != nil {
+		return err
+	}
+
+	var event requests.OmiseWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return errors.New("invalid webhook payload")
+	}
+
+	// Only settled charges become payments; every other event is acknowledged
+	// so the gateway stops retrying it.
+	if event.Key != "charge.complete" || event.Data.Status != "successful" {
+		return nil
+	}
+
+	invoiceID, err := uuid.Parse(event.Data.Metadata["invoice_id"])
+	if err != nil {
+		return errors.New("payment reference does not match any invoice")
+	}
+	if event.Data.ID == "" {
+		return errors.New("invalid webhook payload")
+	}
+
+	method := models.PaymentMethodCreditCard
+	if event.Data.Source != nil && event.Data.Source.Type == "promptpay" {
+		method = models.PaymentMethodPromptPay
+	}
+
+	return u.recordGatewayPayment(ctx, gatewayPayment{
+		InvoiceID: invoiceID,
+		Amount:    float64(event.Data.Amount) / 100, // Omise amounts are in satang
+		Currency:  event.Data.Currency,
+		Method:    method,
+		Reference: "omise:" + event.Data.ID,
+	})
+}
+
+func (u *paymentWebhookUseCase) Handle2C2P(ctx context.Context, req requests.TwoC2PWebhookRequest) error {
+	if len(u.twoC2PSecret) == 0 {
+		return errors.New("webhook secret is not configured")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(req.Payload, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return u.twoC2PSecret, nil
+	})
+	if err != nil {
+		return errors.New("invalid webhook signature")
+	}
+
+	// "0000" is the 2C2P response code for a successful transaction.
+	if respCode, _ := claims["respCode"].(string); respCode != "0000" {
+		return nil
+	}
+
+	invoiceNo, _ := claims["invoiceNo"].(string)
+	invoiceID, err := uuid.Parse(invoiceNo)
+	if err != nil {
+		return errors.New("payment reference does not match any invoice")
+	}
+
+	amount, err := claimFloat(claims["amount"])
+	if err != nil {
+		return errors.New("invalid webhook payload")
+	}
+
+	method := models.PaymentMethodCreditCard
+	if channel, _ := claims["channelCode"].(string); strings.Contains(strings.ToUpper(channel), "QR") {
+		method = models.PaymentMethodPromptPay
+	}
+
+	// The transaction reference is what recognises a repeated
+	// notification, so one without it can't be recorded safely.
+	tranRef, _ := claims["tranRef"].(string)
+	if tranRef == "" {
+		return errors.New("invalid webhook payload")
+	}
+	currency, _ := claims["currencyCode"].(string)
+
+	return u.recordGatewayPayment(ctx, gatewayPayment{
+		InvoiceID: invoiceID,
+		Amount:    amount,
+		Currency:  currency,
+		Method:    method,
+		Reference: "2c2p:" + tranRef,
+	})
+}
+
+func (u *paymentWebhookUseCase) recordGatewayPayment(ctx context.Context, p gatewayPayment) error {
+	invoice, err := u.invoiceRepo.GetByID(ctx, p.InvoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if invoice == nil {
+		return errors.New("payment reference does not match any invoice")
+	}
+
+	if p.Amount <= 0 {
+		return errors.New("payment amount must be greater than 0")
+	}
+	if !strings.EqualFold(p.Currency, gatewayCurrency) {
+		return errors.New("payment currency must be THB")
+	}
+
+	// The client has already been charged, so a payment over what is owed
+	// is recorded but flagged for someone to refund or reallocate.
+	var excess float64
+	if invoice.Amount.Valid {
+		paid, err := u.paymentRepo.GetTotalPaidByInvoiceID(ctx, invoice.InvoiceID)
+		if err != nil {
+			return err
+		}
+		excess = roundTo(p.Amount-(invoice.Amount.Float64-paid), 2)
+	}
+	note := "Recorded from payment gateway notification"
+	if excess > 0 {
+		note = fmt.Sprintf("%s; exceeds the outstanding balance by %.2f", note, excess)
+	}
+
+	// The gateway would keep retrying a refused notification, so a payment
+	// landing in a locked period is posted to the next open one instead.
+	paidAt, err := openPostingDate(ctx, u.periodRepo, time.Now())
+	if err != nil {
+		return err
+	}
+	// Gateways deliver notifications at least once, sometimes concurrently,
+	// so a transaction we have already recorded is treated as success.
+	payment, receipt, err := u.paymentRepo.CreateOnce(ctx, invoice.InvoiceID, requests.RecordPaymentRequest{
+		Amount:    p.Amount,
+		Method:    p.Method,
+		Reference: p.Reference,
+		Note:      note,
+		PaidAt:    &paidAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record payment: %w", err)
+	}
+	if payment == nil {
+		return nil
+	}
+
+	body := fmt.Sprintf("A payment of %.2f was received through the payment gateway and receipt %s was issued.",
+		p.Amount, receipt.ReceiptNumber)
+	if excess > 0 {
+		body += fmt.Sprintf(" It exceeds the outstanding balance by %.2f and needs refunding or reallocating.", excess)
+	}
+	u.notifier.Notify(invoice.ProjectID, models.NotificationEventPaymentReceived, "Payment received", body)
+
+	return nil
+}
+
+// verifyOmiseSignature checks the Omise-Signature header, an HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the base64 decoded webhook secret. The
+// header may carry several comma separated signatures during key rotation.
+func (u *paymentWebhookUseCase) verifyOmiseSignature(body []byte, signature string, timestamp string) error {
+	if u.omiseSecret == "" {
+		return errors.New("webhook secret is not configured")
+	}
+	if signature == "" || timestamp == "" {
+		return errors.New("invalid webhook signature")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid webhook signature")
+	}
+	if time.Since(time.Unix(ts, 0)).Abs() > 5*time.Minute {
+		return errors.New("webhook timestamp is too old")
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(u.omiseSecret)
+	if err != nil {
+		return fmt.Errorf("invalid webhook secret: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, candidate := range strings.Split(signature, ",") {
+		decoded, err := hex.DecodeString(strings.TrimSpace(candidate))
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+
+	return errors.New("invalid webhook signature")
+}
+
+func claimFloat(v interface{}) (float64, error) {
+	switch value := v.(type) {
+	case float64:
+		return value, nil
+	case string:
+		return strconv.ParseFloat(value, 64)
+	}
+	return 0, errors.New("unsupported claim type")
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PermissionUseCase interface {
+	GetMatrix(ctx context.Context, userID uuid.UUID) ([]responses.RolePermissionsResponse, error)
+	UpdateRolePermissions(ctx context.Context, userID uuid.UUID, role string, req requests.UpdateRolePermissionsRequest) (*responses.RolePermissionsResponse, error)
+	// Check returns "permission denied" when the user's role may not
+	// perform action on resource, and "password change required" while an
+	// admin is making the user choose a new password.
+	Check(ctx context.Context, userID uuid.UUID, resource models.PermissionResource, action models.PermissionAction) error
+}
+
+type permissionUseCase struct {
+	permissionRepo repositories.PermissionRepository
+	userRepo       repositories.UserRepository
+	cacheTTL       time.Duration
+
+	mu       sync.Mutex
+	cached   models.PermissionMatrix
+	cachedAt time.Time
+}
+
+// NewPermissionUsecase caches the permission matrix for cacheTTL. Updates
+// through this usecase take effect immediately; edits made directly in the
+// database are picked up when the cache expires.
+func NewPermissionUsecase(
+	permissionRepo repositories.PermissionRepository,
+	userRepo repositories.UserRepository,
+	cacheTTL time.Duration,
+) PermissionUseCase {
+	return &permissionUseCase{
+		permissionRepo: permissionRepo,
+		userRepo:       userRepo,
+		cacheTTL:       cacheTTL,
+	}
+}
+
+func (u *permissionUseCase) GetMatrix(ctx context.Context, userID uuid.UUID) ([]responses.RolePermissionsResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	matrix, err := u.matrix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []responses.RolePermissionsResponse{}
+	for _, role := range models.UserRoles {
+		result = append(result, toRolePermissionsResponse(matrix, role))
+	}
+	return result, nil
+}
+
+func (u *permissionUseCase) UpdateRolePermissions(ctx context.Context, userID uuid.UUID, role string, req requests.UpdateRolePermissionsRequest) (*responses.RolePermissionsResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	userRole := models.UserRole(role)
+	if !userRole.Valid() {
+		return nil, errors.New("invalid role")
+	}
+	if userRole.IsAdmin() {
+		return nil, errors.New("owner permissions cannot be changed")
+	}
+
+	now := time.Now()
+	permissions := make([]models.RolePermission, 0, len(req.Permissions))
+	for _, entry := range req.Permissions {
+		resource := models.PermissionResource(entry.Resource)
+		if !resource.Valid() {
+			return nil, errors.New("invalid permission resource")
+		}
+		action := models.PermissionAction(entry.Action)
+		if !action.Valid() {
+			return nil, errors.New("invalid permission action")
+		}
+		permissions = append(permissions, models.RolePermission{
+			Role:      userRole,
+			Resource:  resource,
+			Action:    action,
+			Allowed:   entry.Allowed,
+			UpdatedAt: now,
+		})
+	}
+
+	if err := u.permissionRepo.SetRolePermissions(ctx, permissions); err != nil {
+		return nil, err
+	}
+	u.invalidate()
+
+	matrix, err := u.matrix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := toRolePermissionsResponse(matrix, userRole)
+	return &response, nil
+}
+
+func (u *permissionUseCase) Check(ctx context.Context, userID uuid.UUID, resource models.PermissionResource, action models.PermissionAction) error {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.MustChangePassword {
+		return errors.New("password change required")
+	}
+
+	matrix, err := u.matrix(ctx)
+	if err != nil {
+		return err
+	}
+	if !matrix.Allows(user.Role, resource, action) {
+		return errors.New("permission denied")
+	}
+	return nil
+}
+
+func (u *permissionUseCase) matrix(ctx context.Context) (models.PermissionMatrix, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.cached != nil && time.Since(u.cachedAt) < u.cacheTTL {
+		return u.cached, nil
+	}
+
+	permissions, err := u.permissionRepo.ListRolePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u.cached = models.NewPermissionMatrix(permissions)
+	u.cachedAt = time.Now()
+	return u.cached, nil
+}
+
+func (u *permissionUseCase) invalidate() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.cached = nil
+}
+
+func toRolePermissionsResponse(matrix models.PermissionMatrix, role models.UserRole) responses.RolePermissionsResponse {
+	response := responses.RolePermissionsResponse{
+		Role:        string(role),
+		Editable:    !role.IsAdmin(),
+		Permissions: map[string]map[string]bool{},
+	}
+	for _, resource := range models.PermissionResources {
+		actions := map[string]bool{}
+		for _, action := range models.PermissionActions {
+			actions[string(action)] = matrix.Allows(role, resource, action)
+		}
+		response.Permissions[string(resource)] = actions
+	}
+	return response
+}
@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PriceIndexUseCase interface {
+	Create(ctx context.Context, req requests.CreatePriceIndexRequest) (*responses.PriceIndexResponse, error)
+	List(ctx context.Context) ([]responses.PriceIndexResponse, error)
+	GetByID(ctx context.Context, indexID uuid.UUID) (*responses.PriceIndexDetailResponse, error)
+	Delete(ctx context.Context, indexID uuid.UUID) error
+
+	AddValue(ctx context.Context, indexID uuid.UUID, req requests.AddPriceIndexValueRequest) error
+	ImportValues(ctx context.Context, indexID uuid.UUID, file io.Reader) (*responses.PriceIndexImportResponse, error)
+
+	LinkMaterial(ctx context.Context, materialID string, req requests.LinkMaterialPriceIndexRequest) error
+	UnlinkMaterial(ctx context.Context, materialID string) error
+
+	GetAlerts(ctx context.Context, thresholdPercent float64) (*responses.PriceIndexAlertListResponse, error)
+	CheckAlerts(ctx context.Context) error
+}
+
+type priceIndexUseCase struct {
+	priceIndexRepo repositories.PriceIndexRepository
+	materialRepo   repositories.MaterialRepository
+	alertThreshold float64
+}
+
+// NewPriceIndexUsecase takes the default alert threshold as a percentage,
+// used when a caller doesn't supply one and by the scheduled check.
+func NewPriceIndexUsecase(
+	priceIndexRepo repositories.PriceIndexRepository,
+	materialRepo repositories.MaterialRepository,
+	alertThreshold float64,
+) PriceIndexUseCase {
+	return &priceIndexUseCase{
+		priceIndexRepo: priceIndexRepo,
+		materialRepo:   materialRepo,
+		alertThreshold: alertThreshold,
+	}
+}
+
+func (u *priceIndexUseCase) Create(ctx context.Context, req requests.CreatePriceIndexRequest) (*responses.PriceIndexResponse, error) {
+	req.Code = strings.ToLower(strings.TrimSpace(req.Code))
+	if req.Code == "" || strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("code and name are required")
+	}
+
+	index, err := u.priceIndexRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPriceIndexResponse(index)
+	return &response, nil
+}
+
+func (u *priceIndexUseCase) List(ctx context.Context) ([]responses.PriceIndexResponse, error) {
+	indices, err := u.priceIndexRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.PriceIndexResponse, len(indices))
+	for i := range indices {
+		result[i] = toPriceIndexResponse(&indices[i])
+	}
+	return result, nil
+}
+
+func (u *priceIndexUseCase) GetByID(ctx context.Context, indexID uuid.UUID) (*responses.PriceIndexDetailResponse, error) {
+	index, err := u.priceIndexRepo.GetByID(ctx, indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := u.priceIndexRepo.ListValues(ctx, indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.PriceIndexDetailResponse{
+		PriceIndexResponse: toPriceIndexResponse(index),
+		Values:             make([]responses.PriceIndexValueResponse, len(values)),
+	}
+	for i, value := range values {
+		response.Values[i] = responses.PriceIndexValueResponse{
+			PeriodDate: value.PeriodDate.Format("2006-01-02"),
+			Value:      value.Value,
+			Source:     value.Source,
+		}
+	}
+
+	return response, nil
+}
+
+func (u *priceIndexUseCase) Delete(ctx context.Context, indexID uuid.UUID) error {
+	return u.priceIndexRepo.Delete(ctx, indexID)
+}
+
+func (u *priceIndexUseCase) AddValue(ctx context.Context, indexID uuid.UUID, req requests.AddPriceIndexValueRequest) error {
+	if _, err := u.priceIndexRepo.GetByID(ctx, indexID); err != nil {
+		return err
+	}
+
+	value, err := parsePriceIndexValue(indexID, req.PeriodDate, req.Value, "manual")
+	if err != nil {
+		return err
+	}
+
+	return u.priceIndexRepo.SaveValues(ctx, []models.PriceIndexValue{*value})
+}
+
+// ImportValues reads a CSV of period_date,value rows. A header row is
+// skipped if present.
+func (u *priceIndexUseCase) ImportValues(ctx context.Context, indexID uuid.UUID, file io.Reader) (*responses.PriceIndexImportResponse, error) {
+	if _, err := u.priceIndexRepo.GetByID(ctx, indexID); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var values []models.PriceIndexValue
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv file: %w", err)
+		}
+
+		if line == 1 && strings.EqualFold(record[0], "period_date") {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv file: invalid value on line %d", line)
+		}
+
+		value, err := parsePriceIndexValue(indexID, record[0], amount, "import")
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv file: %v on line %d", err, line)
+		}
+		values = append(values, *value)
+	}
+
+	if len(values) == 0 {
+		return nil, errors.New("invalid csv file: no values found")
+	}
+
+	if err := u.priceIndexRepo.SaveValues(ctx, values); err != nil {
+		return nil, err
+	}
+
+	return &responses.PriceIndexImportResponse{Imported: len(values)}, nil
+}
+
+func (u *priceIndexUseCase) LinkMaterial(ctx context.Context, materialID string, req requests.LinkMaterialPriceIndexRequest) error {
+	if _, err := u.materialRepo.GetByID(ctx, materialID); err != nil {
+		return err
+	}
+	if _, err := u.priceIndexRepo.GetByID(ctx, req.IndexID); err != nil {
+		return err
+	}
+
+	return u.priceIndexRepo.LinkMaterial(ctx, materialID, req.IndexID)
+}
+
+func (u *priceIndexUseCase) UnlinkMaterial(ctx context.Context, materialID string) error {
+	return u.priceIndexRepo.UnlinkMaterial(ctx, materialID)
+}
+
+func (u *priceIndexUseCase) GetAlerts(ctx context.Context, thresholdPercent float64) (*responses.PriceIndexAlertListResponse, error) {
+	if thresholdPercent <= 0 {
+		thresholdPercent = u.alertThreshold
+	}
+
+	alerts, err := u.priceIndexRepo.GetDraftQuotationAlerts(ctx, thresholdPercent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.PriceIndexAlertListResponse{
+		ThresholdPercent: thresholdPercent,
+		Alerts:           make([]responses.PriceIndexAlertResponse, len(alerts)),
+	}
+	for i, alert := range alerts {
+		response.Alerts[i] = responses.PriceIndexAlertResponse{
+			ProjectID:     alert.ProjectID,
+			ProjectName:   alert.ProjectName,
+			QuotationID:   alert.QuotationID,
+			BOQID:         alert.BOQID,
+			MaterialID:    alert.MaterialID,
+			MaterialName:  alert.MaterialName,
+			IndexID:       alert.IndexID,
+			IndexCode:     alert.IndexCode,
+			EstimatedAt:   alert.EstimatedAt,
+			BaseDate:      alert.BaseDate.Format("2006-01-02"),
+			BaseValue:     alert.BaseValue,
+			LatestDate:    alert.LatestDate.Format("2006-01-02"),
+			LatestValue:   alert.LatestValue,
+			ChangePercent: alert.ChangePercent,
+		}
+	}
+
+	return response, nil
+}
+
+// CheckAlerts runs on a schedule and logs every draft quotation material
+// that has crossed the default threshold.
+func (u *priceIndexUseCase) CheckAlerts(ctx context.Context) error {
+	alerts, err := u.priceIndexRepo.GetDraftQuotationAlerts(ctx, u.alertThreshold)
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range alerts {
+		log.Printf("price index alert: project %q material %q (%s) moved %.2f%% since it was priced on %s",
+			alert.ProjectName, alert.MaterialName, alert.IndexCode, alert.ChangePercent,
+			alert.EstimatedAt.Format("2006-01-02"))
+	}
+
+	return nil
+}
+
+func parsePriceIndexValue(indexID uuid.UUID, periodDate string, value float64, source string) (*models.PriceIndexValue, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(periodDate))
+	if err != nil {
+		return nil, errors.New("invalid period date format, expected YYYY-MM-DD")
+	}
+	if value <= 0 {
+		return nil, errors.New("value must be greater than 0")
+	}
+
+	return &models.PriceIndexValue{
+		IndexID:    indexID,
+		PeriodDate: date,
+		Value:      value,
+		Source:     source,
+	}, nil
+}
+
+func toPriceIndexResponse(index *models.PriceIndex) responses.PriceIndexResponse {
+	return responses.PriceIndexResponse{
+		IndexID:     index.IndexID,
+		Code:        index.Code,
+		Name:        index.Name,
+		Unit:        index.Unit.String,
+		Description: index.Description.String,
+		CreatedAt:   index.CreatedAt,
+	}
+}
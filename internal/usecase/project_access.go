@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"boonkosang/internal/repositories"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// projectAccess limits users without an admin role to the projects they
+// are members of. It acts for the user the REST layer put in the context;
+// calls without one are refused, so a route mounted without auth can't
+// reach project data.
+type projectAccess struct {
+	userRepo   repositories.UserRepository
+	memberRepo repositories.ProjectMemberRepository
+}
+
+// restricted returns the user whose projects are limited, or false when
+// the caller may reach every project.
+func (a projectAccess) restricted(ctx context.Context) (uuid.UUID, bool, error) {
+	actor := actorFromContext(ctx)
+	if !actor.Valid {
+		return uuid.Nil, false, errors.New("project access denied")
+	}
+	user, err := a.userRepo.GetByID(ctx, actor.UUID)
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	if user.Role.IsAdmin() {
+		return uuid.Nil, false, nil
+	}
+	return user.UserID, true, nil
+}
+
+func (a projectAccess) check(ctx context.Context, projectID uuid.UUID) error {
+	userID, limited, err := a.restricted(ctx)
+	if err != nil || !limited {
+		return err
+	}
+	member, err := a.memberRepo.IsMember(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return errors.New("project access denied")
+	}
+	return nil
+}
+
+// visible returns a filter for project lists.
+func (a projectAccess) visible(ctx context.Context) (func(uuid.UUID) bool, error) {
+	userID, limited, err := a.restricted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !limited {
+		return func(uuid.UUID) bool { return true }, nil
+	}
+
+	projectIDs, err := a.memberRepo.ListProjectIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	member := make(map[uuid.UUID]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		member[id] = true
+	}
+	return func(id uuid.UUID) bool { return member[id] }, nil
+}
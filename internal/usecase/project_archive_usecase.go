@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectArchiveUsecase interface {
+	// Archive moves a completed project's photos, inspection logs and
+	// drawing revisions to cold storage as one gzipped file, leaving the
+	// project, its financials and an archive summary in the database.
+	Archive(ctx context.Context, actorID, projectID uuid.UUID) (*responses.ProjectArchiveResponse, error)
+	// Restore loads the archived rows back from cold storage.
+	Restore(ctx context.Context, actorID, projectID uuid.UUID) (*responses.ProjectArchiveResponse, error)
+	// Get returns the project's latest archive, restored or not.
+	Get(ctx context.Context, projectID uuid.UUID) (*responses.ProjectArchiveResponse, error)
+}
+
+type projectArchiveUsecase struct {
+	archiveRepo  repositories.ProjectArchiveRepository
+	projectRepo  repositories.ProjectRepository
+	activityRepo repositories.ActivityRepository
+	storage      repositories.ColdStorage
+}
+
+func NewProjectArchiveUsecase(
+	archiveRepo repositories.ProjectArchiveRepository,
+	projectRepo repositories.ProjectRepository,
+	activityRepo repositories.ActivityRepository,
+	storage repositories.ColdStorage,
+) ProjectArchiveUsecase {
+	return &projectArchiveUsecase{
+		archiveRepo:  archiveRepo,
+		projectRepo:  projectRepo,
+		activityRepo: activityRepo,
+		storage:      storage,
+	}
+}
+
+func (u *projectArchiveUsecase) Archive(ctx context.Context, actorID, projectID uuid.UUID) (*responses.ProjectArchiveResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Status != models.ProjectStatusCompleted {
+		return nil, errors.New("only completed projects can be archived")
+	}
+
+	latest, err := u.archiveRepo.GetLatest(ctx, projectID)
+	if err != nil && err.Error() != "archive not found" {
+		return nil, err
+	}
+	if latest != nil && latest.Active() {
+		return nil, errors.New("project is already archived")
+	}
+
+	exported, err := u.archiveRepo.ExportChildData(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &models.ProjectArchive{
+		ArchiveID:  uuid.New(),
+		ProjectID:  projectID,
+		ArchivedBy: actorID,
+		ArchivedAt: time.Now(),
+	}
+	file := models.ProjectArchiveFile{
+		ArchiveID:  archive.ArchiveID,
+		ProjectID:  projectID,
+		ArchivedAt: archive.ArchivedAt,
+		Tables:     make(map[string]json.RawMessage, len(exported)),
+	}
+	counts := make(map[string]int, len(exported))
+	for table, rows := range exported {
+		file.Tables[table] = rows.Rows
+		counts[table] = rows.Count
+	}
+
+	data, err := compressArchiveFile(file)
+	if err != nil {
+		return nil, err
+	}
+	archive.SizeBytes = int64(len(data))
+	archive.ObjectKey = fmt.Sprintf("projects/%s/%s.json.gz", projectID, archive.ArchiveID)
+	archive.RowCounts, err = json.Marshal(counts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode row counts: %w", err)
+	}
+
+	// The file is stored before the rows are deleted, so a failure in
+	// between leaves an unused object rather than lost data.
+	if err := u.storage.Put(ctx, archive.ObjectKey, data); err != nil {
+		return nil, fmt.Errorf("failed to store project archive: %w", err)
+	}
+	if err := u.archiveRepo.Archive(ctx, archive, counts); err != nil {
+		return nil, err
+	}
+
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType:  models.ActivityEntityProject,
+		EntityID:    projectID,
+		ProjectID:   uuid.NullUUID{UUID: projectID, Valid: true},
+		EventType:   "archived",
+		Description: fmt.Sprintf("Project %s archived to cold storage", project.Name),
+	})
+
+	return toProjectArchiveResponse(archive)
+}
+
+func (u *projectArchiveUsecase) Restore(ctx context.Context, actorID, projectID uuid.UUID) (*responses.ProjectArchiveResponse, error) {
+	archive, err := u.archiveRepo.GetLatest(ctx, projectID)
+	if err != nil {
+		if err.Error() == "archive not found" {
+			return nil, errors.New("project is not archived")
+		}
+		return nil, err
+	}
+	if !archive.Active() {
+		return nil, errors.New("project is not archived")
+	}
+
+	data, err := u.storage.Get(ctx, archive.ObjectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project archive: %w", err)
+	}
+	file, err := decompressArchiveFile(data)
+	if err != nil {
+		return nil, err
+	}
+	if file.ArchiveID != archive.ArchiveID || file.ProjectID != projectID {
+		return nil, errors.New("archived file does not match the archive record")
+	}
+
+	if err := u.archiveRepo.Restore(ctx, archive.ArchiveID, actorID, file.Tables); err != nil {
+		return nil, err
+	}
+
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType:  models.ActivityEntityProject,
+		EntityID:    projectID,
+		ProjectID:   uuid.NullUUID{UUID: projectID, Valid: true},
+		EventType:   "restored",
+		Description: "Project restored from archive",
+	})
+
+	return u.Get(ctx, projectID)
+}
+
+func (u *projectArchiveUsecase) Get(ctx context.Context, projectID uuid.UUID) (*responses.ProjectArchiveResponse, error) {
+	archive, err := u.archiveRepo.GetLatest(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return toProjectArchiveResponse(archive)
+}
+
+func compressArchiveFile(file models.ProjectArchiveFile) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(file); err != nil {
+		return nil, fmt.Errorf("failed to encode project archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress project archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressArchiveFile(data []byte) (*models.ProjectArchiveFile, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress project archive: %w", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress project archive: %w", err)
+	}
+
+	var file models.ProjectArchiveFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode project archive: %w", err)
+	}
+	return &file, nil
+}
+
+func toProjectArchiveResponse(archive *models.ProjectArchive) (*responses.ProjectArchiveResponse, error) {
+	var counts map[string]int
+	if err := json.Unmarshal(archive.RowCounts, &counts); err != nil {
+		return nil, fmt.Errorf("failed to decode row counts: %w", err)
+	}
+
+	response := &responses.ProjectArchiveResponse{
+		ArchiveID:  archive.ArchiveID,
+		ProjectID:  archive.ProjectID,
+		Archived:   archive.Active(),
+		SizeBytes:  archive.SizeBytes,
+		Tables:     counts,
+		ArchivedBy: archive.ArchivedBy,
+		ArchivedAt: archive.ArchivedAt,
+		RestoredBy: nullUUIDPtr(archive.RestoredBy),
+	}
+	if archive.RestoredAt.Valid {
+		response.RestoredAt = &archive.RestoredAt.Time
+	}
+	for table, count := range counts {
+		switch models.ArchivedTableCategory(table) {
+		case models.ArchiveCategoryPhotos:
+			response.Summary.Photos += count
+		case models.ArchiveCategoryLogs:
+			response.Summary.Logs += count
+		case models.ArchiveCategoryRevisions:
+			response.Summary.Revisions += count
+		}
+	}
+	return response, nil
+}
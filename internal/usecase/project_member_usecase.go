@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectMemberUseCase interface {
+	List(ctx context.Context, projectID uuid.UUID) ([]responses.ProjectMemberResponse, error)
+	Add(ctx context.Context, projectID uuid.UUID, req requests.AddProjectMemberRequest) error
+	Remove(ctx context.Context, projectID, userID uuid.UUID) error
+}
+
+type projectMemberUseCase struct {
+	memberRepo  repositories.ProjectMemberRepository
+	projectRepo repositories.ProjectRepository
+	userRepo    repositories.UserRepository
+	access      projectAccess
+}
+
+func NewProjectMemberUsecase(
+	memberRepo repositories.ProjectMemberRepository,
+	projectRepo repositories.ProjectRepository,
+	userRepo repositories.UserRepository,
+) ProjectMemberUseCase {
+	return &projectMemberUseCase{
+		memberRepo:  memberRepo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+		access:      projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *projectMemberUseCase) List(ctx context.Context, projectID uuid.UUID) ([]responses.ProjectMemberResponse, error) {
+	if err := u.project(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	members, err := u.memberRepo.ListByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.ProjectMemberResponse, 0, len(members))
+	for _, m := range members {
+		result = append(result, responses.ProjectMemberResponse{
+			UserID:    m.UserID,
+			Username:  m.Username,
+			FirstName: m.FirstName,
+			LastName:  m.LastName,
+			Role:      string(m.Role),
+			IsActive:  m.IsActive,
+			AddedBy:   nullUUIDPtr(m.AddedBy),
+			AddedAt:   m.AddedAt,
+		})
+	}
+	return result, nil
+}
+
+func (u *projectMemberUseCase) Add(ctx context.Context, projectID uuid.UUID, req requests.AddProjectMemberRequest) error {
+	if err := u.project(ctx, projectID); err != nil {
+		return err
+	}
+	if req.UserID == uuid.Nil {
+		return errors.New("user_id is required")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.IsActive {
+		return errors.New("user is deactivated")
+	}
+
+	return u.memberRepo.Add(ctx, models.ProjectMember{
+		ProjectID: projectID,
+		UserID:    user.UserID,
+		AddedBy:   actorFromContext(ctx),
+		AddedAt:   time.Now(),
+	})
+}
+
+func (u *projectMemberUseCase) Remove(ctx context.Context, projectID, userID uuid.UUID) error {
+	if err := u.project(ctx, projectID); err != nil {
+		return err
+	}
+	return u.memberRepo.Remove(ctx, projectID, userID)
+}
+
+// project checks the project exists and the caller may manage its members,
+// which members themselves can do.
+func (u *projectMemberUseCase) project(ctx context.Context, projectID uuid.UUID) error {
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return err
+	}
+	return u.access.check(ctx, projectID)
+}
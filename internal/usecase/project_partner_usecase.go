@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/infrastructure/pdf"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectPartnerUseCase interface {
+	GetPartners(ctx context.Context, projectID uuid.UUID) ([]responses.ProjectPartnerResponse, error)
+	UpdatePartners(ctx context.Context, projectID uuid.UUID, req requests.UpdateProjectPartnersRequest) ([]responses.ProjectPartnerResponse, error)
+
+	// GetShareReport splits revenue, cost, margin, invoiced and collected
+	// amounts by partner share.
+	GetShareReport(ctx context.Context, projectID uuid.UUID) (*responses.ProjectShareReportResponse, error)
+	// ExportPartnerStatement renders one partner's share as a PDF.
+	ExportPartnerStatement(ctx context.Context, projectID uuid.UUID, partnerID uuid.UUID) ([]byte, error)
+}
+
+type projectPartnerUseCase struct {
+	partnerRepo repositories.ProjectPartnerRepository
+	projectRepo repositories.ProjectRepository
+	invoiceRepo repositories.InvoiceRepository
+	paymentRepo repositories.PaymentRepository
+}
+
+func NewProjectPartnerUsecase(
+	partnerRepo repositories.ProjectPartnerRepository,
+	projectRepo repositories.ProjectRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	paymentRepo repositories.PaymentRepository,
+) ProjectPartnerUseCase {
+	return &projectPartnerUseCase{
+		partnerRepo: partnerRepo,
+		projectRepo: projectRepo,
+		invoiceRepo: invoiceRepo,
+		paymentRepo: paymentRepo,
+	}
+}
+
+func (u *projectPartnerUseCase) GetPartners(ctx context.Context, projectID uuid.UUID) ([]responses.ProjectPartnerResponse, error) {
+	if _, err := u.project(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	partners, err := u.partnerRepo.ListPartners(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return toProjectPartnerResponses(partners), nil
+}
+
+func (u *projectPartnerUseCase) UpdatePartners(ctx context.Context, projectID uuid.UUID, req requests.UpdateProjectPartnersRequest) ([]responses.ProjectPartnerResponse, error) {
+	if _, err := u.project(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	partners := make([]models.ProjectPartner, 0, len(req.Partners))
+	names := map[string]bool{}
+	var total float64
+	selfCount := 0
+	for _, p := range req.Partners {
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			return nil, errors.New("partner name is required")
+		}
+		if names[strings.ToLower(name)] {
+			return nil, errors.New("partner names must be unique")
+		}
+		names[strings.ToLower(name)] = true
+		if p.SharePercent <= 0 || p.SharePercent > 100 {
+			return nil, errors.New("share percent must be greater than 0 and at most 100")
+		}
+		if p.IsSelf {
+			selfCount++
+		}
+		total += p.SharePercent
+
+		partners = append(partners, models.ProjectPartner{
+			PartnerID:    uuid.New(),
+			ProjectID:    projectID,
+			Name:         name,
+			TaxID:        optionalString(p.TaxID),
+			SharePercent: p.SharePercent,
+			IsSelf:       p.IsSelf,
+			CreatedAt:    now,
+		})
+	}
+	if len(partners) > 0 {
+		if selfCount != 1 {
+			return nil, errors.New("exactly one partner must be our company")
+		}
+		if math.Abs(total-100) > 0.001 {
+			return nil, errors.New("partner shares must add up to 100 percent")
+		}
+	}
+
+	if err := u.partnerRepo.ReplacePartners(ctx, projectID, partners); err != nil {
+		return nil, err
+	}
+	return u.GetPartners(ctx, projectID)
+}
+
+func (u *projectPartnerUseCase) GetShareReport(ctx context.Context, projectID uuid.UUID) (*responses.ProjectShareReportResponse, error) {
+	project, err := u.project(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	partners, err := u.partnerRepo.ListPartners(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(partners) == 0 {
+		return nil, errors.New("project has no partners")
+	}
+
+	overview, err := u.projectRepo.GetProjectOverview(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	totals := responses.ProjectFinancials{
+		Revenue:       roundTo(getFloat64Value(overview.TotalSellingPrice), 2),
+		EstimatedCost: roundTo(getFloat64Value(overview.TotalOverallCost), 2),
+		ActualCost:    roundTo(getFloat64Value(overview.TotalActualCost), 2),
+	}
+	totals.EstimatedProfit = roundTo(totals.Revenue-totals.EstimatedCost, 2)
+	totals.ActualProfit = roundTo(totals.Revenue-totals.ActualCost, 2)
+
+	invoices, err := u.invoiceRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, invoice := range invoices {
+		totals.Invoiced += invoice.Amount.Float64
+		paid, err := u.paymentRepo.GetTotalPaidByInvoiceID(ctx, invoice.InvoiceID)
+		if err != nil {
+			return nil, err
+		}
+		totals.Collected += paid
+	}
+	totals.Invoiced = roundTo(totals.Invoiced, 2)
+	totals.Collected = roundTo(totals.Collected, 2)
+
+	shares := make([]float64, len(partners))
+	for i, partner := range partners {
+		shares[i] = partner.SharePercent
+	}
+	split := func(total float64) []float64 { return splitByShare(total, shares) }
+	revenue := split(totals.Revenue)
+	estimatedCost := split(totals.EstimatedCost)
+	actualCost := split(totals.ActualCost)
+	estimatedProfit := split(totals.EstimatedProfit)
+	actualProfit := split(totals.ActualProfit)
+	invoiced := split(totals.Invoiced)
+	collected := split(totals.Collected)
+
+	report := &responses.ProjectShareReportResponse{
+		ProjectID:       project.ProjectID,
+		ProjectName:     project.Name,
+		Totals:          totals,
+		EstimatedMargin: roundTo(calculateMargin(totals.EstimatedProfit, totals.Revenue), 2),
+		ActualMargin:    roundTo(calculateMargin(totals.ActualProfit, totals.Revenue), 2),
+		Partners:        make([]responses.PartnerShareResponse, len(partners)),
+	}
+	for i, partner := range partners {
+		report.Partners[i] = responses.PartnerShareResponse{
+			ProjectPartnerResponse: toProjectPartnerResponse(partner),
+			ProjectFinancials: responses.ProjectFinancials{
+				Revenue:         revenue[i],
+				EstimatedCost:   estimatedCost[i],
+				ActualCost:      actualCost[i],
+				EstimatedProfit: estimatedProfit[i],
+				ActualProfit:    actualProfit[i],
+				Invoiced:        invoiced[i],
+				Collected:       collected[i],
+			},
+		}
+	}
+	return report, nil
+}
+
+func (u *projectPartnerUseCase) ExportPartnerStatement(ctx context.Context, projectID uuid.UUID, partnerID uuid.UUID) ([]byte, error) {
+	report, err := u.GetShareReport(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var share *responses.PartnerShareResponse
+	for i := range report.Partners {
+		if report.Partners[i].PartnerID == partnerID {
+			share = &report.Partners[i]
+			break
+		}
+	}
+	if share == nil {
+		return nil, errors.New("partner not found")
+	}
+
+	money := func(v float64) string { return fmt.Sprintf("%.2f THB", v) }
+	doc := pdf.New()
+	doc.Heading("Joint Venture Partner Statement")
+	doc.Field("Project", report.ProjectName)
+	doc.Field("Partner", share.Name)
+	if share.TaxID != "" {
+		doc.Field("Tax ID", share.TaxID)
+	}
+	doc.Field("Share", fmt.Sprintf("%.2f%%", share.SharePercent))
+	doc.Field("Statement date", time.Now().Format("2 January 2006"))
+	doc.Gap()
+
+	doc.Bold("Share of project results (before VAT)")
+	doc.Field("Revenue", money(share.Revenue))
+	doc.Field("Estimated cost", money(share.EstimatedCost))
+	doc.Field("Actual cost", money(share.ActualCost))
+	doc.Field("Estimated profit", money(share.EstimatedProfit))
+	doc.Field("Actual profit", money(share.ActualProfit))
+	doc.Field("Estimated margin", fmt.Sprintf("%.2f%%", report.EstimatedMargin))
+	doc.Field("Actual margin", fmt.Sprintf("%.2f%%", report.ActualMargin))
+	doc.Gap()
+
+	doc.Bold("Share of billing")
+	doc.Field("Invoiced", money(share.Invoiced))
+	doc.Field("Collected", money(share.Collected))
+	doc.Field("Outstanding", money(roundTo(share.Invoiced-share.Collected, 2)))
+	doc.Gap()
+
+	doc.Bold("Project totals")
+	doc.Field("Revenue", money(report.Totals.Revenue))
+	doc.Field("Actual cost", money(report.Totals.ActualCost))
+	doc.Field("Invoiced", money(report.Totals.Invoiced))
+	doc.Field("Collected", money(report.Totals.Collected))
+
+	return doc.Bytes(), nil
+}
+
+func (u *projectPartnerUseCase) project(ctx context.Context, projectID uuid.UUID) (*models.Project, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+	return project, nil
+}
+
+// splitByShare divides total by percentage shares, rounding each part to
+// the satang and putting the remainder on the last part so the parts add
+// up to total.
+func splitByShare(total float64, shares []float64) []float64 {
+	parts := make([]float64, len(shares))
+	var allocated float64
+	for i, share := range shares {
+		if i == len(shares)-1 {
+			parts[i] = roundTo(total-allocated, 2)
+			break
+		}
+		parts[i] = roundTo(total*share/100, 2)
+		allocated += parts[i]
+	}
+	return parts
+}
+
+func toProjectPartnerResponse(partner models.ProjectPartner) responses.ProjectPartnerResponse {
+	return responses.ProjectPartnerResponse{
+		PartnerID:    partner.PartnerID,
+		Name:         partner.Name,
+		TaxID:        partner.TaxID.String,
+		SharePercent: partner.SharePercent,
+		IsSelf:       partner.IsSelf,
+	}
+}
+
+func toProjectPartnerResponses(partners []models.ProjectPartner) []responses.ProjectPartnerResponse {
+	result := make([]responses.ProjectPartnerResponse, len(partners))
+	for i, partner := range partners {
+		result[i] = toProjectPartnerResponse(partner)
+	}
+	return result
+}
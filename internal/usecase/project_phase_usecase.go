@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ProjectPhaseUseCase interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.CreateProjectPhaseRequest) (*responses.ProjectPhaseResponse, error)
+	CreateDefaults(ctx context.Context, projectID uuid.UUID) ([]responses.ProjectPhaseResponse, error)
+	List(ctx context.Context, projectID uuid.UUID) ([]responses.ProjectPhaseResponse, error)
+	Update(ctx context.Context, phaseID uuid.UUID, req requests.UpdateProjectPhaseRequest) error
+	Delete(ctx context.Context, phaseID uuid.UUID) error
+	AssignBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, req requests.AssignJobPhaseRequest) error
+	GetSummary(ctx context.Context, projectID uuid.UUID) (*responses.PhaseSummaryResponse, error)
+}
+
+type projectPhaseUseCase struct {
+	phaseRepo   repositories.ProjectPhaseRepository
+	projectRepo repositories.ProjectRepository
+	boqRepo     repositories.BOQRepository
+}
+
+func NewProjectPhaseUsecase(
+	phaseRepo repositories.ProjectPhaseRepository,
+	projectRepo repositories.ProjectRepository,
+	boqRepo repositories.BOQRepository,
+) ProjectPhaseUseCase {
+	return &projectPhaseUseCase{
+		phaseRepo:   phaseRepo,
+		projectRepo: projectRepo,
+		boqRepo:     boqRepo,
+	}
+}
+
+func (u *projectPhaseUseCase) Create(ctx context.Context, projectID uuid.UUID, req requests.CreateProjectPhaseRequest) (*responses.ProjectPhaseResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, errors.New("phase name is required")
+	}
+
+	phase, err := u.phaseRepo.Create(ctx, projectID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toProjectPhaseResponse(phase)
+	return &response, nil
+}
+
+func (u *projectPhaseUseCase) CreateDefaults(ctx context.Context, projectID uuid.UUID) ([]responses.ProjectPhaseResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.phaseRepo.CreateDefaults(ctx, projectID, models.DefaultProjectPhases); err != nil {
+		return nil, err
+	}
+
+	return u.List(ctx, projectID)
+}
+
+func (u *projectPhaseUseCase) List(ctx context.Context, projectID uuid.UUID) ([]responses.ProjectPhaseResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	phases, err := u.phaseRepo.ListByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.ProjectPhaseResponse, len(phases))
+	for i := range phases {
+		response[i] = toProjectPhaseResponse(&phases[i])
+	}
+
+	return response, nil
+}
+
+func (u *projectPhaseUseCase) Update(ctx context.Context, phaseID uuid.UUID, req requests.UpdateProjectPhaseRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("phase name is required")
+	}
+
+	return u.phaseRepo.Update(ctx, phaseID, req)
+}
+
+func (u *projectPhaseUseCase) Delete(ctx context.Context, phaseID uuid.UUID) error {
+	return u.phaseRepo.Delete(ctx, phaseID)
+}
+
+func (u *projectPhaseUseCase) AssignBOQJob(ctx context.Context, boqID uuid.UUID, jobID uuid.UUID, req requests.AssignJobPhaseRequest) error {
+	if req.PhaseID != nil {
+		if err := validateBOQPhase(ctx, u.boqRepo, u.phaseRepo, boqID, *req.PhaseID); err != nil {
+			return err
+		}
+	}
+
+	return u.phaseRepo.AssignBOQJob(ctx, boqID, jobID, req.PhaseID)
+}
+
+// GetSummary subtotals estimated, quoted, invoiced and paid amounts per
+// phase. Unassigned jobs and invoices are reported under a nil phase, which
+// is omitted when it has nothing in it.
+func (u *projectPhaseUseCase) GetSummary(ctx context.Context, projectID uuid.UUID) (*responses.PhaseSummaryResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	summary, err := u.phaseRepo.GetSummary(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.PhaseSummaryResponse{
+		ProjectID: projectID,
+		Phases:    []responses.PhaseSubtotal{},
+	}
+	for _, s := range summary {
+		if !s.PhaseID.Valid && s.EstimatedTotal == 0 && s.QuotedTotal == 0 && s.InvoicedTotal == 0 && s.PaidTotal == 0 {
+			continue
+		}
+
+		response.Phases = append(response.Phases, responses.PhaseSubtotal{
+			PhaseID:        nullUUIDPtr(s.PhaseID),
+			PhaseName:      s.PhaseName.String,
+			EstimatedTotal: s.EstimatedTotal,
+			QuotedTotal:    s.QuotedTotal,
+			InvoicedTotal:  s.InvoicedTotal,
+			PaidTotal:      s.PaidTotal,
+		})
+	}
+
+	return response, nil
+}
+
+func (u *projectPhaseUseCase) ensureProject(ctx context.Context, projectID uuid.UUID) error {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return errors.New("project not found")
+	}
+	return nil
+}
+
+// validateBOQPhase checks that a phase belongs to the same project as the
+// BOQ it is being used in.
+func validateBOQPhase(ctx context.Context, boqRepo repositories.BOQRepository, phaseRepo repositories.ProjectPhaseRepository, boqID uuid.UUID, phaseID uuid.UUID) error {
+	boq, err := boqRepo.GetByID(ctx, boqID)
+	if err != nil {
+		return err
+	}
+	if boq == nil {
+		return errors.New("boq not found")
+	}
+
+	phase, err := phaseRepo.GetByID(ctx, phaseID)
+	if err != nil {
+		return err
+	}
+
+	if phase.ProjectID != boq.ProjectID {
+		return errors.New("phase does not belong to the BOQ project")
+	}
+
+	return nil
+}
+
+func toProjectPhaseResponse(phase *models.ProjectPhase) responses.ProjectPhaseResponse {
+	return responses.ProjectPhaseResponse{
+		PhaseID:   phase.PhaseID,
+		ProjectID: phase.ProjectID,
+		Name:      phase.Name,
+		SortOrder: phase.SortOrder,
+		CreatedAt: phase.CreatedAt,
+	}
+}
+
+func nullUUIDPtr(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
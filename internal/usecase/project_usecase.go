@@ -10,6 +10,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -29,14 +30,32 @@ type ProjectUsecase interface {
 }
 
 type projectUsecase struct {
-	projectRepo repositories.ProjectRepository
-	clientRepo  repositories.ClientRepository
+	projectRepo    repositories.ProjectRepository
+	clientRepo     repositories.ClientRepository
+	activityRepo   repositories.ActivityRepository
+	riskRepo       repositories.RiskRepository
+	estimationRepo repositories.EstimationRepository
+	memberRepo     repositories.ProjectMemberRepository
+	access         projectAccess
 }
 
-func NewProjectUsecase(projectRepo repositories.ProjectRepository, clientRepo repositories.ClientRepository) ProjectUsecase {
+func NewProjectUsecase(
+	projectRepo repositories.ProjectRepository,
+	clientRepo repositories.ClientRepository,
+	activityRepo repositories.ActivityRepository,
+	riskRepo repositories.RiskRepository,
+	estimationRepo repositories.EstimationRepository,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) ProjectUsecase {
 	return &projectUsecase{
-		projectRepo: projectRepo,
-		clientRepo:  clientRepo,
+		projectRepo:    projectRepo,
+		clientRepo:     clientRepo,
+		activityRepo:   activityRepo,
+		riskRepo:       riskRepo,
+		estimationRepo: estimationRepo,
+		memberRepo:     memberRepo,
+		access:         projectAccess{userRepo: userRepo, memberRepo: memberRepo},
 	}
 }
 
@@ -45,12 +64,37 @@ func (u *projectUsecase) Create(ctx context.Context, req requests.CreateProjectR
 	if err != nil {
 		return nil, errors.New("client not found")
 	}
+	if client.ArchivedAt.Valid {
+		return nil, errors.New("client is archived")
+	}
 
 	project, err := u.projectRepo.Create(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType:  models.ActivityEntityProject,
+		EntityID:    project.ProjectID,
+		ProjectID:   uuid.NullUUID{UUID: project.ProjectID, Valid: true},
+		ClientID:    uuid.NullUUID{UUID: project.ClientID, Valid: true},
+		EventType:   "project_created",
+		Description: "Project " + project.Name + " created",
+	})
+
+	// Users limited to their own projects would otherwise lose sight of
+	// the project they just created.
+	if userID, limited, err := u.access.restricted(ctx); err == nil && limited {
+		if err := u.memberRepo.Add(ctx, models.ProjectMember{
+			ProjectID: project.ProjectID,
+			UserID:    userID,
+			AddedBy:   uuid.NullUUID{UUID: userID, Valid: true},
+			AddedAt:   time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
 	return &responses.ProjectResponse{
 		ID:          project.ProjectID,
 		Name:        project.Name,
@@ -71,6 +115,10 @@ func (u *projectUsecase) Create(ctx context.Context, req requests.CreateProjectR
 }
 
 func (u *projectUsecase) Update(ctx context.Context, id uuid.UUID, req requests.UpdateProjectRequest) error {
+	if err := u.access.check(ctx, id); err != nil {
+		return err
+	}
+
 	_, err := u.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return errors.New("project not found")
@@ -81,10 +129,18 @@ func (u *projectUsecase) Update(ctx context.Context, id uuid.UUID, req requests.
 }
 
 func (u *projectUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := u.access.check(ctx, id); err != nil {
+		return err
+	}
+
 	return u.projectRepo.Delete(ctx, id)
 }
 
 func (u *projectUsecase) GetByID(ctx context.Context, id uuid.UUID) (*responses.ProjectResponse, error) {
+	if err := u.access.check(ctx, id); err != nil {
+		return nil, err
+	}
+
 	project, client, err := u.projectRepo.GetByIDWithClient(ctx, id)
 	if err != nil {
 		return nil, err
@@ -118,6 +174,17 @@ func (u *projectUsecase) List(
 	if err != nil {
 		return nil, err
 	}
+	visible, err := u.access.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shown := projects[:0]
+	for _, project := range projects {
+		if visible(project.ProjectID) {
+			shown = append(shown, project)
+		}
+	}
+	projects = shown
 
 	projectResponses := make([]responses.ProjectResponse, len(projects))
 	for i, project := range projects {
@@ -152,14 +219,58 @@ func (u *projectUsecase) List(
 }
 
 func (u *projectUsecase) Cancel(ctx context.Context, id uuid.UUID) error {
-	return u.projectRepo.Cancel(ctx, id)
+	if err := u.access.check(ctx, id); err != nil {
+		return err
+	}
+
+	if err := u.projectRepo.Cancel(ctx, id); err != nil {
+		return err
+	}
+
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType:  models.ActivityEntityProject,
+		EntityID:    id,
+		ProjectID:   uuid.NullUUID{UUID: id, Valid: true},
+		EventType:   "project_cancelled",
+		Description: "Project cancelled",
+	})
+	return nil
 }
 
 func (u *projectUsecase) UpdateProjectStatus(ctx context.Context, req requests.UpdateProjectStatusRequest) error {
-	return u.projectRepo.UpdateStatus(ctx, req.ProjectID, req.Status)
+	if err := u.access.check(ctx, req.ProjectID); err != nil {
+		return err
+	}
+
+	if req.Status == models.ProjectStatusInProgress {
+		if err := checkRiskMitigation(ctx, u.riskRepo, req.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	if err := u.projectRepo.UpdateStatus(ctx, req.ProjectID, req.Status); err != nil {
+		return err
+	}
+
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType:  models.ActivityEntityProject,
+		EntityID:    req.ProjectID,
+		ProjectID:   uuid.NullUUID{UUID: req.ProjectID, Valid: true},
+		EventType:   "project_status_changed",
+		Description: fmt.Sprintf("Project status changed to %s", req.Status),
+	})
+
+	if req.Status == models.ProjectStatusCompleted {
+		recordEstimationVariances(ctx, u.estimationRepo, req.ProjectID)
+	}
+	return nil
 }
 
 func (u *projectUsecase) GetProjectOverview(ctx context.Context, projectID uuid.UUID) (*responses.ProjectOverviewResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
 	overview, err := u.projectRepo.GetProjectOverview(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project overview: %w", err)
@@ -223,6 +334,10 @@ func calculateMargin(profit, totalPrice float64) float64 {
 
 // Updated GetProjectSummary method to use the helper function
 func (u *projectUsecase) GetProjectSummary(ctx context.Context, projectID uuid.UUID) (*responses.ProjectSummaryResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
 	// Get project details
 	project, err := u.projectRepo.GetByID(ctx, projectID)
 	if err != nil {
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PromptPay payloads follow the EMVCo merchant-presented QR specification as
+// published by the Bank of Thailand. Each field is encoded as
+// ID (2 digits) + length (2 digits) + value.
+const (
+	promptPayAID             = "A000000677010111"
+	promptPayCountryCode     = "TH"
+	promptPayCurrencyCodeTHB = "764"
+)
+
+func isValidPromptPayID(id string) bool {
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	switch len(id) {
+	case 10, 13, 15:
+		return true
+	}
+	return false
+}
+
+// buildPromptPayPayload returns the string to be encoded in the QR image.
+// An amount of zero produces a static QR where the payer types the amount.
+func buildPromptPayPayload(promptPayID string, amount float64) (string, error) {
+	if !isValidPromptPayID(promptPayID) {
+		return "", errors.New("invalid promptpay ID")
+	}
+	if amount < 0 {
+		return "", errors.New("amount must not be negative")
+	}
+
+	var account string
+	switch len(promptPayID) {
+	case 10:
+		// Mobile numbers are sent in international format without the
+		// leading zero, left padded to 13 digits: 0812345678 -> 0066812345678
+		mobile := "66" + promptPayID[1:]
+		account = emvField("01", strings.Repeat("0", 13-len(mobile))+mobile)
+	case 13:
+		account = emvField("02", promptPayID)
+	case 15:
+		account = emvField("03", promptPayID)
+	}
+
+	initiation := "11"
+	if amount > 0 {
+		initiation = "12"
+	}
+
+	var b strings.Builder
+	b.WriteString(emvField("00", "01"))
+	b.WriteString(emvField("01", initiation))
+	b.WriteString(emvField("29", emvField("00", promptPayAID)+account))
+	b.WriteString(emvField("58", promptPayCountryCode))
+	b.WriteString(emvField("53", promptPayCurrencyCodeTHB))
+	if amount > 0 {
+		b.WriteString(emvField("54", fmt.Sprintf("%.2f", amount)))
+	}
+	b.WriteString("6304")
+
+	payload := b.String()
+	return payload + fmt.Sprintf("%04X", crc16CCITT([]byte(payload))), nil
+}
+
+func emvField(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// crc16CCITT implements CRC-16/CCITT-FALSE (poly 0x1021, init 0xFFFF),
+// which is the checksum required in field 63 of the payload.
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
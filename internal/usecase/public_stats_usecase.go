@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// publicStatsFields is the whitelist of what the widget may expose. Any
+// new field has to be added here explicitly.
+var publicStatsFields = map[string]func(*models.PublicStats) float64{
+	"projects_completed":   func(s *models.PublicStats) float64 { return float64(s.ProjectsCompleted) },
+	"projects_in_progress": func(s *models.PublicStats) float64 { return float64(s.ProjectsInProgress) },
+	"clients_served":       func(s *models.PublicStats) float64 { return float64(s.ClientsServed) },
+	"area_built_m2":        func(s *models.PublicStats) float64 { return s.AreaBuiltM2 },
+}
+
+type PublicStatsUseCase interface {
+	Get(ctx context.Context, token string, fields []string) (responses.PublicStatsResponse, error)
+	CacheTTL() time.Duration
+}
+
+type publicStatsUseCase struct {
+	statsRepo repositories.PublicStatsRepository
+	tokens    []string
+	cacheTTL  time.Duration
+
+	mu       sync.Mutex
+	cached   *models.PublicStats
+	cachedAt time.Time
+}
+
+// NewPublicStatsUsecase accepts any of tokens; with none configured every
+// request is refused. Stats are cached for cacheTTL.
+func NewPublicStatsUsecase(statsRepo repositories.PublicStatsRepository, tokens []string, cacheTTL time.Duration) PublicStatsUseCase {
+	return &publicStatsUseCase{
+		statsRepo: statsRepo,
+		tokens:    tokens,
+		cacheTTL:  cacheTTL,
+	}
+}
+
+func (u *publicStatsUseCase) CacheTTL() time.Duration {
+	return u.cacheTTL
+}
+
+// Get returns the requested fields, or every whitelisted field when fields
+// is empty.
+func (u *publicStatsUseCase) Get(ctx context.Context, token string, fields []string) (responses.PublicStatsResponse, error) {
+	if !u.validToken(token) {
+		return nil, errors.New("invalid widget token")
+	}
+
+	for _, field := range fields {
+		if _, ok := publicStatsFields[field]; !ok {
+			return nil, errors.New("unknown stats field")
+		}
+	}
+	if len(fields) == 0 {
+		for field := range publicStatsFields {
+			fields = append(fields, field)
+		}
+	}
+
+	stats, err := u.stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(responses.PublicStatsResponse, len(fields))
+	for _, field := range fields {
+		response[field] = publicStatsFields[field](stats)
+	}
+
+	return response, nil
+}
+
+func (u *publicStatsUseCase) stats(ctx context.Context) (*models.PublicStats, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.cached != nil && time.Since(u.cachedAt) < u.cacheTTL {
+		return u.cached, nil
+	}
+
+	stats, err := u.statsRepo.GetPublicStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u.cached = stats
+	u.cachedAt = time.Now()
+	return stats, nil
+}
+
+func (u *publicStatsUseCase) validToken(token string) bool {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return false
+	}
+
+	valid := false
+	for _, t := range u.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
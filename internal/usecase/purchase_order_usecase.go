@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseOrderUseCase interface {
+	// Create raises an order whose lines are split between projects, each
+	// delivered to its own address.
+	Create(ctx context.Context, req requests.CreatePurchaseOrderRequest) (*responses.PurchaseOrderResponse, error)
+	GetByID(ctx context.Context, poID uuid.UUID) (*responses.PurchaseOrderResponse, error)
+	List(ctx context.Context, supplierID uuid.NullUUID) ([]responses.PurchaseOrderResponse, error)
+	// ListByProject lists the orders with a line allocated to the project.
+	ListByProject(ctx context.Context, projectID uuid.UUID) ([]responses.PurchaseOrderResponse, error)
+	// Receive records deliveries and charges their cost to each
+	// allocation's project.
+	Receive(ctx context.Context, poID uuid.UUID, req requests.ReceivePurchaseOrderRequest) (*responses.PurchaseOrderResponse, error)
+	Cancel(ctx context.Context, poID uuid.UUID) error
+}
+
+type purchaseOrderUseCase struct {
+	poRepo        repositories.PurchaseOrderRepository
+	agreementRepo repositories.BlanketAgreementRepository
+	supplierRepo  repositories.SupplierRepository
+	projectRepo   repositories.ProjectRepository
+	materialRepo  repositories.MaterialRepository
+	periodRepo    repositories.AccountingPeriodRepository
+	priceUpdates  BOQPriceUpdateUseCase
+	access        projectAccess
+	notifier      Notifier
+}
+
+func NewPurchaseOrderUsecase(
+	poRepo repositories.PurchaseOrderRepository,
+	agreementRepo repositories.BlanketAgreementRepository,
+	supplierRepo repositories.SupplierRepository,
+	projectRepo repositories.ProjectRepository,
+	materialRepo repositories.MaterialRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+	priceUpdates BOQPriceUpdateUseCase,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+	notifier Notifier,
+) PurchaseOrderUseCase {
+	return &purchaseOrderUseCase{
+		poRepo:        poRepo,
+		agreementRepo: agreementRepo,
+		supplierRepo:  supplierRepo,
+		projectRepo:   projectRepo,
+		materialRepo:  materialRepo,
+		periodRepo:    periodRepo,
+		priceUpdates:  priceUpdates,
+		access:        projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+		notifier:      notifier,
+	}
+}
+
+func (u *purchaseOrderUseCase) Create(ctx context.Context, req requests.CreatePurchaseOrderRequest) (*responses.PurchaseOrderResponse, error) {
+	poNumber := strings.TrimSpace(req.PONumber)
+	if poNumber == "" {
+		return nil, errors.New("po number is required")
+	}
+	if len(req.Lines) == 0 {
+		return nil, errors.New("at least one line is required")
+	}
+
+	// Release orders take their supplier and prices from the agreement
+	// and can't order more than it has left.
+	var agreement *models.BlanketAgreementSummary
+	balances := map[string]models.BlanketAgreementBalance{}
+	if req.AgreementID != nil {
+		var err error
+		if agreement, err = u.agreementRepo.GetByID(ctx, *req.AgreementID); err != nil {
+			return nil, err
+		}
+		if req.SupplierID == uuid.Nil {
+			req.SupplierID = agreement.SupplierID
+		} else if req.SupplierID != agreement.SupplierID {
+			return nil, errors.New("supplier does not match the agreement")
+		}
+		items, err := u.agreementRepo.ListBalances(ctx, agreement.AgreementID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			balances[item.MaterialID] = item
+		}
+	}
+
+	supplier, err := u.supplierRepo.GetByID(ctx, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	switch supplier.Status {
+	case models.SupplierStatusBlacklisted:
+		return nil, errors.New("cannot order from a blacklisted supplier")
+	case models.SupplierStatusSuspended:
+		return nil, errors.New("cannot order from a suspended supplier")
+	}
+
+	orderDate := currentDate()
+	if date := strings.TrimSpace(req.OrderDate); date != "" {
+		orderDate, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, errors.New("invalid order date format, expected YYYY-MM-DD")
+		}
+	}
+	if agreement != nil && !agreement.ValidOn(orderDate) {
+		return nil, errors.New("agreement is not valid on the order date")
+	}
+
+	note := strings.TrimSpace(req.Note)
+	po := models.PurchaseOrder{
+		POID:       uuid.New(),
+		PONumber:   poNumber,
+		SupplierID: supplier.SupplierID,
+		Status:     models.PurchaseOrderStatusOpen,
+		OrderDate:  orderDate,
+		Note:       sql.NullString{String: note, Valid: note != ""},
+		CreatedBy:  actorFromContext(ctx),
+		CreatedAt:  time.Now(),
+	}
+	if agreement != nil {
+		po.AgreementID = uuid.NullUUID{UUID: agreement.AgreementID, Valid: true}
+	}
+
+	projects := map[uuid.UUID]*models.Project{}
+	released := map[string]float64{}
+	var lines []models.PurchaseOrderLine
+	var allocations []models.PurchaseOrderAllocation
+	for i, l := range req.Lines {
+		unitPrice := l.UnitPrice
+		if agreement != nil {
+			balance, ok := balances[l.MaterialID]
+			if !ok {
+				return nil, errors.New("material is not on the agreement")
+			}
+			if unitPrice == 0 {
+				unitPrice = balance.UnitPrice
+			} else if unitPrice != balance.UnitPrice {
+				return nil, errors.New("unit price must match the agreement price")
+			}
+		}
+		if unitPrice <= 0 {
+			return nil, errors.New("unit price must be greater than 0")
+		}
+		if len(l.Allocations) == 0 {
+			return nil, errors.New("each line needs at least one project allocation")
+		}
+		if _, err := u.materialRepo.GetByID(ctx, l.MaterialID); err != nil {
+			return nil, err
+		}
+
+		line := models.PurchaseOrderLine{
+			LineID:     uuid.New(),
+			POID:       po.POID,
+			MaterialID: l.MaterialID,
+			UnitPrice:  unitPrice,
+			SortOrder:  i + 1,
+		}
+		lines = append(lines, line)
+
+		allocated := map[uuid.UUID]bool{}
+		for _, a := range l.Allocations {
+			if a.Quantity <= 0 {
+				return nil, errors.New("quantity must be greater than 0")
+			}
+			if allocated[a.ProjectID] {
+				return nil, errors.New("a line can only be allocated to each project once")
+			}
+			allocated[a.ProjectID] = true
+			released[l.MaterialID] += a.Quantity
+
+			project, ok := projects[a.ProjectID]
+			if !ok {
+				if project, err = u.projectRepo.GetByID(ctx, a.ProjectID); err != nil {
+					return nil, err
+				}
+				if err := u.access.check(ctx, a.ProjectID); err != nil {
+					return nil, err
+				}
+				projects[a.ProjectID] = project
+			}
+			// Received costs are charged through the project's BOQ.
+			uses, err := u.poRepo.ProjectUsesMaterial(ctx, a.ProjectID, l.MaterialID)
+			if err != nil {
+				return nil, err
+			}
+			if !uses {
+				return nil, errors.New("material is not in the BOQ of project " + project.Name)
+			}
+
+			address := a.DeliveryAddress
+			if len(address) == 0 || string(address) == "null" {
+				address = project.Address
+			}
+			allocations = append(allocations, models.PurchaseOrderAllocation{
+				AllocationID:    uuid.New(),
+				LineID:          line.LineID,
+				ProjectID:       a.ProjectID,
+				Quantity:        a.Quantity,
+				DeliveryAddress: address,
+			})
+		}
+	}
+
+	for materialID, quantity := range released {
+		if balance, ok := balances[materialID]; ok && quantity > balance.Remaining()+0.00005 {
+			return nil, errors.New("quantity exceeds the agreement's remaining balance")
+		}
+	}
+
+	if err := u.poRepo.Create(ctx, po, lines, allocations); err != nil {
+		return nil, err
+	}
+	return u.GetByID(ctx, po.POID)
+}
+
+func (u *purchaseOrderUseCase) GetByID(ctx context.Context, poID uuid.UUID) (*responses.PurchaseOrderResponse, error) {
+	po, err := u.poRepo.GetByID(ctx, poID)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := u.poRepo.ListLines(ctx, poID)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := u.poRepo.ListAllocations(ctx, poID)
+	if err != nil {
+		return nil, err
+	}
+
+	byLine := map[uuid.UUID][]models.PurchaseOrderAllocation{}
+	for _, a := range allocations {
+		byLine[a.LineID] = append(byLine[a.LineID], a)
+	}
+
+	response := toPurchaseOrderResponse(*po)
+	response.Lines = make([]responses.PurchaseOrderLineResponse, 0, len(lines))
+	for _, l := range lines {
+		line := responses.PurchaseOrderLineResponse{
+			LineID:      l.LineID,
+			MaterialID:  l.MaterialID,
+			UnitPrice:   l.UnitPrice,
+			Allocations: []responses.PurchaseOrderAllocationResponse{},
+		}
+		for _, a := range byLine[l.LineID] {
+			line.Quantity += a.Quantity
+			line.ReceivedQuantity += a.ReceivedQuantity
+			line.Allocations = append(line.Allocations, responses.PurchaseOrderAllocationResponse{
+				AllocationID:     a.AllocationID,
+				ProjectID:        a.ProjectID,
+				Quantity:         a.Quantity,
+				ReceivedQuantity: a.ReceivedQuantity,
+				ReturnedQuantity: a.ReturnedQuantity,
+				Outstanding:      a.Outstanding(),
+				Amount:           roundTo(a.Quantity*l.UnitPrice, 2),
+				DeliveryAddress:  a.DeliveryAddress,
+			})
+		}
+		line.Amount = roundTo(line.Quantity*l.UnitPrice, 2)
+		response.Lines = append(response.Lines, line)
+	}
+	return &response, nil
+}
+
+func (u *purchaseOrderUseCase) List(ctx context.Context, supplierID uuid.NullUUID) ([]responses.PurchaseOrderResponse, error) {
+	orders, err := u.poRepo.List(ctx, supplierID, uuid.NullUUID{})
+	if err != nil {
+		return nil, err
+	}
+	return toPurchaseOrderResponses(orders), nil
+}
+
+func (u *purchaseOrderUseCase) ListByProject(ctx context.Context, projectID uuid.UUID) ([]responses.PurchaseOrderResponse, error) {
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	orders, err := u.poRepo.List(ctx, uuid.NullUUID{}, uuid.NullUUID{UUID: projectID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return toPurchaseOrderResponses(orders), nil
+}
+
+func (u *purchaseOrderUseCase) Receive(ctx context.Context, poID uuid.UUID, req requests.ReceivePurchaseOrderRequest) (*responses.PurchaseOrderResponse, error) {
+	po, err := u.poRepo.GetByID(ctx, poID)
+	if err != nil {
+		return nil, err
+	}
+	switch po.Status {
+	case models.PurchaseOrderStatusCancelled:
+		return nil, errors.New("purchase order is cancelled")
+	case models.PurchaseOrderStatusReceived:
+		return nil, errors.New("purchase order is fully received")
+	}
+	if len(req.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+
+	receivedDate := currentDate()
+	if date := strings.TrimSpace(req.ReceivedDate); date != "" {
+		receivedDate, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, errors.New("invalid received date format, expected YYYY-MM-DD")
+		}
+	}
+	if receivedDate.Before(po.OrderDate) {
+		return nil, errors.New("received date cannot be before the order date")
+	}
+	if err := checkPostingDate(ctx, u.periodRepo, receivedDate); err != nil {
+		return nil, err
+	}
+
+	lines, err := u.poRepo.ListLines(ctx, poID)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := u.poRepo.ListAllocations(ctx, poID)
+	if err != nil {
+		return nil, err
+	}
+	linesByID := map[uuid.UUID]models.PurchaseOrderLine{}
+	for _, l := range lines {
+		linesByID[l.LineID] = l
+	}
+	allocationsByID := map[uuid.UUID]*models.PurchaseOrderAllocation{}
+	for i := range allocations {
+		allocationsByID[allocations[i].AllocationID] = &allocations[i]
+	}
+
+	now := time.Now()
+	receipts := make([]models.PurchaseOrderReceipt, 0, len(req.Items))
+	materials := map[string]bool{}
+	projects := map[uuid.UUID]int{}
+	for _, item := range req.Items {
+		allocation, ok := allocationsByID[item.AllocationID]
+		if !ok {
+			return nil, errors.New("allocation not found on this purchase order")
+		}
+		if item.Quantity <= 0 {
+			return nil, errors.New("quantity must be greater than 0")
+		}
+		if item.Quantity > allocation.Outstanding()+0.00005 {
+			return nil, errors.New("quantity exceeds outstanding")
+		}
+		allocation.ReceivedQuantity += item.Quantity
+
+		line := linesByID[allocation.LineID]
+		materials[line.MaterialID] = true
+		projects[allocation.ProjectID]++
+		receipts = append(receipts, models.PurchaseOrderReceipt{
+			ReceiptID:    uuid.New(),
+			AllocationID: allocation.AllocationID,
+			Quantity:     item.Quantity,
+			Amount:       roundTo(item.Quantity*line.UnitPrice, 2),
+			ReceivedDate: receivedDate,
+			ReceivedBy:   actorFromContext(ctx),
+			CreatedAt:    now,
+		})
+	}
+
+	status := models.PurchaseOrderStatusReceived
+	for _, a := range allocations {
+		if a.Outstanding() > 0.00005 {
+			status = models.PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+
+	if err := u.poRepo.Receive(ctx, poID, status, receipts); err != nil {
+		return nil, err
+	}
+	for materialID := range materials {
+		u.priceUpdates.MaterialPriceChanged(materialID)
+	}
+	// Each project hears about the items delivered to it.
+	for projectID, items := range projects {
+		u.notifier.Notify(projectID, models.NotificationEventPurchaseOrderReceived, "Purchase order received",
+			fmt.Sprintf("%d item(s) on purchase order %s from %s were received on %s.",
+				items, po.PONumber, po.SupplierName, receivedDate.Format("2006-01-02")))
+	}
+	return u.GetByID(ctx, poID)
+}
+
+func (u *purchaseOrderUseCase) Cancel(ctx context.Context, poID uuid.UUID) error {
+	if _, err := u.poRepo.GetByID(ctx, poID); err != nil {
+		return err
+	}
+	return u.poRepo.Cancel(ctx, poID)
+}
+
+func toPurchaseOrderResponses(orders []models.PurchaseOrderSummary) []responses.PurchaseOrderResponse {
+	result := make([]responses.PurchaseOrderResponse, 0, len(orders))
+	for _, po := range orders {
+		result = append(result, toPurchaseOrderResponse(po))
+	}
+	return result
+}
+
+func toPurchaseOrderResponse(po models.PurchaseOrderSummary) responses.PurchaseOrderResponse {
+	return responses.PurchaseOrderResponse{
+		POID:           po.POID,
+		PONumber:       po.PONumber,
+		SupplierID:     po.SupplierID,
+		SupplierName:   po.SupplierName,
+		AgreementID:    nullUUIDPtr(po.AgreementID),
+		Status:         string(po.Status),
+		OrderDate:      po.OrderDate.Format("2006-01-02"),
+		Note:           po.Note.String,
+		TotalAmount:    roundTo(po.TotalAmount, 2),
+		ReceivedAmount: roundTo(po.ReceivedAmount, 2),
+	}
+}
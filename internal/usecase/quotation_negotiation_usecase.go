@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuotationNegotiationUseCase interface {
+	GetNegotiation(ctx context.Context, projectID uuid.UUID) (*responses.QuotationNegotiationResponse, error)
+	// RecordRound and UpdateRound are only allowed while the quotation is a
+	// draft; once approved the negotiation is over.
+	RecordRound(ctx context.Context, projectID uuid.UUID, req requests.QuotationNegotiationRoundRequest) (*responses.QuotationNegotiationRoundResponse, error)
+	UpdateRound(ctx context.Context, projectID uuid.UUID, roundID uuid.UUID, req requests.QuotationNegotiationRoundRequest) (*responses.QuotationNegotiationRoundResponse, error)
+
+	// GetWinLossReport covers quotations for projects created between from
+	// and to (YYYY-MM-DD, inclusive). Both default to the last 12 months.
+	GetWinLossReport(ctx context.Context, from, to string) (*responses.WinLossReportResponse, error)
+}
+
+type quotationNegotiationUseCase struct {
+	negotiationRepo repositories.QuotationNegotiationRepository
+	quotationRepo   repositories.QuotationRepository
+	activityRepo    repositories.ActivityRepository
+}
+
+func NewQuotationNegotiationUsecase(
+	negotiationRepo repositories.QuotationNegotiationRepository,
+	quotationRepo repositories.QuotationRepository,
+	activityRepo repositories.ActivityRepository,
+) QuotationNegotiationUseCase {
+	return &quotationNegotiationUseCase{
+		negotiationRepo: negotiationRepo,
+		quotationRepo:   quotationRepo,
+		activityRepo:    activityRepo,
+	}
+}
+
+func (u *quotationNegotiationUseCase) GetNegotiation(ctx context.Context, projectID uuid.UUID) (*responses.QuotationNegotiationResponse, error) {
+	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quotation == nil {
+		return nil, errors.New("quotation not found")
+	}
+
+	rounds, err := u.negotiationRepo.ListRounds(ctx, quotation.QuotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	quoted := quotation.FinalAmount.Float64
+	response := &responses.QuotationNegotiationResponse{
+		QuotationID:  quotation.QuotationID,
+		QuotedAmount: quoted,
+		Rounds:       make([]responses.QuotationNegotiationRoundResponse, len(rounds)),
+	}
+	for i, round := range rounds {
+		response.Rounds[i] = toNegotiationRoundResponse(round, quoted)
+	}
+	return response, nil
+}
+
+func (u *quotationNegotiationUseCase) RecordRound(ctx context.Context, projectID uuid.UUID, req requests.QuotationNegotiationRoundRequest) (*responses.QuotationNegotiationRoundResponse, error) {
+	quotation, err := u.draftQuotation(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	offeredOn, err := validateNegotiationRound(req)
+	if err != nil {
+		return nil, err
+	}
+
+	round := &models.QuotationNegotiationRound{
+		RoundID:          uuid.New(),
+		QuotationID:      quotation.QuotationID,
+		CounterOffer:     req.CounterOffer,
+		OfferedOn:        offeredOn,
+		ClientNotes:      optionalString(req.ClientNotes),
+		InternalResponse: optionalString(req.InternalResponse),
+		CreatedAt:        time.Now(),
+	}
+	if err := u.negotiationRepo.CreateRound(ctx, round); err != nil {
+		return nil, err
+	}
+
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType: models.ActivityEntityQuotation,
+		EntityID:   quotation.QuotationID,
+		ProjectID:  uuid.NullUUID{UUID: projectID, Valid: true},
+		EventType:  "quotation_counter_offer",
+		Description: fmt.Sprintf("Negotiation round %d: client counter-offered %.2f THB",
+			round.RoundNumber, round.CounterOffer),
+		OccurredAt: round.CreatedAt,
+	})
+
+	response := toNegotiationRoundResponse(*round, quotation.FinalAmount.Float64)
+	return &response, nil
+}
+
+func (u *quotationNegotiationUseCase) UpdateRound(ctx context.Context, projectID uuid.UUID, roundID uuid.UUID, req requests.QuotationNegotiationRoundRequest) (*responses.QuotationNegotiationRoundResponse, error) {
+	quotation, err := u.draftQuotation(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	round, err := u.negotiationRepo.GetRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.QuotationID != quotation.QuotationID {
+		return nil, errors.New("negotiation round not found")
+	}
+
+	offeredOn, err := validateNegotiationRound(req)
+	if err != nil {
+		return nil, err
+	}
+
+	round.CounterOffer = req.CounterOffer
+	round.OfferedOn = offeredOn
+	round.ClientNotes = optionalString(req.ClientNotes)
+	round.InternalResponse = optionalString(req.InternalResponse)
+	round.UpdatedAt.Time, round.UpdatedAt.Valid = time.Now(), true
+	if err := u.negotiationRepo.UpdateRound(ctx, *round); err != nil {
+		return nil, err
+	}
+
+	response := toNegotiationRoundResponse(*round, quotation.FinalAmount.Float64)
+	return &response, nil
+}
+
+func (u *quotationNegotiationUseCase) GetWinLossReport(ctx context.Context, from, to string) (*responses.WinLossReportResponse, error) {
+	end := time.Now()
+	if to != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", to, time.Local)
+		if err != nil {
+			return nil, errors.New("invalid to date")
+		}
+		end = parsed
+	}
+	start := end.AddDate(-1, 0, 0)
+	if from != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", from, time.Local)
+		if err != nil {
+			return nil, errors.New("invalid from date")
+		}
+		start = parsed
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.Local)
+	if end.Before(start) {
+		return nil, errors.New("from date must not be after to date")
+	}
+
+	rows, err := u.negotiationRepo.GetOutcomes(ctx, start, end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &responses.WinLossReportResponse{
+		From:       start.Format("2006-01-02"),
+		To:         end.Format("2006-01-02"),
+		Quotations: make([]responses.WinLossQuotation, len(rows)),
+	}
+	summaries := map[models.QuotationOutcome]*responses.WinLossSummary{}
+	gaps := map[models.QuotationOutcome]float64{}
+	rounds := map[models.QuotationOutcome]int{}
+	outcomes := []models.QuotationOutcome{models.QuotationOutcomeWon, models.QuotationOutcomeLost, models.QuotationOutcomeOpen}
+	for _, outcome := range outcomes {
+		summaries[outcome] = &responses.WinLossSummary{Outcome: string(outcome)}
+	}
+
+	for i, row := range rows {
+		quotation := responses.WinLossQuotation{
+			QuotationID:  row.QuotationID,
+			ProjectID:    row.ProjectID,
+			ProjectName:  row.ProjectName,
+			ClientName:   row.ClientName,
+			Outcome:      string(row.Outcome),
+			QuotedAmount: row.QuotedAmount,
+			Rounds:       row.Rounds,
+		}
+		summary := summaries[row.Outcome]
+		summary.Count++
+		summary.QuotedAmount += row.QuotedAmount
+		if row.LastCounterOffer.Valid {
+			gap := negotiationGapPercent(row.QuotedAmount, row.LastCounterOffer.Float64)
+			quotation.LastCounterOffer = &row.LastCounterOffer.Float64
+			quotation.GapPercent = &gap
+			summary.NegotiatedCount++
+			rounds[row.Outcome] += row.Rounds
+			gaps[row.Outcome] += gap
+		}
+		report.Quotations[i] = quotation
+	}
+
+	for _, outcome := range outcomes {
+		summary := summaries[outcome]
+		summary.QuotedAmount = roundTo(summary.QuotedAmount, 2)
+		if summary.NegotiatedCount > 0 {
+			summary.AverageRounds = roundTo(float64(rounds[outcome])/float64(summary.NegotiatedCount), 2)
+			summary.AverageGapPercent = roundTo(gaps[outcome]/float64(summary.NegotiatedCount), 2)
+		}
+		report.Summary = append(report.Summary, *summary)
+	}
+	won, lost := summaries[models.QuotationOutcomeWon].Count, summaries[models.QuotationOutcomeLost].Count
+	if won+lost > 0 {
+		report.WinRate = roundTo(float64(won)*100/float64(won+lost), 2)
+	}
+
+	return report, nil
+}
+
+func (u *quotationNegotiationUseCase) draftQuotation(ctx context.Context, projectID uuid.UUID) (*models.Quotation, error) {
+	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quotation == nil {
+		return nil, errors.New("quotation not found")
+	}
+	if quotation.Status != models.QuotationStatusDraft {
+		return nil, errors.New("can only negotiate quotation in draft status")
+	}
+	return quotation, nil
+}
+
+func validateNegotiationRound(req requests.QuotationNegotiationRoundRequest) (time.Time, error) {
+	if req.CounterOffer <= 0 {
+		return time.Time{}, errors.New("counter offer must be greater than 0")
+	}
+	offeredOn, err := time.ParseInLocation("2006-01-02", req.OfferedOn, time.Local)
+	if err != nil {
+		return time.Time{}, errors.New("invalid offered_on, expected YYYY-MM-DD")
+	}
+	if offeredOn.After(time.Now()) {
+		return time.Time{}, errors.New("offered_on must not be in the future")
+	}
+	return offeredOn, nil
+}
+
+func negotiationGapPercent(quoted, counterOffer float64) float64 {
+	if quoted <= 0 {
+		return 0
+	}
+	return roundTo((quoted-counterOffer)*100/quoted, 2)
+}
+
+func toNegotiationRoundResponse(round models.QuotationNegotiationRound, quoted float64) responses.QuotationNegotiationRoundResponse {
+	response := responses.QuotationNegotiationRoundResponse{
+		RoundID:          round.RoundID,
+		RoundNumber:      round.RoundNumber,
+		CounterOffer:     round.CounterOffer,
+		OfferedOn:        round.OfferedOn.Format("2006-01-02"),
+		ClientNotes:      round.ClientNotes.String,
+		InternalResponse: round.InternalResponse.String,
+		GapPercent:       negotiationGapPercent(quoted, round.CounterOffer),
+		CreatedAt:        round.CreatedAt,
+	}
+	if round.UpdatedAt.Valid {
+		response.UpdatedAt = &round.UpdatedAt.Time
+	}
+	return response
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuotationSectionUseCase interface {
+	GetSections(ctx context.Context, projectID uuid.UUID) ([]responses.QuotationSectionResponse, error)
+	// UpdateSections only touches the kinds in the request and only while
+	// the quotation is a draft.
+	UpdateSections(ctx context.Context, projectID uuid.UUID, req requests.UpdateQuotationSectionsRequest) ([]responses.QuotationSectionResponse, error)
+
+	CreateSnippet(ctx context.Context, req requests.QuotationSnippetRequest) (*responses.QuotationSnippetResponse, error)
+	ListSnippets(ctx context.Context, kind string) ([]responses.QuotationSnippetResponse, error)
+	UpdateSnippet(ctx context.Context, snippetID uuid.UUID, req requests.QuotationSnippetRequest) error
+	DeleteSnippet(ctx context.Context, snippetID uuid.UUID) error
+}
+
+type quotationSectionUseCase struct {
+	sectionRepo   repositories.QuotationSectionRepository
+	quotationRepo repositories.QuotationRepository
+}
+
+func NewQuotationSectionUsecase(
+	sectionRepo repositories.QuotationSectionRepository,
+	quotationRepo repositories.QuotationRepository,
+) QuotationSectionUseCase {
+	return &quotationSectionUseCase{
+		sectionRepo:   sectionRepo,
+		quotationRepo: quotationRepo,
+	}
+}
+
+func (u *quotationSectionUseCase) GetSections(ctx context.Context, projectID uuid.UUID) ([]responses.QuotationSectionResponse, error) {
+	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quotation == nil {
+		return nil, errors.New("quotation not found")
+	}
+
+	sections, err := u.sectionRepo.ListSections(ctx, quotation.QuotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toQuotationSectionResponses(sections), nil
+}
+
+func (u *quotationSectionUseCase) UpdateSections(ctx context.Context, projectID uuid.UUID, req requests.UpdateQuotationSectionsRequest) ([]responses.QuotationSectionResponse, error) {
+	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quotation == nil {
+		return nil, errors.New("quotation not found")
+	}
+	if quotation.Status != models.QuotationStatusDraft {
+		return nil, errors.New("can only update sections for quotation in draft status")
+	}
+
+	now := time.Now()
+	var save []models.QuotationSection
+	var remove []models.QuotationSectionKind
+	for _, s := range req.Sections {
+		kind := models.QuotationSectionKind(s.Kind)
+		if !kind.Valid() {
+			return nil, errors.New("invalid section kind")
+		}
+
+		content := strings.TrimSpace(s.Content)
+		if content == "" && s.SnippetID != nil {
+			snippet, err := u.sectionRepo.GetSnippetByID(ctx, *s.SnippetID)
+			if err != nil {
+				return nil, err
+			}
+			if snippet.Kind != kind {
+				return nil, errors.New("snippet is for a different section")
+			}
+			content = snippet.Content
+		}
+
+		if content == "" {
+			remove = append(remove, kind)
+			continue
+		}
+		save = append(save, models.QuotationSection{
+			QuotationID: quotation.QuotationID,
+			Kind:        kind,
+			Content:     content,
+			UpdatedAt:   now,
+		})
+	}
+
+	if err := u.sectionRepo.SaveSections(ctx, quotation.QuotationID, save, remove); err != nil {
+		return nil, err
+	}
+
+	sections, err := u.sectionRepo.ListSections(ctx, quotation.QuotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toQuotationSectionResponses(sections), nil
+}
+
+func (u *quotationSectionUseCase) CreateSnippet(ctx context.Context, req requests.QuotationSnippetRequest) (*responses.QuotationSnippetResponse, error) {
+	snippet := &models.QuotationSnippet{
+		SnippetID: uuid.New(),
+		CreatedAt: time.Now(),
+	}
+	if err := applySnippetFields(snippet, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.sectionRepo.CreateSnippet(ctx, snippet); err != nil {
+		return nil, err
+	}
+
+	response := toQuotationSnippetResponse(snippet)
+	return &response, nil
+}
+
+func (u *quotationSectionUseCase) ListSnippets(ctx context.Context, kind string) ([]responses.QuotationSnippetResponse, error) {
+	if kind != "" && !models.QuotationSectionKind(kind).Valid() {
+		return nil, errors.New("invalid section kind")
+	}
+
+	snippets, err := u.sectionRepo.ListSnippets(ctx, models.QuotationSectionKind(kind))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.QuotationSnippetResponse, 0, len(snippets))
+	for i := range snippets {
+		result = append(result, toQuotationSnippetResponse(&snippets[i]))
+	}
+	return result, nil
+}
+
+func (u *quotationSectionUseCase) UpdateSnippet(ctx context.Context, snippetID uuid.UUID, req requests.QuotationSnippetRequest) error {
+	snippet, err := u.sectionRepo.GetSnippetByID(ctx, snippetID)
+	if err != nil {
+		return err
+	}
+	if err := applySnippetFields(snippet, req); err != nil {
+		return err
+	}
+	snippet.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return u.sectionRepo.UpdateSnippet(ctx, snippet)
+}
+
+func (u *quotationSectionUseCase) DeleteSnippet(ctx context.Context, snippetID uuid.UUID) error {
+	return u.sectionRepo.DeleteSnippet(ctx, snippetID)
+}
+
+func applySnippetFields(snippet *models.QuotationSnippet, req requests.QuotationSnippetRequest) error {
+	kind := models.QuotationSectionKind(req.Kind)
+	if !kind.Valid() {
+		return errors.New("invalid section kind")
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return errors.New("snippet title is required")
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return errors.New("snippet content is required")
+	}
+
+	snippet.Kind = kind
+	snippet.Title = title
+	snippet.Content = content
+	return nil
+}
+
+// toQuotationSectionResponses orders sections as they're printed.
+func toQuotationSectionResponses(sections []models.QuotationSection) []responses.QuotationSectionResponse {
+	result := make([]responses.QuotationSectionResponse, 0, len(sections))
+	for _, kind := range models.QuotationSectionKinds {
+		for _, s := range sections {
+			if s.Kind != kind {
+				continue
+			}
+			result = append(result, responses.QuotationSectionResponse{
+				Kind:      string(s.Kind),
+				Title:     s.Kind.Title(),
+				Content:   s.Content,
+				UpdatedAt: s.UpdatedAt,
+			})
+		}
+	}
+	return result
+}
+
+func toQuotationSnippetResponse(snippet *models.QuotationSnippet) responses.QuotationSnippetResponse {
+	response := responses.QuotationSnippetResponse{
+		SnippetID: snippet.SnippetID,
+		Kind:      string(snippet.Kind),
+		Title:     snippet.Title,
+		Content:   snippet.Content,
+		CreatedAt: snippet.CreatedAt,
+	}
+	if snippet.UpdatedAt.Valid {
+		response.UpdatedAt = &snippet.UpdatedAt.Time
+	}
+	return response
+}
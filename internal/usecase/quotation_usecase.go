@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"boonkosang/internal/domain/models"
+	"boonkosang/internal/infrastructure/pdf"
+	"boonkosang/internal/infrastructure/thaidate"
 	"boonkosang/internal/repositories"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/responses"
@@ -9,6 +11,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,25 +21,66 @@ import (
 
 type QuotationUsecase interface {
 	CreateOrGetQuotation(ctx context.Context, projectID uuid.UUID) (*responses.QuotationResponse, error)
-	ApproveQuotation(ctx context.Context, projectID uuid.UUID) error
-	ExportQuotation(ctx context.Context, projectID uuid.UUID) (*responses.QuotationExportData, error)
+	// ApproveQuotation warns, without blocking, when the quotation total is
+	// more than the client's remaining credit.
+	ApproveQuotation(ctx context.Context, projectID uuid.UUID, req requests.ApproveQuotationRequest) (*responses.QuotationApprovalResponse, error)
+	// ExportQuotation returns the quotation with its labels in language,
+	// worded as userID's company has set them (see DocumentLabelUseCase).
+	ExportQuotation(ctx context.Context, projectID uuid.UUID, language string, userID uuid.UUID) (*responses.QuotationExportData, error)
+	// ExportQuotationPDF renders the exported quotation with its cover
+	// letter, scope of work, exclusions and assumptions. The PDF fonts have
+	// no Thai glyphs, so only English is supported.
+	ExportQuotationPDF(ctx context.Context, projectID uuid.UUID, language string, userID uuid.UUID) ([]byte, error)
 
 	UpdateProjectSellingPrice(ctx context.Context, req requests.UpdateProjectSellingPriceRequest) error
 }
 
 type quotationUsecase struct {
 	quotationRepo repositories.QuotationRepository
+	projectRepo   repositories.ProjectRepository
+	clientRepo    repositories.ClientRepository
+	userRepo      repositories.UserRepository
+	activityRepo  repositories.ActivityRepository
+	roundingRepo  repositories.RoundingRepository
+	sectionRepo   repositories.QuotationSectionRepository
+	labelUseCase  DocumentLabelUseCase
+	listPriceRepo repositories.MaterialListPriceRepository
+	access        projectAccess
+	notifier      Notifier
 }
 
-func NewQuotationUsecase(quotationRepo repositories.QuotationRepository) QuotationUsecase {
+func NewQuotationUsecase(
+	quotationRepo repositories.QuotationRepository,
+	projectRepo repositories.ProjectRepository,
+	clientRepo repositories.ClientRepository,
+	userRepo repositories.UserRepository,
+	activityRepo repositories.ActivityRepository,
+	roundingRepo repositories.RoundingRepository,
+	sectionRepo repositories.QuotationSectionRepository,
+	labelUseCase DocumentLabelUseCase,
+	listPriceRepo repositories.MaterialListPriceRepository,
+	memberRepo repositories.ProjectMemberRepository,
+	notifier Notifier,
+) QuotationUsecase {
 	return &quotationUsecase{
 		quotationRepo: quotationRepo,
+		projectRepo:   projectRepo,
+		clientRepo:    clientRepo,
+		userRepo:      userRepo,
+		activityRepo:  activityRepo,
+		roundingRepo:  roundingRepo,
+		sectionRepo:   sectionRepo,
+		labelUseCase:  labelUseCase,
+		listPriceRepo: listPriceRepo,
+		access:        projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+		notifier:      notifier,
 	}
 }
 func (u *quotationUsecase) buildQuotationResponse(
 	quotation *models.Quotation,
 	jobs []models.QuotationJob,
 	costs []models.QuotationGeneralCost,
+	policy models.RoundingPolicy,
 ) *responses.QuotationResponse {
 	response := &responses.QuotationResponse{
 		QuotationID: quotation.QuotationID,
@@ -60,8 +106,11 @@ func (u *quotationUsecase) buildQuotationResponse(
 			TotalMaterialPrice: job.TotalMaterialPrice.Float64,
 			Total:              job.Total.Float64,
 			OverallCost:        job.OverallCost.Float64,
-			TotalSellingPrice:  job.TotalSellingPrice.Float64,
+			TotalSellingPrice:  policy.Line(job.TotalSellingPrice.Float64),
+			TransportCost:      policy.Line(job.TransportCost),
+			TaxTreatment:       string(job.TaxTreatment),
 		}
+		response.TransportCost += jobDetail.TransportCost
 
 		if job.SellingPrice.Valid {
 			jobDetail.SellingPrice = job.SellingPrice.Float64
@@ -100,6 +149,10 @@ func getValidTime(nullTime sql.NullTime) time.Time {
 }
 
 func (u *quotationUsecase) CreateOrGetQuotation(ctx context.Context, projectID uuid.UUID) (*responses.QuotationResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
 	// Check BOQ status
 	boqStatus, err := u.quotationRepo.CheckBOQStatus(ctx, projectID)
 	if err != nil {
@@ -122,6 +175,12 @@ func (u *quotationUsecase) CreateOrGetQuotation(ctx context.Context, projectID u
 		if err != nil {
 			return nil, err
 		}
+
+		// A new quotation is priced at the list prices in effect on the
+		// day it's created.
+		if _, err := u.listPriceRepo.ApplyToProject(ctx, projectID, currentDate()); err != nil {
+			return nil, err
+		}
 	}
 
 	// Get jobs and costs
@@ -135,51 +194,179 @@ func (u *quotationUsecase) CreateOrGetQuotation(ctx context.Context, projectID u
 		return nil, err
 	}
 
+	policy, err := u.roundingRepo.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build response
-	response := u.buildQuotationResponse(quotation, jobs, costs)
+	response := u.buildQuotationResponse(quotation, jobs, costs, *policy)
 
 	if len(jobs) > 0 {
-		response.SellingGeneralCost = jobs[0].SellingGeneralCost.Float64
+		response.SellingGeneralCost = policy.Line(jobs[0].SellingGeneralCost.Float64)
 		response.TaxPercentage = jobs[0].TaxPercentage.Float64
 	}
+
+	// General cost and transport are standard-rated
+	lines := []models.TaxedLine{{Amount: response.SellingGeneralCost, Treatment: models.TaxTreatmentStandard}}
+	for _, job := range response.Jobs {
+		lines = append(lines,
+			models.TaxedLine{Amount: job.TotalSellingPrice, Treatment: models.TaxTreatment(job.TaxTreatment)},
+			models.TaxedLine{Amount: job.TransportCost, Treatment: models.TaxTreatmentStandard},
+		)
+	}
+	totals := policy.Totals(lines, response.TaxPercentage)
+	response.TaxableAmount = totals.Bases.Standard
+	response.ZeroRatedAmount = totals.Bases.ZeroRated
+	response.ExemptAmount = totals.Bases.Exempt
+	response.TaxAmount = totals.TaxAmount
+	response.RoundingAdjustment = totals.RoundingAdjustment
+	response.GrandTotal = totals.GrandTotal
 	return response, nil
 }
 
-func (u *quotationUsecase) ApproveQuotation(ctx context.Context, projectID uuid.UUID) error {
+func (u *quotationUsecase) ApproveQuotation(ctx context.Context, projectID uuid.UUID, req requests.ApproveQuotationRequest) (*responses.QuotationApprovalResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
 	// Validate approval conditions
 	err := u.quotationRepo.ValidateApproval(ctx, projectID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	client, err := u.checkCreditHold(ctx, projectID, req)
+	if err != nil {
+		return nil, err
 	}
 
 	// If validation passes, approve the quotation
 	err = u.quotationRepo.ApproveQuotation(ctx, projectID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get updated quotation details for response
 	quotation, err := u.quotationRepo.GetByProjectID(ctx, projectID)
 	if err != nil {
-		return fmt.Errorf("failed to get updated quotation: %w", err)
+		return nil, fmt.Errorf("failed to get updated quotation: %w", err)
+	}
+
+	description := "Quotation approved"
+	if req.OverrideCreditHold {
+		description = "Quotation approved with credit hold override"
 	}
+	recordActivity(ctx, u.activityRepo, models.ActivityEvent{
+		EntityType:  models.ActivityEntityQuotation,
+		EntityID:    quotation.QuotationID,
+		ProjectID:   uuid.NullUUID{UUID: projectID, Valid: true},
+		EventType:   "quotation_approved",
+		Description: description,
+	})
 
 	jobs, err := u.quotationRepo.GetQuotationJobs(ctx, projectID)
 	if err != nil {
-		return fmt.Errorf("failed to get quotation jobs: %w", err)
+		return nil, fmt.Errorf("failed to get quotation jobs: %w", err)
 	}
 
 	costs, err := u.quotationRepo.GetQuotationGeneralCosts(ctx, projectID)
 	if err != nil {
-		return fmt.Errorf("failed to get quotation costs: %w", err)
+		return nil, fmt.Errorf("failed to get quotation costs: %w", err)
 	}
 
 	// Build and return response
-	_ = u.buildQuotationResponse(quotation, jobs, costs)
-	return nil
+	built := u.buildQuotationResponse(quotation, jobs, costs, models.DefaultRoundingPolicy())
+	response := &responses.QuotationApprovalResponse{
+		QuotationID: quotation.QuotationID,
+		GrandTotal:  built.GrandTotal,
+		Warnings:    []string{},
+	}
+	u.notifier.Notify(projectID, models.NotificationEventQuotationApproved, "Quotation approved",
+		fmt.Sprintf("The quotation was approved with a grand total of %.2f.", built.GrandTotal))
+
+	warning, err := u.checkCreditLimit(ctx, client, built.GrandTotal)
+	if err != nil {
+		return nil, err
+	}
+	if warning != "" {
+		log.Printf("credit limit warning for client %s on project %s: %s", client.ClientID, projectID, warning)
+		response.Warnings = append(response.Warnings, warning)
+	}
+	return response, nil
+}
+
+// checkCreditHold blocks approval for clients on credit hold unless an owner
+// explicitly overrides it. It returns the project's client.
+func (u *quotationUsecase) checkCreditHold(ctx context.Context, projectID uuid.UUID, req requests.ApproveQuotationRequest) (*models.Client, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	client, err := u.clientRepo.GetByID(ctx, project.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !client.CreditHold {
+		return client, nil
+	}
+
+	if !req.OverrideCreditHold {
+		return nil, errors.New("client is on credit hold")
+	}
+
+	if req.UserID == nil {
+		return nil, errors.New("only owners can override a credit hold")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, *req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.Role.IsAdmin() {
+		return nil, errors.New("only owners can override a credit hold")
+	}
+
+	log.Printf("credit hold on client %s overridden by %s for project %s", client.ClientID, user.Username, projectID)
+	return client, nil
 }
 
-func (u *quotationUsecase) ExportQuotation(ctx context.Context, projectID uuid.UUID) (*responses.QuotationExportData, error) {
+// checkCreditLimit describes how far total goes past the client's credit
+// limit less what it still owes on invoices, or returns "" when it fits
+// or the client has no limit.
+func (u *quotationUsecase) checkCreditLimit(ctx context.Context, client *models.Client, total float64) (string, error) {
+	if !client.CreditLimit.Valid {
+		return "", nil
+	}
+
+	outstanding, err := u.clientRepo.OutstandingBalance(ctx, client.ClientID)
+	if err != nil {
+		return "", err
+	}
+
+	remaining := roundTo(client.CreditLimit.Float64-outstanding, 2)
+	if total <= remaining {
+		return "", nil
+	}
+	return fmt.Sprintf("quotation total %.2f exceeds the client's remaining credit of %.2f (limit %.2f, outstanding %.2f)",
+		total, remaining, client.CreditLimit.Float64, outstanding), nil
+}
+
+func (u *quotationUsecase) ExportQuotation(ctx context.Context, projectID uuid.UUID, language string, userID uuid.UUID) (*responses.QuotationExportData, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	format, err := u.labelUseCase.Resolve(ctx, language, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	boqStatus, err := u.quotationRepo.CheckBOQStatus(ctx, projectID)
 	if err != nil {
@@ -206,6 +393,12 @@ func (u *quotationUsecase) ExportQuotation(ctx context.Context, projectID uuid.U
 
 	exportData.FormatFinalAmount()
 
+	sections, err := u.sectionRepo.ListSections(ctx, exportData.QuotationID)
+	if err != nil {
+		return nil, err
+	}
+	exportData.Sections = toQuotationSectionResponses(sections)
+
 	//format job details
 	for job := range exportData.JobDetails {
 		jobDetail := &exportData.JobDetails[job]
@@ -213,10 +406,108 @@ func (u *quotationUsecase) ExportQuotation(ctx context.Context, projectID uuid.U
 		jobDetail.FormatAmount()
 	}
 
+	exportData.Language = string(format.Language)
+	exportData.Labels = format.Labels
+	exportData.DateEra = string(format.DateEra)
+	exportData.Dates = documentDates(format.DateEra, map[string]time.Time{
+		"valid_date": exportData.ValidDate,
+	})
 	return exportData, nil
 }
 
+func (u *quotationUsecase) ExportQuotationPDF(ctx context.Context, projectID uuid.UUID, language string, userID uuid.UUID) ([]byte, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	data, err := u.ExportQuotation(ctx, projectID, language, userID)
+	if err != nil {
+		return nil, err
+	}
+	if data.Language != string(models.DocumentLanguageEnglish) {
+		return nil, errors.New("pdf documents can only be rendered in English")
+	}
+	label := data.Labels
+
+	section := func(kind models.QuotationSectionKind) string {
+		for _, s := range data.Sections {
+			if s.Kind == string(kind) {
+				return s.Content
+			}
+		}
+		return ""
+	}
+
+	doc := pdf.New()
+	doc.Heading(label["quotation"])
+	doc.Field(label["project"], data.ProjectName)
+	doc.Field(label["client"], data.ClientName)
+	if !data.ValidDate.IsZero() {
+		doc.Field(label["valid_until"], thaidate.Format(data.ValidDate, "2 January 2006", data.DateEra == string(models.DateEraBuddhist)))
+	}
+	doc.Gap()
+
+	if letter := section(models.QuotationSectionCoverLetter); letter != "" {
+		doc.Text(letter)
+		doc.Gap()
+	}
+
+	if scope := section(models.QuotationSectionScopeOfWork); scope != "" {
+		doc.Bold(label[string(models.QuotationSectionScopeOfWork)])
+		doc.Text(scope)
+		doc.Gap()
+	}
+
+	doc.Bold(label["prices"])
+	for i, job := range data.JobDetails {
+		line := fmt.Sprintf("%d. %s: %s %s x %.2f = %.2f", i+1, job.Name,
+			strconv.FormatFloat(job.Quantity, 'f', -1, 64), job.Unit, job.SellingPrice.Float64, job.Amount.Float64)
+		if job.TransportCost > 0 {
+			line += fmt.Sprintf(" (%s %.2f)", strings.ToLower(label["transport"]), job.TransportCost)
+		}
+		doc.Item(line)
+	}
+	if data.SellingGeneralCost > 0 {
+		doc.Item(fmt.Sprintf("%d. %s = %.2f", len(data.JobDetails)+1, label["general_costs"], data.SellingGeneralCost))
+	}
+	doc.Gap()
+
+	doc.Field(label["subtotal"], fmt.Sprintf("%.2f", data.SubTotal))
+	if data.ZeroRatedAmount > 0 {
+		doc.Field(label["zero_rated"], fmt.Sprintf("%.2f", data.ZeroRatedAmount))
+	}
+	if data.ExemptAmount > 0 {
+		doc.Field(label["vat_exempt"], fmt.Sprintf("%.2f", data.ExemptAmount))
+	}
+	doc.Field(fmt.Sprintf("%s %s%%", label["vat"], strconv.FormatFloat(data.TaxPercentage, 'f', -1, 64)), fmt.Sprintf("%.2f", data.TaxAmount))
+	if data.RoundingAdjustment != 0 {
+		doc.Field(label["rounding"], fmt.Sprintf("%.2f", data.RoundingAdjustment))
+	}
+	if data.FinalAmount.Valid {
+		doc.Bold(fmt.Sprintf("%s: %.2f %s", label["total"], data.FinalAmount.Float64, label["currency"]))
+	}
+
+	for _, kind := range []models.QuotationSectionKind{models.QuotationSectionExclusions, models.QuotationSectionAssumptions} {
+		content := section(kind)
+		if content == "" {
+			continue
+		}
+		doc.Gap()
+		doc.Bold(label[string(kind)])
+		for _, item := range strings.Split(content, "\n") {
+			if item = strings.TrimSpace(item); item != "" {
+				doc.Item("- " + strings.TrimLeft(item, "-* "))
+			}
+		}
+	}
+
+	return doc.Bytes(), nil
+}
+
 func (u *quotationUsecase) UpdateProjectSellingPrice(ctx context.Context, req requests.UpdateProjectSellingPriceRequest) error {
+	if err := u.access.check(ctx, req.ProjectID); err != nil {
+		return err
+	}
 
 	boqStatus, err := u.quotationRepo.CheckBOQStatus(ctx, req.ProjectID)
 	if err != nil {
@@ -260,6 +551,12 @@ func validateSellingPriceRequest(req requests.UpdateProjectSellingPriceRequest)
 		if job.SellingPrice <= 0 {
 			return fmt.Errorf("selling price for job %s must be greater than 0", job.JobID)
 		}
+
+		switch models.TaxTreatment(job.TaxTreatment) {
+		case "", models.TaxTreatmentStandard, models.TaxTreatmentZeroRated, models.TaxTreatmentExempt:
+		default:
+			return fmt.Errorf("invalid tax treatment for job %s", job.JobID)
+		}
 	}
 
 	return nil
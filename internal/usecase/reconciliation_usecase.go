@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+type ReconciliationUsecase interface {
+	// GetProjectReconciliation ties a project's estimated cost, quoted
+	// price, contract value with change orders, invoices and payments
+	// together and flags where they disagree.
+	GetProjectReconciliation(ctx context.Context, projectID uuid.UUID) (*responses.ProjectReconciliationResponse, error)
+}
+
+type reconciliationUsecase struct {
+	reconciliationRepo repositories.ReconciliationRepository
+	contractRepo       repositories.ContractRepository
+	access             projectAccess
+}
+
+func NewReconciliationUsecase(
+	reconciliationRepo repositories.ReconciliationRepository,
+	contractRepo repositories.ContractRepository,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) ReconciliationUsecase {
+	return &reconciliationUsecase{
+		reconciliationRepo: reconciliationRepo,
+		contractRepo:       contractRepo,
+		access:             projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *reconciliationUsecase) GetProjectReconciliation(ctx context.Context, projectID uuid.UUID) (*responses.ProjectReconciliationResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	totals, err := u.reconciliationRepo.GetTotals(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := u.contractRepo.ListDocuments(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices, err := u.reconciliationRepo.ListInvoices(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := currentDate()
+	response := &responses.ProjectReconciliationResponse{
+		ProjectID: projectID,
+		AsOf:      asOf.Format("2006-01-02"),
+		Estimate: responses.ReconciliationEstimate{
+			BOQID:             nullUUIDPtr(totals.BOQID),
+			Status:            totals.BOQStatus.String,
+			EstimatedCost:     roundTo(totals.EstimatedCost, 2),
+			UnpricedMaterials: totals.UnpricedMaterials,
+		},
+		Quotation: responses.ReconciliationQuotation{
+			QuotationID:     nullUUIDPtr(totals.QuotationID),
+			Status:          totals.QuotationStatus.String,
+			SellingPrice:    roundTo(totals.SellingPrice, 2),
+			TaxPercentage:   totals.TaxPercentage,
+			EstimatedMargin: roundTo(calculateMargin(totals.SellingPrice-totals.EstimatedCost, totals.SellingPrice), 2),
+		},
+		Contract: responses.ReconciliationContract{
+			ChangeOrders: []responses.ReconciliationChangeOrder{},
+		},
+		Billing: responses.ReconciliationBilling{
+			Invoices: []responses.ReconciliationInvoice{},
+		},
+		Issues: []responses.ReconciliationIssue{},
+	}
+	issue := func(code, format string, args ...any) {
+		response.Issues = append(response.Issues, responses.ReconciliationIssue{
+			Code:    code,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	// The quotation's final amount is what the client was asked to pay;
+	// before it is set, work it out from the selling price.
+	quoted := totals.SellingPrice + calculateTaxAmount(totals.SellingPrice, totals.TaxPercentage)
+	if totals.FinalAmount.Valid {
+		quoted = totals.FinalAmount.Float64
+	}
+	response.Quotation.QuotedAmount = roundTo(quoted, 2)
+
+	if !totals.BOQID.Valid {
+		issue("missing_boq", "project has no BOQ")
+	} else if totals.UnpricedMaterials > 0 {
+		issue("unpriced_materials", "%d material lines have no estimated price, so the estimated cost is understated", totals.UnpricedMaterials)
+	}
+	if !totals.QuotationID.Valid {
+		issue("missing_quotation", "project has no quotation")
+	} else if totals.BOQID.Valid && totals.SellingPrice < totals.EstimatedCost {
+		issue("quoted_below_cost", "selling price %.2f is below the estimated cost %.2f", totals.SellingPrice, totals.EstimatedCost)
+	}
+
+	agreed := response.Quotation.QuotedAmount
+	if position := models.CurrentContract(documents, asOf); position != nil {
+		contract := &response.Contract
+		contract.InForce = true
+		contract.BaseValue = roundTo(position.Base.ContractValue.Float64, 2)
+		for _, addendum := range position.Addenda {
+			contract.ChangeOrders = append(contract.ChangeOrders, responses.ReconciliationChangeOrder{
+				DocumentID:    addendum.DocumentID,
+				Title:         addendum.Title,
+				EffectiveDate: addendum.EffectiveDate.Format("2006-01-02"),
+				Amount:        addendum.ValueAdjustment,
+			})
+			contract.ChangeOrderTotal += addendum.ValueAdjustment
+		}
+		contract.ChangeOrderTotal = roundTo(contract.ChangeOrderTotal, 2)
+		contract.ContractValue = position.Value
+		agreed = position.Value
+
+		if totals.QuotationID.Valid && !sameAmount(contract.BaseValue, response.Quotation.QuotedAmount) {
+			issue("contract_differs_from_quotation", "contract base value %.2f differs from the quoted amount %.2f", contract.BaseValue, response.Quotation.QuotedAmount)
+		}
+	}
+
+	billing := &response.Billing
+	billing.AgreedValue = agreed
+	for _, invoice := range invoices {
+		item := responses.ReconciliationInvoice{
+			InvoiceID:    invoice.InvoiceID,
+			PhaseID:      nullUUIDPtr(invoice.PhaseID),
+			LateInterest: roundTo(invoice.LateInterest, 2),
+			Paid:         roundTo(invoice.Paid, 2),
+			CreatedAt:    invoice.CreatedAt,
+		}
+		if invoice.Amount.Valid {
+			amount := roundTo(invoice.Amount.Float64, 2)
+			item.Amount = &amount
+			item.Outstanding = roundTo(amount-invoice.Paid, 2)
+			billing.InvoicedToDate += amount
+			if item.Outstanding < 0 {
+				issue("overpaid_invoice", "invoice %s is overpaid by %.2f", invoice.InvoiceID, -item.Outstanding)
+			}
+		} else {
+			issue("invoice_without_amount", "invoice %s has no amount", invoice.InvoiceID)
+		}
+		billing.LateInterestInvoiced += invoice.LateInterest
+		billing.PaidToDate += invoice.Paid
+		billing.Invoices = append(billing.Invoices, item)
+	}
+	billing.InvoicedToDate = roundTo(billing.InvoicedToDate, 2)
+	billing.LateInterestInvoiced = roundTo(billing.LateInterestInvoiced, 2)
+	billing.PaidToDate = roundTo(billing.PaidToDate, 2)
+	billing.Outstanding = roundTo(billing.InvoicedToDate-billing.PaidToDate, 2)
+	billing.RemainingToInvoice = roundTo(agreed-(billing.InvoicedToDate-billing.LateInterestInvoiced), 2)
+
+	if len(invoices) > 0 {
+		if !response.Contract.InForce {
+			issue("invoiced_without_contract", "project has been invoiced but no contract is in force")
+		}
+		if totals.QuotationStatus.String != string(models.QuotationStatusApproved) {
+			issue("invoiced_without_approved_quotation", "project has been invoiced but its quotation is not approved")
+		}
+	}
+	if billing.RemainingToInvoice < 0 {
+		issue("over_invoiced", "invoices exceed the agreed value by %.2f", -billing.RemainingToInvoice)
+	}
+
+	return response, nil
+}
+
+// sameAmount reports whether a and b are equal to the satang.
+func sameAmount(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
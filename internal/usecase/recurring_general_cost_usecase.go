@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecurringGeneralCostUseCase interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.RecurringGeneralCostRequest) (*responses.RecurringGeneralCostResponse, error)
+	List(ctx context.Context, projectID uuid.UUID) ([]responses.RecurringGeneralCostResponse, error)
+	// Update changes future postings only; months already posted keep
+	// the amount they were posted at.
+	Update(ctx context.Context, recurringID uuid.UUID, req requests.RecurringGeneralCostRequest) (*responses.RecurringGeneralCostResponse, error)
+	Delete(ctx context.Context, recurringID uuid.UUID) error
+
+	// RunPostings posts every month due up to and including the current
+	// one for projects that are still open. Months already posted are
+	// skipped, as are months in locked accounting periods.
+	RunPostings(ctx context.Context) error
+}
+
+type recurringGeneralCostUseCase struct {
+	recurringRepo   repositories.RecurringGeneralCostRepository
+	generalCostRepo repositories.GeneralCostRepository
+	projectRepo     repositories.ProjectRepository
+	periodRepo      repositories.AccountingPeriodRepository
+}
+
+func NewRecurringGeneralCostUsecase(
+	recurringRepo repositories.RecurringGeneralCostRepository,
+	generalCostRepo repositories.GeneralCostRepository,
+	projectRepo repositories.ProjectRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+) RecurringGeneralCostUseCase {
+	return &recurringGeneralCostUseCase{
+		recurringRepo:   recurringRepo,
+		generalCostRepo: generalCostRepo,
+		projectRepo:     projectRepo,
+		periodRepo:      periodRepo,
+	}
+}
+
+func (u *recurringGeneralCostUseCase) Create(ctx context.Context, projectID uuid.UUID, req requests.RecurringGeneralCostRequest) (*responses.RecurringGeneralCostResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+	if project.Status == models.ProjectStatusCompleted || project.Status == models.ProjectStatusCancelled {
+		return nil, errors.New("project is closed")
+	}
+
+	now := time.Now()
+	cost := models.RecurringGeneralCost{
+		RecurringID: uuid.New(),
+		ProjectID:   projectID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := u.applyRequest(ctx, &cost, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.recurringRepo.Create(ctx, cost); err != nil {
+		return nil, err
+	}
+
+	response := toRecurringGeneralCostResponse(&cost, nil)
+	return &response, nil
+}
+
+func (u *recurringGeneralCostUseCase) List(ctx context.Context, projectID uuid.UUID) ([]responses.RecurringGeneralCostResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	costs, err := u.recurringRepo.ListByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	postings, err := u.recurringRepo.ListPostingsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	byCost := groupRecurringPostings(postings)
+
+	response := make([]responses.RecurringGeneralCostResponse, len(costs))
+	for i := range costs {
+		response[i] = toRecurringGeneralCostResponse(&costs[i], byCost[costs[i].RecurringID])
+	}
+	return response, nil
+}
+
+func (u *recurringGeneralCostUseCase) Update(ctx context.Context, recurringID uuid.UUID, req requests.RecurringGeneralCostRequest) (*responses.RecurringGeneralCostResponse, error) {
+	cost, err := u.recurringRepo.GetByID(ctx, recurringID)
+	if err != nil {
+		return nil, err
+	}
+	posted, err := u.postings(ctx, cost)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *cost
+	if err := u.applyRequest(ctx, cost, req); err != nil {
+		return nil, err
+	}
+	// Posted months have already been added to a general cost type and
+	// can't be moved out of the recurring cost's range.
+	if len(posted) > 0 {
+		if cost.TypeName != before.TypeName {
+			return nil, errors.New("cannot change the type of a posted recurring cost")
+		}
+		first, last := posted[0].Period, posted[len(posted)-1].Period
+		if cost.StartPeriod > first || (cost.EndPeriod.Valid && cost.EndPeriod.String < last) {
+			return nil, errors.New("periods already posted must stay in range")
+		}
+	}
+	cost.UpdatedAt = time.Now()
+
+	if err := u.recurringRepo.Update(ctx, *cost); err != nil {
+		return nil, err
+	}
+
+	response := toRecurringGeneralCostResponse(cost, posted)
+	return &response, nil
+}
+
+func (u *recurringGeneralCostUseCase) Delete(ctx context.Context, recurringID uuid.UUID) error {
+	cost, err := u.recurringRepo.GetByID(ctx, recurringID)
+	if err != nil {
+		return err
+	}
+	posted, err := u.postings(ctx, cost)
+	if err != nil {
+		return err
+	}
+	if len(posted) > 0 {
+		return errors.New("recurring general cost already posted")
+	}
+
+	return u.recurringRepo.Delete(ctx, recurringID)
+}
+
+func (u *recurringGeneralCostUseCase) RunPostings(ctx context.Context) error {
+	through := models.AccountingPeriodOf(time.Now())
+
+	costs, err := u.recurringRepo.ListPostable(ctx)
+	if err != nil {
+		return err
+	}
+
+	posted := map[uuid.UUID]map[uuid.UUID][]models.RecurringCostPosting{}
+	locked := map[string]bool{}
+	for _, cost := range costs {
+		byCost, ok := posted[cost.ProjectID]
+		if !ok {
+			postings, err := u.recurringRepo.ListPostingsByProjectID(ctx, cost.ProjectID)
+			if err != nil {
+				return err
+			}
+			byCost = groupRecurringPostings(postings)
+			posted[cost.ProjectID] = byCost
+		}
+		done := make(map[string]bool, len(byCost[cost.RecurringID]))
+		for _, posting := range byCost[cost.RecurringID] {
+			done[posting.Period] = true
+		}
+
+		for _, period := range cost.Periods(through) {
+			if done[period] {
+				continue
+			}
+			isLocked, ok := locked[period]
+			if !ok {
+				isLocked, err = u.periodRepo.IsLocked(ctx, period)
+				if err != nil {
+					return err
+				}
+				locked[period] = isLocked
+			}
+			if isLocked {
+				continue
+			}
+
+			posting := models.RecurringCostPosting{
+				PostingID:   uuid.New(),
+				RecurringID: cost.RecurringID,
+				Period:      period,
+				Amount:      cost.MonthlyAmount,
+				PostedAt:    time.Now(),
+			}
+			if err := u.recurringRepo.CreatePosting(ctx, posting); err != nil {
+				return fmt.Errorf("failed to post recurring cost %s for %s: %w", cost.RecurringID, period, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (u *recurringGeneralCostUseCase) postings(ctx context.Context, cost *models.RecurringGeneralCost) ([]models.RecurringCostPosting, error) {
+	postings, err := u.recurringRepo.ListPostingsByProjectID(ctx, cost.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return groupRecurringPostings(postings)[cost.RecurringID], nil
+}
+
+func (u *recurringGeneralCostUseCase) applyRequest(ctx context.Context, cost *models.RecurringGeneralCost, req requests.RecurringGeneralCostRequest) error {
+	typeName := strings.TrimSpace(req.TypeName)
+	if typeName == "" {
+		return errors.New("type name is required")
+	}
+	types, err := u.generalCostRepo.GetType(ctx)
+	if err != nil {
+		return err
+	}
+	known := false
+	for _, t := range types {
+		if t.TypeName == typeName {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return errors.New("unknown general cost type")
+	}
+
+	if req.MonthlyAmount <= 0 {
+		return errors.New("monthly amount must be positive")
+	}
+
+	if _, err := time.Parse(models.AccountingPeriodLayout, req.StartPeriod); err != nil {
+		return errors.New("invalid period format, expected YYYY-MM")
+	}
+	cost.EndPeriod = sql.NullString{}
+	if req.EndPeriod != nil {
+		if _, err := time.Parse(models.AccountingPeriodLayout, *req.EndPeriod); err != nil {
+			return errors.New("invalid period format, expected YYYY-MM")
+		}
+		if *req.EndPeriod < req.StartPeriod {
+			return errors.New("end period must not be before start period")
+		}
+		cost.EndPeriod = sql.NullString{String: *req.EndPeriod, Valid: true}
+	}
+
+	cost.TypeName = typeName
+	cost.Description = strings.TrimSpace(req.Description)
+	cost.MonthlyAmount = roundTo(req.MonthlyAmount, 2)
+	cost.StartPeriod = req.StartPeriod
+	return nil
+}
+
+// committedRecurringCost is what a project's recurring costs with an end
+// period have still to post. Open-ended costs add nothing, as it isn't
+// known when the project will close.
+func committedRecurringCost(ctx context.Context, recurringRepo repositories.RecurringGeneralCostRepository, projectID uuid.UUID) (float64, error) {
+	costs, err := recurringRepo.ListByProjectID(ctx, projectID)
+	if err != nil || len(costs) == 0 {
+		return 0, err
+	}
+	postings, err := recurringRepo.ListPostingsByProjectID(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	byCost := groupRecurringPostings(postings)
+
+	committed := 0.0
+	for _, cost := range costs {
+		done := make(map[string]bool, len(byCost[cost.RecurringID]))
+		for _, posting := range byCost[cost.RecurringID] {
+			done[posting.Period] = true
+		}
+		if remaining, ok := cost.Remaining(done); ok {
+			committed += remaining
+		}
+	}
+	return roundTo(committed, 2), nil
+}
+
+func groupRecurringPostings(postings []models.RecurringCostPosting) map[uuid.UUID][]models.RecurringCostPosting {
+	byCost := map[uuid.UUID][]models.RecurringCostPosting{}
+	for _, posting := range postings {
+		byCost[posting.RecurringID] = append(byCost[posting.RecurringID], posting)
+	}
+	return byCost
+}
+
+func toRecurringGeneralCostResponse(cost *models.RecurringGeneralCost, postings []models.RecurringCostPosting) responses.RecurringGeneralCostResponse {
+	response := responses.RecurringGeneralCostResponse{
+		RecurringID:   cost.RecurringID,
+		ProjectID:     cost.ProjectID,
+		TypeName:      cost.TypeName,
+		Description:   cost.Description,
+		MonthlyAmount: cost.MonthlyAmount,
+		StartPeriod:   cost.StartPeriod,
+		PostedPeriods: make([]string, len(postings)),
+		CreatedAt:     cost.CreatedAt,
+	}
+	if cost.EndPeriod.Valid {
+		end := cost.EndPeriod.String
+		response.EndPeriod = &end
+	}
+
+	done := make(map[string]bool, len(postings))
+	for i, posting := range postings {
+		response.PostedPeriods[i] = posting.Period
+		response.PostedAmount += posting.Amount
+		done[posting.Period] = true
+	}
+	response.PostedAmount = roundTo(response.PostedAmount, 2)
+	if remaining, ok := cost.Remaining(done); ok {
+		response.RemainingAmount = &remaining
+	}
+	return response
+}
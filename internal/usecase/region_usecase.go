@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RegionUseCase interface {
+	Create(ctx context.Context, req requests.RegionPresetRequest) (*responses.RegionPresetResponse, error)
+	CreateDefaults(ctx context.Context) ([]responses.RegionPresetResponse, error)
+	List(ctx context.Context) ([]responses.RegionPresetResponse, error)
+	Update(ctx context.Context, regionID uuid.UUID, req requests.RegionPresetRequest) error
+	Delete(ctx context.Context, regionID uuid.UUID) error
+
+	GetProjectRegion(ctx context.Context, projectID uuid.UUID) (*responses.RegionPresetResponse, error)
+	SetProjectRegion(ctx context.Context, projectID uuid.UUID, req requests.SetProjectRegionRequest) error
+}
+
+type regionUseCase struct {
+	regionRepo  repositories.RegionRepository
+	projectRepo repositories.ProjectRepository
+}
+
+func NewRegionUsecase(
+	regionRepo repositories.RegionRepository,
+	projectRepo repositories.ProjectRepository,
+) RegionUseCase {
+	return &regionUseCase{
+		regionRepo:  regionRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (u *regionUseCase) Create(ctx context.Context, req requests.RegionPresetRequest) (*responses.RegionPresetResponse, error) {
+	preset := &models.RegionPreset{
+		RegionID:  uuid.New(),
+		CreatedAt: time.Now(),
+	}
+	if err := applyRegionFields(preset, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.regionRepo.Create(ctx, preset); err != nil {
+		return nil, err
+	}
+
+	response := toRegionPresetResponse(preset)
+	return &response, nil
+}
+
+func (u *regionUseCase) CreateDefaults(ctx context.Context) ([]responses.RegionPresetResponse, error) {
+	if err := u.regionRepo.CreateDefaults(ctx, models.DefaultRegionPresets); err != nil {
+		return nil, err
+	}
+
+	return u.List(ctx)
+}
+
+func (u *regionUseCase) List(ctx context.Context) ([]responses.RegionPresetResponse, error) {
+	presets, err := u.regionRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.RegionPresetResponse, len(presets))
+	for i := range presets {
+		response[i] = toRegionPresetResponse(&presets[i])
+	}
+
+	return response, nil
+}
+
+func (u *regionUseCase) Update(ctx context.Context, regionID uuid.UUID, req requests.RegionPresetRequest) error {
+	preset := &models.RegionPreset{
+		RegionID:  regionID,
+		UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	if err := applyRegionFields(preset, req); err != nil {
+		return err
+	}
+
+	return u.regionRepo.Update(ctx, preset)
+}
+
+func (u *regionUseCase) Delete(ctx context.Context, regionID uuid.UUID) error {
+	return u.regionRepo.Delete(ctx, regionID)
+}
+
+func (u *regionUseCase) GetProjectRegion(ctx context.Context, projectID uuid.UUID) (*responses.RegionPresetResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	preset, err := u.regionRepo.GetProjectRegion(ctx, projectID)
+	if err != nil || preset == nil {
+		return nil, err
+	}
+
+	response := toRegionPresetResponse(preset)
+	return &response, nil
+}
+
+func (u *regionUseCase) SetProjectRegion(ctx context.Context, projectID uuid.UUID, req requests.SetProjectRegionRequest) error {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return err
+	}
+
+	if req.RegionID != nil {
+		if _, err := u.regionRepo.GetByID(ctx, *req.RegionID); err != nil {
+			return err
+		}
+	}
+
+	return u.regionRepo.SetProjectRegion(ctx, projectID, req.RegionID)
+}
+
+func (u *regionUseCase) ensureProject(ctx context.Context, projectID uuid.UUID) error {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return errors.New("project not found")
+	}
+	return nil
+}
+
+func applyRegionFields(preset *models.RegionPreset, req requests.RegionPresetRequest) error {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	name := strings.TrimSpace(req.Name)
+	if code == "" || name == "" {
+		return errors.New("region code and name are required")
+	}
+	if req.DailyLaborRate <= 0 || req.LaborRateFactor <= 0 {
+		return errors.New("labor rate and factor must be positive")
+	}
+	if req.TransportCostPercent < 0 || req.TransportCostPercent > 100 {
+		return errors.New("transport cost percent must be between 0 and 100")
+	}
+
+	preset.Code = code
+	preset.Name = name
+	preset.DailyLaborRate = req.DailyLaborRate
+	preset.LaborRateFactor = req.LaborRateFactor
+	preset.TransportCostPercent = req.TransportCostPercent
+	return nil
+}
+
+func toRegionPresetResponse(preset *models.RegionPreset) responses.RegionPresetResponse {
+	return responses.RegionPresetResponse{
+		RegionID:             preset.RegionID,
+		Code:                 preset.Code,
+		Name:                 preset.Name,
+		DailyLaborRate:       preset.DailyLaborRate,
+		LaborRateFactor:      preset.LaborRateFactor,
+		TransportCostPercent: preset.TransportCostPercent,
+	}
+}
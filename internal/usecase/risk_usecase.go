@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type RiskUseCase interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.CreateRiskRequest) (*responses.RiskResponse, error)
+	List(ctx context.Context, projectID uuid.UUID, status string) ([]responses.RiskResponse, error)
+	Update(ctx context.Context, riskID uuid.UUID, req requests.UpdateRiskRequest) error
+	Delete(ctx context.Context, riskID uuid.UUID) error
+	GetHeatMap(ctx context.Context, projectID uuid.UUID) (*responses.RiskHeatMapResponse, error)
+}
+
+type riskUseCase struct {
+	riskRepo    repositories.RiskRepository
+	projectRepo repositories.ProjectRepository
+}
+
+func NewRiskUsecase(
+	riskRepo repositories.RiskRepository,
+	projectRepo repositories.ProjectRepository,
+) RiskUseCase {
+	return &riskUseCase{
+		riskRepo:    riskRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (u *riskUseCase) Create(ctx context.Context, projectID uuid.UUID, req requests.CreateRiskRequest) (*responses.RiskResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	if err := validateRiskFields(req.Description, req.Probability, req.Impact); err != nil {
+		return nil, err
+	}
+
+	risk, err := u.riskRepo.Create(ctx, projectID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toRiskResponse(risk)
+	return &response, nil
+}
+
+func (u *riskUseCase) List(ctx context.Context, projectID uuid.UUID, status string) ([]responses.RiskResponse, error) {
+	if status != "" && !isValidRiskStatus(status) {
+		return nil, errors.New("invalid risk status")
+	}
+
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	risks, err := u.riskRepo.ListByProjectID(ctx, projectID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.RiskResponse, len(risks))
+	for i := range risks {
+		response[i] = toRiskResponse(&risks[i])
+	}
+
+	return response, nil
+}
+
+func (u *riskUseCase) Update(ctx context.Context, riskID uuid.UUID, req requests.UpdateRiskRequest) error {
+	if err := validateRiskFields(req.Description, req.Probability, req.Impact); err != nil {
+		return err
+	}
+	if !isValidRiskStatus(req.Status) {
+		return errors.New("invalid risk status")
+	}
+
+	return u.riskRepo.Update(ctx, riskID, req)
+}
+
+func (u *riskUseCase) Delete(ctx context.Context, riskID uuid.UUID) error {
+	return u.riskRepo.Delete(ctx, riskID)
+}
+
+// GetHeatMap counts the open risks in every probability/impact cell.
+// Closed risks are left out.
+func (u *riskUseCase) GetHeatMap(ctx context.Context, projectID uuid.UUID) (*responses.RiskHeatMapResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	risks, err := u.riskRepo.ListByProjectID(ctx, projectID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var counts [models.RiskScaleMax + 1][models.RiskScaleMax + 1]int
+	response := &responses.RiskHeatMapResponse{ProjectID: projectID}
+
+	for i := range risks {
+		risk := &risks[i]
+		if risk.Status == models.RiskStatusClosed {
+			continue
+		}
+		counts[risk.Probability][risk.Impact]++
+
+		switch risk.Level() {
+		case models.RiskLevelHigh:
+			response.High++
+			if strings.TrimSpace(risk.Mitigation.String) == "" {
+				response.UnmitigatedHigh++
+			}
+		case models.RiskLevelMedium:
+			response.Medium++
+		default:
+			response.Low++
+		}
+	}
+
+	for impact := models.RiskScaleMax; impact >= 1; impact-- {
+		for probability := 1; probability <= models.RiskScaleMax; probability++ {
+			cell := models.Risk{Probability: probability, Impact: impact}
+			response.Cells = append(response.Cells, responses.RiskHeatMapCell{
+				Probability: probability,
+				Impact:      impact,
+				Level:       cell.Level(),
+				Count:       counts[probability][impact],
+			})
+		}
+	}
+
+	return response, nil
+}
+
+func (u *riskUseCase) ensureProject(ctx context.Context, projectID uuid.UUID) error {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return errors.New("project not found")
+	}
+	return nil
+}
+
+// checkRiskMitigation blocks contracting while any open high risk lacks a
+// mitigation plan.
+func checkRiskMitigation(ctx context.Context, riskRepo repositories.RiskRepository, projectID uuid.UUID) error {
+	count, err := riskRepo.CountUnmitigated(ctx, projectID, models.RiskHighScoreMin)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("high risks must have mitigation before contracting")
+	}
+	return nil
+}
+
+func validateRiskFields(description string, probability, impact int) error {
+	if strings.TrimSpace(description) == "" {
+		return errors.New("risk description is required")
+	}
+	if probability < 1 || probability > models.RiskScaleMax || impact < 1 || impact > models.RiskScaleMax {
+		return errors.New("probability and impact must be between 1 and 5")
+	}
+	return nil
+}
+
+func isValidRiskStatus(status string) bool {
+	switch models.RiskStatus(status) {
+	case models.RiskStatusOpen, models.RiskStatusMitigating, models.RiskStatusClosed:
+		return true
+	}
+	return false
+}
+
+func toRiskResponse(risk *models.Risk) responses.RiskResponse {
+	response := responses.RiskResponse{
+		RiskID:      risk.RiskID,
+		ProjectID:   risk.ProjectID,
+		Description: risk.Description,
+		Probability: risk.Probability,
+		Impact:      risk.Impact,
+		Score:       risk.Score(),
+		Level:       risk.Level(),
+		Mitigation:  risk.Mitigation.String,
+		Owner:       risk.Owner.String,
+		Status:      risk.Status,
+		CreatedAt:   risk.CreatedAt,
+	}
+	if risk.UpdatedAt.Valid {
+		response.UpdatedAt = &risk.UpdatedAt.Time
+	}
+	return response
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoundingUseCase interface {
+	GetPolicy(ctx context.Context) (*models.RoundingPolicy, error)
+	// UpdatePolicy applies to quotations the next time they are priced;
+	// stored final amounts are left as quoted.
+	UpdatePolicy(ctx context.Context, userID uuid.UUID, req requests.RoundingPolicyRequest) (*models.RoundingPolicy, error)
+}
+
+type roundingUseCase struct {
+	roundingRepo repositories.RoundingRepository
+	userRepo     repositories.UserRepository
+}
+
+func NewRoundingUsecase(
+	roundingRepo repositories.RoundingRepository,
+	userRepo repositories.UserRepository,
+) RoundingUseCase {
+	return &roundingUseCase{
+		roundingRepo: roundingRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (u *roundingUseCase) GetPolicy(ctx context.Context) (*models.RoundingPolicy, error) {
+	return u.roundingRepo.GetPolicy(ctx)
+}
+
+func (u *roundingUseCase) UpdatePolicy(ctx context.Context, userID uuid.UUID, req requests.RoundingPolicyRequest) (*models.RoundingPolicy, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	mode := models.RoundingMode(req.Mode)
+	if mode != models.RoundingModeLine && mode != models.RoundingModeTotal {
+		return nil, errors.New("invalid rounding mode")
+	}
+
+	supported := false
+	for _, increment := range models.RoundingIncrements {
+		if req.Increment == increment {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, errors.New("unsupported rounding increment")
+	}
+
+	policy := &models.RoundingPolicy{
+		Mode:      mode,
+		Increment: req.Increment,
+		UpdatedAt: time.Now(),
+	}
+	if err := u.roundingRepo.UpdatePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
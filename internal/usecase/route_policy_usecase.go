@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoutePolicyUseCase interface {
+	List(ctx context.Context, userID uuid.UUID) ([]responses.RoutePolicyResponse, error)
+	Save(ctx context.Context, userID uuid.UUID, req requests.SaveRoutePolicyRequest) (*responses.RoutePolicyResponse, error)
+	// Reset removes the saved policy for a route, returning it to its
+	// default or, when it has none, to its prefix's fallback check.
+	Reset(ctx context.Context, userID uuid.UUID, method, path string) error
+	// Load replaces the policies in memory with the table's.
+	Load(ctx context.Context) error
+	// Match returns the policy a request must satisfy, if any. It only
+	// reads memory so it can run on every request.
+	Match(method, path string) (models.RoutePolicy, bool)
+}
+
+type routePolicyUseCase struct {
+	routePolicyRepo repositories.RoutePolicyRepository
+	userRepo        repositories.UserRepository
+
+	mu  sync.RWMutex
+	set models.RoutePolicySet
+}
+
+// NewRoutePolicyUsecase starts with the default policies; call Load to
+// apply the saved ones. Changes made through this usecase take effect
+// immediately, edits made directly in the database on the next Load.
+func NewRoutePolicyUsecase(
+	routePolicyRepo repositories.RoutePolicyRepository,
+	userRepo repositories.UserRepository,
+) RoutePolicyUseCase {
+	return &routePolicyUseCase{
+		routePolicyRepo: routePolicyRepo,
+		userRepo:        userRepo,
+		set:             models.NewRoutePolicySet(nil),
+	}
+}
+
+func (u *routePolicyUseCase) List(ctx context.Context, userID uuid.UUID) ([]responses.RoutePolicyResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	set := u.current()
+	result := []responses.RoutePolicyResponse{}
+	for _, p := range set.Policies() {
+		result = append(result, toRoutePolicyResponse(set, p))
+	}
+	return result, nil
+}
+
+func (u *routePolicyUseCase) Save(ctx context.Context, userID uuid.UUID, req requests.SaveRoutePolicyRequest) (*responses.RoutePolicyResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	method, path, err := u.route(req.Method, req.Path)
+	if err != nil {
+		return nil, err
+	}
+	resource := models.PermissionResource(req.Resource)
+	if !resource.Valid() {
+		return nil, errors.New("invalid permission resource")
+	}
+	action := models.PermissionAction(req.Action)
+	if !action.Valid() {
+		return nil, errors.New("invalid permission action")
+	}
+
+	policy := models.RoutePolicy{
+		Method:    method,
+		Path:      path,
+		Resource:  resource,
+		Action:    action,
+		Enabled:   req.Enabled,
+		UpdatedAt: time.Now(),
+	}
+	if err := u.routePolicyRepo.Save(ctx, policy); err != nil {
+		return nil, err
+	}
+	if err := u.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	response := toRoutePolicyResponse(u.current(), policy)
+	return &response, nil
+}
+
+func (u *routePolicyUseCase) Reset(ctx context.Context, userID uuid.UUID, method, path string) error {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return err
+	}
+
+	method, path, err := u.route(method, path)
+	if err != nil {
+		return err
+	}
+	if err := u.routePolicyRepo.Delete(ctx, method, path); err != nil {
+		return err
+	}
+	return u.Load(ctx)
+}
+
+func (u *routePolicyUseCase) Load(ctx context.Context) error {
+	saved, err := u.routePolicyRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	set := models.NewRoutePolicySet(saved)
+	u.mu.Lock()
+	u.set = set
+	u.mu.Unlock()
+	return nil
+}
+
+func (u *routePolicyUseCase) Match(method, path string) (models.RoutePolicy, bool) {
+	return u.current().Match(method, path)
+}
+
+func (u *routePolicyUseCase) current() models.RoutePolicySet {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.set
+}
+
+// route validates a method and path and, when the route already has a
+// policy under differently named parameters, returns that spelling so it
+// keeps a single row.
+func (u *routePolicyUseCase) route(method, path string) (string, string, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if !models.ValidRoutePolicyMethod(method) {
+		return "", "", errors.New("invalid route method")
+	}
+	path = models.NormalizeRoutePath(strings.TrimSpace(path))
+	if !models.InRoutePolicyScope(path) {
+		return "", "", errors.New("route is not covered by route policies")
+	}
+
+	if existing, ok := u.current().Find(method, path); ok {
+		path = existing.Path
+	}
+	return method, path, nil
+}
+
+func toRoutePolicyResponse(set models.RoutePolicySet, p models.RoutePolicy) responses.RoutePolicyResponse {
+	return responses.RoutePolicyResponse{
+		Method:     p.Method,
+		Path:       p.Path,
+		Resource:   string(p.Resource),
+		Action:     string(p.Action),
+		Enabled:    p.Enabled,
+		Customized: set.Customized(p),
+	}
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// safetyRateBase normalises incident rates to 100 workers over a year
+// (50 weeks x 40 hours), the usual OSHA base.
+const safetyRateBase = 200000
+
+type SafetyUseCase interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.CreateSafetyIncidentRequest) (*responses.SafetyIncidentResponse, error)
+	GetByID(ctx context.Context, incidentID uuid.UUID) (*responses.SafetyIncidentResponse, error)
+	List(ctx context.Context, projectID uuid.UUID) ([]responses.SafetyIncidentResponse, error)
+	Update(ctx context.Context, incidentID uuid.UUID, req requests.UpdateSafetyIncidentRequest) error
+	Delete(ctx context.Context, incidentID uuid.UUID) error
+	AddAction(ctx context.Context, incidentID uuid.UUID, req requests.CreateCorrectiveActionRequest) (*responses.CorrectiveActionResponse, error)
+	CompleteAction(ctx context.Context, incidentID uuid.UUID, actionID uuid.UUID) error
+	GetStatistics(ctx context.Context, year int, projectID *uuid.UUID) (*responses.SafetyStatisticsResponse, error)
+}
+
+type safetyUseCase struct {
+	safetyRepo  repositories.SafetyRepository
+	projectRepo repositories.ProjectRepository
+	hoursPerDay float64
+}
+
+// NewSafetyUsecase derives man-hours from scheduled crew days, each worth
+// hoursPerDay hours.
+func NewSafetyUsecase(
+	safetyRepo repositories.SafetyRepository,
+	projectRepo repositories.ProjectRepository,
+	hoursPerDay float64,
+) SafetyUseCase {
+	return &safetyUseCase{
+		safetyRepo:  safetyRepo,
+		projectRepo: projectRepo,
+		hoursPerDay: hoursPerDay,
+	}
+}
+
+func (u *safetyUseCase) Create(ctx context.Context, projectID uuid.UUID, req requests.CreateSafetyIncidentRequest) (*responses.SafetyIncidentResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	incident := &models.SafetyIncident{
+		IncidentID: uuid.New(),
+		ProjectID:  projectID,
+		CreatedAt:  time.Now(),
+	}
+	if err := applyIncidentFields(incident, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.safetyRepo.Create(ctx, incident); err != nil {
+		return nil, err
+	}
+
+	response := toSafetyIncidentResponse(incident)
+	return &response, nil
+}
+
+func (u *safetyUseCase) GetByID(ctx context.Context, incidentID uuid.UUID) (*responses.SafetyIncidentResponse, error) {
+	incident, err := u.safetyRepo.GetByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toSafetyIncidentResponse(incident)
+	return &response, nil
+}
+
+func (u *safetyUseCase) List(ctx context.Context, projectID uuid.UUID) ([]responses.SafetyIncidentResponse, error) {
+	if err := u.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	incidents, err := u.safetyRepo.ListByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.SafetyIncidentResponse, len(incidents))
+	for i := range incidents {
+		response[i] = toSafetyIncidentResponse(&incidents[i])
+	}
+
+	return response, nil
+}
+
+func (u *safetyUseCase) Update(ctx context.Context, incidentID uuid.UUID, req requests.UpdateSafetyIncidentRequest) error {
+	incident, err := u.safetyRepo.GetByID(ctx, incidentID)
+	if err != nil {
+		return err
+	}
+
+	if err := applyIncidentFields(incident, requests.CreateSafetyIncidentRequest(req)); err != nil {
+		return err
+	}
+
+	return u.safetyRepo.Update(ctx, incident)
+}
+
+func (u *safetyUseCase) Delete(ctx context.Context, incidentID uuid.UUID) error {
+	return u.safetyRepo.Delete(ctx, incidentID)
+}
+
+func (u *safetyUseCase) AddAction(ctx context.Context, incidentID uuid.UUID, req requests.CreateCorrectiveActionRequest) (*responses.CorrectiveActionResponse, error) {
+	if _, err := u.safetyRepo.GetByID(ctx, incidentID); err != nil {
+		return nil, err
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, errors.New("action description is required")
+	}
+
+	action := &models.CorrectiveAction{
+		ActionID:    uuid.New(),
+		IncidentID:  incidentID,
+		Description: description,
+		Owner:       optionalString(req.Owner),
+		CreatedAt:   time.Now(),
+	}
+	if req.DueDate != "" {
+		dueDate, err := time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+		action.DueDate = sql.NullTime{Time: dueDate, Valid: true}
+	}
+
+	if err := u.safetyRepo.AddAction(ctx, action); err != nil {
+		return nil, err
+	}
+
+	response := toCorrectiveActionResponse(action)
+	return &response, nil
+}
+
+func (u *safetyUseCase) CompleteAction(ctx context.Context, incidentID uuid.UUID, actionID uuid.UUID) error {
+	return u.safetyRepo.CompleteAction(ctx, incidentID, actionID)
+}
+
+// GetStatistics reports a year month by month, optionally for one project.
+// Near misses are counted but don't add to the incident rate.
+func (u *safetyUseCase) GetStatistics(ctx context.Context, year int, projectID *uuid.UUID) (*responses.SafetyStatisticsResponse, error) {
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	if year < 2000 || year > 2100 {
+		return nil, errors.New("invalid year")
+	}
+
+	if projectID != nil {
+		if err := u.ensureProject(ctx, *projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	stats, err := u.safetyRepo.GetMonthlyStats(ctx, from, to, projectID, u.hoursPerDay)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.SafetyStatisticsResponse{
+		Year:      year,
+		ProjectID: projectID,
+		Months:    make([]responses.SafetyMonthlyStatResponse, len(stats)),
+	}
+	for i, stat := range stats {
+		response.Months[i] = responses.SafetyMonthlyStatResponse{
+			Month:        stat.Month.Format("2006-01"),
+			Incidents:    stat.Incidents,
+			NearMisses:   stat.NearMiss,
+			Minor:        stat.Minor,
+			Moderate:     stat.Moderate,
+			Serious:      stat.Serious,
+			Fatal:        stat.Fatal,
+			LostDays:     stat.LostDays,
+			ManHours:     stat.ManHours,
+			IncidentRate: incidentRate(stat.Incidents, stat.ManHours),
+		}
+		response.Incidents += stat.Incidents
+		response.NearMisses += stat.NearMiss
+		response.ManHours += stat.ManHours
+	}
+	response.IncidentRate = incidentRate(response.Incidents, response.ManHours)
+
+	return response, nil
+}
+
+func (u *safetyUseCase) ensureProject(ctx context.Context, projectID uuid.UUID) error {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return errors.New("project not found")
+	}
+	return nil
+}
+
+func incidentRate(incidents int, manHours float64) float64 {
+	if manHours <= 0 {
+		return 0
+	}
+	return float64(incidents) * safetyRateBase / manHours
+}
+
+func applyIncidentFields(incident *models.SafetyIncident, req requests.CreateSafetyIncidentRequest) error {
+	switch models.SafetyIncidentType(req.Type) {
+	case models.SafetyIncidentTypeIncident, models.SafetyIncidentTypeNearMiss:
+	default:
+		return errors.New("invalid incident type")
+	}
+
+	switch models.SafetySeverity(req.Severity) {
+	case models.SafetySeverityMinor, models.SafetySeverityModerate, models.SafetySeveritySerious, models.SafetySeverityFatal:
+	default:
+		return errors.New("invalid incident severity")
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return errors.New("incident description is required")
+	}
+	if req.LostDays < 0 {
+		return errors.New("lost days must not be negative")
+	}
+
+	occurredAt, err := time.Parse(time.RFC3339, req.OccurredAt)
+	if err != nil {
+		occurredAt, err = time.Parse("2006-01-02", req.OccurredAt)
+		if err != nil {
+			return errors.New("invalid occurred_at, expected RFC 3339 or YYYY-MM-DD")
+		}
+	}
+	if occurredAt.After(time.Now()) {
+		return errors.New("occurred_at must not be in the future")
+	}
+
+	incident.Type = models.SafetyIncidentType(req.Type)
+	incident.Severity = models.SafetySeverity(req.Severity)
+	incident.OccurredAt = occurredAt
+	incident.Description = description
+	incident.Location = optionalString(req.Location)
+	incident.InjuredName = optionalString(req.InjuredName)
+	incident.InjuredRole = optionalString(req.InjuredRole)
+	incident.InjuryDetails = optionalString(req.InjuryDetails)
+	incident.LostDays = req.LostDays
+	incident.ReportedBy = optionalString(req.ReportedBy)
+
+	return nil
+}
+
+func optionalString(s string) sql.NullString {
+	s = strings.TrimSpace(s)
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func toSafetyIncidentResponse(incident *models.SafetyIncident) responses.SafetyIncidentResponse {
+	response := responses.SafetyIncidentResponse{
+		IncidentID:    incident.IncidentID,
+		ProjectID:     incident.ProjectID,
+		Type:          incident.Type,
+		Severity:      incident.Severity,
+		OccurredAt:    incident.OccurredAt,
+		Location:      incident.Location.String,
+		Description:   incident.Description,
+		InjuredName:   incident.InjuredName.String,
+		InjuredRole:   incident.InjuredRole.String,
+		InjuryDetails: incident.InjuryDetails.String,
+		LostDays:      incident.LostDays,
+		ReportedBy:    incident.ReportedBy.String,
+		Actions:       make([]responses.CorrectiveActionResponse, len(incident.Actions)),
+		CreatedAt:     incident.CreatedAt,
+	}
+	for i := range incident.Actions {
+		response.Actions[i] = toCorrectiveActionResponse(&incident.Actions[i])
+	}
+	return response
+}
+
+func toCorrectiveActionResponse(action *models.CorrectiveAction) responses.CorrectiveActionResponse {
+	response := responses.CorrectiveActionResponse{
+		ActionID:    action.ActionID,
+		Description: action.Description,
+		Owner:       action.Owner.String,
+	}
+	if action.DueDate.Valid {
+		response.DueDate = action.DueDate.Time.Format("2006-01-02")
+	}
+	if action.CompletedAt.Valid {
+		response.CompletedAt = &action.CompletedAt.Time
+	}
+	return response
+}
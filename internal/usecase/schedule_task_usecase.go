@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleTaskUseCase interface {
+	Create(ctx context.Context, projectID uuid.UUID, req requests.CreateScheduleTaskRequest) (*responses.ScheduleTaskResponse, error)
+	List(ctx context.Context, projectID uuid.UUID) ([]responses.ScheduleTaskResponse, error)
+	Update(ctx context.Context, taskID uuid.UUID, req requests.UpdateScheduleTaskRequest) error
+	Delete(ctx context.Context, taskID uuid.UUID) error
+	AssignCrew(ctx context.Context, taskID uuid.UUID, req requests.AssignCrewRequest) error
+	// WhatIf recomputes the project's schedule and labor cost under a
+	// productivity scenario without changing any task.
+	WhatIf(ctx context.Context, projectID uuid.UUID, req requests.ScheduleWhatIfRequest) (*responses.ScheduleWhatIfResponse, error)
+}
+
+type scheduleTaskUseCase struct {
+	taskRepo    repositories.ScheduleTaskRepository
+	projectRepo repositories.ProjectRepository
+	phaseRepo   repositories.ProjectPhaseRepository
+	crewRepo    repositories.CrewRepository
+	regionRepo  repositories.RegionRepository
+}
+
+func NewScheduleTaskUsecase(
+	taskRepo repositories.ScheduleTaskRepository,
+	projectRepo repositories.ProjectRepository,
+	phaseRepo repositories.ProjectPhaseRepository,
+	crewRepo repositories.CrewRepository,
+	regionRepo repositories.RegionRepository,
+) ScheduleTaskUseCase {
+	return &scheduleTaskUseCase{
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		phaseRepo:   phaseRepo,
+		crewRepo:    crewRepo,
+		regionRepo:  regionRepo,
+	}
+}
+
+func (u *scheduleTaskUseCase) Create(ctx context.Context, projectID uuid.UUID, req requests.CreateScheduleTaskRequest) (*responses.ScheduleTaskResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	task := &models.ScheduleTask{
+		TaskID:    uuid.New(),
+		ProjectID: projectID,
+		CreatedAt: time.Now(),
+	}
+	if err := u.applyTaskFields(ctx, task, requests.UpdateScheduleTaskRequest(req)); err != nil {
+		return nil, err
+	}
+
+	if err := u.taskRepo.Create(ctx, task); err != nil {
+		return nil, err
+	}
+
+	response := toScheduleTaskResponse(task)
+	return &response, nil
+}
+
+func (u *scheduleTaskUseCase) List(ctx context.Context, projectID uuid.UUID) ([]responses.ScheduleTaskResponse, error) {
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	tasks, err := u.taskRepo.ListByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.ScheduleTaskResponse, len(tasks))
+	for i := range tasks {
+		response[i] = toScheduleTaskResponse(&tasks[i])
+	}
+
+	return response, nil
+}
+
+func (u *scheduleTaskUseCase) Update(ctx context.Context, taskID uuid.UUID, req requests.UpdateScheduleTaskRequest) error {
+	task, err := u.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.applyTaskFields(ctx, task, req); err != nil {
+		return err
+	}
+
+	return u.taskRepo.Update(ctx, task)
+}
+
+func (u *scheduleTaskUseCase) Delete(ctx context.Context, taskID uuid.UUID) error {
+	return u.taskRepo.Delete(ctx, taskID)
+}
+
+func (u *scheduleTaskUseCase) AssignCrew(ctx context.Context, taskID uuid.UUID, req requests.AssignCrewRequest) error {
+	if req.CrewID != nil {
+		if _, err := u.crewRepo.GetByID(ctx, *req.CrewID); err != nil {
+			return err
+		}
+	}
+
+	return u.taskRepo.AssignCrew(ctx, taskID, req.CrewID)
+}
+
+func (u *scheduleTaskUseCase) WhatIf(ctx context.Context, projectID uuid.UUID, req requests.ScheduleWhatIfRequest) (*responses.ScheduleWhatIfResponse, error) {
+	scenario := models.ProductivityScenario{
+		CrewSizePercent:     req.CrewSizePercent,
+		ProductivityPercent: req.ProductivityPercent,
+	}
+	if !scenario.Valid() {
+		return nil, errors.New("adjustments must be above -100 percent")
+	}
+
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	rate, err := u.dailyLaborRate(ctx, projectID, req.DailyLaborRate)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.taskRepo.ListByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New("project has no schedule tasks")
+	}
+
+	capacity := map[uuid.UUID]int{}
+	for _, task := range tasks {
+		if !task.CrewID.Valid {
+			continue
+		}
+		if _, ok := capacity[task.CrewID.UUID]; ok {
+			continue
+		}
+		crew, err := u.crewRepo.GetByID(ctx, task.CrewID.UUID)
+		if err != nil {
+			return nil, err
+		}
+		capacity[crew.CrewID] = crew.Capacity
+	}
+
+	// Start dates move with the schedule as a whole so tasks keep their
+	// order; each task's length comes from its own crew.
+	start := tasks[0].StartDate
+	for _, task := range tasks {
+		if task.StartDate.Before(start) {
+			start = task.StartDate
+		}
+	}
+	stretch := scenario.Stretch()
+
+	baseline := scheduleSpan{start: start, end: start}
+	adjusted := scheduleSpan{start: start, end: start}
+	response := &responses.ScheduleWhatIfResponse{
+		ProjectID:           projectID,
+		CrewSizePercent:     req.CrewSizePercent,
+		ProductivityPercent: req.ProductivityPercent,
+		DailyLaborRate:      rate,
+		Tasks:               make([]responses.WhatIfTaskResponse, len(tasks)),
+	}
+	for i, task := range tasks {
+		days := int(task.EndDate.Sub(task.StartDate).Hours()/24) + 1
+		offset := int(task.StartDate.Sub(start).Hours() / 24)
+
+		workers := scenario.Workers(task.Workers)
+		adjustedDays := scenario.Days(task.Workers, days)
+		adjustedStart := start.AddDate(0, 0, int(math.Round(float64(offset)*stretch)))
+		adjustedEnd := adjustedStart.AddDate(0, 0, adjustedDays-1)
+
+		baseline.add(task.EndDate, task.Workers*days)
+		adjusted.add(adjustedEnd, workers*adjustedDays)
+
+		limit, hasCrew := capacity[task.CrewID.UUID]
+		response.Tasks[i] = responses.WhatIfTaskResponse{
+			TaskID:            task.TaskID,
+			Name:              task.Name,
+			Workers:           task.Workers,
+			StartDate:         task.StartDate.Format("2006-01-02"),
+			EndDate:           task.EndDate.Format("2006-01-02"),
+			AdjustedWorkers:   workers,
+			AdjustedStartDate: adjustedStart.Format("2006-01-02"),
+			AdjustedEndDate:   adjustedEnd.Format("2006-01-02"),
+			OverCapacity:      hasCrew && workers > limit,
+		}
+	}
+
+	response.Baseline = baseline.response(rate)
+	response.Scenario = adjusted.response(rate)
+	response.DaysChange = response.Scenario.Days - response.Baseline.Days
+	response.LaborCostChange = roundTo(response.Scenario.LaborCost-response.Baseline.LaborCost, 2)
+	return response, nil
+}
+
+// dailyLaborRate is the rate given in the request, or else the project's
+// region rate.
+func (u *scheduleTaskUseCase) dailyLaborRate(ctx context.Context, projectID uuid.UUID, override *float64) (float64, error) {
+	if override != nil {
+		if *override <= 0 {
+			return 0, errors.New("daily labor rate must be positive")
+		}
+		return *override, nil
+	}
+
+	region, err := u.regionRepo.GetProjectRegion(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	if region == nil {
+		return 0, errors.New("daily labor rate is required when the project has no region")
+	}
+	return region.DailyLaborRate, nil
+}
+
+// scheduleSpan totals the dates and worker-days of a set of tasks.
+type scheduleSpan struct {
+	start, end time.Time
+	workerDays int
+}
+
+func (s *scheduleSpan) add(end time.Time, workerDays int) {
+	if end.After(s.end) {
+		s.end = end
+	}
+	s.workerDays += workerDays
+}
+
+func (s scheduleSpan) response(rate float64) responses.ScheduleScenarioResponse {
+	return responses.ScheduleScenarioResponse{
+		StartDate:  s.start.Format("2006-01-02"),
+		EndDate:    s.end.Format("2006-01-02"),
+		Days:       int(s.end.Sub(s.start).Hours()/24) + 1,
+		WorkerDays: s.workerDays,
+		LaborCost:  roundTo(float64(s.workerDays)*rate, 2),
+	}
+}
+
+// applyTaskFields validates the request and copies it onto the task.
+func (u *scheduleTaskUseCase) applyTaskFields(ctx context.Context, task *models.ScheduleTask, req requests.UpdateScheduleTaskRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("task name is required")
+	}
+	if req.Workers <= 0 {
+		return errors.New("workers must be positive")
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return errors.New("invalid date format, expected YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return errors.New("invalid date format, expected YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return errors.New("end date must not be before start date")
+	}
+
+	task.PhaseID = uuid.NullUUID{}
+	if req.PhaseID != nil {
+		phase, err := u.phaseRepo.GetByID(ctx, *req.PhaseID)
+		if err != nil {
+			return err
+		}
+		if phase.ProjectID != task.ProjectID {
+			return errors.New("phase does not belong to the specified project")
+		}
+		task.PhaseID = uuid.NullUUID{UUID: *req.PhaseID, Valid: true}
+	}
+
+	task.CrewID = uuid.NullUUID{}
+	if req.CrewID != nil {
+		if _, err := u.crewRepo.GetByID(ctx, *req.CrewID); err != nil {
+			return err
+		}
+		task.CrewID = uuid.NullUUID{UUID: *req.CrewID, Valid: true}
+	}
+
+	task.Name = name
+	task.StartDate = startDate
+	task.EndDate = endDate
+	task.Workers = req.Workers
+	if req.Outdoor != nil {
+		task.Outdoor = *req.Outdoor
+	} else {
+		task.Outdoor = isOutdoorTaskName(name)
+	}
+
+	return nil
+}
+
+// outdoorTaskKeywords mark weather-sensitive work when a task doesn't say
+// whether it is outdoors.
+var outdoorTaskKeywords = []string{
+	"concrete", "pour", "roof", "excavat", "foundation",
+	"คอนกรีต", "เทพื้น", "หลังคา", "ขุด", "ฐานราก",
+}
+
+func isOutdoorTaskName(name string) bool {
+	name = strings.ToLower(name)
+	for _, keyword := range outdoorTaskKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func toScheduleTaskResponse(task *models.ScheduleTask) responses.ScheduleTaskResponse {
+	return responses.ScheduleTaskResponse{
+		TaskID:    task.TaskID,
+		ProjectID: task.ProjectID,
+		PhaseID:   nullUUIDPtr(task.PhaseID),
+		CrewID:    nullUUIDPtr(task.CrewID),
+		Name:      task.Name,
+		StartDate: task.StartDate.Format("2006-01-02"),
+		EndDate:   task.EndDate.Format("2006-01-02"),
+		Workers:   task.Workers,
+		Outdoor:   task.Outdoor,
+		CreatedAt: task.CreatedAt,
+	}
+}
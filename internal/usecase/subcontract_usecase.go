@@ -0,0 +1,836 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SubcontractUseCase interface {
+	Create(ctx context.Context, req requests.CreateSubcontractRequest) (*responses.SubcontractResponse, error)
+	GetByID(ctx context.Context, subcontractID uuid.UUID) (*responses.SubcontractResponse, error)
+	ListByProject(ctx context.Context, projectID uuid.UUID) ([]responses.SubcontractResponse, error)
+	// Complete starts the defects liability period.
+	Complete(ctx context.Context, subcontractID uuid.UUID, req requests.CompleteSubcontractRequest) (*responses.SubcontractResponse, error)
+
+	// CreateCertificate withholds the subcontract's retention from the
+	// certified gross amount.
+	CreateCertificate(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, req requests.CreateSubcontractCertificateRequest) (*responses.SubcontractCertificateResponse, error)
+	ListCertificates(ctx context.Context, subcontractID uuid.UUID) ([]responses.SubcontractCertificateResponse, error)
+
+	// Items are the bill of quantities claims are made against. They can't
+	// be replaced once claimed against.
+	ListItems(ctx context.Context, subcontractID uuid.UUID) ([]responses.SubcontractItemResponse, error)
+	UpdateItems(ctx context.Context, subcontractID uuid.UUID, req requests.UpdateSubcontractItemsRequest) ([]responses.SubcontractItemResponse, error)
+
+	// SubmitClaim records claimed quantities, by the subcontractor or on
+	// their behalf. CertifyClaim accepts adjusted quantities and creates
+	// the payment certificate net of retention and advance recovery.
+	SubmitClaim(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, req requests.SubmitSubcontractClaimRequest) (*responses.SubcontractClaimResponse, error)
+	ListClaims(ctx context.Context, subcontractID uuid.UUID) ([]responses.SubcontractClaimResponse, error)
+	GetClaim(ctx context.Context, subcontractID uuid.UUID, claimID uuid.UUID) (*responses.SubcontractClaimResponse, error)
+	CertifyClaim(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, claimID uuid.UUID, req requests.CertifySubcontractClaimRequest) (*responses.SubcontractClaimResponse, error)
+	RejectClaim(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, claimID uuid.UUID, req requests.RejectSubcontractClaimRequest) (*responses.SubcontractClaimResponse, error)
+
+	// RequestRelease asks to pay back retention once the DLP has ended.
+	// Owners approve or reject the request.
+	RequestRelease(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, req requests.RequestRetentionReleaseRequest) (*responses.RetentionReleaseResponse, error)
+	ListReleases(ctx context.Context, subcontractID uuid.UUID) ([]responses.RetentionReleaseResponse, error)
+	ApproveRelease(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, releaseID uuid.UUID) (*responses.RetentionReleaseResponse, error)
+	RejectRelease(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, releaseID uuid.UUID) (*responses.RetentionReleaseResponse, error)
+}
+
+type subcontractUseCase struct {
+	subcontractRepo repositories.SubcontractRepository
+	projectRepo     repositories.ProjectRepository
+	supplierRepo    repositories.SupplierRepository
+	userRepo        repositories.UserRepository
+	periodRepo      repositories.AccountingPeriodRepository
+	backChargeRepo  repositories.BackChargeRepository
+}
+
+func NewSubcontractUsecase(
+	subcontractRepo repositories.SubcontractRepository,
+	projectRepo repositories.ProjectRepository,
+	supplierRepo repositories.SupplierRepository,
+	userRepo repositories.UserRepository,
+	periodRepo repositories.AccountingPeriodRepository,
+	backChargeRepo repositories.BackChargeRepository,
+) SubcontractUseCase {
+	return &subcontractUseCase{
+		subcontractRepo: subcontractRepo,
+		projectRepo:     projectRepo,
+		supplierRepo:    supplierRepo,
+		userRepo:        userRepo,
+		periodRepo:      periodRepo,
+		backChargeRepo:  backChargeRepo,
+	}
+}
+
+func (u *subcontractUseCase) Create(ctx context.Context, req requests.CreateSubcontractRequest) (*responses.SubcontractResponse, error) {
+	scope := strings.TrimSpace(req.Scope)
+	if scope == "" {
+		return nil, errors.New("scope is required")
+	}
+	if req.ContractValue <= 0 {
+		return nil, errors.New("contract value must be greater than 0")
+	}
+	if req.RetentionPercent < 0 || req.RetentionPercent >= 100 {
+		return nil, errors.New("retention percent must be at least 0 and less than 100")
+	}
+	if req.DLPMonths < 0 {
+		return nil, errors.New("dlp months must not be negative")
+	}
+	if req.AdvanceAmount < 0 || req.AdvanceAmount > req.ContractValue {
+		return nil, errors.New("advance amount must be at least 0 and at most the contract value")
+	}
+	if req.AdvanceRecoveryPercent < 0 || req.AdvanceRecoveryPercent > 100 {
+		return nil, errors.New("advance recovery percent must be between 0 and 100")
+	}
+	if req.AdvanceAmount > 0 && req.AdvanceRecoveryPercent == 0 {
+		return nil, errors.New("advance recovery percent is required when an advance is paid")
+	}
+
+	if _, err := u.projectRepo.GetByID(ctx, req.ProjectID); err != nil {
+		return nil, err
+	}
+	supplier, err := u.supplierRepo.GetByID(ctx, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	switch supplier.Status {
+	case models.SupplierStatusBlacklisted:
+		return nil, errors.New("cannot subcontract to a blacklisted supplier")
+	case models.SupplierStatusSuspended:
+		return nil, errors.New("cannot subcontract to a suspended supplier")
+	}
+
+	subcontract := models.Subcontract{
+		SubcontractID:          uuid.New(),
+		ProjectID:              req.ProjectID,
+		SupplierID:             req.SupplierID,
+		Scope:                  scope,
+		ContractValue:          roundTo(req.ContractValue, 2),
+		RetentionPercent:       req.RetentionPercent,
+		AdvanceAmount:          roundTo(req.AdvanceAmount, 2),
+		AdvanceRecoveryPercent: req.AdvanceRecoveryPercent,
+		DLPMonths:              req.DLPMonths,
+		CreatedAt:              time.Now(),
+	}
+	if err := u.subcontractRepo.Create(ctx, subcontract); err != nil {
+		return nil, err
+	}
+
+	return u.toResponse(ctx, &subcontract)
+}
+
+func (u *subcontractUseCase) GetByID(ctx context.Context, subcontractID uuid.UUID) (*responses.SubcontractResponse, error) {
+	subcontract, err := u.subcontractRepo.GetByID(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+	return u.toResponse(ctx, subcontract)
+}
+
+func (u *subcontractUseCase) ListByProject(ctx context.Context, projectID uuid.UUID) ([]responses.SubcontractResponse, error) {
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	subcontracts, err := u.subcontractRepo.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.SubcontractResponse, 0, len(subcontracts))
+	for i := range subcontracts {
+		response, err := u.toResponse(ctx, &subcontracts[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *response)
+	}
+	return result, nil
+}
+
+func (u *subcontractUseCase) Complete(ctx context.Context, subcontractID uuid.UUID, req requests.CompleteSubcontractRequest) (*responses.SubcontractResponse, error) {
+	subcontract, err := u.subcontractRepo.GetByID(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	completedAt := time.Now().Truncate(24 * time.Hour)
+	if req.CompletedAt != "" {
+		completedAt, err = time.Parse("2006-01-02", req.CompletedAt)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+	if completedAt.After(time.Now()) {
+		return nil, errors.New("completion date must not be in the future")
+	}
+
+	if err := u.subcontractRepo.Complete(ctx, subcontractID, completedAt); err != nil {
+		return nil, err
+	}
+
+	subcontract.CompletedAt.Time, subcontract.CompletedAt.Valid = completedAt, true
+	return u.toResponse(ctx, subcontract)
+}
+
+func (u *subcontractUseCase) CreateCertificate(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, req requests.CreateSubcontractCertificateRequest) (*responses.SubcontractCertificateResponse, error) {
+	subcontract, err := u.subcontractRepo.GetByID(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+	if req.GrossAmount <= 0 {
+		return nil, errors.New("gross amount must be greater than 0")
+	}
+
+	if err := checkPostingDate(ctx, u.periodRepo, time.Now()); err != nil {
+		return nil, err
+	}
+
+	certificate, offsets, err := u.newCertificate(ctx, subcontract, userID, roundTo(req.GrossAmount, 2), req.Note)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.subcontractRepo.CreateCertificate(ctx, certificate, offsets); err != nil {
+		return nil, err
+	}
+
+	response := toSubcontractCertificateResponse(certificate)
+	return &response, nil
+}
+
+// newCertificate withholds retention from gross, recovers the advance
+// still outstanding and deducts the supplier's open back-charges, oldest
+// first, from what is left. The certificate that brings the subcontract to
+// its full value recovers whatever advance is left.
+func (u *subcontractUseCase) newCertificate(ctx context.Context, subcontract *models.Subcontract, userID uuid.UUID, gross float64, note string) (*models.SubcontractCertificate, []models.BackChargeOffset, error) {
+	certificates, err := u.subcontractRepo.ListCertificates(ctx, subcontract.SubcontractID)
+	if err != nil {
+		return nil, nil, err
+	}
+	certified := roundTo(certifiedTotal(certificates)+gross, 2)
+	if certified > subcontract.ContractValue {
+		return nil, nil, errors.New("certified amount exceeds contract value")
+	}
+
+	retention := roundTo(gross*subcontract.RetentionPercent/100, 2)
+	outstanding := roundTo(subcontract.AdvanceAmount-advanceRecovered(certificates), 2)
+	recovery := roundTo(gross*subcontract.AdvanceRecoveryPercent/100, 2)
+	if certified == subcontract.ContractValue || recovery > outstanding {
+		recovery = outstanding
+	}
+	if recovery > roundTo(gross-retention, 2) {
+		recovery = roundTo(gross-retention, 2)
+	}
+
+	now := time.Now()
+	certificate := &models.SubcontractCertificate{
+		CertificateID:   uuid.New(),
+		SubcontractID:   subcontract.SubcontractID,
+		GrossAmount:     gross,
+		RetentionAmount: retention,
+		AdvanceRecovery: recovery,
+		Note:            optionalString(note),
+		CertifiedBy:     userID,
+		CertifiedAt:     now,
+	}
+
+	payable := roundTo(gross-retention-recovery, 2)
+	backCharges, err := u.backChargeRepo.ListDeductible(ctx, subcontract.SupplierID, subcontract.SubcontractID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var offsets []models.BackChargeOffset
+	for _, backCharge := range backCharges {
+		if payable <= 0 {
+			break
+		}
+		amount := backCharge.Remaining()
+		if amount > payable {
+			amount = payable
+		}
+		offsets = append(offsets, models.BackChargeOffset{
+			BackChargeID:  backCharge.BackChargeID,
+			CertificateID: certificate.CertificateID,
+			Amount:        amount,
+			CreatedAt:     now,
+		})
+		certificate.BackCharges = roundTo(certificate.BackCharges+amount, 2)
+		payable = roundTo(payable-amount, 2)
+	}
+
+	certificate.NetAmount = payable
+	return certificate, offsets, nil
+}
+
+func (u *subcontractUseCase) ListCertificates(ctx context.Context, subcontractID uuid.UUID) ([]responses.SubcontractCertificateResponse, error) {
+	if _, err := u.subcontractRepo.GetByID(ctx, subcontractID); err != nil {
+		return nil, err
+	}
+
+	certificates, err := u.subcontractRepo.ListCertificates(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.SubcontractCertificateResponse, len(certificates))
+	for i := range certificates {
+		result[i] = toSubcontractCertificateResponse(&certificates[i])
+	}
+	return result, nil
+}
+
+func (u *subcontractUseCase) RequestRelease(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, req requests.RequestRetentionReleaseRequest) (*responses.RetentionReleaseResponse, error) {
+	subcontract, err := u.subcontractRepo.GetByID(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	dlpEndsAt, completed := subcontract.DLPEndsAt()
+	if !completed {
+		return nil, errors.New("subcontract is not completed")
+	}
+	if time.Now().Before(dlpEndsAt) {
+		return nil, errors.New("defects liability period has not ended")
+	}
+
+	balance, err := u.subcontractRepo.GetRetentionBalance(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+	available := roundTo(balance.Held()-balance.Pending, 2)
+	if available <= 0 {
+		return nil, errors.New("no retention available for release")
+	}
+
+	amount := roundTo(req.Amount, 2)
+	if amount == 0 {
+		amount = available
+	}
+	if amount < 0 || amount > available {
+		return nil, errors.New("release amount exceeds retention held")
+	}
+
+	release := models.RetentionRelease{
+		ReleaseID:     uuid.New(),
+		SubcontractID: subcontractID,
+		Amount:        amount,
+		Status:        models.RetentionReleasePending,
+		Note:          optionalString(req.Note),
+		RequestedBy:   userID,
+		RequestedAt:   time.Now(),
+	}
+	if err := u.subcontractRepo.CreateRelease(ctx, release); err != nil {
+		return nil, err
+	}
+
+	response := toRetentionReleaseResponse(&release)
+	return &response, nil
+}
+
+func (u *subcontractUseCase) ListReleases(ctx context.Context, subcontractID uuid.UUID) ([]responses.RetentionReleaseResponse, error) {
+	if _, err := u.subcontractRepo.GetByID(ctx, subcontractID); err != nil {
+		return nil, err
+	}
+
+	releases, err := u.subcontractRepo.ListReleases(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.RetentionReleaseResponse, len(releases))
+	for i := range releases {
+		result[i] = toRetentionReleaseResponse(&releases[i])
+	}
+	return result, nil
+}
+
+func (u *subcontractUseCase) ApproveRelease(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, releaseID uuid.UUID) (*responses.RetentionReleaseResponse, error) {
+	return u.decideRelease(ctx, userID, subcontractID, releaseID, models.RetentionReleaseApproved)
+}
+
+func (u *subcontractUseCase) RejectRelease(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, releaseID uuid.UUID) (*responses.RetentionReleaseResponse, error) {
+	return u.decideRelease(ctx, userID, subcontractID, releaseID, models.RetentionReleaseRejected)
+}
+
+func (u *subcontractUseCase) decideRelease(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, releaseID uuid.UUID, status models.RetentionReleaseStatus) (*responses.RetentionReleaseResponse, error) {
+	if err := requireOwner(ctx, u.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	release, err := u.subcontractRepo.GetRelease(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	if release.SubcontractID != subcontractID {
+		return nil, errors.New("retention release not found")
+	}
+	if status == models.RetentionReleaseApproved {
+		if err := checkPostingDate(ctx, u.periodRepo, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.subcontractRepo.DecideRelease(ctx, releaseID, status, userID); err != nil {
+		return nil, err
+	}
+
+	release, err = u.subcontractRepo.GetRelease(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	response := toRetentionReleaseResponse(release)
+	return &response, nil
+}
+
+func (u *subcontractUseCase) ListItems(ctx context.Context, subcontractID uuid.UUID) ([]responses.SubcontractItemResponse, error) {
+	if _, err := u.subcontractRepo.GetByID(ctx, subcontractID); err != nil {
+		return nil, err
+	}
+	return u.itemResponses(ctx, subcontractID)
+}
+
+func (u *subcontractUseCase) UpdateItems(ctx context.Context, subcontractID uuid.UUID, req requests.UpdateSubcontractItemsRequest) ([]responses.SubcontractItemResponse, error) {
+	if _, err := u.subcontractRepo.GetByID(ctx, subcontractID); err != nil {
+		return nil, err
+	}
+
+	items := make([]models.SubcontractItem, len(req.Items))
+	for i, item := range req.Items {
+		description := strings.TrimSpace(item.Description)
+		if description == "" {
+			return nil, errors.New("item description is required")
+		}
+		if item.Quantity <= 0 {
+			return nil, errors.New("item quantity must be greater than 0")
+		}
+		if item.Rate < 0 {
+			return nil, errors.New("item rate must not be negative")
+		}
+		items[i] = models.SubcontractItem{
+			ItemID:        uuid.New(),
+			SubcontractID: subcontractID,
+			Description:   description,
+			Unit:          strings.TrimSpace(item.Unit),
+			Quantity:      item.Quantity,
+			Rate:          roundTo(item.Rate, 2),
+			SortOrder:     i + 1,
+		}
+	}
+
+	if err := u.subcontractRepo.ReplaceItems(ctx, subcontractID, items); err != nil {
+		return nil, err
+	}
+	return u.itemResponses(ctx, subcontractID)
+}
+
+func (u *subcontractUseCase) SubmitClaim(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, req requests.SubmitSubcontractClaimRequest) (*responses.SubcontractClaimResponse, error) {
+	subcontract, err := u.subcontractRepo.GetByID(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Lines) == 0 {
+		return nil, errors.New("at least one claim line is required")
+	}
+
+	items, progress, err := u.itemsWithProgress(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	claim := &models.SubcontractClaim{
+		ClaimID:       uuid.New(),
+		SubcontractID: subcontract.SubcontractID,
+		Status:        models.SubcontractClaimSubmitted,
+		ClaimantName:  optionalString(req.ClaimantName),
+		Note:          optionalString(req.Note),
+		SubmittedBy:   userID,
+		SubmittedAt:   time.Now(),
+	}
+
+	seen := map[uuid.UUID]bool{}
+	lines := make([]models.SubcontractClaimLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		item, ok := items[line.ItemID]
+		if !ok {
+			return nil, errors.New("subcontract item not found")
+		}
+		if seen[line.ItemID] {
+			return nil, errors.New("each item can only be claimed once per claim")
+		}
+		seen[line.ItemID] = true
+		if line.Quantity <= 0 {
+			return nil, errors.New("claimed quantity must be greater than 0")
+		}
+		if line.Quantity > item.Quantity-progress[line.ItemID].Certified+1e-9 {
+			return nil, errors.New("claimed quantity exceeds remaining quantity")
+		}
+		lines = append(lines, models.SubcontractClaimLine{
+			ClaimID:         claim.ClaimID,
+			ItemID:          line.ItemID,
+			ClaimedQuantity: line.Quantity,
+		})
+	}
+
+	if err := u.subcontractRepo.CreateClaim(ctx, claim, lines); err != nil {
+		return nil, err
+	}
+	return u.claimResponse(ctx, claim, lines, items)
+}
+
+func (u *subcontractUseCase) ListClaims(ctx context.Context, subcontractID uuid.UUID) ([]responses.SubcontractClaimResponse, error) {
+	if _, err := u.subcontractRepo.GetByID(ctx, subcontractID); err != nil {
+		return nil, err
+	}
+
+	claims, err := u.subcontractRepo.ListClaims(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+	items, _, err := u.itemsWithProgress(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.SubcontractClaimResponse, 0, len(claims))
+	for i := range claims {
+		lines, err := u.subcontractRepo.ListClaimLines(ctx, claims[i].ClaimID)
+		if err != nil {
+			return nil, err
+		}
+		response, err := u.claimResponse(ctx, &claims[i], lines, items)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *response)
+	}
+	return result, nil
+}
+
+func (u *subcontractUseCase) GetClaim(ctx context.Context, subcontractID uuid.UUID, claimID uuid.UUID) (*responses.SubcontractClaimResponse, error) {
+	claim, err := u.claim(ctx, subcontractID, claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := u.subcontractRepo.ListClaimLines(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	items, _, err := u.itemsWithProgress(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+	return u.claimResponse(ctx, claim, lines, items)
+}
+
+func (u *subcontractUseCase) CertifyClaim(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, claimID uuid.UUID, req requests.CertifySubcontractClaimRequest) (*responses.SubcontractClaimResponse, error) {
+	subcontract, err := u.subcontractRepo.GetByID(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+	claim, err := u.claim(ctx, subcontractID, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status != models.SubcontractClaimSubmitted {
+		return nil, errors.New("claim already decided")
+	}
+	if err := checkPostingDate(ctx, u.periodRepo, time.Now()); err != nil {
+		return nil, err
+	}
+
+	lines, err := u.subcontractRepo.ListClaimLines(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	items, progress, err := u.itemsWithProgress(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	adjusted := make(map[uuid.UUID]float64, len(req.Lines))
+	for _, line := range req.Lines {
+		if line.CertifiedQuantity < 0 {
+			return nil, errors.New("certified quantity must not be negative")
+		}
+		adjusted[line.ItemID] = line.CertifiedQuantity
+	}
+
+	var gross float64
+	for i := range lines {
+		item := items[lines[i].ItemID]
+		quantity := lines[i].ClaimedQuantity
+		if q, ok := adjusted[lines[i].ItemID]; ok {
+			quantity = q
+			delete(adjusted, lines[i].ItemID)
+		}
+		if quantity > item.Quantity-progress[item.ItemID].Certified+1e-9 {
+			return nil, errors.New("certified quantity exceeds remaining quantity")
+		}
+		lines[i].CertifiedQuantity.Float64, lines[i].CertifiedQuantity.Valid = quantity, true
+		gross += quantity * item.Rate
+	}
+	if len(adjusted) > 0 {
+		return nil, errors.New("certified lines must be on the claim")
+	}
+	gross = roundTo(gross, 2)
+	if gross <= 0 {
+		return nil, errors.New("nothing certified, reject the claim instead")
+	}
+
+	note := fmt.Sprintf("Claim #%d", claim.ClaimNo)
+	certificate, offsets, err := u.newCertificate(ctx, subcontract, userID, gross, note)
+	if err != nil {
+		return nil, err
+	}
+	certificate.ClaimID = uuid.NullUUID{UUID: claim.ClaimID, Valid: true}
+
+	now := time.Now()
+	claim.Status = models.SubcontractClaimCertified
+	claim.DecidedBy = uuid.NullUUID{UUID: userID, Valid: true}
+	claim.DecidedAt.Time, claim.DecidedAt.Valid = now, true
+	claim.DecisionNote = optionalString(req.Note)
+	claim.CertificateID = uuid.NullUUID{UUID: certificate.CertificateID, Valid: true}
+
+	if err := u.subcontractRepo.CertifyClaim(ctx, *claim, lines, certificate, offsets); err != nil {
+		return nil, err
+	}
+	return u.claimResponse(ctx, claim, lines, items)
+}
+
+func (u *subcontractUseCase) RejectClaim(ctx context.Context, userID uuid.UUID, subcontractID uuid.UUID, claimID uuid.UUID, req requests.RejectSubcontractClaimRequest) (*responses.SubcontractClaimResponse, error) {
+	claim, err := u.claim(ctx, subcontractID, claimID)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	claim.Status = models.SubcontractClaimRejected
+	claim.DecidedBy = uuid.NullUUID{UUID: userID, Valid: true}
+	claim.DecidedAt.Time, claim.DecidedAt.Valid = time.Now(), true
+	claim.DecisionNote = optionalString(reason)
+	if err := u.subcontractRepo.RejectClaim(ctx, *claim); err != nil {
+		return nil, err
+	}
+
+	return u.GetClaim(ctx, subcontractID, claimID)
+}
+
+func (u *subcontractUseCase) claim(ctx context.Context, subcontractID uuid.UUID, claimID uuid.UUID) (*models.SubcontractClaim, error) {
+	claim, err := u.subcontractRepo.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.SubcontractID != subcontractID {
+		return nil, errors.New("claim not found")
+	}
+	return claim, nil
+}
+
+func (u *subcontractUseCase) itemsWithProgress(ctx context.Context, subcontractID uuid.UUID) (map[uuid.UUID]models.SubcontractItem, map[uuid.UUID]models.SubcontractItemProgress, error) {
+	items, err := u.subcontractRepo.ListItems(ctx, subcontractID)
+	if err != nil {
+		return nil, nil, err
+	}
+	progress, err := u.subcontractRepo.GetItemProgress(ctx, subcontractID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[uuid.UUID]models.SubcontractItem, len(items))
+	for _, item := range items {
+		byID[item.ItemID] = item
+	}
+	progressByID := make(map[uuid.UUID]models.SubcontractItemProgress, len(progress))
+	for _, p := range progress {
+		progressByID[p.ItemID] = p
+	}
+	return byID, progressByID, nil
+}
+
+func (u *subcontractUseCase) itemResponses(ctx context.Context, subcontractID uuid.UUID) ([]responses.SubcontractItemResponse, error) {
+	items, err := u.subcontractRepo.ListItems(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+	_, progress, err := u.itemsWithProgress(ctx, subcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.SubcontractItemResponse, len(items))
+	for i, item := range items {
+		p := progress[item.ItemID]
+		result[i] = responses.SubcontractItemResponse{
+			ItemID:            item.ItemID,
+			Description:       item.Description,
+			Unit:              item.Unit,
+			Quantity:          item.Quantity,
+			Rate:              item.Rate,
+			Amount:            roundTo(item.Quantity*item.Rate, 2),
+			CertifiedQuantity: p.Certified,
+			PendingQuantity:   p.Pending,
+			CertifiedAmount:   roundTo(p.Certified*item.Rate, 2),
+		}
+	}
+	return result, nil
+}
+
+func (u *subcontractUseCase) claimResponse(ctx context.Context, claim *models.SubcontractClaim, lines []models.SubcontractClaimLine, items map[uuid.UUID]models.SubcontractItem) (*responses.SubcontractClaimResponse, error) {
+	response := &responses.SubcontractClaimResponse{
+		ClaimID:       claim.ClaimID,
+		SubcontractID: claim.SubcontractID,
+		ClaimNo:       claim.ClaimNo,
+		Status:        string(claim.Status),
+		ClaimantName:  claim.ClaimantName.String,
+		Note:          claim.Note.String,
+		SubmittedBy:   claim.SubmittedBy,
+		SubmittedAt:   claim.SubmittedAt,
+		DecidedBy:     nullUUIDPtr(claim.DecidedBy),
+		DecisionNote:  claim.DecisionNote.String,
+		Lines:         make([]responses.SubcontractClaimLineResponse, len(lines)),
+	}
+	if claim.DecidedAt.Valid {
+		response.DecidedAt = &claim.DecidedAt.Time
+	}
+
+	for i, line := range lines {
+		item := items[line.ItemID]
+		lineResponse := responses.SubcontractClaimLineResponse{
+			ItemID:          line.ItemID,
+			Description:     item.Description,
+			Unit:            item.Unit,
+			Rate:            item.Rate,
+			ClaimedQuantity: line.ClaimedQuantity,
+			ClaimedAmount:   roundTo(line.ClaimedQuantity*item.Rate, 2),
+		}
+		if line.CertifiedQuantity.Valid {
+			quantity := line.CertifiedQuantity.Float64
+			amount := roundTo(quantity*item.Rate, 2)
+			lineResponse.CertifiedQuantity = &quantity
+			lineResponse.CertifiedAmount = &amount
+		}
+		response.Lines[i] = lineResponse
+		response.ClaimedAmount += lineResponse.ClaimedAmount
+	}
+	response.ClaimedAmount = roundTo(response.ClaimedAmount, 2)
+
+	if claim.CertificateID.Valid {
+		certificates, err := u.subcontractRepo.ListCertificates(ctx, claim.SubcontractID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range certificates {
+			if certificates[i].CertificateID == claim.CertificateID.UUID {
+				certificate := toSubcontractCertificateResponse(&certificates[i])
+				response.Certificate = &certificate
+				break
+			}
+		}
+	}
+	return response, nil
+}
+
+func (u *subcontractUseCase) toResponse(ctx context.Context, subcontract *models.Subcontract) (*responses.SubcontractResponse, error) {
+	certificates, err := u.subcontractRepo.ListCertificates(ctx, subcontract.SubcontractID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := u.subcontractRepo.GetRetentionBalance(ctx, subcontract.SubcontractID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.SubcontractResponse{
+		SubcontractID:          subcontract.SubcontractID,
+		ProjectID:              subcontract.ProjectID,
+		SupplierID:             subcontract.SupplierID,
+		Scope:                  subcontract.Scope,
+		ContractValue:          subcontract.ContractValue,
+		RetentionPercent:       subcontract.RetentionPercent,
+		AdvanceAmount:          subcontract.AdvanceAmount,
+		AdvanceRecoveryPercent: subcontract.AdvanceRecoveryPercent,
+		AdvanceRecovered:       advanceRecovered(certificates),
+		DLPMonths:              subcontract.DLPMonths,
+		Certified:              certifiedTotal(certificates),
+		Retention: responses.RetentionBalanceResponse{
+			Retained:  roundTo(balance.Retained, 2),
+			Released:  roundTo(balance.Released, 2),
+			Held:      balance.Held(),
+			Pending:   roundTo(balance.Pending, 2),
+			Available: roundTo(balance.Held()-balance.Pending, 2),
+		},
+		CreatedAt: subcontract.CreatedAt,
+	}
+	if dlpEndsAt, ok := subcontract.DLPEndsAt(); ok {
+		response.CompletedAt = subcontract.CompletedAt.Time.Format("2006-01-02")
+		response.DLPEndsAt = dlpEndsAt.Format("2006-01-02")
+	}
+	return response, nil
+}
+
+func certifiedTotal(certificates []models.SubcontractCertificate) float64 {
+	var total float64
+	for _, certificate := range certificates {
+		total += certificate.GrossAmount
+	}
+	return roundTo(total, 2)
+}
+
+func advanceRecovered(certificates []models.SubcontractCertificate) float64 {
+	var total float64
+	for _, certificate := range certificates {
+		total += certificate.AdvanceRecovery
+	}
+	return roundTo(total, 2)
+}
+
+func toSubcontractCertificateResponse(certificate *models.SubcontractCertificate) responses.SubcontractCertificateResponse {
+	return responses.SubcontractCertificateResponse{
+		CertificateID:   certificate.CertificateID,
+		SubcontractID:   certificate.SubcontractID,
+		ClaimID:         nullUUIDPtr(certificate.ClaimID),
+		CertificateNo:   certificate.CertificateNo,
+		GrossAmount:     certificate.GrossAmount,
+		RetentionAmount: certificate.RetentionAmount,
+		AdvanceRecovery: certificate.AdvanceRecovery,
+		BackCharges:     certificate.BackCharges,
+		NetAmount:       certificate.NetAmount,
+		Note:            certificate.Note.String,
+		CertifiedBy:     certificate.CertifiedBy,
+		CertifiedAt:     certificate.CertifiedAt,
+	}
+}
+
+func toRetentionReleaseResponse(release *models.RetentionRelease) responses.RetentionReleaseResponse {
+	response := responses.RetentionReleaseResponse{
+		ReleaseID:     release.ReleaseID,
+		SubcontractID: release.SubcontractID,
+		Amount:        release.Amount,
+		Status:        string(release.Status),
+		Note:          release.Note.String,
+		RequestedBy:   release.RequestedBy,
+		RequestedAt:   release.RequestedAt,
+		DecidedBy:     nullUUIDPtr(release.DecidedBy),
+	}
+	if release.DecidedAt.Valid {
+		response.DecidedAt = &release.DecidedAt.Time
+	}
+	return response
+}
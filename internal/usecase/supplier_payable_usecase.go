@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SupplierPayableUseCase interface {
+	GetCreditTerms(ctx context.Context, supplierID uuid.UUID) (*responses.SupplierCreditTermsResponse, error)
+	SetCreditTerms(ctx context.Context, supplierID uuid.UUID, req requests.SupplierCreditTermsRequest) (*responses.SupplierCreditTermsResponse, error)
+
+	// Create records a supplier's bill, working out its due date from the
+	// supplier's credit terms.
+	Create(ctx context.Context, supplierID uuid.UUID, req requests.CreateSupplierPayableRequest) (*responses.SupplierPayableResponse, error)
+	ListBySupplier(ctx context.Context, supplierID uuid.UUID) ([]responses.SupplierPayableResponse, error)
+	MarkPaid(ctx context.Context, payableID uuid.UUID, req requests.MarkSupplierPayablePaidRequest) error
+
+	// PaymentCalendar returns the unpaid bills due in the week containing
+	// weekOf (YYYY-MM-DD, default today), day by day.
+	PaymentCalendar(ctx context.Context, weekOf string) (*responses.PaymentCalendarResponse, error)
+}
+
+type supplierPayableUseCase struct {
+	payableRepo  repositories.SupplierPayableRepository
+	supplierRepo repositories.SupplierRepository
+	projectRepo  repositories.ProjectRepository
+}
+
+func NewSupplierPayableUsecase(
+	payableRepo repositories.SupplierPayableRepository,
+	supplierRepo repositories.SupplierRepository,
+	projectRepo repositories.ProjectRepository,
+) SupplierPayableUseCase {
+	return &supplierPayableUseCase{
+		payableRepo:  payableRepo,
+		supplierRepo: supplierRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+func (u *supplierPayableUseCase) GetCreditTerms(ctx context.Context, supplierID uuid.UUID) (*responses.SupplierCreditTermsResponse, error) {
+	if _, err := u.supplierRepo.GetByID(ctx, supplierID); err != nil {
+		return nil, err
+	}
+
+	terms, isDefault, err := u.creditTerms(ctx, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	response := toSupplierCreditTermsResponse(terms, isDefault)
+	return &response, nil
+}
+
+func (u *supplierPayableUseCase) SetCreditTerms(ctx context.Context, supplierID uuid.UUID, req requests.SupplierCreditTermsRequest) (*responses.SupplierCreditTermsResponse, error) {
+	if _, err := u.supplierRepo.GetByID(ctx, supplierID); err != nil {
+		return nil, err
+	}
+	basis := models.CreditTermsBasis(req.Basis)
+	if !basis.Valid() {
+		return nil, errors.New("basis must be invoice or receipt")
+	}
+	if req.CreditDays < 0 {
+		return nil, errors.New("credit days cannot be negative")
+	}
+
+	terms := models.SupplierCreditTerms{
+		SupplierID: supplierID,
+		CreditDays: req.CreditDays,
+		Basis:      basis,
+		EndOfMonth: req.EndOfMonth,
+		UpdatedAt:  time.Now(),
+	}
+	if err := u.payableRepo.SetCreditTerms(ctx, terms); err != nil {
+		return nil, err
+	}
+
+	response := toSupplierCreditTermsResponse(terms, false)
+	return &response, nil
+}
+
+func (u *supplierPayableUseCase) Create(ctx context.Context, supplierID uuid.UUID, req requests.CreateSupplierPayableRequest) (*responses.SupplierPayableResponse, error) {
+	supplier, err := u.supplierRepo.GetByID(ctx, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceNumber := strings.TrimSpace(req.InvoiceNumber)
+	if invoiceNumber == "" {
+		return nil, errors.New("invoice number is required")
+	}
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+	invoiceDate, err := time.Parse("2006-01-02", strings.TrimSpace(req.InvoiceDate))
+	if err != nil {
+		return nil, errors.New("invalid invoice date format, expected YYYY-MM-DD")
+	}
+	var receivedDate sql.NullTime
+	if req.ReceivedDate != nil && strings.TrimSpace(*req.ReceivedDate) != "" {
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(*req.ReceivedDate))
+		if err != nil {
+			return nil, errors.New("invalid received date format, expected YYYY-MM-DD")
+		}
+		receivedDate = sql.NullTime{Time: date, Valid: true}
+	}
+
+	var projectID uuid.NullUUID
+	if req.ProjectID != nil {
+		if _, err := u.projectRepo.GetByID(ctx, *req.ProjectID); err != nil {
+			return nil, err
+		}
+		projectID = uuid.NullUUID{UUID: *req.ProjectID, Valid: true}
+	}
+
+	terms, _, err := u.creditTerms(ctx, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	payable := models.SupplierPayable{
+		PayableID:     uuid.New(),
+		SupplierID:    supplierID,
+		ProjectID:     projectID,
+		InvoiceNumber: invoiceNumber,
+		InvoiceDate:   invoiceDate,
+		ReceivedDate:  receivedDate,
+		Amount:        roundTo(req.Amount, 2),
+		DueDate:       terms.DueDate(invoiceDate, receivedDate),
+		CreatedBy:     actorFromContext(ctx),
+		CreatedAt:     time.Now(),
+	}
+	if err := u.payableRepo.Create(ctx, payable); err != nil {
+		return nil, err
+	}
+
+	response := toSupplierPayableResponse(payable, supplier.Name, currentDate())
+	return &response, nil
+}
+
+func (u *supplierPayableUseCase) ListBySupplier(ctx context.Context, supplierID uuid.UUID) ([]responses.SupplierPayableResponse, error) {
+	supplier, err := u.supplierRepo.GetByID(ctx, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	payables, err := u.payableRepo.ListBySupplierID(ctx, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := currentDate()
+	result := make([]responses.SupplierPayableResponse, 0, len(payables))
+	for _, p := range payables {
+		result = append(result, toSupplierPayableResponse(p, supplier.Name, today))
+	}
+	return result, nil
+}
+
+func (u *supplierPayableUseCase) MarkPaid(ctx context.Context, payableID uuid.UUID, req requests.MarkSupplierPayablePaidRequest) error {
+	payable, err := u.payableRepo.GetByID(ctx, payableID)
+	if err != nil {
+		return err
+	}
+
+	paidDate := currentDate()
+	if req.PaidDate != nil && strings.TrimSpace(*req.PaidDate) != "" {
+		paidDate, err = time.Parse("2006-01-02", strings.TrimSpace(*req.PaidDate))
+		if err != nil {
+			return errors.New("invalid paid date format, expected YYYY-MM-DD")
+		}
+	}
+	if paidDate.Before(payable.InvoiceDate) {
+		return errors.New("paid date cannot be before the invoice date")
+	}
+
+	return u.payableRepo.MarkPaid(ctx, payableID, paidDate)
+}
+
+func (u *supplierPayableUseCase) PaymentCalendar(ctx context.Context, weekOf string) (*responses.PaymentCalendarResponse, error) {
+	today := currentDate()
+	date := today
+	if weekOf = strings.TrimSpace(weekOf); weekOf != "" {
+		parsed, err := time.Parse("2006-01-02", weekOf)
+		if err != nil {
+			return nil, errors.New("invalid week format, expected YYYY-MM-DD")
+		}
+		date = parsed
+	}
+
+	// Weeks run Monday to Sunday.
+	start := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	end := start.AddDate(0, 0, 6)
+
+	payables, err := u.payableRepo.ListUnpaidDueBy(ctx, end)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &responses.PaymentCalendarResponse{
+		WeekStart: start.Format("2006-01-02"),
+		WeekEnd:   end.Format("2006-01-02"),
+		Overdue:   []responses.SupplierPayableResponse{},
+		Days:      make([]responses.PaymentCalendarDay, 7),
+	}
+	for i := range calendar.Days {
+		calendar.Days[i] = responses.PaymentCalendarDay{
+			Date:     start.AddDate(0, 0, i).Format("2006-01-02"),
+			Payables: []responses.SupplierPayableResponse{},
+		}
+	}
+
+	for _, p := range payables {
+		response := toSupplierPayableResponse(p.SupplierPayable, p.SupplierName, today)
+		due := time.Date(p.DueDate.Year(), p.DueDate.Month(), p.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+		if due.Before(start) {
+			calendar.Overdue = append(calendar.Overdue, response)
+			calendar.OverdueTotal += p.Amount
+			continue
+		}
+		day := &calendar.Days[int(due.Sub(start).Hours()/24)]
+		day.Payables = append(day.Payables, response)
+		day.Total += p.Amount
+		calendar.WeekTotal += p.Amount
+	}
+
+	calendar.OverdueTotal = roundTo(calendar.OverdueTotal, 2)
+	calendar.WeekTotal = roundTo(calendar.WeekTotal, 2)
+	for i := range calendar.Days {
+		calendar.Days[i].Total = roundTo(calendar.Days[i].Total, 2)
+	}
+	return calendar, nil
+}
+
+// creditTerms returns the supplier's terms, or cash terms and true when
+// none have been recorded.
+func (u *supplierPayableUseCase) creditTerms(ctx context.Context, supplierID uuid.UUID) (models.SupplierCreditTerms, bool, error) {
+	terms, err := u.payableRepo.GetCreditTerms(ctx, supplierID)
+	if err != nil {
+		if err.Error() == "credit terms not found" {
+			return models.SupplierCreditTerms{SupplierID: supplierID, Basis: models.CreditTermsBasisInvoice}, true, nil
+		}
+		return models.SupplierCreditTerms{}, false, err
+	}
+	return *terms, false, nil
+}
+
+func toSupplierCreditTermsResponse(terms models.SupplierCreditTerms, isDefault bool) responses.SupplierCreditTermsResponse {
+	return responses.SupplierCreditTermsResponse{
+		SupplierID: terms.SupplierID,
+		CreditDays: terms.CreditDays,
+		Basis:      string(terms.Basis),
+		EndOfMonth: terms.EndOfMonth,
+		Default:    isDefault,
+	}
+}
+
+func toSupplierPayableResponse(p models.SupplierPayable, supplierName string, today time.Time) responses.SupplierPayableResponse {
+	response := responses.SupplierPayableResponse{
+		PayableID:     p.PayableID,
+		SupplierID:    p.SupplierID,
+		SupplierName:  supplierName,
+		ProjectID:     nullUUIDPtr(p.ProjectID),
+		InvoiceNumber: p.InvoiceNumber,
+		InvoiceDate:   p.InvoiceDate.Format("2006-01-02"),
+		Amount:        p.Amount,
+		DueDate:       p.DueDate.Format("2006-01-02"),
+		Overdue:       !p.PaidAt.Valid && p.DueDate.Before(today),
+	}
+	if p.ReceivedDate.Valid {
+		date := p.ReceivedDate.Time.Format("2006-01-02")
+		response.ReceivedDate = &date
+	}
+	if p.PaidAt.Valid {
+		date := p.PaidAt.Time.Format("2006-01-02")
+		response.PaidDate = &date
+	}
+	return response
+}
@@ -1,11 +1,13 @@
 package usecase
 
 import (
+	"boonkosang/internal/domain/models"
 	"boonkosang/internal/repositories"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/responses"
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -15,7 +17,8 @@ type SupplierUsecase interface {
 	Update(ctx context.Context, id uuid.UUID, req requests.UpdateSupplierRequest) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*responses.SupplierResponse, error)
-	List(ctx context.Context, page, pageSize int) (*responses.SupplierListResponse, error)
+	List(ctx context.Context, page, pageSize int, status string) (*responses.SupplierListResponse, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, req requests.UpdateSupplierStatusRequest) error
 }
 
 type supplierUsecase struct {
@@ -39,13 +42,8 @@ func (u *supplierUsecase) Create(ctx context.Context, req requests.CreateSupplie
 		return nil, err
 	}
 
-	return &responses.SupplierResponse{
-		ID:      supplier.SupplierID,
-		Name:    supplier.Name,
-		Email:   supplier.Email,
-		Tel:     supplier.Tel,
-		Address: supplier.Address,
-	}, nil
+	response := toSupplierResponse(supplier)
+	return &response, nil
 }
 
 func (u *supplierUsecase) Update(ctx context.Context, id uuid.UUID, req requests.UpdateSupplierRequest) error {
@@ -74,16 +72,14 @@ func (u *supplierUsecase) GetByID(ctx context.Context, id uuid.UUID) (*responses
 		return nil, err
 	}
 
-	return &responses.SupplierResponse{
-		ID:      supplier.SupplierID,
-		Name:    supplier.Name,
-		Email:   supplier.Email,
-		Tel:     supplier.Tel,
-		Address: supplier.Address,
-	}, nil
+	response := toSupplierResponse(supplier)
+	return &response, nil
 }
 
-func (u *supplierUsecase) List(ctx context.Context, page, pageSize int) (*responses.SupplierListResponse, error) {
+func (u *supplierUsecase) List(ctx context.Context, page, pageSize int, status string) (*responses.SupplierListResponse, error) {
+	if status != "" && !isValidSupplierStatus(models.SupplierStatus(status)) {
+		return nil, errors.New("invalid supplier status")
+	}
 
 	if page < 1 {
 		page = 1
@@ -93,20 +89,14 @@ func (u *supplierUsecase) List(ctx context.Context, page, pageSize int) (*respon
 	}
 
 	offset := (page - 1) * pageSize
-	suppliers, total, err := u.supplierRepo.List(ctx, pageSize, offset)
+	suppliers, total, err := u.supplierRepo.List(ctx, pageSize, offset, status)
 	if err != nil {
 		return nil, err
 	}
 
 	supplierResponses := make([]responses.SupplierResponse, len(suppliers))
-	for i, supplier := range suppliers {
-		supplierResponses[i] = responses.SupplierResponse{
-			ID:      supplier.SupplierID,
-			Name:    supplier.Name,
-			Email:   supplier.Email,
-			Tel:     supplier.Tel,
-			Address: supplier.Address,
-		}
+	for i := range suppliers {
+		supplierResponses[i] = toSupplierResponse(&suppliers[i])
 	}
 
 	return &responses.SupplierListResponse{
@@ -114,3 +104,48 @@ func (u *supplierUsecase) List(ctx context.Context, page, pageSize int) (*respon
 		Total:     total,
 	}, nil
 }
+
+func (u *supplierUsecase) UpdateStatus(ctx context.Context, id uuid.UUID, req requests.UpdateSupplierStatusRequest) error {
+	status := models.SupplierStatus(req.Status)
+	if !isValidSupplierStatus(status) {
+		return errors.New("invalid supplier status")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if (status == models.SupplierStatusSuspended || status == models.SupplierStatusBlacklisted) && reason == "" {
+		return errors.New("reason is required to suspend or blacklist a supplier")
+	}
+
+	if _, err := u.supplierRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	return u.supplierRepo.UpdateStatus(ctx, id, status, reason)
+}
+
+func isValidSupplierStatus(status models.SupplierStatus) bool {
+	switch status {
+	case models.SupplierStatusPendingReview,
+		models.SupplierStatusApproved,
+		models.SupplierStatusSuspended,
+		models.SupplierStatusBlacklisted:
+		return true
+	}
+	return false
+}
+
+func toSupplierResponse(supplier *models.Supplier) responses.SupplierResponse {
+	response := responses.SupplierResponse{
+		ID:           supplier.SupplierID,
+		Name:         supplier.Name,
+		Email:        supplier.Email,
+		Tel:          supplier.Tel,
+		Address:      supplier.Address,
+		Status:       string(supplier.Status),
+		StatusReason: supplier.StatusReason.String,
+	}
+	if supplier.StatusUpdatedAt.Valid {
+		response.StatusUpdatedAt = &supplier.StatusUpdatedAt.Time
+	}
+	return response
+}
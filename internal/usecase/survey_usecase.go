@@ -0,0 +1,515 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SurveyUseCase interface {
+	CreateTemplate(ctx context.Context, req requests.CreateSurveyTemplateRequest) (*responses.SurveyTemplateResponse, error)
+	ListTemplates(ctx context.Context) ([]responses.SurveyTemplateResponse, error)
+	SetDefaultTemplate(ctx context.Context, templateID uuid.UUID) error
+
+	// Send creates the survey for a handed-over project. Each project is
+	// surveyed once.
+	Send(ctx context.Context, projectID uuid.UUID, req requests.SendSurveyRequest) (*responses.SurveyResponse, error)
+	// SendForHandover sends the default template for a new handover. It
+	// returns nil without error when there is no default template.
+	SendForHandover(ctx context.Context, handover *models.Handover, managerID *uuid.UUID) (*responses.SurveyResponse, error)
+	GetByProject(ctx context.Context, projectID uuid.UUID) (*responses.SurveyResponse, error)
+
+	// The token variants serve the client through the signed link.
+	GetByToken(ctx context.Context, token string) (*responses.PublicSurveyResponse, error)
+	Submit(ctx context.Context, token string, req requests.SubmitSurveyRequest) error
+
+	// GetReport summarises NPS and ratings per project manager and year,
+	// for one year when year is non-zero.
+	GetReport(ctx context.Context, year int) (*responses.SurveyReportResponse, error)
+}
+
+type surveyUseCase struct {
+	surveyRepo   repositories.SurveyRepository
+	handoverRepo repositories.HandoverRepository
+	projectRepo  repositories.ProjectRepository
+	userRepo     repositories.UserRepository
+	links        linkSigner
+	linkBaseURL  string
+	linkTTL      time.Duration
+}
+
+// NewSurveyUsecase signs survey links with linkSecret. Links are
+// linkBaseURL followed by the token and stay valid for linkTTL.
+func NewSurveyUsecase(
+	surveyRepo repositories.SurveyRepository,
+	handoverRepo repositories.HandoverRepository,
+	projectRepo repositories.ProjectRepository,
+	userRepo repositories.UserRepository,
+	linkSecret string,
+	linkBaseURL string,
+	linkTTL time.Duration,
+) SurveyUseCase {
+	return &surveyUseCase{
+		surveyRepo:   surveyRepo,
+		handoverRepo: handoverRepo,
+		projectRepo:  projectRepo,
+		userRepo:     userRepo,
+		links:        newLinkSigner(linkSecret, linkPurposeSurvey),
+		linkBaseURL:  strings.TrimRight(linkBaseURL, "/"),
+		linkTTL:      linkTTL,
+	}
+}
+
+func (u *surveyUseCase) CreateTemplate(ctx context.Context, req requests.CreateSurveyTemplateRequest) (*responses.SurveyTemplateResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("template name is required")
+	}
+	if len(req.Questions) == 0 {
+		return nil, errors.New("at least one question is required")
+	}
+
+	questions := make([]models.SurveyQuestion, len(req.Questions))
+	keys := map[string]bool{}
+	hasNPS := false
+	for i, q := range req.Questions {
+		question := models.SurveyQuestion{
+			Key:      strings.TrimSpace(q.Key),
+			Text:     strings.TrimSpace(q.Text),
+			Type:     models.SurveyQuestionType(q.Type),
+			Required: q.Required,
+		}
+		if question.Key == "" || question.Text == "" {
+			return nil, errors.New("question key and text are required")
+		}
+		if !question.Type.Valid() {
+			return nil, errors.New("invalid question type")
+		}
+		if keys[question.Key] {
+			return nil, errors.New("question keys must be unique")
+		}
+		if question.Type == models.SurveyQuestionNPS {
+			if hasNPS {
+				return nil, errors.New("a template can only have one nps question")
+			}
+			hasNPS = true
+		}
+		keys[question.Key] = true
+		questions[i] = question
+	}
+
+	raw, err := json.Marshal(questions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode questions: %w", err)
+	}
+	template := models.SurveyTemplate{
+		TemplateID: uuid.New(),
+		Name:       name,
+		Questions:  raw,
+		IsDefault:  req.IsDefault,
+		CreatedAt:  time.Now(),
+	}
+	if err := u.surveyRepo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return toSurveyTemplateResponse(&template, questions), nil
+}
+
+func (u *surveyUseCase) ListTemplates(ctx context.Context) ([]responses.SurveyTemplateResponse, error) {
+	templates, err := u.surveyRepo.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.SurveyTemplateResponse, len(templates))
+	for i := range templates {
+		questions, err := surveyQuestions(&templates[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *toSurveyTemplateResponse(&templates[i], questions)
+	}
+	return result, nil
+}
+
+func (u *surveyUseCase) SetDefaultTemplate(ctx context.Context, templateID uuid.UUID) error {
+	return u.surveyRepo.SetDefaultTemplate(ctx, templateID)
+}
+
+func (u *surveyUseCase) Send(ctx context.Context, projectID uuid.UUID, req requests.SendSurveyRequest) (*responses.SurveyResponse, error) {
+	handover, err := u.handoverRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var template *models.SurveyTemplate
+	if req.TemplateID != nil {
+		template, err = u.surveyRepo.GetTemplate(ctx, *req.TemplateID)
+	} else {
+		template, err = u.surveyRepo.GetDefaultTemplate(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return u.send(ctx, handover, template, req.ProjectManagerID)
+}
+
+func (u *surveyUseCase) SendForHandover(ctx context.Context, handover *models.Handover, managerID *uuid.UUID) (*responses.SurveyResponse, error) {
+	template, err := u.surveyRepo.GetDefaultTemplate(ctx)
+	if err != nil {
+		if err.Error() == "no default survey template" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return u.send(ctx, handover, template, managerID)
+}
+
+func (u *surveyUseCase) send(ctx context.Context, handover *models.Handover, template *models.SurveyTemplate, managerID *uuid.UUID) (*responses.SurveyResponse, error) {
+	if _, err := u.surveyRepo.GetByProjectID(ctx, handover.ProjectID); err == nil {
+		return nil, errors.New("survey already sent")
+	} else if err.Error() != "survey not found" {
+		return nil, err
+	}
+
+	now := time.Now()
+	survey := models.Survey{
+		SurveyID:   uuid.New(),
+		TemplateID: template.TemplateID,
+		ProjectID:  handover.ProjectID,
+		HandoverID: handover.HandoverID,
+		SentAt:     now,
+		ExpiresAt:  now.Add(u.linkTTL),
+	}
+	if managerID != nil {
+		if _, err := u.userRepo.GetByID(ctx, *managerID); err != nil {
+			return nil, err
+		}
+		survey.ManagerID = uuid.NullUUID{UUID: *managerID, Valid: true}
+	}
+
+	if err := u.surveyRepo.Create(ctx, survey); err != nil {
+		return nil, err
+	}
+
+	return u.toSurveyResponse(&survey)
+}
+
+func (u *surveyUseCase) GetByProject(ctx context.Context, projectID uuid.UUID) (*responses.SurveyResponse, error) {
+	survey, err := u.surveyRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toSurveyResponse(survey)
+}
+
+func (u *surveyUseCase) GetByToken(ctx context.Context, token string) (*responses.PublicSurveyResponse, error) {
+	survey, err := u.surveyFromToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.surveyRepo.GetTemplate(ctx, survey.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := surveyQuestions(template)
+	if err != nil {
+		return nil, err
+	}
+	project, err := u.projectRepo.GetByID(ctx, survey.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses.PublicSurveyResponse{
+		ProjectName: project.Name,
+		Questions:   toSurveyQuestionResponses(questions),
+		Completed:   survey.RespondedAt.Valid,
+	}, nil
+}
+
+func (u *surveyUseCase) Submit(ctx context.Context, token string, req requests.SubmitSurveyRequest) error {
+	survey, err := u.surveyFromToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if survey.RespondedAt.Valid {
+		return errors.New("survey has already been completed")
+	}
+
+	template, err := u.surveyRepo.GetTemplate(ctx, survey.TemplateID)
+	if err != nil {
+		return err
+	}
+	questions, err := surveyQuestions(template)
+	if err != nil {
+		return err
+	}
+
+	byKey := make(map[string]requests.SurveyAnswerRequest, len(req.Answers))
+	for _, answer := range req.Answers {
+		if _, ok := byKey[answer.Key]; ok {
+			return errors.New("each question can only be answered once")
+		}
+		byKey[answer.Key] = answer
+	}
+
+	var answers []models.SurveyAnswer
+	ratingTotal, ratings := 0, 0
+	for _, question := range questions {
+		answer, ok := byKey[question.Key]
+		delete(byKey, question.Key)
+
+		low, high, scored := question.Type.ScoreRange()
+		text := strings.TrimSpace(answer.Text)
+		switch {
+		case !ok || (scored && answer.Score == nil) || (!scored && text == ""):
+			if question.Required {
+				return fmt.Errorf("question %s is required", question.Key)
+			}
+			continue
+		case scored && (*answer.Score < low || *answer.Score > high):
+			return fmt.Errorf("score for %s must be between %d and %d", question.Key, low, high)
+		}
+
+		if !scored {
+			answers = append(answers, models.SurveyAnswer{Key: question.Key, Text: text})
+			continue
+		}
+		score := *answer.Score
+		answers = append(answers, models.SurveyAnswer{Key: question.Key, Score: &score})
+		if question.Type == models.SurveyQuestionNPS {
+			survey.NPSScore = sql.NullInt64{Int64: int64(score), Valid: true}
+		} else {
+			ratingTotal += score
+			ratings++
+		}
+	}
+	if len(byKey) > 0 {
+		return errors.New("answers must match the survey questions")
+	}
+	if ratings > 0 {
+		survey.AverageRating = sql.NullFloat64{Float64: roundTo(float64(ratingTotal)/float64(ratings), 2), Valid: true}
+	}
+
+	raw, err := json.Marshal(answers)
+	if err != nil {
+		return fmt.Errorf("failed to encode answers: %w", err)
+	}
+	survey.Answers = raw
+	survey.RespondedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return u.surveyRepo.Submit(ctx, *survey)
+}
+
+func (u *surveyUseCase) GetReport(ctx context.Context, year int) (*responses.SurveyReportResponse, error) {
+	surveys, err := u.surveyRepo.ListResponded(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	type groupKey struct {
+		manager uuid.NullUUID
+		year    int
+	}
+	type group struct {
+		responses    int
+		npsScores    []int
+		ratingTotal  float64
+		ratingsCount int
+	}
+	add := func(groups map[groupKey]*group, key groupKey, survey *models.Survey) {
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+		}
+		g.responses++
+		if survey.NPSScore.Valid {
+			g.npsScores = append(g.npsScores, int(survey.NPSScore.Int64))
+		}
+		if survey.AverageRating.Valid {
+			g.ratingTotal += survey.AverageRating.Float64
+			g.ratingsCount++
+		}
+	}
+
+	byManager := map[groupKey]*group{}
+	overall := map[groupKey]*group{}
+	for i := range surveys {
+		survey := &surveys[i]
+		responseYear := survey.RespondedAt.Time.In(models.AccountingPeriodLocation).Year()
+		add(byManager, groupKey{manager: survey.ManagerID, year: responseYear}, survey)
+		add(overall, groupKey{year: responseYear}, survey)
+	}
+
+	managerNames := map[uuid.UUID]string{}
+	toLine := func(key groupKey, g *group) (responses.SurveyReportLine, error) {
+		line := responses.SurveyReportLine{
+			ManagerID:    nullUUIDPtr(key.manager),
+			Year:         key.year,
+			Responses:    g.responses,
+			NPSResponses: len(g.npsScores),
+		}
+		if len(g.npsScores) > 0 {
+			nps := models.NetPromoterScore(g.npsScores)
+			line.NPS = &nps
+		}
+		if g.ratingsCount > 0 {
+			average := roundTo(g.ratingTotal/float64(g.ratingsCount), 2)
+			line.AverageRating = &average
+		}
+		if key.manager.Valid {
+			name, ok := managerNames[key.manager.UUID]
+			if !ok {
+				user, err := u.userRepo.GetByID(ctx, key.manager.UUID)
+				if err != nil && err.Error() != "user not found" {
+					return line, err
+				}
+				if user != nil {
+					name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+				}
+				managerNames[key.manager.UUID] = name
+			}
+			line.ManagerName = name
+		}
+		return line, nil
+	}
+
+	report := &responses.SurveyReportResponse{
+		Year:    year,
+		Overall: []responses.SurveyReportLine{},
+		Lines:   []responses.SurveyReportLine{},
+	}
+	for key, g := range overall {
+		line, err := toLine(key, g)
+		if err != nil {
+			return nil, err
+		}
+		report.Overall = append(report.Overall, line)
+	}
+	for key, g := range byManager {
+		line, err := toLine(key, g)
+		if err != nil {
+			return nil, err
+		}
+		report.Lines = append(report.Lines, line)
+	}
+
+	sort.Slice(report.Overall, func(i, j int) bool { return report.Overall[i].Year < report.Overall[j].Year })
+	sort.Slice(report.Lines, func(i, j int) bool {
+		a, b := report.Lines[i], report.Lines[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		return a.ManagerName < b.ManagerName
+	})
+	return report, nil
+}
+
+func (u *surveyUseCase) surveyFromToken(ctx context.Context, token string) (*models.Survey, error) {
+	invalid := errors.New("invalid or expired link")
+
+	surveyID, ok := u.links.verify(token)
+	if !ok {
+		return nil, invalid
+	}
+
+	survey, err := u.surveyRepo.GetByID(ctx, surveyID)
+	if err != nil {
+		if err.Error() == "survey not found" {
+			return nil, invalid
+		}
+		return nil, err
+	}
+
+	return survey, nil
+}
+
+func (u *surveyUseCase) toSurveyResponse(survey *models.Survey) (*responses.SurveyResponse, error) {
+	response := &responses.SurveyResponse{
+		SurveyID:   survey.SurveyID,
+		ProjectID:  survey.ProjectID,
+		TemplateID: survey.TemplateID,
+		ManagerID:  nullUUIDPtr(survey.ManagerID),
+		SentAt:     survey.SentAt,
+		ExpiresAt:  survey.ExpiresAt,
+		Completed:  survey.RespondedAt.Valid,
+	}
+
+	if !survey.RespondedAt.Valid {
+		response.SurveyURL = u.linkBaseURL + "/" + u.links.sign(survey.SurveyID, survey.ExpiresAt)
+		return response, nil
+	}
+
+	response.RespondedAt = &survey.RespondedAt.Time
+	if survey.NPSScore.Valid {
+		score := int(survey.NPSScore.Int64)
+		response.NPSScore = &score
+	}
+	if survey.AverageRating.Valid {
+		response.AverageRating = &survey.AverageRating.Float64
+	}
+
+	var answers []models.SurveyAnswer
+	if len(survey.Answers) > 0 {
+		if err := json.Unmarshal(survey.Answers, &answers); err != nil {
+			return nil, fmt.Errorf("failed to decode survey answers: %w", err)
+		}
+	}
+	for _, answer := range answers {
+		response.Answers = append(response.Answers, responses.SurveyAnswerResponse{
+			Key:   answer.Key,
+			Score: answer.Score,
+			Text:  answer.Text,
+		})
+	}
+	return response, nil
+}
+
+func surveyQuestions(template *models.SurveyTemplate) ([]models.SurveyQuestion, error) {
+	var questions []models.SurveyQuestion
+	if err := json.Unmarshal(template.Questions, &questions); err != nil {
+		return nil, fmt.Errorf("failed to decode survey questions: %w", err)
+	}
+	return questions, nil
+}
+
+func toSurveyTemplateResponse(template *models.SurveyTemplate, questions []models.SurveyQuestion) *responses.SurveyTemplateResponse {
+	return &responses.SurveyTemplateResponse{
+		TemplateID: template.TemplateID,
+		Name:       template.Name,
+		Questions:  toSurveyQuestionResponses(questions),
+		IsDefault:  template.IsDefault,
+		CreatedAt:  template.CreatedAt,
+	}
+}
+
+func toSurveyQuestionResponses(questions []models.SurveyQuestion) []responses.SurveyQuestionResponse {
+	result := make([]responses.SurveyQuestionResponse, len(questions))
+	for i, q := range questions {
+		result[i] = responses.SurveyQuestionResponse{
+			Key:      q.Key,
+			Text:     q.Text,
+			Type:     string(q.Type),
+			Required: q.Required,
+		}
+	}
+	return result
+}
@@ -0,0 +1,431 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TenderUseCase interface {
+	Create(ctx context.Context, req requests.TenderRequest) (*responses.TenderDetailResponse, error)
+	List(ctx context.Context, status string) ([]responses.TenderResponse, error)
+	GetByID(ctx context.Context, tenderID uuid.UUID) (*responses.TenderDetailResponse, error)
+	// Update, Delete and the checklist and worksheet operations are only
+	// allowed while the tender is being prepared.
+	Update(ctx context.Context, tenderID uuid.UUID, req requests.TenderRequest) (*responses.TenderDetailResponse, error)
+	Delete(ctx context.Context, tenderID uuid.UUID) error
+
+	AddDocument(ctx context.Context, tenderID uuid.UUID, req requests.TenderDocumentRequest) (*responses.TenderDocumentResponse, error)
+	UpdateDocument(ctx context.Context, tenderID uuid.UUID, documentID uuid.UUID, req requests.TenderDocumentRequest) (*responses.TenderDocumentResponse, error)
+	DeleteDocument(ctx context.Context, tenderID uuid.UUID, documentID uuid.UUID) error
+
+	UpdateWorksheet(ctx context.Context, tenderID uuid.UUID, req requests.TenderWorksheetRequest) (*responses.TenderDetailResponse, error)
+
+	// Submit marks the bid as lodged. It needs a priced worksheet, every
+	// required document completed and the deadline not yet passed.
+	Submit(ctx context.Context, tenderID uuid.UUID) (*responses.TenderDetailResponse, error)
+	RecordOutcome(ctx context.Context, tenderID uuid.UUID, req requests.TenderOutcomeRequest) (*responses.TenderDetailResponse, error)
+}
+
+type tenderUseCase struct {
+	tenderRepo  repositories.TenderRepository
+	projectRepo repositories.ProjectRepository
+}
+
+func NewTenderUsecase(
+	tenderRepo repositories.TenderRepository,
+	projectRepo repositories.ProjectRepository,
+) TenderUseCase {
+	return &tenderUseCase{
+		tenderRepo:  tenderRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (u *tenderUseCase) Create(ctx context.Context, req requests.TenderRequest) (*responses.TenderDetailResponse, error) {
+	tender := models.Tender{
+		TenderID:  uuid.New(),
+		Status:    models.TenderStatusPreparing,
+		CreatedAt: time.Now(),
+	}
+	if err := u.applyTenderRequest(ctx, &tender, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.tenderRepo.Create(ctx, tender); err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(ctx, tender.TenderID)
+}
+
+func (u *tenderUseCase) List(ctx context.Context, status string) ([]responses.TenderResponse, error) {
+	tenders, err := u.tenderRepo.List(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.TenderResponse, 0, len(tenders))
+	for i := range tenders {
+		detail, err := u.detail(ctx, &tenders[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, detail.TenderResponse)
+	}
+	return result, nil
+}
+
+func (u *tenderUseCase) GetByID(ctx context.Context, tenderID uuid.UUID) (*responses.TenderDetailResponse, error) {
+	tender, err := u.tenderRepo.GetByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	return u.detail(ctx, tender)
+}
+
+func (u *tenderUseCase) Update(ctx context.Context, tenderID uuid.UUID, req requests.TenderRequest) (*responses.TenderDetailResponse, error) {
+	tender, err := u.preparingTender(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyTenderRequest(ctx, tender, req); err != nil {
+		return nil, err
+	}
+	tender.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	if err := u.tenderRepo.Update(ctx, *tender); err != nil {
+		return nil, err
+	}
+
+	return u.detail(ctx, tender)
+}
+
+func (u *tenderUseCase) Delete(ctx context.Context, tenderID uuid.UUID) error {
+	if _, err := u.preparingTender(ctx, tenderID); err != nil {
+		return err
+	}
+	return u.tenderRepo.Delete(ctx, tenderID)
+}
+
+func (u *tenderUseCase) AddDocument(ctx context.Context, tenderID uuid.UUID, req requests.TenderDocumentRequest) (*responses.TenderDocumentResponse, error) {
+	if _, err := u.preparingTender(ctx, tenderID); err != nil {
+		return nil, err
+	}
+
+	document := models.TenderDocument{
+		DocumentID: uuid.New(),
+		TenderID:   tenderID,
+	}
+	if err := applyTenderDocumentRequest(&document, req); err != nil {
+		return nil, err
+	}
+	if err := u.tenderRepo.CreateDocument(ctx, document); err != nil {
+		return nil, err
+	}
+
+	response := toTenderDocumentResponse(document)
+	return &response, nil
+}
+
+func (u *tenderUseCase) UpdateDocument(ctx context.Context, tenderID uuid.UUID, documentID uuid.UUID, req requests.TenderDocumentRequest) (*responses.TenderDocumentResponse, error) {
+	document, err := u.tenderDocument(ctx, tenderID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyTenderDocumentRequest(document, req); err != nil {
+		return nil, err
+	}
+	if err := u.tenderRepo.UpdateDocument(ctx, *document); err != nil {
+		return nil, err
+	}
+
+	response := toTenderDocumentResponse(*document)
+	return &response, nil
+}
+
+func (u *tenderUseCase) DeleteDocument(ctx context.Context, tenderID uuid.UUID, documentID uuid.UUID) error {
+	if _, err := u.tenderDocument(ctx, tenderID, documentID); err != nil {
+		return err
+	}
+	return u.tenderRepo.DeleteDocument(ctx, documentID)
+}
+
+func (u *tenderUseCase) UpdateWorksheet(ctx context.Context, tenderID uuid.UUID, req requests.TenderWorksheetRequest) (*responses.TenderDetailResponse, error) {
+	tender, err := u.preparingTender(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]models.TenderBidLine, 0, len(req.Lines))
+	for i, line := range req.Lines {
+		description := strings.TrimSpace(line.Description)
+		if description == "" {
+			return nil, errors.New("bid line description is required")
+		}
+		if line.Quantity <= 0 {
+			return nil, errors.New("bid line quantity must be greater than 0")
+		}
+		if line.UnitPrice < 0 {
+			return nil, errors.New("bid line unit price must not be negative")
+		}
+		lines = append(lines, models.TenderBidLine{
+			LineID:      uuid.New(),
+			TenderID:    tenderID,
+			Description: description,
+			Unit:        strings.TrimSpace(line.Unit),
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			SortOrder:   i + 1,
+		})
+	}
+
+	if err := u.tenderRepo.ReplaceBidLines(ctx, tenderID, lines); err != nil {
+		return nil, err
+	}
+
+	return u.detail(ctx, tender)
+}
+
+func (u *tenderUseCase) Submit(ctx context.Context, tenderID uuid.UUID) (*responses.TenderDetailResponse, error) {
+	tender, err := u.preparingTender(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.After(tender.SubmissionDeadline) {
+		return nil, errors.New("submission deadline has passed")
+	}
+
+	lines, err := u.tenderRepo.ListBidLines(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("bid worksheet is empty")
+	}
+	documents, err := u.tenderRepo.ListDocuments(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, document := range documents {
+		if document.Required && !document.Completed {
+			return nil, errors.New("required documents are not complete")
+		}
+	}
+
+	tender.Status = models.TenderStatusSubmitted
+	tender.SubmittedAt = sql.NullTime{Time: now, Valid: true}
+	tender.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+	if err := u.tenderRepo.Update(ctx, *tender); err != nil {
+		return nil, err
+	}
+
+	return u.detail(ctx, tender)
+}
+
+func (u *tenderUseCase) RecordOutcome(ctx context.Context, tenderID uuid.UUID, req requests.TenderOutcomeRequest) (*responses.TenderDetailResponse, error) {
+	tender, err := u.tenderRepo.GetByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Status.IsOutcome() {
+		return nil, errors.New("tender outcome is already recorded")
+	}
+
+	status := models.TenderStatus(req.Status)
+	if !status.IsOutcome() {
+		return nil, errors.New("invalid tender outcome")
+	}
+	// A tender can be cancelled at any point, but only a lodged bid can be
+	// won or lost.
+	if status != models.TenderStatusCancelled && tender.Status != models.TenderStatusSubmitted {
+		return nil, errors.New("tender must be submitted before recording a result")
+	}
+	if req.WinningAmount != nil && *req.WinningAmount < 0 {
+		return nil, errors.New("winning amount must not be negative")
+	}
+
+	now := time.Now()
+	tender.Status = status
+	tender.WinningBidder = optionalString(req.WinningBidder)
+	tender.WinningAmount = sql.NullFloat64{}
+	if req.WinningAmount != nil {
+		tender.WinningAmount = sql.NullFloat64{Float64: *req.WinningAmount, Valid: true}
+	}
+	tender.OutcomeNotes = optionalString(req.Notes)
+	tender.OutcomeAt = sql.NullTime{Time: now, Valid: true}
+	tender.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+	if err := u.tenderRepo.Update(ctx, *tender); err != nil {
+		return nil, err
+	}
+
+	return u.detail(ctx, tender)
+}
+
+func (u *tenderUseCase) applyTenderRequest(ctx context.Context, tender *models.Tender, req requests.TenderRequest) error {
+	title := strings.TrimSpace(req.Title)
+	agency := strings.TrimSpace(req.Agency)
+	if title == "" || agency == "" {
+		return errors.New("title and agency are required")
+	}
+	if req.SubmissionDeadline.IsZero() {
+		return errors.New("submission deadline is required")
+	}
+	if req.BondAmount < 0 {
+		return errors.New("bond amount must not be negative")
+	}
+
+	tender.ProjectID = uuid.NullUUID{}
+	if req.ProjectID != nil {
+		project, err := u.projectRepo.GetByID(ctx, *req.ProjectID)
+		if err != nil {
+			return err
+		}
+		if project == nil {
+			return errors.New("project not found")
+		}
+		tender.ProjectID = uuid.NullUUID{UUID: *req.ProjectID, Valid: true}
+	}
+
+	tender.Title = title
+	tender.Agency = agency
+	tender.ReferenceNumber = optionalString(req.ReferenceNumber)
+	tender.SubmissionDeadline = req.SubmissionDeadline
+	tender.BondAmount = req.BondAmount
+	return nil
+}
+
+func (u *tenderUseCase) preparingTender(ctx context.Context, tenderID uuid.UUID) (*models.Tender, error) {
+	tender, err := u.tenderRepo.GetByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Status != models.TenderStatusPreparing {
+		return nil, errors.New("can only modify tender in preparing status")
+	}
+	return tender, nil
+}
+
+func (u *tenderUseCase) tenderDocument(ctx context.Context, tenderID uuid.UUID, documentID uuid.UUID) (*models.TenderDocument, error) {
+	if _, err := u.preparingTender(ctx, tenderID); err != nil {
+		return nil, err
+	}
+	document, err := u.tenderRepo.GetDocument(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if document.TenderID != tenderID {
+		return nil, errors.New("tender document not found")
+	}
+	return document, nil
+}
+
+func (u *tenderUseCase) detail(ctx context.Context, tender *models.Tender) (*responses.TenderDetailResponse, error) {
+	documents, err := u.tenderRepo.ListDocuments(ctx, tender.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := u.tenderRepo.ListBidLines(ctx, tender.TenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.TenderDetailResponse{
+		TenderResponse: responses.TenderResponse{
+			TenderID:           tender.TenderID,
+			ProjectID:          nullUUIDPtr(tender.ProjectID),
+			Title:              tender.Title,
+			Agency:             tender.Agency,
+			ReferenceNumber:    tender.ReferenceNumber.String,
+			SubmissionDeadline: tender.SubmissionDeadline,
+			DaysUntilDeadline:  int(math.Ceil(time.Until(tender.SubmissionDeadline).Hours() / 24)),
+			BondAmount:         tender.BondAmount,
+			Status:             string(tender.Status),
+			ChecklistTotal:     len(documents),
+			WinningBidder:      tender.WinningBidder.String,
+			OutcomeNotes:       tender.OutcomeNotes.String,
+			CreatedAt:          tender.CreatedAt,
+		},
+		Checklist: make([]responses.TenderDocumentResponse, len(documents)),
+		Worksheet: make([]responses.TenderBidLineResponse, len(lines)),
+	}
+	if tender.SubmittedAt.Valid {
+		response.SubmittedAt = &tender.SubmittedAt.Time
+	}
+	if tender.WinningAmount.Valid {
+		response.WinningAmount = &tender.WinningAmount.Float64
+	}
+	if tender.OutcomeAt.Valid {
+		response.OutcomeAt = &tender.OutcomeAt.Time
+	}
+	if tender.UpdatedAt.Valid {
+		response.UpdatedAt = &tender.UpdatedAt.Time
+	}
+
+	for i, document := range documents {
+		if document.Completed {
+			response.ChecklistDone++
+		}
+		response.Checklist[i] = toTenderDocumentResponse(document)
+	}
+	var total float64
+	for i, line := range lines {
+		amount := roundTo(line.Quantity*line.UnitPrice, 2)
+		total += amount
+		response.Worksheet[i] = responses.TenderBidLineResponse{
+			LineID:      line.LineID,
+			Description: line.Description,
+			Unit:        line.Unit,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Amount:      amount,
+		}
+	}
+	response.BidTotal = roundTo(total, 2)
+
+	return response, nil
+}
+
+func applyTenderDocumentRequest(document *models.TenderDocument, req requests.TenderDocumentRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("document name is required")
+	}
+	fileURL := strings.TrimSpace(req.FileURL)
+	if fileURL != "" {
+		if _, err := url.Parse(fileURL); err != nil {
+			return errors.New("invalid file URL")
+		}
+	}
+
+	document.Name = name
+	document.Required = req.Required
+	document.Completed = req.Completed
+	document.FileURL = optionalString(fileURL)
+	document.SortOrder = req.SortOrder
+	return nil
+}
+
+func toTenderDocumentResponse(document models.TenderDocument) responses.TenderDocumentResponse {
+	return responses.TenderDocumentResponse{
+		DocumentID: document.DocumentID,
+		Name:       document.Name,
+		Required:   document.Required,
+		Completed:  document.Completed,
+		FileURL:    document.FileURL.String,
+		SortOrder:  document.SortOrder,
+	}
+}
@@ -5,81 +5,575 @@ import (
 	"boonkosang/internal/repositories"
 	"boonkosang/internal/requests"
 	"boonkosang/internal/responses"
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserUsecase interface {
-	Login(ctx context.Context, req requests.LoginRequest) (*responses.LoginResponse, error) // Changed return type
+	// Login and LoginWithGoogle record every attempt, successful or not,
+	// in the login audit along with where it came from.
+	Login(ctx context.Context, req requests.LoginRequest, client models.LoginClient) (*responses.LoginResponse, error) // Changed return type
+	// Register, ChangePassword and ResetPassword refuse passwords that
+	// break the password policy.
 	Register(ctx context.Context, req requests.RegisterRequest) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, req requests.ChangePasswordRequest) error
+	// ResetPassword sets a new password for another user and lifts any
+	// lockout. Only owners and admins may reset passwords.
+	ResetPassword(ctx context.Context, actorID, userID uuid.UUID, req requests.ResetPasswordRequest) error
+	// LoginWithGoogle signs in with a Google ID token. The Google account
+	// is linked to the user with the same email on first use, or a staff
+	// user is created for it.
+	LoginWithGoogle(ctx context.Context, req requests.GoogleLoginRequest, client models.LoginClient) (*responses.LoginResponse, error)
+	// Refresh exchanges a refresh token for a new access token and a new
+	// refresh token; the presented one can't be used again.
+	Refresh(ctx context.Context, req requests.RefreshTokenRequest, client models.LoginClient) (*responses.LoginResponse, error)
+
+	// ParseToken validates an access token and returns its user and, for
+	// impersonation tokens, the owner acting as them. Revoked tokens are
+	// rejected.
+	ParseToken(ctx context.Context, token string) (*models.AccessClaims, error)
+	// Logout revokes the access token, and the refresh token if given, so
+	// neither can be used again even if they were stolen.
+	Logout(ctx context.Context, token string, req requests.LogoutRequest) error
+	// PurgeRevokedTokens drops revocations of tokens that have expired.
+	PurgeRevokedTokens(ctx context.Context) error
+
+	// ListSessions returns the devices the user is signed in on, marking
+	// the one currentSessionID belongs to.
+	ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID uuid.NullUUID) ([]responses.UserSessionResponse, error)
+	// RevokeSession signs a device out: it can no longer refresh, and its
+	// access token runs out within the JWT expiration.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+
+	// Impersonate issues a short-lived token acting as another user so
+	// support can see what they see. Only owners and admins may
+	// impersonate, and each impersonation is recorded in the activity log.
+	Impersonate(ctx context.Context, impersonatorID uuid.UUID, req requests.ImpersonateRequest) (*responses.ImpersonationResponse, error)
+	ListImpersonations(ctx context.Context, userID uuid.UUID) ([]responses.ImpersonationSessionResponse, error)
+
+	// UpdateRole changes which role, and so which permissions, another
+	// user has. Only owners and admins may assign roles.
+	UpdateRole(ctx context.Context, actorID, userID uuid.UUID, req requests.UpdateUserRoleRequest) (*responses.UserResponse, error)
+	// ListUsers pages through users for administration. Only owners and
+	// admins may list users.
+	ListUsers(ctx context.Context, actorID uuid.UUID, req requests.ListUsersRequest) (*responses.UserListResponse, error)
+	// ForcePasswordReset makes a user choose a new password and signs them
+	// out. Only owners may force an owner to reset.
+	ForcePasswordReset(ctx context.Context, actorID, userID uuid.UUID) error
+	// UnlockUser lifts a login lockout before it expires. Only owners and
+	// admins may unlock accounts.
+	UnlockUser(ctx context.Context, actorID, userID uuid.UUID) error
+	// DeactivateUser stops a user signing in without deleting them, so
+	// their quotations, approvals and other history are kept. Only owners
+	// and admins may deactivate users, and only owners may deactivate an
+	// owner.
+	DeactivateUser(ctx context.Context, actorID, userID uuid.UUID) (*responses.UserResponse, error)
+	ReactivateUser(ctx context.Context, actorID, userID uuid.UUID) (*responses.UserResponse, error)
+	// ListLogins returns a user's most recent login attempts for security
+	// review. Only owners and admins may see them.
+	ListLogins(ctx context.Context, actorID, userID uuid.UUID, limit int) ([]responses.LoginAuditResponse, error)
+
+	// CreateAPIKey issues a key that a machine client can send as
+	// X-API-Key to act as userID on routes that accept it.
+	CreateAPIKey(ctx context.Context, userID uuid.UUID, req requests.CreateAPIKeyRequest) (*responses.CreatedAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]responses.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error
+	// AuthenticateAPIKey is ParseToken for API keys.
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.AccessClaims, error)
+
+	GetProfile(ctx context.Context, userID uuid.UUID) (*responses.UserResponse, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, req requests.UpdateProfileRequest) (*responses.UserResponse, error)
+	// UploadAvatar stores a JPEG or PNG of up to 2 MB as the user's
+	// avatar and returns the profile with its URL.
+	UploadAvatar(ctx context.Context, userID uuid.UUID, file io.Reader) (*responses.UserResponse, error)
+
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, body []byte) (*models.UserPreferences, error)
 }
 
 type userUsecase struct {
-	userRepo    repositories.UserRepository
-	jwtSecret   []byte
-	jwtDuration time.Duration
+	userRepo         repositories.UserRepository
+	jwtKeys          models.JWTKeySet
+	jwtDuration      time.Duration
+	refreshTTL       time.Duration
+	impersonationTTL time.Duration
+
+	// After maxFailedLogins wrong passwords in a row an account can't log
+	// in for lockoutDuration. Zero maxFailedLogins disables the lockout.
+	maxFailedLogins int
+	lockoutDuration time.Duration
+
+	// googleVerifier is nil when Google sign-in isn't configured. When
+	// googleDomains is set only accounts of those Workspace domains may
+	// sign in, and only then are unknown accounts given a user; otherwise
+	// Google signs in existing users only.
+	googleVerifier repositories.IdentityVerifier
+	googleDomains  []string
+
+	// directory is nil when passwords are only checked locally. When set
+	// it checks every password except those of localUsers, which keeps a
+	// way in if the directory is down.
+	directory  repositories.PasswordAuthenticator
+	localUsers []string
+
+	// avatarStore is nil when no file storage is configured.
+	avatarStore repositories.FileStore
+
+	passwordPolicy models.PasswordPolicy
+
+	// activityRepo records impersonations in the activity log, which the
+	// audit trail is sealed from.
+	activityRepo repositories.ActivityRepository
 }
 
-func NewUserUsecase(userRepo repositories.UserRepository, jwtSecret string, jwtDuration time.Duration) UserUsecase {
+func NewUserUsecase(
+	userRepo repositories.UserRepository,
+	jwtKeys models.JWTKeySet,
+	jwtDuration, refreshTTL, impersonationTTL time.Duration,
+	maxFailedLogins int,
+	lockoutDuration time.Duration,
+	googleVerifier repositories.IdentityVerifier,
+	googleDomains []string,
+	directory repositories.PasswordAuthenticator,
+	localUsers []string,
+	avatarStore repositories.FileStore,
+	passwordPolicy models.PasswordPolicy,
+	activityRepo repositories.ActivityRepository,
+) UserUsecase {
 	return &userUsecase{
-		userRepo:    userRepo,
-		jwtSecret:   []byte(jwtSecret),
-		jwtDuration: jwtDuration,
+		userRepo:         userRepo,
+		jwtKeys:          jwtKeys,
+		jwtDuration:      jwtDuration,
+		refreshTTL:       refreshTTL,
+		impersonationTTL: impersonationTTL,
+		maxFailedLogins:  maxFailedLogins,
+		lockoutDuration:  lockoutDuration,
+		googleVerifier:   googleVerifier,
+		googleDomains:    googleDomains,
+		directory:        directory,
+		localUsers:       localUsers,
+		avatarStore:      avatarStore,
+		passwordPolicy:   passwordPolicy,
+		activityRepo:     activityRepo,
 	}
 }
 
-func (uu *userUsecase) generateToken(user *models.User) (string, error) {
+func (uu *userUsecase) generateToken(user *models.User, sessionID uuid.UUID) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id":  user.UserID,
 		"username": user.Username,
+		"sid":      sessionID,
+		"jti":      uuid.New(),
 		"exp":      time.Now().Add(uu.jwtDuration).Unix(),
 	})
 
-	return token.SignedString(uu.jwtSecret)
+	return uu.signToken(token)
+}
+
+// signToken signs with the current key only; previous keys are kept for
+// verifying tokens issued before a rotation.
+func (uu *userUsecase) signToken(token *jwt.Token) (string, error) {
+	token.Header["kid"] = uu.jwtKeys.Current.ID
+	return token.SignedString(uu.jwtKeys.Current.Secret)
+}
+
+func (uu *userUsecase) generateImpersonationToken(user *models.User, impersonatorID uuid.UUID, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":         user.UserID,
+		"username":        user.Username,
+		"impersonator_id": impersonatorID,
+		"jti":             uuid.New(),
+		"exp":             expiresAt.Unix(),
+	})
+
+	return uu.signToken(token)
 }
 
 func (uu *userUsecase) Login(
 	ctx context.Context,
 	loginRequest requests.LoginRequest,
-) (*responses.LoginResponse, error) {
+	client models.LoginClient,
+) (response *responses.LoginResponse, err error) {
+	audit := newLoginAudit(models.LoginMethodPassword, loginRequest.Username, client)
+	defer func() { uu.recordLogin(ctx, audit, err) }()
+
 	user, err := uu.userRepo.GetByUsername(ctx, loginRequest.Username)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
+	audit.UserID = uuid.NullUUID{UUID: user.UserID, Valid: true}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(loginRequest.Password))
+	// A locked account is refused before the password is checked so the
+	// lockout can't be used to keep guessing.
+	now := time.Now()
+	if user.IsLocked(now) {
+		return nil, errors.New("account locked")
+	}
+
+	if uu.usesDirectory(user) {
+		audit.Method = models.LoginMethodLDAP
+		err = uu.directory.Authenticate(ctx, user.Username, loginRequest.Password)
+	} else {
+		err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(loginRequest.Password))
+	}
+	// Only a wrong password counts towards the lockout, not the directory
+	// being unreachable.
+	if err != nil && err.Error() == "directory unavailable" {
+		return nil, err
+	}
 	if err != nil {
+		if uu.maxFailedLogins > 0 {
+			locked, lockErr := uu.userRepo.RecordFailedLogin(ctx, user.UserID, uu.maxFailedLogins, now.Add(uu.lockoutDuration))
+			if lockErr != nil {
+				return nil, lockErr
+			}
+			if locked {
+				return nil, errors.New("account locked")
+			}
+		}
 		return nil, errors.New("invalid credentials")
 	}
 
-	token, err := uu.generateToken(user)
+	// Checked after the password so it doesn't reveal which usernames
+	// belong to deactivated accounts.
+	if !user.IsActive {
+		return nil, errors.New("account deactivated")
+	}
+
+	if user.FailedLoginAttempts > 0 || user.LockedUntil.Valid {
+		if err := uu.userRepo.ResetFailedLogins(ctx, user.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	return uu.issueLoginTokens(ctx, user, audit.Method, client)
+}
+
+// usesDirectory reports whether user's password is checked by the
+// directory rather than against the stored hash.
+func (uu *userUsecase) usesDirectory(user *models.User) bool {
+	return uu.directory != nil && !slices.Contains(uu.localUsers, user.Username)
+}
+
+// issueLoginTokens starts a new session for user with an access token and
+// a refresh token in a new family, which identifies the session.
+func (uu *userUsecase) issueLoginTokens(ctx context.Context, user *models.User, method models.LoginMethod, client models.LoginClient) (*responses.LoginResponse, error) {
+	sessionID := uuid.New()
+	token, err := uu.generateToken(user, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	userResponse := &responses.UserResponse{
-		ID:        user.UserID,
-		Username:  user.Username,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email.String,
-		Tel:       user.Tel.String,
+	refreshToken, refresh, err := uu.newRefreshToken(user.UserID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uu.userRepo.CreateRefreshToken(ctx, refresh); err != nil {
+		return nil, err
+	}
+	if err := uu.userRepo.CreateSession(ctx, models.UserSession{
+		SessionID:   sessionID,
+		UserID:      user.UserID,
+		Method:      method,
+		Fingerprint: client.Fingerprint,
+		UserAgent:   truncateUserAgent(client.UserAgent),
+		IPAddress:   client.IPAddress,
+		CreatedAt:   refresh.CreatedAt,
+		LastSeenAt:  refresh.CreatedAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &responses.LoginResponse{
+		AccessToken:           token,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refresh.ExpiresAt,
+		User:                  toUserResponse(user),
+	}, nil
+}
+
+func (uu *userUsecase) LoginWithGoogle(ctx context.Context, req requests.GoogleLoginRequest, client models.LoginClient) (response *responses.LoginResponse, err error) {
+	if uu.googleVerifier == nil {
+		return nil, errors.New("google sign-in is not configured")
+	}
+
+	audit := newLoginAudit(models.LoginMethodGoogle, "", client)
+	defer func() { uu.recordLogin(ctx, audit, err) }()
+
+	if req.IDToken == "" {
+		return nil, errors.New("invalid id token")
+	}
+
+	identity, err := uu.googleVerifier.VerifyIDToken(ctx, req.IDToken)
+	if err != nil {
+		return nil, err
+	}
+	audit.Username = identity.Email
+	// The email is what links the account to an existing user, so it must
+	// be one Google has confirmed belongs to the holder.
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errors.New("google account email is not verified")
+	}
+	if len(uu.googleDomains) > 0 && !slices.Contains(uu.googleDomains, identity.HostedDomain) {
+		return nil, errors.New("google account is not allowed")
+	}
+
+	user, err := uu.userRepo.GetByIdentity(ctx, identity.Provider, identity.Subject)
+	if err != nil && err.Error() != "user not found" {
+		return nil, err
+	}
+	if user == nil {
+		if user, err = uu.linkOrCreateUser(ctx, identity); err != nil {
+			return nil, err
+		}
+	}
+
+	audit.UserID = uuid.NullUUID{UUID: user.UserID, Valid: true}
+
+	if !user.IsActive {
+		return nil, errors.New("account deactivated")
+	}
+	if user.IsLocked(time.Now()) {
+		return nil, errors.New("account locked")
+	}
+	return uu.issueLoginTokens(ctx, user, audit.Method, client)
+}
+
+// maxUserAgentLength bounds what a client can make us store per attempt.
+const maxUserAgentLength = 512
+
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) > maxUserAgentLength {
+		return userAgent[:maxUserAgentLength]
+	}
+	return userAgent
+}
+
+func newLoginAudit(method models.LoginMethod, username string, client models.LoginClient) models.LoginAudit {
+	return models.LoginAudit{
+		AuditID:     uuid.New(),
+		Username:    username,
+		Method:      method,
+		IPAddress:   client.IPAddress,
+		UserAgent:   truncateUserAgent(client.UserAgent),
+		Fingerprint: client.Fingerprint,
+	}
+}
+
+// recordLogin writes the attempt to the login audit with the outcome of
+// err. A failure to record it is logged rather than failing the login.
+func (uu *userUsecase) recordLogin(ctx context.Context, audit models.LoginAudit, err error) {
+	audit.AttemptedAt = time.Now()
+	audit.Success = err == nil
+	if err != nil {
+		audit.FailureReason = sql.NullString{String: loginFailureReason(err), Valid: true}
+	}
+
+	if recordErr := uu.userRepo.RecordLogin(ctx, audit); recordErr != nil {
+		log.Printf("failed to record %s login for %q: %v", audit.Method, audit.Username, recordErr)
+	}
+}
+
+func loginFailureReason(err error) string {
+	if strings.HasPrefix(err.Error(), "user not found") {
+		return models.LoginFailureUnknownUser
+	}
+
+	switch err.Error() {
+	case "invalid credentials", "invalid id token", "google account email is not verified":
+		return models.LoginFailureInvalidCredentials
+	case "account locked":
+		return models.LoginFailureAccountLocked
+	case "google account is not allowed":
+		return models.LoginFailureNotAllowed
+	}
+	return models.LoginFailureError
+}
+
+func (uu *userUsecase) linkOrCreateUser(ctx context.Context, identity *models.ExternalIdentity) (*models.User, error) {
+	link := models.UserIdentity{
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: time.Now(),
+	}
+
+	user, err := uu.userRepo.GetByEmail(ctx, identity.Email)
+	if err == nil {
+		link.UserID = user.UserID
+		if err := uu.userRepo.LinkIdentity(ctx, link); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	if err.Error() != "user not found" {
+		return nil, err
+	}
+	if len(uu.googleDomains) == 0 {
+		return nil, errors.New("google account is not allowed")
+	}
+
+	// Provisioned users sign in through Google only, so their password is
+	// random and never shown to anyone.
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(base64.RawURLEncoding.EncodeToString(secret)), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user = &models.User{
+		UserID:    uuid.New(),
+		Username:  identity.Email,
+		Password:  string(hashedPassword),
+		FirstName: identity.FirstName,
+		LastName:  identity.LastName,
+		Email:     sql.NullString{String: identity.Email, Valid: true},
+		Role:      models.UserRoleViewer,
+		IsActive:  true,
+	}
+	link.UserID = user.UserID
+	if err := uu.userRepo.CreateUserWithIdentity(ctx, user, link); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (uu *userUsecase) Refresh(ctx context.Context, req requests.RefreshTokenRequest, client models.LoginClient) (*responses.LoginResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	current, err := uu.userRepo.GetRefreshTokenByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		if err.Error() == "refresh token not found" {
+			return nil, errors.New("invalid refresh token")
+		}
+		return nil, err
+	}
+	if current.RevokedAt.Valid {
+		if current.ReplacedBy.Valid {
+			if err := uu.userRepo.RevokeRefreshTokenFamily(ctx, current.FamilyID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, errors.New("invalid refresh token")
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, errors.New("refresh token expired")
+	}
+
+	user, err := uu.userRepo.GetByID(ctx, current.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, errors.New("account deactivated")
+	}
+
+	token, err := uu.generateToken(user, current.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	refreshToken, next, err := uu.newRefreshToken(user.UserID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uu.userRepo.RotateRefreshToken(ctx, current.TokenID, next); err != nil {
+		// Lost a race with another refresh of the same token, which is
+		// treated the same as reuse.
+		if err.Error() == "refresh token already used" {
+			if err := uu.userRepo.RevokeRefreshTokenFamily(ctx, current.FamilyID); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("invalid refresh token")
+		}
+		return nil, err
+	}
+	if err := uu.userRepo.TouchSession(ctx, current.FamilyID, client.IPAddress, next.CreatedAt); err != nil {
+		return nil, err
 	}
 
 	return &responses.LoginResponse{
-		AccessToken: token,
-		User:        *userResponse,
+		AccessToken:           token,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: next.ExpiresAt,
+		User:                  toUserResponse(user),
+	}, nil
+}
+
+// newRefreshToken returns the opaque token for the client and the record
+// to store, which only keeps its hash.
+func (uu *userUsecase) newRefreshToken(userID, familyID uuid.UUID) (string, models.RefreshToken, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", models.RefreshToken{}, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now()
+	return token, models.RefreshToken{
+		TokenID:   uuid.New(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(uu.refreshTTL),
+		CreatedAt: now,
 	}, nil
 }
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func toUserResponse(user *models.User) responses.UserResponse {
+	return responses.UserResponse{
+		ID:           user.UserID,
+		Username:     user.Username,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Email:        user.Email.String,
+		Tel:          user.Tel.String,
+		Role:         string(user.Role),
+		AvatarURL:    user.AvatarURL.String,
+		IsActive:     user.IsActive,
+		PendingEmail: user.PendingEmail.String,
+
+		PasswordChangeRequired: user.MustChangePassword,
+	}
+}
 func (uu *userUsecase) Register(
 	ctx context.Context,
 	registerRequest requests.RegisterRequest,
 ) error {
+	if err := uu.passwordPolicy.Validate(registerRequest.Password, registerRequest.Username); err != nil {
+		return err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(registerRequest.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
@@ -88,3 +582,808 @@ func (uu *userUsecase) Register(
 	registerRequest.Password = string(hashedPassword)
 	return uu.userRepo.CreateUser(ctx, registerRequest)
 }
+
+func (uu *userUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, req requests.ChangePasswordRequest) error {
+	user, err := uu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if uu.usesDirectory(user) {
+		return errors.New("password is managed by the directory")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return errors.New("current password is incorrect")
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return errors.New("new password must differ from the current one")
+	}
+
+	return uu.setPassword(ctx, user, req.NewPassword)
+}
+
+func (uu *userUsecase) ResetPassword(ctx context.Context, actorID, userID uuid.UUID, req requests.ResetPasswordRequest) error {
+	if err := requireOwner(ctx, uu.userRepo, actorID); err != nil {
+		return err
+	}
+
+	user, err := uu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if uu.usesDirectory(user) {
+		return errors.New("password is managed by the directory")
+	}
+
+	if err := uu.setPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+	return uu.userRepo.ResetFailedLogins(ctx, userID)
+}
+
+func (uu *userUsecase) setPassword(ctx context.Context, user *models.User, password string) error {
+	if err := uu.passwordPolicy.Validate(password, user.Username); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return uu.userRepo.UpdatePassword(ctx, user.UserID, string(hashedPassword))
+}
+
+func (uu *userUsecase) ParseToken(ctx context.Context, tokenString string) (*models.AccessClaims, error) {
+	token, err := uu.verifyToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	subject, _ := claims["user_id"].(string)
+	userID, err := uuid.Parse(subject)
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+
+	access := &models.AccessClaims{UserID: userID}
+	if impersonator, ok := claims["impersonator_id"].(string); ok {
+		impersonatorID, err := uuid.Parse(impersonator)
+		if err != nil {
+			return nil, errors.New("invalid token")
+		}
+		access.ImpersonatorID = uuid.NullUUID{UUID: impersonatorID, Valid: true}
+	}
+	if sid, ok := claims["sid"].(string); ok {
+		sessionID, err := uuid.Parse(sid)
+		if err != nil {
+			return nil, errors.New("invalid token")
+		}
+		// Revoking a session ends its access tokens too, not just its
+		// refresh tokens.
+		revoked, err := uu.userRepo.IsSessionRevoked(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, errors.New("invalid token")
+		}
+		access.SessionID = uuid.NullUUID{UUID: sessionID, Valid: true}
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		access.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+
+	if jti, ok := claims["jti"].(string); ok {
+		tokenID, err := uuid.Parse(jti)
+		if err != nil {
+			return nil, errors.New("invalid token")
+		}
+		revoked, err := uu.userRepo.IsAccessTokenRevoked(ctx, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, errors.New("invalid token")
+		}
+		access.TokenID = uuid.NullUUID{UUID: tokenID, Valid: true}
+	}
+
+	// Deactivation takes effect at once rather than when the token expires.
+	user, err := uu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, errors.New("invalid token")
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, errors.New("invalid token")
+	}
+
+	return access, nil
+}
+
+// verifyToken checks the signature against the key named by the token's
+// kid, or against every key when it has none.
+func (uu *userUsecase) verifyToken(tokenString string) (*jwt.Token, error) {
+	parser := jwt.Parser{}
+	unverified, _, err := parser.ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	kid, _ := unverified.Header["kid"].(string)
+
+	for _, key := range uu.jwtKeys.Verifying(kid) {
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+			}
+			return key.Secret, nil
+		})
+		if err == nil && token.Valid {
+			return token, nil
+		}
+	}
+	return nil, errors.New("invalid token")
+}
+
+func (uu *userUsecase) Logout(ctx context.Context, token string, req requests.LogoutRequest) error {
+	claims, err := uu.ParseToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if req.RefreshToken != "" {
+		refresh, err := uu.userRepo.GetRefreshTokenByHash(ctx, hashToken(req.RefreshToken))
+		if err != nil {
+			if err.Error() == "refresh token not found" {
+				return errors.New("invalid refresh token")
+			}
+			return err
+		}
+		if refresh.UserID != claims.UserID {
+			return errors.New("invalid refresh token")
+		}
+		if err := uu.userRepo.RevokeRefreshTokenFamily(ctx, refresh.FamilyID); err != nil {
+			return err
+		}
+	}
+
+	// Tokens issued before revocation existed carry no ID; they can't be
+	// blocked and simply run out within jwtDuration.
+	if !claims.TokenID.Valid {
+		return nil
+	}
+	return uu.userRepo.RevokeAccessToken(ctx, models.RevokedAccessToken{
+		TokenID:   claims.TokenID.UUID,
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt,
+		RevokedAt: time.Now(),
+	})
+}
+
+func (uu *userUsecase) ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID uuid.NullUUID) ([]responses.UserSessionResponse, error) {
+	sessions, err := uu.userRepo.ListActiveSessions(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.UserSessionResponse, len(sessions))
+	for i, s := range sessions {
+		result[i] = responses.UserSessionResponse{
+			SessionID:   s.SessionID,
+			Device:      models.DescribeDevice(s.UserAgent),
+			Method:      string(s.Method),
+			Fingerprint: s.Fingerprint,
+			IPAddress:   s.IPAddress,
+			UserAgent:   s.UserAgent,
+			CreatedAt:   s.CreatedAt,
+			LastSeenAt:  s.LastSeenAt,
+			ExpiresAt:   s.ExpiresAt,
+			Current:     currentSessionID.Valid && currentSessionID.UUID == s.SessionID,
+		}
+	}
+	return result, nil
+}
+
+func (uu *userUsecase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return uu.userRepo.RevokeSession(ctx, userID, sessionID)
+}
+
+func (uu *userUsecase) PurgeRevokedTokens(ctx context.Context) error {
+	deleted, err := uu.userRepo.DeleteExpiredRevokedAccessTokens(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("auth: purged %d expired token revocations", deleted)
+	}
+	return nil
+}
+
+func (uu *userUsecase) Impersonate(ctx context.Context, impersonatorID uuid.UUID, req requests.ImpersonateRequest) (*responses.ImpersonationResponse, error) {
+	if err := requireOwner(ctx, uu.userRepo, impersonatorID); err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	targetID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if targetID == impersonatorID {
+		return nil, errors.New("cannot impersonate yourself")
+	}
+
+	target, err := uu.userRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	// Owners see everything already; impersonating one would only hide
+	// who really made a change.
+	if target.Role.IsAdmin() {
+		return nil, errors.New("owners cannot be impersonated")
+	}
+	if !target.IsActive {
+		return nil, errors.New("account deactivated")
+	}
+
+	now := time.Now()
+	session := models.ImpersonationSession{
+		SessionID:      uuid.New(),
+		ImpersonatorID: impersonatorID,
+		UserID:         target.UserID,
+		Reason:         reason,
+		StartedAt:      now,
+		ExpiresAt:      now.Add(uu.impersonationTTL),
+	}
+	token, err := uu.generateImpersonationToken(target, impersonatorID, session.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	if err := uu.userRepo.CreateImpersonationSession(ctx, session); err != nil {
+		return nil, err
+	}
+	recordActivity(ctx, uu.activityRepo, models.ActivityEvent{
+		EventID:     uuid.New(),
+		EntityType:  models.ActivityEntityUser,
+		EntityID:    target.UserID,
+		EventType:   "impersonation_started",
+		Description: fmt.Sprintf("Impersonation of %s started until %s: %s", target.Username, session.ExpiresAt.Format(time.RFC3339), reason),
+		OccurredAt:  now,
+		ActorID:     uuid.NullUUID{UUID: impersonatorID, Valid: true},
+	})
+
+	return &responses.ImpersonationResponse{
+		AccessToken:    token,
+		ExpiresAt:      session.ExpiresAt,
+		ImpersonatorID: impersonatorID,
+		User:           toUserResponse(target),
+	}, nil
+}
+
+func (uu *userUsecase) ListImpersonations(ctx context.Context, userID uuid.UUID) ([]responses.ImpersonationSessionResponse, error) {
+	if err := requireOwner(ctx, uu.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := uu.userRepo.ListImpersonationSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.ImpersonationSessionResponse, len(sessions))
+	for i, session := range sessions {
+		result[i] = responses.ImpersonationSessionResponse{
+			SessionID:      session.SessionID,
+			ImpersonatorID: session.ImpersonatorID,
+			UserID:         session.UserID,
+			Reason:         session.Reason,
+			StartedAt:      session.StartedAt,
+			ExpiresAt:      session.ExpiresAt,
+		}
+	}
+	return result, nil
+}
+
+func (uu *userUsecase) UpdateRole(ctx context.Context, actorID, userID uuid.UUID, req requests.UpdateUserRoleRequest) (*responses.UserResponse, error) {
+	if err := requireOwner(ctx, uu.userRepo, actorID); err != nil {
+		return nil, err
+	}
+	if actorID == userID {
+		return nil, errors.New("cannot change your own role")
+	}
+	role := models.UserRole(req.Role)
+	if !role.Valid() {
+		return nil, errors.New("invalid role")
+	}
+
+	actor, err := uu.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Admins can't promote anyone to owner or demote an owner, otherwise
+	// they could remove the account that appointed them.
+	if actor.Role != models.UserRoleOwner &&
+		(role == models.UserRoleOwner || target.Role == models.UserRoleOwner) {
+		return nil, errors.New("only owners can manage owners")
+	}
+
+	if err := uu.userRepo.UpdateRole(ctx, userID, role); err != nil {
+		return nil, err
+	}
+	target.Role = role
+	response := toUserResponse(target)
+	return &response, nil
+}
+
+func (uu *userUsecase) ListUsers(ctx context.Context, actorID uuid.UUID, req requests.ListUsersRequest) (*responses.UserListResponse, error) {
+	if err := requireOwner(ctx, uu.userRepo, actorID); err != nil {
+		return nil, err
+	}
+
+	filter := models.UserFilter{
+		Search: strings.TrimSpace(req.Search),
+		Role:   models.UserRole(req.Role),
+	}
+	if filter.Role != "" && !filter.Role.Valid() {
+		return nil, errors.New("invalid role")
+	}
+	switch req.Status {
+	case "":
+	case "active", "inactive":
+		active := req.Status == "active"
+		filter.Active = &active
+	default:
+		return nil, errors.New("status must be active or inactive")
+	}
+
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	users, total, err := uu.userRepo.List(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.UserResponse, len(users))
+	for i := range users {
+		result[i] = toUserResponse(&users[i])
+	}
+	return &responses.UserListResponse{
+		Users:    result,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func (uu *userUsecase) ForcePasswordReset(ctx context.Context, actorID, userID uuid.UUID) error {
+	if err := requireOwner(ctx, uu.userRepo, actorID); err != nil {
+		return err
+	}
+
+	actor, err := uu.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	target, err := uu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if actor.Role != models.UserRoleOwner && target.Role == models.UserRoleOwner {
+		return errors.New("only owners can manage owners")
+	}
+	if uu.usesDirectory(target) {
+		return errors.New("password is managed by the directory")
+	}
+
+	return uu.userRepo.RequirePasswordChange(ctx, userID)
+}
+
+func (uu *userUsecase) UnlockUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	if err := requireOwner(ctx, uu.userRepo, actorID); err != nil {
+		return err
+	}
+	return uu.userRepo.ResetFailedLogins(ctx, userID)
+}
+
+func (uu *userUsecase) DeactivateUser(ctx context.Context, actorID, userID uuid.UUID) (*responses.UserResponse, error) {
+	if actorID == userID {
+		return nil, errors.New("cannot deactivate yourself")
+	}
+	return uu.setActive(ctx, actorID, userID, false)
+}
+
+func (uu *userUsecase) ReactivateUser(ctx context.Context, actorID, userID uuid.UUID) (*responses.UserResponse, error) {
+	return uu.setActive(ctx, actorID, userID, true)
+}
+
+func (uu *userUsecase) setActive(ctx context.Context, actorID, userID uuid.UUID, active bool) (*responses.UserResponse, error) {
+	if err := requireOwner(ctx, uu.userRepo, actorID); err != nil {
+		return nil, err
+	}
+
+	actor, err := uu.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != models.UserRoleOwner && target.Role == models.UserRoleOwner {
+		return nil, errors.New("only owners can manage owners")
+	}
+
+	if err := uu.userRepo.SetActive(ctx, userID, active); err != nil {
+		return nil, err
+	}
+	target.IsActive = active
+	response := toUserResponse(target)
+	return &response, nil
+}
+
+func (uu *userUsecase) ListLogins(ctx context.Context, actorID, userID uuid.UUID, limit int) ([]responses.LoginAuditResponse, error) {
+	if err := requireOwner(ctx, uu.userRepo, actorID); err != nil {
+		return nil, err
+	}
+	if _, err := uu.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	logins, err := uu.userRepo.ListLogins(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.LoginAuditResponse, len(logins))
+	for i, login := range logins {
+		result[i] = responses.LoginAuditResponse{
+			AuditID:       login.AuditID,
+			Method:        string(login.Method),
+			Success:       login.Success,
+			FailureReason: login.FailureReason.String,
+			IPAddress:     login.IPAddress,
+			UserAgent:     login.UserAgent,
+			Device:        models.DescribeDevice(login.UserAgent),
+			Fingerprint:   login.Fingerprint,
+			AttemptedAt:   login.AttemptedAt,
+		}
+	}
+	return result, nil
+}
+
+// apiKeyPrefix marks keys from this API so they are recognisable in
+// config files and secret scanners.
+const apiKeyPrefix = "bks_"
+
+func (uu *userUsecase) CreateAPIKey(ctx context.Context, userID uuid.UUID, req requests.CreateAPIKeyRequest) (*responses.CreatedAPIKeyResponse, error) {
+	// A key outlives the impersonation session and its claims carry no
+	// impersonator, so one minted while impersonating would act as the
+	// user with nothing to flag it.
+	if impersonatorFromContext(ctx).Valid {
+		return nil, errors.New("api keys cannot be created while impersonating")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	apiKey := models.APIKey{
+		KeyID:     uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    key[:len(apiKeyPrefix)+6],
+		KeyHash:   hashToken(key),
+		CreatedAt: now,
+	}
+	if req.ExpiresAt != nil {
+		apiKey.ExpiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	}
+	if err := uu.userRepo.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &responses.CreatedAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(apiKey),
+		Key:            key,
+	}, nil
+}
+
+func (uu *userUsecase) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]responses.APIKeyResponse, error) {
+	keys, err := uu.userRepo.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]responses.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		result[i] = toAPIKeyResponse(key)
+	}
+	return result, nil
+}
+
+func (uu *userUsecase) RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	return uu.userRepo.RevokeAPIKey(ctx, userID, keyID)
+}
+
+func (uu *userUsecase) AuthenticateAPIKey(ctx context.Context, key string) (*models.AccessClaims, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, errors.New("invalid api key")
+	}
+
+	apiKey, err := uu.userRepo.GetAPIKeyByHash(ctx, hashToken(key))
+	if err != nil {
+		if err.Error() == "api key not found" {
+			return nil, errors.New("invalid api key")
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if !apiKey.Active(now) {
+		return nil, errors.New("invalid api key")
+	}
+	// Keys survive deactivation so they work again on reactivation.
+	owner, err := uu.userRepo.GetByID(ctx, apiKey.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !owner.IsActive {
+		return nil, errors.New("invalid api key")
+	}
+
+	// Only informational, so a failed update shouldn't fail the request.
+	if err := uu.userRepo.TouchAPIKey(ctx, apiKey.KeyID, now); err != nil {
+		log.Printf("api key %s: %v", apiKey.KeyID, err)
+	}
+	return &models.AccessClaims{
+		UserID:   apiKey.UserID,
+		APIKeyID: uuid.NullUUID{UUID: apiKey.KeyID, Valid: true},
+	}, nil
+}
+
+func toAPIKeyResponse(key models.APIKey) responses.APIKeyResponse {
+	response := responses.APIKeyResponse{
+		KeyID:     key.KeyID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		CreatedAt: key.CreatedAt,
+	}
+	if key.ExpiresAt.Valid {
+		response.ExpiresAt = &key.ExpiresAt.Time
+	}
+	if key.LastUsedAt.Valid {
+		response.LastUsedAt = &key.LastUsedAt.Time
+	}
+	if key.RevokedAt.Valid {
+		response.RevokedAt = &key.RevokedAt.Time
+	}
+	return response
+}
+
+type actorKey struct{}
+
+// ActorKey is the request context key under which the REST layer stores
+// the authenticated user's ID, so recorded activity says who did it.
+var ActorKey = actorKey{}
+
+func actorFromContext(ctx context.Context) uuid.NullUUID {
+	if id, ok := ctx.Value(ActorKey).(uuid.UUID); ok {
+		return uuid.NullUUID{UUID: id, Valid: true}
+	}
+	return uuid.NullUUID{}
+}
+
+type impersonatorKey struct{}
+
+// ImpersonatorKey is the request context key under which the REST layer
+// stores the impersonating owner's ID, so activity recorded during an
+// impersonation session is flagged with it.
+var ImpersonatorKey = impersonatorKey{}
+
+func impersonatorFromContext(ctx context.Context) uuid.NullUUID {
+	if id, ok := ctx.Value(ImpersonatorKey).(uuid.UUID); ok {
+		return uuid.NullUUID{UUID: id, Valid: true}
+	}
+	return uuid.NullUUID{}
+}
+
+func (uu *userUsecase) GetProfile(ctx context.Context, userID uuid.UUID) (*responses.UserResponse, error) {
+	user, err := uu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toUserResponse(user)
+	return &response, nil
+}
+
+func (uu *userUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req requests.UpdateProfileRequest) (*responses.UserResponse, error) {
+	req.FirstName = strings.TrimSpace(req.FirstName)
+	req.LastName = strings.TrimSpace(req.LastName)
+	req.Tel = strings.TrimSpace(req.Tel)
+	if req.FirstName == "" || req.LastName == "" {
+		return nil, errors.New("first name and last name are required")
+	}
+	if req.Tel != "" && len(req.Tel) != 10 {
+		return nil, errors.New("tel must be 10 digits")
+	}
+
+	if err := uu.userRepo.UpdateProfile(ctx, userID, req); err != nil {
+		return nil, err
+	}
+
+	return uu.GetProfile(ctx, userID)
+}
+
+// maxAvatarSize keeps avatars well under Fiber's default 4 MB body limit.
+const maxAvatarSize = 2 << 20
+
+func (uu *userUsecase) UploadAvatar(ctx context.Context, userID uuid.UUID, file io.Reader) (*responses.UserResponse, error) {
+	if uu.avatarStore == nil {
+		return nil, errors.New("file storage is not configured")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxAvatarSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+	if len(data) > maxAvatarSize {
+		return nil, errors.New("avatar must not exceed 2 MB")
+	}
+
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "jpeg" && format != "png") {
+		return nil, errors.New("avatar must be a JPEG or PNG image")
+	}
+
+	// Each upload gets a new key so caches never serve the previous
+	// avatar under the new URL.
+	key := fmt.Sprintf("avatars/%s/%s.%s", userID, uuid.New(), format)
+	avatarURL, err := uu.avatarStore.Put(ctx, key, "image/"+format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uu.userRepo.UpdateAvatarURL(ctx, userID, avatarURL); err != nil {
+		return nil, err
+	}
+
+	return uu.GetProfile(ctx, userID)
+}
+
+var (
+	preferenceUnits = map[string][]string{
+		"length": {"mm", "cm", "m"},
+		"area":   {"m2", "sqwa", "rai"},
+		"volume": {"m3", "l"},
+		"weight": {"kg", "ton"},
+	}
+	preferenceChannels  = []string{"email", "line", "sms", "in_app"}
+	preferenceLanguages = []string{"th", "en"}
+	// lineUserIDPattern matches the user IDs the LINE Messaging API
+	// pushes to.
+	lineUserIDPattern = regexp.MustCompile(`^U[0-9a-f]{32}$`)
+)
+
+func (uu *userUsecase) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error) {
+	raw, err := uu.userRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := &models.UserPreferences{}
+	if raw == nil {
+		return preferences, nil
+	}
+	if err := json.Unmarshal(raw, preferences); err != nil {
+		return nil, fmt.Errorf("failed to decode preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences replaces the user's preferences with body after
+// checking it against the schema.
+func (uu *userUsecase) UpdatePreferences(ctx context.Context, userID uuid.UUID, body []byte) (*models.UserPreferences, error) {
+	if len(body) > 64*1024 {
+		return nil, errors.New("preferences must not exceed 64 KB")
+	}
+
+	var preferences models.UserPreferences
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&preferences); err != nil {
+		return nil, fmt.Errorf("invalid preferences: %s", err)
+	}
+
+	if err := validatePreferences(&preferences); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(preferences)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode preferences: %w", err)
+	}
+	if err := uu.userRepo.SavePreferences(ctx, userID, raw); err != nil {
+		return nil, err
+	}
+
+	return &preferences, nil
+}
+
+func validatePreferences(p *models.UserPreferences) error {
+	if p.DefaultTaxPercentage != nil && (*p.DefaultTaxPercentage < 0 || *p.DefaultTaxPercentage > 100) {
+		return errors.New("invalid preferences: default_tax_percentage must be between 0 and 100")
+	}
+	if p.Language != "" && !containsString(preferenceLanguages, p.Language) {
+		return errors.New("invalid preferences: unsupported language")
+	}
+	for kind, unit := range p.PreferredUnits {
+		allowed, ok := preferenceUnits[kind]
+		if !ok {
+			return fmt.Errorf("invalid preferences: unknown unit kind %q", kind)
+		}
+		if !containsString(allowed, unit) {
+			return fmt.Errorf("invalid preferences: unsupported %s unit %q", kind, unit)
+		}
+	}
+	for _, channel := range p.NotificationChannels {
+		if !containsString(preferenceChannels, channel) {
+			return fmt.Errorf("invalid preferences: unknown notification channel %q", channel)
+		}
+	}
+	if p.LineUserID != "" && !lineUserIDPattern.MatchString(p.LineUserID) {
+		return errors.New("invalid preferences: line_user_id must be a LINE user ID")
+	}
+	for name, layout := range p.TableLayouts {
+		if name == "" || len(name) > 100 {
+			return errors.New("invalid preferences: table layout names must be 1-100 characters")
+		}
+		if layout.PageSize < 0 || layout.PageSize > 500 {
+			return errors.New("invalid preferences: page_size must be between 0 and 500")
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
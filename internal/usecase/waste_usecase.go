@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/requests"
+	"boonkosang/internal/responses"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WasteUseCase interface {
+	// Record takes scrapped material out of stock and charges it to the
+	// project as waste.
+	Record(ctx context.Context, projectID uuid.UUID, req requests.RecordWasteRequest) (*responses.WasteRecordResponse, error)
+	List(ctx context.Context, projectID uuid.UUID) ([]responses.WasteRecordResponse, error)
+	// Summary compares the project's waste of each material with its
+	// allowance.
+	Summary(ctx context.Context, projectID uuid.UUID) ([]responses.WasteSummaryResponse, error)
+	// Alerts lists the materials over their allowance on every project that
+	// is still open.
+	Alerts(ctx context.Context) ([]responses.WasteSummaryResponse, error)
+
+	ListAllowances(ctx context.Context) (*responses.WastageAllowanceResponse, error)
+	SetAllowance(ctx context.Context, materialID string, req requests.SetWastageAllowanceRequest) error
+	DeleteAllowance(ctx context.Context, materialID string) error
+}
+
+type wasteUseCase struct {
+	wasteRepo      repositories.WasteRepository
+	inventoryRepo  repositories.InventoryRepository
+	projectRepo    repositories.ProjectRepository
+	materialRepo   repositories.MaterialRepository
+	defaultPercent float64
+	access         projectAccess
+}
+
+// NewWasteUsecase flags waste over defaultPercent of the BOQ quantity for
+// materials without an allowance of their own.
+func NewWasteUsecase(
+	wasteRepo repositories.WasteRepository,
+	inventoryRepo repositories.InventoryRepository,
+	projectRepo repositories.ProjectRepository,
+	materialRepo repositories.MaterialRepository,
+	defaultPercent float64,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) WasteUseCase {
+	return &wasteUseCase{
+		wasteRepo:      wasteRepo,
+		inventoryRepo:  inventoryRepo,
+		projectRepo:    projectRepo,
+		materialRepo:   materialRepo,
+		defaultPercent: defaultPercent,
+		access:         projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+	}
+}
+
+func (u *wasteUseCase) Record(ctx context.Context, projectID uuid.UUID, req requests.RecordWasteRequest) (*responses.WasteRecordResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if req.Quantity <= 0 {
+		return nil, errors.New("quantity must be greater than 0")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("waste reason is required")
+	}
+
+	project, err := u.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Status == models.ProjectStatusCompleted || project.Status == models.ProjectStatusCancelled {
+		return nil, errors.New("project is closed")
+	}
+	if _, err := u.materialRepo.GetByID(ctx, req.MaterialID); err != nil {
+		return nil, err
+	}
+
+	recordedDate := currentDate()
+	if date := strings.TrimSpace(req.RecordedDate); date != "" {
+		recordedDate, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+
+	warehouse, err := u.wasteWarehouse(ctx, projectID, req.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record := models.WasteRecord{
+		WasteID:      uuid.New(),
+		ProjectID:    projectID,
+		MaterialID:   req.MaterialID,
+		WarehouseID:  warehouse.WarehouseID,
+		Quantity:     req.Quantity,
+		Reason:       reason,
+		Note:         optionalString(req.Note),
+		RecordedDate: recordedDate,
+		CreatedBy:    actorFromContext(ctx),
+		CreatedAt:    now,
+	}
+	movement := models.StockMovement{
+		MovementID:  record.WasteID,
+		WarehouseID: warehouse.WarehouseID,
+		MaterialID:  req.MaterialID,
+		Quantity:    -req.Quantity,
+		Type:        models.StockMovementWaste,
+		ProjectID:   uuid.NullUUID{UUID: projectID, Valid: true},
+		Reference:   optionalString(reason),
+		Note:        record.Note,
+		CreatedAt:   now,
+	}
+	if err := u.wasteRepo.Create(ctx, record, movement); err != nil {
+		return nil, err
+	}
+
+	response := toWasteRecordResponse(&models.WasteRecordDetail{WasteRecord: record, WarehouseName: warehouse.Name})
+	return &response, nil
+}
+
+// wasteWarehouse is the warehouse named in the request or, without one,
+// the project's only active site store.
+func (u *wasteUseCase) wasteWarehouse(ctx context.Context, projectID uuid.UUID, warehouseID *uuid.UUID) (*models.Warehouse, error) {
+	if warehouseID != nil {
+		warehouse, err := u.inventoryRepo.GetWarehouse(ctx, *warehouseID)
+		if err != nil {
+			return nil, err
+		}
+		if warehouse.Status != models.WarehouseStatusActive {
+			return nil, errors.New("warehouse is closed")
+		}
+		return warehouse, nil
+	}
+
+	warehouses, err := u.inventoryRepo.ListWarehouses(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	var store *models.Warehouse
+	for i, w := range warehouses {
+		if w.Kind != models.WarehouseKindSite || w.ProjectID.UUID != projectID {
+			continue
+		}
+		if store != nil {
+			return nil, errors.New("project has several site stores, warehouse is required")
+		}
+		store = &warehouses[i]
+	}
+	if store == nil {
+		return nil, errors.New("project has no site store, warehouse is required")
+	}
+	return store, nil
+}
+
+func (u *wasteUseCase) List(ctx context.Context, projectID uuid.UUID) ([]responses.WasteRecordResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	records, err := u.wasteRepo.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]responses.WasteRecordResponse, len(records))
+	for i := range records {
+		result[i] = toWasteRecordResponse(&records[i])
+	}
+	return result, nil
+}
+
+func (u *wasteUseCase) Summary(ctx context.Context, projectID uuid.UUID) ([]responses.WasteSummaryResponse, error) {
+	if err := u.access.check(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if _, err := u.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return u.summaries(ctx, uuid.NullUUID{UUID: projectID, Valid: true}, false)
+}
+
+func (u *wasteUseCase) Alerts(ctx context.Context) ([]responses.WasteSummaryResponse, error) {
+	alerts, err := u.summaries(ctx, uuid.NullUUID{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := u.access.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]responses.WasteSummaryResponse, 0, len(alerts))
+	for _, a := range alerts {
+		if visible(a.ProjectID) {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+func (u *wasteUseCase) summaries(ctx context.Context, projectID uuid.NullUUID, exceededOnly bool) ([]responses.WasteSummaryResponse, error) {
+	summaries, err := u.wasteRepo.ListSummaries(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	allowances, err := u.wasteRepo.ListAllowances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	percents := make(map[string]float64, len(allowances))
+	for _, a := range allowances {
+		percents[a.MaterialID] = a.Percent
+	}
+
+	result := make([]responses.WasteSummaryResponse, 0, len(summaries))
+	for _, s := range summaries {
+		percent, ok := percents[s.MaterialID]
+		if !ok {
+			percent = u.defaultPercent
+		}
+		allowed := s.AllowedQuantity(percent)
+		// Waste of a material the BOQ doesn't call for has no allowance, so
+		// any of it is flagged.
+		exceeded := s.WastedQuantity > allowed
+		if exceededOnly && !exceeded {
+			continue
+		}
+		result = append(result, responses.WasteSummaryResponse{
+			ProjectID:        s.ProjectID,
+			ProjectName:      s.ProjectName,
+			MaterialID:       s.MaterialID,
+			MaterialName:     s.MaterialName,
+			Unit:             s.Unit,
+			BOQQuantity:      s.BOQQuantity,
+			WastedQuantity:   s.WastedQuantity,
+			WastePercent:     s.WastePercent(),
+			AllowancePercent: percent,
+			AllowedQuantity:  allowed,
+			Exceeded:         exceeded,
+		})
+	}
+	return result, nil
+}
+
+func (u *wasteUseCase) ListAllowances(ctx context.Context) (*responses.WastageAllowanceResponse, error) {
+	allowances, err := u.wasteRepo.ListAllowances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.WastageAllowanceResponse{
+		DefaultPercent: u.defaultPercent,
+		Materials:      make([]responses.MaterialAllowanceEntry, len(allowances)),
+	}
+	for i, a := range allowances {
+		response.Materials[i] = responses.MaterialAllowanceEntry{
+			MaterialID: a.MaterialID,
+			Percent:    a.Percent,
+			UpdatedAt:  a.UpdatedAt,
+		}
+	}
+	return response, nil
+}
+
+func (u *wasteUseCase) SetAllowance(ctx context.Context, materialID string, req requests.SetWastageAllowanceRequest) error {
+	if req.Percent < 0 || req.Percent > 100 {
+		return errors.New("allowance must be between 0 and 100 percent")
+	}
+	if _, err := u.materialRepo.GetByID(ctx, materialID); err != nil {
+		return err
+	}
+
+	return u.wasteRepo.SetAllowance(ctx, models.WastageAllowance{
+		MaterialID: materialID,
+		Percent:    req.Percent,
+		UpdatedAt:  time.Now(),
+	})
+}
+
+func (u *wasteUseCase) DeleteAllowance(ctx context.Context, materialID string) error {
+	return u.wasteRepo.DeleteAllowance(ctx, materialID)
+}
+
+func toWasteRecordResponse(record *models.WasteRecordDetail) responses.WasteRecordResponse {
+	return responses.WasteRecordResponse{
+		WasteID:       record.WasteID,
+		ProjectID:     record.ProjectID,
+		MaterialID:    record.MaterialID,
+		MaterialName:  record.MaterialName,
+		Unit:          record.Unit,
+		WarehouseID:   record.WarehouseID,
+		WarehouseName: record.WarehouseName,
+		Quantity:      record.Quantity,
+		Reason:        record.Reason,
+		Note:          record.Note.String,
+		RecordedDate:  record.RecordedDate.Format("2006-01-02"),
+	}
+}
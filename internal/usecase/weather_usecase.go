@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"boonkosang/internal/domain/models"
+	"boonkosang/internal/repositories"
+	"boonkosang/internal/responses"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type WeatherUseCase interface {
+	RefreshForecasts(ctx context.Context) error
+	GetRisks(ctx context.Context, projectID *uuid.UUID) ([]responses.WeatherRiskResponse, error)
+}
+
+type weatherUseCase struct {
+	weatherRepo repositories.WeatherRepository
+	projectRepo repositories.ProjectRepository
+	provider    repositories.WeatherProvider
+	heavyRainMM float64
+	access      projectAccess
+
+	mu        sync.Mutex
+	locations map[string]models.GeoLocation
+}
+
+// NewWeatherUsecase flags outdoor tasks on days forecast to get at least
+// heavyRainMM of rain.
+func NewWeatherUsecase(
+	weatherRepo repositories.WeatherRepository,
+	projectRepo repositories.ProjectRepository,
+	provider repositories.WeatherProvider,
+	heavyRainMM float64,
+	userRepo repositories.UserRepository,
+	memberRepo repositories.ProjectMemberRepository,
+) WeatherUseCase {
+	return &weatherUseCase{
+		weatherRepo: weatherRepo,
+		projectRepo: projectRepo,
+		provider:    provider,
+		heavyRainMM: heavyRainMM,
+		access:      projectAccess{userRepo: userRepo, memberRepo: memberRepo},
+		locations:   make(map[string]models.GeoLocation),
+	}
+}
+
+// RefreshForecasts fetches the forecast for every project with upcoming
+// outdoor work. A project that can't be located or fetched is logged and
+// skipped so one bad address doesn't block the rest.
+func (u *weatherUseCase) RefreshForecasts(ctx context.Context) error {
+	targets, err := u.weatherRepo.ListForecastTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, target := range targets {
+		location, err := u.resolveLocation(ctx, target.Address)
+		if err != nil {
+			log.Printf("weather: skipping project %s (%s): %v", target.Name, target.ProjectID, err)
+			failed++
+			continue
+		}
+
+		forecasts, err := u.provider.DailyForecast(ctx, *location)
+		if err != nil {
+			log.Printf("weather: failed to fetch forecast for project %s: %v", target.ProjectID, err)
+			failed++
+			continue
+		}
+
+		if err := u.weatherRepo.SaveForecasts(ctx, target.ProjectID, forecasts); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 && failed == len(targets) {
+		return errors.New("failed to refresh any weather forecast")
+	}
+
+	return nil
+}
+
+func (u *weatherUseCase) GetRisks(ctx context.Context, projectID *uuid.UUID) ([]responses.WeatherRiskResponse, error) {
+	if projectID != nil {
+		project, err := u.projectRepo.GetByID(ctx, *projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project: %w", err)
+		}
+		if project == nil {
+			return nil, errors.New("project not found")
+		}
+		if err := u.access.check(ctx, *projectID); err != nil {
+			return nil, err
+		}
+	}
+	visible, err := u.access.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	risks, err := u.weatherRepo.GetRisks(ctx, projectID, u.heavyRainMM)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.WeatherRiskResponse, 0, len(risks))
+	for _, risk := range risks {
+		if !visible(risk.ProjectID) {
+			continue
+		}
+		item := responses.WeatherRiskResponse{
+			TaskID:            risk.TaskID,
+			TaskName:          risk.TaskName,
+			ProjectID:         risk.ProjectID,
+			ProjectName:       risk.ProjectName,
+			Date:              risk.ForecastDate.Format("2006-01-02"),
+			PrecipitationMM:   risk.PrecipitationMM,
+			ForecastFetchedAt: risk.FetchedAt,
+		}
+		if risk.PrecipitationProbability.Valid {
+			probability := risk.PrecipitationProbability.Float64
+			item.PrecipitationProbability = &probability
+		}
+		response = append(response, item)
+	}
+
+	return response, nil
+}
+
+// resolveLocation uses coordinates stored in the project address when
+// present, otherwise geocodes the district and then the province.
+func (u *weatherUseCase) resolveLocation(ctx context.Context, address json.RawMessage) (*models.GeoLocation, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(address, &fields); err != nil {
+		return nil, errors.New("invalid project address")
+	}
+
+	lat, latOK := fields["latitude"].(float64)
+	lon, lonOK := fields["longitude"].(float64)
+	if latOK && lonOK {
+		return &models.GeoLocation{Latitude: lat, Longitude: lon}, nil
+	}
+
+	for _, key := range []string{"district", "province"} {
+		query, _ := fields[key].(string)
+		query = strings.TrimSpace(query)
+		if query == "" {
+			continue
+		}
+
+		u.mu.Lock()
+		cached, ok := u.locations[query]
+		u.mu.Unlock()
+		if ok {
+			return &cached, nil
+		}
+
+		location, err := u.provider.Geocode(ctx, query)
+		if err != nil {
+			continue
+		}
+
+		u.mu.Lock()
+		u.locations[query] = *location
+		u.mu.Unlock()
+		return location, nil
+	}
+
+	return nil, errors.New("project address has no locatable district or province")
+}